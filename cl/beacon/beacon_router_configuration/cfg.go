@@ -17,6 +17,7 @@
 package beacon_router_configuration
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -43,6 +44,9 @@ type RouterConfiguration struct {
 	Node       bool
 	Validator  bool
 	Lighthouse bool
+	Keymanager bool
+
+	KeymanagerToken string // bearer token of the keymanager endpoints
 }
 
 func (r *RouterConfiguration) UnwrapEndpointsList(l []string) error {
@@ -67,6 +71,8 @@ func (r *RouterConfiguration) UnwrapEndpointsList(l []string) error {
 			r.Validator = true
 		case "lighthouse":
 			r.Lighthouse = true
+		case "keymanager":
+			r.Keymanager = true
 		default:
 			r.Active = false
 			r.Beacon = false
@@ -77,8 +83,24 @@ func (r *RouterConfiguration) UnwrapEndpointsList(l []string) error {
 			r.Node = false
 			r.Validator = false
 			r.Lighthouse = false
-			return fmt.Errorf("unknown endpoint for beacon.api: %s. known endpoints: beacon, builder, config, debug, events, node, validator, lighthouse", v)
+			r.Keymanager = false
+			return fmt.Errorf("unknown endpoint for beacon.api: %s. known endpoints: beacon, builder, config, debug, events, node, validator, lighthouse, keymanager", v)
 		}
 	}
 	return nil
 }
+
+// CheckKeymanager - keymanager endpoints change proposals of validators: they need the validator endpoints
+// and a token
+func (r *RouterConfiguration) CheckKeymanager() error {
+	if !r.Keymanager {
+		return nil
+	}
+	if !r.Validator {
+		return errors.New("keymanager endpoint of beacon.api needs validator endpoint")
+	}
+	if r.KeymanagerToken == "" {
+		return errors.New("keymanager endpoint of beacon.api needs --beacon.api.keymanager.token")
+	}
+	return nil
+}
@@ -282,6 +282,13 @@ func (a *ApiHandler) GetEthV3ValidatorBlock(
 		return nil, err
 	}
 	log.Info("[Beacon API] Found BeaconState object for block production", "slot", targetSlot, "duration", time.Since(start))
+	if !r.URL.Query().Has("graffiti") {
+		if proposerIndex, err := baseState.GetBeaconProposerIndexForSlot(targetSlot); err == nil {
+			if keyGraffiti := a.proposerKeySettings(baseState, proposerIndex).Graffiti; keyGraffiti != nil {
+				graffiti = *keyGraffiti
+			}
+		}
+	}
 	block, err := a.produceBlock(ctx, builderBoostFactor, sourceBlock.Block, baseState, targetSlot, randaoReveal, graffiti)
 	if err != nil {
 		log.Warn("Failed to produce block", "err", err, "slot", targetSlot)
@@ -600,6 +607,9 @@ func (a *ApiHandler) produceBeaconBody(
 		retryTime := 10 * time.Millisecond
 		secsDiff := (targetSlot - baseBlock.Slot) * a.beaconChainCfg.SecondsPerSlot
 		feeRecipient, _ := a.validatorParams.GetFeeRecipient(proposerIndex)
+		if keyFeeRecipient := a.proposerKeySettings(baseState, proposerIndex).FeeRecipient; keyFeeRecipient != nil {
+			feeRecipient = *keyFeeRecipient
+		}
 		clWithdrawals, _ := state.ExpectedWithdrawals(
 			baseState,
 			targetSlot/a.beaconChainCfg.SlotsPerEpoch,
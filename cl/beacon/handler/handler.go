@@ -74,6 +74,7 @@ type ApiHandler struct {
 	syncedData           synced_data.SyncedData
 	stateReader          *historical_states_reader.HistoricalStatesReader
 	sentinel             sentinel.SentinelClient
+	gossipInspector      GossipInspector
 	blobStoage           blob_storage.BlobStorage
 	caplinSnapshots      *freezeblocks.CaplinSnapshots
 	caplinStateSnapshots *snapshotsync.CaplinStateSnapshots
@@ -145,6 +146,7 @@ func NewApiHandler(
 	builderClient builder.BuilderClient,
 	caplinStateSnapshots *snapshotsync.CaplinStateSnapshots,
 	enableMemoizedHeadState bool,
+	gossipInspector GossipInspector,
 ) *ApiHandler {
 	blobBundles, err := lru.New[common.Bytes48, BlobBundle]("blobs", maxBlobBundleCacheSize)
 	if err != nil {
@@ -193,6 +195,7 @@ func NewApiHandler(
 		proposerSlashingService:          proposerSlashingService,
 		builderClient:                    builderClient,
 		enableMemoizedHeadState:          enableMemoizedHeadState,
+		gossipInspector:                  gossipInspector,
 	}
 }
 
@@ -207,6 +210,9 @@ func (a *ApiHandler) init() {
 
 	r.Get("/", a.GetEthV1NodeHealth)
 
+	if a.routerCfg.Debug {
+		r.Get("/caplin/debug/gossip", beaconhttp.HandleEndpointFunc(a.GetCaplinDebugGossip))
+	}
 	if a.routerCfg.Lighthouse {
 		r.Route("/lighthouse", func(r chi.Router) {
 			r.Get("/validator_inclusion/{epoch}/global", beaconhttp.HandleEndpointFunc(a.GetLighthouseValidatorInclusionGlobal))
@@ -323,6 +329,27 @@ func (a *ApiHandler) init() {
 					if a.routerCfg.Builder {
 						r.Post("/register_validator", beaconhttp.HandleEndpointFunc(a.PostEthV1BuilderRegisterValidator))
 					}
+					if a.routerCfg.Keymanager {
+						r.Route("/{pubkey}", func(r chi.Router) {
+							r.Use(a.keymanagerAuth)
+							r.Get("/feerecipient", beaconhttp.HandleEndpointFunc(a.GetEthV1ValidatorFeeRecipient))
+							r.Post("/feerecipient", a.PostEthV1ValidatorFeeRecipient)
+							r.Delete("/feerecipient", a.DeleteEthV1ValidatorFeeRecipient)
+							r.Get("/gas_limit", beaconhttp.HandleEndpointFunc(a.GetEthV1ValidatorGasLimit))
+							r.Post("/gas_limit", a.PostEthV1ValidatorGasLimit)
+							r.Delete("/gas_limit", a.DeleteEthV1ValidatorGasLimit)
+							r.Get("/graffiti", beaconhttp.HandleEndpointFunc(a.GetEthV1ValidatorGraffiti))
+							r.Post("/graffiti", a.PostEthV1ValidatorGraffiti)
+							r.Delete("/graffiti", a.DeleteEthV1ValidatorGraffiti)
+						})
+					}
+				})
+			}
+			if a.routerCfg.Keymanager {
+				r.Group(func(r chi.Router) {
+					r.Use(a.keymanagerAuth)
+					r.Get("/keystores", beaconhttp.HandleEndpointFunc(a.GetEthV1Keystores))
+					r.Get("/remotekeys", beaconhttp.HandleEndpointFunc(a.GetEthV1RemoteKeys))
 				})
 			}
 
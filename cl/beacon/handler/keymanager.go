@@ -0,0 +1,281 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/validator/validator_params"
+)
+
+// Keymanager API (https://ethereum.github.io/keymanager-APIs) for staking dashboards. Caplin holds no keys:
+// keys are the ones of validators which called prepare_beacon_proposer, and the ones configured here. Fee
+// recipient and graffiti of a key are used by block production, gas limit is only kept for the dashboards:
+// local payloads use --miner.gaslimit of the execution layer.
+
+const defaultKeymanagerGasLimit = 36_000_000
+
+type keymanagerKey struct {
+	ValidatingPubkey common.Bytes48 `json:"validating_pubkey"`
+	DerivationPath   string         `json:"derivation_path"`
+	Readonly         bool           `json:"readonly"`
+}
+
+type keymanagerRemoteKey struct {
+	Pubkey   common.Bytes48 `json:"pubkey"`
+	URL      string         `json:"url"`
+	Readonly bool           `json:"readonly"`
+}
+
+type keymanagerFeeRecipient struct {
+	Pubkey     common.Bytes48 `json:"pubkey"`
+	EthAddress common.Address `json:"ethaddress"`
+}
+
+type keymanagerGasLimit struct {
+	Pubkey   common.Bytes48 `json:"pubkey"`
+	GasLimit uint64         `json:"gas_limit,string"`
+}
+
+type keymanagerGraffiti struct {
+	Pubkey   common.Bytes48 `json:"pubkey"`
+	Graffiti string         `json:"graffiti"`
+}
+
+// keymanagerAuth - keymanager API needs "Authorization: Bearer <token>"
+func (a *ApiHandler) keymanagerAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(a.routerCfg.KeymanagerToken)) != 1 {
+			beaconhttp.NewEndpointError(http.StatusUnauthorized, errors.New("missing or invalid bearer token")).WriteTo(w)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// proposerKeySettings - keymanager settings of the validator, empty if the validator is unknown
+func (a *ApiHandler) proposerKeySettings(s *state.CachingBeaconState, validatorIndex uint64) validator_params.KeySettings {
+	pubkey, err := s.ValidatorPublicKey(int(validatorIndex))
+	if err != nil {
+		return validator_params.KeySettings{}
+	}
+	return a.validatorParams.GetKeySettings(pubkey)
+}
+
+// keymanagerKeys - keys with settings and keys of prepared proposers, sorted
+func (a *ApiHandler) keymanagerKeys() []common.Bytes48 {
+	keys := map[common.Bytes48]struct{}{}
+	for _, k := range a.validatorParams.Keys() {
+		keys[k] = struct{}{}
+	}
+	if indices := a.validatorParams.ProposerIndices(); len(indices) > 0 {
+		_ = a.syncedData.ViewHeadState(func(headState *state.CachingBeaconState) error {
+			for _, idx := range indices {
+				if pubkey, err := headState.ValidatorPublicKey(int(idx)); err == nil {
+					keys[pubkey] = struct{}{}
+				}
+			}
+			return nil
+		})
+	}
+	res := make([]common.Bytes48, 0, len(keys))
+	for k := range keys {
+		res = append(res, k)
+	}
+	sort.Slice(res, func(i, j int) bool { return bytes.Compare(res[i][:], res[j][:]) < 0 })
+	return res
+}
+
+func (a *ApiHandler) GetEthV1Keystores(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	keys := a.keymanagerKeys()
+	res := make([]keymanagerKey, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, keymanagerKey{ValidatingPubkey: k, Readonly: true})
+	}
+	return newBeaconResponse(res), nil
+}
+
+// GetEthV1RemoteKeys - Caplin has no remote signers
+func (a *ApiHandler) GetEthV1RemoteKeys(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return newBeaconResponse([]keymanagerRemoteKey{}), nil
+}
+
+func pubkeyFromRequest(r *http.Request) (common.Bytes48, error) {
+	var pubkey common.Bytes48
+	if err := pubkey.UnmarshalText([]byte(chi.URLParam(r, "pubkey"))); err != nil {
+		return pubkey, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("invalid pubkey: %w", err))
+	}
+	return pubkey, nil
+}
+
+// keymanagerIndex - index of the validator with given key, if it's known to the head state
+func (a *ApiHandler) keymanagerIndex(pubkey common.Bytes48) (idx uint64, ok bool) {
+	_ = a.syncedData.ViewHeadState(func(headState *state.CachingBeaconState) error {
+		idx, ok = headState.ValidatorIndexByPubkey(pubkey)
+		return nil
+	})
+	return idx, ok
+}
+
+func (a *ApiHandler) GetEthV1ValidatorFeeRecipient(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	if feeRecipient := a.validatorParams.GetKeySettings(pubkey).FeeRecipient; feeRecipient != nil {
+		return newBeaconResponse(keymanagerFeeRecipient{Pubkey: pubkey, EthAddress: *feeRecipient}), nil
+	}
+	if idx, ok := a.keymanagerIndex(pubkey); ok {
+		if feeRecipient, ok := a.validatorParams.GetFeeRecipient(idx); ok {
+			return newBeaconResponse(keymanagerFeeRecipient{Pubkey: pubkey, EthAddress: feeRecipient}), nil
+		}
+	}
+	return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("no fee recipient for %s", pubkey))
+}
+
+func (a *ApiHandler) PostEthV1ValidatorFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req keymanagerFeeRecipient
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.EthAddress == (common.Address{}) {
+		http.Error(w, "fee recipient can't be zero address", http.StatusBadRequest)
+		return
+	}
+	a.validatorParams.UpdateKeySettings(pubkey, func(s *validator_params.KeySettings) { s.FeeRecipient = &req.EthAddress })
+	a.logger.Info("[Caplin] Keymanager: fee recipient set", "pubkey", pubkey, "feeRecipient", req.EthAddress)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *ApiHandler) DeleteEthV1ValidatorFeeRecipient(w http.ResponseWriter, r *http.Request) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.validatorParams.UpdateKeySettings(pubkey, func(s *validator_params.KeySettings) { s.FeeRecipient = nil })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *ApiHandler) GetEthV1ValidatorGasLimit(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := uint64(defaultKeymanagerGasLimit)
+	if keyGasLimit := a.validatorParams.GetKeySettings(pubkey).GasLimit; keyGasLimit != nil {
+		gasLimit = *keyGasLimit
+	}
+	return newBeaconResponse(keymanagerGasLimit{Pubkey: pubkey, GasLimit: gasLimit}), nil
+}
+
+func (a *ApiHandler) PostEthV1ValidatorGasLimit(w http.ResponseWriter, r *http.Request) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req struct {
+		GasLimit string `json:"gas_limit"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	gasLimit, err := strconv.ParseUint(req.GasLimit, 10, 64)
+	if err != nil || gasLimit == 0 {
+		http.Error(w, fmt.Sprintf("invalid gas_limit %q", req.GasLimit), http.StatusBadRequest)
+		return
+	}
+	a.validatorParams.UpdateKeySettings(pubkey, func(s *validator_params.KeySettings) { s.GasLimit = &gasLimit })
+	a.logger.Info("[Caplin] Keymanager: gas limit set", "pubkey", pubkey, "gasLimit", gasLimit)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *ApiHandler) DeleteEthV1ValidatorGasLimit(w http.ResponseWriter, r *http.Request) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.validatorParams.UpdateKeySettings(pubkey, func(s *validator_params.KeySettings) { s.GasLimit = nil })
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *ApiHandler) GetEthV1ValidatorGraffiti(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	graffiti := defaultGraffitiString
+	if keyGraffiti := a.validatorParams.GetKeySettings(pubkey).Graffiti; keyGraffiti != nil {
+		graffiti = string(bytes.TrimRight(keyGraffiti[:], "\x00"))
+	}
+	return newBeaconResponse(keymanagerGraffiti{Pubkey: pubkey, Graffiti: graffiti}), nil
+}
+
+func (a *ApiHandler) PostEthV1ValidatorGraffiti(w http.ResponseWriter, r *http.Request) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req keymanagerGraffiti
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(req.Graffiti) > len(common.Hash{}) {
+		http.Error(w, fmt.Sprintf("graffiti is longer than %d bytes", len(common.Hash{})), http.StatusBadRequest)
+		return
+	}
+	var graffiti common.Hash
+	copy(graffiti[:], req.Graffiti)
+	a.validatorParams.UpdateKeySettings(pubkey, func(s *validator_params.KeySettings) { s.Graffiti = &graffiti })
+	a.logger.Info("[Caplin] Keymanager: graffiti set", "pubkey", pubkey, "graffiti", req.Graffiti)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *ApiHandler) DeleteEthV1ValidatorGraffiti(w http.ResponseWriter, r *http.Request) {
+	pubkey, err := pubkeyFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	a.validatorParams.UpdateKeySettings(pubkey, func(s *validator_params.KeySettings) { s.Graffiti = nil })
+	w.WriteHeader(http.StatusNoContent)
+}
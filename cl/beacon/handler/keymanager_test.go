@@ -0,0 +1,109 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+)
+
+func TestKeymanager(t *testing.T) {
+	_, _, _, _, postState, handler, _, _, _, vp := setupTestingHandler(t, clparams.BellatrixVersion, log.Root(), true)
+	server := httptest.NewServer(handler.mux)
+	defer server.Close()
+
+	do := func(method, path, body string, token string) (int, string) {
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		out, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(out)
+	}
+
+	code, _ := do(http.MethodGet, "/eth/v1/keystores", "", "")
+	require.Equal(t, http.StatusUnauthorized, code)
+	code, _ = do(http.MethodGet, "/eth/v1/keystores", "", "wrong")
+	require.Equal(t, http.StatusUnauthorized, code)
+
+	// proposer prepared with index becomes a key
+	vp.SetFeeRecipient(1, common.Address{1})
+	pubkey, err := postState.ValidatorPublicKey(1)
+	require.NoError(t, err)
+	code, body := do(http.MethodGet, "/eth/v1/keystores", "", "test-token")
+	require.Equal(t, http.StatusOK, code)
+	var keys struct {
+		Data []keymanagerKey `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &keys))
+	require.Len(t, keys.Data, 1)
+	require.Equal(t, pubkey, keys.Data[0].ValidatingPubkey)
+
+	path := "/eth/v1/validator/" + pubkey.String()
+	feeRecipient := func() common.Address {
+		code, body := do(http.MethodGet, path+"/feerecipient", "", "test-token")
+		require.Equal(t, http.StatusOK, code)
+		var res struct {
+			Data keymanagerFeeRecipient `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &res))
+		return res.Data.EthAddress
+	}
+	require.Equal(t, common.Address{1}, feeRecipient())
+	code, _ = do(http.MethodPost, path+"/feerecipient", `{"ethaddress":"0x0200000000000000000000000000000000000000"}`, "test-token")
+	require.Equal(t, http.StatusAccepted, code)
+	require.Equal(t, common.Address{2}, feeRecipient())
+	code, _ = do(http.MethodDelete, path+"/feerecipient", "", "test-token")
+	require.Equal(t, http.StatusNoContent, code)
+	require.Equal(t, common.Address{1}, feeRecipient())
+
+	code, _ = do(http.MethodPost, path+"/gas_limit", `{"gas_limit":"45000000"}`, "test-token")
+	require.Equal(t, http.StatusAccepted, code)
+	code, body = do(http.MethodGet, path+"/gas_limit", "", "test-token")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, `"gas_limit":"45000000"`)
+	code, _ = do(http.MethodPost, path+"/gas_limit", `{"gas_limit":"abc"}`, "test-token")
+	require.Equal(t, http.StatusBadRequest, code)
+
+	code, body = do(http.MethodGet, path+"/graffiti", "", "test-token")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, `"graffiti":"`+defaultGraffitiString+`"`)
+	code, _ = do(http.MethodPost, path+"/graffiti", `{"graffiti":"solo staker"}`, "test-token")
+	require.Equal(t, http.StatusAccepted, code)
+	code, body = do(http.MethodGet, path+"/graffiti", "", "test-token")
+	require.Equal(t, http.StatusOK, code)
+	require.Contains(t, body, `"graffiti":"solo staker"`)
+	require.NotNil(t, vp.GetKeySettings(pubkey).Graffiti)
+
+	code, _ = do(http.MethodGet, "/eth/v1/validator/0x01/graffiti", "", "test-token")
+	require.Equal(t, http.StatusBadRequest, code)
+}
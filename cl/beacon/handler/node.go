@@ -25,6 +25,7 @@ import (
 
 	sentinel "github.com/erigontech/erigon-lib/gointerfaces/sentinelproto"
 	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	clsentinel "github.com/erigontech/erigon/cl/sentinel"
 )
 
 /*
@@ -35,12 +36,27 @@ import (
 "direction": "inbound"
 */
 type peer struct {
-	PeerID             string `json:"peer_id"`
-	State              string `json:"state"`
-	Enr                string `json:"enr"`
-	LastSeenP2PAddress string `json:"last_seen_p2p_address"`
-	Direction          string `json:"direction"`
-	AgentVersion       string `json:"agent_version"`
+	PeerID             string  `json:"peer_id"`
+	State              string  `json:"state"`
+	Enr                *string `json:"enr"` // nil - unknown, peer dialed us
+	LastSeenP2PAddress string  `json:"last_seen_p2p_address"`
+	Direction          string  `json:"direction"`
+	AgentVersion       string  `json:"agent_version"`
+}
+
+func newPeer(p *sentinel.Peer) peer {
+	res := peer{
+		PeerID:             p.Pid,
+		State:              p.State,
+		LastSeenP2PAddress: p.Address,
+		Direction:          p.Direction,
+		AgentVersion:       p.AgentVersion,
+	}
+	if p.Enr != "" {
+		enr := p.Enr
+		res.Enr = &enr
+	}
+	return res
 }
 
 func (a *ApiHandler) GetEthV1NodeHealth(w http.ResponseWriter, r *http.Request) {
@@ -103,17 +119,10 @@ func (a *ApiHandler) GetEthV1NodePeersInfos(w http.ResponseWriter, r *http.Reque
 	}
 	peers := make([]peer, 0, len(ret.Peers))
 	for i := range ret.Peers {
-		peers = append(peers, peer{
-			PeerID:             ret.Peers[i].Pid,
-			State:              ret.Peers[i].State,
-			Enr:                ret.Peers[i].Enr,
-			LastSeenP2PAddress: ret.Peers[i].Address,
-			Direction:          ret.Peers[i].Direction,
-			AgentVersion:       ret.Peers[i].AgentVersion,
-		})
+		peers = append(peers, newPeer(ret.Peers[i]))
 	}
 
-	return newBeaconResponse(peers), nil
+	return newBeaconResponse(peers).With("meta", map[string]any{"count": strconv.Itoa(len(peers))}), nil
 }
 
 func (a *ApiHandler) GetEthV1NodePeerInfos(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
@@ -128,14 +137,7 @@ func (a *ApiHandler) GetEthV1NodePeerInfos(w http.ResponseWriter, r *http.Reques
 	// find the peer with matching enr
 	for _, p := range ret.Peers {
 		if p.Pid == pid {
-			return newBeaconResponse(peer{
-				PeerID:             p.Pid,
-				State:              p.State,
-				Enr:                p.Enr,
-				LastSeenP2PAddress: p.Address,
-				Direction:          p.Direction,
-				AgentVersion:       p.AgentVersion,
-			}), nil
+			return newBeaconResponse(newPeer(p)), nil
 		}
 	}
 
@@ -173,3 +175,43 @@ func (a *ApiHandler) GetEthV1NodeSyncing(w http.ResponseWriter, r *http.Request)
 			"el_offline":    false,
 		}), nil
 }
+
+// GossipInspector - gossipsub state of the in-process sentinel
+type GossipInspector interface {
+	GossipScores() []clsentinel.PeerScore
+	GossipTopicPeers(topic string) []string
+}
+
+type gossipTopicInspection struct {
+	Topic       string                 `json:"topic"`
+	Subscribers []string               `json:"subscribers"`
+	MeshPeers   []string               `json:"mesh_peers"`
+	Scores      []clsentinel.PeerScore `json:"scores"`
+}
+
+// GetCaplinDebugGossip - gossipsub peer scores with breakdown, the worst first. With topic parameter only the
+// topic is reported, with its subscribers and mesh.
+func (a *ApiHandler) GetCaplinDebugGossip(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	if a.gossipInspector == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, errors.New("gossip inspection needs in-process sentinel"))
+	}
+	scores := a.gossipInspector.GossipScores()
+	topic := r.URL.Query().Get("topic")
+	if topic == "" {
+		return newBeaconResponse(scores), nil
+	}
+	res := gossipTopicInspection{Topic: topic, Subscribers: a.gossipInspector.GossipTopicPeers(topic), MeshPeers: []string{},
+		Scores: []clsentinel.PeerScore{}}
+	for _, s := range scores {
+		t, ok := s.Topics[topic]
+		if !ok {
+			continue
+		}
+		if t.InMesh {
+			res.MeshPeers = append(res.MeshPeers, s.PeerID)
+		}
+		s.Topics = map[string]clsentinel.TopicScore{topic: t}
+		res.Scores = append(res.Scores, s)
+	}
+	return newBeaconResponse(res), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+	clsentinel "github.com/erigontech/erigon/cl/sentinel"
+)
+
+type testGossipInspector struct{}
+
+func (testGossipInspector) GossipScores() []clsentinel.PeerScore {
+	return []clsentinel.PeerScore{
+		{PeerID: "bad", Score: -10, Topics: map[string]clsentinel.TopicScore{"blocks": {InvalidMessageDeliveries: 3}}},
+		{PeerID: "good", Score: 5, Topics: map[string]clsentinel.TopicScore{"blocks": {InMesh: true, TimeInMeshSeconds: 60}, "attestations": {InMesh: true}}},
+	}
+}
+
+func (testGossipInspector) GossipTopicPeers(topic string) []string { return []string{"bad", "good"} }
+
+func TestGetCaplinDebugGossip(t *testing.T) {
+	_, _, _, _, _, handler, _, _, _, _ := setupTestingHandler(t, clparams.BellatrixVersion, log.Root(), false)
+	server := httptest.NewServer(handler.mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/caplin/debug/gossip")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	handler.gossipInspector = testGossipInspector{}
+	resp, err = http.Get(server.URL + "/caplin/debug/gossip?topic=blocks")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var res struct {
+		Data gossipTopicInspection `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	require.Equal(t, []string{"bad", "good"}, res.Data.Subscribers)
+	require.Equal(t, []string{"good"}, res.Data.MeshPeers)
+	require.Len(t, res.Data.Scores, 2)
+	require.Len(t, res.Data.Scores[1].Topics, 1)
+}
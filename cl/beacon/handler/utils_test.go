@@ -93,7 +93,7 @@ func setupTestingHandler(t *testing.T, v clparams.StateVersion, logger log.Logge
 	genesis, err := initial_state.GetGenesisState(networkid.MainnetChainID)
 	require.NoError(t, err)
 	ethClock := eth_clock.NewEthereumClock(genesis.GenesisTime(), genesis.GenesisValidatorsRoot(), &bcfg)
-	blobStorage := blob_storage.NewBlobStore(blobDb, afero.NewMemMapFs(), math.MaxUint64, &bcfg, ethClock)
+	blobStorage := blob_storage.NewBlobStore(blobDb, afero.NewMemMapFs(), clparams.RetentionConfig{BlobSidecarsSlots: math.MaxUint64}, &bcfg, ethClock)
 	blobStorage.WriteBlobSidecars(ctx, firstBlockRoot, []*cltypes.BlobSidecar{
 		{
 			Index:                    0,
@@ -164,6 +164,9 @@ func setupTestingHandler(t *testing.T, v clparams.StateVersion, logger log.Logge
 			Events:     true,
 			Validator:  true,
 			Lighthouse: true,
+			Keymanager: true,
+
+			KeymanagerToken: "test-token",
 		}, nil, blobStorage, nil, vp, nil, nil, fcu.SyncContributionPool, nil, nil,
 		syncCommitteeMessagesService,
 		syncContributionService,
@@ -175,6 +178,7 @@ func setupTestingHandler(t *testing.T, v clparams.StateVersion, logger log.Logge
 		nil,
 		nil,
 		false,
+		nil,
 	) // TODO: add tests
 	h.Init()
 	return
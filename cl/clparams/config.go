@@ -50,6 +50,8 @@ type CaplinConfig struct {
 	ImmediateBlobsBackfilling bool
 	BlobPruningDisabled       bool
 	SnapshotGenerationEnabled bool
+	// Retention of blob sidecars and beacon blocks, coordinated with EL pruning
+	Retention RetentionConfig
 	// Network related config
 	NetworkId NetworkType
 	// DisableCheckpointSync is optional and is used to disable checkpoint sync used by default in the node
@@ -86,6 +88,8 @@ type CaplinConfig struct {
 
 	// Extra
 	EnableEngineAPI bool
+	// BatchVerificationLatency - latency budget of batched BLS signature verification of gossip, 0 - default
+	BatchVerificationLatency time.Duration
 }
 
 func (c CaplinConfig) IsDevnet() bool {
@@ -17,6 +17,7 @@
 package clparams
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -38,3 +39,36 @@ func TestGetConfigsByNetwork(t *testing.T) {
 	testConfig(t, networkid.ChiadoChainID)
 	testConfig(t, networkid.HoodiChainID)
 }
+
+func TestRetentionResolve(t *testing.T) {
+	minBlobs := MainnetBeaconConfig.MinSlotsForBlobsSidecarsRequest()
+
+	res, warnings := RetentionConfig{}.Resolve(&MainnetBeaconConfig)
+	require.Empty(t, warnings)
+	require.Equal(t, minBlobs, res.BlobSidecarsSlots)
+	require.Equal(t, uint64(DefaultBeaconBlocksRetentionSlots), res.BeaconBlocksSlots)
+	require.Zero(t, res.ELBlocks)
+
+	// minimal EL: Caplin keeps less blocks, EL keeps bodies of the blobs window
+	res, warnings = RetentionConfig{ELBlocks: 100_000}.Resolve(&MainnetBeaconConfig)
+	require.Len(t, warnings, 1)
+	require.Equal(t, minBlobs, res.BeaconBlocksSlots)
+	require.Equal(t, minBlobs, res.ELBlocks)
+
+	// explicit windows raise the EL, too short ones are raised
+	res, warnings = RetentionConfig{BlobSidecarsSlots: 10, BeaconBlocksSlots: 2_000_000, ELBlocks: 100_000}.Resolve(&MainnetBeaconConfig)
+	require.Len(t, warnings, 2)
+	require.Equal(t, minBlobs, res.BlobSidecarsSlots)
+	require.Equal(t, uint64(2_000_000), res.ELBlocks)
+
+	// resolved config is stable
+	again, warnings := res.Resolve(&MainnetBeaconConfig)
+	require.Empty(t, warnings)
+	require.Equal(t, res, again)
+
+	// archive blocks keep all bodies of the EL
+	res, _ = CaplinConfig{ArchiveBlocks: true, Retention: RetentionConfig{ELBlocks: 100_000}}.ResolveRetention(&MainnetBeaconConfig)
+	require.False(t, res.BlocksPruned())
+	require.True(t, res.BlobsPruned())
+	require.Equal(t, uint64(math.MaxUint64), res.ELBlocks)
+}
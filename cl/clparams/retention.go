@@ -0,0 +1,90 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package clparams
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultBeaconBlocksRetentionSlots - beacon blocks kept by non-archive Caplin
+const DefaultBeaconBlocksRetentionSlots = 1_000_000
+
+// RetentionConfig - what Caplin and the EL keep of the recent chain. Windows depend on each other: blob
+// sidecars are served together with their beacon blocks, and beacon blocks are stored blinded, their payloads
+// are read back from EL bodies. So EL bodies must outlive beacon blocks, which must outlive blob sidecars.
+// Every slot has at most one block, so windows in slots bound windows in blocks.
+type RetentionConfig struct {
+	BlobSidecarsSlots uint64 // 0 - MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS, math.MaxUint64 - never pruned
+	BeaconBlocksSlots uint64 // 0 - DefaultBeaconBlocksRetentionSlots or less, to fit ELBlocks, math.MaxUint64 - never pruned
+	ELBlocks          uint64 // blocks the EL keeps bodies of (--prune.*), 0 - EL is not embedded, math.MaxUint64 - all
+	DryRun            bool   // report what would be pruned, don't delete
+}
+
+// Resolve - effective retention: windows shorter than the ones depending on them are raised, every raise is
+// reported in warnings. ELBlocks of the result is the floor of EL blocks pruning.
+func (r RetentionConfig) Resolve(beaconCfg *BeaconChainConfig) (res RetentionConfig, warnings []string) {
+	res = r
+	minBlobs := beaconCfg.MinSlotsForBlobsSidecarsRequest()
+	switch {
+	case res.BlobSidecarsSlots == 0:
+		res.BlobSidecarsSlots = minBlobs
+	case res.BlobSidecarsSlots < minBlobs:
+		warnings = append(warnings, fmt.Sprintf("blob sidecars retention raised from %d to %d slots: peers may request them for MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS", res.BlobSidecarsSlots, minBlobs))
+		res.BlobSidecarsSlots = minBlobs
+	}
+	if res.BeaconBlocksSlots == 0 {
+		// by default don't make the EL keep more bodies than it's configured to, only what blobs need
+		res.BeaconBlocksSlots = DefaultBeaconBlocksRetentionSlots
+		if res.ELBlocks != 0 {
+			res.BeaconBlocksSlots = min(res.BeaconBlocksSlots, max(res.ELBlocks, res.BlobSidecarsSlots))
+		}
+	}
+	if res.BeaconBlocksSlots < res.BlobSidecarsSlots {
+		if r.BeaconBlocksSlots != 0 {
+			warnings = append(warnings, fmt.Sprintf("beacon blocks retention raised from %d to %d slots: blob sidecars are served with their blocks", res.BeaconBlocksSlots, res.BlobSidecarsSlots))
+		}
+		res.BeaconBlocksSlots = res.BlobSidecarsSlots
+	}
+	if res.ELBlocks != 0 && res.ELBlocks < res.BeaconBlocksSlots {
+		if res.BlocksPruned() {
+			warnings = append(warnings, fmt.Sprintf("EL keeps bodies of %d blocks, pruning of bodies is held back to %d blocks: Caplin's beacon blocks are read with them", res.ELBlocks, res.BeaconBlocksSlots))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("EL keeps bodies of %d blocks, pruning of bodies is disabled: Caplin archives beacon blocks, which are read with them", res.ELBlocks))
+		}
+		res.ELBlocks = res.BeaconBlocksSlots
+	}
+	return res, warnings
+}
+
+// BlobsPruned - whether blob sidecars are pruned at all
+func (r RetentionConfig) BlobsPruned() bool { return r.BlobSidecarsSlots != math.MaxUint64 }
+
+// BlocksPruned - whether beacon blocks are pruned at all
+func (r RetentionConfig) BlocksPruned() bool { return r.BeaconBlocksSlots != math.MaxUint64 }
+
+// ResolveRetention - Retention with archive flags applied, resolved against beaconCfg
+func (c CaplinConfig) ResolveRetention(beaconCfg *BeaconChainConfig) (RetentionConfig, []string) {
+	r := c.Retention
+	if c.ArchiveBlobs || c.BlobPruningDisabled {
+		r.BlobSidecarsSlots = math.MaxUint64
+	}
+	if c.ArchiveBlocks {
+		r.BeaconBlocksSlots = math.MaxUint64
+	}
+	return r.Resolve(beaconCfg)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
@@ -58,10 +59,13 @@ type BlobStore struct {
 	beaconChainConfig *clparams.BeaconChainConfig
 	ethClock          eth_clock.EthereumClock
 	slotsKept         uint64
+	dryRun            bool
+	dryRunReported    atomic.Uint64 // folders below it were reported by dry-run already
 }
 
-func NewBlobStore(db kv.RwDB, fs afero.Fs, slotsKept uint64, beaconChainConfig *clparams.BeaconChainConfig, ethClock eth_clock.EthereumClock) BlobStorage {
-	return &BlobStore{fs: fs, db: db, slotsKept: slotsKept, beaconChainConfig: beaconChainConfig, ethClock: ethClock}
+// NewBlobStore - blob sidecars are kept for retention.BlobSidecarsSlots, see clparams.RetentionConfig.Resolve
+func NewBlobStore(db kv.RwDB, fs afero.Fs, retention clparams.RetentionConfig, beaconChainConfig *clparams.BeaconChainConfig, ethClock eth_clock.EthereumClock) BlobStorage {
+	return &BlobStore{fs: fs, db: db, slotsKept: retention.BlobSidecarsSlots, dryRun: retention.DryRun, beaconChainConfig: beaconChainConfig, ethClock: ethClock}
 }
 
 func blobSidecarFilePath(slot, index uint64, blockRoot common.Hash) (folderpath, filepath string) {
@@ -152,23 +156,36 @@ func (bs *BlobStore) ReadBlobSidecars(ctx context.Context, slot uint64, blockRoo
 	return blobSidecars, true, nil
 }
 
-// Do a bit of pruning
+// Prune removes folders of slots older than slotsKept. Only folders of the last
+// MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS are visited: older ones were removed by previous calls.
 func (bs *BlobStore) Prune() error {
 	if bs.slotsKept == math.MaxUint64 {
 		return nil
 	}
-
 	currentSlot := bs.ethClock.GetCurrentSlot()
-	currentSlot -= bs.slotsKept
-	currentSlot = (currentSlot / subdivisionSlot) * subdivisionSlot
-	var startPrune uint64
-	minSlotsForBlobSidecarRequest := bs.beaconChainConfig.MinSlotsForBlobsSidecarsRequest()
-	if currentSlot >= minSlotsForBlobSidecarRequest {
-		startPrune = currentSlot - minSlotsForBlobSidecarRequest
+	if currentSlot < bs.slotsKept {
+		return nil
+	}
+	keepFrom := (currentSlot - bs.slotsKept) / subdivisionSlot // folder of the first kept slot stays
+	var pruneFrom uint64
+	if window := bs.beaconChainConfig.MinSlotsForBlobsSidecarsRequest() / subdivisionSlot; keepFrom > window {
+		pruneFrom = keepFrom - window
+	}
+	if bs.dryRun {
+		pruneFrom = max(pruneFrom, bs.dryRunReported.Load())
+	}
+	for i := pruneFrom; i < keepFrom; i++ {
+		folder := strconv.FormatUint(i, 10)
+		if !bs.dryRun {
+			bs.fs.RemoveAll(folder)
+			continue
+		}
+		if exists, _ := afero.DirExists(bs.fs, folder); exists {
+			log.Info("[Caplin] Blob sidecars pruning dry-run: would remove", "fromSlot", i*subdivisionSlot, "toSlot", (i+1)*subdivisionSlot-1, "keptSlots", bs.slotsKept)
+		}
 	}
-	// delete all the folders that are older than slotsKept
-	for i := startPrune; i < currentSlot; i += subdivisionSlot {
-		bs.fs.RemoveAll(strconv.FormatUint(i/subdivisionSlot, 10))
+	if bs.dryRun {
+		bs.dryRunReported.Store(max(keepFrom, bs.dryRunReported.Load()))
 	}
 	return nil
 }
@@ -26,8 +26,10 @@ import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func setupTestDB(t *testing.T) kv.RwDB {
@@ -43,7 +45,7 @@ func TestBlobDB(t *testing.T) {
 	s2 := cltypes.NewBlobSidecar(1, &cltypes.Blob{3}, common.Bytes48{5}, common.Bytes48{9}, &cltypes.SignedBeaconBlockHeader{Header: &cltypes.BeaconBlockHeader{Slot: 1}}, solid.NewHashVector(cltypes.CommitmentBranchSize))
 
 	//
-	bs := NewBlobStore(db, afero.NewMemMapFs(), 12, &clparams.MainnetBeaconConfig, nil)
+	bs := NewBlobStore(db, afero.NewMemMapFs(), clparams.RetentionConfig{BlobSidecarsSlots: 12}, &clparams.MainnetBeaconConfig, nil)
 	blockRoot := common.Hash{1}
 	err := bs.WriteBlobSidecars(context.Background(), blockRoot, []*cltypes.BlobSidecar{s1, s2})
 	require.NoError(t, err)
@@ -66,3 +68,37 @@ func TestBlobDB(t *testing.T) {
 	require.Equal(t, s1.SignedBlockHeader, sidecars[0].SignedBlockHeader)
 	require.Equal(t, s2.SignedBlockHeader, sidecars[1].SignedBlockHeader)
 }
+
+func TestBlobPrune(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	clock := eth_clock.NewMockEthereumClock(gomock.NewController(t))
+	clock.EXPECT().GetCurrentSlot().Return(uint64(5*subdivisionSlot + 1)).AnyTimes()
+
+	write := func(bs BlobStorage, slot uint64) {
+		s := cltypes.NewBlobSidecar(0, &cltypes.Blob{1}, common.Bytes48{2}, common.Bytes48{3}, &cltypes.SignedBeaconBlockHeader{Header: &cltypes.BeaconBlockHeader{Slot: slot}}, solid.NewHashVector(cltypes.CommitmentBranchSize))
+		require.NoError(t, bs.WriteBlobSidecars(context.Background(), common.Hash{byte(slot / subdivisionSlot)}, []*cltypes.BlobSidecar{s}))
+	}
+	fs := afero.NewMemMapFs()
+	retention := clparams.RetentionConfig{BlobSidecarsSlots: 2 * subdivisionSlot, DryRun: true}
+	bs := NewBlobStore(db, fs, retention, &clparams.MainnetBeaconConfig, clock)
+	for folder := uint64(0); folder <= 5; folder++ {
+		write(bs, folder*subdivisionSlot)
+	}
+	// dry-run keeps everything
+	require.NoError(t, bs.Prune())
+	for _, folder := range []string{"0", "2", "3", "5"} {
+		exists, err := afero.DirExists(fs, folder)
+		require.NoError(t, err)
+		require.True(t, exists, folder)
+	}
+
+	retention.DryRun = false
+	bs = NewBlobStore(db, fs, retention, &clparams.MainnetBeaconConfig, clock)
+	require.NoError(t, bs.Prune())
+	for folder, kept := range map[string]bool{"0": false, "2": false, "3": true, "5": true} {
+		exists, err := afero.DirExists(fs, folder)
+		require.NoError(t, err)
+		require.Equal(t, kept, exists, folder)
+	}
+}
@@ -29,6 +29,7 @@ type BatchSignatureVerifier struct {
 	syncContributionVerify     chan *AggregateVerificationData
 	syncCommitteeMessage       chan *AggregateVerificationData
 	voluntaryExitVerify        chan *AggregateVerificationData
+	checkInterval              time.Duration
 	ctx                        context.Context
 }
 
@@ -87,6 +88,11 @@ func (b *BatchSignatureVerifier) ImmediateVerification(data *AggregateVerificati
 	return b.processSignatureVerification([]*AggregateVerificationData{data})
 }
 
+// SetCheckInterval - latency budget of batches: collected signatures are verified at least that often
+func (b *BatchSignatureVerifier) SetCheckInterval(d time.Duration) {
+	b.checkInterval = d
+}
+
 func (b *BatchSignatureVerifier) Start() {
 	// separate goroutines for each type of verification
 	go b.start(b.attVerifyAndExecute)
@@ -100,7 +106,11 @@ func (b *BatchSignatureVerifier) Start() {
 // When receiving AggregateVerificationData, we simply collect all the signature verification data
 // and verify them together - running all the final functions afterwards
 func (b *BatchSignatureVerifier) start(incoming chan *AggregateVerificationData) {
-	ticker := time.NewTicker(batchCheckInterval)
+	checkInterval := batchCheckInterval
+	if b.checkInterval > 0 {
+		checkInterval = b.checkInterval
+	}
+	ticker := time.NewTicker(checkInterval)
 	defer ticker.Stop()
 	aggregateVerificationData := make([]*AggregateVerificationData, 0, reservedSize)
 	for {
@@ -111,7 +121,7 @@ func (b *BatchSignatureVerifier) start(incoming chan *AggregateVerificationData)
 			aggregateVerificationData = append(aggregateVerificationData, verification)
 			if len(aggregateVerificationData) >= batchSignatureVerificationThreshold {
 				b.processSignatureVerification(aggregateVerificationData)
-				ticker.Reset(batchCheckInterval)
+				ticker.Reset(checkInterval)
 				// clear the slice
 				aggregateVerificationData = make([]*AggregateVerificationData, 0, reservedSize)
 			}
@@ -135,15 +135,18 @@ func (b *blobSidecarService) ProcessMessage(ctx context.Context, subnetId *uint6
 }
 
 func (b *blobSidecarService) verifyAndStoreBlobSidecar(msg *cltypes.BlobSidecar) error {
-	kzgCtx := kzg.Ctx()
-
 	if !b.test && !cltypes.VerifyCommitmentInclusionProof(msg.KzgCommitment, msg.CommitmentInclusionProof, msg.Index,
 		clparams.DenebVersion, msg.SignedBlockHeader.Header.BodyRoot) {
 		return ErrCommitmentsInclusionProofFailed
 	}
 
 	start := time.Now()
-	if err := kzgCtx.VerifyBlobKZGProof(msg.Blob[:], gokzg4844.KZGCommitment(msg.KzgCommitment), gokzg4844.KZGProof(msg.KzgProof)); err != nil {
+	// batched with sidecars of other blobs of the block and with blob txns of txpool
+	if err := kzg.VerifyBlobKZGProofs(kzg.BlobProofs{
+		Blobs:       []gokzg4844.BlobRef{msg.Blob[:]},
+		Commitments: []gokzg4844.KZGCommitment{gokzg4844.KZGCommitment(msg.KzgCommitment)},
+		Proofs:      []gokzg4844.KZGProof{gokzg4844.KZGProof(msg.KzgProof)},
+	})[0]; err != nil {
 		return fmt.Errorf("blob KZG proof verification failed: %v", err)
 	}
 
@@ -14,10 +14,12 @@ func cleanupAndPruning(ctx context.Context, logger log.Logger, cfg *Cfg, args Ar
 		return err
 	}
 	defer tx.Rollback()
-	pruneDistance := uint64(1_000_000)
-
-	if !cfg.caplinConfig.ArchiveBlocks {
-		if err := beacon_indicies.PruneBlocks(ctx, tx, args.seenSlot-pruneDistance); err != nil {
+	retention := cfg.caplinConfig.Retention
+	if !cfg.caplinConfig.ArchiveBlocks && retention.BlocksPruned() && args.seenSlot > retention.BeaconBlocksSlots {
+		pruneTo := args.seenSlot - retention.BeaconBlocksSlots
+		if retention.DryRun {
+			logger.Debug("[Caplin] Beacon blocks pruning dry-run: would remove blocks", "toSlot", pruneTo, "keptSlots", retention.BeaconBlocksSlots)
+		} else if err := beacon_indicies.PruneBlocks(ctx, tx, pruneTo); err != nil {
 			return err
 		}
 	}
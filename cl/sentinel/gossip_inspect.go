@@ -0,0 +1,107 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentinel
+
+import (
+	"fmt"
+	"sort"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+var gossipNegativeScorePeers = metrics.GetOrCreateGauge("gossip_negative_score_peers")
+
+// TopicScore - gossipsub score counters of a peer in a topic
+type TopicScore struct {
+	InMesh                   bool    `json:"in_mesh"`
+	TimeInMeshSeconds        float64 `json:"time_in_mesh_seconds"`
+	FirstMessageDeliveries   float64 `json:"first_message_deliveries"`
+	MeshMessageDeliveries    float64 `json:"mesh_message_deliveries"`
+	InvalidMessageDeliveries float64 `json:"invalid_message_deliveries"`
+}
+
+// PeerScore - gossipsub score of a peer and its breakdown
+type PeerScore struct {
+	PeerID             string                `json:"peer_id"`
+	Score              float64               `json:"score"`
+	AppSpecificScore   float64               `json:"app_specific_score"`
+	IPColocationFactor float64               `json:"ip_colocation_factor"`
+	BehaviourPenalty   float64               `json:"behaviour_penalty"`
+	Topics             map[string]TopicScore `json:"topics"`
+}
+
+// inspectScores - called by gossipsub every slot with scores of connected peers. A peer is in the mesh of a
+// topic while it has time in mesh: gossipsub resets it on prune.
+func (s *Sentinel) inspectScores(snapshots map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	scores := make([]PeerScore, 0, len(snapshots))
+	meshPeers := map[string]int{}
+	negative := 0
+	for pid, snapshot := range snapshots {
+		score := PeerScore{PeerID: pid.String(), Score: snapshot.Score, AppSpecificScore: snapshot.AppSpecificScore,
+			IPColocationFactor: snapshot.IPColocationFactor, BehaviourPenalty: snapshot.BehaviourPenalty,
+			Topics: make(map[string]TopicScore, len(snapshot.Topics))}
+		for topic, t := range snapshot.Topics {
+			inMesh := t.TimeInMesh > 0
+			if inMesh {
+				meshPeers[topic]++
+			}
+			score.Topics[topic] = TopicScore{InMesh: inMesh, TimeInMeshSeconds: t.TimeInMesh.Seconds(),
+				FirstMessageDeliveries: t.FirstMessageDeliveries, MeshMessageDeliveries: t.MeshMessageDeliveries,
+				InvalidMessageDeliveries: t.InvalidMessageDeliveries}
+		}
+		if snapshot.Score < 0 {
+			negative++
+		}
+		scores = append(scores, score)
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Score < scores[j].Score })
+
+	s.gossipScoresLock.Lock()
+	defer s.gossipScoresLock.Unlock()
+	s.gossipScores = scores
+
+	gossipNegativeScorePeers.SetInt(negative)
+	if s.gossipMeshTopics == nil {
+		s.gossipMeshTopics = map[string]struct{}{}
+	}
+	for topic := range meshPeers {
+		s.gossipMeshTopics[topic] = struct{}{}
+	}
+	for topic := range s.gossipMeshTopics { // topics with empty mesh too
+		metrics.GetOrCreateGauge(fmt.Sprintf(`gossip_mesh_peers{topic="%s"}`, topic)).SetInt(meshPeers[topic])
+	}
+}
+
+// GossipScores - last scores of connected peers, the worst first
+func (s *Sentinel) GossipScores() []PeerScore {
+	s.gossipScoresLock.Lock()
+	defer s.gossipScoresLock.Unlock()
+	return s.gossipScores
+}
+
+// GossipTopicPeers - peers subscribed to the topic, mesh peers are a subset of them
+func (s *Sentinel) GossipTopicPeers(topic string) []string {
+	pids := s.pubsub.ListPeers(topic)
+	res := make([]string, 0, len(pids))
+	for _, pid := range pids {
+		res = append(res, pid.String())
+	}
+	return res
+}
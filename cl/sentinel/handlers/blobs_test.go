@@ -103,7 +103,7 @@ func TestBlobsByRangeHandler(t *testing.T) {
 	h := expBlocks[0].SignedBeaconBlockHeader()
 	sidecars := getTestBlobSidecars(h)
 	_, beaconCfg := clparams.GetConfigsByNetwork(1)
-	blobStorage := blob_storage.NewBlobStore(blobDb, afero.NewMemMapFs(), math.MaxUint64, beaconCfg, nil)
+	blobStorage := blob_storage.NewBlobStore(blobDb, afero.NewMemMapFs(), clparams.RetentionConfig{BlobSidecarsSlots: math.MaxUint64}, beaconCfg, nil)
 	r, _ := h.Header.HashSSZ()
 	require.NoError(t, blobStorage.WriteBlobSidecars(ctx, r, sidecars))
 
@@ -225,7 +225,7 @@ func TestBlobsByIdentifiersHandler(t *testing.T) {
 	h := expBlocks[0].SignedBeaconBlockHeader()
 	sidecars := getTestBlobSidecars(h)
 	_, beaconCfg := clparams.GetConfigsByNetwork(1)
-	blobStorage := blob_storage.NewBlobStore(blobDb, afero.NewMemMapFs(), math.MaxUint64, beaconCfg, ethClock)
+	blobStorage := blob_storage.NewBlobStore(blobDb, afero.NewMemMapFs(), clparams.RetentionConfig{BlobSidecarsSlots: math.MaxUint64}, beaconCfg, ethClock)
 	r, _ := h.Header.HashSSZ()
 	require.NoError(t, blobStorage.WriteBlobSidecars(ctx, r, sidecars))
 
@@ -65,6 +65,7 @@ func (s *Sentinel) pubsubOptions() []pubsub.Option {
 		pubsub.WithMaxMessageSize(int(s.cfg.NetworkConfig.GossipMaxSizeBellatrix)),
 		pubsub.WithValidateQueueSize(pubsubQueueSize),
 		pubsub.WithPeerScore(scoreParams, thresholds),
+		pubsub.WithPeerScoreInspect(s.inspectScores, s.oneSlotDuration()),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
 	}
 	return psOpts
@@ -105,6 +105,10 @@ type Sentinel struct {
 	ethClock         eth_clock.EthereumClock
 
 	metadataLock sync.Mutex
+
+	gossipScoresLock sync.Mutex
+	gossipScores     []PeerScore         // last result of gossipsub peer score inspection
+	gossipMeshTopics map[string]struct{} // topics ever having mesh peers, for metrics
 }
 
 func (s *Sentinel) createLocalNode(
@@ -489,13 +493,7 @@ func (s *Sentinel) GetPeersInfos() *sentinelrpc.PeersInfoResponse {
 	out := &sentinelrpc.PeersInfoResponse{Peers: make([]*sentinelrpc.Peer, 0, len(peers))}
 
 	for _, p := range peers {
-		entry := &sentinelrpc.Peer{}
-		peerInfo := s.host.Network().Peerstore().PeerInfo(p)
-		if len(peerInfo.Addrs) == 0 {
-			continue
-		}
-		entry.Address = peerInfo.Addrs[0].String()
-		entry.Pid = peerInfo.ID.String()
+		entry := &sentinelrpc.Peer{Pid: p.String()}
 		entry.State = "connected"
 		if s.host.Network().Connectedness(p) != network.Connected {
 			entry.State = "disconnected"
@@ -504,15 +502,16 @@ func (s *Sentinel) GetPeersInfos() *sentinelrpc.PeersInfoResponse {
 		if len(conns) == 0 {
 			continue
 		}
+		// address of the connection, not of the peerstore: inbound peers may have no listen address there
+		entry.Address = conns[0].RemoteMultiaddr().String()
 		if conns[0].Stat().Direction == network.DirOutbound {
 			entry.Direction = "outbound"
 		} else {
 			entry.Direction = "inbound"
 		}
+		// peers which dialed us are not discovered, so their ENR is unknown
 		if enr, ok := s.pidToEnr.Load(p); ok {
 			entry.Enr = enr.(string)
-		} else {
-			continue
 		}
 		agent, err := s.host.Peerstore().Get(p, "AgentVersion")
 		if err == nil {
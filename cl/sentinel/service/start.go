@@ -173,7 +173,7 @@ func StartSentinelService(
 	srvCfg *ServerConfig,
 	ethClock eth_clock.EthereumClock,
 	forkChoiceReader forkchoice.ForkChoiceStorageReader,
-	logger log.Logger) (sentinelrpc.SentinelClient, error) {
+	logger log.Logger) (sentinelrpc.SentinelClient, *sentinel.Sentinel, error) {
 	ctx := context.Background()
 	sent, err := createSentinel(
 		cfg,
@@ -185,7 +185,7 @@ func StartSentinelService(
 		logger,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// rcmgrObs.MustRegisterWith(prometheus.DefaultRegisterer)
 	logger.Info("[Sentinel] Sentinel started", "enr", sent.String())
@@ -195,7 +195,7 @@ func StartSentinelService(
 	server := NewSentinelServer(ctx, sent, logger)
 	go StartServe(server, srvCfg, srvCfg.Creds)
 
-	return direct.NewSentinelClientDirect(server), nil
+	return direct.NewSentinelClientDirect(server), sent, nil
 }
 
 func StartServe(
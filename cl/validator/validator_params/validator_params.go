@@ -22,8 +22,22 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 )
 
+// KeySettings - per-key overrides set with the keymanager API, nil fields are not set
+type KeySettings struct {
+	FeeRecipient *common.Address
+	GasLimit     *uint64
+	Graffiti     *common.Hash
+}
+
+func (s KeySettings) empty() bool {
+	return s.FeeRecipient == nil && s.GasLimit == nil && s.Graffiti == nil
+}
+
 type ValidatorParams struct {
-	feeRecipients sync.Map
+	feeRecipients sync.Map // validator index -> fee recipient of prepare_beacon_proposer
+
+	keysLock sync.RWMutex
+	keys     map[common.Bytes48]KeySettings
 }
 
 func NewValidatorParams() *ValidatorParams {
@@ -41,3 +55,46 @@ func (vp *ValidatorParams) GetFeeRecipient(validatorIndex uint64) (common.Addres
 	}
 	return val.(common.Address), true
 }
+
+// ProposerIndices - validators which called prepare_beacon_proposer
+func (vp *ValidatorParams) ProposerIndices() []uint64 {
+	var res []uint64
+	vp.feeRecipients.Range(func(k, _ any) bool {
+		res = append(res, k.(uint64))
+		return true
+	})
+	return res
+}
+
+// UpdateKeySettings - applies fn to settings of the key, settings without fields set are removed
+func (vp *ValidatorParams) UpdateKeySettings(pubkey common.Bytes48, fn func(s *KeySettings)) {
+	vp.keysLock.Lock()
+	defer vp.keysLock.Unlock()
+	if vp.keys == nil {
+		vp.keys = map[common.Bytes48]KeySettings{}
+	}
+	s := vp.keys[pubkey]
+	fn(&s)
+	if s.empty() {
+		delete(vp.keys, pubkey)
+		return
+	}
+	vp.keys[pubkey] = s
+}
+
+func (vp *ValidatorParams) GetKeySettings(pubkey common.Bytes48) KeySettings {
+	vp.keysLock.RLock()
+	defer vp.keysLock.RUnlock()
+	return vp.keys[pubkey]
+}
+
+// Keys - keys with settings
+func (vp *ValidatorParams) Keys() []common.Bytes48 {
+	vp.keysLock.RLock()
+	defer vp.keysLock.RUnlock()
+	res := make([]common.Bytes48, 0, len(vp.keys))
+	for k := range vp.keys {
+		res = append(res, k)
+	}
+	return res
+}
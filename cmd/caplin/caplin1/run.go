@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math"
 	"os"
 	"path"
 	"time"
@@ -77,6 +76,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon/cl/clparams"
 )
 
@@ -87,7 +87,7 @@ func OpenCaplinDatabase(ctx context.Context,
 	blobDir string,
 	engine execution_client.ExecutionEngine,
 	wipeout bool,
-	blobPruneDistance uint64,
+	retention clparams.RetentionConfig,
 ) (kv.RwDB, blob_storage.BlobStorage, error) {
 	dataDirIndexer := path.Join(dbPath, "beacon_indicies")
 	blobDbPath := path.Join(blobDir, "chaindata")
@@ -125,7 +125,7 @@ func OpenCaplinDatabase(ctx context.Context,
 			blobDB.Close() // close blob database here
 		}()
 	}
-	return db, blob_storage.NewBlobStore(blobDB, afero.NewBasePathFs(afero.NewOsFs(), blobDir), blobPruneDistance, beaconConfig, ethClock), nil
+	return db, blob_storage.NewBlobStore(blobDB, afero.NewBasePathFs(afero.NewOsFs(), blobDir), retention, beaconConfig, ethClock), nil
 }
 
 func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngine, config clparams.CaplinConfig,
@@ -206,12 +206,19 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	}
 	ethClock := eth_clock.NewEthereumClock(state.GenesisTime(), state.GenesisValidatorsRoot(), beaconConfig)
 
-	pruneBlobDistance := uint64(128600)
-	if config.ArchiveBlobs || config.BlobPruningDisabled {
-		pruneBlobDistance = math.MaxUint64
+	retention, warnings := config.ResolveRetention(beaconConfig)
+	for _, w := range warnings {
+		log.Warn("[Caplin] retention", "msg", w)
+	}
+	if retention.ELBlocks != 0 {
+		prune.SetBlocksFloor(retention.ELBlocks)
+	}
+	config.Retention = retention
+	if retention.DryRun {
+		log.Info("[Caplin] Pruning dry-run: nothing is deleted", "blobSidecarsSlots", retention.BlobSidecarsSlots, "beaconBlocksSlots", retention.BeaconBlocksSlots)
 	}
 
-	indexDB, blobStorage, err := OpenCaplinDatabase(ctx, beaconConfig, ethClock, dirs.CaplinIndexing, dirs.CaplinBlobs, engine, false, pruneBlobDistance)
+	indexDB, blobStorage, err := OpenCaplinDatabase(ctx, beaconConfig, ethClock, dirs.CaplinIndexing, dirs.CaplinBlobs, engine, false, retention)
 	if err != nil {
 		return err
 	}
@@ -282,7 +289,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	}
 	activeIndicies := state.GetActiveValidatorsIndices(state.Slot() / beaconConfig.SlotsPerEpoch)
 
-	sentinel, err := service.StartSentinelService(&sentinel.SentinelConfig{
+	sentinel, gossipInspector, err := service.StartSentinelService(&sentinel.SentinelConfig{
 		IpAddr:                       config.CaplinDiscoveryAddr,
 		Port:                         int(config.CaplinDiscoveryPort),
 		TCPPort:                      uint(config.CaplinDiscoveryTCPPort),
@@ -315,6 +322,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	beaconRpc := rpc.NewBeaconRpcP2P(ctx, sentinel, beaconConfig, ethClock)
 	committeeSub := committee_subscription.NewCommitteeSubscribeManagement(ctx, indexDB, beaconConfig, networkConfig, ethClock, sentinel, aggregationPool, syncedDataManager)
 	batchSignatureVerifier := services.NewBatchSignatureVerifier(ctx, sentinel)
+	batchSignatureVerifier.SetCheckInterval(config.BatchVerificationLatency)
 	// Define gossip services
 	blockService := services.NewBlockService(ctx, indexDB, forkChoice, syncedDataManager, ethClock, beaconConfig, emitters)
 	blobService := services.NewBlobSidecarService(ctx, beaconConfig, forkChoice, syncedDataManager, ethClock, emitters, false)
@@ -447,6 +455,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 			option.builderClient,
 			stateSnapshots,
 			true,
+			gossipInspector,
 		)
 		go beacon.ListenAndServe(&beacon.LayeredBeaconHandler{
 			ArchiveApi: apiHandler,
@@ -19,7 +19,6 @@ package caplincli
 import (
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -27,6 +26,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/secrets"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cmd/caplin/caplinflags"
@@ -57,6 +57,7 @@ type CaplinCliCfg struct {
 	AllowedMethods   []string `json:"allowed_methods"`
 	AllowedOrigins   []string `json:"allowed_origins"`
 	AllowCredentials bool     `json:"allow_credentials"`
+	KeymanagerToken  string   `json:"-"`
 
 	Dirs datadir.Dirs
 }
@@ -79,6 +80,7 @@ func SetupCaplinCli(ctx *cli.Context) (cfg *CaplinCliCfg, err error) {
 	cfg.AllowCredentials = ctx.Bool(utils.BeaconApiAllowCredentialsFlag.Name)
 	cfg.AllowedMethods = ctx.StringSlice(utils.BeaconApiAllowMethodsFlag.Name)
 	cfg.AllowedOrigins = ctx.StringSlice(utils.BeaconApiAllowOriginsFlag.Name)
+	cfg.KeymanagerToken = ctx.String(utils.BeaconApiKeymanagerTokenFlag.Name)
 	cfg.BeaconProtocol = "tcp"
 
 	cfg.DataDir = ctx.String(utils.DataDirFlag.Name)
@@ -118,7 +120,7 @@ func ObtainJwtSecret(ctx *cli.Context) ([]byte, error) {
 		return nil, errors.New("Missing jwt secret path")
 	}
 
-	data, err := os.ReadFile(path)
+	data, err := secrets.ReadFile(secrets.JWT, path)
 	if err != nil {
 		return nil, err
 	}
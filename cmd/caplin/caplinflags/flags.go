@@ -23,6 +23,7 @@ import (
 
 var CliFlags = []cli.Flag{
 	&utils.BeaconAPIFlag,
+	&utils.BeaconApiKeymanagerTokenFlag,
 	&BeaconApiReadTimeout,
 	&BeaconApiWriteTimeout,
 	&BeaconApiPort,
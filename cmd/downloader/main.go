@@ -80,6 +80,7 @@ func main() {
 
 var (
 	webseeds                       string
+	webseedAuth                    []string
 	datadirCli, chain              string
 	filePath                       string
 	forceRebuild                   bool
@@ -99,6 +100,11 @@ var (
 	targetFile                     string
 	disableIPV6                    bool
 	disableIPV4                    bool
+	disableUTP                     bool
+	disablePEX                     bool
+	enableDHT                      bool
+	dhtBootstrapStr                string
+	scrubRateStr                   string
 	seedbox                        bool
 	dbWritemap                     bool
 	all                            bool
@@ -111,6 +117,7 @@ func init() {
 	withChainFlag(rootCmd)
 
 	rootCmd.Flags().StringVar(&webseeds, utils.WebSeedsFlag.Name, utils.WebSeedsFlag.Value, utils.WebSeedsFlag.Usage)
+	rootCmd.Flags().StringSliceVar(&webseedAuth, utils.WebSeedAuthFlag.Name, nil, utils.WebSeedAuthFlag.Usage)
 	rootCmd.Flags().StringVar(&natSetting, "nat", utils.NATFlag.Value, utils.NATFlag.Usage)
 	rootCmd.Flags().StringVar(&downloaderApiAddr, "downloader.api.addr", "127.0.0.1:9093", "external downloader api network address, for example: 127.0.0.1:9093 serves remote downloader interface")
 	rootCmd.Flags().StringVar(&downloadRateStr, "torrent.download.rate", utils.TorrentDownloadRateFlag.Value, utils.TorrentDownloadRateFlag.Usage)
@@ -123,6 +130,11 @@ func init() {
 	rootCmd.Flags().StringVar(&staticPeersStr, utils.TorrentStaticPeersFlag.Name, utils.TorrentStaticPeersFlag.Value, utils.TorrentStaticPeersFlag.Usage)
 	rootCmd.Flags().BoolVar(&disableIPV6, "downloader.disable.ipv6", utils.DisableIPV6.Value, utils.DisableIPV6.Usage)
 	rootCmd.Flags().BoolVar(&disableIPV4, "downloader.disable.ipv4", utils.DisableIPV4.Value, utils.DisableIPV6.Usage)
+	rootCmd.Flags().BoolVar(&disableUTP, utils.TorrentDisableUTPFlag.Name, false, utils.TorrentDisableUTPFlag.Usage)
+	rootCmd.Flags().BoolVar(&disablePEX, utils.TorrentDisablePEXFlag.Name, false, utils.TorrentDisablePEXFlag.Usage)
+	rootCmd.Flags().BoolVar(&enableDHT, utils.TorrentDHTFlag.Name, false, utils.TorrentDHTFlag.Usage)
+	rootCmd.Flags().StringVar(&dhtBootstrapStr, utils.TorrentDHTBootstrapFlag.Name, utils.TorrentDHTBootstrapFlag.Value, utils.TorrentDHTBootstrapFlag.Usage)
+	rootCmd.Flags().StringVar(&scrubRateStr, utils.DownloaderScrubRateFlag.Name, utils.DownloaderScrubRateFlag.Value, utils.DownloaderScrubRateFlag.Usage)
 	rootCmd.Flags().BoolVar(&seedbox, "seedbox", false, "Turns downloader into independent (doesn't need Erigon) software which discover/download/seed new files - useful for Erigon network, and can work on very cheap hardware. It will: 1) download .torrent from webseed 2) download new files after upgrade 3) we planing add discovery of new files soon")
 	rootCmd.Flags().BoolVar(&dbWritemap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&verify, "verify", false, utils.DownloaderVerifyFlag.Usage)
@@ -147,6 +159,7 @@ func init() {
 	manifestCmd.Flags().BoolVar(&all, "all", true, "Produce all possible .torrent files")
 
 	manifestVerifyCmd.Flags().StringVar(&webseeds, utils.WebSeedsFlag.Name, utils.WebSeedsFlag.Value, utils.WebSeedsFlag.Usage)
+	manifestVerifyCmd.Flags().StringSliceVar(&webseedAuth, utils.WebSeedAuthFlag.Name, nil, utils.WebSeedAuthFlag.Usage)
 	manifestVerifyCmd.PersistentFlags().BoolVar(&verifyFailfast, "verify.failfast", false, "Stop on first found error. Report it and exit")
 	withChainFlag(manifestVerifyCmd)
 	rootCmd.AddCommand(manifestVerifyCmd)
@@ -230,7 +243,7 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 		return err
 	}
 
-	logger.Info("[snapshots] cli flags", "chain", chain, "addr", downloaderApiAddr, "datadir", dirs.DataDir, "ipv6-enabled", !disableIPV6, "ipv4-enabled", !disableIPV4, "download.rate", downloadRate.String(), "upload.rate", uploadRate.String(), "webseed", webseeds)
+	logger.Info("[snapshots] cli flags", "chain", chain, "addr", downloaderApiAddr, "datadir", dirs.DataDir, "ipv6-enabled", !disableIPV6, "ipv4-enabled", !disableIPV4, "utp-enabled", !disableUTP, "dht-enabled", enableDHT || dhtBootstrapStr != "", "download.rate", downloadRate.String(), "upload.rate", uploadRate.String(), "webseed", webseeds)
 	staticPeers := common.CliString2Array(staticPeersStr)
 
 	version := "erigon: " + params.VersionWithCommit(params.GitCommit)
@@ -250,9 +263,24 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 		return err
 	}
 
+	if cfg.WebseedAuth, err = downloadercfg.ParseWebseedAuth(webseedAuth); err != nil {
+		return err
+	}
 	cfg.ClientConfig.PieceHashersPerTorrent = dbg.EnvInt("DL_HASHERS", 32)
 	cfg.ClientConfig.DisableIPv6 = disableIPV6
-	cfg.ClientConfig.DisableIPv4 = disableIPV4
+	if err := cfg.SetTransport(downloadercfg.Transport{
+		DisableUTP:   disableUTP,
+		DisableIPv4:  disableIPV4,
+		DisableIPv6:  disableIPV6,
+		DisablePEX:   disablePEX,
+		DHT:          enableDHT,
+		DHTBootstrap: common.CliString2Array(dhtBootstrapStr),
+	}); err != nil {
+		return err
+	}
+	if err := cfg.ScrubRate.UnmarshalText([]byte(scrubRateStr)); err != nil {
+		return err
+	}
 
 	natif, err := nat.Parse(natSetting)
 	if err != nil {
@@ -494,7 +522,11 @@ func manifestVerify(ctx context.Context, logger log.Logger) error {
 		logger.Warn("file providers are not supported yet", "fileProviders", webseedFileProviders)
 	}
 
-	wseed := downloader.NewWebSeeds(webseedHttpProviders, log.LvlDebug, logger)
+	auth, err := downloadercfg.ParseWebseedAuth(webseedAuth)
+	if err != nil {
+		return err
+	}
+	wseed := downloader.NewWebSeeds(webseedHttpProviders, auth, log.LvlDebug, logger)
 	return wseed.VerifyManifestedBuckets(ctx, verifyFailfast)
 }
 
@@ -87,6 +87,14 @@ func runErigon(cliCtx *cli.Context) error {
 	erigonInfoGauge := metrics.GetOrCreateGauge(fmt.Sprintf(`erigon_info{version="%s",commit="%s"}`, params.Version, params.GitCommit))
 	erigonInfoGauge.Set(1)
 
+	chains, err := node.ChainsFromFlags(cliCtx)
+	if err != nil {
+		return err
+	}
+	if chains != nil {
+		return runChains(chains, logger, tracer)
+	}
+
 	nodeCfg, err := node.NewNodConfigUrfave(cliCtx, logger)
 	if err != nil {
 		return err
@@ -111,3 +119,15 @@ func runErigon(cliCtx *cli.Context) error {
 	}
 	return err
 }
+
+// runChains - multi-chain mode: metrics and pprof endpoints are shared, diagnostics are not available
+func runChains(chains []node.Chain, logger log.Logger, tracer *tracers.Tracer) error {
+	nodes, err := node.NewChains(chains, logger, tracer)
+	if err != nil {
+		log.Error("Erigon startup", "err", err)
+		return err
+	}
+	logger.Info("Running chains", "count", len(nodes))
+	node.ServeChains(nodes)
+	return nil
+}
@@ -36,6 +36,7 @@ var (
 	bucket                       string
 	datadirCli, toChaindata      string
 	migration                    string
+	dryRun                       bool
 	integrityFast, integritySlow bool
 	file                         string
 	HeimdallURL                  string
@@ -156,6 +157,10 @@ func withMigration(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&migration, "migration", "", "action to apply to given migration")
 }
 
+func withDryRun(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report pending migrations and size of tables they rewrite, without applying")
+}
+
 func withTxTrace(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&txtrace, "txtrace", false, "enable tracing of transactions")
 }
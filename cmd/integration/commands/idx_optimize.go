@@ -9,7 +9,6 @@ import (
 
 	"github.com/erigontech/erigon-lib/common/background"
 	"github.com/erigontech/erigon-lib/common/datadir"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/recsplit"
 	"github.com/erigontech/erigon-lib/recsplit/eliasfano32"
@@ -88,6 +87,13 @@ var idxOptimize = &cobra.Command{
 			return
 		}
 
+		// datadir has .ef files: step is persisted one or legacy default, `want` is not used
+		stepSize, err := state.GetStateAggregationStep(dirs, 0, false)
+		if err != nil {
+			logger.Error("Failed to read aggregation step", "error", err)
+			return
+		}
+
 		logger.Info("Sumarizing idx files...")
 		cEF := 0
 		for _, file := range files {
@@ -111,7 +117,7 @@ var idxOptimize = &cobra.Command{
 			logger.Info("Optimizing...", "file", file.Name(), "n", cOpt, "total", cEF)
 
 			cOpt++
-			baseTxNum := efInfo.startStep * config3.DefaultStepSize
+			baseTxNum := efInfo.startStep * stepSize
 
 			tmpDir := dirs.Tmp
 
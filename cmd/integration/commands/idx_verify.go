@@ -9,13 +9,14 @@ import (
 	"strings"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/hexutil"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/recsplit"
 	"github.com/erigontech/erigon-lib/recsplit/eliasfano32"
 	"github.com/erigontech/erigon-lib/recsplit/multiencseq"
 	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon/turbo/debug"
 	"github.com/spf13/cobra"
 )
@@ -37,6 +38,13 @@ var idxVerify = &cobra.Command{
 			return
 		}
 
+		// datadir has .ef files: step is persisted one or legacy default, `want` is not used
+		stepSize, err := state.GetStateAggregationStep(datadir.New(sourceDirCli), 0, false)
+		if err != nil {
+			logger.Error("Failed to read aggregation step", "error", err)
+			return
+		}
+
 		logger.Info("Comparing idx files:")
 	F:
 		for _, file := range files {
@@ -49,7 +57,7 @@ var idxVerify = &cobra.Command{
 				logger.Error("Failed to parse file info", "error", err)
 				return
 			}
-			baseTxNum := efInfo.startStep * config3.DefaultStepSize
+			baseTxNum := efInfo.startStep * stepSize
 			targetEFFileName := strings.Replace(file.Name(), "v1.0-", "v2.0-", 1)
 			targetEFIFileName := strings.Replace(file.Name(), "v1.0-", "v1.1-", 1)
 
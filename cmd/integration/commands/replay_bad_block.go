@@ -0,0 +1,176 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	trace_logger "github.com/erigontech/erigon/eth/tracers/logger"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var (
+	badBlockHash string
+	traceOut     string
+)
+
+var cmdReplayBadBlock = &cobra.Command{
+	Use:     "replay_bad_block",
+	Aliases: []string{"replay-bad-block"},
+	Short:   "Re-execute a block rejected by validation on top of its parent state, with opcode tracing",
+	Example: "integration replay_bad_block --datadir=<datadir> --block.hash=0x... --trace.out=trace.jsonl",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "integration")
+		db, err := openDB(dbCfg(kv.ChainDB, chaindata), true, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return err
+		}
+		defer db.Close()
+
+		out := io.Writer(os.Stdout)
+		if traceOut != "" {
+			f, err := os.Create(traceOut)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			out = f
+		}
+		return replayBadBlock(cmd.Context(), db, common.HexToHash(badBlockHash), out, logger)
+	},
+}
+
+func init() {
+	withDataDir(cmdReplayBadBlock)
+	cmdReplayBadBlock.Flags().StringVar(&badBlockHash, "block.hash", "", "hash of the bad block, see debug_getBadBlocks")
+	must(cmdReplayBadBlock.MarkFlagRequired("block.hash"))
+	cmdReplayBadBlock.Flags().StringVar(&traceOut, "trace.out", "", "file for the JSON opcode trace, stdout by default")
+	rootCmd.AddCommand(cmdReplayBadBlock)
+}
+
+// readBadBlock prefers the archive, which has the failure reason. Blocks rejected before the archive existed
+// are still readable through kv.BadHeaderNumber, unless their bodies were pruned.
+func readBadBlock(tx kv.Tx, hash common.Hash) (*rawdb.BadBlock, error) {
+	bad, err := rawdb.ReadBadBlock(tx, hash)
+	if err != nil || bad != nil {
+		return bad, err
+	}
+	number, err := rawdb.ReadBadHeaderNumber(tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if number == nil {
+		return nil, fmt.Errorf("block %x is not a known bad block", hash)
+	}
+	block := rawdb.ReadBlock(tx, hash, *number)
+	if block == nil {
+		return nil, fmt.Errorf("bad block %d %x is not archived and its body is pruned", *number, hash)
+	}
+	return &rawdb.BadBlock{Block: block}, nil
+}
+
+func replayBadBlock(ctx context.Context, db kv.TemporalRwDB, hash common.Hash, out io.Writer, logger log.Logger) error {
+	chainConfig := fromdb.ChainConfig(db)
+	blockReader, _ := blocksIO(db, logger)
+	engine, _ := initConsensusEngine(ctx, chainConfig, datadirCli, db, blockReader, logger)
+
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	bad, err := readBadBlock(tx, hash)
+	if err != nil {
+		return err
+	}
+	block := bad.Block
+	number := block.NumberU64()
+	if number == 0 {
+		return errors.New("genesis can't be replayed")
+	}
+	logger.Info("Bad block", "number", number, "hash", hash, "parentStateRoot", bad.ParentStateRoot,
+		"reason", bad.Reason, "rejectedAt", time.Unix(int64(bad.Time), 0))
+
+	// only the canonical chain has state history, so the parent must still be on it
+	parentHash, ok, err := blockReader.CanonicalHash(ctx, tx, number-1)
+	if err != nil {
+		return err
+	}
+	if !ok || parentHash != block.ParentHash() {
+		return fmt.Errorf("parent %x of the bad block is not canonical anymore, its state is unavailable", block.ParentHash())
+	}
+	parent, err := blockReader.Header(ctx, tx, parentHash, number-1)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return fmt.Errorf("parent header %d %x not found", number-1, parentHash)
+	}
+	if bad.ParentStateRoot != (common.Hash{}) && parent.Root != bad.ParentStateRoot {
+		logger.Warn("Parent state root differs from the archived one", "archived", bad.ParentStateRoot, "canonical", parent.Root)
+	}
+
+	txNums := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader))
+	parentMaxTxNum, err := txNums.Max(tx, number-1)
+	if err != nil {
+		return err
+	}
+	stateReader := state.NewHistoryReaderV3()
+	stateReader.SetTx(tx)
+	stateReader.SetTxNum(parentMaxTxNum + 1) // as of the end of the parent
+	if parentMaxTxNum+1 < stateReader.StateHistoryStartFrom() {
+		return fmt.Errorf("%w: state of block %d", state.PrunedError, number-1)
+	}
+
+	getHeader := func(hash common.Hash, number uint64) *types.Header {
+		h, _ := blockReader.Header(ctx, tx, hash, number)
+		return h
+	}
+	vmConfig := &vm.Config{Tracer: trace_logger.NewJSONLogger(&trace_logger.LogConfig{}, out).Tracer().Hooks}
+	chainReader := stagedsync.NewChainReaderImpl(chainConfig, tx, blockReader, logger)
+	res, err := core.ExecuteBlockEphemerally(chainConfig, vmConfig, core.GetHashFn(block.Header(), getHeader), engine, block,
+		stateReader, state.NewNoopWriter(), chainReader, nil, logger)
+	if err != nil {
+		logger.Warn("Replay failed", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	// state root is not recomputed: the replay doesn't write state, compare it with the archived reason instead
+	logger.Info("Replay succeeded", "number", number, "hash", hash, "gasUsed", uint64(res.GasUsed),
+		"receiptsRoot", res.ReceiptRoot, "txs", len(block.Transactions()))
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/audit"
 	kv2 "github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon-lib/kv/temporal"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -110,6 +111,11 @@ func openDB(opts kv2.MdbxOpts, applyMigrations bool, logger log.Logger) (tdb kv.
 		}
 	}
 
+	if unwind > 0 { // manual unwind
+		audit.Record(audit.WithOrigin(context.Background(), "cli"), rawDB, audit.OpUnwind,
+			map[string]any{"blocks": unwind, "every": unwindEvery, "args": os.Args[1:]}, nil, logger)
+	}
+
 	_, _, agg, _, _, _, err := allSnapshots(context.Background(), rawDB, logger)
 	if err != nil {
 		return nil, err
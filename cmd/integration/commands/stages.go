@@ -44,7 +44,6 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/downloader"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/backup"
@@ -451,6 +450,14 @@ var cmdRunMigrations = &cobra.Command{
 	Short: "",
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := debug.SetupCobra(cmd, "integration")
+		if dryRun {
+			if err := dryRunMigrations(cmd.Context(), logger); err != nil {
+				if !errors.Is(err, context.Canceled) {
+					logger.Error(err.Error())
+				}
+			}
+			return
+		}
 		//non-accede and exclusive mode - to apply create new tables if need.
 		cfg := dbCfg(kv.ChainDB, chaindata).RemoveFlags(mdbx.Accede).Exclusive(true)
 		db, err := openDB(cfg, true, logger)
@@ -463,6 +470,25 @@ var cmdRunMigrations = &cobra.Command{
 	},
 }
 
+var cmdRollbackMigration = &cobra.Command{
+	Use:   "rollback_migration",
+	Short: "Restore tables rewritten by given migration from its checkpoint and mark migration as not applied",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		cfg := dbCfg(kv.ChainDB, chaindata).Exclusive(true)
+		db, err := openDB(cfg, false, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return
+		}
+		defer db.Close()
+		if err := migrations.NewMigrator(kv.ChainDB).Rollback(db, datadirCli, migration, logger); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+	},
+}
+
 func init() {
 	withConfig(cmdPrintStages)
 	withDataDir(cmdPrintStages)
@@ -600,9 +626,17 @@ func init() {
 
 	withConfig(cmdRunMigrations)
 	withDataDir(cmdRunMigrations)
+	withDryRun(cmdRunMigrations)
 	withChain(cmdRunMigrations)
 	withHeimdall(cmdRunMigrations)
 	rootCmd.AddCommand(cmdRunMigrations)
+
+	withConfig(cmdRollbackMigration)
+	withDataDir(cmdRollbackMigration)
+	withMigration(cmdRollbackMigration)
+	withChain(cmdRollbackMigration)
+	withHeimdall(cmdRollbackMigration)
+	rootCmd.AddCommand(cmdRollbackMigration)
 }
 
 func stageSnapshots(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) error {
@@ -1143,6 +1177,9 @@ func stageCustomTrace(db kv.TemporalRwDB, ctx context.Context, logger log.Logger
 		if cfg.Produce.RCacheDomain {
 			tables = append(tables, db.Debug().DomainTables(kv.RCacheDomain)...)
 		}
+		if cfg.Produce.AccessLists {
+			tables = append(tables, db.Debug().DomainTables(kv.AccessListDomain)...)
+		}
 		if cfg.Produce.LogAddr {
 			tables = append(tables, db.Debug().InvertedIdxTables(kv.LogAddrIdx)...)
 		}
@@ -1155,6 +1192,12 @@ func stageCustomTrace(db kv.TemporalRwDB, ctx context.Context, logger log.Logger
 		if cfg.Produce.TraceTo {
 			tables = append(tables, db.Debug().InvertedIdxTables(kv.TracesToIdx)...)
 		}
+		if cfg.Produce.Appearances {
+			tables = append(tables, db.Debug().InvertedIdxTables(kv.AddrAppearanceIdx)...)
+		}
+		if cfg.Produce.Withdrawals {
+			tables = append(tables, db.Debug().InvertedIdxTables(kv.WithdrawalAddrIdx)...)
+		}
 		if err := backup.ClearTables(ctx, tx, tables...); err != nil {
 			return err
 		}
@@ -1247,10 +1290,10 @@ func stageTxLookup(db kv.TemporalRwDB, ctx context.Context, logger log.Logger) e
 }
 
 func printAllStages(db kv.RoDB, ctx context.Context, logger log.Logger) error {
-	sn, borSn, _, _, _, _, _ := allSnapshots(ctx, db, logger) // ignore error here to get some stat.
+	sn, borSn, agg, _, _, _, _ := allSnapshots(ctx, db, logger) // ignore error here to get some stat.
 	defer sn.Close()
 	defer borSn.Close()
-	return db.View(ctx, func(tx kv.Tx) error { return printStages(tx, sn, borSn) })
+	return db.View(ctx, func(tx kv.Tx) error { return printStages(tx, sn, borSn, agg) })
 }
 
 func printAppliedMigrations(db kv.RwDB, ctx context.Context, logger log.Logger) error {
@@ -1277,6 +1320,20 @@ func removeMigration(db kv.RwDB, ctx context.Context) error {
 	})
 }
 
+func dryRunMigrations(ctx context.Context, logger log.Logger) error {
+	db, err := dbCfg(kv.ChainDB, chaindata).Readonly(true).Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	report, err := migrations.NewMigrator(kv.ChainDB).DryRun(db)
+	if err != nil {
+		return err
+	}
+	report.Log(logger)
+	return nil
+}
+
 var openSnapshotOnce sync.Once
 var _allSnapshotsSingleton *freezeblocks.RoSnapshots
 var _allBorSnapshotsSingleton *heimdall.RoSnapshots
@@ -1298,6 +1355,18 @@ func allSnapshots(ctx context.Context, db kv.RoDB, logger log.Logger) (*freezebl
 			if err != nil {
 				return err
 			}
+			syncCfg.AddressAppearances, err = kvcfg.AddressAppearances.Enabled(tx)
+			if err != nil {
+				return err
+			}
+			syncCfg.AccessLists, err = kvcfg.AccessLists.Enabled(tx)
+			if err != nil {
+				return err
+			}
+			syncCfg.WithdrawalsIndex, err = kvcfg.WithdrawalsIndex.Enabled(tx)
+			if err != nil {
+				return err
+			}
 			return nil
 		}); err != nil {
 			panic(err)
@@ -1308,6 +1377,15 @@ func allSnapshots(ctx context.Context, db kv.RoDB, logger log.Logger) (*freezebl
 		if syncCfg.PersistReceiptsCacheV2 {
 			libstate.EnableHistoricalRCache()
 		}
+		if syncCfg.AddressAppearances {
+			libstate.EnableAddressAppearances()
+		}
+		if syncCfg.AccessLists {
+			libstate.EnableAccessLists()
+		}
+		if syncCfg.WithdrawalsIndex {
+			libstate.EnableWithdrawalsIndex()
+		}
 
 		dirs := datadir.New(datadirCli)
 
@@ -1321,7 +1399,12 @@ func allSnapshots(ctx context.Context, db kv.RoDB, logger log.Logger) (*freezebl
 		blockReader := freezeblocks.NewBlockReader(_allSnapshotsSingleton, _allBorSnapshotsSingleton, _heimdallStoreSingleton, _bridgeStoreSingleton)
 		txNums := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader))
 
-		_aggSingleton, err = libstate.NewAggregator(ctx, dirs, config3.DefaultStepSize, db, logger)
+		var stepSize uint64
+		stepSize, err = libstate.GetStateAggregationStep(dirs, chainConfig.GetAggregationStep(), false)
+		if err != nil {
+			return
+		}
+		_aggSingleton, err = libstate.NewAggregator(ctx, dirs, stepSize, db, logger)
 		if err != nil {
 			err = fmt.Errorf("aggregator init: %w", err)
 			return
@@ -1461,6 +1544,7 @@ func newSync(ctx context.Context, db kv.TemporalRwDB, miningConfig *params.Minin
 		chainConfig,
 		genesisBlock,
 		chainConfig.ChainID.Uint64(),
+		cfg.Prune,
 		logger,
 	)
 
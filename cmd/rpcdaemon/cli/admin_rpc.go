@@ -0,0 +1,80 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql"
+	"github.com/erigontech/erigon/node"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// startAdminRpcServer - serves cfg.AdminRpcAPI namespaces on dedicated listener with JWT authentication (like Engine API).
+// They are not served by public http/ws endpoints - so exposing public port doesn't expose admin methods.
+func startAdminRpcServer(cfg *httpcfg.HttpCfg, rpcAPI []rpc.API, logger log.Logger) (stop func(), err error) {
+	srv := rpc.NewServer(cfg.RpcBatchConcurrency, cfg.TraceRequests, cfg.DebugSingleRequest, cfg.RpcStreamingDisable, logger, cfg.RPCSlowLogThreshold)
+
+	var adminAPIList []rpc.API
+	for _, api := range rpcAPI {
+		if slices.Contains(cfg.AdminRpcAPI, api.Namespace) {
+			adminAPIList = append(adminAPIList, api)
+		}
+	}
+	if err := node.RegisterApisFromWhitelist(adminAPIList, cfg.AdminRpcAPI, srv, false, logger); err != nil {
+		return nil, fmt.Errorf("could not start register RPC admin api: %w", err)
+	}
+
+	jwtPath := cfg.AdminRpcJWTSecretPath
+	if jwtPath == "" {
+		jwtPath = cfg.JWTSecretPath
+	}
+	if jwtPath == "" {
+		jwtPath = "jwt.hex"
+	}
+	jwtSecret, err := obtainJWTSecret(jwtPath, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	wsHandler := srv.WebsocketHandler([]string{"*"}, jwtSecret, cfg.WebsocketCompression, logger)
+	httpHandler := node.NewHTTPHandlerStack(srv, nil /* cors */, cfg.AuthRpcVirtualHost, cfg.HttpCompression)
+	handler, err := createHandler(cfg, adminAPIList, httpHandler, wsHandler, graphql.CreateHandler(adminAPIList), jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("tcp://%s:%d", cfg.AdminRpcListenAddress, cfg.AdminRpcPort)
+	listener, addr, err := node.StartHTTPEndpoint(endpoint, &node.HttpEndpointConfig{Timeouts: cfg.AuthRpcTimeouts}, handler)
+	if err != nil {
+		return nil, fmt.Errorf("could not start RPC admin api: %w", err)
+	}
+	logger.Info("[rpc] admin endpoint opened", "url", addr, "api", cfg.AdminRpcAPI, "jwt", jwtPath)
+
+	return func() {
+		srv.Stop()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = listener.Shutdown(shutdownCtx)
+		logger.Info("[rpc] admin endpoint closed", "url", addr)
+	}, nil
+}
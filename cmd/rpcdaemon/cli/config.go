@@ -25,8 +25,8 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -45,7 +45,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/paths"
-	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/common/secrets"
 	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
@@ -130,6 +130,7 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().BoolVar(&polygonSync, "polygon.sync", true, "Enable if Erigon has been synced using the new polygon sync component")
 
 	rootCmd.PersistentFlags().StringVar(&cfg.RpcAllowListFilePath, utils.RpcAccessListFlag.Name, "", "Specify granular (method-by-method) API allowlist")
+	rootCmd.PersistentFlags().StringVar(&cfg.RpcAPIKeysFilePath, utils.RpcAPIKeysFlag.Name, "", utils.RpcAPIKeysFlag.Usage)
 	rootCmd.PersistentFlags().UintVar(&cfg.RpcBatchConcurrency, utils.RpcBatchConcurrencyFlag.Name, 2, utils.RpcBatchConcurrencyFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.RpcStreamingDisable, utils.RpcStreamingDisableFlag.Name, false, utils.RpcStreamingDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.DebugSingleRequest, utils.HTTPDebugSingleFlag.Name, false, utils.HTTPDebugSingleFlag.Usage)
@@ -138,6 +139,7 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().StringVar(&cfg.TxPoolApiAddr, "txpool.api.addr", "", "txpool api network address, for example: 127.0.0.1:9090 (default: use value of --private.api.addr)")
 
 	rootCmd.PersistentFlags().StringVar(&stateCacheStr, "state.cache", "0MB", "Amount of data to store in StateCache (enabled if no --datadir set). Set 0 to disable StateCache. Defaults to 0MB RAM")
+	rootCmd.PersistentFlags().StringVar(&cfg.StateCacheApiAddr, "statecache.api.addr", "", "statecache service network address, for example: 127.0.0.1:9095. If set - StateCache is shared with other rpcdaemons by this service instead of --state.cache")
 	rootCmd.PersistentFlags().BoolVar(&cfg.GRPCServerEnabled, "grpc", false, "Enable GRPC server")
 	rootCmd.PersistentFlags().StringVar(&cfg.GRPCListenAddress, "grpc.addr", nodecfg.DefaultGRPCHost, "GRPC server listening interface")
 	rootCmd.PersistentFlags().IntVar(&cfg.GRPCPort, "grpc.port", nodecfg.DefaultGRPCPort, "GRPC server listening port")
@@ -148,6 +150,10 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().StringVar(&cfg.TLSCACert, "tls.cacert", "", "CA certificate for client side TLS handshake for GRPC")
 
 	rootCmd.PersistentFlags().StringSliceVar(&cfg.API, "http.api", []string{"eth", "erigon"}, "API's offered over the RPC interface: eth,erigon,web3,net,debug,trace,txpool,db. Supported methods: https://github.com/erigontech/erigon/tree/main/cmd/rpcdaemon")
+	rootCmd.PersistentFlags().StringVar(&cfg.AdminRpcListenAddress, utils.AdminRpcAddrFlag.Name, utils.AdminRpcAddrFlag.Value, utils.AdminRpcAddrFlag.Usage)
+	rootCmd.PersistentFlags().IntVar(&cfg.AdminRpcPort, utils.AdminRpcPortFlag.Name, int(utils.AdminRpcPortFlag.Value), utils.AdminRpcPortFlag.Usage)
+	rootCmd.PersistentFlags().StringSliceVar(&cfg.AdminRpcAPI, utils.AdminRpcAPIFlag.Name, common.CliString2Array(utils.AdminRpcAPIFlag.Value), utils.AdminRpcAPIFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.AdminRpcJWTSecretPath, utils.AdminRpcJWTSecretFlag.Name, "", "Path to the token of admin HTTP-RPC server (default: jwt.hex)")
 
 	rootCmd.PersistentFlags().BoolVar(&cfg.HttpServerEnabled, "http.enabled", true, "enable http server")
 	rootCmd.PersistentFlags().StringVar(&cfg.HttpListenAddress, "http.addr", nodecfg.DefaultHTTPHost, "HTTP server listening interface")
@@ -183,6 +189,10 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().IntVar(&cfg.RpcFiltersConfig.RpcSubscriptionFiltersMaxTopics, "rpc.subscription.filters.maxtopics", rpchelper.DefaultFiltersConfig.RpcSubscriptionFiltersMaxTopics, "Maximum number of topics per subscription to filter logs by.")
 	rootCmd.PersistentFlags().IntVar(&cfg.BatchLimit, utils.RpcBatchLimit.Name, utils.RpcBatchLimit.Value, utils.RpcBatchLimit.Usage)
 	rootCmd.PersistentFlags().IntVar(&cfg.ReturnDataLimit, utils.RpcReturnDataLimit.Name, utils.RpcReturnDataLimit.Value, utils.RpcReturnDataLimit.Usage)
+	rootCmd.PersistentFlags().Uint64Var(&cfg.LogsPageLimit, utils.RpcLogsPageLimit.Name, utils.RpcLogsPageLimit.Value, utils.RpcLogsPageLimit.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.PortalURL, utils.HistoryPortalURLFlag.Name, utils.HistoryPortalURLFlag.Value, utils.HistoryPortalURLFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.PreconfFeedURL, utils.PreconfFeedURLFlag.Name, utils.PreconfFeedURLFlag.Value, utils.PreconfFeedURLFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.PreconfFeedType, utils.PreconfFeedTypeFlag.Name, utils.PreconfFeedTypeFlag.Value, utils.PreconfFeedTypeFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&cfg.AllowUnprotectedTxs, utils.AllowUnprotectedTxs.Name, utils.AllowUnprotectedTxs.Value, utils.AllowUnprotectedTxs.Usage)
 	rootCmd.PersistentFlags().Uint64Var(&cfg.OtsMaxPageSize, utils.OtsSearchMaxCapFlag.Name, utils.OtsSearchMaxCapFlag.Value, utils.OtsSearchMaxCapFlag.Usage)
 	rootCmd.PersistentFlags().DurationVar(&cfg.RPCSlowLogThreshold, utils.RPCSlowFlag.Name, utils.RPCSlowFlag.Value, utils.RPCSlowFlag.Usage)
@@ -191,6 +201,9 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	if err := rootCmd.MarkPersistentFlagFilename("rpc.accessList", "json"); err != nil {
 		panic(err)
 	}
+	if err := rootCmd.MarkPersistentFlagFilename(utils.RpcAPIKeysFlag.Name, "json"); err != nil {
+		panic(err)
+	}
 	if err := rootCmd.MarkPersistentFlagDirname("datadir"); err != nil {
 		panic(err)
 	}
@@ -235,7 +248,8 @@ type StateChangesClient interface {
 	StateChanges(ctx context.Context, in *remote.StateChangeRequest, opts ...grpc.CallOption) (remote.KV_StateChangesClient, error)
 }
 
-func subscribeToStateChangesLoop(ctx context.Context, client StateChangesClient, cache kvcache.Cache) {
+// SubscribeToStateChangesLoop - passes state changes of Erigon to cache.OnNewBlock until ctx is done, resubscribing on errors
+func SubscribeToStateChangesLoop(ctx context.Context, client StateChangesClient, cache kvcache.Cache) {
 	go func() {
 		for {
 			select {
@@ -341,7 +355,7 @@ func EmbeddedServices(ctx context.Context,
 		stateCache = kvcache.NewDummy()
 	}
 
-	subscribeToStateChangesLoop(ctx, stateDiffClient, stateCache)
+	SubscribeToStateChangesLoop(ctx, stateDiffClient, stateCache)
 
 	directClient := direct.NewEthBackendClientDirect(ethBackendServer)
 
@@ -442,6 +456,27 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 			if cfg.Sync.PersistReceiptsCacheV2 {
 				libstate.EnableHistoricalRCache()
 			}
+			cfg.Sync.AddressAppearances, err = kvcfg.AddressAppearances.Enabled(tx)
+			if err != nil {
+				return err
+			}
+			if cfg.Sync.AddressAppearances {
+				libstate.EnableAddressAppearances()
+			}
+			cfg.Sync.AccessLists, err = kvcfg.AccessLists.Enabled(tx)
+			if err != nil {
+				return err
+			}
+			if cfg.Sync.AccessLists {
+				libstate.EnableAccessLists()
+			}
+			cfg.Sync.WithdrawalsIndex, err = kvcfg.WithdrawalsIndex.Enabled(tx)
+			if err != nil {
+				return err
+			}
+			if cfg.Sync.WithdrawalsIndex {
+				libstate.EnableWithdrawalsIndex()
+			}
 			return nil
 		}); err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, err
@@ -471,7 +506,11 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 		blockReader = freezeblocks.NewBlockReader(allSnapshots, allBorSnapshots, heimdallStore, bridgeStore)
 		txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader))
 
-		agg, err := libstate.NewAggregator(ctx, cfg.Dirs, config3.DefaultStepSize, rawDB, logger)
+		stepSize, err := libstate.GetStateAggregationStep(cfg.Dirs, cc.GetAggregationStep(), false)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, err
+		}
+		agg, err := libstate.NewAggregator(ctx, cfg.Dirs, stepSize, rawDB, logger)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, fmt.Errorf("create aggregator: %w", err)
 		}
@@ -562,8 +601,15 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 		}
 		logger.Info("if you run RPCDaemon on same machine with Erigon add --datadir option")
 	}
+	if cfg.StateCacheApiAddr != "" {
+		stateCacheConn, err := grpcutil.Connect(creds, cfg.StateCacheApiAddr)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, fmt.Errorf("could not connect to statecache api: %w", err)
+		}
+		stateCache = kvcache.NewRemote(remote.NewStateCacheClient(stateCacheConn))
+	}
 
-	subscribeToStateChangesLoop(ctx, remoteKvClient, stateCache)
+	SubscribeToStateChangesLoop(ctx, remoteKvClient, stateCache)
 
 	txpoolConn := conn
 	if cfg.TxPoolApiAddr != cfg.PrivateApiAddr {
@@ -692,10 +738,19 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 }
 
 func StartRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []rpc.API, logger log.Logger) error {
+	if cfg.AdminRpcPort != 0 {
+		stop, err := startAdminRpcServer(cfg, rpcAPI, logger)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
 	if cfg.Enabled {
 		return startRegularRpcServer(ctx, cfg, rpcAPI, logger)
 	}
-
+	if cfg.AdminRpcPort != 0 {
+		<-ctx.Done()
+	}
 	return nil
 }
 
@@ -720,6 +775,11 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 		return err
 	}
 	srv.SetAllowList(allowListForRPC)
+	apiKeys, err := rpc.ReadAPIKeys(cfg.RpcAPIKeysFilePath)
+	if err != nil {
+		return err
+	}
+	srv.SetAPIKeys(apiKeys)
 
 	srv.SetBatchLimit(cfg.BatchLimit)
 
@@ -735,9 +795,14 @@ func startRegularRpcServer(ctx context.Context, cfg *httpcfg.HttpCfg, rpcAPI []r
 
 	var apiFlags []string
 	for _, flag := range cfg.API {
-		if flag != "engine" {
-			apiFlags = append(apiFlags, flag)
+		if flag == "engine" {
+			continue
+		}
+		if cfg.AdminRpcPort != 0 && slices.Contains(cfg.AdminRpcAPI, flag) {
+			logger.Warn("[rpc] namespace is served only by admin endpoint", "namespace", flag, "admin.rpc.port", cfg.AdminRpcPort)
+			continue
 		}
+		apiFlags = append(apiFlags, flag)
 	}
 
 	if err := node.RegisterApisFromWhitelist(defaultAPIList, apiFlags, srv, false, logger); err != nil {
@@ -933,28 +998,32 @@ func isWebsocket(r *http.Request) bool {
 // or from the default location. If neither of those are present, it generates
 // a new secret and stores to the default location.
 func ObtainJWTSecret(cfg *httpcfg.HttpCfg, logger log.Logger) ([]byte, error) {
-	// try reading from file
-	logger.Info("Reading JWT secret", "path", cfg.JWTSecretPath)
 	// If we run the rpcdaemon and datadir is not specified we just use jwt.hex in current directory.
 	if len(cfg.JWTSecretPath) == 0 {
 		cfg.JWTSecretPath = "jwt.hex"
 	}
-	if data, err := os.ReadFile(cfg.JWTSecretPath); err == nil {
+	return obtainJWTSecret(cfg.JWTSecretPath, logger)
+}
+
+func obtainJWTSecret(path string, logger log.Logger) ([]byte, error) {
+	// try reading from file
+	logger.Info("Reading JWT secret", "path", path)
+	if data, err := secrets.ReadFile(secrets.JWT, path); err == nil {
 		jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
 		if len(jwtSecret) == 32 {
 			return jwtSecret, nil
 		}
-		logger.Error("Invalid JWT secret", "path", cfg.JWTSecretPath, "length", len(jwtSecret))
+		logger.Error("Invalid JWT secret", "path", path, "length", len(jwtSecret))
 		return nil, errors.New("invalid JWT secret")
 	}
 	// Need to generate one
 	jwtSecret := make([]byte, 32)
 	rand.Read(jwtSecret)
 
-	if err := os.WriteFile(cfg.JWTSecretPath, []byte(hexutil.Encode(jwtSecret)), 0600); err != nil {
+	if err := secrets.WriteFile(secrets.JWT, path, []byte(hexutil.Encode(jwtSecret))); err != nil {
 		return nil, err
 	}
-	logger.Info("Generated JWT secret", "path", cfg.JWTSecretPath)
+	logger.Info("Generated JWT secret", "path", path)
 	return jwtSecret, nil
 }
 
@@ -1169,6 +1238,14 @@ func (e *remoteConsensusEngine) GetPostApplyMessageFunc() evmtypes.PostApplyMess
 	return e.engine.GetPostApplyMessageFunc()
 }
 
+func (e *remoteConsensusEngine) GetL1CostFunc() evmtypes.L1CostFunc {
+	if err := e.validateEngineReady(); err != nil {
+		panic(err)
+	}
+
+	return e.engine.GetL1CostFunc()
+}
+
 func (e *remoteConsensusEngine) VerifyHeader(_ consensus.ChainHeaderReader, _ *types.Header, _ bool) error {
 	panic("remoteConsensusEngine.VerifyHeader not supported")
 }
@@ -57,6 +57,12 @@ type HttpCfg struct {
 	AuthRpcPort    int
 	PrivateApiAddr string
 
+	// Admin endpoint: AdminRpcAPI namespaces are served only there, with JWT authentication. 0 port - disabled
+	AdminRpcListenAddress string
+	AdminRpcPort          int
+	AdminRpcAPI           []string
+	AdminRpcJWTSecretPath string
+
 	API                               []string
 	Gascap                            uint64
 	Feecap                            float64
@@ -66,6 +72,7 @@ type HttpCfg struct {
 	WebsocketCompression              bool
 	WebsocketSubscribeLogsChannelSize int
 	RpcAllowListFilePath              string
+	RpcAPIKeysFilePath                string
 	RpcBatchConcurrency               uint
 	RpcStreamingDisable               bool
 	RpcFiltersConfig                  rpchelper.FiltersConfig
@@ -73,6 +80,7 @@ type HttpCfg struct {
 	TraceCompatibility                bool // Bug for bug compatibility for trace_ routines with OpenEthereum
 	TxPoolApiAddr                     string
 	StateCache                        kvcache.CoherentConfig
+	StateCacheApiAddr                 string
 	Snap                              ethconfig.BlocksFreezing
 	Sync                              ethconfig.Sync
 
@@ -98,10 +106,14 @@ type HttpCfg struct {
 	LogDirVerbosity string
 	LogDirPath      string
 
-	BatchLimit                  int  // Maximum number of requests in a batch
-	ReturnDataLimit             int  // Maximum number of bytes returned from calls (like eth_call)
-	AllowUnprotectedTxs         bool // Whether to allow non EIP-155 protected transactions  txs over RPC
-	MaxGetProofRewindBlockCount int  //Max GetProof rewind block count
+	BatchLimit                  int    // Maximum number of requests in a batch
+	ReturnDataLimit             int    // Maximum number of bytes returned from calls (like eth_call)
+	AllowUnprotectedTxs         bool   // Whether to allow non EIP-155 protected transactions  txs over RPC
+	MaxGetProofRewindBlockCount int    //Max GetProof rewind block count
+	LogsPageLimit               uint64 // Maximum number of logs in one page of erigon_getLogsPaged
+	PortalURL                   string // Portal Network client - source of expired (EIP-4444) blocks
+	PreconfFeedURL              string // rollup sequencer feed - source of pre-confirmed transactions
+	PreconfFeedType             string // format of sequencer feed: arbitrum, flashblocks
 	// Ots API
 	OtsMaxPageSize uint64
 
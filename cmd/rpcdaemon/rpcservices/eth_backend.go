@@ -428,6 +428,40 @@ func (back *RemoteBackend) AddPeer(ctx context.Context, request *remote.AddPeerR
 	return result, nil
 }
 
+func (back *RemoteBackend) BanPeer(ctx context.Context, request *remote.BanPeerRequest) (*remote.BanPeerReply, error) {
+	result, err := back.remoteEthBackend.BanPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.BanPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) UnbanPeer(ctx context.Context, request *remote.UnbanPeerRequest) (*remote.UnbanPeerReply, error) {
+	result, err := back.remoteEthBackend.UnbanPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.UnbanPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) BannedPeers(ctx context.Context) ([]*p2p.BannedPeerInfo, error) {
+	rpcPeers, err := back.remoteEthBackend.BannedPeers(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.BannedPeers() error: %w", err)
+	}
+
+	peers := make([]*p2p.BannedPeerInfo, 0, len(rpcPeers.Peers))
+	for _, rpcPeer := range rpcPeers.Peers {
+		peers = append(peers, &p2p.BannedPeerInfo{
+			ID:          rpcPeer.Id,
+			Penalties:   rpcPeer.Penalties,
+			BannedUntil: rpcPeer.BannedUntil,
+		})
+	}
+
+	return peers, nil
+}
+
 func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 	rpcPeers, err := back.remoteEthBackend.Peers(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -456,6 +490,7 @@ func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 				Trusted:       rpcPeer.ConnIsTrusted,
 				Static:        rpcPeer.ConnIsStatic,
 			},
+			Penalties: rpcPeer.Penalties,
 			Protocols: nil,
 		}
 
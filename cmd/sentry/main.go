@@ -17,6 +17,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/erigontech/erigon-lib/common/paths"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	"github.com/erigontech/erigon/cmd/utils"
 	"github.com/erigontech/erigon/p2p/sentry"
 	"github.com/erigontech/erigon/turbo/debug"
@@ -44,13 +46,21 @@ var (
 	trustedPeers []string // trusted peers
 	discoveryDNS []string
 	nodiscover   bool // disable sentry's discovery mechanism
+	discoveryV4  bool // enable V4 discovery
+	discoveryV5  bool // enable V5 discovery
 	protocol     uint
 	allowedPorts []uint
+	quicPort     int
 	netRestrict  string // CIDR to restrict peering to
 	maxPeers     int
 	maxPendPeers int
 	healthCheck  bool
 	metrics      bool
+
+	tlsCertFile string
+	tlsKeyFile  string
+	tlsCACert   string
+	tlsACL      string
 )
 
 func init() {
@@ -64,13 +74,20 @@ func init() {
 	rootCmd.Flags().StringSliceVar(&trustedPeers, utils.TrustedPeersFlag.Name, []string{}, utils.TrustedPeersFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&discoveryDNS, utils.DNSDiscoveryFlag.Name, []string{}, utils.DNSDiscoveryFlag.Usage)
 	rootCmd.Flags().BoolVar(&nodiscover, utils.NoDiscoverFlag.Name, false, utils.NoDiscoverFlag.Usage)
+	rootCmd.Flags().BoolVar(&discoveryV4, utils.DiscoveryV4Flag.Name, utils.DiscoveryV4Flag.Value, utils.DiscoveryV4Flag.Usage)
+	rootCmd.Flags().BoolVar(&discoveryV5, utils.DiscoveryV5Flag.Name, utils.DiscoveryV5Flag.Value, utils.DiscoveryV5Flag.Usage)
 	rootCmd.Flags().UintVar(&protocol, utils.P2pProtocolVersionFlag.Name, utils.P2pProtocolVersionFlag.Value.Value()[0], utils.P2pProtocolVersionFlag.Usage)
 	rootCmd.Flags().UintSliceVar(&allowedPorts, utils.P2pProtocolAllowedPorts.Name, utils.P2pProtocolAllowedPorts.Value.Value(), utils.P2pProtocolAllowedPorts.Usage)
+	rootCmd.Flags().IntVar(&quicPort, utils.P2pQUICPortFlag.Name, 0, utils.P2pQUICPortFlag.Usage)
 	rootCmd.Flags().StringVar(&netRestrict, utils.NetrestrictFlag.Name, utils.NetrestrictFlag.Value, utils.NetrestrictFlag.Usage)
 	rootCmd.Flags().IntVar(&maxPeers, utils.MaxPeersFlag.Name, utils.MaxPeersFlag.Value, utils.MaxPeersFlag.Usage)
 	rootCmd.Flags().IntVar(&maxPendPeers, utils.MaxPendingPeersFlag.Name, utils.MaxPendingPeersFlag.Value, utils.MaxPendingPeersFlag.Usage)
 	rootCmd.Flags().BoolVar(&healthCheck, utils.HealthCheckFlag.Name, false, utils.HealthCheckFlag.Usage)
 	rootCmd.Flags().BoolVar(&metrics, utils.MetricsEnabledFlag.Name, false, utils.MetricsEnabledFlag.Usage)
+	rootCmd.Flags().StringVar(&tlsCertFile, "tls.cert", "", "certificate of sentry.api.addr server")
+	rootCmd.Flags().StringVar(&tlsKeyFile, "tls.key", "", "key file of sentry.api.addr server")
+	rootCmd.Flags().StringVar(&tlsCACert, "tls.cacert", "", "CA certificate to verify clients certificates")
+	rootCmd.Flags().StringVar(&tlsACL, "tls.acl", "", "roles of clients by common name of their certificates: erigon=admin,monitoring=read. Requires --tls.cacert")
 
 	if err := rootCmd.MarkFlagDirname(utils.DataDirFlag.Name); err != nil {
 		panic(err)
@@ -108,9 +125,24 @@ var rootCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		p2pConfig.NoDiscoveryV4 = !discoveryV4
+		p2pConfig.DiscoveryV5 = discoveryV5
+		p2pConfig.QUICPort = quicPort
+
+		creds, err := grpcutil.TLS(tlsCACert, tlsCertFile, tlsKeyFile)
+		if err != nil {
+			return err
+		}
+		acl, err := grpcutil.ParseACL(tlsACL)
+		if err != nil {
+			return err
+		}
+		if acl != nil && tlsCACert == "" {
+			return errors.New("--tls.acl requires --tls.cacert: client certificates must be verified")
+		}
 
 		logger := debug.SetupCobra(cmd, "sentry")
-		return sentry.Sentry(cmd.Context(), dirs, sentryAddr, discoveryDNS, p2pConfig, protocol, healthCheck, logger)
+		return sentry.Sentry(cmd.Context(), dirs, sentryAddr, discoveryDNS, p2pConfig, protocol, creds, acl, healthCheck, logger)
 	},
 }
 
@@ -37,7 +37,6 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	datadir2 "github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/debug"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
@@ -436,7 +435,11 @@ func OpcodeTracer(genesis *types.Genesis, blockNum uint64, chaindata string, num
 	rawChainDb := mdbx.MustOpen(dirs.Chaindata)
 	defer rawChainDb.Close()
 
-	agg, err := state2.NewAggregator(context.Background(), dirs, config3.DefaultStepSize, rawChainDb, log.New())
+	stepSize, err := state2.GetStateAggregationStep(dirs, genesis.Config.GetAggregationStep(), false)
+	if err != nil {
+		return err
+	}
+	agg, err := state2.NewAggregator(context.Background(), dirs, stepSize, rawChainDb, log.New())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,156 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/logging"
+)
+
+var (
+	privateApiAddr    string
+	stateCacheApiAddr string
+	stateCacheStr     string
+
+	TLSCertfile string
+	TLSCACert   string
+	TLSKeyFile  string
+)
+
+func init() {
+	utils.CobraFlags(rootCmd, debug.Flags, utils.MetricFlags, logging.Flags)
+	rootCmd.Flags().StringVar(&privateApiAddr, "private.api.addr", "localhost:9090", "execution service <host>:<port>")
+	rootCmd.Flags().StringVar(&stateCacheApiAddr, "statecache.api.addr", "localhost:9095", "statecache service <host>:<port>")
+	rootCmd.Flags().StringVar(&stateCacheStr, "state.cache", "1GB", "Amount of data to store in StateCache (same amount for code)")
+	rootCmd.PersistentFlags().StringVar(&TLSCertfile, "tls.cert", "", "certificate for client side TLS handshake")
+	rootCmd.PersistentFlags().StringVar(&TLSKeyFile, "tls.key", "", "key file for client side TLS handshake")
+	rootCmd.PersistentFlags().StringVar(&TLSCACert, "tls.cacert", "", "CA certificate for client side TLS handshake")
+}
+
+var rootCmd = &cobra.Command{
+	Use:   "statecache",
+	Short: "Launch StateCache shared by many rpcdaemons (--statecache.api.addr) - invalidated by state changes of Erigon",
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		debug.Exit()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "statecache")
+		if err := doStateCache(cmd.Context(), logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func doStateCache(ctx context.Context, logger log.Logger) error {
+	cacheConfig := kvcache.DefaultCoherentConfig
+	cacheConfig.MetricsLabel = "statecache"
+	// nobody waits for views here: rpcdaemons read db on miss
+	cacheConfig.WaitForNewBlock = false
+	if err := cacheConfig.CacheSize.UnmarshalText([]byte(stateCacheStr)); err != nil {
+		return fmt.Errorf("state.cache value of %v is not valid", stateCacheStr)
+	}
+	cacheConfig.CodeCacheSize = cacheConfig.CacheSize
+
+	creds, err := grpcutil.TLS(TLSCACert, TLSCertfile, TLSKeyFile)
+	if err != nil {
+		return fmt.Errorf("could not connect to remoteKv: %w", err)
+	}
+	coreConn, err := grpcutil.Connect(creds, privateApiAddr)
+	if err != nil {
+		return fmt.Errorf("could not connect to remoteKv: %w", err)
+	}
+
+	cache := kvcache.New(cacheConfig)
+	cli.SubscribeToStateChangesLoop(ctx, remote.NewKVClient(coreConn), cache)
+
+	grpcServer, err := startGrpc(kvcache.NewStateCacheServer(cache), stateCacheApiAddr, logger)
+	if err != nil {
+		return err
+	}
+	<-ctx.Done()
+	grpcServer.GracefulStop()
+	return ctx.Err()
+}
+
+func startGrpc(stateCacheServer remote.StateCacheServer, addr string, logger log.Logger) (*grpc.Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("could not create listener: %w, addr=%s", err, addr)
+	}
+
+	opts := []grpc.ServerOption{
+		// Don't drop the connection, settings accordign to this comment on GitHub
+		// https://github.com/grpc/grpc-go/issues/3171#issuecomment-552796779
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             10 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(grpc_recovery.StreamServerInterceptor())),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(grpc_recovery.UnaryServerInterceptor())),
+	}
+	grpcServer := grpc.NewServer(opts...)
+	reflection.Register(grpcServer) // Register reflection service on gRPC server.
+	remote.RegisterStateCacheServer(grpcServer, stateCacheServer)
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+
+	go func() {
+		defer healthServer.Shutdown()
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Error("statecache gRPC server fail", "err", err)
+		}
+	}()
+	logger.Info("Started gRPC server", "on", addr)
+	return grpcServer, nil
+}
+
+func main() {
+	ctx, cancel := common.RootContext()
+	defer cancel()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
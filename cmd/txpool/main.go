@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/erigontech/erigon/turbo/privateapi"
 
@@ -58,6 +59,7 @@ var (
 	TLSCertfile string
 	TLSCACert   string
 	TLSKeyFile  string
+	TLSACL      string
 
 	pendingPoolLimit int
 	baseFeePoolLimit int
@@ -70,7 +72,10 @@ var (
 	priceBump          uint64
 	blobPriceBump      uint64
 
-	noTxGossip bool
+	noTxGossip     bool
+	announceRatio  float64
+	broadcastRatio float64
+	rejectListFile string
 
 	mdbxWriteMap bool
 
@@ -89,6 +94,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&TLSCertfile, "tls.cert", "", "certificate for client side TLS handshake")
 	rootCmd.PersistentFlags().StringVar(&TLSKeyFile, "tls.key", "", "key file for client side TLS handshake")
 	rootCmd.PersistentFlags().StringVar(&TLSCACert, "tls.cacert", "", "CA certificate for client side TLS handshake")
+	rootCmd.PersistentFlags().StringVar(&TLSACL, "tls.acl", "", "serve txpool.api.addr by TLS, with roles of clients by common name of their certificates: rpcdaemon=read,erigon=admin. Requires --tls.cacert")
 
 	rootCmd.PersistentFlags().IntVar(&pendingPoolLimit, "txpool.globalslots", txpoolcfg.DefaultConfig.PendingSubPoolLimit, "Maximum number of executable transaction slots for all accounts")
 	rootCmd.PersistentFlags().IntVar(&baseFeePoolLimit, "txpool.globalbasefeeslots", txpoolcfg.DefaultConfig.BaseFeeSubPoolLimit, "Maximum number of non-executable transactions where only not enough baseFee")
@@ -101,6 +107,9 @@ func init() {
 	rootCmd.PersistentFlags().Uint64Var(&blobPriceBump, "txpool.blobpricebump", txpoolcfg.DefaultConfig.BlobPriceBump, "Price bump percentage to replace an existing blob (type-3) transaction")
 	rootCmd.PersistentFlags().DurationVar(&commitEvery, utils.TxPoolCommitEveryFlag.Name, utils.TxPoolCommitEveryFlag.Value, utils.TxPoolCommitEveryFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
+	rootCmd.PersistentFlags().Float64Var(&announceRatio, utils.TxPoolAnnounceRatioFlag.Name, utils.TxPoolAnnounceRatioFlag.Value, utils.TxPoolAnnounceRatioFlag.Usage)
+	rootCmd.PersistentFlags().Float64Var(&broadcastRatio, utils.TxPoolBroadcastRatioFlag.Name, utils.TxPoolBroadcastRatioFlag.Value, utils.TxPoolBroadcastRatioFlag.Usage)
+	rootCmd.PersistentFlags().StringVar(&rejectListFile, utils.TxPoolRejectListFlag.Name, "", utils.TxPoolRejectListFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&mdbxWriteMap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
 }
@@ -171,6 +180,9 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	cfg.PriceBump = priceBump
 	cfg.BlobPriceBump = blobPriceBump
 	cfg.NoGossip = noTxGossip
+	cfg.AnnounceRatio = announceRatio
+	cfg.BroadcastRatio = broadcastRatio
+	cfg.RejectListFile = rejectListFile
 	cfg.MdbxWriteMap = mdbxWriteMap
 
 	cacheConfig := kvcache.DefaultCoherentConfig
@@ -199,7 +211,18 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	}
 
 	miningGrpcServer := privateapi.NewMiningServer(ctx, &rpcdaemontest.IsMiningMock{}, nil, logger)
-	grpcServer, err := txpool.StartGrpc(txpoolGrpcServer, miningGrpcServer, txpoolApiAddr, nil, logger)
+	acl, err := grpcutil.ParseACL(TLSACL)
+	if err != nil {
+		return err
+	}
+	var serverCreds *credentials.TransportCredentials
+	if acl != nil {
+		if TLSCACert == "" {
+			return errors.New("--tls.acl requires --tls.cacert: client certificates must be verified")
+		}
+		serverCreds = &creds
+	}
+	grpcServer, err := txpool.StartGrpc(txpoolGrpcServer, miningGrpcServer, txpoolApiAddr, serverCreds, acl, logger)
 	if err != nil {
 		return err
 	}
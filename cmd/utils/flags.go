@@ -44,6 +44,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/metrics"
 	"github.com/erigontech/erigon-lib/common/paths"
+	"github.com/erigontech/erigon-lib/common/secrets"
 	"github.com/erigontech/erigon-lib/crypto"
 	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/direct"
@@ -85,6 +86,10 @@ var (
 		Value: flags.DirectoryString(paths.DefaultDataDir()),
 	}
 
+	DataDirSnapFlag = flags.DirectoryFlag{
+		Name:  "datadir.snap",
+		Usage: "Data directory for snapshots (default = inside datadir). To move existing snapshots use: erigon snapshots relocate",
+	}
 	AncientFlag = flags.DirectoryFlag{
 		Name:  "datadir.ancient",
 		Usage: "Data directory for ancient chain segments (default = inside chaindata)",
@@ -103,6 +108,36 @@ var (
 		Usage: "To store receipts in chaindata db (only on chain-tip) - RPC for recent receipts/logs will be faster. Values: 1_000 good starting point. 10_000 receipts it's ~1Gb (not much IO increase). Please test before go over 100_000",
 		Value: ethconfig.Defaults.PersistReceiptsCacheV2,
 	}
+	AddressAppearancesFlag = cli.BoolFlag{
+		Name:  "experiment.address.appearances",
+		Usage: "Maintain index of all appearances of address (calls, logs, rewards, withdrawals) - for erigon_getAddressAppearances. Covers only blocks executed by this node (not blocks downloaded as snapshots). Can't be changed after first start",
+		Value: ethconfig.Defaults.AddressAppearances,
+	}
+	AccessListsFlag = cli.BoolFlag{
+		Name:  "experiment.access.lists",
+		Usage: "Record EIP-2930 access list (accessed addresses and storage slots) of every executed txn - for erigon_getTransactionAccessList. Covers only blocks executed by this node (not blocks downloaded as snapshots). Can't be changed after first start",
+		Value: ethconfig.Defaults.AccessLists,
+	}
+	WithdrawalsIndexFlag = cli.BoolFlag{
+		Name:  "experiment.withdrawals.index",
+		Usage: "Maintain index of withdrawals by execution-layer address - for erigon_getWithdrawalsByAddress. Covers only blocks executed by this node (not blocks downloaded as snapshots). Can't be changed after first start",
+		Value: ethconfig.Defaults.WithdrawalsIndex,
+	}
+	HeadersMMRFlag = cli.BoolFlag{
+		Name:  "experiment.headers.mmr",
+		Usage: "Maintain Merkle Mountain Range over frozen headers (snapshots/accessor/headers.mmr) - for erigon_getHeaderProof of historical headers",
+		Value: ethconfig.Defaults.HeadersMMR,
+	}
+	HistoryExpiryFlag = cli.BoolFlag{
+		Name:  "history.expiry",
+		Usage: "EIP-4444: drop transactions of pre-merge blocks which are already exported to <datadir>/era1 (see `erigon snapshots export-era1`). RPC serves them from era1 files and --history.portal.url",
+		Value: ethconfig.Defaults.HistoryExpiry,
+	}
+	ExecPrefetchFlag = cli.BoolFlag{
+		Name:  "exec.prefetch",
+		Usage: "Before execution of a block concurrently read state it will likely need (accounts of senders and receivers, code, storage slots derived from calldata). Helps on NVMe drives, which serve much more reads at deep queue depths",
+		Value: ethconfig.Defaults.ExecPrefetch,
+	}
 	DeveloperPeriodFlag = cli.IntFlag{
 		Name:  "dev.period",
 		Usage: "Block period to use in developer mode (0 = mine only if transaction pending)",
@@ -124,6 +159,14 @@ var (
 		Name:  "override.prague",
 		Usage: "Manually specify the Prague fork time, overriding the bundled setting",
 	}
+	OverrideFileFlag = cli.StringFlag{
+		Name:  "override.file",
+		Usage: "JSON file with fork timestamps overriding chain config: {\"pragueTime\": 1740434112, \"osakaTime\": ...}. Re-applied on SIGHUP (only forks which are not active yet)",
+	}
+	CustomChainsDirFlag = cli.StringFlag{
+		Name:  "chain.custom-dir",
+		Usage: "Directory with custom chain specs: <dir>/<name>/genesis.json (and optional Caplin config.yaml, genesis.ssz). Then use --chain=<name>",
+	}
 	TrustedSetupFile = cli.StringFlag{
 		Name:  "trusted-setup-file",
 		Usage: "Absolute path to trusted_setup.json file",
@@ -162,6 +205,16 @@ var (
 		Usage: "Disabling p2p gossip of txs. Any txs received by p2p - will be dropped. Some networks like 'Optimism execution engine'/'Optimistic Rollup' - using it to protect against MEV attacks",
 		Value: txpoolcfg.DefaultConfig.NoGossip,
 	}
+	TxPoolAnnounceRatioFlag = cli.Float64Flag{
+		Name:  "txpool.announce.ratio",
+		Usage: "New transactions hashes are announced to ratio*sqrt(peers) peers",
+		Value: txpoolcfg.DefaultConfig.AnnounceRatio,
+	}
+	TxPoolBroadcastRatioFlag = cli.Float64Flag{
+		Name:  "txpool.broadcast.ratio",
+		Usage: "New transactions are pushed in full to ratio*sqrt(peers) peers, 0 to only announce them (local transactions are always pushed)",
+		Value: txpoolcfg.DefaultConfig.BroadcastRatio,
+	}
 	TxPoolPriceLimitFlag = cli.Uint64Flag{
 		Name:  "txpool.pricelimit",
 		Usage: "Minimum gas price (fee cap) limit to enforce for acceptance into the pool",
@@ -177,6 +230,10 @@ var (
 		Usage: "Price bump percentage to replace existing (type-3) blob transaction",
 		Value: txpoolcfg.DefaultConfig.BlobPriceBump,
 	}
+	TxPoolRejectListFlag = cli.StringFlag{
+		Name:  "txpool.rejectlist",
+		Usage: "JSON file with calldata selectors and calldata/initcode regexps of txns to reject: {\"selectors\": [\"0xa9059cbb\"], \"calldata\": [], \"initcode\": []}. Re-read on change",
+	}
 	TxPoolAccountSlotsFlag = cli.Uint64Flag{
 		Name:  "txpool.accountslots",
 		Usage: "Minimum number of executable transaction slots guaranteed per account",
@@ -287,6 +344,26 @@ var (
 		Usage: "Reporting URL of a ethstats service (nodename:secret@host:port)",
 		Value: "",
 	}
+	StreamURLFlag = cli.StringFlag{
+		Name:  "stream.url",
+		Usage: "Stream canonical headers, receipts, state diffs and re-org tombstones to Kafka or NATS. Examples: kafka://broker1:9092,broker2:9092 nats://localhost:4222",
+		Value: "",
+	}
+	StreamFormatFlag = cli.StringFlag{
+		Name:  "stream.format",
+		Usage: "Serialization of streamed messages: json, protobuf",
+		Value: "json",
+	}
+	StreamTopicPrefixFlag = cli.StringFlag{
+		Name:  "stream.topic.prefix",
+		Usage: "Streamed topics (Kafka) or subjects (NATS) are: <prefix>.headers, <prefix>.receipts, <prefix>.statediffs",
+		Value: "erigon",
+	}
+	StreamFromBlockFlag = cli.Uint64Flag{
+		Name:  "stream.fromblock",
+		Usage: "Block to start streaming from. Used only on first start - then streaming continues from last published block",
+		Value: 0,
+	}
 	FakePoWFlag = cli.BoolFlag{
 		Name:  "fakepow",
 		Usage: "Disables proof-of-work verification",
@@ -341,6 +418,26 @@ var (
 		Usage: "Path to the token that ensures safe connection between CL and EL",
 		Value: "",
 	}
+	AdminRpcAddrFlag = cli.StringFlag{
+		Name:  "admin.rpc.addr",
+		Usage: "HTTP-RPC server listening interface for admin namespaces",
+		Value: nodecfg.DefaultHTTPHost,
+	}
+	AdminRpcPortFlag = cli.UintFlag{
+		Name:  "admin.rpc.port",
+		Usage: "HTTP-RPC server listening port for admin namespaces (--admin.rpc.api), requires JWT authentication. They are not served by public --http.api then. 0 - disabled",
+		Value: 0,
+	}
+	AdminRpcAPIFlag = cli.StringFlag{
+		Name:  "admin.rpc.api",
+		Usage: "Namespaces served only by --admin.rpc.port",
+		Value: "admin,debug",
+	}
+	AdminRpcJWTSecretFlag = cli.StringFlag{
+		Name:  "admin.rpc.jwtsecret",
+		Usage: "Path to the token of admin HTTP-RPC server (default: --authrpc.jwtsecret)",
+		Value: "",
+	}
 
 	HttpCompressionFlag = cli.BoolFlag{
 		Name:  "http.compression",
@@ -389,6 +486,26 @@ var (
 		Usage: "Maximum number of bytes returned from eth_call or similar invocations",
 		Value: 100_000,
 	}
+	HistoryPortalURLFlag = cli.StringFlag{
+		Name:  "history.portal.url",
+		Usage: "JSON-RPC endpoint of Portal Network client (history subnetwork) - to serve blocks which are not in this node (--history.expiry) and not in <datadir>/era1",
+		Value: "",
+	}
+	PreconfFeedURLFlag = cli.StringFlag{
+		Name:  "preconf.feed.url",
+		Usage: "Websocket URL of rollup sequencer feed. Its pre-confirmed transactions are served by eth_subscribe(\"preconfirmedTransactions\") and \"preconfirmed\" block tag",
+		Value: "",
+	}
+	PreconfFeedTypeFlag = cli.StringFlag{
+		Name:  "preconf.feed.type",
+		Usage: "Format of --preconf.feed.url: arbitrum (sequencer feed), flashblocks (OP Stack)",
+		Value: "arbitrum",
+	}
+	RpcLogsPageLimit = cli.Uint64Flag{
+		Name:  "rpc.logs.page.limit",
+		Usage: "Maximum number of logs in one page of erigon_getLogsPaged",
+		Value: 10_000,
+	}
 	HTTPTraceFlag = cli.BoolFlag{
 		Name:  "http.trace",
 		Usage: "Print all HTTP requests to logs with INFO level",
@@ -406,6 +523,10 @@ var (
 		Name:  "rpc.accessList",
 		Usage: "Specify granular (method-by-method) API allowlist",
 	}
+	RpcAPIKeysFlag = cli.StringFlag{
+		Name:  "rpc.apikeys",
+		Usage: "Json file with api keys of clients and their methods allowlists. HTTP and WS clients must send key by header X-API-Key or url parameter ?apikey=",
+	}
 
 	RpcGasCapFlag = cli.UintFlag{
 		Name:  "rpc.gascap",
@@ -530,6 +651,14 @@ var (
 		Usage: "Allowed ports to pick for different eth p2p protocol versions as follows <porta>,<portb>,..,<porti>",
 		Value: cli.NewUintSlice(uint(ListenPortFlag.Value), 30304, 30305, 30306, 30307),
 	}
+	P2pQUICPortFlag = cli.IntFlag{
+		Name:  "p2p.quic.port",
+		Usage: "Experimental: UDP port to also accept RLPx connections over QUIC on, and to dial the peers which advertise one over QUIC (0 = disabled). With several eth p2p protocols, the following ports are used for the others",
+	}
+	P2pServeSnapFlag = cli.BoolFlag{
+		Name:  "p2p.snap",
+		Usage: "Serve the snap/1 protocol alongside eth, so that other clients can snap-sync from the latest state of this node",
+	}
 	SentryAddrFlag = cli.StringFlag{
 		Name:  "sentry.api.addr",
 		Usage: "Comma separated sentry addresses '<host>:<port>,<host>:<port>'",
@@ -565,12 +694,22 @@ var (
 		Name:  "nodekeyhex",
 		Usage: "P2P node key as hex (for testing)",
 	}
+	SecretsEncryptFlag = cli.StringFlag{
+		Name:  "secrets.encrypt",
+		Usage: "Comma separated list of secrets to encrypt at rest: nodekey,jwt. Only secrets generated by Erigon are encrypted, existing files are not modified. External Consensus Layer can't read encrypted jwt",
+	}
+	SecretsPasswordFileFlag = cli.StringFlag{
+		Name:  "secrets.password.file",
+		Usage: "File with password of encrypted secrets (default: env " + secrets.PasswordEnv + ", or OS keyring - password is generated on first use)",
+	}
 	NATFlag = cli.StringFlag{
 		Name: "nat",
-		Usage: `NAT port mapping mechanism (any|none|upnp|pmp|stun|extip:<IP>)
+		Usage: `NAT port mapping mechanism (any|auto|none|upnp|pmp|stun|extip:<IP>)
 			 "" or "none"         Default - do not nat
 			 "extip:77.12.33.4"   Will assume the local machine is reachable on the given IP
 			 "any"                Uses the first auto-detected mechanism
+			 "auto"               Maps ports with UPnP or NAT-PMP, and detects the external IP with STUN if needed
+			 "auto:<server>"      Same as "auto" using the given STUN server (host:port)
 			 "upnp"               Uses the Universal Plug and Play protocol
 			 "pmp"                Uses NAT-PMP with an auto-detected gateway address
 			 "pmp:192.168.0.1"    Uses NAT-PMP with the given gateway address
@@ -583,9 +722,15 @@ var (
 		Name:  "nodiscover",
 		Usage: "Disables the peer discovery mechanism (manual peer addition)",
 	}
+	DiscoveryV4Flag = cli.BoolFlag{
+		Name:  "discovery.v4",
+		Usage: "Enables the V4 discovery mechanism",
+		Value: true,
+	}
 	DiscoveryV5Flag = cli.BoolFlag{
-		Name:  "v5disc",
-		Usage: "Enables the experimental RLPx V5 (Topic Discovery) mechanism",
+		Name:    "discovery.v5",
+		Aliases: []string{"v5disc"},
+		Usage:   "Enables the V5 discovery mechanism, nodes are selected by the eth fork ID advertised in their node record",
 	}
 	NetrestrictFlag = cli.StringFlag{
 		Name:  "netrestrict",
@@ -674,6 +819,11 @@ var (
 		Name:  ethconfig.FlagSnapStateStop,
 		Usage: "Workaround to stop producing new state files, if you meet some state-related critical bug. It will stop aggregate DB history in a state files. DB will grow and may slightly slow-down - and removing this flag in future will not fix this effect (db size will not greatly reduce).",
 	}
+	SnapMmapBudgetFlag = cli.StringFlag{
+		Name:  "snap.mmap.budget",
+		Usage: "Limit of RAM (page cache) of memory-mapped accessor indexes (.idx, .efi, ...), e.g. 16gb. Cold indexes over the limit are paged out. Default: no limit, only residency metrics",
+		Value: "",
+	}
 	SnapSkipStateSnapshotDownloadFlag = cli.BoolFlag{
 		Name:  "snap.skip-state-snapshot-download",
 		Usage: "Skip state download and start from genesis block",
@@ -738,6 +888,28 @@ var (
 		Value: 10,
 		Usage: "Number of connections per file",
 	}
+	TorrentDisableUTPFlag = cli.BoolFlag{
+		Name:  "torrent.disable.utp",
+		Usage: "Turns off uTP (UDP) for the downloader: TCP-only seeding, for networks blocking UDP",
+	}
+	TorrentDisablePEXFlag = cli.BoolFlag{
+		Name:  "torrent.disable.pex",
+		Usage: "Turns off peer exchange (PEX) for the downloader",
+	}
+	TorrentDHTFlag = cli.BoolFlag{
+		Name:  "torrent.dht",
+		Usage: "Turns on DHT (UDP) for the downloader",
+	}
+	TorrentDHTBootstrapFlag = cli.StringFlag{
+		Name:  "torrent.dht.bootstrap",
+		Usage: "Comma separated host:port of DHT bootstrap nodes, instead of global ones. Implies --torrent.dht",
+		Value: "",
+	}
+	DownloaderScrubRateFlag = cli.StringFlag{
+		Name:  "downloader.scrub.rate",
+		Usage: "Read rate of background re-hashing of downloaded files against the manifest (defence against silent disk corruption): corrupted files are re-downloaded. 0 - off",
+		Value: "8mb",
+	}
 	DbPageSizeFlag = cli.StringFlag{
 		Name:  "db.pagesize",
 		Usage: "DB is splitted to 'pages' of fixed size. Can't change DB creation. Must be power of 2 and '256b <= pagesize <= 64kb'. Default: equal to OperationSystem's pageSize. Bigger pageSize causing: 1. More writes to disk during commit 2. Smaller b-tree high 3. Less fragmentation 4. Less overhead on 'free-pages list' maintainance (a bit faster Put/Commit) 5. If expecting DB-size > 8Tb then set pageSize >= 8Kb",
@@ -764,6 +936,10 @@ var (
 		Usage: "Comma-separated URL's, holding metadata about network-support infrastructure (like S3 buckets with snapshots, bootnodes, etc...)",
 		Value: "",
 	}
+	WebSeedAuthFlag = cli.StringSliceFlag{
+		Name:  "webseed.auth",
+		Usage: "Credentials of private webseeds: <url-prefix>=<token> or <url-prefix>=env:<VAR>, sent as bearer token to urls of the prefix only. Signed urls (S3 pre-signed, Azure SAS) need no credentials: pass them in --webseed",
+	}
 
 	HeimdallURLFlag = cli.StringFlag{
 		Name:  "bor.heimdall",
@@ -824,6 +1000,12 @@ var (
 		Usage: "Sets erigon flags from YAML/TOML file",
 		Value: "",
 	}
+	ChainsConfigFlag = cli.StringSliceFlag{
+		Name: "chains.config",
+		Usage: "Runs several chains in one process: one YAML/TOML file of erigon flags per chain, each with own --datadir and ports. " +
+			"Command line flags are shared by all chains, the files override them. Every chain runs own downloader (own --torrent.port), " +
+			"they share --torrent.download.rate and --torrent.upload.rate. Metrics and pprof endpoints are shared by all chains",
+	}
 
 	CaplinDiscoveryAddrFlag = cli.StringFlag{
 		Name:  "caplin.discovery.addr",
@@ -885,6 +1067,10 @@ var (
 		Usage: "Max number of peers to connect",
 		Value: 128,
 	}
+	CryptoBatchLatencyFlag = cli.DurationFlag{
+		Name:  "crypto.batch-latency",
+		Usage: "Latency budget of batched verification: BLS signatures of Caplin gossip (default 500ms) and KZG proofs of blob sidecars and blob txns (default 20ms) wait up to this to share one pairing check with others",
+	}
 	CaplinUseEngineApiFlag = cli.BoolFlag{
 		Name:  "caplin.use-engine-api",
 		Usage: "Use engine API for internal Caplin. useful for testing and if CL network is degraded",
@@ -987,7 +1173,11 @@ var (
 
 	BeaconAPIFlag = cli.StringSliceFlag{
 		Name:  "beacon.api",
-		Usage: "Enable beacon API (available endpoints: beacon, builder, config, debug, events, node, validator, lighthouse)",
+		Usage: "Enable beacon API (available endpoints: beacon, builder, config, debug, events, node, validator, lighthouse, keymanager)",
+	}
+	BeaconApiKeymanagerTokenFlag = cli.StringFlag{
+		Name:  "beacon.api.keymanager.token",
+		Usage: "Bearer token of the keymanager endpoints of beacon API (fee recipient, gas limit and graffiti of validator keys)",
 	}
 	BeaconApiProtocolFlag = cli.StringFlag{
 		Name:  "beacon.api.protocol",
@@ -1049,6 +1239,21 @@ var (
 		Usage: "disable blob pruning in caplin",
 		Value: false,
 	}
+	CaplinRetentionBlobsFlag = cli.Uint64Flag{
+		Name:  "caplin.retention.blobs-slots",
+		Usage: "slots of blob sidecars kept by caplin, at least MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS (0 - that minimum)",
+		Value: 0,
+	}
+	CaplinRetentionBlocksFlag = cli.Uint64Flag{
+		Name:  "caplin.retention.blocks-slots",
+		Usage: "slots of beacon blocks kept by caplin, at least the blobs retention. Embedded EL keeps bodies of as many blocks (0 - 1M, or less to fit --prune.*)",
+		Value: 0,
+	}
+	CaplinRetentionDryRunFlag = cli.BoolFlag{
+		Name:  "caplin.retention.dry-run",
+		Usage: "log what caplin's blobs and blocks pruning would remove, without removing it",
+		Value: false,
+	}
 	CaplinDisableCheckpointSyncFlag = cli.BoolFlag{
 		Name:  "caplin.checkpoint-sync.disable",
 		Usage: "disable checkpoint sync in caplin",
@@ -1105,6 +1310,11 @@ var (
 		Usage: "Enable speed test",
 		Value: false,
 	}
+	DiagGeoIPFlag = cli.StringFlag{
+		Name:  "diagnostics.geoip",
+		Usage: "Path to a MaxMind GeoLite2/GeoIP2 City database (.mmdb), used to show peer locations in the diagnostics web UI",
+		Value: "",
+	}
 	ChaosMonkeyFlag = cli.BoolFlag{
 		Name:  "chaos.monkey",
 		Usage: "Enable 'chaos monkey' to generate spontaneous network/consensus/etc failures. Use ONLY for testing",
@@ -1112,7 +1322,7 @@ var (
 	}
 	ShutterEnabledFlag = cli.BoolFlag{
 		Name:  "shutter",
-		Usage: "Enable the Shutter encrypted transactions mempool (defaults to false)",
+		Usage: "Enable the Shutter encrypted transactions mempool, available on gnosis and chiado (defaults to false)",
 	}
 	ShutterP2pBootstrapNodesFlag = cli.StringSliceFlag{
 		Name:  "shutter.p2p.bootstrap.nodes",
@@ -1146,7 +1356,7 @@ var (
 	}
 )
 
-var MetricFlags = []cli.Flag{&MetricsEnabledFlag, &MetricsHTTPFlag, &MetricsPortFlag, &DiagDisabledFlag, &DiagEndpointAddrFlag, &DiagEndpointPortFlag, &DiagSpeedTestFlag}
+var MetricFlags = []cli.Flag{&MetricsEnabledFlag, &MetricsHTTPFlag, &MetricsPortFlag, &DiagDisabledFlag, &DiagEndpointAddrFlag, &DiagEndpointPortFlag, &DiagSpeedTestFlag, &DiagGeoIPFlag}
 
 var DiagnosticsFlags = []cli.Flag{&DiagnosticsURLFlag, &DiagnosticsURLFlag, &DiagnosticsSessionsFlag}
 
@@ -1287,6 +1497,8 @@ func NewP2PConfig(
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth67")
 	case direct.ETH68:
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth68")
+	case direct.ETH69:
+		enodeDBPath = filepath.Join(dirs.Nodes, "eth69")
 	default:
 		return nil, fmt.Errorf("unknown protocol: %v", protocol)
 	}
@@ -1327,7 +1539,7 @@ func NewP2PConfig(
 		}
 		cfg.TrustedNodes = trustedNodes
 	}
-	natif, err := nat.Parse(natSetting)
+	natif, err := nat.Shared(natSetting)
 	if err != nil {
 		return nil, fmt.Errorf("invalid nat option %s: %w", natSetting, err)
 	}
@@ -1356,6 +1568,9 @@ func setListenAddress(ctx *cli.Context, cfg *p2p.Config) {
 	if ctx.IsSet(SentryAddrFlag.Name) {
 		cfg.SentryAddr = common.CliString2Array(ctx.String(SentryAddrFlag.Name))
 	}
+	if ctx.IsSet(P2pQUICPortFlag.Name) {
+		cfg.QUICPort = ctx.Int(P2pQUICPortFlag.Name)
+	}
 	// TODO cli lib doesn't store defaults for UintSlice properly so we have to get value directly
 	cfg.AllowedPorts = P2pProtocolAllowedPorts.Value.Value()
 	if ctx.IsSet(P2pProtocolAllowedPorts.Name) {
@@ -1382,7 +1597,7 @@ func setListenAddress(ctx *cli.Context, cfg *p2p.Config) {
 func setNAT(ctx *cli.Context, cfg *p2p.Config) {
 	if ctx.IsSet(NATFlag.Name) {
 		natSetting := ctx.String(NATFlag.Name)
-		natif, err := nat.Parse(natSetting)
+		natif, err := nat.Shared(natSetting)
 		if err != nil {
 			Fatalf("Option %s: %v", NATFlag.Name, err)
 		}
@@ -1456,10 +1671,18 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, l
 		cfg.NoDiscovery = true
 	}
 
+	if ctx.IsSet(DiscoveryV4Flag.Name) {
+		cfg.NoDiscoveryV4 = !ctx.Bool(DiscoveryV4Flag.Name)
+	}
+
 	if ctx.IsSet(DiscoveryV5Flag.Name) {
 		cfg.DiscoveryV5 = ctx.Bool(DiscoveryV5Flag.Name)
 	}
 
+	if ctx.IsSet(P2pServeSnapFlag.Name) {
+		cfg.ServeSnap = ctx.Bool(P2pServeSnapFlag.Name)
+	}
+
 	if ctx.IsSet(MetricsEnabledFlag.Name) {
 		cfg.MetricsEnabled = ctx.Bool(MetricsEnabledFlag.Name)
 	}
@@ -1482,7 +1705,7 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config, nodeName, datadir string, l
 		}
 		cfg.NoDiscovery = true
 		cfg.DiscoveryV5 = false
-		logger.Info("Development chain flags set", "--nodiscover", cfg.NoDiscovery, "--v5disc", cfg.DiscoveryV5, "--port", cfg.ListenAddr)
+		logger.Info("Development chain flags set", "--nodiscover", cfg.NoDiscovery, "--discovery.v5", cfg.DiscoveryV5, "--port", cfg.ListenAddr)
 	}
 }
 
@@ -1492,12 +1715,23 @@ func SetNodeConfig(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logger) err
 		return err
 	}
 	setNodeUserIdent(ctx, cfg)
+	if err := setSecrets(ctx); err != nil {
+		return err
+	}
 	SetP2PConfig(ctx, &cfg.P2P, cfg.NodeName(), cfg.Dirs.DataDir, logger)
 
 	cfg.SentryLogPeerInfo = ctx.IsSet(SentryLogPeerInfoFlag.Name)
 	return nil
 }
 
+func setSecrets(ctx *cli.Context) error {
+	encrypt := common.CliString2Array(ctx.String(SecretsEncryptFlag.Name))
+	if err := secrets.Configure(secrets.Config{Encrypt: encrypt, PasswordFile: ctx.String(SecretsPasswordFileFlag.Name)}); err != nil {
+		return fmt.Errorf("--%s: %w", SecretsEncryptFlag.Name, err)
+	}
+	return nil
+}
+
 func SetNodeConfigCobra(cmd *cobra.Command, cfg *nodecfg.Config) {
 	flags := cmd.Flags()
 	//SetP2PConfig(ctx, &cfg.P2P)
@@ -1511,6 +1745,12 @@ func setDataDir(ctx *cli.Context, cfg *nodecfg.Config) error {
 	} else {
 		cfg.Dirs = datadir.New(paths.DataDirForNetwork(paths.DefaultDataDir(), ctx.String(ChainFlag.Name)))
 	}
+	if ctx.IsSet(DataDirSnapFlag.Name) {
+		var err error
+		if cfg.Dirs, err = cfg.Dirs.SetLocation(datadir.Snapshots, ctx.String(DataDirSnapFlag.Name)); err != nil {
+			return fmt.Errorf("--%s: %w", DataDirSnapFlag.Name, err)
+		}
+	}
 
 	cfg.MdbxPageSize = flags.DBPageSizeFlagUnmarshal(ctx, DbPageSizeFlag.Name, DbPageSizeFlag.Usage)
 	if err := cfg.MdbxDBSizeLimit.UnmarshalText([]byte(ctx.String(DbSizeLimitFlag.Name))); err != nil {
@@ -1616,7 +1856,14 @@ func setTxPool(ctx *cli.Context, dbDir string, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolGossipDisableFlag.Name) {
 		cfg.NoGossip = ctx.Bool(TxPoolGossipDisableFlag.Name)
 	}
+	if ctx.IsSet(TxPoolAnnounceRatioFlag.Name) {
+		cfg.AnnounceRatio = ctx.Float64(TxPoolAnnounceRatioFlag.Name)
+	}
+	if ctx.IsSet(TxPoolBroadcastRatioFlag.Name) {
+		cfg.BroadcastRatio = ctx.Float64(TxPoolBroadcastRatioFlag.Name)
+	}
 	cfg.AllowAA = ctx.Bool(AAFlag.Name)
+	cfg.RejectListFile = ctx.String(TxPoolRejectListFlag.Name)
 	cfg.LogEvery = 3 * time.Minute
 	cfg.CommitEvery = common.RandomizeDuration(ctx.Duration(TxPoolCommitEveryFlag.Name))
 	cfg.DBDir = dbDir
@@ -1628,6 +1875,10 @@ func setShutter(ctx *cli.Context, chainName string, nodeConfig *nodecfg.Config,
 		return
 	}
 
+	if chainName != networkname.Gnosis && chainName != networkname.Chiado {
+		Fatalf("Option --%s: shutter is deployed on %s and %s only, not on %s", ShutterEnabledFlag.Name, networkname.Gnosis, networkname.Chiado, chainName)
+	}
+
 	config := shuttercfg.ConfigByChainName(chainName)
 	config.PrivateKey = nodeConfig.P2P.PrivateKey
 	// check for cli overrides
@@ -1831,7 +2082,8 @@ func setBeaconAPI(ctx *cli.Context, cfg *ethconfig.Config) error {
 	cfg.CaplinConfig.BeaconAPIRouter.AllowedMethods = ctx.StringSlice(BeaconApiAllowMethodsFlag.Name)
 	cfg.CaplinConfig.BeaconAPIRouter.AllowedOrigins = ctx.StringSlice(BeaconApiAllowOriginsFlag.Name)
 	cfg.CaplinConfig.BeaconAPIRouter.AllowCredentials = ctx.Bool(BeaconApiAllowCredentialsFlag.Name)
-	return nil
+	cfg.CaplinConfig.BeaconAPIRouter.KeymanagerToken = ctx.String(BeaconApiKeymanagerTokenFlag.Name)
+	return cfg.CaplinConfig.BeaconAPIRouter.CheckKeymanager()
 }
 
 func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1859,6 +2111,9 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	}
 
 	cfg.CaplinConfig.ImmediateBlobsBackfilling = ctx.Bool(CaplinImmediateBlobBackfillFlag.Name)
+	cfg.CaplinConfig.Retention.BlobSidecarsSlots = ctx.Uint64(CaplinRetentionBlobsFlag.Name)
+	cfg.CaplinConfig.Retention.BeaconBlocksSlots = ctx.Uint64(CaplinRetentionBlocksFlag.Name)
+	cfg.CaplinConfig.Retention.DryRun = ctx.Bool(CaplinRetentionDryRunFlag.Name)
 	cfg.CaplinConfig.SnapshotGenerationEnabled = ctx.Bool(CaplinEnableSnapshotGeneration.Name)
 	cfg.CaplinConfig.DisabledCheckpointSync = ctx.Bool(CaplinDisableCheckpointSyncFlag.Name)
 	// bunch of extra stuff
@@ -1869,6 +2124,20 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	}
 	cfg.CaplinConfig.CustomConfigPath = ctx.String(CaplinCustomConfigFlag.Name)
 	cfg.CaplinConfig.CustomGenesisStatePath = ctx.String(CaplinCustomGenesisFlag.Name)
+	if c := params2.CustomChainByName(ctx.String(ChainFlag.Name)); c != nil && !ctx.IsSet(CaplinCustomConfigFlag.Name) {
+		cfg.CaplinConfig.CustomConfigPath = c.CaplinConfigPath
+		cfg.CaplinConfig.CustomGenesisStatePath = c.CaplinGenesisStatePath
+	}
+}
+
+// LoadCustomChains - registers chains of --chain.custom-dir. Must be called before chain lookups by name.
+func LoadCustomChains(ctx *cli.Context, logger log.Logger) error {
+	dir := ctx.String(CustomChainsDirFlag.Name)
+	if dir == "" {
+		return nil
+	}
+	_, err := core.LoadCustomChains(dir, logger)
+	return err
 }
 
 func setSilkworm(ctx *cli.Context, cfg *ethconfig.Config) {
@@ -1940,6 +2209,21 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		cfg.PersistReceiptsCacheV2 = true
 		state.EnableHistoricalRCache()
 	}
+	if ctx.Bool(AddressAppearancesFlag.Name) {
+		cfg.AddressAppearances = true
+		state.EnableAddressAppearances()
+	}
+	if ctx.Bool(AccessListsFlag.Name) {
+		cfg.AccessLists = true
+		state.EnableAccessLists()
+	}
+	if ctx.Bool(WithdrawalsIndexFlag.Name) {
+		cfg.WithdrawalsIndex = true
+		state.EnableWithdrawalsIndex()
+	}
+	cfg.HeadersMMR = ctx.Bool(HeadersMMRFlag.Name)
+	cfg.HistoryExpiry = ctx.Bool(HistoryExpiryFlag.Name)
+	cfg.ExecPrefetch = ctx.Bool(ExecPrefetchFlag.Name)
 	cfg.CaplinConfig.EnableUPnP = ctx.Bool(CaplinEnableUPNPlag.Name)
 	var err error
 	cfg.CaplinConfig.MaxInboundTrafficPerPeer, err = datasize.ParseString(ctx.String(CaplinMaxInboundTrafficPerPeerFlag.Name))
@@ -1985,6 +2269,11 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
 	cfg.Snapshot.ChainName = chain
+	if ctx.IsSet(SnapMmapBudgetFlag.Name) {
+		if err := cfg.Snapshot.MmapBudget.UnmarshalText([]byte(ctx.String(SnapMmapBudgetFlag.Name))); err != nil {
+			Fatalf("Option %s: %v", SnapMmapBudgetFlag.Name, err)
+		}
+	}
 	nodeConfig.Http.Snap = cfg.Snapshot
 
 	if ctx.Command.Name == "import" {
@@ -2010,6 +2299,10 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 
 	cfg.AllowAA = ctx.Bool(AAFlag.Name)
 	cfg.Ethstats = ctx.String(EthStatsURLFlag.Name)
+	cfg.StreamURL = ctx.String(StreamURLFlag.Name)
+	cfg.StreamFormat = ctx.String(StreamFormatFlag.Name)
+	cfg.StreamTopicPrefix = ctx.String(StreamTopicPrefixFlag.Name)
+	cfg.StreamFromBlock = ctx.Uint64(StreamFromBlockFlag.Name)
 
 	if ctx.Bool(ExperimentalConcurrentCommitmentFlag.Name) {
 		// cfg.ExperimentalConcurrentCommitment = true
@@ -2073,6 +2366,15 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		cfg.OverridePragueTime = flags.GlobalBig(ctx, OverridePragueFlag.Name)
 		cfg.TxPool.OverridePragueTime = cfg.OverridePragueTime
 	}
+	if cfg.ForkOverridesFile = ctx.String(OverrideFileFlag.Name); cfg.ForkOverridesFile != "" {
+		overrides, err := core.ReadForkOverrides(cfg.ForkOverridesFile)
+		if err != nil {
+			Fatalf("Option %s: %v", OverrideFileFlag.Name, err)
+		}
+		if overrides.PragueTime != nil {
+			cfg.TxPool.OverridePragueTime = overrides.PragueTime
+		}
+	}
 
 	if clparams.EmbeddedSupported(cfg.NetworkID) || cfg.CaplinConfig.IsDevnet() {
 		cfg.InternalCL = !ctx.Bool(ExternalConsensusFlag.Name)
@@ -2081,6 +2383,11 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	if ctx.IsSet(TrustedSetupFile.Name) {
 		libkzg.SetTrustedSetupFilePath(ctx.String(TrustedSetupFile.Name))
 	}
+	if ctx.IsSet(CryptoBatchLatencyFlag.Name) {
+		latency := ctx.Duration(CryptoBatchLatencyFlag.Name)
+		libkzg.SetBatchLatency(latency)
+		cfg.CaplinConfig.BatchVerificationLatency = latency
+	}
 
 	// Do this after chain config as there are chain type registration
 	// dependencies for know config which need to be set-up
@@ -2112,6 +2419,22 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		if err != nil {
 			panic(err)
 		}
+		if cfg.Downloader.WebseedAuth, err = downloadercfg2.ParseWebseedAuth(ctx.StringSlice(WebSeedAuthFlag.Name)); err != nil {
+			Fatalf("Option %s: %v", WebSeedAuthFlag.Name, err)
+		}
+		if err := cfg.Downloader.SetTransport(downloadercfg2.Transport{
+			DisableUTP:   ctx.Bool(TorrentDisableUTPFlag.Name),
+			DisableIPv4:  ctx.Bool(DisableIPV4.Name),
+			DisableIPv6:  ctx.Bool(DisableIPV6.Name),
+			DisablePEX:   ctx.Bool(TorrentDisablePEXFlag.Name),
+			DHT:          ctx.Bool(TorrentDHTFlag.Name),
+			DHTBootstrap: common.CliString2Array(ctx.String(TorrentDHTBootstrapFlag.Name)),
+		}); err != nil {
+			Fatalf("%v", err)
+		}
+		if err := cfg.Downloader.ScrubRate.UnmarshalText([]byte(ctx.String(DownloaderScrubRateFlag.Name))); err != nil {
+			Fatalf("Option %s: %v", DownloaderScrubRateFlag.Name, err)
+		}
 		downloadernat.DoNat(nodeConfig.P2P.NAT, cfg.Downloader.ClientConfig, logger)
 	}
 }
@@ -2158,6 +2481,10 @@ func CobraFlags(cmd *cobra.Command, urfaveCliFlagsLists ...[]cli.Flag) {
 				flags.String(f.Name, f.Value, f.Usage)
 			case *cli.BoolFlag:
 				flags.Bool(f.Name, false, f.Usage)
+			case *cli.Float64Flag:
+				flags.Float64(f.Name, f.Value, f.Usage)
+			case *cli.DurationFlag:
+				flags.Duration(f.Name, f.Value, f.Usage)
 			default:
 				panic(fmt.Errorf("unexpected type: %T", flag))
 			}
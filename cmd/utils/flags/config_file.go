@@ -0,0 +1,381 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigKind - type of value of config file key
+type ConfigKind int
+
+const (
+	ConfigString ConfigKind = iota
+	ConfigBool
+	ConfigInt
+	ConfigUint
+	ConfigFloat
+	ConfigDuration
+	ConfigList
+)
+
+func (k ConfigKind) String() string {
+	return [...]string{"string", "bool", "int", "uint", "float", "duration", "list"}[k]
+}
+
+// ConfigDeprecation - key of config file which was renamed to Replacement, or removed if Replacement is empty
+type ConfigDeprecation struct {
+	Replacement string
+	Note        string
+}
+
+// DeprecatedConfigKeys - keys which are still accepted in config files, with a warning
+var DeprecatedConfigKeys = map[string]ConfigDeprecation{
+	"prune":             {Note: "Erigon2 prune letters are not supported, use prune.mode=full|archive|minimal|blocks"},
+	"snap.keepblocks":   {Note: "blocks are always kept in snapshots, use prune.mode=blocks|minimal to prune them"},
+	"internalcl":        {Note: "Caplin is enabled by default, use externalcl=true to disable it"},
+	"metrics.expensive": {Note: "removed"},
+}
+
+// ConfigWarning - use of deprecated key in config file
+type ConfigWarning struct {
+	Key         string
+	Replacement string
+	Note        string
+}
+
+// ConfigSchema - kind of value of every key which config file accepts. Keys are names (and aliases) of flags.
+type ConfigSchema map[string]ConfigKind
+
+func NewConfigSchema(flagLists ...[]cli.Flag) ConfigSchema {
+	s := ConfigSchema{}
+	for _, flags := range flagLists {
+		for _, flag := range flags {
+			kind := ConfigString
+			switch flag.(type) {
+			case *cli.BoolFlag:
+				kind = ConfigBool
+			case *cli.IntFlag, *cli.Int64Flag:
+				kind = ConfigInt
+			case *cli.UintFlag, *cli.Uint64Flag:
+				kind = ConfigUint
+			case *cli.Float64Flag:
+				kind = ConfigFloat
+			case *cli.DurationFlag:
+				kind = ConfigDuration
+			case *cli.StringSliceFlag, *cli.IntSliceFlag, *cli.Int64SliceFlag, *cli.UintSliceFlag, *cli.Uint64SliceFlag, *cli.Float64SliceFlag:
+				kind = ConfigList
+			}
+			for _, name := range flag.Names() {
+				s[name] = kind
+			}
+		}
+	}
+	return s
+}
+
+// Load reads config file and returns flag values to set. Unknown keys and values of wrong type are errors,
+// deprecated keys are returned as warnings (and renamed).
+func (s ConfigSchema) Load(filePath string) (map[string]string, []ConfigWarning, error) {
+	fileConfig, err := ReadConfigFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var warnings []ConfigWarning
+	for key, d := range DeprecatedConfigKeys {
+		v, ok := fileConfig[key]
+		if !ok {
+			continue
+		}
+		if _, isFlag := s[key]; isFlag {
+			continue // flag was brought back
+		}
+		warnings = append(warnings, ConfigWarning{Key: key, Replacement: d.Replacement, Note: d.Note})
+		delete(fileConfig, key)
+		if d.Replacement == "" {
+			continue
+		}
+		if _, ok := fileConfig[d.Replacement]; ok {
+			return nil, nil, fmt.Errorf("config file %s: both deprecated %q and its replacement %q are set", filePath, key, d.Replacement)
+		}
+		fileConfig[d.Replacement] = v
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Key < warnings[j].Key })
+
+	values := make(map[string]string, len(fileConfig))
+	var errs []error
+	for _, key := range sortedKeys(fileConfig) {
+		kind, ok := s[key]
+		if !ok {
+			if suggestion := s.closest(key); suggestion != "" {
+				errs = append(errs, fmt.Errorf("unknown key %q, did you mean %q?", key, suggestion))
+			} else {
+				errs = append(errs, fmt.Errorf("unknown key %q", key))
+			}
+			continue
+		}
+		v, err := formatConfigValue(kind, fileConfig[key])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("key %q: %w", key, err))
+			continue
+		}
+		values[key] = v
+	}
+	if len(errs) > 0 {
+		return nil, warnings, fmt.Errorf("invalid config file %s: %w", filePath, errors.Join(errs...))
+	}
+	return values, warnings, nil
+}
+
+// closest - known key with smallest edit distance, if the distance is small enough to be a typo
+func (s ConfigSchema) closest(key string) string {
+	best, bestDist := "", len(key)/3+1
+	for name := range s {
+		if d := levenshtein(key, name); d < bestDist || (d == bestDist && best != "" && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func formatConfigValue(kind ConfigKind, v any) (string, error) {
+	if kind == ConfigList {
+		list, ok := v.([]any)
+		if !ok {
+			return formatConfigValue(ConfigString, v)
+		}
+		items := make([]string, len(list))
+		for i, item := range list {
+			s, err := formatConfigValue(ConfigString, item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = s
+		}
+		return strings.Join(items, ","), nil
+	}
+
+	switch typed := v.(type) {
+	case string:
+		if err := checkConfigString(kind, typed); err != nil {
+			return "", err
+		}
+		return typed, nil
+	case bool:
+		if kind != ConfigBool && kind != ConfigString {
+			return "", fmt.Errorf("expected %s, got bool", kind)
+		}
+		return strconv.FormatBool(typed), nil
+	case int, int64, uint64:
+		if kind == ConfigBool || kind == ConfigDuration {
+			return "", fmt.Errorf("expected %s, got number", kind)
+		}
+		s := fmt.Sprintf("%d", typed)
+		if kind == ConfigUint && strings.HasPrefix(s, "-") {
+			return "", fmt.Errorf("expected %s, got %s", kind, s)
+		}
+		return s, nil
+	case float64:
+		switch kind {
+		case ConfigFloat, ConfigString:
+			return strconv.FormatFloat(typed, 'f', -1, 64), nil
+		case ConfigInt, ConfigUint:
+			if typed != math.Trunc(typed) {
+				return "", fmt.Errorf("expected %s, got %v", kind, typed)
+			}
+			return formatConfigValue(kind, int64(typed))
+		}
+		return "", fmt.Errorf("expected %s, got number", kind)
+	case []any:
+		return "", fmt.Errorf("expected %s, got list", kind)
+	case map[string]any:
+		return "", fmt.Errorf("expected %s, got table", kind)
+	case nil:
+		return "", fmt.Errorf("expected %s, got empty value", kind)
+	default:
+		if kind != ConfigString {
+			return "", fmt.Errorf("expected %s, got %T", kind, v)
+		}
+		return fmt.Sprintf("%v", v), nil
+	}
+}
+
+// checkConfigString - strings are accepted for any kind (values may come from environment variables), but must parse
+func checkConfigString(kind ConfigKind, s string) error {
+	var err error
+	switch kind {
+	case ConfigBool:
+		_, err = strconv.ParseBool(s)
+	case ConfigInt:
+		_, err = strconv.ParseInt(s, 0, 64)
+	case ConfigUint:
+		_, err = strconv.ParseUint(s, 0, 64)
+	case ConfigFloat:
+		_, err = strconv.ParseFloat(s, 64)
+	case ConfigDuration:
+		_, err = time.ParseDuration(s)
+	}
+	if err != nil {
+		return fmt.Errorf("expected %s, got %q", kind, s)
+	}
+	return nil
+}
+
+// ReadConfigFile reads .toml/.yaml config file: nested tables are flattened to dotted keys (`[http] api = ...` is
+// `http.api`) and `${VAR}`, `${VAR:-default}` in strings are replaced by environment variables. Keys are not validated.
+func ReadConfigFile(filePath string) (map[string]any, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]any)
+	switch filepath.Ext(filePath) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, errors.New("config files only accepted are .yaml, .yml, and .toml")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fileConfig := make(map[string]any, len(raw))
+	if err := flattenConfig("", raw, fileConfig); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", filePath, err)
+	}
+	return fileConfig, nil
+}
+
+func flattenConfig(prefix string, in map[string]any, out map[string]any) error {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		v, err := normalizeConfigValue(v)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+		if nested, ok := v.(map[string]any); ok {
+			if err := flattenConfig(key, nested, out); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := out[key]; ok {
+			return fmt.Errorf("key %q is set twice", key)
+		}
+		out[key] = v
+	}
+	return nil
+}
+
+// normalizeConfigValue - yaml.v2 tables to map[string]any and environment variables interpolation
+func normalizeConfigValue(v any) (any, error) {
+	switch typed := v.(type) {
+	case string:
+		return interpolateEnv(typed)
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			n, err := normalizeConfigValue(item)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = n
+		}
+		return out, nil
+	case map[any]any:
+		out := make(map[string]any, len(typed))
+		for k, item := range typed {
+			out[fmt.Sprintf("%v", k)] = item
+		}
+		return out, nil
+	default:
+		return v, nil
+	}
+}
+
+var envRef = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolateEnv replaces `${VAR}` and `${VAR:-default}`; `$$` is `$`
+func interpolateEnv(s string) (string, error) {
+	var err error
+	out := envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		m := envRef.FindStringSubmatch(ref)
+		if v, ok := os.LookupEnv(m[1]); ok {
+			return v
+		}
+		if m[2] != "" {
+			return m[3]
+		}
+		if err == nil {
+			err = fmt.Errorf("environment variable %s is not set", m[1])
+		}
+		return ""
+	})
+	return out, err
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,118 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+var testConfigFlags = []cli.Flag{
+	&cli.StringFlag{Name: "datadir"},
+	&cli.BoolFlag{Name: "http"},
+	&cli.IntFlag{Name: "http.port"},
+	&cli.StringSliceFlag{Name: "http.api"},
+	&cli.DurationFlag{Name: "http.timeouts.read"},
+	&cli.Uint64Flag{Name: "txpool.globalslots"},
+}
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestConfigSchemaLoad(t *testing.T) {
+	t.Setenv("TEST_ERIGON_DATADIR", "/data/erigon")
+	schema := NewConfigSchema(testConfigFlags)
+
+	toml := writeConfig(t, "config.toml", `
+datadir = "${TEST_ERIGON_DATADIR}"
+"txpool.globalslots" = "${TEST_ERIGON_SLOTS:-10000}"
+prune = "hrtc"
+
+[http]
+enabled = true
+port = 8545
+api = ["eth", "erigon"]
+"timeouts.read" = "30s"
+`)
+	_, _, err := schema.Load(toml)
+	require.ErrorContains(t, err, `unknown key "http.enabled"`)
+
+	toml = writeConfig(t, "config.toml", `
+datadir = "${TEST_ERIGON_DATADIR}"
+"txpool.globalslots" = "${TEST_ERIGON_SLOTS:-10000}"
+http = true
+prune = "hrtc"
+"http.port" = 8545
+"http.api" = ["eth", "erigon"]
+"http.timeouts.read" = "30s"
+`)
+	values, warnings, err := schema.Load(toml)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"datadir":            "/data/erigon",
+		"txpool.globalslots": "10000",
+		"http":               "true",
+		"http.port":          "8545",
+		"http.api":           "eth,erigon",
+		"http.timeouts.read": (30 * time.Second).String(),
+	}, values)
+	require.Len(t, warnings, 1)
+	require.Equal(t, "prune", warnings[0].Key)
+
+	yaml := writeConfig(t, "config.yaml", `
+datadir: /data
+http:
+  port: 8545
+  api: [eth]
+`)
+	values, _, err = schema.Load(yaml)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datadir": "/data", "http.port": "8545", "http.api": "eth"}, values)
+}
+
+func TestConfigSchemaErrors(t *testing.T) {
+	schema := NewConfigSchema(testConfigFlags)
+
+	_, _, err := schema.Load(writeConfig(t, "config.toml", `
+"http.prot" = 8545
+http = "yes"
+"http.timeouts.read" = 30
+"txpool.globalslots" = -1
+`))
+	require.ErrorContains(t, err, `unknown key "http.prot", did you mean "http.port"?`)
+	require.ErrorContains(t, err, `key "http": expected bool, got "yes"`)
+	require.ErrorContains(t, err, `key "http.timeouts.read": expected duration, got number`)
+	require.ErrorContains(t, err, `key "txpool.globalslots": expected uint, got -1`)
+
+	_, _, err = schema.Load(writeConfig(t, "config.toml", `datadir = "${TEST_ERIGON_NOT_SET}"`))
+	require.ErrorContains(t, err, "environment variable TEST_ERIGON_NOT_SET is not set")
+}
+
+func TestInterpolateEnv(t *testing.T) {
+	t.Setenv("TEST_ERIGON_HOST", "node1")
+	s, err := interpolateEnv("http://${TEST_ERIGON_HOST}:${TEST_ERIGON_PORT:-8545}/$$path")
+	require.NoError(t, err)
+	require.Equal(t, "http://node1:8545/$path", s)
+}
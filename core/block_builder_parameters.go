@@ -31,4 +31,9 @@ type BlockBuilderParameters struct {
 	SuggestedFeeRecipient common.Address
 	Withdrawals           []*types.Withdrawal // added in Shapella (EIP-4895)
 	ParentBeaconBlockRoot *common.Hash        // added in Dencun (EIP-4788)
+
+	// OP Stack, see https://specs.optimism.io/protocol/exec-engine.html#extended-payloadattributesv1
+	Transactions [][]byte // binary encoded transactions forced into block before txpool ones
+	NoTxPool     bool     // block contains only forced transactions
+	GasLimit     *uint64  // overrides gas limit of block
 }
@@ -0,0 +1,125 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+)
+
+// Files of custom chain spec: <dir>/<chain name>/{genesis.json,config.yaml,genesis.ssz}.
+// config.yaml and genesis.ssz are optional - Caplin config and genesis state (same as `--caplin.custom-config`, `--caplin.custom-genesis`).
+const (
+	CustomChainGenesisFile      = "genesis.json"
+	CustomChainCaplinConfigFile = "config.yaml"
+	CustomChainCaplinStateFile  = "genesis.ssz"
+)
+
+// LoadCustomChains - registers (see params.RegisterCustomChain) every chain spec found in dir.
+func LoadCustomChains(dir string, logger log.Logger) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("custom chains: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp("", "erigon-custom-chains")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+	dirs := datadir.New(tmpDir)
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		chainDir := filepath.Join(dir, e.Name())
+		c, err := ReadCustomChain(e.Name(), chainDir, dirs, logger)
+		if err != nil {
+			return names, err
+		}
+		if c == nil {
+			continue
+		}
+		if err := params.RegisterCustomChain(c); err != nil {
+			return names, err
+		}
+		logger.Info("[custom-chains] registered", "chain", c.Name, "chainId", c.Genesis.Config.ChainID, "genesis", c.GenesisHash, "caplin", c.CaplinConfigPath != "")
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
+
+// ReadCustomChain - returns nil if chainDir has no genesis.json
+func ReadCustomChain(name, chainDir string, dirs datadir.Dirs, logger log.Logger) (*params.CustomChain, error) {
+	data, err := os.ReadFile(filepath.Join(chainDir, CustomChainGenesisFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	genesis := &types.Genesis{}
+	if err := json.Unmarshal(data, genesis); err != nil {
+		return nil, fmt.Errorf("custom chain %s: invalid %s: %w", name, CustomChainGenesisFile, err)
+	}
+	if genesis.Config == nil {
+		return nil, fmt.Errorf("custom chain %s: %w", name, types.ErrGenesisNoConfig)
+	}
+	if genesis.Config.BorJSON != nil {
+		borConfig := &borcfg.BorConfig{}
+		if err := json.Unmarshal(genesis.Config.BorJSON, borConfig); err != nil {
+			return nil, fmt.Errorf("custom chain %s: invalid 'bor' config: %w", name, err)
+		}
+		genesis.Config.Bor = borConfig
+	}
+	if err := genesis.Config.CheckConfigForkOrder(); err != nil {
+		return nil, fmt.Errorf("custom chain %s: %w", name, err)
+	}
+	if err := vm.CheckPrecompiles(genesis.Config); err != nil {
+		return nil, fmt.Errorf("custom chain %s: %w", name, err)
+	}
+	genesis.Config.ChainName = name
+	block, _, err := GenesisToBlock(genesis, dirs, logger)
+	if err != nil {
+		return nil, fmt.Errorf("custom chain %s: %w", name, err)
+	}
+
+	c := &params.CustomChain{Name: name, Genesis: genesis, GenesisHash: block.Hash()}
+	if path := filepath.Join(chainDir, CustomChainCaplinConfigFile); fileExists(path) {
+		c.CaplinConfigPath = path
+	}
+	if path := filepath.Join(chainDir, CustomChainCaplinStateFile); fileExists(path) {
+		c.CaplinGenesisStatePath = path
+	}
+	return c, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/params"
+)
+
+func TestLoadCustomChains(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	config := *params.AllProtocolChanges
+	config.ChainID = big.NewInt(424242)
+	config.PragueTime = big.NewInt(1000)
+	genesis, err := json.Marshal(&types.Genesis{Config: &config, GasLimit: 30_000_000, Timestamp: 1, Difficulty: big.NewInt(1), Alloc: types.GenesisAlloc{}})
+	require.NoError(err)
+	require.NoError(os.MkdirAll(filepath.Join(dir, "mydevnet"), 0755))
+	require.NoError(os.WriteFile(filepath.Join(dir, "mydevnet", core.CustomChainGenesisFile), genesis, 0644))
+	require.NoError(os.WriteFile(filepath.Join(dir, "mydevnet", core.CustomChainCaplinConfigFile), []byte("PRESET_BASE: mainnet\n"), 0644))
+	require.NoError(os.MkdirAll(filepath.Join(dir, "empty"), 0755))
+
+	names, err := core.LoadCustomChains(dir, log.New())
+	require.NoError(err)
+	defer params.UnregisterCustomChain("mydevnet")
+	require.Equal([]string{"mydevnet"}, names)
+
+	c := params.CustomChainByName("mydevnet")
+	require.NotNil(c)
+	require.Equal(filepath.Join(dir, "mydevnet", core.CustomChainCaplinConfigFile), c.CaplinConfigPath)
+	require.Empty(c.CaplinGenesisStatePath)
+	require.Equal(uint64(424242), params.NetworkIDByChainName("mydevnet"))
+	require.Equal(c.GenesisHash, *params.GenesisHashByChainName("mydevnet"))
+	require.Equal(c.Genesis.Config, params.ChainConfigByGenesisHash(c.GenesisHash))
+
+	g := core.GenesisBlockByChainName("mydevnet")
+	g.Config.PragueTime = big.NewInt(2000)
+	require.Equal(int64(1000), c.Genesis.Config.PragueTime.Int64()) // registered spec is not changed by callers
+
+	_, err = core.LoadCustomChains(dir, log.New())
+	require.ErrorContains(err, "already registered")
+	require.Error(params.RegisterCustomChain(&params.CustomChain{Name: "mainnet", Genesis: c.Genesis}))
+}
+
+func TestForkOverrides(t *testing.T) {
+	require := require.New(t)
+	config := &chain.Config{ChainID: big.NewInt(1), ShanghaiTime: big.NewInt(0), CancunTime: big.NewInt(100), PragueTime: big.NewInt(200)}
+
+	// prague is not active yet - can be moved
+	changed, err := (&core.ForkOverrides{PragueTime: big.NewInt(300)}).Apply(config, 150)
+	require.NoError(err)
+	require.Equal([]string{"prague"}, changed)
+	require.Equal(int64(300), config.PragueTime.Int64())
+
+	// same values - nothing to do
+	changed, err = (&core.ForkOverrides{CancunTime: big.NewInt(100), PragueTime: big.NewInt(300)}).Apply(config, 150)
+	require.NoError(err)
+	require.Empty(changed)
+
+	// cancun is active already
+	_, err = (&core.ForkOverrides{CancunTime: big.NewInt(400)}).Apply(config, 150)
+	require.ErrorContains(err, "already active")
+
+	// new time in the past
+	_, err = (&core.ForkOverrides{PragueTime: big.NewInt(120)}).Apply(config, 150)
+	require.ErrorContains(err, "must be after head")
+
+	// fork order is checked and config is not changed partially
+	_, err = (&core.ForkOverrides{PragueTime: big.NewInt(1000), OsakaTime: big.NewInt(500)}).Apply(config, 150)
+	require.Error(err)
+	require.Equal(int64(300), config.PragueTime.Int64())
+	require.Nil(config.OsakaTime)
+}
@@ -69,9 +69,11 @@ func NewEVMBlockContext(header *types.Header, blockHashFunc func(n uint64) commo
 
 	var transferFunc evmtypes.TransferFunc
 	var postApplyMessageFunc evmtypes.PostApplyMessageFunc
+	var l1CostFunc evmtypes.L1CostFunc
 	if engine != nil {
 		transferFunc = engine.GetTransferFunc()
 		postApplyMessageFunc = engine.GetPostApplyMessageFunc()
+		l1CostFunc = engine.GetL1CostFunc()
 	} else {
 		transferFunc = consensus.Transfer
 		postApplyMessageFunc = nil
@@ -81,6 +83,7 @@ func NewEVMBlockContext(header *types.Header, blockHashFunc func(n uint64) commo
 		Transfer:         transferFunc,
 		GetHash:          blockHashFunc,
 		PostApplyMessage: postApplyMessageFunc,
+		L1Cost:           l1CostFunc,
 		Coinbase:         beneficiary,
 		BlockNumber:      header.Number.Uint64(),
 		Time:             header.Time,
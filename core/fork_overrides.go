@@ -0,0 +1,125 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// ForkOverrides - fork timestamps which replace ones of chain config (`--override.file`).
+// File can be changed at runtime and re-applied by SIGHUP - so devnets can move forks without resync.
+type ForkOverrides struct {
+	ShanghaiTime *big.Int `json:"shanghaiTime,omitempty"`
+	CancunTime   *big.Int `json:"cancunTime,omitempty"`
+	PragueTime   *big.Int `json:"pragueTime,omitempty"`
+	OsakaTime    *big.Int `json:"osakaTime,omitempty"`
+}
+
+func ReadForkOverrides(file string) (*ForkOverrides, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	o := &ForkOverrides{}
+	if err := json.Unmarshal(data, o); err != nil {
+		return nil, fmt.Errorf("invalid fork overrides %s: %w", file, err)
+	}
+	return o, nil
+}
+
+func (o *ForkOverrides) forks(config *chain.Config) []forkOverride {
+	return []forkOverride{
+		{"shanghai", o.ShanghaiTime, &config.ShanghaiTime},
+		{"cancun", o.CancunTime, &config.CancunTime},
+		{"prague", o.PragueTime, &config.PragueTime},
+		{"osaka", o.OsakaTime, &config.OsakaTime},
+	}
+}
+
+type forkOverride struct {
+	name string
+	time *big.Int
+	dst  **big.Int
+}
+
+// Apply - changes only forks which are not activated yet at headTime, and only to the time after headTime:
+// anything else would change rules of already executed blocks. Config is not changed if any override is invalid.
+func (o *ForkOverrides) Apply(config *chain.Config, headTime uint64) (changed []string, err error) {
+	head := new(big.Int).SetUint64(headTime)
+	check := *config
+	for _, f := range o.forks(&check) {
+		if f.time == nil || (*f.dst != nil && (*f.dst).Cmp(f.time) == 0) {
+			continue
+		}
+		if *f.dst != nil && (*f.dst).Cmp(head) <= 0 {
+			return nil, fmt.Errorf("fork %s is already active: time=%d, head=%d", f.name, *f.dst, headTime)
+		}
+		if f.time.Cmp(head) <= 0 {
+			return nil, fmt.Errorf("fork %s: new time %d must be after head=%d", f.name, f.time, headTime)
+		}
+		*f.dst = f.time
+		changed = append(changed, f.name)
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	var prev forkOverride
+	for _, f := range o.forks(&check) {
+		if *f.dst == nil {
+			continue
+		}
+		if prev.dst != nil && (*prev.dst).Cmp(*f.dst) > 0 {
+			return nil, fmt.Errorf("fork %s (time=%d) can't be before %s (time=%d)", f.name, *f.dst, prev.name, *prev.dst)
+		}
+		prev = f
+	}
+	for _, f := range o.forks(config) {
+		if f.time != nil {
+			*f.dst = f.time
+		}
+	}
+	return changed, nil
+}
+
+// ApplyForkOverrides - applies overrides to config (which is shared with running components) and persists it.
+func ApplyForkOverrides(tx kv.RwTx, config *chain.Config, genesisHash common.Hash, o *ForkOverrides, logger log.Logger) error {
+	var headTime uint64
+	if head := rawdb.ReadCurrentHeader(tx); head != nil {
+		headTime = head.Time
+	}
+	changed, err := o.Apply(config, headTime)
+	if err != nil {
+		return fmt.Errorf("fork overrides: %w", err)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	if err := WriteChainConfig(tx, genesisHash, config); err != nil {
+		return err
+	}
+	logger.Info("[fork-overrides] applied", "forks", changed, "shanghai", config.ShanghaiTime, "cancun", config.CancunTime, "prague", config.PragueTime, "osaka", config.OsakaTime)
+	return nil
+}
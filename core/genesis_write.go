@@ -51,6 +51,7 @@ import (
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
 	params2 "github.com/erigontech/erigon/params"
 )
 
@@ -182,6 +183,9 @@ func WriteGenesisBlock(tx kv.RwTx, genesis *types.Genesis, overridePragueTime *b
 	if err := newCfg.CheckConfigForkOrder(); err != nil {
 		return newCfg, nil, err
 	}
+	if err := vm.CheckPrecompiles(newCfg); err != nil {
+		return newCfg, nil, err
+	}
 	storedCfg, storedErr := ReadChainConfig(tx, storedHash)
 	if storedErr != nil && newCfg.Bor == nil {
 		return newCfg, nil, storedErr
@@ -688,6 +692,11 @@ func GenesisBlockByChainName(chain string) *types.Genesis {
 	case networkname.Test:
 		return TestGenesisBlock()
 	default:
+		if c := params2.CustomChainByName(chain); c != nil {
+			g, config := *c.Genesis, *c.Genesis.Config // callers may apply overrides to config
+			g.Config = &config
+			return &g
+		}
 		return nil
 	}
 }
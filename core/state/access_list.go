@@ -20,7 +20,10 @@
 package state
 
 import (
+	"slices"
+
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
 )
 
 type accessList struct {
@@ -57,6 +60,45 @@ func (al *accessList) Contains(address common.Address, slot common.Hash) (addres
 	return true, slotPresent
 }
 
+// accessRecord collects the addresses and storage slots looked up in the access list
+// during a txn. Unlike the access list it is not journalled, so the accesses of reverted
+// calls stay recorded.
+type accessRecord map[common.Address]map[common.Hash]struct{}
+
+func (r accessRecord) addAddress(addr common.Address) {
+	if _, ok := r[addr]; !ok {
+		r[addr] = nil
+	}
+}
+
+func (r accessRecord) addSlot(addr common.Address, slot common.Hash) {
+	slots := r[addr]
+	if slots == nil {
+		slots = make(map[common.Hash]struct{})
+		r[addr] = slots
+	}
+	slots[slot] = struct{}{}
+}
+
+// accessList returns the record as an EIP-2930 access list sorted by address and slot,
+// leaving out the excluded addresses unless their storage was accessed.
+func (r accessRecord) accessList(exclude []common.Address) types.AccessList {
+	al := make(types.AccessList, 0, len(r))
+	for addr, slots := range r {
+		if len(slots) == 0 && slices.Contains(exclude, addr) {
+			continue
+		}
+		keys := make([]common.Hash, 0, len(slots))
+		for slot := range slots {
+			keys = append(keys, slot)
+		}
+		slices.SortFunc(keys, func(a, b common.Hash) int { return a.Cmp(b) })
+		al = append(al, types.AccessTuple{Address: addr, StorageKeys: keys})
+	}
+	slices.SortFunc(al, func(a, b types.AccessTuple) int { return a.Address.Cmp(b.Address) })
+	return al
+}
+
 // newAccessList creates a new accessList.
 func newAccessList() *accessList {
 	return &accessList{
@@ -193,7 +193,7 @@ func (d *Dumper) DumpToCollector(c DumpCollector, excludeCode, excludeStorage bo
 			account.CodeHash = hexutil.Bytes(acc.CodeHash.Bytes())
 
 			if !excludeCode {
-				r, _, err := ttx.GetLatest(kv.CodeDomain, k)
+				r, _, err := ttx.GetAsOf(kv.CodeDomain, k, txNum)
 				if err != nil {
 					return nil, err
 				}
@@ -86,6 +86,8 @@ type IntraBlockState struct {
 
 	// Per-transaction access list
 	accessList *accessList
+	// Addresses and slots accessed by the transaction, nil unless recording (see RecordAccessList)
+	accessRecord accessRecord
 
 	// Transient storage
 	transientStorage transientStorage
@@ -164,6 +166,7 @@ func (sdb *IntraBlockState) Reset() {
 	//clear(sdb.stateObjectsDirty)
 	clear(sdb.logs) // free pointers
 	sdb.logs = sdb.logs[:0]
+	clear(sdb.accessRecord)
 	sdb.balanceInc = make(map[common.Address]*BalanceIncrease)
 	//clear(sdb.balanceInc)
 	sdb.txIndex = 0
@@ -216,14 +219,14 @@ func (sdb *IntraBlockState) Logs() types.Logs {
 
 // AddRefund adds gas to the refund counter
 func (sdb *IntraBlockState) AddRefund(gas uint64) {
-	sdb.journal.append(refundChange{prev: sdb.refund})
+	sdb.journal.appendRefund(refundChange{prev: sdb.refund})
 	sdb.refund += gas
 }
 
 // SubRefund removes gas from the refund counter.
 // This method will panic if the refund counter goes below zero
 func (sdb *IntraBlockState) SubRefund(gas uint64) {
-	sdb.journal.append(refundChange{prev: sdb.refund})
+	sdb.journal.appendRefund(refundChange{prev: sdb.refund})
 	if gas > sdb.refund {
 		sdb.setErrorUnsafe(errors.New("refund counter below zero"))
 	}
@@ -636,8 +639,8 @@ func (sdb *IntraBlockState) SetTransientState(addr common.Address, key common.Ha
 		return
 	}
 
-	sdb.journal.append(transientStorageChange{
-		account:  &addr,
+	sdb.journal.appendTransientStorage(transientStorageChange{
+		account:  addr,
 		key:      key,
 		prevalue: prev,
 	})
@@ -783,10 +786,14 @@ func (sdb *IntraBlockState) Snapshot() int {
 
 // RevertToSnapshot reverts all state changes made since the given revision.
 func (sdb *IntraBlockState) RevertToSnapshot(revid int) {
-	// Find the snapshot in the stack of valid snapshots.
-	idx := sort.Search(len(sdb.validRevisions), func(i int) bool {
-		return sdb.validRevisions[i].id >= revid
-	})
+	// Find the snapshot in the stack of valid snapshots. Reverts nearly always
+	// undo the innermost call, so check the top of the stack first.
+	idx := len(sdb.validRevisions) - 1
+	if idx < 0 || sdb.validRevisions[idx].id != revid {
+		idx = sort.Search(len(sdb.validRevisions), func(i int) bool {
+			return sdb.validRevisions[i].id >= revid
+		})
+	}
 	if idx == len(sdb.validRevisions) || sdb.validRevisions[idx].id != revid {
 		panic(fmt.Errorf("revision id %v cannot be reverted", revid))
 	}
@@ -1045,27 +1052,33 @@ func (sdb *IntraBlockState) Prepare(rules *chain.Rules, sender, coinbase common.
 
 // AddAddressToAccessList adds the given address to the access list
 func (sdb *IntraBlockState) AddAddressToAccessList(addr common.Address) (addrMod bool) {
+	if sdb.accessRecord != nil {
+		sdb.accessRecord.addAddress(addr)
+	}
 	addrMod = sdb.accessList.AddAddress(addr)
 	if addrMod {
-		sdb.journal.append(accessListAddAccountChange{&addr})
+		sdb.journal.appendAccessListAccount(accessListAddAccountChange{addr})
 	}
 	return addrMod
 }
 
 // AddSlotToAccessList adds the given (address, slot)-tuple to the access list
 func (sdb *IntraBlockState) AddSlotToAccessList(addr common.Address, slot common.Hash) (addrMod, slotMod bool) {
+	if sdb.accessRecord != nil {
+		sdb.accessRecord.addSlot(addr, slot)
+	}
 	addrMod, slotMod = sdb.accessList.AddSlot(addr, slot)
 	if addrMod {
 		// In practice, this should not happen, since there is no way to enter the
 		// scope of 'address' without having the 'address' become already added
 		// to the access list (via call-variant, create, etc).
 		// Better safe than sorry, though
-		sdb.journal.append(accessListAddAccountChange{&addr})
+		sdb.journal.appendAccessListAccount(accessListAddAccountChange{addr})
 	}
 	if slotMod {
-		sdb.journal.append(accessListAddSlotChange{
-			address: &addr,
-			slot:    &slot,
+		sdb.journal.appendAccessListSlot(accessListAddSlotChange{
+			address: addr,
+			slot:    slot,
 		})
 	}
 	return addrMod, slotMod
@@ -1073,9 +1086,36 @@ func (sdb *IntraBlockState) AddSlotToAccessList(addr common.Address, slot common
 
 // AddressInAccessList returns true if the given address is in the access list.
 func (sdb *IntraBlockState) AddressInAccessList(addr common.Address) bool {
+	if sdb.accessRecord != nil {
+		sdb.accessRecord.addAddress(addr)
+	}
 	return sdb.accessList.ContainsAddress(addr)
 }
 
 func (sdb *IntraBlockState) SlotInAccessList(addr common.Address, slot common.Hash) (addressPresent bool, slotPresent bool) {
+	if sdb.accessRecord != nil {
+		sdb.accessRecord.addSlot(addr, slot)
+	}
 	return sdb.accessList.Contains(addr, slot)
 }
+
+// RecordAccessList turns on or off the recording of addresses and storage slots accessed
+// by transactions. Accesses are recorded as the EVM looks them up in the access list, so
+// recording covers transactions from Berlin on. The record is cleared by Reset.
+func (sdb *IntraBlockState) RecordAccessList(enabled bool) {
+	switch {
+	case !enabled:
+		sdb.accessRecord = nil
+	case sdb.accessRecord == nil:
+		sdb.accessRecord = accessRecord{}
+	}
+}
+
+// RecordedAccessList returns the addresses and storage slots accessed since the last Reset,
+// sorted and without the excluded addresses with no accessed slots, or nil when not recording.
+func (sdb *IntraBlockState) RecordedAccessList(exclude []common.Address) types.AccessList {
+	if sdb.accessRecord == nil {
+		return nil
+	}
+	return sdb.accessRecord.accessList(exclude)
+}
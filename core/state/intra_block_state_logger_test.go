@@ -60,11 +60,12 @@ func TestStateLogger(t *testing.T) {
 					t.Errorf("Incorrect BalanceInc count for %s expected: %d, got:%d", common.Address{}, 2, bi.count)
 				}
 
-				if len(stateDB.journal.entries) != 2 {
-					t.Errorf("Incorrect number of jounal entries expectedBalance: %d, got:%d", 2, len(stateDB.journal.entries))
+				if stateDB.journal.length() != 2 {
+					t.Errorf("Incorrect number of jounal entries expectedBalance: %d, got:%d", 2, stateDB.journal.length())
 				}
-				for i := range stateDB.journal.entries {
-					switch balanceInc := stateDB.journal.entries[i].(type) {
+				entries := stateDB.journal.generic.entries()
+				for i := range entries {
+					switch balanceInc := entries[i].(type) {
 					case balanceIncrease:
 						var expectedInc *uint256.Int
 						if i == 0 {
@@ -76,7 +77,7 @@ func TestStateLogger(t *testing.T) {
 							t.Errorf("Incorrect BalanceInc in jounal for  %s expectedBalance: %s, got:%s", common.Address{}, expectedInc, &balanceInc.increase)
 						}
 					default:
-						t.Errorf("Invalid journal entry found:  %s", reflect.TypeOf(stateDB.journal.entries[i]))
+						t.Errorf("Invalid journal entry found:  %s", reflect.TypeOf(entries[i]))
 					}
 				}
 
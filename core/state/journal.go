@@ -34,11 +34,43 @@ type journalEntry interface {
 	dirtied() *common.Address
 }
 
+// journalKind tells which log of the journal holds an entry.
+type journalKind uint8
+
+const (
+	journalGeneric journalKind = iota
+	journalStorage
+	journalBalance
+	journalNonce
+	journalRefund
+	journalTouch
+	journalTransientStorage
+	journalAccessListAccount
+	journalAccessListSlot
+)
+
 // journal contains the list of state modifications applied since the last state
 // commit. These are tracked to be able to be reverted in case of an execution
 // exception or revertal request.
+//
+// The frequent changes (storage, balance, nonce, refund, touch, transient storage
+// and access list) are kept by value in logs of their own type, so journalling
+// them neither boxes nor allocates; all other changes go to the generic log.
+// kinds records the log of every entry in order, which is all a revert needs:
+// undoing the entries backwards pops each log from its end.
 type journal struct {
-	entries []journalEntry         // Current changes tracked by the journal
+	kinds chunkedLog[journalKind] // Log of every entry, in the order of changes
+
+	generic   chunkedLog[journalEntry]
+	storage   chunkedLog[storageChange]
+	balance   chunkedLog[balanceChange]
+	nonce     chunkedLog[nonceChange]
+	refund    chunkedLog[refundChange]
+	touch     chunkedLog[touchChange]
+	transient chunkedLog[transientStorageChange]
+	alAccount chunkedLog[accessListAddAccountChange]
+	alSlot    chunkedLog[accessListAddSlotChange]
+
 	dirties map[common.Address]int // Dirty accounts and the number of changes
 }
 
@@ -49,34 +81,121 @@ func newJournal() *journal {
 	}
 }
 func (j *journal) Reset() {
-	j.entries = j.entries[:0]
+	j.kinds.reset()
+	j.generic.reset()
+	j.storage.reset()
+	j.balance.reset()
+	j.nonce.reset()
+	j.refund.reset()
+	j.touch.reset()
+	j.transient.reset()
+	j.alAccount.reset()
+	j.alSlot.reset()
 	//j.dirties = make(map[common.Address]int, len(j.dirties)/2)
 	clear(j.dirties)
 }
 
 // append inserts a new modification entry to the end of the change journal.
 func (j *journal) append(entry journalEntry) {
-	j.entries = append(j.entries, entry)
+	j.kinds.push(journalGeneric)
+	j.generic.push(entry)
 	if addr := entry.dirtied(); addr != nil {
 		j.dirties[*addr]++
 	}
 }
 
+func (j *journal) appendStorage(ch storageChange) {
+	j.kinds.push(journalStorage)
+	j.storage.push(ch)
+	j.dirties[ch.account]++
+}
+
+func (j *journal) appendBalance(ch balanceChange) {
+	j.kinds.push(journalBalance)
+	j.balance.push(ch)
+	j.dirties[ch.account]++
+}
+
+func (j *journal) appendNonce(ch nonceChange) {
+	j.kinds.push(journalNonce)
+	j.nonce.push(ch)
+	j.dirties[ch.account]++
+}
+
+func (j *journal) appendRefund(ch refundChange) {
+	j.kinds.push(journalRefund)
+	j.refund.push(ch)
+}
+
+func (j *journal) appendTouch(ch touchChange) {
+	j.kinds.push(journalTouch)
+	j.touch.push(ch)
+	j.dirties[ch.account]++
+}
+
+func (j *journal) appendTransientStorage(ch transientStorageChange) {
+	j.kinds.push(journalTransientStorage)
+	j.transient.push(ch)
+}
+
+func (j *journal) appendAccessListAccount(ch accessListAddAccountChange) {
+	j.kinds.push(journalAccessListAccount)
+	j.alAccount.push(ch)
+}
+
+func (j *journal) appendAccessListSlot(ch accessListAddSlotChange) {
+	j.kinds.push(journalAccessListSlot)
+	j.alSlot.push(ch)
+}
+
 // revert undoes a batch of journalled modifications along with any reverted
 // dirty handling too.
 func (j *journal) revert(statedb *IntraBlockState, snapshot int) {
-	for i := len(j.entries) - 1; i >= snapshot; i-- {
-		// Undo the changes made by the operation
-		j.entries[i].revert(statedb)
-
-		// Drop any dirty tracking induced by the change
-		if addr := j.entries[i].dirtied(); addr != nil {
-			if j.dirties[*addr]--; j.dirties[*addr] == 0 {
-				delete(j.dirties, *addr)
+	for j.kinds.len() > snapshot {
+		// Undo the changes made by the operation and drop any dirty tracking
+		// induced by the change
+		switch j.kinds.pop() {
+		case journalGeneric:
+			entry := j.generic.pop()
+			entry.revert(statedb)
+			if addr := entry.dirtied(); addr != nil {
+				j.undirty(*addr)
 			}
+		case journalStorage:
+			ch := j.storage.pop()
+			ch.revert(statedb)
+			j.undirty(ch.account)
+		case journalBalance:
+			ch := j.balance.pop()
+			ch.revert(statedb)
+			j.undirty(ch.account)
+		case journalNonce:
+			ch := j.nonce.pop()
+			ch.revert(statedb)
+			j.undirty(ch.account)
+		case journalRefund:
+			ch := j.refund.pop()
+			ch.revert(statedb)
+		case journalTouch:
+			ch := j.touch.pop()
+			j.undirty(ch.account)
+		case journalTransientStorage:
+			ch := j.transient.pop()
+			ch.revert(statedb)
+		case journalAccessListAccount:
+			ch := j.alAccount.pop()
+			ch.revert(statedb)
+		case journalAccessListSlot:
+			ch := j.alSlot.pop()
+			ch.revert(statedb)
 		}
 	}
-	j.entries = j.entries[:snapshot]
+}
+
+func (j *journal) undirty(addr common.Address) {
+	if j.dirties[addr]--; j.dirties[addr] == 0 {
+		delete(j.dirties, addr)
+	}
 }
 
 // dirty explicitly sets an address to dirty, even if the change entries would
@@ -88,7 +207,61 @@ func (j *journal) dirty(addr common.Address) {
 
 // length returns the current number of entries in the journal.
 func (j *journal) length() int {
-	return len(j.entries)
+	return j.kinds.len()
+}
+
+const (
+	journalFirstChunk = 16   // Capacity of the first chunk of a log
+	journalMaxChunk   = 4096 // Capacity chunks stop doubling at
+)
+
+// chunkedLog is an append-only stack of journal entries stored in chunks of
+// growing capacity. Appending never moves earlier entries, and the chunks are
+// kept across resets so that the journal of a long-lived IntraBlockState
+// stops allocating once it has seen its largest transaction.
+type chunkedLog[T any] struct {
+	chunks [][]T // Chunks up to cur hold the entries, later ones are spare
+	cur    int
+	n      int
+}
+
+func (l *chunkedLog[T]) len() int { return l.n }
+
+func (l *chunkedLog[T]) push(v T) {
+	if len(l.chunks) == 0 {
+		l.chunks = append(l.chunks, make([]T, 0, journalFirstChunk))
+	}
+	if c := l.chunks[l.cur]; len(c) == cap(c) {
+		l.cur++
+		if l.cur == len(l.chunks) {
+			l.chunks = append(l.chunks, make([]T, 0, min(2*cap(c), journalMaxChunk)))
+		}
+	}
+	l.chunks[l.cur] = append(l.chunks[l.cur], v)
+	l.n++
+}
+
+// pop removes the last entry and returns it.
+func (l *chunkedLog[T]) pop() T {
+	c := l.chunks[l.cur]
+	if len(c) == 0 {
+		l.cur--
+		c = l.chunks[l.cur]
+	}
+	v := c[len(c)-1]
+	var zero T
+	c[len(c)-1] = zero // release whatever the entry references
+	l.chunks[l.cur] = c[:len(c)-1]
+	l.n--
+	return v
+}
+
+func (l *chunkedLog[T]) reset() {
+	for i := 0; i <= l.cur && i < len(l.chunks); i++ {
+		clear(l.chunks[i])
+		l.chunks[i] = l.chunks[i][:0]
+	}
+	l.cur, l.n = 0, 0
 }
 
 type (
@@ -108,7 +281,7 @@ type (
 
 	// Changes to individual accounts.
 	balanceChange struct {
-		account *common.Address
+		account common.Address
 		prev    uint256.Int
 	}
 	balanceIncrease struct {
@@ -119,11 +292,11 @@ type (
 		bi *BalanceIncrease
 	}
 	nonceChange struct {
-		account *common.Address
+		account common.Address
 		prev    uint64
 	}
 	storageChange struct {
-		account  *common.Address
+		account  common.Address
 		key      common.Hash
 		prevalue uint256.Int
 	}
@@ -146,20 +319,20 @@ type (
 		txIndex int
 	}
 	touchChange struct {
-		account *common.Address
+		account common.Address
 	}
 
 	// Changes to the access list
 	accessListAddAccountChange struct {
-		address *common.Address
+		address common.Address
 	}
 	accessListAddSlotChange struct {
-		address *common.Address
-		slot    *common.Hash
+		address common.Address
+		slot    common.Hash
 	}
 
 	transientStorageChange struct {
-		account  *common.Address
+		account  common.Address
 		key      common.Hash
 		prevalue uint256.Int
 	}
@@ -208,16 +381,8 @@ func (ch selfdestructChange) dirtied() *common.Address {
 
 var ripemd = common.HexToAddress("0000000000000000000000000000000000000003")
 
-func (ch touchChange) revert(s *IntraBlockState) error {
-	return nil
-}
-
-func (ch touchChange) dirtied() *common.Address {
-	return ch.account
-}
-
 func (ch balanceChange) revert(s *IntraBlockState) error {
-	obj, err := s.getStateObject(*ch.account)
+	obj, err := s.getStateObject(ch.account)
 	if err != nil {
 		return err
 	}
@@ -225,10 +390,6 @@ func (ch balanceChange) revert(s *IntraBlockState) error {
 	return nil
 }
 
-func (ch balanceChange) dirtied() *common.Address {
-	return ch.account
-}
-
 func (ch balanceIncrease) revert(s *IntraBlockState) error {
 	if bi, ok := s.balanceInc[*ch.account]; ok {
 		bi.increase.Sub(&bi.increase, &ch.increase)
@@ -253,7 +414,7 @@ func (ch balanceIncreaseTransfer) revert(s *IntraBlockState) error {
 	return nil
 }
 func (ch nonceChange) revert(s *IntraBlockState) error {
-	obj, err := s.getStateObject(*ch.account)
+	obj, err := s.getStateObject(ch.account)
 	if err != nil {
 		return err
 	}
@@ -261,10 +422,6 @@ func (ch nonceChange) revert(s *IntraBlockState) error {
 	return nil
 }
 
-func (ch nonceChange) dirtied() *common.Address {
-	return ch.account
-}
-
 func (ch codeChange) revert(s *IntraBlockState) error {
 	obj, err := s.getStateObject(*ch.account)
 	if err != nil {
@@ -279,7 +436,7 @@ func (ch codeChange) dirtied() *common.Address {
 }
 
 func (ch storageChange) revert(s *IntraBlockState) error {
-	obj, err := s.getStateObject(*ch.account)
+	obj, err := s.getStateObject(ch.account)
 	if err != nil {
 		return err
 	}
@@ -287,10 +444,6 @@ func (ch storageChange) revert(s *IntraBlockState) error {
 	return nil
 }
 
-func (ch storageChange) dirtied() *common.Address {
-	return ch.account
-}
-
 func (ch fakeStorageChange) revert(s *IntraBlockState) error {
 	obj, err := s.getStateObject(*ch.account)
 	if err != nil {
@@ -305,11 +458,7 @@ func (ch fakeStorageChange) dirtied() *common.Address {
 }
 
 func (ch transientStorageChange) revert(s *IntraBlockState) error {
-	s.setTransientState(*ch.account, ch.key, ch.prevalue)
-	return nil
-}
-
-func (ch transientStorageChange) dirtied() *common.Address {
+	s.setTransientState(ch.account, ch.key, ch.prevalue)
 	return nil
 }
 
@@ -318,10 +467,6 @@ func (ch refundChange) revert(s *IntraBlockState) error {
 	return nil
 }
 
-func (ch refundChange) dirtied() *common.Address {
-	return nil
-}
-
 func (ch addLogChange) revert(s *IntraBlockState) error {
 	txnLogs := s.logs[ch.txIndex]
 	s.logs[ch.txIndex] = txnLogs[:len(txnLogs)-1] // revert 1 log
@@ -346,19 +491,11 @@ func (ch accessListAddAccountChange) revert(s *IntraBlockState) error {
 		(addr) at this point, since no storage adds can remain when come upon
 		a single (addr) change.
 	*/
-	s.accessList.DeleteAddress(*ch.address)
-	return nil
-}
-
-func (ch accessListAddAccountChange) dirtied() *common.Address {
+	s.accessList.DeleteAddress(ch.address)
 	return nil
 }
 
 func (ch accessListAddSlotChange) revert(s *IntraBlockState) error {
-	s.accessList.DeleteSlot(*ch.address, *ch.slot)
-	return nil
-}
-
-func (ch accessListAddSlotChange) dirtied() *common.Address {
+	s.accessList.DeleteSlot(ch.address, ch.slot)
 	return nil
 }
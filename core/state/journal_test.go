@@ -0,0 +1,166 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	stateLib "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+// entries returns the entries of the log in order.
+func (l *chunkedLog[T]) entries() []T {
+	var all []T
+	for i := 0; i <= l.cur && i < len(l.chunks); i++ {
+		all = append(all, l.chunks[i]...)
+	}
+	return all
+}
+
+func TestChunkedLog(t *testing.T) {
+	t.Parallel()
+	var l chunkedLog[int]
+	for i := 0; i < 10_000; i++ {
+		l.push(i)
+	}
+	for i := 9_999; i >= 5_000; i-- {
+		require.Equal(t, i, l.pop())
+	}
+	for i := 5_000; i < 6_000; i++ {
+		l.push(i)
+	}
+	require.Equal(t, 6_000, l.len())
+	all := l.entries()
+	for i := range all {
+		require.Equal(t, i, all[i])
+	}
+
+	chunks := len(l.chunks)
+	l.reset()
+	require.Zero(t, l.len())
+	for i := 0; i < 10_000; i++ {
+		l.push(i)
+	}
+	require.Equal(t, chunks, len(l.chunks), "chunks are not reused after reset")
+}
+
+func newJournalTestState(tb testing.TB) *IntraBlockState {
+	tb.Helper()
+	_, tx, _ := NewTestTemporalDb(tb)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(tb, err)
+	tb.Cleanup(domains.Close)
+	domains.SetTxNum(1)
+	domains.SetBlockNum(1)
+	require.NoError(tb, rawdbv3.TxNums.Append(tx, 1, 1))
+	s := New(NewReaderV3(domains))
+	s.accessList = newAccessList()
+	return s
+}
+
+func TestJournalRevertMixedEntries(t *testing.T) {
+	t.Parallel()
+	s := newJournalTestState(t)
+	addr := common.HexToAddress("aa")
+	key := common.HexToHash("01")
+	require.NoError(t, s.CreateAccount(addr, true))
+	require.NoError(t, s.SetBalance(addr, uint256.NewInt(1), tracing.BalanceChangeUnspecified))
+	require.NoError(t, s.SetState(addr, &key, *uint256.NewInt(1)))
+
+	outer := s.Snapshot()
+	require.NoError(t, s.SetNonce(addr, 7))
+	s.AddRefund(10)
+	s.SetTransientState(addr, key, *uint256.NewInt(5))
+	s.AddSlotToAccessList(addr, key)
+	s.AddLog(&types.Log{Address: addr})
+
+	inner := s.Snapshot()
+	require.NoError(t, s.SetState(addr, &key, *uint256.NewInt(2)))
+	require.NoError(t, s.SetBalance(addr, uint256.NewInt(2), tracing.BalanceChangeUnspecified))
+	s.SetTransientState(addr, key, *uint256.NewInt(6))
+	s.RevertToSnapshot(inner)
+
+	var value uint256.Int
+	require.NoError(t, s.GetState(addr, &key, &value))
+	require.Equal(t, uint64(1), value.Uint64())
+	balance, err := s.GetBalance(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), balance.Uint64())
+	transient := s.GetTransientState(addr, key)
+	require.Equal(t, uint64(5), transient.Uint64())
+	nonce, err := s.GetNonce(addr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), nonce)
+
+	s.RevertToSnapshot(outer)
+	nonce, err = s.GetNonce(addr)
+	require.NoError(t, err)
+	require.Zero(t, nonce)
+	require.Zero(t, s.GetRefund())
+	transient = s.GetTransientState(addr, key)
+	require.True(t, transient.IsZero())
+	_, slotPresent := s.SlotInAccessList(addr, key)
+	require.False(t, slotPresent)
+	require.Empty(t, s.logs)
+	require.NoError(t, s.GetState(addr, &key, &value))
+	require.Equal(t, uint64(1), value.Uint64())
+}
+
+// BenchmarkJournalShallowRevert journals the changes of a transaction making
+// many calls, half of which revert right away, as DEX aggregator and MEV
+// transactions do.
+func BenchmarkJournalShallowRevert(b *testing.B) {
+	s := newJournalTestState(b)
+	addrs := make([]common.Address, 16)
+	for i := range addrs {
+		addrs[i] = common.BytesToAddress([]byte{byte(i + 1)})
+		require.NoError(b, s.CreateAccount(addrs[i], true))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for call := 0; call < 64; call++ {
+			addr := addrs[call%len(addrs)]
+			key := common.Hash{byte(call)}
+			snapshot := s.Snapshot()
+			s.AddAddressToAccessList(addr)
+			s.AddSlotToAccessList(addr, key)
+			if err := s.SetState(addr, &key, *uint256.NewInt(uint64(i + 1))); err != nil {
+				b.Fatal(err)
+			}
+			s.SetTransientState(addr, key, *uint256.NewInt(uint64(i + 1)))
+			if err := s.AddBalance(addr, uint256.NewInt(1), tracing.BalanceChangeTransfer); err != nil {
+				b.Fatal(err)
+			}
+			s.AddRefund(100)
+			if call%2 == 1 {
+				s.RevertToSnapshot(snapshot)
+			}
+		}
+		s.journal.Reset()
+		s.validRevisions = s.validRevisions[:0]
+	}
+}
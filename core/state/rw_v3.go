@@ -63,6 +63,9 @@ type ParallelExecutionState struct {
 	trace   bool
 }
 
+// RecordAccessLists - whether executed txs must record their access lists (see ethconfig.Sync.AccessLists)
+func (rs *ParallelExecutionState) RecordAccessLists() bool { return rs.syncCfg.AccessLists }
+
 func NewParallelExecutionState(domains *libstate.SharedDomains, syncCfg ethconfig.Sync, isBor bool, logger log.Logger) *ParallelExecutionState {
 	return &ParallelExecutionState{
 		domains:      domains,
@@ -243,6 +246,28 @@ func (rs *ParallelExecutionState) ApplyLogsAndTraces(txTask *TxTask, domains *li
 		}
 	}
 
+	if rs.syncCfg.AddressAppearances {
+		for addr := range txTask.AddressAppearances() {
+			if err := domains.IndexAdd(kv.AddrAppearanceIdx, addr[:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rs.syncCfg.WithdrawalsIndex {
+		for addr := range txTask.WithdrawalAddresses() {
+			if err := domains.IndexAdd(kv.WithdrawalAddrIdx, addr[:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rs.syncCfg.AccessLists {
+		if err := rawdb.WriteTxAccessList(domains, txTask.AccessList); err != nil {
+			return err
+		}
+	}
+
 	if rs.syncCfg.PersistReceiptsCacheV2 {
 		var receipt *types.Receipt
 		if txTask.TxIndex > 0 && txTask.TxIndex < len(txTask.BlockReceipts) {
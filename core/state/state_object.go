@@ -137,8 +137,8 @@ func (so *stateObject) markSelfdestructed() {
 }
 
 func (so *stateObject) touch() {
-	so.db.journal.append(touchChange{
-		account: &so.address,
+	so.db.journal.appendTouch(touchChange{
+		account: so.address,
 	})
 	if so.address == ripemd {
 		// Explicitly put it in the dirty-cache, which is otherwise generated from
@@ -217,8 +217,8 @@ func (so *stateObject) SetState(key *common.Hash, value uint256.Int) {
 		return
 	}
 	// New value is different, update and journal the change
-	so.db.journal.append(storageChange{
-		account:  &so.address,
+	so.db.journal.appendStorage(storageChange{
+		account:  so.address,
 		key:      *key,
 		prevalue: prev,
 	})
@@ -293,8 +293,8 @@ func (so *stateObject) SubBalance(amount *uint256.Int, reason tracing.BalanceCha
 }
 
 func (so *stateObject) SetBalance(amount *uint256.Int, reason tracing.BalanceChangeReason) {
-	so.db.journal.append(balanceChange{
-		account: &so.address,
+	so.db.journal.appendBalance(balanceChange{
+		account: so.address,
 		prev:    so.data.Balance,
 	})
 	if so.db.tracingHooks != nil && so.db.tracingHooks.OnBalanceChange != nil {
@@ -360,8 +360,8 @@ func (so *stateObject) setCode(codeHash common.Hash, code []byte) {
 }
 
 func (so *stateObject) SetNonce(nonce uint64) {
-	so.db.journal.append(nonceChange{
-		account: &so.address,
+	so.db.journal.appendNonce(nonceChange{
+		account: so.address,
 		prev:    so.data.Nonce,
 	})
 	if so.db.tracingHooks != nil && so.db.tracingHooks.OnNonceChange != nil {
@@ -81,9 +81,12 @@ type TxTask struct {
 	Logs               []*types.Log
 	TraceFroms         map[common.Address]struct{}
 	TraceTos           map[common.Address]struct{}
+	AccessList         types.AccessList // addresses and slots accessed by txn, nil unless recorded
 
 	UsedGas uint64
 
+	DepositNonce *uint64 // OP Stack deposit: nonce of sender before execution, deposits have no own nonce
+
 	// BlockReceipts is used only by Gnosis:
 	//  - it does store `proof, err := rlp.EncodeToBytes(ValidatorSetProof{Header: header, Receipts: r})`
 	//  - and later read it by filter: len(l.Topics) == 2 && l.Address == s.contractAddress && l.Topics[0] == EVENT_NAME_HASH && l.Topics[1] == header.ParentHash
@@ -145,7 +148,8 @@ func (t *TxTask) CreateReceipt(tx kv.TemporalTx) {
 	}
 
 	cumulativeGasUsed += t.UsedGas
-	if t.UsedGas == 0 {
+	// system deposits don't use gas before Regolith
+	if t.UsedGas == 0 && (t.TxAsMessage == nil || !t.TxAsMessage.IsSystemTx()) {
 		msg := fmt.Sprintf("no gas used stack: %s tx %+v", dbg.Stack(), t.Tx)
 		panic(msg)
 	}
@@ -186,13 +190,74 @@ func (t *TxTask) createReceipt(cumulativeGasUsed uint64, firstLogIndex uint32) *
 	}
 
 	receipt.Bloom = types.LogsBloom(receipt.Logs) // why do we need to add this?
+	nonce := t.Tx.GetNonce()
+	if t.DepositNonce != nil {
+		nonce = *t.DepositNonce
+		receipt.SetDepositNonce(t.Config, t.Header.Time, nonce)
+	}
 	// if the transaction created a contract, store the creation address in the receipt.
 	if t.TxAsMessage != nil && t.TxAsMessage.To() == nil {
-		receipt.ContractAddress = crypto.CreateAddress(*t.Sender(), t.Tx.GetNonce())
+		receipt.ContractAddress = crypto.CreateAddress(*t.Sender(), nonce)
 	}
 
 	return receipt
 }
+
+// AddressAppearances - addresses which appeared in txn: senders and recipients of all calls (including internal),
+// emitters of logs and addresses in indexed topics of logs (token transfers). Block coinbase and withdrawals
+// recipients appear in final (block-level) txn.
+func (t *TxTask) AddressAppearances() map[common.Address]struct{} {
+	res := make(map[common.Address]struct{}, len(t.TraceFroms)+len(t.TraceTos)+len(t.Logs))
+	for addr := range t.TraceFroms {
+		res[addr] = struct{}{}
+	}
+	for addr := range t.TraceTos {
+		res[addr] = struct{}{}
+	}
+	for _, lg := range t.Logs {
+		res[lg.Address] = struct{}{}
+		for i := 1; i < len(lg.Topics); i++ {
+			if addr, ok := topicAddress(lg.Topics[i]); ok {
+				res[addr] = struct{}{}
+			}
+		}
+	}
+	if t.Final {
+		res[t.Coinbase] = struct{}{}
+		for _, w := range t.Withdrawals {
+			res[w.Address] = struct{}{}
+		}
+	}
+	return res
+}
+
+// WithdrawalAddresses - addresses receiving withdrawals of the block, only final txn has them
+func (t *TxTask) WithdrawalAddresses() map[common.Address]struct{} {
+	if !t.Final || len(t.Withdrawals) == 0 {
+		return nil
+	}
+	res := make(map[common.Address]struct{}, len(t.Withdrawals))
+	for _, w := range t.Withdrawals {
+		res[w.Address] = struct{}{}
+	}
+	return res
+}
+
+// topicAddress - topic looks like address: 12 leading zero bytes, and it's not small number (amount, token id)
+func topicAddress(topic common.Hash) (common.Address, bool) {
+	for _, b := range topic[:12] {
+		if b != 0 {
+			return common.Address{}, false
+		}
+	}
+	for _, b := range topic[12:24] {
+		if b != 0 {
+			return common.BytesToAddress(topic[12:]), true
+		}
+	}
+	return common.Address{}, false
+}
+
 func (t *TxTask) Reset() *TxTask {
 	t.BalanceIncreaseSet = nil
 	returnReadList(t.ReadLists)
@@ -202,6 +267,7 @@ func (t *TxTask) Reset() *TxTask {
 	t.Logs = nil
 	t.TraceFroms = nil
 	t.TraceTos = nil
+	t.AccessList = nil
 	t.Error = nil
 	t.Failed = false
 	return t
@@ -66,6 +66,14 @@ func applyTransaction(config *chain.Config, engine consensus.EngineReader, gp *G
 		txContext.TxHash = txn.Hash()
 	}
 
+	// OP Stack deposits have no own nonce: receipt records nonce of sender
+	nonce := txn.GetNonce()
+	if msg.IsDepositTx() {
+		if nonce, err = ibs.GetNonce(msg.From()); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Update the evm with the new transaction context.
 	evm.Reset(txContext, ibs)
 	result, err := ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, engine)
@@ -93,9 +101,12 @@ func applyTransaction(config *chain.Config, engine consensus.EngineReader, gp *G
 		}
 		receipt.TxHash = txn.Hash()
 		receipt.GasUsed = result.UsedGas
+		if msg.IsDepositTx() {
+			receipt.SetDepositNonce(config, header.Time, nonce)
+		}
 		// if the transaction created a contract, store the creation address in the receipt.
 		if msg.To() == nil {
-			receipt.ContractAddress = crypto.CreateAddress(evm.Origin, txn.GetNonce())
+			receipt.ContractAddress = crypto.CreateAddress(evm.Origin, nonce)
 		}
 		// Set the receipt logs and create a bloom for filtering
 		receipt.Logs = ibs.GetLogs(ibs.TxnIndex(), txn.Hash(), blockNum, header.Hash())
@@ -79,6 +79,9 @@ type StateTransition struct {
 	state        evmtypes.IntraBlockState
 	evm          *vm.EVM
 
+	l1Fee          *uint256.Int // rollup L1 data fee, nil on L1 chains
+	l1FeeRecipient common.Address
+
 	//some pre-allocated intermediate variables
 	sharedBuyGas        *uint256.Int
 	sharedBuyGasBalance *uint256.Int
@@ -106,6 +109,13 @@ type Message interface {
 
 	IsFree() bool // service transactions on Gnosis are exempt from EIP-1559 mandatory fees
 	SetIsFree(bool)
+
+	RollupCostData() *types.RollupCostData // nil on non-rollup chains
+
+	// OP Stack deposit transactions: gas is bought on L1, ETH may be minted to sender
+	IsDepositTx() bool
+	IsSystemTx() bool
+	Mint() *uint256.Int
 }
 
 // NewStateTransition initialises and returns a new state transition object.
@@ -187,6 +197,17 @@ func (st *StateTransition) buyGas(gasBailout bool) error {
 		}
 	}
 
+	// rollup L1 data fee is charged upfront, together with gas
+	if costFunc, costData := st.evm.Context.L1Cost, st.msg.RollupCostData(); costFunc != nil && costData != nil {
+		l1Fee, recipient, err := costFunc(st.state, *costData, st.evm.Context.Time)
+		if err != nil {
+			return fmt.Errorf("%w: l1 cost: %w", ErrStateTransitionFailed, err)
+		}
+		if l1Fee != nil && !l1Fee.IsZero() {
+			st.l1Fee, st.l1FeeRecipient = l1Fee, recipient
+		}
+	}
+
 	if !gasBailout {
 		balanceCheck := gasVal
 		if st.feeCap != nil {
@@ -210,6 +231,12 @@ func (st *StateTransition) buyGas(gasBailout bool) error {
 				}
 			}
 		}
+		if st.l1Fee != nil {
+			balanceCheck, overflow = new(uint256.Int).AddOverflow(balanceCheck, st.l1Fee)
+			if overflow {
+				return fmt.Errorf("%w: address %v", ErrInsufficientFunds, st.msg.From().Hex())
+			}
+		}
 		balance, err := st.state.GetBalance(st.msg.From())
 		if err != nil {
 			return err
@@ -219,6 +246,11 @@ func (st *StateTransition) buyGas(gasBailout bool) error {
 		}
 		st.state.SubBalance(st.msg.From(), gasVal, tracing.BalanceDecreaseGasBuy)
 		st.state.SubBalance(st.msg.From(), blobGasVal, tracing.BalanceDecreaseGasBuy)
+		if st.l1Fee != nil {
+			st.state.SubBalance(st.msg.From(), st.l1Fee, tracing.BalanceDecreaseGasBuy)
+		}
+	} else {
+		st.l1Fee = nil // nothing was charged
 	}
 
 	if err := st.gp.SubGas(st.msg.Gas()); err != nil {
@@ -247,8 +279,24 @@ func CheckEip1559TxGasFeeCap(from common.Address, feeCap, tipCap, baseFee *uint2
 	return nil
 }
 
+// buyDepositGas - gas of OP Stack deposit is bought on L1: nothing is charged on L2 and fee checks don't apply
+func (st *StateTransition) buyDepositGas() error {
+	st.gasRemaining += st.msg.Gas()
+	st.initialGas = st.msg.Gas()
+	if st.msg.IsSystemTx() {
+		if st.evm.ChainConfig().IsRegolith(st.evm.Context.Time) {
+			return fmt.Errorf("%w: address %v", ErrSystemTxNotSupported, st.msg.From().Hex())
+		}
+		return nil // system transactions are not metered against block gas limit
+	}
+	return st.gp.SubGas(st.msg.Gas())
+}
+
 // DESCRIBED: docs/programmers_guide/guide.md#nonce
 func (st *StateTransition) preCheck(gasBailout bool) error {
+	if st.msg.IsDepositTx() {
+		return st.buyDepositGas()
+	}
 	// Make sure this transaction's nonce is correct.
 	if st.msg.CheckNonce() {
 		stNonce, err := st.state.GetNonce(st.msg.From())
@@ -391,6 +439,38 @@ func (st *StateTransition) ApplyFrame() (*evmtypes.ExecutionResult, error) {
 // However if any consensus issue encountered, return the error directly with
 // nil evm execution result.
 func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtypes.ExecutionResult, error) {
+	if !st.msg.IsDepositTx() {
+		return st.transitionDb(refunds, gasBailout)
+	}
+
+	// OP Stack deposit can't be rejected: it's minted first, and if it fails to apply, state changes
+	// after minting are reverted and it's included as failed, consuming all its gas
+	if mint := st.msg.Mint(); mint != nil {
+		if err := st.state.AddBalance(st.msg.From(), mint, tracing.BalanceChangeUnspecified); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrStateTransitionFailed, err)
+		}
+	}
+	snapshot := st.state.Snapshot()
+	result, err := st.transitionDb(refunds, gasBailout)
+	if err == nil || errors.Is(err, ErrGasLimitReached) || errors.Is(err, ErrStateTransitionFailed) {
+		return result, err
+	}
+	st.state.RevertToSnapshot(snapshot)
+	nonce, nonceErr := st.state.GetNonce(st.msg.From())
+	if nonceErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateTransitionFailed, nonceErr)
+	}
+	if nonceErr = st.state.SetNonce(st.msg.From(), nonce+1); nonceErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStateTransitionFailed, nonceErr)
+	}
+	usedGas := st.msg.Gas()
+	if st.msg.IsSystemTx() && !st.evm.ChainConfig().IsRegolith(st.evm.Context.Time) {
+		usedGas = 0
+	}
+	return &evmtypes.ExecutionResult{UsedGas: usedGas, Err: fmt.Errorf("failed deposit: %w", err)}, nil
+}
+
+func (st *StateTransition) transitionDb(refunds bool, gasBailout bool) (*evmtypes.ExecutionResult, error) {
 	coinbase := st.evm.Context.Coinbase
 	senderInitBalance, err := st.state.GetBalance(st.msg.From())
 	if err != nil {
@@ -457,6 +537,17 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 	}
 	st.gasRemaining -= gas
 
+	// Deposits skip buyGas, where value is checked against balance for other transactions
+	if msg.IsDepositTx() && !msg.Value().IsZero() {
+		canTransfer, err := st.evm.Context.CanTransfer(st.state, msg.From(), msg.Value())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrStateTransitionFailed, err)
+		}
+		if !canTransfer {
+			return nil, fmt.Errorf("%w: address %v", ErrInsufficientFundsForTransfer, msg.From().Hex())
+		}
+	}
+
 	var bailout bool
 	// Gas bailout (for trace_call) should only be applied if there is not sufficient balance to perform value transfer
 	if gasBailout {
@@ -489,11 +580,31 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 		// nonce to calculate the address of the contract that is being created
 		// It does get incremented inside the `Create` call, after the computation
 		// of the contract's address, but before the execution of the code.
-		ret, _, st.gasRemaining, vmerr = st.evm.Create(sender, st.data, st.gasRemaining, st.value, bailout)
+		if rules.IsOsaka && vm.HasEOFMagic(st.data) {
+			ret, _, st.gasRemaining, vmerr = st.evm.EOFCreateTx(sender, st.data, st.gasRemaining, st.value, bailout)
+		} else {
+			ret, _, st.gasRemaining, vmerr = st.evm.Create(sender, st.data, st.gasRemaining, st.value, bailout)
+		}
 	} else {
 		ret, st.gasRemaining, vmerr = st.evm.Call(sender, st.to(), st.data, st.gasRemaining, st.value, bailout)
 	}
 
+	if msg.IsDepositTx() && !st.evm.ChainConfig().IsRegolith(st.evm.Context.Time) {
+		// before Regolith deposits are reported as using all their gas, system transactions none
+		usedGas := msg.Gas()
+		if msg.IsSystemTx() {
+			usedGas = 0
+		}
+		return &evmtypes.ExecutionResult{
+			UsedGas:             usedGas,
+			Err:                 vmerr,
+			Reverted:            vmerr == vm.ErrExecutionReverted,
+			ReturnData:          ret,
+			SenderInitBalance:   senderInitBalance,
+			CoinbaseInitBalance: coinbaseInitBalance,
+		}, nil
+	}
+
 	if refunds && !gasBailout {
 		refundQuotient := params.RefundQuotient
 		if rules.IsLondon {
@@ -511,6 +622,24 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 		st.gasRemaining = st.initialGas - max(floorGas7623, st.gasUsed())
 	}
 
+	if msg.IsDepositTx() {
+		// deposit fees are paid on L1: no tip, burn or L1 data fee
+		result := &evmtypes.ExecutionResult{
+			UsedGas:             st.gasUsed(),
+			Err:                 vmerr,
+			Reverted:            vmerr == vm.ErrExecutionReverted,
+			ReturnData:          ret,
+			SenderInitBalance:   senderInitBalance,
+			CoinbaseInitBalance: coinbaseInitBalance,
+			FeeTipped:           new(uint256.Int),
+			EvmRefund:           st.state.GetRefund(),
+		}
+		if st.evm.Context.PostApplyMessage != nil {
+			st.evm.Context.PostApplyMessage(st.state, msg.From(), coinbase, result)
+		}
+		return result, nil
+	}
+
 	effectiveTip := st.gasPrice
 	if rules.IsLondon {
 		if st.feeCap.Gt(st.evm.Context.BaseFee) {
@@ -535,6 +664,11 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 			}
 		}
 	}
+	if st.l1Fee != nil {
+		if err := st.state.AddBalance(st.l1FeeRecipient, st.l1Fee, tracing.BalanceChangeUnspecified); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrStateTransitionFailed, err)
+		}
+	}
 
 	result := &evmtypes.ExecutionResult{
 		UsedGas:             st.gasUsed(),
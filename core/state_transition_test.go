@@ -0,0 +1,149 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/tests"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// TestDepositTransition tests that OP Stack deposits mint before execution and, when failed, are still
+// applied: minted ETH is kept, sender nonce is bumped and the whole gas limit is used
+func TestDepositTransition(t *testing.T) {
+	t.Parallel()
+	config := *chain.TestChainConfig
+	config.Optimism = &chain.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	config.RegolithTime = big.NewInt(10)
+
+	from := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	to := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	alloc := types.GenesisAlloc{
+		from: types.GenesisAccount{Nonce: 3, Balance: big.NewInt(1000)},
+	}
+
+	apply := func(t *testing.T, time uint64, tx *types.DepositTx) (*state.IntraBlockState, *evmtypes.ExecutionResult, error) {
+		t.Helper()
+		m := mock.Mock(t)
+		dbTx, err := m.DB.BeginRw(m.Ctx)
+		require.NoError(t, err)
+		t.Cleanup(dbTx.Rollback)
+
+		rules := config.Rules(1, time)
+		ibs, err := tests.MakePreState(rules, dbTx, alloc, 1)
+		require.NoError(t, err)
+		blockContext := evmtypes.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    consensus.Transfer,
+			BlockNumber: 1,
+			Time:        time,
+			Difficulty:  big.NewInt(0),
+			GasLimit:    30_000_000,
+		}
+		msg, err := tx.AsMessage(*types.LatestSigner(&config), nil, rules)
+		require.NoError(t, err)
+		evm := vm.NewEVM(blockContext, core.NewEVMTxContext(msg), ibs, &config, vm.Config{})
+		result, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(blockContext.GasLimit), true /* refunds */, false /* gasBailout */, nil /* engine */)
+		return ibs, result, err
+	}
+
+	t.Run("mint", func(t *testing.T) {
+		ibs, result, err := apply(t, 10, &types.DepositTx{
+			From:     from,
+			To:       &to,
+			Mint:     uint256.NewInt(5000),
+			Value:    uint256.NewInt(5500),
+			GasLimit: 100_000,
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+		require.Equal(t, uint64(21_000), result.UsedGas)
+		balance, err := ibs.GetBalance(from)
+		require.NoError(t, err)
+		require.Equal(t, uint256.NewInt(500), balance)
+		balance, err = ibs.GetBalance(to)
+		require.NoError(t, err)
+		require.Equal(t, uint256.NewInt(5500), balance)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		ibs, result, err := apply(t, 10, &types.DepositTx{
+			From:     from,
+			To:       &to,
+			Mint:     uint256.NewInt(5000),
+			Value:    uint256.NewInt(1_000_000),
+			GasLimit: 100_000,
+		})
+		require.NoError(t, err)
+		require.Error(t, result.Err)
+		require.Equal(t, uint64(100_000), result.UsedGas)
+		balance, err := ibs.GetBalance(from)
+		require.NoError(t, err)
+		require.Equal(t, uint256.NewInt(6000), balance)
+		nonce, err := ibs.GetNonce(from)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), nonce)
+	})
+
+	t.Run("pre-regolith", func(t *testing.T) {
+		_, result, err := apply(t, 5, &types.DepositTx{
+			From:                from,
+			To:                  &to,
+			Value:               new(uint256.Int),
+			GasLimit:            100_000,
+			IsSystemTransaction: true,
+		})
+		require.NoError(t, err)
+		require.NoError(t, result.Err)
+		require.Zero(t, result.UsedGas)
+
+		_, result, err = apply(t, 5, &types.DepositTx{
+			From:     from,
+			To:       &to,
+			Value:    new(uint256.Int),
+			GasLimit: 100_000,
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint64(100_000), result.UsedGas)
+	})
+
+	t.Run("system tx since regolith", func(t *testing.T) {
+		_, result, err := apply(t, 10, &types.DepositTx{
+			From:                from,
+			To:                  &to,
+			Value:               new(uint256.Int),
+			GasLimit:            100_000,
+			IsSystemTransaction: true,
+		})
+		require.NoError(t, err)
+		require.ErrorIs(t, result.Err, core.ErrSystemTxNotSupported)
+		require.Equal(t, uint64(100_000), result.UsedGas)
+	})
+}
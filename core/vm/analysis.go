@@ -19,6 +19,12 @@
 
 package vm
 
+import (
+	"sort"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+)
+
 // codeBitmap collects data locations in code.
 func codeBitmap(code []byte) bitvec {
 	// The bitmap is 4 bytes longer than necessary, in case the code
@@ -65,3 +71,82 @@ func (bits bitvec) setN(flag uint64, pc uint64) {
 func (bits bitvec) codeSegment(pos uint64) bool {
 	return ((bits[pos/64] >> (pos % 64)) & 1) == 0
 }
+
+// basicBlock is a run of instructions that is only entered at its first
+// instruction and only left after its last one. A block ends before a
+// JUMPDEST and at every instruction that jumps, halts or has a dynamic gas
+// cost, so no instruction but the last can change the gas beyond its
+// constant cost.
+type basicBlock struct {
+	start, end uint64 // pc of the first and of the last instruction
+	gas        uint64 // constant gas of all instructions
+	minStack   int    // stack items needed on entry
+	maxGrowth  int    // peak stack height above the entry height
+}
+
+// blockTable holds the basic blocks of a piece of code under one jump table.
+type blockTable struct {
+	jt     *JumpTable
+	starts bitvec // set bits mark the first pc of a block
+	blocks []basicBlock
+}
+
+// analyseBasicBlocks splits code into basic blocks and sums up their static
+// gas and stack requirements under jt.
+func analyseBasicBlocks(code []byte, jt *JumpTable) *blockTable {
+	t := &blockTable{jt: jt, starts: make(bitvec, (len(code)+63)/64)}
+	var (
+		b      *basicBlock
+		height int // stack height relative to the block entry
+	)
+	for pc := uint64(0); pc < uint64(len(code)); {
+		op := OpCode(code[pc])
+		operation := jt[op]
+		if op == JUMPDEST {
+			b = nil
+		}
+		if b == nil {
+			t.starts.set1(pc)
+			t.blocks = append(t.blocks, basicBlock{start: pc})
+			b, height = &t.blocks[len(t.blocks)-1], 0
+		}
+		if need := operation.numPop - height; need > b.minStack {
+			b.minStack = need
+		}
+		height += operation.numPush - operation.numPop
+		if height > b.maxGrowth {
+			b.maxGrowth = height
+		}
+		b.gas += operation.constantGas
+		b.end = pc
+
+		pc++
+		if op >= PUSH1 && op <= PUSH32 {
+			pc += uint64(op - PUSH0)
+		}
+		switch op {
+		case JUMP, JUMPI, STOP, RETURN, REVERT, SELFDESTRUCT, INVALID:
+			b = nil
+		default:
+			if operation.dynamicGas != nil || operation.undefined {
+				b = nil
+			}
+		}
+	}
+	return t
+}
+
+// enter reports whether the block starting at pc can run from the given gas
+// and stack height without running out of gas or over the stack bounds, and
+// returns the pc of its last instruction if so.
+func (t *blockTable) enter(pc, gas uint64, height int) (uint64, bool) {
+	if pc/64 >= uint64(len(t.starts)) || t.starts.codeSegment(pc) {
+		return 0, false
+	}
+	i := sort.Search(len(t.blocks), func(i int) bool { return t.blocks[i].start >= pc })
+	b := &t.blocks[i]
+	if gas < b.gas || height < b.minStack || height+b.maxGrowth > int(params.StackLimit) {
+		return 0, false
+	}
+	return b.end, true
+}
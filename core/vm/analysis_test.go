@@ -57,6 +57,80 @@ func TestJumpDestAnalysis(t *testing.T) {
 	}
 }
 
+func TestBasicBlockAnalysis(t *testing.T) {
+	t.Parallel()
+	code := []byte{
+		byte(PUSH1), 0x01, byte(PUSH1), 0x02, byte(ADD), byte(PUSH1), 0x08, byte(JUMP), // 0: ends at JUMP
+		byte(JUMPDEST), byte(POP), // 8: ends before JUMPDEST
+		byte(JUMPDEST), byte(SLOAD), // 10: ends at the dynamic gas of SLOAD
+		byte(STOP), // 12
+	}
+	table := analyseBasicBlocks(code, &berlinInstructionSet)
+	exp := []basicBlock{
+		{start: 0, end: 7, gas: 20, minStack: 0, maxGrowth: 2},
+		{start: 8, end: 9, gas: 3, minStack: 1, maxGrowth: 0},
+		{start: 10, end: 11, gas: 1, minStack: 1, maxGrowth: 0},
+		{start: 12, end: 12, gas: 0, minStack: 0, maxGrowth: 0},
+	}
+	if len(table.blocks) != len(exp) {
+		t.Fatalf("expected %d blocks, got %d: %+v", len(exp), len(table.blocks), table.blocks)
+	}
+	for i := range exp {
+		if table.blocks[i] != exp[i] {
+			t.Errorf("block %d: expected %+v, got %+v", i, exp[i], table.blocks[i])
+		}
+	}
+
+	for _, test := range []struct {
+		pc, gas uint64
+		height  int
+		end     uint64
+		ok      bool
+	}{
+		{pc: 0, gas: 20, height: 0, end: 7, ok: true},
+		{pc: 0, gas: 19, height: 0},                      // out of gas within the block
+		{pc: 0, gas: 20, height: 1022, end: 7, ok: true}, // peaks at the stack limit
+		{pc: 0, gas: 20, height: 1023},                   // overflows within the block
+		{pc: 8, gas: 3, height: 0},                       // underflows at POP
+		{pc: 8, gas: 3, height: 1, end: 9, ok: true},
+		{pc: 9, gas: 3, height: 1},  // not the start of a block
+		{pc: 13, gas: 3, height: 1}, // past the end of the code
+	} {
+		end, ok := table.enter(test.pc, test.gas, test.height)
+		if ok != test.ok || end != test.end {
+			t.Errorf("enter(%d, %d, %d): expected %d %v, got %d %v", test.pc, test.gas, test.height, test.end, test.ok, end, ok)
+		}
+	}
+}
+
+func TestJumpDestCacheShared(t *testing.T) {
+	t.Parallel()
+	code := []byte{byte(PUSH1), 0x04, byte(JUMP), byte(STOP), byte(JUMPDEST), byte(STOP)}
+	hash := crypto.Keccak256Hash(code)
+
+	first, second := NewJumpDestCache(), NewJumpDestCache()
+	a := NewContract(dummyContractRef{}, common.Address{}, nil, 0, false, first)
+	a.Code, a.CodeHash = code, hash
+	b := NewContract(dummyContractRef{}, common.Address{}, nil, 0, false, second)
+	b.Code, b.CodeHash = code, hash
+
+	if !a.isCode(4) || a.isCode(1) {
+		t.Fatal("wrong jumpdest analysis")
+	}
+	if !b.isCode(4) || b.isCode(1) {
+		t.Fatal("wrong jumpdest analysis")
+	}
+	if second.hit != 1 || a.codeAnalysis() != b.codeAnalysis() {
+		t.Fatal("analysis is not shared between caches")
+	}
+	if a.basicBlocks(&berlinInstructionSet) != b.basicBlocks(&berlinInstructionSet) {
+		t.Fatal("basic blocks are not shared between caches")
+	}
+	if a.basicBlocks(&berlinInstructionSet) == b.basicBlocks(&cancunInstructionSet) {
+		t.Fatal("basic blocks are reused across jump tables")
+	}
+}
+
 func BenchmarkJumpdestAnalysisEmpty_1200k(bench *testing.B) {
 	// 1.4 ms
 	code := make([]byte, 1200000)
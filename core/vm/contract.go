@@ -21,11 +21,12 @@ package vm
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/log/v3"
-	"github.com/hashicorp/golang-lru/v2/simplelru"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/holiman/uint256"
 
 	"github.com/erigontech/erigon/core/tracing"
@@ -59,6 +60,7 @@ type Contract struct {
 	self          common.Address
 	jumpdests     *JumpDestCache // Aggregated result of JUMPDEST analysis.
 	analysis      bitvec         // Locally cached result of JUMPDEST analysis
+	analysed      *codeAnalysis  // Shared analysis of the code, nil for initcode
 	skipAnalysis  bool
 
 	Code     []byte
@@ -68,25 +70,57 @@ type Contract struct {
 
 	Gas   uint64
 	value *uint256.Int
+
+	// EOF execution state; eof is nil for legacy code.
+	eof         *eofContainer
+	codeSection int
+	returnStack []eofReturnFrame
+}
+
+// codeAnalysis is the result of analysing the code behind one code hash.
+type codeAnalysis struct {
+	jumpdests bitvec
+	// blocks are the basic blocks of the code under the jump table they were
+	// last built for; they are rebuilt when a fork changes the table.
+	blocks atomic.Pointer[blockTable]
 }
 
+// JumpDestCache gives an EVM access to the code analysis cache shared by all
+// EVMs of the process, so that popular contracts are analysed once instead of
+// once per transaction or block. hit and total count this EVM's lookups only.
 type JumpDestCache struct {
-	*simplelru.LRU[common.Hash, bitvec]
+	shared     *lru.Cache[common.Hash, *codeAnalysis]
 	hit, total int
 	trace      bool
 }
 
 var (
-	jumpDestCacheLimit = dbg.EnvInt("JD_LRU", 128)
+	jumpDestCacheLimit = dbg.EnvInt("JD_LRU", 4096)
 	jumpDestCacheTrace = dbg.EnvBool("JD_LRU_TRACE", false)
+
+	sharedCodeAnalysis = func() *lru.Cache[common.Hash, *codeAnalysis] {
+		c, err := lru.New[common.Hash, *codeAnalysis](jumpDestCacheLimit)
+		if err != nil {
+			panic(err)
+		}
+		return c
+	}()
 )
 
 func NewJumpDestCache() *JumpDestCache {
-	c, err := simplelru.NewLRU[common.Hash, bitvec](jumpDestCacheLimit, nil)
-	if err != nil {
-		panic(err)
+	return &JumpDestCache{shared: sharedCodeAnalysis, trace: jumpDestCacheTrace}
+}
+
+// get returns the analysis of code, doing it on a miss.
+func (c *JumpDestCache) get(hash common.Hash, code []byte) *codeAnalysis {
+	c.total++
+	if a, ok := c.shared.Get(hash); ok {
+		c.hit++
+		return a
 	}
-	return &JumpDestCache{LRU: c, trace: jumpDestCacheTrace}
+	a := &codeAnalysis{jumpdests: codeBitmap(code)}
+	c.shared.Add(hash, a)
+	return a
 }
 
 func (c *JumpDestCache) LogStats() {
@@ -131,35 +165,41 @@ func (c *Contract) validJumpdest(dest *uint256.Int) (bool, bool) {
 // isCode returns true if the provided PC location is an actual opcode, as
 // opposed to a data-segment following a PUSHN operation.
 func (c *Contract) isCode(udest uint64) bool {
-	// Do we have a contract hash already?
-	// If we do have a hash, that means it's a 'regular' contract. For regular
-	// contracts ( not temporary initcode), we store the analysis in a map
-	if c.CodeHash != (common.Hash{}) {
-		// Does parent context have the analysis?
-		c.jumpdests.total++
-		analysis, exist := c.jumpdests.Get(c.CodeHash)
-		if !exist {
-			// Do the analysis and save in parent context
-			// We do not need to store it in c.analysis
-			analysis = codeBitmap(c.Code)
-			c.jumpdests.Add(c.CodeHash, analysis)
+	if c.analysis == nil {
+		// Regular contracts have a code hash and share their analysis with
+		// every other execution of the same code. Initcode without a hash is
+		// analysed locally, once for all JUMPs of this execution.
+		if a := c.codeAnalysis(); a != nil {
+			c.analysis = a.jumpdests
 		} else {
-			c.jumpdests.hit++
+			c.analysis = codeBitmap(c.Code)
 		}
-		// Also stash it in current contract for faster access
-		c.analysis = analysis
-		return c.analysis.codeSegment(udest)
 	}
+	return c.analysis.codeSegment(udest)
+}
 
-	// We don't have the code hash, most likely a piece of initcode not already
-	// in state trie. In that case, we do an analysis, and save it locally, so
-	// we don't have to recalculate it for every JUMP instruction in the execution
-	// However, we don't save it within the parent context
-	if c.analysis == nil {
-		c.analysis = codeBitmap(c.Code)
+// codeAnalysis returns the shared analysis of the contract code, or nil when
+// the code has no hash.
+func (c *Contract) codeAnalysis() *codeAnalysis {
+	if c.analysed == nil && c.CodeHash != (common.Hash{}) && c.jumpdests != nil {
+		c.analysed = c.jumpdests.get(c.CodeHash, c.Code)
 	}
+	return c.analysed
+}
 
-	return c.analysis.codeSegment(udest)
+// basicBlocks returns the basic blocks of the contract code under jt, or nil
+// when the code has no shared analysis.
+func (c *Contract) basicBlocks(jt *JumpTable) *blockTable {
+	a := c.codeAnalysis()
+	if a == nil {
+		return nil
+	}
+	if t := a.blocks.Load(); t != nil && t.jt == jt {
+		return t
+	}
+	t := analyseBasicBlocks(c.Code, jt)
+	a.blocks.Store(t)
+	return t
 }
 
 // AsDelegate sets the contract to be a delegate call and returns the current
@@ -239,6 +279,19 @@ func (c *Contract) SetCallCode(addr *common.Address, hash common.Hash, code []by
 	c.CodeAddr = addr
 }
 
+// setEOF makes the contract execute the given EOF container, starting at its
+// first code section.
+func (c *Contract) setEOF(container *eofContainer) {
+	c.eof = container
+	c.setCodeSection(0)
+}
+
+// setCodeSection switches Code to the i'th code section of the container.
+func (c *Contract) setCodeSection(i int) {
+	c.codeSection = i
+	c.Code = c.eof.codeSections[i]
+}
+
 // SetCodeOptionalHash can be used to provide code, but it's optional to provide hash.
 // In case hash is not provided, the jumpdest analysis will not be saved to the parent context
 func (c *Contract) SetCodeOptionalHash(addr *common.Address, codeAndHash *codeAndHash) {
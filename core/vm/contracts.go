@@ -186,6 +186,24 @@ func init() {
 
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules *chain.Rules) []common.Address {
+	active := activeStandardPrecompiles(rules)
+	if len(rules.Precompiles) == 0 {
+		return active
+	}
+	standard := make(map[common.Address]struct{}, len(active))
+	for _, addr := range active {
+		standard[addr] = struct{}{}
+	}
+	all := append(make([]common.Address, 0, len(active)+len(rules.Precompiles)), active...)
+	for _, cfg := range rules.Precompiles {
+		if _, ok := standard[cfg.Address]; !ok {
+			all = append(all, cfg.Address)
+		}
+	}
+	return all
+}
+
+func activeStandardPrecompiles(rules *chain.Rules) []common.Address {
 	switch {
 	case rules.IsPrague:
 		return PrecompiledAddressesPrague
@@ -0,0 +1,127 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/math"
+)
+
+// PrecompileConstructor creates precompiled contract from params of chain config entry
+type PrecompileConstructor func(params json.RawMessage) (PrecompiledContract, error)
+
+var (
+	customPrecompilesLock  sync.RWMutex
+	precompileConstructors = map[string]PrecompileConstructor{}
+	customPrecompiles      = map[string]PrecompiledContract{} // by name, params and gas of chain config entry
+)
+
+// RegisterPrecompile makes implementation of additional precompiled contract available to chain configs
+// by name (see chain.PrecompileConfig). It's supposed to be called from init() of package with implementation,
+// panics if name is already registered.
+func RegisterPrecompile(name string, constructor PrecompileConstructor) {
+	customPrecompilesLock.Lock()
+	defer customPrecompilesLock.Unlock()
+	if constructor == nil {
+		panic("vm: RegisterPrecompile constructor is nil")
+	}
+	if _, dup := precompileConstructors[name]; dup {
+		panic("vm: RegisterPrecompile called twice for " + name)
+	}
+	precompileConstructors[name] = constructor
+}
+
+// CheckPrecompiles returns error if additional precompiles of chain config can't be created
+func CheckPrecompiles(config *chain.Config) error {
+	seen := make(map[common.Address]struct{}, len(config.Precompiles))
+	for i := range config.Precompiles {
+		cfg := &config.Precompiles[i]
+		if _, dup := seen[cfg.Address]; dup {
+			return fmt.Errorf("precompile %s: duplicate address %x", cfg.Name, cfg.Address)
+		}
+		seen[cfg.Address] = struct{}{}
+		if _, err := customPrecompile(cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func customPrecompile(cfg *chain.PrecompileConfig) (PrecompiledContract, error) {
+	key := cfg.Name + "/" + string(cfg.Params)
+	if cfg.Gas != nil {
+		key += fmt.Sprintf("/%d/%d", cfg.Gas.Base, cfg.Gas.PerWord)
+	}
+	customPrecompilesLock.RLock()
+	p, ok := customPrecompiles[key]
+	constructor, registered := precompileConstructors[cfg.Name]
+	customPrecompilesLock.RUnlock()
+	if ok {
+		return p, nil
+	}
+	if !registered {
+		return nil, fmt.Errorf("precompile %s: not registered", cfg.Name)
+	}
+	p, err := constructor(cfg.Params)
+	if err != nil {
+		return nil, fmt.Errorf("precompile %s: %w", cfg.Name, err)
+	}
+	if cfg.Gas != nil {
+		p = &linearGasPrecompile{PrecompiledContract: p, base: cfg.Gas.Base, perWord: cfg.Gas.PerWord}
+	}
+	customPrecompilesLock.Lock()
+	defer customPrecompilesLock.Unlock()
+	if existing, ok := customPrecompiles[key]; ok {
+		return existing, nil
+	}
+	customPrecompiles[key] = p
+	return p, nil
+}
+
+// activeCustomPrecompile returns additional precompile of chain at given address, it has priority over standard one
+func activeCustomPrecompile(rules *chain.Rules, addr common.Address) (PrecompiledContract, bool) {
+	for _, cfg := range rules.Precompiles {
+		if cfg.Address != addr {
+			continue
+		}
+		p, err := customPrecompile(cfg)
+		return p, err == nil
+	}
+	return nil, false
+}
+
+// linearGasPrecompile - implementation with gas schedule from chain config
+type linearGasPrecompile struct {
+	PrecompiledContract
+	base, perWord uint64
+}
+
+func (c *linearGasPrecompile) RequiredGas(input []byte) uint64 {
+	gas, overflow := math.SafeMul(ToWordSize(uint64(len(input))), c.perWord)
+	if overflow {
+		return math.MaxUint64
+	}
+	if gas, overflow = math.SafeAdd(gas, c.base); overflow {
+		return math.MaxUint64
+	}
+	return gas
+}
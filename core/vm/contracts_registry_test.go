@@ -0,0 +1,118 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+// testPrefixPrecompile returns input prefixed by bytes from params
+type testPrefixPrecompile struct{ prefix []byte }
+
+func (c *testPrefixPrecompile) RequiredGas(input []byte) uint64 { return 1 }
+func (c *testPrefixPrecompile) Run(input []byte) ([]byte, error) {
+	return append(common.CopyBytes(c.prefix), input...), nil
+}
+
+func init() {
+	RegisterPrecompile("test-prefix", func(params json.RawMessage) (PrecompiledContract, error) {
+		var prefix string
+		if err := json.Unmarshal(params, &prefix); err != nil {
+			return nil, err
+		}
+		return &testPrefixPrecompile{prefix: []byte(prefix)}, nil
+	})
+	RegisterPrecompile("test-broken", func(params json.RawMessage) (PrecompiledContract, error) {
+		return nil, errors.New("broken")
+	})
+}
+
+func TestCustomPrecompiles(t *testing.T) {
+	t.Parallel()
+	addr := common.BytesToAddress([]byte{0x10, 0x00})
+	config := *chain.TestChainConfig
+	config.Precompiles = []chain.PrecompileConfig{{
+		Address: addr,
+		Name:    "test-prefix",
+		Block:   big.NewInt(10),
+		Gas:     &chain.PrecompileGas{Base: 100, PerWord: 3},
+		Params:  json.RawMessage(`"abc"`),
+	}}
+	require.NoError(t, CheckPrecompiles(&config))
+
+	before := NewEVM(evmtypes.BlockContext{BlockNumber: 9}, evmtypes.TxContext{}, &dummyStatedb{}, &config, Config{})
+	_, ok := before.precompile(addr)
+	require.False(t, ok)
+	require.False(t, slices.Contains(ActivePrecompiles(before.ChainRules()), addr))
+
+	after := NewEVM(evmtypes.BlockContext{BlockNumber: 10}, evmtypes.TxContext{}, &dummyStatedb{}, &config, Config{})
+	p, ok := after.precompile(addr)
+	require.True(t, ok)
+	require.True(t, slices.Contains(ActivePrecompiles(after.ChainRules()), addr))
+	require.Len(t, ActivePrecompiles(after.ChainRules()), len(ActivePrecompiles(before.ChainRules()))+1)
+
+	out, remainingGas, err := RunPrecompiledContract(p, []byte("de"), 1000, nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte("abcde"), out)
+	require.Equal(t, uint64(1000-103), remainingGas)
+
+	// standard precompiles are still there
+	_, ok = after.precompile(common.BytesToAddress([]byte{0x01}))
+	require.True(t, ok)
+}
+
+func TestCheckPrecompiles(t *testing.T) {
+	t.Parallel()
+	addr := common.BytesToAddress([]byte{0x10, 0x00})
+	for name, precompiles := range map[string][]chain.PrecompileConfig{
+		"not registered": {{Address: addr, Name: "test-unknown"}},
+		"constructor":    {{Address: addr, Name: "test-broken"}},
+		"bad params":     {{Address: addr, Name: "test-prefix", Params: json.RawMessage(`1`)}},
+		"duplicate": {
+			{Address: addr, Name: "test-prefix", Params: json.RawMessage(`"a"`)},
+			{Address: addr, Name: "test-prefix", Params: json.RawMessage(`"b"`)},
+		},
+	} {
+		config := *chain.TestChainConfig
+		config.Precompiles = precompiles
+		require.Error(t, CheckPrecompiles(&config), name)
+	}
+}
+
+func TestLinearGasPrecompileOverflow(t *testing.T) {
+	t.Parallel()
+	p := &linearGasPrecompile{PrecompiledContract: &testPrefixPrecompile{}, base: 100, perWord: 3}
+	require.Equal(t, uint64(100), p.RequiredGas(nil))
+	require.Equal(t, uint64(106), p.RequiredGas(make([]byte, 33)))
+
+	p = &linearGasPrecompile{PrecompiledContract: &testPrefixPrecompile{}, base: 100, perWord: math.MaxUint64 / 2}
+	require.Equal(t, uint64(math.MaxUint64), p.RequiredGas(make([]byte, 96)))
+
+	p = &linearGasPrecompile{PrecompiledContract: &testPrefixPrecompile{}, base: math.MaxUint64, perWord: 1}
+	require.Equal(t, uint64(math.MaxUint64), p.RequiredGas(make([]byte, 32)))
+}
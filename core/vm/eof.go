@@ -0,0 +1,310 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// EOF container format, see https://eips.ethereum.org/EIPS/eip-3540 and
+// https://eips.ethereum.org/EIPS/eip-7620.
+const (
+	eofFormatByte = 0xef
+	eofMagicByte  = 0x00
+	eofVersion1   = 0x01
+
+	kindTypes     = 0x01
+	kindCode      = 0x02
+	kindContainer = 0x03
+	kindData      = 0xff
+
+	eofNonReturning = 0x80
+
+	eofMaxCodeSections      = 1024
+	eofMaxContainerSections = 256
+	eofMaxInputsOutputs     = 0x7f
+	eofMaxStackHeight       = 1023
+	eofReturnStackLimit     = 1024
+
+	eofTypeSize = 4
+)
+
+var (
+	ErrInvalidMagic              = errors.New("invalid magic")
+	ErrInvalidVersion            = errors.New("invalid version")
+	ErrMissingTypeHeader         = errors.New("missing type header")
+	ErrInvalidTypeSize           = errors.New("invalid type section size")
+	ErrMissingCodeHeader         = errors.New("missing code header")
+	ErrInvalidCodeSize           = errors.New("invalid code size")
+	ErrInvalidContainerSize      = errors.New("invalid container size")
+	ErrMissingDataHeader         = errors.New("missing data header")
+	ErrMissingTerminator         = errors.New("missing header terminator")
+	ErrTooManyCodeSections       = errors.New("too many code sections")
+	ErrTooManyContainerSections  = errors.New("too many container sections")
+	ErrInvalidSectionCount       = errors.New("invalid section count")
+	ErrContainerSizeMismatch     = errors.New("container size mismatch")
+	ErrInvalidSectionArgument    = errors.New("invalid section inputs/outputs")
+	ErrInvalidFirstSectionType   = errors.New("invalid first code section type")
+	ErrInvalidMaxStackHeight     = errors.New("invalid max stack height")
+	ErrTruncatedDataSection      = errors.New("data section truncated")
+	ErrInvalidEOFInitcode        = errors.New("invalid eof initcode")
+	ErrInvalidEOFAuxData         = errors.New("invalid eof aux data")
+	ErrEOFAddressHighBitsNonZero = errors.New("address has non-zero high bytes")
+)
+
+// eofMagic is the prefix of every EOF container.
+var eofMagic = []byte{eofFormatByte, eofMagicByte}
+
+// HasEOFMagic reports whether code starts with the EOF magic.
+func HasEOFMagic(code []byte) bool {
+	return len(code) >= len(eofMagic) && code[0] == eofFormatByte && code[1] == eofMagicByte
+}
+
+// eofFunctionType is an entry of the types section: the signature of the
+// corresponding code section.
+type eofFunctionType struct {
+	inputs           uint8
+	outputs          uint8
+	maxStackIncrease uint16
+}
+
+func (t eofFunctionType) returning() bool { return t.outputs != eofNonReturning }
+
+// eofContainer is a parsed EOF container. Code, subcontainer and data slices
+// alias the bytes it was parsed from.
+type eofContainer struct {
+	types         []eofFunctionType
+	codeSections  [][]byte
+	subContainers [][]byte
+	data          []byte
+	dataSize      int // declared size, may exceed len(data) in deploy containers
+
+	// dataSizePos is the offset of the data_size field in the header,
+	// patched by RETURNCONTRACT.
+	dataSizePos int
+	raw         []byte
+}
+
+// parseEOFHeader reads the container header of b and returns a container with
+// its types and data size allocated, the declared section sizes and the length
+// of the header.
+func parseEOFHeader(b []byte) (c *eofContainer, codeSizes []int, containerSizes []int, headerLen int, err error) {
+	if !HasEOFMagic(b) {
+		return nil, nil, nil, 0, ErrInvalidMagic
+	}
+	if len(b) < 3 || b[2] != eofVersion1 {
+		return nil, nil, nil, 0, ErrInvalidVersion
+	}
+	pos := 3
+	readKind := func(kind byte, missing error) error {
+		if pos >= len(b) || b[pos] != kind {
+			return missing
+		}
+		pos++
+		return nil
+	}
+	readU16 := func() (int, error) {
+		if pos+2 > len(b) {
+			return 0, ErrMissingTerminator
+		}
+		v := int(binary.BigEndian.Uint16(b[pos:]))
+		pos += 2
+		return v, nil
+	}
+	readU32 := func() (int, error) {
+		if pos+4 > len(b) {
+			return 0, ErrMissingTerminator
+		}
+		v := int(binary.BigEndian.Uint32(b[pos:]))
+		pos += 4
+		return v, nil
+	}
+
+	if err = readKind(kindTypes, ErrMissingTypeHeader); err != nil {
+		return
+	}
+	typesSize, err := readU16()
+	if err != nil {
+		return
+	}
+	if typesSize < eofTypeSize || typesSize%eofTypeSize != 0 {
+		return nil, nil, nil, 0, fmt.Errorf("%w: %d", ErrInvalidTypeSize, typesSize)
+	}
+	if err = readKind(kindCode, ErrMissingCodeHeader); err != nil {
+		return
+	}
+	numCode, err := readU16()
+	if err != nil {
+		return
+	}
+	if numCode == 0 {
+		return nil, nil, nil, 0, fmt.Errorf("%w: no code sections", ErrInvalidSectionCount)
+	}
+	if numCode > eofMaxCodeSections {
+		return nil, nil, nil, 0, fmt.Errorf("%w: %d", ErrTooManyCodeSections, numCode)
+	}
+	if numCode != typesSize/eofTypeSize {
+		return nil, nil, nil, 0, fmt.Errorf("%w: %d code sections, %d types", ErrInvalidSectionCount, numCode, typesSize/eofTypeSize)
+	}
+	codeSizes = make([]int, numCode)
+	for i := range codeSizes {
+		if codeSizes[i], err = readU16(); err != nil {
+			return
+		}
+		if codeSizes[i] == 0 {
+			return nil, nil, nil, 0, fmt.Errorf("%w: section %d is empty", ErrInvalidCodeSize, i)
+		}
+	}
+	if pos < len(b) && b[pos] == kindContainer {
+		pos++
+		numContainers, err := readU16()
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+		if numContainers == 0 {
+			return nil, nil, nil, 0, fmt.Errorf("%w: no container sections", ErrInvalidSectionCount)
+		}
+		if numContainers > eofMaxContainerSections {
+			return nil, nil, nil, 0, fmt.Errorf("%w: %d", ErrTooManyContainerSections, numContainers)
+		}
+		containerSizes = make([]int, numContainers)
+		for i := range containerSizes {
+			if containerSizes[i], err = readU32(); err != nil {
+				return nil, nil, nil, 0, err
+			}
+			if containerSizes[i] == 0 {
+				return nil, nil, nil, 0, fmt.Errorf("%w: container %d is empty", ErrInvalidContainerSize, i)
+			}
+		}
+	}
+	if err = readKind(kindData, ErrMissingDataHeader); err != nil {
+		return
+	}
+	dataSizePos := pos
+	dataSize, err := readU16()
+	if err != nil {
+		return
+	}
+	if err = readKind(0x00, ErrMissingTerminator); err != nil {
+		return
+	}
+	c = &eofContainer{
+		types:       make([]eofFunctionType, numCode),
+		dataSize:    dataSize,
+		dataSizePos: dataSizePos,
+	}
+	return c, codeSizes, containerSizes, pos, nil
+}
+
+// eofContainerSize returns the full length of the container at the start of
+// b, as declared by its header. Bytes past it are not part of the container,
+// which is how creation transactions separate initcode from calldata.
+func eofContainerSize(b []byte) (int, error) {
+	c, codeSizes, containerSizes, size, err := parseEOFHeader(b)
+	if err != nil {
+		return 0, err
+	}
+	size += len(c.types) * eofTypeSize
+	for _, s := range codeSizes {
+		size += s
+	}
+	for _, s := range containerSizes {
+		size += s
+	}
+	return size + c.dataSize, nil
+}
+
+// parseEOF parses an EOF container. With allowTruncatedData the data section
+// may be shorter than declared, as is the case for deploy containers before
+// RETURNCONTRACT appends the aux data.
+func parseEOF(b []byte, allowTruncatedData bool) (*eofContainer, error) {
+	c, codeSizes, containerSizes, pos, err := parseEOFHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	if pos+len(c.types)*eofTypeSize > len(b) {
+		return nil, fmt.Errorf("%w: types section", ErrContainerSizeMismatch)
+	}
+	for i := range c.types {
+		c.types[i] = eofFunctionType{
+			inputs:           b[pos],
+			outputs:          b[pos+1],
+			maxStackIncrease: binary.BigEndian.Uint16(b[pos+2:]),
+		}
+		pos += eofTypeSize
+	}
+	c.codeSections = make([][]byte, len(codeSizes))
+	for i, size := range codeSizes {
+		if pos+size > len(b) {
+			return nil, fmt.Errorf("%w: code section %d", ErrContainerSizeMismatch, i)
+		}
+		c.codeSections[i] = b[pos : pos+size]
+		pos += size
+	}
+	if len(containerSizes) > 0 {
+		c.subContainers = make([][]byte, len(containerSizes))
+	}
+	for i, size := range containerSizes {
+		if pos+size > len(b) {
+			return nil, fmt.Errorf("%w: container section %d", ErrContainerSizeMismatch, i)
+		}
+		c.subContainers[i] = b[pos : pos+size]
+		pos += size
+	}
+	switch rest := len(b) - pos; {
+	case rest > c.dataSize:
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrContainerSizeMismatch, rest-c.dataSize)
+	case rest < c.dataSize && !allowTruncatedData:
+		return nil, fmt.Errorf("%w: have %d, declared %d", ErrTruncatedDataSection, rest, c.dataSize)
+	}
+	c.data = b[pos:]
+	c.raw = b
+	return c, nil
+}
+
+// validateTypes checks the types section against the limits of EIP-4750 and
+// EIP-5450.
+func (c *eofContainer) validateTypes() error {
+	if t := c.types[0]; t.inputs != 0 || t.outputs != eofNonReturning {
+		return fmt.Errorf("%w: have %d inputs, %d outputs", ErrInvalidFirstSectionType, t.inputs, t.outputs)
+	}
+	for i, t := range c.types {
+		if t.inputs > eofMaxInputsOutputs || (t.outputs > eofMaxInputsOutputs && t.outputs != eofNonReturning) {
+			return fmt.Errorf("%w: section %d", ErrInvalidSectionArgument, i)
+		}
+		if t.maxStackIncrease > eofMaxStackHeight-uint16(t.inputs) {
+			return fmt.Errorf("%w: section %d", ErrInvalidMaxStackHeight, i)
+		}
+	}
+	return nil
+}
+
+// withAuxData returns the deploy container with aux appended to its data
+// section and the data_size field updated, as RETURNCONTRACT deploys it.
+func (c *eofContainer) withAuxData(aux []byte) ([]byte, error) {
+	newSize := len(c.data) + len(aux)
+	if newSize < c.dataSize || newSize > 0xffff {
+		return nil, fmt.Errorf("%w: data size %d, declared %d", ErrInvalidEOFAuxData, newSize, c.dataSize)
+	}
+	out := make([]byte, 0, len(c.raw)+len(aux))
+	out = append(out, c.raw...)
+	out = append(out, aux...)
+	binary.BigEndian.PutUint16(out[c.dataSizePos:], uint16(newSize))
+	return out, nil
+}
@@ -0,0 +1,515 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm/stack"
+)
+
+// eofReturnFrame is an entry of the CALLF return stack.
+type eofReturnFrame struct {
+	section int
+	pc      uint64
+}
+
+// eofCodeHash is the code hash legacy EXTCODEHASH reports for EOF accounts,
+// keccak256(0xEF00).
+var eofCodeHash = crypto.Keccak256Hash(eofMagic)
+
+// enableEOF turns a legacy jump table into the one EOF code sections run
+// with: it drops the instructions EOF rejects and adds the EIP-7692 ones.
+func enableEOF(jt *JumpTable) {
+	undefined := &operation{execute: opUndefined, undefined: true}
+	for _, op := range []OpCode{
+		CALLCODE, SELFDESTRUCT, JUMP, JUMPI, PC, CREATE, CREATE2, CALL, STATICCALL, DELEGATECALL,
+		CODESIZE, CODECOPY, EXTCODESIZE, EXTCODECOPY, EXTCODEHASH, GAS,
+	} {
+		jt[op] = undefined
+	}
+	jt[INVALID] = &operation{execute: opUndefined}
+
+	// EIP-4200: static relative jumps
+	jt[RJUMP] = &operation{execute: opRjump, constantGas: GasQuickStep}
+	jt[RJUMPI] = &operation{execute: opRjumpi, constantGas: 4, numPop: 1}
+	jt[RJUMPV] = &operation{execute: opRjumpv, constantGas: 4, numPop: 1}
+	// EIP-4750 and EIP-6206: functions
+	jt[CALLF] = &operation{execute: opCallf, constantGas: GasFastStep}
+	jt[RETF] = &operation{execute: opRetf, constantGas: GasFastestStep}
+	jt[JUMPF] = &operation{execute: opJumpf, constantGas: GasFastStep}
+	// EIP-663: unlimited SWAP and DUP
+	jt[DUPN] = &operation{execute: opDupN, constantGas: GasFastestStep, numPush: 1}
+	jt[SWAPN] = &operation{execute: opSwapN, constantGas: GasFastestStep}
+	jt[EXCHANGE] = &operation{execute: opExchange, constantGas: GasFastestStep}
+	// EIP-7480: data section access
+	jt[DATALOAD] = &operation{execute: opDataLoad, constantGas: 4, numPop: 1, numPush: 1}
+	jt[DATALOADN] = &operation{execute: opDataLoadN, constantGas: GasFastestStep, numPush: 1}
+	jt[DATASIZE] = &operation{execute: opDataSize, constantGas: GasQuickStep, numPush: 1}
+	jt[DATACOPY] = &operation{
+		execute:     opDataCopy,
+		constantGas: GasFastestStep,
+		dynamicGas:  gasDataCopy,
+		numPop:      3,
+		memorySize:  memoryDataCopy,
+	}
+	// EIP-7069: revamped CALL instructions
+	jt[RETURNDATALOAD] = &operation{execute: opReturnDataLoad, constantGas: GasFastestStep, numPop: 1, numPush: 1}
+	jt[EXTCALL] = &operation{
+		execute:     opExtCall,
+		constantGas: params.WarmStorageReadCostEIP2929,
+		dynamicGas:  gasExtCall,
+		numPop:      4,
+		numPush:     1,
+		memorySize:  memoryExtCall,
+	}
+	jt[EXTDELEGATECALL] = &operation{
+		execute:     opExtDelegateCall,
+		constantGas: params.WarmStorageReadCostEIP2929,
+		dynamicGas:  gasExtDelegateCall,
+		numPop:      3,
+		numPush:     1,
+		memorySize:  memoryExtCall,
+	}
+	jt[EXTSTATICCALL] = &operation{
+		execute:     opExtStaticCall,
+		constantGas: params.WarmStorageReadCostEIP2929,
+		dynamicGas:  gasExtDelegateCall,
+		numPop:      3,
+		numPush:     1,
+		memorySize:  memoryExtCall,
+	}
+	// EIP-7620: EOF contract creation
+	jt[EOFCREATE] = &operation{
+		execute:     opEOFCreate,
+		constantGas: params.Create2Gas,
+		dynamicGas:  pureMemoryGascost,
+		numPop:      4,
+		numPush:     1,
+		memorySize:  memoryEOFCreate,
+	}
+	jt[RETURNCONTRACT] = &operation{
+		execute:    opReturnContract,
+		dynamicGas: pureMemoryGascost,
+		numPop:     2,
+		memorySize: memoryReturn,
+	}
+}
+
+// enableEOFLegacyStubs makes legacy code observe EOF accounts the way EIP-3540
+// prescribes: as two bytes of code, 0xEF00.
+func enableEOFLegacyStubs(jt *JumpTable) {
+	jt[EXTCODESIZE].execute = opExtCodeSizeEOF
+	jt[EXTCODECOPY].execute = opExtCodeCopyEOF
+	jt[EXTCODEHASH].execute = opExtCodeHashEOF
+}
+
+var gasDataCopy = memoryCopierGas(2)
+
+func memoryDataCopy(stack *stack.Stack) (uint64, bool) {
+	return calcMemSize64(stack.Back(0), stack.Back(2))
+}
+
+func memoryExtCall(stack *stack.Stack) (uint64, bool) {
+	return calcMemSize64(stack.Back(1), stack.Back(2))
+}
+
+func memoryEOFCreate(stack *stack.Stack) (uint64, bool) {
+	return calcMemSize64(stack.Back(2), stack.Back(3))
+}
+
+// readU16 returns the big-endian immediate following the instruction at pc.
+func readU16(code []byte, pc uint64) uint16 {
+	return binary.BigEndian.Uint16(code[pc+1:])
+}
+
+// relativeJump moves pc to the instruction offset bytes past the end of the
+// immediates of the current one; the interpreter loop adds the final byte.
+func relativeJump(pc *uint64, immediates uint64, offset int16) {
+	*pc = uint64(int64(*pc) + int64(immediates) + int64(offset))
+}
+
+func opRjump(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	relativeJump(pc, 2, int16(readU16(scope.Contract.Code, *pc)))
+	return nil, nil
+}
+
+func opRjumpi(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	cond := scope.Stack.Pop()
+	if cond.IsZero() {
+		*pc += 2
+		return nil, nil
+	}
+	return opRjump(pc, interpreter, scope)
+}
+
+func opRjumpv(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		code       = scope.Contract.Code
+		count      = uint64(code[*pc+1]) + 1
+		immediates = 1 + 2*count
+		idx        = scope.Stack.Pop()
+	)
+	if !idx.IsUint64() || idx.Uint64() >= count {
+		*pc += immediates
+		return nil, nil
+	}
+	offset := int16(binary.BigEndian.Uint16(code[*pc+2+2*idx.Uint64():]))
+	relativeJump(pc, immediates, offset)
+	return nil, nil
+}
+
+// enterSection switches execution to the start of code section idx.
+func enterSection(pc *uint64, scope *ScopeContext, idx int) error {
+	target := scope.Contract.eof.types[idx]
+	if limit := int(params.StackLimit) - int(target.maxStackIncrease); scope.Stack.Len() > limit {
+		return &ErrStackOverflow{stackLen: scope.Stack.Len(), limit: limit}
+	}
+	scope.Contract.setCodeSection(idx)
+	*pc = math.MaxUint64 // wraps to 0 when the interpreter loop advances
+	return nil
+}
+
+func opCallf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	if len(scope.Contract.returnStack) >= eofReturnStackLimit {
+		return nil, ErrReturnStackExceeded
+	}
+	frame := eofReturnFrame{section: scope.Contract.codeSection, pc: *pc + 3}
+	if err := enterSection(pc, scope, int(readU16(scope.Contract.Code, *pc))); err != nil {
+		return nil, err
+	}
+	scope.Contract.returnStack = append(scope.Contract.returnStack, frame)
+	return nil, nil
+}
+
+func opRetf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	c := scope.Contract
+	frame := c.returnStack[len(c.returnStack)-1]
+	c.returnStack = c.returnStack[:len(c.returnStack)-1]
+	c.setCodeSection(frame.section)
+	*pc = frame.pc - 1
+	return nil, nil
+}
+
+func opJumpf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	return nil, enterSection(pc, scope, int(readU16(scope.Contract.Code, *pc)))
+}
+
+func opDupN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.Dup(int(scope.Contract.Code[*pc+1]) + 1)
+	*pc += 1
+	return nil, nil
+}
+
+func opSwapN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.Swap(int(scope.Contract.Code[*pc+1]) + 2)
+	*pc += 1
+	return nil, nil
+}
+
+func opExchange(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	imm := scope.Contract.Code[*pc+1]
+	n, m := int(imm>>4)+1, int(imm&0x0f)+1
+	a, b := scope.Stack.Back(n), scope.Stack.Back(n+m)
+	*a, *b = *b, *a
+	*pc += 1
+	return nil, nil
+}
+
+func opDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := scope.Stack.Peek()
+	offset64, overflow := offset.Uint64WithOverflow()
+	if overflow {
+		offset64 = math.MaxUint64
+	}
+	offset.SetBytes32(getData(scope.Contract.eof.data, offset64, 32))
+	return nil, nil
+}
+
+func opDataLoadN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := uint64(readU16(scope.Contract.Code, *pc))
+	var value uint256.Int
+	value.SetBytes32(getData(scope.Contract.eof.data, offset, 32))
+	scope.Stack.Push(&value)
+	*pc += 2
+	return nil, nil
+}
+
+func opDataSize(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var size uint256.Int
+	size.SetUint64(uint64(len(scope.Contract.eof.data)))
+	scope.Stack.Push(&size)
+	return nil, nil
+}
+
+func opDataCopy(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		memOffset = scope.Stack.Pop()
+		offset    = scope.Stack.Pop()
+		size      = scope.Stack.Pop()
+	)
+	offset64, overflow := offset.Uint64WithOverflow()
+	if overflow {
+		offset64 = math.MaxUint64
+	}
+	scope.Memory.Set(memOffset.Uint64(), size.Uint64(), getData(scope.Contract.eof.data, offset64, size.Uint64()))
+	return nil, nil
+}
+
+func opReturnDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := scope.Stack.Peek()
+	offset64, overflow := offset.Uint64WithOverflow()
+	if overflow {
+		offset64 = math.MaxUint64
+	}
+	offset.SetBytes32(getData(interpreter.returnData, offset64, 32))
+	return nil, nil
+}
+
+// gasExtCallCommon charges the EIP-7069 account access, value transfer and
+// memory costs of an EXT*CALL, then sets aside the gas the callee receives.
+// A zero callee gas in evm.callGasTemp tells the instruction to fail lightly.
+func gasExtCallCommon(evm *EVM, contract *Contract, stack *stack.Stack, mem *Memory, memorySize uint64, transfersValue bool) (uint64, error) {
+	target := stack.Back(0)
+	if target.ByteLen() > length.Addr {
+		return 0, ErrEOFAddressHighBitsNonZero
+	}
+	address := common.Address(target.Bytes20())
+	gas, err := memoryGasCost(mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	if evm.IntraBlockState().AddAddressToAccessList(address) {
+		gas += params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
+	}
+	if transfersValue {
+		gas += params.CallValueTransferGas
+		empty, err := evm.IntraBlockState().Empty(address)
+		if err != nil {
+			return 0, err
+		}
+		if empty {
+			gas += params.CallNewAccountGas
+		}
+	}
+	evm.SetCallGasTemp(0)
+	if contract.Gas < gas {
+		return gas, nil // UseGas fails with ErrOutOfGas
+	}
+	available := contract.Gas - gas
+	retained := max(available/64, params.ExtCallMinRetainedGas)
+	if available > retained && available-retained >= params.ExtCallMinCalleeGas {
+		evm.SetCallGasTemp(available - retained)
+	}
+	return gas + evm.CallGasTemp(), nil
+}
+
+func gasExtCall(evm *EVM, contract *Contract, stack *stack.Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	return gasExtCallCommon(evm, contract, stack, mem, memorySize, !stack.Back(3).IsZero())
+}
+
+func gasExtDelegateCall(evm *EVM, contract *Contract, stack *stack.Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	return gasExtCallCommon(evm, contract, stack, mem, memorySize, false)
+}
+
+// extCallLightFailure ends an EXT*CALL without entering the callee: status 1
+// is pushed and the gas set aside for the callee is returned.
+func extCallLightFailure(interpreter *EVMInterpreter, scope *ScopeContext, gas uint64) ([]byte, error) {
+	scope.Stack.Push(uint256.NewInt(1))
+	scope.Contract.RefundGas(gas, interpreter.evm.config.Tracer, tracing.GasChangeCallLeftOverRefunded)
+	interpreter.returnData = nil
+	return nil, nil
+}
+
+// extCallResult pushes the EIP-7069 status of a finished EXT*CALL: 0 on
+// success, 1 on revert or a failed precondition, 2 on exceptional halt.
+func extCallResult(interpreter *EVMInterpreter, scope *ScopeContext, ret []byte, returnGas uint64, err error) ([]byte, error) {
+	var status uint256.Int
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrExecutionReverted), errors.Is(err, ErrDepth), errors.Is(err, ErrInsufficientBalance):
+		status.SetOne()
+	default:
+		status.SetUint64(2)
+		ret = nil
+	}
+	scope.Stack.Push(&status)
+	scope.Contract.RefundGas(returnGas, interpreter.evm.config.Tracer, tracing.GasChangeCallLeftOverRefunded)
+	interpreter.returnData = ret
+	return ret, nil
+}
+
+func opExtCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize, value := stack.Pop(), stack.Pop(), stack.Pop(), stack.Pop()
+	gas := interpreter.evm.CallGasTemp()
+	if !value.IsZero() && interpreter.readOnly {
+		return nil, ErrWriteProtection
+	}
+	if gas == 0 {
+		return extCallLightFailure(interpreter, scope, gas)
+	}
+	args := scope.Memory.GetPtr(int64(inOffset.Uint64()), int64(inSize.Uint64()))
+	ret, returnGas, err := interpreter.evm.call(EXTCALL, scope.Contract, common.Address(addr.Bytes20()), args, gas, &value, false)
+	return extCallResult(interpreter, scope, ret, returnGas, err)
+}
+
+func opExtDelegateCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize := stack.Pop(), stack.Pop(), stack.Pop()
+	toAddr := common.Address(addr.Bytes20())
+	gas := interpreter.evm.CallGasTemp()
+	if gas == 0 {
+		return extCallLightFailure(interpreter, scope, gas)
+	}
+	// Only EOF code may be delegated to from EOF code.
+	code, err := interpreter.evm.IntraBlockState().ResolveCode(toAddr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if !HasEOFMagic(code) {
+		return extCallLightFailure(interpreter, scope, gas)
+	}
+	args := scope.Memory.GetPtr(int64(inOffset.Uint64()), int64(inSize.Uint64()))
+	ret, returnGas, err := interpreter.evm.call(EXTDELEGATECALL, scope.Contract, toAddr, args, gas, nil, false)
+	return extCallResult(interpreter, scope, ret, returnGas, err)
+}
+
+func opExtStaticCall(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	stack := scope.Stack
+	addr, inOffset, inSize := stack.Pop(), stack.Pop(), stack.Pop()
+	gas := interpreter.evm.CallGasTemp()
+	if gas == 0 {
+		return extCallLightFailure(interpreter, scope, gas)
+	}
+	args := scope.Memory.GetPtr(int64(inOffset.Uint64()), int64(inSize.Uint64()))
+	ret, returnGas, err := interpreter.evm.call(EXTSTATICCALL, scope.Contract, common.Address(addr.Bytes20()), args, gas, new(uint256.Int), false)
+	return extCallResult(interpreter, scope, ret, returnGas, err)
+}
+
+func opEOFCreate(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	if interpreter.readOnly {
+		return nil, ErrWriteProtection
+	}
+	var (
+		initContainer = scope.Contract.eof.subContainers[scope.Contract.Code[*pc+1]]
+		value         = scope.Stack.Pop()
+		salt          = scope.Stack.Pop()
+		offset, size  = scope.Stack.Pop(), scope.Stack.Pop()
+		input         = scope.Memory.GetCopy(int64(offset.Uint64()), int64(size.Uint64()))
+		tracer        = interpreter.evm.Config().Tracer
+		stackValue    uint256.Int
+	)
+	*pc += 1
+	// The initcontainer is hashed to derive the new address.
+	if !scope.Contract.UseGas(ToWordSize(uint64(len(initContainer)))*params.Keccak256WordGas, tracer, tracing.GasChangeIgnored) {
+		return nil, ErrOutOfGas
+	}
+	gas := scope.Contract.Gas
+	gas -= gas / 64
+	scope.Contract.UseGas(gas, tracer, tracing.GasChangeCallContractCreation2)
+	res, addr, returnGas, suberr := interpreter.evm.EOFCreate(scope.Contract, initContainer, input, gas, &value, &salt)
+	if suberr == nil {
+		stackValue.SetBytes(addr.Bytes())
+	}
+	scope.Stack.Push(&stackValue)
+	scope.Contract.RefundGas(returnGas, tracer, tracing.GasChangeCallLeftOverRefunded)
+
+	if suberr == ErrExecutionReverted {
+		interpreter.returnData = res // set REVERT data to return data buffer
+		return res, nil
+	}
+	interpreter.returnData = nil // clear dirty return data buffer
+	return nil, nil
+}
+
+func opReturnContract(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		raw          = scope.Contract.eof.subContainers[scope.Contract.Code[*pc+1]]
+		offset, size = scope.Stack.Pop(), scope.Stack.Pop()
+	)
+	deploy, err := parseEOF(raw, true)
+	if err != nil {
+		return nil, err
+	}
+	code, err := deploy.withAuxData(scope.Memory.GetPtr(int64(offset.Uint64()), int64(size.Uint64())))
+	if err != nil {
+		return nil, err
+	}
+	return code, errStopToken
+}
+
+func opExtCodeSizeEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	slot := scope.Stack.Peek()
+	code, err := interpreter.evm.IntraBlockState().GetCode(slot.Bytes20())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		code = eofMagic
+	}
+	slot.SetUint64(uint64(len(code)))
+	return nil, nil
+}
+
+func opExtCodeCopyEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		stack      = scope.Stack
+		a          = stack.Pop()
+		memOffset  = stack.Pop()
+		codeOffset = stack.Pop()
+		length     = stack.Pop()
+	)
+	code, err := interpreter.evm.IntraBlockState().GetCode(a.Bytes20())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		code = eofMagic
+	}
+	scope.Memory.Set(memOffset.Uint64(), length.Uint64(), getDataBig(code, &codeOffset, length.Uint64()))
+	return nil, nil
+}
+
+func opExtCodeHashEOF(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	address := common.Address(scope.Stack.Peek().Bytes20())
+	if _, err := opExtCodeHash(pc, interpreter, scope); err != nil {
+		return nil, err
+	}
+	slot := scope.Stack.Peek()
+	if slot.IsZero() {
+		return nil, nil
+	}
+	code, err := interpreter.evm.IntraBlockState().GetCode(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
+	}
+	if HasEOFMagic(code) {
+		slot.SetBytes(eofCodeHash.Bytes())
+	}
+	return nil, nil
+}
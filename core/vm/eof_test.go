@@ -0,0 +1,261 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+type testCodeSection struct {
+	inputs, outputs  uint8
+	maxStackIncrease uint16
+	code             []byte
+}
+
+// buildEOF assembles an EOF container; dataSize overrides the declared data
+// size when non-negative.
+func buildEOF(sections []testCodeSection, containers [][]byte, data []byte, dataSize int) []byte {
+	b := []byte{eofFormatByte, eofMagicByte, eofVersion1, kindTypes}
+	b = binary.BigEndian.AppendUint16(b, uint16(len(sections)*eofTypeSize))
+	b = append(b, kindCode)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(sections)))
+	for _, s := range sections {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(s.code)))
+	}
+	if len(containers) > 0 {
+		b = append(b, kindContainer)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(containers)))
+		for _, c := range containers {
+			b = binary.BigEndian.AppendUint32(b, uint32(len(c)))
+		}
+	}
+	if dataSize < 0 {
+		dataSize = len(data)
+	}
+	b = append(b, kindData)
+	b = binary.BigEndian.AppendUint16(b, uint16(dataSize))
+	b = append(b, 0)
+	for _, s := range sections {
+		b = append(b, s.inputs, s.outputs)
+		b = binary.BigEndian.AppendUint16(b, s.maxStackIncrease)
+	}
+	for _, s := range sections {
+		b = append(b, s.code...)
+	}
+	for _, c := range containers {
+		b = append(b, c...)
+	}
+	return append(b, data...)
+}
+
+func TestEOFParse(t *testing.T) {
+	code := buildEOF([]testCodeSection{
+		{0, eofNonReturning, 1, []byte{byte(PUSH0), byte(CALLF), 0, 1, byte(STOP)}},
+		{1, 0, 0, []byte{byte(POP), byte(RETF)}},
+	}, nil, []byte{1, 2, 3}, -1)
+
+	c, err := parseEOF(code, false)
+	require.NoError(t, err)
+	require.Len(t, c.codeSections, 2)
+	require.Equal(t, eofFunctionType{1, 0, 0}, c.types[1])
+	require.Equal(t, []byte{1, 2, 3}, c.data)
+
+	size, err := eofContainerSize(append(code, 0xaa, 0xbb))
+	require.NoError(t, err)
+	require.Equal(t, len(code), size)
+
+	_, err = parseEOF(code[:len(code)-1], false)
+	require.ErrorIs(t, err, ErrTruncatedDataSection)
+	_, err = parseEOF(code[:len(code)-1], true)
+	require.NoError(t, err)
+	_, err = parseEOF(append(code, 0), false)
+	require.ErrorIs(t, err, ErrContainerSizeMismatch)
+	_, err = parseEOF([]byte{0xef, 0x00, 0x02}, false)
+	require.ErrorIs(t, err, ErrInvalidVersion)
+	require.False(t, HasEOFMagic([]byte{0xef}))
+}
+
+func TestEOFValidate(t *testing.T) {
+	stop := []byte{byte(STOP)}
+	tests := []struct {
+		name     string
+		code     []byte
+		initcode bool
+		err      error
+	}{
+		{
+			name: "minimal",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, stop}}, nil, nil, -1),
+		},
+		{
+			name:     "STOP in initcode",
+			code:     buildEOF([]testCodeSection{{0, eofNonReturning, 0, stop}}, nil, nil, -1),
+			initcode: true,
+			err:      ErrIncompatibleContainerKind,
+		},
+		{
+			name: "first section returning",
+			code: buildEOF([]testCodeSection{{0, 0, 0, stop}}, nil, nil, -1),
+			err:  ErrInvalidFirstSectionType,
+		},
+		{
+			name: "legacy jump",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 1, []byte{byte(PUSH0), byte(JUMP)}}}, nil, nil, -1),
+			err:  ErrUndefinedInstruction,
+		},
+		{
+			name: "truncated push",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, []byte{byte(PUSH2), 0}}}, nil, nil, -1),
+			err:  ErrTruncatedImmediate,
+		},
+		{
+			name: "jump into immediate",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 1, []byte{byte(RJUMP), 0, 1, byte(PUSH1), 0, byte(STOP)}}}, nil, nil, -1),
+			err:  ErrInvalidJumpDest,
+		},
+		{
+			name: "no terminating instruction",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 1, []byte{byte(PUSH0)}}}, nil, nil, -1),
+			err:  ErrNoTerminatingInstruction,
+		},
+		{
+			name: "unreachable instruction",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, []byte{byte(STOP), byte(STOP)}}}, nil, nil, -1),
+			err:  ErrUnreachableCode,
+		},
+		{
+			name: "wrong max stack increase",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 2, []byte{byte(PUSH0), byte(STOP)}}}, nil, nil, -1),
+			err:  ErrInvalidMaxStackHeight,
+		},
+		{
+			name: "stack underflow",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, []byte{byte(POP), byte(STOP)}}}, nil, nil, -1),
+			err:  ErrEOFStackUnderflow,
+		},
+		{
+			name: "loop",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 1, []byte{byte(PUSH0), byte(RJUMPI), 0xff, 0xfc, byte(STOP)}}}, nil, nil, -1),
+		},
+		{
+			name: "loop growing the stack",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 1, []byte{byte(PUSH0), byte(RJUMP), 0xff, 0xfc}}}, nil, nil, -1),
+			err:  ErrInvalidBackwardJumpHeight,
+		},
+		{
+			name: "unreachable section",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, stop}, {0, eofNonReturning, 0, stop}}, nil, nil, -1),
+			err:  ErrUnreachableCodeSections,
+		},
+		{
+			name: "CALLF to non-returning",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, []byte{byte(CALLF), 0, 1, byte(STOP)}}, {0, eofNonReturning, 0, stop}}, nil, nil, -1),
+			err:  ErrCallfToNonReturning,
+		},
+		{
+			name: "DATALOADN out of bounds",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 1, []byte{byte(DATALOADN), 0, 1, byte(STOP)}}}, nil, make([]byte, 32), -1),
+			err:  ErrInvalidDataloadNArgument,
+		},
+		{
+			name: "unreferenced subcontainer",
+			code: buildEOF([]testCodeSection{{0, eofNonReturning, 0, stop}}, [][]byte{buildEOF([]testCodeSection{{0, eofNonReturning, 0, stop}}, nil, nil, -1)}, nil, -1),
+			err:  ErrUnreferencedSubcontainer,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEOF(tt.code, tt.initcode)
+			if tt.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestEOFValidateInitcode(t *testing.T) {
+	// The deploy container declares 4 bytes of data, 2 of which RETURNCONTRACT appends.
+	deploy := buildEOF([]testCodeSection{{0, eofNonReturning, 0, []byte{byte(STOP)}}}, nil, []byte{1, 2}, 4)
+	initcode := buildEOF([]testCodeSection{{0, eofNonReturning, 2, []byte{byte(PUSH0), byte(PUSH0), byte(RETURNCONTRACT), 0}}}, [][]byte{deploy}, nil, -1)
+	require.NoError(t, ValidateEOF(initcode, true))
+	require.ErrorIs(t, ValidateEOF(initcode, false), ErrIncompatibleContainerKind)
+
+	c, err := parseEOF(deploy, true)
+	require.NoError(t, err)
+	_, err = c.withAuxData([]byte{3})
+	require.ErrorIs(t, err, ErrInvalidEOFAuxData)
+	code, err := c.withAuxData([]byte{3, 4})
+	require.NoError(t, err)
+	deployed, err := parseEOF(code, false)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4}, deployed.data)
+	require.NoError(t, ValidateEOF(code, false))
+
+	// A container referenced by both EOFCREATE and RETURNCONTRACT is rejected.
+	ambiguous := buildEOF([]testCodeSection{{0, eofNonReturning, 4, []byte{
+		byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(PUSH0), byte(EOFCREATE), 0, byte(POP),
+		byte(PUSH0), byte(PUSH0), byte(RETURNCONTRACT), 0,
+	}}}, [][]byte{deploy}, nil, -1)
+	require.ErrorIs(t, ValidateEOF(ambiguous, true), ErrAmbiguousContainer)
+}
+
+func TestEOFExecute(t *testing.T) {
+	data := make([]byte, 32)
+	data[31] = 21
+	code := buildEOF([]testCodeSection{
+		{0, eofNonReturning, 5, []byte{
+			byte(DATALOADN), 0, 0, // [21]
+			byte(CALLF), 0, 1, // [42]
+			byte(PUSH1), 1,
+			byte(RJUMPV), 1, 0, 0, 0, 2, // jumps over PUSH1 7
+			byte(PUSH1), 7,
+			byte(PUSH0),   // [42, 0]
+			byte(DUPN), 0, // [42, 0, 0]
+			byte(EXCHANGE), 0x00, // [0, 42, 0]
+			byte(SWAPN), 0, // [0, 0, 42]
+			byte(PUSH1), 0, byte(MSTORE),
+			byte(PUSH1), 32, byte(PUSH1), 0, byte(RETURN),
+		}},
+		{1, 1, 1, []byte{byte(DUP1), byte(ADD), byte(RETF)}},
+	}, nil, data, -1)
+	require.NoError(t, ValidateEOF(code, false))
+
+	env := NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, nil, chain.TestChainConfig, Config{})
+	interpreter := NewEVMInterpreter(env, env.Config())
+	interpreter.eofJt = &eofInstructionSet
+	env.interpreter = interpreter
+
+	container, err := parseEOF(code, false)
+	require.NoError(t, err)
+	contract := NewContract(&dummyContractRef{}, common.Address{}, new(uint256.Int), 100_000, false, NewJumpDestCache())
+	contract.setEOF(container)
+
+	ret, err := interpreter.Run(contract, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, uint256.NewInt(42).Bytes32(), [32]byte(ret))
+	require.Empty(t, contract.returnStack)
+}
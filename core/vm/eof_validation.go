@@ -0,0 +1,352 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrUndefinedInstruction         = errors.New("undefined instruction")
+	ErrTruncatedImmediate           = errors.New("truncated immediate")
+	ErrInvalidJumpDest              = errors.New("invalid jump destination")
+	ErrInvalidCodeSectionIndex      = errors.New("invalid code section index")
+	ErrInvalidContainerSectionIndex = errors.New("invalid container section index")
+	ErrInvalidDataloadNArgument     = errors.New("invalid DATALOADN argument")
+	ErrCallfToNonReturning          = errors.New("CALLF to non-returning section")
+	ErrJumpfToIncompatibleSection   = errors.New("JUMPF to section with more outputs")
+	ErrInvalidNonReturningFlag      = errors.New("invalid non-returning flag")
+	ErrIncompatibleContainerKind    = errors.New("instruction not allowed in this container kind")
+	ErrUnreachableCode              = errors.New("unreachable code")
+	ErrUnreachableCodeSections      = errors.New("unreachable code sections")
+	ErrUnreferencedSubcontainer     = errors.New("unreferenced subcontainer")
+	ErrAmbiguousContainer           = errors.New("subcontainer referenced as both initcode and runtime code")
+	ErrNoTerminatingInstruction     = errors.New("no terminating instruction")
+	ErrEOFStackUnderflow            = errors.New("stack underflow")
+	ErrEOFStackOverflow             = errors.New("stack overflow")
+	ErrInvalidOutputs               = errors.New("invalid number of outputs")
+	ErrStackHeightMismatch          = errors.New("stack height mismatch")
+	ErrInvalidBackwardJumpHeight    = errors.New("backward jump with varying stack height")
+)
+
+// eofContainerKind tells how a container is going to be used, which decides
+// the set of terminating instructions it may contain.
+type eofContainerKind int
+
+const (
+	// eofRuntime containers are deployed code; they halt with STOP or RETURN.
+	eofRuntime eofContainerKind = iota
+	// eofInitcode containers are executed by EOFCREATE or a creation
+	// transaction and halt with RETURNCONTRACT.
+	eofInitcode
+)
+
+// ValidateEOF parses and validates code as a top-level EOF container, as
+// initcode when initcode is set and as runtime code otherwise.
+func ValidateEOF(code []byte, initcode bool) error {
+	c, err := parseEOF(code, false)
+	if err != nil {
+		return err
+	}
+	kind := eofRuntime
+	if initcode {
+		kind = eofInitcode
+	}
+	return c.validate(kind, &eofInstructionSet)
+}
+
+// validate checks the container and, recursively, its subcontainers.
+func (c *eofContainer) validate(kind eofContainerKind, jt *JumpTable) error {
+	if err := c.validateTypes(); err != nil {
+		return err
+	}
+	var (
+		refs         = make([]int, len(c.subContainers)) // 0 - unreferenced, otherwise kind+1
+		visited      = make([]bool, len(c.codeSections))
+		sectionQueue = []int{0}
+		calls        = make([][]int, len(c.codeSections))
+	)
+	for i := range c.codeSections {
+		targets, err := c.validateCode(i, kind, jt, refs)
+		if err != nil {
+			return fmt.Errorf("code section %d: %w", i, err)
+		}
+		calls[i] = targets
+		if err := c.validateStack(i, jt); err != nil {
+			return fmt.Errorf("code section %d: %w", i, err)
+		}
+	}
+	visited[0] = true
+	for len(sectionQueue) > 0 {
+		s := sectionQueue[0]
+		sectionQueue = sectionQueue[1:]
+		for _, t := range calls[s] {
+			if !visited[t] {
+				visited[t] = true
+				sectionQueue = append(sectionQueue, t)
+			}
+		}
+	}
+	for i, v := range visited {
+		if !v {
+			return fmt.Errorf("%w: section %d", ErrUnreachableCodeSections, i)
+		}
+	}
+	for i, ref := range refs {
+		if ref == 0 {
+			return fmt.Errorf("%w: %d", ErrUnreferencedSubcontainer, i)
+		}
+		subKind := eofContainerKind(ref - 1)
+		sub, err := parseEOF(c.subContainers[i], subKind == eofRuntime)
+		if err != nil {
+			return fmt.Errorf("subcontainer %d: %w", i, err)
+		}
+		if err := sub.validate(subKind, jt); err != nil {
+			return fmt.Errorf("subcontainer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// eofImmediateSize returns the number of immediate bytes following the
+// instruction at pos, or -1 if the immediates are truncated.
+func eofImmediateSize(code []byte, pos int) int {
+	var size int
+	switch op := OpCode(code[pos]); {
+	case op >= PUSH1 && op <= PUSH32:
+		size = int(op-PUSH1) + 1
+	case op == RJUMP || op == RJUMPI || op == CALLF || op == JUMPF || op == DATALOADN:
+		size = 2
+	case op == DUPN || op == SWAPN || op == EXCHANGE || op == EOFCREATE || op == RETURNCONTRACT:
+		size = 1
+	case op == RJUMPV:
+		if pos+1 >= len(code) {
+			return -1
+		}
+		size = 1 + 2*(int(code[pos+1])+1)
+	}
+	if pos+1+size > len(code) {
+		return -1
+	}
+	return size
+}
+
+// relativeJumpTargets returns the destinations of the RJUMP* instruction at pos.
+func relativeJumpTargets(code []byte, pos int) []int {
+	switch OpCode(code[pos]) {
+	case RJUMP, RJUMPI:
+		return []int{pos + 3 + int(int16(binary.BigEndian.Uint16(code[pos+1:])))}
+	case RJUMPV:
+		count := int(code[pos+1]) + 1
+		end := pos + 2 + 2*count
+		targets := make([]int, count)
+		for i := range targets {
+			targets[i] = end + int(int16(binary.BigEndian.Uint16(code[pos+2+2*i:])))
+		}
+		return targets
+	}
+	return nil
+}
+
+// validateCode performs the instruction-level checks of a code section and
+// returns the sections it calls into. refs accumulates subcontainer usage.
+func (c *eofContainer) validateCode(section int, kind eofContainerKind, jt *JumpTable, refs []int) ([]int, error) {
+	var (
+		code          = c.codeSections[section]
+		typ           = c.types[section]
+		boundaries    = make([]bool, len(code))
+		jumps         []int
+		calls         []int
+		returningExit bool
+	)
+	for pos := 0; pos < len(code); {
+		op := OpCode(code[pos])
+		if jt[op].undefined {
+			return nil, fmt.Errorf("%w: %v at %d", ErrUndefinedInstruction, op, pos)
+		}
+		imm := eofImmediateSize(code, pos)
+		if imm < 0 {
+			return nil, fmt.Errorf("%w: %v at %d", ErrTruncatedImmediate, op, pos)
+		}
+		boundaries[pos] = true
+		switch op {
+		case RJUMP, RJUMPI, RJUMPV:
+			jumps = append(jumps, relativeJumpTargets(code, pos)...)
+		case CALLF, JUMPF:
+			idx := int(binary.BigEndian.Uint16(code[pos+1:]))
+			if idx >= len(c.codeSections) {
+				return nil, fmt.Errorf("%w: %d at %d", ErrInvalidCodeSectionIndex, idx, pos)
+			}
+			target := c.types[idx]
+			if op == CALLF && !target.returning() {
+				return nil, fmt.Errorf("%w: %d at %d", ErrCallfToNonReturning, idx, pos)
+			}
+			if op == JUMPF && target.returning() {
+				if !typ.returning() || typ.outputs < target.outputs {
+					return nil, fmt.Errorf("%w: %d at %d", ErrJumpfToIncompatibleSection, idx, pos)
+				}
+				returningExit = true
+			}
+			calls = append(calls, idx)
+		case RETF:
+			if !typ.returning() {
+				return nil, fmt.Errorf("%w: RETF in section %d", ErrInvalidNonReturningFlag, section)
+			}
+			returningExit = true
+		case DATALOADN:
+			if offset := int(binary.BigEndian.Uint16(code[pos+1:])); offset+32 > c.dataSize {
+				return nil, fmt.Errorf("%w: offset %d, data size %d", ErrInvalidDataloadNArgument, offset, c.dataSize)
+			}
+		case EOFCREATE, RETURNCONTRACT:
+			idx := int(code[pos+1])
+			if idx >= len(c.subContainers) {
+				return nil, fmt.Errorf("%w: %d at %d", ErrInvalidContainerSectionIndex, idx, pos)
+			}
+			refKind := eofInitcode
+			if op == RETURNCONTRACT {
+				if kind != eofInitcode {
+					return nil, fmt.Errorf("%w: %v at %d", ErrIncompatibleContainerKind, op, pos)
+				}
+				refKind = eofRuntime
+			}
+			if refs[idx] != 0 && refs[idx] != int(refKind)+1 {
+				return nil, fmt.Errorf("%w: %d", ErrAmbiguousContainer, idx)
+			}
+			refs[idx] = int(refKind) + 1
+		case STOP, RETURN:
+			if kind == eofInitcode {
+				return nil, fmt.Errorf("%w: %v at %d", ErrIncompatibleContainerKind, op, pos)
+			}
+		}
+		pos += 1 + imm
+	}
+	for _, dest := range jumps {
+		if dest < 0 || dest >= len(code) || !boundaries[dest] {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidJumpDest, dest)
+		}
+	}
+	if typ.returning() && !returningExit {
+		return nil, fmt.Errorf("%w: section %d never returns", ErrInvalidNonReturningFlag, section)
+	}
+	return calls, nil
+}
+
+// stackBounds is the range of operand stack heights an instruction can be
+// reached with. An unvisited instruction has min = -1.
+type stackBounds struct {
+	min, max int
+}
+
+// isEOFTerminating reports whether op ends the execution of a code section.
+func isEOFTerminating(op OpCode) bool {
+	switch op {
+	case STOP, RETURN, REVERT, INVALID, RETF, JUMPF, RETURNCONTRACT:
+		return true
+	}
+	return false
+}
+
+// validateStack runs the single-pass stack height analysis of EIP-5450 over a
+// code section. It relies on validateCode having accepted the section.
+func (c *eofContainer) validateStack(section int, jt *JumpTable) error {
+	var (
+		code    = c.codeSections[section]
+		typ     = c.types[section]
+		heights = make([]stackBounds, len(code))
+		inputs  = int(typ.inputs)
+		maxSeen = inputs
+	)
+	for i := range heights {
+		heights[i].min = -1
+	}
+	heights[0] = stackBounds{inputs, inputs}
+
+	for pos := 0; pos < len(code); {
+		op := OpCode(code[pos])
+		cur := heights[pos]
+		if cur.min < 0 {
+			return fmt.Errorf("%w: %v at %d", ErrUnreachableCode, op, pos)
+		}
+		required, delta := jt[op].numPop, jt[op].numPush-jt[op].numPop
+		switch op {
+		case CALLF, JUMPF:
+			target := c.types[binary.BigEndian.Uint16(code[pos+1:])]
+			if cur.max+int(target.maxStackIncrease) > eofMaxStackHeight {
+				return fmt.Errorf("%w: %v at %d", ErrEOFStackOverflow, op, pos)
+			}
+			required = int(target.inputs)
+			if op == CALLF {
+				delta = int(target.outputs) - int(target.inputs)
+			} else if target.returning() {
+				want := int(typ.outputs) + int(target.inputs) - int(target.outputs)
+				if cur.min != want || cur.max != want {
+					return fmt.Errorf("%w: JUMPF at %d with stack height %d-%d, want %d", ErrStackHeightMismatch, pos, cur.min, cur.max, want)
+				}
+			}
+		case RETF:
+			if want := int(typ.outputs); cur.min != want || cur.max != want {
+				return fmt.Errorf("%w: RETF at %d with stack height %d-%d, want %d", ErrInvalidOutputs, pos, cur.min, cur.max, want)
+			}
+		case DUPN:
+			required = int(code[pos+1]) + 1
+		case SWAPN:
+			required = int(code[pos+1]) + 2
+		case EXCHANGE:
+			required = int(code[pos+1]>>4) + int(code[pos+1]&0x0f) + 3
+		}
+		if cur.min < required {
+			return fmt.Errorf("%w: %v at %d needs %d, have %d", ErrEOFStackUnderflow, op, pos, required, cur.min)
+		}
+		next := stackBounds{cur.min + delta, cur.max + delta}
+		if next.max > maxSeen {
+			maxSeen = next.max
+		}
+		if maxSeen > eofMaxStackHeight {
+			return fmt.Errorf("%w: %v at %d", ErrEOFStackOverflow, op, pos)
+		}
+
+		nextPos := pos + 1 + eofImmediateSize(code, pos)
+		successors := relativeJumpTargets(code, pos)
+		if op != RJUMP && !isEOFTerminating(op) {
+			if nextPos >= len(code) {
+				return fmt.Errorf("%w: %v at %d", ErrNoTerminatingInstruction, op, pos)
+			}
+			successors = append(successors, nextPos)
+		}
+		for _, s := range successors {
+			if s <= pos {
+				if heights[s] != next {
+					return fmt.Errorf("%w: to %d from %d", ErrInvalidBackwardJumpHeight, s, pos)
+				}
+				continue
+			}
+			if h := &heights[s]; h.min < 0 {
+				*h = next
+			} else {
+				h.min = min(h.min, next.min)
+				h.max = max(h.max, next.max)
+			}
+		}
+		pos = nextPos
+	}
+	if maxSeen-inputs != int(typ.maxStackIncrease) {
+		return fmt.Errorf("%w: declared %d, computed %d", ErrInvalidMaxStackHeight, typ.maxStackIncrease, maxSeen-inputs)
+	}
+	return nil
+}
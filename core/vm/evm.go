@@ -39,6 +39,11 @@ import (
 var emptyHash = common.Hash{}
 
 func (evm *EVM) precompile(addr common.Address) (PrecompiledContract, bool) {
+	if len(evm.chainRules.Precompiles) > 0 {
+		if p, ok := activeCustomPrecompile(evm.chainRules, addr); ok {
+			return p, true
+		}
+	}
 	var precompiles map[common.Address]PrecompiledContract
 	switch {
 	case evm.chainRules.IsPrague:
@@ -176,6 +181,17 @@ func (evm *EVM) Interpreter() Interpreter {
 
 func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input []byte, gas uint64, value *uint256.Int, bailout bool) (ret []byte, leftOverGas uint64, err error) {
 	depth := evm.interpreter.Depth()
+	// typ is reported to tracers as is; EOF calls otherwise behave as their
+	// legacy counterparts.
+	kind := typ
+	switch typ {
+	case EXTCALL:
+		kind = CALL
+	case EXTDELEGATECALL:
+		kind = DELEGATECALL
+	case EXTSTATICCALL:
+		kind = STATICCALL
+	}
 
 	p, isPrecompile := evm.precompile(addr)
 	var code []byte
@@ -189,9 +205,9 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 	// Invoke tracer hooks that signal entering/exiting a call frame
 	if evm.Config().Tracer != nil {
 		v := value
-		if typ == STATICCALL {
+		if kind == STATICCALL {
 			v = nil
-		} else if typ == DELEGATECALL {
+		} else if kind == DELEGATECALL {
 			// NOTE: caller must, at all times be a contract. It should never happen
 			// that caller is something other than a Contract.
 			parent := caller.(*Contract)
@@ -211,7 +227,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 	if depth > int(params.CallCreateDepth) {
 		return nil, gas, ErrDepth
 	}
-	if typ == CALL || typ == CALLCODE {
+	if kind == CALL || kind == CALLCODE {
 		// Fail if we're trying to transfer more than the available balance
 		canTransfer, err := evm.Context.CanTransfer(evm.intraBlockState, caller.Address(), value)
 		if err != nil {
@@ -226,7 +242,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 
 	snapshot := evm.intraBlockState.Snapshot()
 
-	if typ == CALL {
+	if kind == CALL {
 		exist, err := evm.intraBlockState.Exist(addr)
 		if err != nil {
 			return nil, 0, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
@@ -238,7 +254,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 			evm.intraBlockState.CreateAccount(addr, false)
 		}
 		evm.Context.Transfer(evm.intraBlockState, caller.Address(), addr, value, bailout)
-	} else if typ == STATICCALL {
+	} else if kind == STATICCALL {
 		// We do an AddBalance of zero here, just in order to trigger a touch.
 		// This doesn't matter on Mainnet, where all empties are gone at the time of Byzantium,
 		// but is the correct thing to do and matters on other networks, in tests, and potential
@@ -266,19 +282,23 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr common.Address, input
 			return nil, 0, fmt.Errorf("%w: %w", ErrIntraBlockStateFailed, err)
 		}
 		var contract *Contract
-		if typ == CALLCODE {
+		if kind == CALLCODE {
 			contract = NewContract(caller, caller.Address(), value, gas, evm.config.SkipAnalysis, evm.JumpDestCache)
-		} else if typ == DELEGATECALL {
+		} else if kind == DELEGATECALL {
 			contract = NewContract(caller, caller.Address(), value, gas, evm.config.SkipAnalysis, evm.JumpDestCache).AsDelegate()
 		} else {
 			contract = NewContract(caller, addrCopy, value, gas, evm.config.SkipAnalysis, evm.JumpDestCache)
 		}
 		contract.SetCallCode(&addrCopy, codeHash, code)
-		readOnly := false
-		if typ == STATICCALL {
-			readOnly = true
+		if evm.chainRules.IsOsaka && HasEOFMagic(code) {
+			var container *eofContainer
+			if container, err = parseEOF(code, false); err == nil {
+				contract.setEOF(container)
+			}
+		}
+		if err == nil {
+			ret, err = run(evm, contract, input, kind == STATICCALL)
 		}
-		ret, err = run(evm, contract, input, readOnly)
 		gas = contract.Gas
 	}
 	// When an error was returned by the EVM or when setting the creation code
@@ -338,6 +358,11 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 type codeAndHash struct {
 	code []byte
 	hash common.Hash
+
+	// eof is the parsed container when code is EOF initcode, which, unlike
+	// legacy initcode, receives input.
+	eof   *eofContainer
+	input []byte
 }
 
 func NewCodeAndHash(code []byte) *codeAndHash {
@@ -426,12 +451,15 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 	// The contract is a scoped environment for this execution context only.
 	contract := NewContract(caller, address, value, gasRemaining, evm.config.SkipAnalysis, evm.JumpDestCache)
 	contract.SetCodeOptionalHash(&address, codeAndHash)
+	if codeAndHash.eof != nil {
+		contract.setEOF(codeAndHash.eof)
+	}
 
 	if evm.config.NoRecursion && depth > 0 {
 		return nil, address, gasRemaining, nil
 	}
 
-	ret, err = run(evm, contract, nil, false)
+	ret, err = run(evm, contract, codeAndHash.input, false)
 
 	// EIP-170: Contract code size limit
 	if err == nil && evm.chainRules.IsSpuriousDragon && len(ret) > evm.maxCodeSize() {
@@ -443,7 +471,8 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 	}
 
 	// Reject code starting with 0xEF if EIP-3541 is enabled.
-	if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+	// EOF initcode returns a validated EOF container.
+	if err == nil && evm.chainRules.IsLondon && codeAndHash.eof == nil && len(ret) >= 1 && ret[0] == 0xEF {
 		err = ErrInvalidCode
 	}
 	// if the contract creation ran successfully and no errors were returned
@@ -501,6 +530,48 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gasRemaining uint64, en
 	return evm.create(caller, codeAndHash, gasRemaining, endowment, contractAddr, CREATE2, true /* incrementNonce */, bailout)
 }
 
+// EOFCreate creates a new contract from an EOF initcontainer, as the EOFCREATE
+// instruction does. The address is derived as in Create2, and the initcode is
+// executed with input as its calldata.
+func (evm *EVM) EOFCreate(caller ContractRef, initContainer []byte, input []byte, gasRemaining uint64, endowment *uint256.Int, salt *uint256.Int) (ret []byte, contractAddr common.Address, leftOverGas uint64, err error) {
+	// The subcontainer was validated together with the deployed code of the caller.
+	container, err := parseEOF(initContainer, false)
+	if err != nil {
+		return nil, common.Address{}, 0, err
+	}
+	codeAndHash := &codeAndHash{code: initContainer, eof: container, input: input}
+	contractAddr = crypto.CreateAddress2(caller.Address(), salt.Bytes32(), codeAndHash.Hash().Bytes())
+	return evm.create(caller, codeAndHash, gasRemaining, endowment, contractAddr, EOFCREATE, true /* incrementNonce */, false)
+}
+
+// EOFCreateTx runs the initcode of a creation transaction whose data starts
+// with the EOF magic (EIP-7698). The data is an initcontainer followed by the
+// calldata for it. If the initcontainer is invalid the sender's nonce is still
+// incremented and all gas is consumed.
+func (evm *EVM) EOFCreateTx(caller ContractRef, data []byte, gasRemaining uint64, endowment *uint256.Int, bailout bool) (ret []byte, contractAddr common.Address, leftOverGas uint64, err error) {
+	nonce, err := evm.intraBlockState.GetNonce(caller.Address())
+	if err != nil {
+		return nil, common.Address{}, 0, err
+	}
+	contractAddr = crypto.CreateAddress(caller.Address(), nonce)
+	size, err := eofContainerSize(data)
+	if err == nil && size > len(data) {
+		err = ErrContainerSizeMismatch
+	}
+	var container *eofContainer
+	if err == nil {
+		if container, err = parseEOF(data[:size], false); err == nil {
+			err = container.validate(eofInitcode, &eofInstructionSet)
+		}
+	}
+	if err != nil {
+		evm.intraBlockState.SetNonce(caller.Address(), nonce+1)
+		return nil, contractAddr, 0, fmt.Errorf("%w: %w", ErrInvalidEOFInitcode, err)
+	}
+	codeAndHash := &codeAndHash{code: data[:size], eof: container, input: data[size:]}
+	return evm.create(caller, codeAndHash, gasRemaining, endowment, contractAddr, CREATE, true /* incrementNonce */, bailout)
+}
+
 // SysCreate is a special (system) contract creation methods for genesis constructors.
 // Unlike the normal Create & Create2, it doesn't increment caller's nonce.
 func (evm *EVM) SysCreate(caller ContractRef, code []byte, gas uint64, endowment *uint256.Int, contractAddr common.Address) (ret []byte, leftOverGas uint64, err error) {
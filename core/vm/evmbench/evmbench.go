@@ -0,0 +1,333 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package evmbench measures the throughput of individual EVM opcodes and
+// precompiled contracts and compares it against a recorded baseline, so that
+// interpreter performance regressions can be caught before a release.
+package evmbench
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+)
+
+// DefaultBenchTime is the minimal duration of a measurement.
+const DefaultBenchTime = 200 * time.Millisecond
+
+// opcodeRepeat is how many times an opcode case repeats its instruction in
+// one run of its program, amortising the interpreter setup.
+const opcodeRepeat = 256
+
+// benchChainConfig activates every mainnet fork, so the latest jump table and
+// precompile set are measured.
+var benchChainConfig = &chain.Config{
+	ChainID:               big.NewInt(1),
+	HomesteadBlock:        new(big.Int),
+	TangerineWhistleBlock: new(big.Int),
+	SpuriousDragonBlock:   new(big.Int),
+	ByzantiumBlock:        new(big.Int),
+	ConstantinopleBlock:   new(big.Int),
+	PetersburgBlock:       new(big.Int),
+	IstanbulBlock:         new(big.Int),
+	MuirGlacierBlock:      new(big.Int),
+	BerlinBlock:           new(big.Int),
+	LondonBlock:           new(big.Int),
+	ArrowGlacierBlock:     new(big.Int),
+	GrayGlacierBlock:      new(big.Int),
+	ShanghaiTime:          new(big.Int),
+	CancunTime:            new(big.Int),
+	PragueTime:            new(big.Int),
+}
+
+// Case is a single benchmark. Run executes the measured operation Batch*n
+// times.
+type Case struct {
+	Name string
+	// Gas is the gas charged for one operation, zero if not meaningful.
+	Gas uint64
+	// Batch is the number of operations in one iteration of Run, 1 if unset.
+	Batch int
+	Run   func(n int) error
+}
+
+// Result is the measured cost of one Case.
+type Result struct {
+	Name       string  `json:"name"`
+	NsPerOp    float64 `json:"nsPerOp"`
+	MGasPerSec float64 `json:"mgasPerSec,omitempty"`
+}
+
+// opcodeCase describes a benchmarked instruction: its stack arguments, top of
+// the stack first, and the number of values it leaves on the stack.
+type opcodeCase struct {
+	op      vm.OpCode
+	args    []string
+	outputs int
+}
+
+const (
+	word1 = "0xfeedfacecafebabedeadbeef0123456789abcdef0123456789abcdef01234567"
+	word2 = "0x0000000000000000000000000000000000000000000000056bc75e2d63100000" // 100 ether
+	word3 = "0x00000000000000000000000000000000000000000000000000000000000f4240"
+)
+
+var opcodeCases = []opcodeCase{
+	{vm.ADD, []string{word1, word2}, 1},
+	{vm.MUL, []string{word1, word2}, 1},
+	{vm.SUB, []string{word1, word2}, 1},
+	{vm.DIV, []string{word1, word2}, 1},
+	{vm.SDIV, []string{word1, word2}, 1},
+	{vm.MOD, []string{word1, word3}, 1},
+	{vm.SMOD, []string{word1, word3}, 1},
+	{vm.ADDMOD, []string{word1, word2, word3}, 1},
+	{vm.MULMOD, []string{word1, word2, word3}, 1},
+	{vm.EXP, []string{word1, word1}, 1},
+	{vm.SIGNEXTEND, []string{"0x0f", word1}, 1},
+	{vm.LT, []string{word1, word2}, 1},
+	{vm.SLT, []string{word1, word2}, 1},
+	{vm.EQ, []string{word1, word2}, 1},
+	{vm.ISZERO, []string{word1}, 1},
+	{vm.AND, []string{word1, word2}, 1},
+	{vm.OR, []string{word1, word2}, 1},
+	{vm.XOR, []string{word1, word2}, 1},
+	{vm.NOT, []string{word1}, 1},
+	{vm.BYTE, []string{"0x1f", word1}, 1},
+	{vm.SHL, []string{"0x60", word1}, 1},
+	{vm.SHR, []string{"0xa0", word1}, 1},
+	{vm.SAR, []string{"0xa0", word1}, 1},
+	{vm.KECCAK256, []string{"0x00", "0x40"}, 1},
+	{vm.CALLDATALOAD, []string{"0x04"}, 1},
+	{vm.MLOAD, []string{"0x40"}, 1},
+	{vm.MSTORE, []string{"0x40", word1}, 0},
+	{vm.MSTORE8, []string{"0x40", word1}, 0},
+	{vm.MCOPY, []string{"0x00", "0x20", "0x40"}, 0},
+}
+
+// OpcodeCases returns a case for every benchmarked instruction.
+func OpcodeCases() []Case {
+	cases := make([]Case, 0, len(opcodeCases))
+	for _, c := range opcodeCases {
+		cases = append(cases, c.benchCase())
+	}
+	return cases
+}
+
+// program returns code that executes the instruction opcodeRepeat times,
+// pushing its arguments before and popping its results after each execution.
+func (c opcodeCase) program() []byte {
+	var block []byte
+	for i := len(c.args) - 1; i >= 0; i-- {
+		arg := common.HexToHash(c.args[i])
+		block = append(block, byte(vm.PUSH32))
+		block = append(block, arg[:]...)
+	}
+	block = append(block, byte(c.op))
+	for i := 0; i < c.outputs; i++ {
+		block = append(block, byte(vm.POP))
+	}
+	code := make([]byte, 0, len(block)*opcodeRepeat+1)
+	for i := 0; i < opcodeRepeat; i++ {
+		code = append(code, block...)
+	}
+	return append(code, byte(vm.STOP))
+}
+
+func (c opcodeCase) benchCase() Case {
+	var (
+		code     = c.program()
+		address  = common.BytesToAddress([]byte("evmbench"))
+		codeHash = crypto.Keccak256Hash(code)
+		input    = common.Hex2Bytes("a9059cbb" + word1[2:])
+		cache    = vm.NewJumpDestCache()
+		evm      = vm.NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, nil, benchChainConfig, vm.Config{})
+	)
+	return Case{
+		Name:  "op/" + c.op.String(),
+		Batch: opcodeRepeat,
+		Run: func(n int) error {
+			for i := 0; i < n; i++ {
+				contract := vm.NewContract(vm.AccountRef(address), address, new(uint256.Int), 10_000_000, false, cache)
+				contract.SetCallCode(&address, codeHash, code)
+				if _, err := evm.Interpreter().Run(contract, input, false); err != nil {
+					return fmt.Errorf("%s: %w", c.op, err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+//go:embed testdata/precompiles.json
+var precompileInputsJSON []byte
+
+// precompileInput is an input recorded from mainnet or from the EIP test
+// vectors of a precompiled contract.
+type precompileInput struct {
+	Address  common.Address `json:"address"`
+	Name     string         `json:"name"`
+	Input    hexutil.Bytes  `json:"input"`
+	Expected hexutil.Bytes  `json:"expected"`
+}
+
+// PrecompileCases returns a case for every recorded precompile input. The
+// output of each input is checked against the recorded one.
+func PrecompileCases() ([]Case, error) {
+	var inputs []precompileInput
+	if err := json.Unmarshal(precompileInputsJSON, &inputs); err != nil {
+		return nil, err
+	}
+	cases := make([]Case, 0, len(inputs))
+	for _, in := range inputs {
+		p, ok := vm.PrecompiledContractsPrague[in.Address]
+		if !ok {
+			return nil, fmt.Errorf("no precompile at %x for input %s", in.Address, in.Name)
+		}
+		gas := p.RequiredGas(in.Input)
+		out, _, err := vm.RunPrecompiledContract(p, in.Input, gas, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", in.Name, err)
+		}
+		if !slices.Equal(out, in.Expected) {
+			return nil, fmt.Errorf("%s: unexpected output %x", in.Name, out)
+		}
+		input := in.Input
+		cases = append(cases, Case{
+			Name: fmt.Sprintf("precompile/%x/%s", in.Address[len(in.Address)-1], in.Name),
+			Gas:  gas,
+			Run: func(n int) error {
+				for i := 0; i < n; i++ {
+					if _, _, err := vm.RunPrecompiledContract(p, input, gas, nil); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		})
+	}
+	return cases, nil
+}
+
+// Cases returns all opcode and precompile cases whose name matches filter; a
+// nil filter selects every case.
+func Cases(filter *regexp.Regexp) ([]Case, error) {
+	precompiles, err := PrecompileCases()
+	if err != nil {
+		return nil, err
+	}
+	all := append(OpcodeCases(), precompiles...)
+	if filter == nil {
+		return all, nil
+	}
+	return slices.DeleteFunc(all, func(c Case) bool { return !filter.MatchString(c.Name) }), nil
+}
+
+// Measure runs c with a growing number of iterations until a run takes at
+// least benchtime, like the testing package does, and reports the last run.
+func Measure(c Case, benchtime time.Duration) (Result, error) {
+	n := 1
+	for {
+		start := time.Now()
+		if err := c.Run(n); err != nil {
+			return Result{}, fmt.Errorf("%s: %w", c.Name, err)
+		}
+		elapsed := time.Since(start)
+		if elapsed >= benchtime || n >= 1_000_000_000 {
+			res := Result{Name: c.Name, NsPerOp: float64(elapsed.Nanoseconds()) / float64(n*max(c.Batch, 1))}
+			if c.Gas > 0 {
+				res.MGasPerSec = float64(c.Gas) * 1e3 / res.NsPerOp
+			}
+			return res, nil
+		}
+		// Aim past benchtime, growing at most 100x per round.
+		next := n * 100
+		if elapsed > 0 {
+			next = min(next, int(float64(n)*1.2*float64(benchtime)/float64(elapsed))+1)
+		}
+		n = max(next, n+1)
+	}
+}
+
+// Baseline maps case names to their recorded ns/op.
+type Baseline map[string]float64
+
+// ReadBaseline loads a baseline written by WriteBaseline.
+func ReadBaseline(path string) (Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// WriteBaseline records results as a baseline at path.
+func WriteBaseline(path string, results []Result) error {
+	b := make(Baseline, len(results))
+	for _, r := range results {
+		b[r.Name] = r.NsPerOp
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Regression is a case that got slower than its baseline allows.
+type Regression struct {
+	Name     string
+	Baseline float64
+	Measured float64
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %.1f ns/op, baseline %.1f ns/op (+%.1f%%)", r.Name, r.Measured, r.Baseline, 100*(r.Measured/r.Baseline-1))
+}
+
+// Compare returns the results that are slower than their baseline by more
+// than tolerance, a fraction (0.1 allows 10%). Cases missing from the
+// baseline are not gated.
+func Compare(results []Result, baseline Baseline, tolerance float64) []Regression {
+	var regressions []Regression
+	for _, r := range results {
+		base, ok := baseline[r.Name]
+		if !ok || base <= 0 {
+			continue
+		}
+		if r.NsPerOp > base*(1+tolerance) {
+			regressions = append(regressions, Regression{Name: r.Name, Baseline: base, Measured: r.NsPerOp})
+		}
+	}
+	return regressions
+}
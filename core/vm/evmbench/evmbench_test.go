@@ -0,0 +1,67 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package evmbench
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCasesRun(t *testing.T) {
+	cases, err := Cases(nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, cases)
+	for _, c := range cases {
+		require.NoError(t, c.Run(1), c.Name)
+	}
+
+	filtered, err := Cases(regexp.MustCompile(`^op/(ADD|MUL)$`))
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+}
+
+func TestMeasure(t *testing.T) {
+	cases, err := Cases(regexp.MustCompile(`^precompile/1/`))
+	require.NoError(t, err)
+	require.NotEmpty(t, cases)
+	res, err := Measure(cases[0], time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, cases[0].Name, res.Name)
+	require.Positive(t, res.NsPerOp)
+	require.Positive(t, res.MGasPerSec)
+}
+
+func TestCompare(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, WriteBaseline(path, []Result{{Name: "op/ADD", NsPerOp: 100}, {Name: "op/MUL", NsPerOp: 200}}))
+	baseline, err := ReadBaseline(path)
+	require.NoError(t, err)
+
+	results := []Result{
+		{Name: "op/ADD", NsPerOp: 109},
+		{Name: "op/MUL", NsPerOp: 221},
+		{Name: "op/SUB", NsPerOp: 1000},
+	}
+	regressions := Compare(results, baseline, 0.1)
+	require.Len(t, regressions, 1)
+	require.Equal(t, "op/MUL", regressions[0].Name)
+	require.Equal(t, "op/MUL: 221.0 ns/op, baseline 200.0 ns/op (+10.5%)", regressions[0].String())
+}
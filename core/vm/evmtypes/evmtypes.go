@@ -38,6 +38,7 @@ type BlockContext struct {
 	// GetHash returns the hash corresponding to n
 	GetHash          GetHashFunc
 	PostApplyMessage PostApplyMessageFunc
+	L1Cost           L1CostFunc // nil on non-rollup chains
 
 	// Block information
 	Coinbase    common.Address // Provides information for COINBASE
@@ -116,6 +117,10 @@ type (
 	// PostApplyMessageFunc is an extension point to execute custom logic at the end of core.ApplyMessage.
 	// It's used in Bor for AddFeeTransferLog or in ethereum to clear out the authority code at end of tx.
 	PostApplyMessageFunc func(ibs IntraBlockState, sender common.Address, coinbase common.Address, result *ExecutionResult)
+
+	// L1CostFunc is the signature of rollup L1 data fee function: fee which sender pays (on top of gas) for
+	// publishing the transaction on L1, and account which receives it. Used by OP Stack.
+	L1CostFunc func(ibs IntraBlockState, costData types.RollupCostData, blockTime uint64) (fee *uint256.Int, recipient common.Address, err error)
 )
 
 // IntraBlockState is an EVM database for full state querying.
@@ -142,6 +142,7 @@ type keccakState interface {
 type EVMInterpreter struct {
 	*VM
 	jt    *JumpTable // EVM instruction table
+	eofJt *JumpTable // instruction table of EOF code, nil before Osaka
 	depth int
 }
 
@@ -172,8 +173,10 @@ func copyJumpTable(jt *JumpTable) *JumpTable {
 
 // NewEVMInterpreter returns a new instance of the Interpreter.
 func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
-	var jt *JumpTable
+	var jt, eofJt *JumpTable
 	switch {
+	case evm.ChainRules().IsOsaka:
+		jt, eofJt = &osakaInstructionSet, &eofInstructionSet
 	case evm.ChainRules().IsPrague:
 		jt = &pragueInstructionSet
 	case evm.ChainRules().IsCancun:
@@ -217,7 +220,8 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 			evm: evm,
 			cfg: cfg,
 		},
-		jt: jt,
+		jt:    jt,
+		eofJt: eofJt,
 	}
 }
 
@@ -257,7 +261,18 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		gasCopy uint64 // for Tracer to log gas remaining before execution
 		logged  bool   // deferred Tracer should ignore already logged steps
 		res     []byte // result of the opcode execution function
+		jt      = in.jt
+		// Basic blocks of the code; while inside a block whose bounds were
+		// checked on entry, its instructions skip the stack and gas checks.
+		blocks   *blockTable
+		blockEnd uint64
+		inBlock  bool
 	)
+	if contract.eof != nil {
+		jt = in.eofJt
+	} else {
+		blocks = contract.basicBlocks(jt)
+	}
 
 	mem.Reset()
 
@@ -307,16 +322,25 @@ func (in *EVMInterpreter) Run(contract *Contract, input []byte, readOnly bool) (
 		// Get the operation from the jump table and validate the stack to ensure there are
 		// enough stack items available to perform the operation.
 		op = contract.GetOp(_pc)
-		operation := in.jt[op]
+		operation := jt[op]
 		cost = operation.constantGas // For tracing
-		// Validate stack
-		if sLen := locStack.Len(); sLen < operation.numPop {
-			return nil, &ErrStackUnderflow{stackLen: sLen, required: operation.numPop}
-		} else if sLen > operation.maxStack {
-			return nil, &ErrStackOverflow{stackLen: sLen, limit: operation.maxStack}
+		if !inBlock && blocks != nil {
+			blockEnd, inBlock = blocks.enter(_pc, contract.Gas, locStack.Len())
 		}
-		if !contract.UseGas(cost, in.cfg.Tracer, tracing.GasChangeIgnored) {
-			return nil, ErrOutOfGas
+		if inBlock {
+			// Stack and static gas were checked for the whole block on entry
+			inBlock = _pc != blockEnd
+			contract.Gas -= cost
+		} else {
+			// Validate stack
+			if sLen := locStack.Len(); sLen < operation.numPop {
+				return nil, &ErrStackUnderflow{stackLen: sLen, required: operation.numPop}
+			} else if sLen > operation.maxStack {
+				return nil, &ErrStackOverflow{stackLen: sLen, limit: operation.maxStack}
+			}
+			if !contract.UseGas(cost, in.cfg.Tracer, tracing.GasChangeIgnored) {
+				return nil, ErrOutOfGas
+			}
 		}
 		if operation.dynamicGas != nil {
 			// All ops with a dynamic memory usage also has a dynamic gas cost.
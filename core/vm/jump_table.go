@@ -51,6 +51,9 @@ type operation struct {
 	opNum   int // only for push, swap, dup
 	// memorySize returns the memory size required for the operation
 	memorySize memorySizeFunc
+	// undefined marks opcodes that are not part of the instruction set,
+	// which EOF code validation rejects
+	undefined bool
 }
 
 var (
@@ -67,6 +70,8 @@ var (
 	napoliInstructionSet           = newNapoliInstructionSet()
 	cancunInstructionSet           = newCancunInstructionSet()
 	pragueInstructionSet           = newPragueInstructionSet()
+	osakaInstructionSet            = newOsakaInstructionSet()
+	eofInstructionSet              = newEOFInstructionSet()
 )
 
 // JumpTable contains the EVM opcodes supported at a given fork.
@@ -90,6 +95,25 @@ func validateAndFillMaxStack(jt *JumpTable) {
 	}
 }
 
+// newEOFInstructionSet returns the instructions available to EOF code from
+// Osaka on. Legacy code keeps running with osakaInstructionSet.
+func newEOFInstructionSet() JumpTable {
+	instructionSet := newOsakaInstructionSet()
+	enableEOF(&instructionSet) // EIP-7692: EVM Object Format
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
+// newOsakaInstructionSet returns the frontier, homestead, byzantium,
+// constantinople, istanbul, petersburg, berlin, london, paris, shanghai,
+// cancun, prague, and osaka instructions.
+func newOsakaInstructionSet() JumpTable {
+	instructionSet := newPragueInstructionSet()
+	enableEOFLegacyStubs(&instructionSet) // EIP-3540: legacy code sees EOF accounts as 0xEF00
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
 // newPragueInstructionSet returns the frontier, homestead, byzantium,
 // constantinople, istanbul, petersburg, berlin, london, paris, shanghai,
 // cancun, and prague instructions.
@@ -1215,7 +1239,7 @@ func newFrontierInstructionSet() JumpTable {
 	// Fill all unassigned slots with opUndefined.
 	for i, entry := range tbl {
 		if entry == nil {
-			tbl[i] = &operation{execute: opUndefined}
+			tbl[i] = &operation{execute: opUndefined, undefined: true}
 		}
 	}
 
@@ -147,4 +147,5 @@ func SetupEndpoints(ctx *cli.Context, node *node.ErigonNode, diagMux *http.Serve
 	SetupBodiesAccess(diagMux, diagnostic)
 	SetupSysInfoAccess(diagMux, diagnostic)
 	SetupProfileAccess(diagMux, diagnostic)
+	SetupWebUIAccess(ctx, diagMux, node, diagnostic)
 }
@@ -23,7 +23,7 @@ import (
 	"github.com/erigontech/erigon/params"
 )
 
-const Version = 3
+const Version = 4
 
 func SetupVersionAccess(metricsMux *http.ServeMux) {
 	if metricsMux == nil {
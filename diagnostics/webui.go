@@ -0,0 +1,231 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"net"
+	"net/http"
+	"sort"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/urfave/cli/v2"
+
+	diaglib "github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/turbo/node"
+)
+
+var diagnosticsGeoIPFlag = "diagnostics.geoip"
+
+//go:embed webui
+var webUIFiles embed.FS
+
+// UIState is everything the web UI shows besides the dashboard history, which it
+// streams from the `/dashboard` WebSocket.
+type UIState struct {
+	Stages   []diaglib.SyncStage      `json:"stages"`
+	Download UIDownload               `json:"download"`
+	Segments []UISegment              `json:"segments"`
+	Peers    []UIPeerLocation         `json:"peers"`
+	TxPool   diaglib.TxPoolStatistics `json:"txpool"`
+}
+
+type UIDownload struct {
+	Downloaded   uint64 `json:"downloaded"`
+	Total        uint64 `json:"total"`
+	DownloadRate uint64 `json:"downloadRate"`
+	Finished     bool   `json:"finished"`
+}
+
+type UISegment struct {
+	Name         string `json:"name"`
+	Downloaded   uint64 `json:"downloaded"`
+	Total        uint64 `json:"total"`
+	DownloadRate uint64 `json:"downloadRate"`
+	Peers        int    `json:"peers"`
+	Webseeds     int    `json:"webseeds"`
+}
+
+// UIPeerLocation is the number of peers connected from one place. Without a GeoIP
+// database every peer IP is a place of its own.
+type UIPeerLocation struct {
+	Country   string  `json:"country,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Latitude  float64 `json:"lat,omitempty"`
+	Longitude float64 `json:"lon,omitempty"`
+	IP        string  `json:"ip,omitempty"`
+	Count     int     `json:"count"`
+}
+
+type geoRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Location struct {
+		Latitude  float64 `maxminddb:"latitude"`
+		Longitude float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+}
+
+type geoLookup func(ip net.IP) (UIPeerLocation, bool)
+
+func SetupWebUIAccess(ctx *cli.Context, metricsMux *http.ServeMux, node *node.ErigonNode, diag *diaglib.DiagnosticClient) {
+	if metricsMux == nil {
+		return
+	}
+
+	static, err := fs.Sub(webUIFiles, "webui")
+	if err != nil {
+		log.Error("[Diagnostics] Failed to load web UI", "err", err)
+		return
+	}
+	metricsMux.Handle("/ui/", http.StripPrefix("/ui/", http.FileServer(http.FS(static))))
+
+	var lookup geoLookup
+	if path := ctx.String(diagnosticsGeoIPFlag); path != "" {
+		db, err := maxminddb.Open(path)
+		if err != nil {
+			log.Warn("[Diagnostics] Failed to open GeoIP database, peers are shown without location", "path", path, "err", err)
+		} else {
+			lookup = maxmindLookup(db)
+		}
+	}
+
+	metricsMux.HandleFunc("/ui/state", func(w http.ResponseWriter, r *http.Request) {
+		var ips []string
+		if reply, err := node.Backend().Peers(r.Context()); err == nil {
+			for _, peer := range reply.Peers {
+				ips = append(ips, peer.ConnRemoteAddr)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(uiState(diag, ips, lookup))
+	})
+}
+
+func maxmindLookup(db *maxminddb.Reader) geoLookup {
+	return func(ip net.IP) (UIPeerLocation, bool) {
+		var rec geoRecord
+		if err := db.Lookup(ip, &rec); err != nil || rec.Country.ISOCode == "" {
+			return UIPeerLocation{}, false
+		}
+		return UIPeerLocation{
+			Country:   rec.Country.ISOCode,
+			City:      rec.City.Names["en"],
+			Latitude:  rec.Location.Latitude,
+			Longitude: rec.Location.Longitude,
+		}, true
+	}
+}
+
+func uiState(diag *diaglib.DiagnosticClient, peerAddrs []string, lookup geoLookup) UIState {
+	stats := diag.SyncStatistics()
+	download := stats.SnapshotDownload
+
+	state := UIState{
+		Stages: diag.GetSyncStages(),
+		Download: UIDownload{
+			Downloaded:   download.Downloaded,
+			Total:        download.Total,
+			DownloadRate: download.DownloadRate,
+			Finished:     download.DownloadFinished,
+		},
+		Segments: make([]UISegment, 0, len(download.SegmentsDownloading)),
+		Peers:    peerLocations(peerAddrs, lookup),
+		TxPool:   diag.TxPoolStatistics(),
+	}
+
+	for name, segment := range download.SegmentsDownloading {
+		if segment.TotalBytes > 0 && segment.DownloadedBytes >= segment.TotalBytes {
+			continue
+		}
+		s := UISegment{
+			Name:       name,
+			Downloaded: segment.DownloadedBytes,
+			Total:      segment.TotalBytes,
+			Peers:      len(segment.Peers),
+			Webseeds:   len(segment.Webseeds),
+		}
+		for _, peer := range segment.Peers {
+			s.DownloadRate += peer.DownloadRate
+		}
+		for _, peer := range segment.Webseeds {
+			s.DownloadRate += peer.DownloadRate
+		}
+		state.Segments = append(state.Segments, s)
+	}
+	sort.Slice(state.Segments, func(i, j int) bool { return state.Segments[i].Name < state.Segments[j].Name })
+
+	return state
+}
+
+// peerLocations groups the remote addresses of the peers by location.
+func peerLocations(addrs []string, lookup geoLookup) []UIPeerLocation {
+	byKey := map[string]*UIPeerLocation{}
+	for _, addr := range addrs {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			continue
+		}
+
+		loc, ok := UIPeerLocation{IP: ip.String()}, false
+		if lookup != nil {
+			if geo, found := lookup(ip); found {
+				loc, ok = geo, true
+			}
+		}
+		key := loc.IP
+		if ok {
+			key = loc.Country + "/" + loc.City
+		}
+		if existing, found := byKey[key]; found {
+			existing.Count++
+			continue
+		}
+		loc.Count = 1
+		byKey[key] = &loc
+	}
+
+	locations := make([]UIPeerLocation, 0, len(byKey))
+	for _, loc := range byKey {
+		locations = append(locations, *loc)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Count != locations[j].Count {
+			return locations[i].Count > locations[j].Count
+		}
+		if locations[i].Country != locations[j].Country {
+			return locations[i].Country < locations[j].Country
+		}
+		if locations[i].City != locations[j].City {
+			return locations[i].City < locations[j].City
+		}
+		return locations[i].IP < locations[j].IP
+	})
+	return locations
+}
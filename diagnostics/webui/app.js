@@ -0,0 +1,166 @@
+// The web UI of the diagnostics endpoint. The state of the stages, downloads, peers and
+// txpool is polled from `state`, the peer and traffic history is streamed from the
+// `dashboard` WebSocket.
+(function () {
+  "use strict";
+
+  const pollInterval = 2000;
+
+  function el(tag, attrs, children) {
+    const e = document.createElement(tag);
+    for (const [k, v] of Object.entries(attrs || {})) {
+      e.setAttribute(k, v);
+    }
+    for (const c of children || []) {
+      e.append(c);
+    }
+    return e;
+  }
+
+  function bytes(n) {
+    const units = ["B", "KB", "MB", "GB", "TB"];
+    let i = 0;
+    while (n >= 1024 && i < units.length - 1) {
+      n /= 1024;
+      i++;
+    }
+    return n.toFixed(i === 0 ? 0 : 1) + " " + units[i];
+  }
+
+  function percent(done, total) {
+    return total > 0 ? Math.min(100, (100 * done) / total) : 0;
+  }
+
+  function bar(pct) {
+    const fill = el("div");
+    fill.style.width = pct.toFixed(1) + "%";
+    return el("div", { class: "bar" }, [fill]);
+  }
+
+  function figures(items) {
+    return el("div", { class: "figures" }, items.map(([name, value]) => el("div", {}, [el("b", {}, [String(value)]), name])));
+  }
+
+  function stageRow(stage, sub) {
+    const pct = stage.state === 2 ? 100 : parseFloat(stage.stats.progress) || 0;
+    const state = ["queued", "running", "completed"][stage.state] || "";
+    const info = [stage.stats.progress, stage.stats.timeElapsed, stage.stats.timeLeft && "left " + stage.stats.timeLeft].filter(Boolean).join(" · ");
+    return el("div", { class: "stage " + state + (sub ? " substage" : "") }, [
+      el("div", { class: "label" }, [el("span", {}, [stage.id]), el("span", {}, [info])]),
+      bar(pct),
+    ]);
+  }
+
+  function renderStages(stages) {
+    const root = document.getElementById("stages");
+    root.replaceChildren();
+    for (const stage of stages || []) {
+      root.append(stageRow(stage, false));
+      if (stage.state === 1) {
+        for (const sub of stage.subStages || []) {
+          root.append(stageRow(sub, true));
+        }
+      }
+    }
+  }
+
+  function renderDownload(download, segments) {
+    const pct = download.finished ? 100 : percent(download.downloaded, download.total);
+    document.getElementById("download").replaceChildren(
+      figures([
+        ["downloaded", bytes(download.downloaded) + " / " + bytes(download.total)],
+        ["speed", bytes(download.downloadRate) + "/s"],
+      ]),
+      bar(pct),
+    );
+    const body = document.querySelector("#segments tbody");
+    body.replaceChildren(
+      ...segments.map((s) =>
+        el("tr", {}, [
+          el("td", {}, [s.name]),
+          el("td", {}, [percent(s.downloaded, s.total).toFixed(1) + "%"]),
+          el("td", {}, [bytes(s.downloadRate) + "/s"]),
+          el("td", {}, [String(s.peers)]),
+          el("td", {}, [String(s.webseeds)]),
+        ]),
+      ),
+    );
+  }
+
+  function renderPeers(peers) {
+    const map = document.getElementById("map");
+    map.replaceChildren();
+    const ns = "http://www.w3.org/2000/svg";
+    for (const p of peers) {
+      if (!p.country) {
+        continue;
+      }
+      const c = document.createElementNS(ns, "circle");
+      c.setAttribute("cx", p.lon || 0);
+      c.setAttribute("cy", -(p.lat || 0));
+      c.setAttribute("r", 1.5 + Math.sqrt(p.count));
+      map.append(c);
+    }
+    const body = document.querySelector("#peers tbody");
+    body.replaceChildren(
+      ...peers.map((p) => el("tr", {}, [el("td", {}, [p.country ? [p.city, p.country].filter(Boolean).join(", ") : p.ip]), el("td", {}, [String(p.count)])])),
+    );
+  }
+
+  function renderTxPool(txpool) {
+    document.getElementById("txpool").replaceChildren(
+      figures([
+        ["pending", txpool.pending],
+        ["base fee", txpool.baseFee],
+        ["queued", txpool.queued],
+      ]),
+    );
+  }
+
+  function renderHistory(sample) {
+    document.getElementById("history").replaceChildren(
+      figures([
+        ["connected", sample.p2p.peers],
+        ["received", bytes(sample.p2p.bytesIn)],
+        ["sent", bytes(sample.p2p.bytesOut)],
+      ]),
+    );
+  }
+
+  async function poll() {
+    try {
+      const res = await fetch("state");
+      const state = await res.json();
+      renderStages(state.stages);
+      renderDownload(state.download, state.segments || []);
+      renderPeers(state.peers || []);
+      renderTxPool(state.txpool);
+    } catch (e) {
+      document.getElementById("connection").textContent = "disconnected";
+    }
+    setTimeout(poll, pollInterval);
+  }
+
+  function connect() {
+    const url = new URL("../dashboard", window.location.href);
+    url.protocol = url.protocol === "https:" ? "wss:" : "ws:";
+    const ws = new WebSocket(url);
+    const status = document.getElementById("connection");
+    ws.onopen = () => (status.textContent = "connected");
+    ws.onclose = () => {
+      status.textContent = "disconnected";
+      setTimeout(connect, pollInterval);
+    };
+    ws.onmessage = (ev) => {
+      const msg = JSON.parse(ev.data);
+      if (msg.messageType === "dashboardHistory" && msg.message.length > 0) {
+        renderHistory(msg.message[msg.message.length - 1]);
+      } else if (msg.messageType === "dashboard") {
+        renderHistory(msg.message);
+      }
+    };
+  }
+
+  poll();
+  connect();
+})();
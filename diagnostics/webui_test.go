@@ -0,0 +1,46 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerLocations(t *testing.T) {
+	addrs := []string{"1.1.1.1:30303", "1.1.1.1:30304", "2.2.2.2:30303", "[2001:db8::1]:30303", "not-an-address"}
+
+	// without GeoIP every IP is a location of its own
+	require.Equal(t, []UIPeerLocation{
+		{IP: "1.1.1.1", Count: 2},
+		{IP: "2.2.2.2", Count: 1},
+		{IP: "2001:db8::1", Count: 1},
+	}, peerLocations(addrs, nil))
+
+	lookup := func(ip net.IP) (UIPeerLocation, bool) {
+		if ip.To4() == nil {
+			return UIPeerLocation{}, false
+		}
+		return UIPeerLocation{Country: "DE", City: "Berlin", Latitude: 52.5, Longitude: 13.4}, true
+	}
+	require.Equal(t, []UIPeerLocation{
+		{Country: "DE", City: "Berlin", Latitude: 52.5, Longitude: 13.4, Count: 3},
+		{IP: "2001:db8::1", Count: 1},
+	}, peerLocations(addrs, lookup))
+}
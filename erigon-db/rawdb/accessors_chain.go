@@ -98,9 +98,20 @@ func GetLatestBadBlocks(tx kv.Tx) ([]*types.Block, error) {
 	}
 
 	blockIds := bheapCache.SortedValues()
-	blocks := make([]*types.Block, len(blockIds))
-	for i, blockId := range blockIds {
-		blocks[i] = ReadBlock(tx, blockId.Hash, blockId.Number)
+	blocks := make([]*types.Block, 0, len(blockIds))
+	for _, blockId := range blockIds {
+		block := ReadBlock(tx, blockId.Hash, blockId.Number)
+		if block == nil { // body was pruned, but may be archived
+			bad, err := ReadBadBlock(tx, blockId.Hash)
+			if err != nil {
+				return nil, err
+			}
+			if bad == nil {
+				continue
+			}
+			block = bad.Block
+		}
+		blocks = append(blocks, block)
 	}
 
 	return blocks, nil
@@ -118,6 +129,59 @@ func ResetBadBlockCache(tx kv.Tx, limit int) error {
 
 /* latest bad blocks end */
 
+// BadBlock - archived copy of a block which failed validation. Unlike the block in kv.Headers/kv.BlockBody
+// it survives pruning of bad blocks, so it can be replayed for a postmortem.
+type BadBlock struct {
+	Block           *types.Block
+	ParentStateRoot common.Hash
+	Reason          string
+	Time            uint64 // unix seconds when the block was rejected
+}
+
+// WriteBadBlock archives the block with the reason of its failure in kv.BadBlocks.
+// Must be called before TruncateCanonicalHash(markChainAsBad) - while the block is still readable by hash.
+// Blocks which are not in the DB (e.g. only the header was seen) are skipped.
+func WriteBadBlock(tx kv.RwTx, hash common.Hash, reason error) error {
+	number := ReadHeaderNumber(tx, hash)
+	if number == nil {
+		return nil
+	}
+	block := ReadBlock(tx, hash, *number)
+	if block == nil {
+		return nil
+	}
+	bad := &BadBlock{Block: block, Time: uint64(time.Now().Unix())}
+	if reason != nil {
+		bad.Reason = reason.Error()
+	}
+	if *number > 0 {
+		if parent := ReadHeader(tx, block.ParentHash(), *number-1); parent != nil {
+			bad.ParentStateRoot = parent.Root
+		}
+	}
+	v, err := rlp.EncodeToBytes(bad)
+	if err != nil {
+		return fmt.Errorf("WriteBadBlock: %w", err)
+	}
+	return tx.Put(kv.BadBlocks, hash[:], v)
+}
+
+// ReadBadBlock returns the archived bad block, or nil if the block was never archived.
+func ReadBadBlock(db kv.Getter, hash common.Hash) (*BadBlock, error) {
+	v, err := db.GetOne(kv.BadBlocks, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil
+	}
+	bad := &BadBlock{}
+	if err := rlp.DecodeBytes(v, bad); err != nil {
+		return nil, fmt.Errorf("ReadBadBlock: %w, hash=%x", err, hash)
+	}
+	return bad, nil
+}
+
 func IsCanonicalHash(db kv.Getter, hash common.Hash, number uint64) (bool, error) {
 	canonicalHash, err := ReadCanonicalHash(db, number)
 	if err != nil {
@@ -1354,6 +1418,39 @@ func WriteReceiptCacheV2(tx kv.TemporalPutDel, receipt *types.Receipt) error {
 	return nil
 }
 
+// ReadTxAccessList returns the access list recorded during execution of the txn with the given txNum.
+// ok is false for system txns and for txns executed without access list recording.
+func ReadTxAccessList(tx kv.TemporalTx, txNum uint64) (al types.AccessList, ok bool, err error) {
+	v, ok, err := tx.HistorySeek(kv.AccessListDomain, accessListKey, txNum+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("ReadTxAccessList: txNum=%d, %w", txNum, err)
+	}
+	if !ok || len(v) == 0 {
+		return nil, false, nil
+	}
+	if err := rlp.DecodeBytes(v, &al); err != nil {
+		return nil, false, fmt.Errorf("ReadTxAccessList: txNum=%d, len(v)=%d, %w", txNum, len(v), err)
+	}
+	return al, true, nil
+}
+
+// WriteTxAccessList stores the access list of the current txNum of tx. A nil list marks a txn
+// without one (system txns), so that every txNum has its own value in the history.
+func WriteTxAccessList(tx kv.TemporalPutDel, al types.AccessList) error {
+	toWrite := []byte{}
+	if al != nil {
+		var err error
+		if toWrite, err = rlp.EncodeToBytes(al); err != nil {
+			return fmt.Errorf("WriteTxAccessList: %w", err)
+		}
+	}
+	if err := tx.DomainPut(kv.AccessListDomain, accessListKey, nil, toWrite, nil, 0); err != nil {
+		return fmt.Errorf("WriteTxAccessList: %w", err)
+	}
+	return nil
+}
+
 var (
 	receiptCacheKey = []byte{0x0}
+	accessListKey   = []byte{0x0}
 )
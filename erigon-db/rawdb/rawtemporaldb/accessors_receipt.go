@@ -12,8 +12,16 @@ var (
 	CumulativeGasUsedInBlockKey     = []byte{0x0}
 	CumulativeBlobGasUsedInBlockKey = []byte{0x1}
 	FirstLogIndexKey                = []byte{0x2}
+	// LogsCountKey - amount of logs in chain up to and including the txn. Logs of bor state sync txns are not counted.
+	LogsCountKey = []byte{0x3}
 )
 
+// ReceiptPutter - AppendReceipt needs previous value of LogsCountKey
+type ReceiptPutter interface {
+	kv.TemporalPutDel
+	GetLatest(domain kv.Domain, k []byte) (v []byte, step uint64, err error)
+}
+
 // `ReadReceipt` does fill `rawLogs` calulated fields. but we don't need it anymore.
 func ReceiptAsOfWithApply(tx kv.TemporalTx, txNum uint64, rawLogs types.Logs, txnIdx int, blockHash common.Hash, blockNum uint64, txn types.Transaction) (*types.Receipt, error) {
 	cumulativeGasUsedBeforeTxn, cumulativeBlobGasUsed, firstLogIndexWithinBlock, err := ReceiptAsOf(tx, txNum+1)
@@ -71,12 +79,28 @@ func ReceiptAsOf(tx kv.TemporalTx, txNum uint64) (cumGasUsed uint64, cumBlobGasu
 	return
 }
 
-func AppendReceipt(tx kv.TemporalPutDel, receipt *types.Receipt, cumBlobGasUsed uint64) error {
+// LogsCountAsOf - amount of logs in chain before txNum, it's also chain-global index of first log of txNum.
+// `ok = false` means: receipts domain was produced without logs count.
+func LogsCountAsOf(tx kv.TemporalTx, txNum uint64) (logsCount uint64, ok bool, err error) {
+	v, ok, err := tx.GetAsOf(kv.ReceiptDomain, LogsCountKey, txNum)
+	if err != nil || !ok || len(v) == 0 {
+		return 0, false, err
+	}
+	return uvarint(v), true, nil
+}
+
+func AppendReceipt(tx ReceiptPutter, receipt *types.Receipt, cumBlobGasUsed uint64) error {
 	var cumGasUsedInBlock uint64
 	var firstLogIndexWithinBlock uint32
+	prevLogsCount, prevStep, err := tx.GetLatest(kv.ReceiptDomain, LogsCountKey)
+	if err != nil {
+		return err
+	}
+	logsCount := uvarint(prevLogsCount)
 	if receipt != nil {
 		cumGasUsedInBlock = receipt.CumulativeGasUsed
 		firstLogIndexWithinBlock = receipt.FirstLogIndexWithinBlock
+		logsCount += uint64(len(receipt.Logs))
 	}
 
 	{
@@ -102,6 +126,14 @@ func AppendReceipt(tx kv.TemporalPutDel, receipt *types.Receipt, cumBlobGasUsed
 			return err
 		}
 	}
+
+	{
+		var buf [binary.MaxVarintLen64]byte
+		i := binary.PutUvarint(buf[:], logsCount)
+		if err := tx.DomainPut(kv.ReceiptDomain, LogsCountKey, nil, buf[:i], prevLogsCount, prevStep); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -109,3 +109,34 @@ func TestAppendReceipt(t *testing.T) {
 	// reader
 
 }
+
+func TestLogsCount(t *testing.T) {
+	dirs, require := datadir.New(t.TempDir()), require.New(t)
+	db := temporaltest.NewTestDB(t, dirs)
+	tx, err := db.BeginTemporalRw(context.Background())
+	require.NoError(err)
+	defer tx.Rollback()
+
+	doms, err := state.NewSharedDomains(tx, log.New())
+	require.NoError(err)
+	defer doms.Close()
+	doms.SetTx(tx)
+
+	logs := func(n int) types.Logs { return make(types.Logs, n) }
+	doms.SetTxNum(0) // block1 system txn
+	require.NoError(AppendReceipt(doms, nil, 0))
+	doms.SetTxNum(1)
+	require.NoError(AppendReceipt(doms, &types.Receipt{Logs: logs(2)}, 0))
+	doms.SetTxNum(2)
+	require.NoError(AppendReceipt(doms, &types.Receipt{Logs: logs(0)}, 0))
+	doms.SetTxNum(3) // block2
+	require.NoError(AppendReceipt(doms, &types.Receipt{Logs: logs(3)}, 0))
+	doms.SetTxNum(4)
+	require.NoError(doms.Flush(context.Background(), tx))
+
+	for txNum, expect := range []uint64{0, 0, 2, 2, 5} {
+		count, _, err := LogsCountAsOf(tx, uint64(txNum))
+		require.NoError(err)
+		require.Equal(expect, count, txNum)
+	}
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/config3"
 )
 
 // Config is the core config which determines the blockchain settings.
@@ -92,6 +93,20 @@ type Config struct {
 	Bor     BorConfig       `json:"-"`
 	BorJSON json.RawMessage `json:"bor,omitempty"`
 
+	// OP Stack rollups: execution rules of derived L2 chain. Fork times are activated on top of Ethereum
+	// forks (Canyon goes with Shanghai, Ecotone with Cancun).
+	Optimism     *OptimismConfig `json:"optimism,omitempty"`
+	RegolithTime *big.Int        `json:"regolithTime,omitempty"`
+	CanyonTime   *big.Int        `json:"canyonTime,omitempty"`
+	EcotoneTime  *big.Int        `json:"ecotoneTime,omitempty"`
+
+	// Additional precompiled contracts, activated at given block or time
+	Precompiles []PrecompileConfig `json:"precompiles,omitempty"`
+
+	// (Optional) amount of txNums in one step of state files. Chains with high tx rate (L2s) may want
+	// smaller files. Can't be changed for existing datadir - see `erigon snapshots reshard`.
+	AggregationStep uint64 `json:"aggregationStep,omitempty"`
+
 	// Account Abstraction
 	AllowAA bool
 }
@@ -383,10 +398,20 @@ func (c *Config) GetBlobGasPriceUpdateFraction(t uint64) uint64 {
 	return b.BaseFeeUpdateFraction(c.IsPrague(t))
 }
 
+func (c *Config) GetAggregationStep() uint64 {
+	if c != nil && c.AggregationStep != 0 {
+		return c.AggregationStep
+	}
+	return config3.DefaultStepSize
+}
+
 func (c *Config) SecondsPerSlot() uint64 {
 	if c.Bor != nil {
 		return 2 // Polygon
 	}
+	if c.Optimism != nil {
+		return 2 // OP Stack
+	}
 	if c.Aura != nil {
 		return 5 // Gnosis
 	}
@@ -625,6 +650,9 @@ type Rules struct {
 	IsCancun, IsNapoli                                bool
 	IsPrague, IsOsaka                                 bool
 	IsAura                                            bool
+	IsOptimism                                        bool
+
+	Precompiles []*PrecompileConfig // additional precompiles of the chain, active at the block
 }
 
 // Rules ensures c's ChainID is not nil and returns a new Rules instance
@@ -651,6 +679,8 @@ func (c *Config) Rules(num uint64, time uint64) *Rules {
 		IsPrague:           c.IsPrague(time),
 		IsOsaka:            c.IsOsaka(time),
 		IsAura:             c.Aura != nil,
+		IsOptimism:         c.IsOptimism(),
+		Precompiles:        c.activePrecompiles(num, time),
 	}
 }
 
@@ -0,0 +1,62 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package chain
+
+import "github.com/erigontech/erigon-lib/chain/params"
+
+// OptimismConfig - parameters of OP Stack rollup, see https://specs.optimism.io/protocol/exec-engine.html
+type OptimismConfig struct {
+	EIP1559Elasticity        uint64 `json:"eip1559Elasticity"`
+	EIP1559Denominator       uint64 `json:"eip1559Denominator"`
+	EIP1559DenominatorCanyon uint64 `json:"eip1559DenominatorCanyon,omitempty"`
+}
+
+// IsOptimism returns whether chain is OP Stack rollup
+func (c *Config) IsOptimism() bool {
+	return c != nil && c.Optimism != nil
+}
+
+// IsRegolith returns whether time is either equal to the Regolith fork time or greater.
+func (c *Config) IsRegolith(time uint64) bool {
+	return c.IsOptimism() && isForked(c.RegolithTime, time)
+}
+
+// IsCanyon returns whether time is either equal to the Canyon fork time or greater.
+func (c *Config) IsCanyon(time uint64) bool {
+	return c.IsOptimism() && isForked(c.CanyonTime, time)
+}
+
+// IsEcotone returns whether time is either equal to the Ecotone fork time or greater.
+func (c *Config) IsEcotone(time uint64) bool {
+	return c.IsOptimism() && isForked(c.EcotoneTime, time)
+}
+
+// OptimismBaseFeeParams - EIP-1559 elasticity multiplier and base fee change denominator of block with given time.
+// Unset values fall back to Ethereum ones.
+func (c *Config) OptimismBaseFeeParams(time uint64) (elasticity, denominator uint64) {
+	elasticity, denominator = c.Optimism.EIP1559Elasticity, c.Optimism.EIP1559Denominator
+	if c.IsCanyon(time) && c.Optimism.EIP1559DenominatorCanyon != 0 {
+		denominator = c.Optimism.EIP1559DenominatorCanyon
+	}
+	if elasticity == 0 {
+		elasticity = params.ElasticityMultiplier
+	}
+	if denominator == 0 {
+		denominator = params.BaseFeeChangeDenominator
+	}
+	return elasticity, denominator
+}
@@ -70,6 +70,9 @@ const (
 	ColdSloadCostEIP2929         = uint64(2100) // COLD_SLOAD_COST
 	WarmStorageReadCostEIP2929   = uint64(100)  // WARM_STORAGE_READ_COST
 
+	ExtCallMinRetainedGas uint64 = 5000 // MIN_RETAINED_GAS of EIP-7069: gas an EXT*CALL caller always keeps
+	ExtCallMinCalleeGas   uint64 = 2300 // MIN_CALLEE_GAS of EIP-7069: below it an EXT*CALL fails without executing
+
 	// In EIP-2200: SstoreResetGas was 5000.
 	// In EIP-2929: SstoreResetGas was changed to '5000 - COLD_SLOAD_COST'.
 	// In EIP-3529: SSTORE_CLEARS_SCHEDULE is defined as SSTORE_RESET_GAS + ACCESS_LIST_STORAGE_KEY_COST
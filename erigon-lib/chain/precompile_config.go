@@ -0,0 +1,63 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package chain
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+// PrecompileConfig - additional precompiled contract of the chain. Implementation is registered in core/vm by Name,
+// so L2 forks can add precompiles without patching the vm package.
+type PrecompileConfig struct {
+	Address common.Address  `json:"address"`
+	Name    string          `json:"name"`
+	Block   *big.Int        `json:"block,omitempty"`  // activation block, if set
+	Time    *big.Int        `json:"time,omitempty"`   // activation time, if set
+	Gas     *PrecompileGas  `json:"gas,omitempty"`    // overrides gas schedule of implementation
+	Params  json.RawMessage `json:"params,omitempty"` // passed to implementation constructor
+}
+
+// PrecompileGas - linear gas schedule: Base + PerWord * ceil(len(input) / 32)
+type PrecompileGas struct {
+	Base    uint64 `json:"base"`
+	PerWord uint64 `json:"perWord"`
+}
+
+// IsActive returns whether precompile is active at given block: all set activation conditions hold
+func (p *PrecompileConfig) IsActive(num uint64, time uint64) bool {
+	if p.Block != nil && !isForked(p.Block, num) {
+		return false
+	}
+	if p.Time != nil && !isForked(p.Time, time) {
+		return false
+	}
+	return true
+}
+
+// activePrecompiles returns nil if chain has no additional precompiles active at given block
+func (c *Config) activePrecompiles(num uint64, time uint64) []*PrecompileConfig {
+	var active []*PrecompileConfig
+	for i := range c.Precompiles {
+		if c.Precompiles[i].IsActive(num, time) {
+			active = append(active, &c.Precompiles[i])
+		}
+	}
+	return active
+}
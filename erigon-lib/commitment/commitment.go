@@ -98,6 +98,9 @@ type Trie interface {
 
 	// Process updates
 	Process(ctx context.Context, updates *Updates, logPrefix string) (rootHash []byte, err error)
+
+	// Counters of last Process call
+	ProcessStats() *ProcessStats
 }
 
 type PatriciaContext interface {
@@ -239,6 +239,10 @@ func (p *ConcurrentPatriciaHashed) Process(ctx context.Context, updates *Updates
 	// defer func(s time.Time, wasConcurrent bool) {
 	// 	fmt.Printf("commitment time %s; keys %s; was concurrent: %t\n", time.Since(s), common.PrettyCounter(updCount), wasConcurrent)
 	// }(start, wasConcurrent)
+	p.root.stats.Reset()
+	for _, m := range p.mounts {
+		m.stats.Reset()
+	}
 
 	switch updates.IsConcurrentCommitment() {
 	case true:
@@ -275,6 +279,16 @@ func (p *ConcurrentPatriciaHashed) CanDoConcurrentNext() (bool, error) {
 	return false, nil
 }
 
+// ProcessStats sums counters of root trie and all mounted subtries
+func (p *ConcurrentPatriciaHashed) ProcessStats() *ProcessStats {
+	stats := &ProcessStats{}
+	stats.Add(&p.root.stats)
+	for _, m := range p.mounts {
+		stats.Add(&m.stats)
+	}
+	return stats
+}
+
 // Variant returns commitment trie variant
 func (p *ConcurrentPatriciaHashed) Variant() TrieVariant {
 	return VariantConcurrentHexPatricia
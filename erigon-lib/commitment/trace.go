@@ -0,0 +1,274 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing of root calculation hot spots:
+//   - counters of every Process call (ProcessStats) are attached to OpenTelemetry spans. Spans are no-op until
+//     some exporter is registered by otel.SetTracerProvider
+//   - if prefix tracing is enabled (SetPrefixTracing) - time spent in fold/unfold is accounted to trie prefix
+//     of first tracedPrefixNibbles nibbles, and slowest prefixes of last blocks are kept in memory.
+
+var tracer = otel.Tracer("github.com/erigontech/erigon-lib/commitment")
+
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// ProcessStats - counters of one Process call
+type ProcessStats struct {
+	Keys           uint64        `json:"keys"`
+	BranchesLoaded uint64        `json:"branchesLoaded"`
+	CellsHashed    uint64        `json:"cellsHashed"`
+	AccountsLoaded uint64        `json:"accountsLoaded"`
+	StorageLoaded  uint64        `json:"storageLoaded"`
+	Unfolding      time.Duration `json:"unfoldingNs"` // collected only if prefix tracing enabled
+	Folding        time.Duration `json:"foldingNs"`   // collected only if prefix tracing enabled
+
+	// filled by PatriciaContext: where branches were read from
+	BranchReadsMem     uint64 `json:"branchReadsMem"`
+	BranchReadsDB      uint64 `json:"branchReadsDB"`
+	BranchReadsFiles   uint64 `json:"branchReadsFiles"`
+	BranchReadsHistory uint64 `json:"branchReadsHistory"`
+
+	prefixes map[uint32]prefixSpent
+}
+
+type prefixSpent struct {
+	spent time.Duration
+	calls uint64
+}
+
+func (s *ProcessStats) Reset() {
+	prefixes := s.prefixes
+	clear(prefixes)
+	*s = ProcessStats{prefixes: prefixes}
+}
+
+func (s *ProcessStats) Add(o *ProcessStats) {
+	s.Keys += o.Keys
+	s.BranchesLoaded += o.BranchesLoaded
+	s.CellsHashed += o.CellsHashed
+	s.AccountsLoaded += o.AccountsLoaded
+	s.StorageLoaded += o.StorageLoaded
+	s.Unfolding += o.Unfolding
+	s.Folding += o.Folding
+	s.BranchReadsMem += o.BranchReadsMem
+	s.BranchReadsDB += o.BranchReadsDB
+	s.BranchReadsFiles += o.BranchReadsFiles
+	s.BranchReadsHistory += o.BranchReadsHistory
+	for p, v := range o.prefixes {
+		s.addPrefix(p, v.spent, v.calls)
+	}
+}
+
+func (s *ProcessStats) Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("keys", int64(s.Keys)),
+		attribute.Int64("branches_loaded", int64(s.BranchesLoaded)),
+		attribute.Int64("cells_hashed", int64(s.CellsHashed)),
+		attribute.Int64("accounts_loaded", int64(s.AccountsLoaded)),
+		attribute.Int64("storage_loaded", int64(s.StorageLoaded)),
+		attribute.Int64("unfolding_ns", int64(s.Unfolding)),
+		attribute.Int64("folding_ns", int64(s.Folding)),
+		attribute.Int64("branch_reads_mem", int64(s.BranchReadsMem)),
+		attribute.Int64("branch_reads_db", int64(s.BranchReadsDB)),
+		attribute.Int64("branch_reads_files", int64(s.BranchReadsFiles)),
+		attribute.Int64("branch_reads_history", int64(s.BranchReadsHistory)),
+	}
+}
+
+func (s *ProcessStats) addPrefix(p uint32, spent time.Duration, calls uint64) {
+	if s.prefixes == nil {
+		s.prefixes = make(map[uint32]prefixSpent)
+	}
+	v := s.prefixes[p]
+	v.spent += spent
+	v.calls += calls
+	s.prefixes[p] = v
+}
+
+// traceStart returns zero time if prefix tracing is disabled - to not call time.Now on hot path
+func traceStart() time.Time {
+	if !prefixTracing.enabled.Load() {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+func (s *ProcessStats) traceFold(prefix uint32, start time.Time) {
+	spent := time.Since(start)
+	s.Folding += spent
+	s.addPrefix(prefix, spent, 1)
+}
+
+func (s *ProcessStats) traceUnfold(prefix uint32, start time.Time) {
+	spent := time.Since(start)
+	s.Unfolding += spent
+	s.addPrefix(prefix, spent, 1)
+}
+
+const tracedPrefixNibbles = 4
+
+// packPrefix - first tracedPrefixNibbles nibbles of key and their amount packed into uint32. no allocations on hot path
+func packPrefix(nibbles []byte) uint32 {
+	n := min(len(nibbles), tracedPrefixNibbles)
+	p := uint32(n) << 24
+	for i := 0; i < n; i++ {
+		p |= uint32(nibbles[i]&0x0f) << (4 * (tracedPrefixNibbles - 1 - i))
+	}
+	return p
+}
+
+func unpackPrefix(p uint32) string {
+	n := int(p >> 24)
+	const hextable = "0123456789abcdef"
+	nibbles := make([]byte, n)
+	for i := 0; i < n; i++ {
+		nibbles[i] = hextable[(p>>(4*(tracedPrefixNibbles-1-i)))&0x0f]
+	}
+	return string(nibbles)
+}
+
+type PrefixTiming struct {
+	Prefix string        `json:"prefix"` // nibbles, "" is root
+	Spent  time.Duration `json:"spentNs"`
+	Calls  uint64        `json:"calls"` // fold/unfold calls
+	Blocks int           `json:"blocks"`
+}
+
+type BlockTrace struct {
+	BlockNum uint64         `json:"blockNum"`
+	Spent    time.Duration  `json:"spentNs"`
+	Stats    ProcessStats   `json:"stats"`
+	Slowest  []PrefixTiming `json:"slowest"`
+}
+
+// amount of slowest prefixes kept for each block
+const slowestPrefixesPerBlock = 32
+
+var prefixTracing struct {
+	enabled atomic.Bool
+	mu      sync.Mutex
+	limit   int
+	blocks  []BlockTrace // ring of last `limit` blocks
+}
+
+// SetPrefixTracing - keep slowest prefixes of last `blocks` blocks. 0 disables prefix tracing.
+func SetPrefixTracing(blocks int) {
+	prefixTracing.mu.Lock()
+	defer prefixTracing.mu.Unlock()
+	prefixTracing.limit = blocks
+	prefixTracing.blocks = nil
+	prefixTracing.enabled.Store(blocks > 0)
+}
+
+func PrefixTracingEnabled() bool { return prefixTracing.enabled.Load() }
+
+// RecordBlockTrace - remember slowest prefixes of root calculation of given block
+func RecordBlockTrace(blockNum uint64, spent time.Duration, stats *ProcessStats) {
+	if !prefixTracing.enabled.Load() {
+		return
+	}
+	slowest := make([]PrefixTiming, 0, len(stats.prefixes))
+	for p, v := range stats.prefixes {
+		slowest = append(slowest, PrefixTiming{Prefix: unpackPrefix(p), Spent: v.spent, Calls: v.calls, Blocks: 1})
+	}
+	sortBySpent(slowest)
+	if len(slowest) > slowestPrefixesPerBlock {
+		slowest = slowest[:slowestPrefixesPerBlock]
+	}
+	bt := BlockTrace{BlockNum: blockNum, Spent: spent, Stats: *stats, Slowest: slowest}
+	bt.Stats.prefixes = nil
+
+	prefixTracing.mu.Lock()
+	defer prefixTracing.mu.Unlock()
+	if prefixTracing.limit == 0 {
+		return
+	}
+	// unwind: block can be re-calculated
+	for len(prefixTracing.blocks) > 0 && prefixTracing.blocks[len(prefixTracing.blocks)-1].BlockNum >= blockNum {
+		prefixTracing.blocks = prefixTracing.blocks[:len(prefixTracing.blocks)-1]
+	}
+	prefixTracing.blocks = append(prefixTracing.blocks, bt)
+	if over := len(prefixTracing.blocks) - prefixTracing.limit; over > 0 {
+		prefixTracing.blocks = slices.Delete(prefixTracing.blocks, 0, over)
+	}
+}
+
+// SlowestPrefixes - slowest prefixes over last `lastBlocks` traced blocks (all traced blocks if 0). Also returns traces of these blocks.
+func SlowestPrefixes(lastBlocks, limit int) ([]PrefixTiming, []BlockTrace) {
+	prefixTracing.mu.Lock()
+	blocks := prefixTracing.blocks
+	if lastBlocks > 0 && lastBlocks < len(blocks) {
+		blocks = blocks[len(blocks)-lastBlocks:]
+	}
+	blocks = slices.Clone(blocks)
+	prefixTracing.mu.Unlock()
+
+	byPrefix := map[string]*PrefixTiming{}
+	for _, b := range blocks {
+		for _, p := range b.Slowest {
+			agg, ok := byPrefix[p.Prefix]
+			if !ok {
+				agg = &PrefixTiming{Prefix: p.Prefix}
+				byPrefix[p.Prefix] = agg
+			}
+			agg.Spent += p.Spent
+			agg.Calls += p.Calls
+			agg.Blocks++
+		}
+	}
+	res := make([]PrefixTiming, 0, len(byPrefix))
+	for _, p := range byPrefix {
+		res = append(res, *p)
+	}
+	sortBySpent(res)
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, blocks
+}
+
+func sortBySpent(l []PrefixTiming) {
+	slices.SortFunc(l, func(a, b PrefixTiming) int {
+		if a.Spent != b.Spent {
+			if a.Spent > b.Spent {
+				return -1
+			}
+			return 1
+		}
+		if a.Prefix < b.Prefix {
+			return -1
+		}
+		if a.Prefix > b.Prefix {
+			return 1
+		}
+		return 0
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackPrefix(t *testing.T) {
+	require.Equal(t, "", unpackPrefix(packPrefix(nil)))
+	require.Equal(t, "0", unpackPrefix(packPrefix([]byte{0})))
+	require.Equal(t, "a0f", unpackPrefix(packPrefix([]byte{0xa, 0x0, 0xf})))
+	require.Equal(t, "1234", unpackPrefix(packPrefix([]byte{1, 2, 3, 4, 5, 6})))
+	require.NotEqual(t, packPrefix([]byte{0}), packPrefix([]byte{0, 0}))
+}
+
+func TestSlowestPrefixes(t *testing.T) {
+	SetPrefixTracing(2)
+	defer SetPrefixTracing(0)
+
+	record := func(blockNum uint64, spent map[string]time.Duration) {
+		var stats ProcessStats
+		for p, d := range spent {
+			nibbles := make([]byte, len(p))
+			for i := range p {
+				nibbles[i] = p[i] - '0'
+			}
+			stats.addPrefix(packPrefix(nibbles), d, 1)
+		}
+		RecordBlockTrace(blockNum, time.Second, &stats)
+	}
+
+	record(1, map[string]time.Duration{"1": 100})
+	record(2, map[string]time.Duration{"1": 5, "23": 10})
+	record(3, map[string]time.Duration{"23": 10, "4": 1})
+
+	// only last 2 blocks are kept
+	prefixes, blocks := SlowestPrefixes(0, 0)
+	require.Len(t, blocks, 2)
+	require.Equal(t, uint64(2), blocks[0].BlockNum)
+	require.Equal(t, []PrefixTiming{
+		{Prefix: "23", Spent: 20, Calls: 2, Blocks: 2},
+		{Prefix: "1", Spent: 5, Calls: 1, Blocks: 1},
+		{Prefix: "4", Spent: 1, Calls: 1, Blocks: 1},
+	}, prefixes)
+
+	prefixes, blocks = SlowestPrefixes(1, 1)
+	require.Len(t, blocks, 1)
+	require.Equal(t, []PrefixTiming{{Prefix: "23", Spent: 10, Calls: 1, Blocks: 1}}, prefixes)
+
+	// unwind to block 2 and re-execute it
+	record(2, map[string]time.Duration{"5": 7})
+	prefixes, blocks = SlowestPrefixes(0, 0)
+	require.Len(t, blocks, 1)
+	require.Equal(t, uint64(2), blocks[0].BlockNum)
+	require.Equal(t, []PrefixTiming{{Prefix: "5", Spent: 7, Calls: 1, Blocks: 1}}, prefixes)
+
+	SetPrefixTracing(0)
+	record(3, map[string]time.Duration{"5": 7})
+	_, blocks = SlowestPrefixes(0, 0)
+	require.Empty(t, blocks)
+}
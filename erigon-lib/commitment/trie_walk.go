@@ -0,0 +1,223 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+// WalkFunc is called with the hashed key and the plain key of each leaf visited by a
+// trie walk. The walk stops when it returns false or an error.
+type WalkFunc func(hashedKey, plainKey []byte) (bool, error)
+
+// WalkAccounts visits the accounts of the trie stored in the branches of ctx, in the
+// ascending order of their hashed keys, starting from the first account with a hashed
+// key greater or equal to from.
+//
+// The plain state is keyed by plain keys, so the branches are the only place where the
+// accounts are kept in the order of the state trie. Tries made of a single leaf have
+// no branches and are not walked.
+func WalkAccounts(ctx PatriciaContext, from []byte, fn WalkFunc) error {
+	w := trieWalker{ctx: ctx, bound: splitOntoHexNibbles(from), fn: fn}
+	_, err := w.walk(nil)
+	return err
+}
+
+// PrevAccount returns the hashed and plain key of the last account with a hashed key
+// lower than before, or nils if there is no such account.
+func PrevAccount(ctx PatriciaContext, before []byte) (hashedKey, plainKey []byte, err error) {
+	w := trieWalker{ctx: ctx, bound: splitOntoHexNibbles(before), reverse: true, fn: func(h, p []byte) (bool, error) {
+		hashedKey, plainKey = h, p
+		return false, nil
+	}}
+	_, err = w.walk(nil)
+	return hashedKey, plainKey, err
+}
+
+// AccountPlainKey returns the plain key of the account with the given hashed key, or
+// nil if the trie does not contain it.
+func AccountPlainKey(ctx PatriciaContext, hashedKey []byte) ([]byte, error) {
+	account, err := findAccountCell(ctx, splitOntoHexNibbles(hashedKey))
+	if err != nil || account == nil {
+		return nil, err
+	}
+	return common.Copy(account.accountAddr[:account.accountAddrLen]), nil
+}
+
+// WalkStorage visits the storage slots of the given account in the ascending order of
+// their hashed keys, starting from the first slot with a hashed key greater or equal
+// to from. Hashed keys passed to fn are the hashes of the slot locations, while plain
+// keys are made of the account address and the location.
+func WalkStorage(ctx PatriciaContext, accountPlainKey []byte, from []byte, fn WalkFunc) error {
+	return walkStorage(ctx, accountPlainKey, from, false, fn)
+}
+
+// PrevStorage returns the hashed and plain key of the last storage slot of the given
+// account with a hashed key lower than before, or nils if there is no such slot.
+func PrevStorage(ctx PatriciaContext, accountPlainKey []byte, before []byte) (hashedKey, plainKey []byte, err error) {
+	err = walkStorage(ctx, accountPlainKey, before, true, func(h, p []byte) (bool, error) {
+		hashedKey, plainKey = h, p
+		return false, nil
+	})
+	return hashedKey, plainKey, err
+}
+
+func walkStorage(ctx PatriciaContext, accountPlainKey []byte, bound []byte, reverse bool, fn WalkFunc) error {
+	accountKey := splitOntoHexNibbles(crypto.Keccak256(accountPlainKey))
+	account, err := findAccountCell(ctx, accountKey)
+	if err != nil || account == nil {
+		return err
+	}
+
+	w := trieWalker{ctx: ctx, bound: append(accountKey, splitOntoHexNibbles(bound)...), reverse: reverse, fn: func(h, p []byte) (bool, error) {
+		return fn(h[length.Hash:], p)
+	}}
+	switch {
+	case account.storageAddrLen > 0:
+		// storage made of a single slot is kept in the account cell
+		_, err = w.leaf(account.storageAddr[:account.storageAddrLen], true)
+	case account.hashLen > 0:
+		_, err = w.walk(append(accountKey, account.extension[:account.extLen]...))
+	}
+	return err
+}
+
+// findAccountCell returns the cell holding the account with the given nibblized hashed
+// key, or nil if the trie does not contain it.
+func findAccountCell(ctx PatriciaContext, hashedKey []byte) (*cell, error) {
+	var prefix []byte
+	for len(prefix) < len(hashedKey) {
+		row, err := readRow(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+		c := row[hashedKey[len(prefix)]]
+		switch {
+		case c == nil:
+			return nil, nil
+		case c.accountAddrLen > 0:
+			if !bytes.Equal(splitOntoHexNibbles(crypto.Keccak256(c.accountAddr[:c.accountAddrLen])), hashedKey) {
+				return nil, nil
+			}
+			return c, nil
+		case c.hashLen == 0:
+			return nil, nil
+		}
+		prefix = append(append(prefix, hashedKey[len(prefix)]), c.extension[:c.extLen]...)
+		if !bytes.HasPrefix(hashedKey, prefix) {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+func readRow(ctx PatriciaContext, prefix []byte) (row [16]*cell, err error) {
+	branch, _, err := ctx.Branch(hexNibblesToCompactBytes(prefix))
+	if err != nil {
+		return row, err
+	}
+	if len(branch) < 4 {
+		return row, nil
+	}
+	if _, _, row, err = BranchData(branch).decodeCells(); err != nil {
+		return row, fmt.Errorf("branch %x: %w", prefix, err)
+	}
+	return row, nil
+}
+
+// trieWalker visits the leaves of the trie in the order of their nibblized hashed
+// keys, either from bound up (inclusive) or from bound down (exclusive).
+type trieWalker struct {
+	ctx     PatriciaContext
+	bound   []byte
+	reverse bool
+	fn      WalkFunc
+}
+
+// walk visits the leaves below the branch at the given prefix, returning false once
+// the walk is over.
+func (w *trieWalker) walk(prefix []byte) (bool, error) {
+	row, err := readRow(w.ctx, prefix)
+	if err != nil {
+		return false, err
+	}
+	storage := len(prefix) >= 64
+	for i := 0; i < 16; i++ {
+		nibble := i
+		if w.reverse {
+			nibble = 15 - i
+		}
+		c := row[nibble]
+		if c == nil {
+			continue
+		}
+
+		var next bool
+		switch {
+		case !storage && c.accountAddrLen > 0:
+			next, err = w.leaf(c.accountAddr[:c.accountAddrLen], false)
+		case storage && c.storageAddrLen > 0:
+			next, err = w.leaf(c.storageAddr[:c.storageAddrLen], true)
+		case c.hashLen > 0:
+			child := append(append(append(make([]byte, 0, len(prefix)+1+c.extLen), prefix...), byte(nibble)), c.extension[:c.extLen]...)
+			if !w.inRange(child) {
+				continue
+			}
+			next, err = w.walk(child)
+		default:
+			continue
+		}
+		if err != nil || !next {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (w *trieWalker) leaf(plainKey []byte, storage bool) (bool, error) {
+	var hashedKey []byte
+	if storage {
+		hashedKey = append(crypto.Keccak256(plainKey[:length.Addr]), crypto.Keccak256(plainKey[length.Addr:])...)
+	} else {
+		hashedKey = crypto.Keccak256(plainKey)
+	}
+	if !w.inRange(splitOntoHexNibbles(hashedKey)) {
+		return true, nil
+	}
+	return w.fn(hashedKey, plainKey)
+}
+
+// inRange reports whether the given nibblized key, or the keys starting with it when
+// it is a prefix, may be within the walked range.
+func (w *trieWalker) inRange(key []byte) bool {
+	bound := w.bound
+	if len(key) < len(bound) {
+		bound = bound[:len(key)]
+	}
+	if w.reverse {
+		if len(key) < len(w.bound) {
+			return bytes.Compare(key, bound) <= 0
+		}
+		return bytes.Compare(key, bound) < 0
+	}
+	return bytes.Compare(key, bound) >= 0
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commitment
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+type walkedKey struct {
+	hashedKey, plainKey []byte
+}
+
+func sortedKeys(plainKeys [][]byte, hash func([]byte) []byte) []walkedKey {
+	keys := make([]walkedKey, 0, len(plainKeys))
+	for _, pk := range plainKeys {
+		keys = append(keys, walkedKey{hash(pk), pk})
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i].hashedKey, keys[j].hashedKey) < 0 })
+	return keys
+}
+
+func Test_WalkAccountsAndStorage(t *testing.T) {
+	t.Parallel()
+
+	ms := NewMockState(t)
+	hph := NewHexPatriciaHashed(length.Addr, ms)
+	ub := NewUpdateBuilder()
+
+	var accounts [][]byte
+	storage := map[string][][]byte{}
+	for i := 0; i < 300; i++ {
+		addr := fmt.Sprintf("%040x", i+1)
+		accounts = append(accounts, decodeHex(addr))
+		ub.Balance(addr, uint64(i+1))
+
+		slots := 0
+		switch i % 10 {
+		case 1:
+			slots = 1
+		case 2:
+			slots = 2
+		case 3:
+			slots = 50
+		}
+		for j := 0; j < slots; j++ {
+			loc := fmt.Sprintf("%064x", i*j+1)
+			ub.Storage(addr, loc, "01")
+			storage[string(decodeHex(addr))] = append(storage[string(decodeHex(addr))], decodeHex(addr+loc))
+		}
+	}
+	// slots sharing the first nibble of their hashed keys, so that the storage root is an extension
+	ub.Balance("000000000000000000000000000000000000aaaa", 1)
+	ub.Storage("000000000000000000000000000000000000aaaa", fmt.Sprintf("%064x", 1), "01")
+	ub.Storage("000000000000000000000000000000000000aaaa", fmt.Sprintf("%064x", 0xe), "01")
+	aa := decodeHex("000000000000000000000000000000000000aaaa")
+	accounts = append(accounts, aa)
+	storage[string(aa)] = [][]byte{append(common.Copy(aa), decodeHex(fmt.Sprintf("%064x", 1))...), append(common.Copy(aa), decodeHex(fmt.Sprintf("%064x", 0xe))...)}
+
+	plainKeys, updates := ub.Build()
+	upds := WrapKeyUpdates(t, ModeDirect, KeyToHexNibbleHash, plainKeys, updates)
+	defer upds.Close()
+	require.NoError(t, ms.applyPlainUpdates(plainKeys, updates))
+	_, err := hph.Process(context.Background(), upds, "")
+	require.NoError(t, err)
+
+	collect := func(walk func(fn WalkFunc) error, limit int) (res []walkedKey) {
+		err := walk(func(hashedKey, plainKey []byte) (bool, error) {
+			res = append(res, walkedKey{hashedKey, plainKey})
+			return len(res) < limit, nil
+		})
+		require.NoError(t, err)
+		return res
+	}
+
+	want := sortedKeys(accounts, func(pk []byte) []byte { return crypto.Keccak256(pk) })
+	require.Equal(t, want, collect(func(fn WalkFunc) error { return WalkAccounts(ms, make([]byte, length.Hash), fn) }, len(want)+1))
+	for i, k := range want {
+		// walk from an existing key and from just after the previous one
+		require.Equal(t, want[i:min(i+5, len(want))], collect(func(fn WalkFunc) error { return WalkAccounts(ms, k.hashedKey, fn) }, 5))
+		if i > 0 {
+			from := common.Copy(want[i-1].hashedKey)
+			from[length.Hash-1]++
+			require.Equal(t, want[i], collect(func(fn WalkFunc) error { return WalkAccounts(ms, from, fn) }, 1)[0])
+		}
+
+		plainKey, err := AccountPlainKey(ms, k.hashedKey)
+		require.NoError(t, err)
+		require.Equal(t, k.plainKey, plainKey)
+
+		hashedKey, plainKey, err := PrevAccount(ms, k.hashedKey)
+		require.NoError(t, err)
+		if i == 0 {
+			require.Nil(t, plainKey)
+			continue
+		}
+		require.Equal(t, want[i-1], walkedKey{hashedKey, plainKey})
+	}
+
+	for addr, slots := range storage {
+		want := sortedKeys(slots, func(pk []byte) []byte { return crypto.Keccak256(pk[length.Addr:]) })
+		walkFrom := func(from []byte) func(fn WalkFunc) error {
+			return func(fn WalkFunc) error { return WalkStorage(ms, []byte(addr), from, fn) }
+		}
+		require.Equal(t, want, collect(walkFrom(make([]byte, length.Hash)), len(want)+1), "account %x", addr)
+		for i, k := range want {
+			require.Equal(t, want[i:], collect(walkFrom(k.hashedKey), len(want)+1))
+
+			hashedKey, plainKey, err := PrevStorage(ms, []byte(addr), k.hashedKey)
+			require.NoError(t, err)
+			if i == 0 {
+				require.Nil(t, plainKey)
+				continue
+			}
+			require.Equal(t, want[i-1], walkedKey{hashedKey, plainKey})
+		}
+	}
+
+	// accounts without storage
+	require.Empty(t, collect(func(fn WalkFunc) error { return WalkStorage(ms, accounts[0], make([]byte, length.Hash), fn) }, 1))
+	require.Empty(t, collect(func(fn WalkFunc) error { return WalkStorage(ms, decodeHex("ff"), make([]byte, length.Hash), fn) }, 1))
+}
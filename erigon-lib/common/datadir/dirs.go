@@ -85,6 +85,14 @@ func New(datadir string) Dirs {
 		CaplinGenesis:   filepath.Join(datadir, "caplin", "genesis"),
 	}
 
+	if datadir != "" {
+		reg, err := ReadRegistry(datadir)
+		if err != nil {
+			panic(err)
+		}
+		dirs.applyRegistry(reg)
+	}
+
 	dir.MustExist(dirs.Chaindata, dirs.Tmp,
 		dirs.SnapIdx, dirs.SnapHistory, dirs.SnapDomain, dirs.SnapAccessors, dirs.SnapCaplin,
 		dirs.Downloader, dirs.TxPool, dirs.Nodes, dirs.CaplinBlobs, dirs.CaplinIndexing, dirs.CaplinLatest, dirs.CaplinGenesis)
@@ -0,0 +1,345 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package datadir
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// RegistryFile - sub-directories of datadir placed on other volumes, like {"snapshots": "/mnt/hdd/snapshots"}.
+// Read by New (so Erigon, rpcdaemon and downloader see same locations), updated atomically by Relocate.
+const RegistryFile = "dirs.json"
+
+const Snapshots = "snapshots"
+
+// Relocatable - sub-directories of datadir which can be placed on other volumes.
+// Not relocated sub-directories of "snapshots" follow it.
+var Relocatable = []string{Snapshots, "snapshots/domain", "snapshots/history", "snapshots/idx", "snapshots/accessor"}
+
+var ErrNotRelocatable = fmt.Errorf("only %s can be relocated", strings.Join(Relocatable, ", "))
+
+func ReadRegistry(datadir string) (map[string]string, error) {
+	reg := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(datadir, RegistryFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return reg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("%s: %w", RegistryFile, err)
+	}
+	for key, path := range reg {
+		if !slices.Contains(Relocatable, key) {
+			return nil, fmt.Errorf("%s: %q: %w", RegistryFile, key, ErrNotRelocatable)
+		}
+		if !filepath.IsAbs(path) {
+			return nil, fmt.Errorf("%s: %q: path must be absolute: %s", RegistryFile, key, path)
+		}
+	}
+	return reg, nil
+}
+
+// writeRegistry - atomic: readers see old or new registry, never partial one
+func writeRegistry(datadir string, reg map[string]string) error {
+	data, err := json.MarshalIndent(reg, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(datadir, "."+RegistryFile+".tmp")
+	if err := dir.WriteFileWithFsync(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(datadir, RegistryFile))
+}
+
+func (d *Dirs) applyRegistry(reg map[string]string) {
+	location := func(key, def string) string {
+		if path, ok := reg[key]; ok {
+			return path
+		}
+		return def
+	}
+	d.Snap = location(Snapshots, d.Snap)
+	d.SnapIdx = location("snapshots/idx", filepath.Join(d.Snap, "idx"))
+	d.SnapHistory = location("snapshots/history", filepath.Join(d.Snap, "history"))
+	d.SnapDomain = location("snapshots/domain", filepath.Join(d.Snap, "domain"))
+	d.SnapAccessors = location("snapshots/accessor", filepath.Join(d.Snap, "accessor"))
+	d.SnapCaplin = filepath.Join(d.Snap, "caplin")
+}
+
+// Location - current path of relocatable sub-directory
+func (d Dirs) Location(key string) (string, error) {
+	switch key {
+	case Snapshots:
+		return d.Snap, nil
+	case "snapshots/domain":
+		return d.SnapDomain, nil
+	case "snapshots/history":
+		return d.SnapHistory, nil
+	case "snapshots/idx":
+		return d.SnapIdx, nil
+	case "snapshots/accessor":
+		return d.SnapAccessors, nil
+	}
+	return "", fmt.Errorf("%q: %w", key, ErrNotRelocatable)
+}
+
+// SetLocation - places sub-directory on other volume (like --datadir.snap), only if it has no files yet: existing files
+// must be moved by Relocate
+func (d Dirs) SetLocation(key, path string) (Dirs, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return d, err
+	}
+	current, err := d.Location(key)
+	if err != nil || current == path {
+		return d, err
+	}
+	files, err := regularFiles(current, d.nestedLocations(key))
+	if err != nil {
+		return d, err
+	}
+	if len(files) > 0 {
+		return d, fmt.Errorf("%s has %d files in %s, move them by: erigon snapshots relocate --datadir=%s --dir=%s --to=%s",
+			key, len(files), current, d.DataDir, key, path)
+	}
+	if err := d.updateRegistry(key, path); err != nil {
+		return d, err
+	}
+	return New(d.DataDir), nil
+}
+
+func (d Dirs) updateRegistry(key, path string) error {
+	reg, err := ReadRegistry(d.DataDir)
+	if err != nil {
+		return err
+	}
+	if path == filepath.Join(d.DataDir, key) {
+		delete(reg, key)
+	} else {
+		reg[key] = path
+	}
+	return writeRegistry(d.DataDir, reg)
+}
+
+// nestedLocations - locations of sub-directories which are relocated separately from key, their files are not moved with it
+func (d Dirs) nestedLocations(key string) (nested []string) {
+	current, _ := d.Location(key)
+	for _, other := range Relocatable {
+		sub, ok := strings.CutPrefix(other, key+"/")
+		if !ok {
+			continue
+		}
+		if loc, _ := d.Location(other); loc != filepath.Join(current, sub) {
+			nested = append(nested, filepath.Join(current, sub))
+		}
+	}
+	return nested
+}
+
+// regularFiles - files to move: not symlinks (already moved), not hidden or .tmp (being created) files
+func regularFiles(root string, skipDirs []string) (files []string, err error) {
+	err = filepath.WalkDir(root, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if e.IsDir() {
+			if path != root && slices.Contains(skipDirs, path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if e.Type().IsRegular() && !strings.HasPrefix(e.Name(), ".") && !strings.HasSuffix(e.Name(), ".tmp") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// Relocate - moves files of sub-directory to other volume while node is running, and updates registry.
+// Each file is copied, then atomically replaced by symlink to the copy: running node keeps reading already opened
+// file, new opens follow symlink. Disk space of old files is released when node closes them (at latest - on restart).
+// Files created by node in old location meanwhile are moved on next start. Safe to re-run after interruption.
+func Relocate(ctx context.Context, d Dirs, key, to string, logger log.Logger) error {
+	to, err := filepath.Abs(to)
+	if err != nil {
+		return err
+	}
+	from, err := d.Location(key)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+	if isInside(to, from) || isInside(from, to) {
+		return fmt.Errorf("can't relocate %s to %s: one is inside another", from, to)
+	}
+	moved, size, err := moveFiles(ctx, from, to, d.nestedLocations(key), true, logger)
+	if err != nil {
+		return err
+	}
+	if err := d.updateRegistry(key, to); err != nil {
+		return err
+	}
+	logger.Info("[datadir] relocated", "dir", key, "from", from, "to", to, "files", moved, "size", fmt.Sprintf("%dMb", size>>20))
+	return nil
+}
+
+// FinishRelocation - moves files which were created in old locations of relocated sub-directories after relocation
+// (by node which was running), removes symlinks left by Relocate. Must be called by owner of datadir lock.
+func (d Dirs) FinishRelocation() error {
+	if d.DataDir == "" {
+		return nil
+	}
+	reg, err := ReadRegistry(d.DataDir)
+	if err != nil {
+		return err
+	}
+	for _, key := range Relocatable {
+		to, ok := reg[key]
+		if !ok {
+			continue
+		}
+		var old string
+		if parent := filepath.Dir(key); parent != "." && reg[parent] != "" {
+			old = filepath.Join(reg[parent], filepath.Base(key))
+		} else {
+			old = filepath.Join(d.DataDir, key)
+		}
+		if old == to {
+			continue
+		}
+		if _, _, err := moveFiles(context.Background(), old, to, d.nestedLocations(key), false, log.Root()); err != nil {
+			return fmt.Errorf("finish relocation of %s from %s to %s: %w", key, old, to, err)
+		}
+		if err := removeRelocationSymlinks(old, to); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func moveFiles(ctx context.Context, from, to string, skipDirs []string, symlinkBack bool, logger log.Logger) (moved int, size int64, err error) {
+	files, err := regularFiles(from, skipDirs)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, src := range files {
+		select {
+		case <-ctx.Done():
+			return moved, size, ctx.Err()
+		default:
+		}
+		rel, err := filepath.Rel(from, src)
+		if err != nil {
+			return moved, size, err
+		}
+		dst := filepath.Join(to, rel)
+		n, err := moveFile(src, dst, symlinkBack)
+		if err != nil {
+			return moved, size, err
+		}
+		moved, size = moved+1, size+n
+		logger.Debug("[datadir] moved", "file", rel, "to", to)
+	}
+	return moved, size, nil
+}
+
+// moveFile - copy to hidden file (hidden files are ignored by node), fsync, atomic rename to final name.
+// Then source is atomically replaced by symlink to the copy, or removed.
+func moveFile(src, dst string, symlinkBack bool) (int64, error) {
+	st, err := os.Stat(src)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return 0, err
+	}
+	if dstSt, err := os.Stat(dst); err != nil || dstSt.Size() != st.Size() { // may be already copied by interrupted run
+		tmp := hidden(dst, ".relocate")
+		// running node needs source until it's replaced by symlink - so copy. Otherwise try cheap rename first.
+		if symlinkBack || os.Rename(src, tmp) != nil {
+			if err := CopyFile(src, tmp); err != nil {
+				return 0, err
+			}
+		}
+		// keep mtime: downloader re-hashes files with changed mtime
+		if err := os.Chtimes(tmp, st.ModTime(), st.ModTime()); err != nil {
+			return 0, err
+		}
+		if err := os.Rename(tmp, dst); err != nil {
+			return 0, err
+		}
+	}
+	if !symlinkBack {
+		if err := os.Remove(src); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return 0, err
+		}
+		return st.Size(), nil
+	}
+	link := hidden(src, ".symlink")
+	_ = os.Remove(link)
+	if err := os.Symlink(dst, link); err != nil {
+		return 0, err
+	}
+	return st.Size(), os.Rename(link, src)
+}
+
+func removeRelocationSymlinks(old, to string) error {
+	return filepath.WalkDir(old, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if e.Type()&fs.ModeSymlink == 0 {
+			return nil
+		}
+		if target, err := os.Readlink(path); err == nil && isInside(target, to) {
+			return os.Remove(path)
+		}
+		return nil
+	})
+}
+
+func hidden(path, suffix string) string {
+	return filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+suffix)
+}
+
+func isInside(path, root string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
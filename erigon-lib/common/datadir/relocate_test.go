@@ -0,0 +1,98 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package datadir
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestRelocate(t *testing.T) {
+	datadir, hdd := t.TempDir(), t.TempDir()
+	dirs := New(datadir)
+
+	seg := filepath.Join(dirs.Snap, "v1.0-000000-000500-headers.seg")
+	idx := filepath.Join(dirs.SnapIdx, "v1.0-000000-000500-headers.idx")
+	mtime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, f := range []string{seg, idx} {
+		require.NoError(t, os.WriteFile(f, []byte(filepath.Base(f)), 0644))
+		require.NoError(t, os.Chtimes(f, mtime, mtime))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dirs.Snap, "v1.0-000500-001000-headers.seg.tmp"), nil, 0644))
+
+	_, err := dirs.SetLocation(Snapshots, hdd)
+	require.ErrorContains(t, err, "erigon snapshots relocate")
+
+	to := filepath.Join(hdd, "snapshots")
+	require.NoError(t, Relocate(context.Background(), dirs, Snapshots, to, log.New()))
+
+	// old paths still readable by running node - through symlinks
+	for _, f := range []string{seg, idx} {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Base(f), string(data))
+		target, err := os.Readlink(f)
+		require.NoError(t, err)
+		st, err := os.Stat(target)
+		require.NoError(t, err)
+		require.Equal(t, mtime, st.ModTime())
+	}
+
+	// file created by running node after relocation
+	seg2 := filepath.Join(dirs.Snap, "v1.0-000500-001000-headers.seg")
+	require.NoError(t, os.WriteFile(seg2, nil, 0644))
+
+	dirs = New(datadir)
+	require.Equal(t, to, dirs.Snap)
+	require.Equal(t, filepath.Join(to, "idx"), dirs.SnapIdx)
+	require.NoError(t, dirs.FinishRelocation())
+	for _, f := range []string{seg, idx, seg2} {
+		_, err := os.Lstat(f)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+	require.FileExists(t, filepath.Join(to, "v1.0-000500-001000-headers.seg"))
+	require.FileExists(t, filepath.Join(to, "idx", "v1.0-000000-000500-headers.idx"))
+
+	// back to default location: registry entry removed
+	require.NoError(t, Relocate(context.Background(), dirs, Snapshots, filepath.Join(datadir, "snapshots"), log.New()))
+	reg, err := ReadRegistry(datadir)
+	require.NoError(t, err)
+	require.Empty(t, reg)
+}
+
+func TestSetLocation(t *testing.T) {
+	datadir, hdd := t.TempDir(), t.TempDir()
+	dirs, err := New(datadir).SetLocation("snapshots/history", hdd)
+	require.NoError(t, err)
+	require.Equal(t, hdd, dirs.SnapHistory)
+	require.Equal(t, filepath.Join(datadir, "snapshots", "domain"), dirs.SnapDomain)
+	require.Equal(t, hdd, New(datadir).SnapHistory)
+
+	_, err = dirs.SetLocation("chaindata", hdd)
+	require.ErrorIs(t, err, ErrNotRelocatable)
+
+	require.NoError(t, os.WriteFile(filepath.Join(datadir, RegistryFile), []byte(`{"snapshots":"relative"}`), 0644))
+	_, err = ReadRegistry(datadir)
+	require.ErrorContains(t, err, "absolute")
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package dbg
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync/atomic"
+)
+
+var profileLabels atomic.Bool
+
+// EnableProfileLabels - makes WithProfileLabels attach pprof labels. Labels are not free: enabled only by continuous profiling.
+func EnableProfileLabels() { profileLabels.Store(true) }
+
+// WithProfileLabels runs f with given pprof labels (key, value pairs) - samples of CPU profile are split by them.
+// Goroutines started by f inherit the labels.
+func WithProfileLabels(ctx context.Context, f func(context.Context), labels ...string) {
+	if !profileLabels.Load() {
+		f(ctx)
+		return
+	}
+	pprof.Do(ctx, pprof.Labels(labels...), f)
+}
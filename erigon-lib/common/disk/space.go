@@ -0,0 +1,136 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package disk
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	psdisk "github.com/shirou/gopsutil/v4/disk"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// SpaceLevel - state of free space on disk of datadir
+type SpaceLevel int32
+
+const (
+	SpaceOk       SpaceLevel = iota
+	SpaceLow                 // optional background work is paused: snapshots download, files merge
+	SpaceCritical            // also write-path rpc methods are rejected
+)
+
+func (l SpaceLevel) String() string {
+	switch l {
+	case SpaceLow:
+		return "low"
+	case SpaceCritical:
+		return "critical"
+	default:
+		return "ok"
+	}
+}
+
+// SpaceThresholds - free space below Low/Critical switches process to SpaceLow/SpaceCritical. 0 - disabled
+type SpaceThresholds struct {
+	Low, Critical datasize.ByteSize
+}
+
+var (
+	spaceLevel atomic.Int32
+
+	freeSpaceGauge  = metrics.GetOrCreateGauge(`disk_free_bytes`)
+	spaceLevelGauge = metrics.GetOrCreateGauge(`disk_space_level`)
+)
+
+// Space - current level of free space, SpaceOk if WatchSpace is not running
+func Space() SpaceLevel { return SpaceLevel(spaceLevel.Load()) }
+
+func IsSpaceLow() bool      { return Space() >= SpaceLow }
+func IsSpaceCritical() bool { return Space() >= SpaceCritical }
+
+// spaceHysteresis - level is left only when free space is this part above threshold, to not flap around threshold
+const spaceHysteresis = 0.05
+
+func spaceLevelOf(free uint64, t SpaceThresholds, prev SpaceLevel) SpaceLevel {
+	below := func(threshold datasize.ByteSize, level SpaceLevel) bool {
+		if threshold == 0 {
+			return false
+		}
+		limit := uint64(threshold)
+		if prev >= level {
+			limit += uint64(float64(threshold) * spaceHysteresis)
+		}
+		return free < limit
+	}
+	switch {
+	case below(t.Critical, SpaceCritical):
+		return SpaceCritical
+	case below(t.Low, SpaceLow):
+		return SpaceLow
+	default:
+		return SpaceOk
+	}
+}
+
+const spaceAlertEvery = 5 * time.Minute
+
+// WatchSpace - checks free space on disk of dir until ctx is done and switches Space level.
+// Alerts on each level change and periodically while level is not ok - instead of failing mid-write on full disk.
+func WatchSpace(ctx context.Context, dir string, t SpaceThresholds, interval time.Duration, logger log.Logger) {
+	if t.Low == 0 && t.Critical == 0 {
+		return
+	}
+	checkEvery := time.NewTicker(interval)
+	defer checkEvery.Stop()
+	var lastAlert time.Time
+	for {
+		if usage, err := psdisk.Usage(dir); err != nil {
+			logger.Debug("[disk] free space", "dir", dir, "err", err)
+		} else {
+			freeSpaceGauge.SetUint64(usage.Free)
+			prev := Space()
+			level := spaceLevelOf(usage.Free, t, prev)
+			spaceLevel.Store(int32(level))
+			spaceLevelGauge.SetInt(int(level))
+			if level != prev || (level != SpaceOk && time.Since(lastAlert) > spaceAlertEvery) {
+				lastAlert = time.Now()
+				alertSpace(logger, level, prev, dir, usage.Free, t)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-checkEvery.C:
+		}
+	}
+}
+
+func alertSpace(logger log.Logger, level, prev SpaceLevel, dir string, free uint64, t SpaceThresholds) {
+	args := []any{"dir", dir, "free", datasize.ByteSize(free).HR(), "low", t.Low.HR(), "critical", t.Critical.HR()}
+	switch level {
+	case SpaceCritical:
+		logger.Error("[disk] free space is critically low: downloads and files merge are paused, write rpc methods are rejected. Free up space or grow the volume", args...)
+	case SpaceLow:
+		logger.Warn("[disk] free space is low: downloads and files merge are paused", args...)
+	default:
+		logger.Info("[disk] free space recovered, resuming", append(args, "was", prev.String())...)
+	}
+}
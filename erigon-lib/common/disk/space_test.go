@@ -0,0 +1,42 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package disk
+
+import (
+	"testing"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpaceLevelOf(t *testing.T) {
+	th := SpaceThresholds{Low: 100 * datasize.GB, Critical: 10 * datasize.GB}
+	gb := func(v float64) uint64 { return uint64(v * float64(datasize.GB)) }
+
+	require.Equal(t, SpaceOk, spaceLevelOf(gb(200), th, SpaceOk))
+	require.Equal(t, SpaceLow, spaceLevelOf(gb(99), th, SpaceOk))
+	require.Equal(t, SpaceCritical, spaceLevelOf(gb(9), th, SpaceOk))
+
+	// leaving level requires free space above threshold with margin
+	require.Equal(t, SpaceLow, spaceLevelOf(gb(101), th, SpaceLow))
+	require.Equal(t, SpaceOk, spaceLevelOf(gb(106), th, SpaceLow))
+	require.Equal(t, SpaceCritical, spaceLevelOf(gb(10.1), th, SpaceCritical))
+	require.Equal(t, SpaceLow, spaceLevelOf(gb(11), th, SpaceCritical))
+
+	require.Equal(t, SpaceOk, spaceLevelOf(0, SpaceThresholds{}, SpaceOk))
+	require.Equal(t, SpaceCritical, spaceLevelOf(gb(1), SpaceThresholds{Critical: 10 * datasize.GB}, SpaceOk))
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build darwin
+
+package secrets
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// macOS Keychain by `security`
+
+var (
+	keyringGet = func() (string, error) {
+		out, err := exec.Command("security", "find-generic-password", "-s", "erigon", "-a", "secrets", "-w").Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 44 {
+			return "", ErrKeyringNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+	keyringSet = func(password string) error {
+		return exec.Command("security", "add-generic-password", "-U", "-s", "erigon", "-a", "secrets", "-w", password).Run()
+	}
+)
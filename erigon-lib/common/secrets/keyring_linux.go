@@ -0,0 +1,50 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build linux
+
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// libsecret (GNOME Keyring, KWallet) by `secret-tool`
+
+var (
+	keyringGet = func() (string, error) {
+		out, err := exec.Command("secret-tool", "lookup", "service", "erigon", "account", "secrets").Output()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && len(exitErr.Stderr) == 0 {
+			return "", ErrKeyringNotFound
+		}
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", ErrKeyringNotSupport
+		}
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+	keyringSet = func(password string) error {
+		cmd := exec.Command("secret-tool", "store", "--label=Erigon secrets", "service", "erigon", "account", "secrets")
+		cmd.Stdin = bytes.NewBufferString(password)
+		return cmd.Run()
+	}
+)
@@ -0,0 +1,240 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package secrets - optional encryption at rest of node secrets: p2p nodekey and Engine API jwt secret.
+//
+// Encrypted file is json (scrypt + AES-256-GCM), it records where password is - so any process reading the secret
+// (erigon, rpcdaemon, caplin) can decrypt it: OS keyring, or password file/env variable.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+const (
+	NodeKey = "nodekey"
+	JWT     = "jwt"
+
+	// PasswordEnv - password of encrypted secrets, if --secrets.password.file is not set
+	PasswordEnv = "ERIGON_SECRETS_PASSWORD"
+
+	passwordFromKeyring = "keyring"
+	passwordFromUser    = "password"
+
+	version = 1
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+var Kinds = []string{NodeKey, JWT}
+
+var (
+	ErrNoPassword        = fmt.Errorf("secret is encrypted by password: set --secrets.password.file or env %s", PasswordEnv)
+	ErrKeyringNotFound   = errors.New("password not found in OS keyring")
+	ErrKeyringNotSupport = errors.New("OS keyring is not supported on this platform: use --secrets.password.file")
+)
+
+type Config struct {
+	Encrypt      []string // kinds of secrets to encrypt
+	PasswordFile string   // if empty: env ERIGON_SECRETS_PASSWORD, if empty: OS keyring (password generated on first use)
+}
+
+var config atomic.Pointer[Config]
+
+func Configure(cfg Config) error {
+	for _, kind := range cfg.Encrypt {
+		if !slices.Contains(Kinds, kind) {
+			return fmt.Errorf("unknown secret %q, supported: %s", kind, strings.Join(Kinds, ","))
+		}
+	}
+	config.Store(&cfg)
+	return nil
+}
+
+func encryptionEnabled(kind string) bool {
+	cfg := config.Load()
+	return cfg != nil && slices.Contains(cfg.Encrypt, kind)
+}
+
+type encrypted struct {
+	Version    int           `json:"erigon_secret"`
+	Kind       string        `json:"kind"`
+	Password   string        `json:"password"`
+	N          int           `json:"n"`
+	R          int           `json:"r"`
+	P          int           `json:"p"`
+	Salt       hexutil.Bytes `json:"salt"`
+	Nonce      hexutil.Bytes `json:"nonce"`
+	Ciphertext hexutil.Bytes `json:"ciphertext"`
+}
+
+func IsEncrypted(data []byte) bool {
+	var e encrypted
+	return len(data) > 0 && data[0] == '{' && json.Unmarshal(data, &e) == nil && e.Version > 0
+}
+
+// ReadFile - reads secret, decrypts it if it's encrypted. Never modifies the file: path may be given by user and shared
+// with other programs (jwt secret of external Consensus Layer), only secrets generated by Erigon are encrypted - by WriteFile.
+func ReadFile(kind, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if IsEncrypted(data) {
+		return decrypt(data)
+	}
+	return data, nil
+}
+
+// WriteFile - writes secret generated by Erigon, encrypted if encryption of this kind of secrets is enabled. Atomic.
+func WriteFile(kind, path string, data []byte) error {
+	if encryptionEnabled(kind) {
+		var err error
+		if data, err = encrypt(kind, data); err != nil {
+			return err
+		}
+	}
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func encrypt(kind string, plaintext []byte) ([]byte, error) {
+	password, from, err := encryptionPassword()
+	if err != nil {
+		return nil, err
+	}
+	e := encrypted{Version: version, Kind: kind, Password: from, N: scryptN, R: scryptR, P: scryptP,
+		Salt: make([]byte, 32), Nonce: make([]byte, 12)}
+	if _, err := rand.Read(e.Salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(e.Nonce); err != nil {
+		return nil, err
+	}
+	aead, err := e.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	e.Ciphertext = aead.Seal(nil, e.Nonce, plaintext, []byte(kind))
+	return json.MarshalIndent(e, "", "  ")
+}
+
+func decrypt(data []byte) ([]byte, error) {
+	var e encrypted
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if e.Version != version {
+		return nil, fmt.Errorf("unsupported version of encrypted secret: %d", e.Version)
+	}
+	password, err := decryptionPassword(e.Password)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := e.aead(password)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, e.Nonce, e.Ciphertext, []byte(e.Kind))
+	if err != nil {
+		return nil, errors.New("can't decrypt secret: wrong password or corrupted file")
+	}
+	return plaintext, nil
+}
+
+func (e encrypted) aead(password []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(password, e.Salt, e.N, e.R, e.P, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func userPassword() ([]byte, bool, error) {
+	if cfg := config.Load(); cfg != nil && cfg.PasswordFile != "" {
+		data, err := os.ReadFile(cfg.PasswordFile)
+		if err != nil {
+			return nil, false, err
+		}
+		return []byte(strings.TrimRight(string(data), "\r\n")), true, nil
+	}
+	if password := os.Getenv(PasswordEnv); password != "" {
+		return []byte(password), true, nil
+	}
+	return nil, false, nil
+}
+
+func encryptionPassword() (password []byte, from string, err error) {
+	if password, ok, err := userPassword(); ok || err != nil {
+		return password, passwordFromUser, err
+	}
+	p, err := keyringGet()
+	if errors.Is(err, ErrKeyringNotFound) {
+		random := make([]byte, 32)
+		if _, err := rand.Read(random); err != nil {
+			return nil, "", err
+		}
+		p = hexutil.Encode(random)
+		err = keyringSet(p)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("OS keyring: %w", err)
+	}
+	return []byte(p), passwordFromKeyring, nil
+}
+
+func decryptionPassword(from string) ([]byte, error) {
+	switch from {
+	case passwordFromKeyring:
+		p, err := keyringGet()
+		if err != nil {
+			return nil, fmt.Errorf("OS keyring: %w", err)
+		}
+		return []byte(p), nil
+	case passwordFromUser:
+		password, ok, err := userPassword()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrNoPassword
+		}
+		return password, nil
+	}
+	return nil, fmt.Errorf("unknown password source of encrypted secret: %q", from)
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypt(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile, path := filepath.Join(dir, "password"), filepath.Join(dir, "jwt.hex")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("correct horse\n"), 0600))
+	secret := []byte("0x2a")
+
+	t.Cleanup(func() { config.Store(nil) })
+	require.NoError(t, Configure(Config{Encrypt: []string{JWT}, PasswordFile: passwordFile}))
+
+	require.NoError(t, WriteFile(JWT, path, secret))
+	got, err := ReadFile(JWT, path)
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+	onDisk, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(onDisk))
+	require.NotContains(t, string(onDisk), string(secret))
+
+	// encryption disabled: encrypted secret is still readable, by password from env
+	config.Store(nil)
+	_, err = ReadFile(JWT, path)
+	require.ErrorIs(t, err, ErrNoPassword)
+	t.Setenv(PasswordEnv, "correct horse")
+	got, err = ReadFile(JWT, path)
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+
+	t.Setenv(PasswordEnv, "wrong")
+	_, err = ReadFile(JWT, path)
+	require.ErrorContains(t, err, "wrong password")
+}
+
+func TestReadDoesNotEncrypt(t *testing.T) {
+	dir := t.TempDir()
+	passwordFile, path := filepath.Join(dir, "password"), filepath.Join(dir, "jwt.hex")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("correct horse\n"), 0600))
+	secret := []byte("0x2a")
+	require.NoError(t, os.WriteFile(path, secret, 0600))
+
+	t.Cleanup(func() { config.Store(nil) })
+	require.NoError(t, Configure(Config{Encrypt: []string{JWT}, PasswordFile: passwordFile}))
+
+	// user's file may be read by other programs (external Consensus Layer): it's left as is
+	got, err := ReadFile(JWT, path)
+	require.NoError(t, err)
+	require.Equal(t, secret, got)
+	onDisk, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, secret, onDisk)
+}
+
+func TestKeyring(t *testing.T) {
+	get, set := keyringGet, keyringSet
+	t.Cleanup(func() { keyringGet, keyringSet = get, set; config.Store(nil) })
+	var stored string
+	keyringGet = func() (string, error) {
+		if stored == "" {
+			return "", ErrKeyringNotFound
+		}
+		return stored, nil
+	}
+	keyringSet = func(password string) error { stored = password; return nil }
+
+	require.NoError(t, Configure(Config{Encrypt: []string{NodeKey}}))
+	path := filepath.Join(t.TempDir(), "nodekey")
+	require.NoError(t, WriteFile(NodeKey, path, []byte("key")))
+	require.NotEmpty(t, stored)
+
+	config.Store(nil)
+	got, err := ReadFile(NodeKey, path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), got)
+
+	require.Error(t, Configure(Config{Encrypt: []string{"chaindata"}}))
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	// DefaultBatchLatency - how long verification waits for verifications of other callers to share pairing check with
+	DefaultBatchLatency = 20 * time.Millisecond
+	// batchMaxBlobs - batch is verified without waiting for latency budget when it has that many blobs
+	batchMaxBlobs = 64
+)
+
+var (
+	batchLatency atomic.Int64
+
+	batchMu      sync.Mutex
+	pendingBatch *blobBatch
+
+	batchesVerified  = metrics.GetOrCreateCounter("kzg_batches_verified")
+	batchBlobsTotal  = metrics.GetOrCreateCounter("kzg_batch_blobs")
+	batchesFallbacks = metrics.GetOrCreateCounter("kzg_batch_fallbacks")
+)
+
+func init() {
+	batchLatency.Store(int64(DefaultBatchLatency))
+}
+
+// SetBatchLatency - latency budget of VerifyBlobKZGProofs. 0 - no batching: every caller verifies own blobs
+func SetBatchLatency(d time.Duration) { batchLatency.Store(int64(d)) }
+
+// BlobProofs - blobs of one blob sidecar or blob txn, with their commitments and proofs
+type BlobProofs struct {
+	Blobs       []gokzg4844.BlobRef
+	Commitments []gokzg4844.KZGCommitment
+	Proofs      []gokzg4844.KZGProof
+}
+
+type blobBatch struct {
+	items []*BlobProofs
+	errs  []*error
+	blobs int
+	done  chan struct{}
+}
+
+// VerifyBlobKZGProofs - verify_blob_kzg_proof_batch of each item. Items are pooled with concurrent verifications of
+// other callers of the process (Caplin blob sidecars, txpool blob txns) and verified by one amortized pairing check
+// per batch, instead of one per blob. Batch is verified when it has batchMaxBlobs blobs or when its latency budget
+// is over. If batch fails, items are verified one by one: invalid item of one caller doesn't fail others.
+// Returns error of each item.
+func VerifyBlobKZGProofs(items ...BlobProofs) []error {
+	return verifyBlobKZGProofs(items, time.Duration(batchLatency.Load()) <= 0)
+}
+
+// VerifyBlobKZGProofsNow - same as VerifyBlobKZGProofs, but doesn't wait for latency budget: verifies items right
+// away together with pending items of other callers. For callers which already have batch of items and can't wait
+// (txpool validates txns under lock)
+func VerifyBlobKZGProofsNow(items ...BlobProofs) []error {
+	return verifyBlobKZGProofs(items, true)
+}
+
+func verifyBlobKZGProofs(items []BlobProofs, now bool) []error {
+	errs := make([]error, len(items))
+	if len(items) == 0 {
+		return errs
+	}
+
+	batchMu.Lock()
+	b := pendingBatch
+	if b == nil {
+		b = &blobBatch{done: make(chan struct{})}
+		if !now {
+			pendingBatch = b
+			time.AfterFunc(time.Duration(batchLatency.Load()), func() { flushBatch(b) })
+		}
+	}
+	for i := range items {
+		b.add(&items[i], &errs[i])
+	}
+	if now || b.blobs >= batchMaxBlobs {
+		now = true
+		if pendingBatch == b {
+			pendingBatch = nil
+		}
+	}
+	batchMu.Unlock()
+
+	if now {
+		b.verify()
+	}
+	<-b.done
+	return errs
+}
+
+// flushBatch - verifies batch when its latency budget is over, if it was not verified as full before
+func flushBatch(b *blobBatch) {
+	batchMu.Lock()
+	if pendingBatch != b {
+		batchMu.Unlock()
+		return
+	}
+	pendingBatch = nil
+	batchMu.Unlock()
+	b.verify()
+}
+
+func (b *blobBatch) add(item *BlobProofs, err *error) {
+	b.items = append(b.items, item)
+	b.errs = append(b.errs, err)
+	b.blobs += len(item.Blobs)
+}
+
+func (b *blobBatch) verify() {
+	defer close(b.done)
+	kzgCtx := Ctx()
+	if len(b.items) == 1 {
+		*b.errs[0] = kzgCtx.VerifyBlobKZGProofBatch(b.items[0].Blobs, b.items[0].Commitments, b.items[0].Proofs)
+		return
+	}
+
+	blobs := make([]gokzg4844.BlobRef, 0, b.blobs)
+	commitments := make([]gokzg4844.KZGCommitment, 0, b.blobs)
+	proofs := make([]gokzg4844.KZGProof, 0, b.blobs)
+	for _, item := range b.items {
+		blobs = append(blobs, item.Blobs...)
+		commitments = append(commitments, item.Commitments...)
+		proofs = append(proofs, item.Proofs...)
+	}
+	batchesVerified.Inc()
+	batchBlobsTotal.AddInt(b.blobs)
+	if kzgCtx.VerifyBlobKZGProofBatch(blobs, commitments, proofs) == nil {
+		return
+	}
+
+	batchesFallbacks.Inc()
+	for i, item := range b.items {
+		*b.errs[i] = kzgCtx.VerifyBlobKZGProofBatch(item.Blobs, item.Commitments, item.Proofs)
+	}
+}
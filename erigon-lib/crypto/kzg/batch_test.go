@@ -0,0 +1,88 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/stretchr/testify/require"
+)
+
+func testBlobProofs(t *testing.T, seed byte) BlobProofs {
+	t.Helper()
+	blob := make([]byte, gokzg4844.ScalarsPerBlob*32)
+	for i := 0; i < gokzg4844.ScalarsPerBlob; i++ {
+		blob[i*32+31] = seed + byte(i) // field elements must be less than bls modulus
+	}
+	commitment, err := Ctx().BlobToKZGCommitment(blob, 0)
+	require.NoError(t, err)
+	proof, err := Ctx().ComputeBlobKZGProof(blob, commitment, 0)
+	require.NoError(t, err)
+	return BlobProofs{Blobs: []gokzg4844.BlobRef{blob}, Commitments: []gokzg4844.KZGCommitment{commitment}, Proofs: []gokzg4844.KZGProof{proof}}
+}
+
+func TestVerifyBlobKZGProofs(t *testing.T) {
+	defer SetBatchLatency(DefaultBatchLatency)
+	valid1, valid2, invalid := testBlobProofs(t, 1), testBlobProofs(t, 2), testBlobProofs(t, 3)
+	invalid.Proofs = valid1.Proofs
+
+	for _, latency := range []time.Duration{0, 50 * time.Millisecond} {
+		SetBatchLatency(latency)
+		errs := VerifyBlobKZGProofs(valid1, invalid, valid2)
+		require.NoError(t, errs[0])
+		require.Error(t, errs[1])
+		require.NoError(t, errs[2])
+
+		// concurrent callers share batch, invalid blob of one of them doesn't fail others
+		var wg sync.WaitGroup
+		res := make([]error, 3)
+		for i, item := range []BlobProofs{valid1, invalid, valid2} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res[i] = VerifyBlobKZGProofs(item)[0]
+			}()
+		}
+		wg.Wait()
+		require.NoError(t, res[0])
+		require.Error(t, res[1])
+		require.NoError(t, res[2])
+	}
+	require.Empty(t, VerifyBlobKZGProofs())
+
+	// caller which can't wait takes pending items of others with it
+	SetBatchLatency(time.Hour)
+	var pending error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pending = VerifyBlobKZGProofs(valid1)[0]
+	}()
+	require.Eventually(t, func() bool {
+		batchMu.Lock()
+		defer batchMu.Unlock()
+		return pendingBatch != nil
+	}, time.Second, time.Millisecond)
+	errs := VerifyBlobKZGProofsNow(invalid, valid2)
+	require.Error(t, errs[0])
+	require.NoError(t, errs[1])
+	<-done
+	require.NoError(t, pending)
+}
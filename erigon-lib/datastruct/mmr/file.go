@@ -0,0 +1,131 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mmr
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+)
+
+// File - nodes stored in flat file: 32 bytes per node, in order of positions.
+// Appended nodes are buffered in memory until Flush.
+type File struct {
+	f        *os.File
+	path     string
+	flushed  uint64 // nodes in file
+	pending  []common.Hash
+	readOnly bool
+}
+
+// OpenFile - opens (or creates) file. If file was not fully flushed (crash during Flush) - it's truncated
+// to last complete MMR state.
+func OpenFile(path string) (*File, *MMR, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	leaves, ok := LeavesCount(uint64(st.Size()) / length.Hash)
+	if !ok || uint64(st.Size())%length.Hash != 0 {
+		if err := f.Truncate(int64(NodesCount(leaves) * length.Hash)); err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+	}
+	file := &File{f: f, path: path, flushed: NodesCount(leaves)}
+	return file, New(file, leaves), nil
+}
+
+// OpenFileReadOnly - can be used concurrently with writer in other process: nodes appended after open are not visible.
+// Returns empty MMR if file doesn't exist.
+func OpenFileReadOnly(path string) (*File, *MMR, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			file := &File{path: path, readOnly: true}
+			return file, New(file, 0), nil
+		}
+		return nil, nil, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	// writer may be in the middle of Flush
+	leaves, _ := LeavesCount(uint64(st.Size()) / length.Hash)
+	file := &File{f: f, path: path, flushed: NodesCount(leaves), readOnly: true}
+	return file, New(file, leaves), nil
+}
+
+func (f *File) Nodes() uint64 { return f.flushed + uint64(len(f.pending)) }
+
+func (f *File) Node(pos uint64) (h common.Hash, err error) {
+	if pos >= f.flushed {
+		if pos-f.flushed >= uint64(len(f.pending)) {
+			return h, fmt.Errorf("%s: node %d not found", f.path, pos)
+		}
+		return f.pending[pos-f.flushed], nil
+	}
+	if _, err := f.f.ReadAt(h[:], int64(pos*length.Hash)); err != nil {
+		return h, fmt.Errorf("%s: node %d: %w", f.path, pos, err)
+	}
+	return h, nil
+}
+
+func (f *File) Append(node common.Hash) error {
+	if f.readOnly {
+		return fmt.Errorf("%s: read-only", f.path)
+	}
+	f.pending = append(f.pending, node)
+	return nil
+}
+
+// Flush - writes and fsyncs appended nodes
+func (f *File) Flush() error {
+	if len(f.pending) == 0 {
+		return nil
+	}
+	buf := make([]byte, 0, len(f.pending)*length.Hash)
+	for _, h := range f.pending {
+		buf = append(buf, h[:]...)
+	}
+	if _, err := f.f.WriteAt(buf, int64(f.flushed*length.Hash)); err != nil {
+		return err
+	}
+	if err := f.f.Sync(); err != nil {
+		return err
+	}
+	f.flushed += uint64(len(f.pending))
+	f.pending = f.pending[:0]
+	return nil
+}
+
+func (f *File) Close() {
+	if f.f != nil {
+		f.f.Close()
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package mmr - Merkle Mountain Range: append-only accumulator with O(log n) inclusion proofs of any leaf
+// against root of latest state.
+//
+// Nodes are stored in post-order (position of node = amount of nodes appended before it), so appending
+// is also append-only for storage. Parent = keccak256(left || right). Root = keccak256(leafCount || bagged peaks),
+// where peaks are bagged from right to left: bag = keccak256(peak || bag).
+package mmr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+// Store - storage of nodes. Append adds node at position NodesCount(leaves).
+type Store interface {
+	Node(pos uint64) (common.Hash, error)
+	Append(node common.Hash) error
+}
+
+// NodesCount - amount of nodes in MMR with given amount of leaves
+func NodesCount(leaves uint64) uint64 { return 2*leaves - uint64(bits.OnesCount64(leaves)) }
+
+// LeavesCount - amount of leaves of MMR with given amount of nodes. ok=false if nodes is not valid MMR size.
+func LeavesCount(nodes uint64) (leaves uint64, ok bool) {
+	// NodesCount is monotonic: find max leaves with NodesCount(leaves) <= nodes
+	lo, hi := uint64(0), nodes
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if NodesCount(mid) <= nodes {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo, NodesCount(lo) == nodes
+}
+
+func hashNodes(left, right common.Hash) common.Hash {
+	return crypto.Keccak256Hash(left[:], right[:])
+}
+
+type MMR struct {
+	store  Store
+	leaves uint64
+}
+
+func New(store Store, leaves uint64) *MMR { return &MMR{store: store, leaves: leaves} }
+
+func (m *MMR) Leaves() uint64 { return m.leaves }
+
+func (m *MMR) Append(leaf common.Hash) error {
+	pos, node := NodesCount(m.leaves), leaf
+	if err := m.store.Append(node); err != nil {
+		return err
+	}
+	// every trailing 1 bit of leaf index - one merge with left sibling of same height
+	for i, height := m.leaves, 0; i&1 == 1; i, height = i>>1, height+1 {
+		left, err := m.store.Node(pos - (1<<(height+1) - 1))
+		if err != nil {
+			return err
+		}
+		node = hashNodes(left, node)
+		if err := m.store.Append(node); err != nil {
+			return err
+		}
+		pos++
+	}
+	m.leaves++
+	return nil
+}
+
+// peak - perfect tree of 2^height leaves starting from leaf `first`, its nodes start at position `base`
+type peak struct {
+	first, base uint64
+	height      int
+}
+
+func (p peak) pos() uint64 { return p.base + 1<<(p.height+1) - 2 }
+
+func peaksOf(leaves uint64) []peak {
+	var res []peak
+	var first, base uint64
+	for height := 63; height >= 0; height-- {
+		if leaves&(1<<height) == 0 {
+			continue
+		}
+		res = append(res, peak{first: first, base: base, height: height})
+		first += 1 << height
+		base += 1<<(height+1) - 1
+	}
+	return res
+}
+
+// Leaf - value of leaf with given index
+func (m *MMR) Leaf(index uint64) (common.Hash, error) {
+	if index >= m.leaves {
+		return common.Hash{}, fmt.Errorf("leaf %d not in MMR of %d leaves", index, m.leaves)
+	}
+	return m.store.Node(NodesCount(index))
+}
+
+func (m *MMR) Peaks() ([]common.Hash, error) {
+	peaks := peaksOf(m.leaves)
+	res := make([]common.Hash, len(peaks))
+	for i, p := range peaks {
+		var err error
+		if res[i], err = m.store.Node(p.pos()); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (m *MMR) Root() (common.Hash, error) {
+	peaks, err := m.Peaks()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return bagPeaks(m.leaves, peaks), nil
+}
+
+func bagPeaks(leaves uint64, peaks []common.Hash) common.Hash {
+	var bag common.Hash
+	for i := len(peaks) - 1; i >= 0; i-- {
+		if i == len(peaks)-1 {
+			bag = peaks[i]
+			continue
+		}
+		bag = hashNodes(peaks[i], bag)
+	}
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], leaves)
+	return crypto.Keccak256Hash(size[:], bag[:])
+}
+
+// Proof - inclusion proof of leaf LeafIndex in MMR of LeafCount leaves
+type Proof struct {
+	LeafIndex uint64
+	LeafCount uint64
+	Siblings  []common.Hash // from leaf up to its peak
+	Peaks     []common.Hash
+}
+
+func (m *MMR) Proof(leafIndex uint64) (*Proof, error) {
+	if leafIndex >= m.leaves {
+		return nil, fmt.Errorf("leaf %d not in MMR of %d leaves", leafIndex, m.leaves)
+	}
+	peaks, err := m.Peaks()
+	if err != nil {
+		return nil, err
+	}
+	p := findPeak(m.leaves, leafIndex)
+	siblings := make([]common.Hash, p.height)
+	// descend from peak: left subtree at base, right subtree at base+2^h-1
+	base, idx := p.base, leafIndex-p.first
+	for h := p.height; h > 0; h-- {
+		half := uint64(1) << (h - 1)
+		subtreeNodes := 1<<h - 1
+		var sibling uint64
+		if idx < half {
+			sibling = base + 2*uint64(subtreeNodes) - 1
+		} else {
+			sibling = base + uint64(subtreeNodes) - 1
+			base += uint64(subtreeNodes)
+			idx -= half
+		}
+		if siblings[h-1], err = m.store.Node(sibling); err != nil {
+			return nil, err
+		}
+	}
+	return &Proof{LeafIndex: leafIndex, LeafCount: m.leaves, Siblings: siblings, Peaks: peaks}, nil
+}
+
+func findPeak(leaves, leafIndex uint64) peak {
+	for _, p := range peaksOf(leaves) {
+		if leafIndex < p.first+1<<p.height {
+			return p
+		}
+	}
+	panic("leaf index out of range")
+}
+
+// Verify - checks that leaf is included in MMR with given root
+func Verify(root, leaf common.Hash, proof *Proof) error {
+	if proof.LeafIndex >= proof.LeafCount {
+		return fmt.Errorf("leaf %d not in MMR of %d leaves", proof.LeafIndex, proof.LeafCount)
+	}
+	peaks := peaksOf(proof.LeafCount)
+	if len(proof.Peaks) != len(peaks) {
+		return fmt.Errorf("expected %d peaks, got %d", len(peaks), len(proof.Peaks))
+	}
+	if bagPeaks(proof.LeafCount, proof.Peaks) != root {
+		return errors.New("peaks don't match root")
+	}
+	node, idx := leaf, proof.LeafIndex
+	for i, p := range peaks {
+		if idx >= p.first+1<<p.height {
+			continue
+		}
+		if len(proof.Siblings) != p.height {
+			return fmt.Errorf("expected %d siblings, got %d", p.height, len(proof.Siblings))
+		}
+		idx -= p.first
+		for _, sibling := range proof.Siblings {
+			if idx&1 == 0 {
+				node = hashNodes(node, sibling)
+			} else {
+				node = hashNodes(sibling, node)
+			}
+			idx >>= 1
+		}
+		if node != proof.Peaks[i] {
+			return errors.New("leaf doesn't match peak")
+		}
+		return nil
+	}
+	return errors.New("leaf index out of range")
+}
+
+// MemStore - in-memory nodes on top of read-only base (nil - empty) with `baseNodes` nodes
+type MemStore struct {
+	base      Store
+	baseNodes uint64
+	nodes     []common.Hash
+}
+
+func NewMemStore(base Store, baseNodes uint64) *MemStore {
+	return &MemStore{base: base, baseNodes: baseNodes}
+}
+
+func (s *MemStore) Node(pos uint64) (common.Hash, error) {
+	if pos < s.baseNodes {
+		return s.base.Node(pos)
+	}
+	if pos-s.baseNodes >= uint64(len(s.nodes)) {
+		return common.Hash{}, fmt.Errorf("node %d not found", pos)
+	}
+	return s.nodes[pos-s.baseNodes], nil
+}
+
+func (s *MemStore) Append(node common.Hash) error {
+	s.nodes = append(s.nodes, node)
+	return nil
+}
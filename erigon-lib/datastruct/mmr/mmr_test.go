@@ -0,0 +1,123 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mmr
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+func leaf(i uint64) common.Hash { return crypto.Keccak256Hash(hexutil.EncodeTs(i)) }
+
+func TestProofs(t *testing.T) {
+	require := require.New(t)
+	m := New(NewMemStore(nil, 0), 0)
+	var prevRoot common.Hash
+	for n := uint64(1); n <= 70; n++ {
+		require.NoError(m.Append(leaf(n - 1)))
+		require.Equal(NodesCount(n), uint64(len(m.store.(*MemStore).nodes)))
+		leaves, ok := LeavesCount(NodesCount(n))
+		require.True(ok)
+		require.Equal(n, leaves)
+
+		root, err := m.Root()
+		require.NoError(err)
+		require.NotEqual(prevRoot, root)
+		prevRoot = root
+		for i := uint64(0); i < n; i++ {
+			proof, err := m.Proof(i)
+			require.NoError(err)
+			require.NoError(Verify(root, leaf(i), proof), "n=%d i=%d", n, i)
+			require.Error(Verify(root, leaf(i+1), proof), "n=%d i=%d", n, i)
+			if len(proof.Siblings) > 0 {
+				proof.Siblings[0][0]++
+				require.Error(Verify(root, leaf(i), proof), "n=%d i=%d", n, i)
+			}
+		}
+		_, err = m.Proof(n)
+		require.Error(err)
+	}
+	_, ok := LeavesCount(NodesCount(7) + 1) // leaf appended, parents are not
+	require.False(ok)
+}
+
+func TestFile(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "test.mmr")
+	f, m, err := OpenFile(path)
+	require.NoError(err)
+	mem := New(NewMemStore(nil, 0), 0)
+	for i := uint64(0); i < 37; i++ {
+		require.NoError(m.Append(leaf(i)))
+		require.NoError(mem.Append(leaf(i)))
+		if i%10 == 0 {
+			require.NoError(f.Flush())
+		}
+	}
+	require.NoError(f.Flush())
+	f.Close()
+	expected, err := mem.Root()
+	require.NoError(err)
+
+	// partially written file is truncated to last complete state
+	fd, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(err)
+	_, err = fd.Write(make([]byte, 40))
+	require.NoError(err)
+	require.NoError(fd.Close())
+
+	f, m, err = OpenFileReadOnly(path)
+	require.NoError(err)
+	require.Equal(uint64(37), m.Leaves())
+	root, err := m.Root()
+	require.NoError(err)
+	require.Equal(expected, root)
+	require.Error(m.Append(leaf(37)))
+	f.Close()
+
+	f, m, err = OpenFile(path)
+	require.NoError(err)
+	defer f.Close()
+	require.Equal(uint64(37), m.Leaves())
+	require.Equal(NodesCount(37), f.Nodes())
+
+	// in-memory tail on top of file
+	tail := New(NewMemStore(f, f.Nodes()), m.Leaves())
+	for i := uint64(37); i < 50; i++ {
+		require.NoError(tail.Append(leaf(i)))
+		require.NoError(mem.Append(leaf(i)))
+	}
+	expected, err = mem.Root()
+	require.NoError(err)
+	root, err = tail.Root()
+	require.NoError(err)
+	require.Equal(expected, root)
+	proof, err := tail.Proof(3)
+	require.NoError(err)
+	require.NoError(Verify(root, leaf(3), proof))
+
+	_, m, err = OpenFileReadOnly(filepath.Join(t.TempDir(), "none.mmr"))
+	require.NoError(err)
+	require.Zero(m.Leaves())
+}
@@ -57,6 +57,10 @@ type DiagnosticClient struct {
 	networkSpeedMutex   sync.Mutex
 	webseedsList        []string
 	conn                *websocket.Conn
+	dashboardMu         sync.Mutex
+	dashboardHistory    *ringBuffer[DashboardSample]
+	dashboardClients    map[chan DashboardSample]struct{}
+	txPoolStats         TxPoolStatistics
 }
 
 var (
@@ -139,6 +143,7 @@ func (d *DiagnosticClient) Setup() {
 	d.setupSpeedtestDiagnostics(rootCtx)
 
 	d.setupTxPoolDiagnostics(rootCtx)
+	d.setupDashboardDiagnostics(rootCtx)
 
 	d.runSaveProcess(rootCtx)
 
@@ -0,0 +1,240 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const (
+	DashboardSampleInterval = 10 * time.Second
+	DashboardHistoryLength  = time.Hour
+
+	// peers which didn't exchange messages for longer are not counted as connected
+	dashboardActivePeerWindow = time.Minute
+	// samples which a slow websocket client didn't take yet, newer ones are dropped
+	dashboardClientBuffer = 16
+)
+
+// DashboardSample is the progress of the node at one point in time, as shown by the
+// dashboard of the web UI.
+type DashboardSample struct {
+	Time       time.Time           `json:"time"`
+	P2P        DashboardP2P        `json:"p2p"`
+	Downloader DashboardDownloader `json:"downloader"`
+	Stages     DashboardStages     `json:"stages"`
+}
+
+type DashboardP2P struct {
+	Peers    int    `json:"peers"`
+	BytesIn  uint64 `json:"bytesIn"`
+	BytesOut uint64 `json:"bytesOut"`
+}
+
+type DashboardDownloader struct {
+	Downloaded   uint64 `json:"downloaded"`
+	Total        uint64 `json:"total"`
+	DownloadRate uint64 `json:"downloadRate"`
+	UploadRate   uint64 `json:"uploadRate"`
+	Peers        int32  `json:"peers"`
+	Finished     bool   `json:"finished"`
+}
+
+type DashboardStages struct {
+	Stage    string         `json:"stage"`
+	SubStage string         `json:"subStage"`
+	Stats    SyncStageStats `json:"stats"`
+}
+
+// ringBuffer keeps the last len(items) values added to it.
+type ringBuffer[T any] struct {
+	items []T
+	next  int
+	full  bool
+}
+
+func newRingBuffer[T any](size int) *ringBuffer[T] {
+	return &ringBuffer[T]{items: make([]T, size)}
+}
+
+func (r *ringBuffer[T]) add(item T) {
+	r.items[r.next] = item
+	r.next = (r.next + 1) % len(r.items)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// values returns the values from the oldest to the newest.
+func (r *ringBuffer[T]) values() []T {
+	if !r.full {
+		return append([]T(nil), r.items[:r.next]...)
+	}
+	return append(append(make([]T, 0, len(r.items)), r.items[r.next:]...), r.items[:r.next]...)
+}
+
+func (d *DiagnosticClient) setupDashboardDiagnostics(rootCtx context.Context) {
+	d.metricsMux.HandleFunc("/dashboard", d.HandleDashboardConnections)
+	d.runDashboardSampler(rootCtx)
+}
+
+func (d *DiagnosticClient) runDashboardSampler(rootCtx context.Context) {
+	go func() {
+		ticker := time.NewTicker(DashboardSampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-rootCtx.Done():
+				return
+			case <-ticker.C:
+				d.TakeDashboardSample()
+			}
+		}
+	}()
+}
+
+// TakeDashboardSample collects the current progress of the node, adds it to the
+// history and sends it to the connected dashboard clients.
+func (d *DiagnosticClient) TakeDashboardSample() DashboardSample {
+	sample := DashboardSample{Time: time.Now()}
+
+	if d.peersStats != nil {
+		sample.P2P.Peers = d.peersStats.GetActivePeersCount(sample.Time.Add(-dashboardActivePeerWindow))
+		for _, peer := range d.peersStats.GetPeers() {
+			sample.P2P.BytesIn += peer.BytesIn
+			sample.P2P.BytesOut += peer.BytesOut
+		}
+	}
+
+	d.mu.Lock()
+	download := d.syncStats.SnapshotDownload
+	sample.Downloader = DashboardDownloader{
+		Downloaded:   download.Downloaded,
+		Total:        download.Total,
+		DownloadRate: download.DownloadRate,
+		UploadRate:   download.UploadRate,
+		Peers:        download.Peers,
+		Finished:     download.DownloadFinished,
+	}
+	if idxs := d.getCurrentSyncIdxs(); idxs.Stage >= 0 {
+		stage := d.syncStages[idxs.Stage]
+		sample.Stages.Stage = stage.ID
+		sample.Stages.Stats = stage.Stats
+		if idxs.SubStage >= 0 {
+			sample.Stages.SubStage = stage.SubStages[idxs.SubStage].ID
+			sample.Stages.Stats = stage.SubStages[idxs.SubStage].Stats
+		}
+	}
+	d.mu.Unlock()
+
+	d.dashboardMu.Lock()
+	defer d.dashboardMu.Unlock()
+	d.dashboardHistoryBuffer().add(sample)
+	for ch := range d.dashboardClients {
+		select {
+		case ch <- sample:
+		default:
+		}
+	}
+
+	return sample
+}
+
+// DashboardHistory returns the samples of the last DashboardHistoryLength, from the
+// oldest to the newest.
+func (d *DiagnosticClient) DashboardHistory() []DashboardSample {
+	d.dashboardMu.Lock()
+	defer d.dashboardMu.Unlock()
+	return d.dashboardHistoryBuffer().values()
+}
+
+// dashboardHistoryBuffer must be called with dashboardMu held.
+func (d *DiagnosticClient) dashboardHistoryBuffer() *ringBuffer[DashboardSample] {
+	if d.dashboardHistory == nil {
+		d.dashboardHistory = newRingBuffer[DashboardSample](int(DashboardHistoryLength / DashboardSampleInterval))
+	}
+	return d.dashboardHistory
+}
+
+// subscribeDashboard returns the history and a channel of the following samples, which
+// stays subscribed until unsubscribeDashboard.
+func (d *DiagnosticClient) subscribeDashboard() ([]DashboardSample, chan DashboardSample) {
+	d.dashboardMu.Lock()
+	defer d.dashboardMu.Unlock()
+
+	if d.dashboardClients == nil {
+		d.dashboardClients = make(map[chan DashboardSample]struct{})
+	}
+	ch := make(chan DashboardSample, dashboardClientBuffer)
+	d.dashboardClients[ch] = struct{}{}
+	return d.dashboardHistoryBuffer().values(), ch
+}
+
+func (d *DiagnosticClient) unsubscribeDashboard(ch chan DashboardSample) {
+	d.dashboardMu.Lock()
+	defer d.dashboardMu.Unlock()
+	delete(d.dashboardClients, ch)
+}
+
+// HandleDashboardConnections streams the dashboard to a WebSocket client: the history
+// of the last DashboardHistoryLength is sent first, as a "dashboardHistory" message,
+// followed by a "dashboard" message for every new sample. Unlike the `/ws` endpoint,
+// any number of clients can be connected.
+func (d *DiagnosticClient) HandleDashboardConnections(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Debug("[Diagnostics] Error upgrading to WebSocket", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	history, samples := d.subscribeDashboard()
+	defer d.unsubscribeDashboard(samples)
+
+	// reading is needed to handle the control messages of the client, the dashboard
+	// doesn't expect anything else
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	if err := conn.WriteJSON(DiagMessages{MessageType: "dashboardHistory", Message: history}); err != nil {
+		log.Debug("[Diagnostics] Error writing message to WebSocket client", "err", err)
+		return
+	}
+	for {
+		select {
+		case <-closed:
+			log.Debug("[Diagnostics] Dashboard WebSocket client disconnected")
+			return
+		case sample := <-samples:
+			if err := conn.WriteJSON(DiagMessages{MessageType: "dashboard", Message: sample}); err != nil {
+				log.Debug("[Diagnostics] Error writing message to WebSocket client", "err", err)
+				return
+			}
+		}
+	}
+}
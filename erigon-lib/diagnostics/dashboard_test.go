@@ -0,0 +1,90 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/diagnostics"
+)
+
+func TestDashboardHistory(t *testing.T) {
+	d, err := NewTestDiagnosticClient()
+	require.NoError(t, err)
+
+	d.SetStagesList(diagnostics.InitStagesFromList(nodeStages))
+	require.NoError(t, d.SetCurrentSyncStage(diagnostics.CurrentSyncStage{Stage: "BlockHashes"}))
+
+	size := int(diagnostics.DashboardHistoryLength / diagnostics.DashboardSampleInterval)
+	first := d.TakeDashboardSample()
+	require.Equal(t, "BlockHashes", first.Stages.Stage)
+	var last diagnostics.DashboardSample
+	for i := 0; i < size; i++ {
+		last = d.TakeDashboardSample()
+	}
+
+	// the first sample dropped out of the history
+	history := d.DashboardHistory()
+	require.Len(t, history, size)
+	require.True(t, history[size-1].Time.Equal(last.Time))
+	for i := 1; i < len(history); i++ {
+		require.False(t, history[i].Time.Before(history[i-1].Time))
+	}
+}
+
+func TestDashboardWebSocket(t *testing.T) {
+	d, err := NewTestDiagnosticClient()
+	require.NoError(t, err)
+
+	d.TakeDashboardSample()
+	d.TakeDashboardSample()
+
+	srv := httptest.NewServer(http.HandlerFunc(d.HandleDashboardConnections))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var history struct {
+		MessageType string                        `json:"messageType"`
+		Message     []diagnostics.DashboardSample `json:"message"`
+	}
+	require.NoError(t, conn.ReadJSON(&history))
+	require.Equal(t, "dashboardHistory", history.MessageType)
+	require.Len(t, history.Message, 2)
+
+	taken := d.TakeDashboardSample()
+
+	var sample struct {
+		MessageType string          `json:"messageType"`
+		Message     json.RawMessage `json:"message"`
+	}
+	require.NoError(t, conn.ReadJSON(&sample))
+	require.Equal(t, "dashboard", sample.MessageType)
+
+	var got diagnostics.DashboardSample
+	require.NoError(t, json.Unmarshal(sample.Message, &got))
+	require.True(t, got.Time.Equal(taken.Time))
+}
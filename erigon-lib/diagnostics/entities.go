@@ -76,6 +76,8 @@ type SyncStatistics struct {
 	SnapshotDownload SnapshotDownloadStatistics `json:"snapshotDownload"`
 	SnapshotIndexing SnapshotIndexingStatistics `json:"snapshotIndexing"`
 	SnapshotFillDB   SnapshotFillDBStatistics   `json:"snapshotFillDB"`
+	SnapshotReindex  SnapshotReindexStatistics  `json:"snapshotReindex"`
+	SnapshotScrub    SnapshotScrubStatistics    `json:"snapshotScrub"`
 	SyncFinished     bool                       `json:"syncFinished"`
 }
 
@@ -138,6 +140,31 @@ type SnapshotSegmentIndexingStatistics struct {
 	Sys         uint64 `json:"sys"`
 }
 
+// SnapshotReindexStatistics - background re-build of accessors (.idx) of already open segments (salt change, corrupted files)
+type SnapshotReindexStatistics struct {
+	Segments    []SnapshotSegmentIndexingStatistics `json:"segments"`
+	Swapped     int                                 `json:"swapped"` // segments which readers already see with new accessors
+	TimeElapsed float64                             `json:"timeElapsed"`
+	Finished    bool                                `json:"finished"`
+}
+
+// SnapshotScrubStatistics - background re-hashing of frozen files against the manifest (silent disk corruption)
+type SnapshotScrubStatistics struct {
+	Pass         uint64                    `json:"pass"`
+	FilesTotal   int                       `json:"filesTotal"`
+	FilesChecked int                       `json:"filesChecked"`
+	BytesChecked uint64                    `json:"bytesChecked"`
+	TimeElapsed  float64                   `json:"timeElapsed"`
+	Corrupted    []SnapshotScrubCorruption `json:"corrupted"` // latest found, scheduled for re-download
+}
+
+type SnapshotScrubCorruption struct {
+	FileName  string    `json:"fileName"`
+	BadPieces int       `json:"badPieces"`
+	Pass      uint64    `json:"pass"`
+	Time      time.Time `json:"time"`
+}
+
 type SnapshotFillDBStatistics struct {
 	Stages []SnapshotFillDBStage `json:"stages"`
 }
@@ -332,6 +359,14 @@ func (ti SnapshotIndexingStatistics) Type() Type {
 	return TypeOf(ti)
 }
 
+func (ti SnapshotReindexStatistics) Type() Type {
+	return TypeOf(ti)
+}
+
+func (ti SnapshotScrubStatistics) Type() Type {
+	return TypeOf(ti)
+}
+
 func (ti PeerStatisticMsgUpdate) Type() Type {
 	return TypeOf(ti)
 }
@@ -139,6 +139,20 @@ func (p *PeerStats) getPeersCount() int {
 	return p.recordsCount
 }
 
+// GetActivePeersCount returns the number of peers which were updated after since.
+func (p *PeerStats) GetActivePeersCount(since time.Time) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+	for _, lastUpdate := range p.lastUpdateMap {
+		if lastUpdate.After(since) {
+			count++
+		}
+	}
+	return count
+}
+
 func (p *PeerStats) GetPeers() map[string]PeerStatistics {
 	p.mu.Lock()
 	defer p.mu.Unlock()
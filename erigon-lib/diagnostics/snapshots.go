@@ -39,6 +39,8 @@ func (d *DiagnosticClient) setupSnapshotDiagnostics(rootCtx context.Context) {
 	d.runSegmentDownloadingListener(rootCtx)
 	d.runSnapshotFilesListListener(rootCtx)
 	d.runSegmentIndexingListener(rootCtx)
+	d.runSegmentReindexListener(rootCtx)
+	d.runScrubListener(rootCtx)
 	d.runFileDownloadedListener(rootCtx)
 	d.runFillDBListener(rootCtx)
 }
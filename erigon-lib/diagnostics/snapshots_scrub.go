@@ -0,0 +1,47 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// runScrubListener - scrubber of downloader works all the time: listener lives until shutdown
+func (d *DiagnosticClient) runScrubListener(rootCtx context.Context) {
+	go func() {
+		ctx, ch, closeChannel := Context[SnapshotScrubStatistics](rootCtx, 1)
+		defer closeChannel()
+
+		StartProviders(ctx, TypeOf(SnapshotScrubStatistics{}), log.Root())
+		for {
+			select {
+			case <-rootCtx.Done():
+				return
+			case info := <-ch:
+				d.SetScrubState(info)
+			}
+		}
+	}()
+}
+
+func (d *DiagnosticClient) SetScrubState(upd SnapshotScrubStatistics) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.syncStats.SnapshotScrub = upd
+}
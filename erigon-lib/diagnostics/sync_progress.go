@@ -0,0 +1,107 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SyncProgress is the progress of the node as reported by the erigon extension of eth_syncing:
+// the stage which is running now and the downloads of snapshot files.
+type SyncProgress struct {
+	CurrentStage     *CurrentStageProgress `json:"currentStage,omitempty"`
+	SnapshotDownload *DownloadProgress     `json:"snapshotDownload,omitempty"`
+}
+
+type CurrentStageProgress struct {
+	Stage    string `json:"stage"`
+	SubStage string `json:"subStage,omitempty"`
+	SyncStageStats
+}
+
+type DownloadProgress struct {
+	Downloaded   uint64 `json:"downloadedBytes"`
+	Total        uint64 `json:"totalBytes"`
+	DownloadRate uint64 `json:"downloadRate"`
+	Finished     bool   `json:"finished"`
+	SyncStageStats
+	// Kinds is the progress by kind of files: headers, bodies, transactions, accounts, storage, code, commitment, ...
+	Kinds map[string]*DownloadKindProgress `json:"kinds"`
+}
+
+type DownloadKindProgress struct {
+	Files      int    `json:"files"`
+	Downloaded uint64 `json:"downloadedBytes"`
+	Total      uint64 `json:"totalBytes"`
+}
+
+// SyncProgress returns nil parts for what is not known yet: the diagnostics client is set up
+// only in the process of the node, and download statistics appear when the downloader starts.
+func (d *DiagnosticClient) SyncProgress() SyncProgress {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var progress SyncProgress
+	if idxs := d.getCurrentSyncIdxs(); idxs.Stage >= 0 {
+		stage := d.syncStages[idxs.Stage]
+		progress.CurrentStage = &CurrentStageProgress{Stage: stage.ID, SyncStageStats: stage.Stats}
+		if idxs.SubStage >= 0 {
+			progress.CurrentStage.SubStage = stage.SubStages[idxs.SubStage].ID
+			progress.CurrentStage.SyncStageStats = stage.SubStages[idxs.SubStage].Stats
+		}
+	}
+
+	download := d.syncStats.SnapshotDownload
+	if download.Total == 0 && len(download.SegmentsDownloading) == 0 {
+		return progress
+	}
+	progress.SnapshotDownload = &DownloadProgress{
+		Downloaded:     download.Downloaded,
+		Total:          download.Total,
+		DownloadRate:   download.DownloadRate,
+		Finished:       download.DownloadFinished,
+		SyncStageStats: CalculateSyncStageStats(download),
+		Kinds:          map[string]*DownloadKindProgress{},
+	}
+	for name, segment := range download.SegmentsDownloading {
+		kind := snapshotFileKind(name)
+		if progress.SnapshotDownload.Kinds[kind] == nil {
+			progress.SnapshotDownload.Kinds[kind] = &DownloadKindProgress{}
+		}
+		k := progress.SnapshotDownload.Kinds[kind]
+		k.Files++
+		k.Downloaded += segment.DownloadedBytes
+		k.Total += segment.TotalBytes
+	}
+	return progress
+}
+
+// snapshotFileKind - kind of data in the file: "headers" of v1.0-000000-000500-headers.seg,
+// "accounts" of domain/v1.0-accounts.0-64.kv
+func snapshotFileKind(name string) string {
+	name = filepath.Base(name)
+	parts := strings.Split(strings.TrimSuffix(name, filepath.Ext(name)), "-")
+	if last := parts[len(parts)-1]; len(parts) < 3 {
+		return last
+	} else if _, err := strconv.ParseUint(last, 10, 64); err != nil { // block files: kind is the last part
+		return last
+	}
+	kind, _, _ := strings.Cut(parts[1], ".")
+	return kind
+}
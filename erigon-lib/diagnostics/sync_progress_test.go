@@ -0,0 +1,54 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package diagnostics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/diagnostics"
+)
+
+func TestSyncProgress(t *testing.T) {
+	d, err := NewTestDiagnosticClient()
+	require.NoError(t, err)
+
+	progress := d.SyncProgress()
+	require.Nil(t, progress.CurrentStage)
+	require.Nil(t, progress.SnapshotDownload)
+
+	d.SetStagesList(diagnostics.InitStagesFromList(nodeStages))
+	require.NoError(t, d.SetCurrentSyncStage(diagnostics.CurrentSyncStage{Stage: "BlockHashes"}))
+	d.SetSnapshotDownloadInfo(diagnostics.SnapshotDownloadStatistics{Downloaded: 300, Total: 1000, DownloadRate: 100, Files: 3, TorrentMetadataReady: 3})
+	for _, segment := range []diagnostics.SegmentDownloadStatistics{
+		{Name: "v1.0-000000-000500-headers.seg", TotalBytes: 100, DownloadedBytes: 100},
+		{Name: "v1.0-000500-001000-headers.seg", TotalBytes: 100, DownloadedBytes: 50},
+		{Name: "domain/v1.0-accounts.0-64.kv", TotalBytes: 800, DownloadedBytes: 150},
+	} {
+		d.SetDownloadSegments(segment)
+	}
+
+	progress = d.SyncProgress()
+	require.Equal(t, "BlockHashes", progress.CurrentStage.Stage)
+	download := progress.SnapshotDownload
+	require.Equal(t, uint64(300), download.Downloaded)
+	require.Equal(t, "30%", download.Progress)
+	require.Equal(t, "7s", download.TimeLeft)
+	require.Equal(t, &diagnostics.DownloadKindProgress{Files: 2, Downloaded: 150, Total: 200}, download.Kinds["headers"])
+	require.Equal(t, &diagnostics.DownloadKindProgress{Files: 1, Downloaded: 150, Total: 800}, download.Kinds["accounts"])
+}
@@ -76,9 +76,21 @@ func (ti PoolChangeBatchEvent) Type() Type {
 	return TypeOf(ti)
 }
 
+// TxPoolStatistics is the number of transactions in each of the sub-pools.
+type TxPoolStatistics struct {
+	Pending int `json:"pending"`
+	BaseFee int `json:"baseFee"`
+	Queued  int `json:"queued"`
+}
+
+func (ti TxPoolStatistics) Type() Type {
+	return TypeOf(ti)
+}
+
 func (d *DiagnosticClient) setupTxPoolDiagnostics(rootCtx context.Context) {
 	d.runOnIncommingTxnListener(rootCtx)
 	d.runOnPoolChangeBatchEvent(rootCtx)
+	d.runOnTxPoolStatistics(rootCtx)
 	d.SetupNotifier()
 }
 
@@ -130,3 +142,32 @@ func (d *DiagnosticClient) runOnPoolChangeBatchEvent(rootCtx context.Context) {
 		}
 	}()
 }
+
+func (d *DiagnosticClient) runOnTxPoolStatistics(rootCtx context.Context) {
+	go func() {
+		ctx, ch, closeChannel := Context[TxPoolStatistics](rootCtx, 1)
+		defer closeChannel()
+
+		StartProviders(ctx, TypeOf(TxPoolStatistics{}), log.Root())
+		for {
+			select {
+			case <-rootCtx.Done():
+				return
+			case info := <-ch:
+				d.SetTxPoolStatistics(info)
+			}
+		}
+	}()
+}
+
+func (d *DiagnosticClient) SetTxPoolStatistics(stats TxPoolStatistics) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.txPoolStats = stats
+}
+
+func (d *DiagnosticClient) TxPoolStatistics() TxPoolStatistics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.txPoolStats
+}
@@ -52,6 +52,9 @@ func (c *DownloaderClient) SetLogPrefix(ctx context.Context, in *proto_downloade
 func (c *DownloaderClient) Completed(ctx context.Context, in *proto_downloader.CompletedRequest, opts ...grpc.CallOption) (*proto_downloader.CompletedReply, error) {
 	return c.server.Completed(ctx, in)
 }
+func (c *DownloaderClient) Transport(ctx context.Context, in *proto_downloader.TransportRequest, opts ...grpc.CallOption) (*proto_downloader.TransportReply, error) {
+	return c.server.Transport(ctx, in)
+}
 
 func (c *DownloaderClient) TorrentCompleted(ctx context.Context, in *proto_downloader.TorrentCompletedRequest, opts ...grpc.CallOption) (proto_downloader.Downloader_TorrentCompletedClient, error) {
 	ch := make(chan *downloadedReply, 1<<16)
@@ -241,6 +241,18 @@ func (s *EthBackendClientDirect) AddPeer(ctx context.Context, in *remote.AddPeer
 	return s.server.AddPeer(ctx, in)
 }
 
+func (s *EthBackendClientDirect) BanPeer(ctx context.Context, in *remote.BanPeerRequest, opts ...grpc.CallOption) (*remote.BanPeerReply, error) {
+	return s.server.BanPeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) UnbanPeer(ctx context.Context, in *remote.UnbanPeerRequest, opts ...grpc.CallOption) (*remote.UnbanPeerReply, error) {
+	return s.server.UnbanPeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.BannedPeersReply, error) {
+	return s.server.BannedPeers(ctx, in)
+}
+
 func (s *EthBackendClientDirect) PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.PendingBlockReply, error) {
 	return s.server.PendingBlock(ctx, in)
 }
@@ -36,6 +36,7 @@ const (
 	ETH66 = 66
 	ETH67 = 67
 	ETH68 = 68
+	ETH69 = 69
 )
 
 //go:generate mockgen -typed=true -destination=./sentry_client_mock.go -package=direct . SentryClient
@@ -89,7 +90,7 @@ func (c *SentryClientRemote) HandShake(ctx context.Context, in *emptypb.Empty, o
 	c.Lock()
 	defer c.Unlock()
 	switch reply.Protocol {
-	case sentryproto.Protocol_ETH67, sentryproto.Protocol_ETH68:
+	case sentryproto.Protocol_ETH67, sentryproto.Protocol_ETH68, sentryproto.Protocol_ETH69:
 		c.protocol = reply.Protocol
 	default:
 		return nil, fmt.Errorf("unexpected protocol: %d", reply.Protocol)
@@ -261,6 +262,18 @@ func (c *SentryClientDirect) AddPeer(ctx context.Context, in *sentryproto.AddPee
 	return c.server.AddPeer(ctx, in)
 }
 
+func (c *SentryClientDirect) BanPeer(ctx context.Context, in *sentryproto.BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return c.server.BanPeer(ctx, in)
+}
+
+func (c *SentryClientDirect) UnbanPeer(ctx context.Context, in *sentryproto.UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return c.server.UnbanPeer(ctx, in)
+}
+
+func (c *SentryClientDirect) BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.BannedPeersReply, error) {
+	return c.server.BannedPeers(ctx, in)
+}
+
 type peersReply struct {
 	r   *sentryproto.PeerEvent
 	err error
@@ -319,6 +332,10 @@ func (c *SentryClientDirect) NodeInfo(ctx context.Context, in *emptypb.Empty, op
 	return c.server.NodeInfo(ctx, in)
 }
 
+func (c *SentryClientDirect) PeerStats(ctx context.Context, in *sentryproto.PeerStatsRequest, opts ...grpc.CallOption) (*sentryproto.PeerStatsReply, error) {
+	return c.server.PeerStats(ctx, in)
+}
+
 func filterIds(in []sentryproto.MessageId, protocol sentryproto.Protocol) (filtered []sentryproto.MessageId) {
 	for _, id := range in {
 		if _, ok := libsentry.ProtoIds[protocol][id]; ok {
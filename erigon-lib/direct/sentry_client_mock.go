@@ -88,6 +88,94 @@ func (c *MockSentryClientAddPeerCall) DoAndReturn(f func(context.Context, *sentr
 	return c
 }
 
+// BanPeer mocks base method.
+func (m *MockSentryClient) BanPeer(ctx context.Context, in *sentryproto.BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanPeer indicates an expected call of BanPeer.
+func (mr *MockSentryClientMockRecorder) BanPeer(ctx, in any, opts ...any) *MockSentryClientBanPeerCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPeer", reflect.TypeOf((*MockSentryClient)(nil).BanPeer), varargs...)
+	return &MockSentryClientBanPeerCall{Call: call}
+}
+
+// MockSentryClientBanPeerCall wrap *gomock.Call
+type MockSentryClientBanPeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryClientBanPeerCall) Return(arg0 *emptypb.Empty, arg1 error) *MockSentryClientBanPeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryClientBanPeerCall) Do(f func(context.Context, *sentryproto.BanPeerRequest, ...grpc.CallOption) (*emptypb.Empty, error)) *MockSentryClientBanPeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryClientBanPeerCall) DoAndReturn(f func(context.Context, *sentryproto.BanPeerRequest, ...grpc.CallOption) (*emptypb.Empty, error)) *MockSentryClientBanPeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BannedPeers mocks base method.
+func (m *MockSentryClient) BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.BannedPeersReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BannedPeers", varargs...)
+	ret0, _ := ret[0].(*sentryproto.BannedPeersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BannedPeers indicates an expected call of BannedPeers.
+func (mr *MockSentryClientMockRecorder) BannedPeers(ctx, in any, opts ...any) *MockSentryClientBannedPeersCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BannedPeers", reflect.TypeOf((*MockSentryClient)(nil).BannedPeers), varargs...)
+	return &MockSentryClientBannedPeersCall{Call: call}
+}
+
+// MockSentryClientBannedPeersCall wrap *gomock.Call
+type MockSentryClientBannedPeersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryClientBannedPeersCall) Return(arg0 *sentryproto.BannedPeersReply, arg1 error) *MockSentryClientBannedPeersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryClientBannedPeersCall) Do(f func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*sentryproto.BannedPeersReply, error)) *MockSentryClientBannedPeersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryClientBannedPeersCall) DoAndReturn(f func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*sentryproto.BannedPeersReply, error)) *MockSentryClientBannedPeersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // HandShake mocks base method.
 func (m *MockSentryClient) HandShake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.HandShakeReply, error) {
 	m.ctrl.T.Helper()
@@ -432,6 +520,50 @@ func (c *MockSentryClientPeerMinBlockCall) DoAndReturn(f func(context.Context, *
 	return c
 }
 
+// PeerStats mocks base method.
+func (m *MockSentryClient) PeerStats(ctx context.Context, in *sentryproto.PeerStatsRequest, opts ...grpc.CallOption) (*sentryproto.PeerStatsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PeerStats", varargs...)
+	ret0, _ := ret[0].(*sentryproto.PeerStatsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerStats indicates an expected call of PeerStats.
+func (mr *MockSentryClientMockRecorder) PeerStats(ctx, in any, opts ...any) *MockSentryClientPeerStatsCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerStats", reflect.TypeOf((*MockSentryClient)(nil).PeerStats), varargs...)
+	return &MockSentryClientPeerStatsCall{Call: call}
+}
+
+// MockSentryClientPeerStatsCall wrap *gomock.Call
+type MockSentryClientPeerStatsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryClientPeerStatsCall) Return(arg0 *sentryproto.PeerStatsReply, arg1 error) *MockSentryClientPeerStatsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryClientPeerStatsCall) Do(f func(context.Context, *sentryproto.PeerStatsRequest, ...grpc.CallOption) (*sentryproto.PeerStatsReply, error)) *MockSentryClientPeerStatsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryClientPeerStatsCall) DoAndReturn(f func(context.Context, *sentryproto.PeerStatsRequest, ...grpc.CallOption) (*sentryproto.PeerStatsReply, error)) *MockSentryClientPeerStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Peers mocks base method.
 func (m *MockSentryClient) Peers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.PeersReply, error) {
 	m.ctrl.T.Helper()
@@ -815,3 +947,47 @@ func (c *MockSentryClientSetStatusCall) DoAndReturn(f func(context.Context, *sen
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// UnbanPeer mocks base method.
+func (m *MockSentryClient) UnbanPeer(ctx context.Context, in *sentryproto.UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnbanPeer", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanPeer indicates an expected call of UnbanPeer.
+func (mr *MockSentryClientMockRecorder) UnbanPeer(ctx, in any, opts ...any) *MockSentryClientUnbanPeerCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPeer", reflect.TypeOf((*MockSentryClient)(nil).UnbanPeer), varargs...)
+	return &MockSentryClientUnbanPeerCall{Call: call}
+}
+
+// MockSentryClientUnbanPeerCall wrap *gomock.Call
+type MockSentryClientUnbanPeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryClientUnbanPeerCall) Return(arg0 *emptypb.Empty, arg1 error) *MockSentryClientUnbanPeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryClientUnbanPeerCall) Do(f func(context.Context, *sentryproto.UnbanPeerRequest, ...grpc.CallOption) (*emptypb.Empty, error)) *MockSentryClientUnbanPeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryClientUnbanPeerCall) DoAndReturn(f func(context.Context, *sentryproto.UnbanPeerRequest, ...grpc.CallOption) (*emptypb.Empty, error)) *MockSentryClientUnbanPeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -55,6 +55,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/diagnostics"
 	"github.com/erigontech/erigon-lib/downloader/downloadercfg"
 	"github.com/erigontech/erigon-lib/downloader/downloaderrawdb"
@@ -223,6 +224,7 @@ func (r *requestHandler) RoundTrip(req *http.Request) (resp *http.Response, err
 	}()
 
 	insertCloudflareHeaders(req)
+	r.downloader.cfg.WebseedAuth.Apply(req)
 
 	resp, err = r.Transport.RoundTrip(req)
 
@@ -343,7 +345,7 @@ func New(ctx context.Context, cfg *downloadercfg.Cfg, logger log.Logger, verbosi
 		torrentClient:       torrentClient,
 		lock:                mutex,
 		stats:               stats,
-		webseeds:            NewWebSeeds(cfg.WebSeedUrls, verbosity, logger),
+		webseeds:            NewWebSeeds(cfg.WebSeedUrls, cfg.WebseedAuth, verbosity, logger),
 		logger:              logger,
 		verbosity:           verbosity,
 		torrentFS:           &AtomicTorrentFS{dir: cfg.Dirs.Snap},
@@ -799,6 +801,13 @@ func (d *Downloader) MainLoopInBackground(silent bool) {
 			}
 		}
 	}()
+	if d.cfg.ScrubRate > 0 {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.scrubLoop()
+		}()
+	}
 }
 
 type downloadStatus struct {
@@ -886,6 +895,7 @@ func (d *Downloader) mainLoop(silent bool) error {
 		checkGroup.SetLimit(runtime.GOMAXPROCS(-1) * 4)
 
 		lastIntMult := time.Now()
+		pausedOnLowSpace := false
 
 		for {
 			torrents := d.torrentClient.Torrents()
@@ -1101,7 +1111,12 @@ func (d *Downloader) mainLoop(silent bool) error {
 			webDownloadInfoLen := len(d.webDownloadInfo)
 			d.lock.RUnlock()
 
-			if len(pending)+webDownloadInfoLen == 0 {
+			if paused := disk.IsSpaceLow(); paused != pausedOnLowSpace {
+				pausedOnLowSpace = paused
+				d.allowDataDownload(!paused)
+			}
+
+			if len(pending)+webDownloadInfoLen == 0 || pausedOnLowSpace {
 				select {
 				case <-d.ctx.Done():
 					return
@@ -1601,6 +1616,24 @@ func (d *Downloader) torrentDownload(t *torrent.Torrent, statusChan chan downloa
 	}(t)
 }
 
+// allowDataDownload - pauses/resumes data download of all downloading torrents. Seeding continues.
+func (d *Downloader) allowDataDownload(allow bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	for _, info := range d.downloading {
+		if allow {
+			info.torrent.AllowDataDownload()
+		} else {
+			info.torrent.DisallowDataDownload()
+		}
+	}
+	if allow {
+		d.logger.Info("[snapshots] downloads resumed", "files", len(d.downloading))
+	} else {
+		d.logger.Warn("[snapshots] downloads paused: free disk space is low", "files", len(d.downloading))
+	}
+}
+
 func (d *Downloader) webDownload(peerUrls []*url.URL, t *torrent.Torrent, i *webDownloadInfo, statusChan chan downloadStatus) (*RCloneSession, error) {
 	if d.webDownloadClient == nil {
 		return nil, errors.New("webdownload client not enabled")
@@ -32,6 +32,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
 	prototypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+	"github.com/erigontech/erigon-lib/kv/audit"
 	"github.com/erigontech/erigon-lib/log/v3"
 )
 
@@ -103,8 +104,11 @@ func (s *GrpcServer) Add(ctx context.Context, request *proto_downloader.AddReque
 }
 
 // Delete - stop seeding, remove file, remove .torrent
-func (s *GrpcServer) Delete(ctx context.Context, request *proto_downloader.DeleteRequest) (*emptypb.Empty, error) {
+func (s *GrpcServer) Delete(ctx context.Context, request *proto_downloader.DeleteRequest) (_ *emptypb.Empty, err error) {
 	defer s.d.ReCalcStats(10 * time.Second) // immediately call ReCalc to set stat.Complete flag
+	defer func() {
+		audit.Record(ctx, s.d.db, audit.OpDownloaderDelete, map[string]any{"paths": request.Paths}, err, s.d.logger)
+	}()
 	torrents := s.d.torrentClient.Torrents()
 	for _, name := range request.Paths {
 		if name == "" {
@@ -155,6 +159,24 @@ func (s *GrpcServer) Completed(ctx context.Context, request *proto_downloader.Co
 	return &proto_downloader.CompletedReply{Completed: s.d.Completed()}, nil
 }
 
+// Transport - transports of torrent client, as configured by flags: --torrent.disable.utp, --torrent.dht, ...
+func (s *GrpcServer) Transport(ctx context.Context, request *proto_downloader.TransportRequest) (*proto_downloader.TransportReply, error) {
+	cfg := s.d.cfg.ClientConfig
+	reply := &proto_downloader.TransportReply{
+		Tcp:          !cfg.DisableTCP,
+		Utp:          !cfg.DisableUTP,
+		Ipv4:         !cfg.DisableIPv4,
+		Ipv6:         !cfg.DisableIPv6,
+		Pex:          !cfg.DisablePEX,
+		Dht:          !cfg.NoDHT,
+		DhtBootstrap: s.d.cfg.DHTBootstrap,
+	}
+	for _, addr := range s.d.torrentClient.ListenAddrs() {
+		reply.ListenAddrs = append(reply.ListenAddrs, addr.Network()+"://"+addr.String())
+	}
+	return reply, nil
+}
+
 func (s *GrpcServer) TorrentCompleted(req *proto_downloader.TorrentCompletedRequest, stream proto_downloader.Downloader_TorrentCompletedServer) error {
 	// Register the new subscriber
 	s.mu.Lock()
@@ -26,6 +26,7 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/erigontech/erigon-lib/chain/networkname"
@@ -58,6 +59,9 @@ type Cfg struct {
 
 	WebSeedUrls                     []*url.URL
 	WebSeedFileProviders            []string
+	WebseedAuth                     WebseedAuth       // credentials of private webseeds
+	DHTBootstrap                    []string          // host:port of dht bootstrap nodes, if not default
+	ScrubRate                       datasize.ByteSize // read rate (per second) of background re-hashing of completed files, 0 - off
 	SnapshotConfig                  *snapcfg.Cfg
 	DownloadTorrentFilesFromWebseed bool
 	AddTorrentsFromDisk             bool
@@ -110,6 +114,27 @@ func Default() *torrent.ClientConfig {
 	return torrentConfig
 }
 
+var (
+	rateLimitersLock sync.Mutex
+	rateLimiters     = map[string]*rate.Limiter{}
+)
+
+// sharedRateLimiter - downloaders of all chains of the process (--chains.config) share the bandwidth limit
+func sharedRateLimiter(direction string, r datasize.ByteSize) *rate.Limiter {
+	rateLimitersLock.Lock()
+	defer rateLimitersLock.Unlock()
+	key := fmt.Sprintf("%s/%d", direction, r)
+	if l, ok := rateLimiters[key]; ok {
+		return l
+	}
+	l := rate.NewLimiter(rate.Limit(r.Bytes()), DefaultNetworkChunkSize)
+	if r > 512*datasize.MB {
+		l = rate.NewLimiter(rate.Inf, DefaultNetworkChunkSize) // default: unlimited
+	}
+	rateLimiters[key] = l
+	return l
+}
+
 func New(ctx context.Context, dirs datadir.Dirs, version string, verbosity lg.Level, downloadRate, uploadRate datasize.ByteSize, port, connsPerFile, downloadSlots int, staticPeers, webseeds []string, chainName string, lockSnapshots, mdbxWriteMap bool) (*Cfg, error) {
 	torrentConfig := Default()
 	//torrentConfig.PieceHashersPerTorrent = runtime.NumCPU()
@@ -124,17 +149,8 @@ func New(ctx context.Context, dirs datadir.Dirs, version string, verbosity lg.Le
 	// check if ipv6 is enabled
 	torrentConfig.DisableIPv6 = !getIpv6Enabled()
 
-	if uploadRate > 512*datasize.MB {
-		torrentConfig.UploadRateLimiter = rate.NewLimiter(rate.Inf, DefaultNetworkChunkSize) // default: unlimited
-	} else {
-		torrentConfig.UploadRateLimiter = rate.NewLimiter(rate.Limit(uploadRate.Bytes()), DefaultNetworkChunkSize) // default: unlimited
-	}
-
-	if downloadRate > 512*datasize.MB {
-		torrentConfig.DownloadRateLimiter = rate.NewLimiter(rate.Inf, DefaultNetworkChunkSize) // default: unlimited
-	} else {
-		torrentConfig.DownloadRateLimiter = rate.NewLimiter(rate.Limit(downloadRate.Bytes()), DefaultNetworkChunkSize) // default: unlimited
-	}
+	torrentConfig.UploadRateLimiter = sharedRateLimiter("upload", uploadRate)
+	torrentConfig.DownloadRateLimiter = sharedRateLimiter("download", downloadRate)
 
 	// debug
 	//torrentConfig.Debug = true
@@ -0,0 +1,59 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloadercfg
+
+import (
+	"errors"
+
+	"github.com/anacrolix/dht/v2"
+)
+
+// Transport - network transports of torrent client. Some datacenters block UDP: seeding there must be TCP-only,
+// with uTP and DHT (both UDP) disabled - which is default for DHT.
+type Transport struct {
+	DisableUTP  bool
+	DisableIPv4 bool
+	DisableIPv6 bool
+	DisablePEX  bool
+
+	DHT          bool
+	DHTBootstrap []string // host:port, implies DHT. Default: global bootstrap nodes
+}
+
+// SetTransport - applies t to ClientConfig. IPv6 stays disabled if it's not available on the host.
+func (c *Cfg) SetTransport(t Transport) error {
+	if t.DisableIPv4 && t.DisableIPv6 {
+		return errors.New("torrent transport: both ipv4 and ipv6 are disabled")
+	}
+	c.ClientConfig.DisableUTP = t.DisableUTP
+	c.ClientConfig.DisableIPv4 = t.DisableIPv4
+	c.ClientConfig.DisableIPv6 = c.ClientConfig.DisableIPv6 || t.DisableIPv6
+	c.ClientConfig.DisablePEX = t.DisablePEX
+
+	if !t.DHT && len(t.DHTBootstrap) == 0 {
+		return nil
+	}
+	c.ClientConfig.NoDHT = false
+	if len(t.DHTBootstrap) > 0 {
+		c.DHTBootstrap = t.DHTBootstrap
+		bootstrap := t.DHTBootstrap
+		c.ClientConfig.DhtStartingNodes = func(network string) dht.StartingNodesGetter {
+			return func() ([]dht.Addr, error) { return dht.ResolveHostPorts(bootstrap) }
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloadercfg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetTransport(t *testing.T) {
+	cfg := &Cfg{ClientConfig: Default()}
+	require.NoError(t, cfg.SetTransport(Transport{DisableUTP: true, DisablePEX: true}))
+	require.True(t, cfg.ClientConfig.DisableUTP)
+	require.True(t, cfg.ClientConfig.DisablePEX)
+	require.False(t, cfg.ClientConfig.DisableTCP)
+	require.True(t, cfg.ClientConfig.NoDHT) // tcp-only: dht is udp
+
+	cfg = &Cfg{ClientConfig: Default()}
+	cfg.ClientConfig.DisableIPv6 = true // not available on host
+	require.NoError(t, cfg.SetTransport(Transport{DHTBootstrap: []string{"127.0.0.1:6881"}}))
+	require.True(t, cfg.ClientConfig.DisableIPv6)
+	require.False(t, cfg.ClientConfig.NoDHT)
+	require.Equal(t, []string{"127.0.0.1:6881"}, cfg.DHTBootstrap)
+	addrs, err := cfg.ClientConfig.DhtStartingNodes("udp")()
+	require.NoError(t, err)
+	require.Len(t, addrs, 1)
+
+	require.Error(t, (&Cfg{ClientConfig: Default()}).SetTransport(Transport{DisableIPv4: true, DisableIPv6: true}))
+}
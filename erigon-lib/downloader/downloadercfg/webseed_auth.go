@@ -0,0 +1,92 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloadercfg
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// WebseedAuth - credentials of private webseeds (e.g. internal object storage), by URL prefix. Requests to
+// other URLs don't carry them, Go's http client also drops them on redirects to other hosts.
+// Signed URLs (S3 pre-signed, Azure SAS, ...) need no entry: query of a webseed URL is kept on all its files.
+type WebseedAuth []webseedAuthRule
+
+type webseedAuthRule struct {
+	scheme, host, path string
+	authorization      string
+}
+
+// ParseWebseedAuth - entries of --webseed.auth: <url-prefix>=<token> or <url-prefix>=env:<VAR>. Token is sent
+// as `Authorization: Bearer <token>`, unless it already has a scheme: `Basic ...`, `Bearer ...`.
+func ParseWebseedAuth(entries []string) (WebseedAuth, error) {
+	auth := make(WebseedAuth, 0, len(entries))
+	for _, entry := range entries {
+		prefix, token, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("webseed auth: expected <url-prefix>=<token>, got %q", prefix)
+		}
+		u, err := url.Parse(prefix)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("webseed auth: invalid url prefix %q", prefix)
+		}
+		if env, ok := strings.CutPrefix(token, "env:"); ok {
+			if token, ok = os.LookupEnv(env); !ok {
+				return nil, fmt.Errorf("webseed auth of %s: env variable %s is not set", prefix, env)
+			}
+		}
+		token = strings.TrimSpace(token)
+		if token == "" {
+			return nil, fmt.Errorf("webseed auth of %s: empty token", prefix)
+		}
+		if !strings.HasPrefix(token, "Bearer ") && !strings.HasPrefix(token, "Basic ") {
+			token = "Bearer " + token
+		}
+		auth = append(auth, webseedAuthRule{scheme: u.Scheme, host: u.Host, path: u.Path, authorization: token})
+	}
+	return auth, nil
+}
+
+// Apply - sets Authorization of the rule with longest prefix matching req's URL, if any
+func (a WebseedAuth) Apply(req *http.Request) {
+	var best *webseedAuthRule
+	for i := range a {
+		r := &a[i]
+		if r.scheme != req.URL.Scheme || r.host != req.URL.Host || !strings.HasPrefix(req.URL.Path, r.path) {
+			continue
+		}
+		if best == nil || len(r.path) > len(best.path) {
+			best = r
+		}
+	}
+	if best != nil {
+		req.Header.Set("Authorization", best.authorization)
+	}
+}
+
+// RedactURL - url for logs: without query and user info, which carry credentials of signed and private webseeds
+func RedactURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	if redacted.RawQuery != "" {
+		redacted.RawQuery = "REDACTED"
+	}
+	return redacted.String()
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/anacrolix/torrent/metainfo"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+// PublishManifestFile - signed manifest uploaded next to manifest.txt by `erigon snapshots publish`
+const PublishManifestFile = "manifest.json"
+
+// PublishManifest - files a chain operator publishes on its webseed, with infohashes of their .torrent files.
+// Lets chains not maintained by the Erigon team run own snapshots infrastructure: clients pin the operator's
+// address instead of preverified.toml
+type PublishManifest struct {
+	Chain   string          `json:"chain"`
+	Created int64           `json:"created"`
+	Files   []PublishedFile `json:"files"`
+}
+
+type PublishedFile struct {
+	Name     string `json:"name"`
+	InfoHash string `json:"infoHash"`
+	Length   int64  `json:"length"`
+}
+
+// SignedPublishManifest - Manifest is kept as signed bytes, so verification doesn't depend on re-encoding
+type SignedPublishManifest struct {
+	Manifest  json.RawMessage `json:"manifest"`
+	Signer    common.Address  `json:"signer"`
+	Signature hexutil.Bytes   `json:"signature"`
+}
+
+// NewPublishManifest - manifest of given files, their .torrent files must exist in torrentFiles
+func NewPublishManifest(torrentFiles *AtomicTorrentFS, chain string, created int64, names []string) (*PublishManifest, error) {
+	m := &PublishManifest{Chain: chain, Created: created, Files: make([]PublishedFile, 0, len(names))}
+	for _, name := range names {
+		mi, err := metainfo.LoadFromFile(filepath.Join(torrentFiles.dir, strings.TrimSuffix(name, ".torrent")+".torrent"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		info, err := mi.UnmarshalInfo()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		m.Files = append(m.Files, PublishedFile{Name: name, InfoHash: mi.HashInfoBytes().HexString(), Length: info.TotalLength()})
+	}
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Name < m.Files[j].Name })
+	return m, nil
+}
+
+// Sign - JSON of the signed manifest, the signature is over keccak256 of the manifest bytes
+func (m *PublishManifest) Sign(key *ecdsa.PrivateKey) ([]byte, error) {
+	manifest, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.Sign(crypto.Keccak256(manifest), key)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(SignedPublishManifest{Manifest: manifest, Signer: crypto.PubkeyToAddress(key.PublicKey), Signature: sig})
+}
+
+var ErrPublishManifestSigner = errors.New("manifest is not signed by the expected publisher")
+
+// VerifyPublishManifest - manifest of data, if it's signed by signer
+func VerifyPublishManifest(data []byte, signer common.Address) (*PublishManifest, error) {
+	var signed SignedPublishManifest
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(crypto.Keccak256(signed.Manifest), signed.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if got := crypto.PubkeyToAddress(*pub); got != signer {
+		return nil, fmt.Errorf("%w: signed by %x, expected %x", ErrPublishManifestSigner, got, signer)
+	}
+	var m PublishManifest
+	if err := json.Unmarshal(signed.Manifest, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
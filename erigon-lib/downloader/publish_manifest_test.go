@@ -0,0 +1,70 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+func TestPublishManifest(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	tf := NewAtomicTorrentFS(dirs.Snap)
+
+	for _, name := range []string{"v1.0-000000-000500-headers.seg", "v1.0-000000-000500-bodies.seg"} {
+		require.NoError(os.WriteFile(filepath.Join(dirs.Snap, name), bytes.Repeat([]byte{1}, 1024), 0644))
+		created, err := BuildTorrentIfNeed(context.Background(), name, dirs.Snap, tf)
+		require.NoError(err)
+		require.True(created)
+	}
+
+	m, err := NewPublishManifest(tf, "mychain", 1, []string{"v1.0-000000-000500-headers.seg", "v1.0-000000-000500-bodies.seg"})
+	require.NoError(err)
+	require.Len(m.Files, 2)
+	require.Equal("v1.0-000000-000500-bodies.seg", m.Files[0].Name)
+	require.Equal(int64(1024), m.Files[0].Length)
+	spec, err := tf.LoadByName(m.Files[0].Name)
+	require.NoError(err)
+	require.Equal(spec.InfoHash.HexString(), m.Files[0].InfoHash)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	signed, err := m.Sign(key)
+	require.NoError(err)
+
+	got, err := VerifyPublishManifest(signed, crypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(err)
+	require.Equal(m, got)
+
+	other, err := crypto.GenerateKey()
+	require.NoError(err)
+	_, err = VerifyPublishManifest(signed, crypto.PubkeyToAddress(other.PublicKey))
+	require.ErrorIs(err, ErrPublishManifestSigner)
+
+	tampered := bytes.Replace(signed, []byte("mychain"), []byte("hischain"), 1)
+	_, err = VerifyPublishManifest(tampered, crypto.PubkeyToAddress(key.PublicKey))
+	require.Error(err)
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/torrent"
+	"github.com/anacrolix/torrent/metainfo"
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon-lib/downloader/downloadercfg"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	scrubPassPause    = time.Hour // pause between passes of scrubber over all files
+	scrubMaxCorrupted = 100       // corrupted files kept in diagnostics
+)
+
+var (
+	scrubBytes          = metrics.GetOrCreateCounter("downloader_scrub_bytes")
+	scrubCorruptedFiles = metrics.GetOrCreateCounter("downloader_scrub_corrupted_files")
+)
+
+// scrubLoop - defence against silent disk corruption of frozen files. Continuously re-hashes pieces of completed
+// files, which torrent matches the manifest (snapshot-lock.json), reading not faster than `ScrubRate`.
+// Corrupted pieces are marked incomplete and file goes back to download: only they will be re-downloaded.
+func (d *Downloader) scrubLoop() {
+	limiter := rate.NewLimiter(rate.Limit(d.cfg.ScrubRate), int(downloadercfg.DefaultPieceSize))
+	stats := diagnostics.SnapshotScrubStatistics{}
+	for {
+		toScrub := d.filesToScrub()
+		if len(toScrub) == 0 { // nothing downloaded yet
+			select {
+			case <-d.ctx.Done():
+				return
+			case <-time.After(time.Minute):
+				continue
+			}
+		}
+
+		startTime, corrupted := time.Now(), 0
+		stats.Pass++
+		stats.FilesTotal, stats.FilesChecked, stats.BytesChecked = len(toScrub), 0, 0
+		for _, t := range toScrub {
+			bad, err := scrubFile(d.ctx, t.Info(), d.SnapDir(), limiter)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				// file may be removed by merge or retire
+				d.logger.Debug("[snapshots] scrub", "file", t.Name(), "err", err)
+				continue
+			}
+			stats.FilesChecked++
+			stats.BytesChecked += uint64(t.Info().TotalLength())
+			if len(bad) > 0 {
+				corrupted++
+				scrubCorruptedFiles.Inc()
+				stats.Corrupted = append(stats.Corrupted, diagnostics.SnapshotScrubCorruption{FileName: t.Name(), BadPieces: len(bad), Pass: stats.Pass, Time: time.Now()})
+				if len(stats.Corrupted) > scrubMaxCorrupted {
+					stats.Corrupted = slices.Clone(stats.Corrupted[len(stats.Corrupted)-scrubMaxCorrupted:])
+				}
+				d.logger.Warn("[snapshots] scrub: file corrupted on disk, scheduled re-download", "file", t.Name(), "badPieces", len(bad), "totalPieces", t.NumPieces())
+				if err := d.redownloadPieces(t, bad); err != nil {
+					d.logger.Warn("[snapshots] scrub: can't schedule re-download", "file", t.Name(), "err", err)
+				}
+			}
+			stats.TimeElapsed = time.Since(startTime).Round(time.Second).Seconds()
+			upd := stats
+			upd.Corrupted = slices.Clone(stats.Corrupted)
+			diagnostics.Send(upd)
+		}
+		d.logger.Info("[snapshots] scrub pass done", "files", stats.FilesChecked, "size", common.ByteCount(stats.BytesChecked), "corrupted", corrupted, "took", time.Since(startTime).Round(time.Second))
+
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-time.After(scrubPassPause):
+		}
+	}
+}
+
+// filesToScrub - completed files of the manifest, which have same infohash as in the manifest. Files with local
+// overrides (other hash) are not checked: their correct content is unknown
+func (d *Downloader) filesToScrub() []*torrent.Torrent {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	var res []*torrent.Torrent
+	for _, t := range d.torrentClient.Torrents() {
+		if t.Info() == nil {
+			continue
+		}
+		if _, ok := d.completedTorrents[t.Name()]; !ok {
+			continue
+		}
+		item, ok := d.snapshotLock.Downloads.Get(t.Name())
+		if !ok || item.Hash != t.InfoHash().HexString() {
+			continue
+		}
+		res = append(res, t)
+	}
+	slices.SortFunc(res, func(a, b *torrent.Torrent) int { return strings.Compare(a.Name(), b.Name()) })
+	return res
+}
+
+// redownloadPieces - marks corrupted pieces incomplete (by re-verification in torrent lib) and returns file to
+// download: main loop will download missing pieces from peers and webseeds and complete file again
+func (d *Downloader) redownloadPieces(t *torrent.Torrent, bad []int) error {
+	if err := d.db.Update(d.ctx, torrentInfoReset(t.Name(), t.InfoHash().Bytes(), t.Info().TotalLength())); err != nil {
+		return fmt.Errorf("reset torrent info: %w", err)
+	}
+	d.lock.Lock()
+	delete(d.completedTorrents, t.Name())
+	d.lock.Unlock()
+	for _, i := range bad {
+		t.Piece(i).VerifyData()
+	}
+	return nil
+}
+
+// scrubFile - returns indices of pieces which don't match hashes of `info`
+func scrubFile(ctx context.Context, info *metainfo.Info, root string, limiter *rate.Limiter) (bad []int, err error) {
+	file := info.UpvertedFiles()[0]
+	f, err := os.Open(filepath.Join(append([]string{root, info.Name}, file.Path...)...))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Bittorrent v1 using `sha1`
+	hasher := sha1.New() //nolint:gosec
+	for i := 0; i < info.NumPieces(); i++ {
+		p := info.Piece(i)
+		for left := p.Length(); left > 0; left -= int64(limiter.Burst()) {
+			if err := limiter.WaitN(ctx, int(min(left, int64(limiter.Burst())))); err != nil {
+				return nil, err
+			}
+		}
+		hasher.Reset()
+		if _, err := io.Copy(hasher, io.NewSectionReader(f, p.Offset(), p.Length())); err != nil {
+			return nil, err
+		}
+		scrubBytes.AddUint64(uint64(p.Length()))
+		if !bytes.Equal(hasher.Sum(nil), p.Hash().Bytes()) {
+			bad = append(bad, i)
+		}
+	}
+	return bad, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package downloader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+func TestScrubFile(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	fPath := filepath.Join(dir, "v1.0-000000-000500-headers.seg")
+
+	const pieceLen = 16 * 1024
+	data := make([]byte, 5*pieceLen+100)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	require.NoError(os.WriteFile(fPath, data, 0644))
+	info := &metainfo.Info{PieceLength: pieceLen, Name: filepath.Base(fPath)}
+	require.NoError(info.BuildFromFilePath(fPath))
+
+	limiter := rate.NewLimiter(rate.Inf, pieceLen)
+	bad, err := scrubFile(context.Background(), info, dir, limiter)
+	require.NoError(err)
+	require.Empty(bad)
+
+	// bit rot in 2nd and last pieces
+	data[pieceLen+1] ^= 1
+	data[len(data)-1] ^= 0x80
+	require.NoError(os.WriteFile(fPath, data, 0644))
+	bad, err = scrubFile(context.Background(), info, dir, limiter)
+	require.NoError(err)
+	require.Equal([]int{1, 5}, bad)
+
+	// reading is limited by rate
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = scrubFile(ctx, info, dir, rate.NewLimiter(1024, pieceLen))
+	require.ErrorIs(err, context.Canceled)
+
+	require.NoError(os.Remove(fPath))
+	_, err = scrubFile(context.Background(), info, dir, limiter)
+	require.ErrorIs(err, os.ErrNotExist)
+}
@@ -334,9 +334,9 @@ type Enums struct {
 
 const MinCoreEnum = 1
 const MinBorEnum = 5
-const MinCaplinEnum = 9
+const MinCaplinEnum = 10
 
-const MaxEnum = 12
+const MaxEnum = 13
 
 var CaplinEnums = struct {
 	Enums
@@ -60,11 +60,13 @@ type WebSeeds struct {
 
 	torrentFiles *AtomicTorrentFS
 	client       *http.Client
+	auth         downloadercfg.WebseedAuth
 }
 
-func NewWebSeeds(seeds []*url.URL, verbosity log.Lvl, logger log.Logger) *WebSeeds {
+func NewWebSeeds(seeds []*url.URL, auth downloadercfg.WebseedAuth, verbosity log.Lvl, logger log.Logger) *WebSeeds {
 	ws := &WebSeeds{
 		seeds:     seeds,
+		auth:      auth,
 		logger:    logger,
 		verbosity: verbosity,
 	}
@@ -76,6 +78,11 @@ func NewWebSeeds(seeds []*url.URL, verbosity log.Lvl, logger log.Logger) *WebSee
 	return ws
 }
 
+func (d *WebSeeds) insertHeaders(req *http.Request) {
+	insertCloudflareHeaders(req)
+	d.auth.Apply(req)
+}
+
 func (d *WebSeeds) getWebDownloadInfo(ctx context.Context, t *torrent.Torrent) (infos []webDownloadInfo, seedHashMismatches []*seedHash, err error) {
 	torrentHash := t.InfoHash().Bytes()
 
@@ -83,7 +90,7 @@ func (d *WebSeeds) getWebDownloadInfo(ctx context.Context, t *torrent.Torrent) (
 		downloadUrl := webseed.JoinPath(t.Name())
 
 		if headRequest, err := http.NewRequestWithContext(ctx, http.MethodHead, downloadUrl.String(), nil); err == nil {
-			insertCloudflareHeaders(headRequest)
+			d.insertHeaders(headRequest)
 
 			headResponse, err := d.client.Do(headRequest)
 			if err != nil {
@@ -93,7 +100,7 @@ func (d *WebSeeds) getWebDownloadInfo(ctx context.Context, t *torrent.Torrent) (
 
 			if headResponse.StatusCode != http.StatusOK {
 				d.logger.Trace("[snapshots.webseed] getWebDownloadInfo: HEAD request failed",
-					"webseed", webseed.String(), "name", t.Name(), "status", headResponse.Status)
+					"webseed", downloadercfg.RedactURL(webseed), "name", t.Name(), "status", headResponse.Status)
 				continue
 			}
 			if meta, err := getWebpeerTorrentInfo(ctx, downloadUrl); err == nil {
@@ -172,7 +179,7 @@ func (d *WebSeeds) VerifyManifestedBuckets(ctx context.Context, failFast bool) e
 			return ctx.Err()
 		default:
 		}
-		d.logger.Debug("[snapshots.webseed] verify manifest", "url", webSeedProviderURL.String())
+		d.logger.Debug("[snapshots.webseed] verify manifest", "url", downloadercfg.RedactURL(webSeedProviderURL))
 
 		rep, err := d.VerifyManifestedBucket(ctx, webSeedProviderURL)
 		if err != nil {
@@ -268,7 +275,7 @@ func (w *WebSeedCheckReport) ToString(full bool) string {
 			b.WriteByte(10)
 		}
 	}
-	b.WriteString(fmt.Sprintf("SEED [%s] %s\n", br, w.seed.String()))
+	b.WriteString(fmt.Sprintf("SEED [%s] %s\n", br, downloadercfg.RedactURL(w.seed)))
 	return b.String()
 }
 
@@ -306,7 +313,7 @@ func (d *WebSeeds) constructListsOfFiles(ctx context.Context, httpProviders []*u
 
 		manifestResponse, err := d.retrieveManifest(ctx, webSeedProviderURL)
 		if err != nil { // don't fail on error
-			d.logger.Debug("[snapshots.webseed] get from HTTP provider", "err", err, "url", webSeedProviderURL.String())
+			d.logger.Debug("[snapshots.webseed] get from HTTP provider", "err", err, "url", downloadercfg.RedactURL(webSeedProviderURL))
 			continue
 		}
 		listsOfFiles = append(listsOfFiles, manifestResponse)
@@ -398,10 +405,10 @@ func (d *WebSeeds) retrieveManifest(ctx context.Context, webSeedProviderUrl *url
 		if err != nil {
 			return nil, err
 		}
-		insertCloudflareHeaders(request)
+		d.insertHeaders(request)
 		resp, err := d.client.Do(request)
 		if err != nil {
-			return nil, fmt.Errorf("webseed.http: make request: %w, url=%s", err, u.String())
+			return nil, fmt.Errorf("webseed.http: make request: %w, url=%s", err, downloadercfg.RedactURL(u))
 		}
 		resp.Body.Close()
 	}
@@ -411,22 +418,22 @@ func (d *WebSeeds) retrieveManifest(ctx context.Context, webSeedProviderUrl *url
 		return nil, err
 	}
 
-	insertCloudflareHeaders(request)
+	d.insertHeaders(request)
 
 	resp, err := d.client.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("webseed.http: make request: %w, url=%s", err, u.String())
+		return nil, fmt.Errorf("webseed.http: make request: %w, url=%s", err, downloadercfg.RedactURL(u))
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		d.logger.Debug("[snapshots.webseed] /manifest.txt retrieval failed, no downloads from this webseed",
-			"webseed", webSeedProviderUrl.String(), "status", resp.Status)
-		return nil, fmt.Errorf("webseed.http: status=%d, url=%s", resp.StatusCode, u.String())
+			"webseed", downloadercfg.RedactURL(webSeedProviderUrl), "status", resp.Status)
+		return nil, fmt.Errorf("webseed.http: status=%d, url=%s", resp.StatusCode, downloadercfg.RedactURL(u))
 	}
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("webseed.http: read: %w, url=%s, ", err, u.String())
+		return nil, fmt.Errorf("webseed.http: read: %w, url=%s, ", err, downloadercfg.RedactURL(u))
 	}
 
 	response := snaptype.WebSeedsFromProvider{}
@@ -436,7 +443,7 @@ func (d *WebSeeds) retrieveManifest(ctx context.Context, webSeedProviderUrl *url
 		switch trimmed {
 		case "":
 			if fi != len(fileNames)-1 {
-				d.logger.Debug("[snapshots.webseed] empty line in manifest.txt", "webseed", webSeedProviderUrl.String(), "lineNum", fi)
+				d.logger.Debug("[snapshots.webseed] empty line in manifest.txt", "webseed", downloadercfg.RedactURL(webSeedProviderUrl), "lineNum", fi)
 			}
 			continue
 		case "manifest.txt", "node.txt":
@@ -446,7 +453,7 @@ func (d *WebSeeds) retrieveManifest(ctx context.Context, webSeedProviderUrl *url
 		}
 	}
 
-	d.logger.Debug("[snapshots.webseed] get from HTTP provider", "manifest-len", len(response), "url", webSeedProviderUrl.String())
+	d.logger.Debug("[snapshots.webseed] get from HTTP provider", "manifest-len", len(response), "url", downloadercfg.RedactURL(webSeedProviderUrl))
 	return response, nil
 }
 
@@ -517,7 +524,7 @@ func (d *WebSeeds) downloadTorrentFilesFromProviders(ctx context.Context, rootDi
 				//validation happens inside
 				_, err := d.callTorrentHttpProvider(ctx, url, name)
 				if err != nil {
-					d.logger.Debug("[snapshots] got from webseed", "name", name, "err", err, "url", url)
+					d.logger.Debug("[snapshots] got from webseed", "name", name, "err", err, "url", downloadercfg.RedactURL(url))
 					continue
 				}
 				//don't save .torrent here - do it inside downloader.Add
@@ -541,15 +548,15 @@ func (d *WebSeeds) DownloadAndSaveTorrentFile(ctx context.Context, name string)
 		return nil, false, nil
 	}
 	for _, urlStr := range urls {
-		urlStr += ".torrent"
 		parsedUrl, err := url.Parse(urlStr)
 		if err != nil {
 			d.logger.Log(d.verbosity, "[snapshots] callTorrentHttpProvider parse url", "err", err)
 			continue // it's ok if some HTTP provider failed - try next one
 		}
+		parsedUrl.Path += ".torrent" // query of signed urls stays after the path
 		res, err := d.callTorrentHttpProvider(ctx, parsedUrl, name)
 		if err != nil {
-			d.logger.Debug("[snapshots] .torrent from webseed rejected", "name", name, "err", err, "url", urlStr)
+			d.logger.Debug("[snapshots] .torrent from webseed rejected", "name", name, "err", err, "url", downloadercfg.RedactURL(parsedUrl))
 			continue // it's ok if some HTTP provider failed - try next one
 		}
 		ts, _, err = d.torrentFiles.Create(name, res)
@@ -561,19 +568,19 @@ func (d *WebSeeds) DownloadAndSaveTorrentFile(ctx context.Context, name string)
 
 func (d *WebSeeds) callTorrentHttpProvider(ctx context.Context, url *url.URL, fileName string) ([]byte, error) {
 	if !strings.HasSuffix(url.Path, ".torrent") {
-		return nil, fmt.Errorf("seems not-torrent url passed: %s", url.String())
+		return nil, fmt.Errorf("seems not-torrent url passed: %s", downloadercfg.RedactURL(url))
 	}
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	insertCloudflareHeaders(request)
+	d.insertHeaders(request)
 
 	request = request.WithContext(ctx)
 	resp, err := d.client.Do(request)
 	if err != nil {
-		return nil, fmt.Errorf("webseed.downloadTorrentFile: url=%s, %w", url.String(), err)
+		return nil, fmt.Errorf("webseed.downloadTorrentFile: url=%s, %w", downloadercfg.RedactURL(url), err)
 	}
 	defer resp.Body.Close()
 	//protect against too small and too big data
@@ -462,6 +462,143 @@ func (x *TorrentCompletedReply) GetHash() *typesproto.H160 {
 	return nil
 }
 
+type TransportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransportRequest) Reset() {
+	*x = TransportRequest{}
+	mi := &file_downloader_downloader_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransportRequest) ProtoMessage() {}
+
+func (x *TransportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_downloader_downloader_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransportRequest.ProtoReflect.Descriptor instead.
+func (*TransportRequest) Descriptor() ([]byte, []int) {
+	return file_downloader_downloader_proto_rawDescGZIP(), []int{10}
+}
+
+// Message: transport settings of torrent client
+type TransportReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tcp           bool                   `protobuf:"varint,1,opt,name=tcp,proto3" json:"tcp,omitempty"`
+	Utp           bool                   `protobuf:"varint,2,opt,name=utp,proto3" json:"utp,omitempty"`
+	Ipv4          bool                   `protobuf:"varint,3,opt,name=ipv4,proto3" json:"ipv4,omitempty"`
+	Ipv6          bool                   `protobuf:"varint,4,opt,name=ipv6,proto3" json:"ipv6,omitempty"`
+	Pex           bool                   `protobuf:"varint,5,opt,name=pex,proto3" json:"pex,omitempty"`
+	Dht           bool                   `protobuf:"varint,6,opt,name=dht,proto3" json:"dht,omitempty"`
+	DhtBootstrap  []string               `protobuf:"bytes,7,rep,name=dht_bootstrap,json=dhtBootstrap,proto3" json:"dht_bootstrap,omitempty"` // host:port of dht bootstrap nodes
+	ListenAddrs   []string               `protobuf:"bytes,8,rep,name=listen_addrs,json=listenAddrs,proto3" json:"listen_addrs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransportReply) Reset() {
+	*x = TransportReply{}
+	mi := &file_downloader_downloader_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransportReply) ProtoMessage() {}
+
+func (x *TransportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_downloader_downloader_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransportReply.ProtoReflect.Descriptor instead.
+func (*TransportReply) Descriptor() ([]byte, []int) {
+	return file_downloader_downloader_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TransportReply) GetTcp() bool {
+	if x != nil {
+		return x.Tcp
+	}
+	return false
+}
+
+func (x *TransportReply) GetUtp() bool {
+	if x != nil {
+		return x.Utp
+	}
+	return false
+}
+
+func (x *TransportReply) GetIpv4() bool {
+	if x != nil {
+		return x.Ipv4
+	}
+	return false
+}
+
+func (x *TransportReply) GetIpv6() bool {
+	if x != nil {
+		return x.Ipv6
+	}
+	return false
+}
+
+func (x *TransportReply) GetPex() bool {
+	if x != nil {
+		return x.Pex
+	}
+	return false
+}
+
+func (x *TransportReply) GetDht() bool {
+	if x != nil {
+		return x.Dht
+	}
+	return false
+}
+
+func (x *TransportReply) GetDhtBootstrap() []string {
+	if x != nil {
+		return x.DhtBootstrap
+	}
+	return nil
+}
+
+func (x *TransportReply) GetListenAddrs() []string {
+	if x != nil {
+		return x.ListenAddrs
+	}
+	return nil
+}
+
 var File_downloader_downloader_proto protoreflect.FileDescriptor
 
 const file_downloader_downloader_proto_rawDesc = "" +
@@ -487,7 +624,17 @@ const file_downloader_downloader_proto_rawDesc = "" +
 	"\x17TorrentCompletedRequest\"L\n" +
 	"\x15TorrentCompletedReply\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
-	"\x04hash\x18\x02 \x01(\v2\v.types.H160R\x04hash2\x90\x04\n" +
+	"\x04hash\x18\x02 \x01(\v2\v.types.H160R\x04hash\"\x12\n" +
+	"\x10TransportRequest\"\xc8\x01\n" +
+	"\x0eTransportReply\x12\x10\n" +
+	"\x03tcp\x18\x01 \x01(\bR\x03tcp\x12\x10\n" +
+	"\x03utp\x18\x02 \x01(\bR\x03utp\x12\x12\n" +
+	"\x04ipv4\x18\x03 \x01(\bR\x04ipv4\x12\x12\n" +
+	"\x04ipv6\x18\x04 \x01(\bR\x04ipv6\x12\x10\n" +
+	"\x03pex\x18\x05 \x01(\bR\x03pex\x12\x10\n" +
+	"\x03dht\x18\x06 \x01(\bR\x03dht\x12#\n" +
+	"\rdht_bootstrap\x18\a \x03(\tR\fdhtBootstrap\x12!\n" +
+	"\flisten_addrs\x18\b \x03(\tR\vlistenAddrs2\xd9\x04\n" +
 	"\n" +
 	"Downloader\x12Y\n" +
 	"\x14ProhibitNewDownloads\x12'.downloader.ProhibitNewDownloadsRequest\x1a\x16.google.protobuf.Empty\"\x00\x127\n" +
@@ -496,7 +643,8 @@ const file_downloader_downloader_proto_rawDesc = "" +
 	"\x06Verify\x12\x19.downloader.VerifyRequest\x1a\x16.google.protobuf.Empty\"\x00\x12I\n" +
 	"\fSetLogPrefix\x12\x1f.downloader.SetLogPrefixRequest\x1a\x16.google.protobuf.Empty\"\x00\x12G\n" +
 	"\tCompleted\x12\x1c.downloader.CompletedRequest\x1a\x1a.downloader.CompletedReply\"\x00\x12\\\n" +
-	"\x10TorrentCompleted\x12#.downloader.TorrentCompletedRequest\x1a!.downloader.TorrentCompletedReply0\x01B\x1eZ\x1c./downloader;downloaderprotob\x06proto3"
+	"\x10TorrentCompleted\x12#.downloader.TorrentCompletedRequest\x1a!.downloader.TorrentCompletedReply0\x01\x12G\n" +
+	"\tTransport\x12\x1c.downloader.TransportRequest\x1a\x1a.downloader.TransportReply\"\x00B\x1eZ\x1c./downloader;downloaderprotob\x06proto3"
 
 var (
 	file_downloader_downloader_proto_rawDescOnce sync.Once
@@ -510,7 +658,7 @@ func file_downloader_downloader_proto_rawDescGZIP() []byte {
 	return file_downloader_downloader_proto_rawDescData
 }
 
-var file_downloader_downloader_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_downloader_downloader_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_downloader_downloader_proto_goTypes = []any{
 	(*AddItem)(nil),                     // 0: downloader.AddItem
 	(*AddRequest)(nil),                  // 1: downloader.AddRequest
@@ -522,13 +670,15 @@ var file_downloader_downloader_proto_goTypes = []any{
 	(*CompletedReply)(nil),              // 7: downloader.CompletedReply
 	(*TorrentCompletedRequest)(nil),     // 8: downloader.TorrentCompletedRequest
 	(*TorrentCompletedReply)(nil),       // 9: downloader.TorrentCompletedReply
-	(*typesproto.H160)(nil),             // 10: types.H160
-	(*emptypb.Empty)(nil),               // 11: google.protobuf.Empty
+	(*TransportRequest)(nil),            // 10: downloader.TransportRequest
+	(*TransportReply)(nil),              // 11: downloader.TransportReply
+	(*typesproto.H160)(nil),             // 12: types.H160
+	(*emptypb.Empty)(nil),               // 13: google.protobuf.Empty
 }
 var file_downloader_downloader_proto_depIdxs = []int32{
-	10, // 0: downloader.AddItem.torrent_hash:type_name -> types.H160
+	12, // 0: downloader.AddItem.torrent_hash:type_name -> types.H160
 	0,  // 1: downloader.AddRequest.items:type_name -> downloader.AddItem
-	10, // 2: downloader.TorrentCompletedReply.hash:type_name -> types.H160
+	12, // 2: downloader.TorrentCompletedReply.hash:type_name -> types.H160
 	4,  // 3: downloader.Downloader.ProhibitNewDownloads:input_type -> downloader.ProhibitNewDownloadsRequest
 	1,  // 4: downloader.Downloader.Add:input_type -> downloader.AddRequest
 	2,  // 5: downloader.Downloader.Delete:input_type -> downloader.DeleteRequest
@@ -536,15 +686,17 @@ var file_downloader_downloader_proto_depIdxs = []int32{
 	5,  // 7: downloader.Downloader.SetLogPrefix:input_type -> downloader.SetLogPrefixRequest
 	6,  // 8: downloader.Downloader.Completed:input_type -> downloader.CompletedRequest
 	8,  // 9: downloader.Downloader.TorrentCompleted:input_type -> downloader.TorrentCompletedRequest
-	11, // 10: downloader.Downloader.ProhibitNewDownloads:output_type -> google.protobuf.Empty
-	11, // 11: downloader.Downloader.Add:output_type -> google.protobuf.Empty
-	11, // 12: downloader.Downloader.Delete:output_type -> google.protobuf.Empty
-	11, // 13: downloader.Downloader.Verify:output_type -> google.protobuf.Empty
-	11, // 14: downloader.Downloader.SetLogPrefix:output_type -> google.protobuf.Empty
-	7,  // 15: downloader.Downloader.Completed:output_type -> downloader.CompletedReply
-	9,  // 16: downloader.Downloader.TorrentCompleted:output_type -> downloader.TorrentCompletedReply
-	10, // [10:17] is the sub-list for method output_type
-	3,  // [3:10] is the sub-list for method input_type
+	10, // 10: downloader.Downloader.Transport:input_type -> downloader.TransportRequest
+	13, // 11: downloader.Downloader.ProhibitNewDownloads:output_type -> google.protobuf.Empty
+	13, // 12: downloader.Downloader.Add:output_type -> google.protobuf.Empty
+	13, // 13: downloader.Downloader.Delete:output_type -> google.protobuf.Empty
+	13, // 14: downloader.Downloader.Verify:output_type -> google.protobuf.Empty
+	13, // 15: downloader.Downloader.SetLogPrefix:output_type -> google.protobuf.Empty
+	7,  // 16: downloader.Downloader.Completed:output_type -> downloader.CompletedReply
+	9,  // 17: downloader.Downloader.TorrentCompleted:output_type -> downloader.TorrentCompletedReply
+	11, // 18: downloader.Downloader.Transport:output_type -> downloader.TransportReply
+	11, // [11:19] is the sub-list for method output_type
+	3,  // [3:11] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -561,7 +713,7 @@ func file_downloader_downloader_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_downloader_downloader_proto_rawDesc), len(file_downloader_downloader_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
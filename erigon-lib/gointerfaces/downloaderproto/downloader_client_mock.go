@@ -306,6 +306,50 @@ func (c *MockDownloaderClientTorrentCompletedCall) DoAndReturn(f func(context.Co
 	return c
 }
 
+// Transport mocks base method.
+func (m *MockDownloaderClient) Transport(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Transport", varargs...)
+	ret0, _ := ret[0].(*TransportReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transport indicates an expected call of Transport.
+func (mr *MockDownloaderClientMockRecorder) Transport(ctx, in any, opts ...any) *MockDownloaderClientTransportCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, in}, opts...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transport", reflect.TypeOf((*MockDownloaderClient)(nil).Transport), varargs...)
+	return &MockDownloaderClientTransportCall{Call: call}
+}
+
+// MockDownloaderClientTransportCall wrap *gomock.Call
+type MockDownloaderClientTransportCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDownloaderClientTransportCall) Return(arg0 *TransportReply, arg1 error) *MockDownloaderClientTransportCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDownloaderClientTransportCall) Do(f func(context.Context, *TransportRequest, ...grpc.CallOption) (*TransportReply, error)) *MockDownloaderClientTransportCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDownloaderClientTransportCall) DoAndReturn(f func(context.Context, *TransportRequest, ...grpc.CallOption) (*TransportReply, error)) *MockDownloaderClientTransportCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Verify mocks base method.
 func (m *MockDownloaderClient) Verify(ctx context.Context, in *VerifyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
@@ -27,6 +27,7 @@ const (
 	Downloader_SetLogPrefix_FullMethodName         = "/downloader.Downloader/SetLogPrefix"
 	Downloader_Completed_FullMethodName            = "/downloader.Downloader/Completed"
 	Downloader_TorrentCompleted_FullMethodName     = "/downloader.Downloader/TorrentCompleted"
+	Downloader_Transport_FullMethodName            = "/downloader.Downloader/Transport"
 )
 
 // DownloaderClient is the client API for Downloader service.
@@ -48,6 +49,8 @@ type DownloaderClient interface {
 	// Get is download completed
 	Completed(ctx context.Context, in *CompletedRequest, opts ...grpc.CallOption) (*CompletedReply, error)
 	TorrentCompleted(ctx context.Context, in *TorrentCompletedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TorrentCompletedReply], error)
+	// Get transport settings of torrent client: tcp/utp, ipv4/ipv6, pex, dht
+	Transport(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportReply, error)
 }
 
 type downloaderClient struct {
@@ -137,6 +140,16 @@ func (c *downloaderClient) TorrentCompleted(ctx context.Context, in *TorrentComp
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Downloader_TorrentCompletedClient = grpc.ServerStreamingClient[TorrentCompletedReply]
 
+func (c *downloaderClient) Transport(ctx context.Context, in *TransportRequest, opts ...grpc.CallOption) (*TransportReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransportReply)
+	err := c.cc.Invoke(ctx, Downloader_Transport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DownloaderServer is the server API for Downloader service.
 // All implementations must embed UnimplementedDownloaderServer
 // for forward compatibility.
@@ -156,6 +169,8 @@ type DownloaderServer interface {
 	// Get is download completed
 	Completed(context.Context, *CompletedRequest) (*CompletedReply, error)
 	TorrentCompleted(*TorrentCompletedRequest, grpc.ServerStreamingServer[TorrentCompletedReply]) error
+	// Get transport settings of torrent client: tcp/utp, ipv4/ipv6, pex, dht
+	Transport(context.Context, *TransportRequest) (*TransportReply, error)
 	mustEmbedUnimplementedDownloaderServer()
 }
 
@@ -187,6 +202,9 @@ func (UnimplementedDownloaderServer) Completed(context.Context, *CompletedReques
 func (UnimplementedDownloaderServer) TorrentCompleted(*TorrentCompletedRequest, grpc.ServerStreamingServer[TorrentCompletedReply]) error {
 	return status.Errorf(codes.Unimplemented, "method TorrentCompleted not implemented")
 }
+func (UnimplementedDownloaderServer) Transport(context.Context, *TransportRequest) (*TransportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transport not implemented")
+}
 func (UnimplementedDownloaderServer) mustEmbedUnimplementedDownloaderServer() {}
 func (UnimplementedDownloaderServer) testEmbeddedByValue()                    {}
 
@@ -327,6 +345,24 @@ func _Downloader_TorrentCompleted_Handler(srv interface{}, stream grpc.ServerStr
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Downloader_TorrentCompletedServer = grpc.ServerStreamingServer[TorrentCompletedReply]
 
+func _Downloader_Transport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).Transport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Downloader_Transport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).Transport(ctx, req.(*TransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Downloader_ServiceDesc is the grpc.ServiceDesc for Downloader service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -358,6 +394,10 @@ var Downloader_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Completed",
 			Handler:    _Downloader_Completed_Handler,
 		},
+		{
+			MethodName: "Transport",
+			Handler:    _Downloader_Transport_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
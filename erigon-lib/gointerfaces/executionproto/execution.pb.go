@@ -1051,6 +1051,9 @@ type AssembleBlockRequest struct {
 	SuggestedFeeRecipient *typesproto.H160         `protobuf:"bytes,4,opt,name=suggested_fee_recipient,json=suggestedFeeRecipient,proto3" json:"suggested_fee_recipient,omitempty"`
 	Withdrawals           []*typesproto.Withdrawal `protobuf:"bytes,5,rep,name=withdrawals,proto3" json:"withdrawals,omitempty"`                                                            // added in Shapella (EIP-4895)
 	ParentBeaconBlockRoot *typesproto.H256         `protobuf:"bytes,6,opt,name=parent_beacon_block_root,json=parentBeaconBlockRoot,proto3,oneof" json:"parent_beacon_block_root,omitempty"` // added in Dencun (EIP-4788)
+	Transactions          [][]byte                 `protobuf:"bytes,7,rep,name=transactions,proto3" json:"transactions,omitempty"`                                                          // OP Stack: forced into block first
+	NoTxPool              bool                     `protobuf:"varint,8,opt,name=no_tx_pool,json=noTxPool,proto3" json:"no_tx_pool,omitempty"`                                               // OP Stack: don't include txpool transactions
+	GasLimit              *uint64                  `protobuf:"varint,9,opt,name=gas_limit,json=gasLimit,proto3,oneof" json:"gas_limit,omitempty"`                                           // OP Stack: block gas limit set by rollup node
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -1127,6 +1130,27 @@ func (x *AssembleBlockRequest) GetParentBeaconBlockRoot() *typesproto.H256 {
 	return nil
 }
 
+func (x *AssembleBlockRequest) GetTransactions() [][]byte {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *AssembleBlockRequest) GetNoTxPool() bool {
+	if x != nil {
+		return x.NoTxPool
+	}
+	return false
+}
+
+func (x *AssembleBlockRequest) GetGasLimit() uint64 {
+	if x != nil && x.GasLimit != nil {
+		return *x.GasLimit
+	}
+	return 0
+}
+
 type AssembleBlockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1725,7 +1749,7 @@ const file_execution_execution_proto_rawDesc = "" +
 	"\x06result\x18\x01 \x01(\x0e2\x1a.execution.ExecutionStatusR\x06result\"L\n" +
 	"\x11ValidationRequest\x12\x1f\n" +
 	"\x04hash\x18\x01 \x01(\v2\v.types.H256R\x04hash\x12\x16\n" +
-	"\x06number\x18\x02 \x01(\x04R\x06number\"\xf2\x02\n" +
+	"\x06number\x18\x02 \x01(\x04R\x06number\"\xe4\x03\n" +
 	"\x14AssembleBlockRequest\x12,\n" +
 	"\vparent_hash\x18\x01 \x01(\v2\v.types.H256R\n" +
 	"parentHash\x12\x1c\n" +
@@ -1734,8 +1758,14 @@ const file_execution_execution_proto_rawDesc = "" +
 	"prevRandao\x12C\n" +
 	"\x17suggested_fee_recipient\x18\x04 \x01(\v2\v.types.H160R\x15suggestedFeeRecipient\x123\n" +
 	"\vwithdrawals\x18\x05 \x03(\v2\x11.types.WithdrawalR\vwithdrawals\x12I\n" +
-	"\x18parent_beacon_block_root\x18\x06 \x01(\v2\v.types.H256H\x00R\x15parentBeaconBlockRoot\x88\x01\x01B\x1b\n" +
-	"\x19_parent_beacon_block_root\";\n" +
+	"\x18parent_beacon_block_root\x18\x06 \x01(\v2\v.types.H256H\x00R\x15parentBeaconBlockRoot\x88\x01\x01\x12\"\n" +
+	"\ftransactions\x18\a \x03(\fR\ftransactions\x12\x1c\n" +
+	"\n" +
+	"no_tx_pool\x18\b \x01(\bR\bnoTxPool\x12 \n" +
+	"\tgas_limit\x18\t \x01(\x04H\x01R\bgasLimit\x88\x01\x01B\x1b\n" +
+	"\x19_parent_beacon_block_rootB\f\n" +
+	"\n" +
+	"_gas_limit\";\n" +
 	"\x15AssembleBlockResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
 	"\x04busy\x18\x02 \x01(\bR\x04busy\"*\n" +
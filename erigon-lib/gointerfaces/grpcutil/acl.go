@@ -0,0 +1,145 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcutil
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+)
+
+type Role string
+
+const (
+	RoleRead  Role = "read"  // all methods except adminMethods
+	RoleAdmin Role = "admin" // all methods
+)
+
+// ACL - role of each client: by CommonName of client certificate (verified by --tls.cacert).
+// Clients not in ACL are rejected. nil ACL - all clients allowed.
+type ACL map[string]Role
+
+// ParseACL - from "rpcdaemon=read,txpool=admin"
+func ParseACL(spec string) (ACL, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil
+	}
+	acl := ACL{}
+	for _, entry := range common.CliString2Array(spec) {
+		name, role, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid acl entry %q, expected <cert common name>=<%s|%s>", entry, RoleRead, RoleAdmin)
+		}
+		switch Role(role) {
+		case RoleRead, RoleAdmin:
+			acl[name] = Role(role)
+		default:
+			return nil, fmt.Errorf("invalid acl role %q of %q, expected %s or %s", role, name, RoleRead, RoleAdmin)
+		}
+	}
+	return acl, nil
+}
+
+// adminMethods - methods which change state of node: send transactions, manage peers
+var adminMethods = map[string]struct{}{
+	remoteproto.ETHBACKEND_AddPeer_FullMethodName:   {},
+	remoteproto.ETHBACKEND_BanPeer_FullMethodName:   {},
+	remoteproto.ETHBACKEND_UnbanPeer_FullMethodName: {},
+
+	txpoolproto.Txpool_Add_FullMethodName:            {},
+	txpoolproto.Mining_SubmitWork_FullMethodName:     {},
+	txpoolproto.Mining_SubmitHashRate_FullMethodName: {},
+
+	sentryproto.Sentry_SetStatus_FullMethodName:                {},
+	sentryproto.Sentry_PenalizePeer_FullMethodName:             {},
+	sentryproto.Sentry_PeerMinBlock_FullMethodName:             {},
+	sentryproto.Sentry_HandShake_FullMethodName:                {},
+	sentryproto.Sentry_SendMessageByMinBlock_FullMethodName:    {},
+	sentryproto.Sentry_SendMessageById_FullMethodName:          {},
+	sentryproto.Sentry_SendMessageToRandomPeers_FullMethodName: {},
+	sentryproto.Sentry_SendMessageToAll_FullMethodName:         {},
+	sentryproto.Sentry_AddPeer_FullMethodName:                  {},
+	sentryproto.Sentry_BanPeer_FullMethodName:                  {},
+	sentryproto.Sentry_UnbanPeer_FullMethodName:                {},
+}
+
+func IsAdminMethod(fullMethod string) bool {
+	_, ok := adminMethods[fullMethod]
+	return ok
+}
+
+// ClientName - CommonName of verified client certificate
+func ClientName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+func (acl ACL) check(ctx context.Context, fullMethod string) error {
+	if acl == nil || strings.HasPrefix(fullMethod, "/grpc.health.") {
+		return nil
+	}
+	name, ok := ClientName(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+	switch acl[name] {
+	case RoleAdmin:
+		return nil
+	case RoleRead:
+		if !IsAdminMethod(fullMethod) {
+			return nil
+		}
+		return status.Errorf(codes.PermissionDenied, "%s: %s requires role %s", name, fullMethod, RoleAdmin)
+	}
+	return status.Errorf(codes.PermissionDenied, "%s: not in acl", name)
+}
+
+func (acl ACL) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := acl.check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (acl ACL) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := acl.check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
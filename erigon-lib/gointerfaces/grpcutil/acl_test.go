@@ -0,0 +1,62 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package grpcutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+)
+
+func TestACL(t *testing.T) {
+	acl, err := ParseACL("rpcdaemon=read, txpool=admin")
+	require.NoError(t, err)
+	require.Equal(t, ACL{"rpcdaemon": RoleRead, "txpool": RoleAdmin}, acl)
+	_, err = ParseACL("rpcdaemon=write")
+	require.Error(t, err)
+	_, err = ParseACL("rpcdaemon")
+	require.Error(t, err)
+	acl0, err := ParseACL("")
+	require.NoError(t, err)
+	require.Nil(t, acl0)
+
+	client := func(name string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: name}}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}})
+	}
+	code := func(err error) codes.Code { return status.Code(err) }
+
+	require.NoError(t, acl.check(client("rpcdaemon"), remoteproto.KV_Tx_FullMethodName))
+	require.Equal(t, codes.PermissionDenied, code(acl.check(client("rpcdaemon"), txpoolproto.Txpool_Add_FullMethodName)))
+	require.NoError(t, acl.check(client("txpool"), txpoolproto.Txpool_Add_FullMethodName))
+	require.Equal(t, codes.PermissionDenied, code(acl.check(client("unknown"), remoteproto.KV_Tx_FullMethodName)))
+	require.Equal(t, codes.Unauthenticated, code(acl.check(context.Background(), remoteproto.KV_Tx_FullMethodName)))
+	require.NoError(t, ACL(nil).check(context.Background(), txpoolproto.Txpool_Add_FullMethodName))
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/c2h5oh/datasize"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/codes"
@@ -68,13 +69,17 @@ func TLS(tlsCACert, tlsCertFile, tlsKeyFile string) (credentials.TransportCreden
 	}), nil
 }
 
-func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.Server {
+func NewServer(rateLimit uint32, creds credentials.TransportCredentials, acl ACL) *grpc.Server {
 	var (
 		streamInterceptors []grpc.StreamServerInterceptor
 		unaryInterceptors  []grpc.UnaryServerInterceptor
 	)
 	streamInterceptors = append(streamInterceptors, grpc_recovery.StreamServerInterceptor())
 	unaryInterceptors = append(unaryInterceptors, grpc_recovery.UnaryServerInterceptor())
+	if acl != nil {
+		streamInterceptors = append(streamInterceptors, acl.StreamServerInterceptor())
+		unaryInterceptors = append(unaryInterceptors, acl.UnaryServerInterceptor())
+	}
 
 	//if metrics.Enabled {
 	//	streamInterceptors = append(streamInterceptors, grpc_prometheus.StreamServerInterceptor)
@@ -93,6 +98,7 @@ func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.S
 		}),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()), // continue OpenTelemetry trace of caller
 		grpc.Creds(creds),
 	}
 	grpcServer := grpc.NewServer(opts...)
@@ -115,6 +121,7 @@ func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.
 		grpc.WithConnectParams(grpc.ConnectParams{Backoff: backoffCfg, MinConnectTimeout: 10 * time.Minute}),
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(200 * datasize.MB))),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{}),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()), // propagate OpenTelemetry trace to server
 	}
 	if creds == nil {
 		dialOpts = append(dialOpts, grpc.WithInsecure())
@@ -1738,6 +1738,395 @@ func (x *AAValidationReply) GetValid() bool {
 	return false
 }
 
+type BanPeerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`            // enode url or node id
+	Duration      uint64                 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"` // in seconds, 0 means indefinitely
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanPeerRequest) Reset() {
+	*x = BanPeerRequest{}
+	mi := &file_remote_ethbackend_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanPeerRequest) ProtoMessage() {}
+
+func (x *BanPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanPeerRequest.ProtoReflect.Descriptor instead.
+func (*BanPeerRequest) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BanPeerRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *BanPeerRequest) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type BanPeerReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanPeerReply) Reset() {
+	*x = BanPeerReply{}
+	mi := &file_remote_ethbackend_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanPeerReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanPeerReply) ProtoMessage() {}
+
+func (x *BanPeerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanPeerReply.ProtoReflect.Descriptor instead.
+func (*BanPeerReply) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BanPeerReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UnbanPeerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // enode url or node id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanPeerRequest) Reset() {
+	*x = UnbanPeerRequest{}
+	mi := &file_remote_ethbackend_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanPeerRequest) ProtoMessage() {}
+
+func (x *UnbanPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanPeerRequest.ProtoReflect.Descriptor instead.
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UnbanPeerRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type UnbanPeerReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanPeerReply) Reset() {
+	*x = UnbanPeerReply{}
+	mi := &file_remote_ethbackend_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanPeerReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanPeerReply) ProtoMessage() {}
+
+func (x *UnbanPeerReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanPeerReply.ProtoReflect.Descriptor instead.
+func (*UnbanPeerReply) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UnbanPeerReply) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BannedPeersReply struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Peers         []*typesproto.BannedPeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannedPeersReply) Reset() {
+	*x = BannedPeersReply{}
+	mi := &file_remote_ethbackend_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannedPeersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannedPeersReply) ProtoMessage() {}
+
+func (x *BannedPeersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannedPeersReply.ProtoReflect.Descriptor instead.
+func (*BannedPeersReply) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *BannedPeersReply) GetPeers() []*typesproto.BannedPeerInfo {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// Receipt: consensus fields of receipt with its location in block, logs are in the format of SubscribeLogs
+type Receipt struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Type              uint64                 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Status            uint64                 `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	CumulativeGasUsed uint64                 `protobuf:"varint,3,opt,name=cumulative_gas_used,json=cumulativeGasUsed,proto3" json:"cumulative_gas_used,omitempty"`
+	GasUsed           uint64                 `protobuf:"varint,4,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	TransactionHash   *typesproto.H256       `protobuf:"bytes,5,opt,name=transaction_hash,json=transactionHash,proto3" json:"transaction_hash,omitempty"`
+	TransactionIndex  uint64                 `protobuf:"varint,6,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"`
+	ContractAddress   *typesproto.H160       `protobuf:"bytes,7,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"` // nil if transaction didn't create contract
+	Logs              []*SubscribeLogsReply  `protobuf:"bytes,8,rep,name=logs,proto3" json:"logs,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	mi := &file_remote_ethbackend_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Receipt) GetType() uint64 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *Receipt) GetStatus() uint64 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *Receipt) GetCumulativeGasUsed() uint64 {
+	if x != nil {
+		return x.CumulativeGasUsed
+	}
+	return 0
+}
+
+func (x *Receipt) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *Receipt) GetTransactionHash() *typesproto.H256 {
+	if x != nil {
+		return x.TransactionHash
+	}
+	return nil
+}
+
+func (x *Receipt) GetTransactionIndex() uint64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *Receipt) GetContractAddress() *typesproto.H160 {
+	if x != nil {
+		return x.ContractAddress
+	}
+	return nil
+}
+
+func (x *Receipt) GetLogs() []*SubscribeLogsReply {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type BlockReceipts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BlockHash     *typesproto.H256       `protobuf:"bytes,1,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockNumber   uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Receipts      []*Receipt             `protobuf:"bytes,3,rep,name=receipts,proto3" json:"receipts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockReceipts) Reset() {
+	*x = BlockReceipts{}
+	mi := &file_remote_ethbackend_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockReceipts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockReceipts) ProtoMessage() {}
+
+func (x *BlockReceipts) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_ethbackend_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockReceipts.ProtoReflect.Descriptor instead.
+func (*BlockReceipts) Descriptor() ([]byte, []int) {
+	return file_remote_ethbackend_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *BlockReceipts) GetBlockHash() *typesproto.H256 {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *BlockReceipts) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BlockReceipts) GetReceipts() []*Receipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
 type SyncingReply_StageProgress struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StageName     string                 `protobuf:"bytes,1,opt,name=stage_name,json=stageName,proto3" json:"stage_name,omitempty"`
@@ -1748,7 +2137,7 @@ type SyncingReply_StageProgress struct {
 
 func (x *SyncingReply_StageProgress) Reset() {
 	*x = SyncingReply_StageProgress{}
-	mi := &file_remote_ethbackend_proto_msgTypes[35]
+	mi := &file_remote_ethbackend_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1760,7 +2149,7 @@ func (x *SyncingReply_StageProgress) String() string {
 func (*SyncingReply_StageProgress) ProtoMessage() {}
 
 func (x *SyncingReply_StageProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_remote_ethbackend_proto_msgTypes[35]
+	mi := &file_remote_ethbackend_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1890,13 +2279,38 @@ const file_remote_ethbackend_proto_rawDesc = "" +
 	"\x13AAValidationRequest\x124\n" +
 	"\x02tx\x18\x01 \x01(\v2$.types.AccountAbstractionTransactionR\x02tx\")\n" +
 	"\x11AAValidationReply\x12\x14\n" +
-	"\x05valid\x18\x01 \x01(\bR\x05valid*J\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\">\n" +
+	"\x0eBanPeerRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x04R\bduration\"(\n" +
+	"\fBanPeerReply\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"$\n" +
+	"\x10UnbanPeerRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"*\n" +
+	"\x0eUnbanPeerReply\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"?\n" +
+	"\x10BannedPeersReply\x12+\n" +
+	"\x05peers\x18\x01 \x03(\v2\x15.types.BannedPeerInfoR\x05peers\"\xcd\x02\n" +
+	"\aReceipt\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\x04R\x04type\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\x04R\x06status\x12.\n" +
+	"\x13cumulative_gas_used\x18\x03 \x01(\x04R\x11cumulativeGasUsed\x12\x19\n" +
+	"\bgas_used\x18\x04 \x01(\x04R\agasUsed\x126\n" +
+	"\x10transaction_hash\x18\x05 \x01(\v2\v.types.H256R\x0ftransactionHash\x12+\n" +
+	"\x11transaction_index\x18\x06 \x01(\x04R\x10transactionIndex\x126\n" +
+	"\x10contract_address\x18\a \x01(\v2\v.types.H160R\x0fcontractAddress\x12.\n" +
+	"\x04logs\x18\b \x03(\v2\x1a.remote.SubscribeLogsReplyR\x04logs\"\x8b\x01\n" +
+	"\rBlockReceipts\x12*\n" +
+	"\n" +
+	"block_hash\x18\x01 \x01(\v2\v.types.H256R\tblockHash\x12!\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12+\n" +
+	"\breceipts\x18\x03 \x03(\v2\x0f.remote.ReceiptR\breceipts*J\n" +
 	"\x05Event\x12\n" +
 	"\n" +
 	"\x06HEADER\x10\x00\x12\x10\n" +
 	"\fPENDING_LOGS\x10\x01\x12\x11\n" +
 	"\rPENDING_BLOCK\x10\x02\x12\x10\n" +
-	"\fNEW_SNAPSHOT\x10\x032\x9b\v\n" +
+	"\fNEW_SNAPSHOT\x10\x032\xd4\f\n" +
 	"\n" +
 	"ETHBACKEND\x12=\n" +
 	"\tEtherbase\x12\x18.remote.EtherbaseRequest\x1a\x16.remote.EtherbaseReply\x12@\n" +
@@ -1920,7 +2334,10 @@ const file_remote_ethbackend_proto_rawDesc = "" +
 	"\fPendingBlock\x12\x16.google.protobuf.Empty\x1a\x19.remote.PendingBlockReply\x12F\n" +
 	"\fBorTxnLookup\x12\x1b.remote.BorTxnLookupRequest\x1a\x19.remote.BorTxnLookupReply\x12=\n" +
 	"\tBorEvents\x12\x18.remote.BorEventsRequest\x1a\x16.remote.BorEventsReply\x12F\n" +
-	"\fAAValidation\x12\x1b.remote.AAValidationRequest\x1a\x19.remote.AAValidationReplyB\x16Z\x14./remote;remoteprotob\x06proto3"
+	"\fAAValidation\x12\x1b.remote.AAValidationRequest\x1a\x19.remote.AAValidationReply\x127\n" +
+	"\aBanPeer\x12\x16.remote.BanPeerRequest\x1a\x14.remote.BanPeerReply\x12=\n" +
+	"\tUnbanPeer\x12\x18.remote.UnbanPeerRequest\x1a\x16.remote.UnbanPeerReply\x12?\n" +
+	"\vBannedPeers\x12\x16.google.protobuf.Empty\x1a\x18.remote.BannedPeersReplyB\x16Z\x14./remote;remoteprotob\x06proto3"
 
 var (
 	file_remote_ethbackend_proto_rawDescOnce sync.Once
@@ -1935,7 +2352,7 @@ func file_remote_ethbackend_proto_rawDescGZIP() []byte {
 }
 
 var file_remote_ethbackend_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_remote_ethbackend_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
+var file_remote_ethbackend_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_remote_ethbackend_proto_goTypes = []any{
 	(Event)(0),                                       // 0: remote.Event
 	(*EtherbaseRequest)(nil),                         // 1: remote.EtherbaseRequest
@@ -1973,85 +2390,105 @@ var file_remote_ethbackend_proto_goTypes = []any{
 	(*EngineGetPayloadBodiesByRangeV1Request)(nil),   // 33: remote.EngineGetPayloadBodiesByRangeV1Request
 	(*AAValidationRequest)(nil),                      // 34: remote.AAValidationRequest
 	(*AAValidationReply)(nil),                        // 35: remote.AAValidationReply
-	(*SyncingReply_StageProgress)(nil),               // 36: remote.SyncingReply.StageProgress
-	(*typesproto.H160)(nil),                          // 37: types.H160
-	(*typesproto.H256)(nil),                          // 38: types.H256
-	(*typesproto.NodeInfoReply)(nil),                 // 39: types.NodeInfoReply
-	(*typesproto.PeerInfo)(nil),                      // 40: types.PeerInfo
-	(*typesproto.AccountAbstractionTransaction)(nil), // 41: types.AccountAbstractionTransaction
-	(*emptypb.Empty)(nil),                            // 42: google.protobuf.Empty
-	(*BorTxnLookupRequest)(nil),                      // 43: remote.BorTxnLookupRequest
-	(*BorEventsRequest)(nil),                         // 44: remote.BorEventsRequest
-	(*typesproto.VersionReply)(nil),                  // 45: types.VersionReply
-	(*BorTxnLookupReply)(nil),                        // 46: remote.BorTxnLookupReply
-	(*BorEventsReply)(nil),                           // 47: remote.BorEventsReply
+	(*BanPeerRequest)(nil),                           // 36: remote.BanPeerRequest
+	(*BanPeerReply)(nil),                             // 37: remote.BanPeerReply
+	(*UnbanPeerRequest)(nil),                         // 38: remote.UnbanPeerRequest
+	(*UnbanPeerReply)(nil),                           // 39: remote.UnbanPeerReply
+	(*BannedPeersReply)(nil),                         // 40: remote.BannedPeersReply
+	(*Receipt)(nil),                                  // 41: remote.Receipt
+	(*BlockReceipts)(nil),                            // 42: remote.BlockReceipts
+	(*SyncingReply_StageProgress)(nil),               // 43: remote.SyncingReply.StageProgress
+	(*typesproto.H160)(nil),                          // 44: types.H160
+	(*typesproto.H256)(nil),                          // 45: types.H256
+	(*typesproto.NodeInfoReply)(nil),                 // 46: types.NodeInfoReply
+	(*typesproto.PeerInfo)(nil),                      // 47: types.PeerInfo
+	(*typesproto.AccountAbstractionTransaction)(nil), // 48: types.AccountAbstractionTransaction
+	(*typesproto.BannedPeerInfo)(nil),                // 49: types.BannedPeerInfo
+	(*emptypb.Empty)(nil),                            // 50: google.protobuf.Empty
+	(*BorTxnLookupRequest)(nil),                      // 51: remote.BorTxnLookupRequest
+	(*BorEventsRequest)(nil),                         // 52: remote.BorEventsRequest
+	(*typesproto.VersionReply)(nil),                  // 53: types.VersionReply
+	(*BorTxnLookupReply)(nil),                        // 54: remote.BorTxnLookupReply
+	(*BorEventsReply)(nil),                           // 55: remote.BorEventsReply
 }
 var file_remote_ethbackend_proto_depIdxs = []int32{
-	37, // 0: remote.EtherbaseReply.address:type_name -> types.H160
-	36, // 1: remote.SyncingReply.stages:type_name -> remote.SyncingReply.StageProgress
-	38, // 2: remote.CanonicalHashReply.hash:type_name -> types.H256
-	38, // 3: remote.HeaderNumberRequest.hash:type_name -> types.H256
+	44, // 0: remote.EtherbaseReply.address:type_name -> types.H160
+	43, // 1: remote.SyncingReply.stages:type_name -> remote.SyncingReply.StageProgress
+	45, // 2: remote.CanonicalHashReply.hash:type_name -> types.H256
+	45, // 3: remote.HeaderNumberRequest.hash:type_name -> types.H256
 	0,  // 4: remote.SubscribeRequest.type:type_name -> remote.Event
 	0,  // 5: remote.SubscribeReply.type:type_name -> remote.Event
-	37, // 6: remote.LogsFilterRequest.addresses:type_name -> types.H160
-	38, // 7: remote.LogsFilterRequest.topics:type_name -> types.H256
-	37, // 8: remote.SubscribeLogsReply.address:type_name -> types.H160
-	38, // 9: remote.SubscribeLogsReply.block_hash:type_name -> types.H256
-	38, // 10: remote.SubscribeLogsReply.topics:type_name -> types.H256
-	38, // 11: remote.SubscribeLogsReply.transaction_hash:type_name -> types.H256
-	38, // 12: remote.BlockRequest.block_hash:type_name -> types.H256
-	38, // 13: remote.TxnLookupRequest.txn_hash:type_name -> types.H256
-	39, // 14: remote.NodesInfoReply.nodes_info:type_name -> types.NodeInfoReply
-	40, // 15: remote.PeersReply.peers:type_name -> types.PeerInfo
-	38, // 16: remote.EngineGetPayloadBodiesByHashV1Request.hashes:type_name -> types.H256
-	41, // 17: remote.AAValidationRequest.tx:type_name -> types.AccountAbstractionTransaction
-	1,  // 18: remote.ETHBACKEND.Etherbase:input_type -> remote.EtherbaseRequest
-	3,  // 19: remote.ETHBACKEND.NetVersion:input_type -> remote.NetVersionRequest
-	6,  // 20: remote.ETHBACKEND.NetPeerCount:input_type -> remote.NetPeerCountRequest
-	42, // 21: remote.ETHBACKEND.Version:input_type -> google.protobuf.Empty
-	42, // 22: remote.ETHBACKEND.Syncing:input_type -> google.protobuf.Empty
-	8,  // 23: remote.ETHBACKEND.ProtocolVersion:input_type -> remote.ProtocolVersionRequest
-	10, // 24: remote.ETHBACKEND.ClientVersion:input_type -> remote.ClientVersionRequest
-	18, // 25: remote.ETHBACKEND.Subscribe:input_type -> remote.SubscribeRequest
-	20, // 26: remote.ETHBACKEND.SubscribeLogs:input_type -> remote.LogsFilterRequest
-	22, // 27: remote.ETHBACKEND.Block:input_type -> remote.BlockRequest
-	16, // 28: remote.ETHBACKEND.CanonicalBodyForStorage:input_type -> remote.CanonicalBodyForStorageRequest
-	12, // 29: remote.ETHBACKEND.CanonicalHash:input_type -> remote.CanonicalHashRequest
-	14, // 30: remote.ETHBACKEND.HeaderNumber:input_type -> remote.HeaderNumberRequest
-	24, // 31: remote.ETHBACKEND.TxnLookup:input_type -> remote.TxnLookupRequest
-	26, // 32: remote.ETHBACKEND.NodeInfo:input_type -> remote.NodesInfoRequest
-	42, // 33: remote.ETHBACKEND.Peers:input_type -> google.protobuf.Empty
-	27, // 34: remote.ETHBACKEND.AddPeer:input_type -> remote.AddPeerRequest
-	42, // 35: remote.ETHBACKEND.PendingBlock:input_type -> google.protobuf.Empty
-	43, // 36: remote.ETHBACKEND.BorTxnLookup:input_type -> remote.BorTxnLookupRequest
-	44, // 37: remote.ETHBACKEND.BorEvents:input_type -> remote.BorEventsRequest
-	34, // 38: remote.ETHBACKEND.AAValidation:input_type -> remote.AAValidationRequest
-	2,  // 39: remote.ETHBACKEND.Etherbase:output_type -> remote.EtherbaseReply
-	4,  // 40: remote.ETHBACKEND.NetVersion:output_type -> remote.NetVersionReply
-	7,  // 41: remote.ETHBACKEND.NetPeerCount:output_type -> remote.NetPeerCountReply
-	45, // 42: remote.ETHBACKEND.Version:output_type -> types.VersionReply
-	5,  // 43: remote.ETHBACKEND.Syncing:output_type -> remote.SyncingReply
-	9,  // 44: remote.ETHBACKEND.ProtocolVersion:output_type -> remote.ProtocolVersionReply
-	11, // 45: remote.ETHBACKEND.ClientVersion:output_type -> remote.ClientVersionReply
-	19, // 46: remote.ETHBACKEND.Subscribe:output_type -> remote.SubscribeReply
-	21, // 47: remote.ETHBACKEND.SubscribeLogs:output_type -> remote.SubscribeLogsReply
-	23, // 48: remote.ETHBACKEND.Block:output_type -> remote.BlockReply
-	17, // 49: remote.ETHBACKEND.CanonicalBodyForStorage:output_type -> remote.CanonicalBodyForStorageReply
-	13, // 50: remote.ETHBACKEND.CanonicalHash:output_type -> remote.CanonicalHashReply
-	15, // 51: remote.ETHBACKEND.HeaderNumber:output_type -> remote.HeaderNumberReply
-	25, // 52: remote.ETHBACKEND.TxnLookup:output_type -> remote.TxnLookupReply
-	28, // 53: remote.ETHBACKEND.NodeInfo:output_type -> remote.NodesInfoReply
-	29, // 54: remote.ETHBACKEND.Peers:output_type -> remote.PeersReply
-	30, // 55: remote.ETHBACKEND.AddPeer:output_type -> remote.AddPeerReply
-	31, // 56: remote.ETHBACKEND.PendingBlock:output_type -> remote.PendingBlockReply
-	46, // 57: remote.ETHBACKEND.BorTxnLookup:output_type -> remote.BorTxnLookupReply
-	47, // 58: remote.ETHBACKEND.BorEvents:output_type -> remote.BorEventsReply
-	35, // 59: remote.ETHBACKEND.AAValidation:output_type -> remote.AAValidationReply
-	39, // [39:60] is the sub-list for method output_type
-	18, // [18:39] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	44, // 6: remote.LogsFilterRequest.addresses:type_name -> types.H160
+	45, // 7: remote.LogsFilterRequest.topics:type_name -> types.H256
+	44, // 8: remote.SubscribeLogsReply.address:type_name -> types.H160
+	45, // 9: remote.SubscribeLogsReply.block_hash:type_name -> types.H256
+	45, // 10: remote.SubscribeLogsReply.topics:type_name -> types.H256
+	45, // 11: remote.SubscribeLogsReply.transaction_hash:type_name -> types.H256
+	45, // 12: remote.BlockRequest.block_hash:type_name -> types.H256
+	45, // 13: remote.TxnLookupRequest.txn_hash:type_name -> types.H256
+	46, // 14: remote.NodesInfoReply.nodes_info:type_name -> types.NodeInfoReply
+	47, // 15: remote.PeersReply.peers:type_name -> types.PeerInfo
+	45, // 16: remote.EngineGetPayloadBodiesByHashV1Request.hashes:type_name -> types.H256
+	48, // 17: remote.AAValidationRequest.tx:type_name -> types.AccountAbstractionTransaction
+	49, // 18: remote.BannedPeersReply.peers:type_name -> types.BannedPeerInfo
+	45, // 19: remote.Receipt.transaction_hash:type_name -> types.H256
+	44, // 20: remote.Receipt.contract_address:type_name -> types.H160
+	21, // 21: remote.Receipt.logs:type_name -> remote.SubscribeLogsReply
+	45, // 22: remote.BlockReceipts.block_hash:type_name -> types.H256
+	41, // 23: remote.BlockReceipts.receipts:type_name -> remote.Receipt
+	1,  // 24: remote.ETHBACKEND.Etherbase:input_type -> remote.EtherbaseRequest
+	3,  // 25: remote.ETHBACKEND.NetVersion:input_type -> remote.NetVersionRequest
+	6,  // 26: remote.ETHBACKEND.NetPeerCount:input_type -> remote.NetPeerCountRequest
+	50, // 27: remote.ETHBACKEND.Version:input_type -> google.protobuf.Empty
+	50, // 28: remote.ETHBACKEND.Syncing:input_type -> google.protobuf.Empty
+	8,  // 29: remote.ETHBACKEND.ProtocolVersion:input_type -> remote.ProtocolVersionRequest
+	10, // 30: remote.ETHBACKEND.ClientVersion:input_type -> remote.ClientVersionRequest
+	18, // 31: remote.ETHBACKEND.Subscribe:input_type -> remote.SubscribeRequest
+	20, // 32: remote.ETHBACKEND.SubscribeLogs:input_type -> remote.LogsFilterRequest
+	22, // 33: remote.ETHBACKEND.Block:input_type -> remote.BlockRequest
+	16, // 34: remote.ETHBACKEND.CanonicalBodyForStorage:input_type -> remote.CanonicalBodyForStorageRequest
+	12, // 35: remote.ETHBACKEND.CanonicalHash:input_type -> remote.CanonicalHashRequest
+	14, // 36: remote.ETHBACKEND.HeaderNumber:input_type -> remote.HeaderNumberRequest
+	24, // 37: remote.ETHBACKEND.TxnLookup:input_type -> remote.TxnLookupRequest
+	26, // 38: remote.ETHBACKEND.NodeInfo:input_type -> remote.NodesInfoRequest
+	50, // 39: remote.ETHBACKEND.Peers:input_type -> google.protobuf.Empty
+	27, // 40: remote.ETHBACKEND.AddPeer:input_type -> remote.AddPeerRequest
+	50, // 41: remote.ETHBACKEND.PendingBlock:input_type -> google.protobuf.Empty
+	51, // 42: remote.ETHBACKEND.BorTxnLookup:input_type -> remote.BorTxnLookupRequest
+	52, // 43: remote.ETHBACKEND.BorEvents:input_type -> remote.BorEventsRequest
+	34, // 44: remote.ETHBACKEND.AAValidation:input_type -> remote.AAValidationRequest
+	36, // 45: remote.ETHBACKEND.BanPeer:input_type -> remote.BanPeerRequest
+	38, // 46: remote.ETHBACKEND.UnbanPeer:input_type -> remote.UnbanPeerRequest
+	50, // 47: remote.ETHBACKEND.BannedPeers:input_type -> google.protobuf.Empty
+	2,  // 48: remote.ETHBACKEND.Etherbase:output_type -> remote.EtherbaseReply
+	4,  // 49: remote.ETHBACKEND.NetVersion:output_type -> remote.NetVersionReply
+	7,  // 50: remote.ETHBACKEND.NetPeerCount:output_type -> remote.NetPeerCountReply
+	53, // 51: remote.ETHBACKEND.Version:output_type -> types.VersionReply
+	5,  // 52: remote.ETHBACKEND.Syncing:output_type -> remote.SyncingReply
+	9,  // 53: remote.ETHBACKEND.ProtocolVersion:output_type -> remote.ProtocolVersionReply
+	11, // 54: remote.ETHBACKEND.ClientVersion:output_type -> remote.ClientVersionReply
+	19, // 55: remote.ETHBACKEND.Subscribe:output_type -> remote.SubscribeReply
+	21, // 56: remote.ETHBACKEND.SubscribeLogs:output_type -> remote.SubscribeLogsReply
+	23, // 57: remote.ETHBACKEND.Block:output_type -> remote.BlockReply
+	17, // 58: remote.ETHBACKEND.CanonicalBodyForStorage:output_type -> remote.CanonicalBodyForStorageReply
+	13, // 59: remote.ETHBACKEND.CanonicalHash:output_type -> remote.CanonicalHashReply
+	15, // 60: remote.ETHBACKEND.HeaderNumber:output_type -> remote.HeaderNumberReply
+	25, // 61: remote.ETHBACKEND.TxnLookup:output_type -> remote.TxnLookupReply
+	28, // 62: remote.ETHBACKEND.NodeInfo:output_type -> remote.NodesInfoReply
+	29, // 63: remote.ETHBACKEND.Peers:output_type -> remote.PeersReply
+	30, // 64: remote.ETHBACKEND.AddPeer:output_type -> remote.AddPeerReply
+	31, // 65: remote.ETHBACKEND.PendingBlock:output_type -> remote.PendingBlockReply
+	54, // 66: remote.ETHBACKEND.BorTxnLookup:output_type -> remote.BorTxnLookupReply
+	55, // 67: remote.ETHBACKEND.BorEvents:output_type -> remote.BorEventsReply
+	35, // 68: remote.ETHBACKEND.AAValidation:output_type -> remote.AAValidationReply
+	37, // 69: remote.ETHBACKEND.BanPeer:output_type -> remote.BanPeerReply
+	39, // 70: remote.ETHBACKEND.UnbanPeer:output_type -> remote.UnbanPeerReply
+	40, // 71: remote.ETHBACKEND.BannedPeers:output_type -> remote.BannedPeersReply
+	48, // [48:72] is the sub-list for method output_type
+	24, // [24:48] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_remote_ethbackend_proto_init() }
@@ -2067,7 +2504,7 @@ func file_remote_ethbackend_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_remote_ethbackend_proto_rawDesc), len(file_remote_ethbackend_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   36,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -42,6 +42,9 @@ const (
 	ETHBACKEND_BorTxnLookup_FullMethodName            = "/remote.ETHBACKEND/BorTxnLookup"
 	ETHBACKEND_BorEvents_FullMethodName               = "/remote.ETHBACKEND/BorEvents"
 	ETHBACKEND_AAValidation_FullMethodName            = "/remote.ETHBACKEND/AAValidation"
+	ETHBACKEND_BanPeer_FullMethodName                 = "/remote.ETHBACKEND/BanPeer"
+	ETHBACKEND_UnbanPeer_FullMethodName               = "/remote.ETHBACKEND/UnbanPeer"
+	ETHBACKEND_BannedPeers_FullMethodName             = "/remote.ETHBACKEND/BannedPeers"
 )
 
 // ETHBACKENDClient is the client API for ETHBACKEND service.
@@ -85,6 +88,12 @@ type ETHBACKENDClient interface {
 	BorTxnLookup(ctx context.Context, in *BorTxnLookupRequest, opts ...grpc.CallOption) (*BorTxnLookupReply, error)
 	BorEvents(ctx context.Context, in *BorEventsRequest, opts ...grpc.CallOption) (*BorEventsReply, error)
 	AAValidation(ctx context.Context, in *AAValidationRequest, opts ...grpc.CallOption) (*AAValidationReply, error)
+	// BanPeer bans the given node in all running sentry instances.
+	BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BanPeerReply, error)
+	// UnbanPeer lifts the ban of the given node in all running sentry instances.
+	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerReply, error)
+	// BannedPeers collects and returns banned nodes from all running sentry instances.
+	BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BannedPeersReply, error)
 }
 
 type eTHBACKENDClient struct {
@@ -317,6 +326,36 @@ func (c *eTHBACKENDClient) AAValidation(ctx context.Context, in *AAValidationReq
 	return out, nil
 }
 
+func (c *eTHBACKENDClient) BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*BanPeerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BanPeerReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_BanPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eTHBACKENDClient) UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*UnbanPeerReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnbanPeerReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_UnbanPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eTHBACKENDClient) BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BannedPeersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BannedPeersReply)
+	err := c.cc.Invoke(ctx, ETHBACKEND_BannedPeers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ETHBACKENDServer is the server API for ETHBACKEND service.
 // All implementations must embed UnimplementedETHBACKENDServer
 // for forward compatibility.
@@ -358,6 +397,12 @@ type ETHBACKENDServer interface {
 	BorTxnLookup(context.Context, *BorTxnLookupRequest) (*BorTxnLookupReply, error)
 	BorEvents(context.Context, *BorEventsRequest) (*BorEventsReply, error)
 	AAValidation(context.Context, *AAValidationRequest) (*AAValidationReply, error)
+	// BanPeer bans the given node in all running sentry instances.
+	BanPeer(context.Context, *BanPeerRequest) (*BanPeerReply, error)
+	// UnbanPeer lifts the ban of the given node in all running sentry instances.
+	UnbanPeer(context.Context, *UnbanPeerRequest) (*UnbanPeerReply, error)
+	// BannedPeers collects and returns banned nodes from all running sentry instances.
+	BannedPeers(context.Context, *emptypb.Empty) (*BannedPeersReply, error)
 	mustEmbedUnimplementedETHBACKENDServer()
 }
 
@@ -431,6 +476,15 @@ func (UnimplementedETHBACKENDServer) BorEvents(context.Context, *BorEventsReques
 func (UnimplementedETHBACKENDServer) AAValidation(context.Context, *AAValidationRequest) (*AAValidationReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AAValidation not implemented")
 }
+func (UnimplementedETHBACKENDServer) BanPeer(context.Context, *BanPeerRequest) (*BanPeerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPeer not implemented")
+}
+func (UnimplementedETHBACKENDServer) UnbanPeer(context.Context, *UnbanPeerRequest) (*UnbanPeerReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanPeer not implemented")
+}
+func (UnimplementedETHBACKENDServer) BannedPeers(context.Context, *emptypb.Empty) (*BannedPeersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BannedPeers not implemented")
+}
 func (UnimplementedETHBACKENDServer) mustEmbedUnimplementedETHBACKENDServer() {}
 func (UnimplementedETHBACKENDServer) testEmbeddedByValue()                    {}
 
@@ -812,6 +866,60 @@ func _ETHBACKEND_AAValidation_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ETHBACKEND_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_BanPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).BanPeer(ctx, req.(*BanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ETHBACKEND_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_UnbanPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).UnbanPeer(ctx, req.(*UnbanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ETHBACKEND_BannedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ETHBACKENDServer).BannedPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ETHBACKEND_BannedPeers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ETHBACKENDServer).BannedPeers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ETHBACKEND_ServiceDesc is the grpc.ServiceDesc for ETHBACKEND service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -895,6 +1003,18 @@ var ETHBACKEND_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AAValidation",
 			Handler:    _ETHBACKEND_AAValidation_Handler,
 		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _ETHBACKEND_BanPeer_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _ETHBACKEND_UnbanPeer_Handler,
+		},
+		{
+			MethodName: "BannedPeers",
+			Handler:    _ETHBACKEND_BannedPeers_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1820,6 +1820,186 @@ func (x *IndexPagination) GetLimit() int64 {
 	return 0
 }
 
+type StateCacheLookupRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StateVersionId uint64                 `protobuf:"varint,1,opt,name=state_version_id,json=stateVersionId,proto3" json:"state_version_id,omitempty"`
+	Key            []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Code           bool                   `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"` // lookup of code of account, key is address
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StateCacheLookupRequest) Reset() {
+	*x = StateCacheLookupRequest{}
+	mi := &file_remote_kv_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateCacheLookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateCacheLookupRequest) ProtoMessage() {}
+
+func (x *StateCacheLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_kv_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateCacheLookupRequest.ProtoReflect.Descriptor instead.
+func (*StateCacheLookupRequest) Descriptor() ([]byte, []int) {
+	return file_remote_kv_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StateCacheLookupRequest) GetStateVersionId() uint64 {
+	if x != nil {
+		return x.StateVersionId
+	}
+	return 0
+}
+
+func (x *StateCacheLookupRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StateCacheLookupRequest) GetCode() bool {
+	if x != nil {
+		return x.Code
+	}
+	return false
+}
+
+type StateCacheLookupReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hit           bool                   `protobuf:"varint,1,opt,name=hit,proto3" json:"hit,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StateCacheLookupReply) Reset() {
+	*x = StateCacheLookupReply{}
+	mi := &file_remote_kv_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateCacheLookupReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateCacheLookupReply) ProtoMessage() {}
+
+func (x *StateCacheLookupReply) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_kv_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateCacheLookupReply.ProtoReflect.Descriptor instead.
+func (*StateCacheLookupReply) Descriptor() ([]byte, []int) {
+	return file_remote_kv_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StateCacheLookupReply) GetHit() bool {
+	if x != nil {
+		return x.Hit
+	}
+	return false
+}
+
+func (x *StateCacheLookupReply) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type StateCacheFillRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	StateVersionId uint64                 `protobuf:"varint,1,opt,name=state_version_id,json=stateVersionId,proto3" json:"state_version_id,omitempty"`
+	Key            []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value          []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Code           bool                   `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"` // value is code of account, key is address
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StateCacheFillRequest) Reset() {
+	*x = StateCacheFillRequest{}
+	mi := &file_remote_kv_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StateCacheFillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateCacheFillRequest) ProtoMessage() {}
+
+func (x *StateCacheFillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_remote_kv_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateCacheFillRequest.ProtoReflect.Descriptor instead.
+func (*StateCacheFillRequest) Descriptor() ([]byte, []int) {
+	return file_remote_kv_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StateCacheFillRequest) GetStateVersionId() uint64 {
+	if x != nil {
+		return x.StateVersionId
+	}
+	return 0
+}
+
+func (x *StateCacheFillRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StateCacheFillRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *StateCacheFillRequest) GetCode() bool {
+	if x != nil {
+		return x.Code
+	}
+	return false
+}
+
 var File_remote_kv_proto protoreflect.FileDescriptor
 
 const file_remote_kv_proto_rawDesc = "" +
@@ -1954,7 +2134,19 @@ const file_remote_kv_proto_rawDesc = "" +
 	"\x05limit\x18\x02 \x01(\x12R\x05limit\"O\n" +
 	"\x0fIndexPagination\x12&\n" +
 	"\x0fnext_time_stamp\x18\x01 \x01(\x12R\rnextTimeStamp\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x12R\x05limit*\xfb\x01\n" +
+	"\x05limit\x18\x02 \x01(\x12R\x05limit\"i\n" +
+	"\x17StateCacheLookupRequest\x12(\n" +
+	"\x10state_version_id\x18\x01 \x01(\x04R\x0estateVersionId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\bR\x04code\"?\n" +
+	"\x15StateCacheLookupReply\x12\x10\n" +
+	"\x03hit\x18\x01 \x01(\bR\x03hit\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\"}\n" +
+	"\x15StateCacheFillRequest\x12(\n" +
+	"\x10state_version_id\x18\x01 \x01(\x04R\x0estateVersionId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\x12\x12\n" +
+	"\x04code\x18\x04 \x01(\bR\x04code*\xfb\x01\n" +
 	"\x02Op\x12\t\n" +
 	"\x05FIRST\x10\x00\x12\r\n" +
 	"\tFIRST_DUP\x10\x01\x12\b\n" +
@@ -1999,7 +2191,11 @@ const file_remote_kv_proto_rawDesc = "" +
 	"\n" +
 	"IndexRange\x12\x15.remote.IndexRangeReq\x1a\x17.remote.IndexRangeReply\x126\n" +
 	"\fHistoryRange\x12\x17.remote.HistoryRangeReq\x1a\r.remote.Pairs\x120\n" +
-	"\tRangeAsOf\x12\x14.remote.RangeAsOfReq\x1a\r.remote.PairsB\x16Z\x14./remote;remoteprotob\x06proto3"
+	"\tRangeAsOf\x12\x14.remote.RangeAsOfReq\x1a\r.remote.Pairs2\x95\x01\n" +
+	"\n" +
+	"StateCache\x12H\n" +
+	"\x06Lookup\x12\x1f.remote.StateCacheLookupRequest\x1a\x1d.remote.StateCacheLookupReply\x12=\n" +
+	"\x04Fill\x12\x1d.remote.StateCacheFillRequest\x1a\x16.google.protobuf.EmptyB\x16Z\x14./remote;remoteprotob\x06proto3"
 
 var (
 	file_remote_kv_proto_rawDescOnce sync.Once
@@ -2014,7 +2210,7 @@ func file_remote_kv_proto_rawDescGZIP() []byte {
 }
 
 var file_remote_kv_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_remote_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_remote_kv_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_remote_kv_proto_goTypes = []any{
 	(Op)(0),                         // 0: remote.Op
 	(Action)(0),                     // 1: remote.Action
@@ -2042,22 +2238,25 @@ var file_remote_kv_proto_goTypes = []any{
 	(*Pairs)(nil),                   // 23: remote.Pairs
 	(*PairsPagination)(nil),         // 24: remote.PairsPagination
 	(*IndexPagination)(nil),         // 25: remote.IndexPagination
-	(*typesproto.H256)(nil),         // 26: types.H256
-	(*typesproto.H160)(nil),         // 27: types.H160
-	(*emptypb.Empty)(nil),           // 28: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 29: types.VersionReply
+	(*StateCacheLookupRequest)(nil), // 26: remote.StateCacheLookupRequest
+	(*StateCacheLookupReply)(nil),   // 27: remote.StateCacheLookupReply
+	(*StateCacheFillRequest)(nil),   // 28: remote.StateCacheFillRequest
+	(*typesproto.H256)(nil),         // 29: types.H256
+	(*typesproto.H160)(nil),         // 30: types.H160
+	(*emptypb.Empty)(nil),           // 31: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil), // 32: types.VersionReply
 }
 var file_remote_kv_proto_depIdxs = []int32{
 	0,  // 0: remote.Cursor.op:type_name -> remote.Op
-	26, // 1: remote.StorageChange.location:type_name -> types.H256
-	27, // 2: remote.AccountChange.address:type_name -> types.H160
+	29, // 1: remote.StorageChange.location:type_name -> types.H256
+	30, // 2: remote.AccountChange.address:type_name -> types.H160
 	1,  // 3: remote.AccountChange.action:type_name -> remote.Action
 	5,  // 4: remote.AccountChange.storage_changes:type_name -> remote.StorageChange
 	8,  // 5: remote.StateChangeBatch.change_batch:type_name -> remote.StateChange
 	2,  // 6: remote.StateChange.direction:type_name -> remote.Direction
-	26, // 7: remote.StateChange.block_hash:type_name -> types.H256
+	29, // 7: remote.StateChange.block_hash:type_name -> types.H256
 	6,  // 8: remote.StateChange.changes:type_name -> remote.AccountChange
-	28, // 9: remote.KV.Version:input_type -> google.protobuf.Empty
+	31, // 9: remote.KV.Version:input_type -> google.protobuf.Empty
 	3,  // 10: remote.KV.Tx:input_type -> remote.Cursor
 	9,  // 11: remote.KV.StateChanges:input_type -> remote.StateChangeRequest
 	10, // 12: remote.KV.Snapshots:input_type -> remote.SnapshotsRequest
@@ -2068,19 +2267,23 @@ var file_remote_kv_proto_depIdxs = []int32{
 	19, // 17: remote.KV.IndexRange:input_type -> remote.IndexRangeReq
 	21, // 18: remote.KV.HistoryRange:input_type -> remote.HistoryRangeReq
 	22, // 19: remote.KV.RangeAsOf:input_type -> remote.RangeAsOfReq
-	29, // 20: remote.KV.Version:output_type -> types.VersionReply
-	4,  // 21: remote.KV.Tx:output_type -> remote.Pair
-	7,  // 22: remote.KV.StateChanges:output_type -> remote.StateChangeBatch
-	11, // 23: remote.KV.Snapshots:output_type -> remote.SnapshotsReply
-	23, // 24: remote.KV.Range:output_type -> remote.Pairs
-	14, // 25: remote.KV.Sequence:output_type -> remote.SequenceReply
-	16, // 26: remote.KV.GetLatest:output_type -> remote.GetLatestReply
-	18, // 27: remote.KV.HistorySeek:output_type -> remote.HistorySeekReply
-	20, // 28: remote.KV.IndexRange:output_type -> remote.IndexRangeReply
-	23, // 29: remote.KV.HistoryRange:output_type -> remote.Pairs
-	23, // 30: remote.KV.RangeAsOf:output_type -> remote.Pairs
-	20, // [20:31] is the sub-list for method output_type
-	9,  // [9:20] is the sub-list for method input_type
+	26, // 20: remote.StateCache.Lookup:input_type -> remote.StateCacheLookupRequest
+	28, // 21: remote.StateCache.Fill:input_type -> remote.StateCacheFillRequest
+	32, // 22: remote.KV.Version:output_type -> types.VersionReply
+	4,  // 23: remote.KV.Tx:output_type -> remote.Pair
+	7,  // 24: remote.KV.StateChanges:output_type -> remote.StateChangeBatch
+	11, // 25: remote.KV.Snapshots:output_type -> remote.SnapshotsReply
+	23, // 26: remote.KV.Range:output_type -> remote.Pairs
+	14, // 27: remote.KV.Sequence:output_type -> remote.SequenceReply
+	16, // 28: remote.KV.GetLatest:output_type -> remote.GetLatestReply
+	18, // 29: remote.KV.HistorySeek:output_type -> remote.HistorySeekReply
+	20, // 30: remote.KV.IndexRange:output_type -> remote.IndexRangeReply
+	23, // 31: remote.KV.HistoryRange:output_type -> remote.Pairs
+	23, // 32: remote.KV.RangeAsOf:output_type -> remote.Pairs
+	27, // 33: remote.StateCache.Lookup:output_type -> remote.StateCacheLookupReply
+	31, // 34: remote.StateCache.Fill:output_type -> google.protobuf.Empty
+	22, // [22:35] is the sub-list for method output_type
+	9,  // [9:22] is the sub-list for method input_type
 	9,  // [9:9] is the sub-list for extension type_name
 	9,  // [9:9] is the sub-list for extension extendee
 	0,  // [0:9] is the sub-list for field type_name
@@ -2097,9 +2300,9 @@ func file_remote_kv_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_remote_kv_proto_rawDesc), len(file_remote_kv_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   23,
+			NumMessages:   26,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_remote_kv_proto_goTypes,
 		DependencyIndexes: file_remote_kv_proto_depIdxs,
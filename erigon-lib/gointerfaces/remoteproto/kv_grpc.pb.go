@@ -527,3 +527,151 @@ var KV_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "remote/kv.proto",
 }
+
+const (
+	StateCache_Lookup_FullMethodName = "/remote.StateCache/Lookup"
+	StateCache_Fill_FullMethodName   = "/remote.StateCache/Fill"
+)
+
+// StateCacheClient is the client API for StateCache service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Provides a state cache which is shared by rpcdaemons, the entries are invalidated by the state changes of new blocks
+type StateCacheClient interface {
+	// Lookup returns the value of an account, storage slot or code at the given state version
+	Lookup(ctx context.Context, in *StateCacheLookupRequest, opts ...grpc.CallOption) (*StateCacheLookupReply, error)
+	// Fill adds a value which was read from the database at the given state version
+	Fill(ctx context.Context, in *StateCacheFillRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type stateCacheClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStateCacheClient(cc grpc.ClientConnInterface) StateCacheClient {
+	return &stateCacheClient{cc}
+}
+
+func (c *stateCacheClient) Lookup(ctx context.Context, in *StateCacheLookupRequest, opts ...grpc.CallOption) (*StateCacheLookupReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StateCacheLookupReply)
+	err := c.cc.Invoke(ctx, StateCache_Lookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateCacheClient) Fill(ctx context.Context, in *StateCacheFillRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, StateCache_Fill_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StateCacheServer is the server API for StateCache service.
+// All implementations must embed UnimplementedStateCacheServer
+// for forward compatibility.
+//
+// Provides a state cache which is shared by rpcdaemons, the entries are invalidated by the state changes of new blocks
+type StateCacheServer interface {
+	// Lookup returns the value of an account, storage slot or code at the given state version
+	Lookup(context.Context, *StateCacheLookupRequest) (*StateCacheLookupReply, error)
+	// Fill adds a value which was read from the database at the given state version
+	Fill(context.Context, *StateCacheFillRequest) (*emptypb.Empty, error)
+	mustEmbedUnimplementedStateCacheServer()
+}
+
+// UnimplementedStateCacheServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStateCacheServer struct{}
+
+func (UnimplementedStateCacheServer) Lookup(context.Context, *StateCacheLookupRequest) (*StateCacheLookupReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedStateCacheServer) Fill(context.Context, *StateCacheFillRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fill not implemented")
+}
+func (UnimplementedStateCacheServer) mustEmbedUnimplementedStateCacheServer() {}
+func (UnimplementedStateCacheServer) testEmbeddedByValue()                    {}
+
+// UnsafeStateCacheServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StateCacheServer will
+// result in compilation errors.
+type UnsafeStateCacheServer interface {
+	mustEmbedUnimplementedStateCacheServer()
+}
+
+func RegisterStateCacheServer(s grpc.ServiceRegistrar, srv StateCacheServer) {
+	// If the following call pancis, it indicates UnimplementedStateCacheServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StateCache_ServiceDesc, srv)
+}
+
+func _StateCache_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateCacheLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateCacheServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateCache_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateCacheServer).Lookup(ctx, req.(*StateCacheLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateCache_Fill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateCacheFillRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateCacheServer).Fill(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StateCache_Fill_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateCacheServer).Fill(ctx, req.(*StateCacheFillRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StateCache_ServiceDesc is the grpc.ServiceDesc for StateCache service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StateCache_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remote.StateCache",
+	HandlerType: (*StateCacheServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _StateCache_Lookup_Handler,
+		},
+		{
+			MethodName: "Fill",
+			Handler:    _StateCache_Fill_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "remote/kv.proto",
+}
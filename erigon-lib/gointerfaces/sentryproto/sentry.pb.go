@@ -62,6 +62,9 @@ const (
 	MessageId_POOLED_TRANSACTIONS_66     MessageId = 31
 	// ======= eth 68 protocol ===========
 	MessageId_NEW_POOLED_TRANSACTION_HASHES_68 MessageId = 32
+	// ======= eth 69 protocol ===========
+	// Version 69 removed the total difficulty from Status and blooms from Receipts.
+	MessageId_BLOCK_RANGE_UPDATE_69 MessageId = 33
 )
 
 // Enum value maps for MessageId.
@@ -99,6 +102,7 @@ var (
 		30: "RECEIPTS_66",
 		31: "POOLED_TRANSACTIONS_66",
 		32: "NEW_POOLED_TRANSACTION_HASHES_68",
+		33: "BLOCK_RANGE_UPDATE_69",
 	}
 	MessageId_value = map[string]int32{
 		"STATUS_65":                        0,
@@ -133,6 +137,7 @@ var (
 		"RECEIPTS_66":                      30,
 		"POOLED_TRANSACTIONS_66":           31,
 		"NEW_POOLED_TRANSACTION_HASHES_68": 32,
+		"BLOCK_RANGE_UPDATE_69":            33,
 	}
 )
 
@@ -213,6 +218,7 @@ const (
 	Protocol_ETH66 Protocol = 1
 	Protocol_ETH67 Protocol = 2
 	Protocol_ETH68 Protocol = 3
+	Protocol_ETH69 Protocol = 4
 )
 
 // Enum value maps for Protocol.
@@ -222,12 +228,14 @@ var (
 		1: "ETH66",
 		2: "ETH67",
 		3: "ETH68",
+		4: "ETH69",
 	}
 	Protocol_value = map[string]int32{
 		"ETH65": 0,
 		"ETH66": 1,
 		"ETH67": 2,
 		"ETH68": 3,
+		"ETH69": 4,
 	}
 )
 
@@ -834,15 +842,16 @@ func (x *Forks) GetTimeForks() []uint64 {
 }
 
 type StatusData struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	NetworkId       uint64                 `protobuf:"varint,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
-	TotalDifficulty *typesproto.H256       `protobuf:"bytes,2,opt,name=total_difficulty,json=totalDifficulty,proto3" json:"total_difficulty,omitempty"`
-	BestHash        *typesproto.H256       `protobuf:"bytes,3,opt,name=best_hash,json=bestHash,proto3" json:"best_hash,omitempty"`
-	ForkData        *Forks                 `protobuf:"bytes,4,opt,name=fork_data,json=forkData,proto3" json:"fork_data,omitempty"`
-	MaxBlockHeight  uint64                 `protobuf:"varint,5,opt,name=max_block_height,json=maxBlockHeight,proto3" json:"max_block_height,omitempty"`
-	MaxBlockTime    uint64                 `protobuf:"varint,6,opt,name=max_block_time,json=maxBlockTime,proto3" json:"max_block_time,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	NetworkId          uint64                 `protobuf:"varint,1,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	TotalDifficulty    *typesproto.H256       `protobuf:"bytes,2,opt,name=total_difficulty,json=totalDifficulty,proto3" json:"total_difficulty,omitempty"`
+	BestHash           *typesproto.H256       `protobuf:"bytes,3,opt,name=best_hash,json=bestHash,proto3" json:"best_hash,omitempty"`
+	ForkData           *Forks                 `protobuf:"bytes,4,opt,name=fork_data,json=forkData,proto3" json:"fork_data,omitempty"`
+	MaxBlockHeight     uint64                 `protobuf:"varint,5,opt,name=max_block_height,json=maxBlockHeight,proto3" json:"max_block_height,omitempty"`
+	MaxBlockTime       uint64                 `protobuf:"varint,6,opt,name=max_block_time,json=maxBlockTime,proto3" json:"max_block_time,omitempty"`
+	MinimumBlockHeight uint64                 `protobuf:"varint,7,opt,name=minimum_block_height,json=minimumBlockHeight,proto3" json:"minimum_block_height,omitempty"` // first block served to peers, blocks before it are pruned
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *StatusData) Reset() {
@@ -917,6 +926,13 @@ func (x *StatusData) GetMaxBlockTime() uint64 {
 	return 0
 }
 
+func (x *StatusData) GetMinimumBlockHeight() uint64 {
+	if x != nil {
+		return x.MinimumBlockHeight
+	}
+	return 0
+}
+
 type SetStatusReply struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -1445,6 +1461,363 @@ func (x *AddPeerReply) GetSuccess() bool {
 	return false
 }
 
+type PeerStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        *typesproto.H512       `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"` // nil means all peers
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStatsRequest) Reset() {
+	*x = PeerStatsRequest{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatsRequest) ProtoMessage() {}
+
+func (x *PeerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatsRequest.ProtoReflect.Descriptor instead.
+func (*PeerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PeerStatsRequest) GetPeerId() *typesproto.H512 {
+	if x != nil {
+		return x.PeerId
+	}
+	return nil
+}
+
+// MessageStats: counters of messages with given id, bytes are sizes of encoded messages
+type MessageStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            MessageId              `protobuf:"varint,1,opt,name=id,proto3,enum=sentry.MessageId" json:"id,omitempty"`
+	InboundCount  uint64                 `protobuf:"varint,2,opt,name=inbound_count,json=inboundCount,proto3" json:"inbound_count,omitempty"`
+	InboundBytes  uint64                 `protobuf:"varint,3,opt,name=inbound_bytes,json=inboundBytes,proto3" json:"inbound_bytes,omitempty"`
+	OutboundCount uint64                 `protobuf:"varint,4,opt,name=outbound_count,json=outboundCount,proto3" json:"outbound_count,omitempty"`
+	OutboundBytes uint64                 `protobuf:"varint,5,opt,name=outbound_bytes,json=outboundBytes,proto3" json:"outbound_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageStats) Reset() {
+	*x = MessageStats{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageStats) ProtoMessage() {}
+
+func (x *MessageStats) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageStats.ProtoReflect.Descriptor instead.
+func (*MessageStats) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MessageStats) GetId() MessageId {
+	if x != nil {
+		return x.Id
+	}
+	return MessageId_STATUS_65
+}
+
+func (x *MessageStats) GetInboundCount() uint64 {
+	if x != nil {
+		return x.InboundCount
+	}
+	return 0
+}
+
+func (x *MessageStats) GetInboundBytes() uint64 {
+	if x != nil {
+		return x.InboundBytes
+	}
+	return 0
+}
+
+func (x *MessageStats) GetOutboundCount() uint64 {
+	if x != nil {
+		return x.OutboundCount
+	}
+	return 0
+}
+
+func (x *MessageStats) GetOutboundBytes() uint64 {
+	if x != nil {
+		return x.OutboundBytes
+	}
+	return 0
+}
+
+type PeerStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PeerId        *typesproto.H512       `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	Messages      []*MessageStats        `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStats) Reset() {
+	*x = PeerStats{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStats) ProtoMessage() {}
+
+func (x *PeerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStats.ProtoReflect.Descriptor instead.
+func (*PeerStats) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PeerStats) GetPeerId() *typesproto.H512 {
+	if x != nil {
+		return x.PeerId
+	}
+	return nil
+}
+
+func (x *PeerStats) GetMessages() []*MessageStats {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type PeerStatsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peers         []*PeerStats           `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerStatsReply) Reset() {
+	*x = PeerStatsReply{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerStatsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerStatsReply) ProtoMessage() {}
+
+func (x *PeerStatsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerStatsReply.ProtoReflect.Descriptor instead.
+func (*PeerStatsReply) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PeerStatsReply) GetPeers() []*PeerStats {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+type BanPeerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`            // enode url or node id
+	Duration      uint64                 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"` // in seconds, 0 means indefinitely
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanPeerRequest) Reset() {
+	*x = BanPeerRequest{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanPeerRequest) ProtoMessage() {}
+
+func (x *BanPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanPeerRequest.ProtoReflect.Descriptor instead.
+func (*BanPeerRequest) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BanPeerRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *BanPeerRequest) GetDuration() uint64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type UnbanPeerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"` // enode url or node id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanPeerRequest) Reset() {
+	*x = UnbanPeerRequest{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanPeerRequest) ProtoMessage() {}
+
+func (x *UnbanPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanPeerRequest.ProtoReflect.Descriptor instead.
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnbanPeerRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type BannedPeersReply struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Peers         []*typesproto.BannedPeerInfo `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannedPeersReply) Reset() {
+	*x = BannedPeersReply{}
+	mi := &file_p2psentry_sentry_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannedPeersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannedPeersReply) ProtoMessage() {}
+
+func (x *BannedPeersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_p2psentry_sentry_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannedPeersReply.ProtoReflect.Descriptor instead.
+func (*BannedPeersReply) Descriptor() ([]byte, []int) {
+	return file_p2psentry_sentry_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *BannedPeersReply) GetPeers() []*typesproto.BannedPeerInfo {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
 var File_p2psentry_sentry_proto protoreflect.FileDescriptor
 
 const file_p2psentry_sentry_proto_rawDesc = "" +
@@ -1481,7 +1854,7 @@ const file_p2psentry_sentry_proto_rawDesc = "" +
 	"\agenesis\x18\x01 \x01(\v2\v.types.H256R\agenesis\x12!\n" +
 	"\fheight_forks\x18\x02 \x03(\x04R\vheightForks\x12\x1d\n" +
 	"\n" +
-	"time_forks\x18\x03 \x03(\x04R\ttimeForks\"\x89\x02\n" +
+	"time_forks\x18\x03 \x03(\x04R\ttimeForks\"\xbb\x02\n" +
 	"\n" +
 	"StatusData\x12\x1d\n" +
 	"\n" +
@@ -1490,7 +1863,8 @@ const file_p2psentry_sentry_proto_rawDesc = "" +
 	"\tbest_hash\x18\x03 \x01(\v2\v.types.H256R\bbestHash\x12*\n" +
 	"\tfork_data\x18\x04 \x01(\v2\r.sentry.ForksR\bforkData\x12(\n" +
 	"\x10max_block_height\x18\x05 \x01(\x04R\x0emaxBlockHeight\x12$\n" +
-	"\x0emax_block_time\x18\x06 \x01(\x04R\fmaxBlockTime\"\x10\n" +
+	"\x0emax_block_time\x18\x06 \x01(\x04R\fmaxBlockTime\x120\n" +
+	"\x14minimum_block_height\x18\a \x01(\x04R\x12minimumBlockHeight\"\x10\n" +
 	"\x0eSetStatusReply\">\n" +
 	"\x0eHandShakeReply\x12,\n" +
 	"\bprotocol\x18\x01 \x01(\x0e2\x10.sentry.ProtocolR\bprotocol\"6\n" +
@@ -1520,7 +1894,27 @@ const file_p2psentry_sentry_proto_rawDesc = "" +
 	"\n" +
 	"Disconnect\x10\x01\"(\n" +
 	"\fAddPeerReply\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*\x80\x06\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"8\n" +
+	"\x10PeerStatsRequest\x12$\n" +
+	"\apeer_id\x18\x01 \x01(\v2\v.types.H512R\x06peerId\"\xc9\x01\n" +
+	"\fMessageStats\x12!\n" +
+	"\x02id\x18\x01 \x01(\x0e2\x11.sentry.MessageIdR\x02id\x12#\n" +
+	"\rinbound_count\x18\x02 \x01(\x04R\finboundCount\x12#\n" +
+	"\rinbound_bytes\x18\x03 \x01(\x04R\finboundBytes\x12%\n" +
+	"\x0eoutbound_count\x18\x04 \x01(\x04R\routboundCount\x12%\n" +
+	"\x0eoutbound_bytes\x18\x05 \x01(\x04R\routboundBytes\"c\n" +
+	"\tPeerStats\x12$\n" +
+	"\apeer_id\x18\x01 \x01(\v2\v.types.H512R\x06peerId\x120\n" +
+	"\bmessages\x18\x02 \x03(\v2\x14.sentry.MessageStatsR\bmessages\"9\n" +
+	"\x0ePeerStatsReply\x12'\n" +
+	"\x05peers\x18\x01 \x03(\v2\x11.sentry.PeerStatsR\x05peers\">\n" +
+	"\x0eBanPeerRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x1a\n" +
+	"\bduration\x18\x02 \x01(\x04R\bduration\"$\n" +
+	"\x10UnbanPeerRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"?\n" +
+	"\x10BannedPeersReply\x12+\n" +
+	"\x05peers\x18\x01 \x03(\v2\x15.types.BannedPeerInfoR\x05peers*\x9b\x06\n" +
 	"\tMessageId\x12\r\n" +
 	"\tSTATUS_65\x10\x00\x12\x18\n" +
 	"\x14GET_BLOCK_HEADERS_65\x10\x01\x12\x14\n" +
@@ -1554,14 +1948,16 @@ const file_p2psentry_sentry_proto_rawDesc = "" +
 	"\fNODE_DATA_66\x10\x1d\x12\x0f\n" +
 	"\vRECEIPTS_66\x10\x1e\x12\x1a\n" +
 	"\x16POOLED_TRANSACTIONS_66\x10\x1f\x12$\n" +
-	" NEW_POOLED_TRANSACTION_HASHES_68\x10 *\x17\n" +
+	" NEW_POOLED_TRANSACTION_HASHES_68\x10 \x12\x19\n" +
+	"\x15BLOCK_RANGE_UPDATE_69\x10!*\x17\n" +
 	"\vPenaltyKind\x12\b\n" +
-	"\x04Kick\x10\x00*6\n" +
+	"\x04Kick\x10\x00*A\n" +
 	"\bProtocol\x12\t\n" +
 	"\x05ETH65\x10\x00\x12\t\n" +
 	"\x05ETH66\x10\x01\x12\t\n" +
 	"\x05ETH67\x10\x02\x12\t\n" +
-	"\x05ETH68\x10\x032\xdc\a\n" +
+	"\x05ETH68\x10\x03\x12\t\n" +
+	"\x05ETH69\x10\x042\xd6\t\n" +
 	"\x06Sentry\x127\n" +
 	"\tSetStatus\x12\x12.sentry.StatusData\x1a\x16.sentry.SetStatusReply\x12C\n" +
 	"\fPenalizePeer\x12\x1b.sentry.PenalizePeerRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
@@ -1578,7 +1974,11 @@ const file_p2psentry_sentry_proto_rawDesc = "" +
 	"\n" +
 	"PeerEvents\x12\x19.sentry.PeerEventsRequest\x1a\x11.sentry.PeerEvent0\x01\x127\n" +
 	"\aAddPeer\x12\x16.sentry.AddPeerRequest\x1a\x14.sentry.AddPeerReply\x128\n" +
-	"\bNodeInfo\x12\x16.google.protobuf.Empty\x1a\x14.types.NodeInfoReplyB\x16Z\x14./sentry;sentryprotob\x06proto3"
+	"\bNodeInfo\x12\x16.google.protobuf.Empty\x1a\x14.types.NodeInfoReply\x12=\n" +
+	"\tPeerStats\x12\x18.sentry.PeerStatsRequest\x1a\x16.sentry.PeerStatsReply\x129\n" +
+	"\aBanPeer\x12\x16.sentry.BanPeerRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\tUnbanPeer\x12\x18.sentry.UnbanPeerRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\vBannedPeers\x12\x16.google.protobuf.Empty\x1a\x18.sentry.BannedPeersReplyB\x16Z\x14./sentry;sentryprotob\x06proto3"
 
 var (
 	file_p2psentry_sentry_proto_rawDescOnce sync.Once
@@ -1593,7 +1993,7 @@ func file_p2psentry_sentry_proto_rawDescGZIP() []byte {
 }
 
 var file_p2psentry_sentry_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_p2psentry_sentry_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_p2psentry_sentry_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_p2psentry_sentry_proto_goTypes = []any{
 	(MessageId)(0),                          // 0: sentry.MessageId
 	(PenaltyKind)(0),                        // 1: sentry.PenaltyKind
@@ -1622,72 +2022,94 @@ var file_p2psentry_sentry_proto_goTypes = []any{
 	(*PeerEventsRequest)(nil),               // 24: sentry.PeerEventsRequest
 	(*PeerEvent)(nil),                       // 25: sentry.PeerEvent
 	(*AddPeerReply)(nil),                    // 26: sentry.AddPeerReply
-	(*typesproto.H512)(nil),                 // 27: types.H512
-	(*typesproto.H256)(nil),                 // 28: types.H256
-	(*typesproto.PeerInfo)(nil),             // 29: types.PeerInfo
-	(*emptypb.Empty)(nil),                   // 30: google.protobuf.Empty
-	(*typesproto.NodeInfoReply)(nil),        // 31: types.NodeInfoReply
+	(*PeerStatsRequest)(nil),                // 27: sentry.PeerStatsRequest
+	(*MessageStats)(nil),                    // 28: sentry.MessageStats
+	(*PeerStats)(nil),                       // 29: sentry.PeerStats
+	(*PeerStatsReply)(nil),                  // 30: sentry.PeerStatsReply
+	(*BanPeerRequest)(nil),                  // 31: sentry.BanPeerRequest
+	(*UnbanPeerRequest)(nil),                // 32: sentry.UnbanPeerRequest
+	(*BannedPeersReply)(nil),                // 33: sentry.BannedPeersReply
+	(*typesproto.H512)(nil),                 // 34: types.H512
+	(*typesproto.H256)(nil),                 // 35: types.H256
+	(*typesproto.PeerInfo)(nil),             // 36: types.PeerInfo
+	(*typesproto.BannedPeerInfo)(nil),       // 37: types.BannedPeerInfo
+	(*emptypb.Empty)(nil),                   // 38: google.protobuf.Empty
+	(*typesproto.NodeInfoReply)(nil),        // 39: types.NodeInfoReply
 }
 var file_p2psentry_sentry_proto_depIdxs = []int32{
 	0,  // 0: sentry.OutboundMessageData.id:type_name -> sentry.MessageId
 	4,  // 1: sentry.SendMessageByMinBlockRequest.data:type_name -> sentry.OutboundMessageData
 	4,  // 2: sentry.SendMessageByIdRequest.data:type_name -> sentry.OutboundMessageData
-	27, // 3: sentry.SendMessageByIdRequest.peer_id:type_name -> types.H512
+	34, // 3: sentry.SendMessageByIdRequest.peer_id:type_name -> types.H512
 	4,  // 4: sentry.SendMessageToRandomPeersRequest.data:type_name -> sentry.OutboundMessageData
-	27, // 5: sentry.SentPeers.peers:type_name -> types.H512
-	27, // 6: sentry.PenalizePeerRequest.peer_id:type_name -> types.H512
+	34, // 5: sentry.SentPeers.peers:type_name -> types.H512
+	34, // 6: sentry.PenalizePeerRequest.peer_id:type_name -> types.H512
 	1,  // 7: sentry.PenalizePeerRequest.penalty:type_name -> sentry.PenaltyKind
-	27, // 8: sentry.PeerMinBlockRequest.peer_id:type_name -> types.H512
+	34, // 8: sentry.PeerMinBlockRequest.peer_id:type_name -> types.H512
 	0,  // 9: sentry.InboundMessage.id:type_name -> sentry.MessageId
-	27, // 10: sentry.InboundMessage.peer_id:type_name -> types.H512
-	28, // 11: sentry.Forks.genesis:type_name -> types.H256
-	28, // 12: sentry.StatusData.total_difficulty:type_name -> types.H256
-	28, // 13: sentry.StatusData.best_hash:type_name -> types.H256
+	34, // 10: sentry.InboundMessage.peer_id:type_name -> types.H512
+	35, // 11: sentry.Forks.genesis:type_name -> types.H256
+	35, // 12: sentry.StatusData.total_difficulty:type_name -> types.H256
+	35, // 13: sentry.StatusData.best_hash:type_name -> types.H256
 	13, // 14: sentry.StatusData.fork_data:type_name -> sentry.Forks
 	2,  // 15: sentry.HandShakeReply.protocol:type_name -> sentry.Protocol
 	0,  // 16: sentry.MessagesRequest.ids:type_name -> sentry.MessageId
-	29, // 17: sentry.PeersReply.peers:type_name -> types.PeerInfo
+	36, // 17: sentry.PeersReply.peers:type_name -> types.PeerInfo
 	2,  // 18: sentry.PeerCountPerProtocol.protocol:type_name -> sentry.Protocol
 	20, // 19: sentry.PeerCountReply.counts_per_protocol:type_name -> sentry.PeerCountPerProtocol
-	27, // 20: sentry.PeerByIdRequest.peer_id:type_name -> types.H512
-	29, // 21: sentry.PeerByIdReply.peer:type_name -> types.PeerInfo
-	27, // 22: sentry.PeerEvent.peer_id:type_name -> types.H512
+	34, // 20: sentry.PeerByIdRequest.peer_id:type_name -> types.H512
+	36, // 21: sentry.PeerByIdReply.peer:type_name -> types.PeerInfo
+	34, // 22: sentry.PeerEvent.peer_id:type_name -> types.H512
 	3,  // 23: sentry.PeerEvent.event_id:type_name -> sentry.PeerEvent.PeerEventId
-	14, // 24: sentry.Sentry.SetStatus:input_type -> sentry.StatusData
-	9,  // 25: sentry.Sentry.PenalizePeer:input_type -> sentry.PenalizePeerRequest
-	10, // 26: sentry.Sentry.PeerMinBlock:input_type -> sentry.PeerMinBlockRequest
-	30, // 27: sentry.Sentry.HandShake:input_type -> google.protobuf.Empty
-	5,  // 28: sentry.Sentry.SendMessageByMinBlock:input_type -> sentry.SendMessageByMinBlockRequest
-	6,  // 29: sentry.Sentry.SendMessageById:input_type -> sentry.SendMessageByIdRequest
-	7,  // 30: sentry.Sentry.SendMessageToRandomPeers:input_type -> sentry.SendMessageToRandomPeersRequest
-	4,  // 31: sentry.Sentry.SendMessageToAll:input_type -> sentry.OutboundMessageData
-	17, // 32: sentry.Sentry.Messages:input_type -> sentry.MessagesRequest
-	30, // 33: sentry.Sentry.Peers:input_type -> google.protobuf.Empty
-	19, // 34: sentry.Sentry.PeerCount:input_type -> sentry.PeerCountRequest
-	22, // 35: sentry.Sentry.PeerById:input_type -> sentry.PeerByIdRequest
-	24, // 36: sentry.Sentry.PeerEvents:input_type -> sentry.PeerEventsRequest
-	11, // 37: sentry.Sentry.AddPeer:input_type -> sentry.AddPeerRequest
-	30, // 38: sentry.Sentry.NodeInfo:input_type -> google.protobuf.Empty
-	15, // 39: sentry.Sentry.SetStatus:output_type -> sentry.SetStatusReply
-	30, // 40: sentry.Sentry.PenalizePeer:output_type -> google.protobuf.Empty
-	30, // 41: sentry.Sentry.PeerMinBlock:output_type -> google.protobuf.Empty
-	16, // 42: sentry.Sentry.HandShake:output_type -> sentry.HandShakeReply
-	8,  // 43: sentry.Sentry.SendMessageByMinBlock:output_type -> sentry.SentPeers
-	8,  // 44: sentry.Sentry.SendMessageById:output_type -> sentry.SentPeers
-	8,  // 45: sentry.Sentry.SendMessageToRandomPeers:output_type -> sentry.SentPeers
-	8,  // 46: sentry.Sentry.SendMessageToAll:output_type -> sentry.SentPeers
-	12, // 47: sentry.Sentry.Messages:output_type -> sentry.InboundMessage
-	18, // 48: sentry.Sentry.Peers:output_type -> sentry.PeersReply
-	21, // 49: sentry.Sentry.PeerCount:output_type -> sentry.PeerCountReply
-	23, // 50: sentry.Sentry.PeerById:output_type -> sentry.PeerByIdReply
-	25, // 51: sentry.Sentry.PeerEvents:output_type -> sentry.PeerEvent
-	26, // 52: sentry.Sentry.AddPeer:output_type -> sentry.AddPeerReply
-	31, // 53: sentry.Sentry.NodeInfo:output_type -> types.NodeInfoReply
-	39, // [39:54] is the sub-list for method output_type
-	24, // [24:39] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	34, // 24: sentry.PeerStatsRequest.peer_id:type_name -> types.H512
+	0,  // 25: sentry.MessageStats.id:type_name -> sentry.MessageId
+	34, // 26: sentry.PeerStats.peer_id:type_name -> types.H512
+	28, // 27: sentry.PeerStats.messages:type_name -> sentry.MessageStats
+	29, // 28: sentry.PeerStatsReply.peers:type_name -> sentry.PeerStats
+	37, // 29: sentry.BannedPeersReply.peers:type_name -> types.BannedPeerInfo
+	14, // 30: sentry.Sentry.SetStatus:input_type -> sentry.StatusData
+	9,  // 31: sentry.Sentry.PenalizePeer:input_type -> sentry.PenalizePeerRequest
+	10, // 32: sentry.Sentry.PeerMinBlock:input_type -> sentry.PeerMinBlockRequest
+	38, // 33: sentry.Sentry.HandShake:input_type -> google.protobuf.Empty
+	5,  // 34: sentry.Sentry.SendMessageByMinBlock:input_type -> sentry.SendMessageByMinBlockRequest
+	6,  // 35: sentry.Sentry.SendMessageById:input_type -> sentry.SendMessageByIdRequest
+	7,  // 36: sentry.Sentry.SendMessageToRandomPeers:input_type -> sentry.SendMessageToRandomPeersRequest
+	4,  // 37: sentry.Sentry.SendMessageToAll:input_type -> sentry.OutboundMessageData
+	17, // 38: sentry.Sentry.Messages:input_type -> sentry.MessagesRequest
+	38, // 39: sentry.Sentry.Peers:input_type -> google.protobuf.Empty
+	19, // 40: sentry.Sentry.PeerCount:input_type -> sentry.PeerCountRequest
+	22, // 41: sentry.Sentry.PeerById:input_type -> sentry.PeerByIdRequest
+	24, // 42: sentry.Sentry.PeerEvents:input_type -> sentry.PeerEventsRequest
+	11, // 43: sentry.Sentry.AddPeer:input_type -> sentry.AddPeerRequest
+	38, // 44: sentry.Sentry.NodeInfo:input_type -> google.protobuf.Empty
+	27, // 45: sentry.Sentry.PeerStats:input_type -> sentry.PeerStatsRequest
+	31, // 46: sentry.Sentry.BanPeer:input_type -> sentry.BanPeerRequest
+	32, // 47: sentry.Sentry.UnbanPeer:input_type -> sentry.UnbanPeerRequest
+	38, // 48: sentry.Sentry.BannedPeers:input_type -> google.protobuf.Empty
+	15, // 49: sentry.Sentry.SetStatus:output_type -> sentry.SetStatusReply
+	38, // 50: sentry.Sentry.PenalizePeer:output_type -> google.protobuf.Empty
+	38, // 51: sentry.Sentry.PeerMinBlock:output_type -> google.protobuf.Empty
+	16, // 52: sentry.Sentry.HandShake:output_type -> sentry.HandShakeReply
+	8,  // 53: sentry.Sentry.SendMessageByMinBlock:output_type -> sentry.SentPeers
+	8,  // 54: sentry.Sentry.SendMessageById:output_type -> sentry.SentPeers
+	8,  // 55: sentry.Sentry.SendMessageToRandomPeers:output_type -> sentry.SentPeers
+	8,  // 56: sentry.Sentry.SendMessageToAll:output_type -> sentry.SentPeers
+	12, // 57: sentry.Sentry.Messages:output_type -> sentry.InboundMessage
+	18, // 58: sentry.Sentry.Peers:output_type -> sentry.PeersReply
+	21, // 59: sentry.Sentry.PeerCount:output_type -> sentry.PeerCountReply
+	23, // 60: sentry.Sentry.PeerById:output_type -> sentry.PeerByIdReply
+	25, // 61: sentry.Sentry.PeerEvents:output_type -> sentry.PeerEvent
+	26, // 62: sentry.Sentry.AddPeer:output_type -> sentry.AddPeerReply
+	39, // 63: sentry.Sentry.NodeInfo:output_type -> types.NodeInfoReply
+	30, // 64: sentry.Sentry.PeerStats:output_type -> sentry.PeerStatsReply
+	38, // 65: sentry.Sentry.BanPeer:output_type -> google.protobuf.Empty
+	38, // 66: sentry.Sentry.UnbanPeer:output_type -> google.protobuf.Empty
+	33, // 67: sentry.Sentry.BannedPeers:output_type -> sentry.BannedPeersReply
+	49, // [49:68] is the sub-list for method output_type
+	30, // [30:49] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_p2psentry_sentry_proto_init() }
@@ -1702,7 +2124,7 @@ func file_p2psentry_sentry_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_p2psentry_sentry_proto_rawDesc), len(file_p2psentry_sentry_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   23,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
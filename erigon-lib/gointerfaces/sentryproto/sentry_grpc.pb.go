@@ -36,6 +36,10 @@ const (
 	Sentry_PeerEvents_FullMethodName               = "/sentry.Sentry/PeerEvents"
 	Sentry_AddPeer_FullMethodName                  = "/sentry.Sentry/AddPeer"
 	Sentry_NodeInfo_FullMethodName                 = "/sentry.Sentry/NodeInfo"
+	Sentry_PeerStats_FullMethodName                = "/sentry.Sentry/PeerStats"
+	Sentry_BanPeer_FullMethodName                  = "/sentry.Sentry/BanPeer"
+	Sentry_UnbanPeer_FullMethodName                = "/sentry.Sentry/UnbanPeer"
+	Sentry_BannedPeers_FullMethodName              = "/sentry.Sentry/BannedPeers"
 )
 
 // SentryClient is the client API for Sentry service.
@@ -65,6 +69,14 @@ type SentryClient interface {
 	AddPeer(ctx context.Context, in *AddPeerRequest, opts ...grpc.CallOption) (*AddPeerReply, error)
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*typesproto.NodeInfoReply, error)
+	// PeerStats returns per peer message and byte counters, broken down by message id.
+	PeerStats(ctx context.Context, in *PeerStatsRequest, opts ...grpc.CallOption) (*PeerStatsReply, error)
+	// BanPeer disconnects the given node and refuses connections to it for the given duration.
+	BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UnbanPeer lifts the ban of the given node.
+	UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// BannedPeers returns the currently banned nodes.
+	BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BannedPeersReply, error)
 }
 
 type sentryClient struct {
@@ -243,6 +255,46 @@ func (c *sentryClient) NodeInfo(ctx context.Context, in *emptypb.Empty, opts ...
 	return out, nil
 }
 
+func (c *sentryClient) PeerStats(ctx context.Context, in *PeerStatsRequest, opts ...grpc.CallOption) (*PeerStatsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerStatsReply)
+	err := c.cc.Invoke(ctx, Sentry_PeerStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryClient) BanPeer(ctx context.Context, in *BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Sentry_BanPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryClient) UnbanPeer(ctx context.Context, in *UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Sentry_UnbanPeer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sentryClient) BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*BannedPeersReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BannedPeersReply)
+	err := c.cc.Invoke(ctx, Sentry_BannedPeers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SentryServer is the server API for Sentry service.
 // All implementations must embed UnimplementedSentryServer
 // for forward compatibility.
@@ -270,6 +322,14 @@ type SentryServer interface {
 	AddPeer(context.Context, *AddPeerRequest) (*AddPeerReply, error)
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(context.Context, *emptypb.Empty) (*typesproto.NodeInfoReply, error)
+	// PeerStats returns per peer message and byte counters, broken down by message id.
+	PeerStats(context.Context, *PeerStatsRequest) (*PeerStatsReply, error)
+	// BanPeer disconnects the given node and refuses connections to it for the given duration.
+	BanPeer(context.Context, *BanPeerRequest) (*emptypb.Empty, error)
+	// UnbanPeer lifts the ban of the given node.
+	UnbanPeer(context.Context, *UnbanPeerRequest) (*emptypb.Empty, error)
+	// BannedPeers returns the currently banned nodes.
+	BannedPeers(context.Context, *emptypb.Empty) (*BannedPeersReply, error)
 	mustEmbedUnimplementedSentryServer()
 }
 
@@ -325,6 +385,18 @@ func (UnimplementedSentryServer) AddPeer(context.Context, *AddPeerRequest) (*Add
 func (UnimplementedSentryServer) NodeInfo(context.Context, *emptypb.Empty) (*typesproto.NodeInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NodeInfo not implemented")
 }
+func (UnimplementedSentryServer) PeerStats(context.Context, *PeerStatsRequest) (*PeerStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerStats not implemented")
+}
+func (UnimplementedSentryServer) BanPeer(context.Context, *BanPeerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BanPeer not implemented")
+}
+func (UnimplementedSentryServer) UnbanPeer(context.Context, *UnbanPeerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnbanPeer not implemented")
+}
+func (UnimplementedSentryServer) BannedPeers(context.Context, *emptypb.Empty) (*BannedPeersReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BannedPeers not implemented")
+}
 func (UnimplementedSentryServer) mustEmbedUnimplementedSentryServer() {}
 func (UnimplementedSentryServer) testEmbeddedByValue()                {}
 
@@ -602,6 +674,78 @@ func _Sentry_NodeInfo_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Sentry_PeerStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryServer).PeerStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sentry_PeerStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryServer).PeerStats(ctx, req.(*PeerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sentry_BanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryServer).BanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sentry_BanPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryServer).BanPeer(ctx, req.(*BanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sentry_UnbanPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryServer).UnbanPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sentry_UnbanPeer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryServer).UnbanPeer(ctx, req.(*UnbanPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sentry_BannedPeers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SentryServer).BannedPeers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sentry_BannedPeers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SentryServer).BannedPeers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Sentry_ServiceDesc is the grpc.ServiceDesc for Sentry service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -661,6 +805,22 @@ var Sentry_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "NodeInfo",
 			Handler:    _Sentry_NodeInfo_Handler,
 		},
+		{
+			MethodName: "PeerStats",
+			Handler:    _Sentry_PeerStats_Handler,
+		},
+		{
+			MethodName: "BanPeer",
+			Handler:    _Sentry_BanPeer_Handler,
+		},
+		{
+			MethodName: "UnbanPeer",
+			Handler:    _Sentry_UnbanPeer_Handler,
+		},
+		{
+			MethodName: "BannedPeers",
+			Handler:    _Sentry_BannedPeers_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -82,6 +82,84 @@ func (c *MockSentryServerAddPeerCall) DoAndReturn(f func(context.Context, *AddPe
 	return c
 }
 
+// BanPeer mocks base method.
+func (m *MockSentryServer) BanPeer(arg0 context.Context, arg1 *BanPeerRequest) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanPeer", arg0, arg1)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanPeer indicates an expected call of BanPeer.
+func (mr *MockSentryServerMockRecorder) BanPeer(arg0, arg1 any) *MockSentryServerBanPeerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPeer", reflect.TypeOf((*MockSentryServer)(nil).BanPeer), arg0, arg1)
+	return &MockSentryServerBanPeerCall{Call: call}
+}
+
+// MockSentryServerBanPeerCall wrap *gomock.Call
+type MockSentryServerBanPeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerBanPeerCall) Return(arg0 *emptypb.Empty, arg1 error) *MockSentryServerBanPeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerBanPeerCall) Do(f func(context.Context, *BanPeerRequest) (*emptypb.Empty, error)) *MockSentryServerBanPeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerBanPeerCall) DoAndReturn(f func(context.Context, *BanPeerRequest) (*emptypb.Empty, error)) *MockSentryServerBanPeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// BannedPeers mocks base method.
+func (m *MockSentryServer) BannedPeers(arg0 context.Context, arg1 *emptypb.Empty) (*BannedPeersReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BannedPeers", arg0, arg1)
+	ret0, _ := ret[0].(*BannedPeersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BannedPeers indicates an expected call of BannedPeers.
+func (mr *MockSentryServerMockRecorder) BannedPeers(arg0, arg1 any) *MockSentryServerBannedPeersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BannedPeers", reflect.TypeOf((*MockSentryServer)(nil).BannedPeers), arg0, arg1)
+	return &MockSentryServerBannedPeersCall{Call: call}
+}
+
+// MockSentryServerBannedPeersCall wrap *gomock.Call
+type MockSentryServerBannedPeersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerBannedPeersCall) Return(arg0 *BannedPeersReply, arg1 error) *MockSentryServerBannedPeersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerBannedPeersCall) Do(f func(context.Context, *emptypb.Empty) (*BannedPeersReply, error)) *MockSentryServerBannedPeersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerBannedPeersCall) DoAndReturn(f func(context.Context, *emptypb.Empty) (*BannedPeersReply, error)) *MockSentryServerBannedPeersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // HandShake mocks base method.
 func (m *MockSentryServer) HandShake(arg0 context.Context, arg1 *emptypb.Empty) (*HandShakeReply, error) {
 	m.ctrl.T.Helper()
@@ -353,6 +431,45 @@ func (c *MockSentryServerPeerMinBlockCall) DoAndReturn(f func(context.Context, *
 	return c
 }
 
+// PeerStats mocks base method.
+func (m *MockSentryServer) PeerStats(arg0 context.Context, arg1 *PeerStatsRequest) (*PeerStatsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerStats", arg0, arg1)
+	ret0, _ := ret[0].(*PeerStatsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerStats indicates an expected call of PeerStats.
+func (mr *MockSentryServerMockRecorder) PeerStats(arg0, arg1 any) *MockSentryServerPeerStatsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerStats", reflect.TypeOf((*MockSentryServer)(nil).PeerStats), arg0, arg1)
+	return &MockSentryServerPeerStatsCall{Call: call}
+}
+
+// MockSentryServerPeerStatsCall wrap *gomock.Call
+type MockSentryServerPeerStatsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerPeerStatsCall) Return(arg0 *PeerStatsReply, arg1 error) *MockSentryServerPeerStatsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerPeerStatsCall) Do(f func(context.Context, *PeerStatsRequest) (*PeerStatsReply, error)) *MockSentryServerPeerStatsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerPeerStatsCall) DoAndReturn(f func(context.Context, *PeerStatsRequest) (*PeerStatsReply, error)) *MockSentryServerPeerStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Peers mocks base method.
 func (m *MockSentryServer) Peers(arg0 context.Context, arg1 *emptypb.Empty) (*PeersReply, error) {
 	m.ctrl.T.Helper()
@@ -626,6 +743,45 @@ func (c *MockSentryServerSetStatusCall) DoAndReturn(f func(context.Context, *Sta
 	return c
 }
 
+// UnbanPeer mocks base method.
+func (m *MockSentryServer) UnbanPeer(arg0 context.Context, arg1 *UnbanPeerRequest) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanPeer", arg0, arg1)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanPeer indicates an expected call of UnbanPeer.
+func (mr *MockSentryServerMockRecorder) UnbanPeer(arg0, arg1 any) *MockSentryServerUnbanPeerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPeer", reflect.TypeOf((*MockSentryServer)(nil).UnbanPeer), arg0, arg1)
+	return &MockSentryServerUnbanPeerCall{Call: call}
+}
+
+// MockSentryServerUnbanPeerCall wrap *gomock.Call
+type MockSentryServerUnbanPeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerUnbanPeerCall) Return(arg0 *emptypb.Empty, arg1 error) *MockSentryServerUnbanPeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerUnbanPeerCall) Do(f func(context.Context, *UnbanPeerRequest) (*emptypb.Empty, error)) *MockSentryServerUnbanPeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerUnbanPeerCall) DoAndReturn(f func(context.Context, *UnbanPeerRequest) (*emptypb.Empty, error)) *MockSentryServerUnbanPeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // mustEmbedUnimplementedSentryServer mocks base method.
 func (m *MockSentryServer) mustEmbedUnimplementedSentryServer() {
 	m.ctrl.T.Helper()
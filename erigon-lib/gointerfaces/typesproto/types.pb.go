@@ -908,6 +908,7 @@ type PeerInfo struct {
 	ConnIsInbound  bool                   `protobuf:"varint,8,opt,name=conn_is_inbound,json=connIsInbound,proto3" json:"conn_is_inbound,omitempty"`
 	ConnIsTrusted  bool                   `protobuf:"varint,9,opt,name=conn_is_trusted,json=connIsTrusted,proto3" json:"conn_is_trusted,omitempty"`
 	ConnIsStatic   bool                   `protobuf:"varint,10,opt,name=conn_is_static,json=connIsStatic,proto3" json:"conn_is_static,omitempty"`
+	Penalties      uint64                 `protobuf:"varint,11,opt,name=penalties,proto3" json:"penalties,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -1012,6 +1013,13 @@ func (x *PeerInfo) GetConnIsStatic() bool {
 	return false
 }
 
+func (x *PeerInfo) GetPenalties() uint64 {
+	if x != nil {
+		return x.Penalties
+	}
+	return 0
+}
+
 type ExecutionPayloadBodyV1 struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transactions  [][]byte               `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
@@ -1328,6 +1336,66 @@ func (x *Authorization) GetS() []byte {
 	return nil
 }
 
+type BannedPeerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Penalties     uint64                 `protobuf:"varint,2,opt,name=penalties,proto3" json:"penalties,omitempty"`
+	BannedUntil   uint64                 `protobuf:"varint,3,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"` // unix time at which the ban expires, 0 if banned indefinitely
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannedPeerInfo) Reset() {
+	*x = BannedPeerInfo{}
+	mi := &file_types_types_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannedPeerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannedPeerInfo) ProtoMessage() {}
+
+func (x *BannedPeerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_types_types_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannedPeerInfo.ProtoReflect.Descriptor instead.
+func (*BannedPeerInfo) Descriptor() ([]byte, []int) {
+	return file_types_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *BannedPeerInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BannedPeerInfo) GetPenalties() uint64 {
+	if x != nil {
+		return x.Penalties
+	}
+	return 0
+}
+
+func (x *BannedPeerInfo) GetBannedUntil() uint64 {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return 0
+}
+
 var file_types_types_proto_extTypes = []protoimpl.ExtensionInfo{
 	{
 		ExtendedType:  (*descriptorpb.FileOptions)(nil),
@@ -1442,7 +1510,7 @@ const file_types_types_proto_rawDesc = "" +
 	"\x03enr\x18\x04 \x01(\tR\x03enr\x12*\n" +
 	"\x05ports\x18\x05 \x01(\v2\x14.types.NodeInfoPortsR\x05ports\x12#\n" +
 	"\rlistener_addr\x18\x06 \x01(\tR\flistenerAddr\x12\x1c\n" +
-	"\tprotocols\x18\a \x01(\fR\tprotocols\"\xb2\x02\n" +
+	"\tprotocols\x18\a \x01(\fR\tprotocols\"\xd0\x02\n" +
 	"\bPeerInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -1454,7 +1522,8 @@ const file_types_types_proto_rawDesc = "" +
 	"\x0fconn_is_inbound\x18\b \x01(\bR\rconnIsInbound\x12&\n" +
 	"\x0fconn_is_trusted\x18\t \x01(\bR\rconnIsTrusted\x12$\n" +
 	"\x0econn_is_static\x18\n" +
-	" \x01(\bR\fconnIsStatic\"q\n" +
+	" \x01(\bR\fconnIsStatic\x12\x1c\n" +
+	"\tpenalties\x18\v \x01(\x04R\tpenalties\"q\n" +
 	"\x16ExecutionPayloadBodyV1\x12\"\n" +
 	"\ftransactions\x18\x01 \x03(\fR\ftransactions\x123\n" +
 	"\vwithdrawals\x18\x02 \x03(\v2\x11.types.WithdrawalR\vwithdrawals\"\xb5\x05\n" +
@@ -1485,7 +1554,11 @@ const file_types_types_proto_rawDesc = "" +
 	"\x05nonce\x18\x03 \x01(\x04R\x05nonce\x12\x19\n" +
 	"\by_parity\x18\x04 \x01(\rR\ayParity\x12\f\n" +
 	"\x01r\x18\x05 \x01(\fR\x01r\x12\f\n" +
-	"\x01s\x18\x06 \x01(\fR\x01s:R\n" +
+	"\x01s\x18\x06 \x01(\fR\x01s\"a\n" +
+	"\x0eBannedPeerInfo\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
+	"\tpenalties\x18\x02 \x01(\x04R\tpenalties\x12!\n" +
+	"\fbanned_until\x18\x03 \x01(\x04R\vbannedUntil:R\n" +
 	"\x15service_major_version\x12\x1c.google.protobuf.FileOptions\x18ц\x03 \x01(\rR\x13serviceMajorVersion:R\n" +
 	"\x15service_minor_version\x12\x1c.google.protobuf.FileOptions\x18҆\x03 \x01(\rR\x13serviceMinorVersion:R\n" +
 	"\x15service_patch_version\x12\x1c.google.protobuf.FileOptions\x18ӆ\x03 \x01(\rR\x13servicePatchVersionB\x14Z\x12./types;typesprotob\x06proto3"
@@ -1502,7 +1575,7 @@ func file_types_types_proto_rawDescGZIP() []byte {
 	return file_types_types_proto_rawDescData
 }
 
-var file_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_types_types_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_types_types_proto_goTypes = []any{
 	(*H128)(nil),                          // 0: types.H128
 	(*H160)(nil),                          // 1: types.H160
@@ -1521,7 +1594,8 @@ var file_types_types_proto_goTypes = []any{
 	(*ExecutionPayloadBodyV1)(nil),        // 14: types.ExecutionPayloadBodyV1
 	(*AccountAbstractionTransaction)(nil), // 15: types.AccountAbstractionTransaction
 	(*Authorization)(nil),                 // 16: types.Authorization
-	(*descriptorpb.FileOptions)(nil),      // 17: google.protobuf.FileOptions
+	(*BannedPeerInfo)(nil),                // 17: types.BannedPeerInfo
+	(*descriptorpb.FileOptions)(nil),      // 18: google.protobuf.FileOptions
 }
 var file_types_types_proto_depIdxs = []int32{
 	0,  // 0: types.H160.hi:type_name -> types.H128
@@ -1546,9 +1620,9 @@ var file_types_types_proto_depIdxs = []int32{
 	11, // 19: types.NodeInfoReply.ports:type_name -> types.NodeInfoPorts
 	8,  // 20: types.ExecutionPayloadBodyV1.withdrawals:type_name -> types.Withdrawal
 	16, // 21: types.AccountAbstractionTransaction.authorizations:type_name -> types.Authorization
-	17, // 22: types.service_major_version:extendee -> google.protobuf.FileOptions
-	17, // 23: types.service_minor_version:extendee -> google.protobuf.FileOptions
-	17, // 24: types.service_patch_version:extendee -> google.protobuf.FileOptions
+	18, // 22: types.service_major_version:extendee -> google.protobuf.FileOptions
+	18, // 23: types.service_minor_version:extendee -> google.protobuf.FileOptions
+	18, // 24: types.service_patch_version:extendee -> google.protobuf.FileOptions
 	25, // [25:25] is the sub-list for method output_type
 	25, // [25:25] is the sub-list for method input_type
 	25, // [25:25] is the sub-list for extension type_name
@@ -1568,7 +1642,7 @@ func file_types_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_types_types_proto_rawDesc), len(file_types_types_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   18,
 			NumExtensions: 3,
 			NumServices:   0,
 		},
@@ -0,0 +1,147 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package audit - append-only log of write-path admin operations: admin RPC, prune/retention changes,
+// manual unwinds, downloader deletions. Entries are stored in kv.AuditLog table of the DB they change.
+package audit
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const (
+	OpAddPeer          = "admin_addPeer"
+	OpBanPeer          = "admin_banPeer"
+	OpUnbanPeer        = "admin_unbanPeer"
+	OpSetHead          = "debug_setHead"
+	OpAllowReorg       = "admin_allowReorg"
+	OpUnwind           = "unwind"
+	OpRetention        = "retention"
+	OpDownloaderDelete = "downloader_delete"
+)
+
+type Entry struct {
+	ID     uint64         `json:"id"`
+	Time   time.Time      `json:"time"`
+	Op     string         `json:"op"`
+	Origin string         `json:"origin,omitempty"` // who requested: "http 1.2.3.4:5678", "grpc 127.0.0.1:3456", "cli"
+	Args   map[string]any `json:"args,omitempty"`
+	Err    string         `json:"err,omitempty"`
+}
+
+// Append - adds entry with next id (starting from 1). Entries are never updated or deleted
+func Append(tx kv.RwTx, e *Entry) error {
+	c, err := tx.RwCursor(kv.AuditLog)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	lastK, _, err := c.Last()
+	if err != nil {
+		return err
+	}
+	e.ID = 1
+	if lastK != nil {
+		e.ID = binary.BigEndian.Uint64(lastK) + 1
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return c.Append(binary.BigEndian.AppendUint64(nil, e.ID), v)
+}
+
+// Record - appends entry of operation `op` requested by Origin(ctx) in own transaction. Failure to write audit
+// doesn't fail operation - it's logged.
+func Record(ctx context.Context, db kv.RwDB, op string, args map[string]any, opErr error, logger log.Logger) {
+	e := &Entry{Op: op, Origin: Origin(ctx), Args: args}
+	if opErr != nil {
+		e.Err = opErr.Error()
+	}
+	// not ctx: operation may be done even if request is cancelled
+	if err := db.Update(context.Background(), func(tx kv.RwTx) error { return Append(tx, e) }); err != nil {
+		logger.Warn("[audit] write failed", "op", op, "err", err)
+		return
+	}
+	logger.Info("[audit]", "id", e.ID, "op", op, "origin", e.Origin, "args", args, "err", e.Err)
+}
+
+// Read - up to `limit` entries starting from id `from` (0 - from first)
+func Read(tx kv.Tx, from uint64, limit int) ([]Entry, error) {
+	c, err := tx.Cursor(kv.AuditLog)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	var res []Entry
+	for k, v, err := c.Seek(binary.BigEndian.AppendUint64(nil, from)); k != nil; k, v, err = c.Next() {
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+		var e Entry
+		if err := json.Unmarshal(v, &e); err != nil {
+			return nil, fmt.Errorf("audit entry %x: %w", k, err)
+		}
+		res = append(res, e)
+	}
+	return res, nil
+}
+
+type originKey struct{}
+
+// originMetadataKey - passes origin of request over gRPC (e.g. from standalone rpcdaemon to Erigon)
+const originMetadataKey = "erigon-audit-origin"
+
+// WithOrigin - remember who requested operation. Origin is also passed to gRPC servers called with returned context.
+func WithOrigin(ctx context.Context, origin string) context.Context {
+	ctx = context.WithValue(ctx, originKey{}, origin)
+	return metadata.AppendToOutgoingContext(ctx, originMetadataKey, origin)
+}
+
+// Origin - origin set by WithOrigin (in this process or by gRPC client), else address of gRPC client
+func Origin(ctx context.Context) string {
+	if origin, ok := ctx.Value(originKey{}).(string); ok {
+		return origin
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(originMetadataKey); len(v) > 0 {
+			return v[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if _, isTCP := p.Addr.(*net.TCPAddr); isTCP {
+			return "grpc " + p.Addr.String()
+		}
+	}
+	return ""
+}
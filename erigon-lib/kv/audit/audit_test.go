@@ -0,0 +1,67 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	for _, db := range []kv.RwDB{memdb.NewTestDB(t, kv.ChainDB), memdb.NewTestDownloaderDB(t)} {
+		ctx := WithOrigin(context.Background(), "http 1.2.3.4:5678")
+		Record(ctx, db, OpBanPeer, map[string]any{"url": "enode://a"}, nil, log.New())
+		Record(context.Background(), db, OpDownloaderDelete, map[string]any{"paths": []string{"a.seg"}}, errors.New("boom"), log.New())
+		Record(context.Background(), db, OpUnwind, nil, nil, log.New())
+
+		tx, err := db.BeginRo(context.Background())
+		require.NoError(t, err)
+		all, err := Read(tx, 0, 0)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		require.Equal(t, uint64(1), all[0].ID)
+		require.Equal(t, OpBanPeer, all[0].Op)
+		require.Equal(t, "http 1.2.3.4:5678", all[0].Origin)
+		require.Equal(t, "enode://a", all[0].Args["url"])
+		require.False(t, all[0].Time.IsZero())
+		require.Equal(t, "boom", all[1].Err)
+		require.Equal(t, uint64(3), all[2].ID)
+
+		page, err := Read(tx, 2, 1)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, OpDownloaderDelete, page[0].Op)
+		tx.Rollback()
+	}
+}
+
+func TestOriginOverGrpcMetadata(t *testing.T) {
+	out := WithOrigin(context.Background(), "ws 1.2.3.4:5678")
+	md, ok := metadata.FromOutgoingContext(out)
+	require.True(t, ok)
+	in := metadata.NewIncomingContext(context.Background(), md)
+	require.Equal(t, "ws 1.2.3.4:5678", Origin(in))
+	require.Equal(t, "", Origin(context.Background()))
+}
@@ -30,4 +30,7 @@ const (
 	FileLogTopicsIdx  = "logtopics"
 	FileTracesFromIdx = "tracesfrom"
 	FileTracesToIdx   = "tracesto"
+
+	FileAddrAppearanceIdx = "appearances"
+	FileWithdrawalAddrIdx = "withdrawals"
 )
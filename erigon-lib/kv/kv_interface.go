@@ -82,6 +82,7 @@ import (
 
 const ReadersLimit = 32000 // MDBX_READERS_LIMIT=32767
 const dbLabelName = "db"
+const tableLabelName = "table"
 
 type DBGauges struct { // these gauges are shared by all MDBX instances, but need to be filtered by label
 	DbSize        *metrics.GaugeVec
@@ -104,6 +105,11 @@ type DBGauges struct { // these gauges are shared by all MDBX instances, but nee
 	GcLeafMetric     *metrics.GaugeVec
 	GcOverflowMetric *metrics.GaugeVec
 	GcPagesMetric    *metrics.GaugeVec
+
+	// per table: labels db, table (and type for pages)
+	TablePages   *metrics.GaugeVec
+	TableEntries *metrics.GaugeVec
+	TableDepth   *metrics.GaugeVec
 }
 
 type DBSummaries struct { // the summaries are particular to a DB instance
@@ -136,6 +142,10 @@ func InitMDBXMGauges() *DBGauges {
 		GcLeafMetric:     metrics.GetOrCreateGaugeVec(`db_gc_leaf`, []string{dbLabelName}),
 		GcOverflowMetric: metrics.GetOrCreateGaugeVec(`db_gc_overflow`, []string{dbLabelName}),
 		GcPagesMetric:    metrics.GetOrCreateGaugeVec(`db_gc_pages`, []string{dbLabelName}),
+
+		TablePages:   metrics.GetOrCreateGaugeVec(`db_table_pages`, []string{dbLabelName, tableLabelName, "type"}),
+		TableEntries: metrics.GetOrCreateGaugeVec(`db_table_entries`, []string{dbLabelName, tableLabelName}),
+		TableDepth:   metrics.GetOrCreateGaugeVec(`db_table_depth`, []string{dbLabelName, tableLabelName}),
 	}
 }
 
@@ -228,6 +238,7 @@ const (
 	PolygonBridgeDB = "polygon-bridge"
 	CaplinDB        = "caplin"
 	TemporaryDB     = "temporary"
+	ExExDB          = "exex"
 )
 
 type GetPut interface {
@@ -444,6 +444,45 @@ func (c *Coherent) GetCode(k []byte, tx kv.TemporalTx, id uint64) (v []byte, err
 	v = c.addCode(common.Copy(k), common.Copy(v), r, id).V
 	return v, nil
 }
+
+// Peek - returns cached value without reading db. Unknown ViewID is a miss.
+func (c *Coherent) Peek(k []byte, id uint64, code bool) (v []byte, ok bool) {
+	it, _, err := c.getFromCache(k, id, code)
+	if err != nil || it == nil {
+		if code {
+			c.codeMiss.Inc()
+		} else {
+			c.miss.Inc()
+		}
+		return nil, false
+	}
+	if code {
+		c.codeHits.Inc()
+	} else {
+		c.hits.Inc()
+	}
+	return it.V, true
+}
+
+// Fill - adds value which was read from db by other process at given ViewID. Does nothing if ViewID is unknown:
+// only OnNewBlock can create view which is coherent with db.
+func (c *Coherent) Fill(k, v []byte, id uint64, code bool) {
+	if !code && len(v) == 0 {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	r, ok := c.roots[id]
+	if !ok || !r.isCanonical {
+		return
+	}
+	if code {
+		c.addCode(common.Copy(k), common.Copy(v), r, id)
+		return
+	}
+	c.add(common.Copy(k), common.Copy(v), r, id)
+}
+
 func (c *Coherent) removeOldest(r *CoherentRoot) {
 	e := c.stateEvict.Oldest()
 	if e != nil {
@@ -0,0 +1,132 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kvcache
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// StateCacheServer - shares Coherent cache with many rpcdaemons (which don't have own cache).
+// Coherent must receive OnNewBlock of same Erigon node which rpcdaemons are connected to - then values are
+// invalidated by head events and every ViewID stays consistent with db transactions of rpcdaemons.
+type StateCacheServer struct {
+	remote.UnimplementedStateCacheServer
+	cache *Coherent
+}
+
+func NewStateCacheServer(cache *Coherent) *StateCacheServer {
+	return &StateCacheServer{cache: cache}
+}
+
+func (s *StateCacheServer) Lookup(_ context.Context, req *remote.StateCacheLookupRequest) (*remote.StateCacheLookupReply, error) {
+	v, ok := s.cache.Peek(req.Key, req.StateVersionId, req.Code)
+	return &remote.StateCacheLookupReply{Hit: ok, Value: v}, nil
+}
+
+func (s *StateCacheServer) Fill(_ context.Context, req *remote.StateCacheFillRequest) (*emptypb.Empty, error) {
+	s.cache.Fill(req.Key, req.Value, req.StateVersionId, req.Code)
+	return &emptypb.Empty{}, nil
+}
+
+// RemoteCache - Cache of rpcdaemon which uses StateCacheServer instead of own memory:
+//   - on hit - value returned by StateCacheServer
+//   - on miss - value read from db transaction and sent to StateCacheServer, for other rpcdaemons
+//   - if StateCacheServer is unavailable - works as DummyCache
+//
+// OnNewBlock does nothing - StateCacheServer receives state changes directly from Erigon.
+type RemoteCache struct {
+	client remote.StateCacheClient
+	miss   metrics.Counter
+	hits   metrics.Counter
+	failed metrics.Counter
+}
+
+var _ Cache = (*RemoteCache)(nil)    // compile-time interface check
+var _ CacheView = (*RemoteView)(nil) // compile-time interface check
+
+func NewRemote(client remote.StateCacheClient) *RemoteCache {
+	return &RemoteCache{
+		client: client,
+		miss:   metrics.GetOrCreateCounter(`cache_total{result="miss",name="remote"}`),
+		hits:   metrics.GetOrCreateCounter(`cache_total{result="hit",name="remote"}`),
+		failed: metrics.GetOrCreateCounter(`cache_remote_errors_total{name="remote"}`),
+	}
+}
+
+func (c *RemoteCache) View(ctx context.Context, tx kv.TemporalTx) (CacheView, error) {
+	id, err := tx.ReadSequence(string(kv.PlainStateVersion))
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteView{ctx: ctx, tx: tx, cache: c, stateVersionID: id}, nil
+}
+func (c *RemoteCache) OnNewBlock(sc *remote.StateChangeBatch) {}
+func (c *RemoteCache) Len() int                               { return 0 }
+func (c *RemoteCache) ValidateCurrentRoot(_ context.Context, _ kv.Tx) (*CacheValidationResult, error) {
+	return &CacheValidationResult{Enabled: false}, nil
+}
+
+func (c *RemoteCache) get(ctx context.Context, k []byte, tx kv.TemporalTx, id uint64, code bool) ([]byte, error) {
+	reply, err := c.client.Lookup(ctx, &remote.StateCacheLookupRequest{StateVersionId: id, Key: k, Code: code})
+	if err == nil && reply.Hit {
+		c.hits.Inc()
+		return reply.Value, nil
+	}
+	c.miss.Inc()
+
+	var v []byte
+	var dbErr error
+	switch {
+	case code:
+		v, _, dbErr = tx.GetLatest(kv.CodeDomain, k)
+	case len(k) == 20:
+		v, _, dbErr = tx.GetLatest(kv.AccountsDomain, k)
+	default:
+		v, _, dbErr = tx.GetLatest(kv.StorageDomain, k)
+	}
+	if dbErr != nil {
+		return nil, dbErr
+	}
+	if err != nil { // don't wait for unavailable service twice
+		c.failed.Inc()
+		return v, nil
+	}
+	if _, err := c.client.Fill(ctx, &remote.StateCacheFillRequest{StateVersionId: id, Key: k, Value: v, Code: code}); err != nil {
+		c.failed.Inc()
+	}
+	return v, nil
+}
+
+type RemoteView struct {
+	ctx            context.Context
+	tx             kv.TemporalTx
+	cache          *RemoteCache
+	stateVersionID uint64
+}
+
+func (c *RemoteView) Get(k []byte) ([]byte, error) {
+	return c.cache.get(c.ctx, k, c.tx, c.stateVersionID, false)
+}
+func (c *RemoteView) GetCode(k []byte) ([]byte, error) {
+	return c.cache.get(c.ctx, k, c.tx, c.stateVersionID, true)
+}
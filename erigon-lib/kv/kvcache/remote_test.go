@@ -0,0 +1,112 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kvcache
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+func TestRemoteCache(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+
+	shared := New(DefaultCoherentConfig)
+	server := grpc.NewServer()
+	remote.RegisterStateCacheServer(server, NewStateCacheServer(shared))
+	listener := bufconn.Listen(1024 * 1024)
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }))
+	require.NoError(err)
+	defer conn.Close()
+	c := NewRemote(remote.NewStateCacheClient(conn))
+
+	db := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	k1 := [20]byte{1}
+	acc := accounts.Account{Nonce: 1, Balance: *uint256.NewInt(11)}
+	accEnc := accounts.SerialiseV3(&acc)
+	err = db.UpdateTemporal(ctx, func(tx kv.TemporalRwTx) error {
+		d, err := state.NewSharedDomains(tx, log.New())
+		if err != nil {
+			return err
+		}
+		defer d.Close()
+		if err := d.DomainPut(kv.AccountsDomain, k1[:], nil, accEnc, nil, 0); err != nil {
+			return err
+		}
+		return d.Flush(ctx, tx)
+	})
+	require.NoError(err)
+
+	var id uint64
+	err = db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+		id, err = tx.ReadSequence(string(kv.PlainStateVersion))
+		if err != nil {
+			return err
+		}
+
+		// the service didn't see this state version yet: nothing is cached
+		view, err := c.View(ctx, tx)
+		require.NoError(err)
+		v, err := view.Get(k1[:])
+		require.NoError(err)
+		require.Equal(accEnc, v)
+		_, ok := shared.Peek(k1[:], id, false)
+		require.False(ok)
+
+		// once it did, values read by rpcdaemons are shared
+		shared.OnNewBlock(&remote.StateChangeBatch{StateVersionId: id})
+		v, err = view.Get(k1[:])
+		require.NoError(err)
+		require.Equal(accEnc, v)
+		v, ok = shared.Peek(k1[:], id, false)
+		require.True(ok)
+		require.Equal(accEnc, v)
+		return nil
+	})
+	require.NoError(err)
+
+	// head events invalidate the shared values
+	shared.OnNewBlock(&remote.StateChangeBatch{StateVersionId: id + 1, ChangeBatch: []*remote.StateChange{
+		{Changes: []*remote.AccountChange{{Action: remote.Action_REMOVE, Address: gointerfaces.ConvertAddressToH160(k1)}}},
+	}})
+	v, ok := shared.Peek(k1[:], id+1, false)
+	require.True(ok)
+	require.Nil(v)
+	v, ok = shared.Peek(k1[:], id, false)
+	require.True(ok)
+	require.Equal(accEnc, v)
+}
@@ -25,8 +25,11 @@ import (
 type ConfigKey []byte
 
 var (
-	PersistReceipts   = ConfigKey("persist.receipts")
-	CommitmentHistory = ConfigKey("commitment.history")
+	PersistReceipts    = ConfigKey("persist.receipts")
+	CommitmentHistory  = ConfigKey("commitment.history")
+	AddressAppearances = ConfigKey("address.appearances")
+	AccessLists        = ConfigKey("access.lists")
+	WithdrawalsIndex   = ConfigKey("withdrawals.index")
 )
 
 func (k ConfigKey) Enabled(tx kv.Tx) (bool, error) { return kv.GetBool(tx, kv.DatabaseInfo, k) }
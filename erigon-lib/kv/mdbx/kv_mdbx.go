@@ -520,6 +520,8 @@ type MdbxKV struct {
 
 	leakDetector *dbg.LeakDetector
 
+	tableMetricsAt atomic.Int64 // unix time of last collection of per-table metrics
+
 	// MaxBatchSize is the maximum size of a batch. Default value is
 	// copied from DefaultMaxBatchSize in Open.
 	//
@@ -811,6 +813,33 @@ func (tx *MdbxTx) CollectMetrics() {
 	kv.MDBXGauges.GcLeafMetric.WithLabelValues(dbLabel).SetUint64(gc.LeafPages)
 	kv.MDBXGauges.GcOverflowMetric.WithLabelValues(dbLabel).SetUint64(gc.OverflowPages)
 	kv.MDBXGauges.GcPagesMetric.WithLabelValues(dbLabel).SetUint64((gc.LeafPages + gc.OverflowPages) * tx.db.opts.pageSize.Bytes() / 8)
+
+	tx.collectTableMetrics(dbLabel)
+}
+
+// tables stats are not free (~100 tables) - collect them not more often than tableMetricsEvery
+const tableMetricsEvery = 30 * time.Second
+
+func (tx *MdbxTx) collectTableMetrics(dbLabel string) {
+	now := time.Now().Unix()
+	last := tx.db.tableMetricsAt.Load()
+	if now-last < int64(tableMetricsEvery.Seconds()) || !tx.db.tableMetricsAt.CompareAndSwap(last, now) {
+		return
+	}
+	for name, cfg := range tx.db.buckets {
+		if cfg.IsDeprecated || cfg.DBI == NonExistingDBI {
+			continue
+		}
+		st, err := tx.tx.StatDBI(mdbx.DBI(cfg.DBI))
+		if err != nil {
+			continue
+		}
+		kv.MDBXGauges.TablePages.WithLabelValues(dbLabel, name, "leaf").SetUint64(st.LeafPages)
+		kv.MDBXGauges.TablePages.WithLabelValues(dbLabel, name, "branch").SetUint64(st.BranchPages)
+		kv.MDBXGauges.TablePages.WithLabelValues(dbLabel, name, "overflow").SetUint64(st.OverflowPages)
+		kv.MDBXGauges.TableEntries.WithLabelValues(dbLabel, name).SetUint64(st.Entries)
+		kv.MDBXGauges.TableDepth.WithLabelValues(dbLabel, name).SetUint64(uint64(st.Depth))
+	}
 }
 
 func (tx *MdbxTx) WarmupDB(force bool) error {
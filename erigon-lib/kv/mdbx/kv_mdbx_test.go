@@ -1127,3 +1127,26 @@ func TestMdbxWithSyncBytes(t *testing.T) {
 	}
 	t.Cleanup(db.Close)
 }
+
+func TestTableMetrics(t *testing.T) {
+	path := t.TempDir()
+	db := New(kv.TxPoolDB, log.New()).InMem(path).WithMetrics().WithTableCfg(func(defaultBuckets kv.TableCfg) kv.TableCfg {
+		return kv.TableCfg{"Table": kv.TableCfgItem{}}
+	}).MapSize(128 * datasize.MB).MustOpen()
+	t.Cleanup(db.Close)
+	db.(*MdbxKV).tableMetricsAt.Store(0) // not throttled by commits of db opening
+
+	err := db.Update(context.Background(), func(tx kv.RwTx) error {
+		for i := 0; i < 100; i++ {
+			if err := tx.Put("Table", []byte{byte(i)}, []byte{1}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	dbLabel := string(kv.TxPoolDB)
+	require.Equal(t, uint64(100), kv.MDBXGauges.TableEntries.WithLabelValues(dbLabel, "Table").GetValueUint64())
+	require.Equal(t, uint64(1), kv.MDBXGauges.TablePages.WithLabelValues(dbLabel, "Table", "leaf").GetValueUint64())
+}
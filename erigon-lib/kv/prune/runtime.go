@@ -0,0 +1,76 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package prune
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/erigontech/erigon-lib/config3"
+)
+
+// runtimeDistance - distances set by admin_setPruneDistance while node is running. 0 - configured distance is used.
+// Not persisted: after restart distances of --prune.* flags (stored in db) are used again.
+type runtimeDistance struct {
+	History, Blocks uint64
+}
+
+var runtimeDistances atomic.Pointer[runtimeDistance]
+
+var ErrRuntimeDistanceTooSmall = fmt.Errorf("prune distance must be 0 (configured) or at least %d blocks (max unwind)", config3.FullImmutabilityThreshold)
+
+// SetRuntimeDistance - changes distance of already enabled history/blocks pruning until restart. 0 - back to configured distance.
+// Pruning can't be enabled or disabled at runtime: it changes what node promises to serve.
+func SetRuntimeDistance(history, blocks uint64) error {
+	if (history != 0 && history < config3.FullImmutabilityThreshold) || (blocks != 0 && blocks < config3.FullImmutabilityThreshold) {
+		return ErrRuntimeDistanceTooSmall
+	}
+	runtimeDistances.Store(&runtimeDistance{History: history, Blocks: blocks})
+	return nil
+}
+
+// RuntimeDistance - distances set by SetRuntimeDistance, 0 - not set
+func RuntimeDistance() (history, blocks uint64) {
+	d := runtimeDistances.Load()
+	if d == nil {
+		return 0, 0
+	}
+	return d.History, d.Blocks
+}
+
+// blocksFloor - blocks other subsystems read bodies of (e.g. embedded Caplin un-blinds its beacon blocks with them),
+// pruning of blocks never keeps less. 0 - no floor.
+var blocksFloor atomic.Uint64
+
+// SetBlocksFloor - pruning of blocks keeps at least given amount of blocks, whatever the configured or runtime distance
+func SetBlocksFloor(blocks uint64) { blocksFloor.Store(blocks) }
+
+// Runtime - mode with distances set by SetRuntimeDistance and the floor of SetBlocksFloor applied
+func (m Mode) Runtime() Mode {
+	if d := runtimeDistances.Load(); d != nil {
+		if d.History != 0 && m.History != nil && m.History.Enabled() {
+			m.History = Distance(d.History)
+		}
+		if d.Blocks != 0 && m.Blocks != nil && m.Blocks.Enabled() {
+			m.Blocks = Distance(d.Blocks)
+		}
+	}
+	if floor := blocksFloor.Load(); floor != 0 && m.Blocks != nil && m.Blocks.Enabled() && m.Blocks.toValue() < floor {
+		m.Blocks = Distance(floor)
+	}
+	return m
+}
@@ -119,3 +119,31 @@ func TestDistancePruneTo(t *testing.T) {
 		})
 	}
 }
+
+func TestRuntimeDistance(t *testing.T) {
+	defer SetRuntimeDistance(0, 0) //nolint:errcheck
+
+	assert.ErrorIs(t, SetRuntimeDistance(1000, 0), ErrRuntimeDistanceTooSmall)
+	assert.Equal(t, ArchiveMode, ArchiveMode.Runtime())
+
+	assert.NoError(t, SetRuntimeDistance(200_000, 0))
+	assert.Equal(t, ArchiveMode, ArchiveMode.Runtime(), "pruning can't be enabled at runtime")
+	assert.Equal(t, Distance(200_000), FullMode.Runtime().History)
+	assert.Equal(t, FullMode.Blocks, FullMode.Runtime().Blocks)
+	assert.Equal(t, MinimalMode.Blocks, MinimalMode.Runtime().Blocks)
+
+	assert.NoError(t, SetRuntimeDistance(0, 0))
+	assert.Equal(t, FullMode, FullMode.Runtime())
+}
+
+func TestBlocksFloor(t *testing.T) {
+	defer SetBlocksFloor(0)
+
+	SetBlocksFloor(150_000)
+	assert.Equal(t, Distance(150_000), MinimalMode.Runtime().Blocks)
+	assert.Equal(t, MinimalMode.History, MinimalMode.Runtime().History)
+	assert.Equal(t, FullMode, FullMode.Runtime(), "not pruned blocks stay not pruned")
+
+	SetBlocksFloor(50_000)
+	assert.Equal(t, MinimalMode.Blocks, MinimalMode.Runtime().Blocks, "floor doesn't lower distance")
+}
@@ -75,6 +75,7 @@ const (
 	//   Same about: TxNum/TxID, BlockNum/BlockID
 	HeaderNumber    = "HeaderNumber"           // header_hash -> header_num_u64
 	BadHeaderNumber = "BadHeaderNumber"        // header_hash -> header_num_u64
+	BadBlocks       = "BadBlocks"              // header_hash -> archived bad block (RLP): block, parent state root, failure reason
 	HeaderCanonical = "CanonicalHeader"        // block_num_u64 -> header hash
 	Headers         = "Header"                 // block_num_u64 + hash -> header (RLP)
 	HeaderTD        = "HeadersTotalDifficulty" // block_num_u64 + hash -> td (RLP)
@@ -115,6 +116,8 @@ const (
 	NodeRecords = "NodeRecord"
 	// Inodes stores P2P discovery service info about the nodes
 	Inodes = "Inode"
+	// NodeReputations stores the penalties and bans of P2P nodes: node_id -> penalties_u64 + banned_until_u64 + banned_u8
+	NodeReputations = "NodeReputation"
 
 	// Transaction senders - stored separately from the block bodies
 	Senders = "TxSender" // block_num_u64 + blockHash -> sendersList (no serialization format, every 20 bytes is new sender)
@@ -134,6 +137,8 @@ const (
 
 	Sequence = "Sequence" // tbl_name -> seq_u64
 
+	AuditLog = "AuditLog" // id_u64 -> audit entry (json), append-only log of admin operations
+
 	Epoch        = "DevEpoch"        // block_num_u64+block_hash->transition_proof
 	PendingEpoch = "DevPendingEpoch" // block_num_u64+block_hash->transition_proof
 
@@ -189,6 +194,11 @@ const (
 	TblRCacheHistoryVals = "ReceiptCacheHistoryVals"
 	TblRCacheIdx         = "ReceiptCacheIdx"
 
+	TblAccessListVals        = "AccessListVals"
+	TblAccessListHistoryKeys = "AccessListHistoryKeys"
+	TblAccessListHistoryVals = "AccessListHistoryVals"
+	TblAccessListIdx         = "AccessListIdx"
+
 	TblLogAddressKeys = "LogAddressKeys"
 	TblLogAddressIdx  = "LogAddressIdx"
 	TblLogTopicsKeys  = "LogTopicsKeys"
@@ -199,6 +209,12 @@ const (
 	TblTracesToKeys   = "TracesToKeys"
 	TblTracesToIdx    = "TracesToIdx"
 
+	TblAddrAppearanceKeys = "AddrAppearanceKeys"
+	TblAddrAppearanceIdx  = "AddrAppearanceIdx"
+
+	TblWithdrawalAddrKeys = "WithdrawalAddrKeys"
+	TblWithdrawalAddrIdx  = "WithdrawalAddrIdx"
+
 	// Prune progress of execution: tableName -> [8bytes of invStep]latest pruned key
 	// Could use table constants `Tbl{Account,Storage,Code,Commitment}Keys` for domains
 	// corresponding history tables `Tbl{Account,Storage,Code,Commitment}HistoryKeys` for history
@@ -288,6 +304,9 @@ const (
 	//Diagnostics tables
 	DiagSystemInfo = "DiagSystemInfo"
 	DiagSyncStages = "DiagSyncStages"
+
+	//ExEx tables
+	ExExCheckpoints = "ExExCheckpoints" // exex_name -> block_num_u64 + block_hash (last delivered block)
 )
 
 // Keys
@@ -299,6 +318,8 @@ var (
 	PruneHistory   = []byte("pruneHistory")
 	PruneBlocks    = []byte("pruneBlocks")
 
+	AuditRetentionKey = []byte("auditRetention") // retention settings of last run, change is recorded in AuditLog
+
 	DBSchemaVersionKey = []byte("dbVersion")
 	GenesisKey         = []byte("genesis")
 
@@ -327,6 +348,7 @@ var ChaindataTables = []string{
 	Code,
 	HeaderNumber,
 	BadHeaderNumber,
+	BadBlocks,
 	BlockBody,
 	TxLookup,
 	ConfigTable,
@@ -342,6 +364,7 @@ var ChaindataTables = []string{
 	LastForkchoice,
 	Migrations,
 	Sequence,
+	AuditLog,
 	EthTx,
 	HeaderCanonical,
 	Headers,
@@ -391,6 +414,11 @@ var ChaindataTables = []string{
 	TblRCacheHistoryVals,
 	TblRCacheIdx,
 
+	TblAccessListVals,
+	TblAccessListHistoryKeys,
+	TblAccessListHistoryVals,
+	TblAccessListIdx,
+
 	TblLogAddressKeys,
 	TblLogAddressIdx,
 	TblLogTopicsKeys,
@@ -401,6 +429,12 @@ var ChaindataTables = []string{
 	TblTracesToKeys,
 	TblTracesToIdx,
 
+	TblAddrAppearanceKeys,
+	TblAddrAppearanceIdx,
+
+	TblWithdrawalAddrKeys,
+	TblWithdrawalAddrIdx,
+
 	TblPruningProgress,
 
 	MaxTxNum,
@@ -470,6 +504,7 @@ var TxPoolTables = []string{
 var SentryTables = []string{
 	Inodes,
 	NodeRecords,
+	NodeReputations,
 }
 var ConsensusTables = append([]string{
 	CliqueSeparate,
@@ -482,6 +517,7 @@ var PolygonBridgeTables = []string{}
 var DownloaderTables = []string{
 	BittorrentCompletion,
 	BittorrentInfo,
+	AuditLog,
 }
 var ReconTables = []string{
 	PlainStateR,
@@ -501,6 +537,11 @@ var DiagnosticsTables = []string{
 	DiagSyncStages,
 }
 
+// ExEx tables
+var ExExTables = []string{
+	ExExCheckpoints,
+}
+
 type CmpFunc func(k1, k2, v1, v2 []byte) int
 
 type TableCfg map[string]TableCfgItem
@@ -575,6 +616,9 @@ var ChaindataTablesCfg = TableCfg{
 	TblRCacheHistoryKeys: {Flags: DupSort},
 	TblRCacheIdx:         {Flags: DupSort},
 
+	TblAccessListHistoryKeys: {Flags: DupSort},
+	TblAccessListIdx:         {Flags: DupSort},
+
 	TblLogAddressKeys: {Flags: DupSort},
 	TblLogAddressIdx:  {Flags: DupSort},
 	TblLogTopicsKeys:  {Flags: DupSort},
@@ -584,6 +628,12 @@ var ChaindataTablesCfg = TableCfg{
 	TblTracesFromIdx:  {Flags: DupSort},
 	TblTracesToKeys:   {Flags: DupSort},
 	TblTracesToIdx:    {Flags: DupSort},
+
+	TblAddrAppearanceKeys: {Flags: DupSort},
+	TblAddrAppearanceIdx:  {Flags: DupSort},
+
+	TblWithdrawalAddrKeys: {Flags: DupSort},
+	TblWithdrawalAddrIdx:  {Flags: DupSort},
 }
 
 var AuRaTablesCfg = TableCfg{
@@ -611,6 +661,7 @@ var SentryTablesCfg = TableCfg{}
 var ConsensusTablesCfg = TableCfg{}
 var DownloaderTablesCfg = TableCfg{}
 var DiagnosticsTablesCfg = TableCfg{}
+var ExExTablesCfg = TableCfg{}
 var HeimdallTablesCfg = TableCfg{}
 var PolygonBridgeTablesCfg = TableCfg{}
 var ReconTablesCfg = TableCfg{
@@ -631,6 +682,8 @@ func TablesCfgByLabel(label Label) TableCfg {
 		return DownloaderTablesCfg
 	case DiagnosticsDB:
 		return DiagnosticsTablesCfg
+	case ExExDB:
+		return ExExTablesCfg
 	case HeimdallDB:
 		return HeimdallTablesCfg
 	case PolygonBridgeDB:
@@ -713,6 +766,13 @@ func reinit() {
 		}
 	}
 
+	for _, name := range ExExTables {
+		_, ok := ExExTablesCfg[name]
+		if !ok {
+			ExExTablesCfg[name] = TableCfgItem{}
+		}
+	}
+
 	for _, name := range HeimdallTables {
 		_, ok := HeimdallTablesCfg[name]
 		if !ok {
@@ -736,7 +796,8 @@ const (
 	CommitmentDomain Domain = 3 // Merkle Trie
 	ReceiptDomain    Domain = 4 // Tiny Receipts - without logs. Required for node-operations.
 	RCacheDomain     Domain = 5 // Fat Receipts - with logs. Optional.
-	DomainLen        Domain = 6 // Technical marker of Enum. Not real Domain.
+	AccessListDomain Domain = 6 // EIP-2930 access list of every executed txn. Optional.
+	DomainLen        Domain = 7 // Technical marker of Enum. Not real Domain.
 )
 
 var StateDomains = []Domain{AccountsDomain, StorageDomain, CodeDomain, CommitmentDomain}
@@ -753,6 +814,12 @@ const (
 	LogAddrIdx    InvertedIdx = 7
 	TracesFromIdx InvertedIdx = 8
 	TracesToIdx   InvertedIdx = 9
+
+	AddrAppearanceIdx InvertedIdx = 10 // Optional. Address -> txNums where it appeared: calls, logs, rewards, withdrawals
+
+	AccessListHistoryIdx InvertedIdx = 11
+
+	WithdrawalAddrIdx InvertedIdx = 12 // Optional. Withdrawal address -> txNums of blocks (final txn) with withdrawals to it
 )
 
 func (idx InvertedIdx) String() string {
@@ -769,6 +836,8 @@ func (idx InvertedIdx) String() string {
 		return "receipt"
 	case RCacheHistoryIdx:
 		return "rcache"
+	case AccessListHistoryIdx:
+		return "accesslist"
 	case LogAddrIdx:
 		return "logaddrs"
 	case LogTopicIdx:
@@ -777,6 +846,10 @@ func (idx InvertedIdx) String() string {
 		return "tracesfrom"
 	case TracesToIdx:
 		return "tracesto"
+	case AddrAppearanceIdx:
+		return "appearances"
+	case WithdrawalAddrIdx:
+		return "withdrawals"
 	default:
 		return "unknown index"
 	}
@@ -796,6 +869,8 @@ func String2InvertedIdx(in string) (InvertedIdx, error) {
 		return ReceiptHistoryIdx, nil
 	case "rcache":
 		return RCacheHistoryIdx, nil
+	case "accesslist":
+		return AccessListHistoryIdx, nil
 	case "logaddrs":
 		return LogAddrIdx, nil
 	case "logtopics":
@@ -804,6 +879,10 @@ func String2InvertedIdx(in string) (InvertedIdx, error) {
 		return TracesFromIdx, nil
 	case "tracesto":
 		return TracesToIdx, nil
+	case "appearances":
+		return AddrAppearanceIdx, nil
+	case "withdrawals":
+		return WithdrawalAddrIdx, nil
 	default:
 		return InvertedIdx(MaxUint16), fmt.Errorf("unknown inverted index name: %s", in)
 	}
@@ -828,6 +907,8 @@ func (d Domain) String() string {
 		return "receipt"
 	case RCacheDomain:
 		return "rcache"
+	case AccessListDomain:
+		return "accesslist"
 	default:
 		return "unknown domain"
 	}
@@ -847,6 +928,8 @@ func String2Domain(in string) (Domain, error) {
 		return ReceiptDomain, nil
 	case "rcache":
 		return RCacheDomain, nil
+	case "accesslist":
+		return AccessListDomain, nil
 	default:
 		return Domain(MaxUint16), fmt.Errorf("unknown name: %s", in)
 	}
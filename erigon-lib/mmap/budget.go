@@ -0,0 +1,194 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mmap
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+var (
+	mmapResident      = metrics.GetOrCreateGaugeVec("mmap_resident_bytes", []string{"file"}, "page cache resident bytes of mapped file")
+	mmapResidentTotal = metrics.GetOrCreateGauge("mmap_resident_total_bytes")
+	mmapPagedOut      = metrics.GetOrCreateCounter("mmap_paged_out_bytes")
+)
+
+// DefaultBudget - budget of accessor indexes (.idx, .efi, .vi, .kvi) of the process
+var DefaultBudget = NewBudget(0)
+
+// Budget - limit of page cache resident bytes of registered read-only mappings. Archive nodes serving historical
+// queries touch many disjoint files: without limit their indexes hold RAM until OS is under memory pressure.
+// Each Scan measures residency (mincore) of every file and, if total is over the limit, pages out cold files -
+// not touched since previous scans, coldest and biggest first. Limit 0 - only residency metrics. Linux only.
+type Budget struct {
+	limit atomic.Uint64
+
+	mu    sync.Mutex
+	files map[*Region]struct{}
+
+	resident func(mmapHandle1 []byte) (int64, error)
+	pageOut  func(mmapHandle1 []byte) error
+}
+
+// Region - mapping registered in Budget
+type Region struct {
+	name    string
+	touched atomic.Bool
+
+	mu       sync.Mutex
+	data     []byte // nil after Unregister
+	idle     int    // amount of scans without Touch
+	resident int64
+}
+
+func NewBudget(limit datasize.ByteSize) *Budget {
+	b := &Budget{files: map[*Region]struct{}{}, resident: Resident, pageOut: MadvisePageOut}
+	b.limit.Store(uint64(limit))
+	return b
+}
+
+func (b *Budget) SetLimit(limit datasize.ByteSize) { b.limit.Store(uint64(limit)) }
+func (b *Budget) Limit() datasize.ByteSize         { return datasize.ByteSize(b.limit.Load()) }
+
+// Register - mmapHandle1 must be unregistered before unmap
+func (b *Budget) Register(name string, mmapHandle1 []byte) *Region {
+	if len(mmapHandle1) == 0 {
+		return nil
+	}
+	r := &Region{name: name, data: mmapHandle1}
+	r.touched.Store(true)
+	b.mu.Lock()
+	b.files[r] = struct{}{}
+	b.mu.Unlock()
+	return r
+}
+
+func (b *Budget) Unregister(r *Region) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock() // wait for Scan of the region
+	r.data = nil
+	r.mu.Unlock()
+	b.mu.Lock()
+	delete(b.files, r)
+	b.mu.Unlock()
+	mmapResident.DeleteLabelValues(r.name)
+}
+
+// Touch - mark region as used. Cheap: only first Touch after Scan writes.
+func (r *Region) Touch() {
+	if r != nil && !r.touched.Load() {
+		r.touched.Store(true)
+	}
+}
+
+// Scan - updates residency of all regions and pages out cold ones if total is over the limit
+func (b *Budget) Scan() (resident, pagedOut int64) {
+	b.mu.Lock()
+	regions := make([]*Region, 0, len(b.files))
+	for r := range b.files {
+		regions = append(regions, r)
+	}
+	b.mu.Unlock()
+
+	type coldRegion struct {
+		r        *Region
+		idle     int
+		resident int64
+	}
+	var cold []coldRegion
+	for _, r := range regions {
+		r.mu.Lock()
+		if r.data == nil {
+			r.mu.Unlock()
+			continue
+		}
+		res, err := b.resident(r.data)
+		if err != nil {
+			r.mu.Unlock()
+			if errors.Is(err, errors.ErrUnsupported) {
+				return 0, 0
+			}
+			continue
+		}
+		r.resident = res
+		if r.touched.Swap(false) {
+			r.idle = 0
+		} else {
+			r.idle++
+		}
+		if r.idle > 0 && res > 0 {
+			cold = append(cold, coldRegion{r: r, idle: r.idle, resident: res})
+		}
+		r.mu.Unlock()
+		resident += res
+		mmapResident.WithLabelValues(r.name).SetInt(int(res))
+	}
+
+	if limit := int64(b.limit.Load()); limit > 0 && resident > limit {
+		slices.SortFunc(cold, func(a, b coldRegion) int {
+			if c := cmp.Compare(b.idle, a.idle); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.resident, a.resident)
+		})
+		for _, c := range cold {
+			if resident <= limit {
+				break
+			}
+			c.r.mu.Lock()
+			if c.r.data != nil && b.pageOut(c.r.data) == nil {
+				resident -= c.r.resident
+				pagedOut += c.r.resident
+				c.r.resident = 0
+				mmapResident.WithLabelValues(c.r.name).SetInt(0)
+			}
+			c.r.mu.Unlock()
+		}
+	}
+	mmapResidentTotal.SetInt(int(resident))
+	mmapPagedOut.AddUint64(uint64(pagedOut))
+	return resident, pagedOut
+}
+
+// Run - Scan every `every` until ctx is done
+func (b *Budget) Run(ctx context.Context, every time.Duration, logger log.Logger) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			resident, pagedOut := b.Scan()
+			if pagedOut > 0 {
+				logger.Debug("[mmap] paged out cold files", "resident", datasize.ByteSize(resident).HR(), "pagedOut", datasize.ByteSize(pagedOut).HR(), "limit", b.Limit().HR())
+			}
+		}
+	}
+}
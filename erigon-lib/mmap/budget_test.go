@@ -0,0 +1,69 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(1_000)
+	resident := map[*byte]int64{}
+	var pagedOut []string
+	b.resident = func(data []byte) (int64, error) { return resident[&data[0]], nil }
+
+	hot, cold, colder := make([]byte, 100), make([]byte, 100), make([]byte, 100)
+	rHot, rCold, rColder := b.Register("hot.idx", hot), b.Register("cold.idx", cold), b.Register("colder.idx", colder)
+	names := map[*byte]string{&hot[0]: "hot.idx", &cold[0]: "cold.idx", &colder[0]: "colder.idx"}
+	b.pageOut = func(data []byte) error {
+		pagedOut = append(pagedOut, names[&data[0]])
+		resident[&data[0]] = 0
+		return nil
+	}
+	resident[&hot[0]], resident[&cold[0]], resident[&colder[0]] = 100, 100, 100
+
+	total, out := b.Scan() // all were touched by open
+	require.Equal(t, int64(300), total)
+	require.Zero(t, out)
+
+	rColder.Touch()
+	total, out = b.Scan() // under limit
+	require.Equal(t, int64(300), total)
+	require.Zero(t, out)
+
+	b.SetLimit(150)
+	rHot.Touch()
+	total, out = b.Scan() // idle: hot=0, cold=2, colder=1
+	require.Equal(t, []string{"cold.idx", "colder.idx"}, pagedOut)
+	require.Equal(t, int64(100), total)
+	require.Equal(t, int64(200), out)
+
+	b.Unregister(rCold)
+	require.Nil(t, rCold.data)
+	resident[&hot[0]] = 200
+	b.SetLimit(0) // no limit: only metrics
+	rHot.Touch()
+	total, out = b.Scan()
+	require.Equal(t, int64(200), total)
+	require.Zero(t, out)
+
+	require.Nil(t, b.Register("empty.idx", nil))
+	b.Unregister(nil)
+	(*Region)(nil).Touch()
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build linux
+
+package mmap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Resident - amount of bytes of the mapping, which are in page cache (mincore)
+func Resident(mmapHandle1 []byte) (int64, error) {
+	if len(mmapHandle1) == 0 {
+		return 0, nil
+	}
+	pageSize := os.Getpagesize()
+	vec := make([]byte, (len(mmapHandle1)+pageSize-1)/pageSize)
+	_, _, errno := unix.Syscall(unix.SYS_MINCORE, uintptr(unsafe.Pointer(&mmapHandle1[0])), uintptr(len(mmapHandle1)), uintptr(unsafe.Pointer(&vec[0])))
+	if errno != 0 {
+		return 0, fmt.Errorf("mincore: %w", errno)
+	}
+	var pages int64
+	for _, v := range vec {
+		pages += int64(v & 1)
+	}
+	return min(pages*int64(pageSize), int64(len(mmapHandle1))), nil
+}
+
+// MadvisePageOut - drop pages of the mapping from page cache (they will be read from disk on next access).
+// Kernels older than 5.4 have no MADV_PAGEOUT: pages are only unmapped from process.
+func MadvisePageOut(mmapHandle1 []byte) error {
+	err := unix.Madvise(mmapHandle1, unix.MADV_PAGEOUT)
+	if errors.Is(err, syscall.EINVAL) {
+		err = unix.Madvise(mmapHandle1, unix.MADV_DONTNEED)
+	}
+	if err != nil && !errors.Is(err, syscall.ENOSYS) {
+		return fmt.Errorf("madvise: %w", err)
+	}
+	return nil
+}
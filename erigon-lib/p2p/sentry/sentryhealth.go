@@ -0,0 +1,227 @@
+package sentry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+)
+
+const (
+	healthCheckInterval = 10 * time.Second
+	healthCheckTimeout  = 5 * time.Second
+)
+
+// errNoHealthySentry is a grpc Unavailable error, so that stream loops treat it as a
+// reason to retry later rather than as a failure.
+var errNoHealthySentry = status.Error(codes.Unavailable, "no healthy sentry")
+
+// isSentryDown reports whether err means that the sentry can't be reached, as opposed
+// to the sentry rejecting the request.
+func isSentryDown(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unavailable
+}
+
+func newMuxClient(c sentryproto.SentryClient) *client {
+	up := make(chan struct{})
+	close(up)
+	return &client{SentryClient: c, protocol: -1, healthy: true, up: up}
+}
+
+func (c *client) isHealthy() bool {
+	c.RLock()
+	defer c.RUnlock()
+	return c.healthy
+}
+
+// setHealthy records the health of the client and reports whether it changed.
+func (c *client) setHealthy(healthy bool) bool {
+	c.Lock()
+	defer c.Unlock()
+	if c.healthy == healthy {
+		return false
+	}
+	c.healthy = healthy
+	if healthy {
+		close(c.up)
+	} else {
+		c.up = make(chan struct{})
+	}
+	return true
+}
+
+// waitHealthy blocks until the client is healthy or ctx is done.
+func (c *client) waitHealthy(ctx context.Context) error {
+	c.RLock()
+	up := c.up
+	c.RUnlock()
+	select {
+	case <-up:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// markDown marks the client unhealthy and forgets the peers of its sentry, outbound
+// messages to them are routed to the other sentries until it recovers.
+func (m *sentryMultiplexer) markDown(c *client) {
+	if !c.setHealthy(false) {
+		return
+	}
+	m.peersMu.Lock()
+	defer m.peersMu.Unlock()
+	for id, owner := range m.owners {
+		if owner == c {
+			delete(m.owners, id)
+		}
+	}
+}
+
+// checkDown marks the client unhealthy if err means that its sentry can't be reached,
+// and reports whether it did.
+func (m *sentryMultiplexer) checkDown(c *client, err error) bool {
+	if !isSentryDown(err) {
+		return false
+	}
+	m.markDown(c)
+	return true
+}
+
+func (m *sentryMultiplexer) anyHealthy() bool {
+	for _, c := range m.clients {
+		if c.isHealthy() {
+			return true
+		}
+	}
+	return false
+}
+
+// handShake handshakes the client and, if the status was already set, sends it to
+// the sentry, which is needed for a sentry that restarted to be able to serve again.
+func (m *sentryMultiplexer) handShake(ctx context.Context, c *client) error {
+	reply, err := c.HandShake(ctx, &emptypb.Empty{})
+	if err != nil {
+		m.checkDown(c, err)
+		return err
+	}
+
+	m.statusMu.RLock()
+	statusData := m.status
+	m.statusMu.RUnlock()
+
+	if statusData != nil {
+		if _, err := c.SetStatus(ctx, statusData); err != nil {
+			m.checkDown(c, err)
+			return err
+		}
+	}
+
+	c.Lock()
+	c.protocol = reply.Protocol
+	c.Unlock()
+	c.setHealthy(true)
+
+	return nil
+}
+
+// Run probes the sentries every healthCheckInterval until ctx is done: sentries which
+// don't answer are marked unhealthy and skipped by the multiplexer, while unhealthy
+// ones are handshaken again and brought back once they answer.
+func (m *sentryMultiplexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.checkHealth(ctx)
+		}
+	}
+}
+
+func (m *sentryMultiplexer) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, client := range m.clients {
+		client := client
+
+		client.RLock()
+		handshaken := client.protocol >= 0
+		client.RUnlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			if !client.isHealthy() {
+				_ = m.handShake(probeCtx, client)
+				return
+			}
+
+			// clients which were not handshaken yet are left to the HandShake of the
+			// multiplexer, as the status is not known before it
+			if !handshaken {
+				return
+			}
+
+			// a sentry which doesn't answer in time is as good as down
+			if _, err := client.PeerCount(probeCtx, &sentryproto.PeerCountRequest{}); err != nil && ctx.Err() == nil {
+				m.markDown(client)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// learnPeer records that the sentry of the client is connected to the peer, unless
+// the peer is already known to be connected to another healthy sentry.
+func (m *sentryMultiplexer) learnPeer(c *client, peerId string) {
+	m.peersMu.Lock()
+	defer m.peersMu.Unlock()
+	if owner, ok := m.owners[peerId]; ok && owner != c && owner.isHealthy() {
+		return
+	}
+	m.owners[peerId] = c
+}
+
+// forgetPeer drops the peer from the index if it is recorded as connected to the
+// sentry of the client.
+func (m *sentryMultiplexer) forgetPeer(c *client, peerId string) {
+	m.peersMu.Lock()
+	defer m.peersMu.Unlock()
+	if m.owners[peerId] == c {
+		delete(m.owners, peerId)
+	}
+}
+
+// owner returns the healthy client connected to the peer which supports minProtocol,
+// or nil if the index doesn't know one.
+func (m *sentryMultiplexer) owner(peerId string, minProtocol sentryproto.Protocol) *client {
+	m.peersMu.RLock()
+	owner := m.owners[peerId]
+	m.peersMu.RUnlock()
+
+	if owner == nil || !owner.isHealthy() {
+		return nil
+	}
+
+	owner.RLock()
+	defer owner.RUnlock()
+	if owner.protocol < minProtocol {
+		return nil
+	}
+	return owner
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/gointerfaces"
@@ -28,40 +30,92 @@ type client struct {
 	sync.RWMutex
 	sentryproto.SentryClient
 	protocol sentryproto.Protocol
+	healthy  bool
+	up       chan struct{} // closed while the client is healthy
 }
 
+// sentryMultiplexer presents several sentries as a single one. Sentries which can't be
+// reached are left out until they recover, and messages to a peer are routed to the
+// sentry connected to it.
 type sentryMultiplexer struct {
 	clients []*client
+
+	statusMu sync.RWMutex
+	status   *sentryproto.StatusData
+
+	peersMu sync.RWMutex
+	owners  map[string]*client // peer id -> client of the sentry connected to the peer
 }
 
 func NewSentryMultiplexer(clients []sentryproto.SentryClient) *sentryMultiplexer {
-	mux := &sentryMultiplexer{}
+	mux := &sentryMultiplexer{owners: map[string]*client{}}
 	mux.clients = make([]*client, len(clients))
 	for i, c := range clients {
-		mux.clients[i] = &client{sync.RWMutex{}, c, -1}
+		mux.clients[i] = newMuxClient(c)
 	}
 	return mux
 }
 
-func (m *sentryMultiplexer) SetStatus(ctx context.Context, in *sentryproto.StatusData, opts ...grpc.CallOption) (*sentryproto.SetStatusReply, error) {
+// fanOut calls f concurrently for each healthy client. Clients whose sentry turns out
+// to be down are marked unhealthy and left out, so that the call only fails when a
+// sentry rejects it or when none of the sentries is able to serve it.
+func (m *sentryMultiplexer) fanOut(ctx context.Context, f func(ctx context.Context, client *client) error) error {
+	if len(m.clients) == 0 {
+		return nil
+	}
+
 	g, gctx := errgroup.WithContext(ctx)
 
+	var served atomic.Int32
+
 	for _, client := range m.clients {
 		client := client
 
+		if !client.isHealthy() {
+			continue
+		}
+
+		g.Go(func() error {
+			if err := f(gctx, client); err != nil {
+				if m.checkDown(client, err) {
+					return nil
+				}
+				return err
+			}
+			served.Add(1)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	if served.Load() == 0 {
+		return errNoHealthySentry
+	}
+
+	return nil
+}
+
+func (m *sentryMultiplexer) SetStatus(ctx context.Context, in *sentryproto.StatusData, opts ...grpc.CallOption) (*sentryproto.SetStatusReply, error) {
+	// kept to be sent to the sentries which recover
+	m.statusMu.Lock()
+	m.status = in
+	m.statusMu.Unlock()
+
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
 		client.RLock()
 		protocol := client.protocol
 		client.RUnlock()
 
-		if protocol >= 0 {
-			g.Go(func() error {
-				_, err := client.SetStatus(gctx, in, opts...)
-				return err
-			})
+		if protocol < 0 {
+			return nil
 		}
-	}
 
-	err := g.Wait()
+		_, err := client.SetStatus(ctx, in, opts...)
+		return err
+	})
 
 	if err != nil {
 		return nil, err
@@ -71,77 +125,55 @@ func (m *sentryMultiplexer) SetStatus(ctx context.Context, in *sentryproto.Statu
 }
 
 func (m *sentryMultiplexer) PenalizePeer(ctx context.Context, in *sentryproto.PenalizePeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	g, gctx := errgroup.WithContext(ctx)
-
-	for _, client := range m.clients {
-		client := client
-
-		g.Go(func() error {
-			_, err := client.PenalizePeer(gctx, in, opts...)
-			return err
-		})
-	}
-
-	return &emptypb.Empty{}, g.Wait()
+	return &emptypb.Empty{}, m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		_, err := client.PenalizePeer(ctx, in, opts...)
+		return err
+	})
 }
 
 func (m *sentryMultiplexer) PeerMinBlock(ctx context.Context, in *sentryproto.PeerMinBlockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	g, gctx := errgroup.WithContext(ctx)
-
-	for _, client := range m.clients {
-		client := client
-
-		g.Go(func() error {
-			_, err := client.PeerMinBlock(gctx, in, opts...)
-			return err
-		})
-	}
-
-	return &emptypb.Empty{}, g.Wait()
+	return &emptypb.Empty{}, m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		_, err := client.PeerMinBlock(ctx, in, opts...)
+		return err
+	})
 }
 
-// Handshake is not performed on the multi-client level
+// HandShake handshakes the clients which were not handshaken yet, or which were found
+// to be down, and returns the highest protocol of the healthy sentries. It only fails
+// if none of the sentries is healthy afterwards.
 func (m *sentryMultiplexer) HandShake(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.HandShakeReply, error) {
-	g, gctx := errgroup.WithContext(ctx)
-
-	var protocol sentryproto.Protocol
-	var mu sync.Mutex
+	var wg sync.WaitGroup
 
 	for _, client := range m.clients {
 		client := client
 
 		client.RLock()
-		protocol := client.protocol
+		handshaken := client.protocol >= 0 && client.healthy
 		client.RUnlock()
 
-		if protocol < 0 {
-			g.Go(func() error {
-				reply, err := client.HandShake(gctx, &emptypb.Empty{}, grpc.WaitForReady(true))
-
-				if err != nil {
-					return err
-				}
+		if !handshaken {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = m.handShake(ctx, client)
+			}()
+		}
+	}
 
-				mu.Lock()
-				defer mu.Unlock()
+	wg.Wait()
 
-				if reply.Protocol > protocol {
-					protocol = reply.Protocol
-				}
+	protocol := sentryproto.Protocol(-1)
 
-				client.Lock()
-				client.protocol = protocol
-				client.Unlock()
-
-				return nil
-			})
+	for _, client := range m.clients {
+		client.RLock()
+		if client.healthy && client.protocol > protocol {
+			protocol = client.protocol
 		}
+		client.RUnlock()
 	}
 
-	err := g.Wait()
-
-	if err != nil {
-		return nil, err
+	if protocol < 0 {
+		return nil, errNoHealthySentry
 	}
 
 	return &sentryproto.HandShakeReply{Protocol: protocol}, nil
@@ -155,6 +187,10 @@ func (m *sentryMultiplexer) SendMessageByMinBlock(ctx context.Context, in *sentr
 	// sends via cliants with duplicate peers - that would require
 	// a refactor of the entry code
 	for _, client := range m.clients {
+		if !client.isHealthy() {
+			continue
+		}
+
 		cin := &sentryproto.SendMessageByMinBlockRequest{
 			Data:     in.Data,
 			MinBlock: in.MinBlock,
@@ -164,6 +200,9 @@ func (m *sentryMultiplexer) SendMessageByMinBlock(ctx context.Context, in *sentr
 		sentPeers, err := client.SendMessageByMinBlock(ctx, cin, opts...)
 
 		if err != nil {
+			if m.checkDown(client, err) {
+				continue
+			}
 			return nil, err
 		}
 
@@ -197,50 +236,41 @@ func (m *sentryMultiplexer) SendMessageById(ctx context.Context, in *sentryproto
 		return nil, fmt.Errorf("unknown protocol for: %s", in.Data.Id.String())
 	}
 
-	peerReplies, err := m.peersByClient(ctx, minProtocol, opts...)
+	peerId := AsPeerIdString(in.PeerId)
 
-	if err != nil {
-		return nil, err
-	}
+	owner := m.owner(peerId, minProtocol)
 
-	clientIndex := -1
-	peerId := AsPeerIdString(in.PeerId)
+	// each failed attempt leaves the owner out, the peer may still be
+	// connected to one of the other sentries
+	for attempt := 0; attempt <= len(m.clients); attempt++ {
+		if owner == nil {
+			if _, err := m.peersByClient(ctx, minProtocol, opts...); err != nil {
+				return nil, err
+			}
 
-CLIENTS:
-	for i, reply := range peerReplies {
-		for _, peer := range reply.GetPeers() {
-			if peer.Id == peerId {
-				clientIndex = i
-				break CLIENTS
+			if owner = m.owner(peerId, minProtocol); owner == nil {
+				break
 			}
 		}
-	}
 
-	if clientIndex < 0 {
-		return nil, fmt.Errorf("peer not found: %s", peerId)
-	}
+		sentPeers, err := owner.SendMessageById(ctx, in, opts...)
 
-	g, gctx := errgroup.WithContext(ctx)
-
-	var allSentPeers []*typesproto.H512
-
-	g.Go(func() error {
-		sentPeers, err := m.clients[clientIndex].SendMessageById(gctx, in, opts...)
-
-		if err != nil {
-			return err
+		if err == nil {
+			return &sentryproto.SentPeers{Peers: sentPeers.GetPeers()}, nil
 		}
 
-		allSentPeers = sentPeers.GetPeers()
-
-		return nil
-	})
+		switch {
+		case m.checkDown(owner, err):
+		case IsPeerNotFoundErr(err):
+			m.forgetPeer(owner, peerId)
+		default:
+			return nil, err
+		}
 
-	if err = g.Wait(); err != nil {
-		return nil, err
+		owner = nil
 	}
 
-	return &sentryproto.SentPeers{Peers: allSentPeers}, nil
+	return nil, fmt.Errorf("peer not found: %s", peerId)
 }
 
 func (m *sentryMultiplexer) SendMessageToRandomPeers(ctx context.Context, in *sentryproto.SendMessageToRandomPeersRequest, opts ...grpc.CallOption) (*sentryproto.SentPeers, error) {
@@ -299,12 +329,16 @@ func (m *sentryMultiplexer) SendMessageToRandomPeers(ctx context.Context, in *se
 		peer := peer
 
 		g.Go(func() error {
-			sentPeers, err := m.clients[peer.clientIndex].SendMessageById(gctx, &sentryproto.SendMessageByIdRequest{
+			client := m.clients[peer.clientIndex]
+			sentPeers, err := client.SendMessageById(gctx, &sentryproto.SendMessageByIdRequest{
 				PeerId: peer.peerId,
 				Data:   in.Data,
 			}, opts...)
 
 			if err != nil {
+				if m.checkDown(client, err) {
+					return nil
+				}
 				return err
 			}
 
@@ -366,7 +400,8 @@ func (m *sentryMultiplexer) SendMessageToAll(ctx context.Context, in *sentryprot
 		peer := peer
 
 		g.Go(func() error {
-			sentPeers, err := m.clients[peer.clientIndex].SendMessageById(gctx, &sentryproto.SendMessageByIdRequest{
+			client := m.clients[peer.clientIndex]
+			sentPeers, err := client.SendMessageById(gctx, &sentryproto.SendMessageByIdRequest{
 				PeerId: peer.peerId,
 				Data: &sentryproto.OutboundMessageData{
 					Id:   in.Id,
@@ -374,6 +409,9 @@ func (m *sentryMultiplexer) SendMessageToAll(ctx context.Context, in *sentryprot
 				}}, opts...)
 
 			if err != nil {
+				if m.checkDown(client, err) {
+					return nil
+				}
 				return err
 			}
 
@@ -456,11 +494,24 @@ func (c *SentryStreamC[T]) RecvMsg(anyMessage interface{}) error {
 	return nil
 }
 
-func (m *sentryMultiplexer) Messages(ctx context.Context, in *sentryproto.MessagesRequest, opts ...grpc.CallOption) (sentryproto.Sentry_MessagesClient, error) {
+type recvStream[T protoreflect.ProtoMessage] interface {
+	Recv() (T, error)
+}
+
+// multiplexStream merges the streams opened by open on each client. The stream of a
+// sentry which goes down is opened again once the sentry recovers, so the merged stream
+// only ends when all the streams ended, when one of them fails for another reason, or
+// when none of the sentries is left.
+func multiplexStream[T protoreflect.ProtoMessage](
+	ctx context.Context,
+	m *sentryMultiplexer,
+	open func(context.Context, *client) (recvStream[T], error),
+	received func(*client, T),
+) *SentryStreamC[T] {
 	g, gctx := errgroup.WithContext(ctx)
 
-	ch := make(chan StreamReply[*sentryproto.InboundMessage], 16384)
-	streamServer := &SentryStreamS[*sentryproto.InboundMessage]{Ch: ch, Ctx: ctx}
+	ch := make(chan StreamReply[T], 16384)
+	streamServer := &SentryStreamS[T]{Ch: ch, Ctx: ctx}
 
 	go func() {
 		defer close(ch)
@@ -469,33 +520,41 @@ func (m *sentryMultiplexer) Messages(ctx context.Context, in *sentryproto.Messag
 			client := client
 
 			g.Go(func() error {
-				messages, err := client.Messages(gctx, in, opts...)
-
-				if err != nil {
-					streamServer.Err(err)
-					return err
-				}
-
 				for {
-					inboundMessage, err := messages.Recv()
+					if err := client.waitHealthy(gctx); err != nil {
+						return err
+					}
+
+					stream, err := open(gctx, client)
 
-					if err != nil {
-						if errors.Is(err, io.EOF) {
-							return nil
+					for err == nil {
+						var message T
+						if message, err = stream.Recv(); err == nil {
+							received(client, message)
+							streamServer.Send(message)
 						}
+					}
 
-						streamServer.Err(err)
+					if errors.Is(err, io.EOF) {
+						return nil
+					}
 
-						select {
-						case <-gctx.Done():
-							return gctx.Err()
-						default:
+					if m.checkDown(client, err) {
+						if m.anyHealthy() {
+							continue
 						}
+						err = errNoHealthySentry
+					}
 
-						return fmt.Errorf("recv: %w", err)
+					streamServer.Err(err)
+
+					select {
+					case <-gctx.Done():
+						return gctx.Err()
+					default:
 					}
 
-					streamServer.Send(inboundMessage)
+					return fmt.Errorf("recv: %w", err)
 				}
 			})
 		}
@@ -503,44 +562,54 @@ func (m *sentryMultiplexer) Messages(ctx context.Context, in *sentryproto.Messag
 		g.Wait()
 	}()
 
-	return &SentryStreamC[*sentryproto.InboundMessage]{Ch: ch, Ctx: ctx}, nil
+	return &SentryStreamC[T]{Ch: ch, Ctx: ctx}
 }
 
-func (m *sentryMultiplexer) peersByClient(ctx context.Context, minProtocol sentryproto.Protocol, opts ...grpc.CallOption) ([]*sentryproto.PeersReply, error) {
-	g, gctx := errgroup.WithContext(ctx)
+func (m *sentryMultiplexer) Messages(ctx context.Context, in *sentryproto.MessagesRequest, opts ...grpc.CallOption) (sentryproto.Sentry_MessagesClient, error) {
+	open := func(ctx context.Context, client *client) (recvStream[*sentryproto.InboundMessage], error) {
+		return client.Messages(ctx, in, opts...)
+	}
+
+	// a message from a peer tells which sentry it is connected to
+	received := func(client *client, message *sentryproto.InboundMessage) {
+		if message.GetPeerId() != nil {
+			m.learnPeer(client, AsPeerIdString(message.PeerId))
+		}
+	}
 
+	return multiplexStream(ctx, m, open, received), nil
+}
+
+func (m *sentryMultiplexer) peersByClient(ctx context.Context, minProtocol sentryproto.Protocol, opts ...grpc.CallOption) ([]*sentryproto.PeersReply, error) {
 	var allReplies []*sentryproto.PeersReply = make([]*sentryproto.PeersReply, len(m.clients))
 	var allMutex sync.RWMutex
 
-	for i, client := range m.clients {
-		i := i
-		client := client
-
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
 		client.RLock()
 		protocol := client.protocol
 		client.RUnlock()
 
 		if protocol < minProtocol {
-			continue
+			return nil
 		}
 
-		g.Go(func() error {
-			sentPeers, err := client.Peers(gctx, &emptypb.Empty{}, opts...)
+		sentPeers, err := client.Peers(ctx, &emptypb.Empty{}, opts...)
 
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return err
+		}
 
-			allMutex.Lock()
-			defer allMutex.Unlock()
+		for _, peer := range sentPeers.GetPeers() {
+			m.learnPeer(client, peer.Id)
+		}
 
-			allReplies[i] = sentPeers
+		allMutex.Lock()
+		defer allMutex.Unlock()
 
-			return nil
-		})
-	}
+		allReplies[slices.Index(m.clients, client)] = sentPeers
 
-	err := g.Wait()
+		return nil
+	})
 
 	if err != nil {
 		return nil, err
@@ -566,31 +635,23 @@ func (m *sentryMultiplexer) Peers(ctx context.Context, in *emptypb.Empty, opts .
 }
 
 func (m *sentryMultiplexer) PeerCount(ctx context.Context, in *sentryproto.PeerCountRequest, opts ...grpc.CallOption) (*sentryproto.PeerCountReply, error) {
-	g, gctx := errgroup.WithContext(ctx)
-
 	var allCount uint64
 	var allMutex sync.RWMutex
 
-	for _, client := range m.clients {
-		client := client
-
-		g.Go(func() error {
-			peerCount, err := client.PeerCount(gctx, in, opts...)
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		peerCount, err := client.PeerCount(ctx, in, opts...)
 
-			if err != nil {
-				return err
-			}
+		if err != nil {
+			return err
+		}
 
-			allMutex.Lock()
-			defer allMutex.Unlock()
+		allMutex.Lock()
+		defer allMutex.Unlock()
 
-			allCount += peerCount.GetCount()
+		allCount += peerCount.GetCount()
 
-			return nil
-		})
-	}
-
-	err := g.Wait()
+		return nil
+	})
 
 	if err != nil {
 		return nil, err
@@ -602,38 +663,31 @@ func (m *sentryMultiplexer) PeerCount(ctx context.Context, in *sentryproto.PeerC
 var errFound = fmt.Errorf("found peer")
 
 func (m *sentryMultiplexer) PeerById(ctx context.Context, in *sentryproto.PeerByIdRequest, opts ...grpc.CallOption) (*sentryproto.PeerByIdReply, error) {
-	g, gctx := errgroup.WithContext(ctx)
-
 	var peer *typesproto.PeerInfo
 	var peerMutex sync.RWMutex
 
-	for _, client := range m.clients {
-		client := client
-
-		g.Go(func() error {
-			reply, err := client.PeerById(gctx, in, opts...)
-
-			if err != nil {
-				return err
-			}
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		reply, err := client.PeerById(ctx, in, opts...)
 
-			peerMutex.Lock()
-			defer peerMutex.Unlock()
+		if err != nil {
+			return err
+		}
 
-			if peer == nil && reply.GetPeer() != nil {
-				peer = reply.GetPeer()
-				// return a success error here to have the
-				// group stop other concurrent requests
-				return errFound
-			}
+		peerMutex.Lock()
+		defer peerMutex.Unlock()
 
-			return nil
-		})
-	}
+		if peer == nil && reply.GetPeer() != nil {
+			peer = reply.GetPeer()
+			m.learnPeer(client, peer.Id)
+			// return a success error here to have the
+			// group stop other concurrent requests
+			return errFound
+		}
 
-	err := g.Wait()
+		return nil
+	})
 
-	if err != nil && !errors.Is(errFound, err) {
+	if err != nil && !errors.Is(err, errFound) {
 		return nil, err
 	}
 
@@ -641,126 +695,158 @@ func (m *sentryMultiplexer) PeerById(ctx context.Context, in *sentryproto.PeerBy
 }
 
 func (m *sentryMultiplexer) PeerEvents(ctx context.Context, in *sentryproto.PeerEventsRequest, opts ...grpc.CallOption) (sentryproto.Sentry_PeerEventsClient, error) {
-	g, gctx := errgroup.WithContext(ctx)
+	open := func(ctx context.Context, client *client) (recvStream[*sentryproto.PeerEvent], error) {
+		return client.PeerEvents(ctx, in, opts...)
+	}
 
-	ch := make(chan StreamReply[*sentryproto.PeerEvent], 16384)
-	streamServer := &SentryStreamS[*sentryproto.PeerEvent]{Ch: ch, Ctx: ctx}
+	// keep the peer index in sync with the sentries
+	received := func(client *client, event *sentryproto.PeerEvent) {
+		if event.GetPeerId() == nil {
+			return
+		}
 
-	go func() {
-		defer close(ch)
+		switch peerId := AsPeerIdString(event.PeerId); event.EventId {
+		case sentryproto.PeerEvent_Connect:
+			m.learnPeer(client, peerId)
+		case sentryproto.PeerEvent_Disconnect:
+			m.forgetPeer(client, peerId)
+		}
+	}
 
-		for _, client := range m.clients {
-			client := client
+	return multiplexStream(ctx, m, open, received), nil
+}
 
-			g.Go(func() error {
-				messages, err := client.PeerEvents(gctx, in, opts...)
+func (m *sentryMultiplexer) AddPeer(ctx context.Context, in *sentryproto.AddPeerRequest, opts ...grpc.CallOption) (*sentryproto.AddPeerReply, error) {
+	var success bool
+	var successMutex sync.RWMutex
 
-				if err != nil {
-					streamServer.Err(err)
-					return err
-				}
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		result, err := client.AddPeer(ctx, in, opts...)
 
-				for {
-					inboundMessage, err := messages.Recv()
+		if err != nil {
+			return err
+		}
 
-					if err != nil {
-						if errors.Is(err, io.EOF) {
-							return nil
-						}
+		successMutex.Lock()
+		defer successMutex.Unlock()
 
-						streamServer.Err(err)
+		// if any client returns success return success
+		if !success && result.GetSuccess() {
+			success = true
+		}
 
-						select {
-						case <-gctx.Done():
-							return gctx.Err()
-						default:
-						}
+		return nil
+	})
 
-						return fmt.Errorf("recv: %w", err)
-					}
+	if err != nil {
+		return nil, err
+	}
 
-					streamServer.Send(inboundMessage)
-				}
-			})
+	return &sentryproto.AddPeerReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) NodeInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*typesproto.NodeInfoReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, `method "NodeInfo" not implemented: use "NodeInfos" instead`)
+}
+
+func (m *sentryMultiplexer) NodeInfos(ctx context.Context, opts ...grpc.CallOption) ([]*typesproto.NodeInfoReply, error) {
+	var allInfos []*typesproto.NodeInfoReply
+	var allMutex sync.RWMutex
+
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		info, err := client.NodeInfo(ctx, &emptypb.Empty{}, opts...)
+
+		if err != nil {
+			return err
 		}
 
-		g.Wait()
-	}()
+		allMutex.Lock()
+		defer allMutex.Unlock()
 
-	return &SentryStreamC[*sentryproto.PeerEvent]{Ch: ch, Ctx: ctx}, nil
-}
+		allInfos = append(allInfos, info)
 
-func (m *sentryMultiplexer) AddPeer(ctx context.Context, in *sentryproto.AddPeerRequest, opts ...grpc.CallOption) (*sentryproto.AddPeerReply, error) {
-	g, gctx := errgroup.WithContext(ctx)
+		return nil
+	})
 
-	var success bool
-	var successMutex sync.RWMutex
+	if err != nil {
+		return nil, err
+	}
 
-	for _, client := range m.clients {
-		client := client
+	return allInfos, nil
+}
 
-		g.Go(func() error {
-			result, err := client.AddPeer(gctx, in, opts...)
+func (m *sentryMultiplexer) PeerStats(ctx context.Context, in *sentryproto.PeerStatsRequest, opts ...grpc.CallOption) (*sentryproto.PeerStatsReply, error) {
+	var allStats []*sentryproto.PeerStats
+	var allMutex sync.RWMutex
 
-			if err != nil {
-				return err
-			}
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		reply, err := client.PeerStats(ctx, in, opts...)
 
-			successMutex.Lock()
-			defer successMutex.Unlock()
+		if err != nil {
+			return err
+		}
 
-			// if any client returns success return success
-			if !success && result.GetSuccess() {
-				success = true
-			}
+		allMutex.Lock()
+		defer allMutex.Unlock()
 
-			return nil
-		})
-	}
+		allStats = append(allStats, reply.GetPeers()...)
 
-	err := g.Wait()
+		return nil
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &sentryproto.AddPeerReply{Success: success}, nil
+	return &sentryproto.PeerStatsReply{Peers: allStats}, nil
 }
 
-func (m *sentryMultiplexer) NodeInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*typesproto.NodeInfoReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, `method "NodeInfo" not implemented: use "NodeInfos" instead`)
+func (m *sentryMultiplexer) BanPeer(ctx context.Context, in *sentryproto.BanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		_, err := client.BanPeer(ctx, in, opts...)
+		return err
+	})
 }
 
-func (m *sentryMultiplexer) NodeInfos(ctx context.Context, opts ...grpc.CallOption) ([]*typesproto.NodeInfoReply, error) {
-	g, gctx := errgroup.WithContext(ctx)
+func (m *sentryMultiplexer) UnbanPeer(ctx context.Context, in *sentryproto.UnbanPeerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		_, err := client.UnbanPeer(ctx, in, opts...)
+		return err
+	})
+}
 
-	var allInfos []*typesproto.NodeInfoReply
+func (m *sentryMultiplexer) BannedPeers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.BannedPeersReply, error) {
+	allBanned := map[string]*typesproto.BannedPeerInfo{}
 	var allMutex sync.RWMutex
 
-	for _, client := range m.clients {
-		client := client
-
-		g.Go(func() error {
-			info, err := client.NodeInfo(gctx, &emptypb.Empty{}, opts...)
+	err := m.fanOut(ctx, func(ctx context.Context, client *client) error {
+		reply, err := client.BannedPeers(ctx, in, opts...)
 
-			if err != nil {
-				return err
-			}
-
-			allMutex.Lock()
-			defer allMutex.Unlock()
+		if err != nil {
+			return err
+		}
 
-			allInfos = append(allInfos, info)
+		allMutex.Lock()
+		defer allMutex.Unlock()
 
-			return nil
-		})
-	}
+		// the same node may be banned by several sentries
+		for _, peer := range reply.GetPeers() {
+			if _, ok := allBanned[peer.GetId()]; !ok {
+				allBanned[peer.GetId()] = peer
+			}
+		}
 
-	err := g.Wait()
+		return nil
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	return allInfos, nil
+	reply := &sentryproto.BannedPeersReply{Peers: make([]*typesproto.BannedPeerInfo, 0, len(allBanned))}
+	for _, peer := range allBanned {
+		reply.Peers = append(reply.Peers, peer)
+	}
+
+	return reply, nil
 }
@@ -81,6 +81,7 @@ type Index struct {
 	startSeed          []uint64
 	golombRice         []uint32
 	mmapHandle1        []byte // mmap handle for unix (this is used to close mmap)
+	mmapRegion         *mmap.Region
 	ef                 eliasfano16.DoubleEliasFano
 	bucketSize         int
 	size               int64
@@ -145,6 +146,7 @@ func OpenIndex(indexFilePath string) (idx *Index, err error) {
 		return nil, err
 	}
 	idx.data = idx.mmapHandle1[:idx.size]
+	idx.mmapRegion = mmap.DefaultBudget.Register(fName, idx.mmapHandle1)
 	defer idx.MadvSequential().DisableReadAhead()
 
 	// Read number of keys and bytes per record
@@ -266,12 +268,15 @@ func (idx *Index) ModTime() time.Time { return idx.modTime }
 func (idx *Index) BaseDataID() uint64 { return idx.baseDataID }
 func (idx *Index) FilePath() string   { return idx.filePath }
 func (idx *Index) FileName() string   { return idx.fileName }
+func (idx *Index) Salt() uint32       { return idx.salt }
 func (idx *Index) IsOpen() bool       { return idx != nil && idx.f != nil }
 
 func (idx *Index) Close() {
 	if idx == nil || idx.f == nil {
 		return
 	}
+	mmap.DefaultBudget.Unregister(idx.mmapRegion)
+	idx.mmapRegion = nil
 	if err := mmap.Munmap(idx.mmapHandle1, idx.mmapHandle2); err != nil {
 		log.Log(dbg.FileCloseLogLevel, "unmap", "err", err, "file", idx.FileName(), "stack", dbg.Stack())
 	}
@@ -313,6 +318,7 @@ func (idx *Index) Lookup(bucketHash, fingerprint uint64) (uint64, bool) {
 	if idx.keyCount == 1 {
 		return 0, true
 	}
+	idx.mmapRegion.Touch()
 	var gr GolombRiceReader
 	gr.data = idx.grData
 
@@ -380,6 +386,7 @@ func (idx *Index) Lookup(bucketHash, fingerprint uint64) (uint64, bool) {
 // Perfect hash table lookup is not performed, only access to the
 // Elias-Fano structure containing all offsets.
 func (idx *Index) OrdinalLookup(i uint64) uint64 {
+	idx.mmapRegion.Touch()
 	return idx.offsetEf.Get(i)
 }
 
@@ -45,6 +45,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/diagnostics"
 	"github.com/erigontech/erigon-lib/kv"
@@ -745,8 +746,8 @@ func (a *Aggregator) RemoveOverlapsAfterMerge(ctx context.Context) (err error) {
 }
 
 func (a *Aggregator) MergeLoop(ctx context.Context) (err error) {
-	if dbg.NoMerge() || !a.mergingFiles.CompareAndSwap(false, true) {
-		return nil // currently merging or merge is prohibited
+	if dbg.NoMerge() || disk.IsSpaceLow() || !a.mergingFiles.CompareAndSwap(false, true) {
+		return nil // currently merging or merge is prohibited, or postponed until free disk space is back: merge needs space for new files before old are deleted
 	}
 
 	// Merge is background operation. It must not crush application.
@@ -1223,9 +1224,9 @@ func (a *Aggregator) recalcVisibleFilesMinimaxTxNum() {
 func (at *AggregatorRoTx) findMergeRange(maxEndTxNum, maxSpan uint64) *Ranges {
 	r := &Ranges{invertedIndex: make([]*MergeRange, len(at.a.iis))}
 	if at.a.commitmentValuesTransform {
-		lmrAcc := at.d[kv.AccountsDomain].files.LatestMergedRange()
-		lmrSto := at.d[kv.StorageDomain].files.LatestMergedRange()
-		lmrCom := at.d[kv.CommitmentDomain].files.LatestMergedRange()
+		lmrAcc := at.d[kv.AccountsDomain].files.LatestMergedRange(at.StepSize())
+		lmrSto := at.d[kv.StorageDomain].files.LatestMergedRange(at.StepSize())
+		lmrCom := at.d[kv.CommitmentDomain].files.LatestMergedRange(at.StepSize())
 
 		if !lmrCom.Equal(&lmrAcc) || !lmrCom.Equal(&lmrSto) {
 			// ensure that we do not make further merge progress until ranges are not equal
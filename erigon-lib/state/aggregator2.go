@@ -45,6 +45,9 @@ func NewAggregator2(ctx context.Context, dirs datadir.Dirs, aggregationStep uint
 	if err := a.registerDomain(kv.RCacheDomain, salt, dirs, logger); err != nil {
 		return nil, err
 	}
+	if err := a.registerDomain(kv.AccessListDomain, salt, dirs, logger); err != nil {
+		return nil, err
+	}
 	if err := a.registerII(kv.LogAddrIdx, salt, dirs, logger); err != nil {
 		return nil, err
 	}
@@ -57,6 +60,12 @@ func NewAggregator2(ctx context.Context, dirs datadir.Dirs, aggregationStep uint
 	if err := a.registerII(kv.TracesToIdx, salt, dirs, logger); err != nil {
 		return nil, err
 	}
+	if err := a.registerII(kv.AddrAppearanceIdx, salt, dirs, logger); err != nil {
+		return nil, err
+	}
+	if err := a.registerII(kv.WithdrawalAddrIdx, salt, dirs, logger); err != nil {
+		return nil, err
+	}
 	a.KeepRecentTxnsOfHistoriesWithDisabledSnapshots(100_000) // ~1k blocks of history
 
 	a.dirtyFilesLock.Lock()
@@ -77,16 +86,19 @@ func init() {
 }
 
 type SchemaGen struct {
-	AccountsDomain   domainCfg
-	StorageDomain    domainCfg
-	CodeDomain       domainCfg
-	CommitmentDomain domainCfg
-	ReceiptDomain    domainCfg
-	RCacheDomain     domainCfg
-	LogAddrIdx       iiCfg
-	LogTopicIdx      iiCfg
-	TracesFromIdx    iiCfg
-	TracesToIdx      iiCfg
+	AccountsDomain    domainCfg
+	StorageDomain     domainCfg
+	CodeDomain        domainCfg
+	CommitmentDomain  domainCfg
+	ReceiptDomain     domainCfg
+	RCacheDomain      domainCfg
+	AccessListDomain  domainCfg
+	LogAddrIdx        iiCfg
+	LogTopicIdx       iiCfg
+	TracesFromIdx     iiCfg
+	TracesToIdx       iiCfg
+	AddrAppearanceIdx iiCfg
+	WithdrawalAddrIdx iiCfg
 }
 
 type Versioned interface {
@@ -95,13 +107,13 @@ type Versioned interface {
 
 func (s *SchemaGen) GetVersioned(name string) (Versioned, error) {
 	switch name {
-	case "accounts", "storage", "code", "commitment", "receipt", "rcache":
+	case "accounts", "storage", "code", "commitment", "receipt", "rcache", "accesslist":
 		domain, err := kv.String2Domain(name)
 		if err != nil {
 			return nil, err
 		}
 		return s.GetDomainCfg(domain), nil
-	case "logtopics", "logaddrs", "tracesfrom", "tracesto":
+	case "logtopics", "logaddrs", "tracesfrom", "tracesto", "appearances", "withdrawals":
 		ii, err := kv.String2InvertedIdx(name)
 		if err != nil {
 			return nil, err
@@ -127,6 +139,8 @@ func (s *SchemaGen) GetDomainCfg(name kv.Domain) domainCfg {
 		v = s.ReceiptDomain
 	case kv.RCacheDomain:
 		v = s.RCacheDomain
+	case kv.AccessListDomain:
+		v = s.AccessListDomain
 	default:
 		v = domainCfg{}
 	}
@@ -145,6 +159,10 @@ func (s *SchemaGen) GetIICfg(name kv.InvertedIdx) iiCfg {
 		v = s.TracesFromIdx
 	case kv.TracesToIdx:
 		v = s.TracesToIdx
+	case kv.AddrAppearanceIdx:
+		v = s.AddrAppearanceIdx
+	case kv.WithdrawalAddrIdx:
+		v = s.WithdrawalAddrIdx
 	default:
 		v = iiCfg{}
 	}
@@ -282,6 +300,30 @@ var Schema = SchemaGen{
 			},
 		},
 	},
+	AccessListDomain: domainCfg{
+		name: kv.AccessListDomain, valuesTable: kv.TblAccessListVals,
+		largeValues: true,
+
+		Accessors:   AccessorHashMap,
+		CompressCfg: DomainCompressCfg, Compression: seg.CompressNone,
+
+		hist: histCfg{
+			valuesTable: kv.TblAccessListHistoryVals,
+			Compression: seg.CompressNone,
+
+			historyLargeValues: true,
+			historyIdx:         kv.AccessListHistoryIdx,
+
+			snapshotsDisabled:             true,
+			historyValuesOnCompressedPage: 16,
+
+			iiCfg: iiCfg{
+				disable:      true, // see EnableAccessLists
+				filenameBase: kv.AccessListDomain.String(), keysTable: kv.TblAccessListHistoryKeys, valuesTable: kv.TblAccessListIdx,
+				CompressorCfg: seg.DefaultCfg,
+			},
+		},
+	},
 
 	LogAddrIdx: iiCfg{
 		filenameBase: kv.FileLogAddressIdx, keysTable: kv.TblLogAddressKeys, valuesTable: kv.TblLogAddressIdx,
@@ -307,6 +349,20 @@ var Schema = SchemaGen{
 		Compression: seg.CompressNone,
 		name:        kv.TracesToIdx,
 	},
+	AddrAppearanceIdx: iiCfg{
+		disable:      true, // see EnableAddressAppearances
+		filenameBase: kv.FileAddrAppearanceIdx, keysTable: kv.TblAddrAppearanceKeys, valuesTable: kv.TblAddrAppearanceIdx,
+
+		Compression: seg.CompressNone,
+		name:        kv.AddrAppearanceIdx,
+	},
+	WithdrawalAddrIdx: iiCfg{
+		disable:      true, // see EnableWithdrawalsIndex
+		filenameBase: kv.FileWithdrawalAddrIdx, keysTable: kv.TblWithdrawalAddrKeys, valuesTable: kv.TblWithdrawalAddrIdx,
+
+		Compression: seg.CompressNone,
+		name:        kv.WithdrawalAddrIdx,
+	},
 }
 
 func EnableHistoricalCommitment() {
@@ -336,6 +392,29 @@ var HistoryCompressCfg = seg.Cfg{
 	Workers:              1,
 }
 
+// EnableAddressAppearances - maintain address -> txNums index of all appearances of address
+func EnableAddressAppearances() {
+	cfg := Schema.AddrAppearanceIdx
+	cfg.disable = false
+	Schema.AddrAppearanceIdx = cfg
+}
+
+// EnableWithdrawalsIndex - maintain withdrawal address -> txNums index of blocks with withdrawals to it
+func EnableWithdrawalsIndex() {
+	cfg := Schema.WithdrawalAddrIdx
+	cfg.disable = false
+	Schema.WithdrawalAddrIdx = cfg
+}
+
+// EnableAccessLists - keep access list of every executed txn (erigon_getTransactionAccessList)
+func EnableAccessLists() {
+	cfg := Schema.AccessListDomain
+	cfg.hist.iiCfg.disable = false
+	cfg.hist.historyDisabled = false
+	cfg.hist.snapshotsDisabled = false
+	Schema.AccessListDomain = cfg
+}
+
 func EnableHistoricalRCache() {
 	cfg := Schema.RCacheDomain
 	cfg.hist.iiCfg.disable = false
@@ -29,6 +29,8 @@ import (
 	"unsafe"
 
 	btree2 "github.com/tidwall/btree"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/erigontech/erigon-lib/commitment"
 	"github.com/erigontech/erigon-lib/common"
@@ -365,39 +367,52 @@ func (sd *SharedDomains) SizeEstimate() uint64 {
 }
 
 func (sd *SharedDomains) LatestCommitment(prefix []byte) ([]byte, uint64, error) {
+	v, step, _, err := sd.latestCommitment(prefix)
+	return v, step, err
+}
+
+type branchSource uint8
+
+const (
+	branchFromMem branchSource = iota
+	branchFromDB
+	branchFromFiles
+)
+
+func (sd *SharedDomains) latestCommitment(prefix []byte) ([]byte, uint64, branchSource, error) {
 	aggTx := sd.AggTx()
 	if v, prevStep, ok := sd.get(kv.CommitmentDomain, prefix); ok {
 		// sd cache values as is (without transformation) so safe to return
-		return v, prevStep, nil
+		return v, prevStep, branchFromMem, nil
 	}
 	v, step, found, err := sd.roTtx.Debug().GetLatestFromDB(kv.CommitmentDomain, prefix)
 	if err != nil {
-		return nil, 0, fmt.Errorf("commitment prefix %x read error: %w", prefix, err)
+		return nil, 0, branchFromDB, fmt.Errorf("commitment prefix %x read error: %w", prefix, err)
 	}
 	if found {
 		// db store values as is (without transformation) so safe to return
-		return v, step, nil
+		return v, step, branchFromDB, nil
 	}
 
 	// getLatestFromFiles doesn't provide same semantics as getLatestFromDB - it returns start/end tx
 	// of file where the value is stored (not exact step when kv has been set)
 	v, _, startTx, endTx, err := sd.roTtx.Debug().GetLatestFromFiles(kv.CommitmentDomain, prefix, 0)
 	if err != nil {
-		return nil, 0, fmt.Errorf("commitment prefix %x read error: %w", prefix, err)
+		return nil, 0, branchFromFiles, fmt.Errorf("commitment prefix %x read error: %w", prefix, err)
 	}
 
 	if !aggTx.a.commitmentValuesTransform || bytes.Equal(prefix, keyCommitmentState) {
 		sd.put(kv.CommitmentDomain, toStringZeroCopy(prefix), v)
-		return v, endTx / sd.StepSize(), nil
+		return v, endTx / sd.StepSize(), branchFromFiles, nil
 	}
 
 	// replace shortened keys in the branch with full keys to allow HPH work seamlessly
 	rv, err := sd.replaceShortenedKeysInBranch(prefix, commitment.BranchData(v), startTx, endTx, aggTx)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, branchFromFiles, err
 	}
 	sd.put(kv.CommitmentDomain, toStringZeroCopy(prefix), rv) // keep dereferenced value in cache (to avoid waste on another dereference)
-	return rv, endTx / sd.StepSize(), nil
+	return rv, endTx / sd.StepSize(), branchFromFiles, nil
 }
 
 // replaceShortenedKeysInBranch replaces shortened keys in the branch with full keys
@@ -804,7 +819,7 @@ func (sd *SharedDomains) DomainPut(domain kv.Domain, k1, k2 []byte, val, prevVal
 			return nil
 		}
 		return sd.updateAccountCode(k1, val, prevVal, prevStep)
-	case kv.CommitmentDomain, kv.RCacheDomain:
+	case kv.CommitmentDomain, kv.RCacheDomain, kv.AccessListDomain:
 		sd.put(domain, toStringZeroCopy(append(k1, k2...)), val)
 		return sd.domainWriters[domain].PutWithPrev(k1, k2, val, sd.txNum, prevVal, prevStep)
 	default:
@@ -895,6 +910,9 @@ type SharedDomainsCommitmentContext struct {
 
 	limitReadAsOfTxNum uint64
 	domainsOnly        bool // if true, do not use history reader and limit to domain files only
+
+	branchReads        [3]atomic.Uint64 // by branchSource
+	branchReadsHistory atomic.Uint64
 }
 
 // Limits max txNum for read operations. If set to 0, all read operations will be from latest value.
@@ -927,6 +945,7 @@ func (sdc *SharedDomainsCommitmentContext) Branch(pref []byte) ([]byte, uint64,
 	// Keep dereferenced version inside sd commitmentDomain map ready to read again
 	if !sdc.domainsOnly && sdc.limitReadAsOfTxNum > 0 {
 		branch, _, err := sdc.sharedDomains.roTtx.GetAsOf(kv.CommitmentDomain, pref, sdc.limitReadAsOfTxNum)
+		sdc.branchReadsHistory.Add(1)
 		if sdc.sharedDomains.trace {
 			fmt.Printf("[SDC] Branch @%d: %x: %x\n%s\n", sdc.limitReadAsOfTxNum, pref, branch, commitment.BranchData(branch).String())
 		}
@@ -938,10 +957,11 @@ func (sdc *SharedDomainsCommitmentContext) Branch(pref []byte) ([]byte, uint64,
 
 	// Trie reads prefix during unfold and after everything is ready reads it again to Merge update.
 	// Dereferenced branch is kept inside sharedDomains commitment domain map (but not written into buffer so not flushed into db, unless updated)
-	v, step, err := sdc.sharedDomains.LatestCommitment(pref)
+	v, step, src, err := sdc.sharedDomains.latestCommitment(pref)
 	if err != nil {
 		return nil, 0, fmt.Errorf("branch failed: %w", err)
 	}
+	sdc.branchReads[src].Add(1)
 	if sdc.sharedDomains.trace {
 		fmt.Printf("[SDC] Branch: %x: %x\n", pref, v)
 	}
@@ -1150,16 +1170,37 @@ func (sdc *SharedDomainsCommitmentContext) ComputeCommitment(ctx context.Context
 		return rootHash, err
 	}
 
+	ctx, span := commitment.StartSpan(ctx, "commitment.ComputeCommitment",
+		attribute.Int64("block", int64(blockNum)), attribute.Int64("updates", int64(updateCount)))
+	defer span.End()
+
 	// data accessing functions should be set when domain is opened/shared context updated
 	sdc.patriciaTrie.SetTrace(sdc.sharedDomains.trace)
 	sdc.Reset()
+	for i := range sdc.branchReads {
+		sdc.branchReads[i].Store(0)
+	}
+	sdc.branchReadsHistory.Store(0)
 
+	start := time.Now()
 	rootHash, err = sdc.patriciaTrie.Process(ctx, sdc.updates, logPrefix)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 	sdc.justRestored.Store(false)
 
+	stats := sdc.patriciaTrie.ProcessStats()
+	stats.BranchReadsMem = sdc.branchReads[branchFromMem].Load()
+	stats.BranchReadsDB = sdc.branchReads[branchFromDB].Load()
+	stats.BranchReadsFiles = sdc.branchReads[branchFromFiles].Load()
+	stats.BranchReadsHistory = sdc.branchReadsHistory.Load()
+	span.SetAttributes(stats.Attributes()...)
+	if saveState {
+		commitment.RecordBlockTrace(blockNum, time.Since(start), stats)
+	}
+
 	if saveState {
 		if err := sdc.storeCommitmentState(blockNum, rootHash); err != nil {
 			return nil, err
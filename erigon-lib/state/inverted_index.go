@@ -399,7 +399,7 @@ func (iit *InvertedIndexRoTx) Files() (res VisibleFiles) {
 }
 
 func (iit *InvertedIndexRoTx) NewWriter() *InvertedIndexBufferedWriter {
-	return iit.newWriter(iit.ii.dirs.Tmp, false)
+	return iit.newWriter(iit.ii.dirs.Tmp, iit.ii.disable)
 }
 
 type InvertedIndexBufferedWriter struct {
@@ -1264,6 +1264,9 @@ func (ii *InvertedIndex) buildMapAccessor(ctx context.Context, fromStep, toStep
 }
 
 func (ii *InvertedIndex) integrateDirtyFiles(sf InvertedFiles, txNumFrom, txNumTo uint64) {
+	if ii.disable {
+		return
+	}
 	fi := newFilesItem(txNumFrom, txNumTo, ii.aggregationStep)
 	fi.decompressor = sf.decomp
 	fi.index = sf.index
@@ -122,5 +122,13 @@ var (
 			metrics.GetOrCreateSummary(`kv_get{level="L4",domain="rcache"}`),
 			metrics.GetOrCreateSummary(`kv_get{level="recent",domain="rcache"}`),
 		},
+		kv.AccessListDomain: {
+			metrics.GetOrCreateSummary(`kv_get{level="L0",domain="accesslist"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L1",domain="accesslist"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L2",domain="accesslist"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L3",domain="accesslist"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="L4",domain="accesslist"}`),
+			metrics.GetOrCreateSummary(`kv_get{level="recent",domain="accesslist"}`),
+		},
 	}
 )
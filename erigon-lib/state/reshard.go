@@ -0,0 +1,214 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const stepFileName = "step-state.txt"
+
+// GetStateAggregationStep - returns step size of state files in `dirs.Snap`. Files of different step sizes can't be mixed,
+// so step is persisted next to `salt-state.txt`. Datadirs created before step became configurable have no such file:
+// if they have state files - they use config3.DefaultStepSize. Otherwise `want` is persisted (if genNew) and returned.
+func GetStateAggregationStep(dirs datadir.Dirs, want uint64, genNew bool) (uint64, error) {
+	fpath := filepath.Join(dirs.Snap, stepFileName)
+	exists, err := dir.FileExist(fpath)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		content, err := os.ReadFile(fpath)
+		if err != nil {
+			return 0, err
+		}
+		step, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil || step == 0 {
+			return 0, fmt.Errorf("invalid %s: %q", fpath, content)
+		}
+		return step, nil
+	}
+
+	step := want
+	hasFiles, err := hasStateFiles(dirs)
+	if err != nil {
+		return 0, err
+	}
+	if hasFiles {
+		step = config3.DefaultStepSize
+	}
+	if !genNew {
+		return step, nil
+	}
+	if err := WriteStateAggregationStep(dirs, step); err != nil {
+		return 0, err
+	}
+	return step, nil
+}
+
+func WriteStateAggregationStep(dirs datadir.Dirs, step uint64) error {
+	if step == 0 {
+		return errors.New("aggregation step must be positive")
+	}
+	return dir.WriteFileWithFsync(filepath.Join(dirs.Snap, stepFileName), []byte(strconv.FormatUint(step, 10)), os.ModePerm)
+}
+
+func hasStateFiles(dirs datadir.Dirs) (bool, error) {
+	for _, d := range []string{dirs.SnapDomain, dirs.SnapIdx} {
+		files, err := dir.ListFiles(d, ".kv", ".ef")
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return false, err
+		}
+		if len(files) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// v1-accounts.0-64.kv, v1.1-logaddrs.64-96.efi, v1-code.0-64.kv.torrent
+var reshardFileRegex = regexp.MustCompile(`^(v[0-9]+(?:\.[0-9]+)?)-([[:lower:]]+)\.([0-9]+)-([0-9]+)\.(.+)$`)
+
+type reshardFile struct {
+	path               string
+	version, name, ext string
+	fromTxNum, toTxNum uint64
+	aligned            bool
+}
+
+func (f reshardFile) kind() string { return f.name + "." + f.ext }
+
+// isDataFile - files which hold state. Other files are accessors of data files (.kvi, .bt, .vi, .efi, ...) and can be re-built.
+func (f reshardFile) isDataFile() bool { return f.ext == "kv" || f.ext == "v" || f.ext == "ef" }
+
+// Reshard - moves state files (domains, histories, inverted indices and their accessors) from `oldStep` to `newStep` geometry.
+// Content of state files is addressed by txNum: only file names depend on step, so files are renamed - not rewritten.
+// Files which boundaries are not multiple of `newStep` are removed: it's either merge leftovers (covered by bigger file)
+// or the tail of state - which will be re-executed from the end of remaining files. So, state in chaindata must be reset after Reshard.
+// Not crash-safe: interrupted Reshard leaves datadir with mixed geometry.
+//
+// Returns txNum up to which state files remain.
+func Reshard(dirs datadir.Dirs, oldStep, newStep uint64, logger log.Logger) (uint64, error) {
+	if oldStep == 0 || newStep == 0 {
+		return 0, errors.New("aggregation step must be positive")
+	}
+	var files []reshardFile
+	for _, d := range []string{dirs.SnapDomain, dirs.SnapHistory, dirs.SnapIdx, dirs.SnapAccessors} {
+		paths, err := dir.ListFiles(d)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, err
+		}
+		for _, fPath := range paths {
+			subs := reshardFileRegex.FindStringSubmatch(filepath.Base(fPath))
+			if len(subs) != 6 {
+				continue
+			}
+			from, err := strconv.ParseUint(subs[3], 10, 64)
+			if err != nil {
+				continue
+			}
+			to, err := strconv.ParseUint(subs[4], 10, 64)
+			if err != nil {
+				continue
+			}
+			f := reshardFile{path: fPath, version: subs[1], name: subs[2], ext: subs[5], fromTxNum: from * oldStep, toTxNum: to * oldStep}
+			f.aligned = f.fromTxNum%newStep == 0 && f.toTxNum%newStep == 0
+			files = append(files, f)
+		}
+	}
+
+	// state is continuous only up to the end of last aligned file reachable from 0 - in every data file kind
+	covered := map[string]uint64{}
+	for _, f := range files {
+		if f.isDataFile() {
+			covered[f.kind()] = 0
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, f := range files {
+			if !f.aligned || !f.isDataFile() {
+				continue
+			}
+			if c := covered[f.kind()]; f.fromTxNum <= c && f.toTxNum > c {
+				covered[f.kind()] = f.toTxNum
+				changed = true
+			}
+		}
+	}
+	cut := uint64(math.MaxUint64)
+	for _, c := range covered {
+		cut = min(cut, c)
+	}
+	if len(covered) == 0 {
+		cut = 0
+	}
+
+	var removed, renamed int
+	type rename struct{ from, tmp, to string }
+	var renames []rename
+	for _, f := range files {
+		if !f.aligned || f.toTxNum > cut || strings.HasSuffix(f.ext, ".torrent") {
+			// .torrent files describe old file names. New ones will be created by downloader
+			if err := os.Remove(f.path); err != nil {
+				return 0, err
+			}
+			removed++
+			continue
+		}
+		if oldStep == newStep {
+			continue
+		}
+		to := filepath.Join(filepath.Dir(f.path), fmt.Sprintf("%s-%s.%d-%d.%s", f.version, f.name, f.fromTxNum/newStep, f.toTxNum/newStep, f.ext))
+		renames = append(renames, rename{from: f.path, tmp: to + ".reshard", to: to})
+	}
+	// new name of one file may be old name of another: 0-1 -> 0-2, 0-2 -> 0-4
+	for _, r := range renames {
+		if err := os.Rename(r.from, r.tmp); err != nil {
+			return 0, err
+		}
+	}
+	for _, r := range renames {
+		if err := os.Rename(r.tmp, r.to); err != nil {
+			return 0, err
+		}
+		renamed++
+	}
+	if err := WriteStateAggregationStep(dirs, newStep); err != nil {
+		return 0, err
+	}
+	logger.Info("[reshard] state files moved to new step", "old_step", oldStep, "new_step", newStep, "renamed", renamed, "removed", removed, "state_files_to_txnum", cut)
+	return cut, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestReshard(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	ctx, logger := context.Background(), log.New()
+	db, agg := testDbAndAggregatorv3(t, 20)
+	dirs := agg.dirs
+
+	step, err := GetStateAggregationStep(dirs, 20, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), step)
+
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ac := agg.BeginFilesRo()
+	domains, err := NewSharedDomains(wrapTxWithCtx(tx, ac), logger)
+	require.NoError(t, err)
+
+	const txs = 110
+	keys := make([][]byte, 8)
+	for i := range keys {
+		keys[i] = make([]byte, length.Addr)
+		keys[i][0] = byte(i + 1)
+	}
+	for txNum := uint64(1); txNum <= txs; txNum++ {
+		domains.SetTxNum(txNum)
+		k := keys[txNum%uint64(len(keys))]
+		val := binary.BigEndian.AppendUint64(nil, txNum)
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, k, nil, val, nil, 0))
+	}
+	require.NoError(t, domains.Flush(ctx, tx))
+	domains.Close()
+	ac.Close()
+	require.NoError(t, tx.Commit())
+	require.NoError(t, agg.BuildFiles(txs))
+	require.Equal(t, uint64(100), agg.EndTxNumMinimax())
+	agg.Close()
+
+	// reads only from files: db is empty
+	check := func(stepSize, filesTo uint64) {
+		t.Helper()
+		db := mdbx.New(kv.ChainDB, logger).InMem(t.TempDir()).GrowthStep(32 * datasize.MB).MapSize(2 * datasize.GB).MustOpen()
+		defer db.Close()
+		salt, err := GetStateIndicesSalt(dirs, false, logger)
+		require.NoError(t, err)
+		agg, err := NewAggregator2(ctx, dirs, stepSize, salt, db, logger)
+		require.NoError(t, err)
+		defer agg.Close()
+		require.NoError(t, agg.OpenFolder())
+		require.Equal(t, filesTo, agg.EndTxNumMinimax())
+
+		ac := agg.BeginFilesRo()
+		defer ac.Close()
+		roTx, err := db.BeginRo(ctx)
+		require.NoError(t, err)
+		defer roTx.Rollback()
+		for txNum := uint64(1); txNum <= filesTo-uint64(len(keys)); txNum++ {
+			k := keys[txNum%uint64(len(keys))]
+			// value written at txNum is visible before next write of same key
+			v, ok, err := ac.GetAsOf(kv.AccountsDomain, k, txNum+1, roTx)
+			require.NoError(t, err)
+			require.True(t, ok, txNum)
+			require.Equal(t, txNum, binary.BigEndian.Uint64(v), txNum)
+		}
+		for _, k := range keys {
+			v, found, _, _, err := ac.DebugGetLatestFromFiles(kv.AccountsDomain, k, filesTo)
+			require.NoError(t, err)
+			require.True(t, found)
+			require.GreaterOrEqual(t, binary.BigEndian.Uint64(v), filesTo-uint64(len(keys)))
+		}
+	}
+
+	check(20, 100)
+
+	cut, err := Reshard(dirs, 20, 10, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), cut)
+	step, err = GetStateAggregationStep(dirs, config3.DefaultStepSize, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), step)
+	check(10, 100)
+
+	// 80-100 is not multiple of 40: removed
+	cut, err = Reshard(dirs, 10, 40, logger)
+	require.NoError(t, err)
+	require.Equal(t, uint64(80), cut)
+	check(40, 80)
+}
+
+func TestGetStateAggregationStep(t *testing.T) {
+	dirs := datadir.New(t.TempDir())
+	step, err := GetStateAggregationStep(dirs, 10, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), step)
+
+	// datadir created before step became configurable
+	require.NoError(t, os.WriteFile(filepath.Join(dirs.SnapDomain, "v1-accounts.0-1.kv"), nil, 0644))
+	step, err = GetStateAggregationStep(dirs, 10, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(config3.DefaultStepSize), step)
+
+	step, err = GetStateAggregationStep(dirs, 10, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(config3.DefaultStepSize), step)
+}
@@ -54,6 +54,13 @@ func InitSchemas() {
 	Schema.RCacheDomain.hist.iiCfg.version.DataEF = version.V2_0
 	Schema.RCacheDomain.hist.iiCfg.version.AccessorEFI = version.V1_1
 
+	Schema.AccessListDomain.version.DataKV = version.V1_0
+	Schema.AccessListDomain.version.AccessorKVI = version.V1_0
+	Schema.AccessListDomain.hist.version.DataV = version.V1_0
+	Schema.AccessListDomain.hist.version.AccessorVI = version.V1_0
+	Schema.AccessListDomain.hist.iiCfg.version.DataEF = version.V2_0
+	Schema.AccessListDomain.hist.iiCfg.version.AccessorEFI = version.V1_1
+
 	Schema.LogAddrIdx.version.DataEF = version.V2_0
 	Schema.LogAddrIdx.version.AccessorEFI = version.V1_1
 
@@ -65,6 +72,12 @@ func InitSchemas() {
 
 	Schema.TracesToIdx.version.DataEF = version.V2_0
 	Schema.TracesToIdx.version.AccessorEFI = version.V1_1
+
+	Schema.AddrAppearanceIdx.version.DataEF = version.V2_0
+	Schema.AddrAppearanceIdx.version.AccessorEFI = version.V1_1
+
+	Schema.WithdrawalAddrIdx.version.DataEF = version.V2_0
+	Schema.WithdrawalAddrIdx.version.AccessorEFI = version.V1_1
 }
 
 type DomainVersionTypes struct {
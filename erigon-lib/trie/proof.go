@@ -117,9 +117,110 @@ func (t *Trie) Prove(key []byte, fromLevel int, storage bool) ([][]byte, error)
 			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
 		}
 	}
+	// key is consumed by branch nodes, but value is in leaf with empty key (happens with short keys, like in receipts trie)
+	if n, ok := tn.(*ShortNode); ok && fromLevel == 0 && len(key) == 0 && len(n.Key) == 1 && n.Key[0] == 16 {
+		rlp, err := hasher.hashChildren(n, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(rlp) >= length.Hash { // shorter nodes are embedded into parent
+			proof = append(proof, common.CopyBytes(rlp))
+		}
+	}
 	return proof, nil
 }
 
+// ProveRange constructs the merkle proof of a range of keys, as used by the snap sync
+// protocol. The proof is made of the proofs of the first and the last key of the range,
+// nodes shared by both proofs are only included once. The first key does not need to
+// be in the trie, in which case its proof proves its absence.
+func (t *Trie) ProveRange(first, last []byte, fromLevel int, storage bool) ([][]byte, error) {
+	proof, err := t.Prove(first, fromLevel, storage)
+	if err != nil {
+		return nil, err
+	}
+	if last == nil || bytes.Equal(first, last) {
+		return proof, nil
+	}
+	lastProof, err := t.Prove(last, fromLevel, storage)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(proof))
+	for _, node := range proof {
+		seen[string(node)] = struct{}{}
+	}
+	for _, node := range lastProof {
+		if _, ok := seen[string(node)]; !ok {
+			seen[string(node)] = struct{}{}
+			proof = append(proof, node)
+		}
+	}
+	return proof, nil
+}
+
+// EncodedNodeAt returns the encoding of the node found at the given nibble path, as it
+// appears in proofs, or nil if no node starts at the path. Paths into storage tries are
+// made of the nibbles of the hashed account key followed by the path in the storage
+// trie.
+func (t *Trie) EncodedNodeAt(path []byte) ([]byte, error) {
+	tn := t.RootNode
+	for tn != nil {
+		if n, ok := tn.(*AccountNode); ok {
+			tn = n.Storage
+			continue
+		}
+		if len(path) == 0 {
+			break
+		}
+		switch n := tn.(type) {
+		case *ShortNode:
+			nKey := n.Key
+			if nKey[len(nKey)-1] == 16 {
+				nKey = nKey[:len(nKey)-1]
+			}
+			if len(path) < len(nKey) || !bytes.Equal(nKey, path[:len(nKey)]) {
+				return nil, nil
+			}
+			tn = n.Val
+			path = path[len(nKey):]
+		case *DuoNode:
+			i1, i2 := n.childrenIdx()
+			switch path[0] {
+			case i1:
+				tn = n.child1
+			case i2:
+				tn = n.child2
+			default:
+				tn = nil
+			}
+			path = path[1:]
+		case *FullNode:
+			tn = n.Children[path[0]]
+			path = path[1:]
+		case ValueNode:
+			return nil, nil
+		case HashNode:
+			return nil, fmt.Errorf("encountered hashNode unexpectedly, path %x", path)
+		default:
+			panic(fmt.Sprintf("%T: invalid node: %v", tn, tn))
+		}
+	}
+	switch tn.(type) {
+	case nil, ValueNode, HashNode:
+		return nil, nil
+	}
+
+	hasher := newHasher(t.valueNodesRLPEncoded)
+	defer returnHasherToPool(hasher)
+	enc, err := hasher.hashChildren(tn, 0)
+	if err != nil {
+		return nil, err
+	}
+	return common.CopyBytes(enc), nil
+}
+
 func decodeRef(buf []byte) (Node, []byte, error) {
 	kind, val, rest, err := rlp.Split(buf)
 	if err != nil {
@@ -286,6 +387,25 @@ func verifyProof(root common.Hash, key []byte, proofs map[common.Hash]Node, used
 	}
 }
 
+// VerifyMultiAccountProof will verify account and storage proofs of every account of erigon_getProofs result.
+func VerifyMultiAccountProof(stateRoot common.Hash, proof *accounts.MultiAccProofResult) error {
+	for i := range proof.Accounts {
+		accProof, err := proof.Proof(i)
+		if err != nil {
+			return err
+		}
+		if err := VerifyAccountProof(stateRoot, accProof); err != nil {
+			return fmt.Errorf("account %x: %w", accProof.Address, err)
+		}
+		for _, storageProof := range accProof.StorageProof {
+			if err := VerifyStorageProof(accProof.StorageHash, storageProof); err != nil {
+				return fmt.Errorf("account %x, storage key %s: %w", accProof.Address, storageProof.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
 func VerifyAccountProof(stateRoot common.Hash, proof *accounts.AccProofResult) error {
 	accountKey := crypto.Keccak256Hash(proof.Address[:])
 	return VerifyAccountProofByHash(stateRoot, accountKey, proof)
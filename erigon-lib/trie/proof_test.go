@@ -0,0 +1,106 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+)
+
+func TestProveRange(t *testing.T) {
+	trie := newEmpty()
+	var keys [][]byte
+	for i := byte(0); i < 100; i++ {
+		key := crypto.Keccak256([]byte{i})
+		keys = append(keys, key)
+		trie.Update(key, bytes.Repeat([]byte{i + 1}, 40))
+	}
+	root := trie.Hash()
+
+	first, last := keys[3], keys[42]
+	proof, err := trie.ProveRange(first, last, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, root, crypto.Keccak256Hash(proof[0]))
+
+	nodes := map[string]struct{}{}
+	for _, node := range proof {
+		_, dup := nodes[string(node)]
+		require.False(t, dup, "duplicate node in range proof")
+		nodes[string(node)] = struct{}{}
+	}
+	for _, key := range [][]byte{first, last} {
+		keyProof, err := trie.Prove(key, 0, false)
+		require.NoError(t, err)
+		for _, node := range keyProof {
+			require.Contains(t, nodes, string(node))
+		}
+	}
+
+	// proof of a missing first key
+	missing := common.Hash{}
+	proof, err = trie.ProveRange(missing[:], last, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, root, crypto.Keccak256Hash(proof[0]))
+}
+
+func TestProveShortKeys(t *testing.T) {
+	// receipts trie: keys are rlp(index), key of some values is fully consumed by branch nodes
+	trie := newEmpty()
+	keys := [][]byte{{0x80}, {0x01}, {0x02}, {0x03}}
+	for i, key := range keys {
+		trie.Update(key, bytes.Repeat([]byte{byte(i + 1)}, 40))
+	}
+	root := trie.Hash()
+	for i, key := range keys {
+		proof, err := trie.Prove(key, 0, false)
+		require.NoError(t, err)
+		require.Equal(t, root, crypto.Keccak256Hash(proof[0]))
+		// every node is referenced by hash from previous one, last node contains value
+		for j := 1; j < len(proof); j++ {
+			require.Contains(t, string(proof[j-1]), string(crypto.Keccak256(proof[j])))
+		}
+		require.Contains(t, string(proof[len(proof)-1]), string(bytes.Repeat([]byte{byte(i + 1)}, 40)))
+	}
+}
+
+func TestEncodedNodeAt(t *testing.T) {
+	trie := newEmpty()
+	for i := byte(0); i < 100; i++ {
+		trie.Update(crypto.Keccak256([]byte{i}), bytes.Repeat([]byte{i + 1}, 40))
+	}
+
+	enc, err := trie.EncodedNodeAt(nil)
+	require.NoError(t, err)
+	require.Equal(t, trie.Hash(), crypto.Keccak256Hash(enc))
+
+	// every node on the path to a key is found at its own path
+	key := crypto.Keccak256([]byte{7})
+	proof, err := trie.Prove(key, 0, false)
+	require.NoError(t, err)
+	enc, err = trie.EncodedNodeAt(keybytesToHex(key)[:1])
+	require.NoError(t, err)
+	require.Equal(t, proof[1], enc)
+
+	enc, err = trie.EncodedNodeAt([]byte{0, 0, 0, 0, 0, 0, 0, 0})
+	require.NoError(t, err)
+	require.Nil(t, enc)
+}
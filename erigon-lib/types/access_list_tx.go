@@ -428,6 +428,7 @@ func (tx *AccessListTx) AsMessage(s Signer, _ *big.Int, rules *chain.Rules) (*Me
 	if !rules.IsBerlin {
 		return nil, errors.New("eip-2930 transactions require Berlin")
 	}
+	msg.rollupCostData = rollupCostDataOf(tx, rules)
 
 	var err error
 	msg.from, err = tx.Sender(s)
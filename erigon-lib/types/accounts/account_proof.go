@@ -17,6 +17,8 @@
 package accounts
 
 import (
+	"fmt"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 )
@@ -36,3 +38,107 @@ type StorProofResult struct {
 	Value *hexutil.Big    `json:"value"`
 	Proof []hexutil.Bytes `json:"proof"`
 }
+
+// Result structs for erigon_getProofs - proofs of many accounts at same block. Every trie node is
+// included once in Nodes and proofs refer to it by index, so nodes of common prefixes of keys are not repeated.
+type MultiAccProofResult struct {
+	Nodes    []hexutil.Bytes      `json:"nodes"`
+	Accounts []MultiAccProofEntry `json:"accounts"`
+}
+type MultiAccProofEntry struct {
+	Address      common.Address        `json:"address"`
+	AccountProof []int                 `json:"accountProof"`
+	Balance      *hexutil.Big          `json:"balance"`
+	CodeHash     common.Hash           `json:"codeHash"`
+	Nonce        hexutil.Uint64        `json:"nonce"`
+	StorageHash  common.Hash           `json:"storageHash"`
+	StorageProof []MultiStorProofEntry `json:"storageProof"`
+}
+type MultiStorProofEntry struct {
+	Key   string       `json:"key"`
+	Value *hexutil.Big `json:"value"`
+	Proof []int        `json:"proof"`
+}
+
+func NewMultiAccProofResult(proofs []*AccProofResult) *MultiAccProofResult {
+	res := &MultiAccProofResult{Accounts: make([]MultiAccProofEntry, len(proofs))}
+	indices := map[string]int{}
+	index := func(nodes []hexutil.Bytes) []int {
+		if nodes == nil {
+			return nil
+		}
+		idx := make([]int, len(nodes))
+		for i, node := range nodes {
+			j, ok := indices[string(node)]
+			if !ok {
+				j = len(res.Nodes)
+				indices[string(node)] = j
+				res.Nodes = append(res.Nodes, node)
+			}
+			idx[i] = j
+		}
+		return idx
+	}
+	for i, proof := range proofs {
+		res.Accounts[i] = MultiAccProofEntry{
+			Address:      proof.Address,
+			AccountProof: index(proof.AccountProof),
+			Balance:      proof.Balance,
+			CodeHash:     proof.CodeHash,
+			Nonce:        proof.Nonce,
+			StorageHash:  proof.StorageHash,
+			StorageProof: make([]MultiStorProofEntry, len(proof.StorageProof)),
+		}
+		for j, storageProof := range proof.StorageProof {
+			res.Accounts[i].StorageProof[j] = MultiStorProofEntry{
+				Key:   storageProof.Key,
+				Value: storageProof.Value,
+				Proof: index(storageProof.Proof),
+			}
+		}
+	}
+	return res
+}
+
+// Proof - returns proof of i-th account in format of eth_getProof
+func (m *MultiAccProofResult) Proof(i int) (*AccProofResult, error) {
+	if i < 0 || i >= len(m.Accounts) {
+		return nil, fmt.Errorf("account %d is out of range [0, %d)", i, len(m.Accounts))
+	}
+	nodes := func(idx []int) ([]hexutil.Bytes, error) {
+		if idx == nil {
+			return nil, nil
+		}
+		res := make([]hexutil.Bytes, len(idx))
+		for i, j := range idx {
+			if j < 0 || j >= len(m.Nodes) {
+				return nil, fmt.Errorf("node %d is out of range [0, %d)", j, len(m.Nodes))
+			}
+			res[i] = m.Nodes[j]
+		}
+		return res, nil
+	}
+
+	acc := m.Accounts[i]
+	accountProof, err := nodes(acc.AccountProof)
+	if err != nil {
+		return nil, err
+	}
+	res := &AccProofResult{
+		Address:      acc.Address,
+		AccountProof: accountProof,
+		Balance:      acc.Balance,
+		CodeHash:     acc.CodeHash,
+		Nonce:        acc.Nonce,
+		StorageHash:  acc.StorageHash,
+		StorageProof: make([]StorProofResult, len(acc.StorageProof)),
+	}
+	for j, storageProof := range acc.StorageProof {
+		proof, err := nodes(storageProof.Proof)
+		if err != nil {
+			return nil, err
+		}
+		res.StorageProof[j] = StorProofResult{Key: storageProof.Key, Value: storageProof.Value, Proof: proof}
+	}
+	return res, nil
+}
@@ -64,6 +64,7 @@ func (stx *BlobTx) AsMessage(s Signer, baseFee *big.Int, rules *chain.Rules) (*M
 	if !rules.IsCancun {
 		return nil, errors.New("BlobTx transactions require Cancun")
 	}
+	msg.rollupCostData = rollupCostDataOf(stx, rules)
 	if baseFee != nil {
 		overflow := msg.gasPrice.SetFromBig(baseFee)
 		if overflow {
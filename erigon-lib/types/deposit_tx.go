@@ -0,0 +1,284 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+// DepositTx - OP Stack deposit transaction, derived by rollup node from L1 and forced into L2 block through
+// payload attributes. It is not signed: sender is given explicitly, gas is paid on L1.
+// See https://specs.optimism.io/protocol/deposits.html
+type DepositTx struct {
+	TransactionMisc
+	SourceHash          common.Hash     // uniquely identifies the source of the deposit
+	From                common.Address  // exposed through Sender, no signature
+	To                  *common.Address // nil means contract creation
+	Mint                *uint256.Int    // ETH minted on L2, locked on L1; nil if no minting
+	Value               *uint256.Int    // transferred from L2 balance, executed after minting
+	GasLimit            uint64          // bought on L1, not refunded
+	IsSystemTransaction bool            // not metered against block gas limit before Regolith, disallowed since
+	Data                []byte
+}
+
+func (tx *DepositTx) Type() byte                                           { return DepositTxType }
+func (tx *DepositTx) GetChainID() *uint256.Int                             { return new(uint256.Int) }
+func (tx *DepositTx) GetNonce() uint64                                     { return 0 }
+func (tx *DepositTx) GetTipCap() *uint256.Int                              { return new(uint256.Int) }
+func (tx *DepositTx) GetEffectiveGasTip(baseFee *uint256.Int) *uint256.Int { return new(uint256.Int) }
+func (tx *DepositTx) GetFeeCap() *uint256.Int                              { return new(uint256.Int) }
+func (tx *DepositTx) GetBlobHashes() []common.Hash                         { return []common.Hash{} }
+func (tx *DepositTx) GetGasLimit() uint64                                  { return tx.GasLimit }
+func (tx *DepositTx) GetBlobGas() uint64                                   { return 0 }
+func (tx *DepositTx) GetTo() *common.Address                               { return tx.To }
+func (tx *DepositTx) GetData() []byte                                      { return tx.Data }
+func (tx *DepositTx) GetAccessList() AccessList                            { return AccessList{} }
+func (tx *DepositTx) Protected() bool                                      { return true }
+func (tx *DepositTx) IsContractDeploy() bool                               { return tx.To == nil }
+func (tx *DepositTx) Unwrap() Transaction                                  { return tx }
+
+func (tx *DepositTx) GetValue() *uint256.Int {
+	if tx.Value == nil {
+		return new(uint256.Int)
+	}
+	return tx.Value
+}
+
+func (tx *DepositTx) AsMessage(s Signer, baseFee *big.Int, rules *chain.Rules) (*Message, error) {
+	if !rules.IsOptimism {
+		return nil, errors.New("deposit transactions require OP Stack rollup")
+	}
+	msg := Message{
+		from:        tx.From,
+		to:          tx.To,
+		amount:      *tx.GetValue(),
+		gasLimit:    tx.GasLimit,
+		data:        tx.Data,
+		accessList:  AccessList{},
+		checkNonce:  false,
+		isDepositTx: true,
+		isSystemTx:  tx.IsSystemTransaction,
+	}
+	if tx.Mint != nil {
+		msg.mint = new(uint256.Int).Set(tx.Mint)
+	}
+	return &msg, nil
+}
+
+// WithSignature - deposit transactions are not signed
+func (tx *DepositTx) WithSignature(signer Signer, sig []byte) (Transaction, error) {
+	return tx, nil
+}
+
+func (tx *DepositTx) Hash() common.Hash {
+	if hash := tx.hash.Load(); hash != nil {
+		return *hash
+	}
+	hash := prefixedRlpHash(DepositTxType, []interface{}{
+		tx.SourceHash,
+		tx.From,
+		tx.To,
+		tx.Mint,
+		tx.Value,
+		tx.GasLimit,
+		tx.IsSystemTransaction,
+		tx.Data,
+	})
+	tx.hash.Store(&hash)
+	return hash
+}
+
+// SigningHash - deposit transactions are not signed
+func (tx *DepositTx) SigningHash(chainID *big.Int) common.Hash {
+	return common.Hash{}
+}
+
+func (tx *DepositTx) RawSignatureValues() (*uint256.Int, *uint256.Int, *uint256.Int) {
+	return new(uint256.Int), new(uint256.Int), new(uint256.Int)
+}
+
+func (tx *DepositTx) Sender(Signer) (common.Address, error) { return tx.From, nil }
+func (tx *DepositTx) cachedSender() (common.Address, bool)  { return tx.From, true }
+func (tx *DepositTx) GetSender() (common.Address, bool)     { return tx.From, true }
+func (tx *DepositTx) SetSender(common.Address)              {}
+
+func (tx *DepositTx) payloadSize() (payloadSize int) {
+	// size of SourceHash
+	payloadSize += 33
+	// size of From
+	payloadSize += 21
+	// size of To
+	payloadSize++
+	if tx.To != nil {
+		payloadSize += 20
+	}
+	// size of Mint
+	payloadSize++
+	if tx.Mint != nil {
+		payloadSize += rlp.Uint256LenExcludingHead(tx.Mint)
+	}
+	// size of Value
+	payloadSize++
+	if tx.Value != nil {
+		payloadSize += rlp.Uint256LenExcludingHead(tx.Value)
+	}
+	// size of GasLimit
+	payloadSize++
+	payloadSize += rlp.IntLenExcludingHead(tx.GasLimit)
+	// size of IsSystemTransaction
+	payloadSize++
+	// size of Data
+	payloadSize += rlp.StringLen(tx.Data)
+	return payloadSize
+}
+
+func (tx *DepositTx) EncodingSize() int {
+	payloadSize := tx.payloadSize()
+	// Add envelope size and type size
+	return 1 + rlp.ListPrefixLen(payloadSize) + payloadSize
+}
+
+func (tx *DepositTx) MarshalBinary(w io.Writer) error {
+	b := newEncodingBuf()
+	defer pooledBuf.Put(b)
+	// encode TxType
+	b[0] = DepositTxType
+	if _, err := w.Write(b[:1]); err != nil {
+		return err
+	}
+	return tx.encodePayload(w, b[:], tx.payloadSize())
+}
+
+func (tx *DepositTx) EncodeRLP(w io.Writer) error {
+	payloadSize := tx.payloadSize()
+	// size of struct prefix and TxType
+	envelopeSize := 1 + rlp.ListPrefixLen(payloadSize) + payloadSize
+	b := newEncodingBuf()
+	defer pooledBuf.Put(b)
+	// envelope
+	if err := rlp.EncodeStringSizePrefix(envelopeSize, w, b[:]); err != nil {
+		return err
+	}
+	// encode TxType
+	b[0] = DepositTxType
+	if _, err := w.Write(b[:1]); err != nil {
+		return err
+	}
+	return tx.encodePayload(w, b[:], payloadSize)
+}
+
+func (tx *DepositTx) encodePayload(w io.Writer, b []byte, payloadSize int) error {
+	// prefix
+	if err := rlp.EncodeStructSizePrefix(payloadSize, w, b); err != nil {
+		return err
+	}
+	// encode SourceHash
+	if err := rlp.EncodeString(tx.SourceHash[:], w, b); err != nil {
+		return err
+	}
+	// encode From
+	if err := rlp.EncodeString(tx.From[:], w, b); err != nil {
+		return err
+	}
+	// encode To
+	if err := rlp.EncodeOptionalAddress(tx.To, w, b); err != nil {
+		return err
+	}
+	// encode Mint
+	if err := rlp.EncodeUint256(tx.Mint, w, b); err != nil {
+		return err
+	}
+	// encode Value
+	if err := rlp.EncodeUint256(tx.Value, w, b); err != nil {
+		return err
+	}
+	// encode GasLimit
+	if err := rlp.EncodeInt(tx.GasLimit, w, b); err != nil {
+		return err
+	}
+	// encode IsSystemTransaction
+	var isSystemTx uint64
+	if tx.IsSystemTransaction {
+		isSystemTx = 1
+	}
+	if err := rlp.EncodeInt(isSystemTx, w, b); err != nil {
+		return err
+	}
+	// encode Data
+	return rlp.EncodeString(tx.Data, w, b)
+}
+
+func (tx *DepositTx) DecodeRLP(s *rlp.Stream) error {
+	_, err := s.List()
+	if err != nil {
+		return err
+	}
+	var b []byte
+	if b, err = s.Bytes(); err != nil {
+		return err
+	}
+	if len(b) != 32 {
+		return fmt.Errorf("wrong size for SourceHash: %d", len(b))
+	}
+	copy(tx.SourceHash[:], b)
+	if b, err = s.Bytes(); err != nil {
+		return err
+	}
+	if len(b) != 20 {
+		return fmt.Errorf("wrong size for From: %d", len(b))
+	}
+	copy(tx.From[:], b)
+	if b, err = s.Bytes(); err != nil {
+		return err
+	}
+	if len(b) > 0 && len(b) != 20 {
+		return fmt.Errorf("wrong size for To: %d", len(b))
+	}
+	if len(b) > 0 {
+		tx.To = &common.Address{}
+		copy((*tx.To)[:], b)
+	}
+	if b, err = s.Uint256Bytes(); err != nil {
+		return err
+	}
+	if len(b) > 0 {
+		tx.Mint = new(uint256.Int).SetBytes(b)
+	}
+	if b, err = s.Uint256Bytes(); err != nil {
+		return err
+	}
+	tx.Value = new(uint256.Int).SetBytes(b)
+	if tx.GasLimit, err = s.Uint(); err != nil {
+		return err
+	}
+	if tx.IsSystemTransaction, err = s.Bool(); err != nil {
+		return err
+	}
+	if tx.Data, err = s.Bytes(); err != nil {
+		return err
+	}
+	return s.ListEnd()
+}
@@ -344,6 +344,7 @@ func (tx *DynamicFeeTransaction) AsMessage(s Signer, baseFee *big.Int, rules *ch
 	if !rules.IsLondon {
 		return nil, errors.New("eip-1559 transactions require London")
 	}
+	msg.rollupCostData = rollupCostDataOf(tx, rules)
 	if baseFee != nil {
 		overflow := msg.gasPrice.SetFromBig(baseFee)
 		if overflow {
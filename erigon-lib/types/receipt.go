@@ -28,6 +28,7 @@ import (
 	"math/big"
 	"slices"
 
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -74,6 +75,10 @@ type Receipt struct {
 	BlockNumber      *big.Int    `json:"blockNumber,omitempty"`
 	TransactionIndex uint        `json:"transactionIndex"`
 
+	// OP Stack deposit transactions: nonce used by deposit (since Regolith) and receipt version (since Canyon)
+	DepositNonce          *uint64 `json:"depositNonce,omitempty"`
+	DepositReceiptVersion *uint64 `json:"depositReceiptVersion,omitempty"`
+
 	FirstLogIndexWithinBlock uint32 `json:"-"` // field which used to store in db and re-calc
 }
 
@@ -85,6 +90,9 @@ type receiptMarshaling struct {
 	GasUsed           hexutil.Uint64
 	BlockNumber       *hexutil.Big
 	TransactionIndex  hexutil.Uint
+
+	DepositNonce          *hexutil.Uint64
+	DepositReceiptVersion *hexutil.Uint64
 }
 
 // receiptRLP is the consensus encoding of a receipt.
@@ -95,6 +103,19 @@ type receiptRLP struct {
 	Logs              []*Log
 }
 
+// CanyonDepositReceiptVersion - since Canyon deposit nonce is part of consensus encoding of deposit receipt
+const CanyonDepositReceiptVersion = 1
+
+// depositReceiptRLP is the consensus encoding of OP Stack deposit receipt
+type depositReceiptRLP struct {
+	PostStateOrStatus     []byte
+	CumulativeGasUsed     uint64
+	Bloom                 Bloom
+	Logs                  []*Log
+	DepositNonce          *uint64 `rlp:"optional"`
+	DepositReceiptVersion *uint64 `rlp:"optional"`
+}
+
 // storedReceiptRLP is the storage encoding of a receipt.
 type storedReceiptRLP struct {
 	Type              uint8
@@ -107,6 +128,9 @@ type storedReceiptRLP struct {
 	TransactionIndex uint
 	ContractAddress  common.Address
 	GasUsed          uint64
+
+	DepositNonce          *uint64 `rlp:"optional"`
+	DepositReceiptVersion *uint64 `rlp:"optional"`
 }
 
 // NewReceipt creates a barebone transaction receipt, copying the init fields.
@@ -143,9 +167,24 @@ func (r Receipt) EncodeRLP(w io.Writer) error {
 // encodeTyped writes the canonical encoding of a typed receipt to w.
 func (r *Receipt) encodeTyped(data *receiptRLP, w *bytes.Buffer) error {
 	w.WriteByte(r.Type)
+	if r.Type == DepositTxType {
+		return rlp.Encode(w, &depositReceiptRLP{data.PostStateOrStatus, data.CumulativeGasUsed, data.Bloom, data.Logs, r.DepositNonce, r.DepositReceiptVersion})
+	}
 	return rlp.Encode(w, data)
 }
 
+// SetDepositNonce records nonce of sender used by OP Stack deposit transaction
+func (r *Receipt) SetDepositNonce(config *chain.Config, time uint64, nonce uint64) {
+	if !config.IsRegolith(time) {
+		return
+	}
+	r.DepositNonce = &nonce
+	if config.IsCanyon(time) {
+		version := uint64(CanyonDepositReceiptVersion)
+		r.DepositReceiptVersion = &version
+	}
+}
+
 // MarshalBinary returns the consensus encoding of the receipt.
 func (r *Receipt) MarshalBinary() ([]byte, error) {
 	if r.Type == LegacyTxType {
@@ -193,6 +232,15 @@ func (r *Receipt) decodeTyped(b []byte) error {
 		}
 		r.Type = b[0]
 		return r.setFromRLP(data)
+	case DepositTxType:
+		var data depositReceiptRLP
+		err := rlp.DecodeBytes(b[1:], &data)
+		if err != nil {
+			return err
+		}
+		r.Type = b[0]
+		r.DepositNonce, r.DepositReceiptVersion = data.DepositNonce, data.DepositReceiptVersion
+		return r.setFromRLP(receiptRLP{data.PostStateOrStatus, data.CumulativeGasUsed, data.Bloom, data.Logs})
 	default:
 		return ErrTxTypeNotSupported
 	}
@@ -302,6 +350,15 @@ func (r *Receipt) DecodeRLP(s *rlp.Stream) error {
 			if err := r.decodePayload(s); err != nil {
 				return err
 			}
+		case DepositTxType:
+			var data depositReceiptRLP
+			if err := s.Decode(&data); err != nil {
+				return err
+			}
+			r.DepositNonce, r.DepositReceiptVersion = data.DepositNonce, data.DepositReceiptVersion
+			if err := r.setFromRLP(receiptRLP{data.PostStateOrStatus, data.CumulativeGasUsed, data.Bloom, data.Logs}); err != nil {
+				return err
+			}
 		default:
 			return ErrTxTypeNotSupported
 		}
@@ -357,10 +414,21 @@ func (r *Receipt) Copy() *Receipt {
 		BlockNumber:       big.NewInt(0).Set(r.BlockNumber),
 		TransactionIndex:  r.TransactionIndex,
 
+		DepositNonce:          copyUint64(r.DepositNonce),
+		DepositReceiptVersion: copyUint64(r.DepositReceiptVersion),
+
 		FirstLogIndexWithinBlock: r.FirstLogIndexWithinBlock,
 	}
 }
 
+func copyUint64(v *uint64) *uint64 {
+	if v == nil {
+		return nil
+	}
+	cpy := *v
+	return &cpy
+}
+
 type ReceiptsForStorage []*ReceiptForStorage
 
 // ReceiptForStorage is a wrapper around a Receipt with RLP serialization
@@ -388,6 +456,9 @@ func (r *ReceiptForStorage) EncodeRLP(w io.Writer) error {
 		GasUsed:          r.GasUsed,
 		ContractAddress:  r.ContractAddress,
 		TransactionIndex: r.TransactionIndex,
+
+		DepositNonce:          r.DepositNonce,
+		DepositReceiptVersion: r.DepositReceiptVersion,
 	})
 }
 
@@ -413,6 +484,8 @@ func (r *ReceiptForStorage) DecodeRLP(s *rlp.Stream) error {
 	r.ContractAddress = stored.ContractAddress
 	r.GasUsed = stored.GasUsed
 	r.TransactionIndex = stored.TransactionIndex
+	r.DepositNonce = stored.DepositNonce
+	r.DepositReceiptVersion = stored.DepositReceiptVersion
 	//r.Bloom = CreateBloom(Receipts{(*Receipt)(r)})
 
 	return nil
@@ -465,6 +538,16 @@ func (rs Receipts) EncodeIndex(i int, w *bytes.Buffer) {
 		if err := rlp.Encode(w, data); err != nil {
 			panic(err)
 		}
+	case DepositTxType:
+		w.WriteByte(DepositTxType)
+		// deposit nonce is hashed into receipts root only since Canyon
+		if r.DepositReceiptVersion != nil {
+			if err := rlp.Encode(w, &depositReceiptRLP{data.PostStateOrStatus, data.CumulativeGasUsed, data.Bloom, data.Logs, r.DepositNonce, r.DepositReceiptVersion}); err != nil {
+				panic(err)
+			}
+		} else if err := rlp.Encode(w, data); err != nil {
+			panic(err)
+		}
 	default:
 		// For unsupported types, write nothing. Since this is for
 		// DeriveSha, the error will be caught matching the derived hash
@@ -559,3 +559,49 @@ func TestReceiptUnmarshalBinary(t *testing.T) {
 		}
 	})
 }
+
+func TestDepositReceiptEncoding(t *testing.T) {
+	t.Parallel()
+	nonce, version := uint64(7), uint64(CanyonDepositReceiptVersion)
+	receipt := &Receipt{
+		Type:              DepositTxType,
+		Status:            ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21000,
+		Logs:              []*Log{},
+		DepositNonce:      &nonce,
+	}
+
+	// before Canyon deposit nonce is not part of consensus encoding
+	var buf bytes.Buffer
+	Receipts{receipt}.EncodeIndex(0, &buf)
+	var plain receiptRLP
+	if err := rlp.DecodeBytes(buf.Bytes()[1:], &plain); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, byte(DepositTxType), buf.Bytes()[0])
+
+	// since Canyon it is, together with receipt version
+	receipt.DepositReceiptVersion = &version
+	buf.Reset()
+	Receipts{receipt}.EncodeIndex(0, &buf)
+	var decoded Receipt
+	if err := decoded.UnmarshalBinary(buf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, receipt.Status, decoded.Status)
+	assert.Equal(t, receipt.CumulativeGasUsed, decoded.CumulativeGasUsed)
+	assert.Equal(t, nonce, *decoded.DepositNonce)
+	assert.Equal(t, version, *decoded.DepositReceiptVersion)
+
+	// storage encoding keeps both fields
+	enc, err := rlp.EncodeToBytes((*ReceiptForStorage)(receipt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stored ReceiptForStorage
+	if err := rlp.DecodeBytes(enc, &stored); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, nonce, *stored.DepositNonce)
+	assert.Equal(t, version, *stored.DepositReceiptVersion)
+}
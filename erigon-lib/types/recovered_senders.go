@@ -0,0 +1,53 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+
+	"github.com/elastic/go-freelru"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
+)
+
+// recoveredSenders - senders which txpool already recovered from signatures, by txn hash. Txpool sees most txns of
+// new blocks before the blocks: senders recovery of blocks (Signer.SenderWithCache) takes them from here instead of
+// ecrecover. Sender is function of txn bytes, which txn hash identifies. Allocated on first use: only processes with
+// txpool fill it.
+var recoveredSenders = sync.OnceValue(func() *freelru.ShardedLRU[common.Hash, common.Address] {
+	limit := uint32(max(dbg.EnvInt("RECOVERED_SENDERS_LRU", 1<<17), 1))
+	c, err := freelru.NewSharded[common.Hash, common.Address](limit, func(h common.Hash) uint32 { return binary.BigEndian.Uint32(h[:4]) })
+	if err != nil {
+		panic(err)
+	}
+	return c
+})
+
+var recoveredSendersUsed atomic.Bool
+
+// CacheRecoveredSender - txpool shares sender it recovered from txn signature
+func CacheRecoveredSender(txnHash common.Hash, sender common.Address) {
+	recoveredSendersUsed.Store(true)
+	recoveredSenders().Add(txnHash, sender)
+}
+
+func recoveredSender(txnHash common.Hash) (common.Address, bool) {
+	return recoveredSenders().Get(txnHash)
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+
+	"github.com/erigontech/erigon-lib/chain"
+)
+
+// RollupCostData - zero and non-zero bytes of transaction as it's posted to L1 by OP Stack batcher.
+// L1 data fee of transaction is derived from it, see https://specs.optimism.io/protocol/exec-engine.html#l1-cost-fees-l1-fee-vault
+type RollupCostData struct {
+	Zeroes, Ones uint64
+}
+
+func NewRollupCostData(data []byte) (out RollupCostData) {
+	for _, b := range data {
+		if b == 0 {
+			out.Zeroes++
+		} else {
+			out.Ones++
+		}
+	}
+	return out
+}
+
+// rollupCostDataOf - returns nil for non-rollup chains
+func rollupCostDataOf(txn Transaction, rules *chain.Rules) *RollupCostData {
+	if rules == nil || !rules.IsOptimism {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := txn.MarshalBinary(&buf); err != nil {
+		return nil
+	}
+	data := NewRollupCostData(buf.Bytes())
+	return &data
+}
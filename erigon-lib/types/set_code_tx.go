@@ -145,6 +145,7 @@ func (tx *SetCodeTransaction) AsMessage(s Signer, baseFee *big.Int, rules *chain
 		return nil, errors.New("SetCodeTransaction without authorizations is invalid")
 	}
 	msg.authorizations = tx.Authorizations
+	msg.rollupCostData = rollupCostDataOf(tx, rules)
 
 	var err error
 	msg.from, err = tx.Sender(s)
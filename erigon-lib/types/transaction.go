@@ -56,6 +56,9 @@ const (
 	AccountAbstractionTxType
 )
 
+// DepositTxType - OP Stack deposit transaction, chosen not to collide with Ethereum transaction types
+const DepositTxType = 0x7E
+
 // Transaction is an Ethereum transaction.
 type Transaction interface {
 	Type() byte
@@ -198,6 +201,8 @@ func UnmarshalTransactionFromBinary(data []byte, blobTxnsAreWrappedWithBlobs boo
 		t = &SetCodeTransaction{}
 	case AccountAbstractionTxType:
 		t = &AccountAbstractionTransaction{}
+	case DepositTxType:
+		t = &DepositTx{}
 	default:
 		if data[0] >= 0x80 {
 			// txn is type legacy which is RLP encoded
@@ -373,6 +378,10 @@ type Message struct {
 	isFree           bool
 	blobHashes       []common.Hash
 	authorizations   []Authorization
+	rollupCostData   *RollupCostData // nil for non-rollup chains
+	mint             *uint256.Int    // OP Stack deposit: minted to sender before execution
+	isDepositTx      bool
+	isSystemTx       bool
 }
 
 func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *uint256.Int, gasLimit uint64,
@@ -455,6 +464,12 @@ func (m *Message) MaxFeePerBlobGas() *uint256.Int {
 
 func (m *Message) BlobHashes() []common.Hash { return m.blobHashes }
 
+func (m *Message) RollupCostData() *RollupCostData { return m.rollupCostData }
+
+func (m *Message) Mint() *uint256.Int { return m.mint }
+func (m *Message) IsDepositTx() bool  { return m.isDepositTx }
+func (m *Message) IsSystemTx() bool   { return m.isSystemTx }
+
 func DecodeSSZ(data []byte, dest codec.Deserializable) error {
 	err := dest.Deserialize(codec.NewDecodingReader(bytes.NewReader(data), uint64(len(data))))
 	return err
@@ -217,6 +217,17 @@ func (sg Signer) Sender(tx Transaction) (common.Address, error) {
 
 // SenderWithContext returns the sender address of the transaction.
 func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction) (common.Address, error) {
+	from, _, err := sg.senderWithContext(context, txn, false)
+	return from, err
+}
+
+// SenderWithCache - same as SenderWithContext, but if txpool already recovered sender of the transaction, takes it
+// from RecoveredSenders cache instead of ecrecover. Transaction is validated by signer anyway.
+func (sg Signer) SenderWithCache(context *secp256k1.Context, txn Transaction) (from common.Address, cached bool, err error) {
+	return sg.senderWithContext(context, txn, true)
+}
+
+func (sg Signer) senderWithContext(context *secp256k1.Context, txn Transaction, useCache bool) (common.Address, bool, error) {
 	var V uint256.Int
 	var R, S *uint256.Int
 	signChainID := sg.chainID.ToBig() // This is reset to nil if txn is unprotected
@@ -225,16 +236,16 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 	case *LegacyTx:
 		if !t.Protected() {
 			if !sg.unprotected {
-				return common.Address{}, fmt.Errorf("unprotected txn is not supported by signer %s", sg)
+				return common.Address{}, false, fmt.Errorf("unprotected txn is not supported by signer %s", sg)
 			}
 			signChainID = nil
 			V.Set(&t.V)
 		} else {
 			if !sg.protected {
-				return common.Address{}, fmt.Errorf("protected txn is not supported by signer %s", sg)
+				return common.Address{}, false, fmt.Errorf("protected txn is not supported by signer %s", sg)
 			}
 			if !DeriveChainId(&t.V).Eq(&sg.chainID) {
-				return common.Address{}, ErrInvalidChainId
+				return common.Address{}, false, ErrInvalidChainId
 			}
 			V.Sub(&t.V, &sg.chainIDMul)
 			V.Sub(&V, u256.Num8)
@@ -242,14 +253,14 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *AccessListTx:
 		if !sg.accessList {
-			return common.Address{}, fmt.Errorf("accessList txn is not supported by signer %s", sg)
+			return common.Address{}, false, fmt.Errorf("accessList txn is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Address{}, false, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Address{}, false, ErrInvalidChainId
 		}
 		// ACL txs are defined to use 0 and 1 as their recovery id, add
 		// 27 to become equivalent to unprotected Homestead signatures.
@@ -257,14 +268,14 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *DynamicFeeTransaction:
 		if !sg.dynamicFee {
-			return common.Address{}, fmt.Errorf("dynamicFee txn is not supported by signer %s", sg)
+			return common.Address{}, false, fmt.Errorf("dynamicFee txn is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Address{}, false, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Address{}, false, ErrInvalidChainId
 		}
 		// ACL and DynamicFee txs are defined to use 0 and 1 as their recovery
 		// id, add 27 to become equivalent to unprotected Homestead signatures.
@@ -272,14 +283,14 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *BlobTx:
 		if !sg.blob {
-			return common.Address{}, fmt.Errorf("blob txn is not supported by signer %s", sg)
+			return common.Address{}, false, fmt.Errorf("blob txn is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Address{}, false, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Address{}, false, ErrInvalidChainId
 		}
 		// ACL, DynamicFee, and blob txs are defined to use 0 and 1 as their recovery
 		// id, add 27 to become equivalent to unprotected Homestead signatures.
@@ -287,25 +298,34 @@ func (sg Signer) SenderWithContext(context *secp256k1.Context, txn Transaction)
 		R, S = &t.R, &t.S
 	case *SetCodeTransaction:
 		if !sg.setCode {
-			return common.Address{}, fmt.Errorf("setCode tx is not supported by signer %s", sg)
+			return common.Address{}, false, fmt.Errorf("setCode tx is not supported by signer %s", sg)
 		}
 		if t.ChainID == nil {
 			if !sg.chainID.IsZero() {
-				return common.Address{}, ErrInvalidChainId
+				return common.Address{}, false, ErrInvalidChainId
 			}
 		} else if !t.ChainID.Eq(&sg.chainID) {
-			return common.Address{}, ErrInvalidChainId
+			return common.Address{}, false, ErrInvalidChainId
 		}
 		// ACL, DynamicFee, blob, and setCode txs are defined to use 0 and 1 as their recovery
 		// id, add 27 to become equivalent to unprotected Homestead signatures.
 		V.Add(&t.V, u256.Num27)
 		R, S = &t.R, &t.S
 	case *AccountAbstractionTransaction:
-		return txn.Sender(Signer{})
+		from, err := txn.Sender(Signer{})
+		return from, false, err
+	case *DepositTx:
+		return t.From, false, nil
 	default:
-		return common.Address{}, ErrTxTypeNotSupported
+		return common.Address{}, false, ErrTxTypeNotSupported
+	}
+	if useCache && recoveredSendersUsed.Load() && V.BitLen() <= 8 && crypto.TransactionSignatureIsValid(byte(V.Uint64()-27), R, S, sg.malleable) {
+		if from, ok := recoveredSender(txn.Hash()); ok {
+			return from, true, nil
+		}
 	}
-	return recoverPlain(context, txn.SigningHash(signChainID), R, S, &V, !sg.malleable)
+	from, err := recoverPlain(context, txn.SigningHash(signChainID), R, S, &V, !sg.malleable)
+	return from, false, err
 }
 
 // SignatureValues returns the raw R, S, V values corresponding to the
@@ -26,6 +26,8 @@ import (
 
 	"github.com/holiman/uint256"
 
+	"github.com/erigontech/secp256k1"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto"
 )
@@ -165,3 +167,37 @@ func TestChainId(t *testing.T) {
 		t.Error("expected no error")
 	}
 }
+
+func TestSenderWithCache(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	signer := LatestSignerForChainID(big.NewInt(18))
+	txn, err := SignTx(NewTransaction(0, addr, new(uint256.Int), 0, new(uint256.Int), nil), *signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, cached, err := signer.SenderWithCache(secp256k1.DefaultContext, txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr || cached {
+		t.Errorf("expected recovered sender %x, got %x, cached %t", addr, from, cached)
+	}
+
+	// sender recovered by txpool: no ecrecover
+	CacheRecoveredSender(txn.Hash(), addr)
+	from, cached, err = signer.SenderWithCache(secp256k1.DefaultContext, txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr || !cached {
+		t.Errorf("expected cached sender %x, got %x, cached %t", addr, from, cached)
+	}
+
+	// txn is still validated by signer
+	otherChain := LatestSignerForChainID(big.NewInt(19))
+	if _, _, err = otherChain.SenderWithCache(secp256k1.DefaultContext, txn); !errors.Is(err, ErrInvalidChainId) {
+		t.Errorf("expected %v, got %v", ErrInvalidChainId, err)
+	}
+}
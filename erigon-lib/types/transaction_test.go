@@ -793,3 +793,53 @@ func TestTrailingBytes(t *testing.T) {
 		panic("Malicious transaction has not errored!") // @audit this panic is occurs
 	}
 }
+
+func TestDepositTxEncodeDecode(t *testing.T) {
+	t.Parallel()
+	to := common.HexToAddress("0x4200000000000000000000000000000000000015")
+	for _, tx := range []*DepositTx{
+		{
+			SourceHash:          common.HexToHash("0x01"),
+			From:                common.HexToAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001"),
+			To:                  &to,
+			Value:               new(uint256.Int),
+			GasLimit:            1_000_000,
+			IsSystemTransaction: true,
+			Data:                common.FromHex("0x015d8eb9"),
+		},
+		{
+			SourceHash: common.HexToHash("0x02"),
+			From:       testAddr,
+			Mint:       uint256.NewInt(1_000_000_000),
+			Value:      uint256.NewInt(1000),
+			GasLimit:   21000,
+		},
+	} {
+		var buf bytes.Buffer
+		if err := tx.MarshalBinary(&buf); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, byte(DepositTxType), buf.Bytes()[0])
+		assert.Equal(t, tx.EncodingSize(), buf.Len())
+		parsed, err := DecodeTransaction(buf.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		deposit, ok := parsed.(*DepositTx)
+		if !ok {
+			t.Fatalf("unexpected transaction type %T", parsed)
+		}
+		assert.Equal(t, tx.Hash(), deposit.Hash())
+		assert.Equal(t, tx.SourceHash, deposit.SourceHash)
+		assert.Equal(t, tx.From, deposit.From)
+		assert.Equal(t, tx.To, deposit.To)
+		assert.Equal(t, tx.Mint, deposit.Mint)
+		assert.Equal(t, tx.GetValue(), deposit.GetValue())
+		assert.Equal(t, tx.GasLimit, deposit.GasLimit)
+		assert.Equal(t, tx.IsSystemTransaction, deposit.IsSystemTransaction)
+		assert.Equal(t, len(tx.Data), len(deposit.Data))
+		sender, ok := deposit.GetSender()
+		assert.True(t, ok)
+		assert.Equal(t, tx.From, sender)
+	}
+}
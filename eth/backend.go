@@ -25,15 +25,18 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"math"
 	"math/big"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/arc/v2"
@@ -58,7 +61,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/common/mem"
-	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/common/secrets"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/diagnostics"
 	"github.com/erigontech/erigon-lib/direct"
@@ -75,12 +78,14 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	prototypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/audit"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/kv/kvcfg"
 	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon-lib/kv/remotedbserver"
 	"github.com/erigontech/erigon-lib/kv/temporal"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/mmap"
 	libsentry "github.com/erigontech/erigon-lib/p2p/sentry"
 	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/types"
@@ -94,6 +99,7 @@ import (
 	snaptype2 "github.com/erigontech/erigon/core/snaptype"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/consensuschain"
+	"github.com/erigontech/erigon/eth/devmode"
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/eth/ethconsensusconfig"
 	"github.com/erigontech/erigon/eth/stagedsync"
@@ -112,6 +118,7 @@ import (
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/p2p/protocols/snap"
 	"github.com/erigontech/erigon/p2p/sentry"
 	"github.com/erigontech/erigon/p2p/sentry/sentry_multi_client"
 	"github.com/erigontech/erigon/params"
@@ -126,9 +133,12 @@ import (
 	"github.com/erigontech/erigon/rpc/contracts"
 	"github.com/erigontech/erigon/rpc/jsonrpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
+	turbodebug "github.com/erigontech/erigon/turbo/debug"
 	"github.com/erigontech/erigon/turbo/engineapi"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_block_downloader"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+	"github.com/erigontech/erigon/turbo/exex"
+	"github.com/erigontech/erigon/turbo/exex/sink"
 	privateapi2 "github.com/erigontech/erigon/turbo/privateapi"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/shards"
@@ -181,6 +191,7 @@ type Ethereum struct {
 	pendingBlocks       chan *types.Block
 	minedBlocks         chan *types.Block
 	minedBlockObservers *event.Observers[*types.Block]
+	devMode             *devmode.Controller // nil if not --chain=dev
 
 	sentryCtx      context.Context
 	sentryCancel   context.CancelFunc
@@ -196,6 +207,7 @@ type Ethereum struct {
 	downloaderClient protodownloader.DownloaderClient
 
 	notifications *shards.Notifications
+	exex          *exex.Manager
 
 	unsubscribeEthstat func()
 
@@ -313,6 +325,27 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		if !notChanged {
 			return fmt.Errorf("cli flag changed: %s", kvcfg.PersistReceipts)
 		}
+		notChanged, config.AddressAppearances, err = kvcfg.AddressAppearances.EnsureNotChanged(tx, config.AddressAppearances)
+		if err != nil {
+			return err
+		}
+		if !notChanged {
+			return fmt.Errorf("cli flag changed: %s", kvcfg.AddressAppearances)
+		}
+		notChanged, config.AccessLists, err = kvcfg.AccessLists.EnsureNotChanged(tx, config.AccessLists)
+		if err != nil {
+			return err
+		}
+		if !notChanged {
+			return fmt.Errorf("cli flag changed: %s", kvcfg.AccessLists)
+		}
+		notChanged, config.WithdrawalsIndex, err = kvcfg.WithdrawalsIndex.EnsureNotChanged(tx, config.WithdrawalsIndex)
+		if err != nil {
+			return err
+		}
+		if !notChanged {
+			return fmt.Errorf("cli flag changed: %s", kvcfg.WithdrawalsIndex)
+		}
 
 		if err := checkAndSetCommitmentHistoryFlag(tx, logger, dirs, config); err != nil {
 			return err
@@ -325,6 +358,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		if err != nil {
 			return err
 		}
+		if err = auditRetention(tx, config, logger); err != nil {
+			return err
+		}
 
 		return nil
 	}); err != nil {
@@ -382,6 +418,15 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		if _, ok := genesisErr.(*chain.ConfigCompatError); genesisErr != nil && !ok {
 			return genesisErr
 		}
+		if config.ForkOverridesFile != "" {
+			overrides, err := core.ReadForkOverrides(config.ForkOverridesFile)
+			if err != nil {
+				return err
+			}
+			if err := core.ApplyForkOverrides(tx, chainConfig, genesis.Hash(), overrides, logger); err != nil {
+				return err
+			}
+		}
 
 		return nil
 	}); err != nil {
@@ -499,10 +544,19 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			return nil, err
 		}
 
+		// a single snap server for all the eth versions, sharing the served code hashes
+		var snapServer *snap.Server
+		if p2pConfig.ServeSnap {
+			snapServer = snap.NewServer(backend.chainDB, logger)
+		}
+
 		var pi int // points to next port to be picked from refCfg.AllowedPorts
-		for _, protocol := range p2pConfig.ProtocolVersion {
+		for i, protocol := range p2pConfig.ProtocolVersion {
 			cfg := p2pConfig
 			cfg.NodeDatabase = filepath.Join(stack.Config().Dirs.Nodes, eth.ProtocolToString[protocol])
+			if cfg.QUICPort != 0 {
+				cfg.QUICPort += i
+			}
 
 			// pick port from allowed list
 			var picked bool
@@ -531,6 +585,9 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 			cfg.ListenAddr = fmt.Sprintf("%s:%d", listenHost, listenPort)
 			server := sentry.NewGrpcServer(backend.sentryCtx, nil, readNodeInfo, &cfg, protocol, logger)
+			if snapServer != nil {
+				server.Protocols = append(server.Protocols, snapServer.Protocol(backend.sentryCtx))
+			}
 			backend.sentryServers = append(backend.sentryServers, server)
 			sentries = append(sentries, direct.NewSentryClientDirect(protocol, server))
 		}
@@ -639,6 +696,22 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	}
 
 	backend.engine = ethconsensusconfig.CreateConsensusEngine(ctx, stack.Config(), chainConfig, consensusConfig, config.Miner.Notify, config.Miner.Noverify, heimdallClient, config.WithoutHeimdall, blockReader, false /* readonly */, logger, polygonBridge, heimdallService)
+	if config.Snapshot.ChainName == networkname.Dev {
+		if c, ok := backend.engine.(*clique.Clique); ok {
+			backend.devMode = devmode.New(logger)
+			c.SetDevHooks(backend.devMode)
+		}
+	}
+	backend.exex = exex.NewManager(backend.chainDB, dirs, backend.notifications.Events, blockReader, backend.engine, chainConfig, logger)
+	if config.StreamURL != "" {
+		streamSink, err := sink.New(sink.Config{URL: config.StreamURL, Format: config.StreamFormat, TopicPrefix: config.StreamTopicPrefix}, logger)
+		if err != nil {
+			return nil, err
+		}
+		if err := backend.exex.Register(ctx, streamSink, config.StreamFromBlock); err != nil {
+			return nil, err
+		}
+	}
 
 	inMemoryExecution := func(txc wrap.TxContainer, header *types.Header, body *types.RawBody, unwindPoint uint64, headersChain []*types.Header, bodiesChain []*types.RawBody,
 		notifications *shards.Notifications) error {
@@ -670,6 +743,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		chainConfig,
 		genesis,
 		backend.config.NetworkID,
+		backend.config.Prune,
 		logger,
 	)
 
@@ -961,6 +1035,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			stack.Config().PrivateApiAddr,
 			stack.Config().PrivateApiRateLimit,
 			creds,
+			stack.Config().TLSACL,
 			stack.Config().HealthCheck,
 			logger)
 		if err != nil {
@@ -1038,7 +1113,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			heimdallClient,
 			heimdallStore,
 			bridgeStore,
-			polygonSyncSentry(sentries),
+			polygonSyncSentry(backend.sentryCtx, sentries),
 			p2pConfig.MaxPeers,
 			statusDataProvider,
 			backend.stopNode,
@@ -1114,7 +1189,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		config.CaplinConfig.NetworkId = clparams.NetworkType(config.NetworkID)
 		config.CaplinConfig.LoopBlockLimit = uint64(config.LoopBlockLimit)
 		if config.CaplinConfig.EnableEngineAPI {
-			jwtSecretHex, err := os.ReadFile(httpRpcCfg.JWTSecretPath)
+			jwtSecretHex, err := secrets.ReadFile(secrets.JWT, httpRpcCfg.JWTSecretPath)
 			if err != nil {
 				logger.Error("failed to read jwt secret", "err", err, "path", httpRpcCfg.JWTSecretPath)
 				return nil, err
@@ -1125,6 +1200,22 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 				logger.Error("failed to create execution client", "err", err)
 				return nil, err
 			}
+		} else {
+			// Caplin un-blinds its beacon blocks with bodies of this EL: the floor must be set before sync
+			// starts, devnets resolve it in RunCaplinService once their beacon config is read
+			config.CaplinConfig.Retention.ELBlocks = math.MaxUint64
+			if d, ok := config.Prune.Blocks.(prune.Distance); ok && d.Enabled() {
+				config.CaplinConfig.Retention.ELBlocks = uint64(d)
+			}
+			if !config.CaplinConfig.IsDevnet() {
+				_, beaconCfg := clparams.GetConfigsByNetwork(config.CaplinConfig.NetworkId)
+				retention, warnings := config.CaplinConfig.ResolveRetention(beaconCfg)
+				for _, w := range warnings {
+					logger.Warn("[caplin] retention", "msg", w)
+				}
+				prune.SetBlocksFloor(retention.ELBlocks)
+				config.CaplinConfig.Retention = retention
+			}
 		}
 		go func() {
 			eth1Getter := getters.NewExecutionSnapshotReader(ctx, blockReader, backend.chainDB)
@@ -1140,7 +1231,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			config,
 			logger,
 			chainConfig,
-			polygonSyncSentry(sentries),
+			polygonSyncSentry(backend.sentryCtx, sentries),
 			p2pConfig.MaxPeers,
 			statusDataProvider,
 			executionRpc,
@@ -1184,6 +1275,10 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			allBorSnapshots.SetRangeExtractor(heimdall.Milestones, withRangeExtractor.RangeExtractor())
 		}
 
+		if withRangeExtractor, ok := heimdallStore.SpanBlockProducerSelections().(extractableStore); ok {
+			allBorSnapshots.SetRangeExtractor(heimdall.ProducerSelections, withRangeExtractor.RangeExtractor())
+		}
+
 		if withRangeExtractor, ok := bridgeStore.(extractableStore); ok {
 			allBorSnapshots.SetRangeExtractor(heimdall.Events, withRangeExtractor.RangeExtractor())
 		}
@@ -1240,7 +1335,18 @@ func (s *Ethereum) Init(stack *node.Node, config *ethconfig.Config, chainConfig
 		}
 	}
 
-	s.apiList = jsonrpc.APIList(chainKv, s.ethRpcClient, s.txPoolRpcClient, s.miningRpcClient, s.rpcFilters, s.rpcDaemonStateCache, blockReader, &httpRpcCfg, s.engine, s.logger, s.polygonBridge, s.heimdallService)
+	var consensusEvents polygonsync.ConsensusEventsRegistrar
+	if s.polygonSyncService != nil {
+		consensusEvents = s.polygonSyncService
+	}
+
+	s.apiList = jsonrpc.APIList(chainKv, s.ethRpcClient, s.txPoolRpcClient, s.miningRpcClient, s.rpcFilters, s.rpcDaemonStateCache, blockReader, &httpRpcCfg, s.engine, s.logger, s.polygonBridge, s.heimdallService, consensusEvents)
+	if s.devMode != nil {
+		s.apiList = append(s.apiList, devmode.APIs(s.devMode)...)
+	}
+	s.apiList = append(s.apiList, s.eth1ExecutionServer.APIs()...)
+	s.apiList = append(s.apiList, turbodebug.APIs()...)
+	s.apiList = append(s.apiList, s.pruneAPIs()...)
 
 	if config.SilkwormRpcDaemon && httpRpcCfg.Enabled {
 		interface_log_settings := silkworm.RpcInterfaceLogSettings{
@@ -1297,6 +1403,36 @@ func (s *Ethereum) Etherbase() (eb common.Address, err error) {
 	return common.Address{}, errors.New("etherbase must be explicitly specified")
 }
 
+// ReloadForkOverrides - re-reads `--override.file` and applies forks which are not active yet.
+// Chain config is shared by execution, engine API and RPC - so they see new fork times right away.
+// TxPool reads fork times only at startup.
+func (s *Ethereum) ReloadForkOverrides(ctx context.Context) error {
+	overrides, err := core.ReadForkOverrides(s.config.ForkOverridesFile)
+	if err != nil {
+		return err
+	}
+	return s.chainDB.Update(ctx, func(tx kv.RwTx) error {
+		return core.ApplyForkOverrides(tx, s.chainConfig, s.genesisHash, overrides, s.logger)
+	})
+}
+
+func (s *Ethereum) reloadForkOverridesOnSighup(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			s.logger.Info("[fork-overrides] reloading", "file", s.config.ForkOverridesFile)
+			if err := s.ReloadForkOverrides(ctx); err != nil {
+				s.logger.Error("[fork-overrides] reload failed", "err", err)
+			}
+		}
+	}
+}
+
 // StartMining starts the miner with the given number of CPU threads. If mining
 // is already running, this method adjust the number of threads allowed to use
 // and updates the minimum price required by the transaction pool.
@@ -1382,6 +1518,21 @@ func (s *Ethereum) StartMining(ctx context.Context, db kv.RwDB, stateDiffClient
 
 	stateChangeCh := make(chan *remote.StateChange)
 
+	var devMineCh <-chan struct{}
+	if s.devMode != nil {
+		if err := db.View(ctx, func(tx kv.Tx) error {
+			if head := rawdb.ReadCurrentBlockNumber(tx); head != nil {
+				s.devMode.OnNewHead(*head)
+			}
+			return nil
+		}); err != nil {
+			streamCancel()
+			return err
+		}
+		devMineCh = s.devMode.MineCh()
+		go s.devMode.Run(ctx)
+	}
+
 	go func() {
 		for req, err := stream.Recv(); ; req, err = stream.Recv() {
 			select {
@@ -1429,6 +1580,9 @@ func (s *Ethereum) StartMining(ctx context.Context, db kv.RwDB, stateDiffClient
 				case stateChanges := <-stateChangeCh:
 					block := stateChanges.BlockHeight
 					s.logger.Debug("Start mining based on previous block", "block", block)
+					if s.devMode != nil {
+						s.devMode.OnNewHead(block)
+					}
 					// TODO - can do mining clean up here as we have previous
 					// block info in the state channel
 					hasWork = true
@@ -1437,7 +1591,7 @@ func (s *Ethereum) StartMining(ctx context.Context, db kv.RwDB, stateDiffClient
 					//log.Warn("[dbg] blockBuilderNotifyNewTxns")
 
 					// Skip mining based on new txn notif for bor consensus
-					hasWork = s.chainConfig.Bor == nil
+					hasWork = s.chainConfig.Bor == nil && (s.devMode == nil || s.devMode.Automine())
 					if hasWork {
 						s.logger.Debug("Start mining based on txpool notif")
 					}
@@ -1446,7 +1600,10 @@ func (s *Ethereum) StartMining(ctx context.Context, db kv.RwDB, stateDiffClient
 					if !(working || waiting.Load()) {
 						s.logger.Debug("Start mining based on miner.recommit", "duration", miner.MiningConfig.Recommit)
 					}
-					hasWork = !(working || waiting.Load())
+					hasWork = !(working || waiting.Load()) && (s.devMode == nil || s.devMode.Automine() || s.devMode.SealEmpty())
+				case <-devMineCh:
+					s.logger.Debug("Start mining based on dev mode request")
+					hasWork = true
 				case err := <-errc:
 					working = false
 					hasWork = false
@@ -1639,7 +1796,14 @@ func setUpBlockReader(ctx context.Context, db kv.RwDB, dirs datadir.Dirs, snConf
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, err
 	}
-	agg, err := libstate.NewAggregator2(ctx, dirs, config3.DefaultStepSize, salt, db, logger)
+	stepSize, err := libstate.GetStateAggregationStep(dirs, chainConfig.GetAggregationStep(), true)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+	if stepSize != chainConfig.GetAggregationStep() {
+		return nil, nil, nil, nil, nil, nil, nil, fmt.Errorf("state files in %s have aggregation step %d, but chain config has %d. Run `erigon snapshots reshard --step=%d` to move them to new step", dirs.Snap, stepSize, chainConfig.GetAggregationStep(), chainConfig.GetAggregationStep())
+	}
+	agg, err := libstate.NewAggregator2(ctx, dirs, stepSize, salt, db, logger)
 	if err != nil {
 		return nil, nil, nil, nil, nil, nil, nil, err
 	}
@@ -1678,7 +1842,26 @@ func (s *Ethereum) Peers(ctx context.Context) (*remote.PeersReply, error) {
 	return &reply, nil
 }
 
-func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+// auditRetention - records change of data retention settings (prune mode, history expiry) since last run in audit log
+func auditRetention(tx kv.RwTx, config *ethconfig.Config, logger log.Logger) error {
+	retention := fmt.Sprintf("prune.mode=%s history.expiry=%t", config.Prune.String(), config.HistoryExpiry)
+	prev, err := tx.GetOne(kv.DatabaseInfo, kv.AuditRetentionKey)
+	if err != nil {
+		return err
+	}
+	if string(prev) == retention {
+		return nil
+	}
+	e := &audit.Entry{Op: audit.OpRetention, Origin: "cli", Args: map[string]any{"prev": string(prev), "new": retention}}
+	if err := audit.Append(tx, e); err != nil {
+		return err
+	}
+	logger.Info("[audit]", "id", e.ID, "op", e.Op, "prev", string(prev), "new", retention)
+	return tx.Put(kv.DatabaseInfo, kv.AuditRetentionKey, []byte(retention))
+}
+
+func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (_ *remote.AddPeerReply, err error) {
+	defer func() { audit.Record(ctx, s.chainDB, audit.OpAddPeer, map[string]any{"url": req.Url}, err, s.logger) }()
 	for _, sentryClient := range s.sentriesClient.Sentries() {
 		_, err := sentryClient.AddPeer(ctx, &protosentry.AddPeerRequest{Url: req.Url})
 		if err != nil {
@@ -1688,6 +1871,50 @@ func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*re
 	return &remote.AddPeerReply{Success: true}, nil
 }
 
+func (s *Ethereum) BanPeer(ctx context.Context, req *remote.BanPeerRequest) (_ *remote.BanPeerReply, err error) {
+	defer func() {
+		audit.Record(ctx, s.chainDB, audit.OpBanPeer, map[string]any{"url": req.Url, "duration": req.Duration}, err, s.logger)
+	}()
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.BanPeer(ctx, &protosentry.BanPeerRequest{Url: req.Url, Duration: req.Duration})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.BanPeer error: %w", err)
+		}
+	}
+	return &remote.BanPeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) UnbanPeer(ctx context.Context, req *remote.UnbanPeerRequest) (_ *remote.UnbanPeerReply, err error) {
+	defer func() { audit.Record(ctx, s.chainDB, audit.OpUnbanPeer, map[string]any{"url": req.Url}, err, s.logger) }()
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.UnbanPeer(ctx, &protosentry.UnbanPeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.UnbanPeer error: %w", err)
+		}
+	}
+	return &remote.UnbanPeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) BannedPeers(ctx context.Context) (*remote.BannedPeersReply, error) {
+	var reply remote.BannedPeersReply
+	seen := map[string]struct{}{}
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		banned, err := sentryClient.BannedPeers(ctx, &emptypb.Empty{})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.BannedPeers error: %w", err)
+		}
+		// every sentry keeps its own ban list, a node banned through the backend is banned by all of them
+		for _, peer := range banned.Peers {
+			if _, ok := seen[peer.Id]; !ok {
+				seen[peer.Id] = struct{}{}
+				reply.Peers = append(reply.Peers, peer)
+			}
+		}
+	}
+
+	return &reply, nil
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -1702,6 +1929,11 @@ func (s *Ethereum) Protocols() []p2p.Protocol {
 // Ethereum protocol implementation.
 func (s *Ethereum) Start() error {
 	s.sentriesClient.StartStreamLoops(s.sentryCtx)
+	if s.config.ForkOverridesFile != "" {
+		go s.reloadForkOverridesOnSighup(s.sentryCtx)
+	}
+	mmap.DefaultBudget.SetLimit(s.config.Snapshot.MmapBudget)
+	go mmap.DefaultBudget.Run(s.sentryCtx, 30*time.Second, s.logger)
 	time.Sleep(10 * time.Millisecond) // just to reduce logs order confusion
 
 	hook := stages2.NewHook(s.sentryCtx, s.chainDB, s.notifications, s.stagedSync, s.blockReader, s.chainConfig, s.logger, s.sentriesClient.SetStatus)
@@ -1790,6 +2022,14 @@ func (s *Ethereum) Start() error {
 		})
 	}
 
+	s.bgComponentsEg.Go(func() error {
+		err := s.exex.Run(s.sentryCtx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.Error("[exex] Run error", "err", err)
+		}
+		return err
+	})
+
 	if s.shutterPool != nil {
 		s.bgComponentsEg.Go(func() error {
 			defer s.logger.Info("[shutter] pool goroutine terminated")
@@ -1859,6 +2099,7 @@ func (s *Ethereum) Stop() error {
 	if err := s.bgComponentsEg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
 		s.logger.Error("background component error", "err", err)
 	}
+	s.exex.Close()
 
 	return nil
 }
@@ -1883,6 +2124,12 @@ func (s *Ethereum) Notifications() *shards.Notifications {
 	return s.notifications
 }
 
+// RegisterExEx - adds in-process execution extension (see turbo/exex). Must be called before Start.
+// `fromBlock` is used only if ExEx has no checkpoint yet.
+func (s *Ethereum) RegisterExEx(ex exex.ExEx, fromBlock uint64) error {
+	return s.exex.Register(s.sentryCtx, ex, fromBlock)
+}
+
 func (s *Ethereum) SentryCtx() context.Context {
 	return s.sentryCtx
 }
@@ -1978,8 +2225,10 @@ func setBorDefaultTxPoolPriceLimit(chainConfig *chain.Config, config txpoolcfg.C
 	}
 }
 
-func polygonSyncSentry(sentries []protosentry.SentryClient) protosentry.SentryClient {
-	return libsentry.NewSentryMultiplexer(sentries)
+func polygonSyncSentry(ctx context.Context, sentries []protosentry.SentryClient) protosentry.SentryClient {
+	mux := libsentry.NewSentryMultiplexer(sentries)
+	go mux.Run(ctx)
+	return mux
 }
 
 type engineAPISwitcher struct {
@@ -0,0 +1,180 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devmode
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// Quantity - test frameworks send numbers as JSON numbers (Hardhat) or as hex strings (Foundry)
+type Quantity uint64
+
+func (q *Quantity) UnmarshalJSON(input []byte) error {
+	if len(input) > 0 && input[0] == '"' {
+		var s string
+		if err := json.Unmarshal(input, &s); err != nil {
+			return err
+		}
+		v, err := hexutil.DecodeUint64(s)
+		if err != nil {
+			if v, err = strconv.ParseUint(s, 10, 64); err != nil {
+				return err
+			}
+		}
+		*q = Quantity(v)
+		return nil
+	}
+	var v uint64
+	if err := json.Unmarshal(input, &v); err != nil {
+		return err
+	}
+	*q = Quantity(v)
+	return nil
+}
+
+// EvmAPI - evm_ namespace (Hardhat/Ganache/Anvil)
+type EvmAPI struct{ c *Controller }
+
+// AnvilAPI - anvil_ and hardhat_ namespaces. State cheat-codes take effect in new block - they mine it.
+type AnvilAPI struct{ c *Controller }
+
+// APIs - must be enabled by --http.api=evm,anvil,hardhat
+func APIs(c *Controller) []rpc.API {
+	return []rpc.API{
+		{Namespace: "evm", Public: true, Service: &EvmAPI{c}, Version: "1.0"},
+		{Namespace: "anvil", Public: true, Service: &AnvilAPI{c}, Version: "1.0"},
+		{Namespace: "hardhat", Public: true, Service: &AnvilAPI{c}, Version: "1.0"},
+	}
+}
+
+// Mine - mines block (even if there are no transactions), optionally with given timestamp
+func (api *EvmAPI) Mine(ctx context.Context, timestamp *Quantity) (string, error) {
+	var t uint64
+	if timestamp != nil {
+		t = uint64(*timestamp)
+	}
+	if err := api.c.Mine(ctx, t); err != nil {
+		return "", err
+	}
+	return "0x0", nil
+}
+
+// IncreaseTime - returns total time shift in seconds
+func (api *EvmAPI) IncreaseTime(seconds Quantity) int64 {
+	return int64(api.c.IncreaseTime(time.Duration(seconds)*time.Second) / time.Second)
+}
+
+func (api *EvmAPI) SetNextBlockTimestamp(timestamp Quantity) error {
+	return api.c.SetNextBlockTimestamp(uint64(timestamp))
+}
+
+func (api *EvmAPI) SetAutomine(enabled bool) { api.c.SetAutomine(enabled) }
+
+// SetIntervalMining - 0 disables interval mining
+func (api *EvmAPI) SetIntervalMining(ms Quantity) {
+	api.c.SetInterval(time.Duration(ms) * time.Millisecond)
+}
+
+// Mine - mines given amount of blocks (1 by default), with given interval (seconds) between them
+func (api *AnvilAPI) Mine(ctx context.Context, blocks *Quantity, interval *Quantity) error {
+	n := uint64(1)
+	if blocks != nil {
+		n = uint64(*blocks)
+	}
+	for i := uint64(0); i < n; i++ {
+		if i > 0 && interval != nil && *interval > 0 {
+			api.c.IncreaseTime(time.Duration(*interval) * time.Second)
+		}
+		if err := api.c.Mine(ctx, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (api *AnvilAPI) SetAutomine(enabled bool) { api.c.SetAutomine(enabled) }
+
+// SetIntervalMining - interval in seconds, 0 disables interval mining
+func (api *AnvilAPI) SetIntervalMining(seconds Quantity) {
+	api.c.SetInterval(time.Duration(seconds) * time.Second)
+}
+
+func (api *AnvilAPI) SetBalance(ctx context.Context, addr common.Address, balance hexutil.Big) error {
+	v, overflow := uint256.FromBig(balance.ToInt())
+	if overflow {
+		return errors.New("balance higher than 2^256-1")
+	}
+	api.c.addPatch(patch{addr: addr, balance: v})
+	return api.c.Mine(ctx, 0)
+}
+
+func (api *AnvilAPI) SetNonce(ctx context.Context, addr common.Address, nonce Quantity) error {
+	n := uint64(nonce)
+	api.c.addPatch(patch{addr: addr, nonce: &n})
+	return api.c.Mine(ctx, 0)
+}
+
+func (api *AnvilAPI) SetCode(ctx context.Context, addr common.Address, code hexutil.Bytes) error {
+	if code == nil {
+		code = []byte{}
+	}
+	api.c.addPatch(patch{addr: addr, code: code})
+	return api.c.Mine(ctx, 0)
+}
+
+// SetStorageAt - slot is quantity (Hardhat) or 32 bytes (Anvil), value is 32 bytes
+func (api *AnvilAPI) SetStorageAt(ctx context.Context, addr common.Address, slot string, value hexutil.Bytes) (bool, error) {
+	key, err := parseSlot(slot)
+	if err != nil {
+		return false, err
+	}
+	if len(value) > 32 {
+		return false, errors.New("storage value longer than 32 bytes")
+	}
+	p := patch{addr: addr, slot: &key}
+	p.value.SetBytes(value)
+	api.c.addPatch(p)
+	if err := api.c.Mine(ctx, 0); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func parseSlot(s string) (common.Hash, error) {
+	s = strings.TrimPrefix(s, "0x")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) > 32 {
+		return common.Hash{}, fmt.Errorf("invalid storage slot: %q", s)
+	}
+	return common.BytesToHash(b), nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package devmode - control of block production of dev chain (--chain=dev) by test frameworks (Foundry, Hardhat):
+// automine, interval mining, mining on demand, time manipulation and state cheat-codes.
+// Compatible with evm_*, anvil_* and hardhat_* RPC methods.
+package devmode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/execution/consensus/clique"
+)
+
+// mineTimeout - how long evm_mine and cheat-codes wait for mined block
+const mineTimeout = 30 * time.Second
+
+// patch - state change applied at beginning of block
+type patch struct {
+	addr    common.Address
+	balance *uint256.Int
+	nonce   *uint64
+	code    []byte // nil - not changed
+	slot    *common.Hash
+	value   uint256.Int
+}
+
+type Controller struct {
+	lock     sync.Mutex
+	offset   time.Duration // evm_increaseTime
+	nextTime uint64        // evm_setNextBlockTimestamp, 0 - not set
+	automine bool
+
+	head      uint64
+	headCh    chan struct{} // closed and replaced on every new head
+	requested int           // blocks requested by evm_mine (or interval mining) and not mined yet
+
+	pending []patch
+	patches map[uint64][]patch // block number -> patches bound to it (applied again on re-execution)
+
+	mineCh     chan struct{}
+	intervalCh chan time.Duration
+	logger     log.Logger
+}
+
+var _ clique.DevHooks = (*Controller)(nil)
+
+func New(logger log.Logger) *Controller {
+	return &Controller{
+		automine:   true,
+		headCh:     make(chan struct{}),
+		patches:    map[uint64][]patch{},
+		mineCh:     make(chan struct{}, 1),
+		intervalCh: make(chan time.Duration, 1),
+		logger:     logger,
+	}
+}
+
+// Run - interval mining loop
+func (c *Controller) Run(ctx context.Context) {
+	var ticker *time.Ticker
+	var tick <-chan time.Time
+	stop := func() {
+		if ticker != nil {
+			ticker.Stop()
+			ticker, tick = nil, nil
+		}
+	}
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case interval := <-c.intervalCh:
+			stop()
+			if interval > 0 {
+				ticker = time.NewTicker(interval)
+				tick = ticker.C
+			}
+			c.logger.Info("[dev] interval mining", "interval", interval)
+		case <-tick:
+			c.requestBlock()
+		}
+	}
+}
+
+// MineCh - miner must build block when it receives from this channel
+func (c *Controller) MineCh() <-chan struct{} { return c.mineCh }
+
+// Automine - whether miner must build block when new transactions arrive
+func (c *Controller) Automine() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.automine
+}
+
+// OnNewHead - must be called by miner on every new canonical head
+func (c *Controller) OnNewHead(number uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if number > c.head {
+		c.nextTime = 0
+		if c.requested > 0 {
+			c.requested--
+		}
+	}
+	c.head = number
+	close(c.headCh)
+	c.headCh = make(chan struct{})
+	if c.requested > 0 {
+		c.signal()
+	}
+}
+
+func (c *Controller) signal() {
+	select {
+	case c.mineCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Controller) requestBlock() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.requested++
+	c.signal()
+}
+
+func (c *Controller) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return time.Now().Add(c.offset)
+}
+
+func (c *Controller) NextBlockTime() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.nextTime
+}
+
+func (c *Controller) SealEmpty() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.requested > 0
+}
+
+// ApplyStatePatches - pending patches are bound to first block built on top of current head. Same patches
+// are applied on every (re-)execution of that block - so block producer and block execution see same state.
+func (c *Controller) ApplyStatePatches(header *types.Header, ibs *state.IntraBlockState) error {
+	c.lock.Lock()
+	number := header.Number.Uint64()
+	patches, ok := c.patches[number]
+	if !ok && len(c.pending) > 0 && number == c.head+1 {
+		patches, c.pending = c.pending, nil
+		c.patches[number] = patches
+	}
+	c.lock.Unlock()
+
+	for _, p := range patches {
+		if p.balance != nil {
+			if err := ibs.SetBalance(p.addr, p.balance, tracing.BalanceChangeUnspecified); err != nil {
+				return err
+			}
+		}
+		if p.nonce != nil {
+			if err := ibs.SetNonce(p.addr, *p.nonce); err != nil {
+				return err
+			}
+		}
+		if p.code != nil {
+			if err := ibs.SetCode(p.addr, p.code); err != nil {
+				return err
+			}
+		}
+		if p.slot != nil {
+			if err := ibs.SetState(p.addr, p.slot, p.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Mine - requests block and waits until it's mined. timestamp: exact time of block, 0 - not set
+func (c *Controller) Mine(ctx context.Context, timestamp uint64) error {
+	c.lock.Lock()
+	if timestamp != 0 {
+		c.setNextBlockTimestamp(timestamp)
+	}
+	target, headCh := c.head+1, c.headCh
+	c.requested++
+	c.signal()
+	c.lock.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, mineTimeout)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("block %d is not mined: %w", target, ctx.Err())
+		case <-headCh:
+		}
+		c.lock.Lock()
+		head := c.head
+		headCh = c.headCh
+		c.lock.Unlock()
+		if head >= target {
+			return nil
+		}
+	}
+}
+
+func (c *Controller) SetAutomine(enabled bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.automine = enabled
+}
+
+// SetInterval - 0 disables interval mining
+func (c *Controller) SetInterval(interval time.Duration) {
+	select {
+	case <-c.intervalCh: // replace value which is not consumed yet
+	default:
+	}
+	c.intervalCh <- interval
+}
+
+// IncreaseTime - shifts clock forward, returns total shift
+func (c *Controller) IncreaseTime(d time.Duration) time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.offset += d
+	return c.offset
+}
+
+func (c *Controller) SetNextBlockTimestamp(timestamp uint64) error {
+	if timestamp == 0 {
+		return errors.New("timestamp must be positive")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.setNextBlockTimestamp(timestamp)
+	return nil
+}
+
+// setNextBlockTimestamp - also shifts clock, so following blocks continue from timestamp
+func (c *Controller) setNextBlockTimestamp(timestamp uint64) {
+	c.nextTime = timestamp
+	c.offset = time.Until(time.Unix(int64(timestamp), 0))
+}
+
+// addPatch - state change is applied at beginning of next mined block
+func (c *Controller) addPatch(p patch) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pending = append(c.pending, p)
+}
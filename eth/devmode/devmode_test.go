@@ -0,0 +1,129 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devmode
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+)
+
+type emptyReader struct{}
+
+func (emptyReader) ReadAccountData(common.Address) (*accounts.Account, error) { return nil, nil }
+func (emptyReader) ReadAccountDataForDebug(common.Address) (*accounts.Account, error) {
+	return nil, nil
+}
+func (emptyReader) ReadAccountStorage(common.Address, *common.Hash) ([]byte, error) { return nil, nil }
+func (emptyReader) ReadAccountCode(common.Address) ([]byte, error)                  { return nil, nil }
+func (emptyReader) ReadAccountCodeSize(common.Address) (int, error)                 { return 0, nil }
+func (emptyReader) ReadAccountIncarnation(common.Address) (uint64, error)           { return 0, nil }
+
+func TestMine(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	c := New(log.New())
+	c.OnNewHead(10)
+	require.False(c.SealEmpty())
+
+	done := make(chan error, 1)
+	go func() { done <- c.Mine(ctx, 2_000_000_000) }()
+	<-c.MineCh() // miner is notified
+	require.Eventually(c.SealEmpty, time.Second, time.Millisecond)
+	require.Equal(uint64(2_000_000_000), c.NextBlockTime())
+	require.InDelta(2_000_000_000, c.Now().Unix(), 1)
+
+	c.OnNewHead(10) // same head - not mined yet
+	select {
+	case <-done:
+		t.Fatal("mined too early")
+	case <-time.After(10 * time.Millisecond):
+	}
+	c.OnNewHead(11)
+	require.NoError(<-done)
+	require.False(c.SealEmpty())
+	require.Zero(c.NextBlockTime())
+	require.InDelta(2_000_000_000, c.Now().Unix(), 1) // clock continues from pinned timestamp
+}
+
+func TestStatePatches(t *testing.T) {
+	require := require.New(t)
+	c := New(log.New())
+	c.OnNewHead(10)
+	addr := common.Address{1}
+	nonce := uint64(5)
+	c.addPatch(patch{addr: addr, balance: uint256.NewInt(100), nonce: &nonce})
+	c.addPatch(patch{addr: addr, code: []byte{0x60, 0x00}, slot: &common.Hash{1}, value: *uint256.NewInt(7)})
+
+	apply := func(number int64) *state.IntraBlockState {
+		ibs := state.New(emptyReader{})
+		require.NoError(c.ApplyStatePatches(&types.Header{Number: big.NewInt(number)}, ibs))
+		return ibs
+	}
+	// not bound to other blocks
+	ibs := apply(12)
+	balance, err := ibs.GetBalance(addr)
+	require.NoError(err)
+	require.True(balance.IsZero())
+
+	// bound to next block, and applied again on re-execution
+	for i := 0; i < 2; i++ {
+		ibs = apply(11)
+		balance, err = ibs.GetBalance(addr)
+		require.NoError(err)
+		require.Equal(uint64(100), balance.Uint64())
+		n, err := ibs.GetNonce(addr)
+		require.NoError(err)
+		require.Equal(uint64(5), n)
+		code, err := ibs.GetCode(addr)
+		require.NoError(err)
+		require.Equal([]byte{0x60, 0x00}, code)
+		var v uint256.Int
+		require.NoError(ibs.GetState(addr, &common.Hash{1}, &v))
+		require.Equal(uint64(7), v.Uint64())
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	require := require.New(t)
+	for _, in := range []string{`3600`, `"0xe10"`, `"3600"`} {
+		var q Quantity
+		require.NoError(json.Unmarshal([]byte(in), &q), in)
+		require.Equal(Quantity(3600), q, in)
+	}
+	var q Quantity
+	require.Error(json.Unmarshal([]byte(`"x"`), &q))
+
+	slot, err := parseSlot("0x1")
+	require.NoError(err)
+	require.Equal(common.Hash{31: 1}, slot)
+	slot, err = parseSlot("0x0000000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(err)
+	require.Equal(common.Hash{31: 2}, slot)
+	_, err = parseSlot("0x" + common.Bytes2Hex(make([]byte, 33)))
+	require.Error(err)
+}
@@ -143,6 +143,8 @@ type BlocksFreezing struct {
 	DisableDownloadE3 bool // disable download state snapshots
 	DownloaderAddr    string
 	ChainName         string
+
+	MmapBudget datasize.ByteSize // limit of page cache resident bytes of accessor indexes, 0 - no limit
 }
 
 func (s BlocksFreezing) String() string {
@@ -240,10 +242,17 @@ type Config struct {
 
 	// Ethstats service
 	Ethstats string
+
+	// Streaming of chain events to Kafka or NATS (see turbo/exex/sink)
+	StreamURL         string
+	StreamFormat      string
+	StreamTopicPrefix string
+	StreamFromBlock   uint64
 	// Consensus layer
 	InternalCL bool
 
 	OverridePragueTime *big.Int `toml:",omitempty"`
+	ForkOverridesFile  string   // see core.ForkOverrides
 
 	// Embedded Silkworm support
 	SilkwormExecution            bool
@@ -287,4 +296,13 @@ type Sync struct {
 	AlwaysGenerateChangesets bool
 	KeepExecutionProofs      bool
 	PersistReceiptsCacheV2   bool
+	AddressAppearances       bool // maintain address -> txNums index of all appearances (erigon_getAddressAppearances)
+	AccessLists              bool // record EIP-2930 access list of every executed txn (erigon_getTransactionAccessList)
+	WithdrawalsIndex         bool // maintain withdrawal address -> txNums index (erigon_getWithdrawalsByAddress)
+	HeadersMMR               bool // maintain Merkle Mountain Range over frozen headers (erigon_getHeaderProof)
+	HistoryExpiry            bool // EIP-4444: drop transactions of pre-merge blocks exported to era1 files
+	ExecPrefetch             bool // concurrently read state of upcoming blocks before their execution (--exec.prefetch)
+
+	MaxReorgDepth uint64 // forkchoice reorgs deeper than this are refused until admin_allowReorg, 0 - no limit
+	ReorgAlertURL string // deep reorgs are POSTed here as JSON
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon/execution/consensus/ethash"
 	"github.com/erigontech/erigon/execution/consensus/ethash/ethashcfg"
 	"github.com/erigontech/erigon/execution/consensus/merge"
+	"github.com/erigontech/erigon/execution/consensus/optimism"
 	"github.com/erigontech/erigon/node"
 	"github.com/erigontech/erigon/node/nodecfg"
 	"github.com/erigontech/erigon/params"
@@ -134,6 +135,9 @@ func CreateConsensusEngine(ctx context.Context, nodeConfig *nodecfg.Config, chai
 		panic("unknown config" + spew.Sdump(config))
 	}
 
+	if chainConfig.Optimism != nil {
+		return optimism.New(chainConfig, eng) // OP Stack rollup, post-Merge from genesis
+	}
 	if chainConfig.TerminalTotalDifficulty == nil {
 		return eng
 	} else {
@@ -68,7 +68,9 @@ func MarshalReceipt(
 		"logsBloom":         types.CreateBloom(types.Receipts{receipt}),
 	}
 
-	if !chainConfig.IsLondon(header.Number.Uint64()) {
+	if txn.Type() == types.DepositTxType {
+		fields["effectiveGasPrice"] = (*hexutil.Big)(new(big.Int))
+	} else if !chainConfig.IsLondon(header.Number.Uint64()) {
 		fields["effectiveGasPrice"] = (*hexutil.Big)(txn.GetTipCap().ToBig())
 	} else {
 		baseFee, _ := uint256.FromBig(header.BaseFee)
@@ -87,6 +89,14 @@ func MarshalReceipt(
 		fields["contractAddress"] = receipt.ContractAddress
 	}
 
+	// OP Stack deposit receipts
+	if receipt.DepositNonce != nil {
+		fields["depositNonce"] = hexutil.Uint64(*receipt.DepositNonce)
+	}
+	if receipt.DepositReceiptVersion != nil {
+		fields["depositReceiptVersion"] = hexutil.Uint64(*receipt.DepositReceiptVersion)
+	}
+
 	// Set derived blob related fields
 	numBlobs := len(txn.GetBlobHashes())
 	if numBlobs > 0 {
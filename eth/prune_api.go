@@ -0,0 +1,85 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv/audit"
+	"github.com/erigontech/erigon-lib/kv/prune"
+	"github.com/erigontech/erigon/rpc"
+)
+
+var errPruneDistanceArchive = errors.New("node doesn't prune (--prune.mode=archive), pruning can't be enabled at runtime")
+
+// PruneAPI - admin_setPruneDistance. Served only by node's own rpc: standalone rpcdaemon doesn't prune.
+type PruneAPI struct {
+	s *Ethereum
+}
+
+func (s *Ethereum) pruneAPIs() []rpc.API {
+	return []rpc.API{{Namespace: "admin", Public: false, Service: &PruneAPI{s}, Version: "1.0"}}
+}
+
+// PruneDistance - distances of history and blocks pruning in effect, omitted if data is not pruned
+type PruneDistance struct {
+	Mode    string          `json:"mode"`
+	History *hexutil.Uint64 `json:"history,omitempty"`
+	Blocks  *hexutil.Uint64 `json:"blocks,omitempty"`
+	Runtime bool            `json:"runtime"` // distances were changed by admin_setPruneDistance
+}
+
+// SetPruneDistance - changes distance of enabled history and blocks pruning until restart, omitted or 0 - back to --prune.* value.
+// Already pruned data is not restored by increasing distance.
+func (api *PruneAPI) SetPruneDistance(ctx context.Context, history, blocks *hexutil.Uint64) (_ *PruneDistance, err error) {
+	var h, b uint64
+	if history != nil {
+		h = uint64(*history)
+	}
+	if blocks != nil {
+		b = uint64(*blocks)
+	}
+	ctx = audit.WithOrigin(ctx, rpc.PeerInfoFromContext(ctx).Origin())
+	defer func() {
+		audit.Record(ctx, api.s.chainDB, audit.OpRetention, map[string]any{"runtime.prune.distance": h, "runtime.prune.distance.blocks": b}, err, api.s.logger)
+	}()
+	configured := api.s.config.Prune
+	if !configured.History.Enabled() && !configured.Blocks.Enabled() {
+		return nil, errPruneDistanceArchive
+	}
+	if err := prune.SetRuntimeDistance(h, b); err != nil {
+		return nil, err
+	}
+	return api.PruneDistance(), nil
+}
+
+// PruneDistance - distances of pruning in effect
+func (api *PruneAPI) PruneDistance() *PruneDistance {
+	configured := api.s.config.Prune
+	history, blocks := prune.RuntimeDistance()
+	mode := configured.Runtime()
+	res := &PruneDistance{Mode: configured.String(), Runtime: history != 0 || blocks != 0}
+	if d, ok := mode.History.(prune.Distance); ok && d.Enabled() {
+		res.History = (*hexutil.Uint64)(&d)
+	}
+	if d, ok := mode.Blocks.(prune.Distance); ok && d.Enabled() {
+		res.Blocks = (*hexutil.Uint64)(&d)
+	}
+	return res
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	// prefetchWorkersPerCPU - concurrent reads of --exec.prefetch per GOMAXPROCS. Execution reads state one key
+	// at a time: queue depth 1. NVMe drives serve many times more IOPS at deep queue depths, workers mostly wait for IO.
+	prefetchWorkersPerCPU = 2
+	// prefetchTxMaxAge - prefetch worker re-opens its read-only tx at least this often: not to hold old
+	// db snapshot and state files, which blocks pages reuse and files deletion by prune and merge
+	prefetchTxMaxAge = time.Second
+	// prefetchBlocksAhead - prefetch state of block N+prefetchBlocksAhead while block N is executing
+	prefetchBlocksAhead = 2
+	// prefetchMappingSlots - positions of solidity mappings (ERC-20 balances, allowances, ...) which are prefetched
+	// for address arguments of calldata: keccak256(address . position)
+	prefetchMappingSlots = 4
+)
+
+func prefetchWorkers() int { return runtime.GOMAXPROCS(-1) * prefetchWorkersPerCPU }
+
+type prefetchKey struct {
+	domain kv.Domain
+	key    []byte
+}
+
+// blockPrefetchKeys - keys of state which execution of the block will likely read: accounts of coinbase,
+// senders and receivers, code of receivers and storage of receivers at mapping slots of calldata address arguments
+// and of sender
+func blockPrefetchKeys(block *types.Block, senders []common.Address) []prefetchKey {
+	seen := map[string]struct{}{}
+	var keys []prefetchKey
+	add := func(domain kv.Domain, key []byte) {
+		k := string([]byte{byte(domain)}) + string(key)
+		if _, ok := seen[k]; ok {
+			return
+		}
+		seen[k] = struct{}{}
+		keys = append(keys, prefetchKey{domain: domain, key: key})
+	}
+
+	add(kv.AccountsDomain, block.Coinbase().Bytes())
+	for i, txn := range block.Transactions() {
+		sender, hasSender := txn.GetSender()
+		if i < len(senders) {
+			sender, hasSender = senders[i], true
+		}
+		if hasSender {
+			add(kv.AccountsDomain, sender.Bytes())
+		}
+		to := txn.GetTo()
+		if to == nil {
+			continue
+		}
+		add(kv.AccountsDomain, to.Bytes())
+		add(kv.CodeDomain, to.Bytes())
+
+		args := calldataAddresses(txn.GetData())
+		if hasSender {
+			args = append(args, sender)
+		}
+		for _, arg := range args {
+			for _, slot := range mappingSlots(arg) {
+				add(kv.StorageDomain, append(to.Bytes(), slot[:]...))
+			}
+		}
+	}
+	return keys
+}
+
+// calldataAddresses - ABI-encoded address arguments of calldata: 32-bytes words with 12 leading zero bytes.
+// Small integers (amounts, indices, flags) are padded the same way, so words with address starting from two zero bytes are skipped
+func calldataAddresses(data []byte) []common.Address {
+	if len(data) < 4 {
+		return nil
+	}
+	var res []common.Address
+	for args := data[4:]; len(args) >= 32; args = args[32:] {
+		word := args[:32]
+		if slices.ContainsFunc(word[:32-length.Addr], func(b byte) bool { return b != 0 }) {
+			continue
+		}
+		addr := common.BytesToAddress(word[32-length.Addr:])
+		if addr[0] == 0 && addr[1] == 0 {
+			continue
+		}
+		res = append(res, addr)
+	}
+	return res
+}
+
+// mappingSlots - storage slots of `addr` key in solidity mappings at first positions of contract storage layout
+func mappingSlots(addr common.Address) [prefetchMappingSlots]common.Hash {
+	var res [prefetchMappingSlots]common.Hash
+	var buf [64]byte
+	copy(buf[32-length.Addr:32], addr.Bytes())
+	for i := range res {
+		buf[63] = byte(i)
+		res[i] = common.BytesToHash(crypto.Keccak256(buf[:]))
+	}
+	return res
+}
+
+// blockPrefetchFunc - sends keys of the block to prefetch workers. Keys are dropped if workers are busy: prefetch
+// must not lag behind execution
+func blockPrefetchFunc(ctx context.Context, tx kv.Tx, cfg *ExecuteBlockCfg, blockNum uint64, prefetch chan<- prefetchKey) error {
+	hash, ok, err := cfg.blockReader.CanonicalHash(ctx, tx, blockNum)
+	if err != nil || !ok {
+		return err
+	}
+	block, senders, err := cfg.blockReader.BlockWithSenders(ctx, tx, hash, blockNum)
+	if err != nil || block == nil {
+		return err
+	}
+	for _, k := range blockPrefetchKeys(block, senders) {
+		select {
+		case prefetch <- k:
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// prefetchStateLoop - reads keys from channel in own read-only tx: to warm page cache of domain files.
+// Values are not used: best-effort, execution reads state in own tx. The tx is closed when there is nothing
+// to prefetch and re-opened every prefetchTxMaxAge
+func prefetchStateLoop(ctx context.Context, db kv.TemporalRoDB, keys <-chan prefetchKey) error {
+	var tx kv.TemporalTx
+	var txStarted time.Time
+	closeTx := func() {
+		if tx != nil {
+			tx.Rollback()
+			tx = nil
+		}
+	}
+	defer closeTx()
+	for {
+		var k prefetchKey
+		var ok bool
+		select {
+		case k, ok = <-keys:
+		default:
+			closeTx() // idle: don't hold db snapshot while waiting for next block
+			select {
+			case k, ok = <-keys:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if !ok {
+			return nil
+		}
+
+		if tx != nil && time.Since(txStarted) > prefetchTxMaxAge {
+			closeTx()
+		}
+		if tx == nil {
+			var err error
+			if tx, err = db.BeginTemporalRo(ctx); err != nil {
+				return err
+			}
+			txStarted = time.Now()
+		}
+		if _, _, err := tx.GetLatest(k.domain, k.key); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestBlockPrefetchKeys(t *testing.T) {
+	require := require.New(t)
+	coinbase := common.HexToAddress("0xc0")
+	token := common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7")
+	sender := common.HexToAddress("0x5e")
+	recipient := common.HexToAddress("0x7e5f4552091a69125d5dfcb7b8c2659029395bdf")
+
+	// transfer(address,uint256)
+	calldata := common.FromHex("a9059cbb")
+	calldata = append(calldata, common.LeftPadBytes(recipient.Bytes(), 32)...)
+	calldata = append(calldata, common.LeftPadBytes([]byte{0xff, 0xff}, 32)...)
+	require.Equal([]common.Address{recipient}, calldataAddresses(calldata))
+	require.Empty(calldataAddresses(calldata[:3]))
+
+	slots := mappingSlots(recipient)
+	require.Equal(common.BytesToHash(crypto.Keccak256(common.LeftPadBytes(recipient.Bytes(), 32), common.LeftPadBytes([]byte{2}, 32))), slots[2])
+
+	transfer := types.NewTransaction(0, token, uint256.NewInt(0), 100_000, uint256.NewInt(1), calldata)
+	sameTokenAgain := types.NewTransaction(1, token, uint256.NewInt(0), 100_000, uint256.NewInt(1), calldata)
+	block := types.NewBlock(&types.Header{Coinbase: coinbase}, []types.Transaction{transfer, sameTokenAgain}, nil, nil, nil)
+
+	keys := blockPrefetchKeys(block, []common.Address{sender, sender})
+	require.Len(keys, 4+2*prefetchMappingSlots) // coinbase, sender, token account and code; slots of recipient and sender
+	require.Equal(prefetchKey{domain: kv.AccountsDomain, key: coinbase.Bytes()}, keys[0])
+	require.Equal(prefetchKey{domain: kv.AccountsDomain, key: sender.Bytes()}, keys[1])
+	require.Equal(prefetchKey{domain: kv.AccountsDomain, key: token.Bytes()}, keys[2])
+	require.Equal(prefetchKey{domain: kv.CodeDomain, key: token.Bytes()}, keys[3])
+	require.Equal(prefetchKey{domain: kv.StorageDomain, key: append(token.Bytes(), slots[0].Bytes()...)}, keys[4])
+	senderSlots := mappingSlots(sender)
+	require.Equal(prefetchKey{domain: kv.StorageDomain, key: append(token.Bytes(), senderSlots[0].Bytes()...)}, keys[4+prefetchMappingSlots])
+
+	// without senders: only what is known from txns
+	require.Len(blockPrefetchKeys(block, nil), 3+prefetchMappingSlots)
+}
+
+// openTxsDB - counts read-only txs opened and not yet closed
+type openTxsDB struct {
+	kv.TemporalRoDB
+	open atomic.Int32
+}
+
+type countedTx struct {
+	kv.TemporalTx
+	db *openTxsDB
+}
+
+func (tx *countedTx) Rollback() {
+	tx.db.open.Add(-1)
+	tx.TemporalTx.Rollback()
+}
+
+func (db *openTxsDB) BeginTemporalRo(ctx context.Context) (kv.TemporalTx, error) {
+	tx, err := db.TemporalRoDB.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db.open.Add(1)
+	return &countedTx{TemporalTx: tx, db: db}, nil
+}
+
+func TestPrefetchStateLoopReleasesTx(t *testing.T) {
+	db := &openTxsDB{TemporalRoDB: temporaltest.NewTestDB(t, datadir.New(t.TempDir()))}
+	keys := make(chan prefetchKey)
+	done := make(chan error, 1)
+	go func() { done <- prefetchStateLoop(context.Background(), db, keys) }()
+
+	// tx is open only while there are keys to prefetch
+	for i := 0; i < 10; i++ {
+		keys <- prefetchKey{domain: kv.AccountsDomain, key: common.HexToAddress("0x7e").Bytes()}
+	}
+	require.Eventually(t, func() bool { return db.open.Load() == 0 }, 5*time.Second, 10*time.Millisecond)
+
+	keys <- prefetchKey{domain: kv.AccountsDomain, key: common.HexToAddress("0x5e").Bytes()}
+	close(keys)
+	require.NoError(t, <-done)
+	require.Zero(t, db.open.Load())
+}
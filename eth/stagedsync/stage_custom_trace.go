@@ -55,10 +55,13 @@ type CustomTraceCfg struct {
 type Produce struct {
 	ReceiptDomain bool
 	RCacheDomain  bool
+	AccessLists   bool
 	LogAddr       bool
 	LogTopic      bool
 	TraceFrom     bool
 	TraceTo       bool
+	Appearances   bool
+	Withdrawals   bool
 }
 
 func NewProduce(produceList []string) Produce {
@@ -70,6 +73,8 @@ func NewProduce(produceList []string) Produce {
 			produce.ReceiptDomain = true
 		case kv.RCacheDomain.String():
 			produce.RCacheDomain = true
+		case kv.AccessListDomain.String():
+			produce.AccessLists = true
 		case kv.LogAddrIdx.String():
 			produce.LogAddr = true
 		case kv.LogTopicIdx.String():
@@ -78,6 +83,10 @@ func NewProduce(produceList []string) Produce {
 			produce.TraceFrom = true
 		case kv.TracesToIdx.String():
 			produce.TraceTo = true
+		case kv.AddrAppearanceIdx.String():
+			produce.Appearances = true
+		case kv.WithdrawalAddrIdx.String():
+			produce.Withdrawals = true
 		default:
 			panic(fmt.Errorf("assert: unknown Produce %#v", p))
 		}
@@ -95,10 +104,12 @@ func StageCustomTraceCfg(produce []string, db kv.TemporalRwDB, dirs datadir.Dirs
 		Genesis:     genesis,
 		Workers:     syncCfg.ExecWorkerCount,
 	}
+	p := NewProduce(produce)
+	execArgs.RecordAccessLists = p.AccessLists
 	return CustomTraceCfg{
 		db:       db,
 		ExecArgs: execArgs,
-		Produce:  NewProduce(produce),
+		Produce:  p,
 	}
 }
 
@@ -129,6 +140,9 @@ func SpawnCustomTrace(cfg CustomTraceCfg, ctx context.Context, logger log.Logger
 		if cfg.Produce.RCacheDomain {
 			txNum = min(txNum, ac.HistoryProgress(kv.RCacheDomain, tx))
 		}
+		if cfg.Produce.AccessLists {
+			txNum = min(txNum, ac.HistoryProgress(kv.AccessListDomain, tx))
+		}
 		if cfg.Produce.LogAddr {
 			txNum = min(txNum, ac.ProgressII(kv.LogAddrIdx, tx))
 		}
@@ -141,6 +155,12 @@ func SpawnCustomTrace(cfg CustomTraceCfg, ctx context.Context, logger log.Logger
 		if cfg.Produce.TraceTo {
 			txNum = min(txNum, ac.ProgressII(kv.TracesToIdx, tx))
 		}
+		if cfg.Produce.Appearances {
+			txNum = min(txNum, ac.ProgressII(kv.AddrAppearanceIdx, tx))
+		}
+		if cfg.Produce.Withdrawals {
+			txNum = min(txNum, ac.ProgressII(kv.WithdrawalAddrIdx, tx))
+		}
 		fromTxNum := txNum
 		var ok bool
 		ok, startBlock, err = txNumsReader.FindBlockNum(tx, fromTxNum)
@@ -165,6 +185,9 @@ func SpawnCustomTrace(cfg CustomTraceCfg, ctx context.Context, logger log.Logger
 	if cfg.Produce.RCacheDomain {
 		producingDomain = kv.RCacheDomain
 	}
+	if cfg.Produce.AccessLists {
+		producingDomain = kv.AccessListDomain
+	}
 
 	batchSize := uint64(50_000)
 	for ; startBlock < endBlock; startBlock += batchSize {
@@ -431,6 +454,12 @@ func customTraceBatch(ctx context.Context, produce Produce, cfg *exec3.ExecArgs,
 				}
 			}
 
+			if produce.AccessLists {
+				if err := rawdb.WriteTxAccessList(doms, txTask.AccessList); err != nil {
+					return err
+				}
+			}
+
 			if produce.LogAddr {
 				for _, lg := range txTask.Logs {
 					if err := doms.IndexAdd(kv.LogAddrIdx, lg.Address[:]); err != nil {
@@ -461,6 +490,20 @@ func customTraceBatch(ctx context.Context, produce Produce, cfg *exec3.ExecArgs,
 					}
 				}
 			}
+			if produce.Appearances {
+				for addr := range txTask.AddressAppearances() {
+					if err := doms.IndexAdd(kv.AddrAppearanceIdx, addr[:]); err != nil {
+						return err
+					}
+				}
+			}
+			if produce.Withdrawals {
+				for addr := range txTask.WithdrawalAddresses() {
+					if err := doms.IndexAdd(kv.WithdrawalAddrIdx, addr[:]); err != nil {
+						return err
+					}
+				}
+			}
 
 			select {
 			case <-logEvery.C:
@@ -268,6 +268,17 @@ func blocksReadAhead(ctx context.Context, cfg *ExecuteBlockCfg, workers int, his
 	const readAheadBlocks = 100
 	readAhead := make(chan uint64, readAheadBlocks)
 	g, gCtx := errgroup.WithContext(ctx)
+
+	// --exec.prefetch: readers of blocks also feed keys of upcoming blocks to prefetch workers
+	var prefetch chan prefetchKey
+	prefetchGroup, prefetchCtx := errgroup.WithContext(ctx)
+	if temporalDb, ok := cfg.db.(kv.TemporalRoDB); ok && cfg.syncCfg.ExecPrefetch {
+		prefetchWorkers := prefetchWorkers()
+		prefetch = make(chan prefetchKey, prefetchWorkers*64)
+		for i := 0; i < prefetchWorkers; i++ {
+			prefetchGroup.Go(func() error { return prefetchStateLoop(prefetchCtx, temporalDb, prefetch) })
+		}
+	}
 	for workerNum := 0; workerNum < workers; workerNum++ {
 		g.Go(func() (err error) {
 			var bn uint64
@@ -302,12 +313,21 @@ func blocksReadAhead(ctx context.Context, cfg *ExecuteBlockCfg, workers int, his
 				if err := blocksReadAheadFunc(gCtx, tx, cfg, bn+readAheadBlocks, histV3); err != nil {
 					return err
 				}
+				if prefetch != nil {
+					if err := blockPrefetchFunc(gCtx, tx, cfg, bn+prefetchBlocksAhead, prefetch); err != nil {
+						return err
+					}
+				}
 			}
 		})
 	}
 	return readAhead, func() {
 		close(readAhead)
 		_ = g.Wait()
+		if prefetch != nil {
+			close(prefetch)
+			_ = prefetchGroup.Wait()
+		}
 	}
 }
 func blocksReadAheadFunc(ctx context.Context, tx kv.Tx, cfg *ExecuteBlockCfg, blockNum uint64, histV3 bool) error {
@@ -426,7 +446,8 @@ func PruneExecutionStage(s *PruneState, tx kv.RwTx, cfg ExecuteBlockCfg, ctx con
 		}
 	}
 
-	mxExecStepsInDB.Set(rawdbhelpers.IdxStepsCountV3(tx) * 100)
+	stepSize := tx.(libstate.HasAggTx).AggTx().(*libstate.AggregatorRoTx).StepSize()
+	mxExecStepsInDB.Set(rawdbhelpers.IdxStepsCountV3(tx, stepSize) * 100)
 
 	// on chain-tip:
 	//  - can prune only between blocks (without blocking blocks processing)
@@ -430,6 +430,9 @@ func HeadersUnwind(ctx context.Context, u *UnwindState, s *StageState, tx kv.RwT
 		badBlock = u.Reason.IsBadBlock()
 		if badBlock {
 			cfg.hd.ReportBadHeader(*u.Reason.Block)
+			if err := rawdb.WriteBadBlock(tx, *u.Reason.Block, u.Reason.Err); err != nil {
+				return err
+			}
 		}
 
 		cfg.hd.UnlinkHeader(*u.Reason.Block)
@@ -51,6 +51,8 @@ type MiningBlock struct {
 	Withdrawals      []*types.Withdrawal
 	PreparedTxns     types.Transactions
 	Requests         types.FlatRequests
+	ForcedTxns       types.Transactions // OP Stack: included before txpool ones, block can't be built without them
+	NoTxPool         bool               // OP Stack: block contains only forced transactions
 }
 
 type MiningState struct {
@@ -184,7 +186,10 @@ func SpawnMiningCreateBlockStage(s *StageState, txc wrap.TxContainer, cfg Mining
 	}
 
 	header := core.MakeEmptyHeader(parent, &cfg.chainConfig, timestamp, &cfg.miner.MiningConfig.GasLimit)
-	if err := misc.VerifyGaslimit(parent.GasLimit, header.GasLimit); err != nil {
+	if cfg.blockBuilderParameters != nil && cfg.blockBuilderParameters.GasLimit != nil {
+		// OP Stack: gas limit is set by rollup node, it isn't bounded by parent one
+		header.GasLimit = *cfg.blockBuilderParameters.GasLimit
+	} else if err := misc.VerifyGaslimit(parent.GasLimit, header.GasLimit); err != nil {
 		logger.Warn("Failed to verify gas limit given by the validator, defaulting to parent gas limit", "err", err)
 		header.GasLimit = parent.GasLimit
 	}
@@ -215,6 +220,13 @@ func SpawnMiningCreateBlockStage(s *StageState, txc wrap.TxContainer, cfg Mining
 		current.Header = header
 		current.Uncles = nil
 		current.Withdrawals = cfg.blockBuilderParameters.Withdrawals
+		current.NoTxPool = cfg.blockBuilderParameters.NoTxPool
+		current.ForcedTxns = make(types.Transactions, len(cfg.blockBuilderParameters.Transactions))
+		for i, txn := range cfg.blockBuilderParameters.Transactions {
+			if current.ForcedTxns[i], err = types.DecodeTransaction(txn); err != nil {
+				return fmt.Errorf("forced transaction %d: %w", i, err)
+			}
+		}
 		return nil
 	}
 
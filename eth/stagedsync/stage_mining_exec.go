@@ -44,6 +44,7 @@ import (
 	"github.com/erigontech/erigon/core/vm/evmtypes"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
 	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/consensus/clique"
 	"github.com/erigontech/erigon/polygon/aa"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/txnprovider"
@@ -109,6 +110,16 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 	// Clique consensus needs forced author in the evm context
 	//if cfg.chainConfig.Consensus == chain.CliqueConsensus {
 	//	execCfg.author = &cfg.miningState.MiningConfig.Etherbase
+
+	// dev mode cheat-codes change state at beginning of block: transactions must see same state as at block execution
+	if c, ok := cfg.engine.(*clique.Clique); ok {
+		if err := c.ApplyDevStatePatches(current.Header, ibs); err != nil {
+			return err
+		}
+		if err := ibs.FinalizeTx(cfg.chainConfig.Rules(current.Header.Number.Uint64(), current.Header.Time), state.NewNoopWriter()); err != nil {
+			return err
+		}
+	}
 	//}
 	execCfg.author = &cfg.miningState.MiningConfig.Etherbase
 
@@ -123,13 +134,25 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 		return header
 	}
 
+	if len(current.ForcedTxns) > 0 {
+		// not interrupted: block is invalid without any of forced transactions
+		logs, _, err := addTransactionsToMiningBlock(ctx, logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, current.ForcedTxns, cfg.miningState.MiningConfig.Etherbase, ibs, nil, cfg.payloadId, logger)
+		if err != nil {
+			return err
+		}
+		if len(current.Txns) != len(current.ForcedTxns) {
+			return fmt.Errorf("[%s] included %d of %d forced transactions", logPrefix, len(current.Txns), len(current.ForcedTxns))
+		}
+		NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+	}
+
 	if len(preparedTxns) > 0 {
 		logs, _, err := addTransactionsToMiningBlock(ctx, logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, preparedTxns, cfg.miningState.MiningConfig.Etherbase, ibs, cfg.interrupt, cfg.payloadId, logger)
 		if err != nil {
 			return err
 		}
 		NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
-	} else {
+	} else if !current.NoTxPool {
 
 		yielded := mapset.NewSet[[32]byte]()
 		var simStateReader state.StateReader
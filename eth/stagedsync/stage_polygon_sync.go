@@ -156,6 +156,7 @@ func NewPolygonSyncStageCfg(
 		notifications,
 		sync.NewWiggleCalculator(borConfig, signaturesCache, heimdallService),
 		engineAPISwitcher,
+		nil, // bor_subscribe("consensusEvents") is served by polygon sync service only
 	)
 	syncService := &polygonSyncStageService{
 		logger:          logger,
@@ -247,6 +248,11 @@ func UnwindPolygonSyncStage(ctx context.Context, tx kv.RwTx, u *UnwindState, cfg
 
 	// headers
 	badBlock := u.Reason.IsBadBlock()
+	if badBlock && u.Reason.Block != nil {
+		if err := rawdb.WriteBadBlock(tx, *u.Reason.Block, u.Reason.Err); err != nil {
+			return err
+		}
+	}
 	if err := rawdb.TruncateCanonicalHash(tx, u.UnwindPoint+1, badBlock); err != nil {
 		return err
 	}
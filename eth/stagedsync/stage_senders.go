@@ -146,6 +146,7 @@ func SpawnRecoverSendersStage(cfg SendersCfg, s *StageState, u Unwinder, tx kv.R
 		defer cancelWorkers()
 		var ok bool
 		var j *senderRecoveryJob
+		var txns, cached int
 		for {
 			select {
 			case <-quitCh:
@@ -155,7 +156,7 @@ func SpawnRecoverSendersStage(cfg SendersCfg, s *StageState, u Unwinder, tx kv.R
 				if j != nil {
 					n += uint64(j.index)
 				}
-				logger.Info(fmt.Sprintf("[%s] Recovery", logPrefix), "block_number", n, "ch", fmt.Sprintf("%d/%d", len(jobs), cap(jobs)))
+				logger.Info(fmt.Sprintf("[%s] Recovery", logPrefix), "block_number", n, "ch", fmt.Sprintf("%d/%d", len(jobs), cap(jobs)), "txns", txns, "recovered_by_txpool", cached)
 			case j, ok = <-out:
 				if !ok {
 					return
@@ -164,6 +165,8 @@ func SpawnRecoverSendersStage(cfg SendersCfg, s *StageState, u Unwinder, tx kv.R
 					errCh <- senderRecoveryError{err: j.err, blockNumber: j.blockNumber, blockHash: j.blockHash}
 					return
 				}
+				txns += len(j.senders) / length.Addr
+				cached += j.cached
 
 				k := make([]byte, 4)
 				binary.BigEndian.PutUint32(k, uint32(j.index))
@@ -326,6 +329,7 @@ type senderRecoveryJob struct {
 	blockNumber uint64
 	blockTime   uint64
 	index       int
+	cached      int // senders recovered by txpool
 	err         error
 }
 
@@ -351,12 +355,16 @@ func recoverSenders(ctx context.Context, logPrefix string, cryptoContext *secp25
 		signer := types.MakeSigner(config, job.blockNumber, job.blockTime)
 		job.senders = make([]byte, len(body.Transactions)*length.Addr)
 		for i, txn := range body.Transactions {
-			from, err := signer.SenderWithContext(cryptoContext, txn)
+			// txpool already recovered senders of most txns of new blocks
+			from, cached, err := signer.SenderWithCache(cryptoContext, txn)
 			if err != nil {
 				job.err = fmt.Errorf("%w: error recovering sender for tx=%x, %v",
 					consensus.ErrInvalidBlock, txn.Hash(), err)
 				break
 			}
+			if cached {
+				job.cached++
+			}
 			copy(job.senders[i*length.Addr:], from[:])
 		}
 
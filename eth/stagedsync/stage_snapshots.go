@@ -66,6 +66,7 @@ import (
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
 	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/era1"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/shards"
 	"github.com/erigontech/erigon/turbo/silkworm"
@@ -298,6 +299,7 @@ func DownloadAndIndexSnapshotsIfNeed(s *StageState, ctx context.Context, tx kv.R
 	if err := cfg.blockRetire.BuildMissedIndicesIfNeed(ctx, s.LogPrefix(), cfg.notifier.Events, &cfg.chainConfig); err != nil {
 		return err
 	}
+	cfg.blockRetire.ReindexInBackground(ctx, &cfg.chainConfig)
 
 	indexWorkers := estimate.IndexSnapshot.Workers()
 	diagnostics.Send(diagnostics.CurrentSyncSubStage{SubStage: "E3 Indexing"})
@@ -615,6 +617,11 @@ func SnapshotsPrune(s *PruneState, cfg SnapshotsCfg, ctx context.Context, tx kv.
 			return nil
 		}, func() error {
 			filesDeleted, err := pruneBlockSnapshots(ctx, cfg, logger)
+			if err == nil && cfg.syncConfig.HistoryExpiry {
+				var expired bool
+				expired, err = expirePreMergeBlocks(ctx, cfg, logger)
+				filesDeleted = filesDeleted || expired
+			}
 			if filesDeleted && cfg.notifier != nil {
 				cfg.notifier.Events.OnNewSnapshot()
 			}
@@ -679,13 +686,14 @@ func pruneBlockSnapshots(ctx context.Context, cfg SnapshotsCfg, logger log.Logge
 	if err != nil {
 		return false, err
 	}
+	pruneBlocks := cfg.prune.Runtime().Blocks
 	// If we are behind the execution stage, we should not prune snapshots
-	if headNumber > executionProgress || !cfg.prune.Blocks.Enabled() {
+	if headNumber > executionProgress || !pruneBlocks.Enabled() {
 		return false, nil
 	}
 
 	// Keep at least 2 block snapshots as we do not want FrozenBlocks to be 0
-	pruneTo := cfg.prune.Blocks.PruneTo(headNumber)
+	pruneTo := pruneBlocks.PruneTo(headNumber)
 
 	if pruneTo > executionProgress {
 		return false, nil
@@ -695,7 +703,7 @@ func pruneBlockSnapshots(ctx context.Context, cfg SnapshotsCfg, logger log.Logge
 	filesDeleted := false
 	// Prune blocks snapshots if necessary
 	for _, file := range snapshotFileNames {
-		if !cfg.prune.Blocks.Enabled() || headNumber == 0 || !strings.Contains(file, "transactions") {
+		if !pruneBlocks.Enabled() || headNumber == 0 || !strings.Contains(file, "transactions") {
 			continue
 		}
 
@@ -723,6 +731,80 @@ func pruneBlockSnapshots(ctx context.Context, cfg SnapshotsCfg, logger log.Logge
 	return filesDeleted, nil
 }
 
+// expirePreMergeBlocks - EIP-4444: removes transactions files of pre-merge blocks which are in era1 files
+func expirePreMergeBlocks(ctx context.Context, cfg SnapshotsCfg, logger log.Logger) (bool, error) {
+	tx, err := cfg.db.BeginRo(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	frozen := cfg.blockReader.FrozenBlocks()
+	if frozen == 0 {
+		return false, nil
+	}
+	mergeBlock, ok, err := era1.MergeBlock(ctx, tx, cfg.blockReader, &cfg.chainConfig, frozen-1)
+	if err != nil || !ok {
+		return false, err
+	}
+	store, err := era1.OpenStore(era1.Dir(cfg.dirs))
+	if err != nil {
+		return false, err
+	}
+	defer store.Close()
+	covered, err := store.Covered()
+	if err != nil {
+		return false, err
+	}
+	expireTo := min(mergeBlock, covered)
+
+	filesDeleted := false
+	for _, file := range cfg.blockReader.FrozenFiles() {
+		if !strings.Contains(file, "transactions") {
+			continue
+		}
+		info, _, ok := snaptype.ParseFileName(cfg.dirs.Snap, file)
+		if !ok || info.To > expireTo {
+			continue
+		}
+		// era1 files are made by other tool (or other node) - make sure they have our chain
+		for _, num := range []uint64{info.From, info.To - 1} {
+			if err := checkEra1Block(ctx, tx, cfg.blockReader, store, num); err != nil {
+				logger.Warn("[snapshots] can't expire pre-merge blocks", "file", file, "err", err)
+				return filesDeleted, nil
+			}
+		}
+		if cfg.snapshotDownloader != nil && !reflect.ValueOf(cfg.snapshotDownloader).IsNil() {
+			if _, err := cfg.snapshotDownloader.Delete(ctx, &protodownloader.DeleteRequest{Paths: []string{file}}); err != nil {
+				return filesDeleted, err
+			}
+		}
+		if err := cfg.blockReader.Snapshots().Delete(file); err != nil {
+			return filesDeleted, err
+		}
+		filesDeleted = true
+		logger.Info("[snapshots] expired pre-merge blocks", "file", file)
+	}
+	return filesDeleted, nil
+}
+
+func checkEra1Block(ctx context.Context, tx kv.Tx, blockReader services.FullBlockReader, store *era1.Store, num uint64) error {
+	hash, ok, err := blockReader.CanonicalHash(ctx, tx, num)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("canonical hash of block %d not found", num)
+	}
+	block, err := store.Block(num)
+	if err != nil {
+		return err
+	}
+	if block == nil || block.Hash() != hash {
+		return fmt.Errorf("era1 files have no canonical block %d", num)
+	}
+	return nil
+}
+
 type uploadState struct {
 	sync.Mutex
 	file             string
@@ -1247,7 +1329,8 @@ func (u *snapshotUploader) removeBefore(before uint64) {
 		if f.To > before {
 			switch f.Type.Enum() {
 			case heimdall.Enums.Events, heimdall.Enums.Spans,
-				heimdall.Enums.Checkpoints, heimdall.Enums.Milestones:
+				heimdall.Enums.Checkpoints, heimdall.Enums.Milestones,
+				heimdall.Enums.ProducerSelections:
 				borToReopen = append(borToReopen, filepath.Base(f.Path))
 			default:
 				toReopen = append(toReopen, filepath.Base(f.Path))
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/huandu/xstrings"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/metrics"
@@ -27,14 +28,36 @@ import (
 
 var SyncMetrics = map[SyncStage]metrics.Gauge{}
 
+// StageHistograms - distribution of stage runs: how long they take and how many blocks/sec forward runs process
+type StageHistograms struct {
+	Forward         metrics.Histogram
+	Unwind          metrics.Histogram
+	Prune           metrics.Histogram
+	BlocksPerSecond metrics.Histogram
+}
+
+var SyncHistograms = map[SyncStage]*StageHistograms{}
+
+var (
+	stageSecondsBuckets         = prometheus.ExponentialBuckets(0.01, 4, 10) // 10ms..~44min
+	stageBlocksPerSecondBuckets = prometheus.ExponentialBuckets(1, 4, 10)    // 1..~262K
+)
+
 func init() {
 	for _, v := range AllStages {
+		stage := xstrings.ToSnakeCase(string(v))
 		SyncMetrics[v] = metrics.GetOrCreateGauge(
 			fmt.Sprintf(
 				`sync{stage="%s"}`,
-				xstrings.ToSnakeCase(string(v)),
+				stage,
 			),
 		)
+		SyncHistograms[v] = &StageHistograms{
+			Forward:         metrics.NewHistogram(fmt.Sprintf(`sync_stage_seconds{stage="%s",phase="forward"}`, stage), stageSecondsBuckets),
+			Unwind:          metrics.NewHistogram(fmt.Sprintf(`sync_stage_seconds{stage="%s",phase="unwind"}`, stage), stageSecondsBuckets),
+			Prune:           metrics.NewHistogram(fmt.Sprintf(`sync_stage_seconds{stage="%s",phase="prune"}`, stage), stageSecondsBuckets),
+			BlocksPerSecond: metrics.NewHistogram(fmt.Sprintf(`sync_stage_blocks_per_second{stage="%s"}`, stage), stageBlocksPerSecondBuckets),
+		}
 	}
 }
 
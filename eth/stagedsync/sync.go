@@ -273,14 +273,26 @@ func (s *Sync) StageState(stage stages.SyncStage, tx kv.Tx, db kv.RoDB, initialC
 }
 
 func (s *Sync) RunUnwind(db kv.RwDB, txc wrap.TxContainer) error {
+	return s.RunUnwindWithProgress(db, txc, nil)
+}
+
+// RunUnwindWithProgress - progress is called before unwind of every stage (done - amount of already unwound stages)
+func (s *Sync) RunUnwindWithProgress(db kv.RwDB, txc wrap.TxContainer, progress func(stage stages.SyncStage, done, total int)) error {
 	if s.unwindPoint == nil {
 		return nil
 	}
+	var unwindStages []*Stage
 	for j := 0; j < len(s.unwindOrder); j++ {
 		if s.unwindOrder[j] == nil || s.unwindOrder[j].Disabled || s.unwindOrder[j].Unwind == nil {
 			continue
 		}
-		if err := s.unwindStage(false, s.unwindOrder[j], db, txc); err != nil {
+		unwindStages = append(unwindStages, s.unwindOrder[j])
+	}
+	for i, stage := range unwindStages {
+		if progress != nil {
+			progress(stage.ID, i, len(unwindStages))
+		}
+		if err := s.unwindStage(false, stage, db, txc); err != nil {
 			return err
 		}
 	}
@@ -526,7 +538,10 @@ func (s *Sync) runStage(stage *Stage, db kv.RwDB, txc wrap.TxContainer, initialC
 		return err
 	}
 
-	if err = stage.Forward(badBlockUnwind, stageState, s, txc, s.logger); err != nil {
+	dbg.WithProfileLabels(context.Background(), func(context.Context) {
+		err = stage.Forward(badBlockUnwind, stageState, s, txc, s.logger)
+	}, "module", "stagedsync", "stage", string(stage.ID), "phase", "forward")
+	if err != nil {
 		wrappedError := fmt.Errorf("[%s] %w", s.LogPrefix(), err)
 		s.logger.Debug("Error while executing stage", "err", wrappedError)
 		return wrappedError
@@ -540,9 +555,35 @@ func (s *Sync) runStage(stage *Stage, db kv.RwDB, txc wrap.TxContainer, initialC
 		s.logger.Debug(fmt.Sprintf("[%s] DONE", logPrefix), "in", took)
 	}
 	s.timings = append(s.timings, Timing{stage: stage.ID, took: took})
+	s.observeForward(stage.ID, db, txc.Tx, stageState.BlockNumber, took)
 	return nil
 }
 
+func (s *Sync) observeForward(id stages.SyncStage, db kv.RwDB, tx kv.Tx, from uint64, took time.Duration) {
+	h, ok := stages.SyncHistograms[id]
+	if !ok {
+		return
+	}
+	h.Forward.Observe(took.Seconds())
+
+	var progress uint64
+	var err error
+	if tx != nil {
+		progress, err = stages.GetStageProgress(tx, id)
+	} else if db != nil { // stage commits own transactions
+		err = db.View(context.Background(), func(tx kv.Tx) error {
+			progress, err = stages.GetStageProgress(tx, id)
+			return err
+		})
+	} else {
+		return
+	}
+	if err != nil || progress <= from || took <= 0 {
+		return
+	}
+	h.BlocksPerSecond.Observe(float64(progress-from) / took.Seconds())
+}
+
 func (s *Sync) unwindStage(initialCycle bool, stage *Stage, db kv.RwDB, txc wrap.TxContainer) error {
 	start := time.Now()
 	stageState, err := s.StageState(stage.ID, txc.Tx, db, initialCycle, false)
@@ -561,7 +602,9 @@ func (s *Sync) unwindStage(initialCycle bool, stage *Stage, db kv.RwDB, txc wrap
 		return err
 	}
 
-	err = stage.Unwind(unwind, stageState, txc, s.logger)
+	dbg.WithProfileLabels(context.Background(), func(context.Context) {
+		err = stage.Unwind(unwind, stageState, txc, s.logger)
+	}, "module", "stagedsync", "stage", string(stage.ID), "phase", "unwind")
 	if err != nil {
 		return fmt.Errorf("[%s] %w", s.LogPrefix(), err)
 	}
@@ -572,6 +615,9 @@ func (s *Sync) unwindStage(initialCycle bool, stage *Stage, db kv.RwDB, txc wrap
 		s.logger.Info(fmt.Sprintf("[%s] Unwind done", logPrefix), "in", took)
 	}
 	s.timings = append(s.timings, Timing{isUnwind: true, stage: stage.ID, took: took})
+	if h, ok := stages.SyncHistograms[stage.ID]; ok {
+		h.Unwind.Observe(took.Seconds())
+	}
 	return nil
 }
 
@@ -591,7 +637,9 @@ func (s *Sync) pruneStage(initialCycle bool, stage *Stage, db kv.RwDB, tx kv.RwT
 		return err
 	}
 
-	err = stage.Prune(pruneState, tx, s.logger)
+	dbg.WithProfileLabels(context.Background(), func(context.Context) {
+		err = stage.Prune(pruneState, tx, s.logger)
+	}, "module", "stagedsync", "stage", string(stage.ID), "phase", "prune")
 	if err != nil {
 		return fmt.Errorf("[%s] %w", s.LogPrefix(), err)
 	}
@@ -603,6 +651,9 @@ func (s *Sync) pruneStage(initialCycle bool, stage *Stage, db kv.RwDB, tx kv.RwT
 		s.logger.Debug(fmt.Sprintf("[%s] Prune done", s.LogPrefix()), "in", took)
 	}
 	s.timings = append(s.timings, Timing{isPrune: true, stage: stage.ID, took: took})
+	if h, ok := stages.SyncHistograms[stage.ID]; ok {
+		h.Prune.Observe(took.Seconds())
+	}
 	return nil
 }
 
@@ -605,3 +605,33 @@ func TestSyncInterruptLongUnwind(t *testing.T) {
 func unwindOf(s stages.SyncStage) stages.SyncStage {
 	return stages.SyncStage(append([]byte(s), 0xF0))
 }
+
+func TestRunUnwindWithProgress(t *testing.T) {
+	unwind := func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
+		return u.Done(txc.Tx)
+	}
+	s := []*Stage{
+		{ID: stages.Headers, Unwind: unwind},
+		{ID: stages.Bodies, Unwind: unwind, Disabled: true},
+		{ID: stages.Senders, Unwind: unwind},
+	}
+	state := New(ethconfig.Defaults.Sync, s, []stages.SyncStage{s[2].ID, s[1].ID, s[0].ID}, nil, log.New(), stages.ModeApplyingBlocks)
+	_, tx := memdb.NewTestTx(t)
+	for _, stage := range s {
+		require.NoError(t, stages.SaveStageProgress(tx, stage.ID, 2000))
+	}
+	require.NoError(t, state.UnwindTo(1500, StagedUnwind, tx))
+
+	var progress []string
+	err := state.RunUnwindWithProgress(nil, wrap.NewTxContainer(tx, nil), func(stage stages.SyncStage, done, total int) {
+		progress = append(progress, fmt.Sprintf("%s %d/%d", stage, done, total))
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Senders 0/2", "Headers 1/2"}, progress)
+	require.False(t, state.HasUnwindPoint())
+	for id, expected := range map[stages.SyncStage]uint64{stages.Headers: 1500, stages.Bodies: 2000, stages.Senders: 1500} {
+		p, err := stages.GetStageProgress(tx, id)
+		require.NoError(t, err)
+		require.Equal(t, expected, p, id)
+	}
+}
@@ -0,0 +1,572 @@
+package live
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/holiman/uint256"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+	"github.com/erigontech/erigon/eth/tracers/live/pbeth"
+)
+
+func init() {
+	register("firehose", newFirehose)
+}
+
+const (
+	// FirehoseProtocolVersion - version of Firehose console reader protocol
+	FirehoseProtocolVersion = "3.0"
+	// firehoseFinalityDepth - used as LIB (last irreversible block) distance when consensus didn't provide finalized block
+	firehoseFinalityDepth = 200
+)
+
+type firehoseConfig struct {
+	Output string `json:"output"` // file to write FIRE lines to, stdout if empty
+}
+
+// Firehose - StreamingFast Firehose extractor. Emits every executed block as `sf.ethereum.type.v2.Block` (with
+// call trees, balance/nonce/storage/code changes) using Firehose console reader protocol:
+//
+//	FIRE INIT 3.0 sf.ethereum.type.v2.Block
+//	FIRE BLOCK <num> <hash> <parent_num> <parent_hash> <lib_num> <timestamp_nano> <base64 payload>
+//
+// Use: erigon --vmtrace=firehose [--vmtrace.jsonconfig='{"output":"/path/to/fifo"}'] and point firecore reader to its output.
+type Firehose struct {
+	out    io.Writer
+	closer io.Closer
+
+	block     *pbeth.Block
+	finalized *types.Header
+	ordinal   uint64
+	logIndex  uint32 // index of log in block
+
+	tx        *pbeth.TransactionTrace
+	calls     []*pbeth.Call // stack of calls in progress, nil for SELFDESTRUCT pseudo-calls
+	deferred  *pbeth.Call   // changes of txn which happened before root call started (gas buy, nonce increment)
+	sysCall   bool
+	sysCalls  []*pbeth.Call
+	blockLogs []*types.Log
+}
+
+func newFirehose(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	var config firehoseConfig
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, fmt.Errorf("firehose config: %w", err)
+		}
+	}
+	var out io.Writer = os.Stdout
+	var closer io.Closer
+	if config.Output != "" {
+		f, err := os.OpenFile(config.Output, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("firehose output: %w", err)
+		}
+		out, closer = f, f
+	}
+	t := NewFirehose(out)
+	t.closer = closer
+	return &tracers.Tracer{
+		Hooks:     t.Hooks(),
+		GetResult: func() (json.RawMessage, error) { return json.RawMessage{}, nil },
+		Stop:      t.Stop,
+	}, nil
+}
+
+func NewFirehose(out io.Writer) *Firehose { return &Firehose{out: out} }
+
+func (f *Firehose) Hooks() *tracing.Hooks {
+	return &tracing.Hooks{
+		OnBlockchainInit:  f.OnBlockchainInit,
+		OnGenesisBlock:    f.OnGenesisBlock,
+		OnBlockStart:      f.OnBlockStart,
+		OnBlockEnd:        f.OnBlockEnd,
+		OnSystemCallStart: f.OnSystemCallStart,
+		OnSystemCallEnd:   f.OnSystemCallEnd,
+		OnTxStart:         f.OnTxStart,
+		OnTxEnd:           f.OnTxEnd,
+		OnEnter:           f.OnEnter,
+		OnExit:            f.OnExit,
+		OnBalanceChange:   f.OnBalanceChange,
+		OnNonceChange:     f.OnNonceChange,
+		OnCodeChange:      f.OnCodeChange,
+		OnStorageChange:   f.OnStorageChange,
+		OnLog:             f.OnLog,
+	}
+}
+
+func (f *Firehose) Stop(err error) {
+	if f.closer != nil {
+		f.closer.Close()
+	}
+}
+
+func (f *Firehose) nextOrdinal() uint64 {
+	f.ordinal++
+	return f.ordinal
+}
+
+func (f *Firehose) OnBlockchainInit(chainConfig *chain.Config) {
+	fmt.Fprintf(f.out, "FIRE INIT %s %s\n", FirehoseProtocolVersion, proto.MessageName(&pbeth.Block{}))
+}
+
+func (f *Firehose) OnGenesisBlock(b *types.Block, alloc types.GenesisAlloc) {
+	f.startBlock(b, nil, nil)
+	addrs := make([]common.Address, 0, len(alloc))
+	for addr := range alloc {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Cmp(addrs[j]) < 0 })
+	for _, addr := range addrs {
+		acc := alloc[addr]
+		if acc.Balance != nil && acc.Balance.Sign() > 0 {
+			f.OnBalanceChange(addr, new(uint256.Int), uint256.MustFromBig(acc.Balance), tracing.BalanceIncreaseGenesisBalance)
+		}
+		if len(acc.Code) > 0 {
+			f.OnCodeChange(addr, common.Hash{}, nil, crypto.Keccak256Hash(acc.Code), acc.Code)
+		}
+	}
+	f.OnBlockEnd(nil)
+}
+
+func (f *Firehose) OnBlockStart(event tracing.BlockEvent) {
+	f.startBlock(event.Block, event.TD, event.Finalized)
+}
+
+func (f *Firehose) startBlock(b *types.Block, td *big.Int, finalized *types.Header) {
+	f.block = &pbeth.Block{
+		Ver:         4,
+		Hash:        b.Hash().Bytes(),
+		Number:      b.NumberU64(),
+		Size:        uint64(b.Size()),
+		Header:      firehoseHeader(b.HeaderNoCopy(), td),
+		DetailLevel: pbeth.Block_DETAILLEVEL_EXTENDED,
+	}
+	for _, uncle := range b.Uncles() {
+		f.block.Uncles = append(f.block.Uncles, firehoseHeader(uncle, nil))
+	}
+	f.finalized, f.ordinal, f.logIndex = finalized, 0, 0
+}
+
+func (f *Firehose) OnBlockEnd(err error) {
+	defer func() { f.block, f.tx, f.calls, f.deferred, f.sysCall = nil, nil, nil, nil, false }()
+	if err != nil || f.block == nil {
+		return
+	}
+	f.block.SystemCalls = f.sysCalls
+	f.sysCalls = nil
+	payload, err := proto.Marshal(f.block)
+	if err != nil {
+		panic(fmt.Errorf("firehose: marshal block %d: %w", f.block.Number, err))
+	}
+
+	num, parentNum := f.block.Number, uint64(0)
+	if num > 0 {
+		parentNum = num - 1
+	}
+	libNum := uint64(0)
+	if f.finalized != nil {
+		libNum = f.finalized.Number.Uint64()
+	} else if num > firehoseFinalityDepth {
+		libNum = num - firehoseFinalityDepth
+	}
+	ts := f.block.Header.Timestamp.AsTime().UnixNano()
+	line := fmt.Sprintf("FIRE BLOCK %d %s %d %s %d %d %s\n", num, hex.EncodeToString(f.block.Hash), parentNum,
+		hex.EncodeToString(f.block.Header.ParentHash), libNum, ts, base64.StdEncoding.EncodeToString(payload))
+	if _, err := io.WriteString(f.out, line); err != nil {
+		panic(fmt.Errorf("firehose: write block %d: %w", num, err))
+	}
+}
+
+func (f *Firehose) OnSystemCallStart() { f.sysCall = true }
+func (f *Firehose) OnSystemCallEnd()   { f.sysCall = false }
+
+func (f *Firehose) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
+	if f.block == nil {
+		return
+	}
+	v, r, s := tx.RawSignatureValues()
+	f.tx = &pbeth.TransactionTrace{
+		Index:        uint32(len(f.block.TransactionTraces)),
+		Hash:         tx.Hash().Bytes(),
+		From:         from.Bytes(),
+		Nonce:        tx.GetNonce(),
+		GasLimit:     tx.GetGasLimit(),
+		GasPrice:     firehoseBigInt(env.GasPrice),
+		Value:        firehoseBigInt(tx.GetValue()),
+		Input:        tx.GetData(),
+		V:            firehoseUintBytes(v),
+		R:            firehoseUintBytes(r),
+		S:            firehoseUintBytes(s),
+		Type:         pbeth.TransactionTrace_Type(tx.Type()),
+		BeginOrdinal: f.nextOrdinal(),
+	}
+	if to := tx.GetTo(); to != nil {
+		f.tx.To = to.Bytes()
+	}
+	if tx.Type() >= types.DynamicFeeTxType {
+		f.tx.MaxFeePerGas, f.tx.MaxPriorityFeePerGas = firehoseBigInt(tx.GetFeeCap()), firehoseBigInt(tx.GetTipCap())
+	}
+	for _, tuple := range tx.GetAccessList() {
+		pt := &pbeth.AccessTuple{Address: tuple.Address.Bytes()}
+		for _, key := range tuple.StorageKeys {
+			pt.StorageKeys = append(pt.StorageKeys, key.Bytes())
+		}
+		f.tx.AccessList = append(f.tx.AccessList, pt)
+	}
+	if tx.Type() == types.BlobTxType {
+		blobGas := tx.GetBlobGas()
+		f.tx.BlobGas = &blobGas
+		if blobTx, ok := tx.Unwrap().(*types.BlobTx); ok {
+			f.tx.BlobGasFeeCap = firehoseBigInt(blobTx.MaxFeePerBlobGas)
+		}
+		for _, h := range tx.GetBlobHashes() {
+			f.tx.BlobHashes = append(f.tx.BlobHashes, h.Bytes())
+		}
+	}
+	f.blockLogs = f.blockLogs[:0]
+}
+
+func (f *Firehose) OnTxEnd(receipt *types.Receipt, err error) {
+	tx := f.tx
+	f.tx, f.calls, f.deferred = nil, nil, nil
+	if tx == nil || err != nil || receipt == nil { // invalid txn - block will fail
+		return
+	}
+	tx.GasUsed = receipt.GasUsed
+	tx.Receipt = &pbeth.TransactionReceipt{
+		StateRoot:         receipt.PostState,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		LogsBloom:         receipt.Bloom.Bytes(),
+	}
+	switch {
+	case receipt.Status == types.ReceiptStatusSuccessful:
+		tx.Status = pbeth.TransactionTraceStatus_SUCCEEDED
+	case len(tx.Calls) > 0 && tx.Calls[0].StatusReverted:
+		tx.Status = pbeth.TransactionTraceStatus_REVERTED
+	default:
+		tx.Status = pbeth.TransactionTraceStatus_FAILED
+	}
+	if len(tx.Calls) > 0 {
+		tx.ReturnData = tx.Calls[0].ReturnData
+	}
+
+	// receipt has logs only of calls which weren't reverted, in order of execution
+	var logs []*pbeth.Log
+	for _, call := range tx.Calls {
+		if !call.StateReverted {
+			logs = append(logs, call.Logs...)
+		}
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return logs[i].Ordinal < logs[j].Ordinal })
+	for i, l := range logs {
+		l.Index, l.BlockIndex = uint32(i), f.logIndex
+		f.logIndex++
+		tx.Receipt.Logs = append(tx.Receipt.Logs, &pbeth.Log{Address: l.Address, Topics: l.Topics, Data: l.Data, Index: l.Index, BlockIndex: l.BlockIndex, Ordinal: l.Ordinal})
+	}
+	tx.EndOrdinal = f.nextOrdinal()
+	f.block.TransactionTraces = append(f.block.TransactionTraces, tx)
+}
+
+// callList - calls of current txn or system calls
+func (f *Firehose) callList() *[]*pbeth.Call {
+	if f.tx != nil {
+		return &f.tx.Calls
+	}
+	if f.sysCall {
+		return &f.sysCalls
+	}
+	return nil
+}
+
+func (f *Firehose) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	list := f.callList()
+	if f.block == nil || list == nil {
+		return
+	}
+	op := vm.OpCode(typ)
+	if op == vm.SELFDESTRUCT {
+		if top := f.currentCall(); top != nil {
+			top.Suicide = true
+		}
+		f.calls = append(f.calls, nil)
+		return
+	}
+	call := &pbeth.Call{
+		Index:        uint32(len(*list)) + 1,
+		Depth:        uint32(depth),
+		CallType:     firehoseCallType(op),
+		Caller:       from.Bytes(),
+		Address:      to.Bytes(),
+		Value:        firehoseBigInt(value),
+		GasLimit:     gas,
+		Input:        input,
+		ExecutedCode: !precompile && len(code) > 0,
+		BeginOrdinal: f.nextOrdinal(),
+	}
+	if parent := f.currentCall(); parent != nil {
+		call.ParentIndex = parent.Index
+	}
+	if call.CallType == pbeth.CallType_CREATE {
+		call.AccountCreations = append(call.AccountCreations, &pbeth.AccountCreation{Account: to.Bytes(), Ordinal: f.nextOrdinal()})
+	}
+	if depth == 0 && f.tx != nil {
+		if call.CallType == pbeth.CallType_CREATE {
+			f.tx.To = to.Bytes()
+		}
+		if d := f.deferred; d != nil {
+			call.BalanceChanges, call.NonceChanges = append(d.BalanceChanges, call.BalanceChanges...), append(d.NonceChanges, call.NonceChanges...)
+			call.StorageChanges, call.CodeChanges = append(d.StorageChanges, call.StorageChanges...), append(d.CodeChanges, call.CodeChanges...)
+			f.deferred = nil
+		}
+	}
+	*list = append(*list, call)
+	f.calls = append(f.calls, call)
+}
+
+func (f *Firehose) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(f.calls) == 0 {
+		return
+	}
+	call := f.calls[len(f.calls)-1]
+	f.calls = f.calls[:len(f.calls)-1]
+	if call == nil { // SELFDESTRUCT
+		return
+	}
+	call.GasConsumed, call.ReturnData = gasUsed, output
+	if err != nil {
+		call.StatusFailed, call.FailureReason = true, err.Error()
+		call.StatusReverted = errors.Is(err, vm.ErrExecutionReverted)
+	}
+	if reverted {
+		// this call and all its sub-calls (they follow it in list) lost their changes
+		list := *f.callList()
+		for i := int(call.Index) - 1; i < len(list); i++ {
+			list[i].StateReverted = true
+		}
+	}
+	call.EndOrdinal = f.nextOrdinal()
+}
+
+func (f *Firehose) currentCall() *pbeth.Call {
+	for i := len(f.calls) - 1; i >= 0; i-- {
+		if f.calls[i] != nil {
+			return f.calls[i]
+		}
+	}
+	return nil
+}
+
+// changesCall - call to which state change belongs. nil for changes outside of txn and system call (block-level).
+func (f *Firehose) changesCall() *pbeth.Call {
+	if call := f.currentCall(); call != nil {
+		return call
+	}
+	if f.tx == nil {
+		return nil
+	}
+	if len(f.tx.Calls) > 0 { // after root call finished: gas refund, miner fee
+		return f.tx.Calls[0]
+	}
+	if f.deferred == nil {
+		f.deferred = &pbeth.Call{}
+	}
+	return f.deferred
+}
+
+func (f *Firehose) OnBalanceChange(addr common.Address, prev, new *uint256.Int, reason tracing.BalanceChangeReason) {
+	if f.block == nil || reason == tracing.BalanceChangeTouchAccount || prev.Eq(new) {
+		return
+	}
+	change := &pbeth.BalanceChange{
+		Address:  addr.Bytes(),
+		OldValue: firehoseBigInt(prev),
+		NewValue: firehoseBigInt(new),
+		Reason:   firehoseBalanceReason(reason),
+		Ordinal:  f.nextOrdinal(),
+	}
+	if call := f.changesCall(); call != nil {
+		call.BalanceChanges = append(call.BalanceChanges, change)
+		return
+	}
+	f.block.BalanceChanges = append(f.block.BalanceChanges, change)
+}
+
+func (f *Firehose) OnNonceChange(addr common.Address, prev, new uint64) {
+	if f.block == nil {
+		return
+	}
+	if call := f.changesCall(); call != nil {
+		call.NonceChanges = append(call.NonceChanges, &pbeth.NonceChange{Address: addr.Bytes(), OldValue: prev, NewValue: new, Ordinal: f.nextOrdinal()})
+	}
+}
+
+func (f *Firehose) OnCodeChange(addr common.Address, prevCodeHash common.Hash, prevCode []byte, codeHash common.Hash, code []byte) {
+	if f.block == nil {
+		return
+	}
+	change := &pbeth.CodeChange{
+		Address: addr.Bytes(),
+		OldHash: prevCodeHash.Bytes(),
+		OldCode: prevCode,
+		NewHash: codeHash.Bytes(),
+		NewCode: code,
+		Ordinal: f.nextOrdinal(),
+	}
+	if call := f.changesCall(); call != nil {
+		call.CodeChanges = append(call.CodeChanges, change)
+		return
+	}
+	f.block.CodeChanges = append(f.block.CodeChanges, change)
+}
+
+func (f *Firehose) OnStorageChange(addr common.Address, slot *common.Hash, prev, new uint256.Int) {
+	if f.block == nil {
+		return
+	}
+	if call := f.changesCall(); call != nil {
+		call.StorageChanges = append(call.StorageChanges, &pbeth.StorageChange{
+			Address:  addr.Bytes(),
+			Key:      slot.Bytes(),
+			OldValue: common.BigToHash(prev.ToBig()).Bytes(),
+			NewValue: common.BigToHash(new.ToBig()).Bytes(),
+			Ordinal:  f.nextOrdinal(),
+		})
+	}
+}
+
+func (f *Firehose) OnLog(l *types.Log) {
+	if f.block == nil {
+		return
+	}
+	if call := f.changesCall(); call != nil {
+		pl := &pbeth.Log{Address: l.Address.Bytes(), Data: l.Data, Ordinal: f.nextOrdinal()}
+		for _, topic := range l.Topics {
+			pl.Topics = append(pl.Topics, topic.Bytes())
+		}
+		call.Logs = append(call.Logs, pl)
+	}
+}
+
+func firehoseHeader(h *types.Header, td *big.Int) *pbeth.BlockHeader {
+	ph := &pbeth.BlockHeader{
+		ParentHash:       h.ParentHash.Bytes(),
+		UncleHash:        h.UncleHash.Bytes(),
+		Coinbase:         h.Coinbase.Bytes(),
+		StateRoot:        h.Root.Bytes(),
+		TransactionsRoot: h.TxHash.Bytes(),
+		ReceiptRoot:      h.ReceiptHash.Bytes(),
+		LogsBloom:        h.Bloom.Bytes(),
+		Difficulty:       firehoseBig(h.Difficulty),
+		TotalDifficulty:  firehoseBig(td),
+		Number:           h.Number.Uint64(),
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Timestamp:        timestamppb.New(time.Unix(int64(h.Time), 0)),
+		ExtraData:        h.Extra,
+		MixHash:          h.MixDigest.Bytes(),
+		Nonce:            h.Nonce.Uint64(),
+		Hash:             h.Hash().Bytes(),
+		BaseFeePerGas:    firehoseBig(h.BaseFee),
+		BlobGasUsed:      h.BlobGasUsed,
+		ExcessBlobGas:    h.ExcessBlobGas,
+	}
+	if h.WithdrawalsHash != nil {
+		ph.WithdrawalsRoot = h.WithdrawalsHash.Bytes()
+	}
+	if h.ParentBeaconBlockRoot != nil {
+		ph.ParentBeaconRoot = h.ParentBeaconBlockRoot.Bytes()
+	}
+	if h.RequestsHash != nil {
+		ph.RequestsHash = h.RequestsHash.Bytes()
+	}
+	return ph
+}
+
+func firehoseCallType(op vm.OpCode) pbeth.CallType {
+	switch op {
+	case vm.CALL:
+		return pbeth.CallType_CALL
+	case vm.CALLCODE:
+		return pbeth.CallType_CALLCODE
+	case vm.DELEGATECALL:
+		return pbeth.CallType_DELEGATE
+	case vm.STATICCALL:
+		return pbeth.CallType_STATIC
+	case vm.CREATE, vm.CREATE2:
+		return pbeth.CallType_CREATE
+	default:
+		return pbeth.CallType_UNSPECIFIED
+	}
+}
+
+func firehoseBalanceReason(reason tracing.BalanceChangeReason) pbeth.BalanceChange_Reason {
+	switch reason {
+	case tracing.BalanceIncreaseRewardMineUncle:
+		return pbeth.BalanceChange_REASON_REWARD_MINE_UNCLE
+	case tracing.BalanceIncreaseRewardMineBlock:
+		return pbeth.BalanceChange_REASON_REWARD_MINE_BLOCK
+	case tracing.BalanceIncreaseWithdrawal:
+		return pbeth.BalanceChange_REASON_WITHDRAWAL
+	case tracing.BalanceIncreaseGenesisBalance:
+		return pbeth.BalanceChange_REASON_GENESIS_BALANCE
+	case tracing.BalanceIncreaseRewardTransactionFee:
+		return pbeth.BalanceChange_REASON_REWARD_TRANSACTION_FEE
+	case tracing.BalanceDecreaseGasBuy:
+		return pbeth.BalanceChange_REASON_GAS_BUY
+	case tracing.BalanceIncreaseGasReturn:
+		return pbeth.BalanceChange_REASON_GAS_REFUND
+	case tracing.BalanceIncreaseDaoContract:
+		return pbeth.BalanceChange_REASON_DAO_REFUND_CONTRACT
+	case tracing.BalanceDecreaseDaoAccount:
+		return pbeth.BalanceChange_REASON_DAO_ADJUST_BALANCE
+	case tracing.BalanceChangeTransfer:
+		return pbeth.BalanceChange_REASON_TRANSFER
+	case tracing.BalanceIncreaseSelfdestruct:
+		return pbeth.BalanceChange_REASON_SUICIDE_REFUND
+	case tracing.BalanceDecreaseSelfdestruct:
+		return pbeth.BalanceChange_REASON_SUICIDE_WITHDRAW
+	case tracing.BalanceDecreaseSelfdestructBurn:
+		return pbeth.BalanceChange_REASON_BURN
+	default:
+		return pbeth.BalanceChange_REASON_UNKNOWN
+	}
+}
+
+func firehoseBigInt(v *uint256.Int) *pbeth.BigInt {
+	if v == nil {
+		return nil
+	}
+	return &pbeth.BigInt{Bytes: v.Bytes()}
+}
+
+func firehoseBig(v *big.Int) *pbeth.BigInt {
+	if v == nil {
+		return nil
+	}
+	return &pbeth.BigInt{Bytes: v.Bytes()}
+}
+
+func firehoseUintBytes(v *uint256.Int) []byte {
+	if v == nil {
+		return nil
+	}
+	return v.Bytes()
+}
@@ -0,0 +1,111 @@
+package live
+
+import (
+	"bytes"
+	"encoding/base64"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers/live/pbeth"
+)
+
+func TestFirehose(t *testing.T) {
+	require := require.New(t)
+	var (
+		out      bytes.Buffer
+		f        = NewFirehose(&out)
+		from     = common.Address{1}
+		to       = common.Address{2}
+		created  = common.Address{3}
+		coinbase = common.Address{4}
+		header   = &types.Header{ParentHash: common.Hash{9}, Number: big.NewInt(10), Difficulty: big.NewInt(0), GasLimit: 30_000_000, Time: 1700000000, BaseFee: big.NewInt(7)}
+		txn      = types.NewTransaction(0, to, uint256.NewInt(100), 100_000, uint256.NewInt(10), []byte{0xaa})
+	)
+	f.OnBlockchainInit(chain.TestChainConfig)
+	block := types.NewBlockWithHeader(header)
+	f.OnBlockStart(tracing.BlockEvent{Block: block, TD: big.NewInt(1), Finalized: &types.Header{Number: big.NewInt(8)}})
+
+	f.OnSystemCallStart()
+	f.OnEnter(0, byte(vm.CALL), common.Address{0xff}, common.Address{0xbe}, false, nil, 30_000_000, nil, []byte{0x60})
+	f.OnStorageChange(common.Address{0xbe}, &common.Hash{1}, *uint256.NewInt(0), *uint256.NewInt(1))
+	f.OnExit(0, nil, 100, nil, false)
+	f.OnSystemCallEnd()
+
+	f.OnTxStart(&tracing.VMContext{GasPrice: uint256.NewInt(10)}, txn, from)
+	f.OnBalanceChange(from, uint256.NewInt(10_000_000), uint256.NewInt(9_000_000), tracing.BalanceDecreaseGasBuy)
+	f.OnNonceChange(from, 0, 1)
+	f.OnEnter(0, byte(vm.CALL), from, to, false, []byte{0xaa}, 79_000, uint256.NewInt(100), []byte{0x60})
+	f.OnBalanceChange(from, uint256.NewInt(9_000_000), uint256.NewInt(8_999_900), tracing.BalanceChangeTransfer)
+	f.OnBalanceChange(to, uint256.NewInt(0), uint256.NewInt(100), tracing.BalanceChangeTransfer)
+	f.OnEnter(1, byte(vm.CREATE2), to, created, false, []byte{0x60}, 50_000, uint256.NewInt(0), nil)
+	f.OnCodeChange(created, common.Hash{}, nil, common.Hash{5}, []byte{0x00})
+	f.OnLog(&types.Log{Address: created, Topics: []common.Hash{{6}}})
+	f.OnExit(1, nil, 50_000, vm.ErrExecutionReverted, true)
+	f.OnLog(&types.Log{Address: to, Topics: []common.Hash{{7}}, Data: []byte{1}})
+	f.OnEnter(1, byte(vm.SELFDESTRUCT), to, coinbase, false, nil, 0, uint256.NewInt(0), nil)
+	f.OnExit(1, nil, 0, nil, false)
+	f.OnExit(0, []byte{0xbb}, 60_000, nil, false)
+	f.OnBalanceChange(from, uint256.NewInt(8_999_900), uint256.NewInt(9_399_900), tracing.BalanceIncreaseGasReturn)
+	f.OnTxEnd(&types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 60_000, GasUsed: 60_000}, nil)
+
+	f.OnBalanceChange(coinbase, uint256.NewInt(0), uint256.NewInt(2), tracing.BalanceIncreaseRewardMineBlock)
+	f.OnBlockEnd(nil)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(lines, 2)
+	require.Equal("FIRE INIT 3.0 sf.ethereum.type.v2.Block", lines[0])
+	parts := strings.Split(lines[1], " ")
+	require.Len(parts, 9)
+	require.Equal([]string{"FIRE", "BLOCK", "10", block.Hash().Hex()[2:], "9", common.Hash{9}.Hex()[2:], "8"}, parts[:7])
+	require.Equal(strconv.FormatInt(1700000000*1e9, 10), parts[7])
+
+	payload, err := base64.StdEncoding.DecodeString(parts[8])
+	require.NoError(err)
+	var b pbeth.Block
+	require.NoError(proto.Unmarshal(payload, &b))
+	require.Equal(uint64(10), b.Number)
+	require.Equal([]byte{7}, b.Header.BaseFeePerGas.Bytes)
+	require.Len(b.BalanceChanges, 1)
+	require.Equal(pbeth.BalanceChange_REASON_REWARD_MINE_BLOCK, b.BalanceChanges[0].Reason)
+	require.Len(b.SystemCalls, 1)
+	require.Len(b.SystemCalls[0].StorageChanges, 1)
+
+	require.Len(b.TransactionTraces, 1)
+	tx := b.TransactionTraces[0]
+	require.Equal(pbeth.TransactionTraceStatus_SUCCEEDED, tx.Status)
+	require.Equal(uint64(60_000), tx.GasUsed)
+	require.Equal([]byte{0xbb}, tx.ReturnData)
+	require.Len(tx.Calls, 2)
+
+	root, create := tx.Calls[0], tx.Calls[1]
+	require.Equal(uint32(1), root.Index)
+	require.True(root.Suicide)
+	require.Len(root.NonceChanges, 1) // happened before root call
+	require.Equal([]pbeth.BalanceChange_Reason{
+		pbeth.BalanceChange_REASON_GAS_BUY, pbeth.BalanceChange_REASON_TRANSFER, pbeth.BalanceChange_REASON_TRANSFER, pbeth.BalanceChange_REASON_GAS_REFUND,
+	}, []pbeth.BalanceChange_Reason{root.BalanceChanges[0].Reason, root.BalanceChanges[1].Reason, root.BalanceChanges[2].Reason, root.BalanceChanges[3].Reason})
+
+	require.Equal(pbeth.CallType_CREATE, create.CallType)
+	require.Equal(uint32(1), create.ParentIndex)
+	require.True(create.StatusFailed && create.StatusReverted && create.StateReverted)
+	require.Len(create.CodeChanges, 1)
+	require.Len(create.AccountCreations, 1)
+	require.False(root.StateReverted)
+
+	// log of reverted call is not in receipt
+	require.Len(tx.Receipt.Logs, 1)
+	require.Equal(to.Bytes(), tx.Receipt.Logs[0].Address)
+	require.Less(tx.BeginOrdinal, root.BeginOrdinal)
+	require.Less(root.EndOrdinal, tx.EndOrdinal)
+}
@@ -0,0 +1,2090 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: sf/ethereum/type/v2/type.proto
+
+package pbeth
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TransactionTraceStatus int32
+
+const (
+	TransactionTraceStatus_UNKNOWN   TransactionTraceStatus = 0
+	TransactionTraceStatus_SUCCEEDED TransactionTraceStatus = 1
+	TransactionTraceStatus_FAILED    TransactionTraceStatus = 2
+	TransactionTraceStatus_REVERTED  TransactionTraceStatus = 3
+)
+
+// Enum value maps for TransactionTraceStatus.
+var (
+	TransactionTraceStatus_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "SUCCEEDED",
+		2: "FAILED",
+		3: "REVERTED",
+	}
+	TransactionTraceStatus_value = map[string]int32{
+		"UNKNOWN":   0,
+		"SUCCEEDED": 1,
+		"FAILED":    2,
+		"REVERTED":  3,
+	}
+)
+
+func (x TransactionTraceStatus) Enum() *TransactionTraceStatus {
+	p := new(TransactionTraceStatus)
+	*p = x
+	return p
+}
+
+func (x TransactionTraceStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionTraceStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_ethereum_type_v2_type_proto_enumTypes[0].Descriptor()
+}
+
+func (TransactionTraceStatus) Type() protoreflect.EnumType {
+	return &file_sf_ethereum_type_v2_type_proto_enumTypes[0]
+}
+
+func (x TransactionTraceStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionTraceStatus.Descriptor instead.
+func (TransactionTraceStatus) EnumDescriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{0}
+}
+
+type CallType int32
+
+const (
+	CallType_UNSPECIFIED CallType = 0
+	CallType_CALL        CallType = 1
+	CallType_CALLCODE    CallType = 2
+	CallType_DELEGATE    CallType = 3
+	CallType_STATIC      CallType = 4
+	CallType_CREATE      CallType = 5
+)
+
+// Enum value maps for CallType.
+var (
+	CallType_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "CALL",
+		2: "CALLCODE",
+		3: "DELEGATE",
+		4: "STATIC",
+		5: "CREATE",
+	}
+	CallType_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"CALL":        1,
+		"CALLCODE":    2,
+		"DELEGATE":    3,
+		"STATIC":      4,
+		"CREATE":      5,
+	}
+)
+
+func (x CallType) Enum() *CallType {
+	p := new(CallType)
+	*p = x
+	return p
+}
+
+func (x CallType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CallType) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_ethereum_type_v2_type_proto_enumTypes[1].Descriptor()
+}
+
+func (CallType) Type() protoreflect.EnumType {
+	return &file_sf_ethereum_type_v2_type_proto_enumTypes[1]
+}
+
+func (x CallType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CallType.Descriptor instead.
+func (CallType) EnumDescriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{1}
+}
+
+type Block_DetailLevel int32
+
+const (
+	Block_DETAILLEVEL_EXTENDED Block_DetailLevel = 0
+	Block_DETAILLEVEL_BASE     Block_DetailLevel = 2
+)
+
+// Enum value maps for Block_DetailLevel.
+var (
+	Block_DetailLevel_name = map[int32]string{
+		0: "DETAILLEVEL_EXTENDED",
+		2: "DETAILLEVEL_BASE",
+	}
+	Block_DetailLevel_value = map[string]int32{
+		"DETAILLEVEL_EXTENDED": 0,
+		"DETAILLEVEL_BASE":     2,
+	}
+)
+
+func (x Block_DetailLevel) Enum() *Block_DetailLevel {
+	p := new(Block_DetailLevel)
+	*p = x
+	return p
+}
+
+func (x Block_DetailLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Block_DetailLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_ethereum_type_v2_type_proto_enumTypes[2].Descriptor()
+}
+
+func (Block_DetailLevel) Type() protoreflect.EnumType {
+	return &file_sf_ethereum_type_v2_type_proto_enumTypes[2]
+}
+
+func (x Block_DetailLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Block_DetailLevel.Descriptor instead.
+func (Block_DetailLevel) EnumDescriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type TransactionTrace_Type int32
+
+const (
+	TransactionTrace_TRX_TYPE_LEGACY      TransactionTrace_Type = 0
+	TransactionTrace_TRX_TYPE_ACCESS_LIST TransactionTrace_Type = 1
+	TransactionTrace_TRX_TYPE_DYNAMIC_FEE TransactionTrace_Type = 2
+	TransactionTrace_TRX_TYPE_BLOB        TransactionTrace_Type = 3
+	TransactionTrace_TRX_TYPE_SET_CODE    TransactionTrace_Type = 4
+)
+
+// Enum value maps for TransactionTrace_Type.
+var (
+	TransactionTrace_Type_name = map[int32]string{
+		0: "TRX_TYPE_LEGACY",
+		1: "TRX_TYPE_ACCESS_LIST",
+		2: "TRX_TYPE_DYNAMIC_FEE",
+		3: "TRX_TYPE_BLOB",
+		4: "TRX_TYPE_SET_CODE",
+	}
+	TransactionTrace_Type_value = map[string]int32{
+		"TRX_TYPE_LEGACY":      0,
+		"TRX_TYPE_ACCESS_LIST": 1,
+		"TRX_TYPE_DYNAMIC_FEE": 2,
+		"TRX_TYPE_BLOB":        3,
+		"TRX_TYPE_SET_CODE":    4,
+	}
+)
+
+func (x TransactionTrace_Type) Enum() *TransactionTrace_Type {
+	p := new(TransactionTrace_Type)
+	*p = x
+	return p
+}
+
+func (x TransactionTrace_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionTrace_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_ethereum_type_v2_type_proto_enumTypes[3].Descriptor()
+}
+
+func (TransactionTrace_Type) Type() protoreflect.EnumType {
+	return &file_sf_ethereum_type_v2_type_proto_enumTypes[3]
+}
+
+func (x TransactionTrace_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionTrace_Type.Descriptor instead.
+func (TransactionTrace_Type) EnumDescriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type BalanceChange_Reason int32
+
+const (
+	BalanceChange_REASON_UNKNOWN                BalanceChange_Reason = 0
+	BalanceChange_REASON_REWARD_MINE_UNCLE      BalanceChange_Reason = 1
+	BalanceChange_REASON_REWARD_MINE_BLOCK      BalanceChange_Reason = 2
+	BalanceChange_REASON_DAO_REFUND_CONTRACT    BalanceChange_Reason = 3
+	BalanceChange_REASON_DAO_ADJUST_BALANCE     BalanceChange_Reason = 4
+	BalanceChange_REASON_TRANSFER               BalanceChange_Reason = 5
+	BalanceChange_REASON_GENESIS_BALANCE        BalanceChange_Reason = 6
+	BalanceChange_REASON_GAS_BUY                BalanceChange_Reason = 7
+	BalanceChange_REASON_REWARD_TRANSACTION_FEE BalanceChange_Reason = 8
+	BalanceChange_REASON_GAS_REFUND             BalanceChange_Reason = 9
+	BalanceChange_REASON_TOUCH_ACCOUNT          BalanceChange_Reason = 10
+	BalanceChange_REASON_SUICIDE_REFUND         BalanceChange_Reason = 11
+	BalanceChange_REASON_CALL_BALANCE_OVERRIDE  BalanceChange_Reason = 12
+	BalanceChange_REASON_SUICIDE_WITHDRAW       BalanceChange_Reason = 13
+	BalanceChange_REASON_BURN                   BalanceChange_Reason = 15
+	BalanceChange_REASON_WITHDRAWAL             BalanceChange_Reason = 16
+)
+
+// Enum value maps for BalanceChange_Reason.
+var (
+	BalanceChange_Reason_name = map[int32]string{
+		0:  "REASON_UNKNOWN",
+		1:  "REASON_REWARD_MINE_UNCLE",
+		2:  "REASON_REWARD_MINE_BLOCK",
+		3:  "REASON_DAO_REFUND_CONTRACT",
+		4:  "REASON_DAO_ADJUST_BALANCE",
+		5:  "REASON_TRANSFER",
+		6:  "REASON_GENESIS_BALANCE",
+		7:  "REASON_GAS_BUY",
+		8:  "REASON_REWARD_TRANSACTION_FEE",
+		9:  "REASON_GAS_REFUND",
+		10: "REASON_TOUCH_ACCOUNT",
+		11: "REASON_SUICIDE_REFUND",
+		12: "REASON_CALL_BALANCE_OVERRIDE",
+		13: "REASON_SUICIDE_WITHDRAW",
+		15: "REASON_BURN",
+		16: "REASON_WITHDRAWAL",
+	}
+	BalanceChange_Reason_value = map[string]int32{
+		"REASON_UNKNOWN":                0,
+		"REASON_REWARD_MINE_UNCLE":      1,
+		"REASON_REWARD_MINE_BLOCK":      2,
+		"REASON_DAO_REFUND_CONTRACT":    3,
+		"REASON_DAO_ADJUST_BALANCE":     4,
+		"REASON_TRANSFER":               5,
+		"REASON_GENESIS_BALANCE":        6,
+		"REASON_GAS_BUY":                7,
+		"REASON_REWARD_TRANSACTION_FEE": 8,
+		"REASON_GAS_REFUND":             9,
+		"REASON_TOUCH_ACCOUNT":          10,
+		"REASON_SUICIDE_REFUND":         11,
+		"REASON_CALL_BALANCE_OVERRIDE":  12,
+		"REASON_SUICIDE_WITHDRAW":       13,
+		"REASON_BURN":                   15,
+		"REASON_WITHDRAWAL":             16,
+	}
+)
+
+func (x BalanceChange_Reason) Enum() *BalanceChange_Reason {
+	p := new(BalanceChange_Reason)
+	*p = x
+	return p
+}
+
+func (x BalanceChange_Reason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceChange_Reason) Descriptor() protoreflect.EnumDescriptor {
+	return file_sf_ethereum_type_v2_type_proto_enumTypes[4].Descriptor()
+}
+
+func (BalanceChange_Reason) Type() protoreflect.EnumType {
+	return &file_sf_ethereum_type_v2_type_proto_enumTypes[4]
+}
+
+func (x BalanceChange_Reason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceChange_Reason.Descriptor instead.
+func (BalanceChange_Reason) EnumDescriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type Block struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Ver               int32                  `protobuf:"varint,1,opt,name=ver,proto3" json:"ver,omitempty"`
+	Hash              []byte                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Number            uint64                 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Size              uint64                 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Header            *BlockHeader           `protobuf:"bytes,5,opt,name=header,proto3" json:"header,omitempty"`
+	Uncles            []*BlockHeader         `protobuf:"bytes,6,rep,name=uncles,proto3" json:"uncles,omitempty"`
+	TransactionTraces []*TransactionTrace    `protobuf:"bytes,10,rep,name=transaction_traces,json=transactionTraces,proto3" json:"transaction_traces,omitempty"`
+	BalanceChanges    []*BalanceChange       `protobuf:"bytes,11,rep,name=balance_changes,json=balanceChanges,proto3" json:"balance_changes,omitempty"`
+	DetailLevel       Block_DetailLevel      `protobuf:"varint,12,opt,name=detail_level,json=detailLevel,proto3,enum=sf.ethereum.type.v2.Block_DetailLevel" json:"detail_level,omitempty"`
+	CodeChanges       []*CodeChange          `protobuf:"bytes,20,rep,name=code_changes,json=codeChanges,proto3" json:"code_changes,omitempty"`
+	SystemCalls       []*Call                `protobuf:"bytes,21,rep,name=system_calls,json=systemCalls,proto3" json:"system_calls,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Block) GetVer() int32 {
+	if x != nil {
+		return x.Ver
+	}
+	return 0
+}
+
+func (x *Block) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Block) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Block) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Block) GetHeader() *BlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *Block) GetUncles() []*BlockHeader {
+	if x != nil {
+		return x.Uncles
+	}
+	return nil
+}
+
+func (x *Block) GetTransactionTraces() []*TransactionTrace {
+	if x != nil {
+		return x.TransactionTraces
+	}
+	return nil
+}
+
+func (x *Block) GetBalanceChanges() []*BalanceChange {
+	if x != nil {
+		return x.BalanceChanges
+	}
+	return nil
+}
+
+func (x *Block) GetDetailLevel() Block_DetailLevel {
+	if x != nil {
+		return x.DetailLevel
+	}
+	return Block_DETAILLEVEL_EXTENDED
+}
+
+func (x *Block) GetCodeChanges() []*CodeChange {
+	if x != nil {
+		return x.CodeChanges
+	}
+	return nil
+}
+
+func (x *Block) GetSystemCalls() []*Call {
+	if x != nil {
+		return x.SystemCalls
+	}
+	return nil
+}
+
+type BlockHeader struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ParentHash       []byte                 `protobuf:"bytes,1,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	UncleHash        []byte                 `protobuf:"bytes,2,opt,name=uncle_hash,json=uncleHash,proto3" json:"uncle_hash,omitempty"`
+	Coinbase         []byte                 `protobuf:"bytes,3,opt,name=coinbase,proto3" json:"coinbase,omitempty"`
+	StateRoot        []byte                 `protobuf:"bytes,4,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	TransactionsRoot []byte                 `protobuf:"bytes,5,opt,name=transactions_root,json=transactionsRoot,proto3" json:"transactions_root,omitempty"`
+	ReceiptRoot      []byte                 `protobuf:"bytes,6,opt,name=receipt_root,json=receiptRoot,proto3" json:"receipt_root,omitempty"`
+	LogsBloom        []byte                 `protobuf:"bytes,7,opt,name=logs_bloom,json=logsBloom,proto3" json:"logs_bloom,omitempty"`
+	Difficulty       *BigInt                `protobuf:"bytes,8,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Number           uint64                 `protobuf:"varint,9,opt,name=number,proto3" json:"number,omitempty"`
+	GasLimit         uint64                 `protobuf:"varint,10,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasUsed          uint64                 `protobuf:"varint,11,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	ExtraData        []byte                 `protobuf:"bytes,13,opt,name=extra_data,json=extraData,proto3" json:"extra_data,omitempty"`
+	MixHash          []byte                 `protobuf:"bytes,14,opt,name=mix_hash,json=mixHash,proto3" json:"mix_hash,omitempty"`
+	Nonce            uint64                 `protobuf:"varint,15,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Hash             []byte                 `protobuf:"bytes,16,opt,name=hash,proto3" json:"hash,omitempty"`
+	TotalDifficulty  *BigInt                `protobuf:"bytes,17,opt,name=total_difficulty,json=totalDifficulty,proto3" json:"total_difficulty,omitempty"`
+	BaseFeePerGas    *BigInt                `protobuf:"bytes,18,opt,name=base_fee_per_gas,json=baseFeePerGas,proto3" json:"base_fee_per_gas,omitempty"`
+	WithdrawalsRoot  []byte                 `protobuf:"bytes,19,opt,name=withdrawals_root,json=withdrawalsRoot,proto3" json:"withdrawals_root,omitempty"`
+	BlobGasUsed      *uint64                `protobuf:"varint,22,opt,name=blob_gas_used,json=blobGasUsed,proto3,oneof" json:"blob_gas_used,omitempty"`
+	ExcessBlobGas    *uint64                `protobuf:"varint,23,opt,name=excess_blob_gas,json=excessBlobGas,proto3,oneof" json:"excess_blob_gas,omitempty"`
+	ParentBeaconRoot []byte                 `protobuf:"bytes,24,opt,name=parent_beacon_root,json=parentBeaconRoot,proto3" json:"parent_beacon_root,omitempty"`
+	RequestsHash     []byte                 `protobuf:"bytes,25,opt,name=requests_hash,json=requestsHash,proto3" json:"requests_hash,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *BlockHeader) Reset() {
+	*x = BlockHeader{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHeader) ProtoMessage() {}
+
+func (x *BlockHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHeader.ProtoReflect.Descriptor instead.
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockHeader) GetParentHash() []byte {
+	if x != nil {
+		return x.ParentHash
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetUncleHash() []byte {
+	if x != nil {
+		return x.UncleHash
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetCoinbase() []byte {
+	if x != nil {
+		return x.Coinbase
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetStateRoot() []byte {
+	if x != nil {
+		return x.StateRoot
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetTransactionsRoot() []byte {
+	if x != nil {
+		return x.TransactionsRoot
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetReceiptRoot() []byte {
+	if x != nil {
+		return x.ReceiptRoot
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetLogsBloom() []byte {
+	if x != nil {
+		return x.LogsBloom
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetDifficulty() *BigInt {
+	if x != nil {
+		return x.Difficulty
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetExtraData() []byte {
+	if x != nil {
+		return x.ExtraData
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetMixHash() []byte {
+	if x != nil {
+		return x.MixHash
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetTotalDifficulty() *BigInt {
+	if x != nil {
+		return x.TotalDifficulty
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetBaseFeePerGas() *BigInt {
+	if x != nil {
+		return x.BaseFeePerGas
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetWithdrawalsRoot() []byte {
+	if x != nil {
+		return x.WithdrawalsRoot
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetBlobGasUsed() uint64 {
+	if x != nil && x.BlobGasUsed != nil {
+		return *x.BlobGasUsed
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetExcessBlobGas() uint64 {
+	if x != nil && x.ExcessBlobGas != nil {
+		return *x.ExcessBlobGas
+	}
+	return 0
+}
+
+func (x *BlockHeader) GetParentBeaconRoot() []byte {
+	if x != nil {
+		return x.ParentBeaconRoot
+	}
+	return nil
+}
+
+func (x *BlockHeader) GetRequestsHash() []byte {
+	if x != nil {
+		return x.RequestsHash
+	}
+	return nil
+}
+
+type BigInt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bytes         []byte                 `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BigInt) Reset() {
+	*x = BigInt{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BigInt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BigInt) ProtoMessage() {}
+
+func (x *BigInt) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BigInt.ProtoReflect.Descriptor instead.
+func (*BigInt) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BigInt) GetBytes() []byte {
+	if x != nil {
+		return x.Bytes
+	}
+	return nil
+}
+
+type TransactionTrace struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	To                   []byte                 `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
+	Nonce                uint64                 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	GasPrice             *BigInt                `protobuf:"bytes,3,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasLimit             uint64                 `protobuf:"varint,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Value                *BigInt                `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Input                []byte                 `protobuf:"bytes,6,opt,name=input,proto3" json:"input,omitempty"`
+	V                    []byte                 `protobuf:"bytes,7,opt,name=v,proto3" json:"v,omitempty"`
+	R                    []byte                 `protobuf:"bytes,8,opt,name=r,proto3" json:"r,omitempty"`
+	S                    []byte                 `protobuf:"bytes,9,opt,name=s,proto3" json:"s,omitempty"`
+	GasUsed              uint64                 `protobuf:"varint,10,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	MaxFeePerGas         *BigInt                `protobuf:"bytes,11,opt,name=max_fee_per_gas,json=maxFeePerGas,proto3" json:"max_fee_per_gas,omitempty"`
+	Type                 TransactionTrace_Type  `protobuf:"varint,12,opt,name=type,proto3,enum=sf.ethereum.type.v2.TransactionTrace_Type" json:"type,omitempty"`
+	MaxPriorityFeePerGas *BigInt                `protobuf:"bytes,13,opt,name=max_priority_fee_per_gas,json=maxPriorityFeePerGas,proto3" json:"max_priority_fee_per_gas,omitempty"`
+	AccessList           []*AccessTuple         `protobuf:"bytes,14,rep,name=access_list,json=accessList,proto3" json:"access_list,omitempty"`
+	Index                uint32                 `protobuf:"varint,20,opt,name=index,proto3" json:"index,omitempty"`
+	Hash                 []byte                 `protobuf:"bytes,21,opt,name=hash,proto3" json:"hash,omitempty"`
+	From                 []byte                 `protobuf:"bytes,22,opt,name=from,proto3" json:"from,omitempty"`
+	ReturnData           []byte                 `protobuf:"bytes,23,opt,name=return_data,json=returnData,proto3" json:"return_data,omitempty"`
+	PublicKey            []byte                 `protobuf:"bytes,24,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	BeginOrdinal         uint64                 `protobuf:"varint,25,opt,name=begin_ordinal,json=beginOrdinal,proto3" json:"begin_ordinal,omitempty"`
+	EndOrdinal           uint64                 `protobuf:"varint,26,opt,name=end_ordinal,json=endOrdinal,proto3" json:"end_ordinal,omitempty"`
+	Status               TransactionTraceStatus `protobuf:"varint,30,opt,name=status,proto3,enum=sf.ethereum.type.v2.TransactionTraceStatus" json:"status,omitempty"`
+	Receipt              *TransactionReceipt    `protobuf:"bytes,31,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	Calls                []*Call                `protobuf:"bytes,32,rep,name=calls,proto3" json:"calls,omitempty"`
+	BlobGas              *uint64                `protobuf:"varint,33,opt,name=blob_gas,json=blobGas,proto3,oneof" json:"blob_gas,omitempty"`
+	BlobGasFeeCap        *BigInt                `protobuf:"bytes,34,opt,name=blob_gas_fee_cap,json=blobGasFeeCap,proto3" json:"blob_gas_fee_cap,omitempty"`
+	BlobHashes           [][]byte               `protobuf:"bytes,35,rep,name=blob_hashes,json=blobHashes,proto3" json:"blob_hashes,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TransactionTrace) Reset() {
+	*x = TransactionTrace{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionTrace) ProtoMessage() {}
+
+func (x *TransactionTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionTrace.ProtoReflect.Descriptor instead.
+func (*TransactionTrace) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TransactionTrace) GetTo() []byte {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetGasPrice() *BigInt {
+	if x != nil {
+		return x.GasPrice
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetValue() *BigInt {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetV() []byte {
+	if x != nil {
+		return x.V
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetR() []byte {
+	if x != nil {
+		return x.R
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetMaxFeePerGas() *BigInt {
+	if x != nil {
+		return x.MaxFeePerGas
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetType() TransactionTrace_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionTrace_TRX_TYPE_LEGACY
+}
+
+func (x *TransactionTrace) GetMaxPriorityFeePerGas() *BigInt {
+	if x != nil {
+		return x.MaxPriorityFeePerGas
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetAccessList() []*AccessTuple {
+	if x != nil {
+		return x.AccessList
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetFrom() []byte {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetReturnData() []byte {
+	if x != nil {
+		return x.ReturnData
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetBeginOrdinal() uint64 {
+	if x != nil {
+		return x.BeginOrdinal
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetEndOrdinal() uint64 {
+	if x != nil {
+		return x.EndOrdinal
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetStatus() TransactionTraceStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionTraceStatus_UNKNOWN
+}
+
+func (x *TransactionTrace) GetReceipt() *TransactionReceipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetCalls() []*Call {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetBlobGas() uint64 {
+	if x != nil && x.BlobGas != nil {
+		return *x.BlobGas
+	}
+	return 0
+}
+
+func (x *TransactionTrace) GetBlobGasFeeCap() *BigInt {
+	if x != nil {
+		return x.BlobGasFeeCap
+	}
+	return nil
+}
+
+func (x *TransactionTrace) GetBlobHashes() [][]byte {
+	if x != nil {
+		return x.BlobHashes
+	}
+	return nil
+}
+
+type AccessTuple struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	StorageKeys   [][]byte               `protobuf:"bytes,2,rep,name=storage_keys,json=storageKeys,proto3" json:"storage_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccessTuple) Reset() {
+	*x = AccessTuple{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccessTuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccessTuple) ProtoMessage() {}
+
+func (x *AccessTuple) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccessTuple.ProtoReflect.Descriptor instead.
+func (*AccessTuple) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AccessTuple) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *AccessTuple) GetStorageKeys() [][]byte {
+	if x != nil {
+		return x.StorageKeys
+	}
+	return nil
+}
+
+type TransactionReceipt struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	StateRoot         []byte                 `protobuf:"bytes,1,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	CumulativeGasUsed uint64                 `protobuf:"varint,2,opt,name=cumulative_gas_used,json=cumulativeGasUsed,proto3" json:"cumulative_gas_used,omitempty"`
+	LogsBloom         []byte                 `protobuf:"bytes,3,opt,name=logs_bloom,json=logsBloom,proto3" json:"logs_bloom,omitempty"`
+	Logs              []*Log                 `protobuf:"bytes,4,rep,name=logs,proto3" json:"logs,omitempty"`
+	BlobGasUsed       *uint64                `protobuf:"varint,5,opt,name=blob_gas_used,json=blobGasUsed,proto3,oneof" json:"blob_gas_used,omitempty"`
+	BlobGasPrice      *BigInt                `protobuf:"bytes,6,opt,name=blob_gas_price,json=blobGasPrice,proto3" json:"blob_gas_price,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *TransactionReceipt) Reset() {
+	*x = TransactionReceipt{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionReceipt) ProtoMessage() {}
+
+func (x *TransactionReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionReceipt.ProtoReflect.Descriptor instead.
+func (*TransactionReceipt) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TransactionReceipt) GetStateRoot() []byte {
+	if x != nil {
+		return x.StateRoot
+	}
+	return nil
+}
+
+func (x *TransactionReceipt) GetCumulativeGasUsed() uint64 {
+	if x != nil {
+		return x.CumulativeGasUsed
+	}
+	return 0
+}
+
+func (x *TransactionReceipt) GetLogsBloom() []byte {
+	if x != nil {
+		return x.LogsBloom
+	}
+	return nil
+}
+
+func (x *TransactionReceipt) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *TransactionReceipt) GetBlobGasUsed() uint64 {
+	if x != nil && x.BlobGasUsed != nil {
+		return *x.BlobGasUsed
+	}
+	return 0
+}
+
+func (x *TransactionReceipt) GetBlobGasPrice() *BigInt {
+	if x != nil {
+		return x.BlobGasPrice
+	}
+	return nil
+}
+
+type Log struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics        [][]byte               `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Index         uint32                 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	BlockIndex    uint32                 `protobuf:"varint,6,opt,name=blockIndex,proto3" json:"blockIndex,omitempty"`
+	Ordinal       uint64                 `protobuf:"varint,7,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Log) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Log) ProtoMessage() {}
+
+func (x *Log) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Log) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Log) GetTopics() [][]byte {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *Log) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Log) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Log) GetBlockIndex() uint32 {
+	if x != nil {
+		return x.BlockIndex
+	}
+	return 0
+}
+
+func (x *Log) GetOrdinal() uint64 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type Call struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Index            uint32                 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ParentIndex      uint32                 `protobuf:"varint,2,opt,name=parent_index,json=parentIndex,proto3" json:"parent_index,omitempty"`
+	Depth            uint32                 `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	CallType         CallType               `protobuf:"varint,4,opt,name=call_type,json=callType,proto3,enum=sf.ethereum.type.v2.CallType" json:"call_type,omitempty"`
+	Caller           []byte                 `protobuf:"bytes,5,opt,name=caller,proto3" json:"caller,omitempty"`
+	Address          []byte                 `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	Value            *BigInt                `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
+	GasLimit         uint64                 `protobuf:"varint,8,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasConsumed      uint64                 `protobuf:"varint,9,opt,name=gas_consumed,json=gasConsumed,proto3" json:"gas_consumed,omitempty"`
+	StatusFailed     bool                   `protobuf:"varint,10,opt,name=status_failed,json=statusFailed,proto3" json:"status_failed,omitempty"`
+	FailureReason    string                 `protobuf:"bytes,11,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	StatusReverted   bool                   `protobuf:"varint,12,opt,name=status_reverted,json=statusReverted,proto3" json:"status_reverted,omitempty"`
+	ReturnData       []byte                 `protobuf:"bytes,13,opt,name=return_data,json=returnData,proto3" json:"return_data,omitempty"`
+	Input            []byte                 `protobuf:"bytes,14,opt,name=input,proto3" json:"input,omitempty"`
+	ExecutedCode     bool                   `protobuf:"varint,15,opt,name=executed_code,json=executedCode,proto3" json:"executed_code,omitempty"`
+	Suicide          bool                   `protobuf:"varint,16,opt,name=suicide,proto3" json:"suicide,omitempty"`
+	StorageChanges   []*StorageChange       `protobuf:"bytes,21,rep,name=storage_changes,json=storageChanges,proto3" json:"storage_changes,omitempty"`
+	BalanceChanges   []*BalanceChange       `protobuf:"bytes,22,rep,name=balance_changes,json=balanceChanges,proto3" json:"balance_changes,omitempty"`
+	NonceChanges     []*NonceChange         `protobuf:"bytes,24,rep,name=nonce_changes,json=nonceChanges,proto3" json:"nonce_changes,omitempty"`
+	Logs             []*Log                 `protobuf:"bytes,25,rep,name=logs,proto3" json:"logs,omitempty"`
+	CodeChanges      []*CodeChange          `protobuf:"bytes,26,rep,name=code_changes,json=codeChanges,proto3" json:"code_changes,omitempty"`
+	StateReverted    bool                   `protobuf:"varint,30,opt,name=state_reverted,json=stateReverted,proto3" json:"state_reverted,omitempty"`
+	BeginOrdinal     uint64                 `protobuf:"varint,31,opt,name=begin_ordinal,json=beginOrdinal,proto3" json:"begin_ordinal,omitempty"`
+	EndOrdinal       uint64                 `protobuf:"varint,32,opt,name=end_ordinal,json=endOrdinal,proto3" json:"end_ordinal,omitempty"`
+	AccountCreations []*AccountCreation     `protobuf:"bytes,33,rep,name=account_creations,json=accountCreations,proto3" json:"account_creations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Call) Reset() {
+	*x = Call{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Call) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Call) ProtoMessage() {}
+
+func (x *Call) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Call.ProtoReflect.Descriptor instead.
+func (*Call) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Call) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *Call) GetParentIndex() uint32 {
+	if x != nil {
+		return x.ParentIndex
+	}
+	return 0
+}
+
+func (x *Call) GetDepth() uint32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *Call) GetCallType() CallType {
+	if x != nil {
+		return x.CallType
+	}
+	return CallType_UNSPECIFIED
+}
+
+func (x *Call) GetCaller() []byte {
+	if x != nil {
+		return x.Caller
+	}
+	return nil
+}
+
+func (x *Call) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *Call) GetValue() *BigInt {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Call) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *Call) GetGasConsumed() uint64 {
+	if x != nil {
+		return x.GasConsumed
+	}
+	return 0
+}
+
+func (x *Call) GetStatusFailed() bool {
+	if x != nil {
+		return x.StatusFailed
+	}
+	return false
+}
+
+func (x *Call) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Call) GetStatusReverted() bool {
+	if x != nil {
+		return x.StatusReverted
+	}
+	return false
+}
+
+func (x *Call) GetReturnData() []byte {
+	if x != nil {
+		return x.ReturnData
+	}
+	return nil
+}
+
+func (x *Call) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *Call) GetExecutedCode() bool {
+	if x != nil {
+		return x.ExecutedCode
+	}
+	return false
+}
+
+func (x *Call) GetSuicide() bool {
+	if x != nil {
+		return x.Suicide
+	}
+	return false
+}
+
+func (x *Call) GetStorageChanges() []*StorageChange {
+	if x != nil {
+		return x.StorageChanges
+	}
+	return nil
+}
+
+func (x *Call) GetBalanceChanges() []*BalanceChange {
+	if x != nil {
+		return x.BalanceChanges
+	}
+	return nil
+}
+
+func (x *Call) GetNonceChanges() []*NonceChange {
+	if x != nil {
+		return x.NonceChanges
+	}
+	return nil
+}
+
+func (x *Call) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *Call) GetCodeChanges() []*CodeChange {
+	if x != nil {
+		return x.CodeChanges
+	}
+	return nil
+}
+
+func (x *Call) GetStateReverted() bool {
+	if x != nil {
+		return x.StateReverted
+	}
+	return false
+}
+
+func (x *Call) GetBeginOrdinal() uint64 {
+	if x != nil {
+		return x.BeginOrdinal
+	}
+	return 0
+}
+
+func (x *Call) GetEndOrdinal() uint64 {
+	if x != nil {
+		return x.EndOrdinal
+	}
+	return 0
+}
+
+func (x *Call) GetAccountCreations() []*AccountCreation {
+	if x != nil {
+		return x.AccountCreations
+	}
+	return nil
+}
+
+type StorageChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Key           []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	OldValue      []byte                 `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      []byte                 `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	Ordinal       uint64                 `protobuf:"varint,5,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StorageChange) Reset() {
+	*x = StorageChange{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageChange) ProtoMessage() {}
+
+func (x *StorageChange) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageChange.ProtoReflect.Descriptor instead.
+func (*StorageChange) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StorageChange) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *StorageChange) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StorageChange) GetOldValue() []byte {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *StorageChange) GetNewValue() []byte {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+func (x *StorageChange) GetOrdinal() uint64 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type BalanceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	OldValue      *BigInt                `protobuf:"bytes,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      *BigInt                `protobuf:"bytes,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	Reason        BalanceChange_Reason   `protobuf:"varint,4,opt,name=reason,proto3,enum=sf.ethereum.type.v2.BalanceChange_Reason" json:"reason,omitempty"`
+	Ordinal       uint64                 `protobuf:"varint,5,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceChange) Reset() {
+	*x = BalanceChange{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceChange) ProtoMessage() {}
+
+func (x *BalanceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceChange.ProtoReflect.Descriptor instead.
+func (*BalanceChange) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BalanceChange) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *BalanceChange) GetOldValue() *BigInt {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *BalanceChange) GetNewValue() *BigInt {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+func (x *BalanceChange) GetReason() BalanceChange_Reason {
+	if x != nil {
+		return x.Reason
+	}
+	return BalanceChange_REASON_UNKNOWN
+}
+
+func (x *BalanceChange) GetOrdinal() uint64 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type NonceChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	OldValue      uint64                 `protobuf:"varint,2,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      uint64                 `protobuf:"varint,3,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	Ordinal       uint64                 `protobuf:"varint,4,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NonceChange) Reset() {
+	*x = NonceChange{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NonceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NonceChange) ProtoMessage() {}
+
+func (x *NonceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NonceChange.ProtoReflect.Descriptor instead.
+func (*NonceChange) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NonceChange) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *NonceChange) GetOldValue() uint64 {
+	if x != nil {
+		return x.OldValue
+	}
+	return 0
+}
+
+func (x *NonceChange) GetNewValue() uint64 {
+	if x != nil {
+		return x.NewValue
+	}
+	return 0
+}
+
+func (x *NonceChange) GetOrdinal() uint64 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type AccountCreation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       []byte                 `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Ordinal       uint64                 `protobuf:"varint,2,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountCreation) Reset() {
+	*x = AccountCreation{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountCreation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountCreation) ProtoMessage() {}
+
+func (x *AccountCreation) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountCreation.ProtoReflect.Descriptor instead.
+func (*AccountCreation) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AccountCreation) GetAccount() []byte {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *AccountCreation) GetOrdinal() uint64 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+type CodeChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       []byte                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	OldHash       []byte                 `protobuf:"bytes,2,opt,name=old_hash,json=oldHash,proto3" json:"old_hash,omitempty"`
+	OldCode       []byte                 `protobuf:"bytes,3,opt,name=old_code,json=oldCode,proto3" json:"old_code,omitempty"`
+	NewHash       []byte                 `protobuf:"bytes,4,opt,name=new_hash,json=newHash,proto3" json:"new_hash,omitempty"`
+	NewCode       []byte                 `protobuf:"bytes,5,opt,name=new_code,json=newCode,proto3" json:"new_code,omitempty"`
+	Ordinal       uint64                 `protobuf:"varint,6,opt,name=ordinal,proto3" json:"ordinal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CodeChange) Reset() {
+	*x = CodeChange{}
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CodeChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodeChange) ProtoMessage() {}
+
+func (x *CodeChange) ProtoReflect() protoreflect.Message {
+	mi := &file_sf_ethereum_type_v2_type_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodeChange.ProtoReflect.Descriptor instead.
+func (*CodeChange) Descriptor() ([]byte, []int) {
+	return file_sf_ethereum_type_v2_type_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CodeChange) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *CodeChange) GetOldHash() []byte {
+	if x != nil {
+		return x.OldHash
+	}
+	return nil
+}
+
+func (x *CodeChange) GetOldCode() []byte {
+	if x != nil {
+		return x.OldCode
+	}
+	return nil
+}
+
+func (x *CodeChange) GetNewHash() []byte {
+	if x != nil {
+		return x.NewHash
+	}
+	return nil
+}
+
+func (x *CodeChange) GetNewCode() []byte {
+	if x != nil {
+		return x.NewCode
+	}
+	return nil
+}
+
+func (x *CodeChange) GetOrdinal() uint64 {
+	if x != nil {
+		return x.Ordinal
+	}
+	return 0
+}
+
+var File_sf_ethereum_type_v2_type_proto protoreflect.FileDescriptor
+
+const file_sf_ethereum_type_v2_type_proto_rawDesc = "" +
+	"\n" +
+	"\x1esf/ethereum/type/v2/type.proto\x12\x13sf.ethereum.type.v2\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfc\x04\n" +
+	"\x05Block\x12\x10\n" +
+	"\x03ver\x18\x01 \x01(\x05R\x03ver\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\fR\x04hash\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x04R\x06number\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x04R\x04size\x128\n" +
+	"\x06header\x18\x05 \x01(\v2 .sf.ethereum.type.v2.BlockHeaderR\x06header\x128\n" +
+	"\x06uncles\x18\x06 \x03(\v2 .sf.ethereum.type.v2.BlockHeaderR\x06uncles\x12T\n" +
+	"\x12transaction_traces\x18\n" +
+	" \x03(\v2%.sf.ethereum.type.v2.TransactionTraceR\x11transactionTraces\x12K\n" +
+	"\x0fbalance_changes\x18\v \x03(\v2\".sf.ethereum.type.v2.BalanceChangeR\x0ebalanceChanges\x12I\n" +
+	"\fdetail_level\x18\f \x01(\x0e2&.sf.ethereum.type.v2.Block.DetailLevelR\vdetailLevel\x12B\n" +
+	"\fcode_changes\x18\x14 \x03(\v2\x1f.sf.ethereum.type.v2.CodeChangeR\vcodeChanges\x12<\n" +
+	"\fsystem_calls\x18\x15 \x03(\v2\x19.sf.ethereum.type.v2.CallR\vsystemCalls\"=\n" +
+	"\vDetailLevel\x12\x18\n" +
+	"\x14DETAILLEVEL_EXTENDED\x10\x00\x12\x14\n" +
+	"\x10DETAILLEVEL_BASE\x10\x02\"\xaa\a\n" +
+	"\vBlockHeader\x12\x1f\n" +
+	"\vparent_hash\x18\x01 \x01(\fR\n" +
+	"parentHash\x12\x1d\n" +
+	"\n" +
+	"uncle_hash\x18\x02 \x01(\fR\tuncleHash\x12\x1a\n" +
+	"\bcoinbase\x18\x03 \x01(\fR\bcoinbase\x12\x1d\n" +
+	"\n" +
+	"state_root\x18\x04 \x01(\fR\tstateRoot\x12+\n" +
+	"\x11transactions_root\x18\x05 \x01(\fR\x10transactionsRoot\x12!\n" +
+	"\freceipt_root\x18\x06 \x01(\fR\vreceiptRoot\x12\x1d\n" +
+	"\n" +
+	"logs_bloom\x18\a \x01(\fR\tlogsBloom\x12;\n" +
+	"\n" +
+	"difficulty\x18\b \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\n" +
+	"difficulty\x12\x16\n" +
+	"\x06number\x18\t \x01(\x04R\x06number\x12\x1b\n" +
+	"\tgas_limit\x18\n" +
+	" \x01(\x04R\bgasLimit\x12\x19\n" +
+	"\bgas_used\x18\v \x01(\x04R\agasUsed\x128\n" +
+	"\ttimestamp\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"extra_data\x18\r \x01(\fR\textraData\x12\x19\n" +
+	"\bmix_hash\x18\x0e \x01(\fR\amixHash\x12\x14\n" +
+	"\x05nonce\x18\x0f \x01(\x04R\x05nonce\x12\x12\n" +
+	"\x04hash\x18\x10 \x01(\fR\x04hash\x12F\n" +
+	"\x10total_difficulty\x18\x11 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\x0ftotalDifficulty\x12D\n" +
+	"\x10base_fee_per_gas\x18\x12 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\rbaseFeePerGas\x12)\n" +
+	"\x10withdrawals_root\x18\x13 \x01(\fR\x0fwithdrawalsRoot\x12'\n" +
+	"\rblob_gas_used\x18\x16 \x01(\x04H\x00R\vblobGasUsed\x88\x01\x01\x12+\n" +
+	"\x0fexcess_blob_gas\x18\x17 \x01(\x04H\x01R\rexcessBlobGas\x88\x01\x01\x12,\n" +
+	"\x12parent_beacon_root\x18\x18 \x01(\fR\x10parentBeaconRoot\x12#\n" +
+	"\rrequests_hash\x18\x19 \x01(\fR\frequestsHashB\x10\n" +
+	"\x0e_blob_gas_usedB\x12\n" +
+	"\x10_excess_blob_gas\"\x1e\n" +
+	"\x06BigInt\x12\x14\n" +
+	"\x05bytes\x18\x01 \x01(\fR\x05bytes\"\xc5\t\n" +
+	"\x10TransactionTrace\x12\x0e\n" +
+	"\x02to\x18\x01 \x01(\fR\x02to\x12\x14\n" +
+	"\x05nonce\x18\x02 \x01(\x04R\x05nonce\x128\n" +
+	"\tgas_price\x18\x03 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\bgasPrice\x12\x1b\n" +
+	"\tgas_limit\x18\x04 \x01(\x04R\bgasLimit\x121\n" +
+	"\x05value\x18\x05 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\x05value\x12\x14\n" +
+	"\x05input\x18\x06 \x01(\fR\x05input\x12\f\n" +
+	"\x01v\x18\a \x01(\fR\x01v\x12\f\n" +
+	"\x01r\x18\b \x01(\fR\x01r\x12\f\n" +
+	"\x01s\x18\t \x01(\fR\x01s\x12\x19\n" +
+	"\bgas_used\x18\n" +
+	" \x01(\x04R\agasUsed\x12B\n" +
+	"\x0fmax_fee_per_gas\x18\v \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\fmaxFeePerGas\x12>\n" +
+	"\x04type\x18\f \x01(\x0e2*.sf.ethereum.type.v2.TransactionTrace.TypeR\x04type\x12S\n" +
+	"\x18max_priority_fee_per_gas\x18\r \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\x14maxPriorityFeePerGas\x12A\n" +
+	"\vaccess_list\x18\x0e \x03(\v2 .sf.ethereum.type.v2.AccessTupleR\n" +
+	"accessList\x12\x14\n" +
+	"\x05index\x18\x14 \x01(\rR\x05index\x12\x12\n" +
+	"\x04hash\x18\x15 \x01(\fR\x04hash\x12\x12\n" +
+	"\x04from\x18\x16 \x01(\fR\x04from\x12\x1f\n" +
+	"\vreturn_data\x18\x17 \x01(\fR\n" +
+	"returnData\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x18 \x01(\fR\tpublicKey\x12#\n" +
+	"\rbegin_ordinal\x18\x19 \x01(\x04R\fbeginOrdinal\x12\x1f\n" +
+	"\vend_ordinal\x18\x1a \x01(\x04R\n" +
+	"endOrdinal\x12C\n" +
+	"\x06status\x18\x1e \x01(\x0e2+.sf.ethereum.type.v2.TransactionTraceStatusR\x06status\x12A\n" +
+	"\areceipt\x18\x1f \x01(\v2'.sf.ethereum.type.v2.TransactionReceiptR\areceipt\x12/\n" +
+	"\x05calls\x18  \x03(\v2\x19.sf.ethereum.type.v2.CallR\x05calls\x12\x1e\n" +
+	"\bblob_gas\x18! \x01(\x04H\x00R\ablobGas\x88\x01\x01\x12D\n" +
+	"\x10blob_gas_fee_cap\x18\" \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\rblobGasFeeCap\x12\x1f\n" +
+	"\vblob_hashes\x18# \x03(\fR\n" +
+	"blobHashes\"y\n" +
+	"\x04Type\x12\x13\n" +
+	"\x0fTRX_TYPE_LEGACY\x10\x00\x12\x18\n" +
+	"\x14TRX_TYPE_ACCESS_LIST\x10\x01\x12\x18\n" +
+	"\x14TRX_TYPE_DYNAMIC_FEE\x10\x02\x12\x11\n" +
+	"\rTRX_TYPE_BLOB\x10\x03\x12\x15\n" +
+	"\x11TRX_TYPE_SET_CODE\x10\x04B\v\n" +
+	"\t_blob_gas\"J\n" +
+	"\vAccessTuple\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12!\n" +
+	"\fstorage_keys\x18\x02 \x03(\fR\vstorageKeys\"\xae\x02\n" +
+	"\x12TransactionReceipt\x12\x1d\n" +
+	"\n" +
+	"state_root\x18\x01 \x01(\fR\tstateRoot\x12.\n" +
+	"\x13cumulative_gas_used\x18\x02 \x01(\x04R\x11cumulativeGasUsed\x12\x1d\n" +
+	"\n" +
+	"logs_bloom\x18\x03 \x01(\fR\tlogsBloom\x12,\n" +
+	"\x04logs\x18\x04 \x03(\v2\x18.sf.ethereum.type.v2.LogR\x04logs\x12'\n" +
+	"\rblob_gas_used\x18\x05 \x01(\x04H\x00R\vblobGasUsed\x88\x01\x01\x12A\n" +
+	"\x0eblob_gas_price\x18\x06 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\fblobGasPriceB\x10\n" +
+	"\x0e_blob_gas_used\"\x9b\x01\n" +
+	"\x03Log\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x16\n" +
+	"\x06topics\x18\x02 \x03(\fR\x06topics\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x14\n" +
+	"\x05index\x18\x04 \x01(\rR\x05index\x12\x1e\n" +
+	"\n" +
+	"blockIndex\x18\x06 \x01(\rR\n" +
+	"blockIndex\x12\x18\n" +
+	"\aordinal\x18\a \x01(\x04R\aordinal\"\xb4\b\n" +
+	"\x04Call\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\rR\x05index\x12!\n" +
+	"\fparent_index\x18\x02 \x01(\rR\vparentIndex\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\rR\x05depth\x12:\n" +
+	"\tcall_type\x18\x04 \x01(\x0e2\x1d.sf.ethereum.type.v2.CallTypeR\bcallType\x12\x16\n" +
+	"\x06caller\x18\x05 \x01(\fR\x06caller\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\fR\aaddress\x121\n" +
+	"\x05value\x18\a \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\x05value\x12\x1b\n" +
+	"\tgas_limit\x18\b \x01(\x04R\bgasLimit\x12!\n" +
+	"\fgas_consumed\x18\t \x01(\x04R\vgasConsumed\x12#\n" +
+	"\rstatus_failed\x18\n" +
+	" \x01(\bR\fstatusFailed\x12%\n" +
+	"\x0efailure_reason\x18\v \x01(\tR\rfailureReason\x12'\n" +
+	"\x0fstatus_reverted\x18\f \x01(\bR\x0estatusReverted\x12\x1f\n" +
+	"\vreturn_data\x18\r \x01(\fR\n" +
+	"returnData\x12\x14\n" +
+	"\x05input\x18\x0e \x01(\fR\x05input\x12#\n" +
+	"\rexecuted_code\x18\x0f \x01(\bR\fexecutedCode\x12\x18\n" +
+	"\asuicide\x18\x10 \x01(\bR\asuicide\x12K\n" +
+	"\x0fstorage_changes\x18\x15 \x03(\v2\".sf.ethereum.type.v2.StorageChangeR\x0estorageChanges\x12K\n" +
+	"\x0fbalance_changes\x18\x16 \x03(\v2\".sf.ethereum.type.v2.BalanceChangeR\x0ebalanceChanges\x12E\n" +
+	"\rnonce_changes\x18\x18 \x03(\v2 .sf.ethereum.type.v2.NonceChangeR\fnonceChanges\x12,\n" +
+	"\x04logs\x18\x19 \x03(\v2\x18.sf.ethereum.type.v2.LogR\x04logs\x12B\n" +
+	"\fcode_changes\x18\x1a \x03(\v2\x1f.sf.ethereum.type.v2.CodeChangeR\vcodeChanges\x12%\n" +
+	"\x0estate_reverted\x18\x1e \x01(\bR\rstateReverted\x12#\n" +
+	"\rbegin_ordinal\x18\x1f \x01(\x04R\fbeginOrdinal\x12\x1f\n" +
+	"\vend_ordinal\x18  \x01(\x04R\n" +
+	"endOrdinal\x12Q\n" +
+	"\x11account_creations\x18! \x03(\v2$.sf.ethereum.type.v2.AccountCreationR\x10accountCreations\"\x8f\x01\n" +
+	"\rStorageChange\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12\x1b\n" +
+	"\told_value\x18\x03 \x01(\fR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x04 \x01(\fR\bnewValue\x12\x18\n" +
+	"\aordinal\x18\x05 \x01(\x04R\aordinal\"\xaf\x05\n" +
+	"\rBalanceChange\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x128\n" +
+	"\told_value\x18\x02 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\boldValue\x128\n" +
+	"\tnew_value\x18\x03 \x01(\v2\x1b.sf.ethereum.type.v2.BigIntR\bnewValue\x12A\n" +
+	"\x06reason\x18\x04 \x01(\x0e2).sf.ethereum.type.v2.BalanceChange.ReasonR\x06reason\x12\x18\n" +
+	"\aordinal\x18\x05 \x01(\x04R\aordinal\"\xb2\x03\n" +
+	"\x06Reason\x12\x12\n" +
+	"\x0eREASON_UNKNOWN\x10\x00\x12\x1c\n" +
+	"\x18REASON_REWARD_MINE_UNCLE\x10\x01\x12\x1c\n" +
+	"\x18REASON_REWARD_MINE_BLOCK\x10\x02\x12\x1e\n" +
+	"\x1aREASON_DAO_REFUND_CONTRACT\x10\x03\x12\x1d\n" +
+	"\x19REASON_DAO_ADJUST_BALANCE\x10\x04\x12\x13\n" +
+	"\x0fREASON_TRANSFER\x10\x05\x12\x1a\n" +
+	"\x16REASON_GENESIS_BALANCE\x10\x06\x12\x12\n" +
+	"\x0eREASON_GAS_BUY\x10\a\x12!\n" +
+	"\x1dREASON_REWARD_TRANSACTION_FEE\x10\b\x12\x15\n" +
+	"\x11REASON_GAS_REFUND\x10\t\x12\x18\n" +
+	"\x14REASON_TOUCH_ACCOUNT\x10\n" +
+	"\x12\x19\n" +
+	"\x15REASON_SUICIDE_REFUND\x10\v\x12 \n" +
+	"\x1cREASON_CALL_BALANCE_OVERRIDE\x10\f\x12\x1b\n" +
+	"\x17REASON_SUICIDE_WITHDRAW\x10\r\x12\x0f\n" +
+	"\vREASON_BURN\x10\x0f\x12\x15\n" +
+	"\x11REASON_WITHDRAWAL\x10\x10\"{\n" +
+	"\vNonceChange\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x1b\n" +
+	"\told_value\x18\x02 \x01(\x04R\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x03 \x01(\x04R\bnewValue\x12\x18\n" +
+	"\aordinal\x18\x04 \x01(\x04R\aordinal\"E\n" +
+	"\x0fAccountCreation\x12\x18\n" +
+	"\aaccount\x18\x01 \x01(\fR\aaccount\x12\x18\n" +
+	"\aordinal\x18\x02 \x01(\x04R\aordinal\"\xac\x01\n" +
+	"\n" +
+	"CodeChange\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x19\n" +
+	"\bold_hash\x18\x02 \x01(\fR\aoldHash\x12\x19\n" +
+	"\bold_code\x18\x03 \x01(\fR\aoldCode\x12\x19\n" +
+	"\bnew_hash\x18\x04 \x01(\fR\anewHash\x12\x19\n" +
+	"\bnew_code\x18\x05 \x01(\fR\anewCode\x12\x18\n" +
+	"\aordinal\x18\x06 \x01(\x04R\aordinal*N\n" +
+	"\x16TransactionTraceStatus\x12\v\n" +
+	"\aUNKNOWN\x10\x00\x12\r\n" +
+	"\tSUCCEEDED\x10\x01\x12\n" +
+	"\n" +
+	"\x06FAILED\x10\x02\x12\f\n" +
+	"\bREVERTED\x10\x03*Y\n" +
+	"\bCallType\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04CALL\x10\x01\x12\f\n" +
+	"\bCALLCODE\x10\x02\x12\f\n" +
+	"\bDELEGATE\x10\x03\x12\n" +
+	"\n" +
+	"\x06STATIC\x10\x04\x12\n" +
+	"\n" +
+	"\x06CREATE\x10\x05B;Z9github.com/erigontech/erigon/eth/tracers/live/pbeth;pbethb\x06proto3"
+
+var (
+	file_sf_ethereum_type_v2_type_proto_rawDescOnce sync.Once
+	file_sf_ethereum_type_v2_type_proto_rawDescData []byte
+)
+
+func file_sf_ethereum_type_v2_type_proto_rawDescGZIP() []byte {
+	file_sf_ethereum_type_v2_type_proto_rawDescOnce.Do(func() {
+		file_sf_ethereum_type_v2_type_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sf_ethereum_type_v2_type_proto_rawDesc), len(file_sf_ethereum_type_v2_type_proto_rawDesc)))
+	})
+	return file_sf_ethereum_type_v2_type_proto_rawDescData
+}
+
+var file_sf_ethereum_type_v2_type_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_sf_ethereum_type_v2_type_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_sf_ethereum_type_v2_type_proto_goTypes = []any{
+	(TransactionTraceStatus)(0),   // 0: sf.ethereum.type.v2.TransactionTraceStatus
+	(CallType)(0),                 // 1: sf.ethereum.type.v2.CallType
+	(Block_DetailLevel)(0),        // 2: sf.ethereum.type.v2.Block.DetailLevel
+	(TransactionTrace_Type)(0),    // 3: sf.ethereum.type.v2.TransactionTrace.Type
+	(BalanceChange_Reason)(0),     // 4: sf.ethereum.type.v2.BalanceChange.Reason
+	(*Block)(nil),                 // 5: sf.ethereum.type.v2.Block
+	(*BlockHeader)(nil),           // 6: sf.ethereum.type.v2.BlockHeader
+	(*BigInt)(nil),                // 7: sf.ethereum.type.v2.BigInt
+	(*TransactionTrace)(nil),      // 8: sf.ethereum.type.v2.TransactionTrace
+	(*AccessTuple)(nil),           // 9: sf.ethereum.type.v2.AccessTuple
+	(*TransactionReceipt)(nil),    // 10: sf.ethereum.type.v2.TransactionReceipt
+	(*Log)(nil),                   // 11: sf.ethereum.type.v2.Log
+	(*Call)(nil),                  // 12: sf.ethereum.type.v2.Call
+	(*StorageChange)(nil),         // 13: sf.ethereum.type.v2.StorageChange
+	(*BalanceChange)(nil),         // 14: sf.ethereum.type.v2.BalanceChange
+	(*NonceChange)(nil),           // 15: sf.ethereum.type.v2.NonceChange
+	(*AccountCreation)(nil),       // 16: sf.ethereum.type.v2.AccountCreation
+	(*CodeChange)(nil),            // 17: sf.ethereum.type.v2.CodeChange
+	(*timestamppb.Timestamp)(nil), // 18: google.protobuf.Timestamp
+}
+var file_sf_ethereum_type_v2_type_proto_depIdxs = []int32{
+	6,  // 0: sf.ethereum.type.v2.Block.header:type_name -> sf.ethereum.type.v2.BlockHeader
+	6,  // 1: sf.ethereum.type.v2.Block.uncles:type_name -> sf.ethereum.type.v2.BlockHeader
+	8,  // 2: sf.ethereum.type.v2.Block.transaction_traces:type_name -> sf.ethereum.type.v2.TransactionTrace
+	14, // 3: sf.ethereum.type.v2.Block.balance_changes:type_name -> sf.ethereum.type.v2.BalanceChange
+	2,  // 4: sf.ethereum.type.v2.Block.detail_level:type_name -> sf.ethereum.type.v2.Block.DetailLevel
+	17, // 5: sf.ethereum.type.v2.Block.code_changes:type_name -> sf.ethereum.type.v2.CodeChange
+	12, // 6: sf.ethereum.type.v2.Block.system_calls:type_name -> sf.ethereum.type.v2.Call
+	7,  // 7: sf.ethereum.type.v2.BlockHeader.difficulty:type_name -> sf.ethereum.type.v2.BigInt
+	18, // 8: sf.ethereum.type.v2.BlockHeader.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 9: sf.ethereum.type.v2.BlockHeader.total_difficulty:type_name -> sf.ethereum.type.v2.BigInt
+	7,  // 10: sf.ethereum.type.v2.BlockHeader.base_fee_per_gas:type_name -> sf.ethereum.type.v2.BigInt
+	7,  // 11: sf.ethereum.type.v2.TransactionTrace.gas_price:type_name -> sf.ethereum.type.v2.BigInt
+	7,  // 12: sf.ethereum.type.v2.TransactionTrace.value:type_name -> sf.ethereum.type.v2.BigInt
+	7,  // 13: sf.ethereum.type.v2.TransactionTrace.max_fee_per_gas:type_name -> sf.ethereum.type.v2.BigInt
+	3,  // 14: sf.ethereum.type.v2.TransactionTrace.type:type_name -> sf.ethereum.type.v2.TransactionTrace.Type
+	7,  // 15: sf.ethereum.type.v2.TransactionTrace.max_priority_fee_per_gas:type_name -> sf.ethereum.type.v2.BigInt
+	9,  // 16: sf.ethereum.type.v2.TransactionTrace.access_list:type_name -> sf.ethereum.type.v2.AccessTuple
+	0,  // 17: sf.ethereum.type.v2.TransactionTrace.status:type_name -> sf.ethereum.type.v2.TransactionTraceStatus
+	10, // 18: sf.ethereum.type.v2.TransactionTrace.receipt:type_name -> sf.ethereum.type.v2.TransactionReceipt
+	12, // 19: sf.ethereum.type.v2.TransactionTrace.calls:type_name -> sf.ethereum.type.v2.Call
+	7,  // 20: sf.ethereum.type.v2.TransactionTrace.blob_gas_fee_cap:type_name -> sf.ethereum.type.v2.BigInt
+	11, // 21: sf.ethereum.type.v2.TransactionReceipt.logs:type_name -> sf.ethereum.type.v2.Log
+	7,  // 22: sf.ethereum.type.v2.TransactionReceipt.blob_gas_price:type_name -> sf.ethereum.type.v2.BigInt
+	1,  // 23: sf.ethereum.type.v2.Call.call_type:type_name -> sf.ethereum.type.v2.CallType
+	7,  // 24: sf.ethereum.type.v2.Call.value:type_name -> sf.ethereum.type.v2.BigInt
+	13, // 25: sf.ethereum.type.v2.Call.storage_changes:type_name -> sf.ethereum.type.v2.StorageChange
+	14, // 26: sf.ethereum.type.v2.Call.balance_changes:type_name -> sf.ethereum.type.v2.BalanceChange
+	15, // 27: sf.ethereum.type.v2.Call.nonce_changes:type_name -> sf.ethereum.type.v2.NonceChange
+	11, // 28: sf.ethereum.type.v2.Call.logs:type_name -> sf.ethereum.type.v2.Log
+	17, // 29: sf.ethereum.type.v2.Call.code_changes:type_name -> sf.ethereum.type.v2.CodeChange
+	16, // 30: sf.ethereum.type.v2.Call.account_creations:type_name -> sf.ethereum.type.v2.AccountCreation
+	7,  // 31: sf.ethereum.type.v2.BalanceChange.old_value:type_name -> sf.ethereum.type.v2.BigInt
+	7,  // 32: sf.ethereum.type.v2.BalanceChange.new_value:type_name -> sf.ethereum.type.v2.BigInt
+	4,  // 33: sf.ethereum.type.v2.BalanceChange.reason:type_name -> sf.ethereum.type.v2.BalanceChange.Reason
+	34, // [34:34] is the sub-list for method output_type
+	34, // [34:34] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_sf_ethereum_type_v2_type_proto_init() }
+func file_sf_ethereum_type_v2_type_proto_init() {
+	if File_sf_ethereum_type_v2_type_proto != nil {
+		return
+	}
+	file_sf_ethereum_type_v2_type_proto_msgTypes[1].OneofWrappers = []any{}
+	file_sf_ethereum_type_v2_type_proto_msgTypes[3].OneofWrappers = []any{}
+	file_sf_ethereum_type_v2_type_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sf_ethereum_type_v2_type_proto_rawDesc), len(file_sf_ethereum_type_v2_type_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sf_ethereum_type_v2_type_proto_goTypes,
+		DependencyIndexes: file_sf_ethereum_type_v2_type_proto_depIdxs,
+		EnumInfos:         file_sf_ethereum_type_v2_type_proto_enumTypes,
+		MessageInfos:      file_sf_ethereum_type_v2_type_proto_msgTypes,
+	}.Build()
+	File_sf_ethereum_type_v2_type_proto = out.File
+	file_sf_ethereum_type_v2_type_proto_goTypes = nil
+	file_sf_ethereum_type_v2_type_proto_depIdxs = nil
+}
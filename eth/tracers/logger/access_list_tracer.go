@@ -22,6 +22,8 @@ package logger
 import (
 	"sort"
 
+	"github.com/holiman/uint256"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/types"
@@ -175,10 +177,30 @@ func NewAccessListTracer(acl types.AccessList, exclude map[common.Address]struct
 
 func (a *AccessListTracer) Hooks() *tracing.Hooks {
 	return &tracing.Hooks{
+		OnEnter:  a.OnEnter,
 		OnOpcode: a.OnOpcode,
 	}
 }
 
+// OnEnter - EIP-7702: call of delegated account loads code of delegate, so delegate must be in access list too
+// (including the delegate of txn recipient).
+func (a *AccessListTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if a.state == nil || precompile {
+		return
+	}
+	switch vm.OpCode(typ) {
+	case vm.CREATE, vm.CREATE2:
+		return
+	}
+	delegate, ok, err := a.state.GetDelegatedDesignation(to)
+	if err != nil || !ok {
+		return
+	}
+	if _, ok := a.excl[delegate]; !ok {
+		a.list.addAddress(delegate)
+	}
+}
+
 func (a *AccessListTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
 	stackData := scope.StackData()
 	stackLen := len(stackData)
@@ -0,0 +1,76 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package logger_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/runtime"
+	"github.com/erigontech/erigon/eth/tracers/logger"
+)
+
+func TestAccessListTracerDelegation(t *testing.T) {
+	require := require.New(t)
+	db := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	tx, err := db.BeginTemporalRw(context.Background())
+	require.NoError(err)
+	defer tx.Rollback()
+	domains, err := libstate.NewSharedDomains(tx, log.New())
+	require.NoError(err)
+	defer domains.Close()
+	ibs := state.New(state.NewReaderV3(domains))
+
+	// entry -> CALL eoa (delegated to impl), impl code: SLOAD(1)
+	entry, eoa, impl := common.HexToAddress("0xaa"), common.HexToAddress("0xbb"), common.HexToAddress("0xcc")
+	require.NoError(ibs.SetCode(impl, []byte{byte(vm.PUSH1), 1, byte(vm.SLOAD), byte(vm.STOP)}))
+	require.NoError(ibs.SetCode(eoa, types.AddressToDelegation(impl)))
+	require.NoError(ibs.SetCode(entry, []byte{
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH1), 0xbb, byte(vm.GAS), byte(vm.CALL), byte(vm.STOP),
+	}))
+
+	tracer := logger.NewAccessListTracer(nil, nil, ibs)
+	_, _, err = runtime.Call(entry, nil, &runtime.Config{State: ibs, EVMConfig: vm.Config{Tracer: tracer.Hooks()}})
+	require.NoError(err)
+
+	al := tracer.AccessListSorted()
+	addrs := make([]common.Address, 0, len(al))
+	for _, tuple := range al {
+		addrs = append(addrs, tuple.Address)
+	}
+	require.ElementsMatch([]common.Address{eoa, impl}, addrs)
+
+	// txn recipient is delegated
+	tracer = logger.NewAccessListTracer(nil, nil, ibs)
+	_, _, err = runtime.Call(eoa, nil, &runtime.Config{State: ibs, EVMConfig: vm.Config{Tracer: tracer.Hooks()}})
+	require.NoError(err)
+	require.ElementsMatch(types.AccessList{
+		{Address: impl, StorageKeys: []common.Hash{}},
+		{Address: eoa, StorageKeys: []common.Hash{common.BytesToHash([]byte{1})}}, // delegated code runs in context of eoa
+	}, tracer.AccessListSorted())
+}
@@ -0,0 +1,489 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func init() {
+	register("bundlerCollectorTracer", newBundlerCollectorTracer)
+}
+
+const (
+	// bundlerMaxData is the amount of returned data and of the call outputs which is kept
+	bundlerMaxData = 2000
+	// KECCAK256 inputs are kept if their length is in this exclusive range, shorter and longer ones
+	// aren't mapping keys
+	bundlerMinKeccak, bundlerMaxKeccak = 20, 512
+	// bundlerAssociatedSlots is how many slots after keccak(sender || x) are associated with the sender
+	bundlerAssociatedSlots = 128
+)
+
+// beforeExecutionTopic is the topic of the BeforeExecution() event of the EntryPoint, which separates
+// the validation of the UserOperations from their execution.
+var beforeExecutionTopic = crypto.Keccak256Hash([]byte("BeforeExecution()"))
+
+// bundlerBannedOpcodes may not be used in the validation of a UserOperation, see OP-011 and OP-080
+// of ERC-7562.
+var bundlerBannedOpcodes = map[vm.OpCode]string{
+	vm.GASPRICE:     "OP-011",
+	vm.GASLIMIT:     "OP-011",
+	vm.DIFFICULTY:   "OP-011",
+	vm.TIMESTAMP:    "OP-011",
+	vm.BASEFEE:      "OP-011",
+	vm.BLOCKHASH:    "OP-011",
+	vm.NUMBER:       "OP-011",
+	vm.ORIGIN:       "OP-011",
+	vm.COINBASE:     "OP-011",
+	vm.SELFDESTRUCT: "OP-011",
+	vm.INVALID:      "OP-011",
+	vm.BLOBHASH:     "OP-011",
+	vm.BLOBBASEFEE:  "OP-011",
+	vm.BALANCE:      "OP-080",
+	vm.SELFBALANCE:  "OP-080",
+}
+
+// bundlerCollectorTracer is a native implementation of the bundlerCollectorTracer of the ERC-4337
+// reference bundler, with the same result. It collects what the validation of UserOperations in
+// EntryPoint.simulateValidation or handleOps accesses, separately for each call of the EntryPoint
+// (the validation of the factory, the account and the paymaster), until the BeforeExecution event.
+//
+// If the sender of the UserOperation is given in the config, the result also has the violations of
+// the opcode and storage rules of ERC-7562 for unstaked entities. Bundlers ignore the ones which are
+// allowed for staked entities.
+//
+// Example:
+//
+//	> debug.traceCall({to: entryPoint, data: simulateValidation(userOp)}, "latest", {tracer: "bundlerCollectorTracer", tracerConfig: {sender: userOp.sender}})
+type bundlerCollectorTracer struct {
+	env               *tracing.VMContext
+	config            bundlerCollectorTracerConfig
+	activePrecompiles []common.Address
+
+	callsFromEntryPoint []*bundlerTopLevelCall
+	currentLevel        *bundlerTopLevelCall
+	keccak              []hexutil.Bytes
+	calls               []any
+	logs                []bundlerLog
+	entryPoint          common.Address
+	stopCollecting      bool
+	lastOp              vm.OpCode
+	lastExtCode         *common.Address // address of the last EXTCODE* opcode
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+type bundlerCollectorTracerConfig struct {
+	Sender *common.Address `json:"sender"` // If set, the rules of ERC-7562 are checked
+}
+
+type bundlerTopLevelCall struct {
+	TopLevelMethodSig     hexutil.Bytes                           `json:"topLevelMethodSig"`
+	TopLevelTargetAddress common.Address                          `json:"topLevelTargetAddress"`
+	Opcodes               map[string]int                          `json:"opcodes"`
+	Access                map[common.Address]*bundlerAccessInfo   `json:"access"`
+	ContractSize          map[common.Address]*bundlerContractSize `json:"contractSize"`
+	ExtCodeAccessInfo     map[common.Address]string               `json:"extCodeAccessInfo"`
+	OOG                   bool                                    `json:"oog,omitempty"`
+}
+
+type bundlerAccessInfo struct {
+	Reads  map[common.Hash]common.Hash `json:"reads"`
+	Writes map[common.Hash]int         `json:"writes"`
+}
+
+type bundlerContractSize struct {
+	ContractSize int    `json:"contractSize"`
+	Opcode       string `json:"opcode"`
+}
+
+type bundlerMethodInfo struct {
+	Type   string         `json:"type"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Method hexutil.Bytes  `json:"method"`
+	Value  *hexutil.Big   `json:"value"`
+	Gas    uint64         `json:"gas"`
+}
+
+type bundlerExitInfo struct {
+	Type    string        `json:"type"`
+	GasUsed uint64        `json:"gasUsed"`
+	Data    hexutil.Bytes `json:"data"`
+}
+
+type bundlerLog struct {
+	Topics []common.Hash `json:"topics"`
+	Data   hexutil.Bytes `json:"data"`
+}
+
+type bundlerViolation struct {
+	Level   int            `json:"level"` // index in callsFromEntryPoint
+	Rule    string         `json:"rule"`
+	Address common.Address `json:"address"`
+	Message string         `json:"message"`
+}
+
+type bundlerCollectorResult struct {
+	CallsFromEntryPoint []*bundlerTopLevelCall `json:"callsFromEntryPoint"`
+	Keccak              []hexutil.Bytes        `json:"keccak"`
+	Calls               []any                  `json:"calls"`
+	Logs                []bundlerLog           `json:"logs"`
+	Debug               []string               `json:"debug"`
+	Violations          []bundlerViolation     `json:"violations,omitempty"`
+}
+
+func newBundlerCollectorTracer(ctx *tracers.Context, cfg json.RawMessage) (*tracers.Tracer, error) {
+	var config bundlerCollectorTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	t := &bundlerCollectorTracer{
+		config:              config,
+		callsFromEntryPoint: []*bundlerTopLevelCall{},
+		keccak:              []hexutil.Bytes{},
+		calls:               []any{},
+		logs:                []bundlerLog{},
+	}
+	return &tracers.Tracer{
+		Hooks: &tracing.Hooks{
+			OnTxStart: t.OnTxStart,
+			OnEnter:   t.OnEnter,
+			OnExit:    t.OnExit,
+			OnOpcode:  t.OnOpcode,
+			OnFault:   t.OnFault,
+		},
+		GetResult: t.GetResult,
+		Stop:      t.Stop,
+	}, nil
+}
+
+func (t *bundlerCollectorTracer) OnTxStart(env *tracing.VMContext, tx types.Transaction, from common.Address) {
+	t.env = env
+	rules := env.ChainConfig.Rules(env.BlockNumber, env.Time)
+	t.activePrecompiles = vm.ActivePrecompiles(rules)
+}
+
+func (t *bundlerCollectorTracer) isPrecompiled(addr common.Address) bool {
+	for _, p := range t.activePrecompiles {
+		if p == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *bundlerCollectorTracer) OnEnter(depth int, typ byte, from common.Address, to common.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if depth == 0 || t.stopCollecting || atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	call := bundlerMethodInfo{
+		Type:  vm.OpCode(typ).String(),
+		From:  from,
+		To:    to,
+		Value: new(hexutil.Big),
+		Gas:   gas,
+	}
+	if len(input) >= 4 {
+		call.Method = common.CopyBytes(input[:4])
+	} else {
+		call.Method = common.CopyBytes(input)
+	}
+	if value != nil {
+		call.Value = (*hexutil.Big)(value.ToBig())
+	}
+	t.calls = append(t.calls, call)
+}
+
+func (t *bundlerCollectorTracer) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if depth == 0 || t.stopCollecting || atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	typ := "RETURN"
+	if err != nil {
+		typ = "REVERT"
+	}
+	t.calls = append(t.calls, bundlerExitInfo{Type: typ, GasUsed: gasUsed, Data: truncate(output)})
+}
+
+func (t *bundlerCollectorTracer) OnFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+	if t.currentLevel != nil && !t.stopCollecting && errors.Is(err, vm.ErrOutOfGas) {
+		t.currentLevel.OOG = true
+	}
+}
+
+func (t *bundlerCollectorTracer) OnOpcode(pc uint64, opcode byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	op := vm.OpCode(opcode)
+	stack := scope.StackData()
+	// peek returns the n-th item from the top of the stack
+	peek := func(n int) *uint256.Int {
+		if n >= len(stack) {
+			return new(uint256.Int)
+		}
+		return &stack[len(stack)-1-n]
+	}
+	memory := func(offset, size *uint256.Int) []byte {
+		data, err := tracers.GetMemoryCopyPadded(scope.MemoryData(), int64(offset.Uint64()), int64(size.Uint64()))
+		if err != nil {
+			t.Stop(fmt.Errorf("bundlerCollectorTracer: %w", err))
+		}
+		return data
+	}
+
+	if t.currentLevel != nil && (gas < cost || (op == vm.SSTORE && gas < 2300)) {
+		t.currentLevel.OOG = true
+	}
+	if (op == vm.REVERT || op == vm.RETURN) && depth == 1 {
+		t.calls = append(t.calls, bundlerExitInfo{Type: op.String(), Data: truncate(memory(peek(0), peek(1)))})
+	}
+
+	// the EntryPoint itself runs at depth 1, every call from it starts the validation of another entity
+	if depth == 1 {
+		t.entryPoint = scope.Address()
+		switch op {
+		case vm.CALL, vm.STATICCALL:
+			ofs := peek(3)
+			if op == vm.STATICCALL {
+				ofs = peek(2)
+			}
+			t.currentLevel = &bundlerTopLevelCall{
+				TopLevelMethodSig:     memory(ofs, uint256.NewInt(4)),
+				TopLevelTargetAddress: common.Address(peek(1).Bytes20()),
+				Opcodes:               map[string]int{},
+				Access:                map[common.Address]*bundlerAccessInfo{},
+				ContractSize:          map[common.Address]*bundlerContractSize{},
+				ExtCodeAccessInfo:     map[common.Address]string{},
+			}
+			t.callsFromEntryPoint = append(t.callsFromEntryPoint, t.currentLevel)
+		case vm.LOG1:
+			if common.Hash(peek(2).Bytes32()) == beforeExecutionTopic {
+				t.stopCollecting = true
+			}
+		}
+		t.lastOp = 0
+		t.lastExtCode = nil
+		return
+	}
+	if t.stopCollecting || t.currentLevel == nil {
+		return
+	}
+
+	// EXTCODESIZE followed by ISZERO is the check that a contract is deployed, which is allowed (OP-051)
+	if t.lastExtCode != nil && !(t.lastOp == vm.EXTCODESIZE && op == vm.ISZERO) {
+		t.currentLevel.ExtCodeAccessInfo[*t.lastExtCode] = t.lastOp.String()
+	}
+	t.lastExtCode = nil
+
+	switch op {
+	case vm.EXTCODESIZE, vm.EXTCODEHASH, vm.EXTCODECOPY, vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		addr := common.Address(peek(1).Bytes20())
+		if op == vm.EXTCODESIZE || op == vm.EXTCODEHASH || op == vm.EXTCODECOPY {
+			addr = common.Address(peek(0).Bytes20())
+			t.lastExtCode = &addr
+		}
+		if _, ok := t.currentLevel.ContractSize[addr]; !ok && !t.isPrecompiled(addr) {
+			code, _ := t.env.IntraBlockState.GetCode(addr)
+			t.currentLevel.ContractSize[addr] = &bundlerContractSize{ContractSize: len(code), Opcode: op.String()}
+		}
+	}
+
+	// GAS is allowed only as the gas argument of a call (OP-012)
+	if t.lastOp == vm.GAS && !isCallOp(op) {
+		t.currentLevel.Opcodes[vm.GAS.String()]++
+	}
+	if op != vm.GAS && !isTrivialOp(op) {
+		t.currentLevel.Opcodes[op.String()]++
+	}
+	t.lastOp = op
+
+	switch {
+	case op == vm.SLOAD || op == vm.SSTORE:
+		slot := common.Hash(peek(0).Bytes32())
+		addr := scope.Address()
+		access, ok := t.currentLevel.Access[addr]
+		if !ok {
+			access = &bundlerAccessInfo{Reads: map[common.Hash]common.Hash{}, Writes: map[common.Hash]int{}}
+			t.currentLevel.Access[addr] = access
+		}
+		if op == vm.SSTORE {
+			access.Writes[slot]++
+			break
+		}
+		// the value before the UserOperation, slots which it wrote are not read from the state
+		_, read := access.Reads[slot]
+		_, written := access.Writes[slot]
+		if !read && !written {
+			var value uint256.Int
+			_ = t.env.IntraBlockState.GetState(addr, &slot, &value)
+			access.Reads[slot] = value.Bytes32()
+		}
+	case op == vm.KECCAK256:
+		if size := peek(1).Uint64(); size > bundlerMinKeccak && size < bundlerMaxKeccak {
+			t.keccak = append(t.keccak, memory(peek(0), peek(1)))
+		}
+	case op >= vm.LOG0 && op <= vm.LOG4:
+		topics := make([]common.Hash, int(op-vm.LOG0))
+		for i := range topics {
+			topics[i] = peek(2 + i).Bytes32()
+		}
+		t.logs = append(t.logs, bundlerLog{Topics: topics, Data: memory(peek(0), peek(1))})
+	}
+}
+
+func isCallOp(op vm.OpCode) bool {
+	return op == vm.CALL || op == vm.CALLCODE || op == vm.DELEGATECALL || op == vm.STATICCALL
+}
+
+// isTrivialOp returns whether op isn't counted in the opcodes of the result, which is only used to
+// check the banned ones.
+func isTrivialOp(op vm.OpCode) bool {
+	switch {
+	case op >= vm.PUSH0 && op <= vm.SWAP16, op == vm.POP:
+		return true
+	}
+	switch op {
+	case vm.ADD, vm.SUB, vm.MUL, vm.DIV, vm.EQ, vm.LT, vm.GT, vm.SLT, vm.SGT, vm.SHL, vm.SHR, vm.AND, vm.OR, vm.NOT, vm.ISZERO:
+		return true
+	}
+	return false
+}
+
+func truncate(data []byte) hexutil.Bytes {
+	if len(data) > bundlerMaxData {
+		data = data[:bundlerMaxData]
+	}
+	return common.CopyBytes(data)
+}
+
+// violations checks the collected access against the rules of ERC-7562 for unstaked entities.
+func (t *bundlerCollectorTracer) violations(sender common.Address) []bundlerViolation {
+	var res []bundlerViolation
+	for level, call := range t.callsFromEntryPoint {
+		ops := make([]string, 0, len(call.Opcodes))
+		for op := range call.Opcodes {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+		for _, name := range ops {
+			op := vm.StringToOp(name)
+			if rule, banned := bundlerBannedOpcodes[op]; banned {
+				res = append(res, bundlerViolation{Level: level, Rule: rule, Address: call.TopLevelTargetAddress, Message: "banned opcode " + name})
+			} else if op == vm.GAS {
+				res = append(res, bundlerViolation{Level: level, Rule: "OP-012", Address: call.TopLevelTargetAddress, Message: "GAS not followed by a call"})
+			}
+		}
+		if call.OOG {
+			res = append(res, bundlerViolation{Level: level, Rule: "OP-020", Address: call.TopLevelTargetAddress, Message: "out of gas"})
+		}
+
+		for addr, access := range call.Access {
+			if addr == sender || addr == t.entryPoint {
+				continue
+			}
+			slots := make([]common.Hash, 0, len(access.Reads)+len(access.Writes))
+			for slot := range access.Reads {
+				slots = append(slots, slot)
+			}
+			for slot := range access.Writes {
+				if _, ok := access.Reads[slot]; !ok {
+					slots = append(slots, slot)
+				}
+			}
+			sort.Slice(slots, func(i, j int) bool { return bytes.Compare(slots[i][:], slots[j][:]) < 0 })
+			for _, slot := range slots {
+				if !t.associatedWith(slot, sender) {
+					res = append(res, bundlerViolation{Level: level, Rule: "STO-021", Address: addr, Message: fmt.Sprintf("access to slot %x not associated with the sender", slot)})
+				}
+			}
+		}
+
+		for addr, size := range call.ContractSize {
+			if size.ContractSize == 0 && addr != sender {
+				res = append(res, bundlerViolation{Level: level, Rule: "OP-041", Address: addr, Message: size.Opcode + " of an address without code"})
+			}
+		}
+	}
+	return res
+}
+
+// associatedWith returns whether slot is keccak(addr || x) + n, with n < bundlerAssociatedSlots.
+func (t *bundlerCollectorTracer) associatedWith(slot common.Hash, addr common.Address) bool {
+	var padded common.Hash
+	copy(padded[12:], addr[:])
+	if slot == padded {
+		return true
+	}
+	s := new(uint256.Int).SetBytes32(slot[:])
+	for _, preimage := range t.keccak {
+		if !bytes.HasPrefix(preimage, padded[:]) {
+			continue
+		}
+		base := new(uint256.Int).SetBytes32(crypto.Keccak256(preimage))
+		if s.Cmp(base) >= 0 && new(uint256.Int).Sub(s, base).CmpUint64(bundlerAssociatedSlots) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// GetResult returns the json-encoded collected access, and any error arising from the encoding
+// or forceful termination (via `Stop`).
+func (t *bundlerCollectorTracer) GetResult() (json.RawMessage, error) {
+	res := bundlerCollectorResult{
+		CallsFromEntryPoint: t.callsFromEntryPoint,
+		Keccak:              t.keccak,
+		Calls:               t.calls,
+		Logs:                t.logs,
+		Debug:               []string{},
+	}
+	if t.config.Sender != nil {
+		res.Violations = t.violations(*t.config.Sender)
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return data, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *bundlerCollectorTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
@@ -139,3 +139,92 @@ func TestPrestateTracerCreate2(t *testing.T) {
 		t.Fatalf("Expected 0x60f3f640a8508fc6a86d45df051962668e1e8ac7 in result")
 	}
 }
+
+func TestBundlerCollectorTracer(t *testing.T) {
+	var (
+		entryPoint = common.HexToAddress("0x00000000000000000000000000000000000000e0")
+		sender     = common.HexToAddress("0x00000000000000000000000000000000000000a0")
+		other      = common.HexToAddress("0x00000000000000000000000000000000000000b0")
+	)
+	privateKeyECDSA, err := ecdsa.GenerateKey(crypto.S256(), rand.Reader)
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	txn, err := types.SignTx(types.NewTransaction(1, entryPoint, uint256.NewInt(0), 5000000, uint256.NewInt(1), []byte{}), *signer, privateKeyECDSA)
+	require.NoError(t, err)
+	origin, _ := signer.Sender(txn)
+
+	alloc := types.GenesisAlloc{
+		origin: {Nonce: 1, Balance: big.NewInt(500000000000000)},
+		// MSTORE(0, 0xdeadbeef), CALL(GAS, sender, 0, 28, 4, 0, 0)
+		entryPoint: {Nonce: 1, Balance: big.NewInt(1), Code: hexutil.MustDecode("0x63deadbeef600052600060006004601c60007300000000000000000000000000000000000000a05af15000")},
+		// NUMBER, SLOAD(0), CALL(GAS, other, 0, 0, 0, 0, 0)
+		sender: {Nonce: 1, Code: hexutil.MustDecode("0x435060005450600060006000600060007300000000000000000000000000000000000000b05af15000")},
+		// SLOAD(1)
+		other: {Nonce: 1, Code: hexutil.MustDecode("0x600154500000")},
+	}
+	blockContext := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    consensus.Transfer,
+		BlockNumber: 8000000,
+		Time:        5,
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+		BaseFee:     uint256.NewInt(0),
+		BlobBaseFee: uint256.NewInt(50000),
+	}
+
+	m := mock.Mock(t)
+	tx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	rules := params.AllProtocolChanges.Rules(blockContext.BlockNumber, blockContext.Time)
+	statedb, _ := tests.MakePreState(rules, tx, alloc, blockContext.BlockNumber)
+
+	tracer, err := tracers.New("bundlerCollectorTracer", new(tracers.Context), json.RawMessage(`{"sender":"`+sender.Hex()+`"}`))
+	require.NoError(t, err)
+	evm := vm.NewEVM(blockContext, evmtypes.TxContext{Origin: origin, GasPrice: uint256.NewInt(1)}, statedb, params.AllProtocolChanges, vm.Config{Tracer: tracer.Hooks})
+	msg, err := txn.AsMessage(*signer, nil, rules)
+	require.NoError(t, err)
+	tracer.OnTxStart(evm.GetVMContext(), txn, msg.From())
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(txn.GetGasLimit()).AddBlobGas(txn.GetBlobGas()))
+	_, err = st.TransitionDb(false, false)
+	require.NoError(t, err)
+	res, err := tracer.GetResult()
+	require.NoError(t, err)
+
+	var ret struct {
+		CallsFromEntryPoint []struct {
+			TopLevelMethodSig     hexutil.Bytes
+			TopLevelTargetAddress common.Address
+			Opcodes               map[string]int
+			Access                map[common.Address]struct {
+				Reads map[common.Hash]common.Hash
+			}
+			ContractSize map[common.Address]struct {
+				ContractSize int
+				Opcode       string
+			}
+		}
+		Calls      []map[string]any
+		Violations []struct {
+			Rule    string
+			Address common.Address
+		}
+	}
+	require.NoError(t, json.Unmarshal(res, &ret))
+	require.Len(t, ret.CallsFromEntryPoint, 1)
+	level := ret.CallsFromEntryPoint[0]
+	require.Equal(t, hexutil.Bytes{0xde, 0xad, 0xbe, 0xef}, level.TopLevelMethodSig)
+	require.Equal(t, sender, level.TopLevelTargetAddress)
+	require.Equal(t, map[string]int{"NUMBER": 1, "SLOAD": 2, "CALL": 1, "STOP": 2}, level.Opcodes)
+	require.Contains(t, level.Access[sender].Reads, common.Hash{})
+	require.Contains(t, level.Access[other].Reads, common.BigToHash(big.NewInt(1)))
+	require.Equal(t, 6, level.ContractSize[other].ContractSize)
+	require.Equal(t, "CALL", level.ContractSize[other].Opcode)
+	require.Len(t, ret.Calls, 4) // enter and exit of sender and other
+
+	require.Len(t, ret.Violations, 2)
+	require.Equal(t, "OP-011", ret.Violations[0].Rule)
+	require.Equal(t, "STO-021", ret.Violations[1].Rule)
+	require.Equal(t, other, ret.Violations[1].Address)
+}
@@ -859,3 +859,8 @@ func (m callMsg) SetIsFree(_ bool)                      {}
 func (m callMsg) BlobGas() uint64                { return misc.GetBlobGasUsed(len(m.CallMsg.BlobHashes)) }
 func (m callMsg) MaxFeePerBlobGas() *uint256.Int { return m.CallMsg.MaxFeePerBlobGas }
 func (m callMsg) BlobHashes() []common.Hash      { return m.CallMsg.BlobHashes }
+
+func (m callMsg) RollupCostData() *types.RollupCostData { return nil }
+func (m callMsg) IsDepositTx() bool                     { return false }
+func (m callMsg) IsSystemTx() bool                      { return false }
+func (m callMsg) Mint() *uint256.Int                    { return nil }
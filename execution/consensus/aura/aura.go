@@ -1157,6 +1157,10 @@ func (c *AuRa) GetPostApplyMessageFunc() evmtypes.PostApplyMessageFunc {
 	return nil
 }
 
+func (c *AuRa) GetL1CostFunc() evmtypes.L1CostFunc {
+	return nil
+}
+
 /*
 // extracts the empty steps from the header seal. should only be called when there are 3 fields in the seal
 // (i.e. header.number() >= self.empty_steps_transition).
@@ -195,6 +195,8 @@ type Clique struct {
 	// The fields below are for testing only
 	FakeDiff bool // Skip difficulty verifications
 
+	dev DevHooks // nil if not in dev mode
+
 	exitCh chan struct{}
 	logger log.Logger
 }
@@ -358,16 +360,24 @@ func (c *Clique) Prepare(chain consensus.ChainHeaderReader, header *types.Header
 	}
 	header.Time = parent.Time + c.config.Period
 
-	now := uint64(time.Now().Unix())
+	now := uint64(c.now().Unix())
 	if header.Time < now {
 		header.Time = now
 	}
+	if c.dev != nil {
+		if t := c.dev.NextBlockTime(); t >= parent.Time+c.config.Period && t > parent.Time {
+			header.Time = t
+		}
+	}
 
 	return nil
 }
 
 func (c *Clique) Initialize(config *chain.Config, chain consensus.ChainHeaderReader, header *types.Header,
 	state *state.IntraBlockState, syscall consensus.SysCallCustom, logger log.Logger, tracer *tracing.Hooks) {
+	if err := c.ApplyDevStatePatches(header, state); err != nil {
+		logger.Error("[clique] dev state patches", "block", header.Number, "err", err)
+	}
 }
 
 func (c *Clique) CalculateRewards(config *chain.Config, header *types.Header, uncles []*types.Header, syscall consensus.SystemCall,
@@ -416,7 +426,7 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, blockWithReceipts *type
 		return errUnknownBlock
 	}
 	// For 0-period chains, refuse to seal empty blocks (no reward but would spin sealing)
-	if c.config.Period == 0 && len(block.Transactions()) == 0 {
+	if c.config.Period == 0 && len(block.Transactions()) == 0 && (c.dev == nil || !c.dev.SealEmpty()) {
 		c.logger.Info("Sealing paused, waiting for transactions")
 		results <- nil
 
@@ -446,7 +456,7 @@ func (c *Clique) Seal(chain consensus.ChainHeaderReader, blockWithReceipts *type
 		}
 	}
 	// Sweet, the protocol permits us to sign the block, wait for our time
-	delay := time.Unix(int64(header.Time), 0).Sub(time.Now()) // nolint: gosimple
+	delay := time.Unix(int64(header.Time), 0).Sub(c.now())
 	if header.Difficulty.Cmp(diffNoTurn) == 0 {
 		// It's not our turn explicitly to sign, delay it a bit
 		wiggle := time.Duration(len(snap.Signers)/2+1) * wiggleTime
@@ -646,3 +656,7 @@ func (c *Clique) GetTransferFunc() evmtypes.TransferFunc {
 func (c *Clique) GetPostApplyMessageFunc() evmtypes.PostApplyMessageFunc {
 	return nil
 }
+
+func (c *Clique) GetL1CostFunc() evmtypes.L1CostFunc {
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package clique
+
+import (
+	"time"
+
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/state"
+)
+
+// DevHooks - control of block production in dev mode (--chain=dev) by test frameworks: time manipulation,
+// mining of empty blocks on demand and state changes (cheat-codes). Implemented by eth/devmode.
+type DevHooks interface {
+	Now() time.Time        // clock shifted by evm_increaseTime
+	NextBlockTime() uint64 // timestamp of next block set by evm_setNextBlockTimestamp, 0 - not set
+	SealEmpty() bool       // mining of block was requested: seal it even if it's empty (0-period chain)
+	ApplyStatePatches(header *types.Header, ibs *state.IntraBlockState) error
+}
+
+// SetDevHooks - must be called before mining is started
+func (c *Clique) SetDevHooks(hooks DevHooks) { c.dev = hooks }
+
+func (c *Clique) now() time.Time {
+	if c.dev != nil {
+		return c.dev.Now()
+	}
+	return time.Now()
+}
+
+// ApplyDevStatePatches - applies cheat-codes (anvil_setBalance, etc.) at beginning of block. Called by Initialize
+// at block execution, and by block producer before it adds transactions to block.
+func (c *Clique) ApplyDevStatePatches(header *types.Header, ibs *state.IntraBlockState) error {
+	if c.dev == nil {
+		return nil
+	}
+	return c.dev.ApplyStatePatches(header, ibs)
+}
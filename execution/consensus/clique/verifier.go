@@ -19,7 +19,6 @@ package clique
 import (
 	"bytes"
 	"fmt"
-	"time"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/length"
@@ -39,7 +38,7 @@ func (c *Clique) verifyHeader(chain consensus.ChainHeaderReader, header *types.H
 	}
 	number := header.Number.Uint64()
 
-	now := time.Now()
+	now := c.now()
 	nowUnix := now.Unix()
 
 	// Don't waste time checking blocks from the future
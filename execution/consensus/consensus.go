@@ -140,6 +140,9 @@ type EngineReader interface {
 
 	GetPostApplyMessageFunc() evmtypes.PostApplyMessageFunc
 
+	// GetL1CostFunc returns rollup L1 data fee function, nil for L1 chains
+	GetL1CostFunc() evmtypes.L1CostFunc
+
 	// Close terminates any background threads, DB's etc maintained by the consensus engine.
 	Close() error
 }
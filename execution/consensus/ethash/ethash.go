@@ -586,3 +586,7 @@ func (ethash *Ethash) GetTransferFunc() evmtypes.TransferFunc {
 func (ethash *Ethash) GetPostApplyMessageFunc() evmtypes.PostApplyMessageFunc {
 	return nil
 }
+
+func (ethash *Ethash) GetL1CostFunc() evmtypes.L1CostFunc {
+	return nil
+}
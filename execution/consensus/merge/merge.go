@@ -385,6 +385,10 @@ func (s *Merge) GetPostApplyMessageFunc() evmtypes.PostApplyMessageFunc {
 	return s.eth1Engine.GetPostApplyMessageFunc()
 }
 
+func (s *Merge) GetL1CostFunc() evmtypes.L1CostFunc {
+	return s.eth1Engine.GetL1CostFunc()
+}
+
 func (s *Merge) Close() error {
 	return s.eth1Engine.Close()
 }
@@ -108,10 +108,15 @@ func CalcBaseFee(config *chain.Config, parent *types.Header) *big.Int {
 		return new(big.Int).SetUint64(params.InitialBaseFee)
 	}
 
+	elasticity, denominator := uint64(params.ElasticityMultiplier), getBaseFeeChangeDenominator(config.Bor, parent.Number.Uint64())
+	if config.IsOptimism() {
+		// block time of OP Stack chains is constant, fork of the block is known from the parent
+		elasticity, denominator = config.OptimismBaseFeeParams(parent.Time + config.SecondsPerSlot())
+	}
 	var (
-		parentGasTarget          = parent.GasLimit / params.ElasticityMultiplier
+		parentGasTarget          = parent.GasLimit / elasticity
 		parentGasTargetBig       = new(big.Int).SetUint64(parentGasTarget)
-		baseFeeChangeDenominator = new(big.Int).SetUint64(getBaseFeeChangeDenominator(config.Bor, parent.Number.Uint64()))
+		baseFeeChangeDenominator = new(big.Int).SetUint64(denominator)
 	)
 	// If the parent gasUsed is the same as the target, the baseFee remains unchanged.
 	if parent.GasUsed == parentGasTarget {
@@ -116,3 +116,33 @@ func TestCalcBaseFee(t *testing.T) {
 		}
 	}
 }
+
+// TestCalcBaseFeeOptimism tests that OP Stack chains use own EIP-1559 parameters, switched by Canyon
+func TestCalcBaseFeeOptimism(t *testing.T) {
+	opConfig := config()
+	opConfig.Optimism = &chain.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50, EIP1559DenominatorCanyon: 250}
+	opConfig.CanyonTime = big.NewInt(100)
+
+	tests := []struct {
+		parentTime      uint64
+		parentGasUsed   uint64
+		expectedBaseFee int64
+	}{
+		{50, 5000000, params.InitialBaseFee}, // usage == target
+		{50, 6000000, 1004000000},            // usage above target, pre-Canyon
+		{100, 6000000, 1000800000},           // usage above target, Canyon
+		{100, 4000000, 999200000},            // usage below target, Canyon
+	}
+	for i, test := range tests {
+		parent := &types.Header{
+			Number:   common.Big32,
+			Time:     test.parentTime,
+			GasLimit: 30000000,
+			GasUsed:  test.parentGasUsed,
+			BaseFee:  big.NewInt(params.InitialBaseFee),
+		}
+		if have, want := CalcBaseFee(opConfig, parent), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d  want %d, ", i, have, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package optimism
+
+import (
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/execution/consensus/merge"
+)
+
+// OP Stack predeploys, see https://specs.optimism.io/protocol/predeploys.html
+var (
+	L1BlockAddr    = common.HexToAddress("0x4200000000000000000000000000000000000015")
+	L1FeeVaultAddr = common.HexToAddress("0x420000000000000000000000000000000000001A")
+)
+
+// Storage layout of L1Block predeploy
+var (
+	l1BaseFeeSlot     = common.HexToHash("0x01")
+	l1FeeScalarsSlot  = common.HexToHash("0x03") // Ecotone: baseFeeScalar and blobBaseFeeScalar packed
+	l1OverheadSlot    = common.HexToHash("0x05") // Bedrock
+	l1ScalarSlot      = common.HexToHash("0x06") // Bedrock
+	l1BlobBaseFeeSlot = common.HexToHash("0x07") // Ecotone
+)
+
+var (
+	oneMillion     = uint256.NewInt(1_000_000)
+	sixteenMillion = uint256.NewInt(16_000_000)
+	sixteen        = uint256.NewInt(16)
+)
+
+// Optimism - execution side of OP Stack rollup. Blocks are derived from L1 by op-node and delivered through
+// Engine API, so it's post-Merge engine which only adds L1 data fee of transactions.
+type Optimism struct {
+	*merge.Merge
+	config *chain.Config
+}
+
+// New creates OP Stack engine on top of the Merge engine
+func New(config *chain.Config, eth1Engine consensus.Engine) *Optimism {
+	return &Optimism{Merge: merge.New(eth1Engine), config: config}
+}
+
+func (o *Optimism) Type() chain.ConsensusName {
+	return chain.OptimismConsensus
+}
+
+func (o *Optimism) GetL1CostFunc() evmtypes.L1CostFunc {
+	return o.l1Cost
+}
+
+func (o *Optimism) l1Cost(ibs evmtypes.IntraBlockState, costData types.RollupCostData, blockTime uint64) (*uint256.Int, common.Address, error) {
+	var l1BaseFee uint256.Int
+	if err := ibs.GetState(L1BlockAddr, &l1BaseFeeSlot, &l1BaseFee); err != nil {
+		return nil, common.Address{}, err
+	}
+	if o.config.IsEcotone(blockTime) {
+		var scalars, blobBaseFee uint256.Int
+		if err := ibs.GetState(L1BlockAddr, &l1FeeScalarsSlot, &scalars); err != nil {
+			return nil, common.Address{}, err
+		}
+		if err := ibs.GetState(L1BlockAddr, &l1BlobBaseFeeSlot, &blobBaseFee); err != nil {
+			return nil, common.Address{}, err
+		}
+		// the upgrade block still runs with Bedrock L1Block contract - then scalars are not set yet
+		if !scalars.IsZero() {
+			return EcotoneL1Cost(costData, &l1BaseFee, &blobBaseFee, &scalars), L1FeeVaultAddr, nil
+		}
+	}
+	var overhead, scalar uint256.Int
+	if err := ibs.GetState(L1BlockAddr, &l1OverheadSlot, &overhead); err != nil {
+		return nil, common.Address{}, err
+	}
+	if err := ibs.GetState(L1BlockAddr, &l1ScalarSlot, &scalar); err != nil {
+		return nil, common.Address{}, err
+	}
+	return BedrockL1Cost(costData, &l1BaseFee, &overhead, &scalar), L1FeeVaultAddr, nil
+}
+
+// BedrockL1Cost = (calldataGas + overhead) * l1BaseFee * scalar / 1e6
+func BedrockL1Cost(costData types.RollupCostData, l1BaseFee, overhead, scalar *uint256.Int) *uint256.Int {
+	fee := uint256.NewInt(calldataGas(costData))
+	fee.Add(fee, overhead)
+	fee.Mul(fee, l1BaseFee)
+	fee.Mul(fee, scalar)
+	return fee.Div(fee, oneMillion)
+}
+
+// EcotoneL1Cost = calldataGas * (16 * l1BaseFee * baseFeeScalar + blobBaseFee * blobBaseFeeScalar) / 16e6
+func EcotoneL1Cost(costData types.RollupCostData, l1BaseFee, blobBaseFee, scalars *uint256.Int) *uint256.Int {
+	baseFeeScalar, blobBaseFeeScalar := unpackScalars(scalars)
+
+	calldataCostPerByte := new(uint256.Int).Mul(l1BaseFee, sixteen)
+	calldataCostPerByte.Mul(calldataCostPerByte, baseFeeScalar)
+	blobCostPerByte := new(uint256.Int).Mul(blobBaseFee, blobBaseFeeScalar)
+
+	fee := new(uint256.Int).Add(calldataCostPerByte, blobCostPerByte)
+	fee.Mul(fee, uint256.NewInt(calldataGas(costData)))
+	return fee.Div(fee, sixteenMillion)
+}
+
+// unpackScalars - L1Block stores both 4-byte scalars in one slot: baseFeeScalar at bytes [16:20],
+// blobBaseFeeScalar at bytes [20:24] of big-endian word
+func unpackScalars(scalars *uint256.Int) (baseFeeScalar, blobBaseFeeScalar *uint256.Int) {
+	word := scalars.Bytes32()
+	baseFeeScalar = new(uint256.Int).SetBytes(word[16:20])
+	blobBaseFeeScalar = new(uint256.Int).SetBytes(word[20:24])
+	return baseFeeScalar, blobBaseFeeScalar
+}
+
+func calldataGas(costData types.RollupCostData) uint64 {
+	return costData.Zeroes*4 + costData.Ones*16
+}
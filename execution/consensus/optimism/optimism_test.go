@@ -0,0 +1,54 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package optimism
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestRollupCostData(t *testing.T) {
+	costData := types.NewRollupCostData([]byte{0x02, 0x00, 0x00, 0xff, 0x01})
+	require.Equal(t, types.RollupCostData{Zeroes: 2, Ones: 3}, costData)
+}
+
+func TestBedrockL1Cost(t *testing.T) {
+	costData := types.RollupCostData{Zeroes: 5, Ones: 7} // 5*4 + 7*16 = 132 calldata gas
+	fee := BedrockL1Cost(costData, uint256.NewInt(1_000_000_000), uint256.NewInt(50), uint256.NewInt(7_000_000))
+	// (132 + 50) * 1e9 * 7e6 / 1e6
+	require.Equal(t, uint256.NewInt(1_274_000_000_000), fee)
+}
+
+func TestEcotoneL1Cost(t *testing.T) {
+	costData := types.RollupCostData{Zeroes: 5, Ones: 7}
+	var word [32]byte
+	word[19] = 2 // baseFeeScalar
+	word[23] = 3 // blobBaseFeeScalar
+	scalars := new(uint256.Int).SetBytes32(word[:])
+
+	baseFeeScalar, blobBaseFeeScalar := unpackScalars(scalars)
+	require.Equal(t, uint256.NewInt(2), baseFeeScalar)
+	require.Equal(t, uint256.NewInt(3), blobBaseFeeScalar)
+
+	fee := EcotoneL1Cost(costData, uint256.NewInt(1_000_000_000), uint256.NewInt(10_000_000), scalars)
+	// 132 * (16 * 1e9 * 2 + 1e7 * 3) / 16e6
+	require.Equal(t, uint256.NewInt(264_247), fee)
+}
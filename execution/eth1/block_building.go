@@ -75,6 +75,18 @@ func (e *EthereumExecutionModule) AssembleBlock(ctx context.Context, req *execut
 		return nil, err
 	}
 
+	if len(req.Transactions) > 0 || req.NoTxPool || req.GasLimit != nil {
+		if !e.config.IsOptimism() {
+			return nil, &rpc.InvalidParamsError{Message: "transactions, noTxPool and gasLimit are OP Stack payload attributes"}
+		}
+		for i, txn := range req.Transactions {
+			if _, err := types.DecodeTransaction(txn); err != nil {
+				return nil, &rpc.InvalidParamsError{Message: fmt.Sprintf("transaction %d: %s", i, err)}
+			}
+		}
+		param.Transactions, param.NoTxPool, param.GasLimit = req.Transactions, req.NoTxPool, req.GasLimit
+	}
+
 	if req.ParentBeaconBlockRoot != nil {
 		pbbr := common.Hash(gointerfaces.ConvertH256ToHash(req.ParentBeaconBlockRoot))
 		param.ParentBeaconBlockRoot = &pbbr
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"math/big"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"golang.org/x/sync/semaphore"
@@ -123,6 +124,13 @@ type EthereumExecutionModule struct {
 
 	doingPostForkchoice atomic.Bool
 
+	// debug_setHead
+	started       atomic.Bool // execution module drives the chain (Engine API)
+	setHeadLock   sync.Mutex
+	setHeadStatus *SetHeadStatus
+
+	reorgGuard *reorgGuard
+
 	// metrics for average mgas/sec
 	avgMgasSec float64
 
@@ -139,7 +147,7 @@ func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.Temp
 	syncCfg ethconfig.Sync,
 	ctx context.Context,
 ) *EthereumExecutionModule {
-	return &EthereumExecutionModule{
+	e := &EthereumExecutionModule{
 		blockReader:         blockReader,
 		db:                  db,
 		executionPipeline:   executionPipeline,
@@ -156,7 +164,12 @@ func NewEthereumExecutionModule(blockReader services.FullBlockReader, db kv.Temp
 		engine:              engine,
 		syncCfg:             syncCfg,
 		bacgroundCtx:        ctx,
+		reorgGuard:          newReorgGuard(syncCfg.MaxReorgDepth),
+	}
+	if syncCfg.ReorgAlertURL != "" {
+		e.AddReorgAlertHook(WebhookReorgAlertHook(syncCfg.ReorgAlertURL, logger))
 	}
+	return e
 }
 
 func (e *EthereumExecutionModule) getHeader(ctx context.Context, tx kv.Tx, blockHash common.Hash, blockNumber uint64) (*types.Header, error) {
@@ -362,6 +375,7 @@ func (e *EthereumExecutionModule) Start(ctx context.Context) {
 		return
 	}
 	defer e.semaphore.Release(1)
+	e.started.Store(true)
 
 	if err := stages.ProcessFrozenBlocks(ctx, e.db, e.blockReader, e.executionPipeline, nil); err != nil {
 		if !errors.Is(err, context.Canceled) {
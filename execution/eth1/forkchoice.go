@@ -369,6 +369,16 @@ func (e *EthereumExecutionModule) updateForkChoice(ctx context.Context, original
 			unwindTarget = minUnwindableBlock
 		}
 
+		newChain := make([]common.Hash, len(newCanonicals))
+		for i, c := range newCanonicals {
+			newChain[i] = c.hash
+		}
+		if err := e.reorgGuard.check(headersProgressBefore, unwindTarget, newChain); err != nil {
+			e.logger.Error("[reorg-guard] refused forkchoice", "err", err)
+			sendForkchoiceErrorWithoutWaiting(e.logger, outcomeCh, err, false)
+			return
+		}
+
 		// if unwindTarget <
 		if err := e.executionPipeline.UnwindTo(unwindTarget, stagedsync.ForkChoice, tx); err != nil {
 			sendForkchoiceErrorWithoutWaiting(e.logger, outcomeCh, err, false)
@@ -0,0 +1,189 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv/audit"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon/rpc"
+)
+
+var ErrReorgTooDeep = errors.New("reorg is deeper than --sync.max-reorg-depth, allow it with admin_allowReorg")
+
+var (
+	reorgsRefused = metrics.NewCounter(`reorg_guard{result="refused"}`)
+	reorgsAllowed = metrics.NewCounter(`reorg_guard{result="allowed"}`)
+)
+
+const reorgAlertsKept = 16
+
+// ReorgAlert - reorg deeper than the limit: refused, or done because an operator allowed it
+type ReorgAlert struct {
+	Time     time.Time      `json:"time"`
+	Head     hexutil.Uint64 `json:"head"`     // canonical head before the reorg
+	Ancestor hexutil.Uint64 `json:"ancestor"` // last block common with the new chain
+	Depth    hexutil.Uint64 `json:"depth"`    // number of canonical blocks the reorg removes
+	NewHead  common.Hash    `json:"newHead"`
+	MaxDepth hexutil.Uint64 `json:"maxDepth"`
+	Refused  bool           `json:"refused"`
+	Repeats  hexutil.Uint64 `json:"repeats"` // refusals of same reorg after this one: CL resends forkchoice every slot
+}
+
+// ReorgAlertHook - receives alerts of deep reorgs, must not block
+type ReorgAlertHook func(ReorgAlert)
+
+// reorgGuard - refuses forkchoice reorgs deeper than maxDepth, unless one of the blocks of the new chain was
+// allowed by admin_allowReorg. Protects RPC providers of small networks from following a long-range fork.
+type reorgGuard struct {
+	maxDepth uint64 // 0 - no limit
+
+	lock    sync.Mutex
+	allowed map[common.Hash]struct{} // one-shot: removed when the reorg is done
+	alerts  []ReorgAlert             // last reorgAlertsKept
+	hooks   []ReorgAlertHook
+}
+
+func newReorgGuard(maxDepth uint64) *reorgGuard {
+	return &reorgGuard{maxDepth: maxDepth, allowed: map[common.Hash]struct{}{}}
+}
+
+func (g *reorgGuard) addHook(h ReorgAlertHook) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.hooks = append(g.hooks, h)
+}
+
+func (g *reorgGuard) allow(hash common.Hash) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.allowed[hash] = struct{}{}
+}
+
+// check - newChain are hashes of the blocks becoming canonical, from the new head down to ancestor+1
+func (g *reorgGuard) check(head, ancestor uint64, newChain []common.Hash) error {
+	if g.maxDepth == 0 || head <= ancestor || head-ancestor <= g.maxDepth {
+		return nil
+	}
+	g.lock.Lock()
+	alert := ReorgAlert{Time: time.Now().UTC(), Head: hexutil.Uint64(head), Ancestor: hexutil.Uint64(ancestor),
+		Depth: hexutil.Uint64(head - ancestor), NewHead: newChain[0], MaxDepth: hexutil.Uint64(g.maxDepth), Refused: true}
+	for _, hash := range newChain {
+		if _, ok := g.allowed[hash]; ok {
+			delete(g.allowed, hash)
+			alert.Refused = false
+			break
+		}
+	}
+	var hooks []ReorgAlertHook
+	if last := len(g.alerts) - 1; alert.Refused && last >= 0 && g.alerts[last].Refused &&
+		g.alerts[last].NewHead == alert.NewHead && g.alerts[last].Head == alert.Head {
+		g.alerts[last].Repeats++ // already reported
+	} else {
+		if len(g.alerts) == reorgAlertsKept {
+			g.alerts = g.alerts[1:]
+		}
+		g.alerts = append(g.alerts, alert)
+		hooks = g.hooks
+	}
+	g.lock.Unlock()
+
+	for _, h := range hooks {
+		h(alert)
+	}
+	if !alert.Refused {
+		reorgsAllowed.Inc()
+		return nil
+	}
+	reorgsRefused.Inc()
+	return fmt.Errorf("%w: depth=%d, max=%d, head=%d, newHead=%x", ErrReorgTooDeep, head-ancestor, g.maxDepth, head, newChain[0])
+}
+
+// ReorgGuardStatus - result of admin_reorgGuard
+type ReorgGuardStatus struct {
+	MaxDepth hexutil.Uint64 `json:"maxDepth"`
+	Allowed  []common.Hash  `json:"allowed"`
+	Alerts   []ReorgAlert   `json:"alerts"`
+}
+
+func (g *reorgGuard) status() *ReorgGuardStatus {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	s := &ReorgGuardStatus{MaxDepth: hexutil.Uint64(g.maxDepth), Allowed: make([]common.Hash, 0, len(g.allowed)),
+		Alerts: append([]ReorgAlert{}, g.alerts...)}
+	for hash := range g.allowed {
+		s.Allowed = append(s.Allowed, hash)
+	}
+	return s
+}
+
+// AddReorgAlertHook - hook is called on every reorg deeper than --sync.max-reorg-depth, refusals repeated by CL are
+// reported once
+func (e *EthereumExecutionModule) AddReorgAlertHook(h ReorgAlertHook) {
+	e.reorgGuard.addHook(h)
+}
+
+// WebhookReorgAlertHook - POSTs alerts as JSON to url, in background
+func WebhookReorgAlertHook(url string, logger log.Logger) ReorgAlertHook {
+	client := &http.Client{Timeout: 10 * time.Second}
+	return func(alert ReorgAlert) {
+		go func() {
+			body, err := json.Marshal(alert)
+			if err != nil {
+				logger.Warn("[reorg-guard] alert", "err", err)
+				return
+			}
+			resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+			if err != nil {
+				logger.Warn("[reorg-guard] alert", "url", url, "err", err)
+				return
+			}
+			resp.Body.Close()
+			if resp.StatusCode >= 300 {
+				logger.Warn("[reorg-guard] alert", "url", url, "status", resp.Status)
+			}
+		}()
+	}
+}
+
+// ReorgGuardAPI - admin_allowReorg and admin_reorgGuard
+type ReorgGuardAPI struct{ e *EthereumExecutionModule }
+
+// AllowReorg - allows one reorg deeper than --sync.max-reorg-depth to the chain containing given block
+// (e.g. newHead of the refused reorg from admin_reorgGuard)
+func (api *ReorgGuardAPI) AllowReorg(ctx context.Context, hash common.Hash) error {
+	api.e.reorgGuard.allow(hash)
+	audit.Record(audit.WithOrigin(ctx, rpc.PeerInfoFromContext(ctx).Origin()), api.e.db, audit.OpAllowReorg,
+		map[string]any{"hash": hash.Hex()}, nil, api.e.logger)
+	return nil
+}
+
+// ReorgGuard - max reorg depth, pending allowances and last deep reorgs
+func (api *ReorgGuardAPI) ReorgGuard() *ReorgGuardStatus {
+	return api.e.reorgGuard.status()
+}
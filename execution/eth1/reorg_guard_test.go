@@ -0,0 +1,61 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+)
+
+func TestReorgGuard(t *testing.T) {
+	fork := []common.Hash{{3}, {2}, {1}} // new head first
+
+	var alerts []ReorgAlert
+	g := newReorgGuard(2)
+	g.addHook(func(a ReorgAlert) { alerts = append(alerts, a) })
+
+	require.NoError(t, g.check(100, 100, fork[:1])) // extends the chain
+	require.NoError(t, g.check(100, 98, fork[1:]))  // at the limit
+	require.Empty(t, alerts)
+
+	require.ErrorIs(t, g.check(100, 97, fork), ErrReorgTooDeep)
+	require.Len(t, alerts, 1)
+	require.True(t, alerts[0].Refused)
+	require.Equal(t, common.Hash{3}, alerts[0].NewHead)
+
+	// CL resends same forkchoice: refused, but reported once
+	require.ErrorIs(t, g.check(100, 97, fork), ErrReorgTooDeep)
+	require.Len(t, alerts, 1)
+	require.Equal(t, hexutil.Uint64(1), g.status().Alerts[0].Repeats)
+
+	// allowance of any block of the new chain lets it through, once
+	g.allow(common.Hash{1})
+	require.NoError(t, g.check(100, 97, fork))
+	require.Len(t, alerts, 2)
+	require.False(t, alerts[1].Refused)
+	require.ErrorIs(t, g.check(100, 97, fork), ErrReorgTooDeep)
+
+	status := g.status()
+	require.Empty(t, status.Allowed)
+	require.Len(t, status.Alerts, 3)
+
+	require.NoError(t, newReorgGuard(0).check(1000, 0, fork)) // no limit
+}
@@ -0,0 +1,270 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/audit"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/wrap"
+	"github.com/erigontech/erigon/eth/stagedsync"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/rpc"
+)
+
+var (
+	errSetHeadNotSupported = errors.New("debug_setHead is supported only when chain is driven by Engine API")
+	errSetHeadInProgress   = errors.New("debug_setHead is already in progress")
+)
+
+// SetHeadStatus - plan of debug_setHead (before confirmation) and progress of unwind
+type SetHeadStatus struct {
+	From          hexutil.Uint64 `json:"from"`
+	To            hexutil.Uint64 `json:"to"`
+	Hash          common.Hash    `json:"hash"` // hash of new head, debug_setHead must be called again with it to confirm unwind
+	MinUnwindable hexutil.Uint64 `json:"minUnwindable"`
+	Running       bool           `json:"running"`
+	Done          bool           `json:"done"`
+	Stage         string         `json:"stage,omitempty"`
+	StagesDone    int            `json:"stagesDone"`
+	StagesTotal   int            `json:"stagesTotal"`
+	Elapsed       string         `json:"elapsed,omitempty"`
+	Error         string         `json:"error,omitempty"`
+
+	started time.Time
+}
+
+// SetHead - unwinds all stages (including state domains) to given canonical block. Without confirm - only validates
+// target block and returns plan. With confirm (must be hash of target block) - starts unwind in background,
+// progress is available by SetHeadStatus.
+func (e *EthereumExecutionModule) SetHead(ctx context.Context, number uint64, confirm *common.Hash) (_ *SetHeadStatus, err error) {
+	if !e.started.Load() {
+		return nil, errSetHeadNotSupported
+	}
+	tx, err := e.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	plan, err := e.setHeadPlan(ctx, tx, number)
+	if err != nil {
+		return nil, err
+	}
+	if confirm == nil {
+		return plan, nil
+	}
+	defer func() {
+		audit.Record(ctx, e.db, audit.OpSetHead, map[string]any{"number": number, "hash": confirm.Hex()}, err, e.logger)
+	}()
+	if *confirm != plan.Hash {
+		return nil, fmt.Errorf("confirmation hash %x doesn't match hash %x of block %d", *confirm, plan.Hash, number)
+	}
+
+	e.setHeadLock.Lock()
+	defer e.setHeadLock.Unlock()
+	if e.setHeadStatus != nil && e.setHeadStatus.Running {
+		return nil, errSetHeadInProgress
+	}
+	plan.Running, plan.started = true, time.Now()
+	e.setHeadStatus = plan
+	status := *plan
+	go e.setHead(number, plan.Hash)
+	return &status, nil
+}
+
+// SetHeadStatus - progress of last debug_setHead, nil if there was no one
+func (e *EthereumExecutionModule) SetHeadStatus() *SetHeadStatus {
+	e.setHeadLock.Lock()
+	defer e.setHeadLock.Unlock()
+	if e.setHeadStatus == nil {
+		return nil
+	}
+	status := *e.setHeadStatus
+	if !status.started.IsZero() && status.Running {
+		status.Elapsed = time.Since(status.started).Round(time.Millisecond).String()
+	}
+	return &status
+}
+
+func (e *EthereumExecutionModule) updateSetHeadStatus(f func(s *SetHeadStatus)) {
+	e.setHeadLock.Lock()
+	defer e.setHeadLock.Unlock()
+	f(e.setHeadStatus)
+}
+
+func (e *EthereumExecutionModule) setHeadPlan(ctx context.Context, tx kv.Tx, number uint64) (*SetHeadStatus, error) {
+	head := rawdb.ReadCurrentBlockNumber(tx)
+	if head == nil {
+		return nil, errors.New("head block not found")
+	}
+	if number >= *head {
+		return nil, fmt.Errorf("block %d is not below head block %d", number, *head)
+	}
+	if frozen := e.blockReader.FrozenBlocks(); number < frozen {
+		return nil, fmt.Errorf("block %d is in snapshot files, min block: %d", number, frozen)
+	}
+	minUnwindable, err := minUnwindableBlock(tx, number)
+	if err != nil {
+		return nil, err
+	}
+	if number < minUnwindable {
+		return nil, fmt.Errorf("block %d is too deep to unwind state, min block: %d", number, minUnwindable)
+	}
+	hash, err := e.canonicalHash(ctx, tx, number)
+	if err != nil {
+		return nil, err
+	}
+	if hash == (common.Hash{}) {
+		return nil, fmt.Errorf("canonical block %d not found", number)
+	}
+	return &SetHeadStatus{From: hexutil.Uint64(*head), To: hexutil.Uint64(number), Hash: hash, MinUnwindable: hexutil.Uint64(minUnwindable)}, nil
+}
+
+func (e *EthereumExecutionModule) setHead(number uint64, hash common.Hash) {
+	err := e.unwindHead(number, hash)
+	e.updateSetHeadStatus(func(s *SetHeadStatus) {
+		s.Running, s.Done, s.Stage = false, err == nil, ""
+		s.Elapsed = time.Since(s.started).Round(time.Millisecond).String()
+		if err != nil {
+			s.Error = err.Error()
+		} else {
+			s.StagesDone = s.StagesTotal
+		}
+	})
+	if err != nil {
+		e.logger.Error("[setHead] unwind failed", "block", number, "err", err)
+		return
+	}
+	e.logger.Info("[setHead] head updated", "block", number, "hash", hash)
+}
+
+func (e *EthereumExecutionModule) unwindHead(number uint64, hash common.Hash) error {
+	ctx := e.bacgroundCtx
+	if err := e.semaphore.Acquire(ctx, 1); err != nil {
+		return err
+	}
+	defer e.semaphore.Release(1)
+	defer e.forkValidator.ClearWithUnwind(e.accumulator, e.stateChangeConsumer)
+
+	tx, err := e.db.BeginTemporalRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// canonical chain may change while waiting for semaphore
+	plan, err := e.setHeadPlan(ctx, tx, number)
+	if err != nil {
+		return err
+	}
+	if plan.Hash != hash {
+		return fmt.Errorf("canonical block %d changed: %x", number, plan.Hash)
+	}
+	finishProgressBefore, err := stages.GetStageProgress(tx, stages.Finish)
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info("[setHead] unwinding", "from", uint64(plan.From), "to", number, "hash", hash)
+	if err := e.hook.BeforeRun(tx, true); err != nil {
+		return err
+	}
+	if err := e.executionPipeline.UnwindTo(number, stagedsync.StagedUnwind, tx); err != nil {
+		return err
+	}
+	progress := func(stage stages.SyncStage, done, total int) {
+		e.logger.Info("[setHead] unwinding", "stage", stage, "progress", fmt.Sprintf("%d/%d", done, total))
+		e.updateSetHeadStatus(func(s *SetHeadStatus) {
+			s.Stage, s.StagesDone, s.StagesTotal = string(stage), done, total
+		})
+	}
+	if err := e.executionPipeline.RunUnwindWithProgress(e.db, wrap.NewTxContainer(tx, nil), progress); err != nil {
+		return err
+	}
+
+	// execution pipeline doesn't have headers and bodies stages - move chain markers here
+	if err := rawdb.TruncateCanonicalChain(ctx, tx, number+1); err != nil {
+		return err
+	}
+	if err := rawdbv3.TxNums.Truncate(tx, number+1); err != nil {
+		return err
+	}
+	for _, stage := range []stages.SyncStage{stages.Headers, stages.BlockHashes, stages.Bodies} {
+		if err := stages.SaveStageProgress(tx, stage, number); err != nil {
+			return err
+		}
+	}
+	if err := rawdb.WriteHeadHeaderHash(tx, hash); err != nil {
+		return err
+	}
+	rawdb.WriteHeadBlockHash(tx, hash)
+	rawdb.WriteForkchoiceHead(tx, hash)
+	// safe and finalized blocks above new head are not canonical anymore
+	if h, err := e.isAbove(ctx, tx, rawdb.ReadForkchoiceSafe(tx), number); err != nil {
+		return err
+	} else if h {
+		rawdb.WriteForkchoiceSafe(tx, hash)
+	}
+	if h, err := e.isAbove(ctx, tx, rawdb.ReadForkchoiceFinalized(tx), number); err != nil {
+		return err
+	} else if h {
+		rawdb.WriteForkchoiceFinalized(tx, hash)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return e.hook.AfterRun(nil, finishProgressBefore)
+}
+
+func (e *EthereumExecutionModule) isAbove(ctx context.Context, tx kv.Tx, hash common.Hash, number uint64) (bool, error) {
+	if hash == (common.Hash{}) {
+		return false, nil
+	}
+	n, err := e.blockReader.HeaderNumber(ctx, tx, hash)
+	if err != nil {
+		return false, err
+	}
+	return n != nil && *n > number, nil
+}
+
+// SetHeadAPI - debug_setHead and debug_setHeadStatus, for operators of devnets recovering from bad forks
+type SetHeadAPI struct{ e *EthereumExecutionModule }
+
+func (e *EthereumExecutionModule) APIs() []rpc.API {
+	return []rpc.API{
+		{Namespace: "debug", Public: false, Service: &SetHeadAPI{e}, Version: "1.0"},
+		{Namespace: "admin", Public: false, Service: &ReorgGuardAPI{e}, Version: "1.0"},
+	}
+}
+
+// SetHead - without confirm returns plan of unwind. With confirm (hash of target block from plan) starts unwind.
+func (api *SetHeadAPI) SetHead(ctx context.Context, number hexutil.Uint64, confirm *common.Hash) (*SetHeadStatus, error) {
+	return api.e.SetHead(audit.WithOrigin(ctx, rpc.PeerInfoFromContext(ctx).Origin()), uint64(number), confirm)
+}
+
+// SetHeadStatus - progress of last debug_setHead
+func (api *SetHeadAPI) SetHeadStatus() *SetHeadStatus {
+	return api.e.SetHeadStatus()
+}
@@ -144,6 +144,7 @@ func (rw *HistoricalTraceWorker) RunTxTask(txTask *state.TxTask) {
 	rw.stateWriter = state.NewNoopWriter()
 
 	rw.ibs.Reset()
+	rw.ibs.RecordAccessList(rw.execArgs.RecordAccessLists)
 	ibs := rw.ibs
 
 	var err error
@@ -195,6 +196,14 @@ func (rw *HistoricalTraceWorker) RunTxTask(txTask *state.TxTask) {
 		ibs.SetTxContext(txTask.TxIndex)
 		msg := txTask.TxAsMessage
 		msg.SetCheckNonce(!rw.vmConfig.StatelessExec)
+		if msg.IsDepositTx() {
+			nonce, err := ibs.GetNonce(msg.From())
+			if err != nil {
+				txTask.Error = err
+				break
+			}
+			txTask.DepositNonce = &nonce
+		}
 
 		txContext := core.NewEVMTxContext(msg)
 		if rw.vmConfig.TraceJumpDest {
@@ -218,6 +227,9 @@ func (rw *HistoricalTraceWorker) RunTxTask(txTask *state.TxTask) {
 			txTask.Logs = ibs.GetLogs(txTask.TxIndex, txTask.Tx.Hash(), txTask.BlockNum, txTask.BlockHash)
 			txTask.TraceFroms = txTask.Tracer.Froms()
 			txTask.TraceTos = txTask.Tracer.Tos()
+			if rw.execArgs.RecordAccessLists {
+				txTask.AccessList = ibs.RecordedAccessList(accessListExcludes(rules, msg))
+			}
 		}
 	}
 }
@@ -243,6 +255,8 @@ type ExecArgs struct {
 	Dirs        datadir.Dirs
 	ChainConfig *chain.Config
 	Workers     int
+
+	RecordAccessLists bool // fill TxTask.AccessList
 }
 
 func NewHistoricalTraceWorkers(consumer TraceConsumer, cfg *ExecArgs, ctx context.Context, toTxNum uint64, in *state.QueueWithRetry, workerCount int, outputTxNum *atomic.Uint64, logger log.Logger) *errgroup.Group {
@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
@@ -211,6 +212,7 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 	rw.stateWriter.ResetWriteSet()
 
 	rw.ibs.Reset()
+	rw.ibs.RecordAccessList(rw.rs.RecordAccessLists())
 	ibs := rw.ibs
 	//ibs.SetTrace(true)
 	ibs.SetHooks(rw.hooks)
@@ -303,6 +305,14 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 		}
 
 		msg := txTask.TxAsMessage
+		if msg.IsDepositTx() {
+			nonce, err := ibs.GetNonce(msg.From())
+			if err != nil {
+				txTask.Error = err
+				break
+			}
+			txTask.DepositNonce = &nonce
+		}
 		rw.evm.ResetBetweenBlocks(txTask.EvmBlockContext, core.NewEVMTxContext(msg), ibs, rw.vmCfg, rules)
 
 		if rw.hooks != nil && rw.hooks.OnTxStart != nil {
@@ -324,6 +334,9 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 			txTask.Logs = ibs.GetLogs(txTask.TxIndex, txTask.Tx.Hash(), txTask.BlockNum, txTask.BlockHash)
 			txTask.TraceFroms = rw.callTracer.Froms()
 			txTask.TraceTos = rw.callTracer.Tos()
+			if rw.rs.RecordAccessLists() {
+				txTask.AccessList = ibs.RecordedAccessList(accessListExcludes(rules, msg))
+			}
 
 			txTask.CreateReceipt(rw.Tx())
 			if rw.hooks != nil && rw.hooks.OnTxEnd != nil {
@@ -347,6 +360,21 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining, skipPostEvalua
 	}
 }
 
+// accessListExcludes - addresses which are warm from the start of txn: sender, recipient (or created contract)
+// and precompiles. They are part of its access list only with accessed storage slots.
+func accessListExcludes(rules *chain.Rules, msg *types.Message) []common.Address {
+	precompiles := vm.ActivePrecompiles(rules)
+	excludes := make([]common.Address, 0, len(precompiles)+2)
+	excludes = append(excludes, precompiles...)
+	excludes = append(excludes, msg.From())
+	if to := msg.To(); to != nil {
+		excludes = append(excludes, *to)
+	} else {
+		excludes = append(excludes, crypto.CreateAddress(msg.From(), msg.Nonce()))
+	}
+	return excludes
+}
+
 func (rw *Worker) execAATxn(txTask *state.TxTask) {
 	if !txTask.InBatch {
 		// this is the first transaction in an AA transaction batch, run all validation frames, then execute execution frames in its own txtask
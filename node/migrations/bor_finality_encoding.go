@@ -0,0 +1,54 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	finalityrawdb "github.com/erigontech/erigon/polygon/bor/finality/rawdb"
+)
+
+var BorFinalityTypedEncoding = Migration{
+	// bor whitelist state (milestone, checkpoint, lock field, future milestones) moved from JSON to versioned RLP
+	Name: "bor_finality_typed_encoding",
+	Up: func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback, logger log.Logger) (err error) {
+		tx, err := db.BeginRw(context.Background())
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		migrated, err := finalityrawdb.MigrateLegacyEncoding(tx)
+		if err != nil {
+			return err
+		}
+
+		if migrated > 0 {
+			logger.Info("[migration] re-encoded bor finality entries", "count", migrated)
+		}
+
+		if err := BeforeCommit(tx, nil, true); err != nil {
+			return err
+		}
+
+		return tx.Commit()
+	},
+	Tables: []string{kv.BorFinality},
+}
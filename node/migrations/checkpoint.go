@@ -0,0 +1,308 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Checkpoint is a copy of Migration.Tables taken before the migration is applied. It's stored as one file per
+// table in <datadir>/migrations/checkpoints/<migration name>:
+//   - if Up fails - tables are restored from checkpoint, and migration will start from scratch next time
+//   - if Up succeeds - checkpoint is kept, `integration rollback_migration` can restore tables and mark migration
+//     as not applied (for example to downgrade erigon after upgrade)
+//
+// Checkpoint is taken only once: if Up was interrupted (by crash) - tables are half-migrated, and checkpoint of
+// previous attempt must not be overwritten.
+
+const checkpointExt = ".bak"
+
+var ErrNoCheckpoint = errors.New("migration has no checkpoint")
+
+func CheckpointDir(dataDir, name string) string {
+	return filepath.Join(dataDir, "migrations", "checkpoints", name)
+}
+
+// TableImpact - size of table which migration is going to rewrite
+type TableImpact struct {
+	Table   string
+	Entries uint64
+	Size    uint64 // bytes of db pages used by table
+}
+
+type MigrationImpact struct {
+	Name   string
+	Tables []TableImpact
+}
+
+// DryRunReport - what Apply is going to do, without doing it
+type DryRunReport struct {
+	SchemaFrom string // empty if db has no schema version yet
+	SchemaTo   string
+	Pending    []MigrationImpact
+}
+
+func (r *DryRunReport) Log(logger log.Logger) {
+	if len(r.Pending) == 0 {
+		logger.Info("[migration] dry-run: no pending migrations", "schema", r.SchemaFrom)
+		return
+	}
+	logger.Info("[migration] dry-run", "pending", len(r.Pending), "schemaFrom", r.SchemaFrom, "schemaTo", r.SchemaTo)
+	for _, m := range r.Pending {
+		if len(m.Tables) == 0 {
+			logger.Info("[migration] dry-run: migration doesn't declare tables, no checkpoint", "name", m.Name)
+			continue
+		}
+		for _, t := range m.Tables {
+			logger.Info("[migration] dry-run", "name", m.Name, "table", t.Table, "entries", t.Entries, "size", datasize.ByteSize(t.Size).HR())
+		}
+	}
+}
+
+// DryRun - reports pending migrations and size of tables which they will rewrite
+func (m *Migrator) DryRun(db kv.RwDB) (*DryRunReport, error) {
+	report := &DryRunReport{SchemaTo: schemaVersionString(kv.DBSchemaVersion.Major, kv.DBSchemaVersion.Minor, kv.DBSchemaVersion.Patch)}
+	if err := db.View(context.Background(), func(tx kv.Tx) error {
+		major, minor, patch, ok, err := rawdb.ReadDBSchemaVersion(tx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			report.SchemaFrom = schemaVersionString(major, minor, patch)
+		}
+
+		pending, err := m.PendingMigrations(tx)
+		if err != nil {
+			return err
+		}
+		for _, v := range pending {
+			impact := MigrationImpact{Name: v.Name}
+			for _, table := range v.Tables {
+				t, err := tableImpact(tx, table)
+				if err != nil {
+					return err
+				}
+				impact.Tables = append(impact.Tables, t)
+			}
+			report.Pending = append(report.Pending, impact)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("migrator.DryRun: %w", err)
+	}
+	return report, nil
+}
+
+func schemaVersionString(major, minor, patch uint32) string {
+	return fmt.Sprintf("%d.%d.%d", major, minor, patch)
+}
+
+func tableImpact(tx kv.Tx, table string) (TableImpact, error) {
+	res := TableImpact{Table: table}
+	if bm, ok := tx.(kv.BucketMigrator); ok { // table may be created by the migration
+		exists, err := bm.ExistsTable(table)
+		if err != nil || !exists {
+			return res, err
+		}
+	}
+	var err error
+	if res.Entries, err = tx.Count(table); err != nil {
+		return res, err
+	}
+	if res.Size, err = tx.BucketSize(table); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// takeCheckpoint - copies tables to checkpoint dir. Does nothing if checkpoint of same tables already exists.
+func takeCheckpoint(db kv.RoDB, checkpointDir string, tables []string, logger log.Logger) error {
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		return err
+	}
+	return db.View(context.Background(), func(tx kv.Tx) error {
+		for _, table := range tables {
+			fPath := filepath.Join(checkpointDir, table+checkpointExt)
+			exists, err := dir.FileExist(fPath)
+			if err != nil {
+				return err
+			}
+			if exists {
+				continue
+			}
+			entries, err := writeCheckpointFile(tx, table, fPath)
+			if err != nil {
+				return fmt.Errorf("checkpoint of table %s: %w", table, err)
+			}
+			logger.Info("[migration] checkpoint", "table", table, "entries", entries, "file", fPath)
+		}
+		return nil
+	})
+}
+
+// writeCheckpointFile - file is sequence of (uvarint len(k), k, uvarint len(v), v). File is renamed into place
+// only when complete: existing file is always a full copy of table.
+func writeCheckpointFile(tx kv.Tx, table, fPath string) (entries uint64, err error) {
+	tmpPath := fPath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	defer os.Remove(tmpPath)
+
+	w := bufio.NewWriter(f)
+	numBuf := make([]byte, binary.MaxVarintLen64)
+	writeBytes := func(b []byte) error {
+		n := binary.PutUvarint(numBuf, uint64(len(b)))
+		if _, err := w.Write(numBuf[:n]); err != nil {
+			return err
+		}
+		_, err := w.Write(b)
+		return err
+	}
+
+	exists := true
+	if bm, ok := tx.(kv.BucketMigrator); ok {
+		if exists, err = bm.ExistsTable(table); err != nil {
+			return 0, err
+		}
+	}
+	if exists {
+		if err := tx.ForEach(table, nil, func(k, v []byte) error {
+			entries++
+			if err := writeBytes(k); err != nil {
+				return err
+			}
+			return writeBytes(v)
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	if err := f.Sync(); err != nil {
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	return entries, os.Rename(tmpPath, fPath)
+}
+
+// restoreCheckpoint - replaces content of tables by content of checkpoint files
+func restoreCheckpoint(tx kv.RwTx, checkpointDir string, logger log.Logger) error {
+	files, err := os.ReadDir(checkpointDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return ErrNoCheckpoint
+		}
+		return err
+	}
+	restored := 0
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != checkpointExt {
+			continue
+		}
+		table := strings.TrimSuffix(file.Name(), checkpointExt)
+		entries, err := readCheckpointFile(tx, table, filepath.Join(checkpointDir, file.Name()))
+		if err != nil {
+			return fmt.Errorf("restore of table %s: %w", table, err)
+		}
+		logger.Info("[migration] restored from checkpoint", "table", table, "entries", entries)
+		restored++
+	}
+	if restored == 0 {
+		return ErrNoCheckpoint
+	}
+	return nil
+}
+
+func readCheckpointFile(tx kv.RwTx, table, fPath string) (entries uint64, err error) {
+	f, err := os.Open(fPath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if err := tx.ClearTable(table); err != nil {
+		return 0, err
+	}
+	r := bufio.NewReader(f)
+	readBytes := func() ([]byte, error) {
+		l, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, l)
+		_, err = io.ReadFull(r, b)
+		return b, err
+	}
+	for {
+		k, err := readBytes()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		v, err := readBytes()
+		if err != nil {
+			return entries, fmt.Errorf("truncated file %s: %w", fPath, err)
+		}
+		if err := tx.Put(table, k, v); err != nil {
+			return entries, err
+		}
+		entries++
+	}
+}
+
+// Rollback - restores tables of migration from its checkpoint and marks migration as not applied (and drops its
+// progress), next Apply will run it again. Apply calls it when Up fails.
+func (m *Migrator) Rollback(db kv.RwDB, dataDir, name string, logger log.Logger) error {
+	checkpointDir := CheckpointDir(dataDir, name)
+	if err := db.Update(context.Background(), func(tx kv.RwTx) error {
+		if err := restoreCheckpoint(tx, checkpointDir, logger); err != nil {
+			return err
+		}
+		if err := tx.Delete(kv.Migrations, []byte(name)); err != nil {
+			return err
+		}
+		return tx.Delete(kv.Migrations, []byte("_progress_"+name))
+	}); err != nil {
+		return fmt.Errorf("migrator.Rollback: %s, %w", name, err)
+	}
+	logger.Info("Rolled back migration", "name", name)
+	return os.RemoveAll(checkpointDir)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestCheckpoint(t *testing.T) {
+	require, db, dataDir := require.New(t), memdb.NewTestDB(t, kv.ChainDB), t.TempDir()
+	logger := log.New()
+
+	err := db.Update(context.Background(), func(tx kv.RwTx) error {
+		for _, k := range []string{"a", "b", "c"} {
+			if err := tx.Put(kv.BorFinality, []byte(k), []byte("old_"+k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(err)
+
+	rewrite := func(fail bool) func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback, logger log.Logger) error {
+		return func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback, logger log.Logger) error {
+			// commit half of work with progress, as long-running migrations do
+			if err := db.Update(context.Background(), func(tx kv.RwTx) error {
+				if err := tx.Put(kv.BorFinality, []byte("a"), []byte("new_a")); err != nil {
+					return err
+				}
+				return BeforeCommit(tx, []byte("a"), false)
+			}); err != nil {
+				return err
+			}
+			if fail {
+				return errors.New("oops")
+			}
+			tx, err := db.BeginRw(context.Background())
+			if err != nil {
+				return err
+			}
+			defer tx.Rollback()
+			if err := tx.Delete(kv.BorFinality, []byte("b")); err != nil {
+				return err
+			}
+			if err := BeforeCommit(tx, nil, true); err != nil {
+				return err
+			}
+			return tx.Commit()
+		}
+	}
+	migrator := NewMigrator(kv.ChainDB)
+	migrator.Migrations = []Migration{{Name: "one", Up: rewrite(true), Tables: []string{kv.BorFinality}}}
+
+	report, err := migrator.DryRun(db)
+	require.NoError(err)
+	require.Equal([]MigrationImpact{{Name: "one", Tables: []TableImpact{{Table: kv.BorFinality, Entries: 3, Size: report.Pending[0].Tables[0].Size}}}}, report.Pending)
+
+	readTable := func() map[string]string {
+		res := map[string]string{}
+		err := db.View(context.Background(), func(tx kv.Tx) error {
+			return tx.ForEach(kv.BorFinality, nil, func(k, v []byte) error {
+				res[string(k)] = string(v)
+				return nil
+			})
+		})
+		require.NoError(err)
+		return res
+	}
+	before := map[string]string{"a": "old_a", "b": "old_b", "c": "old_c"}
+
+	// failed migration: half-done work is rolled back, progress is dropped
+	err = migrator.Apply(db, dataDir, "", logger)
+	require.ErrorContains(err, "oops")
+	require.Equal(before, readTable())
+	err = db.View(context.Background(), func(tx kv.Tx) error {
+		progress, err := tx.GetOne(kv.Migrations, []byte("_progress_one"))
+		require.Nil(progress)
+		return err
+	})
+	require.NoError(err)
+	exists, err := dir.Exist(CheckpointDir(dataDir, "one"))
+	require.NoError(err)
+	require.False(exists)
+
+	// successful migration: checkpoint is kept
+	migrator.Migrations[0].Up = rewrite(false)
+	require.NoError(migrator.Apply(db, dataDir, "", logger))
+	require.Equal(map[string]string{"a": "new_a", "c": "old_c"}, readTable())
+	has, err := migrator.HasPendingMigrations(db)
+	require.NoError(err)
+	require.False(has)
+	report, err = migrator.DryRun(db)
+	require.NoError(err)
+	require.Empty(report.Pending)
+
+	// manual rollback: tables are restored and migration is pending again
+	require.NoError(migrator.Rollback(db, dataDir, "one", logger))
+	require.Equal(before, readTable())
+	has, err = migrator.HasPendingMigrations(db)
+	require.NoError(err)
+	require.True(has)
+
+	err = migrator.Rollback(db, dataDir, "one", logger)
+	require.ErrorIs(err, ErrNoCheckpoint)
+}
@@ -54,6 +54,7 @@ var migrations = map[kv.Label][]Migration{
 		ProhibitNewDownloadsLock2,
 		ClearBorTables,
 		ResetStageTxnLookup,
+		BorFinalityTypedEncoding,
 	},
 	kv.TxPoolDB: {},
 	kv.SentryDB: {},
@@ -63,6 +64,9 @@ type Callback func(tx kv.RwTx, progress []byte, isDone bool) error
 type Migration struct {
 	Name string
 	Up   func(db kv.RwDB, dirs datadir.Dirs, progress []byte, BeforeCommit Callback, logger log.Logger) error
+	// Tables - which migration rewrites. Optional: they are reported by DryRun and copied to checkpoint before Up,
+	// see CheckpointDir
+	Tables []string
 }
 
 var (
@@ -215,6 +219,13 @@ func (m *Migrator) Apply(db kv.RwDB, dataDir, chaindata string, logger log.Logge
 			return fmt.Errorf("migrator.Apply: %w", err)
 		}
 
+		checkpointDir := CheckpointDir(dirs.DataDir, v.Name)
+		if len(v.Tables) > 0 {
+			if err := takeCheckpoint(db, checkpointDir, v.Tables, logger); err != nil {
+				return fmt.Errorf("migrator.Apply: %s, %w", v.Name, err)
+			}
+		}
+
 		dirs.Tmp = filepath.Join(dirs.DataDir, "migrations", v.Name)
 		dir.MustExist(dirs.Tmp)
 		if err := v.Up(db, dirs, progress, func(tx kv.RwTx, key []byte, isDone bool) error {
@@ -244,6 +255,11 @@ func (m *Migrator) Apply(db kv.RwDB, dataDir, chaindata string, logger log.Logge
 
 			return nil
 		}, logger); err != nil {
+			if len(v.Tables) > 0 {
+				if rollbackErr := m.Rollback(db, dirs.DataDir, v.Name, logger); rollbackErr != nil {
+					return fmt.Errorf("migrator.Apply.Up: %s, %w, rollback: %w", v.Name, err, rollbackErr)
+				}
+			}
 			return fmt.Errorf("migrator.Apply.Up: %s, %w", v.Name, err)
 		}
 
@@ -248,7 +248,7 @@ func (n *Node) openDataDir(ctx context.Context) error {
 		n.dirLock = l
 		break
 	}
-	return nil
+	return n.config.Dirs.FinishRelocation()
 }
 
 func (n *Node) closeDataDir() {
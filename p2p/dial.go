@@ -82,6 +82,7 @@ var (
 	errAlreadyConnected = errors.New("already connected")
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
+	errBanned           = errors.New("banned")
 	errNoPort           = errors.New("node does not provide TCP port")
 )
 
@@ -140,6 +141,7 @@ type dialConfig struct {
 	maxDialPeers   int              // maximum number of dialed peers
 	maxActiveDials int              // maximum number of active dials
 	netRestrict    *netutil.Netlist // IP whitelist, disabled if nil
+	isBanned       func(enode.ID) bool
 	resolver       nodeResolver
 	dialer         NodeDialer
 	log            log.Logger
@@ -297,6 +299,8 @@ loop:
 			_, exists := d.static[id]
 			d.log.Trace("Adding static node", "id", id, "ip", node.IP(), "added", !exists)
 			if exists {
+				// The node may have been refused earlier, e.g. while it was banned.
+				d.updateStaticPool(id)
 				continue loop
 			}
 			task := newDialTask(node, staticDialedConn)
@@ -420,6 +424,9 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	if d.netRestrict != nil && !d.netRestrict.Contains(n.IP()) {
 		return errNotWhitelisted
 	}
+	if d.isBanned != nil && d.isBanned(n.ID()) {
+		return errBanned
+	}
 	if d.history.contains(string(n.ID().Bytes())) {
 		return errRecentlyDialed
 	}
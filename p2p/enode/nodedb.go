@@ -95,8 +95,9 @@ func OpenDB(ctx context.Context, path string, tmpDir string, logger log.Logger)
 
 func bucketsConfig(_ kv.TableCfg) kv.TableCfg {
 	return kv.TableCfg{
-		kv.Inodes:      {},
-		kv.NodeRecords: {},
+		kv.Inodes:          {},
+		kv.NodeRecords:     {},
+		kv.NodeReputations: {},
 	}
 }
 
@@ -548,6 +549,132 @@ func (db *DB) UpdateFindFailsV5(id ID, ip net.IP, fails int) error {
 	return db.storeInt64(v5Key(id, ip, dbNodeFindFails), int64(fails))
 }
 
+// NodeReputation is the standing of a node, built from the penalties it received and
+// the bans imposed on it. It is stored apart from the discovery data, so that it is not
+// dropped together with unseen nodes.
+type NodeReputation struct {
+	Penalties   uint64
+	LastPenalty time.Time // zero if never penalized
+	Banned      bool
+	BannedUntil time.Time // zero if banned indefinitely
+}
+
+// PenaltyDecayPeriod is the time after which one penalty of a node is forgiven, so
+// that only nodes misbehaving repeatedly accumulate penalties.
+const PenaltyDecayPeriod = time.Hour
+
+// PenaltiesAt returns the penalties of the node at the given time, without the ones
+// which decayed since the last penalty.
+func (r NodeReputation) PenaltiesAt(now time.Time) uint64 {
+	if r.LastPenalty.IsZero() || !now.After(r.LastPenalty) {
+		return r.Penalties
+	}
+	decayed := uint64(now.Sub(r.LastPenalty) / PenaltyDecayPeriod)
+	return r.Penalties - min(decayed, r.Penalties)
+}
+
+// Penalize records a penalty received at the given time.
+func (r *NodeReputation) Penalize(now time.Time) {
+	r.Penalties = r.PenaltiesAt(now) + 1
+	// stored with a second precision, truncate so the cache matches the database
+	r.LastPenalty = time.Unix(now.Unix(), 0)
+}
+
+// IsBanned reports whether the node is banned at the given time.
+func (r NodeReputation) IsBanned(now time.Time) bool {
+	return r.Banned && (r.BannedUntil.IsZero() || now.Before(r.BannedUntil))
+}
+
+const nodeReputationLen = 25
+
+func (r NodeReputation) encode() []byte {
+	blob := make([]byte, nodeReputationLen)
+	binary.BigEndian.PutUint64(blob, r.Penalties)
+	if !r.BannedUntil.IsZero() {
+		binary.BigEndian.PutUint64(blob[8:], uint64(r.BannedUntil.Unix()))
+	}
+	if r.Banned {
+		blob[16] = 1
+	}
+	if !r.LastPenalty.IsZero() {
+		binary.BigEndian.PutUint64(blob[17:], uint64(r.LastPenalty.Unix()))
+	}
+	return blob
+}
+
+func decodeNodeReputation(blob []byte) (NodeReputation, error) {
+	if len(blob) != nodeReputationLen {
+		return NodeReputation{}, fmt.Errorf("invalid node reputation length: %d", len(blob))
+	}
+
+	r := NodeReputation{
+		Penalties: binary.BigEndian.Uint64(blob),
+		Banned:    blob[16] == 1,
+	}
+	if bannedUntil := binary.BigEndian.Uint64(blob[8:]); bannedUntil != 0 {
+		r.BannedUntil = time.Unix(int64(bannedUntil), 0)
+	}
+	if lastPenalty := binary.BigEndian.Uint64(blob[17:]); lastPenalty != 0 {
+		r.LastPenalty = time.Unix(int64(lastPenalty), 0)
+	}
+	return r, nil
+}
+
+// Reputation retrieves the stored reputation of a node.
+func (db *DB) Reputation(id ID) (r NodeReputation, err error) {
+	err = db.kv.View(db.ctx, func(tx kv.Tx) error {
+		blob, err := tx.GetOne(kv.NodeReputations, id[:])
+		if err != nil || blob == nil {
+			return err
+		}
+		r, err = decodeNodeReputation(blob)
+		return err
+	})
+	return r, err
+}
+
+// UpdateReputation atomically applies update to the stored reputation of a node and
+// returns the result. A reputation updated to the zero value is deleted.
+func (db *DB) UpdateReputation(id ID, update func(r *NodeReputation)) (r NodeReputation, err error) {
+	err = db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+		blob, err := tx.GetOne(kv.NodeReputations, id[:])
+		if err != nil {
+			return err
+		}
+		if blob != nil {
+			if r, err = decodeNodeReputation(blob); err != nil {
+				return err
+			}
+		}
+
+		update(&r)
+
+		if r == (NodeReputation{}) {
+			return tx.Delete(kv.NodeReputations, id[:])
+		}
+		return tx.Put(kv.NodeReputations, id[:], r.encode())
+	})
+	return r, err
+}
+
+// Reputations retrieves the stored reputations of all nodes.
+func (db *DB) Reputations() (map[ID]NodeReputation, error) {
+	reputations := map[ID]NodeReputation{}
+	err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		return tx.ForEach(kv.NodeReputations, nil, func(k, v []byte) error {
+			r, err := decodeNodeReputation(v)
+			if err != nil {
+				return err
+			}
+			var id ID
+			copy(id[:], k)
+			reputations[id] = r
+			return nil
+		})
+	})
+	return reputations, err
+}
+
 // LocalSeq retrieves the local record sequence counter.
 func (db *DB) localSeq(id ID) uint64 {
 	return db.fetchUint64(localItemKey(id, dbLocalSeq))
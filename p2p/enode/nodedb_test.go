@@ -494,3 +494,103 @@ func TestDBExpireV5(t *testing.T) {
 	db.UpdateFindFailsV5(ID{}, ip, 4)
 	db.expireNodes()
 }
+
+func TestDBReputation(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "database")
+
+	db, err := OpenDB(context.Background(), path, root, log.Root())
+	if err != nil {
+		t.Fatalf("failed to create persistent database: %v", err)
+	}
+	defer db.Close()
+
+	lastPenalty := time.Now()
+	penalize := func(r *NodeReputation) { r.Penalize(lastPenalty) }
+	for i := 0; i < 3; i++ {
+		if _, err := db.UpdateReputation(keytestID, penalize); err != nil {
+			t.Fatalf("failed to update reputation: %v", err)
+		}
+	}
+	bannedUntil := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	r, err := db.UpdateReputation(keytestID, func(r *NodeReputation) {
+		r.Banned = true
+		r.BannedUntil = bannedUntil
+	})
+	if err != nil {
+		t.Fatalf("failed to update reputation: %v", err)
+	}
+	want := NodeReputation{Penalties: 3, LastPenalty: time.Unix(lastPenalty.Unix(), 0), Banned: true, BannedUntil: bannedUntil}
+	if r != want {
+		t.Fatalf("reputation mismatch: have %v, want %v", r, want)
+	}
+	if !r.IsBanned(time.Now()) {
+		t.Errorf("node not banned before expiry")
+	}
+	if r.IsBanned(bannedUntil) {
+		t.Errorf("node still banned after expiry")
+	}
+	db.Close()
+
+	// Reopen the database and check that the reputation survived
+	db, err = OpenDB(context.Background(), path, root, log.Root())
+	if err != nil {
+		t.Fatalf("failed to open persistent database: %v", err)
+	}
+	defer db.Close()
+	if r, err := db.Reputation(keytestID); err != nil || r != want {
+		t.Fatalf("reputation mismatch: have %v (%v), want %v", r, err, want)
+	}
+	reputations, err := db.Reputations()
+	if err != nil {
+		t.Fatalf("failed to list reputations: %v", err)
+	}
+	if len(reputations) != 1 {
+		t.Fatalf("wrong number of reputations: have %d, want 1", len(reputations))
+	}
+
+	// Resetting the reputation removes it
+	if _, err := db.UpdateReputation(keytestID, func(r *NodeReputation) { *r = NodeReputation{} }); err != nil {
+		t.Fatalf("failed to update reputation: %v", err)
+	}
+	if reputations, _ := db.Reputations(); len(reputations) != 0 {
+		t.Fatalf("reputation not deleted: %v", reputations)
+	}
+}
+
+func TestNodeReputationDecay(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+	var r NodeReputation
+	for i := 0; i < 3; i++ {
+		r.Penalize(now)
+	}
+	if r.Penalties != 3 || r.PenaltiesAt(now) != 3 {
+		t.Fatalf("wrong penalties: have %d, want 3", r.Penalties)
+	}
+
+	// one penalty decays per period since the last one
+	if have := r.PenaltiesAt(now.Add(PenaltyDecayPeriod - time.Second)); have != 3 {
+		t.Errorf("penalty decayed too early: have %d, want 3", have)
+	}
+	if have := r.PenaltiesAt(now.Add(2 * PenaltyDecayPeriod)); have != 1 {
+		t.Errorf("wrong decayed penalties: have %d, want 1", have)
+	}
+	if have := r.PenaltiesAt(now.Add(10 * PenaltyDecayPeriod)); have != 0 {
+		t.Errorf("wrong decayed penalties: have %d, want 0", have)
+	}
+
+	// a penalty after a long time of good behaviour starts from decayed penalties
+	r.Penalize(now.Add(2 * PenaltyDecayPeriod))
+	if r.Penalties != 2 {
+		t.Errorf("wrong penalties: have %d, want 2", r.Penalties)
+	}
+
+	// occasional penalties never accumulate
+	r = NodeReputation{}
+	for i := 0; i < 10; i++ {
+		r.Penalize(now.Add(time.Duration(i) * PenaltyDecayPeriod))
+	}
+	if r.Penalties != 1 {
+		t.Errorf("occasional penalties accumulated: have %d, want 1", r.Penalties)
+	}
+}
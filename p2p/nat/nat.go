@@ -28,9 +28,10 @@ import (
 	"sync"
 	"time"
 
-	"github.com/erigontech/erigon-lib/log/v3"
-
 	natpmp "github.com/jackpal/go-nat-pmp"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/netutil"
 )
 
 // An implementation of nat.Interface can map local ports to ports
@@ -61,6 +62,8 @@ type Interface interface {
 //	"" or "none"         return nil
 //	"extip:77.12.33.4"   will assume the local machine is reachable on the given IP
 //	"any"                uses the first auto-detected mechanism
+//	"auto"               maps ports with UPnP or NAT-PMP, and detects the external IP with STUN if needed
+//	"auto:<server>"      same as "auto" using the given STUN server (host:port)
 //	"upnp"               uses the Universal Plug and Play protocol
 //	"pmp"                uses NAT-PMP with an auto-detected gateway address
 //	"pmp:192.168.0.1"    uses NAT-PMP with the given gateway address
@@ -74,8 +77,14 @@ func Parse(spec string) (Interface, error) {
 	switch mech {
 	case "", "none", "off":
 		return nil, nil
-	case "any", "auto", "on":
+	case "any", "on":
 		return Any(), nil
+	case "auto":
+		var addr string
+		if len(parts) > 1 {
+			addr = parts[1]
+		}
+		return Auto(addr), nil
 	case "extip", "ip":
 		if len(parts) < 2 {
 			return nil, errors.New("missing IP address")
@@ -107,8 +116,33 @@ func Parse(spec string) (Interface, error) {
 	}
 }
 
+var (
+	sharedLock sync.Mutex
+	shared     = map[string]Interface{}
+)
+
+// Shared is like Parse, but returns the same port mapper for the same description within the process:
+// sentries of all protocols and of all chains (--chains.config) share gateway discovery and external IP.
+func Shared(spec string) (Interface, error) {
+	sharedLock.Lock()
+	defer sharedLock.Unlock()
+	if m, ok := shared[spec]; ok {
+		return m, nil
+	}
+	m, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	shared[spec] = m
+	return m, nil
+}
+
 const (
 	mapTimeout = 10 * time.Minute
+	// mappings are renewed well before their lease expires, so that a lost renewal can
+	// be retried before the router drops the mapping
+	mapUpdateInterval = mapTimeout / 2
+	mapRetryInterval  = 30 * time.Second
 )
 
 // Map adds a port mapping on m and keeps it alive until c is closed.
@@ -119,16 +153,19 @@ func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int,
 	}
 
 	logger1 := logger.New("proto", protocol, "extport", extport, "intport", intport, "interface", m)
-	refresh := time.NewTimer(mapTimeout)
+	refresh := time.NewTimer(mapUpdateInterval)
 	defer func() {
 		refresh.Stop()
 		logger1.Trace("Deleting port mapping")
 		m.DeleteMapping(protocol, extport, intport)
 	}()
-	if err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
-		logger1.Debug("Couldn't add port mapping", "err", err)
-	} else {
+	err := m.AddMapping(protocol, extport, intport, name, mapTimeout)
+	mapped := err == nil
+	if mapped {
 		logger1.Info("Mapped network port")
+	} else {
+		logger1.Debug("Couldn't add port mapping", "err", err)
+		refresh.Reset(mapRetryInterval)
 	}
 	for {
 		select {
@@ -139,9 +176,20 @@ func Map(m Interface, c <-chan struct{}, protocol string, extport, intport int,
 		case <-refresh.C:
 			logger1.Trace("Refreshing port mapping")
 			if err := m.AddMapping(protocol, extport, intport, name, mapTimeout); err != nil {
-				logger1.Debug("Couldn't add port mapping", "err", err)
+				if mapped {
+					logger1.Warn("Couldn't renew port mapping, retrying", "err", err)
+				} else {
+					logger1.Debug("Couldn't add port mapping", "err", err)
+				}
+				mapped = false
+				refresh.Reset(mapRetryInterval)
+				continue
 			}
-			refresh.Reset(mapTimeout)
+			if !mapped {
+				logger1.Info("Mapped network port")
+			}
+			mapped = true
+			refresh.Reset(mapUpdateInterval)
 		}
 	}
 }
@@ -178,6 +226,59 @@ func Any() Interface {
 	})
 }
 
+// Auto returns a port mapper that maps ports with UPnP or NAT-PMP, and detects the
+// external IP with STUN when no router supports them, or when the router is itself
+// behind another NAT (carrier-grade NAT, or a second router) and only knows a private
+// address. An empty stunServer uses the default STUN server.
+func Auto(stunServer string) Interface {
+	return &auto{mapper: Any(), stun: NewSTUN(stunServer)}
+}
+
+type auto struct {
+	mapper Interface
+	stun   Interface
+}
+
+func (n *auto) AddMapping(protocol string, extport, intport int, name string, lifetime time.Duration) error {
+	return n.mapper.AddMapping(protocol, extport, intport, name, lifetime)
+}
+
+func (n *auto) DeleteMapping(protocol string, extport, intport int) error {
+	return n.mapper.DeleteMapping(protocol, extport, intport)
+}
+
+func (n *auto) SupportsMapping() bool {
+	return true
+}
+
+func (n *auto) ExternalIP() (net.IP, error) {
+	ip, err := n.mapper.ExternalIP()
+	if err == nil && isPublicIP(ip) {
+		return ip, nil
+	}
+	stunIP, stunErr := n.stun.ExternalIP()
+	if stunErr == nil {
+		return stunIP, nil
+	}
+	if err == nil {
+		// better than nothing, peers on the same network can still reach us
+		return ip, nil
+	}
+	return nil, errors.Join(err, stunErr)
+}
+
+func (n *auto) String() string {
+	return fmt.Sprintf("auto(%v, %v)", n.mapper, n.stun)
+}
+
+// cgnat is the shared address space of carrier-grade NATs, RFC 6598.
+var _, cgnat, _ = net.ParseCIDR("100.64.0.0/10")
+
+// isPublicIP reports whether peers on the Internet can reach the given IP.
+func isPublicIP(ip net.IP) bool {
+	return ip != nil && !ip.IsUnspecified() && !netutil.IsLAN(ip) && !netutil.IsSpecialNetwork(ip) && !cgnat.Contains(ip)
+}
+
 // UPnP returns a port mapper that uses UPnP. It will attempt to
 // discover the address of your router using UDP broadcasts.
 func UPnP() Interface {
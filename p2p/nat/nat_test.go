@@ -64,3 +64,72 @@ func TestAutoDiscRace(t *testing.T) {
 		}
 	}
 }
+
+func TestAutoExternalIP(t *testing.T) {
+	failing := startautodisc("nothing", func() Interface { return nil })
+
+	for _, tt := range []struct {
+		name   string
+		mapper Interface
+		stun   Interface
+		want   net.IP
+	}{
+		{"public router", ExtIP{33, 44, 55, 66}, ExtIP{1, 2, 3, 4}, net.IP{33, 44, 55, 66}},
+		{"double NAT", ExtIP{192, 168, 1, 2}, ExtIP{33, 44, 55, 66}, net.IP{33, 44, 55, 66}},
+		{"carrier-grade NAT", ExtIP{100, 64, 3, 4}, ExtIP{33, 44, 55, 66}, net.IP{33, 44, 55, 66}},
+		{"no router", failing, ExtIP{33, 44, 55, 66}, net.IP{33, 44, 55, 66}},
+		{"no STUN", ExtIP{192, 168, 1, 2}, failing, net.IP{192, 168, 1, 2}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &auto{mapper: tt.mapper, stun: tt.stun}
+			ip, err := n.ExternalIP()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !ip.Equal(tt.want) {
+				t.Errorf("got IP %v, want %v", ip, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&auto{mapper: failing, stun: failing}).ExternalIP(); err == nil {
+		t.Error("expected an error without router and STUN")
+	}
+}
+
+func TestParseAuto(t *testing.T) {
+	n, err := Parse("auto:stun.example.org:3478")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, ok := n.(*auto)
+	if !ok {
+		t.Fatalf("got %T, want *auto", n)
+	}
+	if s := a.stun.(STUN); s.serverAddr != "stun.example.org:3478" {
+		t.Errorf("got STUN server %q", s.serverAddr)
+	}
+	if !n.SupportsMapping() {
+		t.Error("auto should support mapping")
+	}
+}
+
+func TestShared(t *testing.T) {
+	a, err := Shared("pmp:192.168.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Shared("pmp:192.168.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Error("same description should give the same port mapper")
+	}
+	if c, _ := Shared("pmp:192.168.0.2"); c == a {
+		t.Error("different descriptions should give different port mappers")
+	}
+	if _, err := Shared("bogus"); err == nil {
+		t.Error("expected error for unknown mechanism")
+	}
+}
@@ -18,11 +18,14 @@ package p2p
 
 import (
 	"crypto/ecdsa"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"path"
+	"strings"
 
+	"github.com/erigontech/erigon-lib/common/secrets"
 	"github.com/erigontech/erigon-lib/crypto"
 )
 
@@ -50,7 +53,11 @@ func (config NodeKeyConfig) parseHex(hex string) (*ecdsa.PrivateKey, error) {
 }
 
 func (config NodeKeyConfig) load(keyfile string) (*ecdsa.PrivateKey, error) {
-	key, err := crypto.LoadECDSA(keyfile)
+	data, err := secrets.ReadFile(secrets.NodeKey, keyfile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node key from %s: %w", keyfile, err)
+	}
+	key, err := crypto.HexToECDSA(strings.TrimSpace(string(data)))
 	if err != nil {
 		err = fmt.Errorf("failed to load node key from %s: %w", keyfile, err)
 	}
@@ -60,7 +67,7 @@ func (config NodeKeyConfig) load(keyfile string) (*ecdsa.PrivateKey, error) {
 func (config NodeKeyConfig) save(keyfile string, key *ecdsa.PrivateKey) error {
 	err := os.MkdirAll(path.Dir(keyfile), 0755)
 	if err == nil {
-		err = crypto.SaveECDSA(keyfile, key)
+		err = secrets.WriteFile(secrets.NodeKey, keyfile, []byte(hex.EncodeToString(crypto.FromECDSA(key))))
 	}
 	if err != nil {
 		return fmt.Errorf("failed to save node key to %s: %w", keyfile, err)
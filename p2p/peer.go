@@ -539,6 +539,7 @@ type PeerInfo struct {
 		Trusted       bool   `json:"trusted"`
 		Static        bool   `json:"static"`
 	} `json:"network"`
+	Penalties uint64                 `json:"penalties"` // Penalties received by the peer, including previous connections
 	Protocols map[string]interface{} `json:"protocols"` // Sub-protocol specific metadata fields
 }
 
@@ -62,6 +62,12 @@ type Protocol struct {
 	// attempts to create connections to them.
 	DialCandidates enode.Iterator
 
+	// NodeFilter, if non-nil, selects the nodes found by the V5 discovery which should
+	// be dialed for this protocol. The V5 discovery network is shared with other
+	// protocols, e.g. the consensus layer, so nodes are usually selected based on the
+	// entries of their node record.
+	NodeFilter func(n *enode.Node) bool
+
 	// Attributes contains protocol specific information for the node record.
 	Attributes []enr.Entry
 }
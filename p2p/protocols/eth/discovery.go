@@ -24,6 +24,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/enr"
 	"github.com/erigontech/erigon/p2p/forkid"
 )
@@ -58,3 +59,20 @@ func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	}
 	return &entry.ForkID, nil
 }
+
+// NewNodeFilter returns a filter accepting the nodes which advertise an `eth` ENR entry
+// with a fork ID compatible with the filter returned by forkFilter. Nodes are rejected
+// while forkFilter returns nil.
+func NewNodeFilter(forkFilter func() forkid.Filter) func(*enode.Node) bool {
+	return func(n *enode.Node) bool {
+		filter := forkFilter()
+		if filter == nil {
+			return false
+		}
+		forkID, err := LoadENRForkID(n.Record())
+		if err != nil || forkID == nil {
+			return false
+		}
+		return filter(*forkID) == nil
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/forkid"
+)
+
+func TestNodeFilter(t *testing.T) {
+	genesis := common.HexToHash("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3")
+	heightForks := []uint64{1150000, 1920000}
+
+	newNode := func(entry enr.Entry) *enode.Node {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var r enr.Record
+		if entry != nil {
+			r.Set(entry)
+		}
+		if err := enode.SignV4(&r, key); err != nil {
+			t.Fatal(err)
+		}
+		n, err := enode.New(enode.ValidSchemes, &r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	var forkFilter forkid.Filter
+	filter := NewNodeFilter(func() forkid.Filter { return forkFilter })
+
+	compatible := newNode(CurrentENREntryFromForks(heightForks, nil, genesis, 2000000, 0))
+	if filter(compatible) {
+		t.Fatal("node accepted before the fork filter is known")
+	}
+
+	forkFilter = forkid.NewFilterFromForks(heightForks, nil, genesis, 2000000, 0)
+	if !filter(compatible) {
+		t.Error("compatible node rejected")
+	}
+	if filter(newNode(nil)) {
+		t.Error("node without eth entry accepted")
+	}
+	if filter(newNode(CurrentENREntryFromForks(heightForks, nil, common.Hash{1}, 2000000, 0))) {
+		t.Error("node of another network accepted")
+	}
+}
@@ -230,3 +230,43 @@ func TestEth66Messages(t *testing.T) {
 		}
 	}
 }
+
+func TestReceiptsPacket69RoundTrip(t *testing.T) {
+	logs := []*types.Log{{Address: common.HexToAddress("0x11"), Topics: []common.Hash{common.HexToHash("0x22")}, Data: []byte{0x33}}}
+	receipts := ReceiptsPacket{
+		{
+			{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: logs, Bloom: types.LogsBloom(logs)},
+			{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 42000, Logs: []*types.Log{}},
+		},
+		{},
+	}
+
+	data, err := rlp.EncodeToBytes(&ReceiptsPacket66{RequestId: 7, ReceiptsPacket: receipts})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data69, err := ToReceiptsPacket69(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data69) >= len(data) {
+		t.Fatalf("eth/69 receipts should be smaller without blooms: %d >= %d", len(data69), len(data))
+	}
+
+	var packet69 ReceiptsPacket69
+	if err := rlp.DecodeBytes(data69, &packet69); err != nil {
+		t.Fatal(err)
+	}
+	if packet69.RequestId != 7 || len(packet69.Receipts) != 2 || packet69.Receipts[0][1].Type != types.DynamicFeeTxType {
+		t.Fatalf("unexpected eth/69 packet: %+v", packet69)
+	}
+
+	roundTrip, err := FromReceiptsPacket69(data69)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, roundTrip) {
+		t.Fatalf("receipts round trip mismatch:\nhave %x\nwant %x", roundTrip, data)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// Receipt69 is the eth/69 network encoding of a receipt: the transaction type is part
+// of the list instead of an envelope prefix and the bloom filter is dropped, as it can
+// be recomputed from the logs.
+type Receipt69 struct {
+	Type              uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*types.Log
+}
+
+// ReceiptsPacket69 is the eth/69 network packet for block receipts distribution.
+type ReceiptsPacket69 struct {
+	RequestId uint64
+	Receipts  [][]*Receipt69
+}
+
+var receiptStatusSuccessfulRLP = []byte{0x01}
+
+func newReceipt69(receipt *types.Receipt) *Receipt69 {
+	postStateOrStatus := receipt.PostState
+	if len(postStateOrStatus) == 0 && receipt.Status == types.ReceiptStatusSuccessful {
+		postStateOrStatus = receiptStatusSuccessfulRLP
+	}
+
+	return &Receipt69{
+		Type:              receipt.Type,
+		PostStateOrStatus: postStateOrStatus,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Logs:              receipt.Logs,
+	}
+}
+
+func (r *Receipt69) receipt() (*types.Receipt, error) {
+	receipt := &types.Receipt{
+		Type:              r.Type,
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Logs:              r.Logs,
+	}
+
+	switch {
+	case bytes.Equal(r.PostStateOrStatus, receiptStatusSuccessfulRLP):
+		receipt.Status = types.ReceiptStatusSuccessful
+	case len(r.PostStateOrStatus) == 0:
+		receipt.Status = types.ReceiptStatusFailed
+	case len(r.PostStateOrStatus) == len(common.Hash{}):
+		receipt.PostState = r.PostStateOrStatus
+	default:
+		return nil, errors.New("invalid receipt status")
+	}
+
+	receipt.Bloom = types.LogsBloom(receipt.Logs)
+	return receipt, nil
+}
+
+// ToReceiptsPacket69 converts an encoded eth/66 receipts response, as produced by the
+// receipts query handlers, into its eth/69 encoding.
+func ToReceiptsPacket69(data []byte) ([]byte, error) {
+	var packet ReceiptsPacket66
+	if err := rlp.DecodeBytes(data, &packet); err != nil {
+		return nil, err
+	}
+
+	packet69 := ReceiptsPacket69{
+		RequestId: packet.RequestId,
+		Receipts:  make([][]*Receipt69, len(packet.ReceiptsPacket)),
+	}
+	for i, receipts := range packet.ReceiptsPacket {
+		packet69.Receipts[i] = make([]*Receipt69, len(receipts))
+		for j, receipt := range receipts {
+			packet69.Receipts[i][j] = newReceipt69(receipt)
+		}
+	}
+
+	return rlp.EncodeToBytes(&packet69)
+}
+
+// FromReceiptsPacket69 converts an encoded eth/69 receipts response into its eth/66
+// encoding, recomputing the bloom filters, so that consumers see a single format.
+func FromReceiptsPacket69(data []byte) ([]byte, error) {
+	var packet69 ReceiptsPacket69
+	if err := rlp.DecodeBytes(data, &packet69); err != nil {
+		return nil, err
+	}
+
+	packet := ReceiptsPacket66{
+		RequestId:      packet69.RequestId,
+		ReceiptsPacket: make(ReceiptsPacket, len(packet69.Receipts)),
+	}
+	for i, receipts := range packet69.Receipts {
+		packet.ReceiptsPacket[i] = make([]*types.Receipt, len(receipts))
+		for j, receipt69 := range receipts {
+			receipt, err := receipt69.receipt()
+			if err != nil {
+				return nil, err
+			}
+			packet.ReceiptsPacket[i][j] = receipt
+		}
+	}
+
+	return rlp.EncodeToBytes(&packet)
+}
@@ -0,0 +1,571 @@
+// Copyright 2020 The go-ethereum Authors
+// (original work)
+// Copyright 2025 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxCodeLookups is the maximum number of bytecodes to serve. This number is
+	// there to limit the number of disk lookups.
+	maxCodeLookups = 1024
+
+	// stateLookupSlack defines the ratio by how much a state response can exceed
+	// the requested limit in order to try and avoid breaking up contracts into
+	// multiple packages and proving them.
+	stateLookupSlack = 0.1
+
+	// maxTrieNodeLookups is the maximum number of state trie nodes to serve. This
+	// number is there to limit the number of disk lookups.
+	maxTrieNodeLookups = 1024
+
+	// maxTrieNodeTimeSpent is the maximum time we should spend on looking up trie
+	// nodes. If we spend too much time, then it's a fairly high chance of timing
+	// out at the remote side, which means all the work is in vain.
+	maxTrieNodeTimeSpent = 5 * time.Second
+
+	// codeHashesCacheSize is the number of code hashes served in account ranges
+	// remembered to serve the bytecodes.
+	codeHashesCacheSize = 1 << 18
+)
+
+var emptyCodeHash = crypto.Keccak256Hash(nil)
+
+// Server serves the snap protocol from the latest state of the database.
+//
+// The state is kept in flat domains keyed by plain keys, the order of the state trie
+// is recovered by walking the branches of the commitment domain and the merkle proofs
+// are built from witnesses of the touched keys. Only the latest state root is served,
+// requests for other roots get empty responses as allowed by the protocol.
+type Server struct {
+	db kv.TemporalRoDB
+
+	// Code is keyed by account, while snap requests it by hash. Remember which
+	// account holds the code of the hashes served in account ranges, which is where
+	// peers learn about them.
+	codeHashes *lru.Cache[common.Hash, common.Address]
+
+	logger log.Logger
+}
+
+func NewServer(db kv.TemporalRoDB, logger log.Logger) *Server {
+	codeHashes, err := lru.New[common.Hash, common.Address](codeHashesCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return &Server{db: db, codeHashes: codeHashes, logger: logger}
+}
+
+// Protocol returns the snap/1 protocol served by s. It is a satellite of the eth
+// protocol, peers not running eth are disconnected.
+func (s *Server) Protocol(ctx context.Context) p2p.Protocol {
+	return p2p.Protocol{
+		Name:    ProtocolName,
+		Version: SNAP1,
+		Length:  ProtocolLengths[SNAP1],
+		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
+			if !peer.RunningCap(eth.ProtocolName, []uint{direct.ETH67, direct.ETH68, direct.ETH69}) {
+				return p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscUselessPeer, nil, "snap.Server: peer does not run eth")
+			}
+			for {
+				if err := s.handleMessage(ctx, rw); err != nil {
+					return err
+				}
+			}
+		},
+	}
+}
+
+func (s *Server) handleMessage(ctx context.Context, rw p2p.MsgReadWriter) *p2p.PeerError {
+	msg, err := rw.ReadMsg()
+	if err != nil {
+		return p2p.NewPeerError(p2p.PeerErrorMessageReceive, p2p.DiscNetworkError, err, "snap.Server: ReadMsg error")
+	}
+	defer msg.Discard()
+	if msg.Size > maxMessageSize {
+		return p2p.NewPeerError(p2p.PeerErrorMessageSizeLimit, p2p.DiscSubprotocolError, nil, fmt.Sprintf("snap.Server: message is too large %d, limit %d", msg.Size, maxMessageSize))
+	}
+
+	// Internal failures are answered with empty responses, as if the state was not
+	// available, only malformed requests get the peer disconnected.
+	var res Packet
+	switch msg.Code {
+	case GetAccountRangeMsg:
+		var req GetAccountRangePacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap.Server: GetAccountRange decode error")
+		}
+		if res, err = s.AccountRange(ctx, &req); err != nil {
+			res = &AccountRangePacket{ID: req.ID}
+		}
+	case GetStorageRangesMsg:
+		var req GetStorageRangesPacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap.Server: GetStorageRanges decode error")
+		}
+		if res, err = s.StorageRanges(ctx, &req); err != nil {
+			res = &StorageRangesPacket{ID: req.ID}
+		}
+	case GetByteCodesMsg:
+		var req GetByteCodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap.Server: GetByteCodes decode error")
+		}
+		if res, err = s.ByteCodes(ctx, &req); err != nil {
+			res = &ByteCodesPacket{ID: req.ID}
+		}
+	case GetTrieNodesMsg:
+		var req GetTrieNodesPacket
+		if err := msg.Decode(&req); err != nil {
+			return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "snap.Server: GetTrieNodes decode error")
+		}
+		if res, err = s.TrieNodes(ctx, &req); err != nil {
+			res = &TrieNodesPacket{ID: req.ID}
+		}
+	case AccountRangeMsg, StorageRangesMsg, ByteCodesMsg, TrieNodesMsg:
+		return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, errUnexpectedResponse, fmt.Sprintf("snap.Server: unexpected message code %d", msg.Code))
+	default:
+		return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, fmt.Sprintf("snap.Server: unknown message code %d", msg.Code))
+	}
+	if errors.Is(err, errBadRequest) {
+		return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, fmt.Sprintf("snap.Server: %s bad request", res.Name()))
+	}
+	if err != nil {
+		s.logger.Debug("[snap] request failed", "msg", res.Name(), "err", err)
+	}
+
+	if err := p2p.Send(rw, uint64(res.Kind()), res); err != nil {
+		return p2p.NewPeerError(p2p.PeerErrorMessageSend, p2p.DiscNetworkError, err, fmt.Sprintf("snap.Server: %s send error", res.Name()))
+	}
+	return nil
+}
+
+// withLatestState runs f against the latest state if it has the given root.
+func (s *Server) withLatestState(ctx context.Context, root common.Hash, f func(sd *state.SharedDomains) error) error {
+	tx, err := s.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	sd, err := state.NewSharedDomains(tx, s.logger)
+	if err != nil {
+		return err
+	}
+	defer sd.Close()
+
+	latest, err := sd.GetCommitmentContext().Trie().RootHash()
+	if err != nil {
+		return err
+	}
+	if common.BytesToHash(latest) != root {
+		return nil
+	}
+	return f(sd)
+}
+
+// AccountRange serves the accounts of the requested range, along with the proof of
+// its bounds.
+func (s *Server) AccountRange(ctx context.Context, req *GetAccountRangePacket) (*AccountRangePacket, error) {
+	res := &AccountRangePacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+
+	err := s.withLatestState(ctx, req.Root, func(sd *state.SharedDomains) error {
+		sdc := sd.GetCommitmentContext()
+
+		var (
+			plainKeys [][]byte
+			accs      []*accounts.Account
+			size      uint64
+		)
+		err := commitment.WalkAccounts(sdc, req.Origin[:], func(hashedKey, plainKey []byte) (bool, error) {
+			enc, _, err := sd.GetLatest(kv.AccountsDomain, plainKey)
+			if err != nil {
+				return false, err
+			}
+			acc := new(accounts.Account)
+			if err := accounts.DeserialiseV3(acc, enc); err != nil {
+				return false, fmt.Errorf("account %x: %w", plainKey, err)
+			}
+
+			res.Accounts = append(res.Accounts, &AccountData{Hash: common.BytesToHash(hashedKey)})
+			plainKeys = append(plainKeys, plainKey)
+			accs = append(accs, acc)
+
+			// hash, nonce, balance, storage root and code hash
+			size += length.Hash + 8 + 32 + length.Hash + length.Hash
+			return size < req.Bytes && bytes.Compare(hashedKey, req.Limit[:]) < 0, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// The storage roots are only found in the trie, take them from the witness
+		// also proving the range. The proof of the origin is made of the paths to its
+		// neighbours, as the origin itself may not exist.
+		_, prev, err := commitment.PrevAccount(sdc, req.Origin[:])
+		if err != nil {
+			return err
+		}
+		if prev == nil && len(plainKeys) == 0 {
+			return nil
+		}
+		if prev != nil {
+			sdc.TouchKey(kv.AccountsDomain, string(prev), nil)
+		}
+		for _, plainKey := range plainKeys {
+			sdc.TouchKey(kv.AccountsDomain, string(plainKey), nil)
+		}
+		proofTrie, _, err := sdc.Witness(ctx, req.Root[:], "snap.AccountRange")
+		if err != nil {
+			return err
+		}
+
+		for i, data := range res.Accounts {
+			account := slimAccount{Nonce: accs[i].Nonce, Balance: &accs[i].Balance}
+			if acc, _ := proofTrie.GetAccount(data.Hash[:]); acc != nil && !acc.IsEmptyRoot() {
+				account.Root = acc.Root[:]
+			}
+			if !accs[i].IsEmptyCodeHash() {
+				account.CodeHash = accs[i].CodeHash[:]
+				s.codeHashes.Add(accs[i].CodeHash, common.BytesToAddress(plainKeys[i]))
+			}
+			if data.Body, err = rlp.EncodeToBytes(&account); err != nil {
+				return err
+			}
+		}
+
+		var last []byte
+		if len(res.Accounts) > 0 {
+			last = res.Accounts[len(res.Accounts)-1].Hash[:]
+		}
+		res.Proof, err = proofTrie.ProveRange(req.Origin[:], last, 0, false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// StorageRanges serves the storage slots of the requested accounts. Only the range of
+// the last account may be incomplete, in which case the proof of its bounds is added.
+func (s *Server) StorageRanges(ctx context.Context, req *GetStorageRangesPacket) (*StorageRangesPacket, error) {
+	res := &StorageRangesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	// allow the last account to exceed the limit a bit, rather than to prove its range
+	hardLimit := uint64(float64(req.Bytes) * (1 + stateLookupSlack))
+
+	err := s.withLatestState(ctx, req.Root, func(sd *state.SharedDomains) error {
+		sdc := sd.GetCommitmentContext()
+
+		var size uint64
+		for i, account := range req.Accounts {
+			// If we've exceeded the requested data limit, abort without opening a new
+			// storage range (that we'd need to prove due to exceeded size)
+			if size >= req.Bytes {
+				break
+			}
+			// The first account might start from a different origin and end sooner
+			var origin common.Hash
+			if len(req.Origin) > 0 {
+				origin, req.Origin = common.BytesToHash(req.Origin), nil
+			}
+			limit := common.BytesToHash(bytes.Repeat([]byte{0xff}, length.Hash))
+			if i == len(req.Accounts)-1 && len(req.Limit) > 0 {
+				limit, req.Limit = common.BytesToHash(req.Limit), nil
+			}
+
+			address, err := commitment.AccountPlainKey(sdc, account[:])
+			if err != nil {
+				return err
+			}
+			if address == nil {
+				return nil
+			}
+
+			var (
+				slots     []*StorageData
+				plainKeys [][]byte
+				abort     bool
+			)
+			err = commitment.WalkStorage(sdc, address, origin[:], func(hashedKey, plainKey []byte) (bool, error) {
+				if size >= hardLimit {
+					abort = true
+					return false, nil
+				}
+				v, _, err := sd.GetLatest(kv.StorageDomain, plainKey)
+				if err != nil {
+					return false, err
+				}
+				body, err := rlp.EncodeToBytes(v)
+				if err != nil {
+					return false, err
+				}
+
+				size += uint64(length.Hash + len(body))
+				slots = append(slots, &StorageData{Hash: common.BytesToHash(hashedKey), Body: body})
+				plainKeys = append(plainKeys, plainKey)
+				return bytes.Compare(hashedKey, limit[:]) < 0, nil
+			})
+			if err != nil {
+				return err
+			}
+			if len(slots) > 0 {
+				res.Slots = append(res.Slots, slots)
+			}
+
+			// Prove the range only if it is incomplete, the proof terminates the reply
+			if origin == (common.Hash{}) && (!abort || len(slots) == 0) {
+				continue
+			}
+			_, prev, err := commitment.PrevStorage(sdc, address, origin[:])
+			if err != nil {
+				return err
+			}
+			sdc.TouchKey(kv.AccountsDomain, string(address), nil)
+			if prev != nil {
+				sdc.TouchKey(kv.StorageDomain, string(prev), nil)
+			}
+			var last []byte
+			if len(slots) > 0 {
+				sdc.TouchKey(kv.StorageDomain, string(plainKeys[0]), nil)
+				sdc.TouchKey(kv.StorageDomain, string(plainKeys[len(plainKeys)-1]), nil)
+				last = append(common.Copy(account[:]), slots[len(slots)-1].Hash[:]...)
+			}
+			proofTrie, _, err := sdc.Witness(ctx, req.Root[:], "snap.StorageRanges")
+			if err != nil {
+				return err
+			}
+			accountProof, err := proofTrie.Prove(account[:], 0, false)
+			if err != nil {
+				return err
+			}
+			res.Proof, err = proofTrie.ProveRange(append(common.Copy(account[:]), origin[:]...), last, len(accountProof), true)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ByteCodes serves the requested bytecodes. Codes of unknown hashes are skipped.
+func (s *Server) ByteCodes(ctx context.Context, req *GetByteCodesPacket) (*ByteCodesPacket, error) {
+	res := &ByteCodesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+	if len(req.Hashes) > maxCodeLookups {
+		req.Hashes = req.Hashes[:maxCodeLookups]
+	}
+
+	tx, err := s.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var size uint64
+	for _, hash := range req.Hashes {
+		if hash == emptyCodeHash {
+			// Peers should not request the empty code, but if they do, at least
+			// send them back a correct response without db lookups
+			res.Codes = append(res.Codes, []byte{})
+			continue
+		}
+		address, ok := s.codeHashes.Get(hash)
+		if !ok {
+			continue
+		}
+		code, _, err := tx.GetLatest(kv.CodeDomain, address[:])
+		if err != nil {
+			return nil, err
+		}
+		// the code of the account may have changed since its hash was served
+		if crypto.Keccak256Hash(code) != hash {
+			continue
+		}
+
+		res.Codes = append(res.Codes, code)
+		if size += uint64(len(code)); size > req.Bytes {
+			break
+		}
+	}
+	return res, nil
+}
+
+// TrieNodes serves the requested trie nodes. Missing nodes are skipped.
+func (s *Server) TrieNodes(ctx context.Context, req *GetTrieNodesPacket) (*TrieNodesPacket, error) {
+	res := &TrieNodesPacket{ID: req.ID}
+	if req.Bytes > softResponseLimit {
+		req.Bytes = softResponseLimit
+	}
+
+	err := s.withLatestState(ctx, req.Root, func(sd *state.SharedDomains) error {
+		sdc := sd.GetCommitmentContext()
+		start := time.Now()
+
+		// Nodes are taken from a witness, touch a leaf below each of the requested
+		// nodes so that the witness expands all of them.
+		var paths [][]byte
+		for _, pathset := range req.Paths {
+			if len(paths) >= maxTrieNodeLookups || time.Since(start) > maxTrieNodeTimeSpent {
+				break
+			}
+			switch len(pathset) {
+			case 0:
+				return errBadRequest
+			case 1:
+				path := compactToNibbles(pathset[0])
+				plainKey, err := leafBelow(path, func(from []byte, fn commitment.WalkFunc) error {
+					return commitment.WalkAccounts(sdc, from, fn)
+				})
+				if err != nil {
+					return err
+				}
+				if plainKey != nil {
+					sdc.TouchKey(kv.AccountsDomain, string(plainKey), nil)
+				}
+				paths = append(paths, path)
+			default:
+				address, err := commitment.AccountPlainKey(sdc, pathset[0])
+				if err != nil {
+					return err
+				}
+				if address == nil {
+					continue
+				}
+				sdc.TouchKey(kv.AccountsDomain, string(address), nil)
+				accountPath := keyNibbles(pathset[0])
+				for _, storagePath := range pathset[1:] {
+					path := compactToNibbles(storagePath)
+					plainKey, err := leafBelow(path, func(from []byte, fn commitment.WalkFunc) error {
+						return commitment.WalkStorage(sdc, address, from, fn)
+					})
+					if err != nil {
+						return err
+					}
+					if plainKey != nil {
+						sdc.TouchKey(kv.StorageDomain, string(plainKey), nil)
+					}
+					paths = append(paths, append(common.Copy(accountPath), path...))
+				}
+			}
+		}
+		if len(paths) == 0 {
+			return nil
+		}
+
+		proofTrie, _, err := sdc.Witness(ctx, req.Root[:], "snap.TrieNodes")
+		if err != nil {
+			return err
+		}
+		var size uint64
+		for _, path := range paths {
+			node, err := proofTrie.EncodedNodeAt(path)
+			if err != nil {
+				return err
+			}
+			if node == nil {
+				continue
+			}
+			res.Nodes = append(res.Nodes, node)
+			if size += uint64(len(node)); size > req.Bytes {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// leafBelow returns the plain key of the first leaf walked below the given nibble
+// path, or nil if there is none.
+func leafBelow(path []byte, walk func(from []byte, fn commitment.WalkFunc) error) (plainKey []byte, err error) {
+	from := make([]byte, length.Hash)
+	for i, nibble := range path {
+		if i >= 2*length.Hash {
+			break
+		}
+		from[i/2] |= nibble << (4 * (1 - i%2))
+	}
+	err = walk(from, func(hashedKey, pk []byte) (bool, error) {
+		if bytes.HasPrefix(keyNibbles(hashedKey), path) {
+			plainKey = pk
+		}
+		return false, nil
+	})
+	return plainKey, err
+}
+
+func keyNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+// compactToNibbles decodes a compact (hex-prefix) encoded path of the snap protocol.
+func compactToNibbles(compact []byte) []byte {
+	if len(compact) == 0 {
+		return nil
+	}
+	k := trie.CompactToKeybytes(compact)
+	nibbles := keyNibbles(k.Data)
+	if k.Odd {
+		nibbles = nibbles[:len(nibbles)-1]
+	}
+	return nibbles
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snap
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+type testAccount struct {
+	hash    common.Hash
+	address common.Address
+	account accounts.Account
+	code    []byte
+	storage map[common.Hash][]byte // slot hash -> rlp encoded value
+}
+
+// newTestState writes accounts with storage and code into a new database, and returns
+// them sorted by hash, along with the state root.
+func newTestState(t *testing.T) (kv.TemporalRwDB, []*testAccount, common.Hash) {
+	ctx := context.Background()
+	db := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	tx, err := db.BeginTemporalRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	sd, err := state.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer sd.Close()
+
+	var accs []*testAccount
+	for i := 0; i < 200; i++ {
+		a := &testAccount{address: common.BytesToAddress([]byte{byte(i >> 8), byte(i), 0x42}), storage: map[common.Hash][]byte{}}
+		a.hash = crypto.Keccak256Hash(a.address[:])
+		a.account = accounts.Account{Nonce: uint64(i), Balance: *uint256.NewInt(uint64(i+1) * 1000), CodeHash: emptyCodeHash, Incarnation: 1}
+		if i%5 == 0 {
+			a.code = bytes.Repeat([]byte{byte(i)}, 100+i)
+			a.account.CodeHash = crypto.Keccak256Hash(a.code)
+		}
+		require.NoError(t, sd.DomainPut(kv.AccountsDomain, a.address[:], nil, accounts.SerialiseV3(&a.account), nil, 0))
+		if a.code != nil {
+			require.NoError(t, sd.DomainPut(kv.CodeDomain, a.address[:], nil, a.code, nil, 0))
+		}
+
+		slots := 0
+		switch i % 10 {
+		case 1:
+			slots = 2
+		case 2:
+			slots = 300
+		}
+		for j := 0; j < slots; j++ {
+			loc := common.BytesToHash([]byte{byte(j >> 8), byte(j)})
+			value := []byte{byte(j + 1), byte(i)}
+			require.NoError(t, sd.DomainPut(kv.StorageDomain, a.address[:], loc[:], value, nil, 0))
+			body, err := rlp.EncodeToBytes(value)
+			require.NoError(t, err)
+			a.storage[crypto.Keccak256Hash(loc[:])] = body
+		}
+		accs = append(accs, a)
+	}
+
+	root, err := sd.ComputeCommitment(ctx, true, 0, "")
+	require.NoError(t, err)
+	require.NoError(t, sd.Flush(ctx, tx))
+	require.NoError(t, tx.Commit())
+
+	sort.Slice(accs, func(i, j int) bool { return bytes.Compare(accs[i].hash[:], accs[j].hash[:]) < 0 })
+	return db, accs, common.BytesToHash(root)
+}
+
+func sortedSlots(storage map[common.Hash][]byte) []*StorageData {
+	slots := make([]*StorageData, 0, len(storage))
+	for hash, body := range storage {
+		slots = append(slots, &StorageData{Hash: hash, Body: body})
+	}
+	sort.Slice(slots, func(i, j int) bool { return bytes.Compare(slots[i].Hash[:], slots[j].Hash[:]) < 0 })
+	return slots
+}
+
+// storageTrie returns the reference storage trie of the account.
+func storageTrie(t *testing.T, a *testAccount) *trie.Trie {
+	st := trie.New(common.Hash{})
+	for hash, body := range a.storage {
+		var value []byte
+		require.NoError(t, rlp.DecodeBytes(body, &value))
+		st.Update(hash[:], value)
+	}
+	return st
+}
+
+// requireProven checks that the proof contains the paths to the given keys in the
+// reference trie.
+func requireProven(t *testing.T, ref *trie.Trie, proof [][]byte, keys ...[]byte) {
+	nodes := map[string]struct{}{}
+	for _, node := range proof {
+		nodes[string(node)] = struct{}{}
+	}
+	require.Len(t, nodes, len(proof), "duplicate proof nodes")
+	for _, key := range keys {
+		keyProof, err := ref.Prove(key, 0, false)
+		require.NoError(t, err)
+		for _, node := range keyProof {
+			require.Contains(t, nodes, string(node), "key %x", key)
+		}
+	}
+}
+
+func TestAccountRange(t *testing.T) {
+	db, accs, root := newTestState(t)
+	s := NewServer(db, log.New())
+	ctx := context.Background()
+
+	// rebuild the trie from the served accounts, it must match the state root
+	res, err := s.AccountRange(ctx, &GetAccountRangePacket{ID: 1, Root: root, Limit: common.BytesToHash(bytes.Repeat([]byte{0xff}, 32)), Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), res.ID)
+	require.Len(t, res.Accounts, len(accs))
+
+	ref := trie.New(common.Hash{})
+	for i, data := range res.Accounts {
+		a := accs[i]
+		require.Equal(t, a.hash, data.Hash)
+
+		var slim slimAccount
+		require.NoError(t, rlp.DecodeBytes(data.Body, &slim))
+		require.Equal(t, a.account.Nonce, slim.Nonce)
+		require.Equal(t, a.account.Balance, *slim.Balance)
+		if a.code == nil {
+			require.Empty(t, slim.CodeHash)
+		} else {
+			require.Equal(t, a.account.CodeHash[:], slim.CodeHash)
+		}
+
+		storageRoot := trie.EmptyRoot
+		if len(a.storage) > 0 {
+			storageRoot = storageTrie(t, a).Hash()
+			require.Equal(t, storageRoot[:], slim.Root)
+		} else {
+			require.Empty(t, slim.Root)
+		}
+
+		ref.UpdateAccount(data.Hash[:], &accounts.Account{Initialised: true, Nonce: slim.Nonce, Balance: *slim.Balance, Root: storageRoot, CodeHash: a.account.CodeHash})
+	}
+	require.Equal(t, root, ref.Hash())
+	requireProven(t, ref, res.Proof, make([]byte, 32), accs[len(accs)-1].hash[:])
+
+	// a range starting between two accounts, capped by its limit
+	origin := common.Copy(accs[10].hash[:])
+	origin[31]++
+	res, err = s.AccountRange(ctx, &GetAccountRangePacket{ID: 2, Root: root, Origin: common.BytesToHash(origin), Limit: accs[20].hash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, res.Accounts, 10)
+	require.Equal(t, accs[11].hash, res.Accounts[0].Hash)
+	require.Equal(t, accs[20].hash, res.Accounts[9].Hash)
+	requireProven(t, ref, res.Proof, origin, accs[20].hash[:])
+
+	// a range capped by its size
+	res, err = s.AccountRange(ctx, &GetAccountRangePacket{ID: 3, Root: root, Limit: accs[100].hash, Bytes: 1000})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Accounts)
+	require.Less(t, len(res.Accounts), 100)
+
+	// only the latest state is served
+	res, err = s.AccountRange(ctx, &GetAccountRangePacket{ID: 4, Root: common.HexToHash("0x01"), Limit: accs[100].hash, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), res.ID)
+	require.Empty(t, res.Accounts)
+	require.Empty(t, res.Proof)
+}
+
+func TestStorageRanges(t *testing.T) {
+	db, accs, root := newTestState(t)
+	s := NewServer(db, log.New())
+	ctx := context.Background()
+
+	var small, large []*testAccount
+	for _, a := range accs {
+		switch {
+		case len(a.storage) == 2:
+			small = append(small, a)
+		case len(a.storage) > 2:
+			large = append(large, a)
+		}
+	}
+
+	// complete storage of several accounts, no proof needed
+	req := &GetStorageRangesPacket{ID: 1, Root: root, Bytes: softResponseLimit}
+	for _, a := range small[:5] {
+		req.Accounts = append(req.Accounts, a.hash)
+	}
+	res, err := s.StorageRanges(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, res.Slots, 5)
+	for i, a := range small[:5] {
+		require.Equal(t, sortedSlots(a.storage), res.Slots[i])
+	}
+	require.Empty(t, res.Proof)
+
+	// storage of a large account capped by size, proven
+	res, err = s.StorageRanges(ctx, &GetStorageRangesPacket{ID: 2, Root: root, Accounts: []common.Hash{large[0].hash}, Bytes: 1000})
+	require.NoError(t, err)
+	require.Len(t, res.Slots, 1)
+	want := sortedSlots(large[0].storage)
+	require.Less(t, len(res.Slots[0]), len(want))
+	require.Equal(t, want[:len(res.Slots[0])], res.Slots[0])
+
+	st := storageTrie(t, large[0])
+	requireProven(t, st, res.Proof, make([]byte, 32), res.Slots[0][len(res.Slots[0])-1].Hash[:])
+
+	// continuation of the large account from the middle of its storage
+	origin := want[100].Hash
+	res, err = s.StorageRanges(ctx, &GetStorageRangesPacket{ID: 3, Root: root, Accounts: []common.Hash{large[0].hash}, Origin: origin[:], Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, res.Slots, 1)
+	require.Equal(t, want[100:], res.Slots[0])
+	requireProven(t, st, res.Proof, origin[:], want[len(want)-1].Hash[:])
+}
+
+func TestByteCodes(t *testing.T) {
+	db, accs, root := newTestState(t)
+	s := NewServer(db, log.New())
+	ctx := context.Background()
+
+	var hashes []common.Hash
+	var codes [][]byte
+	for _, a := range accs {
+		if a.code != nil {
+			hashes = append(hashes, a.account.CodeHash)
+			codes = append(codes, a.code)
+		}
+	}
+
+	// codes are found once their hashes were served
+	res, err := s.ByteCodes(ctx, &GetByteCodesPacket{ID: 1, Hashes: hashes, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Empty(t, res.Codes)
+
+	_, err = s.AccountRange(ctx, &GetAccountRangePacket{ID: 2, Root: root, Limit: common.BytesToHash(bytes.Repeat([]byte{0xff}, 32)), Bytes: softResponseLimit})
+	require.NoError(t, err)
+	res, err = s.ByteCodes(ctx, &GetByteCodesPacket{ID: 3, Hashes: append(hashes, emptyCodeHash), Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Equal(t, append(codes, []byte{}), res.Codes)
+}
+
+func TestTrieNodes(t *testing.T) {
+	db, accs, root := newTestState(t)
+	s := NewServer(db, log.New())
+	ctx := context.Background()
+
+	var large *testAccount
+	for _, a := range accs {
+		if len(a.storage) > 2 {
+			large = a
+			break
+		}
+	}
+
+	res, err := s.TrieNodes(ctx, &GetTrieNodesPacket{ID: 1, Root: root, Paths: []TrieNodePathSet{
+		{{0x00}},       // account trie root
+		{{0x11}},       // first nibble 1
+		{{0x00, 0x1f}}, // first two nibbles 1f
+		{large.hash[:], {}},
+	}, Bytes: softResponseLimit})
+	require.NoError(t, err)
+	require.Len(t, res.Nodes, 4)
+	require.Equal(t, root, crypto.Keccak256Hash(res.Nodes[0]))
+
+	require.Equal(t, storageTrie(t, large).Hash(), crypto.Keccak256Hash(res.Nodes[3]))
+
+	// each node hash is referenced by the root node
+	for _, node := range res.Nodes[1:3] {
+		require.True(t, bytes.Contains(res.Nodes[0], crypto.Keccak256(node)) || bytes.Contains(res.Nodes[1], crypto.Keccak256(node)))
+	}
+
+	_, err = s.TrieNodes(ctx, &GetTrieNodesPacket{ID: 2, Root: root, Paths: []TrieNodePathSet{{}}, Bytes: softResponseLimit})
+	require.ErrorIs(t, err, errBadRequest)
+}
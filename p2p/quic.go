@@ -0,0 +1,289 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+)
+
+// RLPx over QUIC is an experiment: nodes which listen for it advertise the port with the
+// "quic" ENR entry, and nodes which have it enabled too dial them over QUIC instead of
+// TCP. The TLS layer of QUIC is only used for encryption of the QUIC packets, the peers
+// are authenticated by the RLPx handshake, which runs on the first stream of the
+// connection as it would on a TCP connection.
+const (
+	quicALPN = "devp2p"
+
+	quicKeepAlivePeriod = 15 * time.Second
+)
+
+var errQUICStreamDirection = errors.New("quic stream can't be used in this direction")
+
+// newQUICTLSConfig creates a TLS config with an ephemeral self-signed certificate. The
+// certificate of the remote end isn't verified, see above.
+func newQUICTLSConfig() (*tls.Config, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(10 * 365 * 24 * time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates:       []tls.Certificate{{Certificate: [][]byte{cert}, PrivateKey: key}},
+		NextProtos:         []string{quicALPN},
+		MinVersion:         tls.VersionTLS13,
+		InsecureSkipVerify: true, //nolint:gosec // peers are authenticated by the RLPx handshake
+	}, nil
+}
+
+func newQUICConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout: handshakeTimeout,
+		MaxIdleTimeout:       frameReadTimeout,
+		KeepAlivePeriod:      quicKeepAlivePeriod,
+		// the only bidirectional stream is the one of the RLPx handshake
+		MaxIncomingStreams: 1,
+	}
+}
+
+// quicConn is the net.Conn of a QUIC connection: it reads and writes the stream of the
+// RLPx handshake and closes the whole connection.
+type quicConn struct {
+	quic.Stream
+	conn quic.Connection
+
+	eof     chan struct{} // closed once the peer closed its side of the stream
+	eofOnce sync.Once
+}
+
+func newQUICConn(stream quic.Stream, conn quic.Connection) *quicConn {
+	return &quicConn{Stream: stream, conn: conn, eof: make(chan struct{})}
+}
+
+func (c *quicConn) Read(b []byte) (int, error) {
+	n, err := c.Stream.Read(b)
+	if errors.Is(err, io.EOF) {
+		c.eofOnce.Do(func() { close(c.eof) })
+	}
+	return n, err
+}
+
+func (c *quicConn) LocalAddr() net.Addr  { return c.conn.LocalAddr() }
+func (c *quicConn) RemoteAddr() net.Addr { return c.conn.RemoteAddr() }
+
+// Close closes the connection once the peer has closed the stream too, or after
+// discWriteTimeout. Closing a QUIC connection drops the data which wasn't delivered
+// yet, like the disconnect reason written just before.
+func (c *quicConn) Close() error {
+	if err := c.Stream.Close(); err == nil {
+		select {
+		case <-c.eof:
+		case <-c.conn.Context().Done():
+		case <-time.After(discWriteTimeout):
+		}
+	}
+	return c.conn.CloseWithError(0, "")
+}
+
+// asQUICConn returns the QUIC connection of fd, if it is one.
+func asQUICConn(fd net.Conn) (*quicConn, bool) {
+	if m, ok := fd.(*meteredConn); ok {
+		fd = m.Conn
+	}
+	c, ok := fd.(*quicConn)
+	return c, ok
+}
+
+// quicUniConn is the net.Conn of a unidirectional QUIC stream, either send or recv
+// is set.
+type quicUniConn struct {
+	conn quic.Connection
+	send quic.SendStream
+	recv quic.ReceiveStream
+}
+
+func (c *quicUniConn) Read(b []byte) (int, error) {
+	if c.recv == nil {
+		return 0, errQUICStreamDirection
+	}
+	return c.recv.Read(b)
+}
+
+func (c *quicUniConn) Write(b []byte) (int, error) {
+	if c.send == nil {
+		return 0, errQUICStreamDirection
+	}
+	return c.send.Write(b)
+}
+
+func (c *quicUniConn) Close() error {
+	if c.send != nil {
+		return c.send.Close()
+	}
+	c.recv.CancelRead(0)
+	return nil
+}
+
+func (c *quicUniConn) LocalAddr() net.Addr  { return c.conn.LocalAddr() }
+func (c *quicUniConn) RemoteAddr() net.Addr { return c.conn.RemoteAddr() }
+
+func (c *quicUniConn) SetDeadline(t time.Time) error {
+	if c.send != nil {
+		return c.send.SetWriteDeadline(t)
+	}
+	return c.recv.SetReadDeadline(t)
+}
+
+func (c *quicUniConn) SetReadDeadline(t time.Time) error {
+	if c.recv == nil {
+		return errQUICStreamDirection
+	}
+	return c.recv.SetReadDeadline(t)
+}
+
+func (c *quicUniConn) SetWriteDeadline(t time.Time) error {
+	if c.send == nil {
+		return errQUICStreamDirection
+	}
+	return c.send.SetWriteDeadline(t)
+}
+
+// quicListener is a net.Listener which accepts QUIC connections once their peer has
+// opened the stream of the RLPx handshake.
+type quicListener struct {
+	ln     *quic.Listener
+	conns  chan net.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger log.Logger
+}
+
+func listenQUIC(addr string, tlsConf *tls.Config, logger log.Logger) (*quicListener, error) {
+	ln, err := quic.ListenAddr(addr, tlsConf, newQUICConfig())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &quicListener{ln: ln, conns: make(chan net.Conn), ctx: ctx, cancel: cancel, logger: logger}
+	go l.loop()
+	return l, nil
+}
+
+func (l *quicListener) loop() {
+	for {
+		conn, err := l.ln.Accept(l.ctx)
+		if err != nil {
+			return
+		}
+		go l.acceptStream(conn)
+	}
+}
+
+// acceptStream waits for the peer to open the stream of the RLPx handshake, so that a
+// connection which never starts it doesn't take the accept slot of the server.
+func (l *quicListener) acceptStream(conn quic.Connection) {
+	ctx, cancel := context.WithTimeout(l.ctx, handshakeTimeout)
+	defer cancel()
+
+	stream, err := conn.AcceptStream(ctx)
+	if err != nil {
+		l.logger.Trace("QUIC connection didn't open a stream", "addr", conn.RemoteAddr(), "err", err)
+		_ = conn.CloseWithError(0, "")
+		return
+	}
+	select {
+	case l.conns <- newQUICConn(stream, conn):
+	case <-l.ctx.Done():
+		_ = conn.CloseWithError(0, "")
+	}
+}
+
+func (l *quicListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.ctx.Done():
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *quicListener) Close() error {
+	l.cancel()
+	return l.ln.Close()
+}
+
+func (l *quicListener) Addr() net.Addr {
+	return l.ln.Addr()
+}
+
+// quicDialer dials nodes which advertise a QUIC port over QUIC, and the others, or the
+// ones which can't be reached over QUIC, with the next dialer.
+type quicDialer struct {
+	next    NodeDialer
+	tlsConf *tls.Config
+	logger  log.Logger
+}
+
+func (d quicDialer) Dial(ctx context.Context, dest *enode.Node) (net.Conn, error) {
+	var port enr.QUIC
+	if err := dest.Load(&port); err != nil || port == 0 {
+		return d.next.Dial(ctx, dest)
+	}
+	conn, err := dialQUIC(ctx, &net.UDPAddr{IP: dest.IP(), Port: int(port)}, d.tlsConf)
+	if err != nil {
+		d.logger.Trace("QUIC dial failed, falling back to TCP", "id", dest.ID(), "port", port, "err", err)
+		return d.next.Dial(ctx, dest)
+	}
+	return conn, nil
+}
+
+func dialQUIC(ctx context.Context, addr *net.UDPAddr, tlsConf *tls.Config) (*quicConn, error) {
+	conn, err := quic.DialAddr(ctx, addr.String(), tlsConf, newQUICConfig())
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return nil, err
+	}
+	return newQUICConn(stream, conn), nil
+}
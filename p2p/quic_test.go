@@ -0,0 +1,181 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+)
+
+func TestQUICTransport(t *testing.T) {
+	protocols := []Protocol{{Name: "a", Version: 1, Length: 5}, {Name: "b", Version: 1, Length: 3}}
+	caps := []Cap{{"a", 1}, {"b", 1}}
+	var (
+		prv0, _ = crypto.GenerateKey()
+		prv1, _ = crypto.GenerateKey()
+		hs0     = &protoHandshake{Version: baseProtocolVersion, Pubkey: crypto.MarshalPubkey(&prv0.PublicKey), Caps: caps}
+		hs1     = &protoHandshake{Version: baseProtocolVersion, Pubkey: crypto.MarshalPubkey(&prv1.PublicKey), Caps: caps}
+	)
+
+	tlsConf, err := newQUICTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := listenQUIC("127.0.0.1:0", tlsConf, log.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// codes 16-20 belong to "a" and 21-23 to "b"
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fd, err := listener.Accept()
+		if err != nil {
+			t.Errorf("accept failed: %v", err)
+			return
+		}
+		tr := handshakeQUIC(t, fd, nil, prv1, hs1, protocols)
+		if tr == nil {
+			return
+		}
+		defer tr.close(DiscQuitting)
+
+		// the messages of different streams can arrive in any order
+		got := make(map[uint64]bool)
+		for i := 0; i < 3; i++ {
+			msg, err := tr.ReadMsg()
+			if err != nil {
+				t.Errorf("listen side read failed: %v", err)
+				return
+			}
+			got[msg.Code] = true
+			msg.Discard()
+		}
+		if !got[pingMsg] || !got[17] || !got[22] {
+			t.Errorf("listen side got wrong messages: %v", got)
+		}
+		if err := Send(tr, 16, []uint{1}); err != nil {
+			t.Errorf("listen side write failed: %v", err)
+		}
+		if err := ExpectMsg(tr, discMsg, []DiscReason{DiscQuitting}); err != nil {
+			t.Errorf("error receiving disconnect: %v", err)
+		}
+	}()
+
+	fd, err := dialQUIC(context.Background(), listener.Addr().(*net.UDPAddr), tlsConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := handshakeQUIC(t, fd, &prv1.PublicKey, prv0, hs0, protocols)
+	if tr != nil {
+		for _, code := range []uint64{pingMsg, 17, 22} {
+			if err := Send(tr, code, []uint{1}); err != nil {
+				t.Fatalf("dial side write of %d failed: %v", code, err)
+			}
+		}
+		if len(tr.senders) != 2 {
+			t.Errorf("dial side opened %d subprotocol streams, want 2", len(tr.senders))
+		}
+		if err := ExpectMsg(tr, 16, []uint{1}); err != nil {
+			t.Errorf("dial side read failed: %v", err)
+		}
+		tr.close(DiscQuitting)
+	}
+	wg.Wait()
+}
+
+func handshakeQUIC(t *testing.T, fd net.Conn, dialDest *ecdsa.PublicKey, prv *ecdsa.PrivateKey, hs *protoHandshake, protocols []Protocol) *quicTransport {
+	qc, ok := asQUICConn(fd)
+	if !ok {
+		t.Errorf("%T is not a QUIC connection", fd)
+		return nil
+	}
+	tr := newQUICTransport(fd, qc.conn, dialDest, protocols).(*quicTransport)
+	if _, err := tr.doEncHandshake(prv); err != nil {
+		t.Errorf("enc handshake failed: %v", err)
+		return nil
+	}
+	if _, err := tr.doProtoHandshake(hs); err != nil {
+		t.Errorf("proto handshake failed: %v", err)
+		return nil
+	}
+	return tr
+}
+
+type errDialer struct{ err error }
+
+func (d errDialer) Dial(context.Context, *enode.Node) (net.Conn, error) {
+	return nil, d.err
+}
+
+func TestQUICDialerFallback(t *testing.T) {
+	tlsConf, err := newQUICTLSConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	errTCP := errors.New("tcp dial")
+	dialer := quicDialer{next: errDialer{errTCP}, tlsConf: tlsConf, logger: log.New()}
+	key, _ := crypto.GenerateKey()
+
+	// nodes without a QUIC port are dialed with the next dialer
+	var r enr.Record
+	r.Set(enr.IPv4{127, 0, 0, 1})
+	r.Set(enr.TCP(30303))
+	if err := enode.SignV4(&r, key); err != nil {
+		t.Fatal(err)
+	}
+	n, err := enode.New(enode.ValidSchemes, &r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dialer.Dial(context.Background(), n); !errors.Is(err, errTCP) {
+		t.Fatalf("got %v, want the error of the next dialer", err)
+	}
+
+	listener, err := listenQUIC("127.0.0.1:0", tlsConf, log.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	r.Set(enr.QUIC(listener.Addr().(*net.UDPAddr).Port))
+	if err := enode.SignV4(&r, key); err != nil {
+		t.Fatal(err)
+	}
+	if n, err = enode.New(enode.ValidSchemes, &r); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := dialer.Dial(context.Background(), n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+	if _, ok := asQUICConn(fd); !ok {
+		t.Fatalf("got %T, want a QUIC connection", fd)
+	}
+}
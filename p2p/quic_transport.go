@@ -0,0 +1,280 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/p2p/rlpx"
+)
+
+// quicTransport runs RLPx over a QUIC connection. The handshakes and the messages of
+// the base protocol go over the stream which the dialer opened, like they would over
+// TCP. Once the protocol handshake is done, every matched subprotocol gets a
+// unidirectional stream per direction, which is opened by the sender with the first
+// message of the subprotocol. A lost packet then only stalls the subprotocol it belongs
+// to instead of the whole connection.
+//
+// A subprotocol stream starts with the index of the subprotocol in the list of matched
+// subprotocols ordered by their message code offset, counting from 1, followed by RLPx
+// frames which are encrypted with keys derived from the session of the handshake.
+type quicTransport struct {
+	*rlpxTransport // the stream of the handshakes
+
+	conn      quic.Connection
+	protocols []Protocol
+	initiator bool
+
+	started atomic.Bool
+	ranges  []quicProtoRange
+	snappy  bool
+
+	smu     sync.Mutex
+	senders map[int]*rlpxTransport
+
+	in        chan Msg
+	errc      chan error
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// quicProtoRange is the message code range of a matched subprotocol.
+type quicProtoRange struct {
+	offset, length uint64
+}
+
+func newQUICTransport(fd net.Conn, conn quic.Connection, dialDest *ecdsa.PublicKey, protocols []Protocol) transport {
+	return &quicTransport{
+		rlpxTransport: &rlpxTransport{conn: rlpx.NewConn(fd, dialDest)},
+		conn:          conn,
+		protocols:     protocols,
+		initiator:     dialDest != nil,
+		senders:       make(map[int]*rlpxTransport),
+		in:            make(chan Msg),
+		errc:          make(chan error, 1),
+		closing:       make(chan struct{}),
+	}
+}
+
+// quicProtoRanges returns the code ranges of the subprotocols which match caps, ordered
+// by offset. Both ends of the connection compute the same ranges.
+func quicProtoRanges(protocols []Protocol, caps []Cap) []quicProtoRange {
+	caps = append([]Cap(nil), caps...)
+	matched := matchProtocols(protocols, caps, nil, nil)
+
+	ranges := make([]quicProtoRange, 0, len(matched))
+	for _, rw := range matched {
+		ranges = append(ranges, quicProtoRange{offset: rw.offset, length: rw.Length})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].offset < ranges[j].offset })
+	return ranges
+}
+
+// streamIndex returns the index of the subprotocol stream of code, or 0 if the message
+// goes over the stream of the handshakes.
+func (t *quicTransport) streamIndex(code uint64) int {
+	for i, r := range t.ranges {
+		if code >= r.offset && code < r.offset+r.length {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// streamLabel is the label of the keys of a subprotocol stream, which differs by the
+// sender as both ends open a stream for every subprotocol.
+func streamLabel(index int, initiator bool) []byte {
+	role := "recipient"
+	if initiator {
+		role = "initiator"
+	}
+	return []byte(fmt.Sprintf("quic-stream/%d/%s", index, role))
+}
+
+func (t *quicTransport) doProtoHandshake(our *protoHandshake) (*protoHandshake, error) {
+	their, err := t.rlpxTransport.doProtoHandshake(our)
+	if err != nil {
+		return nil, err
+	}
+	t.ranges = quicProtoRanges(t.protocols, their.Caps)
+	t.snappy = their.Version >= snappyProtocolVersion
+	t.started.Store(true)
+
+	go t.readControl()
+	go t.acceptStreams()
+	return their, nil
+}
+
+func (t *quicTransport) ReadMsg() (Msg, error) {
+	if !t.started.Load() {
+		return t.rlpxTransport.ReadMsg()
+	}
+	select {
+	case msg := <-t.in:
+		return msg, nil
+	case err := <-t.errc:
+		return Msg{}, err
+	case <-t.closing:
+		return Msg{}, net.ErrClosed
+	}
+}
+
+func (t *quicTransport) WriteMsg(msg Msg) error {
+	index := t.streamIndex(msg.Code)
+	if index == 0 {
+		return t.rlpxTransport.WriteMsg(msg)
+	}
+	sender, err := t.sender(index)
+	if err != nil {
+		return err
+	}
+	return sender.WriteMsg(msg)
+}
+
+// sender returns the transport of the outbound stream of the subprotocol, opening the
+// stream if this is the first message of the subprotocol.
+func (t *quicTransport) sender(index int) (*rlpxTransport, error) {
+	t.smu.Lock()
+	defer t.smu.Unlock()
+
+	if sender, ok := t.senders[index]; ok {
+		return sender, nil
+	}
+
+	ctx, cancel := context.WithTimeout(t.conn.Context(), frameWriteTimeout)
+	defer cancel()
+	stream, err := t.conn.OpenUniStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SetWriteDeadline(time.Now().Add(frameWriteTimeout)); err != nil {
+		return nil, err
+	}
+	if _, err := stream.Write([]byte{byte(index)}); err != nil {
+		return nil, err
+	}
+
+	conn := t.rlpxTransport.conn.DeriveConn(&quicUniConn{conn: t.conn, send: stream}, streamLabel(index, t.initiator))
+	conn.SetSnappy(t.snappy)
+	sender := &rlpxTransport{conn: conn}
+	t.senders[index] = sender
+	return sender, nil
+}
+
+// readControl reads the stream of the handshakes until it fails. It keeps reading after
+// the transport was closed, so that the close of the stream by the peer is noticed,
+// see quicConn.Close.
+func (t *quicTransport) readControl() {
+	for {
+		msg, err := t.rlpxTransport.ReadMsg()
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		t.deliver(msg)
+	}
+}
+
+func (t *quicTransport) acceptStreams() {
+	for {
+		stream, err := t.conn.AcceptUniStream(t.conn.Context())
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		go t.readStream(stream)
+	}
+}
+
+// readStream reads the messages of an inbound subprotocol stream. Unlike the stream of
+// the handshakes, it is read without a deadline, as a subprotocol may be idle for long,
+// and the liveness of the connection is covered by the pings of the base protocol.
+func (t *quicTransport) readStream(stream quic.ReceiveStream) {
+	var header [1]byte
+	if _, err := io.ReadFull(stream, header[:]); err != nil {
+		t.fail(err)
+		return
+	}
+	index := int(header[0])
+	if index < 1 || index > len(t.ranges) {
+		t.fail(fmt.Errorf("quic stream of unknown subprotocol %d", index))
+		return
+	}
+	// the streams opened by the other end are keyed with its role
+	conn := t.rlpxTransport.conn.DeriveConn(&quicUniConn{conn: t.conn, recv: stream}, streamLabel(index, !t.initiator))
+	conn.SetSnappy(t.snappy)
+
+	r := t.ranges[index-1]
+	for {
+		code, data, wireSize, err := conn.Read()
+		if err != nil {
+			t.fail(err)
+			return
+		}
+		if code < r.offset || code >= r.offset+r.length {
+			t.fail(fmt.Errorf("message code %d on the quic stream of subprotocol %d", code, index))
+			return
+		}
+		// rlpx may reuse data on the next read, see rlpxTransport.ReadMsg
+		data = common.CopyBytes(data)
+		msg := Msg{
+			ReceivedAt: time.Now(),
+			Code:       code,
+			Size:       uint32(len(data)),
+			meterSize:  uint32(wireSize),
+			Payload:    bytes.NewReader(data),
+		}
+		if !t.deliver(msg) {
+			return
+		}
+	}
+}
+
+func (t *quicTransport) deliver(msg Msg) bool {
+	select {
+	case t.in <- msg:
+		return true
+	case <-t.closing:
+		return false
+	}
+}
+
+// fail reports the first read error to ReadMsg.
+func (t *quicTransport) fail(err error) {
+	select {
+	case t.errc <- err:
+	default:
+	}
+}
+
+func (t *quicTransport) close(err error) {
+	t.closeOnce.Do(func() { close(t.closing) })
+	// this sends the reason over the stream of the handshakes and closes the connection
+	t.rlpxTransport.close(err)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"sort"
+	"time"
+
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+// BannedPeerInfo represents a short summary of a banned node.
+type BannedPeerInfo struct {
+	ID          string `json:"id"`          // Unique node identifier
+	Penalties   uint64 `json:"penalties"`   // Penalties received by the node
+	BannedUntil uint64 `json:"bannedUntil"` // Unix time at which the ban expires, 0 if banned indefinitely
+}
+
+func (srv *Server) loadReputations() error {
+	reputations, err := srv.nodedb.Reputations()
+	if err != nil {
+		return err
+	}
+
+	srv.reputationsMu.Lock()
+	defer srv.reputationsMu.Unlock()
+	srv.reputations = reputations
+	return nil
+}
+
+func (srv *Server) reputation(id enode.ID) enode.NodeReputation {
+	srv.reputationsMu.RLock()
+	defer srv.reputationsMu.RUnlock()
+	return srv.reputations[id]
+}
+
+func (srv *Server) isBanned(id enode.ID) bool {
+	return srv.reputation(id).IsBanned(time.Now())
+}
+
+// updateReputation applies update to the reputation of a node in the node database,
+// and keeps the cache used by the connection checks in sync.
+func (srv *Server) updateReputation(id enode.ID, update func(r *enode.NodeReputation)) (enode.NodeReputation, error) {
+	if !srv.running.Load() {
+		return enode.NodeReputation{}, errServerStopped
+	}
+
+	srv.reputationsMu.Lock()
+	defer srv.reputationsMu.Unlock()
+
+	r, err := srv.nodedb.UpdateReputation(id, update)
+	if err != nil {
+		return r, err
+	}
+
+	if r == (enode.NodeReputation{}) {
+		delete(srv.reputations, id)
+	} else {
+		srv.reputations[id] = r
+	}
+	return r, nil
+}
+
+// PenalizePeer records a penalty against the given node and returns the number of
+// penalties it has, one penalty decays every enode.PenaltyDecayPeriod. Penalties are
+// persisted across restarts.
+func (srv *Server) PenalizePeer(id enode.ID) (uint64, error) {
+	now := time.Now()
+	r, err := srv.updateReputation(id, func(r *enode.NodeReputation) {
+		r.Penalize(now)
+	})
+	return r.Penalties, err
+}
+
+// BanPeer disconnects the given node and refuses connections to and from it for the
+// given duration, or indefinitely if the duration is zero. Bans are persisted across
+// restarts.
+func (srv *Server) BanPeer(id enode.ID, duration time.Duration) error {
+	_, err := srv.updateReputation(id, func(r *enode.NodeReputation) {
+		r.Banned = true
+		r.BannedUntil = time.Time{}
+		if duration > 0 {
+			// stored with a second precision, truncate so the cache matches the database
+			r.BannedUntil = time.Unix(time.Now().Add(duration).Unix(), 0)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	srv.doPeerOp(func(peers map[enode.ID]*Peer) {
+		if peer := peers[id]; peer != nil {
+			peer.Disconnect(NewPeerError(PeerErrorDiscReason, DiscRequested, nil, "Server.BanPeer Disconnect"))
+		}
+	})
+	return nil
+}
+
+// UnbanPeer lifts the ban of the given node, keeping its penalties.
+func (srv *Server) UnbanPeer(id enode.ID) error {
+	_, err := srv.updateReputation(id, func(r *enode.NodeReputation) {
+		r.Banned = false
+		r.BannedUntil = time.Time{}
+	})
+	return err
+}
+
+// BannedPeers returns the currently banned nodes, sorted by node identifier.
+func (srv *Server) BannedPeers() []*BannedPeerInfo {
+	srv.reputationsMu.RLock()
+	defer srv.reputationsMu.RUnlock()
+
+	now := time.Now()
+	var infos []*BannedPeerInfo
+	for id, r := range srv.reputations {
+		if !r.IsBanned(now) {
+			continue
+		}
+
+		info := &BannedPeerInfo{ID: id.String(), Penalties: r.PenaltiesAt(now)}
+		if !r.BannedUntil.IsZero() {
+			info.BannedUntil = uint64(r.BannedUntil.Unix())
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
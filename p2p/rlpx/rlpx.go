@@ -40,6 +40,7 @@ import (
 	"github.com/golang/snappy"
 	"golang.org/x/crypto/sha3"
 
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/crypto/ecies"
 	"github.com/erigontech/erigon-lib/rlp"
@@ -67,6 +68,9 @@ type sessionState struct {
 	enc cipher.Stream
 	dec cipher.Stream
 
+	// the secrets are kept to derive the keys of further streams of the session
+	aesSecret, macSecret []byte
+
 	egressMAC  hashMAC
 	ingressMAC hashMAC
 	rbuf       readBuffer
@@ -341,11 +345,41 @@ func (c *Conn) InitWithSecrets(sec Secrets) {
 	c.session = &sessionState{
 		enc:        cipher.NewCTR(encc, iv),
 		dec:        cipher.NewCTR(encc, iv),
+		aesSecret:  common.CopyBytes(sec.AES),
+		macSecret:  common.CopyBytes(sec.MAC),
 		egressMAC:  newHashMAC(macc, sec.EgressMAC),
 		ingressMAC: newHashMAC(macc, sec.IngressMAC),
 	}
 }
 
+// DeriveConn returns a connection over conn which is keyed with secrets derived from
+// the session of c and label. Both ends of a session derive the same keys for the same
+// label, so it can be used to run further RLPx streams alongside c without another
+// handshake. The label must be unique within the session and the derived connection
+// must only be used in one direction, as it uses the same MAC state for both. Snappy
+// compression is not inherited from c and has to be enabled with SetSnappy.
+func (c *Conn) DeriveConn(conn net.Conn, label []byte) *Conn {
+	if c.session == nil {
+		panic("can't derive a connection before the handshake")
+	}
+	macSecret := crypto.Keccak256(c.session.macSecret, label)
+	newMAC := func() hash.Hash {
+		mac := sha3.NewLegacyKeccak256()
+		mac.Write(macSecret)
+		mac.Write(label)
+		return mac
+	}
+
+	derived := &Conn{conn: conn}
+	derived.InitWithSecrets(Secrets{
+		AES:        crypto.Keccak256(c.session.aesSecret, label),
+		MAC:        macSecret,
+		EgressMAC:  newMAC(),
+		IngressMAC: newMAC(),
+	})
+	return derived
+}
+
 // Close closes the underlying network connection.
 func (c *Conn) Close() error {
 	return c.conn.Close()
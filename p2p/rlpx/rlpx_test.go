@@ -68,6 +68,30 @@ func TestReadWriteMsg(t *testing.T) {
 	checkMsgReadWrite(t, peer1, peer2, testCode, testData)
 }
 
+func TestDeriveConn(t *testing.T) {
+	peer1, peer2 := createPeers(t)
+	defer peer1.Close()
+	defer peer2.Close()
+
+	conn1, conn2 := net.Pipe()
+	defer conn1.Close()
+	defer conn2.Close()
+
+	// the same label gives matching keys on both ends
+	reader := peer1.DeriveConn(conn1, []byte("stream-1"))
+	writer := peer2.DeriveConn(conn2, []byte("stream-1"))
+	checkMsgReadWrite(t, reader, writer, 23, []byte("test"))
+	checkMsgReadWrite(t, reader, writer, 24, []byte("more"))
+
+	// a different label doesn't
+	reader = peer1.DeriveConn(conn1, []byte("stream-1"))
+	writer = peer2.DeriveConn(conn2, []byte("stream-2"))
+	go writer.Write(23, []byte("test")) //nolint:errcheck
+	if _, _, _, err := reader.Read(); err == nil {
+		t.Fatal("expected error reading a message with keys derived from another label")
+	}
+}
+
 func checkMsgReadWrite(t *testing.T, p1, p2 *Conn, msgCode uint64, msgData []byte) {
 	// Set up the reader.
 	ch := make(chan message, 1)
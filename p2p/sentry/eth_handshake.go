@@ -19,6 +19,8 @@ package sentry
 import (
 	"fmt"
 
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon/p2p"
@@ -26,24 +28,76 @@ import (
 	"github.com/erigontech/erigon/p2p/protocols/eth"
 )
 
+// peerStatus is the part of a peer's handshake status used once the handshake is done.
+type peerStatus struct {
+	bestHash common.Hash
+	// latestBlock is only advertised from eth/69 onwards, zero otherwise
+	latestBlock uint64
+}
+
+// makeStatusPacket builds our status message for the given protocol version. From
+// eth/69 onwards the total difficulty is replaced by the range of blocks we serve.
+func makeStatusPacket(status *proto_sentry.StatusData, version uint) eth.Packet {
+	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	forkId := forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
+	bestHash := gointerfaces.ConvertH256ToHash(status.BestHash)
+
+	if version >= direct.ETH69 {
+		return &eth.StatusPacket69{
+			ProtocolVersion: uint32(version),
+			NetworkID:       status.NetworkId,
+			Genesis:         genesisHash,
+			ForkID:          forkId,
+			EarliestBlock:   status.MinimumBlockHeight,
+			LatestBlock:     status.MaxBlockHeight,
+			LatestBlockHash: bestHash,
+		}
+	}
+
+	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
+	return &eth.StatusPacket{
+		ProtocolVersion: uint32(version),
+		NetworkID:       status.NetworkId,
+		TD:              ourTD.ToBig(),
+		Head:            bestHash,
+		Genesis:         genesisHash,
+		ForkID:          forkId,
+	}
+}
+
 func readAndValidatePeerStatusMessage(
 	rw p2p.MsgReadWriter,
 	status *proto_sentry.StatusData,
 	version uint,
 	minVersion uint,
-) (*eth.StatusPacket, *p2p.PeerError) {
+) (*peerStatus, *p2p.PeerError) {
 	msg, err := rw.ReadMsg()
 	if err != nil {
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusReceive, p2p.DiscNetworkError, err, "readAndValidatePeerStatusMessage rw.ReadMsg error")
 	}
 
-	reply, err := tryDecodeStatusMessage(&msg)
-	msg.Discard()
-	if err != nil {
-		return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatusMessage tryDecodeStatusMessage error")
-	}
+	var reply *peerStatus
+	if version >= direct.ETH69 {
+		var reply69 *eth.StatusPacket69
+		reply69, err = tryDecodeStatusMessage69(&msg)
+		msg.Discard()
+		if err != nil {
+			return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatusMessage tryDecodeStatusMessage69 error")
+		}
 
-	err = checkPeerStatusCompatibility(reply, status, version, minVersion)
+		err = checkPeerStatusCompatibility69(reply69, status, version, minVersion)
+		reply = &peerStatus{bestHash: reply69.LatestBlockHash, latestBlock: reply69.LatestBlock}
+	} else {
+		var reply66 *eth.StatusPacket
+		reply66, err = tryDecodeStatusMessage(&msg)
+		msg.Discard()
+		if err != nil {
+			return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatusMessage tryDecodeStatusMessage error")
+		}
+
+		err = checkPeerStatusCompatibility(reply66, status, version, minVersion)
+		reply = &peerStatus{bestHash: reply66.Head}
+	}
 	if err != nil {
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusIncompatible, p2p.DiscUselessPeer, err, "readAndValidatePeerStatusMessage checkPeerStatusCompatibility error")
 	}
@@ -51,13 +105,21 @@ func readAndValidatePeerStatusMessage(
 	return reply, nil
 }
 
-func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
+func checkStatusMessage(msg *p2p.Msg) error {
 	if msg.Code != eth.StatusMsg {
-		return nil, fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
+		return fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
 	}
 
 	if msg.Size > eth.ProtocolMaxMsgSize {
-		return nil, fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
+		return fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
+	}
+
+	return nil
+}
+
+func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
+	if err := checkStatusMessage(msg); err != nil {
+		return nil, err
 	}
 
 	var reply eth.StatusPacket
@@ -68,29 +130,71 @@ func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
 	return &reply, nil
 }
 
+func tryDecodeStatusMessage69(msg *p2p.Msg) (*eth.StatusPacket69, error) {
+	if err := checkStatusMessage(msg); err != nil {
+		return nil, err
+	}
+
+	var reply eth.StatusPacket69
+	if err := msg.Decode(&reply); err != nil {
+		return nil, fmt.Errorf("decode message %v: %w", msg, err)
+	}
+
+	return &reply, nil
+}
+
 func checkPeerStatusCompatibility(
 	reply *eth.StatusPacket,
 	status *proto_sentry.StatusData,
 	version uint,
 	minVersion uint,
 ) error {
-	networkID := status.NetworkId
-	if reply.NetworkID != networkID {
-		return fmt.Errorf("network id does not match: theirs %d, ours %d", reply.NetworkID, networkID)
+	return checkPeerChainCompatibility(reply.ProtocolVersion, reply.NetworkID, reply.Genesis, reply.ForkID, status, version, minVersion)
+}
+
+func checkPeerStatusCompatibility69(
+	reply *eth.StatusPacket69,
+	status *proto_sentry.StatusData,
+	version uint,
+	minVersion uint,
+) error {
+	blockRange := eth.BlockRangeUpdatePacket{
+		EarliestBlock:   reply.EarliestBlock,
+		LatestBlock:     reply.LatestBlock,
+		LatestBlockHash: reply.LatestBlockHash,
+	}
+	if err := blockRange.Validate(); err != nil {
+		return err
+	}
+
+	return checkPeerChainCompatibility(reply.ProtocolVersion, reply.NetworkID, reply.Genesis, reply.ForkID, status, version, minVersion)
+}
+
+func checkPeerChainCompatibility(
+	protocolVersion uint32,
+	networkID uint64,
+	genesis common.Hash,
+	forkID forkid.ID,
+	status *proto_sentry.StatusData,
+	version uint,
+	minVersion uint,
+) error {
+	if networkID != status.NetworkId {
+		return fmt.Errorf("network id does not match: theirs %d, ours %d", networkID, status.NetworkId)
 	}
 
-	if uint(reply.ProtocolVersion) > version {
-		return fmt.Errorf("version is more than what this senty supports: theirs %d, max %d", reply.ProtocolVersion, version)
+	if uint(protocolVersion) > version {
+		return fmt.Errorf("version is more than what this senty supports: theirs %d, max %d", protocolVersion, version)
 	}
-	if uint(reply.ProtocolVersion) < minVersion {
-		return fmt.Errorf("version is less than allowed minimum: theirs %d, min %d", reply.ProtocolVersion, minVersion)
+	if uint(protocolVersion) < minVersion {
+		return fmt.Errorf("version is less than allowed minimum: theirs %d, min %d", protocolVersion, minVersion)
 	}
 
 	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
-	if reply.Genesis != genesisHash {
-		return fmt.Errorf("genesis hash does not match: theirs %x, ours %x", reply.Genesis, genesisHash)
+	if genesis != genesisHash {
+		return fmt.Errorf("genesis hash does not match: theirs %x, ours %x", genesis, genesisHash)
 	}
 
 	forkFilter := forkid.NewFilterFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
-	return forkFilter(reply.ForkID)
+	return forkFilter(forkID)
 }
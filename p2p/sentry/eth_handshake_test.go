@@ -27,6 +27,7 @@ import (
 	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon/p2p/forkid"
 	"github.com/erigontech/erigon/p2p/protocols/eth"
 	"github.com/erigontech/erigon/params"
@@ -96,3 +97,79 @@ func TestCheckPeerStatusCompatibility(t *testing.T) {
 		assert.ErrorIs(t, err, forkid.ErrLocalIncompatibleOrStale)
 	})
 }
+
+func TestCheckPeerStatusCompatibility69(t *testing.T) {
+	var version uint = direct.ETH69
+	networkID := params.MainnetChainConfig.ChainID.Uint64()
+	heightForks, timeForks := forkid.GatherForks(params.MainnetChainConfig, 0 /* genesisTime */)
+	goodReply := eth.StatusPacket69{
+		ProtocolVersion: uint32(version),
+		NetworkID:       networkID,
+		Genesis:         params.MainnetGenesisHash,
+		ForkID:          forkid.NewIDFromForks(heightForks, timeForks, params.MainnetGenesisHash, 0, 0),
+		EarliestBlock:   0,
+		LatestBlock:     100,
+		LatestBlockHash: common.HexToHash("0x01"),
+	}
+	status := proto_sentry.StatusData{
+		NetworkId: networkID,
+		ForkData: &proto_sentry.Forks{
+			Genesis:     gointerfaces.ConvertHashToH256(params.MainnetGenesisHash),
+			HeightForks: heightForks,
+			TimeForks:   timeForks,
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		err := checkPeerStatusCompatibility69(&goodReply, &status, version, version)
+		assert.NoError(t, err)
+	})
+	t.Run("network mismatch", func(t *testing.T) {
+		reply := goodReply
+		reply.NetworkID = 0
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "network")
+	})
+	t.Run("version mismatch min", func(t *testing.T) {
+		reply := goodReply
+		reply.ProtocolVersion = direct.ETH68
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "version is less")
+	})
+	t.Run("invalid block range", func(t *testing.T) {
+		reply := goodReply
+		reply.EarliestBlock = reply.LatestBlock + 1
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid block range")
+	})
+	t.Run("missing latest hash", func(t *testing.T) {
+		reply := goodReply
+		reply.LatestBlockHash = common.Hash{}
+		err := checkPeerStatusCompatibility69(&reply, &status, version, version)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid block range")
+	})
+}
+
+func TestMakeStatusPacket69(t *testing.T) {
+	heightForks, timeForks := forkid.GatherForks(params.MainnetChainConfig, 0 /* genesisTime */)
+	pruneMode := prune.MinimalMode
+	s := &StatusDataProvider{networkId: 1, genesisHash: params.MainnetGenesisHash, heightForks: heightForks, timeForks: timeForks, pruneMode: pruneMode}
+
+	status := s.makeStatusData(ChainHead{HeadHeight: 1_000_000, HeadHash: common.HexToHash("0x01"), HeadTd: new(uint256.Int)})
+	packet := makeStatusPacket(status, direct.ETH69).(*eth.StatusPacket69)
+	assert.Equal(t, pruneMode.Blocks.PruneTo(1_000_000), packet.EarliestBlock)
+	assert.Equal(t, uint64(1_000_000), packet.LatestBlock)
+
+	// blocks before distance are not pruned yet
+	status = s.makeStatusData(ChainHead{HeadHeight: 10, HeadTd: new(uint256.Int)})
+	assert.Zero(t, status.MinimumBlockHeight)
+
+	// full node serves all blocks
+	s.pruneMode = prune.FullMode
+	status = s.makeStatusData(ChainHead{HeadHeight: 1_000_000, HeadTd: new(uint256.Int)})
+	assert.Zero(t, makeStatusPacket(status, direct.ETH69).(*eth.StatusPacket69).EarliestBlock)
+}
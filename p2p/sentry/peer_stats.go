@@ -0,0 +1,107 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentry
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+type messageCounters struct {
+	messages metrics.Counter
+	bytes    metrics.Counter
+}
+
+// per message id counters aggregated over all peers, the per peer breakdown is
+// served by the PeerStats rpc to keep the metrics cardinality bounded
+var inboundMessageCounters, outboundMessageCounters = newMessageCounters("inbound"), newMessageCounters("outbound")
+
+func newMessageCounters(direction string) map[sentryproto.MessageId]messageCounters {
+	counters := make(map[sentryproto.MessageId]messageCounters, len(sentryproto.MessageId_name))
+	for id, name := range sentryproto.MessageId_name {
+		counters[sentryproto.MessageId(id)] = messageCounters{
+			messages: metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_messages{direction="%s",id="%s"}`, direction, name)),
+			bytes:    metrics.GetOrCreateCounter(fmt.Sprintf(`sentry_message_bytes{direction="%s",id="%s"}`, direction, name)),
+		}
+	}
+	return counters
+}
+
+type messageStats struct {
+	inboundCount  uint64
+	inboundBytes  uint64
+	outboundCount uint64
+	outboundBytes uint64
+}
+
+// peerStats accumulates the number of messages and bytes exchanged with a peer per
+// message id, so that peers which spam requests or leech data can be identified.
+type peerStats struct {
+	lock     sync.Mutex
+	messages map[sentryproto.MessageId]*messageStats
+}
+
+func (s *peerStats) track(id sentryproto.MessageId, inbound bool, bytes uint64) {
+	s.lock.Lock()
+	if s.messages == nil {
+		s.messages = map[sentryproto.MessageId]*messageStats{}
+	}
+	stats, ok := s.messages[id]
+	if !ok {
+		stats = &messageStats{}
+		s.messages[id] = stats
+	}
+	if inbound {
+		stats.inboundCount++
+		stats.inboundBytes += bytes
+	} else {
+		stats.outboundCount++
+		stats.outboundBytes += bytes
+	}
+	s.lock.Unlock()
+
+	counters := outboundMessageCounters
+	if inbound {
+		counters = inboundMessageCounters
+	}
+	if c, ok := counters[id]; ok {
+		c.messages.Inc()
+		c.bytes.AddUint64(bytes)
+	}
+}
+
+func (s *peerStats) messageStats() []*sentryproto.MessageStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	res := make([]*sentryproto.MessageStats, 0, len(s.messages))
+	for id, stats := range s.messages {
+		res = append(res, &sentryproto.MessageStats{
+			Id:            id,
+			InboundCount:  stats.inboundCount,
+			InboundBytes:  stats.inboundBytes,
+			OutboundCount: stats.outboundCount,
+			OutboundBytes: stats.outboundBytes,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Id < res[j].Id })
+	return res
+}
@@ -36,6 +36,7 @@ import (
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -66,6 +67,13 @@ const (
 	// complete before dropping the connection.= as malicious.
 	handshakeTimeout  = 5 * time.Second
 	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+	// blockRangeUpdateInterval is how many blocks the head needs to advance before
+	// announcing the new block range to eth/69 peers
+	blockRangeUpdateInterval = 32
+	// peer with penaltiesPerBan penalties is banned for penaltyBanDuration, penalties decay
+	// with time (enode.PenaltyDecayPeriod): only peers penalized often are banned
+	penaltiesPerBan    = 3
+	penaltyBanDuration = time.Hour
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -78,6 +86,7 @@ type PeerInfo struct {
 	height        uint64
 	rw            p2p.MsgReadWriter
 	protocol      uint
+	stats         peerStats
 
 	ctx       context.Context
 	ctxCancel context.CancelFunc
@@ -301,25 +310,14 @@ func handShake(
 	rw p2p.MsgReadWriter,
 	version uint,
 	minVersion uint,
-) (*common.Hash, *p2p.PeerError) {
+) (*peerStatus, *p2p.PeerError) {
 	// Send out own handshake in a new thread
 	errChan := make(chan *p2p.PeerError, 2)
-	resultChan := make(chan *eth.StatusPacket, 1)
-
-	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
-	// Convert proto status data into the one required by devp2p
-	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	resultChan := make(chan *peerStatus, 1)
 
 	go func() {
 		defer debug.LogPanic()
-		status := &eth.StatusPacket{
-			ProtocolVersion: uint32(version),
-			NetworkID:       status.NetworkId,
-			TD:              ourTD.ToBig(),
-			Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
-			Genesis:         genesisHash,
-			ForkID:          forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime),
-		}
+		status := makeStatusPacket(status, version)
 		err := p2p.Send(rw, eth.StatusMsg, status)
 
 		if err == nil {
@@ -356,8 +354,7 @@ func handShake(
 		}
 	}
 
-	peerStatus := <-resultChan
-	return &peerStatus.Head, nil
+	return <-resultChan, nil
 }
 
 func runPeer(
@@ -471,6 +468,13 @@ func runPeer(
 			if _, err := io.ReadFull(msg.Payload, b); err != nil {
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
+			if protocol >= direct.ETH69 {
+				// subscribers always get receipts in the eth/66 encoding
+				if b, err = eth.FromReceiptsPacket69(b); err != nil {
+					msg.Discard()
+					return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: invalid eth/69 receipts")
+				}
+			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
 			//log.Info(fmt.Sprintf("[%s] ReceiptsMsg", peerID))
 		case eth.NewBlockHashesMsg:
@@ -533,6 +537,28 @@ func runPeer(
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
+		case eth.BlockRangeUpdateMsg:
+			if protocol < direct.ETH69 {
+				logger.Error(fmt.Sprintf("[p2p] Unknown message code: %d, peerID=%x", msg.Code, peerID))
+				break
+			}
+			b := make([]byte, msg.Size)
+			if _, err := io.ReadFull(msg.Payload, b); err != nil {
+				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
+			}
+			var blockRange eth.BlockRangeUpdatePacket
+			if err := rlp.DecodeBytes(b, &blockRange); err != nil {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: invalid block range update")
+			}
+			if err := blockRange.Validate(); err != nil {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscProtocolError, err, "sentry.runPeer: invalid block range update")
+			}
+			peerInfo.SetIncreasedHeight(blockRange.LatestBlock)
+			if hasSubscribers(eth.ToProto[protocol][msg.Code]) {
+				send(eth.ToProto[protocol][msg.Code], peerID, b)
+			}
 		case 11:
 			// Ignore
 			// TODO: Investigate why BSC peers for eth/67 send these messages
@@ -540,10 +566,13 @@ func runPeer(
 			logger.Error(fmt.Sprintf("[p2p] Unknown message code: %d, peerID=%x", msg.Code, peerID))
 		}
 
-		msgType := eth.ToProto[protocol][msg.Code]
+		msgType, known := eth.ToProto[protocol][msg.Code]
 		msgCap := cap.String()
 
 		trackPeerStatistics(peerInfo.peer.Fullname(), peerInfo.peer.ID().String(), true, msgType.String(), msgCap, int(msg.Size))
+		if known {
+			peerInfo.stats.track(msgType, true, uint64(msg.Size))
+		}
 
 		msg.Discard()
 		peerInfo.ClearDeadlines(time.Now(), givePermit)
@@ -567,7 +596,7 @@ func trackPeerStatistics(peerName string, peerID string, inbound bool, msgType s
 	}
 }
 
-func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, healthCheck bool) (*grpc.Server, error) {
+func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, creds credentials.TransportCredentials, acl grpcutil.ACL, healthCheck bool) (*grpc.Server, error) {
 	// STARTING GRPC SERVER
 	ss.logger.Info("Starting Sentry gRPC server", "on", sentryAddr)
 	listenConfig := net.ListenConfig{
@@ -580,7 +609,7 @@ func grpcSentryServer(ctx context.Context, sentryAddr string, ss *GrpcServer, he
 	if err != nil {
 		return nil, fmt.Errorf("could not create Sentry P2P listener: %w, addr=%s", err, sentryAddr)
 	}
-	grpcServer := grpcutil.NewServer(100, nil)
+	grpcServer := grpcutil.NewServer(100, creds, acl)
 	proto_sentry.RegisterSentryServer(grpcServer, ss)
 	var healthServer *health.Server
 	if healthCheck {
@@ -617,8 +646,9 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 	ss.Protocols = append(ss.Protocols, p2p.Protocol{
 		Name:           eth.ProtocolName,
 		Version:        protocol,
-		Length:         17,
+		Length:         eth.ProtocolLengths[protocol],
 		DialCandidates: disc,
+		NodeFilter:     eth.NewNodeFilter(ss.currentForkFilter),
 		Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
 			peerID := peer.Pubkey()
 			printablePeerID := hex.EncodeToString(peerID[:])[:20]
@@ -639,10 +669,11 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 				return p2p.NewPeerError(p2p.PeerErrorLocalStatusNeeded, p2p.DiscProtocolError, nil, "could not get status message from core")
 			}
 
-			peerBestHash, err := handShake(ctx, status, rw, protocol, protocol)
+			handshakeStatus, err := handShake(ctx, status, rw, protocol, protocol)
 			if err != nil {
 				return err
 			}
+			peerInfo.SetIncreasedHeight(handshakeStatus.latestBlock)
 
 			// handshake is successful
 			logger.Trace("[p2p] Received status message OK", "peerId", printablePeerID, "name", peer.Name())
@@ -650,7 +681,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 			ss.GoodPeers.Store(peerID, peerInfo)
 			ss.sendNewPeerToClients(gointerfaces.ConvertHashToH512(peerID))
 			defer ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID))
-			getBlockHeadersErr := ss.getBlockHeaders(ctx, *peerBestHash, peerID)
+			getBlockHeadersErr := ss.getBlockHeaders(ctx, handshakeStatus.bestHash, peerID)
 			if getBlockHeadersErr != nil {
 				return p2p.NewPeerError(p2p.PeerErrorFirstMessageSend, p2p.DiscNetworkError, getBlockHeadersErr, "p2p.Protocol.Run getBlockHeaders failure")
 			}
@@ -682,7 +713,7 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 }
 
 // Sentry creates and runs standalone sentry
-func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discoveryDNS []string, cfg *p2p.Config, protocolVersion uint, healthCheck bool, logger log.Logger) error {
+func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discoveryDNS []string, cfg *p2p.Config, protocolVersion uint, creds credentials.TransportCredentials, acl grpcutil.ACL, healthCheck bool, logger log.Logger) error {
 	dir.MustExist(dirs.DataDir)
 
 	discovery := func() enode.Iterator {
@@ -695,7 +726,7 @@ func Sentry(ctx context.Context, dirs datadir.Dirs, sentryAddr string, discovery
 	cfg.DiscoveryDNS = discoveryDNS
 	sentryServer := NewGrpcServer(ctx, discovery, func() *eth.NodeInfo { return nil }, cfg, protocolVersion, logger)
 
-	grpcServer, err := grpcSentryServer(ctx, sentryAddr, sentryServer, healthCheck)
+	grpcServer, err := grpcSentryServer(ctx, sentryAddr, sentryServer, creds, acl, healthCheck)
 	if err != nil {
 		return err
 	}
@@ -716,6 +747,8 @@ type GrpcServer struct {
 	p2pServerLock        sync.RWMutex
 	statusData           *proto_sentry.StatusData
 	statusDataLock       sync.RWMutex
+	blockRangeHeight     uint64                        // Latest block announced to eth/69 peers, guarded by statusDataLock
+	forkFilter           atomic.Pointer[forkid.Filter] // Fork ID filter of the latest status, selects discv5 dial candidates
 	messageStreams       map[proto_sentry.MessageId]map[uint64]chan *proto_sentry.InboundMessage
 	messagesSubscriberID uint64
 	messageStreamsLock   sync.RWMutex
@@ -756,8 +789,11 @@ func (ss *GrpcServer) removePeer(peerID [64]byte, reason *p2p.PeerError) {
 
 func (ss *GrpcServer) writePeer(logPrefix string, peerInfo *PeerInfo, msgcode uint64, data []byte, ttl time.Duration) {
 	peerInfo.Async(func() {
-		msgType := eth.ToProto[peerInfo.protocol][msgcode]
+		msgType, known := eth.ToProto[peerInfo.protocol][msgcode]
 		trackPeerStatistics(peerInfo.peer.Fullname(), peerInfo.peer.ID().String(), false, msgType.String(), fmt.Sprintf("%s/%d", eth.ProtocolName, peerInfo.protocol), len(data))
+		if known {
+			peerInfo.stats.track(msgType, false, uint64(len(data)))
+		}
 
 		err := peerInfo.rw.WriteMsg(p2p.Msg{Code: msgcode, Size: uint32(len(data)), Payload: bytes.NewReader(data)})
 		if err != nil {
@@ -802,10 +838,32 @@ func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.Penalize
 	peerInfo := ss.getPeer(peerID)
 	if ss.statusData != nil && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
 		ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscRequested, nil, "penalized peer"))
+		ss.recordPenalty(peerInfo.peer.ID())
 	}
 	return &emptypb.Empty{}, nil
 }
 
+// recordPenalty persists a penalty against the node, and bans repeat offenders so that
+// they are not reconnected to, even after a restart.
+func (ss *GrpcServer) recordPenalty(id enode.ID) {
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return
+	}
+
+	penalties, err := p2pServer.PenalizePeer(id)
+	if err != nil {
+		ss.logger.Debug("[sentry] failed to record penalty", "peer", id, "err", err)
+		return
+	}
+
+	if penalties >= penaltiesPerBan {
+		if err := p2pServer.BanPeer(id, penaltyBanDuration); err != nil {
+			ss.logger.Debug("[sentry] failed to ban peer", "peer", id, "err", err)
+		}
+	}
+}
+
 func (ss *GrpcServer) PeerMinBlock(_ context.Context, req *proto_sentry.PeerMinBlockRequest) (*emptypb.Empty, error) {
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	if peerInfo := ss.getPeer(peerID); peerInfo != nil {
@@ -914,7 +972,15 @@ func (ss *GrpcServer) SendMessageById(_ context.Context, inreq *proto_sentry.Sen
 		return reply, fmt.Errorf("msgcode not found for message Id: %s (peer protocol %d)", inreq.Data.Id, peerInfo.protocol)
 	}
 
-	ss.writePeer("[sentry] sendMessageById", peerInfo, msgcode, inreq.Data.Data, 0)
+	data := inreq.Data.Data
+	if msgcode == eth.ReceiptsMsg && peerInfo.protocol >= direct.ETH69 {
+		var err error
+		if data, err = eth.ToReceiptsPacket69(data); err != nil {
+			return reply, fmt.Errorf("converting receipts to eth/69: %w", err)
+		}
+	}
+
+	ss.writePeer("[sentry] sendMessageById", peerInfo, msgcode, data, 0)
 	reply.Peers = []*proto_types.H512{inreq.PeerId}
 	return reply, nil
 }
@@ -999,6 +1065,8 @@ func (ss *GrpcServer) HandShake(context.Context, *emptypb.Empty) (*proto_sentry.
 		reply.Protocol = proto_sentry.Protocol_ETH67
 	case direct.ETH68:
 		reply.Protocol = proto_sentry.Protocol_ETH68
+	case direct.ETH69:
+		reply.Protocol = proto_sentry.Protocol_ETH69
 	}
 	return reply, nil
 }
@@ -1033,6 +1101,13 @@ func (ss *GrpcServer) startP2PServer(genesisHash common.Hash) (*p2p.Server, erro
 	return srv, nil
 }
 
+func (ss *GrpcServer) currentForkFilter() forkid.Filter {
+	if filter := ss.forkFilter.Load(); filter != nil {
+		return *filter
+	}
+	return nil
+}
+
 func (ss *GrpcServer) getP2PServer() *p2p.Server {
 	ss.p2pServerLock.RLock()
 	defer ss.p2pServerLock.RUnlock()
@@ -1058,13 +1133,39 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 	defer ss.statusDataLock.Unlock()
 
 	ss.p2pServer.LocalNode().Set(eth.CurrentENREntryFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime))
+	forkFilter := forkid.NewFilterFromForks(statusData.ForkData.HeightForks, statusData.ForkData.TimeForks, genesisHash, statusData.MaxBlockHeight, statusData.MaxBlockTime)
+	ss.forkFilter.Store(&forkFilter)
 	if ss.statusData == nil || statusData.MaxBlockHeight != 0 {
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
 	}
+	if statusData.MaxBlockHeight >= ss.blockRangeHeight+blockRangeUpdateInterval {
+		ss.blockRangeHeight = statusData.MaxBlockHeight
+		ss.sendBlockRangeUpdate(statusData)
+	}
 	return reply, nil
 }
 
+// sendBlockRangeUpdate announces the block range we serve to the eth/69 peers.
+func (ss *GrpcServer) sendBlockRangeUpdate(statusData *proto_sentry.StatusData) {
+	b, err := rlp.EncodeToBytes(&eth.BlockRangeUpdatePacket{
+		EarliestBlock:   statusData.MinimumBlockHeight,
+		LatestBlock:     statusData.MaxBlockHeight,
+		LatestBlockHash: gointerfaces.ConvertH256ToHash(statusData.BestHash),
+	})
+	if err != nil {
+		ss.logger.Error("[sentry] failed to encode block range update", "err", err)
+		return
+	}
+
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if peerInfo.protocol >= direct.ETH69 {
+			ss.writePeer("[sentry] sendBlockRangeUpdate", peerInfo, eth.BlockRangeUpdateMsg, b, 0)
+		}
+		return true
+	})
+}
+
 func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeersReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -1088,6 +1189,7 @@ func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.
 			ConnIsInbound:  peer.Network.Inbound,
 			ConnIsTrusted:  peer.Network.Trusted,
 			ConnIsStatic:   peer.Network.Static,
+			Penalties:      peer.Penalties,
 		}
 		reply.Peers = append(reply.Peers, &rpcPeer)
 	}
@@ -1139,6 +1241,29 @@ func (ss *GrpcServer) PeerById(_ context.Context, req *proto_sentry.PeerByIdRequ
 	return &proto_sentry.PeerByIdReply{Peer: rpcPeer}, nil
 }
 
+// PeerStats returns the message and byte counters of the given peer, or of all
+// connected peers if no peer id is set.
+func (ss *GrpcServer) PeerStats(_ context.Context, req *proto_sentry.PeerStatsRequest) (*proto_sentry.PeerStatsReply, error) {
+	var reply proto_sentry.PeerStatsReply
+	appendStats := func(peerInfo *PeerInfo) bool {
+		reply.Peers = append(reply.Peers, &proto_sentry.PeerStats{
+			PeerId:   gointerfaces.ConvertHashToH512(peerInfo.ID()),
+			Messages: peerInfo.stats.messageStats(),
+		})
+		return true
+	}
+
+	if req.PeerId != nil {
+		if peerInfo := ss.getPeer(ConvertH512ToPeerID(req.PeerId)); peerInfo != nil {
+			appendStats(peerInfo)
+		}
+		return &reply, nil
+	}
+
+	ss.rangePeers(appendStats)
+	return &reply, nil
+}
+
 // setupDiscovery creates the node discovery source for the `eth` and `snap`
 // protocols.
 func setupDiscovery(urls []string) (enode.Iterator, error) {
@@ -1282,6 +1407,67 @@ func (ss *GrpcServer) AddPeer(_ context.Context, req *proto_sentry.AddPeerReques
 	return &proto_sentry.AddPeerReply{Success: true}, nil
 }
 
+// parseNodeID accepts an enode URL, an ENR or a hex encoded node id.
+func parseNodeID(url string) (enode.ID, error) {
+	if id, err := enode.ParseID(url); err == nil {
+		return id, nil
+	}
+
+	node, err := enode.Parse(enode.ValidSchemes, url)
+	if err != nil {
+		return enode.ID{}, err
+	}
+	return node.ID(), nil
+}
+
+func (ss *GrpcServer) BanPeer(_ context.Context, req *proto_sentry.BanPeerRequest) (*emptypb.Empty, error) {
+	id, err := parseNodeID(req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+
+	return &emptypb.Empty{}, p2pServer.BanPeer(id, time.Duration(req.Duration)*time.Second)
+}
+
+func (ss *GrpcServer) UnbanPeer(_ context.Context, req *proto_sentry.UnbanPeerRequest) (*emptypb.Empty, error) {
+	id, err := parseNodeID(req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+
+	return &emptypb.Empty{}, p2pServer.UnbanPeer(id)
+}
+
+func (ss *GrpcServer) BannedPeers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.BannedPeersReply, error) {
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+
+	banned := p2pServer.BannedPeers()
+
+	reply := proto_sentry.BannedPeersReply{Peers: make([]*proto_types.BannedPeerInfo, 0, len(banned))}
+	for _, peer := range banned {
+		reply.Peers = append(reply.Peers, &proto_types.BannedPeerInfo{
+			Id:          peer.ID,
+			Penalties:   peer.Penalties,
+			BannedUntil: peer.BannedUntil,
+		})
+	}
+
+	return &reply, nil
+}
+
 func (ss *GrpcServer) NodeInfo(_ context.Context, _ *emptypb.Empty) (*proto_types.NodeInfoReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
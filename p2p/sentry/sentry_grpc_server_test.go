@@ -38,6 +38,7 @@ import (
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/p2p/forkid"
 )
 
@@ -87,6 +88,7 @@ func startHandshake(
 // Tests that peers are correctly accepted (or rejected) based on the advertised
 // fork IDs in the protocol handshake.
 func TestForkIDSplit67(t *testing.T) { testForkIDSplit(t, direct.ETH67) }
+func TestForkIDSplit69(t *testing.T) { testForkIDSplit(t, direct.ETH69) }
 
 func testForkIDSplit(t *testing.T, protocol uint) {
 	var (
@@ -184,6 +186,73 @@ func testForkIDSplit(t *testing.T, protocol uint) {
 	}
 }
 
+// Tests that eth/69 peers exchange their block ranges in the handshake and that a
+// peer speaking an older protocol version is rejected.
+func TestHandshake69(t *testing.T) {
+	ctx := context.Background()
+	genesisHash := common.HexToHash("0x01")
+	newStatus := func(height uint64, hash common.Hash) *proto_sentry.StatusData {
+		return &proto_sentry.StatusData{
+			NetworkId:       1,
+			TotalDifficulty: gointerfaces.ConvertUint256IntToH256(uint256.NewInt(1)),
+			BestHash:        gointerfaces.ConvertHashToH256(hash),
+			MaxBlockHeight:  height,
+			ForkData:        &proto_sentry.Forks{Genesis: gointerfaces.ConvertHashToH256(genesisHash)},
+		}
+	}
+
+	t.Run("block range", func(t *testing.T) {
+		pipe1, pipe2 := p2p.MsgPipe()
+		defer pipe1.Close()
+		defer pipe2.Close()
+
+		type result struct {
+			status *peerStatus
+			err    *p2p.PeerError
+		}
+		results := make(chan result, 1)
+		go func() {
+			status, err := handShake(ctx, newStatus(10, common.HexToHash("0x0a")), pipe1, direct.ETH69, direct.ETH69)
+			results <- result{status, err}
+		}()
+
+		status, err := handShake(ctx, newStatus(20, common.HexToHash("0x14")), pipe2, direct.ETH69, direct.ETH69)
+		require.Nil(t, err)
+		require.Equal(t, uint64(10), status.latestBlock)
+		require.Equal(t, common.HexToHash("0x0a"), status.bestHash)
+
+		res := <-results
+		require.Nil(t, res.err)
+		require.Equal(t, uint64(20), res.status.latestBlock)
+		require.Equal(t, common.HexToHash("0x14"), res.status.bestHash)
+	})
+
+	t.Run("older peer", func(t *testing.T) {
+		pipe1, pipe2 := p2p.MsgPipe()
+		defer pipe1.Close()
+		defer pipe2.Close()
+
+		errc := make(chan *p2p.PeerError, 2)
+		go func() {
+			_, err := handShake(ctx, newStatus(10, common.HexToHash("0x0a")), pipe1, direct.ETH68, direct.ETH68)
+			errc <- err
+		}()
+		go func() {
+			_, err := handShake(ctx, newStatus(20, common.HexToHash("0x14")), pipe2, direct.ETH69, direct.ETH69)
+			errc <- err
+		}()
+
+		for i := 0; i < 2; i++ {
+			select {
+			case err := <-errc:
+				require.NotNil(t, err)
+			case <-time.After(time.Second):
+				t.Fatalf("mismatched protocol versions not rejected")
+			}
+		}
+	})
+}
+
 func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -212,3 +281,44 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestPeerStats(t *testing.T) {
+	ss := &GrpcServer{}
+	newPeerInfo := func(id byte) *PeerInfo {
+		pubkey := [64]byte{id}
+		peerInfo := NewPeerInfo(p2p.NewPeer(enode.ID{id}, pubkey, "peer", nil, false), nil)
+		t.Cleanup(peerInfo.Close)
+		ss.GoodPeers.Store(pubkey, peerInfo)
+		return peerInfo
+	}
+
+	spammer := newPeerInfo(1)
+	for i := 0; i < 3; i++ {
+		spammer.stats.track(proto_sentry.MessageId_GET_BLOCK_HEADERS_66, true, 10)
+	}
+	spammer.stats.track(proto_sentry.MessageId_BLOCK_HEADERS_66, false, 100)
+	leecher := newPeerInfo(2)
+	leecher.stats.track(proto_sentry.MessageId_BLOCK_BODIES_66, false, 1000)
+
+	reply, err := ss.PeerStats(context.Background(), &proto_sentry.PeerStatsRequest{PeerId: gointerfaces.ConvertHashToH512(spammer.ID())})
+	require.NoError(t, err)
+	require.Len(t, reply.Peers, 1)
+	require.Equal(t, spammer.ID(), ConvertH512ToPeerID(reply.Peers[0].PeerId))
+	messages := reply.Peers[0].Messages
+	require.Len(t, messages, 2)
+	require.Equal(t, proto_sentry.MessageId_GET_BLOCK_HEADERS_66, messages[0].Id)
+	require.Equal(t, uint64(3), messages[0].InboundCount)
+	require.Equal(t, uint64(30), messages[0].InboundBytes)
+	require.Zero(t, messages[0].OutboundCount)
+	require.Equal(t, proto_sentry.MessageId_BLOCK_HEADERS_66, messages[1].Id)
+	require.Equal(t, uint64(1), messages[1].OutboundCount)
+	require.Equal(t, uint64(100), messages[1].OutboundBytes)
+
+	reply, err = ss.PeerStats(context.Background(), &proto_sentry.PeerStatsRequest{})
+	require.NoError(t, err)
+	require.Len(t, reply.Peers, 2)
+
+	reply, err = ss.PeerStats(context.Background(), &proto_sentry.PeerStatsRequest{PeerId: gointerfaces.ConvertHashToH512([64]byte{3})})
+	require.NoError(t, err)
+	require.Empty(t, reply.Peers)
+}
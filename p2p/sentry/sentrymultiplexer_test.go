@@ -9,6 +9,7 @@ import (
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/erigontech/erigon-lib/direct"
@@ -21,6 +22,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -379,3 +382,186 @@ func TestPeers(t *testing.T) {
 	require.NotNil(t, peersReply)
 	require.Len(t, peersReply.GetPeers(), 10)
 }
+
+func TestFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	sharedPeer := [64]byte{0xff}
+
+	var down atomic.Int32
+	var sentTo []int
+	var mu sync.Mutex
+
+	var clients []sentryproto.SentryClient
+
+	for i := 0; i < 3; i++ {
+		client := direct.NewMockSentryClient(ctrl)
+
+		isDown := func() bool { return down.Load() == int32(i) }
+
+		client.EXPECT().HandShake(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.HandShakeReply, error) {
+				if isDown() {
+					return nil, unavailable
+				}
+				return &sentryproto.HandShakeReply{Protocol: sentryproto.Protocol_ETH67}, nil
+			}).AnyTimes()
+		client.EXPECT().SetStatus(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *sentryproto.StatusData, opts ...grpc.CallOption) (*sentryproto.SetStatusReply, error) {
+				if isDown() {
+					return nil, unavailable
+				}
+				return &sentryproto.SetStatusReply{}, nil
+			}).AnyTimes()
+		client.EXPECT().Peers(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.PeersReply, error) {
+				if isDown() {
+					return nil, unavailable
+				}
+				// the first two sentries are connected to the same peer
+				id := [64]byte{byte(i)}
+				if i < 2 {
+					id = sharedPeer
+				}
+				return &sentryproto.PeersReply{
+					Peers: []*typesproto.PeerInfo{{Id: hex.EncodeToString(id[:])}},
+				}, nil
+			}).AnyTimes()
+		client.EXPECT().SendMessageById(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *sentryproto.SendMessageByIdRequest, opts ...grpc.CallOption) (*sentryproto.SentPeers, error) {
+				if isDown() {
+					return nil, unavailable
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				sentTo = append(sentTo, i)
+				return &sentryproto.SentPeers{Peers: []*typesproto.H512{in.PeerId}}, nil
+			}).AnyTimes()
+		client.EXPECT().PeerCount(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *sentryproto.PeerCountRequest, opts ...grpc.CallOption) (*sentryproto.PeerCountReply, error) {
+				if isDown() {
+					return nil, unavailable
+				}
+				return &sentryproto.PeerCountReply{Count: 1}, nil
+			}).AnyTimes()
+
+		clients = append(clients, client)
+	}
+
+	down.Store(-1)
+
+	mux := sentry.NewSentryMultiplexer(clients)
+	ctx := context.Background()
+
+	_, err := mux.HandShake(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	_, err = mux.SetStatus(ctx, &sentryproto.StatusData{})
+	require.NoError(t, err)
+
+	send := func() error {
+		_, err := mux.SendMessageById(ctx, &sentryproto.SendMessageByIdRequest{
+			Data:   &sentryproto.OutboundMessageData{Id: sentryproto.MessageId_BLOCK_BODIES_66},
+			PeerId: gointerfaces.ConvertHashToH512(sharedPeer),
+		})
+		return err
+	}
+
+	require.NoError(t, send())
+	require.Len(t, sentTo, 1)
+	owner := sentTo[0]
+
+	// the sentry owning the peer dies, the message goes through the other one
+	down.Store(int32(owner))
+	sentTo = nil
+
+	require.NoError(t, send())
+	require.Equal(t, []int{1 - owner}, sentTo)
+
+	count, err := mux.PeerCount(ctx, &sentryproto.PeerCountRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, count.Count)
+
+	// the dead sentry is brought back by the next handshake
+	down.Store(-1)
+
+	_, err = mux.HandShake(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+
+	count, err = mux.PeerCount(ctx, &sentryproto.PeerCountRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, count.Count)
+}
+
+func TestNoHealthySentry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var clients []sentryproto.SentryClient
+
+	for i := 0; i < 2; i++ {
+		client := direct.NewMockSentryClient(ctrl)
+		client.EXPECT().HandShake(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, status.Error(codes.Unavailable, "connection refused")).AnyTimes()
+		clients = append(clients, client)
+	}
+
+	mux := sentry.NewSentryMultiplexer(clients)
+
+	_, err := mux.HandShake(context.Background(), &emptypb.Empty{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+
+	// down sentries are not called until they recover
+	_, err = mux.PeerCount(context.Background(), &sentryproto.PeerCountRequest{})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestMessagesFailover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var clients []sentryproto.SentryClient
+	dead := make(chan struct{})
+
+	for i := 0; i < 2; i++ {
+		client := newClient(ctrl, i, nil)
+		client.EXPECT().Messages(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, in *sentryproto.MessagesRequest, opts ...grpc.CallOption) (sentryproto.Sentry_MessagesClient, error) {
+				ch := make(chan sentry.StreamReply[*sentryproto.InboundMessage], 16384)
+				streamServer := &sentry.SentryStreamS[*sentryproto.InboundMessage]{Ch: ch, Ctx: ctx}
+
+				go func() {
+					if i == 0 {
+						streamServer.Err(status.Error(codes.Unavailable, "connection reset"))
+						close(dead)
+						return
+					}
+					// the live sentry sends after the dead one has failed
+					<-dead
+					for i := 0; i < 5; i++ {
+						streamServer.Send(&sentryproto.InboundMessage{})
+					}
+				}()
+
+				return &sentry.SentryStreamC[*sentryproto.InboundMessage]{Ch: ch, Ctx: ctx}, nil
+			})
+
+		clients = append(clients, client)
+	}
+
+	mux := sentry.NewSentryMultiplexer(clients)
+
+	client, err := mux.Messages(ctx, &sentryproto.MessagesRequest{})
+	require.NoError(t, err)
+
+	// the stream of the dead sentry doesn't end the stream of the live one
+	for i := 0; i < 5; i++ {
+		message, err := client.Recv()
+		require.NoError(t, err)
+		require.NotNil(t, message)
+	}
+}
@@ -0,0 +1,314 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/mclock"
+	isentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	coretypes "github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/p2p"
+)
+
+// staleForkExtra is appended to the extra data of headers served from a stale fork,
+// so that they hash differently from the canonical ones
+var staleForkExtra = []byte("stale-fork")
+
+// LatencyDistribution samples the delay a simulated peer waits before responding.
+type LatencyDistribution interface {
+	Sample(rng *rand.Rand) time.Duration
+}
+
+// FixedLatency delays every response by the same duration.
+type FixedLatency time.Duration
+
+func (l FixedLatency) Sample(*rand.Rand) time.Duration {
+	return time.Duration(l)
+}
+
+// UniformLatency delays responses by a duration drawn uniformly from [Min, Max].
+type UniformLatency struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+func (l UniformLatency) Sample(rng *rand.Rand) time.Duration {
+	if l.Max <= l.Min {
+		return l.Min
+	}
+
+	return l.Min + time.Duration(rng.Int63n(int64(l.Max-l.Min)+1))
+}
+
+// PeerProfile describes how a simulated peer behaves when serving requests. The zero
+// value is a well behaved peer which responds immediately with canonical data.
+type PeerProfile struct {
+	// Latency is the response delay distribution, no delay if nil
+	Latency LatencyDistribution
+	// DropRate is the probability in [0, 1] that a request is silently ignored
+	DropRate float64
+	// StaleForkBlock, when set, makes the peer serve a fork which diverges from the
+	// canonical chain at this block number
+	StaleForkBlock *uint64
+	// ProtocolViolationRate is the probability in [0, 1] that a response is replaced by
+	// a protocol violation: an undecodable payload or a mismatched request id
+	ProtocolViolationRate float64
+	// DisconnectRate is the probability in [0, 1] that the peer disconnects instead of
+	// responding to a request
+	DisconnectRate float64
+	// ReconnectDelay is the time after which a disconnected peer connects again, it
+	// stays disconnected if zero
+	ReconnectDelay time.Duration
+	// Available, when set, limits the blocks the peer serves to these ranges - as a peer
+	// with partially downloaded snapshots does. Responses stop at the first block which
+	// is not available
+	Available []BlockRange
+}
+
+// BlockRange is the range of block numbers [From, To).
+type BlockRange struct {
+	From uint64
+	To   uint64
+}
+
+func (r BlockRange) Contains(blockNum uint64) bool {
+	return blockNum >= r.From && blockNum < r.To
+}
+
+type protocolViolation int
+
+const (
+	noViolation protocolViolation = iota
+	malformedPayloadViolation
+	mismatchedRequestIdViolation
+)
+
+// Option configures the simulated sentry.
+type Option func(*options)
+
+type options struct {
+	profiles     []PeerProfile
+	seed         int64
+	headerReader HeaderReader
+	bodyReader   BodyReader
+	clock        mclock.Clock
+	inbound      func(*isentry.InboundMessage)
+}
+
+// WithPeerProfiles assigns behavior profiles to the simulated peers in order. Peers
+// beyond the given profiles use the zero (well behaved) profile.
+func WithPeerProfiles(profiles ...PeerProfile) Option {
+	return func(opts *options) {
+		opts.profiles = profiles
+	}
+}
+
+// WithSeed seeds the per peer random sources, so that fault injection is reproducible.
+func WithSeed(seed int64) Option {
+	return func(opts *options) {
+		opts.seed = seed
+	}
+}
+
+// WithHeaderReader serves headers from the given reader instead of downloading the
+// chain snapshots, e.g. to run the simulator against an in memory chain.
+func WithHeaderReader(headerReader HeaderReader) Option {
+	return func(opts *options) {
+		opts.headerReader = headerReader
+	}
+}
+
+// WithBodyReader makes the peers serve block bodies from the given reader, without it
+// body requests are rejected.
+func WithBodyReader(bodyReader BodyReader) Option {
+	return func(opts *options) {
+		opts.bodyReader = bodyReader
+	}
+}
+
+// WithClock schedules the responses of the peers, their delays and reconnects on the
+// given clock instead of the system one. With a mclock.Simulated the peers respond in
+// virtual time, only when the clock is run.
+func WithClock(clock mclock.Clock) Option {
+	return func(opts *options) {
+		opts.clock = clock
+	}
+}
+
+// WithInboundHandler passes every message sent by the peers also to handler. It is
+// called on the goroutine which fires the clock timers, so with a simulated clock the
+// messages are handled in order by the caller of its Run.
+func WithInboundHandler(handler func(*isentry.InboundMessage)) Option {
+	return func(opts *options) {
+		opts.inbound = handler
+	}
+}
+
+type simulatedPeer struct {
+	*p2p.Peer
+	profile PeerProfile
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
+
+	connected atomic.Bool
+
+	forkMu     sync.Mutex
+	forkHashes map[uint64]common.Hash
+}
+
+func newSimulatedPeer(index int, profile PeerProfile, seed int64) (*simulatedPeer, error) {
+	peer, err := newPeer(fmt.Sprint("peer-", index), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	simulated := &simulatedPeer{
+		Peer:       peer,
+		profile:    profile,
+		rng:        rand.New(rand.NewSource(seed + int64(index))),
+		forkHashes: map[uint64]common.Hash{},
+	}
+	simulated.connected.Store(true)
+
+	return simulated, nil
+}
+
+// nextFaults draws the faults to inject for a single request. All draws happen at
+// once, so the random sequence only depends on the order of requests to this peer.
+func (p *simulatedPeer) nextFaults() (delay time.Duration, drop bool, violation protocolViolation) {
+	p.rngMu.Lock()
+	defer p.rngMu.Unlock()
+
+	if p.profile.Latency != nil {
+		delay = p.profile.Latency.Sample(p.rng)
+	}
+
+	drop = p.rng.Float64() < p.profile.DropRate
+
+	if p.rng.Float64() < p.profile.ProtocolViolationRate {
+		violation = malformedPayloadViolation + protocolViolation(p.rng.Intn(2))
+	}
+
+	return delay, drop, violation
+}
+
+// nextDisconnect draws whether the peer disconnects instead of responding to a request.
+func (p *simulatedPeer) nextDisconnect() bool {
+	if p.profile.DisconnectRate <= 0 {
+		return false
+	}
+
+	p.rngMu.Lock()
+	defer p.rngMu.Unlock()
+
+	return p.rng.Float64() < p.profile.DisconnectRate
+}
+
+func (p *simulatedPeer) isConnected() bool {
+	return p.connected.Load()
+}
+
+// serves reports whether the block is available from the peer.
+func (p *simulatedPeer) serves(blockNum uint64) bool {
+	if len(p.profile.Available) == 0 {
+		return true
+	}
+
+	for _, r := range p.profile.Available {
+		if r.Contains(blockNum) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *simulatedPeer) onStaleFork(blockNum uint64) bool {
+	return p.profile.StaleForkBlock != nil && blockNum >= *p.profile.StaleForkBlock
+}
+
+// forkHeader returns the stale fork version of a canonical header: it carries a marker
+// in its extra data and links to the fork version of its parent.
+func (p *simulatedPeer) forkHeader(ctx context.Context, headerReader HeaderReader, header *coretypes.Header) (*coretypes.Header, error) {
+	blockNum := header.Number.Uint64()
+	if !p.onStaleFork(blockNum) {
+		return header, nil
+	}
+
+	forked := coretypes.CopyHeader(header)
+	forked.Extra = append(forked.Extra, staleForkExtra...)
+
+	if blockNum > *p.profile.StaleForkBlock {
+		parentHash, err := p.forkHash(ctx, headerReader, blockNum-1)
+		if err != nil {
+			return nil, err
+		}
+
+		forked.ParentHash = parentHash
+	}
+
+	return forked, nil
+}
+
+// forkHash returns the hash of the stale fork header at blockNum, building the fork
+// upwards from the highest known fork hash below it.
+func (p *simulatedPeer) forkHash(ctx context.Context, headerReader HeaderReader, blockNum uint64) (common.Hash, error) {
+	p.forkMu.Lock()
+	defer p.forkMu.Unlock()
+
+	if hash, ok := p.forkHashes[blockNum]; ok {
+		return hash, nil
+	}
+
+	from := *p.profile.StaleForkBlock
+	for num := blockNum; num > from; num-- {
+		if _, ok := p.forkHashes[num-1]; ok {
+			from = num
+			break
+		}
+	}
+
+	var hash common.Hash
+	for num := from; num <= blockNum; num++ {
+		header, err := headerReader.Header(ctx, num)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		if header == nil {
+			return common.Hash{}, fmt.Errorf("unknown header %d", num)
+		}
+
+		forked := coretypes.CopyHeader(header)
+		forked.Extra = append(forked.Extra, staleForkExtra...)
+		if num > *p.profile.StaleForkBlock {
+			forked.ParentHash = p.forkHashes[num-1]
+		}
+
+		hash = forked.Hash()
+		p.forkHashes[num] = hash
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/mclock"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	coretypes "github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/p2p/sentry"
+)
+
+type memoryHeaderReader struct {
+	headers []*coretypes.Header
+}
+
+func newMemoryHeaderReader(count int) *memoryHeaderReader {
+	headers := make([]*coretypes.Header, count)
+	var parentHash common.Hash
+	for i := range headers {
+		headers[i] = &coretypes.Header{Number: big.NewInt(int64(i)), ParentHash: parentHash, Difficulty: big.NewInt(1)}
+		parentHash = headers[i].Hash()
+	}
+	return &memoryHeaderReader{headers: headers}
+}
+
+func (r *memoryHeaderReader) Header(_ context.Context, blockNum uint64) (*coretypes.Header, error) {
+	if blockNum >= uint64(len(r.headers)) {
+		return nil, nil
+	}
+	return r.headers[blockNum], nil
+}
+
+func (r *memoryHeaderReader) HeaderByHash(_ context.Context, hash common.Hash) (*coretypes.Header, error) {
+	for _, header := range r.headers {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryHeaderReader) BodyByHash(ctx context.Context, hash common.Hash) (*coretypes.Body, error) {
+	header, err := r.HeaderByHash(ctx, hash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+	return &coretypes.Body{}, nil
+}
+
+func TestSimulatorPeerProfiles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	headerReader := newMemoryHeaderReader(100)
+	forkBlock := uint64(15)
+	profiles := []PeerProfile{
+		{Latency: FixedLatency(10 * time.Millisecond)},
+		{DropRate: 1},
+		{StaleForkBlock: &forkBlock},
+		{ProtocolViolationRate: 1},
+	}
+
+	sim, err := NewSentry(ctx, "", "", len(profiles), log.New(), WithPeerProfiles(profiles...), WithSeed(1), WithHeaderReader(headerReader))
+	require.NoError(t, err)
+	s := sim.(*server)
+
+	simClient := direct.NewSentryClientDirect(direct.ETH67, sim)
+	receiver, err := simClient.Messages(ctx, &sentryproto.MessagesRequest{
+		Ids: []sentryproto.MessageId{sentryproto.MessageId_BLOCK_HEADERS_66},
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return len(s.receivers(sentryproto.MessageId_BLOCK_HEADERS_66)) > 0
+	}, time.Second, time.Millisecond)
+
+	var data bytes.Buffer
+	err = rlp.Encode(&data, &eth.GetBlockHeadersPacket66{
+		RequestId:             1,
+		GetBlockHeadersPacket: &eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 10}, Amount: 10},
+	})
+	require.NoError(t, err)
+
+	peers, err := simClient.SendMessageToAll(ctx, &sentryproto.OutboundMessageData{
+		Id:   sentryproto.MessageId_GET_BLOCK_HEADERS_66,
+		Data: data.Bytes(),
+	})
+	require.NoError(t, err)
+	require.Len(t, peers.Peers, len(profiles))
+
+	responses := map[[64]byte][]byte{}
+	for len(responses) < len(profiles)-1 {
+		message, err := receiver.Recv()
+		require.NoError(t, err)
+		responses[sentry.ConvertH512ToPeerID(message.PeerId)] = message.Data
+	}
+
+	decode := func(peer *simulatedPeer) (*eth.BlockHeadersPacket66, error) {
+		packet := &eth.BlockHeadersPacket66{}
+		return packet, rlp.DecodeBytes(responses[peer.Pubkey()], packet)
+	}
+
+	// the well behaved peer serves the canonical headers after its latency
+	packet, err := decode(s.peerOrder[0])
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), packet.RequestId)
+	require.Len(t, packet.BlockHeadersPacket, 10)
+	for i, header := range packet.BlockHeadersPacket {
+		require.Equal(t, headerReader.headers[10+i].Hash(), header.Hash())
+	}
+
+	// the dropping peer never responds
+	require.NotContains(t, responses, s.peerOrder[1].Pubkey())
+
+	// the stale fork peer diverges at the fork block, but its fork is linked
+	packet, err = decode(s.peerOrder[2])
+	require.NoError(t, err)
+	require.Len(t, packet.BlockHeadersPacket, 10)
+	for i, header := range packet.BlockHeadersPacket {
+		blockNum := header.Number.Uint64()
+		require.Equal(t, blockNum < forkBlock, headerReader.headers[blockNum].Hash() == header.Hash())
+		if i > 0 {
+			require.Equal(t, packet.BlockHeadersPacket[i-1].Hash(), header.ParentHash)
+		}
+	}
+
+	// the violating peer sends an undecodable payload or answers another request
+	packet, err = decode(s.peerOrder[3])
+	require.True(t, err != nil || packet.RequestId != 1)
+}
+
+func TestSimulatorFaultsAreDeterministic(t *testing.T) {
+	profile := PeerProfile{
+		Latency:               UniformLatency{Min: time.Millisecond, Max: time.Second},
+		DropRate:              0.3,
+		ProtocolViolationRate: 0.3,
+	}
+
+	draw := func() []any {
+		peer, err := newSimulatedPeer(0, profile, 42)
+		require.NoError(t, err)
+
+		var faults []any
+		for i := 0; i < 100; i++ {
+			delay, drop, violation := peer.nextFaults()
+			require.GreaterOrEqual(t, delay, profile.Latency.(UniformLatency).Min)
+			require.LessOrEqual(t, delay, profile.Latency.(UniformLatency).Max)
+			faults = append(faults, delay, drop, violation)
+		}
+		return faults
+	}
+
+	require.Equal(t, draw(), draw())
+	require.Equal(t, time.Second, UniformLatency{Min: time.Second}.Sample(rand.New(rand.NewSource(0))))
+}
+
+func TestSimulatorVirtualClock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader := newMemoryHeaderReader(100)
+	clock := &mclock.Simulated{}
+	var inbound []*sentryproto.InboundMessage
+	profiles := []PeerProfile{
+		{Latency: FixedLatency(time.Second), Available: []BlockRange{{From: 0, To: 50}}},
+		{DisconnectRate: 1, ReconnectDelay: time.Minute},
+	}
+
+	sim, err := NewSentry(ctx, "", "", len(profiles), log.New(), WithPeerProfiles(profiles...), WithSeed(1),
+		WithHeaderReader(reader), WithBodyReader(reader), WithClock(clock),
+		WithInboundHandler(func(message *sentryproto.InboundMessage) { inbound = append(inbound, message) }))
+	require.NoError(t, err)
+
+	peerCount := func() uint64 {
+		reply, err := sim.PeerCount(ctx, &sentryproto.PeerCountRequest{})
+		require.NoError(t, err)
+		return reply.Count
+	}
+
+	var data bytes.Buffer
+	err = rlp.Encode(&data, &eth.GetBlockHeadersPacket66{
+		RequestId:             1,
+		GetBlockHeadersPacket: &eth.GetBlockHeadersPacket{Origin: eth.HashOrNumber{Number: 40}, Amount: 20},
+	})
+	require.NoError(t, err)
+
+	peers, err := sim.SendMessageToAll(ctx, &sentryproto.OutboundMessageData{
+		Id:   sentryproto.MessageId_GET_BLOCK_HEADERS_66,
+		Data: data.Bytes(),
+	})
+	require.NoError(t, err)
+	require.Len(t, peers.Peers, 2)
+
+	// the second peer disconnected instead of responding
+	require.Equal(t, uint64(1), peerCount())
+
+	// the response only comes once its latency passed in virtual time
+	clock.Run(time.Second - 1)
+	require.Empty(t, inbound)
+	clock.Run(1)
+	require.Len(t, inbound, 1)
+
+	// the headers stop where the partially available peer has no more blocks
+	headers := &eth.BlockHeadersPacket66{}
+	require.NoError(t, rlp.DecodeBytes(inbound[0].Data, headers))
+	require.Len(t, headers.BlockHeadersPacket, 10)
+
+	hashes := make(eth.GetBlockBodiesPacket, 0, 10)
+	for _, header := range reader.headers[55:65] {
+		hashes = append(hashes, header.Hash())
+	}
+	data.Reset()
+	err = rlp.Encode(&data, &eth.GetBlockBodiesPacket66{RequestId: 2, GetBlockBodiesPacket: hashes})
+	require.NoError(t, err)
+	bodiesRequest := &sentryproto.OutboundMessageData{Id: sentryproto.MessageId_GET_BLOCK_BODIES_66, Data: data.Bytes()}
+
+	// no connected peer serves these blocks
+	sent, err := sim.SendMessageByMinBlock(ctx, &sentryproto.SendMessageByMinBlockRequest{Data: bodiesRequest, MinBlock: 64})
+	require.NoError(t, err)
+	require.Empty(t, sent.Peers)
+
+	// the disconnected peer comes back after its reconnect delay, and serves them
+	clock.Run(time.Minute)
+	require.Equal(t, uint64(2), peerCount())
+	sim.(*server).peerOrder[1].profile.DisconnectRate = 0
+
+	sent, err = sim.SendMessageByMinBlock(ctx, &sentryproto.SendMessageByMinBlockRequest{Data: bodiesRequest, MinBlock: 64})
+	require.NoError(t, err)
+	require.Len(t, sent.Peers, 1)
+	require.Equal(t, peers.Peers[1].String(), sent.Peers[0].String())
+
+	clock.Run(0)
+	require.Len(t, inbound, 2)
+	bodies := &eth.BlockBodiesPacket66{}
+	require.NoError(t, rlp.DecodeBytes(inbound[1].Data, bodies))
+	require.Equal(t, uint64(2), bodies.RequestId)
+	require.Len(t, bodies.BlockBodiesPacket, 10)
+}
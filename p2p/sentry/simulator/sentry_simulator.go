@@ -22,11 +22,13 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	gosync "sync"
 
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/mclock"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/gointerfaces"
@@ -48,17 +50,33 @@ import (
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 )
 
+// HeaderReader provides the canonical headers served by the simulated peers.
+type HeaderReader interface {
+	Header(ctx context.Context, blockNum uint64) (*coretypes.Header, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*coretypes.Header, error)
+}
+
+// BodyReader provides the block bodies served by the simulated peers.
+type BodyReader interface {
+	BodyByHash(ctx context.Context, hash common.Hash) (*coretypes.Body, error)
+}
+
 type server struct {
 	isentry.UnimplementedSentryServer
 	ctx              context.Context
-	peers            map[[64]byte]*p2p.Peer
+	peers            map[[64]byte]*simulatedPeer
+	peerOrder        []*simulatedPeer
+	nextPeerMu       gosync.Mutex
+	nextPeer         int
+	receiversMu      gosync.RWMutex
 	messageReceivers map[isentry.MessageId][]isentry.Sentry_MessagesServer
+	eventReceivers   []isentry.Sentry_PeerEventsServer
+	inbound          func(*isentry.InboundMessage)
 	logger           log.Logger
-	knownSnapshots   *freezeblocks.RoSnapshots
-	activeSnapshots  *freezeblocks.RoSnapshots
-	blockReader      *freezeblocks.BlockReader
-	downloader       *sync.TorrentClient
-	chain            string
+	headerReader     HeaderReader
+	bodyReader       BodyReader
+	clock            mclock.Clock
+	closers          []func()
 }
 
 func newPeer(name string, caps []p2p.Cap) (*p2p.Peer, error) {
@@ -71,60 +89,59 @@ func newPeer(name string, caps []p2p.Cap) (*p2p.Peer, error) {
 	return p2p.NewPeer(enode.PubkeyToIDV4(&key.PublicKey), v4wire.EncodePubkey(&key.PublicKey), name, caps, true), nil
 }
 
-func NewSentry(ctx context.Context, chain string, snapshotLocation string, peerCount int, logger log.Logger) (isentry.SentryServer, error) {
-	peers := map[[64]byte]*p2p.Peer{}
+// NewSentry creates a sentry server backed by peerCount simulated peers. By default the
+// peers serve the chain headers from its snapshots, downloading them on demand, and
+// behave well. Options allow assigning per peer fault injection profiles, serving
+// headers and bodies from another source and responding in virtual time, so sync code
+// can be exercised deterministically.
+func NewSentry(ctx context.Context, chain string, snapshotLocation string, peerCount int, logger log.Logger, opts ...Option) (isentry.SentryServer, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	peers := map[[64]byte]*simulatedPeer{}
+	peerOrder := make([]*simulatedPeer, 0, peerCount)
 
 	for i := 0; i < peerCount; i++ {
-		peer, err := newPeer(fmt.Sprint("peer-", i), nil)
+		var profile PeerProfile
+		if i < len(options.profiles) {
+			profile = options.profiles[i]
+		}
+
+		peer, err := newSimulatedPeer(i, profile, options.seed)
 
 		if err != nil {
 			return nil, err
 		}
 		peers[peer.Pubkey()] = peer
-	}
-
-	cfg := snapcfg.KnownCfg(chain)
-	torrentDir := filepath.Join(snapshotLocation, "torrents", chain)
-
-	freezeCfg := ethconfig.Defaults.Snapshot
-	freezeCfg.NoDownloader = true
-	freezeCfg.ProduceE2 = false
-	freezeCfg.ProduceE3 = false
-	freezeCfg.ChainName = chain
-	knownSnapshots := freezeblocks.NewRoSnapshots(freezeCfg, "", 0, logger)
-
-	files := make([]string, 0, len(cfg.Preverified))
-
-	for _, item := range cfg.Preverified {
-		files = append(files, item.Name)
-	}
-
-	knownSnapshots.InitSegments(files)
-
-	//s.knownSnapshots.OpenList([]string{ent2.Name()}, false)
-	activeSnapshots := freezeblocks.NewRoSnapshots(freezeCfg, torrentDir, 0, logger)
-
-	if err := activeSnapshots.OpenFolder(); err != nil {
-		return nil, err
-	}
-
-	config := sync.NewDefaultTorrentClientConfig(chain, snapshotLocation, logger)
-	downloader, err := sync.NewTorrentClient(ctx, config)
-
-	if err != nil {
-		return nil, err
+		peerOrder = append(peerOrder, peer)
 	}
 
 	s := &server{
 		ctx:              ctx,
 		peers:            peers,
+		peerOrder:        peerOrder,
 		messageReceivers: map[isentry.MessageId][]isentry.Sentry_MessagesServer{},
-		knownSnapshots:   knownSnapshots,
-		activeSnapshots:  activeSnapshots,
-		blockReader:      freezeblocks.NewBlockReader(activeSnapshots, nil, nil, nil),
+		inbound:          options.inbound,
 		logger:           logger,
-		downloader:       downloader,
-		chain:            chain,
+		headerReader:     options.headerReader,
+		bodyReader:       options.bodyReader,
+		clock:            options.clock,
+	}
+
+	if s.clock == nil {
+		s.clock = mclock.System{}
+	}
+
+	if s.headerReader == nil {
+		headerReader, err := newSnapshotHeaderReader(ctx, chain, snapshotLocation, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		s.headerReader = headerReader
+		s.closers = append(s.closers, headerReader.Close)
 	}
 
 	go func() {
@@ -136,8 +153,9 @@ func NewSentry(ctx context.Context, chain string, snapshotLocation string, peerC
 }
 
 func (s *server) Close() {
-	_ = s.downloader.Close()
-	s.activeSnapshots.Close()
+	for _, closer := range s.closers {
+		closer()
+	}
 }
 
 func (s *server) NodeInfo(context.Context, *emptypb.Empty) (*types.NodeInfoReply, error) {
@@ -149,7 +167,7 @@ func (s *server) PeerById(ctx context.Context, in *isentry.PeerByIdRequest) (*is
 
 	peer, ok := s.peers[peerId]
 
-	if !ok {
+	if !ok || !peer.isConnected() {
 		return nil, errors.New("unknown peer")
 	}
 
@@ -172,11 +190,60 @@ func (s *server) PeerById(ctx context.Context, in *isentry.PeerByIdRequest) (*is
 }
 
 func (s *server) PeerCount(context.Context, *isentry.PeerCountRequest) (*isentry.PeerCountReply, error) {
-	return &isentry.PeerCountReply{Count: uint64(len(s.peers))}, nil
+	var count uint64
+
+	for _, peer := range s.peerOrder {
+		if peer.isConnected() {
+			count++
+		}
+	}
+
+	return &isentry.PeerCountReply{Count: count}, nil
+}
+
+func (s *server) PeerEvents(_ *isentry.PeerEventsRequest, receiver isentry.Sentry_PeerEventsServer) error {
+	s.receiversMu.Lock()
+	s.eventReceivers = append(s.eventReceivers, receiver)
+	s.receiversMu.Unlock()
+
+	<-s.ctx.Done()
+
+	return nil
 }
 
-func (s *server) PeerEvents(*isentry.PeerEventsRequest, isentry.Sentry_PeerEventsServer) error {
-	return errors.New("TODO")
+func (s *server) publishPeerEvent(peer *simulatedPeer, eventId isentry.PeerEvent_PeerEventId) {
+	peerKey := peer.Pubkey()
+	event := &isentry.PeerEvent{
+		PeerId:  gointerfaces.ConvertBytesToH512(peerKey[:]),
+		EventId: eventId,
+	}
+
+	s.receiversMu.RLock()
+	receivers := s.eventReceivers
+	s.receiversMu.RUnlock()
+
+	for _, receiver := range receivers {
+		if err := receiver.Send(event); err != nil {
+			s.logger.Debug("Can't send peer event", "error", err)
+		}
+	}
+}
+
+// disconnect drops the peer until its reconnect delay passes on the simulation clock.
+func (s *server) disconnect(peer *simulatedPeer) {
+	if !peer.connected.CompareAndSwap(true, false) {
+		return
+	}
+
+	s.publishPeerEvent(peer, isentry.PeerEvent_Disconnect)
+
+	if delay := peer.profile.ReconnectDelay; delay > 0 {
+		s.clock.AfterFunc(delay, func() {
+			if s.ctx.Err() == nil && peer.connected.CompareAndSwap(false, true) {
+				s.publishPeerEvent(peer, isentry.PeerEvent_Connect)
+			}
+		})
+	}
 }
 
 func (s *server) PeerMinBlock(context.Context, *isentry.PeerMinBlockRequest) (*emptypb.Empty, error) {
@@ -186,7 +253,11 @@ func (s *server) PeerMinBlock(context.Context, *isentry.PeerMinBlockRequest) (*e
 func (s *server) Peers(context.Context, *emptypb.Empty) (*isentry.PeersReply, error) {
 	reply := &isentry.PeersReply{}
 
-	for _, peer := range s.peers {
+	for _, peer := range s.peerOrder {
+		if !peer.isConnected() {
+			continue
+		}
+
 		info := peer.Info()
 
 		reply.Peers = append(reply.Peers,
@@ -222,7 +293,7 @@ func (s *server) SendMessageById(ctx context.Context, in *isentry.SendMessageByI
 func (s *server) sendMessageById(ctx context.Context, peerId [64]byte, messageData *isentry.OutboundMessageData) error {
 	peer, ok := s.peers[peerId]
 
-	if !ok {
+	if !ok || !peer.isConnected() {
 		return errors.New("unknown peer")
 	}
 
@@ -233,7 +304,23 @@ func (s *server) sendMessageById(ctx context.Context, peerId [64]byte, messageDa
 			return fmt.Errorf("failed to decode packet: %w", err)
 		}
 
-		go s.processGetBlockHeaders(ctx, peer, packet.RequestId, packet.GetBlockHeadersPacket)
+		s.schedule(peer, func(violation protocolViolation) {
+			s.processGetBlockHeaders(s.ctx, peer, packet.RequestId, packet.GetBlockHeadersPacket, violation)
+		})
+
+	case isentry.MessageId_GET_BLOCK_BODIES_66:
+		if s.bodyReader == nil {
+			return fmt.Errorf("unhandled message id: %s", messageData.Id)
+		}
+
+		packet := &eth.GetBlockBodiesPacket66{}
+		if err := rlp.DecodeBytes(messageData.Data, packet); err != nil {
+			return fmt.Errorf("failed to decode packet: %w", err)
+		}
+
+		s.schedule(peer, func(violation protocolViolation) {
+			s.processGetBlockBodies(s.ctx, peer, packet.RequestId, packet.GetBlockBodiesPacket, violation)
+		})
 
 	default:
 		return fmt.Errorf("unhandled message id: %s", messageData.Id)
@@ -242,14 +329,68 @@ func (s *server) sendMessageById(ctx context.Context, peerId [64]byte, messageDa
 	return nil
 }
 
+// schedule draws the faults for a request to the peer and, unless it is dropped or the
+// peer disconnects, responds after the drawn delay on the simulation clock. The faults
+// are drawn when the request is sent, so they only depend on the order of requests.
+func (s *server) schedule(peer *simulatedPeer, respond func(violation protocolViolation)) {
+	if peer.nextDisconnect() {
+		s.disconnect(peer)
+		return
+	}
+
+	delay, drop, violation := peer.nextFaults()
+
+	if drop {
+		return
+	}
+
+	s.clock.AfterFunc(delay, func() {
+		if s.ctx.Err() != nil || !peer.isConnected() {
+			return
+		}
+
+		respond(violation)
+	})
+}
+
+// SendMessageByMinBlock sends the message to the next connected peer, in turn, which
+// serves the block.
 func (s *server) SendMessageByMinBlock(ctx context.Context, request *isentry.SendMessageByMinBlockRequest) (*isentry.SentPeers, error) {
-	return s.UnimplementedSentryServer.SendMessageByMinBlock(ctx, request)
+	sentPeers := &isentry.SentPeers{}
+
+	s.nextPeerMu.Lock()
+	defer s.nextPeerMu.Unlock()
+
+	for i := 0; i < len(s.peerOrder); i++ {
+		peer := s.peerOrder[(s.nextPeer+i)%len(s.peerOrder)]
+
+		if !peer.isConnected() || !peer.serves(request.MinBlock) {
+			continue
+		}
+
+		peerKey := peer.Pubkey()
+
+		if err := s.sendMessageById(ctx, peerKey, request.Data); err != nil {
+			return sentPeers, err
+		}
+
+		sentPeers.Peers = append(sentPeers.Peers, gointerfaces.ConvertBytesToH512(peerKey[:]))
+		s.nextPeer = (s.nextPeer + i + 1) % len(s.peerOrder)
+
+		break
+	}
+
+	return sentPeers, nil
 }
 
 func (s *server) SendMessageToAll(ctx context.Context, data *isentry.OutboundMessageData) (*isentry.SentPeers, error) {
 	sentPeers := &isentry.SentPeers{}
 
-	for _, peer := range s.peers {
+	for _, peer := range s.peerOrder {
+		if !peer.isConnected() {
+			continue
+		}
+
 		peerKey := peer.Pubkey()
 
 		if err := s.sendMessageById(ctx, peerKey, data); err != nil {
@@ -267,7 +408,11 @@ func (s *server) SendMessageToRandomPeers(ctx context.Context, request *isentry.
 
 	var i uint64
 
-	for _, peer := range s.peers {
+	for _, peer := range s.peerOrder {
+		if !peer.isConnected() {
+			continue
+		}
+
 		peerKey := peer.Pubkey()
 
 		if err := s.sendMessageById(ctx, peerKey, request.Data); err != nil {
@@ -288,54 +433,122 @@ func (s *server) SendMessageToRandomPeers(ctx context.Context, request *isentry.
 }
 
 func (s *server) Messages(request *isentry.MessagesRequest, receiver isentry.Sentry_MessagesServer) error {
+	s.receiversMu.Lock()
 	for _, messageId := range request.Ids {
 		receivers := s.messageReceivers[messageId]
 		s.messageReceivers[messageId] = append(receivers, receiver)
 	}
+	s.receiversMu.Unlock()
 
 	<-s.ctx.Done()
 
 	return nil
 }
 
-func (s *server) processGetBlockHeaders(ctx context.Context, peer *p2p.Peer, requestId uint64, request *eth.GetBlockHeadersPacket) {
-	r66 := s.messageReceivers[isentry.MessageId_BLOCK_HEADERS_66]
+func (s *server) receivers(messageId isentry.MessageId) []isentry.Sentry_MessagesServer {
+	s.receiversMu.RLock()
+	defer s.receiversMu.RUnlock()
+	return s.messageReceivers[messageId]
+}
 
-	if len(r66) > 0 {
+func (s *server) processGetBlockHeaders(ctx context.Context, peer *simulatedPeer, requestId uint64, request *eth.GetBlockHeadersPacket, violation protocolViolation) {
+	if len(s.receivers(isentry.MessageId_BLOCK_HEADERS_66)) == 0 && s.inbound == nil {
+		return
+	}
 
-		peerKey := peer.Pubkey()
-		peerId := gointerfaces.ConvertBytesToH512(peerKey[:])
+	headers, err := s.getHeaders(ctx, peer, request.Origin, request.Amount, request.Skip, request.Reverse)
 
-		headers, err := s.getHeaders(ctx, request.Origin, request.Amount, request.Skip, request.Reverse)
+	if err != nil {
+		s.logger.Warn("Can't get headers", "error", err)
+		return
+	}
+
+	if violation == mismatchedRequestIdViolation {
+		requestId++
+	}
+
+	s.respond(peer, isentry.MessageId_BLOCK_HEADERS_66, violation, &eth.BlockHeadersPacket66{
+		RequestId:          requestId,
+		BlockHeadersPacket: headers,
+	})
+}
+
+func (s *server) processGetBlockBodies(ctx context.Context, peer *simulatedPeer, requestId uint64, hashes eth.GetBlockBodiesPacket, violation protocolViolation) {
+	if len(s.receivers(isentry.MessageId_BLOCK_BODIES_66)) == 0 && s.inbound == nil {
+		return
+	}
+
+	bodies := make(eth.BlockBodiesPacket, 0, len(hashes))
+
+	for _, hash := range hashes {
+		header, err := s.headerReader.HeaderByHash(ctx, hash)
 
 		if err != nil {
-			s.logger.Warn("Can't get headers", "error", err)
+			s.logger.Warn("Can't get header", "error", err)
 			return
 		}
 
-		var data bytes.Buffer
+		// a stale fork peer does not know canonical blocks past its fork point
+		if header == nil || !peer.serves(header.Number.Uint64()) || peer.onStaleFork(header.Number.Uint64()) {
+			break
+		}
 
-		err = rlp.Encode(&data, &eth.BlockHeadersPacket66{
-			RequestId:          requestId,
-			BlockHeadersPacket: headers,
-		})
+		body, err := s.bodyReader.BodyByHash(ctx, hash)
 
 		if err != nil {
-			fmt.Printf("Error (move to logger): %s", err)
+			s.logger.Warn("Can't get body", "error", err)
 			return
 		}
 
-		for _, receiver := range r66 {
-			receiver.Send(&isentry.InboundMessage{
-				Id:     isentry.MessageId_BLOCK_HEADERS_66,
-				Data:   data.Bytes(),
-				PeerId: peerId,
-			})
+		if body == nil {
+			break
 		}
+
+		bodies = append(bodies, body)
+	}
+
+	if violation == mismatchedRequestIdViolation {
+		requestId++
+	}
+
+	s.respond(peer, isentry.MessageId_BLOCK_BODIES_66, violation, &eth.BlockBodiesPacket66{
+		RequestId:         requestId,
+		BlockBodiesPacket: bodies,
+	})
+}
+
+// respond encodes the packet, breaks it if the peer violates the protocol, and delivers
+// it from the peer to the message receivers and the inbound handler.
+func (s *server) respond(peer *simulatedPeer, messageId isentry.MessageId, violation protocolViolation, packet any) {
+	var data bytes.Buffer
+
+	if err := rlp.Encode(&data, packet); err != nil {
+		s.logger.Warn("Can't encode response", "id", messageId, "error", err)
+		return
+	}
+
+	payload := data.Bytes()
+	if violation == malformedPayloadViolation {
+		payload = payload[:len(payload)/2]
+	}
+
+	peerKey := peer.Pubkey()
+	message := &isentry.InboundMessage{
+		Id:     messageId,
+		Data:   payload,
+		PeerId: gointerfaces.ConvertBytesToH512(peerKey[:]),
+	}
+
+	for _, receiver := range s.receivers(messageId) {
+		receiver.Send(message)
+	}
+
+	if s.inbound != nil {
+		s.inbound(message)
 	}
 }
 
-func (s *server) getHeaders(ctx context.Context, origin eth.HashOrNumber, amount uint64, skip uint64, reverse bool) (eth.BlockHeadersPacket, error) {
+func (s *server) getHeaders(ctx context.Context, peer *simulatedPeer, origin eth.HashOrNumber, amount uint64, skip uint64, reverse bool) (eth.BlockHeadersPacket, error) {
 
 	var headers eth.BlockHeadersPacket
 
@@ -355,44 +568,105 @@ func (s *server) getHeaders(ctx context.Context, origin eth.HashOrNumber, amount
 		}
 	}
 
+	var header *coretypes.Header
+	var err error
+
 	if origin.Hash != (common.Hash{}) {
-		header, err := s.getHeaderByHash(ctx, origin.Hash)
+		header, err = s.headerReader.HeaderByHash(ctx, origin.Hash)
+	} else {
+		header, err = s.headerReader.Header(ctx, origin.Number)
+	}
 
-		if err != nil {
-			return nil, err
+	for err == nil && header != nil {
+		if !peer.serves(header.Number.Uint64()) {
+			break
 		}
 
-		headers = append(headers, header)
-
-		next = nextBlockNum(header.Number.Uint64())
-	} else {
-		header, err := s.getHeader(ctx, origin.Number)
+		// a stale fork peer does not know canonical headers past its fork point, so
+		// hash lookups of those are served as unknown
+		if origin.Hash != (common.Hash{}) && len(headers) == 0 && peer.onStaleFork(header.Number.Uint64()) {
+			return headers, nil
+		}
 
-		if err != nil {
+		if header, err = peer.forkHeader(ctx, s.headerReader, header); err != nil {
 			return nil, err
 		}
 
 		headers = append(headers, header)
 
+		if len(headers) >= int(amount) || (reverse && header.Number.Uint64() < max(skip, 1)) {
+			break
+		}
+
 		next = nextBlockNum(header.Number.Uint64())
+		header, err = s.headerReader.Header(ctx, next)
 	}
 
-	for len(headers) < int(amount) {
-		header, err := s.getHeader(ctx, next)
+	if err != nil {
+		return nil, err
+	}
 
-		if err != nil {
-			return nil, err
-		}
+	return headers, nil
+}
 
-		headers = append(headers, header)
+type snapshotHeaderReader struct {
+	knownSnapshots  *freezeblocks.RoSnapshots
+	activeSnapshots *freezeblocks.RoSnapshots
+	blockReader     *freezeblocks.BlockReader
+	downloader      *sync.TorrentClient
+	chain           string
+	logger          log.Logger
+}
 
-		next = nextBlockNum(header.Number.Uint64())
+func newSnapshotHeaderReader(ctx context.Context, chain string, snapshotLocation string, logger log.Logger) (*snapshotHeaderReader, error) {
+	cfg := snapcfg.KnownCfg(chain)
+	torrentDir := filepath.Join(snapshotLocation, "torrents", chain)
+
+	freezeCfg := ethconfig.Defaults.Snapshot
+	freezeCfg.NoDownloader = true
+	freezeCfg.ProduceE2 = false
+	freezeCfg.ProduceE3 = false
+	freezeCfg.ChainName = chain
+	knownSnapshots := freezeblocks.NewRoSnapshots(freezeCfg, "", 0, logger)
+
+	files := make([]string, 0, len(cfg.Preverified))
+
+	for _, item := range cfg.Preverified {
+		files = append(files, item.Name)
 	}
 
-	return headers, nil
+	knownSnapshots.InitSegments(files)
+
+	//s.knownSnapshots.OpenList([]string{ent2.Name()}, false)
+	activeSnapshots := freezeblocks.NewRoSnapshots(freezeCfg, torrentDir, 0, logger)
+
+	if err := activeSnapshots.OpenFolder(); err != nil {
+		return nil, err
+	}
+
+	config := sync.NewDefaultTorrentClientConfig(chain, snapshotLocation, logger)
+	downloader, err := sync.NewTorrentClient(ctx, config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshotHeaderReader{
+		knownSnapshots:  knownSnapshots,
+		activeSnapshots: activeSnapshots,
+		blockReader:     freezeblocks.NewBlockReader(activeSnapshots, nil, nil, nil),
+		downloader:      downloader,
+		chain:           chain,
+		logger:          logger,
+	}, nil
+}
+
+func (s *snapshotHeaderReader) Close() {
+	_ = s.downloader.Close()
+	s.activeSnapshots.Close()
 }
 
-func (s *server) getHeader(ctx context.Context, blockNum uint64) (*coretypes.Header, error) {
+func (s *snapshotHeaderReader) Header(ctx context.Context, blockNum uint64) (*coretypes.Header, error) {
 	header, err := s.blockReader.Header(ctx, nil, common.Hash{}, blockNum)
 
 	if err != nil {
@@ -421,11 +695,11 @@ func (s *server) getHeader(ctx context.Context, blockNum uint64) (*coretypes.Hea
 	return header, nil
 }
 
-func (s *server) getHeaderByHash(ctx context.Context, hash common.Hash) (*coretypes.Header, error) {
+func (s *snapshotHeaderReader) HeaderByHash(ctx context.Context, hash common.Hash) (*coretypes.Header, error) {
 	return s.blockReader.HeaderByHash(ctx, nil, hash)
 }
 
-func (s *server) downloadHeaders(ctx context.Context, header *snapshotsync.VisibleSegment) error {
+func (s *snapshotHeaderReader) downloadHeaders(ctx context.Context, header *snapshotsync.VisibleSegment) error {
 	fileName := snaptype.SegmentFileName(version.ZeroVersion, header.From(), header.To(), coresnaptype.Enums.Headers)
 	session := sync.NewTorrentSession(s.downloader, s.chain)
 
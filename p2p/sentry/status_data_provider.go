@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/p2p/forkid"
@@ -52,6 +53,7 @@ type StatusDataProvider struct {
 	genesisHead ChainHead
 	heightForks []uint64
 	timeForks   []uint64
+	pruneMode   prune.Mode
 
 	logger log.Logger
 }
@@ -61,6 +63,7 @@ func NewStatusDataProvider(
 	chainConfig *chain.Config,
 	genesis *types.Block,
 	networkId uint64,
+	pruneMode prune.Mode,
 	logger log.Logger,
 ) *StatusDataProvider {
 	s := &StatusDataProvider{
@@ -68,6 +71,7 @@ func NewStatusDataProvider(
 		networkId:   networkId,
 		genesisHash: genesis.Hash(),
 		genesisHead: makeGenesisChainHead(genesis),
+		pruneMode:   pruneMode,
 		logger:      logger,
 	}
 
@@ -102,13 +106,24 @@ func makeGenesisChainHead(genesis *types.Block) ChainHead {
 	}
 }
 
+// minimumBlockHeight - first block we serve to peers. Block files are pruned only behind
+// the distance from frozen blocks, so all blocks after the distance from head are available.
+func (s *StatusDataProvider) minimumBlockHeight(head ChainHead) uint64 {
+	blocks := s.pruneMode.Runtime().Blocks
+	if blocks == nil || !blocks.Enabled() {
+		return 0
+	}
+	return blocks.PruneTo(head.HeadHeight)
+}
+
 func (s *StatusDataProvider) makeStatusData(head ChainHead) *proto_sentry.StatusData {
 	return &proto_sentry.StatusData{
-		NetworkId:       s.networkId,
-		TotalDifficulty: gointerfaces.ConvertUint256IntToH256(head.HeadTd),
-		BestHash:        gointerfaces.ConvertHashToH256(head.HeadHash),
-		MaxBlockHeight:  head.HeadHeight,
-		MaxBlockTime:    head.HeadTime,
+		NetworkId:          s.networkId,
+		TotalDifficulty:    gointerfaces.ConvertUint256IntToH256(head.HeadTd),
+		BestHash:           gointerfaces.ConvertHashToH256(head.HeadHash),
+		MaxBlockHeight:     head.HeadHeight,
+		MaxBlockTime:       head.HeadTime,
+		MinimumBlockHeight: s.minimumBlockHeight(head),
 		ForkData: &proto_sentry.Forks{
 			Genesis:     gointerfaces.ConvertHashToH256(s.genesisHash),
 			HeightForks: s.heightForks,
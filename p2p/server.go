@@ -24,6 +24,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/tls"
 	"encoding/hex"
 	"errors"
 	"net"
@@ -71,6 +72,10 @@ const (
 	frameWriteTimeout = 20 * time.Second
 
 	serverStatsLogInterval = 60 * time.Second
+
+	// How often the external IP is resolved again with the NAT interface, the address
+	// given by the ISP may change while the node runs.
+	natExternalIPInterval = 10 * time.Minute
 )
 
 var errServerStopped = errors.New("server stopped")
@@ -102,6 +107,10 @@ type Config struct {
 	// protocol should be started or not.
 	DiscoveryV5 bool `toml:",omitempty"`
 
+	// NoDiscoveryV4 disables the V4 discovery protocol, so that only the V5 discovery
+	// is used to find peers if DiscoveryV5 is set.
+	NoDiscoveryV4 bool `toml:",omitempty"`
+
 	// Name sets the node name of this server.
 	// Use common.MakeName to create a name that follows existing conventions.
 	Name string `toml:"-"`
@@ -145,6 +154,11 @@ type Config struct {
 	// the server is started.
 	ListenAddr string
 
+	// If QUICPort is non-zero, the server also listens for RLPx connections over QUIC
+	// on this UDP port of the ListenAddr host, advertises the port in the node record,
+	// and dials the nodes which advertise one over QUIC. This is experimental.
+	QUICPort int
+
 	// AllowedPorts is list of ports allowed to pick to create Listener on it (see ListenAddr)
 	// for different protocol versions
 	AllowedPorts []uint
@@ -152,6 +166,10 @@ type Config struct {
 	// eth/66, eth/67, etc
 	ProtocolVersion []uint
 
+	// ServeSnap enables the snap protocol alongside eth, serving the latest state to
+	// snap-syncing peers.
+	ServeSnap bool
+
 	SentryAddr []string
 
 	// If set to a non-nil value, the given NAT port mapper
@@ -210,6 +228,8 @@ type Server struct {
 	running atomic.Bool
 
 	listener     net.Listener
+	quicListener net.Listener
+	quicTLS      *tls.Config
 	ourHandshake *protoHandshake
 	loopWG       sync.WaitGroup // loop, listenLoop
 	peerFeed     event.Feed
@@ -240,6 +260,9 @@ type Server struct {
 
 	errorsMu sync.Mutex
 	errors   map[string]uint
+
+	reputationsMu sync.RWMutex
+	reputations   map[enode.ID]enode.NodeReputation // cache of the reputations in nodedb
 }
 
 type peerOpFunc func(map[enode.ID]*Peer)
@@ -447,6 +470,9 @@ func (srv *Server) Stop() {
 		// this unblocks listener Accept
 		_ = srv.listener.Close()
 	}
+	if srv.quicListener != nil {
+		_ = srv.quicListener.Close()
+	}
 	if srv.nodedb != nil {
 		srv.nodedb.Close()
 	}
@@ -523,10 +549,18 @@ func (srv *Server) Start(ctx context.Context, logger log.Logger) error {
 	if err := srv.setupLocalNode(); err != nil {
 		return err
 	}
+	if err := srv.loadReputations(); err != nil {
+		return err
+	}
 	if srv.ListenAddr != "" {
 		if err := srv.setupListening(srv.quitCtx); err != nil {
 			return err
 		}
+		if srv.QUICPort != 0 {
+			if err := srv.setupQUIC(srv.quitCtx); err != nil {
+				return err
+			}
+		}
 	}
 	if err := srv.setupDiscovery(srv.quitCtx); err != nil {
 		return err
@@ -586,16 +620,50 @@ func (srv *Server) setupLocalNode() error {
 		go func() {
 			defer debug.LogPanic()
 			defer srv.loopWG.Done()
-			if ip, err := srv.NAT.ExternalIP(); err == nil {
+			srv.natExternalIPLoop()
+		}()
+	}
+	return nil
+}
+
+// natExternalIPLoop resolves the external IP with the NAT interface, and updates the
+// local node record whenever it changes until the server is stopped.
+func (srv *Server) natExternalIPLoop() {
+	var current net.IP
+	var failed bool
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-srv.quit:
+			return
+		case <-timer.C:
+		}
+
+		ip, err := srv.NAT.ExternalIP()
+		switch {
+		case err != nil:
+			if !failed && current == nil {
+				srv.logger.Warn("NAT ExternalIP resolution has failed, try to pass a different --nat option", "err", err)
+			} else {
+				srv.logger.Debug("NAT ExternalIP resolution has failed", "err", err)
+			}
+			failed = true
+		case !ip.Equal(current):
+			if current == nil {
 				srv.logger.Info("NAT ExternalIP resolved", "ip", ip)
-				srv.localnode.SetStaticIP(ip)
-				srv.updateLocalNodeStaticAddrCache()
 			} else {
-				srv.logger.Warn("NAT ExternalIP resolution has failed, try to pass a different --nat option", "err", err)
+				srv.logger.Info("NAT ExternalIP changed", "old", current, "ip", ip)
 			}
-		}()
+			current = ip
+			srv.localnode.SetStaticIP(ip)
+			srv.updateLocalNodeStaticAddrCache()
+		}
+
+		timer.Reset(natExternalIPInterval)
 	}
-	return nil
 }
 
 func (srv *Server) setupDiscovery(ctx context.Context) error {
@@ -611,7 +679,7 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	}
 
 	// Don't listen on UDP endpoint if DHT is disabled.
-	if srv.NoDiscovery && !srv.DiscoveryV5 {
+	if (srv.NoDiscovery || srv.NoDiscoveryV4) && !srv.DiscoveryV5 {
 		return nil
 	}
 
@@ -641,7 +709,7 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	// Discovery V4
 	var unhandled chan discover.ReadPacket
 	var sconn *sharedUDPConn
-	if !srv.NoDiscovery {
+	if !srv.NoDiscovery && !srv.NoDiscoveryV4 {
 		if srv.DiscoveryV5 {
 			unhandled = make(chan discover.ReadPacket, 100)
 			sconn = &sharedUDPConn{conn, unhandled}
@@ -679,10 +747,27 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		srv.discmix.AddSource(enode.Filter(srv.DiscV5.RandomNodes(), srv.acceptV5Node))
 	}
 	return nil
 }
 
+// acceptV5Node reports whether a node found by the V5 discovery supports any of
+// the running protocols. All nodes are accepted if no protocol filters them.
+func (srv *Server) acceptV5Node(n *enode.Node) bool {
+	filtered := false
+	for _, proto := range srv.Protocols {
+		if proto.NodeFilter == nil {
+			continue
+		}
+		if proto.NodeFilter(n) {
+			return true
+		}
+		filtered = true
+	}
+	return !filtered
+}
+
 func (srv *Server) setupDialScheduler() {
 	config := dialConfig{
 		self:           srv.localnode.ID(),
@@ -690,15 +775,21 @@ func (srv *Server) setupDialScheduler() {
 		maxActiveDials: srv.MaxPendingPeers,
 		log:            srv.logger,
 		netRestrict:    srv.NetRestrict,
+		isBanned:       srv.isBanned,
 		dialer:         srv.Dialer,
 		clock:          srv.clock,
 	}
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
+	} else if srv.DiscV5 != nil {
+		config.resolver = srv.DiscV5
 	}
 	if config.dialer == nil {
 		config.dialer = tcpDialer{&net.Dialer{Timeout: defaultDialTimeout}}
 	}
+	if srv.quicTLS != nil {
+		config.dialer = quicDialer{next: config.dialer, tlsConf: srv.quicTLS, logger: srv.logger}
+	}
 	var subProtocolVersion uint
 	if len(srv.Protocols) > 0 {
 		subProtocolVersion = srv.Protocols[0].Version
@@ -756,7 +847,47 @@ func (srv *Server) setupListening(ctx context.Context) error {
 	go func() {
 		defer debug.LogPanic()
 		defer srv.loopWG.Done()
-		srv.listenLoop(ctx)
+		srv.listenLoop(ctx, listener)
+	}()
+	return nil
+}
+
+func (srv *Server) setupQUIC(ctx context.Context) error {
+	host, _, err := net.SplitHostPort(srv.ListenAddr)
+	if err != nil {
+		return err
+	}
+	tlsConf, err := newQUICTLSConfig()
+	if err != nil {
+		return err
+	}
+	listener, err := listenQUIC(net.JoinHostPort(host, strconv.Itoa(srv.QUICPort)), tlsConf, srv.logger)
+	if err != nil {
+		return err
+	}
+	srv.quicListener = listener
+	srv.quicTLS = tlsConf
+
+	// Advertise the port, so that the nodes which have QUIC enabled dial it, and map it
+	// if NAT is configured.
+	if udp, ok := listener.Addr().(*net.UDPAddr); ok {
+		srv.localnode.Set(enr.QUIC(udp.Port))
+
+		if !udp.IP.IsLoopback() && (srv.NAT != nil) && srv.NAT.SupportsMapping() {
+			srv.loopWG.Add(1)
+			go func() {
+				defer debug.LogPanic()
+				defer srv.loopWG.Done()
+				nat.Map(srv.NAT, srv.quit, "udp", udp.Port, udp.Port, "ethereum p2p quic", srv.logger)
+			}()
+		}
+	}
+
+	srv.loopWG.Add(1)
+	go func() {
+		defer debug.LogPanic()
+		defer srv.loopWG.Done()
+		srv.listenLoop(ctx, listener)
 	}()
 	return nil
 }
@@ -899,6 +1030,8 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 		return DiscAlreadyConnected
 	case c.node.ID() == srv.localnode.ID():
 		return DiscSelf
+	case srv.isBanned(c.node.ID()):
+		return DiscRequested
 	case (len(srv.Protocols) > 0) && (countMatchingProtocols(srv.Protocols, c.caps) == 0):
 		return DiscUselessPeer
 	default:
@@ -908,8 +1041,8 @@ func (srv *Server) postHandshakeChecks(peers map[enode.ID]*Peer, inboundCount in
 
 // listenLoop runs in its own goroutine and accepts
 // inbound connections.
-func (srv *Server) listenLoop(ctx context.Context) {
-	srv.logger.Trace("TCP listener up", "addr", srv.listener.Addr())
+func (srv *Server) listenLoop(ctx context.Context, listener net.Listener) {
+	srv.logger.Trace("Listener up", "addr", listener.Addr())
 
 	srv.resetErrors()
 
@@ -937,7 +1070,7 @@ func (srv *Server) listenLoop(ctx context.Context) {
 			lastLog time.Time
 		)
 		for {
-			fd, err = srv.listener.Accept()
+			fd, err = listener.Accept()
 			if netutil.IsTemporaryError(err) {
 				if time.Since(lastLog) > 1*time.Second {
 					srv.logger.Trace("Temporary read error", "err", err)
@@ -1005,10 +1138,16 @@ func (srv *Server) checkInboundConn(fd net.Conn, remoteIP net.IP) error {
 // or the handshakes have failed.
 func (srv *Server) SetupConn(fd net.Conn, flags connFlag, dialDest *enode.Node) error {
 	c := &conn{fd: fd, flags: flags, cont: make(chan error)}
+	newTransport := srv.newTransport
+	if qc, ok := asQUICConn(fd); ok {
+		newTransport = func(fd net.Conn, dialDest *ecdsa.PublicKey) transport {
+			return newQUICTransport(fd, qc.conn, dialDest, srv.Protocols)
+		}
+	}
 	if dialDest == nil {
-		c.transport = srv.newTransport(fd, nil)
+		c.transport = newTransport(fd, nil)
 	} else {
-		c.transport = srv.newTransport(fd, dialDest.Pubkey())
+		c.transport = newTransport(fd, dialDest.Pubkey())
 	}
 
 	err := srv.setupConn(c, flags, dialDest)
@@ -1079,9 +1218,13 @@ func (srv *Server) setupConn(c *conn, flags connFlag, dialDest *enode.Node) erro
 func nodeFromConn(pubkey *ecdsa.PublicKey, conn net.Conn) *enode.Node {
 	var ip net.IP
 	var port int
-	if tcp, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
-		ip = tcp.IP
-		port = tcp.Port
+	switch addr := conn.RemoteAddr().(type) {
+	case *net.TCPAddr:
+		ip = addr.IP
+		port = addr.Port
+	case *net.UDPAddr:
+		// QUIC connection, the ports of the node are not known
+		ip = addr.IP
 	}
 	return enode.NewV4(pubkey, ip, port, port)
 }
@@ -1192,7 +1335,9 @@ func (srv *Server) PeersInfo() []*PeerInfo {
 	infos := make([]*PeerInfo, 0, srv.PeerCount())
 	for _, peer := range srv.Peers() {
 		if peer != nil {
-			infos = append(infos, peer.Info())
+			info := peer.Info()
+			info.Penalties = srv.reputation(peer.ID()).PenaltiesAt(time.Now())
+			infos = append(infos, info)
 		}
 	}
 	// Sort the result array alphabetically by node identifier
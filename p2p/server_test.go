@@ -27,6 +27,7 @@ import (
 	"io"
 	"math/rand"
 	"net"
+	"path/filepath"
 	"reflect"
 	"sync"
 	"testing"
@@ -248,6 +249,75 @@ func TestServerRemovePeerDisconnect(t *testing.T) {
 	}
 }
 
+// This test checks that banned peers are disconnected and refused, and that bans
+// survive a restart of the server.
+func TestServerBanPeer(t *testing.T) {
+	logger := log.New()
+	root := t.TempDir()
+	config1 := Config{
+		PrivateKey:      newkey(),
+		MaxPeers:        1,
+		MaxPendingPeers: 1,
+		NoDiscovery:     true,
+		NodeDatabase:    filepath.Join(root, "nodes"),
+		TmpDir:          root,
+	}
+	srv1 := &Server{Config: config1}
+	srv2 := &Server{Config: Config{
+		PrivateKey:      newkey(),
+		MaxPeers:        1,
+		MaxPendingPeers: 1,
+		NoDiscovery:     true,
+		NoDial:          true,
+		ListenAddr:      "127.0.0.1:0",
+	}}
+	if err := srv1.TestStart(logger); err != nil {
+		t.Fatal("cant start srv1")
+	}
+	if err := srv2.TestStart(logger); err != nil {
+		t.Fatal("cant start srv2")
+	}
+	defer srv2.Stop()
+
+	if !syncAddPeer(srv1, srv2.Self()) {
+		t.Fatal("peer not connected")
+	}
+	if err := srv1.BanPeer(srv2.Self().ID(), 0); err != nil {
+		t.Fatalf("can't ban peer: %v", err)
+	}
+	for start := time.Now(); srv1.PeerCount() > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 2*time.Second {
+			t.Fatal("banned peer still connected")
+		}
+	}
+	srv1.Stop()
+
+	// Restart with the same node database, the ban must still be in effect.
+	srv1 = &Server{Config: config1}
+	if err := srv1.TestStart(logger); err != nil {
+		t.Fatal("cant restart srv1")
+	}
+	defer srv1.Stop()
+
+	banned := srv1.BannedPeers()
+	if len(banned) != 1 || banned[0].ID != srv2.Self().ID().String() || banned[0].BannedUntil != 0 {
+		t.Fatalf("unexpected banned peers: %v", banned)
+	}
+	if syncAddPeer(srv1, srv2.Self()) {
+		t.Fatal("banned peer connected")
+	}
+
+	if err := srv1.UnbanPeer(srv2.Self().ID()); err != nil {
+		t.Fatalf("can't unban peer: %v", err)
+	}
+	if banned := srv1.BannedPeers(); len(banned) != 0 {
+		t.Fatalf("unexpected banned peers after unban: %v", banned)
+	}
+	if !syncAddPeer(srv1, srv2.Self()) {
+		t.Fatal("unbanned peer not connected")
+	}
+}
+
 // This test checks that connections are disconnected just after the encryption handshake
 // when the server is at capacity. Trusted connections should still be accepted.
 func TestServerAtCap(t *testing.T) {
@@ -656,3 +726,24 @@ func syncAddPeer(srv *Server, node *enode.Node) bool {
 		}
 	}
 }
+
+func TestServerAcceptV5Node(t *testing.T) {
+	node := newNode(randomID(), "127.0.0.1:30303")
+	srv := &Server{}
+	if !srv.acceptV5Node(node) {
+		t.Error("node rejected without protocol filters")
+	}
+
+	srv.Protocols = []Protocol{
+		{Name: "a"},
+		{Name: "b", NodeFilter: func(n *enode.Node) bool { return false }},
+	}
+	if srv.acceptV5Node(node) {
+		t.Error("node accepted although rejected by all filters")
+	}
+
+	srv.Protocols = append(srv.Protocols, Protocol{Name: "c", NodeFilter: func(n *enode.Node) bool { return true }})
+	if !srv.acceptV5Node(node) {
+		t.Error("node rejected although accepted by a filter")
+	}
+}
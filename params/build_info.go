@@ -0,0 +1,78 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// BuildInfo - provenance of the binary: for version audits of fleets of nodes
+type BuildInfo struct {
+	Version   string   `json:"version"`
+	GitCommit string   `json:"gitCommit"`
+	GitBranch string   `json:"gitBranch,omitempty"`
+	GitTag    string   `json:"gitTag,omitempty"`
+	BuildDate string   `json:"buildDate,omitempty"`
+	GoVersion string   `json:"goVersion"`
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+	Features  []string `json:"features"` // build tags and cgo
+}
+
+// GetBuildInfo - values injected by Makefile, or recorded by `go build` if binary was built without Makefile
+func GetBuildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:   VersionWithMeta,
+		GitCommit: GitCommit,
+		GitBranch: GitBranch,
+		GitTag:    GitTag,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Features:  []string{},
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "-tags":
+			for _, tag := range strings.Split(s.Value, ",") {
+				if tag != "" {
+					info.Features = append(info.Features, tag)
+				}
+			}
+		case "CGO_ENABLED":
+			if s.Value == "1" {
+				info.Features = append(info.Features, "cgo")
+			}
+		case "vcs.revision":
+			if info.GitCommit == "" {
+				info.GitCommit = s.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" { // time of commit: closest to build date which is known
+				info.BuildDate = s.Value
+			}
+		}
+	}
+	return info
+}
@@ -177,6 +177,16 @@ func NewSnapshotConfig(checkpointInterval uint64, inmemorySnapshots int, inmemor
 }
 
 func ChainConfigByChainName(chainName string) *chain.Config {
+	if config := bundledChainConfigByChainName(chainName); config != nil {
+		return config
+	}
+	if c := CustomChainByName(chainName); c != nil {
+		return c.Genesis.Config
+	}
+	return nil
+}
+
+func bundledChainConfigByChainName(chainName string) *chain.Config {
 	switch chainName {
 	case networkname.Mainnet:
 		return MainnetChainConfig
@@ -228,11 +238,24 @@ func GenesisHashByChainName(chain string) *common.Hash {
 	case networkname.Test:
 		return &TestGenesisHash
 	default:
+		if c := CustomChainByName(chain); c != nil {
+			return &c.GenesisHash
+		}
 		return nil
 	}
 }
 
 func ChainConfigByGenesisHash(genesisHash common.Hash) *chain.Config {
+	if config := bundledChainConfigByGenesisHash(genesisHash); config != nil {
+		return config
+	}
+	if c := CustomChainByGenesisHash(genesisHash); c != nil {
+		return c.Genesis.Config
+	}
+	return nil
+}
+
+func bundledChainConfigByGenesisHash(genesisHash common.Hash) *chain.Config {
 	switch {
 	case genesisHash == MainnetGenesisHash:
 		return MainnetChainConfig
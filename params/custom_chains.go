@@ -0,0 +1,106 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package params
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// CustomChain - chain spec which is not bundled with Erigon (devnets, private networks).
+// Registered chains can be used by name everywhere where bundled ones can: `--chain=<name>`.
+type CustomChain struct {
+	Name        string
+	Genesis     *types.Genesis
+	GenesisHash common.Hash
+
+	// Optional Caplin config: paths to config.yaml and genesis.ssz - used when `--caplin.custom-config` is not set
+	CaplinConfigPath       string
+	CaplinGenesisStatePath string
+}
+
+var customChains = struct {
+	sync.RWMutex
+	byName map[string]*CustomChain
+	byHash map[common.Hash]*CustomChain
+}{byName: map[string]*CustomChain{}, byHash: map[common.Hash]*CustomChain{}}
+
+// RegisterCustomChain - must be called before flags parsing (see core.LoadCustomChains).
+// Bundled chains can't be overridden.
+func RegisterCustomChain(c *CustomChain) error {
+	if c.Name == "" {
+		return fmt.Errorf("custom chain: empty name")
+	}
+	if c.Genesis == nil || c.Genesis.Config == nil {
+		return fmt.Errorf("custom chain %s: genesis without config", c.Name)
+	}
+	if bundledChainConfigByChainName(c.Name) != nil {
+		return fmt.Errorf("custom chain %s: name is taken by bundled chain", c.Name)
+	}
+	if bundledChainConfigByGenesisHash(c.GenesisHash) != nil {
+		return fmt.Errorf("custom chain %s: genesis %x is taken by bundled chain", c.Name, c.GenesisHash)
+	}
+
+	customChains.Lock()
+	defer customChains.Unlock()
+	if _, ok := customChains.byName[c.Name]; ok {
+		return fmt.Errorf("custom chain %s: already registered", c.Name)
+	}
+	if other, ok := customChains.byHash[c.GenesisHash]; ok {
+		return fmt.Errorf("custom chain %s: genesis %x is taken by %s", c.Name, c.GenesisHash, other.Name)
+	}
+	c.Genesis.Config.ChainName = c.Name
+	customChains.byName[c.Name] = c
+	customChains.byHash[c.GenesisHash] = c
+	return nil
+}
+
+func UnregisterCustomChain(name string) {
+	customChains.Lock()
+	defer customChains.Unlock()
+	if c, ok := customChains.byName[name]; ok {
+		delete(customChains.byName, name)
+		delete(customChains.byHash, c.GenesisHash)
+	}
+}
+
+func CustomChainByName(name string) *CustomChain {
+	customChains.RLock()
+	defer customChains.RUnlock()
+	return customChains.byName[name]
+}
+
+func CustomChainByGenesisHash(hash common.Hash) *CustomChain {
+	customChains.RLock()
+	defer customChains.RUnlock()
+	return customChains.byHash[hash]
+}
+
+func CustomChainNames() []string {
+	customChains.RLock()
+	defer customChains.RUnlock()
+	names := make([]string, 0, len(customChains.byName))
+	for name := range customChains.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
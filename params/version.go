@@ -31,6 +31,7 @@ var (
 	GitCommit string
 	GitBranch string
 	GitTag    string
+	BuildDate string
 )
 
 const (
@@ -1776,6 +1776,10 @@ func (c *Bor) GetPostApplyMessageFunc() evmtypes.PostApplyMessageFunc {
 	return AddFeeTransferLog
 }
 
+func (c *Bor) GetL1CostFunc() evmtypes.L1CostFunc {
+	return nil
+}
+
 // In bor, RLP encoding of BlockExtraData will be stored in the Extra field in the header
 type BlockExtraData struct {
 	// Validator bytes of bor
@@ -44,6 +44,7 @@ type BorConfig struct {
 	NapoliBlock                *big.Int          `json:"napoliBlock"`                // Napoli switch block (nil = no fork, 0 = already on Napoli)
 	AhmedabadBlock             *big.Int          `json:"ahmedabadBlock"`             // Ahmedabad switch block (nil = no fork, 0 = already on Ahmedabad)
 	StateSyncConfirmationDelay map[string]uint64 `json:"stateSyncConfirmationDelay"` // StateSync Confirmation Delay, in seconds, to calculate `to`
+	SingleProducerBlock        *big.Int          `json:"singleProducerBlock"`        // Single producer per span switch block (nil = no fork), for testing forks only
 
 	sprints sprints
 }
@@ -168,6 +169,16 @@ func (c *BorConfig) GetAhmedabadBlock() *big.Int {
 	return c.AhmedabadBlock
 }
 
+// IsSingleProducer returns whether num is at or after the block from which a single
+// producer is selected for each span instead of rotating the proposer every sprint.
+func (c *BorConfig) IsSingleProducer(num uint64) bool {
+	return isForked(c.SingleProducerBlock, num)
+}
+
+func (c *BorConfig) GetSingleProducerBlock() *big.Int {
+	return c.SingleProducerBlock
+}
+
 func (c *BorConfig) CalculateStateSyncDelay(number uint64) uint64 {
 	return chain.ConfigValueLookup(c.StateSyncConfirmationDelay, number)
 }
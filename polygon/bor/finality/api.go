@@ -24,7 +24,20 @@ import (
 	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
 )
 
+// Sources of finalized block
+const (
+	SourceMilestone  = "milestone"
+	SourceCheckpoint = "checkpoint"
+)
+
 func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
+	number, _, _ := GetFinalizedBlock(tx)
+	return number
+}
+
+// GetFinalizedBlock - whitelisted milestone, or checkpoint if milestone is not in canonical chain yet. Returns 0 if
+// there is no such block.
+func GetFinalizedBlock(tx kv.Tx) (number uint64, hash common.Hash, source string) {
 	currentBlockNum := rawdb.ReadCurrentHeader(tx)
 
 	service := whitelist.GetWhitelistingService()
@@ -35,11 +48,11 @@ func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
 		blockHeader := rawdb.ReadHeaderByNumber(tx, number)
 
 		if blockHeader == nil {
-			return 0
+			return 0, common.Hash{}, ""
 		}
 
 		if blockHeader.Hash() == hash {
-			return number
+			return number, hash, SourceMilestone
 		}
 	}
 
@@ -49,15 +62,15 @@ func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
 		blockHeader := rawdb.ReadHeaderByNumber(tx, number)
 
 		if blockHeader == nil {
-			return 0
+			return 0, common.Hash{}, ""
 		}
 
 		if blockHeader.Hash() == hash {
-			return number
+			return number, hash, SourceCheckpoint
 		}
 	}
 
-	return 0
+	return 0, common.Hash{}, ""
 }
 
 // CurrentFinalizedBlock retrieves the current finalized block of the canonical
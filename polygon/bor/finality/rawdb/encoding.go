@@ -0,0 +1,254 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+// Values in kv.BorFinality are prefixed with a single version byte followed by the
+// RLP encoding of the versioned struct. Older nodes wrote plain JSON objects, which
+// always start with '{' and therefore never collide with a version byte.
+const (
+	encodingVersionV1 byte = 0x01
+
+	legacyJSONPrefix byte = '{'
+)
+
+var ErrUnknownEncodingVersion = errors.New("unknown bor finality encoding version")
+
+type finalityV1 struct {
+	Block uint64
+	Hash  common.Hash
+}
+
+type lockFieldV1 struct {
+	Val    bool
+	Block  uint64
+	Hash   common.Hash
+	IdList []string
+}
+
+type futureMilestoneFieldV1 struct {
+	Order  []uint64
+	Blocks []uint64
+	Hashes []common.Hash
+}
+
+func isLegacyJSON(data []byte) bool {
+	return len(data) > 0 && data[0] == legacyJSONPrefix
+}
+
+func encodeVersioned(val interface{}) ([]byte, error) {
+	enc, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{encodingVersionV1}, enc...), nil
+}
+
+func decodeVersioned(data []byte, val interface{}) error {
+	if len(data) == 0 {
+		return ErrEmptyLastFinality
+	}
+
+	switch data[0] {
+	case encodingVersionV1:
+		return rlp.DecodeBytes(data[1:], val)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownEncodingVersion, data[0])
+	}
+}
+
+func encodeFinality(block uint64, hash common.Hash) ([]byte, error) {
+	return encodeVersioned(&finalityV1{Block: block, Hash: hash})
+}
+
+func decodeFinality(data []byte) (uint64, common.Hash, error) {
+	if isLegacyJSON(data) {
+		var f Finality
+		if err := json.Unmarshal(data, &f); err != nil {
+			return 0, common.Hash{}, err
+		}
+
+		return f.Block, f.Hash, nil
+	}
+
+	var f finalityV1
+	if err := decodeVersioned(data, &f); err != nil {
+		return 0, common.Hash{}, err
+	}
+
+	return f.Block, f.Hash, nil
+}
+
+func encodeLockField(lockField LockField) ([]byte, error) {
+	ids := make([]string, 0, len(lockField.IdList))
+	for id := range lockField.IdList {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return encodeVersioned(&lockFieldV1{
+		Val:    lockField.Val,
+		Block:  lockField.Block,
+		Hash:   lockField.Hash,
+		IdList: ids,
+	})
+}
+
+func decodeLockField(data []byte) (LockField, error) {
+	if isLegacyJSON(data) {
+		var lockField LockField
+		err := json.Unmarshal(data, &lockField)
+		return lockField, err
+	}
+
+	var v1 lockFieldV1
+	if err := decodeVersioned(data, &v1); err != nil {
+		return LockField{}, err
+	}
+
+	idList := make(map[string]struct{}, len(v1.IdList))
+	for _, id := range v1.IdList {
+		idList[id] = struct{}{}
+	}
+
+	return LockField{
+		Val:    v1.Val,
+		Block:  v1.Block,
+		Hash:   v1.Hash,
+		IdList: idList,
+	}, nil
+}
+
+func encodeFutureMilestoneField(field FutureMilestoneField) ([]byte, error) {
+	blocks := make([]uint64, 0, len(field.List))
+	for block := range field.List {
+		blocks = append(blocks, block)
+	}
+	slices.Sort(blocks)
+
+	hashes := make([]common.Hash, len(blocks))
+	for i, block := range blocks {
+		hashes[i] = field.List[block]
+	}
+
+	return encodeVersioned(&futureMilestoneFieldV1{
+		Order:  field.Order,
+		Blocks: blocks,
+		Hashes: hashes,
+	})
+}
+
+func decodeFutureMilestoneField(data []byte) (FutureMilestoneField, error) {
+	if isLegacyJSON(data) {
+		var field FutureMilestoneField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	}
+
+	var v1 futureMilestoneFieldV1
+	if err := decodeVersioned(data, &v1); err != nil {
+		return FutureMilestoneField{}, err
+	}
+
+	if len(v1.Blocks) != len(v1.Hashes) {
+		return FutureMilestoneField{}, fmt.Errorf("future milestone field: %d blocks but %d hashes", len(v1.Blocks), len(v1.Hashes))
+	}
+
+	list := make(map[uint64]common.Hash, len(v1.Blocks))
+	for i, block := range v1.Blocks {
+		list[block] = v1.Hashes[i]
+	}
+
+	order := v1.Order
+	if order == nil {
+		order = make([]uint64, 0)
+	}
+
+	return FutureMilestoneField{Order: order, List: list}, nil
+}
+
+// MigrateLegacyEncoding re-encodes all JSON values stored in kv.BorFinality by older
+// versions of erigon into the current versioned encoding. Values which are already
+// in the versioned encoding are left untouched, so it is safe to call repeatedly.
+func MigrateLegacyEncoding(tx kv.RwTx) (migrated int, err error) {
+	type reencoder func(data []byte) ([]byte, error)
+
+	reencodeFinality := func(data []byte) ([]byte, error) {
+		block, hash, err := decodeFinality(data)
+		if err != nil {
+			return nil, err
+		}
+		return encodeFinality(block, hash)
+	}
+
+	keys := []struct {
+		key       []byte
+		reencoder reencoder
+	}{
+		{key: lastCheckpoint, reencoder: reencodeFinality},
+		{key: lastMilestone, reencoder: reencodeFinality},
+		{key: lockFieldKey, reencoder: func(data []byte) ([]byte, error) {
+			lockField, err := decodeLockField(data)
+			if err != nil {
+				return nil, err
+			}
+			return encodeLockField(lockField)
+		}},
+		{key: futureMilestoneKey, reencoder: func(data []byte) ([]byte, error) {
+			field, err := decodeFutureMilestoneField(data)
+			if err != nil {
+				return nil, err
+			}
+			return encodeFutureMilestoneField(field)
+		}},
+	}
+
+	for _, k := range keys {
+		data, err := tx.GetOne(kv.BorFinality, k.key)
+		if err != nil {
+			return migrated, err
+		}
+
+		if !isLegacyJSON(data) {
+			continue
+		}
+
+		enc, err := k.reencoder(data)
+		if err != nil {
+			return migrated, fmt.Errorf("re-encoding %s: %w", string(k.key), err)
+		}
+
+		if err = tx.Put(kv.BorFinality, k.key, enc); err != nil {
+			return migrated, err
+		}
+
+		migrated++
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+)
+
+func TestLockFieldRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewTestDB(t, kv.ChainDB)
+	hash := common.HexToHash("0x1234")
+	ids := map[string]struct{}{"b": {}, "a": {}}
+
+	require.NoError(t, WriteLockField(db, true, 10, hash, ids))
+
+	val, block, readHash, readIds, err := ReadLockField(db)
+	require.NoError(t, err)
+	require.True(t, val)
+	require.Equal(t, uint64(10), block)
+	require.Equal(t, hash, readHash)
+	require.Equal(t, ids, readIds)
+}
+
+func TestFutureMilestoneListRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewTestDB(t, kv.ChainDB)
+	order := []uint64{20, 10}
+	list := map[uint64]common.Hash{20: common.HexToHash("0x20"), 10: common.HexToHash("0x10")}
+
+	require.NoError(t, WriteFutureMilestoneList(db, order, list))
+
+	readOrder, readList, err := ReadFutureMilestoneList(db)
+	require.NoError(t, err)
+	require.Equal(t, order, readOrder)
+	require.Equal(t, list, readList)
+}
+
+func TestMigrateLegacyEncoding(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewTestDB(t, kv.ChainDB)
+	hash := common.HexToHash("0xabcd")
+
+	legacy := map[string]interface{}{
+		string(lastMilestone):      &Milestone{Finality{Block: 5, Hash: hash}},
+		string(lastCheckpoint):     &Checkpoint{Finality{Block: 4, Hash: hash}},
+		string(lockFieldKey):       LockField{Val: true, Block: 6, Hash: hash, IdList: map[string]struct{}{"id": {}}},
+		string(futureMilestoneKey): FutureMilestoneField{Order: []uint64{7}, List: map[uint64]common.Hash{7: hash}},
+	}
+
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		for key, val := range legacy {
+			enc, err := json.Marshal(val)
+			if err != nil {
+				return err
+			}
+			if err = tx.Put(kv.BorFinality, []byte(key), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	// legacy values are still readable before the migration runs
+	block, readHash, err := ReadFinality[*Milestone](db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), block)
+	require.Equal(t, hash, readHash)
+
+	var migrated int
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		migrated, err = MigrateLegacyEncoding(tx)
+		return err
+	}))
+	require.Equal(t, len(legacy), migrated)
+
+	require.NoError(t, db.View(context.Background(), func(tx kv.Tx) error {
+		for key := range legacy {
+			data, err := tx.GetOne(kv.BorFinality, []byte(key))
+			require.NoError(t, err)
+			require.Equal(t, encodingVersionV1, data[0])
+		}
+		return nil
+	}))
+
+	block, readHash, err = ReadFinality[*Checkpoint](db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), block)
+	require.Equal(t, hash, readHash)
+
+	val, block, readHash, ids, err := ReadLockField(db)
+	require.NoError(t, err)
+	require.True(t, val)
+	require.Equal(t, uint64(6), block)
+	require.Equal(t, hash, readHash)
+	require.Equal(t, map[string]struct{}{"id": {}}, ids)
+
+	order, list, err := ReadFutureMilestoneList(db)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7}, order)
+	require.Equal(t, map[uint64]common.Hash{7: hash}, list)
+
+	// a second run is a no-op
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		migrated, err = MigrateLegacyEncoding(tx)
+		return err
+	}))
+	require.Zero(t, migrated)
+}
@@ -18,7 +18,6 @@ package rawdb
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/erigontech/erigon-lib/common"
@@ -86,14 +85,15 @@ func ReadFinality[T BlockFinality[T]](db kv.RwDB) (uint64, common.Hash, error) {
 		return 0, common.Hash{}, fmt.Errorf("%w for %s", ErrEmptyLastFinality, string(key))
 	}
 
-	if err = json.Unmarshal(data, lastTV); err != nil {
-		log.Error(fmt.Sprintf("Unable to unmarshal the last %s block number in database", string(key)), "err", err)
+	block, hash, err := decodeFinality(data)
+	if err != nil {
+		log.Error(fmt.Sprintf("Unable to decode the last %s block number in database", string(key)), "err", err)
 
 		return 0, common.Hash{}, fmt.Errorf("%w(%v) for %s, data %v(%q)",
 			ErrIncorrectFinality, err, string(key), data, string(data))
 	}
 
-	block, hash := lastTV.block()
+	lastTV.set(block, hash)
 
 	return block, hash, nil
 }
@@ -103,9 +103,9 @@ func WriteLastFinality[T BlockFinality[T]](db kv.RwDB, block uint64, hash common
 
 	lastTV.set(block, hash)
 
-	enc, err := json.Marshal(lastTV)
+	enc, err := encodeFinality(block, hash)
 	if err != nil {
-		log.Error(fmt.Sprintf("Failed to marshal the %s struct", string(key)), "err", err)
+		log.Error(fmt.Sprintf("Failed to encode the %s struct", string(key)), "err", err)
 
 		return fmt.Errorf("%w: %v for %s struct", ErrIncorrectFinalityToStore, err, string(key))
 	}
@@ -155,9 +155,9 @@ func WriteLockField(db kv.RwDB, val bool, block uint64, hash common.Hash, idList
 
 	key := lockFieldKey
 
-	enc, err := json.Marshal(lockField)
+	enc, err := encodeLockField(lockField)
 	if err != nil {
-		log.Error("Failed to marshal the lock field struct", "err", err)
+		log.Error("Failed to encode the lock field struct", "err", err)
 
 		return fmt.Errorf("%w: %v for lock field struct", ErrIncorrectLockFieldToStore, err)
 	}
@@ -177,7 +177,6 @@ func WriteLockField(db kv.RwDB, val bool, block uint64, hash common.Hash, idList
 
 func ReadLockField(db kv.RwDB) (bool, uint64, common.Hash, map[string]struct{}, error) {
 	key := lockFieldKey
-	lockField := LockField{}
 
 	var data []byte
 	err := db.View(context.Background(), func(tx kv.Tx) error {
@@ -194,8 +193,9 @@ func ReadLockField(db kv.RwDB) (bool, uint64, common.Hash, map[string]struct{},
 		return false, 0, common.Hash{}, nil, fmt.Errorf("%w for %s", ErrIncorrectLockField, string(key))
 	}
 
-	if err = json.Unmarshal(data, &lockField); err != nil {
-		log.Error("Unable to unmarshal the lock field in database", "err", err)
+	lockField, err := decodeLockField(data)
+	if err != nil {
+		log.Error("Unable to decode the lock field in database", "err", err)
 
 		return false, 0, common.Hash{}, nil, fmt.Errorf("%w(%v) for lock field , data %v(%q)",
 			ErrIncorrectLockField, err, data, string(data))
@@ -214,9 +214,9 @@ func WriteFutureMilestoneList(db kv.RwDB, order []uint64, list map[uint64]common
 
 	key := futureMilestoneKey
 
-	enc, err := json.Marshal(futureMilestoneField)
+	enc, err := encodeFutureMilestoneField(futureMilestoneField)
 	if err != nil {
-		log.Error("Failed to marshal the future milestone field struct", "err", err)
+		log.Error("Failed to encode the future milestone field struct", "err", err)
 
 		return fmt.Errorf("%w: %v for future milestone field struct", ErrIncorrectFutureMilestoneFieldToStore, err)
 	}
@@ -236,7 +236,6 @@ func WriteFutureMilestoneList(db kv.RwDB, order []uint64, list map[uint64]common
 
 func ReadFutureMilestoneList(db kv.RwDB) ([]uint64, map[uint64]common.Hash, error) {
 	key := futureMilestoneKey
-	futureMilestoneField := FutureMilestoneField{}
 
 	var data []byte
 	err := db.View(context.Background(), func(tx kv.Tx) error {
@@ -253,8 +252,9 @@ func ReadFutureMilestoneList(db kv.RwDB) ([]uint64, map[uint64]common.Hash, erro
 		return nil, nil, fmt.Errorf("%w for %s", ErrIncorrectLockField, string(key))
 	}
 
-	if err = json.Unmarshal(data, &futureMilestoneField); err != nil {
-		log.Error("Unable to unmarshal the future milestone field in database", "err", err)
+	futureMilestoneField, err := decodeFutureMilestoneField(data)
+	if err != nil {
+		log.Error("Unable to decode the future milestone field in database", "err", err)
 
 		return nil, nil, fmt.Errorf("%w(%v) for future milestone field, data %v(%q)",
 			ErrIncorrectFutureMilestoneField, err, data, string(data))
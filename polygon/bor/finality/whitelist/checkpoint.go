@@ -41,9 +41,6 @@ var (
 // IsValidChain checks the validity of chain by comparing it
 // against the local checkpoint entry
 func (w *checkpoint) IsValidChain(currentHeader uint64, chain []*types.Header) bool {
-	w.finality.RLock()
-	defer w.finality.RUnlock()
-
 	res := w.finality.IsValidChain(currentHeader, chain)
 
 	if res {
@@ -56,9 +53,6 @@ func (w *checkpoint) IsValidChain(currentHeader uint64, chain []*types.Header) b
 }
 
 func (w *checkpoint) Process(block uint64, hash common.Hash) {
-	w.finality.Lock()
-	defer w.finality.Unlock()
-
 	w.finality.Process(block, hash)
 
 	whitelistedCheckpointNumberMeter.SetUint64(block)
@@ -18,6 +18,7 @@ package whitelist
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
@@ -26,13 +27,19 @@ import (
 	"github.com/erigontech/erigon/polygon/bor/finality/rawdb"
 )
 
+// finalityHead is an immutable snapshot of the whitelisted entry. It is replaced
+// as a whole on every update so that readers never need to take a lock.
+type finalityHead struct {
+	doExist bool
+	Number  uint64      // Number , populated by reaching out to heimdall
+	Hash    common.Hash // Whitelisted Hash, populated by reaching out to heimdall
+}
+
 type finality[T rawdb.BlockFinality[T]] struct {
-	sync.RWMutex
+	mu       sync.Mutex // serialises writers, readers go through head
 	db       kv.RwDB
-	Hash     common.Hash // Whitelisted Hash, populated by reaching out to heimdall
-	Number   uint64      // Number , populated by reaching out to heimdall
-	interval uint64      // Interval, until which we can allow importing
-	doExist  bool
+	interval uint64 // Interval, until which we can allow importing
+	head     atomic.Pointer[finalityHead]
 }
 
 type finalityService interface {
@@ -42,6 +49,16 @@ type finalityService interface {
 	Purge()
 }
 
+// current returns the current whitelisted entry, it is safe to call concurrently
+// with writers.
+func (f *finality[T]) current() *finalityHead {
+	if head := f.head.Load(); head != nil {
+		return head
+	}
+
+	return &finalityHead{}
+}
+
 // IsValidChain checks the validity of chain by comparing it
 // against the local checkpoint entry
 func (f *finality[T]) IsValidChain(currentHeader uint64, chain []*types.Header) bool {
@@ -50,15 +67,16 @@ func (f *finality[T]) IsValidChain(currentHeader uint64, chain []*types.Header)
 		return false
 	}
 
-	res := isValidChain(currentHeader, chain, f.doExist, f.Number, f.Hash, f.interval)
+	head := f.current()
 
-	return res
+	return isValidChain(currentHeader, chain, head.doExist, head.Number, head.Hash, f.interval)
 }
 
 func (f *finality[T]) Process(block uint64, hash common.Hash) {
-	f.doExist = true
-	f.Hash = hash
-	f.Number = block
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.head.Store(&finalityHead{doExist: true, Number: block, Hash: hash})
 
 	err := rawdb.WriteLastFinality[T](f.db, block, hash)
 
@@ -70,16 +88,15 @@ func (f *finality[T]) Process(block uint64, hash common.Hash) {
 // Get returns the existing whitelisted
 // entries of checkpoint of the form (doExist,block number,block hash.)
 func (f *finality[T]) Get() (bool, uint64, common.Hash) {
-	f.RLock()
-	defer f.RUnlock()
+	head := f.current()
 
-	if f.doExist {
-		return f.doExist, f.Number, f.Hash
+	if head.doExist {
+		return head.doExist, head.Number, head.Hash
 	}
 
 	block, hash, err := rawdb.ReadFinality[T](f.db)
 	if err != nil {
-		return false, f.Number, f.Hash
+		return false, head.Number, head.Hash
 	}
 
 	return true, block, hash
@@ -87,8 +104,10 @@ func (f *finality[T]) Get() (bool, uint64, common.Hash) {
 
 // Purge purges the whitlisted checkpoint
 func (f *finality[T]) Purge() {
-	f.Lock()
-	defer f.Unlock()
+	f.mu.Lock()
+	defer f.mu.Unlock()
 
-	f.doExist = false
+	head := *f.current()
+	head.doExist = false
+	f.head.Store(&head)
 }
@@ -17,6 +17,11 @@
 package whitelist
 
 import (
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/metrics"
@@ -25,17 +30,34 @@ import (
 	"github.com/erigontech/erigon/polygon/bor/finality/rawdb"
 )
 
-type milestone struct {
-	finality[*rawdb.Milestone]
-
+// milestoneLock is an immutable snapshot of the sprint lock taken while voting on milestones.
+type milestoneLock struct {
+	Locked                bool                //
 	LockedMilestoneNumber uint64              // Locked sprint number
 	LockedMilestoneHash   common.Hash         //Hash for the locked endBlock
-	Locked                bool                //
 	LockedMilestoneIDs    map[string]struct{} //list of milestone ids
+}
 
+// futureMilestones is an immutable snapshot of the future milestone queue.
+type futureMilestones struct {
 	FutureMilestoneList  map[uint64]common.Hash // Future Milestone list
 	FutureMilestoneOrder []uint64               // Future Milestone Order
-	MaxCapacity          int                    //Capacity of future Milestone list
+}
+
+// milestone keeps the whitelisted milestone, the sprint lock and the future milestone
+// queue in separate shards. Each shard is published through an atomic pointer to an
+// immutable snapshot, so chain validation never blocks, and has its own writer mutex,
+// so a vote holding the sprint lock does not stall whitelisting or future milestones.
+type milestone struct {
+	finality[*rawdb.Milestone]
+
+	lockMu sync.Mutex // held by voting between LockMutex and UnlockMutex
+	lock   atomic.Pointer[milestoneLock]
+
+	futureMu sync.Mutex
+	future   atomic.Pointer[futureMilestones]
+
+	MaxCapacity int //Capacity of future Milestone list
 }
 
 type milestoneService interface {
@@ -63,6 +85,24 @@ var (
 	milestoneChainMeter = metrics.GetOrCreateGauge("chain_milestone_isvalidchain")
 )
 
+// lockState returns the current sprint lock snapshot.
+func (m *milestone) lockState() *milestoneLock {
+	if lock := m.lock.Load(); lock != nil {
+		return lock
+	}
+
+	return &milestoneLock{LockedMilestoneIDs: map[string]struct{}{}}
+}
+
+// futureState returns the current future milestone queue snapshot.
+func (m *milestone) futureState() *futureMilestones {
+	if future := m.future.Load(); future != nil {
+		return future
+	}
+
+	return &futureMilestones{FutureMilestoneList: map[uint64]common.Hash{}, FutureMilestoneOrder: []uint64{}}
+}
+
 // IsValidChain checks the validity of chain by comparing it
 // against the local milestone entries
 func (m *milestone) IsValidChain(currentHeader uint64, chain []*types.Header) bool {
@@ -71,9 +111,6 @@ func (m *milestone) IsValidChain(currentHeader uint64, chain []*types.Header) bo
 		return true
 	}
 
-	m.finality.RLock()
-	defer m.finality.RUnlock()
-
 	var isValid = false
 	defer func() {
 		if isValid {
@@ -90,7 +127,8 @@ func (m *milestone) IsValidChain(currentHeader uint64, chain []*types.Header) bo
 		return false
 	}
 
-	if m.Locked && !m.IsReorgAllowed(chain, m.LockedMilestoneNumber, m.LockedMilestoneHash) {
+	lock := m.lockState()
+	if lock.Locked && !m.IsReorgAllowed(chain, lock.LockedMilestoneNumber, lock.LockedMilestoneHash) {
 		isValid = false
 		return false
 	}
@@ -105,35 +143,38 @@ func (m *milestone) IsValidChain(currentHeader uint64, chain []*types.Header) bo
 }
 
 func (m *milestone) Process(block uint64, hash common.Hash) {
-	m.finality.Lock()
-	defer m.finality.Unlock()
-
 	m.finality.Process(block, hash)
 
-	for i := 0; i < len(m.FutureMilestoneOrder); i++ {
-		if m.FutureMilestoneOrder[i] <= block {
-			m.dequeueFutureMilestone()
-		} else {
-			break
-		}
+	m.futureMu.Lock()
+	future := m.futureState()
+	dequeued := 0
+	for dequeued < len(future.FutureMilestoneOrder) && future.FutureMilestoneOrder[dequeued] <= block {
+		dequeued++
+	}
+	if dequeued > 0 {
+		m.dequeueFutureMilestones(future, dequeued)
 	}
+	m.futureMu.Unlock()
 
 	whitelistedMilestoneMeter.SetUint64(block)
 
+	m.lockMu.Lock()
+	defer m.lockMu.Unlock()
+
 	m.unlockSprint(block)
 }
 
 // LockMutex This function will Lock the mutex at the time of voting
 func (m *milestone) LockMutex(endBlockNum uint64) bool {
-	m.finality.Lock()
+	m.lockMu.Lock()
 
-	if m.doExist && endBlockNum <= m.Number { //if endNum is less than whitelisted milestone, then we won't lock the sprint
-		log.Debug("[bor] endBlockNumber is less than or equal to latesMilestoneNumber", "endBlock Number", endBlockNum, "LatestMilestone Number", m.Number)
+	if head := m.current(); head.doExist && endBlockNum <= head.Number { //if endNum is less than whitelisted milestone, then we won't lock the sprint
+		log.Debug("[bor] endBlockNumber is less than or equal to latesMilestoneNumber", "endBlock Number", endBlockNum, "LatestMilestone Number", head.Number)
 		return false
 	}
 
-	if m.Locked && endBlockNum < m.LockedMilestoneNumber {
-		log.Debug("[bor] endBlockNum is less than locked milestone number", "endBlock Number", endBlockNum, "Locked Milestone Number", m.LockedMilestoneNumber)
+	if lock := m.lockState(); lock.Locked && endBlockNum < lock.LockedMilestoneNumber {
+		log.Debug("[bor] endBlockNum is less than locked milestone number", "endBlock Number", endBlockNum, "Locked Milestone Number", lock.LockedMilestoneNumber)
 		return false
 	}
 
@@ -142,50 +183,55 @@ func (m *milestone) LockMutex(endBlockNum uint64) bool {
 
 // UnlockMutex This function will unlock the mutex locked in LockMutex
 func (m *milestone) UnlockMutex(doLock bool, milestoneId string, endBlockNum uint64, endBlockHash common.Hash) {
-	m.Locked = m.Locked || doLock
+	defer m.lockMu.Unlock()
 
-	if doLock {
-		m.unlockSprint(m.LockedMilestoneNumber)
-		m.Locked = true
-		m.LockedMilestoneHash = endBlockHash
-		m.LockedMilestoneNumber = endBlockNum
-		m.LockedMilestoneIDs[milestoneId] = struct{}{}
-	}
+	lock := *m.lockState()
+	lock.Locked = lock.Locked || doLock
 
-	err := rawdb.WriteLockField(m.db, m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, m.LockedMilestoneIDs)
-	if err != nil {
-		log.Error("Error in writing lock data of milestone to db", "err", err)
+	if doLock {
+		m.unlockSprint(lock.LockedMilestoneNumber)
+		lock.LockedMilestoneIDs = maps.Clone(m.lockState().LockedMilestoneIDs)
+		lock.Locked = true
+		lock.LockedMilestoneHash = endBlockHash
+		lock.LockedMilestoneNumber = endBlockNum
+		lock.LockedMilestoneIDs[milestoneId] = struct{}{}
 	}
 
-	milestoneIdsLengthMeter.SetInt(len(m.LockedMilestoneIDs))
+	m.storeLock(&lock, lock.LockedMilestoneIDs)
 
-	m.finality.Unlock()
+	milestoneIdsLengthMeter.SetInt(len(lock.LockedMilestoneIDs))
 }
 
 // UnlockSprint This function will unlock the locked sprint
 func (m *milestone) UnlockSprint(endBlockNum uint64) {
-	if endBlockNum < m.LockedMilestoneNumber {
+	if endBlockNum < m.lockState().LockedMilestoneNumber {
 		return
 	}
 
-	m.finality.Lock()
-	defer m.finality.Unlock()
+	m.lockMu.Lock()
+	defer m.lockMu.Unlock()
 
 	m.unlockSprint(endBlockNum)
 }
 
-// UnlockSprint This function will unlock the locked sprint
+// UnlockSprint This function will unlock the locked sprint, lockMu must be held
 func (m *milestone) unlockSprint(endBlockNum uint64) {
-	if endBlockNum < m.LockedMilestoneNumber {
+	lock := *m.lockState()
+	if endBlockNum < lock.LockedMilestoneNumber {
 		return
 	}
 
-	m.Locked = false
+	lock.Locked = false
+	lock.LockedMilestoneIDs = map[string]struct{}{}
+
+	m.storeLock(&lock, lock.LockedMilestoneIDs)
+}
 
-	m.purgeMilestoneIDsList()
-	purgedMilestoneIDs := map[string]struct{}{}
-	err := rawdb.WriteLockField(m.db, m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, purgedMilestoneIDs)
+// storeLock publishes the new lock snapshot and persists it, lockMu must be held
+func (m *milestone) storeLock(lock *milestoneLock, persistedIDs map[string]struct{}) {
+	m.lock.Store(lock)
 
+	err := rawdb.WriteLockField(m.db, lock.Locked, lock.LockedMilestoneNumber, lock.LockedMilestoneHash, persistedIDs)
 	if err != nil {
 		log.Error("[bor] Error in writing lock data of milestone to db", "err", err)
 	}
@@ -193,21 +239,18 @@ func (m *milestone) unlockSprint(endBlockNum uint64) {
 
 // RemoveMilestoneID This function will remove the stored milestoneID
 func (m *milestone) RemoveMilestoneID(milestoneId string) {
-	m.finality.Lock()
-	defer m.finality.Unlock()
+	m.lockMu.Lock()
+	defer m.lockMu.Unlock()
 
-	delete(m.LockedMilestoneIDs, milestoneId)
+	lock := *m.lockState()
+	lock.LockedMilestoneIDs = maps.Clone(lock.LockedMilestoneIDs)
+	delete(lock.LockedMilestoneIDs, milestoneId)
 
-	if len(m.LockedMilestoneIDs) == 0 {
-		m.Locked = false
-	}
-
-	err := rawdb.WriteLockField(m.db, m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, m.LockedMilestoneIDs)
-
-	if err != nil {
-		log.Error("[bor] Error in writing lock data of milestone to db", "err", err)
+	if len(lock.LockedMilestoneIDs) == 0 {
+		lock.Locked = false
 	}
 
+	m.storeLock(&lock, lock.LockedMilestoneIDs)
 }
 
 // IsReorgAllowed This will check whether the incoming chain matches the locked sprint hash
@@ -227,36 +270,24 @@ func (m *milestone) IsReorgAllowed(chain []*types.Header, lockedMilestoneNumber
 
 // GetMilestoneIDsList This will return the list of milestoneIDs stored.
 func (m *milestone) GetMilestoneIDsList() []string {
-	m.finality.RLock()
-	defer m.finality.RUnlock()
-
-	// fixme: use generics :)
-	keys := make([]string, 0, len(m.LockedMilestoneIDs))
-	for key := range m.LockedMilestoneIDs {
-		keys = append(keys, key)
-	}
-
-	return keys
-}
-
-// This is remove the milestoneIDs stored in the list.
-func (m *milestone) purgeMilestoneIDsList() {
-	m.LockedMilestoneIDs = make(map[string]struct{})
+	return slices.Collect(maps.Keys(m.lockState().LockedMilestoneIDs))
 }
 
 func (m *milestone) IsFutureMilestoneCompatible(chain []*types.Header) bool {
 	//Tip of the received chain
 	chainTipNumber := chain[len(chain)-1].Number.Uint64()
 
-	for i := len(m.FutureMilestoneOrder) - 1; i >= 0; i-- {
+	future := m.futureState()
+
+	for i := len(future.FutureMilestoneOrder) - 1; i >= 0; i-- {
 		//Finding out the highest future milestone number
 		//which is less or equal to received chain tip
-		if chainTipNumber >= m.FutureMilestoneOrder[i] {
+		if chainTipNumber >= future.FutureMilestoneOrder[i] {
 			//Looking for the received chain 's particular block number(matching future milestone number)
 			for j := len(chain) - 1; j >= 0; j-- {
-				if chain[j].Number.Uint64() == m.FutureMilestoneOrder[i] {
-					endBlockNum := m.FutureMilestoneOrder[i]
-					endBlockHash := m.FutureMilestoneList[endBlockNum]
+				if chain[j].Number.Uint64() == future.FutureMilestoneOrder[i] {
+					endBlockNum := future.FutureMilestoneOrder[i]
+					endBlockHash := future.FutureMilestoneList[endBlockNum]
 
 					//Checking the received chain matches with future milestone
 					return chain[j].Hash() == endBlockHash
@@ -269,53 +300,65 @@ func (m *milestone) IsFutureMilestoneCompatible(chain []*types.Header) bool {
 }
 
 func (m *milestone) ProcessFutureMilestone(num uint64, hash common.Hash) {
-	m.finality.Lock()
-	defer m.finality.Unlock()
-
-	if len(m.FutureMilestoneOrder) < m.MaxCapacity {
-		m.enqueueFutureMilestone(num, hash)
+	m.futureMu.Lock()
+	if future := m.futureState(); len(future.FutureMilestoneOrder) < m.MaxCapacity {
+		m.enqueueFutureMilestone(future, num, hash)
 	}
+	m.futureMu.Unlock()
 
-	if num < m.LockedMilestoneNumber {
+	m.lockMu.Lock()
+	defer m.lockMu.Unlock()
+
+	lock := *m.lockState()
+	if num < lock.LockedMilestoneNumber {
 		return
 	}
 
-	m.Locked = false
-	m.purgeMilestoneIDsList()
-	purgedMilestoneIDs := map[string]struct{}{}
-	err := rawdb.WriteLockField(m.db, m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, purgedMilestoneIDs)
+	lock.Locked = false
+	lock.LockedMilestoneIDs = map[string]struct{}{}
 
-	if err != nil {
-		log.Error("[bor] Error in writing lock data of milestone to db", "err", err)
-	}
+	m.storeLock(&lock, lock.LockedMilestoneIDs)
 }
 
-// EnqueueFutureMilestone add the future milestone to the list
-func (m *milestone) enqueueFutureMilestone(key uint64, hash common.Hash) {
-	if _, ok := m.FutureMilestoneList[key]; ok {
+// EnqueueFutureMilestone add the future milestone to the list, futureMu must be held
+func (m *milestone) enqueueFutureMilestone(future *futureMilestones, key uint64, hash common.Hash) {
+	if _, ok := future.FutureMilestoneList[key]; ok {
 		log.Debug("[bor] Future milestone already exist", "endBlockNumber", key, "futureMilestoneHash", hash)
 		return
 	}
 
 	log.Debug("[bor] Enqueing new future milestone", "endBlockNumber", key, "futureMilestoneHash", hash)
 
-	m.FutureMilestoneList[key] = hash
-	m.FutureMilestoneOrder = append(m.FutureMilestoneOrder, key)
-
-	err := rawdb.WriteFutureMilestoneList(m.db, m.FutureMilestoneOrder, m.FutureMilestoneList)
-	if err != nil {
-		log.Error("[bor] Error in writing future milestone data to db", "err", err)
+	next := &futureMilestones{
+		FutureMilestoneList:  maps.Clone(future.FutureMilestoneList),
+		FutureMilestoneOrder: append(slices.Clone(future.FutureMilestoneOrder), key),
 	}
+	next.FutureMilestoneList[key] = hash
+
+	m.storeFuture(next)
 
 	futureMilestoneMeter.SetUint64(key)
 }
 
-// DequeueFutureMilestone remove the future milestone entry from the list.
-func (m *milestone) dequeueFutureMilestone() {
-	delete(m.FutureMilestoneList, m.FutureMilestoneOrder[0])
-	m.FutureMilestoneOrder = m.FutureMilestoneOrder[1:]
+// dequeueFutureMilestones removes the first count future milestone entries from the list,
+// futureMu must be held
+func (m *milestone) dequeueFutureMilestones(future *futureMilestones, count int) {
+	next := &futureMilestones{
+		FutureMilestoneList:  maps.Clone(future.FutureMilestoneList),
+		FutureMilestoneOrder: slices.Clone(future.FutureMilestoneOrder[count:]),
+	}
+	for _, key := range future.FutureMilestoneOrder[:count] {
+		delete(next.FutureMilestoneList, key)
+	}
+
+	m.storeFuture(next)
+}
+
+// storeFuture publishes the new future milestone snapshot and persists it, futureMu must be held
+func (m *milestone) storeFuture(future *futureMilestones) {
+	m.future.Store(future)
 
-	err := rawdb.WriteFutureMilestoneList(m.db, m.FutureMilestoneOrder, m.FutureMilestoneList)
+	err := rawdb.WriteFutureMilestoneList(m.db, future.FutureMilestoneOrder, future.FutureMilestoneList)
 	if err != nil {
 		log.Error("[bor] Error in writing future milestone data to db", "err", err)
 	}
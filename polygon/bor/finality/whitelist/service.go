@@ -72,35 +72,34 @@ func NewService(db kv.RwDB) *Service {
 		list = make(map[uint64]common.Hash)
 	}
 
-	return &Service{
-		&checkpoint{
-			finality[*rawdb.Checkpoint]{
-				doExist:  checkpointDoExist,
-				Number:   checkpointNumber,
-				Hash:     checkpointHash,
-				interval: 256,
-				db:       db,
-			},
+	checkpoint := &checkpoint{
+		finality[*rawdb.Checkpoint]{
+			interval: 256,
+			db:       db,
 		},
+	}
+	checkpoint.head.Store(&finalityHead{doExist: checkpointDoExist, Number: checkpointNumber, Hash: checkpointHash})
 
-		&milestone{
-			finality: finality[*rawdb.Milestone]{
-				doExist:  milestoneDoExist,
-				Number:   milestoneNumber,
-				Hash:     milestoneHash,
-				interval: 256,
-				db:       db,
-			},
-
-			Locked:                locked,
-			LockedMilestoneNumber: lockedMilestoneNumber,
-			LockedMilestoneHash:   lockedMilestoneHash,
-			LockedMilestoneIDs:    lockedMilestoneIDs,
-			FutureMilestoneList:   list,
-			FutureMilestoneOrder:  order,
-			MaxCapacity:           10,
+	milestone := &milestone{
+		finality: finality[*rawdb.Milestone]{
+			interval: 256,
+			db:       db,
 		},
+		MaxCapacity: 10,
 	}
+	milestone.head.Store(&finalityHead{doExist: milestoneDoExist, Number: milestoneNumber, Hash: milestoneHash})
+	milestone.lock.Store(&milestoneLock{
+		Locked:                locked,
+		LockedMilestoneNumber: lockedMilestoneNumber,
+		LockedMilestoneHash:   lockedMilestoneHash,
+		LockedMilestoneIDs:    lockedMilestoneIDs,
+	})
+	milestone.future.Store(&futureMilestones{
+		FutureMilestoneList:  list,
+		FutureMilestoneOrder: order,
+	})
+
+	return &Service{checkpoint, milestone}
 }
 
 func (s *Service) PurgeWhitelistedCheckpoint() error {
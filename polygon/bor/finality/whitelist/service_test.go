@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"reflect"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -40,7 +41,6 @@ func NewMockService(db kv.RwDB) *Service {
 
 		&checkpoint{
 			finality[*rawdb.Checkpoint]{
-				doExist:  false,
 				interval: 256,
 				db:       db,
 			},
@@ -48,14 +48,10 @@ func NewMockService(db kv.RwDB) *Service {
 
 		&milestone{
 			finality: finality[*rawdb.Milestone]{
-				doExist:  false,
 				interval: 256,
 				db:       db,
 			},
-			LockedMilestoneIDs:   make(map[string]struct{}),
-			FutureMilestoneList:  make(map[uint64]common.Hash),
-			FutureMilestoneOrder: make([]uint64, 0),
-			MaxCapacity:          10,
+			MaxCapacity: 10,
 		},
 	}
 }
@@ -71,19 +67,19 @@ func TestWhitelistedCheckpoint(t *testing.T) {
 
 	cp := s.checkpointService.(*checkpoint)
 
-	require.False(t, cp.doExist, "expected false as no cp exist at this point")
+	require.False(t, cp.current().doExist, "expected false as no cp exist at this point")
 
 	_, _, err := rawdb.ReadFinality[*rawdb.Checkpoint](db)
 	require.Error(t, err, "Error should be nil while reading from the db")
 	//Adding the checkpoint
 	s.ProcessCheckpoint(11, common.Hash{})
 
-	require.True(t, cp.doExist, "expected true as cp exist")
+	require.True(t, cp.current().doExist, "expected true as cp exist")
 
 	//Removing the checkpoint
 	s.PurgeWhitelistedCheckpoint()
 
-	require.False(t, cp.doExist, "expected false as no cp exist at this point")
+	require.False(t, cp.current().doExist, "expected false as no cp exist at this point")
 
 	//Adding the checkpoint
 	s.ProcessCheckpoint(12, common.Hash{1})
@@ -122,62 +118,62 @@ func TestMilestone(t *testing.T) {
 	milestone := s.milestoneService.(*milestone)
 
 	//Checking for the variables when no milestone is Processed
-	require.False(t, milestone.doExist, "expected false as no milestone exist at this point")
-	require.False(t, milestone.Locked, "expected false as it was not locked")
-	require.Equal(t, uint64(0), milestone.LockedMilestoneNumber, "expected 0 as it was not initialized")
+	require.False(t, milestone.current().doExist, "expected false as no milestone exist at this point")
+	require.False(t, milestone.lockState().Locked, "expected false as it was not locked")
+	require.Equal(t, uint64(0), milestone.lockState().LockedMilestoneNumber, "expected 0 as it was not initialized")
 
 	_, _, err := rawdb.ReadFinality[*rawdb.Milestone](db)
 	require.Error(t, err, "Error should be nil while reading from the db")
 
 	//Acquiring the mutex lock
 	milestone.LockMutex(11)
-	require.False(t, milestone.Locked, "expected false as sprint is not locked till this point")
+	require.False(t, milestone.lockState().Locked, "expected false as sprint is not locked till this point")
 
 	//Releasing the mutex lock
 	milestone.UnlockMutex(true, "milestoneID1", uint64(11), common.Hash{})
-	require.Equal(t, uint64(11), milestone.LockedMilestoneNumber, "expected 11 as it was not initialized")
-	require.True(t, milestone.Locked, "expected true as sprint is locked now")
-	require.Equal(t, 1, len(milestone.LockedMilestoneIDs), "expected 1 as only 1 milestoneID has been entered")
+	require.Equal(t, uint64(11), milestone.lockState().LockedMilestoneNumber, "expected 11 as it was not initialized")
+	require.True(t, milestone.lockState().Locked, "expected true as sprint is locked now")
+	require.Equal(t, 1, len(milestone.lockState().LockedMilestoneIDs), "expected 1 as only 1 milestoneID has been entered")
 
-	_, ok := milestone.LockedMilestoneIDs["milestoneID1"]
+	_, ok := milestone.lockState().LockedMilestoneIDs["milestoneID1"]
 	require.True(t, ok, "milestoneID1 should exist in the LockedMilestoneIDs map")
 
-	_, ok = milestone.LockedMilestoneIDs["milestoneID2"]
+	_, ok = milestone.lockState().LockedMilestoneIDs["milestoneID2"]
 	require.False(t, ok, "milestoneID2 shouldn't exist in the LockedMilestoneIDs map")
 
 	milestone.LockMutex(11)
 	milestone.UnlockMutex(true, "milestoneID2", uint64(11), common.Hash{})
-	require.Equal(t, 1, len(milestone.LockedMilestoneIDs), "expected 1 as only 1 milestoneID has been entered")
+	require.Equal(t, 1, len(milestone.lockState().LockedMilestoneIDs), "expected 1 as only 1 milestoneID has been entered")
 
-	_, ok = milestone.LockedMilestoneIDs["milestoneID2"]
+	_, ok = milestone.lockState().LockedMilestoneIDs["milestoneID2"]
 	require.True(t, ok, "milestoneID2 should exist in the LockedMilestoneIDs map")
 
 	milestone.RemoveMilestoneID("milestoneID1")
-	require.Equal(t, 1, len(milestone.LockedMilestoneIDs), "expected 1 as one out of two has been removed in previous step")
-	require.True(t, milestone.Locked, "expected true as sprint is locked now")
+	require.Equal(t, 1, len(milestone.lockState().LockedMilestoneIDs), "expected 1 as one out of two has been removed in previous step")
+	require.True(t, milestone.lockState().Locked, "expected true as sprint is locked now")
 
 	milestone.RemoveMilestoneID("milestoneID2")
-	require.Empty(t, milestone.LockedMilestoneIDs, "expected 1 as both the milestonesIDs has been removed in previous step")
-	require.False(t, milestone.Locked, "expected false")
+	require.Empty(t, milestone.lockState().LockedMilestoneIDs, "expected 1 as both the milestonesIDs has been removed in previous step")
+	require.False(t, milestone.lockState().Locked, "expected false")
 
 	milestone.LockMutex(11)
 	milestone.UnlockMutex(true, "milestoneID3", uint64(11), common.Hash{})
-	require.True(t, milestone.Locked, "expected true")
-	require.Equal(t, uint64(11), milestone.LockedMilestoneNumber, "Expected 11")
+	require.True(t, milestone.lockState().Locked, "expected true")
+	require.Equal(t, uint64(11), milestone.lockState().LockedMilestoneNumber, "Expected 11")
 
 	milestone.LockMutex(15)
-	require.True(t, milestone.Locked, "expected true")
-	require.Equal(t, uint64(11), milestone.LockedMilestoneNumber, "Expected 11")
+	require.True(t, milestone.lockState().Locked, "expected true")
+	require.Equal(t, uint64(11), milestone.lockState().LockedMilestoneNumber, "Expected 11")
 	milestone.UnlockMutex(true, "milestoneID4", uint64(15), common.Hash{})
-	require.True(t, milestone.Locked, "expected true as final confirmation regarding the lock has been made")
-	require.Equal(t, 1, len(milestone.LockedMilestoneIDs), "expected 1 as previous milestonesIDs has been removed in previous step")
+	require.True(t, milestone.lockState().Locked, "expected true as final confirmation regarding the lock has been made")
+	require.Equal(t, 1, len(milestone.lockState().LockedMilestoneIDs), "expected 1 as previous milestonesIDs has been removed in previous step")
 
 	//Adding the milestone
 	s.ProcessMilestone(11, common.Hash{})
 
-	require.True(t, milestone.Locked, "expected true as locked sprint is of number 15")
-	require.True(t, milestone.doExist, "expected true as milestone exist")
-	require.Equal(t, 1, len(milestone.LockedMilestoneIDs), "expected 1 as still last milestone of sprint number 15 exist")
+	require.True(t, milestone.lockState().Locked, "expected true as locked sprint is of number 15")
+	require.True(t, milestone.current().doExist, "expected true as milestone exist")
+	require.Equal(t, 1, len(milestone.lockState().LockedMilestoneIDs), "expected 1 as still last milestone of sprint number 15 exist")
 
 	//Reading from the Db
 	locked, lockedMilestoneNumber, lockedMilestoneHash, lockedMilestoneIDs, err := rawdb.ReadLockField(db)
@@ -197,9 +193,9 @@ func TestMilestone(t *testing.T) {
 
 	//Adding the milestone
 	s.ProcessMilestone(51, common.Hash{})
-	require.False(t, milestone.Locked, "expected false as lock from sprint number 15 is removed")
-	require.True(t, milestone.doExist, "expected true as milestone exist")
-	require.Empty(t, milestone.LockedMilestoneIDs, "expected 0 as all the milestones have been removed")
+	require.False(t, milestone.lockState().Locked, "expected false as lock from sprint number 15 is removed")
+	require.True(t, milestone.current().doExist, "expected true as milestone exist")
+	require.Empty(t, milestone.lockState().LockedMilestoneIDs, "expected 0 as all the milestones have been removed")
 
 	//Reading from the Db
 	locked, _, _, lockedMilestoneIDs, err = rawdb.ReadLockField(db)
@@ -211,7 +207,7 @@ func TestMilestone(t *testing.T) {
 	//Removing the milestone
 	s.PurgeWhitelistedMilestone()
 
-	require.False(t, milestone.doExist, "expected false as no milestone exist at this point")
+	require.False(t, milestone.current().doExist, "expected false as no milestone exist at this point")
 
 	//Removing the milestone
 	s.ProcessMilestone(11, common.Hash{1})
@@ -240,9 +236,9 @@ func TestMilestone(t *testing.T) {
 	require.Error(t, err, "Error should be not nil")
 
 	s.ProcessFutureMilestone(16, common.Hash{16})
-	require.Equal(t, 1, len(milestone.FutureMilestoneOrder), "expected length is 1 as we added only 1 future milestone")
-	require.Equal(t, uint64(16), milestone.FutureMilestoneOrder[0], "expected value is 16 but got", milestone.FutureMilestoneOrder[0])
-	require.Equal(t, common.Hash{16}, milestone.FutureMilestoneList[16], "expected value is", common.Hash{16}.String()[2:], "but got", milestone.FutureMilestoneList[16])
+	require.Equal(t, 1, len(milestone.futureState().FutureMilestoneOrder), "expected length is 1 as we added only 1 future milestone")
+	require.Equal(t, uint64(16), milestone.futureState().FutureMilestoneOrder[0], "expected value is 16 but got", milestone.futureState().FutureMilestoneOrder[0])
+	require.Equal(t, common.Hash{16}, milestone.futureState().FutureMilestoneList[16], "expected value is", common.Hash{16}.String()[2:], "but got", milestone.futureState().FutureMilestoneList[16])
 
 	order, list, err := rawdb.ReadFutureMilestoneList(db)
 	require.NoError(t, err, "Error should be nil while reading from the db")
@@ -255,8 +251,8 @@ func TestMilestone(t *testing.T) {
 		s.ProcessFutureMilestone(uint64(i), common.Hash{16})
 	}
 
-	require.Equal(t, len(milestone.FutureMilestoneOrder), capicity, "expected length is", capicity)
-	require.Equal(t, milestone.FutureMilestoneOrder[capicity-1], uint64(16*capicity), "expected value is", uint64(16*capicity), "but got", milestone.FutureMilestoneOrder[capicity-1])
+	require.Equal(t, len(milestone.futureState().FutureMilestoneOrder), capicity, "expected length is", capicity)
+	require.Equal(t, milestone.futureState().FutureMilestoneOrder[capicity-1], uint64(16*capicity), "expected value is", uint64(16*capicity), "but got", milestone.futureState().FutureMilestoneOrder[capicity-1])
 }
 
 // TestIsValidChain checks the IsValidChain function in isolation
@@ -372,7 +368,7 @@ func TestIsValidChain(t *testing.T) {
 	s.PurgeWhitelistedCheckpoint()
 	s.ProcessCheckpoint(chainA[15].Number.Uint64(), chainA[15].Hash())
 
-	require.True(t, checkpoint.doExist, "expected true as checkpoint exists.")
+	require.True(t, checkpoint.current().doExist, "expected true as checkpoint exists.")
 
 	// case9: As the received chain is having valid checkpoint,should consider the chain as valid.
 	res = s.IsValidChain(chainA[len(chainA)-1].Number.Uint64(), chainA)
@@ -424,7 +420,7 @@ func TestIsValidChain(t *testing.T) {
 	s.PurgeWhitelistedCheckpoint()
 	s.ProcessCheckpoint(tempChain[0].Number.Uint64(), tempChain[0].Hash())
 
-	require.True(t, checkpoint.doExist, "expected true")
+	require.True(t, checkpoint.current().doExist, "expected true")
 
 	// case17: Try importing a past chain having invalid checkpoint,should consider the chain as invalid
 	res = s.IsValidChain(tempChain[0].Number.Uint64(), chainA)
@@ -483,20 +479,10 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 
 		milestone := milestone{
 			finality: finality[*rawdb.Milestone]{
-				doExist:  false,
-				Number:   0,
-				Hash:     common.Hash{},
 				interval: 256,
 				db:       db,
 			},
-
-			Locked:                false,
-			LockedMilestoneNumber: 0,
-			LockedMilestoneHash:   common.Hash{},
-			LockedMilestoneIDs:    make(map[string]struct{}),
-			FutureMilestoneList:   make(map[uint64]common.Hash),
-			FutureMilestoneOrder:  make([]uint64, 0),
-			MaxCapacity:           10,
+			MaxCapacity: 10,
 		}
 
 		var (
@@ -514,24 +500,24 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 
 		if doLock {
 			//Milestone should not be whitelisted
-			if milestone.doExist {
+			if milestone.current().doExist {
 				t.Error("Milestone is not expected to be whitelisted")
 			}
 
 			//Local chain should be locked
-			if !milestone.Locked {
+			if !milestone.lockState().Locked {
 				t.Error("Milestone is expected to be locked at", milestoneEndNum)
 			}
 
-			if milestone.LockedMilestoneNumber != milestoneEndNum {
+			if milestone.lockState().LockedMilestoneNumber != milestoneEndNum {
 				t.Error("Locked milestone number is expected to be", milestoneEndNum)
 			}
 
-			if len(milestone.LockedMilestoneIDs) != 1 {
+			if len(milestone.lockState().LockedMilestoneIDs) != 1 {
 				t.Error("List should contain 1 milestone")
 			}
 
-			_, ok := milestone.LockedMilestoneIDs[milestoneID]
+			_, ok := milestone.lockState().LockedMilestoneIDs[milestoneID]
 
 			if !ok {
 				t.Error("List doesn't contain correct milestoneID")
@@ -539,23 +525,23 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 		}
 
 		if !doLock {
-			if milestone.doExist {
+			if milestone.current().doExist {
 				t.Error("Milestone is not expected to be whitelisted")
 			}
 
-			if milestone.Locked {
+			if milestone.lockState().Locked {
 				t.Error("Milestone is expected not to be locked")
 			}
 
-			if milestone.LockedMilestoneNumber != 0 {
+			if milestone.lockState().LockedMilestoneNumber != 0 {
 				t.Error("Locked milestone number is expected to be", 0)
 			}
 
-			if len(milestone.LockedMilestoneIDs) != 0 {
+			if len(milestone.lockState().LockedMilestoneIDs) != 0 {
 				t.Error("List should not contain milestone")
 			}
 
-			_, ok := milestone.LockedMilestoneIDs[milestoneID]
+			_, ok := milestone.lockState().LockedMilestoneIDs[milestoneID]
 
 			if ok {
 				t.Error("List shouldn't contain any milestoneID")
@@ -602,23 +588,23 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 		milestone.UnlockMutex(doLock2, milestoneID2, milestoneEndNum2, common.Hash{})
 
 		if doLock2 {
-			if milestone.doExist {
+			if milestone.current().doExist {
 				t.Error("Milestone is not expected to be whitelisted")
 			}
 
-			if !milestone.Locked {
+			if !milestone.lockState().Locked {
 				t.Error("Milestone is expected to be locked at", milestoneEndNum2)
 			}
 
-			if milestone.LockedMilestoneNumber != milestoneEndNum2 {
+			if milestone.lockState().LockedMilestoneNumber != milestoneEndNum2 {
 				t.Error("Locked milestone number is expected to be", milestoneEndNum)
 			}
 
-			if len(milestone.LockedMilestoneIDs) != 1 {
+			if len(milestone.lockState().LockedMilestoneIDs) != 1 {
 				t.Error("List should contain 1 milestone")
 			}
 
-			_, ok := milestone.LockedMilestoneIDs[milestoneID2]
+			_, ok := milestone.lockState().LockedMilestoneIDs[milestoneID2]
 
 			if !ok {
 				t.Error("List doesn't contain correct milestoneID")
@@ -626,35 +612,35 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 		}
 
 		if !doLock2 {
-			if milestone.doExist {
+			if milestone.current().doExist {
 				t.Error("Milestone is not expected to be whitelisted")
 			}
 
-			if !doLock && milestone.Locked {
+			if !doLock && milestone.lockState().Locked {
 				t.Error("Milestone is expected not to be locked")
 			}
 
-			if doLock && !milestone.Locked {
+			if doLock && !milestone.lockState().Locked {
 				t.Error("Milestone is expected to be locked at", milestoneEndNum)
 			}
 
-			if !doLock && milestone.LockedMilestoneNumber != 0 {
+			if !doLock && milestone.lockState().LockedMilestoneNumber != 0 {
 				t.Error("Locked milestone number is expected to be", 0)
 			}
 
-			if doLock && milestone.LockedMilestoneNumber != milestoneEndNum {
+			if doLock && milestone.lockState().LockedMilestoneNumber != milestoneEndNum {
 				t.Error("Locked milestone number is expected to be", milestoneEndNum)
 			}
 
-			if !doLock && len(milestone.LockedMilestoneIDs) != 0 {
+			if !doLock && len(milestone.lockState().LockedMilestoneIDs) != 0 {
 				t.Error("List should not contain milestone")
 			}
 
-			if doLock && len(milestone.LockedMilestoneIDs) != 1 {
+			if doLock && len(milestone.lockState().LockedMilestoneIDs) != 1 {
 				t.Error("List should not contain milestone")
 			}
 
-			_, ok := milestone.LockedMilestoneIDs[milestoneID]
+			_, ok := milestone.lockState().LockedMilestoneIDs[milestoneID]
 
 			if !doLock && ok {
 				t.Error("List shouldn't contain any milestoneID")
@@ -669,27 +655,27 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 			milestoneNum = rapid.Uint64().Draw(t, "milestone Number")
 		)
 
-		lockedValue := milestone.LockedMilestoneNumber
+		lockedValue := milestone.lockState().LockedMilestoneNumber
 
 		milestone.Process(milestoneNum, common.Hash{})
 
 		isChainLocked := doLock || doLock2
 
-		if !milestone.doExist {
+		if !milestone.current().doExist {
 			t.Error("Should have the whitelisted milestone")
 		}
 
-		if milestone.finality.Number != milestoneNum {
+		if milestone.current().Number != milestoneNum {
 			t.Error("Should have the whitelisted milestone", milestoneNum)
 		}
 
 		if isChainLocked {
 			if milestoneNum < lockedValue {
-				if !milestone.Locked {
+				if !milestone.lockState().Locked {
 					t.Error("Milestone is expected to be locked")
 				}
 			} else {
-				if milestone.Locked {
+				if milestone.lockState().Locked {
 					t.Error("Milestone is expected not to be locked")
 				}
 			}
@@ -699,17 +685,17 @@ func TestPropertyBasedTestingMilestone(t *testing.T) {
 			futureMilestoneNum = rapid.Uint64Min(milestoneNum).Draw(t, "future milestone Number")
 		)
 
-		isChainLocked = milestone.Locked
+		isChainLocked = milestone.lockState().Locked
 
 		milestone.ProcessFutureMilestone(futureMilestoneNum, common.Hash{})
 
 		if isChainLocked {
 			if futureMilestoneNum < lockedValue {
-				if !milestone.Locked {
+				if !milestone.lockState().Locked {
 					t.Error("Milestone is expected to be locked")
 				}
 			} else {
-				if milestone.Locked {
+				if milestone.lockState().Locked {
 					t.Error("Milestone is expected not to be locked")
 				}
 			}
@@ -915,6 +901,60 @@ func TestSplitChainProperties(t *testing.T) {
 
 // createMockChain returns a chain with dummy headers
 // starting from `start` to `end` (inclusive)
+// TestConcurrentValidationDuringVote checks that chain validation is not blocked by a
+// vote holding the sprint lock, and that readers and writers can run concurrently.
+func TestConcurrentValidationDuringVote(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewTestDB(t, kv.ChainDB)
+	s := NewMockService(db)
+	chain := createMockChain(1, 64)
+
+	// hold the sprint lock as bor_getVoteOnHash does while it reads the local chain
+	require.True(t, s.LockMutex(64))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.IsValidChain(64, chain)
+		s.GetWhitelistedMilestone()
+		s.ProcessCheckpoint(32, chain[31].Hash())
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("validation blocked while the sprint lock was held")
+	}
+
+	s.UnlockMutex(true, "milestoneID", 64, chain[63].Hash())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.IsValidChain(64, chain)
+				s.GetMilestoneIDsList()
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				end := uint64(i*100 + j + 1)
+				s.ProcessMilestone(end, common.Hash{byte(j)})
+				s.ProcessFutureMilestone(end+10, common.Hash{byte(j)})
+				s.RemoveMilestoneID(fmt.Sprintf("milestoneID%d", j))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	doExist, _, _ := s.GetWhitelistedMilestone()
+	require.True(t, doExist)
+}
+
 func createMockChain(start, end uint64) []*types.Header {
 	var (
 		i   uint64
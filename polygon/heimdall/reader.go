@@ -63,6 +63,14 @@ func (r *Reader) MilestonesFromBlock(ctx context.Context, startBlock uint64) ([]
 	return r.store.Milestones().RangeFromBlockNum(ctx, startBlock)
 }
 
+func (r *Reader) Milestone(ctx context.Context, id uint64) (*Milestone, bool, error) {
+	return r.store.Milestones().Entity(ctx, id)
+}
+
+func (r *Reader) LastMilestoneId(ctx context.Context) (uint64, bool, error) {
+	return r.store.Milestones().LastEntityId(ctx)
+}
+
 func (r *Reader) Producers(ctx context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
 	return r.spanBlockProducersTracker.Producers(ctx, blockNum)
 }
@@ -228,6 +228,14 @@ func (s *Service) MilestonesFromBlock(ctx context.Context, startBlock uint64) ([
 	return s.reader.MilestonesFromBlock(ctx, startBlock)
 }
 
+func (s *Service) Milestone(ctx context.Context, id uint64) (*Milestone, bool, error) {
+	return s.reader.Milestone(ctx, id)
+}
+
+func (s *Service) LastMilestoneId(ctx context.Context) (uint64, bool, error) {
+	return s.reader.LastMilestoneId(ctx)
+}
+
 func (s *Service) Producers(ctx context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
 	return s.reader.Producers(ctx, blockNum)
 }
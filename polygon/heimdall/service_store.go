@@ -57,7 +57,7 @@ func newMdbxStore(db *polygoncommon.Database) *MdbxStore {
 		spans: newMdbxEntityStore(
 			db, kv.BorSpans, Spans, generics.New[Span], spanIndex),
 		spanBlockProducerSelections: newMdbxEntityStore(
-			db, kv.BorProducerSelections, nil, generics.New[SpanBlockProducerSelection], spanIndex),
+			db, kv.BorProducerSelections, ProducerSelections, generics.New[SpanBlockProducerSelection], spanIndex),
 	}
 }
 
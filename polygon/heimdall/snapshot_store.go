@@ -25,7 +25,7 @@ func NewSnapshotStore(base Store, snapshots *RoSnapshots) *SnapshotStore {
 		checkpoints:                 NewCheckpointSnapshotStore(base.Checkpoints(), snapshots),
 		milestones:                  NewMilestoneSnapshotStore(base.Milestones(), snapshots),
 		spans:                       NewSpanSnapshotStore(base.Spans(), snapshots),
-		spanBlockProducerSelections: base.SpanBlockProducerSelections(),
+		spanBlockProducerSelections: NewSpanBlockProducerSelectionSnapshotStore(base.SpanBlockProducerSelections(), snapshots),
 	}
 }
 
@@ -202,6 +202,127 @@ func (s *SpanSnapshotStore) ValidateSnapshots(ctx context.Context, logger log.Lo
 	return validateSnapshots(ctx, logger, s.EntityStore, failFast, s.snapshots, s.SnapType(), generics.New[Span])
 }
 
+type SpanBlockProducerSelectionSnapshotStore struct {
+	EntityStore[*SpanBlockProducerSelection]
+	snapshots *RoSnapshots
+}
+
+func NewSpanBlockProducerSelectionSnapshotStore(base EntityStore[*SpanBlockProducerSelection], snapshots *RoSnapshots) *SpanBlockProducerSelectionSnapshotStore {
+	return &SpanBlockProducerSelectionSnapshotStore{base, snapshots}
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) Prepare(ctx context.Context) error {
+	if err := s.EntityStore.Prepare(ctx); err != nil {
+		return err
+	}
+
+	return <-s.snapshots.Ready(ctx)
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) WithTx(tx kv.Tx) EntityStore[*SpanBlockProducerSelection] {
+	return &SpanBlockProducerSelectionSnapshotStore{
+		txEntityStore[*SpanBlockProducerSelection]{s.EntityStore.(*mdbxEntityStore[*SpanBlockProducerSelection]), tx},
+		s.snapshots,
+	}
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) RangeExtractor() snaptype.RangeExtractor {
+	return snaptype.RangeExtractorFunc(
+		func(ctx context.Context, blockFrom, blockTo uint64, firstKey snaptype.FirstKeyGetter, db kv.RoDB, chainConfig *chain.Config, collect func([]byte) error, workers int, lvl log.Lvl, logger log.Logger) (uint64, error) {
+			return s.SnapType().RangeExtractor().Extract(ctx, blockFrom, blockTo, firstKey,
+				s.EntityStore.(*mdbxEntityStore[*SpanBlockProducerSelection]).db.RoDB(), chainConfig, collect, workers, lvl, logger)
+		})
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) LastFrozenEntityId() uint64 {
+	if s.snapshots == nil {
+		return 0
+	}
+
+	tx := s.snapshots.ViewType(s.SnapType())
+	defer tx.Close()
+	segments := tx.Segments
+
+	if len(segments) == 0 {
+		return 0
+	}
+	// find the last segment which has a built non-empty index
+	var lastSegment *snapshotsync.VisibleSegment
+	for i := len(segments) - 1; i >= 0; i-- {
+		if index := segments[i].Src().Index(); index != nil && index.KeyCount() > 0 {
+			lastSegment = segments[i]
+			break
+		}
+	}
+	if lastSegment == nil {
+		return 0
+	}
+
+	index := lastSegment.Src().Index()
+
+	return index.BaseDataID() + index.KeyCount() - 1
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) Entity(ctx context.Context, id uint64) (*SpanBlockProducerSelection, bool, error) {
+	entity, ok, err := s.EntityStore.Entity(ctx, id)
+	if ok || err != nil || s.snapshots == nil {
+		return entity, ok, err
+	}
+
+	tx := s.snapshots.ViewType(s.SnapType())
+	defer tx.Close()
+	segments := tx.Segments
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		sn := segments[i]
+		index := sn.Src().Index()
+
+		if index == nil || index.KeyCount() == 0 {
+			continue
+		}
+		if id < index.BaseDataID() || id >= index.BaseDataID()+index.KeyCount() {
+			continue
+		}
+
+		offset := index.OrdinalLookup(id - index.BaseDataID())
+		gg := sn.Src().MakeGetter()
+		gg.Reset(offset)
+		result, _ := gg.Next(nil)
+
+		var selection SpanBlockProducerSelection
+		if err := json.Unmarshal(result, &selection); err != nil {
+			return nil, false, err
+		}
+
+		return &selection, true, nil
+	}
+
+	return nil, false, nil
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) LastEntityId(ctx context.Context) (uint64, bool, error) {
+	lastId, ok, err := s.EntityStore.LastEntityId(ctx)
+
+	snapshotLastId := s.LastFrozenEntityId()
+	if snapshotLastId > lastId {
+		return snapshotLastId, true, nil
+	}
+
+	return lastId, ok, err
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) LastEntity(ctx context.Context) (*SpanBlockProducerSelection, bool, error) {
+	return snapshotStoreLastEntity(ctx, s)
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) RangeFromBlockNum(ctx context.Context, startBlockNum uint64) ([]*SpanBlockProducerSelection, error) {
+	return snapshotStoreRangeFromBlockNum(ctx, startBlockNum, s.EntityStore, s.snapshots, s.SnapType(), generics.New[SpanBlockProducerSelection])
+}
+
+func (s *SpanBlockProducerSelectionSnapshotStore) ValidateSnapshots(ctx context.Context, logger log.Logger, failFast bool) error {
+	return validateSnapshots(ctx, logger, s.EntityStore, failFast, s.snapshots, s.SnapType(), generics.New[SpanBlockProducerSelection])
+}
+
 type MilestoneSnapshotStore struct {
 	EntityStore[*Milestone]
 	snapshots *RoSnapshots
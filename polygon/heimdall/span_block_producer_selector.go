@@ -0,0 +1,99 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+)
+
+// RotatedProducersFunc returns the producers for a block as computed by the proposer
+// priority rotation of the span block producer selections.
+type RotatedProducersFunc func(ctx context.Context, blockNum uint64) (*valset.ValidatorSet, error)
+
+// ProducerSelector decides which validators may produce the sprint containing a given
+// block, on top of the proposer priority rotation. It allows experimenting with
+// alternative producer selection rules (e.g. a single producer per span) without
+// changing how the rotation itself is tracked and persisted.
+type ProducerSelector interface {
+	SelectProducers(ctx context.Context, blockNum uint64, rotated RotatedProducersFunc) (*valset.ValidatorSet, error)
+}
+
+// NewProducerSelector returns the producer selector for the given bor config: the
+// rotating selector, switching to the single producer selector from the configured
+// single producer activation block onwards.
+func NewProducerSelector(borConfig *borcfg.BorConfig) ProducerSelector {
+	if borConfig == nil || borConfig.GetSingleProducerBlock() == nil {
+		return RotatingProducerSelector{}
+	}
+
+	return &ForkedProducerSelector{
+		IsActive: borConfig.IsSingleProducer,
+		Before:   RotatingProducerSelector{},
+		After:    SingleProducerSelector{},
+	}
+}
+
+// RotatingProducerSelector is the default bor selection: every producer of the
+// span takes part and the proposer rotates each sprint.
+type RotatingProducerSelector struct{}
+
+func (RotatingProducerSelector) SelectProducers(ctx context.Context, blockNum uint64, rotated RotatedProducersFunc) (*valset.ValidatorSet, error) {
+	return rotated(ctx, blockNum)
+}
+
+// SingleProducerSelector keeps the proposer of the first sprint of a span as the
+// only producer for the remainder of the span.
+type SingleProducerSelector struct{}
+
+func (SingleProducerSelector) SelectProducers(ctx context.Context, blockNum uint64, rotated RotatedProducersFunc) (*valset.ValidatorSet, error) {
+	spanId := SpanIdAt(blockNum)
+	spanStartBlock := uint64(0)
+	if spanId > 0 {
+		spanStartBlock = SpanEndBlockNum(spanId-1) + 1
+	}
+
+	producers, err := rotated(ctx, spanStartBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	proposer := producers.GetProposer()
+	if proposer == nil {
+		return nil, errors.New("no proposer for single producer selection")
+	}
+
+	return valset.NewValidatorSet([]*valset.Validator{proposer.Copy()}), nil
+}
+
+// ForkedProducerSelector switches between two selectors at an activation block.
+type ForkedProducerSelector struct {
+	IsActive func(blockNum uint64) bool
+	Before   ProducerSelector
+	After    ProducerSelector
+}
+
+func (s *ForkedProducerSelector) SelectProducers(ctx context.Context, blockNum uint64, rotated RotatedProducersFunc) (*valset.ValidatorSet, error) {
+	if s.IsActive(blockNum) {
+		return s.After.SelectProducers(ctx, blockNum, rotated)
+	}
+
+	return s.Before.SelectProducers(ctx, blockNum, rotated)
+}
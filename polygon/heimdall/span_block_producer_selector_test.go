@@ -0,0 +1,82 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+)
+
+func TestProducerSelector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	validators := []*valset.Validator{
+		valset.NewValidator(common.HexToAddress("0x1"), 10),
+		valset.NewValidator(common.HexToAddress("0x2"), 10),
+		valset.NewValidator(common.HexToAddress("0x3"), 10),
+	}
+
+	var requested []uint64
+	rotated := func(ctx context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
+		requested = append(requested, blockNum)
+		producers := valset.NewValidatorSet(validators)
+		producers.IncrementProposerPriority(int(blockNum / 16))
+		return producers, nil
+	}
+
+	t.Run("rotating without activation block", func(t *testing.T) {
+		requested = nil
+		selector := NewProducerSelector(&borcfg.BorConfig{})
+		require.IsType(t, RotatingProducerSelector{}, selector)
+
+		producers, err := selector.SelectProducers(ctx, 300, rotated)
+		require.NoError(t, err)
+		require.Len(t, producers.Validators, 3)
+		require.Equal(t, []uint64{300}, requested)
+	})
+
+	t.Run("single producer after activation block", func(t *testing.T) {
+		selector := NewProducerSelector(&borcfg.BorConfig{SingleProducerBlock: big.NewInt(6656)})
+
+		requested = nil
+		producers, err := selector.SelectProducers(ctx, 6655, rotated)
+		require.NoError(t, err)
+		require.Len(t, producers.Validators, 3)
+		require.Equal(t, []uint64{6655}, requested)
+
+		spanStartProducers, err := rotated(ctx, 6656)
+		require.NoError(t, err)
+		expectedProducer := spanStartProducers.GetProposer().Address
+
+		for _, blockNum := range []uint64{6656, 6700, 13055} {
+			requested = nil
+			producers, err = selector.SelectProducers(ctx, blockNum, rotated)
+			require.NoError(t, err)
+			require.Len(t, producers.Validators, 1)
+			require.Equal(t, expectedProducer, producers.GetProposer().Address)
+			require.Equal(t, []uint64{6656}, requested)
+		}
+	})
+}
@@ -43,6 +43,7 @@ func newSpanBlockProducersTracker(
 	return &spanBlockProducersTracker{
 		logger:           logger,
 		borConfig:        borConfig,
+		selector:         NewProducerSelector(borConfig),
 		store:            store,
 		recentSelections: recentSelectionsLru,
 		newSpans:         make(chan *Span),
@@ -53,6 +54,7 @@ func newSpanBlockProducersTracker(
 type spanBlockProducersTracker struct {
 	logger           log.Logger
 	borConfig        *borcfg.BorConfig
+	selector         ProducerSelector
 	store            EntityStore[*SpanBlockProducerSelection]
 	recentSelections *lru.Cache[uint64, SpanBlockProducerSelection] // sprint number -> SpanBlockProducerSelection
 	newSpans         chan *Span
@@ -182,7 +184,12 @@ func (t *spanBlockProducersTracker) ObserveSpan(ctx context.Context, newSpan *Sp
 func (t *spanBlockProducersTracker) Producers(ctx context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
 	startTime := time.Now()
 
-	producers, increments, err := t.producers(ctx, blockNum)
+	var increments int
+	producers, err := t.selector.SelectProducers(ctx, blockNum, func(ctx context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
+		producers, n, err := t.producers(ctx, blockNum)
+		increments += n
+		return producers, err
+	})
 	if err != nil {
 		return nil, err
 	}
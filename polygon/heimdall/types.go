@@ -64,25 +64,29 @@ var Enums = struct {
 	Events,
 	Spans,
 	Checkpoints,
-	Milestones snaptype.Enum
+	Milestones,
+	ProducerSelections snaptype.Enum
 }{
-	Enums:       snaptype.Enums{},
-	Events:      snaptype.MinBorEnum,
-	Spans:       snaptype.MinBorEnum + 1,
-	Checkpoints: snaptype.MinBorEnum + 2,
-	Milestones:  snaptype.MinBorEnum + 3,
+	Enums:              snaptype.Enums{},
+	Events:             snaptype.MinBorEnum,
+	Spans:              snaptype.MinBorEnum + 1,
+	Checkpoints:        snaptype.MinBorEnum + 2,
+	Milestones:         snaptype.MinBorEnum + 3,
+	ProducerSelections: snaptype.MinBorEnum + 4,
 }
 
 var Indexes = struct {
 	BorTxnHash,
 	BorSpanId,
 	BorCheckpointId,
-	BorMilestoneId snaptype.Index
+	BorMilestoneId,
+	BorProducerSelectionId snaptype.Index
 }{
-	BorTxnHash:      snaptype.Index{Name: "borevents"},
-	BorSpanId:       snaptype.Index{Name: "borspans"},
-	BorCheckpointId: snaptype.Index{Name: "borcheckpoints"},
-	BorMilestoneId:  snaptype.Index{Name: "bormilestones"},
+	BorTxnHash:             snaptype.Index{Name: "borevents"},
+	BorSpanId:              snaptype.Index{Name: "borspans"},
+	BorCheckpointId:        snaptype.Index{Name: "borcheckpoints"},
+	BorMilestoneId:         snaptype.Index{Name: "bormilestones"},
+	BorProducerSelectionId: snaptype.Index{Name: "borproducerselections"},
 }
 
 type EventRangeExtractor struct {
@@ -400,6 +404,53 @@ var (
 				return buildValueIndex(ctx, sn, salt, d, firstMilestoneId, tmpDir, p, lvl, logger)
 			}),
 	)
+
+	// ProducerSelections are the span block producer selections with accumulated proposer
+	// priorities computed by Astrid. Freezing them lets a fresh node start tracking producers
+	// from the snapshot tip instead of replaying every span since genesis.
+	ProducerSelections = snaptype.RegisterType(
+		Enums.ProducerSelections,
+		"borproducerselections",
+		snaptype.Versions{
+			Current:      version.V1_0,
+			MinSupported: version.V1_0,
+		},
+		snaptype.RangeExtractorFunc(
+			func(ctx context.Context, blockFrom, blockTo uint64, firstKeyGetter snaptype.FirstKeyGetter, db kv.RoDB, _ *chain.Config, collect func([]byte) error, workers int, lvl log.Lvl, logger log.Logger) (uint64, error) {
+				spanFrom := uint64(SpanIdAt(blockFrom))
+				spanTo := uint64(SpanIdAt(blockTo))
+				return extractValueRange(ctx, kv.BorProducerSelections, spanFrom, spanTo, db, collect, workers, lvl, logger)
+			}),
+		[]snaptype.Index{Indexes.BorProducerSelectionId},
+		snaptype.IndexBuilderFunc(
+			func(ctx context.Context, sn snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+				d, err := seg.NewDecompressor(sn.Path)
+
+				if err != nil {
+					return err
+				}
+				defer d.Close()
+
+				gg := d.MakeGetter()
+
+				// selections are only recorded from the span at which producer tracking
+				// started, so the first id is taken from the data rather than the range
+				var firstSpanId uint64
+
+				if gg.HasNext() {
+					buf, _ := gg.Next(nil)
+					if len(buf) > 0 {
+						var firstSelection SpanBlockProducerSelection
+						if err = json.Unmarshal(buf, &firstSelection); err != nil {
+							return err
+						}
+						firstSpanId = uint64(firstSelection.SpanId)
+					}
+				}
+
+				return buildValueIndex(ctx, sn, salt, d, firstSpanId, tmpDir, p, lvl, logger)
+			}),
+	)
 )
 
 var recordWaypoints bool
@@ -411,7 +462,7 @@ func RecordWayPoints(value bool) {
 
 func SnapshotTypes() []snaptype.Type {
 	if recordWaypoints {
-		return []snaptype.Type{Events, Spans, Checkpoints, Milestones}
+		return []snaptype.Type{Events, Spans, Checkpoints, Milestones, ProducerSelections}
 	}
 
 	return []snaptype.Type{Events, Spans}
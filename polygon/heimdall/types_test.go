@@ -39,6 +39,10 @@ func TestEnumeration(t *testing.T) {
 	if heimdall.Milestones.Enum() != heimdall.Enums.Milestones {
 		t.Fatal("enum mismatch", heimdall.Milestones, heimdall.Milestones.Enum(), heimdall.Enums.Milestones)
 	}
+
+	if heimdall.ProducerSelections.Enum() != heimdall.Enums.ProducerSelections {
+		t.Fatal("enum mismatch", heimdall.ProducerSelections, heimdall.ProducerSelections.Enum(), heimdall.Enums.ProducerSelections)
+	}
 }
 
 func TestNames(t *testing.T) {
@@ -58,4 +62,8 @@ func TestNames(t *testing.T) {
 	if heimdall.Milestones.Name() != heimdall.Enums.Milestones.String() {
 		t.Fatal("name mismatch", heimdall.Milestones, heimdall.Milestones.Name(), heimdall.Enums.Milestones.String())
 	}
+
+	if heimdall.ProducerSelections.Name() != heimdall.Enums.ProducerSelections.String() {
+		t.Fatal("name mismatch", heimdall.ProducerSelections, heimdall.ProducerSelections.Name(), heimdall.Enums.ProducerSelections.String())
+	}
 }
@@ -0,0 +1,233 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/event"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// maxConsensusEventsCatchUpBlocks limits how many blocks are scanned for events when
+// the tip jumps forward, e.g. after committing a long range of waypoint blocks
+const maxConsensusEventsCatchUpBlocks = 1024
+
+// consensusEventsQueueSize - milestones buffered for the events worker, when it's full (a slow observer
+// or producers lookup) milestones are dropped. Tips are not queued: only the latest one is kept, it covers
+// the blocks of tips which the worker didn't get to.
+const consensusEventsQueueSize = 64
+
+type ConsensusEventType string
+
+const ConsensusEventTypeSpanStart ConsensusEventType = "span-start"
+const ConsensusEventTypeSpanEnd ConsensusEventType = "span-end"
+const ConsensusEventTypeSprintStart ConsensusEventType = "sprint-start"
+const ConsensusEventTypeProducersChange ConsensusEventType = "producers-change"
+const ConsensusEventTypeMilestoneFinalized ConsensusEventType = "milestone-finalized"
+
+// ConsensusEvent is a notification about a bor consensus lifecycle change observed
+// by the sync while moving the canonical tip, intended for external consumers which
+// would otherwise need to poll heimdall.
+type ConsensusEvent struct {
+	Type        ConsensusEventType `json:"type"`
+	BlockNum    uint64             `json:"blockNumber"`
+	SpanId      *uint64            `json:"spanId,omitempty"`
+	MilestoneId *uint64            `json:"milestoneId,omitempty"`
+	BlockHash   *common.Hash       `json:"blockHash,omitempty"`
+	Producers   []common.Address   `json:"producers,omitempty"`
+}
+
+// ConsensusEventsRegistrar allows subscribing to consensus lifecycle events.
+type ConsensusEventsRegistrar interface {
+	RegisterConsensusEventObserver(observer event.Observer[ConsensusEvent]) event.UnregisterFunc
+}
+
+func NewConsensusEvents(logger log.Logger, borConfig *borcfg.BorConfig, producersReader blockProducersReader) *ConsensusEvents {
+	return &ConsensusEvents{
+		logger:          logger,
+		borConfig:       borConfig,
+		producersReader: producersReader,
+		observers:       event.NewObservers[ConsensusEvent](),
+		newTip:          make(chan struct{}, 1),
+		milestones:      make(chan *heimdall.Milestone, consensusEventsQueueSize),
+	}
+}
+
+// ConsensusEvents derives span, sprint, producer set and milestone events from tip
+// updates and fans them out to registered observers. Sync loop only queues updates:
+// events are derived and observers are notified by Run, so neither slow producers
+// lookup nor slow observer stalls the sync.
+type ConsensusEvents struct {
+	logger          log.Logger
+	borConfig       *borcfg.BorConfig
+	producersReader blockProducersReader
+	observers       *event.Observers[ConsensusEvent]
+	observersCount  atomic.Int64
+	pendingTipNum   atomic.Uint64
+	newTip          chan struct{} // signals pendingTipNum update
+	milestones      chan *heimdall.Milestone
+
+	// owned by Run
+	lastTipNum    uint64
+	lastProducers []common.Address
+}
+
+// RegisterConsensusEventObserver registers an observer for consensus events. Observers are
+// notified in order from a single goroutine, a blocking observer delays events to others and
+// makes events of following blocks be skipped.
+func (ce *ConsensusEvents) RegisterConsensusEventObserver(observer event.Observer[ConsensusEvent]) event.UnregisterFunc {
+	ce.observersCount.Add(1)
+	unregister := ce.observers.Register(observer)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unregister()
+			ce.observersCount.Add(-1)
+		})
+	}
+}
+
+func (ce *ConsensusEvents) hasObservers() bool {
+	return ce.observersCount.Load() > 0
+}
+
+// Run derives events from tip and milestone updates until ctx is done.
+func (ce *ConsensusEvents) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ce.newTip:
+			ce.onNewTip(ctx, ce.pendingTipNum.Load())
+		case milestone := <-ce.milestones:
+			// milestone is verified against the tip it came after
+			select {
+			case <-ce.newTip:
+				ce.onNewTip(ctx, ce.pendingTipNum.Load())
+			default:
+			}
+			ce.onMilestoneFinalized(milestone)
+		}
+	}
+}
+
+// OnNewTip records the new tip for Run, it never blocks.
+func (ce *ConsensusEvents) OnNewTip(newTip *types.Header) {
+	ce.pendingTipNum.Store(newTip.Number.Uint64())
+	select {
+	case ce.newTip <- struct{}{}:
+	default: // Run has not picked up previous tip yet, it will get this one instead
+	}
+}
+
+// OnMilestoneFinalized queues milestone verified against the canonical chain, it never blocks:
+// if queue is full or nobody is subscribed the milestone is dropped.
+func (ce *ConsensusEvents) OnMilestoneFinalized(milestone *heimdall.Milestone) {
+	if !ce.hasObservers() {
+		return
+	}
+	select {
+	case ce.milestones <- milestone:
+	default:
+		ce.logger.Debug(syncLogPrefix("consensus events: queue is full, dropping milestone"), "id", milestone.RawId())
+	}
+}
+
+// onNewTip emits the span and sprint boundaries crossed between the previous tip and
+// newTipNum. The first tip after startup and reorgs to a lower tip only reset the tracked
+// position, and long catch-up ranges only emit events for their last blocks. When nobody
+// is subscribed it only keeps track of the last seen tip.
+func (ce *ConsensusEvents) onNewTip(ctx context.Context, newTipNum uint64) {
+	lastTipNum := ce.lastTipNum
+	ce.lastTipNum = newTipNum
+
+	if !ce.hasObservers() || lastTipNum == 0 || lastTipNum >= newTipNum {
+		return
+	}
+
+	fromNum := lastTipNum + 1
+	if newTipNum-lastTipNum > maxConsensusEventsCatchUpBlocks {
+		fromNum = newTipNum - maxConsensusEventsCatchUpBlocks + 1
+	}
+
+	for blockNum := fromNum; blockNum <= newTipNum; blockNum++ {
+		spanId := uint64(heimdall.SpanIdAt(blockNum))
+
+		if heimdall.SpanIdAt(blockNum-1) != heimdall.SpanId(spanId) {
+			ce.notify(ConsensusEvent{Type: ConsensusEventTypeSpanStart, BlockNum: blockNum, SpanId: &spanId})
+		}
+
+		if ce.borConfig.IsSprintStart(blockNum) {
+			ce.notify(ConsensusEvent{Type: ConsensusEventTypeSprintStart, BlockNum: blockNum, SpanId: &spanId})
+			ce.checkProducersChange(ctx, blockNum, spanId)
+		}
+
+		if heimdall.SpanEndBlockNum(heimdall.SpanId(spanId)) == blockNum {
+			ce.notify(ConsensusEvent{Type: ConsensusEventTypeSpanEnd, BlockNum: blockNum, SpanId: &spanId})
+		}
+	}
+}
+
+func (ce *ConsensusEvents) onMilestoneFinalized(milestone *heimdall.Milestone) {
+	milestoneId := milestone.RawId()
+	hash := milestone.RootHash()
+	ce.notify(ConsensusEvent{
+		Type:        ConsensusEventTypeMilestoneFinalized,
+		BlockNum:    milestone.EndBlock().Uint64(),
+		MilestoneId: &milestoneId,
+		BlockHash:   &hash,
+	})
+}
+
+func (ce *ConsensusEvents) checkProducersChange(ctx context.Context, blockNum uint64, spanId uint64) {
+	producers, err := ce.producersReader.Producers(ctx, blockNum)
+	if err != nil {
+		ce.logger.Debug(syncLogPrefix("consensus events: failed to read producers"), "blockNum", blockNum, "err", err)
+		return
+	}
+
+	addresses := make([]common.Address, 0, len(producers.Validators))
+	for _, validator := range producers.Validators {
+		addresses = append(addresses, validator.Address)
+	}
+	slices.SortFunc(addresses, func(a, b common.Address) int { return a.Cmp(b) })
+
+	if slices.Equal(addresses, ce.lastProducers) {
+		return
+	}
+
+	ce.lastProducers = addresses
+	ce.notify(ConsensusEvent{
+		Type:      ConsensusEventTypeProducersChange,
+		BlockNum:  blockNum,
+		SpanId:    &spanId,
+		Producers: addresses,
+	})
+}
+
+func (ce *ConsensusEvents) notify(e ConsensusEvent) {
+	ce.observers.NotifySync(e)
+}
@@ -0,0 +1,175 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"context"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/turbo/testlog"
+)
+
+type testProducersReader func(blockNum uint64) *valset.ValidatorSet
+
+func (r testProducersReader) Producers(_ context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
+	return r(blockNum), nil
+}
+
+func TestConsensusEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := testlog.Logger(t, log.LvlCrit)
+	borConfig := &borcfg.BorConfig{Sprint: map[string]uint64{"0": 16}}
+	producers := testProducersReader(func(blockNum uint64) *valset.ValidatorSet {
+		address := common.BigToAddress(big.NewInt(int64(heimdall.SpanIdAt(blockNum)) + 1))
+		return &valset.ValidatorSet{Validators: []*valset.Validator{{Address: address}}}
+	})
+
+	ce := NewConsensusEvents(logger, borConfig, producers)
+	go ce.Run(ctx) //nolint:errcheck
+
+	eventsCh := make(chan ConsensusEvent, 16)
+	receive := func(n int) []ConsensusEvent {
+		t.Helper()
+		events := make([]ConsensusEvent, 0, n)
+		for len(events) < n {
+			select {
+			case e := <-eventsCh:
+				events = append(events, e)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("received %d events of %d", len(events), n)
+			}
+		}
+		return events
+	}
+	// marker milestone: tip updates before it have been processed
+	sync := func() {
+		t.Helper()
+		ce.OnMilestoneFinalized(&heimdall.Milestone{Id: 1_000_000, Fields: heimdall.WaypointFields{EndBlock: big.NewInt(0)}})
+		e := receive(1)[0]
+		require.Equal(t, uint64(1_000_000), *e.MilestoneId)
+	}
+
+	unregister := ce.RegisterConsensusEventObserver(func(e ConsensusEvent) {
+		eventsCh <- e
+	})
+	// first tip only sets the tracked position
+	ce.OnNewTip(&types.Header{Number: big.NewInt(250)})
+	sync()
+
+	ce.OnNewTip(&types.Header{Number: big.NewInt(260)})
+	events := receive(4)
+
+	var eventTypes []ConsensusEventType
+	for _, e := range events {
+		eventTypes = append(eventTypes, e.Type)
+	}
+	require.Equal(t, []ConsensusEventType{
+		ConsensusEventTypeSpanEnd,
+		ConsensusEventTypeSpanStart,
+		ConsensusEventTypeSprintStart,
+		ConsensusEventTypeProducersChange,
+	}, eventTypes)
+	require.Equal(t, uint64(255), events[0].BlockNum)
+	require.Equal(t, uint64(0), *events[0].SpanId)
+	require.Equal(t, uint64(256), events[1].BlockNum)
+	require.Equal(t, uint64(1), *events[1].SpanId)
+	require.Equal(t, []common.Address{common.BigToAddress(big.NewInt(2))}, events[3].Producers)
+
+	ce.OnMilestoneFinalized(&heimdall.Milestone{
+		Id:     7,
+		Fields: heimdall.WaypointFields{StartBlock: big.NewInt(250), EndBlock: big.NewInt(260)},
+	})
+	events = receive(1)
+	require.Equal(t, ConsensusEventTypeMilestoneFinalized, events[0].Type)
+	require.Equal(t, uint64(7), *events[0].MilestoneId)
+
+	// reorg to a lower tip emits nothing
+	ce.OnNewTip(&types.Header{Number: big.NewInt(258)})
+	sync()
+
+	// no observers - only the tip position is tracked
+	unregister()
+	unregister()
+	ce.OnNewTip(&types.Header{Number: big.NewInt(300)})
+	ce.OnMilestoneFinalized(&heimdall.Milestone{Id: 8, Fields: heimdall.WaypointFields{EndBlock: big.NewInt(300)}})
+	require.Never(t, func() bool { return len(eventsCh) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestConsensusEventsBlockingObserver(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := testlog.Logger(t, log.LvlCrit)
+	borConfig := &borcfg.BorConfig{Sprint: map[string]uint64{"0": 16}}
+	producers := testProducersReader(func(blockNum uint64) *valset.ValidatorSet {
+		return &valset.ValidatorSet{}
+	})
+
+	ce := NewConsensusEvents(logger, borConfig, producers)
+	go ce.Run(ctx) //nolint:errcheck
+
+	marker := make(chan struct{})
+	unblock := make(chan struct{})
+	var received atomic.Int64
+	var lastBlockNum atomic.Uint64
+	ce.RegisterConsensusEventObserver(func(e ConsensusEvent) {
+		if e.MilestoneId != nil {
+			close(marker)
+			return
+		}
+		<-unblock
+		received.Add(1)
+		lastBlockNum.Store(e.BlockNum)
+	})
+	ce.OnNewTip(&types.Header{Number: big.NewInt(1)})
+	ce.OnMilestoneFinalized(&heimdall.Milestone{Fields: heimdall.WaypointFields{EndBlock: big.NewInt(1)}})
+	<-marker
+
+	// observer is stuck: tips keep coming without blocking the caller
+	tipsDone := make(chan struct{})
+	go func() {
+		defer close(tipsDone)
+		for blockNum := int64(16); blockNum <= 16*10*consensusEventsQueueSize; blockNum += 16 {
+			ce.OnNewTip(&types.Header{Number: big.NewInt(blockNum)})
+		}
+	}()
+	select {
+	case <-tipsDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnNewTip blocked on observer")
+	}
+
+	// once observer is unstuck, tips which it didn't get to are coalesced into the last one
+	close(unblock)
+	require.Eventually(t, func() bool { return lastBlockNum.Load() == 16*10*consensusEventsQueueSize }, 5*time.Second, 10*time.Millisecond)
+	require.Less(t, received.Load(), int64(10*consensusEventsQueueSize))
+}
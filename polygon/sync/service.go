@@ -26,6 +26,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/event"
 	"github.com/erigontech/erigon-lib/gointerfaces/executionproto"
 	"github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -79,6 +80,7 @@ func NewService(
 	)
 	ccBuilderFactory := NewCanonicalChainBuilderFactory(chainConfig, borConfig, heimdallService, signaturesCache)
 	events := NewTipEvents(logger, p2pService, heimdallService, minedBlockReg)
+	consensusEvents := NewConsensusEvents(logger, borConfig, heimdallService)
 	sync := NewSync(
 		config,
 		logger,
@@ -95,6 +97,7 @@ func NewService(
 		notifications,
 		NewWiggleCalculator(borConfig, signaturesCache, heimdallService),
 		engineAPISwitcher,
+		consensusEvents,
 	)
 	return &Service{
 		logger:          logger,
@@ -102,6 +105,7 @@ func NewService(
 		p2pService:      p2pService,
 		store:           store,
 		events:          events,
+		consensusEvents: consensusEvents,
 		heimdallService: heimdallService,
 		bridgeService:   bridgeService,
 	}
@@ -113,10 +117,15 @@ type Service struct {
 	p2pService      *p2p.Service
 	store           Store
 	events          *TipEvents
+	consensusEvents *ConsensusEvents
 	heimdallService *heimdall.Service
 	bridgeService   *bridge.Service
 }
 
+func (s *Service) RegisterConsensusEventObserver(observer event.Observer[ConsensusEvent]) event.UnregisterFunc {
+	return s.consensusEvents.RegisterConsensusEventObserver(observer)
+}
+
 func (s *Service) Run(parentCtx context.Context) error {
 	defer s.logger.Info(syncLogPrefix("sync service component stopped"))
 	s.logger.Info(syncLogPrefix("running sync service component"))
@@ -143,6 +152,13 @@ func (s *Service) Run(parentCtx context.Context) error {
 
 		return nil
 	})
+	group.Go(func() error {
+		if err := s.consensusEvents.Run(ctx); err != nil {
+			return fmt.Errorf("pos sync consensus events failed: %w", err)
+		}
+
+		return nil
+	})
 	group.Go(func() error {
 		if err := s.heimdallService.Run(ctx); err != nil {
 			return fmt.Errorf("pos sync heimdall failed: %w", err)
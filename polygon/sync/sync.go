@@ -80,6 +80,7 @@ func NewSync(
 	notifications *shards.Notifications,
 	wiggleCalculator wiggleCalculator,
 	engineAPISwitcher EngineAPISwitcher,
+	consensusEvents *ConsensusEvents,
 ) *Sync {
 	badBlocksLru, err := simplelru.NewLRU[common.Hash, struct{}](1024, nil)
 	if err != nil {
@@ -103,6 +104,7 @@ func NewSync(
 		notifications:     notifications,
 		wiggleCalculator:  wiggleCalculator,
 		engineAPISwitcher: engineAPISwitcher,
+		consensusEvents:   consensusEvents,
 	}
 }
 
@@ -123,6 +125,7 @@ type Sync struct {
 	notifications     *shards.Notifications
 	wiggleCalculator  wiggleCalculator
 	engineAPISwitcher EngineAPISwitcher
+	consensusEvents   *ConsensusEvents // nil if nobody can subscribe to them (polygon sync stage)
 }
 
 func (s *Sync) commitExecution(ctx context.Context, newTip *types.Header, finalizedHeader *types.Header) error {
@@ -146,6 +149,9 @@ func (s *Sync) commitExecution(ctx context.Context, newTip *types.Header, finali
 	}
 
 	s.logger.Info(syncLogPrefix("update fork choice done"), "in", time.Since(fcStartTime))
+	if s.consensusEvents != nil {
+		s.consensusEvents.OnNewTip(newTip)
+	}
 	return nil
 }
 
@@ -231,6 +237,9 @@ func (s *Sync) applyNewMilestoneOnTip(ctx context.Context, event EventNewMilesto
 		return s.handleMilestoneTipMismatch(ctx, ccb, milestone)
 	}
 
+	if s.consensusEvents != nil {
+		s.consensusEvents.OnMilestoneFinalized(milestone)
+	}
 	return ccb.PruneRoot(milestone.EndBlock().Uint64())
 }
 
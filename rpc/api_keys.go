@@ -0,0 +1,126 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	APIKeyParam  = "apikey"
+)
+
+// APIKey - client of public endpoint. Sends key by header X-API-Key or by url parameter ?apikey=
+type APIKey struct {
+	Name  string   `json:"name"`
+	Key   string   `json:"key"`
+	Allow []string `json:"allow"` // methods "eth_call" or namespaces "eth_*". Empty - all methods enabled on server
+
+	requests metrics.Counter
+	denied   metrics.Counter
+}
+
+func (k *APIKey) allowed(method string) bool {
+	if len(k.Allow) == 0 {
+		return true
+	}
+	for _, allow := range k.Allow {
+		if allow == method {
+			return true
+		}
+		if namespace, ok := strings.CutSuffix(allow, "*"); ok && strings.HasPrefix(method, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeys - by key. nil - no authentication.
+type APIKeys map[string]*APIKey
+
+type apiKeysFile struct {
+	Keys []*APIKey `json:"keys"`
+}
+
+func ReadAPIKeys(path string) (APIKeys, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f apiKeysFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(f.Keys) == 0 {
+		return nil, fmt.Errorf("%s: no keys", path)
+	}
+	keys, names := APIKeys{}, map[string]struct{}{}
+	for _, k := range f.Keys {
+		if k.Name == "" || len(k.Key) < 16 {
+			return nil, fmt.Errorf("%s: key %q: name and key of at least 16 characters required", path, k.Name)
+		}
+		if _, ok := names[k.Name]; ok {
+			return nil, fmt.Errorf("%s: duplicated name %q", path, k.Name)
+		}
+		if _, ok := keys[k.Key]; ok {
+			return nil, fmt.Errorf("%s: key of %q is used by another name", path, k.Name)
+		}
+		k.requests = metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_api_key_requests{key="%s"}`, k.Name))
+		k.denied = metrics.GetOrCreateCounter(fmt.Sprintf(`rpc_api_key_denied{key="%s"}`, k.Name))
+		keys[k.Key], names[k.Name] = k, struct{}{}
+	}
+	return keys, nil
+}
+
+var errInvalidAPIKey = errors.New("missing or invalid api key")
+
+func (keys APIKeys) authenticate(r *http.Request) (*APIKey, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		key = r.URL.Query().Get(APIKeyParam)
+	}
+	if k, ok := keys[key]; ok {
+		return k, nil
+	}
+	return nil, errInvalidAPIKey
+}
+
+// checkAPIKey - method calls allowed for api key of connection, and accounts usage
+func checkAPIKey(info PeerInfo, method string) error {
+	k := info.APIKey
+	if k == nil {
+		return nil
+	}
+	if !k.allowed(method) {
+		k.denied.Inc()
+		return &apiKeyMethodNotAllowedError{key: k.Name, method: method}
+	}
+	k.requests.Inc()
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"keys": [
+		{"name": "alice", "key": "alice-0123456789abcdef", "allow": ["test_echo", "nftest_*"]},
+		{"name": "bob", "key": "bob-0123456789abcdef"}
+	]}`), 0600))
+	keys, err := ReadAPIKeys(path)
+	require.NoError(t, err)
+
+	logger := log.New()
+	s := newTestServer(logger)
+	defer s.Stop()
+	s.SetAPIKeys(keys)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	c, err := DialHTTP(ts.URL, logger)
+	require.NoError(t, err)
+	defer c.Close()
+	var res echoResult
+	err = c.Call(&res, "test_echo", "x", 1)
+	require.ErrorContains(t, err, http.StatusText(http.StatusUnauthorized))
+
+	c.SetHeader(APIKeyHeader, "alice-0123456789abcdef")
+	require.NoError(t, c.Call(&res, "test_echo", "x", 1))
+	var info PeerInfo
+	err = c.Call(&info, "test_peerInfo")
+	require.ErrorContains(t, err, "not allowed for api key alice")
+
+	byParam, err := DialHTTP(ts.URL+"?"+APIKeyParam+"=bob-0123456789abcdef", logger)
+	require.NoError(t, err)
+	defer byParam.Close()
+	require.NoError(t, byParam.Call(&info, "test_peerInfo"))
+	require.Nil(t, info.APIKey) // not exposed to clients
+}
+
+func TestReadAPIKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	for _, invalid := range []string{
+		`{"keys": []}`,
+		`{"keys": [{"name": "alice", "key": "short"}]}`,
+		`{"keys": [{"name": "alice", "key": "0123456789abcdef"}, {"name": "alice", "key": "0123456789abcdefg"}]}`,
+		`{"keys": [{"name": "alice", "key": "0123456789abcdef"}, {"name": "bob", "key": "0123456789abcdef"}]}`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(invalid), 0600))
+		_, err := ReadAPIKeys(path)
+		require.Error(t, err, invalid)
+	}
+	keys, err := ReadAPIKeys("")
+	require.NoError(t, err)
+	require.Nil(t, keys)
+}
@@ -47,7 +47,7 @@ func NewDirectBackend(api jsonrpc.EthAPI) DirectBackend {
 }
 
 func (b DirectBackend) CodeAt(ctx context.Context, account common.Address, blockNum *big.Int) ([]byte, error) {
-	return b.api.GetCode(ctx, account, BlockNumArg(blockNum))
+	return b.api.GetCode(ctx, account, BlockNumArg(blockNum), nil)
 }
 
 func (b DirectBackend) CallContract(ctx context.Context, callMsg ethereum.CallMsg, blockNum *big.Int) ([]byte, error) {
@@ -55,7 +55,7 @@ func (b DirectBackend) CallContract(ctx context.Context, callMsg ethereum.CallMs
 }
 
 func (b DirectBackend) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	return b.api.GetCode(ctx, account, PendingBlockNumArg())
+	return b.api.GetCode(ctx, account, PendingBlockNumArg(), nil)
 }
 
 func (b DirectBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
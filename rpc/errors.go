@@ -29,6 +29,7 @@ var (
 	_ Error = new(invalidMessageError)
 	_ Error = new(InvalidParamsError)
 	_ Error = new(CustomError)
+	_ Error = new(diskSpaceCriticalError)
 )
 
 const defaultErrorCode = -32000
@@ -84,6 +85,24 @@ func (e *UnsupportedForkError) ErrorCode() int { return -38005 }
 
 func (e *UnsupportedForkError) Error() string { return e.Message }
 
+// node serves only reads while free disk space is critical
+type diskSpaceCriticalError struct{ method string }
+
+func (e *diskSpaceCriticalError) ErrorCode() int { return -32002 } // EIP-1474: resource unavailable
+
+func (e *diskSpaceCriticalError) Error() string {
+	return fmt.Sprintf("the method %s is not available: node is read-only while free disk space is critically low", e.method)
+}
+
+// method is not in allowlist of api key
+type apiKeyMethodNotAllowedError struct{ key, method string }
+
+func (e *apiKeyMethodNotAllowedError) ErrorCode() int { return -32004 } // EIP-1474: method not supported
+
+func (e *apiKeyMethodNotAllowedError) Error() string {
+	return fmt.Sprintf("the method %s is not allowed for api key %s", e.method, e.key)
+}
+
 type CustomError struct {
 	Code    int
 	Message string
@@ -483,6 +483,11 @@ type RPCTransaction struct {
 	YParity              *hexutil.Big               `json:"yParity,omitempty"`
 	R                    *hexutil.Big               `json:"r"`
 	S                    *hexutil.Big               `json:"s"`
+
+	// OP Stack deposit transactions
+	SourceHash *common.Hash `json:"sourceHash,omitempty"`
+	Mint       *hexutil.Big `json:"mint,omitempty"`
+	IsSystemTx *bool        `json:"isSystemTx,omitempty"`
 }
 
 // NewRPCTransaction returns a transaction that will serialize to the RPC
@@ -518,6 +523,14 @@ func NewRPCTransaction(txn types.Transaction, blockHash common.Hash, blockNumber
 			result.ChainID = (*hexutil.Big)(chainId.ToBig())
 		}
 		result.GasPrice = (*hexutil.Big)(txn.GetTipCap().ToBig())
+	} else if deposit, ok := txn.(*types.DepositTx); ok {
+		// deposits are not signed and pay no gas on L2
+		result.GasPrice = (*hexutil.Big)(new(big.Int))
+		result.SourceHash = &deposit.SourceHash
+		if deposit.Mint != nil {
+			result.Mint = (*hexutil.Big)(deposit.Mint.ToBig())
+		}
+		result.IsSystemTx = &deposit.IsSystemTransaction
 	} else {
 		chainId.Set(txn.GetChainID())
 		result.ChainID = (*hexutil.Big)(chainId.ToBig())
@@ -32,6 +32,8 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon/rpc/rpccfg"
@@ -458,8 +460,25 @@ func (h *handler) isMethodAllowedByGranularControl(method string) bool {
 	return ok
 }
 
+// diskWriteMethods - methods which make node write to disk: txpool, peers db, unwind, audit log.
+// They are rejected while free disk space is critical, reads keep working.
+var diskWriteMethods = map[string]struct{}{
+	"eth_sendRawTransaction": {},
+	"eth_sendTransaction":    {},
+	"admin_addPeer":          {},
+	"admin_banPeer":          {},
+	"admin_unbanPeer":        {},
+	"admin_setPruneDistance": {},
+	"debug_setHead":          {},
+}
+
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream *jsoniter.Stream) *jsonrpcMessage {
+	if !msg.isUnsubscribe() {
+		if err := checkAPIKey(PeerInfoFromContext(cp.ctx), msg.Method); err != nil {
+			return msg.errorResponse(err)
+		}
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg, stream)
 	}
@@ -472,12 +491,20 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage, stream *jsoniter
 	if callb == nil {
 		return msg.errorResponse(&methodNotFoundError{method: msg.Method})
 	}
+	if _, ok := diskWriteMethods[msg.Method]; ok && disk.IsSpaceCritical() {
+		return msg.errorResponse(&diskSpaceCriticalError{method: msg.Method})
+	}
 	args, err := parsePositionalArguments(msg.Params, callb.argTypes)
 	if err != nil {
 		return msg.errorResponse(&InvalidParamsError{err.Error()})
 	}
 	start := time.Now()
-	answer := h.runMethod(cp.ctx, msg, callb, args, stream)
+	ctx, span := startCallSpan(cp.ctx, msg.Method)
+	var answer *jsonrpcMessage
+	dbg.WithProfileLabels(ctx, func(ctx context.Context) {
+		answer = h.runMethod(ctx, msg, callb, args, stream)
+	}, "module", "rpc", "method", msg.Method)
+	endCallSpan(span, answer)
 
 	// Collect the statistics for RPC calls if metrics is enabled.
 	// We only care about pure rpc call. Filter out subscription.
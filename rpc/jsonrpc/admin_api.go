@@ -21,15 +21,22 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/audit"
+	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/logging"
 )
 
 // AdminAPI the interface for the admin_* RPC commands.
 type AdminAPI interface {
-	// NodeInfo returns a collection of metadata known about the host.
-	NodeInfo(ctx context.Context) (*p2p.NodeInfo, error)
+	// NodeInfo returns a collection of metadata known about the host and build info of the binary serving RPC.
+	NodeInfo(ctx context.Context) (*NodeInfo, error)
 
 	// Peers returns information about the connected remote nodes.
 	// https://geth.ethereum.org/docs/rpc/ns-admin#admin_peers
@@ -37,21 +44,69 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// BanPeer disconnects a remote node and refuses connections to it for the given
+	// number of seconds, or indefinitely if omitted. Bans persist across restarts.
+	BanPeer(ctx context.Context, url string, duration *uint64) (bool, error)
+
+	// UnbanPeer lifts the ban of a remote node.
+	UnbanPeer(ctx context.Context, url string) (bool, error)
+
+	// BannedPeers returns the currently banned remote nodes.
+	BannedPeers(ctx context.Context) ([]*p2p.BannedPeerInfo, error)
+
+	// AuditLog returns up to limit entries of audit log of admin operations starting from id `from`.
+	AuditLog(ctx context.Context, from uint64, limit *int) ([]audit.Entry, error)
+
+	// SetLogLevel changes verbosity of this process logs without restart: of given module (like "p2p" or "eth/stagedsync")
+	// or of all logs if module is omitted. Level "default" resets it to command line value.
+	SetLogLevel(ctx context.Context, level string, module *string) (*LogLevels, error)
+
+	// LogLevels returns current verbosity of logs.
+	LogLevels(ctx context.Context) (*LogLevels, error)
+
+	// SetRpcGasCap changes gas cap of eth_call, eth_estimateGas, debug_traceCall, etc. without restart. 0 - back to --rpc.gascap.
+	SetRpcGasCap(ctx context.Context, gasCap hexutil.Uint64) (hexutil.Uint64, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
 type AdminAPIImpl struct {
+	*BaseAPI
 	ethBackend rpchelper.ApiBackend
+	db         kv.RoDB
 }
 
 // NewAdminAPI returns AdminAPIImpl instance.
-func NewAdminAPI(eth rpchelper.ApiBackend) *AdminAPIImpl {
+func NewAdminAPI(base *BaseAPI, eth rpchelper.ApiBackend, db kv.RoDB) *AdminAPIImpl {
 	return &AdminAPIImpl{
+		BaseAPI:    base,
 		ethBackend: eth,
+		db:         db,
 	}
 }
 
-func (api *AdminAPIImpl) NodeInfo(ctx context.Context) (*p2p.NodeInfo, error) {
+// LogLevels - verbosity of console, dir and per-module logs
+type LogLevels struct {
+	Console string            `json:"console"`
+	Dir     string            `json:"dir"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+const auditLogPageLimit = 1000
+
+// auditContext - node records origin of admin request in audit log
+func auditContext(ctx context.Context) context.Context {
+	return audit.WithOrigin(ctx, rpc.PeerInfoFromContext(ctx).Origin())
+}
+
+// NodeInfo - p2p info of the node and build info of the binary serving RPC (which is the node, or rpcdaemon of the
+// same release)
+type NodeInfo struct {
+	*p2p.NodeInfo
+	Build params.BuildInfo `json:"build"`
+}
+
+func (api *AdminAPIImpl) NodeInfo(ctx context.Context) (*NodeInfo, error) {
 	nodes, err := api.ethBackend.NodeInfo(ctx, 1)
 	if err != nil {
 		return nil, fmt.Errorf("node info request error: %w", err)
@@ -61,7 +116,7 @@ func (api *AdminAPIImpl) NodeInfo(ctx context.Context) (*p2p.NodeInfo, error) {
 		return nil, errors.New("empty nodesInfo response")
 	}
 
-	return &nodes[0], nil
+	return &NodeInfo{NodeInfo: &nodes[0], Build: params.GetBuildInfo()}, nil
 }
 
 func (api *AdminAPIImpl) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
@@ -69,7 +124,7 @@ func (api *AdminAPIImpl) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 }
 
 func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error) {
-	result, err := api.ethBackend.AddPeer(ctx, &remote.AddPeerRequest{Url: url})
+	result, err := api.ethBackend.AddPeer(auditContext(ctx), &remote.AddPeerRequest{Url: url})
 	if err != nil {
 		return false, err
 	}
@@ -78,3 +133,86 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) BanPeer(ctx context.Context, url string, duration *uint64) (bool, error) {
+	req := &remote.BanPeerRequest{Url: url}
+	if duration != nil {
+		req.Duration = *duration
+	}
+	result, err := api.ethBackend.BanPeer(auditContext(ctx), req)
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil banPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) UnbanPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.UnbanPeer(auditContext(ctx), &remote.UnbanPeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil unbanPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) BannedPeers(ctx context.Context) ([]*p2p.BannedPeerInfo, error) {
+	return api.ethBackend.BannedPeers(ctx)
+}
+
+func (api *AdminAPIImpl) AuditLog(ctx context.Context, from uint64, limit *int) ([]audit.Entry, error) {
+	pageLimit := auditLogPageLimit
+	if limit != nil && *limit > 0 && *limit < pageLimit {
+		pageLimit = *limit
+	}
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return audit.Read(tx, from, pageLimit)
+}
+
+func (api *AdminAPIImpl) SetLogLevel(ctx context.Context, level string, module *string) (*LogLevels, error) {
+	var m string
+	if module != nil {
+		m = *module
+	}
+	if level == "default" {
+		if err := logging.ResetLogLevel(m); err != nil {
+			return nil, err
+		}
+	} else {
+		lvl, err := logging.ParseLogLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("unknown log level %q", level)
+		}
+		if err := logging.SetLogLevel(m, lvl); err != nil {
+			return nil, err
+		}
+	}
+	log.Info("[admin] log level changed", "module", m, "level", level, "origin", rpc.PeerInfoFromContext(ctx).Origin())
+	return api.LogLevels(ctx)
+}
+
+func (api *AdminAPIImpl) LogLevels(ctx context.Context) (*LogLevels, error) {
+	console, dir, modules, err := logging.LogLevels()
+	if err != nil {
+		return nil, err
+	}
+	levels := &LogLevels{Console: console.String(), Dir: dir.String(), Modules: map[string]string{}}
+	for module, lvl := range modules {
+		levels.Modules[module] = lvl.String()
+	}
+	return levels, nil
+}
+
+func (api *AdminAPIImpl) SetRpcGasCap(ctx context.Context, gasCap hexutil.Uint64) (hexutil.Uint64, error) {
+	prev := api._gasCap.Swap(uint64(gasCap))
+	log.Info("[admin] rpc gas cap changed", "prev", prev, "new", uint64(gasCap), "origin", rpc.PeerInfoFromContext(ctx).Origin())
+	return hexutil.Uint64(prev), nil
+}
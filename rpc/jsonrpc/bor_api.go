@@ -22,10 +22,13 @@ import (
 	"reflect"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/execution/consensus"
 	"github.com/erigontech/erigon/polygon/bor"
 	"github.com/erigontech/erigon/polygon/bor/valset"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	polygonsync "github.com/erigontech/erigon/polygon/sync"
 	"github.com/erigontech/erigon/rpc"
 )
 
@@ -37,11 +40,19 @@ type BorAPI interface {
 	GetSnapshotAtHash(hash common.Hash) (*Snapshot, error)
 	GetSigners(number *rpc.BlockNumber) ([]common.Address, error)
 	GetSignersAtHash(hash common.Hash) ([]common.Address, error)
-	GetCurrentProposer() (common.Address, error)
-	GetCurrentValidators() ([]*valset.Validator, error)
+	GetCurrentProposer(blockNrOrHash *rpc.BlockNumberOrHash) (common.Address, error)
+	GetCurrentValidators(blockNrOrHash *rpc.BlockNumberOrHash) ([]*valset.Validator, error)
 	GetSnapshotProposer(blockNrOrHash *rpc.BlockNumberOrHash) (common.Address, error)
 	GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOrHash) (BlockSigners, error)
 	GetRootHash(start uint64, end uint64) (string, error)
+
+	// Milestone history related (see ./bor_milestones.go)
+	GetMilestoneByNumber(ctx context.Context, number hexutil.Uint64) (*heimdall.Milestone, error)
+	GetLatestMilestone(ctx context.Context) (*heimdall.Milestone, error)
+	GetMilestones(ctx context.Context, start hexutil.Uint64, pageSize hexutil.Uint64) (*MilestonesPage, error)
+
+	// Consensus events subscription (see ./bor_consensus_events.go)
+	ConsensusEvents(ctx context.Context) (*rpc.Subscription, error)
 }
 
 type spanProducersReader interface {
@@ -54,15 +65,26 @@ type BorImpl struct {
 	db                     kv.TemporalRoDB // the chain db
 	useSpanProducersReader bool
 	spanProducersReader    spanProducersReader
+	milestoneReader        milestoneReader // nil when milestones should be read from the chain db
+	consensusEvents        polygonsync.ConsensusEventsRegistrar
 }
 
 // NewBorAPI returns BorImpl instance
-func NewBorAPI(base *BaseAPI, db kv.TemporalRoDB, spanProducersReader spanProducersReader) *BorImpl {
+func NewBorAPI(base *BaseAPI, db kv.TemporalRoDB, spanProducersReader spanProducersReader, consensusEvents polygonsync.ConsensusEventsRegistrar) *BorImpl {
+	useSpanProducersReader := spanProducersReader != nil && !reflect.ValueOf(spanProducersReader).IsNil() // needed for interface nil caveat
+
+	var milestones milestoneReader
+	if useSpanProducersReader {
+		milestones, _ = spanProducersReader.(milestoneReader)
+	}
+
 	return &BorImpl{
 		BaseAPI:                base,
 		db:                     db,
-		useSpanProducersReader: spanProducersReader != nil && !reflect.ValueOf(spanProducersReader).IsNil(), // needed for interface nil caveat
+		useSpanProducersReader: useSpanProducersReader,
 		spanProducersReader:    spanProducersReader,
+		milestoneReader:        milestones,
+		consensusEvents:        consensusEvents,
 	}
 }
 
@@ -18,20 +18,25 @@ package jsonrpc
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/polygon/bor/valset"
+	"github.com/erigontech/erigon/polygon/heimdall"
 )
 
 func TestUseSpanProducersReader(t *testing.T) {
 	// test for Go's interface nil-ness caveat - https://codefibershq.com/blog/golang-why-nil-is-not-always-nil
 	var spr *mockSpanProducersReader
-	api := NewBorAPI(nil, nil, spr)
+	api := NewBorAPI(nil, nil, spr, nil)
 	require.False(t, api.useSpanProducersReader)
 	spr = &mockSpanProducersReader{}
-	api = NewBorAPI(nil, nil, spr)
+	api = NewBorAPI(nil, nil, spr, nil)
 	require.True(t, api.useSpanProducersReader)
 }
 
@@ -42,3 +47,110 @@ type mockSpanProducersReader struct{}
 func (m mockSpanProducersReader) Producers(context.Context, uint64) (*valset.ValidatorSet, error) {
 	panic("mock")
 }
+
+func TestSnapshotAtUsesSpanProducers(t *testing.T) {
+	ctx := context.Background()
+	validators := []*valset.Validator{
+		valset.NewValidator(common.HexToAddress("0x01"), 10),
+		valset.NewValidator(common.HexToAddress("0x02"), 20),
+	}
+	reader := &mockValidatorsSpanProducersReader{validatorSet: valset.NewValidatorSet(validators)}
+
+	// no bor engine is available, the snapshot must be built from the span producers alone
+	api := NewBorAPI(nil, nil, reader, nil)
+	header := &types.Header{Number: big.NewInt(1234)}
+	snap, err := api.snapshotAt(ctx, nil, header)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), reader.blockNum)
+	require.Equal(t, header.Hash(), snap.Hash)
+	require.ElementsMatch(t, []common.Address{validators[0].Address, validators[1].Address}, snap.signers())
+
+	proposerSnap, err := api.proposerSnapshotAt(ctx, nil, header)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), proposerSnap.Number)
+}
+
+type mockValidatorsSpanProducersReader struct {
+	validatorSet *valset.ValidatorSet
+	blockNum     uint64
+}
+
+func (m *mockValidatorsSpanProducersReader) Producers(_ context.Context, blockNum uint64) (*valset.ValidatorSet, error) {
+	m.blockNum = blockNum
+	return m.validatorSet, nil
+}
+
+func TestGetMilestones(t *testing.T) {
+	ctx := context.Background()
+	reader := &mockMilestoneReader{milestones: map[uint64]*heimdall.Milestone{}}
+	for _, id := range []uint64{3, 4, 6, 7} {
+		reader.milestones[id] = &heimdall.Milestone{Id: heimdall.MilestoneId(id)}
+	}
+
+	api := NewBorAPI(nil, nil, reader, nil)
+	require.NotNil(t, api.milestoneReader)
+
+	milestone, err := api.GetMilestoneByNumber(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, heimdall.MilestoneId(4), milestone.Id)
+
+	milestone, err = api.GetMilestoneByNumber(ctx, 5)
+	require.NoError(t, err)
+	require.Nil(t, milestone)
+
+	milestone, err = api.GetLatestMilestone(ctx)
+	require.NoError(t, err)
+	require.Equal(t, heimdall.MilestoneId(7), milestone.Id)
+
+	page, err := api.GetMilestones(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Milestones, 2)
+	require.Equal(t, heimdall.MilestoneId(3), page.Milestones[0].Id)
+	require.Equal(t, heimdall.MilestoneId(4), page.Milestones[1].Id)
+	require.NotNil(t, page.Next)
+
+	page, err = api.GetMilestones(ctx, *page.Next, 2)
+	require.NoError(t, err)
+	require.Len(t, page.Milestones, 2)
+	require.Equal(t, heimdall.MilestoneId(6), page.Milestones[0].Id)
+	require.Equal(t, heimdall.MilestoneId(7), page.Milestones[1].Id)
+	require.Nil(t, page.Next)
+
+	page, err = api.GetMilestones(ctx, 8, 2)
+	require.NoError(t, err)
+	require.Empty(t, page.Milestones)
+	require.Nil(t, page.Next)
+
+	_, err = api.GetMilestones(ctx, 0, 0)
+	require.Error(t, err)
+
+	// long range of missing ids: page ends after maxMilestonesScanned ids
+	reader.milestones[maxMilestonesScanned+10] = &heimdall.Milestone{Id: heimdall.MilestoneId(maxMilestonesScanned + 10)}
+	page, err = api.GetMilestones(ctx, 6, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Milestones, 2)
+	require.Equal(t, hexutil.Uint64(6+maxMilestonesScanned), *page.Next)
+
+	page, err = api.GetMilestones(ctx, *page.Next, 10)
+	require.NoError(t, err)
+	require.Len(t, page.Milestones, 1)
+	require.Nil(t, page.Next)
+}
+
+type mockMilestoneReader struct {
+	mockSpanProducersReader
+	milestones map[uint64]*heimdall.Milestone
+}
+
+func (m *mockMilestoneReader) Milestone(_ context.Context, id uint64) (*heimdall.Milestone, bool, error) {
+	milestone, ok := m.milestones[id]
+	return milestone, ok, nil
+}
+
+func (m *mockMilestoneReader) LastMilestoneId(context.Context) (uint64, bool, error) {
+	var lastId uint64
+	for id := range m.milestones {
+		lastId = max(lastId, id)
+	}
+	return lastId, len(m.milestones) > 0, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon-lib/log/v3"
+	polygonsync "github.com/erigontech/erigon/polygon/sync"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// consensusEventsSubscriptionBuffer is the number of events buffered per subscriber,
+// events are dropped for subscribers which fall further behind
+const consensusEventsSubscriptionBuffer = 128
+
+// ConsensusEvents streams span start/end, sprint start, producer set change and milestone
+// finalization events produced by the polygon sync (astrid). Usage: bor_subscribe("consensusEvents").
+func (api *BorImpl) ConsensusEvents(ctx context.Context) (*rpc.Subscription, error) {
+	if api.consensusEvents == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	events := make(chan polygonsync.ConsensusEvent, consensusEventsSubscriptionBuffer)
+	unregister := api.consensusEvents.RegisterConsensusEventObserver(func(e polygonsync.ConsensusEvent) {
+		select {
+		case events <- e:
+		default:
+			log.Warn("[rpc] dropping bor consensus event for slow subscriber", "type", e.Type, "blockNum", e.BlockNum)
+		}
+	})
+
+	go func() {
+		defer debug.LogPanic()
+		defer unregister()
+		for {
+			select {
+			case e := <-events:
+				if err := notifier.Notify(rpcSub.ID, e); err != nil {
+					log.Warn("[rpc] error while notifying subscription", "err", err)
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
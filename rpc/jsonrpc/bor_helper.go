@@ -162,9 +162,18 @@ func getUpdatedValidatorSet(oldValidatorSet *ValidatorSet, newVals []*valset.Val
 
 // author returns the Ethereum address recovered
 // from the signature in the header's extra-data section.
-func author(api *BorImpl, tx kv.Tx, header *types.Header) (common.Address, error) {
-	borEngine, _ := api.bor()
-	return ecrecover(header, borEngine.Config())
+func author(ctx context.Context, api *BorImpl, tx kv.Tx, header *types.Header) (common.Address, error) {
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	borConfig, ok := chainConfig.Bor.(*borcfg.BorConfig)
+	if !ok {
+		return common.Address{}, errors.New("not a bor chain")
+	}
+
+	return ecrecover(header, borConfig)
 }
 
 func rankMapDifficulties(values map[common.Address]uint64) []difficultiesKV {
@@ -0,0 +1,162 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+const (
+	// maxMilestonesPageSize caps the number of milestones returned by a single bor_getMilestones call
+	maxMilestonesPageSize = 1000
+	// maxMilestonesScanned caps the number of ids looked up by a single bor_getMilestones call: ids below
+	// the pruned range are missing, the page ends early with Next set then
+	maxMilestonesScanned = 10 * maxMilestonesPageSize
+)
+
+type milestoneReader interface {
+	Milestone(ctx context.Context, id uint64) (*heimdall.Milestone, bool, error)
+	LastMilestoneId(ctx context.Context) (uint64, bool, error)
+}
+
+// MilestonesPage is a single page of milestone history returned by bor_getMilestones
+type MilestonesPage struct {
+	Milestones []*heimdall.Milestone `json:"milestones"`
+	// Next is the id to pass as start of the following page, nil when the end of history is reached
+	Next *hexutil.Uint64 `json:"next"`
+}
+
+// GetMilestoneByNumber returns the milestone with the given sequential id, or nil if it is unknown or pruned.
+func (api *BorImpl) GetMilestoneByNumber(ctx context.Context, number hexutil.Uint64) (*heimdall.Milestone, error) {
+	milestone, ok, err := api.milestone(ctx, uint64(number))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return milestone, nil
+}
+
+// GetLatestMilestone returns the most recent milestone persisted by the node, or nil if there is none.
+func (api *BorImpl) GetLatestMilestone(ctx context.Context) (*heimdall.Milestone, error) {
+	lastId, ok, err := api.lastMilestoneId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return api.GetMilestoneByNumber(ctx, hexutil.Uint64(lastId))
+}
+
+// GetMilestones returns up to pageSize milestones in ascending id order starting at start.
+// Ids which are missing (e.g. pruned or not yet fetched) are skipped.
+func (api *BorImpl) GetMilestones(ctx context.Context, start hexutil.Uint64, pageSize hexutil.Uint64) (*MilestonesPage, error) {
+	if pageSize == 0 || uint64(pageSize) > maxMilestonesPageSize {
+		return nil, fmt.Errorf("page size must be between 1 and %d", maxMilestonesPageSize)
+	}
+
+	var tx kv.Tx // one tx for the whole page when milestones are read from the chain db
+	if api.milestoneReader == nil {
+		var err error
+		if tx, err = api.db.BeginTemporalRo(ctx); err != nil {
+			return nil, err
+		}
+		defer tx.Rollback()
+	}
+
+	lastId, ok, err := api.lastMilestoneIdTx(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &MilestonesPage{Milestones: []*heimdall.Milestone{}}
+	if !ok || uint64(start) > lastId {
+		return page, nil
+	}
+
+	id, scanEnd := uint64(start), uint64(start)+maxMilestonesScanned
+	for ; id <= lastId && id < scanEnd && uint64(len(page.Milestones)) < uint64(pageSize); id++ {
+		milestone, ok, err := api.milestoneTx(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		page.Milestones = append(page.Milestones, milestone)
+	}
+
+	if id <= lastId {
+		next := hexutil.Uint64(id)
+		page.Next = &next
+	}
+
+	return page, nil
+}
+
+func (api *BorImpl) milestone(ctx context.Context, id uint64) (*heimdall.Milestone, bool, error) {
+	if api.milestoneReader != nil {
+		return api.milestoneReader.Milestone(ctx, id)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	return api._blockReader.Milestone(ctx, tx, id)
+}
+
+func (api *BorImpl) lastMilestoneId(ctx context.Context) (uint64, bool, error) {
+	if api.milestoneReader != nil {
+		return api.milestoneReader.LastMilestoneId(ctx)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	return api._blockReader.LastMilestoneId(ctx, tx)
+}
+
+// milestoneTx - tx is nil when milestones are read from milestoneReader
+func (api *BorImpl) milestoneTx(ctx context.Context, tx kv.Tx, id uint64) (*heimdall.Milestone, bool, error) {
+	if api.milestoneReader != nil {
+		return api.milestoneReader.Milestone(ctx, id)
+	}
+	return api._blockReader.Milestone(ctx, tx, id)
+}
+
+func (api *BorImpl) lastMilestoneIdTx(ctx context.Context, tx kv.Tx) (uint64, bool, error) {
+	if api.milestoneReader != nil {
+		return api.milestoneReader.LastMilestoneId(ctx)
+	}
+	return api._blockReader.LastMilestoneId(ctx, tx)
+}
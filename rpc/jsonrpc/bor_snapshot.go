@@ -65,45 +65,11 @@ func (api *BorImpl) GetSnapshot(number *rpc.BlockNumber) (*Snapshot, error) {
 		return nil, errUnknownBlock
 	}
 
-	// init consensus db
-	borEngine, err := api.bor()
-	if err != nil {
-		return nil, err
-	}
-
-	borTx, err := borEngine.DB.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer borTx.Rollback()
-
-	if api.useSpanProducersReader {
-		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
-		if err != nil {
-			return nil, err
-		}
-
-		snap := &Snapshot{
-			Number:       header.Number.Uint64(),
-			Hash:         header.Hash(),
-			ValidatorSet: validatorSet,
-		}
-
-		return snap, nil
-	}
-
-	return snapshot(ctx, api, tx, borTx, header)
+	return api.snapshotAt(ctx, tx, header)
 }
 
 // GetAuthor retrieves the author a block.
 func (api *BorImpl) GetAuthor(blockNrOrHash *rpc.BlockNumberOrHash) (*common.Address, error) {
-	// init consensus db
-	borEngine, err := api.bor()
-
-	if err != nil {
-		return nil, err
-	}
-
 	ctx := context.Background()
 	tx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
@@ -112,30 +78,14 @@ func (api *BorImpl) GetAuthor(blockNrOrHash *rpc.BlockNumberOrHash) (*common.Add
 	defer tx.Rollback()
 
 	// Retrieve the requested block number (or current if none requested)
-	var header *types.Header
-
-	//nolint:nestif
-	if blockNrOrHash == nil {
-		header = rawdb.ReadCurrentHeader(tx)
-	} else {
-		if blockNr, ok := blockNrOrHash.Number(); ok {
-			header = rawdb.ReadHeaderByNumber(tx, uint64(blockNr))
-			if blockNr == rpc.LatestBlockNumber {
-				header = rawdb.ReadCurrentHeader(tx)
-			}
-		} else {
-			if blockHash, ok := blockNrOrHash.Hash(); ok {
-				header, err = rawdb.ReadHeaderByHash(tx, blockHash)
-			}
-		}
-	}
+	header, err := api.headerByNumberOrHash(ctx, tx, blockNrOrHash)
 
 	// Ensure we have an actually valid block and return its snapshot
 	if header == nil || err != nil {
 		return nil, errUnknownBlock
 	}
 
-	author, err := borEngine.Author(header)
+	author, err := author(ctx, api, tx, header)
 
 	return &author, err
 }
@@ -158,142 +108,22 @@ func (api *BorImpl) GetSnapshotAtHash(hash common.Hash) (*Snapshot, error) {
 		return nil, errUnknownBlock
 	}
 
-	// init consensus db
-	borEngine, err := api.bor()
-
-	if err != nil {
-		return nil, err
-	}
-
-	borTx, err := borEngine.DB.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer borTx.Rollback()
-
-	if api.useSpanProducersReader {
-		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
-		if err != nil {
-			return nil, err
-		}
-
-		snap := &Snapshot{
-			Number:       header.Number.Uint64(),
-			Hash:         header.Hash(),
-			ValidatorSet: validatorSet,
-		}
-
-		return snap, nil
-	}
-
-	return snapshot(ctx, api, tx, borTx, header)
+	return api.snapshotAt(ctx, tx, header)
 }
 
 // GetSigners retrieves the list of authorized signers at the specified block.
 func (api *BorImpl) GetSigners(number *rpc.BlockNumber) ([]common.Address, error) {
-	// init chain db
-	ctx := context.Background()
-	tx, err := api.db.BeginTemporalRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
-	// Retrieve the requested block number (or current if none requested)
-	var header *types.Header
-	if number == nil || *number == rpc.LatestBlockNumber {
-		header = rawdb.ReadCurrentHeader(tx)
-	} else {
-		header, _ = getHeaderByNumber(ctx, *number, api, tx)
-	}
-	// Ensure we have an actually valid block
-	if header == nil {
-		return nil, errUnknownBlock
-	}
-
-	// init consensus db
-	borEngine, err := api.bor()
-
-	if err != nil {
-		return nil, err
-	}
-
-	borTx, err := borEngine.DB.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer borTx.Rollback()
-
-	if api.useSpanProducersReader {
-		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
-		if err != nil {
-			return nil, err
-		}
-
-		snap := &Snapshot{
-			Number:       header.Number.Uint64(),
-			Hash:         header.Hash(),
-			ValidatorSet: validatorSet,
-		}
-
-		return snap.signers(), nil
-	}
-
-	snap, err := snapshot(ctx, api, tx, borTx, header)
+	snap, err := api.GetSnapshot(number)
 	if err != nil {
 		return nil, err
 	}
 
-	return snap.signers(), err
+	return snap.signers(), nil
 }
 
 // GetSignersAtHash retrieves the list of authorized signers at the specified block.
 func (api *BorImpl) GetSignersAtHash(hash common.Hash) ([]common.Address, error) {
-	// init chain db
-	ctx := context.Background()
-	tx, err := api.db.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
-	// Retreive the header
-	header, _ := getHeaderByHash(ctx, api, tx, hash)
-
-	// Ensure we have an actually valid block
-	if header == nil {
-		return nil, errUnknownBlock
-	}
-
-	// init consensus db
-	borEngine, err := api.bor()
-
-	if err != nil {
-		return nil, err
-	}
-
-	borTx, err := borEngine.DB.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer borTx.Rollback()
-
-	if api.useSpanProducersReader {
-		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
-		if err != nil {
-			return nil, err
-		}
-
-		snap := &Snapshot{
-			Number:       header.Number.Uint64(),
-			Hash:         header.Hash(),
-			ValidatorSet: validatorSet,
-		}
-
-		return snap.signers(), nil
-	}
-
-	snap, err := snapshot(ctx, api, tx, borTx, header)
+	snap, err := api.GetSnapshotAtHash(hash)
 	if err != nil {
 		return nil, err
 	}
@@ -301,18 +131,18 @@ func (api *BorImpl) GetSignersAtHash(hash common.Hash) ([]common.Address, error)
 	return snap.signers(), nil
 }
 
-// GetCurrentProposer gets the current proposer
-func (api *BorImpl) GetCurrentProposer() (common.Address, error) {
-	snap, err := api.GetSnapshot(nil)
+// GetCurrentProposer gets the proposer at the given block, or at the current block if none is requested
+func (api *BorImpl) GetCurrentProposer(blockNrOrHash *rpc.BlockNumberOrHash) (common.Address, error) {
+	snap, err := api.snapshotAtBlock(blockNrOrHash)
 	if err != nil {
 		return common.Address{}, err
 	}
 	return snap.ValidatorSet.GetProposer().Address, nil
 }
 
-// GetCurrentValidators gets the current validators
-func (api *BorImpl) GetCurrentValidators() ([]*valset.Validator, error) {
-	snap, err := api.GetSnapshot(nil)
+// GetCurrentValidators gets the validators at the given block, or at the current block if none is requested
+func (api *BorImpl) GetCurrentValidators(blockNrOrHash *rpc.BlockNumberOrHash) ([]*valset.Validator, error) {
+	snap, err := api.snapshotAtBlock(blockNrOrHash)
 	if err != nil {
 		return make([]*valset.Validator, 0), err
 	}
@@ -387,62 +217,15 @@ func (api *BorImpl) GetSnapshotProposer(blockNrOrHash *rpc.BlockNumberOrHash) (c
 	}
 	defer tx.Rollback()
 
-	var header *types.Header
-	//nolint:nestif
-	if blockNrOrHash == nil {
-		header = rawdb.ReadCurrentHeader(tx)
-	} else {
-		if blockNr, ok := blockNrOrHash.Number(); ok {
-			if blockNr == rpc.LatestBlockNumber {
-				header = rawdb.ReadCurrentHeader(tx)
-			} else {
-				header, err = getHeaderByNumber(ctx, blockNr, api, tx)
-			}
-		} else {
-			if blockHash, ok := blockNrOrHash.Hash(); ok {
-				header, err = getHeaderByHash(ctx, api, tx, blockHash)
-			}
-		}
-	}
-
+	header, err := api.headerByNumberOrHash(ctx, tx, blockNrOrHash)
 	if header == nil || err != nil {
 		return common.Address{}, errUnknownBlock
 	}
 
-	borEngine, err := api.bor()
-	if err != nil {
-		return common.Address{}, err
-	}
-
-	borTx, err := borEngine.DB.BeginRo(ctx)
+	snap, err := api.proposerSnapshotAt(ctx, tx, header)
 	if err != nil {
 		return common.Address{}, err
 	}
-	defer borTx.Rollback()
-
-	var snap *Snapshot
-	if api.useSpanProducersReader {
-		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
-		if err != nil {
-			return common.Address{}, err
-		}
-
-		snap = &Snapshot{
-			Number:       header.Number.Uint64(),
-			Hash:         header.Hash(),
-			ValidatorSet: validatorSet,
-		}
-	} else {
-		parent, err := getHeaderByNumber(ctx, rpc.BlockNumber(int64(header.Number.Uint64()-1)), api, tx)
-		if parent == nil || err != nil {
-			return common.Address{}, errUnknownBlock
-		}
-
-		snap, err = snapshot(ctx, api, tx, borTx, parent)
-		if err != nil {
-			return common.Address{}, err
-		}
-	}
 
 	return snap.ValidatorSet.GetProposer().Address, nil
 }
@@ -457,65 +240,18 @@ func (api *BorImpl) GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOr
 	defer tx.Rollback()
 
 	// Retrieve the requested block number (or current if none requested)
-	var header *types.Header
-	if blockNrOrHash == nil {
-		header = rawdb.ReadCurrentHeader(tx)
-	} else {
-		if blockNr, ok := blockNrOrHash.Number(); ok {
-			if blockNr == rpc.LatestBlockNumber {
-				header = rawdb.ReadCurrentHeader(tx)
-			} else {
-				header, err = getHeaderByNumber(ctx, blockNr, api, tx)
-			}
-		} else {
-			if blockHash, ok := blockNrOrHash.Hash(); ok {
-				header, err = getHeaderByHash(ctx, api, tx, blockHash)
-			}
-		}
-	}
+	header, err := api.headerByNumberOrHash(ctx, tx, blockNrOrHash)
 
 	// Ensure we have an actually valid block
 	if header == nil || err != nil {
 		return BlockSigners{}, errUnknownBlock
 	}
 
-	// init consensus db
-	borEngine, err := api.bor()
-
+	snap, err := api.proposerSnapshotAt(ctx, tx, header)
 	if err != nil {
 		return BlockSigners{}, err
 	}
 
-	borTx, err := borEngine.DB.BeginRo(ctx)
-	if err != nil {
-		return BlockSigners{}, err
-	}
-	defer borTx.Rollback()
-
-	var snap *Snapshot
-	if api.useSpanProducersReader {
-		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
-		if err != nil {
-			return BlockSigners{}, err
-		}
-
-		snap = &Snapshot{
-			Number:       header.Number.Uint64(),
-			Hash:         header.Hash(),
-			ValidatorSet: validatorSet,
-		}
-	} else {
-		parent, err := getHeaderByNumber(ctx, rpc.BlockNumber(int64(header.Number.Uint64()-1)), api, tx)
-		if parent == nil || err != nil {
-			return BlockSigners{}, errUnknownBlock
-		}
-
-		snap, err = snapshot(ctx, api, tx, borTx, parent)
-		if err != nil {
-			return BlockSigners{}, err
-		}
-	}
-
 	var difficulties = make(map[common.Address]uint64)
 
 	proposer := snap.ValidatorSet.GetProposer().Address
@@ -533,7 +269,7 @@ func (api *BorImpl) GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOr
 
 	rankedDifficulties := rankMapDifficulties(difficulties)
 
-	author, err := author(api, tx, header)
+	author, err := author(ctx, api, tx, header)
 	if err != nil {
 		return BlockSigners{}, err
 	}
@@ -548,6 +284,91 @@ func (api *BorImpl) GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOr
 	return blockSigners, nil
 }
 
+// headerByNumberOrHash resolves the requested header, or the current header if none is requested
+func (api *BorImpl) headerByNumberOrHash(ctx context.Context, tx kv.Tx, blockNrOrHash *rpc.BlockNumberOrHash) (*types.Header, error) {
+	if blockNrOrHash == nil {
+		return rawdb.ReadCurrentHeader(tx), nil
+	}
+
+	if blockNr, ok := blockNrOrHash.Number(); ok {
+		if blockNr == rpc.LatestBlockNumber {
+			return rawdb.ReadCurrentHeader(tx), nil
+		}
+		return getHeaderByNumber(ctx, blockNr, api, tx)
+	}
+
+	if blockHash, ok := blockNrOrHash.Hash(); ok {
+		return getHeaderByHash(ctx, api, tx, blockHash)
+	}
+
+	return nil, nil
+}
+
+// snapshotAtBlock retrieves the snapshot at the requested block, or at the current block if none is requested
+func (api *BorImpl) snapshotAtBlock(blockNrOrHash *rpc.BlockNumberOrHash) (*Snapshot, error) {
+	ctx := context.Background()
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	header, err := api.headerByNumberOrHash(ctx, tx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, errUnknownBlock
+	}
+
+	return api.snapshotAt(ctx, tx, header)
+}
+
+// snapshotAt retrieves the snapshot at the given header. With polygon sync it is backed
+// by the span block producer selections, so it works without the bor consensus engine,
+// otherwise it is rebuilt from the snapshots in the bor consensus db.
+func (api *BorImpl) snapshotAt(ctx context.Context, tx kv.Tx, header *types.Header) (*Snapshot, error) {
+	if api.useSpanProducersReader {
+		validatorSet, err := api.spanProducersReader.Producers(ctx, header.Number.Uint64())
+		if err != nil {
+			return nil, err
+		}
+
+		return &Snapshot{
+			Number:       header.Number.Uint64(),
+			Hash:         header.Hash(),
+			ValidatorSet: validatorSet,
+		}, nil
+	}
+
+	// init consensus db
+	borEngine, err := api.bor()
+	if err != nil {
+		return nil, err
+	}
+
+	borTx, err := borEngine.DB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer borTx.Rollback()
+
+	return snapshot(ctx, api, tx, borTx, header)
+}
+
+// proposerSnapshotAt retrieves the snapshot which determines the proposer of the given
+// header. The span producers are tracked per block, while bor db snapshots are taken
+// after applying a block, so the latter are read at the parent.
+func (api *BorImpl) proposerSnapshotAt(ctx context.Context, tx kv.Tx, header *types.Header) (*Snapshot, error) {
+	if api.useSpanProducersReader {
+		return api.snapshotAt(ctx, tx, header)
+	}
+
+	parent, err := getHeaderByNumber(ctx, rpc.BlockNumber(int64(header.Number.Uint64()-1)), api, tx)
+	if parent == nil || err != nil {
+		return nil, errUnknownBlock
+	}
+
+	return api.snapshotAt(ctx, tx, parent)
+}
+
 // GetRootHash returns the merkle root of the start to end block headers
 func (api *BorImpl) GetRootHash(start, end uint64) (string, error) {
 	borEngine, err := api.bor()
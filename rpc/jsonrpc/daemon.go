@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon/execution/consensus"
 	"github.com/erigontech/erigon/execution/consensus/clique"
 	"github.com/erigontech/erigon/polygon/bor"
+	polygonsync "github.com/erigontech/erigon/polygon/sync"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/turbo/services"
@@ -35,17 +36,20 @@ func APIList(db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPool txpool.TxpoolC
 	filters *rpchelper.Filters, stateCache kvcache.Cache,
 	blockReader services.FullBlockReader, cfg *httpcfg.HttpCfg, engine consensus.EngineReader,
 	logger log.Logger, bridgeReader bridgeReader, spanProducersReader spanProducersReader,
+	consensusEvents polygonsync.ConsensusEventsRegistrar,
 ) (list []rpc.API) {
 	base := NewBaseApi(filters, stateCache, blockReader, cfg.WithDatadir, cfg.EvmCallTimeout, engine, cfg.Dirs, bridgeReader)
+	base.expiredHistory = newExpiredHistory(cfg.Dirs, cfg.PortalURL, logger)
+	base.preconf = newPreconfStore(cfg.PreconfFeedURL, cfg.PreconfFeedType, logger)
 	ethImpl := NewEthAPI(base, db, eth, txPool, mining, cfg.Gascap, cfg.Feecap, cfg.ReturnDataLimit, cfg.AllowUnprotectedTxs, cfg.MaxGetProofRewindBlockCount, cfg.WebsocketSubscribeLogsChannelSize, logger)
-	erigonImpl := NewErigonAPI(base, db, eth)
+	erigonImpl := NewErigonAPI(base, db, eth, cfg.Gascap, cfg.LogsPageLimit)
 	txpoolImpl := NewTxPoolAPI(base, db, txPool)
 	netImpl := NewNetAPIImpl(eth)
 	debugImpl := NewPrivateDebugAPI(base, db, cfg.Gascap)
 	traceImpl := NewTraceAPI(base, db, cfg)
 	web3Impl := NewWeb3APIImpl(eth)
 	dbImpl := NewDBAPIImpl() /* deprecated */
-	adminImpl := NewAdminAPI(eth)
+	adminImpl := NewAdminAPI(base, eth, db)
 	parityImpl := NewParityAPIImpl(base, db)
 
 	var borImpl *BorImpl
@@ -57,10 +61,10 @@ func APIList(db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPool txpool.TxpoolC
 
 	switch engine := engine.(type) {
 	case *bor.Bor:
-		borImpl = NewBorAPI(base, db, spanProducersReader)
+		borImpl = NewBorAPI(base, db, spanProducersReader, consensusEvents)
 	case lazy:
 		if _, ok := engine.Engine().(*bor.Bor); !engine.HasEngine() || ok {
-			borImpl = NewBorAPI(base, db, spanProducersReader)
+			borImpl = NewBorAPI(base, db, spanProducersReader, consensusEvents)
 		}
 	}
 
@@ -27,6 +27,7 @@ import (
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
@@ -50,9 +51,17 @@ const AccountRangeMaxResults = 8192
 // be careful not overwhelming our clients or being stuck in db.
 const AccountRangeMaxResultsWithStorage = 256
 
+// StorageRangeMaxResults is the maximum number of storage slots returned by one debug_storageRangeAt call,
+// contracts with millions of slots must be paginated by NextKey.
+const StorageRangeMaxResults = 8192
+
+// StorageRangeMaxResultsWithProofs is the maximum number of storage slots returned by one debug_storageRangeAt
+// call with proofs - each slot has own merkle path.
+const StorageRangeMaxResultsWithProofs = 256
+
 // PrivateDebugAPI Exposed RPC endpoints for debugging use
 type PrivateDebugAPI interface {
-	StorageRangeAt(ctx context.Context, blockHash common.Hash, txIndex uint64, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int) (StorageRangeResult, error)
+	StorageRangeAt(ctx context.Context, blockHash common.Hash, txIndex uint64, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int, includeProofs *bool) (StorageRangeResult, error)
 	TraceTransaction(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error
 	TraceBlockByHash(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error
 	TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error
@@ -84,8 +93,23 @@ func NewPrivateDebugAPI(base *BaseAPI, db kv.TemporalRoDB, gascap uint64) *Priva
 	}
 }
 
-// storageRangeAt implements debug_storageRangeAt. Returns information about a range of storage locations (if any) for the given address.
-func (api *PrivateDebugAPIImpl) StorageRangeAt(ctx context.Context, blockHash common.Hash, txIndex uint64, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int) (StorageRangeResult, error) {
+// StorageRangeAt implements debug_storageRangeAt. Returns information about a range of storage locations (if any) for the given address.
+// Slots are ordered by plain key: pass NextKey of previous page as keyStart to read next page.
+// With includeProofs - txIndex must be amount of transactions in block, storage is read at state after the block
+// and Proof has merkle path of each returned slot against its stateRoot.
+func (api *PrivateDebugAPIImpl) StorageRangeAt(ctx context.Context, blockHash common.Hash, txIndex uint64, contractAddress common.Address, keyStart hexutil.Bytes, maxResult int, includeProofs *bool) (StorageRangeResult, error) {
+	withProofs := includeProofs != nil && *includeProofs
+	if len(keyStart) > length.Hash {
+		return StorageRangeResult{}, fmt.Errorf("keyStart too long: %d bytes, max %d", len(keyStart), length.Hash)
+	}
+	if withProofs {
+		if maxResult > StorageRangeMaxResultsWithProofs || maxResult <= 0 {
+			maxResult = StorageRangeMaxResultsWithProofs
+		}
+	} else if maxResult > StorageRangeMaxResults || maxResult <= 0 {
+		maxResult = StorageRangeMaxResults
+	}
+
 	tx, err := api.db.BeginTemporalRo(ctx)
 	if err != nil {
 		return StorageRangeResult{}, err
@@ -103,8 +127,37 @@ func (api *PrivateDebugAPIImpl) StorageRangeAt(ctx context.Context, blockHash co
 	if err != nil {
 		return StorageRangeResult{}, err
 	}
-	fromTxNum := minTxNum + txIndex + 1 //+1 for system txn in the beginning of block
-	return storageRangeAt(tx, contractAddress, keyStart, fromTxNum, maxResult)
+	if !withProofs {
+		fromTxNum := minTxNum + txIndex + 1 //+1 for system txn in the beginning of block
+		return storageRangeAt(tx, contractAddress, keyStart, fromTxNum, maxResult)
+	}
+
+	_, txCount, err := api._blockReader.Body(ctx, tx, blockHash, *number)
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+	if txIndex != uint64(txCount) {
+		return StorageRangeResult{}, fmt.Errorf("proofs available only at block boundary: txIndex must be %d (amount of transactions in block)", txCount)
+	}
+	maxTxNum, err := api._txNumReader.Max(tx, *number)
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+	// state after system txn in the end of block - the one stateRoot of block commits to
+	result, err := storageRangeAt(tx, contractAddress, keyStart, maxTxNum+1, maxResult)
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+	keys := make([]common.Hash, 0, len(result.Storage))
+	for _, entry := range result.Storage {
+		keys = append(keys, *entry.Key)
+	}
+	proofs, err := api.getProofs(ctx, tx, []common.Address{contractAddress}, [][]common.Hash{keys}, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*number)), api.db, log.Root())
+	if err != nil {
+		return StorageRangeResult{}, err
+	}
+	result.Proof = proofs[0]
+	return result, nil
 }
 
 // AccountRange implements debug_accountRange. Returns a range of accounts involved in the given block rangeb
@@ -473,11 +526,21 @@ func (api *PrivateDebugAPIImpl) GetBadBlocks(ctx context.Context) ([]map[string]
 			log.Error("Failed to marshal block", "err", err)
 			blockJson = map[string]interface{}{}
 		}
-		results = append(results, map[string]interface{}{
+		result := map[string]interface{}{
 			"hash":  block.Hash(),
 			"block": blockRlp,
 			"rlp":   blockJson,
-		})
+		}
+		bad, err := rawdb.ReadBadBlock(tx, block.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if bad != nil {
+			result["reason"] = bad.Reason
+			result["parentStateRoot"] = bad.ParentStateRoot
+			result["rejectedAt"] = hexutil.Uint64(bad.Time)
+		}
+		results = append(results, result)
 	}
 
 	return results, nil
@@ -38,6 +38,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/kv/stream"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/trie"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
 	"github.com/erigontech/erigon/eth/ethconfig"
@@ -228,8 +229,8 @@ func TestStorageRangeAt(t *testing.T) {
 		})
 		require.NoError(t, err)
 		addr := common.HexToAddress("0x537e697c7ab75a26f9ecf0ce810e3154dfcaaf55")
-		expect := StorageRangeResult{storageMap{}, nil}
-		result, err := api.StorageRangeAt(m.Ctx, block4.Hash(), 0, addr, nil, 100)
+		expect := StorageRangeResult{Storage: storageMap{}}
+		result, err := api.StorageRangeAt(m.Ctx, block4.Hash(), 0, addr, nil, 100, nil)
 		require.NoError(t, err)
 		require.Equal(t, expect, result)
 	})
@@ -248,9 +249,9 @@ func TestStorageRangeAt(t *testing.T) {
 		storage := storageMap{
 			keys[0]: {Key: &keys[1], Value: common.HexToHash("0000000000000000000000000d3ab14bbad3d99f4203bd7a11acb94882050e7e")},
 		}
-		expect := StorageRangeResult{storageMap{keys[0]: storage[keys[0]]}, nil}
+		expect := StorageRangeResult{Storage: storageMap{keys[0]: storage[keys[0]]}}
 
-		result, err := api.StorageRangeAt(m.Ctx, block4.Hash(), 0, addr, nil, 100)
+		result, err := api.StorageRangeAt(m.Ctx, block4.Hash(), 0, addr, nil, 100, nil)
 		require.NoError(t, err)
 		require.Equal(t, expect, result)
 	})
@@ -282,32 +283,63 @@ func TestStorageRangeAt(t *testing.T) {
 			keys[6]: {Key: &keys[7], Value: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000007")},
 		}
 		expect := StorageRangeResult{
-			storageMap{keys[0]: storage[keys[0]], keys[2]: storage[keys[2]], keys[4]: storage[keys[4]], keys[6]: storage[keys[6]]},
-			nil}
+			Storage: storageMap{keys[0]: storage[keys[0]], keys[2]: storage[keys[2]], keys[4]: storage[keys[4]], keys[6]: storage[keys[6]]},
+		}
 
-		result, err := api.StorageRangeAt(m.Ctx, latestBlock.Hash(), 0, addr, nil, 100)
+		result, err := api.StorageRangeAt(m.Ctx, latestBlock.Hash(), 0, addr, nil, 100, nil)
 		require.NoError(t, err)
 		if !reflect.DeepEqual(result, expect) {
 			t.Fatalf("wrong result:\ngot %s\nwant %s", dumper.Sdump(result), dumper.Sdump(&expect))
 		}
 
 		// limited
-		result, err = api.StorageRangeAt(m.Ctx, latestBlock.Hash(), 0, addr, nil, 2)
+		result, err = api.StorageRangeAt(m.Ctx, latestBlock.Hash(), 0, addr, nil, 2, nil)
 		require.NoError(t, err)
-		expect = StorageRangeResult{storageMap{keys[0]: storage[keys[0]], keys[2]: storage[keys[2]]}, &keys[5]}
+		expect = StorageRangeResult{Storage: storageMap{keys[0]: storage[keys[0]], keys[2]: storage[keys[2]]}, NextKey: &keys[5]}
 		if !reflect.DeepEqual(result, expect) {
 			t.Fatalf("wrong result:\ngot %s\nwant %s", dumper.Sdump(result), dumper.Sdump(&expect))
 		}
 
 		// start from something, limited
-		result, err = api.StorageRangeAt(m.Ctx, latestBlock.Hash(), 0, addr, expect.NextKey.Bytes(), 2)
+		result, err = api.StorageRangeAt(m.Ctx, latestBlock.Hash(), 0, addr, expect.NextKey.Bytes(), 2, nil)
 		require.NoError(t, err)
-		expect = StorageRangeResult{storageMap{keys[4]: storage[keys[4]], keys[6]: storage[keys[6]]}, nil}
+		expect = StorageRangeResult{Storage: storageMap{keys[4]: storage[keys[4]], keys[6]: storage[keys[6]]}}
 		if !reflect.DeepEqual(result, expect) {
 			t.Fatalf("wrong result:\ngot %s\nwant %s", dumper.Sdump(result), dumper.Sdump(&expect))
 		}
 	})
+	t.Run("block latest, addr 1, with proofs", func(t *testing.T) {
+		var latestBlock *types.Block
+		err := m.DB.View(m.Ctx, func(tx kv.Tx) (err error) {
+			latestBlock, err = m.BlockReader.CurrentBlock(tx)
+			return err
+		})
+		require.NoError(t, err)
+		addr := common.HexToAddress("0x537e697c7ab75a26f9ecf0ce810e3154dfcaaf44")
+		withProofs := true
+
+		txCount := uint64(len(latestBlock.Transactions()))
+		result, err := api.StorageRangeAt(m.Ctx, latestBlock.Hash(), txCount, addr, nil, 2, &withProofs)
+		require.NoError(t, err)
+		require.Len(t, result.Storage, 2)
+		require.NotNil(t, result.NextKey)
+		require.NotNil(t, result.Proof)
+		require.NoError(t, trie.VerifyAccountProof(latestBlock.Root(), result.Proof))
+		require.Len(t, result.Proof.StorageProof, 2)
+		for _, sp := range result.Proof.StorageProof {
+			require.NoError(t, trie.VerifyStorageProof(result.Proof.StorageHash, sp))
+		}
 
+		// next page continues from NextKey, proofs against same root
+		result, err = api.StorageRangeAt(m.Ctx, latestBlock.Hash(), txCount, addr, result.NextKey.Bytes(), 2, &withProofs)
+		require.NoError(t, err)
+		require.Len(t, result.Storage, 2)
+		require.Nil(t, result.NextKey)
+		require.NoError(t, trie.VerifyAccountProof(latestBlock.Root(), result.Proof))
+
+		_, err = api.StorageRangeAt(m.Ctx, latestBlock.Hash(), txCount+1, addr, nil, 2, &withProofs)
+		require.ErrorContains(t, err, "block boundary")
+	})
 }
 
 func TestAccountRange(t *testing.T) {
@@ -0,0 +1,65 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv/kvcfg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+var errAccessListsDisabled = errors.New("access lists recording is disabled, see --experiment.access.lists")
+
+// GetTransactionAccessList implements erigon_getTransactionAccessList. Returns EIP-2930 access list recorded
+// during execution of txn: all addresses and storage slots it accessed (also in reverted calls). Sender, recipient
+// and precompiles are warm anyway - so they are listed only with accessed storage slots.
+func (api *ErigonImpl) GetTransactionAccessList(ctx context.Context, txnHash common.Hash) (types.AccessList, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	enabled, err := kvcfg.AccessLists.Enabled(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, errAccessListsDisabled
+	}
+
+	blockNum, txNum, ok, err := api.txnLookup(ctx, tx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	al, ok, err := rawdb.ReadTxAccessList(tx, txNum)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("access list of %x is not recorded: block %d was not executed by this node", txnHash, blockNum)
+	}
+	return al, nil
+}
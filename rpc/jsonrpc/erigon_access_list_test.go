@@ -0,0 +1,70 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestGetTransactionAccessList(t *testing.T) {
+	require := require.New(t)
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t, mock.WithAccessLists())
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+
+	var withSlots int
+	for _, block := range chain.Blocks {
+		for _, txn := range block.Transactions() {
+			al, err := api.GetTransactionAccessList(ctx, txn.Hash())
+			require.NoError(err)
+			require.NotNil(al)
+
+			// sender and recipient are warm anyway - listed only with storage slots
+			sender, err := txn.Sender(*signer)
+			require.NoError(err)
+			for _, tuple := range al {
+				if len(tuple.StorageKeys) == 0 {
+					require.NotEqual(sender, tuple.Address)
+					if to := txn.GetTo(); to != nil {
+						require.NotEqual(*to, tuple.Address)
+					}
+				}
+				require.True(slices.IsSortedFunc(tuple.StorageKeys, func(a, b common.Hash) int { return a.Cmp(b) }))
+			}
+			require.True(slices.IsSortedFunc(al, func(a, b types.AccessTuple) int { return a.Address.Cmp(b.Address) }))
+			if al.StorageKeys() > 0 {
+				withSlots++
+			}
+		}
+	}
+	require.Positive(withSlots)
+
+	// unknown txn
+	al, err := api.GetTransactionAccessList(ctx, common.Hash{0xde, 0xad})
+	require.NoError(err)
+	require.Nil(al)
+}
@@ -18,14 +18,17 @@ package jsonrpc
 
 import (
 	"context"
+	"math"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
 	"github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 )
 
@@ -34,6 +37,7 @@ type ErigonAPI interface {
 	// System related (see ./erigon_system.go)
 	Forks(ctx context.Context) (Forks, error)
 	BlockNumber(ctx context.Context, rpcBlockNumPtr *rpc.BlockNumber) (hexutil.Uint64, error)
+	GetFinalityStatus(ctx context.Context) (*FinalityStatus, error)
 
 	// Blocks related (see ./erigon_blocks.go)
 	GetHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error)
@@ -41,30 +45,66 @@ type ErigonAPI interface {
 	GetBlockByTimestamp(ctx context.Context, timeStamp rpc.Timestamp, fullTx bool) (map[string]interface{}, error)
 	GetBalanceChangesInBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (map[common.Address]*hexutil.Big, error)
 
+	// Withdrawals and uncles of block ranges (see ./erigon_withdrawals.go)
+	GetWithdrawals(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, opts *WithdrawalsOptions) ([]*BlockWithdrawals, error)
+	GetWithdrawalsByAddress(ctx context.Context, addr common.Address, filter *WithdrawalsFilter) (*AddressWithdrawals, error)
+
 	// Receipt related (see ./erigon_receipts.go)
 	GetLogsByHash(ctx context.Context, hash common.Hash) ([][]*types.Log, error)
 	//GetLogsByNumber(ctx context.Context, number rpc.BlockNumber) ([][]*types.Log, error)
 	GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.ErigonLogs, error)
+	GetLogsPaged(ctx context.Context, crit filters.FilterCriteria, opts *LogsPageOptions) (*LogsPage, error)
 	GetLatestLogs(ctx context.Context, crit filters.FilterCriteria, logOptions filters.LogFilterOptions) (types.ErigonLogs, error)
+	GetLogByIndex(ctx context.Context, index hexutil.Uint64) (*IndexedLog, error)
+	GetLogsByIndexRange(ctx context.Context, from, to hexutil.Uint64) ([]*IndexedLog, error)
 	// Gets cannonical block receipt through hash. If the block is not cannonical returns error
 	GetBlockReceiptsByBlockHash(ctx context.Context, cannonicalBlockHash common.Hash) ([]map[string]interface{}, error)
 
+	// Light-client proofs (see ./erigon_header_proof.go)
+	GetHeadersMMRRoot(ctx context.Context) (*HeadersMMRRoot, error)
+	GetHeaderProof(ctx context.Context, number rpc.BlockNumber) (*HeaderProof, error)
+	GetReceiptProof(ctx context.Context, txnHash common.Hash) (*ReceiptProof, error)
+
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error)
+	// NodeStatus returns build and data provenance of the node (see ./erigon_nodeInfo.go)
+	NodeStatus(ctx context.Context) (*NodeStatus, error)
+
+	// State related (see ./erigon_proofs.go)
+	GetProofs(ctx context.Context, requests []ProofRequest, blockNrOrHash rpc.BlockNumberOrHash) (*accounts.MultiAccProofResult, error)
+
+	// Address appearances (see ./erigon_appearances.go)
+	GetAddressAppearances(ctx context.Context, addr common.Address, filter *AppearancesFilter) (*AddressAppearances, error)
+
+	// EIP-7702 delegations (see ./erigon_delegations.go)
+	GetDelegations(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, filter *DelegationsFilter) (*Delegations, error)
+
+	// Access lists recorded during execution (see ./erigon_access_list.go)
+	GetTransactionAccessList(ctx context.Context, txnHash common.Hash) (types.AccessList, error)
+
+	// Calls at mid-block state (see ./erigon_call.go)
+	CallAtTransaction(ctx context.Context, blockHash common.Hash, txIndex hexutil.Uint64, args ethapi.CallArgs) (hexutil.Bytes, error)
 }
 
 // ErigonImpl is implementation of the ErigonAPI interface
 type ErigonImpl struct {
 	*BaseAPI
-	db         kv.TemporalRoDB
-	ethBackend rpchelper.ApiBackend
+	db            kv.TemporalRoDB
+	ethBackend    rpchelper.ApiBackend
+	gasCap        uint64
+	logsPageLimit uint64
 }
 
 // NewErigonAPI returns ErigonImpl instance
-func NewErigonAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend) *ErigonImpl {
+func NewErigonAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend, gascap uint64, logsPageLimit uint64) *ErigonImpl {
+	if gascap == 0 {
+		gascap = uint64(math.MaxUint64 / 2)
+	}
 	return &ErigonImpl{
-		BaseAPI:    base,
-		db:         db,
-		ethBackend: eth,
+		BaseAPI:       base,
+		db:            db,
+		ethBackend:    eth,
+		gasCap:        gascap,
+		logsPageLimit: logsPageLimit,
 	}
 }
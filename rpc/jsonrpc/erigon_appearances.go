@@ -0,0 +1,171 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcfg"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+const (
+	defaultAppearancesPageSize = 100
+	maxAppearancesPageSize     = 1000
+)
+
+var errAppearancesDisabled = errors.New("address appearances index is disabled, see --experiment.address.appearances")
+
+// AppearancesFilter - parameters of erigon_getAddressAppearances. All fields are optional.
+type AppearancesFilter struct {
+	FromBlock *rpc.BlockNumber `json:"fromBlock"` // default: 0
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`   // default: latest executed block
+	PageSize  hexutil.Uint64   `json:"pageSize"`  // default: 100, max: 1000
+	PageToken *hexutil.Uint64  `json:"pageToken"` // nextPageToken of previous page
+	Reverse   bool             `json:"reverse"`   // newest first
+}
+
+// AddressAppearance - block-level appearances (block reward, withdrawal, system call) have no transaction
+type AddressAppearance struct {
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+	TransactionHash  *common.Hash    `json:"transactionHash"`
+}
+
+type AddressAppearances struct {
+	Appearances   []AddressAppearance `json:"appearances"`
+	NextPageToken *hexutil.Uint64     `json:"nextPageToken"` // nil - it's last page
+}
+
+// GetAddressAppearances implements erigon_getAddressAppearances. Returns transactions in which address appeared:
+// as sender or recipient of any call (including internal), emitter of log, address in indexed topic of log
+// (token transfers) - and blocks in which it received reward or withdrawal.
+func (api *ErigonImpl) GetAddressAppearances(ctx context.Context, addr common.Address, filter *AppearancesFilter) (*AddressAppearances, error) {
+	if filter == nil {
+		filter = &AppearancesFilter{}
+	}
+	pageSize := uint64(filter.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultAppearancesPageSize
+	}
+	if pageSize > maxAppearancesPageSize {
+		return nil, fmt.Errorf("pageSize %d is greater than max %d", pageSize, maxAppearancesPageSize)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	enabled, err := kvcfg.AddressAppearances.Enabled(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, errAppearancesDisabled
+	}
+
+	begin, end, err := api.appearancesBlockRange(ctx, tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	res := &AddressAppearances{Appearances: []AddressAppearance{}}
+	if begin > end {
+		return res, nil
+	}
+	fromTxNum, err := api._txNumReader.Min(tx, begin)
+	if err != nil {
+		return nil, err
+	}
+	toTxNum, err := api._txNumReader.Max(tx, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// [from, to) in Asc order, [from, to) with from > to in Desc order
+	from, to, asc := int(fromTxNum), int(toTxNum)+1, order.Asc
+	if filter.Reverse {
+		from, to, asc = int(toTxNum), int(fromTxNum)-1, order.Desc
+	}
+	if filter.PageToken != nil {
+		token := int(*filter.PageToken)
+		if (!filter.Reverse && (token < from || token >= to)) || (filter.Reverse && (token > from || token <= to)) {
+			return nil, fmt.Errorf("pageToken %d is out of requested blocks range", token)
+		}
+		from = token
+	}
+
+	txNums, err := tx.IndexRange(kv.AddrAppearanceIdx, addr[:], from, to, asc, int(pageSize)+1)
+	if err != nil {
+		return nil, err
+	}
+	defer txNums.Close()
+	it := rawdbv3.TxNums2BlockNums(tx, api._txNumReader, txNums, asc)
+	for it.HasNext() {
+		txNum, blockNum, txIndex, isFinalTxn, _, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(res.Appearances)) == pageSize {
+			next := hexutil.Uint64(txNum)
+			res.NextPageToken = &next
+			break
+		}
+		appearance := AddressAppearance{BlockNumber: hexutil.Uint64(blockNum)}
+		if txIndex >= 0 && !isFinalTxn {
+			txn, err := api._txnReader.TxnByIdxInBlock(ctx, tx, blockNum, txIndex)
+			if err != nil {
+				return nil, err
+			}
+			if txn != nil {
+				idx, hash := hexutil.Uint64(txIndex), txn.Hash()
+				appearance.TransactionIndex, appearance.TransactionHash = &idx, &hash
+			}
+		}
+		res.Appearances = append(res.Appearances, appearance)
+	}
+	return res, nil
+}
+
+func (api *ErigonImpl) appearancesBlockRange(ctx context.Context, tx kv.TemporalTx, filter *AppearancesFilter) (begin, end uint64, err error) {
+	end, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestExecutedBlockNumber), tx, api._blockReader, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	latest := end
+	if filter.ToBlock != nil {
+		if end, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(*filter.ToBlock), tx, api._blockReader, api.filters); err != nil {
+			return 0, 0, err
+		}
+		end = min(end, latest)
+	}
+	if filter.FromBlock != nil {
+		if begin, _, _, err = rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(*filter.FromBlock), tx, api._blockReader, api.filters); err != nil {
+			return 0, 0, err
+		}
+	}
+	return begin, end, nil
+}
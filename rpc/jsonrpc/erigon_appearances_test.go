@@ -0,0 +1,116 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/stream"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestGetAddressAppearances(t *testing.T) {
+	require := require.New(t)
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t, mock.WithAddressAppearances())
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	all, err := api.GetAddressAppearances(ctx, sender, nil)
+	require.NoError(err)
+	require.Nil(all.NextPageToken)
+	require.NotEmpty(all.Appearances)
+	first := all.Appearances[0]
+	require.Equal(hexutil.Uint64(1), first.BlockNumber)
+	require.NotNil(first.TransactionIndex)
+	require.Equal(chain.Blocks[0].Transactions()[0].Hash(), *first.TransactionHash)
+
+	// every txn found by trace indices is an appearance
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	from, err := tx.IndexRange(kv.TracesFromIdx, sender[:], -1, -1, order.Asc, kv.Unlim)
+	require.NoError(err)
+	to, err := tx.IndexRange(kv.TracesToIdx, sender[:], -1, -1, order.Asc, kv.Unlim)
+	require.NoError(err)
+	traced, err := stream.ToArrayU64(stream.Union[uint64](from, to, order.Asc, kv.Unlim))
+	require.NoError(err)
+	appearances, err := stream.ToArrayU64(mustIndexRange(t, tx, sender))
+	require.NoError(err)
+	for _, txNum := range traced {
+		require.Contains(appearances, txNum)
+	}
+	require.Len(all.Appearances, len(appearances))
+
+	// pages
+	var paged []AddressAppearance
+	filter := &AppearancesFilter{PageSize: 2}
+	for {
+		page, err := api.GetAddressAppearances(ctx, sender, filter)
+		require.NoError(err)
+		require.LessOrEqual(len(page.Appearances), 2)
+		paged = append(paged, page.Appearances...)
+		if page.NextPageToken == nil {
+			break
+		}
+		filter.PageToken = page.NextPageToken
+	}
+	require.Equal(all.Appearances, paged)
+
+	// reverse
+	reversed, err := api.GetAddressAppearances(ctx, sender, &AppearancesFilter{Reverse: true})
+	require.NoError(err)
+	slices.Reverse(reversed.Appearances)
+	require.Equal(all.Appearances, reversed.Appearances)
+
+	// blocks range
+	fromBlock, toBlock := rpc.BlockNumber(2), rpc.BlockNumber(3)
+	ranged, err := api.GetAddressAppearances(ctx, sender, &AppearancesFilter{FromBlock: &fromBlock, ToBlock: &toBlock})
+	require.NoError(err)
+	require.NotEmpty(ranged.Appearances)
+	for _, a := range ranged.Appearances {
+		require.True(a.BlockNumber >= 2 && a.BlockNumber <= 3)
+	}
+
+	// address which never appeared
+	none, err := api.GetAddressAppearances(ctx, common.Address{0xde, 0xad}, nil)
+	require.NoError(err)
+	require.Empty(none.Appearances)
+
+	_, err = api.GetAddressAppearances(ctx, sender, &AppearancesFilter{PageSize: maxAppearancesPageSize + 1})
+	require.Error(err)
+}
+
+func mustIndexRange(t *testing.T, tx kv.TemporalTx, addr common.Address) stream.U64 {
+	t.Helper()
+	it, err := tx.IndexRange(kv.AddrAppearanceIdx, addr[:], -1, -1, order.Asc, kv.Unlim)
+	require.NoError(t, err)
+	return it
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/transactions"
+)
+
+// CallAtTransaction implements erigon_callAtTransaction. Executes a new message call against the state as it was right after
+// transaction txIndex of given canonical block (before transaction txIndex+1), in context of this block.
+func (api *ErigonImpl) CallAtTransaction(ctx context.Context, blockHash common.Hash, txIndex hexutil.Uint64, args ethapi.CallArgs) (hexutil.Bytes, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNrOrHash := rpc.BlockNumberOrHashWithHash(blockHash, true)
+	blockNum, _, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	header, err := api._blockReader.Header(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %x not found", blockHash)
+	}
+	body, txCount, err := api._blockReader.Body(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if body == nil {
+		return nil, fmt.Errorf("block %x body not found", blockHash)
+	}
+	if uint64(txIndex) >= uint64(txCount) {
+		return nil, fmt.Errorf("txIndex %d out of range: block %d has %d transactions", txIndex, blockNum, txCount)
+	}
+
+	stateReader, err := rpchelper.CreateHistoryStateReader(tx, api._txNumReader, blockNum, int(txIndex)+1, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Gas == nil || uint64(*args.Gas) == 0 {
+		gasCap := hexutil.Uint64(api.rpcGasCap(api.gasCap))
+		args.Gas = &gasCap
+	}
+	result, err := transactions.DoCall(ctx, api.engine(), args, tx, blockNrOrHash, header, nil /* overrides */, api.rpcGasCap(api.gasCap), chainConfig, stateReader, api._blockReader, api.evmCallTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Revert()) > 0 {
+		return nil, ethapi.NewRevertError(result)
+	}
+	return result.Return(), result.Err
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+func TestCallAtTransaction(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	// block 7: token deploy, mint of 100 to address2, then 32 transfers of 1 from address2
+	block, err := m.BlockReader.BlockByNumber(ctx, tx, 7)
+	require.NoError(t, err)
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	deployer := crypto.PubkeyToAddress(key.PublicKey)
+	key2, _ := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	holder := crypto.PubkeyToAddress(key2.PublicKey)
+	token := crypto.CreateAddress(deployer, block.Transactions()[0].GetNonce())
+
+	balanceOf := append(common.FromHex("0x70a08231"), common.LeftPadBytes(holder[:], 32)...)
+	input := hexutil.Bytes(balanceOf)
+	balanceAfter := func(txIndex uint64) uint64 {
+		res, err := api.CallAtTransaction(ctx, block.Hash(), hexutil.Uint64(txIndex), ethapi.CallArgs{To: &token, Input: &input})
+		require.NoError(t, err)
+		return new(uint256.Int).SetBytes(res).Uint64()
+	}
+	require.Equal(t, uint64(100), balanceAfter(1))
+	require.Equal(t, uint64(96), balanceAfter(5))
+	require.Equal(t, uint64(68), balanceAfter(33))
+
+	res, err := api.CallAtTransaction(ctx, block.Hash(), 0, ethapi.CallArgs{To: &token, Input: &input})
+	require.NoError(t, err)
+	require.Zero(t, new(uint256.Int).SetBytes(res).Uint64())
+
+	_, err = api.CallAtTransaction(ctx, block.Hash(), 34, ethapi.CallArgs{To: &token, Input: &input})
+	require.ErrorContains(t, err, "out of range")
+	_, err = api.CallAtTransaction(ctx, common.Hash{1}, 0, ethapi.CallArgs{To: &token, Input: &input})
+	require.Error(t, err)
+}
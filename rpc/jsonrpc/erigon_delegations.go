@@ -0,0 +1,120 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+const (
+	defaultDelegationsPageSize = 100
+	maxDelegationsPageSize     = 1000
+	// delegationsScanLimit - max accounts with code visited by one page, page may be returned incomplete
+	delegationsScanLimit = 100_000
+)
+
+// DelegationsFilter - parameters of erigon_getDelegations. All fields are optional.
+type DelegationsFilter struct {
+	Delegate  *common.Address `json:"delegate"`  // only accounts delegated to this address
+	PageSize  hexutil.Uint64  `json:"pageSize"`  // default: 100, max: 1000
+	PageToken *common.Address `json:"pageToken"` // nextPageToken of previous page
+}
+
+// Delegation - account delegated its code to Delegate by EIP-7702 authorization
+type Delegation struct {
+	Address  common.Address `json:"address"`
+	Delegate common.Address `json:"delegate"`
+}
+
+type Delegations struct {
+	Delegations   []Delegation    `json:"delegations"`
+	NextPageToken *common.Address `json:"nextPageToken"` // nil - it's last page
+}
+
+// GetDelegations implements erigon_getDelegations. Returns accounts which have active EIP-7702 delegation at
+// given block, ordered by address. Accounts with code are scanned, so a page may contain less than pageSize
+// delegations (even none) while nextPageToken is set.
+func (api *ErigonImpl) GetDelegations(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, filter *DelegationsFilter) (*Delegations, error) {
+	if filter == nil {
+		filter = &DelegationsFilter{}
+	}
+	pageSize := uint64(filter.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultDelegationsPageSize
+	}
+	if pageSize > maxDelegationsPageSize {
+		return nil, fmt.Errorf("pageSize %d is greater than max %d", pageSize, maxDelegationsPageSize)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, _, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	maxTxNum, err := api._txNumReader.Max(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	txNum := maxTxNum + 1 // state at the end of the block
+	if txNum < tx.HistoryStartFrom(kv.CodeDomain) {
+		return nil, fmt.Errorf("%w: code history of block %d", state.PrunedError, blockNum)
+	}
+
+	var from []byte
+	if filter.PageToken != nil {
+		from = filter.PageToken[:]
+	}
+	it, err := tx.RangeAsOf(kv.CodeDomain, from, nil, txNum, order.Asc, kv.Unlim) // unlim because need skip empty vals
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	res := &Delegations{Delegations: []Delegation{}}
+	for scanned := 0; it.HasNext(); scanned++ {
+		k, v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(res.Delegations)) == pageSize || scanned == delegationsScanLimit {
+			next := common.BytesToAddress(k)
+			res.NextPageToken = &next
+			break
+		}
+		delegate, ok := types.ParseDelegation(v)
+		if !ok || (filter.Delegate != nil && delegate != *filter.Delegate) {
+			continue
+		}
+		res.Delegations = append(res.Delegations, Delegation{Address: common.BytesToAddress(k), Delegate: delegate})
+	}
+	return res, nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestDelegations(t *testing.T) {
+	require := require.New(t)
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	erigonApi := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	token := crypto.CreateAddress(crypto.PubkeyToAddress(key.PublicKey), 2) // deployed in block 3
+
+	// contract code is not a delegation designator: resolving changes nothing
+	code, err := ethApi.GetCode(ctx, token, latest, nil)
+	require.NoError(err)
+	require.NotEmpty(code)
+	resolved, err := ethApi.GetCode(ctx, token, latest, &GetCodeOptions{ResolveDelegation: true})
+	require.NoError(err)
+	require.Equal(code, resolved)
+
+	delegate, err := ethApi.GetDelegation(ctx, token, latest)
+	require.NoError(err)
+	require.Nil(delegate)
+
+	delegations, err := erigonApi.GetDelegations(ctx, latest, nil)
+	require.NoError(err)
+	require.Empty(delegations.Delegations)
+	require.Nil(delegations.NextPageToken)
+
+	_, err = erigonApi.GetDelegations(ctx, latest, &DelegationsFilter{PageSize: maxDelegationsPageSize + 1})
+	require.Error(err)
+}
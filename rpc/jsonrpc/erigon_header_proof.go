@@ -0,0 +1,237 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/datastruct/mmr"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// maxHeadersMMRTail - headers which are not in headers MMR file (not frozen yet) are hashed on every request
+const maxHeadersMMRTail = 100_000
+
+// HeadersMMRRoot - root of Merkle Mountain Range over hashes of canonical headers 0..BlockNumber
+type HeadersMMRRoot struct {
+	Root        common.Hash    `json:"root"`
+	LeafCount   hexutil.Uint64 `json:"leafCount"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+}
+
+// HeaderProof - inclusion proof of header hash (leaf with index BlockNumber) in headers MMR with given root.
+// Can be verified by mmr.Verify.
+type HeaderProof struct {
+	Root        common.Hash    `json:"root"`
+	LeafCount   hexutil.Uint64 `json:"leafCount"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Siblings    []common.Hash  `json:"siblings"`
+	Peaks       []common.Hash  `json:"peaks"`
+}
+
+// ReceiptProof - proof of receipt (consensus encoding) in receipts trie of block: key is rlp(TransactionIndex)
+type ReceiptProof struct {
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	BlockHash        common.Hash     `json:"blockHash"`
+	ReceiptsRoot     common.Hash     `json:"receiptsRoot"`
+	TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+	Receipt          hexutil.Bytes   `json:"receipt"`
+	Proof            []hexutil.Bytes `json:"proof"`
+}
+
+// headersMMR - headers MMR file (--experiment.headers.mmr) extended in memory by not frozen headers up to latest block
+func (api *ErigonImpl) headersMMR(ctx context.Context, tx kv.Tx) (acc *mmr.MMR, latestHash common.Hash, closeFile func(), err error) {
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, common.Hash{}, nil, err
+	}
+	f, frozen, err := mmr.OpenFileReadOnly(freezeblocks.HeadersMMRPath(api.dirs))
+	if err != nil {
+		return nil, common.Hash{}, nil, err
+	}
+	if frozen.Leaves() > latest+1 { // execution is behind snapshots
+		latest = frozen.Leaves() - 1
+	}
+	if tail := latest + 1 - frozen.Leaves(); tail > maxHeadersMMRTail {
+		f.Close()
+		return nil, common.Hash{}, nil, fmt.Errorf("headers MMR covers %d blocks of %d: node must run with --experiment.headers.mmr", frozen.Leaves(), latest+1)
+	}
+	acc = mmr.New(mmr.NewMemStore(f, f.Nodes()), frozen.Leaves())
+	for n := frozen.Leaves(); n <= latest; n++ {
+		hash, ok, err := api._blockReader.CanonicalHash(ctx, tx, n)
+		if err != nil {
+			f.Close()
+			return nil, common.Hash{}, nil, err
+		}
+		if !ok {
+			f.Close()
+			return nil, common.Hash{}, nil, fmt.Errorf("canonical hash of block %d not found", n)
+		}
+		if err := acc.Append(hash); err != nil {
+			f.Close()
+			return nil, common.Hash{}, nil, err
+		}
+	}
+	if latestHash, err = acc.Leaf(latest); err != nil {
+		f.Close()
+		return nil, common.Hash{}, nil, err
+	}
+	return acc, latestHash, f.Close, nil
+}
+
+// GetHeadersMMRRoot implements erigon_getHeadersMMRRoot. Returns root of MMR over canonical headers up to latest block.
+func (api *ErigonImpl) GetHeadersMMRRoot(ctx context.Context) (*HeadersMMRRoot, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	acc, latestHash, closeFile, err := api.headersMMR(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+	root, err := acc.Root()
+	if err != nil {
+		return nil, err
+	}
+	return &HeadersMMRRoot{Root: root, LeafCount: hexutil.Uint64(acc.Leaves()), BlockNumber: hexutil.Uint64(acc.Leaves() - 1), BlockHash: latestHash}, nil
+}
+
+// GetHeaderProof implements erigon_getHeaderProof. Returns inclusion proof of canonical header against latest headers MMR root.
+func (api *ErigonImpl) GetHeaderProof(ctx context.Context, number rpc.BlockNumber) (*HeaderProof, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(number), tx, api._blockReader, nil)
+	if err != nil {
+		return nil, err
+	}
+	acc, _, closeFile, err := api.headersMMR(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFile()
+	root, err := acc.Root()
+	if err != nil {
+		return nil, err
+	}
+	proof, err := acc.Proof(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := acc.Leaf(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return &HeaderProof{
+		Root:        root,
+		LeafCount:   hexutil.Uint64(proof.LeafCount),
+		BlockNumber: hexutil.Uint64(blockNum),
+		BlockHash:   hash,
+		Siblings:    proof.Siblings,
+		Peaks:       proof.Peaks,
+	}, nil
+}
+
+// GetReceiptProof implements erigon_getReceiptProof. Returns proof of receipt against receiptsRoot of its header -
+// together with erigon_getHeaderProof it proves receipt against headers MMR root.
+func (api *ErigonImpl) GetReceiptProof(ctx context.Context, txnHash common.Hash) (*ReceiptProof, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockNum, _, ok, err := api.txnLookup(ctx, tx, txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	block, err := api.blockByNumberWithSenders(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+	receipts, err := api.getReceipts(ctx, tx, block)
+	if err != nil {
+		return nil, fmt.Errorf("getReceipts error: %w", err)
+	}
+
+	receiptsTrie := trie.NewInMemoryTrieRLPEncoded(nil) // same value encoding as types.DeriveSha
+	txIndex := -1
+	var buf bytes.Buffer
+	for i, r := range receipts {
+		if r.TxHash == txnHash {
+			txIndex = i
+		}
+		key, err := rlp.EncodeToBytes(uint64(i))
+		if err != nil {
+			return nil, err
+		}
+		buf.Reset()
+		receipts.EncodeIndex(i, &buf)
+		receiptsTrie.Update(key, common.Copy(buf.Bytes()))
+	}
+	if txIndex < 0 { // bor state sync txn is not in receipts trie
+		return nil, fmt.Errorf("receipt of %x is not in receipts trie of block %d", txnHash, blockNum)
+	}
+	if root := receiptsTrie.Hash(); root != block.ReceiptHash() {
+		return nil, fmt.Errorf("receipts root mismatch in block %d: %x != %x", blockNum, root, block.ReceiptHash())
+	}
+	key, err := rlp.EncodeToBytes(uint64(txIndex))
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := receiptsTrie.Prove(key, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	proof := make([]hexutil.Bytes, len(nodes))
+	for i, n := range nodes {
+		proof[i] = n
+	}
+	buf.Reset()
+	receipts.EncodeIndex(txIndex, &buf)
+	return &ReceiptProof{
+		BlockNumber:      hexutil.Uint64(blockNum),
+		BlockHash:        block.Hash(),
+		ReceiptsRoot:     block.ReceiptHash(),
+		TransactionIndex: hexutil.Uint64(txIndex),
+		Receipt:          common.Copy(buf.Bytes()),
+		Proof:            proof,
+	}, nil
+}
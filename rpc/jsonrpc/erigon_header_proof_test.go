@@ -0,0 +1,102 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/datastruct/mmr"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+func TestGetHeaderProof(t *testing.T) {
+	require := require.New(t)
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	hashes := []common.Hash{m.Genesis.Hash()}
+	for _, b := range chain.Blocks {
+		hashes = append(hashes, b.Hash())
+	}
+	expected := mmr.New(mmr.NewMemStore(nil, 0), 0)
+	for _, h := range hashes {
+		require.NoError(expected.Append(h))
+	}
+	expectedRoot, err := expected.Root()
+	require.NoError(err)
+
+	check := func() {
+		root, err := api.GetHeadersMMRRoot(ctx)
+		require.NoError(err)
+		require.Equal(expectedRoot, root.Root)
+		require.Equal(uint64(len(hashes)), uint64(root.LeafCount))
+		require.Equal(hashes[len(hashes)-1], root.BlockHash)
+
+		for i, h := range hashes {
+			proof, err := api.GetHeaderProof(ctx, rpc.BlockNumber(i))
+			require.NoError(err)
+			require.Equal(h, proof.BlockHash)
+			require.Equal(expectedRoot, proof.Root)
+			p := &mmr.Proof{LeafIndex: uint64(i), LeafCount: uint64(proof.LeafCount), Siblings: proof.Siblings, Peaks: proof.Peaks}
+			require.NoError(mmr.Verify(proof.Root, h, p), i)
+		}
+		_, err = api.GetHeaderProof(ctx, rpc.BlockNumber(len(hashes)))
+		require.Error(err)
+	}
+	check() // without MMR file: all headers are hashed in memory
+
+	// frozen part of headers is in MMR file
+	f, acc, err := mmr.OpenFile(freezeblocks.HeadersMMRPath(m.Dirs))
+	require.NoError(err)
+	for _, h := range hashes[:5] {
+		require.NoError(acc.Append(h))
+	}
+	require.NoError(f.Flush())
+	f.Close()
+	check()
+}
+
+func TestGetReceiptProof(t *testing.T) {
+	require := require.New(t)
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	for i, block := range chain.Blocks {
+		for j, txn := range block.Transactions() {
+			proof, err := api.GetReceiptProof(ctx, txn.Hash())
+			require.NoError(err)
+			require.Equal(block.Hash(), proof.BlockHash)
+			require.Equal(uint64(j), uint64(proof.TransactionIndex))
+			require.Equal(block.ReceiptHash(), proof.ReceiptsRoot)
+			// first node is root of receipts trie, last node contains receipt
+			require.Equal(proof.ReceiptsRoot, crypto.Keccak256Hash(proof.Proof[0]), "block %d", i+1)
+			require.Contains(string(proof.Proof[len(proof.Proof)-1]), string(proof.Receipt))
+		}
+	}
+	proof, err := api.GetReceiptProof(ctx, common.Hash{1})
+	require.NoError(err)
+	require.Nil(proof)
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/erigontech/erigon-db/rawdb/rawtemporaldb"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/eth/filters"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+var errGlobalLogIndexUnavailable = errors.New("global log index is not available: receipts domain was produced without it, regenerate it by `integration stage_custom_trace --domain=receipt`")
+
+// IndexedLog - log with its chain-global sequence number. Numbering starts at 0 from genesis and never has
+// gaps, so indexer can consume logs exactly once by remembering last globalIndex. Logs of bor state sync
+// txns are not numbered.
+type IndexedLog struct {
+	GlobalIndex hexutil.Uint64   `json:"globalIndex"`
+	Log         *types.ErigonLog `json:"log"`
+}
+
+// GetLogByIndex implements erigon_getLogByIndex. Returns log with given chain-global index, or nil if chain
+// doesn't have so many logs yet.
+func (api *ErigonImpl) GetLogByIndex(ctx context.Context, index hexutil.Uint64) (*IndexedLog, error) {
+	logs, err := api.GetLogsByIndexRange(ctx, index, index)
+	if err != nil || len(logs) == 0 {
+		return nil, err
+	}
+	return logs[0], nil
+}
+
+// GetLogsByIndexRange implements erigon_getLogsByIndexRange. Returns logs with chain-global index in [from, to],
+// at most --rpc.logs.page.limit of them. Less logs are returned only if chain doesn't have more yet.
+func (api *ErigonImpl) GetLogsByIndexRange(ctx context.Context, from, to hexutil.Uint64) ([]*IndexedLog, error) {
+	if to < from {
+		return nil, fmt.Errorf("to (%d) < from (%d)", to, from)
+	}
+	if uint64(to-from) >= api.logsPageLimit {
+		return nil, fmt.Errorf("max allowed range: %d logs", api.logsPageLimit)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	latest, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestExecutedBlockNumber), tx, api._blockReader, nil)
+	if err != nil {
+		return nil, err
+	}
+	maxTxNum, err := api._txNumReader.Max(tx, latest)
+	if err != nil {
+		return nil, err
+	}
+	var firstTxNum uint64 // of block 1, first block written by execution
+	if latest > 0 {
+		if firstTxNum, err = api._txNumReader.Min(tx, 1); err != nil {
+			return nil, err
+		}
+	}
+	total, err := globalLogsCount(tx, firstTxNum)
+	if err != nil {
+		return nil, err
+	}
+	// logsCount - amount of logs before txNum, GetAsOf is not defined for txNums after the last executed one
+	logsCount := func(txNum uint64) (uint64, error) {
+		if txNum > maxTxNum {
+			return total, nil
+		}
+		count, _, err := rawtemporaldb.LogsCountAsOf(tx, txNum)
+		return count, err
+	}
+	if uint64(from) >= total {
+		return []*IndexedLog{}, nil
+	}
+
+	// binary search of txn which produced the first log: logs count is non-decreasing over txNums
+	var searchErr error
+	txNum := uint64(sort.Search(int(maxTxNum+1), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+		count, err := logsCount(uint64(i) + 1) // logs up to and including txn i
+		searchErr = err
+		return count > uint64(from)
+	}))
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	_, blockNum, err := api._txNumReader.FindBlockNum(tx, txNum)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*IndexedLog, 0, to-from+1)
+	if err := api.iterateLogsV3(ctx, tx, blockNum, latest, txNum, filters.FilterCriteria{}, func(txNum uint64, logs []*types.ErigonLog) (bool, error) {
+		first, err := logsCount(txNum)
+		if err != nil {
+			return false, err
+		}
+		next, err := logsCount(txNum + 1)
+		if err != nil {
+			return false, err
+		}
+		if next == first { // bor state sync
+			return true, nil
+		}
+		if next-first != uint64(len(logs)) {
+			return false, fmt.Errorf("txNum %d has %d logs, but %d are numbered", txNum, len(logs), next-first)
+		}
+		for i, l := range logs {
+			index := first + uint64(i)
+			if index < uint64(from) {
+				continue
+			}
+			if index > uint64(to) {
+				return false, nil
+			}
+			res = append(res, &IndexedLog{GlobalIndex: hexutil.Uint64(index), Log: l})
+		}
+		return uint64(len(res)) <= uint64(to-from), nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// globalLogsCount - amount of logs in chain. Logs count must be present from firstTxNum: receipts domain downloaded
+// from snapshots may be produced without it, then only logs executed by this node are counted.
+func globalLogsCount(tx kv.TemporalTx, firstTxNum uint64) (uint64, error) {
+	v, _, err := tx.GetLatest(kv.ReceiptDomain, rawtemporaldb.LogsCountKey)
+	if err != nil {
+		return 0, err
+	}
+	if len(v) == 0 {
+		return 0, errGlobalLogIndexUnavailable
+	}
+	_, ok, err := rawtemporaldb.LogsCountAsOf(tx, firstTxNum+1)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errGlobalLogIndexUnavailable
+	}
+	total, _ := binary.Uvarint(v)
+	return total, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-db/rawdb/rawtemporaldb"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/filters"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestGetLogsByIndex(t *testing.T) {
+	require := require.New(t)
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	all, err := api.GetLogs(ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
+	require.NoError(err)
+	require.NotEmpty(all)
+
+	// global numbering follows chain order of logs, without gaps
+	indexed, err := api.GetLogsByIndexRange(ctx, 0, hexutil.Uint64(len(all)+10))
+	require.NoError(err)
+	require.Len(indexed, len(all))
+	for i, l := range indexed {
+		require.Equal(hexutil.Uint64(i), l.GlobalIndex)
+		require.Equal(all[i].TxHash, l.Log.TxHash)
+		require.Equal(all[i].Index, l.Log.Index)
+	}
+
+	for _, i := range []int{0, len(all) / 2, len(all) - 1} {
+		l, err := api.GetLogByIndex(ctx, hexutil.Uint64(i))
+		require.NoError(err)
+		require.Equal(indexed[i], l)
+	}
+
+	// range starting in the middle of txn logs
+	mid := len(all) / 2
+	part, err := api.GetLogsByIndexRange(ctx, hexutil.Uint64(mid), hexutil.Uint64(mid+2))
+	require.NoError(err)
+	require.Equal(indexed[mid:min(mid+3, len(indexed))], part)
+
+	none, err := api.GetLogByIndex(ctx, hexutil.Uint64(len(all)))
+	require.NoError(err)
+	require.Nil(none)
+
+	_, err = api.GetLogsByIndexRange(ctx, 0, 1000)
+	require.Error(err)
+}
+
+func TestGlobalLogsCountSyncedFromSnapshots(t *testing.T) {
+	require := require.New(t)
+	db := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	tx, err := db.BeginTemporalRw(context.Background())
+	require.NoError(err)
+	defer tx.Rollback()
+
+	doms, err := libstate.NewSharedDomains(tx, log.New())
+	require.NoError(err)
+	defer doms.Close()
+	doms.SetTx(tx)
+
+	// receipts before txNum 10 are from snapshots produced without logs count: node counts logs from txNum 10
+	doms.SetTxNum(10)
+	require.NoError(rawtemporaldb.AppendReceipt(doms, &types.Receipt{Logs: make(types.Logs, 2)}, 0))
+	doms.SetTxNum(11)
+	require.NoError(rawtemporaldb.AppendReceipt(doms, &types.Receipt{Logs: make(types.Logs, 1)}, 0))
+	require.NoError(doms.Flush(context.Background(), tx))
+
+	_, err = globalLogsCount(tx, 2)
+	require.ErrorIs(err, errGlobalLogIndexUnavailable)
+
+	// chain starting at txNum 10 is fully counted
+	total, err := globalLogsCount(tx, 10)
+	require.NoError(err)
+	require.Equal(uint64(3), total)
+}
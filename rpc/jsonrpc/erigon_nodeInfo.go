@@ -18,8 +18,20 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"unicode"
 
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc/rpchelper"
 )
 
 const (
@@ -30,3 +42,91 @@ const (
 func (api *ErigonImpl) NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error) {
 	return api.ethBackend.NodeInfo(ctx, allNodesInfo)
 }
+
+// NodeStatus - what fleet-management tooling needs for version audits: which binary serves RPC and which data
+// it serves
+type NodeStatus struct {
+	Build           params.BuildInfo `json:"build"`
+	ChainID         *hexutil.Big     `json:"chainId"`
+	ChainConfigHash common.Hash      `json:"chainConfigHash"` // keccak256 of JSON of chain config
+	GenesisHash     common.Hash      `json:"genesisHash"`
+	LatestBlock     hexutil.Uint64   `json:"latestBlock"`
+	FrozenBlocks    hexutil.Uint64   `json:"frozenBlocks"`
+	DBCreatedBy     string           `json:"dbCreatedBy,omitempty"`     // version of erigon which created the db
+	DBFirstSyncedBy string           `json:"dbFirstSyncedBy,omitempty"` // version of erigon which finished first sync
+	// SnapshotVersions - amount of snapshot files of each version of file format. Only for rpcdaemon with datadir
+	SnapshotVersions map[string]int `json:"snapshotVersions,omitempty"`
+}
+
+// NodeStatus implements erigon_nodeStatus. Returns build and data provenance of the node.
+func (api *ErigonImpl) NodeStatus(ctx context.Context) (*NodeStatus, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, genesis, err := api.chainConfigWithGenesis(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	chainConfigJson, err := json.Marshal(chainConfig)
+	if err != nil {
+		return nil, err
+	}
+	latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	syncing, err := api.ethBackend.Syncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &NodeStatus{
+		Build:           params.GetBuildInfo(),
+		ChainID:         (*hexutil.Big)(chainConfig.ChainID),
+		ChainConfigHash: crypto.Keccak256Hash(chainConfigJson),
+		GenesisHash:     genesis.Hash(),
+		LatestBlock:     hexutil.Uint64(latestBlock),
+		FrozenBlocks:    hexutil.Uint64(syncing.FrozenBlocks),
+	}
+
+	for key, version := range map[string]*string{params.VersionKeyCreated: &status.DBCreatedBy, params.VersionKeyFinished: &status.DBFirstSyncedBy} {
+		v, err := tx.GetOne(kv.DatabaseInfo, []byte(key))
+		if err != nil {
+			return nil, err
+		}
+		*version = string(v)
+	}
+
+	if api.dirs.Snap != "" {
+		if status.SnapshotVersions, err = snapshotVersions(api.dirs.Snap); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// snapshotVersions - amount of files of each version in snapshots dir and its sub-dirs: v1.0-000000-000500-headers.seg,
+// domain/v1.0-accounts.0-64.kv, ...
+func snapshotVersions(dir string) (map[string]int, error) {
+	res := map[string]int{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) { // files may be removed by merge
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || filepath.Ext(d.Name()) == ".torrent" {
+			return nil
+		}
+		version, _, ok := strings.Cut(d.Name(), "-")
+		if !ok || len(version) < 2 || version[0] != 'v' || !unicode.IsDigit(rune(version[1])) {
+			return nil
+		}
+		res[version]++
+		return nil
+	})
+	return res, err
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotVersions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "domain"), 0755))
+	for _, name := range []string{
+		"v1.0-000000-000500-headers.seg",
+		"v1.0-000000-000500-headers.seg.torrent",
+		"v1.0-000000-000500-headers.idx",
+		"v1.1-000500-001000-transactions.seg",
+		"domain/v1.0-accounts.0-64.kv",
+		"salt-blocks.txt",
+		"erigondb.toml",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644))
+	}
+
+	versions, err := snapshotVersions(dir)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"v1.0": 3, "v1.1": 1}, versions)
+
+	versions, err = snapshotVersions(filepath.Join(dir, "not-exists"))
+	require.NoError(t, err)
+	require.Empty(t, versions)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// maxGetProofsAccounts - limits amount of work of one erigon_getProofs request
+const maxGetProofsAccounts = 1024
+
+// ProofRequest - account and its storage keys to prove by erigon_getProofs
+type ProofRequest struct {
+	Address     common.Address  `json:"address"`
+	StorageKeys []hexutil.Bytes `json:"storageKeys"`
+}
+
+// GetProofs implements erigon_getProofs - same as eth_getProof for many accounts at once. Trie nodes are
+// included once in the result, use trie.VerifyMultiAccountProof or MultiAccProofResult.Proof to read it.
+func (api *ErigonImpl) GetProofs(ctx context.Context, requests []ProofRequest, blockNrOrHash rpc.BlockNumberOrHash) (*accounts.MultiAccProofResult, error) {
+	if len(requests) == 0 {
+		return nil, errors.New("no accounts requested")
+	}
+	if len(requests) > maxGetProofsAccounts {
+		return nil, fmt.Errorf("too many accounts requested: %d, max %d", len(requests), maxGetProofsAccounts)
+	}
+
+	roTx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer roTx.Rollback()
+
+	requestedBlockNr, _, _, err := rpchelper.GetCanonicalBlockNumber(ctx, blockNrOrHash, roTx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	} else if requestedBlockNr == 0 {
+		return nil, errors.New("block not found")
+	}
+
+	addresses := make([]common.Address, len(requests))
+	storageKeys := make([][]common.Hash, len(requests))
+	for i, req := range requests {
+		addresses[i] = req.Address
+		storageKeys[i] = make([]common.Hash, len(req.StorageKeys))
+		for j, s := range req.StorageKeys {
+			storageKeys[i][j].SetBytes(s)
+		}
+	}
+	proofs, err := api.getProofs(ctx, roTx, addresses, storageKeys, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(requestedBlockNr)), api.db, log.Root())
+	if err != nil {
+		return nil, err
+	}
+	return accounts.NewMultiAccProofResult(proofs), nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/trie"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestGetProofs(t *testing.T) {
+	m, bankAddr, contractAddr := chainWithDeployedContract(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+
+	key := func(b byte) hexutil.Bytes {
+		result := common.Hash{}
+		result[31] = b
+		return result.Bytes()
+	}
+	requests := []ProofRequest{
+		{Address: contractAddr, StorageKeys: []hexutil.Bytes{key(0), key(4), key(8), key(10)}},
+		{Address: bankAddr},
+		{Address: common.HexToAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddeaddead0"), StorageKeys: []hexutil.Bytes{key(0)}},
+	}
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(3)
+
+	proofs, err := api.GetProofs(context.Background(), requests, blockNrOrHash)
+	require.NoError(t, err)
+	require.Len(t, proofs.Accounts, len(requests))
+
+	tx, err := m.DB.BeginTemporalRo(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	header, err := api.headerByRPCNumber(context.Background(), 3, tx)
+	require.NoError(t, err)
+	require.NoError(t, trie.VerifyMultiAccountProof(header.Root, proofs))
+
+	// same proofs as eth_getProof, but common nodes are included once
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	nodes := 0
+	for i, req := range requests {
+		proof, err := proofs.Proof(i)
+		require.NoError(t, err)
+		expected, err := ethApi.GetProof(context.Background(), req.Address, req.StorageKeys, blockNrOrHash)
+		require.NoError(t, err)
+		require.Equal(t, expected, proof)
+
+		nodes += len(proof.AccountProof)
+		for _, storageProof := range proof.StorageProof {
+			nodes += len(storageProof.Proof)
+		}
+	}
+	require.Less(t, len(proofs.Nodes), nodes)
+	require.Equal(t, uint64(2), (*big.Int)(proofs.Accounts[0].StorageProof[0].Value).Uint64())
+
+	_, err = api.GetProofs(context.Background(), nil, blockNrOrHash)
+	require.Error(t, err)
+}
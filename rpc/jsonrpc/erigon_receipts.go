@@ -18,12 +18,15 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
 	"github.com/RoaringBitmap/roaring/v2"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -67,7 +70,6 @@ func (api *ErigonImpl) GetLogsByHash(ctx context.Context, hash common.Hash) ([][
 
 // GetLogs implements erigon_getLogs. Returns an array of logs matching a given filter object.
 func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.ErigonLogs, error) {
-	var begin, end uint64
 	erigonLogs := types.ErigonLogs{}
 
 	tx, beginErr := api.db.BeginTemporalRo(ctx)
@@ -76,10 +78,19 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 	}
 	defer tx.Rollback()
 
+	begin, end, ok, err := api.logsBlockRange(ctx, tx, crit)
+	if !ok {
+		return nil, err
+	}
+	return api.getLogsV3(ctx, tx, begin, end, crit)
+}
+
+// logsBlockRange - converts filter into block range [begin, end]. ok=false if block of crit.BlockHash is not found.
+func (api *ErigonImpl) logsBlockRange(ctx context.Context, tx kv.Tx, crit filters.FilterCriteria) (begin, end uint64, ok bool, err error) {
 	if crit.BlockHash != nil {
 		header, err := api._blockReader.HeaderByHash(ctx, tx, *crit.BlockHash)
 		if header == nil {
-			return nil, err
+			return 0, 0, false, err
 		}
 		begin = header.Number.Uint64()
 		end = header.Number.Uint64()
@@ -88,7 +99,7 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 		// Convert the RPC block numbers into internal representations
 		latest, err := rpchelper.GetLatestBlockNumber(tx)
 		if err != nil {
-			return nil, err
+			return 0, 0, false, err
 		}
 
 		begin = 0
@@ -96,7 +107,7 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 			if crit.FromBlock.Sign() >= 0 {
 				begin = crit.FromBlock.Uint64()
 			} else if !crit.FromBlock.IsInt64() || crit.FromBlock.Int64() != int64(rpc.LatestBlockNumber) {
-				return nil, fmt.Errorf("negative value for FromBlock: %v", crit.FromBlock)
+				return 0, 0, false, fmt.Errorf("negative value for FromBlock: %v", crit.FromBlock)
 			}
 		}
 		end = latest
@@ -104,18 +115,105 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 			if crit.ToBlock.Sign() >= 0 {
 				end = crit.ToBlock.Uint64()
 			} else if !crit.ToBlock.IsInt64() || crit.ToBlock.Int64() != int64(rpc.LatestBlockNumber) {
-				return nil, fmt.Errorf("negative value for ToBlock: %v", crit.ToBlock)
+				return 0, 0, false, fmt.Errorf("negative value for ToBlock: %v", crit.ToBlock)
 			}
 		}
 	}
 	if end < begin {
-		return nil, fmt.Errorf("end (%d) < begin (%d)", end, begin)
+		return 0, 0, false, fmt.Errorf("end (%d) < begin (%d)", end, begin)
 	}
 	if end > roaring.MaxUint32 {
-		return nil, fmt.Errorf("end (%d) > MaxUint32", end)
+		return 0, 0, false, fmt.Errorf("end (%d) > MaxUint32", end)
 	}
+	return begin, end, true, nil
+}
 
-	return api.getLogsV3(ctx, tx, begin, end, crit)
+// LogsPageOptions - options of erigon_getLogsPaged
+type LogsPageOptions struct {
+	PageSize hexutil.Uint64 `json:"pageSize"` // 0 - max allowed by server (--rpc.logs.page.limit)
+	Cursor   hexutil.Bytes  `json:"cursor"`   // Cursor of previous page, empty - first page
+}
+
+// LogsPage - result of erigon_getLogsPaged
+type LogsPage struct {
+	Logs   types.ErigonLogs `json:"logs"`
+	Cursor hexutil.Bytes    `json:"cursor,omitempty"` // Continuation of same filter. Absent - no more logs. Next page may be empty.
+}
+
+// logsCursor: txNum of next txn to scan + amount of its matching logs already returned
+const logsCursorLen = 8 + 4
+
+func encodeLogsCursor(txNum uint64, skip int) hexutil.Bytes {
+	c := make([]byte, logsCursorLen)
+	binary.BigEndian.PutUint64(c, txNum)
+	binary.BigEndian.PutUint32(c[8:], uint32(skip))
+	return c
+}
+
+func decodeLogsCursor(c hexutil.Bytes) (txNum uint64, skip int, err error) {
+	if len(c) == 0 {
+		return 0, 0, nil
+	}
+	if len(c) != logsCursorLen {
+		return 0, 0, fmt.Errorf("invalid cursor: %x", []byte(c))
+	}
+	return binary.BigEndian.Uint64(c), int(binary.BigEndian.Uint32(c[8:])), nil
+}
+
+// GetLogsPaged implements erigon_getLogsPaged. Same as erigon_getLogs, but returns logs page by page: every
+// page has at most opts.PageSize logs and cursor to request next page with same filter. Logs are scanned
+// lazily - huge block ranges don't need unbounded memory or time for one request.
+func (api *ErigonImpl) GetLogsPaged(ctx context.Context, crit filters.FilterCriteria, opts *LogsPageOptions) (*LogsPage, error) {
+	if opts == nil {
+		opts = &LogsPageOptions{}
+	}
+	pageSize := api.logsPageLimit
+	if opts.PageSize > 0 {
+		if uint64(opts.PageSize) > api.logsPageLimit {
+			return nil, fmt.Errorf("max allowed page size: %v", api.logsPageLimit)
+		}
+		pageSize = uint64(opts.PageSize)
+	}
+	if pageSize == 0 {
+		return nil, errors.New("page size must be positive")
+	}
+	fromTxNum, skip, err := decodeLogsCursor(opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	page := &LogsPage{Logs: types.ErigonLogs{}}
+	begin, end, ok, err := api.logsBlockRange(ctx, tx, crit)
+	if !ok {
+		return page, err
+	}
+	if err := api.iterateLogsV3(ctx, tx, begin, end, fromTxNum, crit, func(txNum uint64, logs []*types.ErigonLog) (bool, error) {
+		skipped := 0
+		if txNum == fromTxNum {
+			skipped = min(skip, len(logs))
+			logs = logs[skipped:]
+		}
+		if room := int(pageSize) - len(page.Logs); len(logs) > room {
+			page.Logs = append(page.Logs, logs[:room]...)
+			page.Cursor = encodeLogsCursor(txNum, skipped+room)
+			return false, nil
+		}
+		page.Logs = append(page.Logs, logs...)
+		if uint64(len(page.Logs)) == pageSize {
+			page.Cursor = encodeLogsCursor(txNum+1, 0)
+			return false, nil
+		}
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
 // GetLatestLogs implements erigon_getLatestLogs.
@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/crypto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -76,7 +77,7 @@ func TestErigonGetLatestLogs(t *testing.T) {
 	assert := assert.New(t)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, 5000000, 1000)
 	expectedLogs, _ := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
 
 	expectedErigonLogs := make(types.ErigonLogs, 0)
@@ -118,11 +119,65 @@ func TestErigonGetLatestLogs(t *testing.T) {
 	assert.Equal(expectedLog, actual[0])
 }
 
+func TestErigonGetLogsPaged(t *testing.T) {
+	require := require.New(t)
+	// every call emits 3 logs: LOG0 x3
+	emitter := common.HexToAddress("0xee")
+	m := mock.MockWithGenesis(t, &types.Genesis{
+		Config: chain.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			testAddr: {Balance: big.NewInt(1_000_000_000)},
+			emitter:  {Balance: common.Big0, Code: common.FromHex("0x60006000a060006000a060006000a000")},
+		},
+	}, testKey, false)
+	signer := types.LatestSignerForChainID(nil)
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 4, func(i int, block *core.BlockGen) {
+		for j := 0; j < 2; j++ {
+			txn, err := types.SignTx(types.NewTransaction(block.TxNonce(testAddr), emitter, uint256.NewInt(0), 50_000, uint256.NewInt(1), nil), *signer, testKey)
+			require.NoError(err)
+			block.AddTx(txn)
+		}
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chainPack))
+
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 5)
+	crit := filters.FilterCriteria{FromBlock: big.NewInt(2), ToBlock: big.NewInt(4)}
+	expected, err := api.GetLogs(m.Ctx, crit)
+	require.NoError(err)
+	require.Len(expected, 3*2*3)
+
+	for _, pageSize := range []uint64{0, 1, 2, 3, 4, 5} {
+		var all types.ErigonLogs
+		opts := &LogsPageOptions{PageSize: hexutil.Uint64(pageSize)}
+		for pages := 0; ; pages++ {
+			require.Less(pages, len(expected)+1, "pageSize=%d", pageSize)
+			page, err := api.GetLogsPaged(m.Ctx, crit, opts)
+			require.NoError(err)
+			require.LessOrEqual(len(page.Logs), 5)
+			if pageSize > 0 {
+				require.LessOrEqual(uint64(len(page.Logs)), pageSize)
+			}
+			all = append(all, page.Logs...)
+			if len(page.Cursor) == 0 {
+				break
+			}
+			opts.Cursor = page.Cursor
+		}
+		require.Equal(expected, all, "pageSize=%d", pageSize)
+	}
+
+	_, err = api.GetLogsPaged(m.Ctx, crit, &LogsPageOptions{PageSize: 6})
+	require.Error(err)
+	_, err = api.GetLogsPaged(m.Ctx, crit, &LogsPageOptions{Cursor: []byte{1}})
+	require.Error(err)
+}
+
 func TestErigonGetLatestLogsIgnoreTopics(t *testing.T) {
 	assert := assert.New(t)
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	db := m.DB
-	api := NewErigonAPI(newBaseApiForTest(m), db, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), db, nil, 5000000, 1000)
 	expectedLogs, _ := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(rpc.LatestBlockNumber.Int64())})
 
 	expectedErigonLogs := make([]*types.ErigonLog, 0)
@@ -209,7 +264,7 @@ func TestGetBlockReceiptsByBlockHash(t *testing.T) {
 	}
 	// Assemble the test environment
 	m := mockWithGenerator(t, 4, generator)
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
 
 	expect := map[uint64]string{
 		0: `[]`,
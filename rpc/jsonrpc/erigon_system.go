@@ -18,13 +18,10 @@ package jsonrpc
 
 import (
 	"context"
-	"errors"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon/p2p/forkid"
-	borfinality "github.com/erigontech/erigon/polygon/bor/finality"
-	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 )
@@ -84,16 +81,6 @@ func (api *ErigonImpl) BlockNumber(ctx context.Context, rpcBlockNumPtr *rpc.Bloc
 			return 0, err
 		}
 	case rpc.FinalizedBlockNumber:
-		if whitelist.GetWhitelistingService() != nil {
-			num := borfinality.GetFinalizedBlockNumber(tx)
-			if num == 0 {
-				return 0, errors.New("no finalized block")
-			}
-
-			blockNum = borfinality.CurrentFinalizedBlock(tx, num).NumberU64()
-			return hexutil.Uint64(blockNum), nil
-		}
-
 		blockNum, err = rpchelper.GetFinalizedBlockNumber(tx)
 		if err != nil {
 			return 0, err
@@ -107,3 +94,53 @@ func (api *ErigonImpl) BlockNumber(ctx context.Context, rpcBlockNumPtr *rpc.Bloc
 
 	return hexutil.Uint64(blockNum), nil
 }
+
+// FinalityTagStatus - block of "safe" or "finalized" tag, source it's resolved from and distance from latest block
+type FinalityTagStatus struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Source string         `json:"source"`
+	Lag    hexutil.Uint64 `json:"lag"`
+}
+
+// FinalityStatus - result of erigon_getFinalityStatus, nil tag - block is not known yet
+type FinalityStatus struct {
+	Latest    hexutil.Uint64     `json:"latest"`
+	Safe      *FinalityTagStatus `json:"safe"`
+	Finalized *FinalityTagStatus `json:"finalized"`
+}
+
+// GetFinalityStatus implements erigon_getFinalityStatus. Returns blocks of "safe" and "finalized" tags, with
+// their source (forkchoice of consensus layer, or polygon milestone/checkpoint) and lag behind latest block.
+func (api *ErigonImpl) GetFinalityStatus(ctx context.Context) (*FinalityStatus, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	tagStatus := func(b rpchelper.FinalizedBlock, ok bool, err error) (*FinalityTagStatus, error) {
+		if err != nil || !ok {
+			return nil, err
+		}
+		var lag uint64
+		if latest > b.Number {
+			lag = latest - b.Number
+		}
+		return &FinalityTagStatus{Number: hexutil.Uint64(b.Number), Hash: b.Hash, Source: b.Source, Lag: hexutil.Uint64(lag)}, nil
+	}
+
+	status := &FinalityStatus{Latest: hexutil.Uint64(latest)}
+	finality := rpchelper.Finality()
+	if status.Safe, err = tagStatus(finality.Safe(tx)); err != nil {
+		return nil, err
+	}
+	if status.Finalized, err = tagStatus(finality.Finalized(tx)); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
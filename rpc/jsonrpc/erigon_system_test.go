@@ -0,0 +1,68 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+func TestGetFinalityStatus(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	status, err := api.GetFinalityStatus(ctx)
+	require.NoError(t, err)
+	require.Nil(t, status.Safe)
+	require.Nil(t, status.Finalized)
+	finalizedTag, safeTag := rpc.FinalizedBlockNumber, rpc.SafeBlockNumber
+	_, err = api.BlockNumber(ctx, &finalizedTag)
+	require.ErrorIs(t, err, rpchelper.UnknownBlockError)
+
+	var safe, finalized common.Hash
+	require.NoError(t, m.DB.Update(ctx, func(tx kv.RwTx) error {
+		safe, finalized = rawdb.ReadHeaderByNumber(tx, 5).Hash(), rawdb.ReadHeaderByNumber(tx, 3).Hash()
+		rawdb.WriteForkchoiceSafe(tx, safe)
+		rawdb.WriteForkchoiceFinalized(tx, finalized)
+		return nil
+	}))
+
+	status, err = api.GetFinalityStatus(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, status.Finalized)
+	require.Equal(t, finalized, status.Finalized.Hash)
+	require.Equal(t, uint64(3), uint64(status.Finalized.Number))
+	require.Equal(t, rpchelper.FinalitySourceForkchoice, status.Finalized.Source)
+	require.Equal(t, uint64(status.Latest)-3, uint64(status.Finalized.Lag))
+	require.NotNil(t, status.Safe)
+	require.Equal(t, safe, status.Safe.Hash)
+	require.Equal(t, uint64(status.Latest)-5, uint64(status.Safe.Lag))
+
+	n, err := api.BlockNumber(ctx, &safeTag)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), uint64(n))
+}
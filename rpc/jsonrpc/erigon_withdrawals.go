@@ -0,0 +1,255 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcfg"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+const maxWithdrawalsBlockRange = 10_000
+
+var errWithdrawalsIndexDisabled = errors.New("withdrawals index is disabled, see --experiment.withdrawals.index")
+
+// WithdrawalsOptions - optional parameters of erigon_getWithdrawals
+type WithdrawalsOptions struct {
+	Format        string          `json:"format"`        // "json" (default) or "ssz" - List[Withdrawal] of the block, as in ExecutionPayload
+	Address       *common.Address `json:"address"`       // only withdrawals to this address
+	IncludeUncles bool            `json:"includeUncles"` // return uncles (ommers) headers too
+}
+
+// BlockWithdrawals - withdrawals (and uncles) of one block
+type BlockWithdrawals struct {
+	BlockNumber    hexutil.Uint64      `json:"blockNumber"`
+	Withdrawals    []*types.Withdrawal `json:"withdrawals,omitempty"`
+	WithdrawalsSSZ hexutil.Bytes       `json:"withdrawalsSsz,omitempty"`
+	Uncles         []*types.Header     `json:"uncles,omitempty"`
+}
+
+// GetWithdrawals implements erigon_getWithdrawals. Returns withdrawals of canonical blocks [fromBlock, toBlock],
+// blocks without them (after opts.Address filter) are skipped. Bodies are read without transactions, so it's
+// much cheaper than eth_getBlockByNumber of every block.
+func (api *ErigonImpl) GetWithdrawals(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, opts *WithdrawalsOptions) ([]*BlockWithdrawals, error) {
+	if opts == nil {
+		opts = &WithdrawalsOptions{}
+	}
+	var asSSZ bool
+	switch opts.Format {
+	case "", "json":
+	case "ssz":
+		asSSZ = true
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected json or ssz", opts.Format)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	from, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(fromBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	to, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(toBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if to < from {
+		return nil, fmt.Errorf("toBlock (%d) < fromBlock (%d)", to, from)
+	}
+	if to-from >= maxWithdrawalsBlockRange {
+		return nil, fmt.Errorf("max allowed range: %d blocks", maxWithdrawalsBlockRange)
+	}
+
+	res := []*BlockWithdrawals{}
+	for blockNum := from; blockNum <= to; blockNum++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		body, err := api._blockReader.CanonicalBodyForStorage(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			return nil, fmt.Errorf("block %d not found", blockNum)
+		}
+
+		withdrawals := body.Withdrawals
+		if opts.Address != nil {
+			withdrawals = make([]*types.Withdrawal, 0, len(body.Withdrawals))
+			for _, w := range body.Withdrawals {
+				if w.Address == *opts.Address {
+					withdrawals = append(withdrawals, w)
+				}
+			}
+		}
+		bw := &BlockWithdrawals{BlockNumber: hexutil.Uint64(blockNum)}
+		if opts.IncludeUncles {
+			bw.Uncles = body.Uncles
+		}
+		if len(withdrawals) == 0 && len(bw.Uncles) == 0 {
+			continue
+		}
+		if asSSZ {
+			bw.WithdrawalsSSZ = withdrawalsSSZ(withdrawals)
+		} else {
+			bw.Withdrawals = withdrawals
+		}
+		res = append(res, bw)
+	}
+	return res, nil
+}
+
+// withdrawalsSSZ - Withdrawal is fixed-size container, so the list is concatenation of its items
+func withdrawalsSSZ(withdrawals []*types.Withdrawal) []byte {
+	buf := make([]byte, 0, len(withdrawals)*(3*8+20))
+	for _, w := range withdrawals {
+		buf = append(buf, ssz.Uint64SSZ(w.Index)...)
+		buf = append(buf, ssz.Uint64SSZ(w.Validator)...)
+		buf = append(buf, w.Address[:]...)
+		buf = append(buf, ssz.Uint64SSZ(w.Amount)...)
+	}
+	return buf
+}
+
+// WithdrawalsFilter - parameters of erigon_getWithdrawalsByAddress, same as of erigon_getAddressAppearances
+type WithdrawalsFilter = AppearancesFilter
+
+// AddressWithdrawal - one withdrawal to the address
+type AddressWithdrawal struct {
+	BlockNumber    hexutil.Uint64 `json:"blockNumber"`
+	Index          hexutil.Uint64 `json:"index"`
+	ValidatorIndex hexutil.Uint64 `json:"validatorIndex"`
+	Amount         hexutil.Uint64 `json:"amount"` // Gwei
+}
+
+type AddressWithdrawals struct {
+	Withdrawals   []AddressWithdrawal `json:"withdrawals"`
+	NextPageToken *hexutil.Uint64     `json:"nextPageToken"` // nil - it's last page
+}
+
+// GetWithdrawalsByAddress implements erigon_getWithdrawalsByAddress. Returns withdrawals to given execution-layer
+// address. Pages end at block boundary: page may have more than pageSize withdrawals, if many validators of the
+// same block withdraw to the address.
+func (api *ErigonImpl) GetWithdrawalsByAddress(ctx context.Context, addr common.Address, filter *WithdrawalsFilter) (*AddressWithdrawals, error) {
+	if filter == nil {
+		filter = &WithdrawalsFilter{}
+	}
+	pageSize := uint64(filter.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultAppearancesPageSize
+	}
+	if pageSize > maxAppearancesPageSize {
+		return nil, fmt.Errorf("pageSize %d is greater than max %d", pageSize, maxAppearancesPageSize)
+	}
+
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	enabled, err := kvcfg.WithdrawalsIndex.Enabled(tx)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, errWithdrawalsIndexDisabled
+	}
+
+	begin, end, err := api.appearancesBlockRange(ctx, tx, filter)
+	if err != nil {
+		return nil, err
+	}
+	res := &AddressWithdrawals{Withdrawals: []AddressWithdrawal{}}
+	if begin > end {
+		return res, nil
+	}
+	fromTxNum, err := api._txNumReader.Min(tx, begin)
+	if err != nil {
+		return nil, err
+	}
+	toTxNum, err := api._txNumReader.Max(tx, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// [from, to) in Asc order, [from, to) with from > to in Desc order
+	from, to, asc := int(fromTxNum), int(toTxNum)+1, order.Asc
+	if filter.Reverse {
+		from, to, asc = int(toTxNum), int(fromTxNum)-1, order.Desc
+	}
+	if filter.PageToken != nil {
+		token := int(*filter.PageToken)
+		if (!filter.Reverse && (token < from || token >= to)) || (filter.Reverse && (token > from || token <= to)) {
+			return nil, fmt.Errorf("pageToken %d is out of requested blocks range", token)
+		}
+		from = token
+	}
+
+	txNums, err := tx.IndexRange(kv.WithdrawalAddrIdx, addr[:], from, to, asc, kv.Unlim)
+	if err != nil {
+		return nil, err
+	}
+	defer txNums.Close()
+	it := rawdbv3.TxNums2BlockNums(tx, api._txNumReader, txNums, asc)
+	for it.HasNext() {
+		txNum, blockNum, _, _, _, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(res.Withdrawals)) >= pageSize {
+			next := hexutil.Uint64(txNum)
+			res.NextPageToken = &next
+			break
+		}
+		body, err := api._blockReader.CanonicalBodyForStorage(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if body == nil {
+			return nil, fmt.Errorf("block %d not found", blockNum)
+		}
+		start := len(res.Withdrawals)
+		for _, w := range body.Withdrawals {
+			if w.Address != addr {
+				continue
+			}
+			res.Withdrawals = append(res.Withdrawals, AddressWithdrawal{BlockNumber: hexutil.Uint64(blockNum),
+				Index: hexutil.Uint64(w.Index), ValidatorIndex: hexutil.Uint64(w.Validator), Amount: hexutil.Uint64(w.Amount)})
+		}
+		if filter.Reverse {
+			slices.Reverse(res.Withdrawals[start:])
+		}
+	}
+	return res, nil
+}
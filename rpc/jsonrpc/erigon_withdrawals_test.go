@@ -0,0 +1,79 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestGetWithdrawals(t *testing.T) {
+	require := require.New(t)
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	// pre-Shanghai chain without uncles: nothing to return
+	res, err := api.GetWithdrawals(ctx, 0, rpc.LatestBlockNumber, &WithdrawalsOptions{IncludeUncles: true})
+	require.NoError(err)
+	require.Empty(res)
+
+	_, err = api.GetWithdrawals(ctx, 0, rpc.LatestBlockNumber, &WithdrawalsOptions{Format: "rlp"})
+	require.Error(err)
+	_, err = api.GetWithdrawals(ctx, 3, 2, nil)
+	require.Error(err)
+}
+
+func TestWithdrawalsSSZ(t *testing.T) {
+	w := []*types.Withdrawal{{Index: 1, Validator: 2, Address: common.Address{3}, Amount: 4}, {Index: 5}}
+	enc := withdrawalsSSZ(w)
+	require.Len(t, enc, 2*44)
+	require.Equal(t, uint64(1), binary.LittleEndian.Uint64(enc[0:]))
+	require.Equal(t, uint64(2), binary.LittleEndian.Uint64(enc[8:]))
+	require.Equal(t, common.Address{3}, common.BytesToAddress(enc[16:36]))
+	require.Equal(t, uint64(4), binary.LittleEndian.Uint64(enc[36:]))
+	require.Equal(t, uint64(5), binary.LittleEndian.Uint64(enc[44:]))
+}
+
+func TestGetWithdrawalsByAddress(t *testing.T) {
+	require := require.New(t)
+	m, _, _ := rpcdaemontest.CreateTestSentry(t, mock.WithWithdrawalsIndex())
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
+	ctx := context.Background()
+
+	// pre-Shanghai chain: index is enabled, but has nothing
+	res, err := api.GetWithdrawalsByAddress(ctx, common.Address{1}, nil)
+	require.NoError(err)
+	require.Empty(res.Withdrawals)
+	require.Nil(res.NextPageToken)
+
+	res, err = api.GetWithdrawalsByAddress(ctx, common.Address{1}, &WithdrawalsFilter{Reverse: true, PageSize: 1})
+	require.NoError(err)
+	require.Empty(res.Withdrawals)
+
+	_, err = api.GetWithdrawalsByAddress(ctx, common.Address{1}, &WithdrawalsFilter{PageSize: maxAppearancesPageSize + 1})
+	require.Error(err)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"google.golang.org/grpc"
@@ -86,8 +87,14 @@ func (api *APIImpl) GetTransactionCount(ctx context.Context, address common.Addr
 	return (*hexutil.Uint64)(&acc.Nonce), err
 }
 
+// GetCodeOptions - optional 3rd parameter of eth_getCode
+type GetCodeOptions struct {
+	// ResolveDelegation - for account delegated by EIP-7702 return code of the delegate instead of the delegation designator
+	ResolveDelegation bool `json:"resolveDelegation"`
+}
+
 // GetCode implements eth_getCode. Returns the byte code at a given address (if it's a smart contract).
-func (api *APIImpl) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+func (api *APIImpl) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash, opts *GetCodeOptions) (hexutil.Bytes, error) {
 	tx, err1 := api.db.BeginTemporalRo(ctx)
 	if err1 != nil {
 		return nil, fmt.Errorf("getCode cannot open tx: %w", err1)
@@ -110,9 +117,40 @@ func (api *APIImpl) GetCode(ctx context.Context, address common.Address, blockNr
 	if res == nil {
 		return hexutil.Bytes(""), nil
 	}
+	if opts != nil && opts.ResolveDelegation {
+		// delegation is not followed further: code of the delegate is executed even if it's a designator itself
+		if delegate, ok := types.ParseDelegation(res); ok {
+			if res, _ = reader.ReadAccountCode(delegate); res == nil {
+				return hexutil.Bytes(""), nil
+			}
+		}
+	}
 	return res, nil
 }
 
+// GetDelegation implements eth_getDelegation. Returns the address to which the account delegated its code by
+// EIP-7702 authorization, or nil if the account is not delegated.
+func (api *APIImpl) GetDelegation(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*common.Address, error) {
+	tx, err := api.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getDelegation cannot open tx: %w", err)
+	}
+	defer tx.Rollback()
+	reader, err := rpchelper.CreateStateReader(ctx, tx, api._blockReader, blockNrOrHash, 0, api.filters, api.stateCache, "")
+	if err != nil {
+		return nil, err
+	}
+	code, err := reader.ReadAccountCode(address)
+	if err != nil {
+		return nil, err
+	}
+	delegate, ok := types.ParseDelegation(code)
+	if !ok {
+		return nil, nil
+	}
+	return &delegate, nil
+}
+
 // GetStorageAt implements eth_getStorageAt. Returns the value from a storage position at a given address.
 func (api *APIImpl) GetStorageAt(ctx context.Context, address common.Address, index string, blockNrOrHash rpc.BlockNumberOrHash) (string, error) {
 	var empty []byte
@@ -50,6 +50,7 @@ import (
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/ethapi"
 	"github.com/erigontech/erigon/rpc/jsonrpc/receipts"
+	"github.com/erigontech/erigon/rpc/preconf"
 	"github.com/erigontech/erigon/rpc/rpchelper"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
@@ -90,13 +91,17 @@ type EthAPI interface {
 	GetFilterChanges(_ context.Context, index string) ([]any, error)
 	GetFilterLogs(_ context.Context, index string) ([]*types.Log, error)
 	Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error)
+	StateDiffs(ctx context.Context, filter *StateDiffFilter) (*rpc.Subscription, error)
+	Reorgs(ctx context.Context) (*rpc.Subscription, error)
+	PreconfirmedTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error)
 
 	// Account related (see ./eth_accounts.go)
 	Accounts(ctx context.Context) ([]common.Address, error)
 	GetBalance(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error)
 	GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Uint64, error)
 	GetStorageAt(ctx context.Context, address common.Address, index string, blockNrOrHash rpc.BlockNumberOrHash) (string, error)
-	GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error)
+	GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash, opts *GetCodeOptions) (hexutil.Bytes, error)
+	GetDelegation(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*common.Address, error)
 
 	// System related (see ./eth_system.go)
 	BlockNumber(ctx context.Context) (hexutil.Uint64, error)
@@ -133,6 +138,7 @@ type BaseAPI struct {
 	_chainConfig atomic.Pointer[chain.Config]
 	_genesis     atomic.Pointer[types.Block]
 	_pruneMode   atomic.Pointer[prune.Mode]
+	_gasCap      atomic.Uint64 // set by admin_setRpcGasCap, 0 - --rpc.gascap is used
 
 	_blockReader services.FullBlockReader
 	_txNumReader rawdbv3.TxNumsReader
@@ -146,6 +152,8 @@ type BaseAPI struct {
 	dirs                datadir.Dirs
 	receiptsGenerator   *receipts.Generator
 	borReceiptGenerator *receipts.BorGenerator
+	expiredHistory      *expiredHistory // nil if node has all blocks it serves
+	preconf             *preconf.Store  // nil if there is no sequencer feed
 }
 
 func NewBaseApi(f *rpchelper.Filters, stateCache kvcache.Cache, blockReader services.FullBlockReader, singleNodeMode bool, evmCallTimeout time.Duration, engine consensus.EngineReader, dirs datadir.Dirs, bridgeReader bridgeReader) *BaseAPI {
@@ -232,6 +240,13 @@ func (api *BaseAPI) blockWithSenders(ctx context.Context, tx kv.Tx, hash common.
 		}
 	}
 	block, _, err := api._blockReader.BlockWithSenders(ctx, tx, hash, number)
+	if (err != nil || block == nil) && api.expiredHistory != nil {
+		if expired, expiredErr := api.expiredBlock(ctx, tx, hash, number); expiredErr != nil {
+			api.expiredHistory.logger.Debug("[rpc] expired block not found", "block", number, "err", expiredErr)
+		} else if expired != nil {
+			block, err = expired, nil
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -344,6 +359,8 @@ func (api *BaseAPI) checkPruneHistory(ctx context.Context, tx kv.Tx, block uint6
 			return nil
 		}
 		prunedTo := p.History.PruneTo(latest)
+		// distance changed by admin_setPruneDistance: history may be not pruned yet or already pruned - use stricter one
+		prunedTo = max(prunedTo, p.Runtime().History.PruneTo(latest))
 		if block < prunedTo {
 			return errors.New("history has been pruned for this block")
 		}
@@ -352,6 +369,14 @@ func (api *BaseAPI) checkPruneHistory(ctx context.Context, tx kv.Tx, block uint6
 	return nil
 }
 
+// rpcGasCap - gas cap set by admin_setRpcGasCap, or configured one
+func (api *BaseAPI) rpcGasCap(configured uint64) uint64 {
+	if gasCap := api._gasCap.Load(); gasCap != 0 {
+		return gasCap
+	}
+	return configured
+}
+
 func (api *BaseAPI) pruneMode(tx kv.Tx) (*prune.Mode, error) {
 	p := api._pruneMode.Load()
 	if p != nil {
@@ -387,6 +412,7 @@ type APIImpl struct {
 	AllowUnprotectedTxs         bool
 	MaxGetProofRewindBlockCount int
 	SubscribeLogsChannelSize    int
+	pendingView                 *pendingBlockView
 	logger                      log.Logger
 }
 
@@ -413,6 +439,7 @@ func NewEthAPI(base *BaseAPI, db kv.TemporalRoDB, eth rpchelper.ApiBackend, txPo
 		ReturnDataLimit:             returnDataLimit,
 		MaxGetProofRewindBlockCount: maxGetProofRewindBlockCount,
 		SubscribeLogsChannelSize:    subscribeLogsChannelSize,
+		pendingView:                 &pendingBlockView{},
 		logger:                      logger,
 	}
 }
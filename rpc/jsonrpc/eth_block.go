@@ -446,15 +446,20 @@ func (api *APIImpl) GetBlockTransactionCountByHash(ctx context.Context, blockHas
 }
 
 func (api *APIImpl) blockByNumber(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, error) {
+	if ttx, ok := tx.(kv.TemporalTx); ok && number == rpc.PreconfirmedBlockNumber {
+		block, _, err := api.preconfirmedBlock(ctx, ttx)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			return block, nil
+		}
+	}
 	if number != rpc.PendingBlockNumber {
 		return api.blockByRPCNumber(ctx, number, tx)
 	}
 
-	if block := api.pendingBlock(); block != nil {
-		return block, nil
-	}
-
-	block, err := api.ethBackend.PendingBlock(ctx)
+	block, err := api.minerPendingBlock(ctx, tx)
 	if err != nil {
 		return nil, err
 	}
@@ -462,5 +467,14 @@ func (api *APIImpl) blockByNumber(ctx context.Context, number rpc.BlockNumber, t
 		return block, nil
 	}
 
+	if ttx, ok := tx.(kv.TemporalTx); ok {
+		if block, _, err = api.builtPendingBlock(ctx, ttx); err != nil {
+			return nil, err
+		}
+		if block != nil {
+			return block, nil
+		}
+	}
+
 	return api.blockByRPCNumber(ctx, number, tx)
 }
@@ -73,7 +73,8 @@ func (api *APIImpl) Call(ctx context.Context, args ethapi2.CallArgs, blockNrOrHa
 	engine := api.engine()
 
 	if args.Gas == nil || uint64(*args.Gas) == 0 {
-		args.Gas = (*hexutil.Uint64)(&api.GasCap)
+		gasCap := hexutil.Uint64(api.rpcGasCap(api.GasCap))
+		args.Gas = &gasCap
 	}
 
 	header, err := headerByNumberOrHash(ctx, tx, blockNrOrHash, api)
@@ -88,7 +89,7 @@ func (api *APIImpl) Call(ctx context.Context, args ethapi2.CallArgs, blockNrOrHa
 	if err != nil {
 		return nil, err
 	}
-	result, err := transactions.DoCall(ctx, engine, args, tx, blockNrOrHash, header, overrides, api.GasCap, chainConfig, stateReader, api._blockReader, api.evmCallTimeout)
+	result, err := transactions.DoCall(ctx, engine, args, tx, blockNrOrHash, header, overrides, api.rpcGasCap(api.GasCap), chainConfig, stateReader, api._blockReader, api.evmCallTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -204,9 +205,9 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 		hi = header.GasLimit
 	}
 	// Recap the highest gas allowance with specified gascap.
-	if hi > api.GasCap {
-		log.Warn("Caller gas above allowance, capping", "requested", hi, "cap", api.GasCap)
-		hi = api.GasCap
+	if gasCap := api.rpcGasCap(api.GasCap); hi > gasCap {
+		log.Warn("Caller gas above allowance, capping", "requested", hi, "cap", gasCap)
+		hi = gasCap
 	}
 
 	var feeCap *big.Int
@@ -251,7 +252,7 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 		}
 	}
 
-	caller, err := transactions.NewReusableCaller(engine, stateReader, overrides, header, args, api.GasCap, *blockNrOrHash, dbtx, api._blockReader, chainConfig, api.evmCallTimeout)
+	caller, err := transactions.NewReusableCaller(engine, stateReader, overrides, header, args, api.rpcGasCap(api.GasCap), *blockNrOrHash, dbtx, api._blockReader, chainConfig, api.evmCallTimeout)
 	if err != nil {
 		return 0, err
 	}
@@ -276,32 +277,69 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	// We want to ensure that the gas used doesn't fall below this
 	trueGas := result.UsedGas // Must not fall below this
 	lo = max(trueGas+result.EvmRefund-1, params.TxGas-1)
+	if lo+1 >= hi {
+		return hexutil.Uint64(hi), nil
+	}
+
+	// Single-pass estimation instead of binary search: gas limit must cover used gas and refunds.
+	// Most txns need exactly this - then 1 verification is enough.
+	// Otherwise txn is sensitive to gas limit (63/64 of gas forwarded to sub-calls, stipend checks, GAS opcode) -
+	// then verify optimistic limit which covers them. Binary search is only fallback if both fail.
+	ok, err := estimateGasSucceeds(ctx, caller, engine, overrides, lo+1, trueGas)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return hexutil.Uint64(lo + 1), nil
+	}
+	lo++
+	if optimistic := optimisticGasLimit(trueGas, result.EvmRefund); optimistic > lo && optimistic < hi {
+		ok, err := estimateGasSucceeds(ctx, caller, engine, overrides, optimistic, trueGas)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return hexutil.Uint64(optimistic), nil
+		}
+		lo = optimistic
+	}
 
-	i := 0
 	// Execute the binary search and hone in on an executable gas limit
 	for lo+1 < hi {
 		mid := (hi + lo) / 2
-		if mid < trueGas {
-			lo = mid
-			continue
-		}
-		result, err := caller.DoCallWithNewGas(ctx, mid, engine, overrides)
-		// If the error is not nil(consensus error), it means the provided message
-		// call or transaction will never be accepted no matter how much gas it is
-		// assigened. Return the error directly, don't struggle any more.
-		if err != nil && !errors.Is(err, core.ErrIntrinsicGas) {
+		ok, err := estimateGasSucceeds(ctx, caller, engine, overrides, mid, trueGas)
+		if err != nil {
 			return 0, err
 		}
-		if errors.Is(err, core.ErrIntrinsicGas) || result.Failed() || result.UsedGas < trueGas {
-			lo = mid
-		} else {
+		if ok {
 			hi = mid
+		} else {
+			lo = mid
 		}
-		i++
 	}
 	return hexutil.Uint64(hi), nil
 }
 
+// optimisticGasLimit - gas limit which is enough for most of txns sensitive to gas limit:
+// sub-calls receive only 63/64 of available gas and value transfers need call stipend.
+func optimisticGasLimit(usedGas, refund uint64) uint64 {
+	return (usedGas + refund + params.CallStipend) * 64 / 63
+}
+
+func estimateGasSucceeds(ctx context.Context, caller *transactions.ReusableCaller, engine consensus.EngineReader, overrides *ethapi2.StateOverrides, gas, trueGas uint64) (bool, error) {
+	result, err := caller.DoCallWithNewGas(ctx, gas, engine, overrides)
+	// If the error is not nil(consensus error), it means the provided message
+	// call or transaction will never be accepted no matter how much gas it is
+	// assigned. Return the error directly, don't struggle any more.
+	if err != nil {
+		if errors.Is(err, core.ErrIntrinsicGas) {
+			return false, nil
+		}
+		return false, err
+	}
+	return !result.Failed() && result.UsedGas >= trueGas, nil
+}
+
 // GetProof implements eth_getProof partially; Proofs are available only with the `latest` block tag.
 func (api *APIImpl) GetProof(ctx context.Context, address common.Address, storageKeys []hexutil.Bytes, blockNrOrHash rpc.BlockNumberOrHash) (*accounts.AccProofResult, error) {
 	roTx, err := api.db.BeginRo(ctx)
@@ -321,11 +359,17 @@ func (api *APIImpl) GetProof(ctx context.Context, address common.Address, storag
 	for i, s := range storageKeys {
 		storageKeysConverted[i].SetBytes(s)
 	}
-	return api.getProof(ctx, roTx, address, storageKeysConverted, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(requestedBlockNr)), api.db, api.logger)
+	proofs, err := api.getProofs(ctx, roTx, []common.Address{address}, [][]common.Hash{storageKeysConverted}, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(requestedBlockNr)), api.db, api.logger)
+	if err != nil {
+		return nil, err
+	}
+	return proofs[0], nil
 }
 
-func (api *APIImpl) getProof(ctx context.Context, roTx kv.Tx, address common.Address, storageKeys []common.Hash, blockNrOrHash rpc.BlockNumberOrHash, db kv.RoDB, logger log.Logger) (*accounts.AccProofResult, error) {
-	tx, err := api.db.BeginTemporalRo(ctx)
+// getProofs - generates proofs of accounts and their storageKeys at blockNrOrHash. Merkle paths of all
+// accounts are loaded by one trie walk, so proofs of many accounts are cheaper than separate calls.
+func (api *BaseAPI) getProofs(ctx context.Context, roTx kv.Tx, addresses []common.Address, storageKeys [][]common.Hash, blockNrOrHash rpc.BlockNumberOrHash, db kv.TemporalRoDB, logger log.Logger) ([]*accounts.AccProofResult, error) {
+	tx, err := db.BeginTemporalRo(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -368,8 +412,10 @@ func (api *APIImpl) getProof(ctx context.Context, roTx kv.Tx, address common.Add
 		domains.SetTrace(false)
 	}
 
-	// touch account
-	sdCtx.TouchKey(kv.AccountsDomain, string(address.Bytes()), nil)
+	// touch accounts
+	for _, address := range addresses {
+		sdCtx.TouchKey(kv.AccountsDomain, string(address.Bytes()), nil)
+	}
 
 	// generate the trie for proofs, this works by loading the merkle paths to the touched keys
 	proofTrie, _, err := sdCtx.Witness(ctx, header.Root[:], "eth_getProof")
@@ -377,48 +423,57 @@ func (api *APIImpl) getProof(ctx context.Context, roTx kv.Tx, address common.Add
 		return nil, err
 	}
 
-	// set initial response fields
-	proof := &accounts.AccProofResult{
-		Address:      address,
-		Balance:      new(hexutil.Big),
-		Nonce:        hexutil.Uint64(0),
-		CodeHash:     common.Hash{},
-		StorageHash:  common.Hash{},
-		StorageProof: make([]accounts.StorProofResult, len(storageKeys)),
-	}
+	proofs := make([]*accounts.AccProofResult, len(addresses))
+	exists := make([]bool, len(addresses))
+	touchedStorage := false
+	for i, address := range addresses {
+		// set initial response fields
+		proof := &accounts.AccProofResult{
+			Address:      address,
+			Balance:      new(hexutil.Big),
+			Nonce:        hexutil.Uint64(0),
+			CodeHash:     common.Hash{},
+			StorageHash:  common.Hash{},
+			StorageProof: make([]accounts.StorProofResult, len(storageKeys[i])),
+		}
+		proofs[i] = proof
 
-	// get account proof
-	accountProof, err := proofTrie.Prove(crypto.Keccak256(address.Bytes()), 0, false)
-	if err != nil {
-		return nil, err
-	}
-	proof.AccountProof = *(*[]hexutil.Bytes)(unsafe.Pointer(&accountProof))
+		// get account proof
+		accountProof, err := proofTrie.Prove(crypto.Keccak256(address.Bytes()), 0, false)
+		if err != nil {
+			return nil, err
+		}
+		proof.AccountProof = *(*[]hexutil.Bytes)(unsafe.Pointer(&accountProof))
 
-	// get account data from the trie
-	acc, _ := proofTrie.GetAccount(crypto.Keccak256(address.Bytes()))
-	if acc == nil {
-		for i, k := range storageKeys {
-			proof.StorageProof[i] = accounts.StorProofResult{
-				Key:   uint256.NewInt(0).SetBytes(k[:]).Hex(),
-				Value: new(hexutil.Big),
-				Proof: nil,
+		// get account data from the trie
+		acc, _ := proofTrie.GetAccount(crypto.Keccak256(address.Bytes()))
+		if acc == nil {
+			for j, k := range storageKeys[i] {
+				proof.StorageProof[j] = accounts.StorProofResult{
+					Key:   uint256.NewInt(0).SetBytes(k[:]).Hex(),
+					Value: new(hexutil.Big),
+					Proof: nil,
+				}
 			}
+			continue
 		}
-		return proof, nil
-	}
+		exists[i] = true
 
-	proof.Balance = (*hexutil.Big)(acc.Balance.ToBig())
-	proof.Nonce = hexutil.Uint64(acc.Nonce)
-	proof.CodeHash = acc.CodeHash
-	proof.StorageHash = acc.Root
+		proof.Balance = (*hexutil.Big)(acc.Balance.ToBig())
+		proof.Nonce = hexutil.Uint64(acc.Nonce)
+		proof.CodeHash = acc.CodeHash
+		proof.StorageHash = acc.Root
 
-	// if storage is not empty touch keys and build trie
-	if proof.StorageHash.Cmp(common.BytesToHash(commitment.EmptyRootHash)) != 0 && len(storageKeys) != 0 {
-		// touch storage keys
-		for _, storageKey := range storageKeys {
-			sdCtx.TouchKey(kv.StorageDomain, string(common.FromHex(address.Hex()[2:]+storageKey.String()[2:])), nil)
+		// if storage is not empty touch keys
+		if proof.StorageHash.Cmp(common.BytesToHash(commitment.EmptyRootHash)) != 0 && len(storageKeys[i]) != 0 {
+			for _, storageKey := range storageKeys[i] {
+				sdCtx.TouchKey(kv.StorageDomain, string(common.FromHex(address.Hex()[2:]+storageKey.String()[2:])), nil)
+			}
+			touchedStorage = true
 		}
+	}
 
+	if touchedStorage {
 		// generate the trie for proofs, this works by loading the merkle paths to the touched keys
 		proofTrie, _, err = sdCtx.Witness(ctx, header.Root[:], "eth_getProof")
 		if err != nil {
@@ -431,59 +486,66 @@ func (api *APIImpl) getProof(ctx context.Context, roTx kv.Tx, address common.Add
 		return nil, err
 	}
 
-	// get storage key proofs
-	for i, keyHash := range storageKeys {
-		proof.StorageProof[i].Key = uint256.NewInt(0).SetBytes(keyHash[:]).Hex()
-
-		// if we have simple non contract account just set values directly without requesting any key proof
-		if proof.StorageHash.Cmp(common.BytesToHash(commitment.EmptyRootHash)) == 0 {
-			proof.StorageProof[i].Proof = nil
-			proof.StorageProof[i].Value = new(hexutil.Big)
+	for i, address := range addresses {
+		proof := proofs[i]
+		if !exists[i] {
 			continue
 		}
 
-		// prepare key path (keccak(address) | keccak(key))
-		var fullKey []byte
-		fullKey = append(fullKey, crypto.Keccak256(address.Bytes())...)
-		fullKey = append(fullKey, crypto.Keccak256(keyHash.Bytes())...)
+		// get storage key proofs
+		for j, keyHash := range storageKeys[i] {
+			proof.StorageProof[j].Key = uint256.NewInt(0).SetBytes(keyHash[:]).Hex()
 
-		// get proof for the given key
-		storageProof, err := proofTrie.Prove(fullKey, len(proof.AccountProof), true)
-		if err != nil {
-			return nil, errors.New("cannot verify store proof")
-		}
+			// if we have simple non contract account just set values directly without requesting any key proof
+			if proof.StorageHash.Cmp(common.BytesToHash(commitment.EmptyRootHash)) == 0 {
+				proof.StorageProof[j].Proof = nil
+				proof.StorageProof[j].Value = new(hexutil.Big)
+				continue
+			}
 
-		res, err := reader.ReadAccountStorage(address, &keyHash)
-		if err != nil {
-			res = []byte{}
-			logger.Warn(fmt.Sprintf("couldn't read account storage for the address %s\n", address.String()))
-		}
-		n := new(big.Int)
-		n.SetBytes(res)
-		proof.StorageProof[i].Value = (*hexutil.Big)(n)
+			// prepare key path (keccak(address) | keccak(key))
+			var fullKey []byte
+			fullKey = append(fullKey, crypto.Keccak256(address.Bytes())...)
+			fullKey = append(fullKey, crypto.Keccak256(keyHash.Bytes())...)
 
-		// 0x80 represents RLP encoding of an empty proof slice
-		proof.StorageProof[i].Proof = []hexutil.Bytes{[]byte{0x80}}
-		if len(storageProof) != 0 {
-			proof.StorageProof[i].Proof = *(*[]hexutil.Bytes)(unsafe.Pointer(&storageProof))
-		}
-	}
+			// get proof for the given key
+			storageProof, err := proofTrie.Prove(fullKey, len(proof.AccountProof), true)
+			if err != nil {
+				return nil, errors.New("cannot verify store proof")
+			}
 
-	// Verify proofs before returning result to the user
-	err = trie.VerifyAccountProof(header.Root, proof)
-	if err != nil {
-		return nil, fmt.Errorf("internal error: failed to verify account proof for generated proof : %w", err)
-	}
+			res, err := reader.ReadAccountStorage(address, &keyHash)
+			if err != nil {
+				res = []byte{}
+				logger.Warn(fmt.Sprintf("couldn't read account storage for the address %s\n", address.String()))
+			}
+			n := new(big.Int)
+			n.SetBytes(res)
+			proof.StorageProof[j].Value = (*hexutil.Big)(n)
+
+			// 0x80 represents RLP encoding of an empty proof slice
+			proof.StorageProof[j].Proof = []hexutil.Bytes{[]byte{0x80}}
+			if len(storageProof) != 0 {
+				proof.StorageProof[j].Proof = *(*[]hexutil.Bytes)(unsafe.Pointer(&storageProof))
+			}
+		}
 
-	// verify storage proofs
-	for _, storageProof := range proof.StorageProof {
-		err = trie.VerifyStorageProof(proof.StorageHash, storageProof)
+		// Verify proofs before returning result to the user
+		err = trie.VerifyAccountProof(header.Root, proof)
 		if err != nil {
-			return nil, fmt.Errorf("internal error: failed to verify storage proof for key=%x , proof=%+v : %w", storageProof.Key, proof, err)
+			return nil, fmt.Errorf("internal error: failed to verify account proof for generated proof : %w", err)
+		}
+
+		// verify storage proofs
+		for _, storageProof := range proof.StorageProof {
+			err = trie.VerifyStorageProof(proof.StorageHash, storageProof)
+			if err != nil {
+				return nil, fmt.Errorf("internal error: failed to verify storage proof for key=%x , proof=%+v : %w", storageProof.Key, proof, err)
+			}
 		}
 	}
 
-	return proof, nil
+	return proofs, nil
 }
 
 func (api *APIImpl) GetWitness(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
@@ -715,6 +777,8 @@ type accessListResult struct {
 	GasUsed    hexutil.Uint64    `json:"gasUsed"`
 }
 
+const maxAccessListIterations = 64
+
 // CreateAccessList implements eth_createAccessList. It creates an access list for the given transaction.
 // If the accesslist creation fails an error is returned.
 // If the transaction itself fails, an vmErr is returned.
@@ -811,7 +875,13 @@ func (api *APIImpl) CreateAccessList(ctx context.Context, args ethapi2.CallArgs,
 	if args.AccessList != nil {
 		prevTracer = logger.NewAccessListTracer(*args.AccessList, excl, nil)
 	}
-	for {
+	// Every iteration can only extend the list (tracer starts from previous list) - stop at fixed point:
+	// when execution with the list doesn't touch anything new. Usually it takes a few iterations
+	// (proxies read implementation address from storage, nested proxies - one more iteration per level).
+	for i := 0; ; i++ {
+		if i == maxAccessListIterations {
+			return nil, fmt.Errorf("access list did not converge after %d iterations", maxAccessListIterations)
+		}
 		state := state.New(stateReader)
 		// Retrieve the current access list to expand
 		accessList := prevTracer.AccessList()
@@ -832,7 +902,7 @@ func (api *APIImpl) CreateAccessList(ctx context.Context, args ethapi2.CallArgs,
 			baseFee, _ = uint256.FromBig(header.BaseFee)
 		}
 
-		msg, err := args.ToMessage(api.GasCap, baseFee)
+		msg, err := args.ToMessage(api.rpcGasCap(api.GasCap), baseFee)
 		if err != nil {
 			return nil, err
 		}
@@ -268,9 +268,10 @@ func (api *APIImpl) CallMany(ctx context.Context, bundles []Bundle, simulateCont
 		results := []map[string]interface{}{}
 		for _, txn := range bundle.Transactions {
 			if txn.Gas == nil || *(txn.Gas) == 0 {
-				txn.Gas = (*hexutil.Uint64)(&api.GasCap)
+				gasCap := hexutil.Uint64(api.rpcGasCap(api.GasCap))
+				txn.Gas = &gasCap
 			}
-			msg, err := txn.ToMessage(api.GasCap, blockCtx.BaseFee)
+			msg, err := txn.ToMessage(api.rpcGasCap(api.GasCap), blockCtx.BaseFee)
 			if err != nil {
 				return nil, err
 			}
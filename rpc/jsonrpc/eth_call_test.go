@@ -29,6 +29,7 @@ import (
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/crypto"
@@ -67,6 +68,55 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+func TestEstimateGasSinglePass(t *testing.T) {
+	m, bankAddress, contractAddress := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx, latest := context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	// plain transfer - exactly intrinsic gas
+	to := common.Address{1}
+	gas, err := api.EstimateGas(ctx, &ethapi.CallArgs{From: &bankAddress, To: &to}, &latest, nil)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(params.TxGas), gas)
+
+	// contract call - estimation must be executable and not lower than used gas
+	data := hexutil.Bytes(contractInvocationData(3))
+	gas, err = api.EstimateGas(ctx, &ethapi.CallArgs{From: &bankAddress, To: &contractAddress, Data: &data}, &latest, nil)
+	require.NoError(t, err)
+	_, err = api.Call(ctx, ethapi.CallArgs{From: &bankAddress, To: &contractAddress, Data: &data, Gas: &gas}, latest, nil)
+	require.NoError(t, err)
+	lower := gas - 1
+	_, err = api.Call(ctx, ethapi.CallArgs{From: &bankAddress, To: &contractAddress, Data: &data, Gas: &lower}, latest, nil)
+	require.Error(t, err)
+
+	require.Equal(t, uint64((21000+2300)*64/63), optimisticGasLimit(21000, 0))
+}
+
+func TestSetRpcGasCap(t *testing.T) {
+	m, bankAddress, contractAddress := chainWithDeployedContract(t)
+	base := newBaseApiForTest(m)
+	api := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	admin := NewAdminAPI(base, nil, m.DB)
+	ctx, latest := context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	data := hexutil.Bytes(contractInvocationData(3))
+	call := ethapi.CallArgs{From: &bankAddress, To: &contractAddress, Data: &data}
+
+	_, err := api.Call(ctx, call, latest, nil)
+	require.NoError(t, err)
+
+	prev, err := admin.SetRpcGasCap(ctx, hexutil.Uint64(params.TxGas))
+	require.NoError(t, err)
+	require.Zero(t, prev)
+	_, err = api.Call(ctx, call, latest, nil)
+	require.Error(t, err)
+
+	prev, err = admin.SetRpcGasCap(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, hexutil.Uint64(params.TxGas), prev)
+	_, err = api.Call(ctx, call, latest, nil)
+	require.NoError(t, err)
+}
+
 func TestEthCallNonCanonical(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
@@ -236,7 +286,7 @@ func TestGetBlockByTimestampLatestTime(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
 
 	latestBlock, err := m.BlockReader.CurrentBlock(tx)
 	require.NoError(t, err)
@@ -271,7 +321,7 @@ func TestGetBlockByTimestampOldestTime(t *testing.T) {
 		t.Errorf("failed at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
 
 	oldestBlock, err := m.BlockReader.BlockByNumber(m.Ctx, tx, 0)
 	if err != nil {
@@ -309,7 +359,7 @@ func TestGetBlockByTimeHigherThanLatestBlock(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
 
 	latestBlock, err := m.BlockReader.CurrentBlock(tx)
 	require.NoError(t, err)
@@ -345,7 +395,7 @@ func TestGetBlockByTimeMiddle(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
 
 	currentHeader := rawdb.ReadCurrentHeader(tx)
 	oldestHeader, err := api._blockReader.HeaderByNumber(ctx, tx, 0)
@@ -392,7 +442,7 @@ func TestGetBlockByTimestamp(t *testing.T) {
 		t.Errorf("fail at beginning tx")
 	}
 	defer tx.Rollback()
-	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil, 5000000, 1000)
 
 	highestBlockNumber := rawdb.ReadCurrentHeader(tx).Number
 	pickedBlock, err := m.BlockReader.BlockByNumber(m.Ctx, tx, highestBlockNumber.Uint64()/3)
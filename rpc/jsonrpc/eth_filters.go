@@ -302,3 +302,118 @@ func (api *APIImpl) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc
 
 	return rpcSub, nil
 }
+
+// StateDiffs send a notification with account and storage changes of each new block, optionally only of given addresses.
+func (api *APIImpl) StateDiffs(ctx context.Context, filter *StateDiffFilter) (*rpc.Subscription, error) {
+	if api.filters == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		headers, id := api.filters.SubscribeNewHeads(32)
+		defer api.filters.UnsubscribeHeads(id)
+		for {
+			select {
+			case h, ok := <-headers:
+				if h != nil {
+					diff, err := api.readStateDiff(h, filter)
+					if err != nil {
+						log.Warn("[rpc] error while reading state diff", "block", h.Number.Uint64(), "err", err)
+					} else if diff != nil {
+						if err := notifier.Notify(rpcSub.ID, diff); err != nil {
+							log.Warn("[rpc] error while notifying subscription", "err", err)
+						}
+					}
+				}
+				if !ok {
+					log.Warn("[rpc] new heads channel was closed")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func (api *APIImpl) readStateDiff(header *types.Header, filter *StateDiffFilter) (*BlockStateDiff, error) {
+	tx, err := api.db.BeginTemporalRo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	diff, ok, err := api.stateDiff(tx, header, filter)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		log.Debug("[rpc] no changeset of block, skipping state diff", "block", header.Number.Uint64(), "hash", header.Hash())
+		return nil, nil
+	}
+	return diff, nil
+}
+
+// Reorgs send a notification each time when new head is not a descendant of previous one: with common ancestor,
+// removed segment of old chain and added segment of new canonical chain.
+func (api *APIImpl) Reorgs(ctx context.Context) (*rpc.Subscription, error) {
+	if api.filters == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		headers, id := api.filters.SubscribeNewHeads(32)
+		defer api.filters.UnsubscribeHeads(id)
+		var lastHead *types.Header
+		for {
+			select {
+			case h, ok := <-headers:
+				if h != nil {
+					if lastHead != nil {
+						event, err := api.readReorgEvent(lastHead, h)
+						if err != nil {
+							log.Warn("[rpc] error while reading reorg", "block", h.Number.Uint64(), "err", err)
+						} else if event != nil {
+							if err := notifier.Notify(rpcSub.ID, event); err != nil {
+								log.Warn("[rpc] error while notifying subscription", "err", err)
+							}
+						}
+					}
+					lastHead = h
+				}
+				if !ok {
+					log.Warn("[rpc] new heads channel was closed")
+					return
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func (api *APIImpl) readReorgEvent(oldHead, newHead *types.Header) (*ReorgEvent, error) {
+	tx, err := api.db.BeginRo(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	return api.reorgEvent(context.Background(), tx, oldHead, newHead)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/RoaringBitmap/roaring/v2"
 
+	"github.com/erigontech/erigon-db/rawdb/rawtemporaldb"
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
@@ -163,6 +164,11 @@ func (api *APIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (t
 }
 
 func applyFiltersV3(txNumsReader rawdbv3.TxNumsReader, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria) (out stream.U64, err error) {
+	return applyFiltersV3From(txNumsReader, tx, begin, end, 0, crit)
+}
+
+// applyFiltersV3From - same as applyFiltersV3, but skips txNums below minTxNum (continuation of paged requests)
+func applyFiltersV3From(txNumsReader rawdbv3.TxNumsReader, tx kv.TemporalTx, begin, end, minTxNum uint64, crit filters.FilterCriteria) (out stream.U64, err error) {
 	//[from,to)
 	var fromTxNum, toTxNum uint64
 	if begin > 0 {
@@ -176,6 +182,10 @@ func applyFiltersV3(txNumsReader rawdbv3.TxNumsReader, tx kv.TemporalTx, begin,
 		return out, err
 	}
 	toTxNum++
+	fromTxNum = max(fromTxNum, minTxNum)
+	if fromTxNum >= toTxNum {
+		return stream.EmptyU64, nil
+	}
 
 	topicsBitmap, err := getTopicsBitmapV3(tx, crit.Topics, fromTxNum, toTxNum)
 	if err != nil {
@@ -203,7 +213,18 @@ func applyFiltersV3(txNumsReader rawdbv3.TxNumsReader, tx kv.TemporalTx, begin,
 
 func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria) ([]*types.ErigonLog, error) {
 	logs := []*types.ErigonLog{} //nolint
+	if err := api.iterateLogsV3(ctx, tx, begin, end, 0, crit, func(_ uint64, txnLogs []*types.ErigonLog) (bool, error) {
+		logs = append(logs, txnLogs...)
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
 
+// iterateLogsV3 - calls yield with matching logs of every txn (in ascending order) starting from minTxNum,
+// until yield returns false. Logs of one txn are never split between calls.
+func (api *BaseAPI) iterateLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end, minTxNum uint64, crit filters.FilterCriteria, yield func(txNum uint64, logs []*types.ErigonLog) (bool, error)) error {
 	addrMap := make(map[common.Address]struct{}, len(crit.Addresses))
 	for _, v := range crit.Addresses {
 		addrMap[v] = struct{}{}
@@ -211,7 +232,7 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 
 	chainConfig, err := api.chainConfig(ctx, tx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	exec := exec3.NewTraceWorker(tx, chainConfig, api.engine(), api._blockReader, nil)
 	defer exec.Close()
@@ -219,9 +240,9 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 	//var blockHash common.Hash
 	var header *types.Header
 
-	txNumbers, err := applyFiltersV3(api._txNumReader, tx, begin, end, crit)
+	txNumbers, err := applyFiltersV3From(api._txNumReader, tx, begin, end, minTxNum, crit)
 	if err != nil {
-		return logs, err
+		return err
 	}
 
 	it := rawdbv3.TxNums2BlockNums(tx,
@@ -230,36 +251,53 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 	defer it.Close()
 	for it.HasNext() {
 		if err = ctx.Err(); err != nil {
-			return nil, err
+			return err
 		}
 		txNum, blockNum, txIndex, isFinalTxn, blockNumChanged, err := it.Next()
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if isFinalTxn {
 			if chainConfig.Bor != nil {
-				if header == nil {
+				// the state sync txn may be the only match in its block, so the header of
+				// a previously visited block must not be reused
+				if header == nil || blockNumChanged {
 					header, err = api._blockReader.HeaderByNumber(ctx, tx, blockNum)
 					if err != nil {
-						return nil, err
+						return err
+					}
+					if header == nil {
+						log.Warn("[rpc] header is nil", "blockNum", blockNum)
+						continue
 					}
 				}
 				// check for state sync event logs
 				events, err := api.stateSyncEvents(ctx, tx, header.Hash(), blockNum, chainConfig)
 				if err != nil {
-					return logs, err
+					return err
 				}
 
 				if len(events) == 0 {
 					continue
 				}
 
-				borLogs, err := api.borReceiptGenerator.GenerateBorLogs(ctx, events, api._txNumReader, tx, header, chainConfig, txIndex, len(logs))
+				// state sync logs are numbered after all the other logs of the block,
+				// same as in the receipt returned by eth_getTransactionReceipt
+				_, _, firstLogIndex, err := rawtemporaldb.ReceiptAsOf(tx, txNum+1)
 				if err != nil {
-					return logs, err
+					return err
+				}
+
+				borLogs, err := api.borReceiptGenerator.GenerateBorLogs(ctx, events, api._txNumReader, tx, header, chainConfig, txIndex, int(firstLogIndex))
+				if err != nil {
+					return err
 				}
 
 				borLogs = borLogs.Filter(addrMap, crit.Topics, 0)
+				if len(borLogs) == 0 {
+					continue
+				}
+				logs := make([]*types.ErigonLog, 0, len(borLogs))
 				for _, filteredLog := range borLogs {
 					logs = append(logs, &types.ErigonLog{
 						Address:     filteredLog.Address,
@@ -274,6 +312,9 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 						Timestamp:   header.Time,
 					})
 				}
+				if ok, err := yield(txNum, logs); err != nil || !ok {
+					return err
+				}
 			}
 
 			continue
@@ -283,7 +324,7 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 
 		if blockNumChanged {
 			if header, err = api._blockReader.HeaderByNumber(ctx, tx, blockNum); err != nil {
-				return nil, err
+				return err
 			}
 			if header == nil {
 				log.Warn("[rpc] header is nil", "blockNum", blockNum)
@@ -296,7 +337,7 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 		//fmt.Printf("txNum=%d, blockNum=%d, txIndex=%d, maxTxNumInBlock=%d,mixTxNumInBlock=%d\n", txNum, blockNum, txIndex, maxTxNumInBlock, minTxNumInBlock)
 		txn, err := api._txnReader.TxnByIdxInBlock(ctx, tx, blockNum, txIndex)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if txn == nil {
 			continue
@@ -304,13 +345,16 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 
 		r, err := api.receiptsGenerator.GetReceipt(ctx, chainConfig, tx, header, txn, txIndex, txNum)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		if r == nil {
-			return nil, err
+			return err
 		}
 		filtered := r.Logs.Filter(addrMap, crit.Topics, 0)
-
+		if len(filtered) == 0 {
+			continue
+		}
+		logs := make([]*types.ErigonLog, 0, len(filtered))
 		for _, filteredLog := range filtered {
 			logs = append(logs, &types.ErigonLog{
 				Address:     filteredLog.Address,
@@ -325,9 +369,12 @@ func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end
 				Timestamp:   header.Time,
 			})
 		}
+		if ok, err := yield(txNum, logs); err != nil || !ok {
+			return err
+		}
 	}
 
-	return logs, nil
+	return nil
 }
 
 // The Topic list restricts matches to particular event topics. Each event has a list
@@ -482,6 +529,30 @@ func (api *APIImpl) GetBlockReceipts(ctx context.Context, numberOrHash rpc.Block
 		return nil, err
 	}
 	defer tx.Rollback()
+	if number, ok := numberOrHash.Number(); ok && (number == rpc.PendingBlockNumber || number == rpc.PreconfirmedBlockNumber) {
+		var block *types.Block
+		var receipts types.Receipts
+		if number == rpc.PendingBlockNumber {
+			block, receipts, err = api.builtPendingReceipts(ctx, tx)
+		} else {
+			block, receipts, err = api.preconfirmedBlock(ctx, tx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			chainConfig, err := api.chainConfig(ctx, tx)
+			if err != nil {
+				return nil, err
+			}
+			result := make([]map[string]interface{}, 0, len(receipts))
+			for i, receipt := range receipts {
+				txn := block.Transactions()[i]
+				result = append(result, ethutils.MarshalReceipt(receipt, txn, chainConfig, block.HeaderNoCopy(), txn.Hash(), true))
+			}
+			return result, nil
+		}
+	}
 	blockNum, blockHash, _, err := rpchelper.GetBlockNumber(ctx, numberOrHash, tx, api._blockReader, api.filters)
 	if err != nil {
 		bnh, _ := numberOrHash.Hash()
@@ -0,0 +1,159 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/filters"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+	bortypes "github.com/erigontech/erigon/polygon/bor/types"
+	"github.com/erigontech/erigon/polygon/bridge"
+	"github.com/erigontech/erigon/rpc/rpccfg"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// stateSyncEvents - bridge reader serving state sync events of blocks
+type stateSyncEvents map[uint64][]*types.Message
+
+func (s stateSyncEvents) Events(_ context.Context, blockNum uint64) ([]*types.Message, error) {
+	return s[blockNum], nil
+}
+
+func (s stateSyncEvents) EventTxnLookup(context.Context, common.Hash) (uint64, bool, error) {
+	return 0, false, nil
+}
+
+func TestGetLogsBorStateSync(t *testing.T) {
+	require := require.New(t)
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	chainConfig := params.BorDevnetChainConfig
+	stateReceiver := chainConfig.Bor.(*borcfg.BorConfig).StateReceiverContractAddress()
+	emitter := common.HexToAddress("0xe1")
+	topic := common.HexToHash("0x2a")
+	gspec := &types.Genesis{
+		Config: chainConfig,
+		Alloc: types.GenesisAlloc{
+			sender: {Balance: big.NewInt(1_000_000_000_000_000_000)},
+			// 2 logs without topics
+			emitter: {Code: []byte{
+				byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG0),
+				byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG0),
+			}, Balance: common.Big0},
+			// log of calldata, same as state receiver contract emits StateCommitted
+			stateReceiver: {Code: []byte{
+				byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATACOPY),
+				byte(vm.PUSH1), topic[31], byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.LOG1),
+			}, Balance: common.Big0},
+		},
+		GasLimit: 10_000_000,
+	}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+
+	signer := types.LatestSigner(chainConfig)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 2, func(i int, b *core.BlockGen) {
+		if i != 0 {
+			return // block with state sync txn only
+		}
+		txn, err := types.SignTx(types.NewTransaction(b.TxNonce(sender), emitter, uint256.NewInt(0), 100_000, uint256.NewInt(100*common.GWei), nil), *signer, key)
+		require.NoError(err)
+		b.AddTx(txn)
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chain))
+
+	bridgeReader := stateSyncEvents{
+		1: bridge.NewStateSyncEventMessages([]rlp.RawValue{{1}, {2}}, &stateReceiver, core.SysCallGasLimit),
+		2: bridge.NewStateSyncEventMessages([]rlp.RawValue{{3}}, &stateReceiver, core.SysCallGasLimit),
+	}
+	base := NewBaseApi(nil, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, bridgeReader)
+	api := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+
+	logs, err := api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2)})
+	require.NoError(err)
+	require.Len(logs, 5)
+
+	block1, block2 := chain.Blocks[0], chain.Blocks[1]
+	for i, l := range logs[:2] {
+		require.Equal(emitter, l.Address)
+		require.Equal(uint(i), l.Index)
+		require.Equal(uint(0), l.TxIndex)
+	}
+	// state sync logs of block 1 are numbered after logs of its txns
+	for i, l := range logs[2:4] {
+		require.Equal(stateReceiver, l.Address)
+		require.Equal([]common.Hash{topic}, l.Topics)
+		require.Equal([]byte{byte(i + 1)}, l.Data)
+		require.Equal(uint(2+i), l.Index)
+		require.Equal(uint(1), l.TxIndex)
+		require.Equal(uint64(1), l.BlockNumber)
+		require.Equal(block1.Hash(), l.BlockHash)
+		require.Equal(bortypes.ComputeBorTxHash(1, block1.Hash()), l.TxHash)
+	}
+	// block with the only matching state sync txn: its own header, not header of previous block
+	l := logs[4]
+	require.Equal(stateReceiver, l.Address)
+	require.Equal([]byte{3}, l.Data)
+	require.Equal(uint(0), l.Index)
+	require.Equal(uint(0), l.TxIndex)
+	require.Equal(uint64(2), l.BlockNumber)
+	require.Equal(block2.Hash(), l.BlockHash)
+	require.Equal(bortypes.ComputeBorTxHash(2, block2.Hash()), l.TxHash)
+
+	// bor execution indexes logs of state sync txn same as logs of other txns
+	tx, err := m.DB.BeginTemporalRw(m.Ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	doms, err := libstate.NewSharedDomains(tx, log.New())
+	require.NoError(err)
+	defer doms.Close()
+	for _, blockNum := range []uint64{1, 2} {
+		finalTxNum, err := base._txNumReader.Max(tx, blockNum)
+		require.NoError(err)
+		doms.SetTxNum(finalTxNum)
+		require.NoError(doms.IndexAdd(kv.LogAddrIdx, stateReceiver[:]))
+	}
+	require.NoError(doms.Flush(m.Ctx, tx))
+	doms.Close()
+	require.NoError(tx.Commit())
+
+	// the only match of block 2 is its state sync txn, after match in block 1
+	logs, err = api.GetLogs(m.Ctx, filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(2), Addresses: []common.Address{stateReceiver}})
+	require.NoError(err)
+	require.Len(logs, 3)
+	require.Equal(uint(2), logs[0].Index)
+	require.Equal(block2.Hash(), logs[2].BlockHash)
+	require.Equal(bortypes.ComputeBorTxHash(2, block2.Hash()), logs[2].TxHash)
+}
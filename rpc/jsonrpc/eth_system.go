@@ -18,12 +18,14 @@ package jsonrpc
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/diagnostics"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon/eth/ethconfig"
@@ -70,14 +72,40 @@ func (api *APIImpl) Syncing(ctx context.Context) (interface{}, error) {
 		stagesMap[i].BlockNumber = hexutil.Uint64(stage.BlockNumber)
 	}
 
+	// erigon extension: progress of stages relative to the highest block, the current stage with ETA
+	// and downloads of snapshot files, which happen before highestBlock is known
+	progress := syncingProgress{
+		Stages:       make([]syncingStageProgress, len(reply.Stages)),
+		SyncProgress: diagnostics.Client().SyncProgress(),
+	}
+	for i, stage := range reply.Stages {
+		progress.Stages[i] = syncingStageProgress{StageName: stage.StageName, BlockNumber: hexutil.Uint64(stage.BlockNumber)}
+		if highestBlock > 0 {
+			progress.Stages[i].Progress = fmt.Sprintf("%.2f%%", 100*float64(min(stage.BlockNumber, highestBlock))/float64(highestBlock))
+		}
+	}
+
 	return map[string]interface{}{
 		"startingBlock": "0x0", // 0x0 is a placeholder, I do not think it matters what we return here
 		"currentBlock":  hexutil.Uint64(currentBlock),
 		"highestBlock":  hexutil.Uint64(highestBlock),
 		"stages":        stagesMap,
+		"erigon":        progress,
 	}, nil
 }
 
+type syncingProgress struct {
+	Stages []syncingStageProgress `json:"stages"`
+	// CurrentStage and SnapshotDownload are known only by rpcdaemon in the process of the node
+	diagnostics.SyncProgress
+}
+
+type syncingStageProgress struct {
+	StageName   string         `json:"stageName"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Progress    string         `json:"progress,omitempty"`
+}
+
 // ChainId implements eth_chainId. Returns the current ethereum chainId.
 func (api *APIImpl) ChainId(ctx context.Context) (hexutil.Uint64, error) {
 	tx, err := api.db.BeginTemporalRo(ctx)
@@ -0,0 +1,101 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/turbo/era1"
+	"github.com/erigontech/erigon/turbo/portal"
+)
+
+// expiredHistory - source of blocks which node doesn't have (--history.expiry): era1 files of datadir, then Portal Network
+type expiredHistory struct {
+	era1   *era1.Store
+	portal *portal.Client
+	logger log.Logger
+}
+
+// newExpiredHistory - nil if there is neither era1 dir nor portal client
+func newExpiredHistory(dirs datadir.Dirs, portalURL string, logger log.Logger) *expiredHistory {
+	h := &expiredHistory{logger: logger}
+	if _, err := os.Stat(era1.Dir(dirs)); err == nil {
+		if h.era1, err = era1.OpenStore(era1.Dir(dirs)); err != nil {
+			logger.Warn("[rpc] can't open era1 files", "err", err)
+		}
+	}
+	if portalURL != "" {
+		var err error
+		if h.portal, err = portal.Dial(context.Background(), portalURL, logger); err != nil {
+			logger.Warn("[rpc] can't connect Portal Network client", "url", portalURL, "err", err)
+		}
+	}
+	if h.era1 == nil && h.portal == nil {
+		return nil
+	}
+	return h
+}
+
+func (h *expiredHistory) block(ctx context.Context, header *types.Header) (*types.Block, error) {
+	num, hash := header.Number.Uint64(), header.Hash()
+	var errs []error
+	if h.era1 != nil {
+		block, err := h.era1.Block(num)
+		if err == nil && block == nil { // maybe file was exported after start
+			if err = h.era1.Reopen(); err == nil {
+				block, err = h.era1.Block(num)
+			}
+		}
+		if err == nil && block != nil && block.Hash() != hash {
+			err = fmt.Errorf("era1: block %d has hash %x, expected %x", num, block.Hash(), hash)
+		}
+		if err == nil && block != nil {
+			return block, nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if h.portal != nil {
+		body, err := h.portal.BlockBody(ctx, header)
+		if err == nil {
+			return types.NewBlockFromNetwork(header, body), nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// expiredBlock - block which is frozen, but its transactions are not available locally
+func (api *BaseAPI) expiredBlock(ctx context.Context, tx kv.Tx, hash common.Hash, number uint64) (*types.Block, error) {
+	if number >= api._blockReader.FrozenBlocks() {
+		return nil, nil
+	}
+	header, err := api._blockReader.Header(ctx, tx, hash, number)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return api.expiredHistory.block(ctx, header)
+}
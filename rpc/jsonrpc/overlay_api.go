@@ -208,15 +208,16 @@ func (api *OverlayAPIImpl) CallConstructor(ctx context.Context, address common.A
 		return nil, err
 	}
 
+	gasCap := api.rpcGasCap(api.GasCap)
 	contractAddr := crypto.CreateAddress(msg.From(), msg.Nonce())
 	if creationTx.GetTo() == nil && contractAddr == address {
 		// CREATE: adapt message with new code so it's replaced instantly
-		msg = types.NewMessage(msg.From(), msg.To(), msg.Nonce(), msg.Value(), api.GasCap, msg.GasPrice(), msg.FeeCap(), msg.TipCap(), *code, msg.AccessList(), msg.CheckNonce(), msg.IsFree(), msg.MaxFeePerBlobGas())
+		msg = types.NewMessage(msg.From(), msg.To(), msg.Nonce(), msg.Value(), gasCap, msg.GasPrice(), msg.FeeCap(), msg.TipCap(), *code, msg.AccessList(), msg.CheckNonce(), msg.IsFree(), msg.MaxFeePerBlobGas())
 	} else {
-		msg.ChangeGas(api.GasCap, api.GasCap)
+		msg.ChangeGas(gasCap, gasCap)
 	}
 	txCtx = core.NewEVMTxContext(msg)
-	ct := OverlayCreateTracer{contractAddress: address, code: *code, gasCap: api.GasCap}
+	ct := OverlayCreateTracer{contractAddress: address, code: *code, gasCap: gasCap}
 	evm = vm.NewEVM(blockCtx, txCtx, evm.IntraBlockState(), chainConfig, vm.Config{Tracer: ct.Tracer().Hooks})
 
 	// Execute the transaction message
@@ -496,7 +497,8 @@ func (api *OverlayAPIImpl) replayBlock(ctx context.Context, blockNum uint64, sta
 			log.Error(err.Error())
 			return nil, err
 		}
-		msg.ChangeGas(api.GasCap, api.GasCap)
+		gasCap := api.rpcGasCap(api.GasCap)
+		msg.ChangeGas(gasCap, gasCap)
 
 		receipt := receipts[uint64(idx)]
 		log.Debug("[replayBlock]", "receipt.TransactionIndex", receipt.TransactionIndex, "receipt.TxHash", receipt.TxHash, "receipt.Status", receipt.Status)
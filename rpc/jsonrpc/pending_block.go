@@ -0,0 +1,214 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// pendingBlockView - pending block built on top of latest block from best transactions of txpool, with receipts
+// of its simulated execution. Used when there is no pending block of miner. Cached until new head or new
+// pending transactions in txpool.
+type pendingBlockView struct {
+	mu       sync.Mutex
+	block    *types.Block
+	receipts types.Receipts
+
+	watchOnce sync.Once
+	poolDirty atomic.Bool
+}
+
+// watch - marks view outdated on every batch of new pending transactions, head changes are detected by parent hash
+func (v *pendingBlockView) watch(filters *rpchelper.Filters) {
+	v.watchOnce.Do(func() {
+		txsCh, _ := filters.SubscribePendingTxs(32)
+		go func() {
+			for range txsCh {
+				v.poolDirty.Store(true)
+			}
+		}()
+	})
+}
+
+// builtPendingBlock - returns cached pending block and receipts, or builds new ones. Returns nil if txpool is not available.
+func (api *APIImpl) builtPendingBlock(ctx context.Context, tx kv.TemporalTx) (*types.Block, types.Receipts, error) {
+	if api.txPool == nil {
+		return nil, nil, nil
+	}
+	v := api.pendingView
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	cacheable := api.filters != nil
+	if cacheable {
+		v.watch(api.filters)
+	}
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := api._blockReader.HeaderByNumber(ctx, tx, latest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent == nil {
+		return nil, nil, nil
+	}
+	if cacheable && v.block != nil && v.block.ParentHash() == parent.Hash() && !v.poolDirty.Load() {
+		return v.block, v.receipts, nil
+	}
+
+	v.poolDirty.Store(false) // before reading pool: transactions which arrive during build will trigger next build
+	txns, err := api.poolBestTxns(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, receipts, err := api.buildPendingBlock(ctx, tx, parent, txns)
+	if err != nil {
+		return nil, nil, err
+	}
+	v.block, v.receipts = block, receipts
+	return block, receipts, nil
+}
+
+// poolBestTxns - pending transactions of txpool, best first
+func (api *APIImpl) poolBestTxns(ctx context.Context) ([]types.Transaction, error) {
+	reply, err := api.txPool.Pending(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	txns := make([]types.Transaction, 0, len(reply.Txs))
+	for _, pending := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(pending.RlpTx)
+		if err != nil {
+			return nil, err
+		}
+		if wrapper, ok := txn.(*types.BlobTxWrapper); ok {
+			txn = &wrapper.Tx
+		}
+		txn.SetSender(gointerfaces.ConvertH160toAddress(pending.Sender))
+		txns = append(txns, txn)
+	}
+	return txns, nil
+}
+
+// buildPendingBlock - executes transactions in given order on top of parent, skipping ones which fail or don't fit into block
+func (api *APIImpl) buildPendingBlock(ctx context.Context, tx kv.TemporalTx, parent *types.Header, candidates []types.Transaction) (*types.Block, types.Receipts, error) {
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := core.MakeEmptyHeader(parent, chainConfig, parent.Time+chainConfig.SecondsPerSlot(), nil)
+	header.Coinbase = parent.Coinbase
+
+	cacheView, err := api.stateCache.View(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ibs := state.New(rpchelper.CreateLatestCachedStateReader(cacheView, tx))
+	getHeader := func(hash common.Hash, n uint64) *types.Header {
+		h, _ := api._blockReader.HeaderByNumber(ctx, tx, n)
+		return h
+	}
+	blockHashFunc := core.GetHashFn(header, getHeader)
+	gp := new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock(header.Time))
+	noop := state.NewNoopWriter()
+
+	var (
+		txs                  types.Transactions
+		receipts             types.Receipts
+		usedGas, usedBlobGas uint64
+	)
+	for _, txn := range candidates {
+		if gp.Gas() < params.TxGas {
+			break
+		}
+		snap := ibs.Snapshot()
+		ibs.SetTxContext(len(txs))
+		receipt, _, err := core.ApplyTransaction(chainConfig, blockHashFunc, api.engine(), &header.Coinbase, gp, ibs, noop, header, txn, &usedGas, &usedBlobGas, vm.Config{})
+		if err != nil {
+			ibs.RevertToSnapshot(snap)
+			continue
+		}
+		txs = append(txs, txn)
+		receipts = append(receipts, receipt)
+	}
+
+	header.GasUsed = usedGas
+	if header.BlobGasUsed != nil {
+		header.BlobGasUsed = &usedBlobGas
+	}
+	var withdrawals []*types.Withdrawal
+	if chainConfig.IsShanghai(header.Time) {
+		withdrawals = []*types.Withdrawal{}
+	}
+	block := types.NewBlock(header, txs, nil, receipts, withdrawals)
+	for _, r := range receipts {
+		r.BlockHash = block.Hash()
+		for _, l := range r.Logs {
+			l.BlockHash = r.BlockHash
+		}
+	}
+	return block, receipts, nil
+}
+
+// minerPendingBlock - pending block built by miner, nil if there is none. Backend falls back to its latest block
+// when nothing was built - such block is already canonical and is not treated as pending.
+func (api *APIImpl) minerPendingBlock(ctx context.Context, tx kv.Tx) (*types.Block, error) {
+	if block := api.pendingBlock(); block != nil {
+		return block, nil
+	}
+	if api.ethBackend == nil {
+		return nil, nil
+	}
+	block, err := api.ethBackend.PendingBlock(ctx)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	canonicalHash, ok, err := api._blockReader.CanonicalHash(ctx, tx, block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+	if ok && canonicalHash == block.Hash() {
+		return nil, nil
+	}
+	return block, nil
+}
+
+// builtPendingReceipts - receipts of built pending block, nil if pending block is provided by miner
+func (api *APIImpl) builtPendingReceipts(ctx context.Context, tx kv.TemporalTx) (*types.Block, types.Receipts, error) {
+	minerBlock, err := api.minerPendingBlock(ctx, tx)
+	if err != nil || minerBlock != nil {
+		return nil, nil, err
+	}
+	return api.builtPendingBlock(ctx, tx)
+}
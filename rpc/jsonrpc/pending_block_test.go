@@ -0,0 +1,86 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/jsonrpc"
+	"github.com/erigontech/erigon/rpc/rpccfg"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestGetBlockByNumberPendingFromTxPool(t *testing.T) {
+	mockSentry, require := mock.MockWithTxPool(t), require.New(t)
+	oneBlockStep(mockSentry, require, t)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, mockSentry)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, mockSentry.Log)
+	base := jsonrpc.NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), mockSentry.BlockReader, false, rpccfg.DefaultEvmCallTimeout, mockSentry.Engine, mockSentry.Dirs, nil)
+	api := jsonrpc.NewEthAPI(base, mockSentry.DB, nil, txPool, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+
+	// no pending transactions - empty block on top of latest
+	b, err := api.GetBlockByNumber(ctx, rpc.PendingBlockNumber, false)
+	require.NoError(err)
+	require.Equal((*hexutil.Big)(big.NewInt(2)), b["number"])
+	require.Empty(b["transactions"])
+
+	txn, err := types.SignTx(types.NewTransaction(0, common.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*common.GWei), nil), *types.LatestSignerForChainID(mockSentry.ChainConfig.ChainID), mockSentry.Key)
+	require.NoError(err)
+	buf := bytes.NewBuffer(nil)
+	require.NoError(txn.MarshalBinary(buf))
+
+	txsCh, id := ff.SubscribePendingTxs(1)
+	defer ff.UnsubscribePendingTxs(id)
+	_, err = api.SendRawTransaction(ctx, buf.Bytes())
+	require.NoError(err)
+	select {
+	case <-txsCh:
+	case <-time.After(20 * time.Second):
+		t.Fatal("timeout waiting for txn from channel")
+	}
+
+	b, err = api.GetBlockByNumber(ctx, rpc.PendingBlockNumber, false)
+	require.NoError(err)
+	require.Equal((*hexutil.Big)(big.NewInt(2)), b["number"])
+	require.Equal([]interface{}{txn.Hash()}, b["transactions"])
+	require.Equal(hexutil.Uint64(params.TxGas), b["gasUsed"])
+
+	receipts, err := api.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+	require.NoError(err)
+	require.Len(receipts, 1)
+	require.Equal(txn.Hash(), receipts[0]["transactionHash"])
+	require.Equal(hexutil.Uint64(types.ReceiptStatusSuccessful), receipts[0]["status"])
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/debug"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/preconf"
+	"github.com/erigontech/erigon/rpc/rpchelper"
+)
+
+// newPreconfStore - nil if there is no sequencer feed, otherwise starts reading the feed
+func newPreconfStore(feedURL, feedType string, logger log.Logger) *preconf.Store {
+	if feedURL == "" {
+		return nil
+	}
+	decoder, err := preconf.DecoderByName(feedType)
+	if err != nil {
+		logger.Warn("[rpc] sequencer feed is disabled", "err", err)
+		return nil
+	}
+	store := preconf.NewStore()
+	go preconf.NewFeed(feedURL, decoder, store, logger).Run(context.Background())
+	return store
+}
+
+// preconfirmedTxns - pre-confirmed transactions which are not in canonical chain yet, included ones are forgotten
+func (api *APIImpl) preconfirmedTxns(ctx context.Context, tx kv.Tx) ([]types.Transaction, error) {
+	txns := api.preconf.Txns()
+	pending := txns[:0]
+	var included []common.Hash
+	for _, txn := range txns {
+		_, _, ok, err := api.txnLookup(ctx, tx, txn.Hash())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			included = append(included, txn.Hash())
+			continue
+		}
+		pending = append(pending, txn)
+	}
+	api.preconf.Remove(included)
+	return pending, nil
+}
+
+// preconfirmedBlock - pre-confirmed transactions executed on top of latest block, nil if there is no sequencer feed
+func (api *APIImpl) preconfirmedBlock(ctx context.Context, tx kv.TemporalTx) (*types.Block, types.Receipts, error) {
+	if api.preconf == nil {
+		return nil, nil, nil
+	}
+	latest, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := api._blockReader.HeaderByNumber(ctx, tx, latest)
+	if err != nil || parent == nil {
+		return nil, nil, err
+	}
+	txns, err := api.preconfirmedTxns(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return api.buildPendingBlock(ctx, tx, parent, txns)
+}
+
+// PreconfirmedTransactions send a notification each time when sequencer pre-confirms transactions. Sends full
+// transactions if fullTx is true, otherwise hashes.
+func (api *APIImpl) PreconfirmedTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+	if api.preconf == nil {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer debug.LogPanic()
+		txsCh, id := api.preconf.Subscribe(256)
+		defer api.preconf.Unsubscribe(id)
+
+		for {
+			select {
+			case txs := <-txsCh:
+				for _, t := range txs {
+					var err error
+					if fullTx != nil && *fullTx {
+						err = notifier.Notify(rpcSub.ID, t)
+					} else {
+						err = notifier.Notify(rpcSub.ID, t.Hash())
+					}
+					if err != nil {
+						log.Warn("[rpc] error while notifying subscription", "err", err)
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
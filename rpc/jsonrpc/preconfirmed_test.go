@@ -0,0 +1,80 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/preconf"
+)
+
+func TestPreconfirmedBlock(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	// no sequencer feed - latest block
+	b, err := api.GetBlockByNumber(ctx, rpc.PreconfirmedBlockNumber, false)
+	require.NoError(t, err)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(11)), b["number"])
+
+	api.preconf = preconf.NewStore()
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	nonce, err := api.GetTransactionCount(ctx, crypto.PubkeyToAddress(key.PublicKey), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	sign := func(nonce uint64) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, common.Address{2}, uint256.NewInt(7), params.TxGas, uint256.NewInt(10*common.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		return txn
+	}
+
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	block1, err := api.blockByNumber(ctx, 1, tx)
+	require.NoError(t, err)
+	included := block1.Transactions()[0]
+
+	preconfirmed := sign(uint64(*nonce))
+	api.preconf.Add([]types.Transaction{included, sign(uint64(*nonce) + 5), preconfirmed})
+
+	b, err = api.GetBlockByNumber(ctx, rpc.PreconfirmedBlockNumber, false)
+	require.NoError(t, err)
+	require.Equal(t, (*hexutil.Big)(big.NewInt(12)), b["number"])
+	require.Equal(t, []interface{}{preconfirmed.Hash()}, b["transactions"]) // nonce gap is skipped
+	require.Len(t, api.preconf.Txns(), 2)                                   // included one is forgotten
+
+	receipts, err := api.GetBlockReceipts(ctx, rpc.BlockNumberOrHashWithNumber(rpc.PreconfirmedBlockNumber))
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	require.Equal(t, preconfirmed.Hash(), receipts[0]["transactionHash"])
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// maxReorgEventDepth - limits walk back to common ancestor, deeper reorgs are reported truncated
+const maxReorgEventDepth = 1024
+
+// BlockRef - number and hash of block
+type BlockRef struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// ReorgEvent - notification of eth_subscribe("reorgs"). Removed is the segment of old canonical chain after
+// CommonAncestor, Added is the new canonical segment after CommonAncestor - both ordered by block number.
+type ReorgEvent struct {
+	CommonAncestor BlockRef      `json:"commonAncestor"`
+	Removed        []common.Hash `json:"removed"`
+	Added          []common.Hash `json:"added"`
+	Truncated      bool          `json:"truncated,omitempty"` // reorg deeper than maxReorgEventDepth
+}
+
+// reorgEvent - returns nil if oldHead is still canonical (newHead extends its chain), otherwise finds common
+// ancestor by walking back from oldHead until block which is canonical again.
+func (api *BaseAPI) reorgEvent(ctx context.Context, tx kv.Tx, oldHead, newHead *types.Header) (*ReorgEvent, error) {
+	if newHead.ParentHash == oldHead.Hash() {
+		return nil, nil
+	}
+	isCanonical := func(h *types.Header) (bool, error) {
+		if h.Number.Uint64() > newHead.Number.Uint64() {
+			return false, nil
+		}
+		return api._blockReader.IsCanonical(ctx, tx, h.Hash(), h.Number.Uint64())
+	}
+	ok, err := isCanonical(oldHead)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+
+	var removed []common.Hash
+	ancestor := oldHead
+	for !ok {
+		if len(removed) == maxReorgEventDepth || ancestor.Number.Uint64() == 0 {
+			break
+		}
+		removed = append(removed, ancestor.Hash())
+		parent, err := api._blockReader.Header(ctx, tx, ancestor.ParentHash, ancestor.Number.Uint64()-1)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, fmt.Errorf("header %d %x not found", ancestor.Number.Uint64()-1, ancestor.ParentHash)
+		}
+		ancestor = parent
+		if ok, err = isCanonical(ancestor); err != nil {
+			return nil, err
+		}
+	}
+	for i, j := 0, len(removed)-1; i < j; i, j = i+1, j-1 {
+		removed[i], removed[j] = removed[j], removed[i]
+	}
+
+	event := &ReorgEvent{
+		CommonAncestor: BlockRef{Number: hexutil.Uint64(ancestor.Number.Uint64()), Hash: ancestor.Hash()},
+		Removed:        removed,
+		Truncated:      !ok,
+	}
+	for n := ancestor.Number.Uint64() + 1; n <= newHead.Number.Uint64() && len(event.Added) < maxReorgEventDepth; n++ {
+		hash, found, err := api._blockReader.CanonicalHash(ctx, tx, n)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			break
+		}
+		event.Added = append(event.Added, hash)
+	}
+	return event, nil
+}
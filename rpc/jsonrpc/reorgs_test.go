@@ -0,0 +1,75 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestReorgEvent(t *testing.T) {
+	m := mock.Mock(t)
+	ctx := context.Background()
+	oldChain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 3, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{1})
+	})
+	require.NoError(t, err)
+	// same first block, then fork
+	newChain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 5, func(i int, b *core.BlockGen) {
+		if i == 0 {
+			b.SetCoinbase(common.Address{1})
+			return
+		}
+		b.SetCoinbase(common.Address{2})
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(oldChain))
+	require.NoError(t, m.InsertChain(newChain))
+
+	api := newBaseApiForTest(m)
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	event, err := api.reorgEvent(ctx, tx, oldChain.TopBlock.Header(), newChain.TopBlock.Header())
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	require.Equal(t, oldChain.Blocks[0].Hash(), event.CommonAncestor.Hash)
+	require.Equal(t, uint64(1), uint64(event.CommonAncestor.Number))
+	require.Equal(t, []common.Hash{oldChain.Blocks[1].Hash(), oldChain.Blocks[2].Hash()}, event.Removed)
+	require.Equal(t, oldChain.Blocks[0].Hash(), newChain.Blocks[0].Hash())
+	require.Len(t, event.Added, 4)
+	for i, b := range newChain.Blocks[1:] {
+		require.Equal(t, b.Hash(), event.Added[i])
+	}
+	require.False(t, event.Truncated)
+
+	// new head extends previous one - not a reorg
+	event, err = api.reorgEvent(ctx, tx, newChain.Blocks[2].Header(), newChain.TopBlock.Header())
+	require.NoError(t, err)
+	require.Nil(t, event)
+	// previous head is still canonical (missed heads) - not a reorg
+	event, err = api.reorgEvent(ctx, tx, oldChain.Blocks[0].Header(), newChain.TopBlock.Header())
+	require.NoError(t, err)
+	require.Nil(t, event)
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// StateDiffFilter - criteria of eth_subscribe("stateDiffs"), empty Addresses - all accounts
+type StateDiffFilter struct {
+	Addresses []common.Address `json:"addresses"`
+}
+
+// BlockStateDiff - changes of accounts and storage made by one block, notification of eth_subscribe("stateDiffs")
+type BlockStateDiff struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	Accounts    []*AccountDiff `json:"accounts"`
+}
+
+// AccountDiff - account state before and after the block, nil Before - account created, nil After - account deleted
+type AccountDiff struct {
+	Address common.Address `json:"address"`
+	Before  *AccountState  `json:"before"`
+	After   *AccountState  `json:"after"`
+	Storage []StorageDiff  `json:"storage,omitempty"`
+}
+
+type AccountState struct {
+	Balance  *hexutil.Big   `json:"balance"`
+	Nonce    hexutil.Uint64 `json:"nonce"`
+	CodeHash common.Hash    `json:"codeHash"`
+}
+
+type StorageDiff struct {
+	Key    common.Hash `json:"key"`
+	Before common.Hash `json:"before"`
+	After  common.Hash `json:"after"`
+}
+
+// stateDiff - reads keys changed by block from its changeset, and their values before and after the block from history.
+// Changesets are kept only for blocks which can be unwound - returns false for older blocks.
+func (api *APIImpl) stateDiff(tx kv.TemporalTx, header *types.Header, filter *StateDiffFilter) (*BlockStateDiff, bool, error) {
+	blockNum, blockHash := header.Number.Uint64(), header.Hash()
+	diffSet, ok, err := libstate.ReadDiffSet(tx, blockNum, blockHash)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	fromTxNum, err := api._txNumReader.Min(tx, blockNum)
+	if err != nil {
+		return nil, false, err
+	}
+	toTxNum, err := api._txNumReader.Max(tx, blockNum)
+	if err != nil {
+		return nil, false, err
+	}
+	toTxNum++ // state after system txn in the end of block
+
+	var watched map[common.Address]struct{}
+	if filter != nil && len(filter.Addresses) > 0 {
+		watched = make(map[common.Address]struct{}, len(filter.Addresses))
+		for _, addr := range filter.Addresses {
+			watched[addr] = struct{}{}
+		}
+	}
+	changed := map[common.Address]*AccountDiff{}
+	account := func(addr common.Address) (*AccountDiff, bool) {
+		if watched != nil {
+			if _, ok := watched[addr]; !ok {
+				return nil, false
+			}
+		}
+		if d, ok := changed[addr]; ok {
+			return d, true
+		}
+		d := &AccountDiff{Address: addr}
+		changed[addr] = d
+		return d, true
+	}
+
+	for _, entry := range diffSet[kv.AccountsDomain] {
+		key := changesetKey(entry)
+		if len(key) != length.Addr {
+			continue
+		}
+		d, ok := account(common.BytesToAddress(key))
+		if !ok {
+			continue
+		}
+		before, err := accountStateAsOf(tx, key, fromTxNum)
+		if err != nil {
+			return nil, false, err
+		}
+		after, err := accountStateAsOf(tx, key, toTxNum)
+		if err != nil {
+			return nil, false, err
+		}
+		d.Before, d.After = before, after
+	}
+	for _, entry := range diffSet[kv.StorageDomain] {
+		key := changesetKey(entry)
+		if len(key) != length.Addr+length.Hash {
+			continue
+		}
+		d, ok := account(common.BytesToAddress(key[:length.Addr]))
+		if !ok {
+			continue
+		}
+		before, _, err := tx.GetAsOf(kv.StorageDomain, key, fromTxNum)
+		if err != nil {
+			return nil, false, err
+		}
+		after, _, err := tx.GetAsOf(kv.StorageDomain, key, toTxNum)
+		if err != nil {
+			return nil, false, err
+		}
+		if bytes.Equal(before, after) {
+			continue
+		}
+		d.Storage = append(d.Storage, StorageDiff{Key: common.BytesToHash(key[length.Addr:]), Before: common.BytesToHash(before), After: common.BytesToHash(after)})
+	}
+
+	result := &BlockStateDiff{BlockNumber: hexutil.Uint64(blockNum), BlockHash: blockHash, Accounts: make([]*AccountDiff, 0, len(changed))}
+	for addr, d := range changed {
+		if len(d.Storage) == 0 && d.Before == nil && d.After == nil {
+			continue
+		}
+		if d.Before == nil && d.After == nil { // only storage changed - account itself is same
+			st, err := accountStateAsOf(tx, addr[:], toTxNum)
+			if err != nil {
+				return nil, false, err
+			}
+			d.Before, d.After = st, st
+		}
+		result.Accounts = append(result.Accounts, d)
+	}
+	sort.Slice(result.Accounts, func(i, j int) bool {
+		return bytes.Compare(result.Accounts[i].Address[:], result.Accounts[j].Address[:]) < 0
+	})
+	return result, true, nil
+}
+
+// changesetKey - domain key of changeset entry, without step suffix
+func changesetKey(entry kv.DomainEntryDiff) []byte {
+	return []byte(entry.Key[:len(entry.Key)-8])
+}
+
+func accountStateAsOf(tx kv.TemporalTx, addr []byte, txNum uint64) (*AccountState, error) {
+	v, _, err := tx.GetAsOf(kv.AccountsDomain, addr, txNum)
+	if err != nil {
+		return nil, err
+	}
+	if len(v) == 0 {
+		return nil, nil
+	}
+	var acc accounts.Account
+	if err := accounts.DeserialiseV3(&acc, v); err != nil {
+		return nil, fmt.Errorf("account %x: %w", addr, err)
+	}
+	return &AccountState{Balance: (*hexutil.Big)(acc.Balance.ToBig()), Nonce: hexutil.Uint64(acc.Nonce), CodeHash: acc.CodeHash}, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/eth/ethconfig"
+)
+
+func TestStateDiff(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, ethconfig.Defaults.RPCTxFeeCap, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	tx, err := m.DB.BeginTemporalRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	// block 1: transfer of 0.001 eth from address to 0x01
+	header, err := m.BlockReader.HeaderByNumber(ctx, tx, 1)
+	require.NoError(t, err)
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender, receiver := crypto.PubkeyToAddress(key.PublicKey), common.Address{1}
+
+	diff, ok, err := api.stateDiff(tx, header, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, header.Hash(), diff.BlockHash)
+	byAddr := map[common.Address]*AccountDiff{}
+	for _, d := range diff.Accounts {
+		byAddr[d.Address] = d
+	}
+	require.Contains(t, byAddr, sender)
+	require.Contains(t, byAddr, receiver)
+	require.Nil(t, byAddr[receiver].Before)
+	require.Equal(t, uint64(1_000_000_000_000_000), byAddr[receiver].After.Balance.ToInt().Uint64())
+	require.Equal(t, uint64(0), uint64(byAddr[sender].Before.Nonce))
+	require.Equal(t, uint64(1), uint64(byAddr[sender].After.Nonce))
+
+	diff, ok, err = api.stateDiff(tx, header, &StateDiffFilter{Addresses: []common.Address{receiver}})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, diff.Accounts, 1)
+	require.Equal(t, receiver, diff.Accounts[0].Address)
+
+	// block 4: token mint - storage change
+	header, err = m.BlockReader.HeaderByNumber(ctx, tx, 4)
+	require.NoError(t, err)
+	diff, ok, err = api.stateDiff(tx, header, nil)
+	require.NoError(t, err)
+	require.True(t, ok)
+	var storageChanges int
+	for _, d := range diff.Accounts {
+		storageChanges += len(d.Storage)
+		for _, s := range d.Storage {
+			require.NotEqual(t, s.Before, s.After)
+		}
+	}
+	require.NotZero(t, storageChanges)
+}
@@ -22,12 +22,14 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/types/accounts"
 )
 
 // StorageRangeResult is the result of a debug_storageRangeAt API call.
 type StorageRangeResult struct {
-	Storage storageMap   `json:"storage"`
-	NextKey *common.Hash `json:"nextKey"` // nil if Storage includes the last key in the trie.
+	Storage storageMap               `json:"storage"`
+	NextKey *common.Hash             `json:"nextKey"`         // nil if Storage includes the last key in the trie.
+	Proof   *accounts.AccProofResult `json:"proof,omitempty"` // account proof and proofs of all slots in Storage, if requested
 }
 
 // storageMap a map from storage locations to StorageEntry items
@@ -1120,11 +1120,11 @@ func (api *TraceAPIImpl) Call(ctx context.Context, args TraceCallParam, traceTyp
 			return nil, errors.New("header.BaseFee uint256 overflow")
 		}
 	}
-	msg, err := args.ToMessage(api.gasCap, baseFee)
+	msg, err := args.ToMessage(api.rpcGasCap(api.gasCap), baseFee)
 	if err != nil {
 		return nil, err
 	}
-	txn, err := args.ToTransaction(api.gasCap, baseFee)
+	txn, err := args.ToTransaction(api.rpcGasCap(api.gasCap), baseFee)
 	if err != nil {
 		return nil, err
 	}
@@ -1260,12 +1260,12 @@ func (api *TraceAPIImpl) CallMany(ctx context.Context, calls json.RawMessage, pa
 	msgs := make([]*types.Message, len(callParams))
 	txns := make([]types.Transaction, len(callParams))
 	for i, args := range callParams {
-		msgs[i], err = args.ToMessage(api.gasCap, baseFee)
+		msgs[i], err = args.ToMessage(api.rpcGasCap(api.gasCap), baseFee)
 		if err != nil {
 			return nil, fmt.Errorf("convert callParam to msg: %w", err)
 		}
 
-		txns[i], err = args.ToTransaction(api.gasCap, baseFee)
+		txns[i], err = args.ToTransaction(api.rpcGasCap(api.gasCap), baseFee)
 		if err != nil {
 			return nil, fmt.Errorf("convert callParam to txn: %w", err)
 		}
@@ -412,11 +412,11 @@ func (api *PrivateDebugAPIImpl) TraceCall(ctx context.Context, args ethapi.CallA
 			return errors.New("header.BaseFee uint256 overflow")
 		}
 	}
-	msg, err := args.ToMessage(api.GasCap, baseFee)
+	msg, err := args.ToMessage(api.rpcGasCap(api.GasCap), baseFee)
 	if err != nil {
 		return fmt.Errorf("convert args to msg: %v", err)
 	}
-	transaction, err := args.ToTransaction(api.GasCap, baseFee)
+	transaction, err := args.ToTransaction(api.rpcGasCap(api.GasCap), baseFee)
 	if err != nil {
 		return fmt.Errorf("convert args to msg: %v", err)
 	}
@@ -552,15 +552,16 @@ func (api *PrivateDebugAPIImpl) TraceCallMany(ctx context.Context, bundles []Bun
 		ibs.Reset()
 		for txnIndex, txn := range bundle.Transactions {
 			if txn.Gas == nil || *(txn.Gas) == 0 {
-				txn.Gas = (*hexutil.Uint64)(&api.GasCap)
+				gasCap := hexutil.Uint64(api.rpcGasCap(api.GasCap))
+				txn.Gas = &gasCap
 			}
-			msg, err := txn.ToMessage(api.GasCap, blockCtx.BaseFee)
+			msg, err := txn.ToMessage(api.rpcGasCap(api.GasCap), blockCtx.BaseFee)
 			if err != nil {
 				stream.WriteArrayEnd()
 				stream.WriteArrayEnd()
 				return err
 			}
-			transaction, err := txn.ToTransaction(api.GasCap, blockCtx.BaseFee)
+			transaction, err := txn.ToTransaction(api.rpcGasCap(api.GasCap), blockCtx.BaseFee)
 			if err != nil {
 				stream.WriteNil()
 				return err
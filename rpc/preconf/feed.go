@@ -0,0 +1,213 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package preconf
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	FeedArbitrum    = "arbitrum"    // Arbitrum sequencer feed (nitro broadcaster)
+	FeedFlashblocks = "flashblocks" // OP Stack flashblocks stream (rollup-boost websocket proxy)
+)
+
+// Decoder - raw transactions of one feed message. Transactions of rollup-specific types, which are not
+// Ethereum transactions, are skipped by Feed.
+type Decoder func(msg []byte) ([][]byte, error)
+
+func DecoderByName(name string) (Decoder, error) {
+	switch name {
+	case FeedArbitrum:
+		return DecodeArbitrum, nil
+	case FeedFlashblocks:
+		return DecodeFlashblocks, nil
+	default:
+		return nil, fmt.Errorf("unknown sequencer feed type %q, supported: %s, %s", name, FeedArbitrum, FeedFlashblocks)
+	}
+}
+
+const (
+	arbL1MessageTypeL2Message = 3
+	arbL2MessageKindBatch     = 3
+	arbL2MessageKindSignedTx  = 4
+	arbMaxBatchDepth          = 16
+)
+
+type arbBroadcast struct {
+	Messages []struct {
+		SequenceNumber uint64 `json:"sequenceNumber"`
+		Message        struct {
+			Message struct {
+				Header struct {
+					Kind uint8 `json:"kind"`
+				} `json:"header"`
+				L2Msg []byte `json:"l2Msg"` // base64
+			} `json:"message"`
+		} `json:"message"`
+	} `json:"messages"`
+}
+
+// DecodeArbitrum - signed transactions of L2 messages of broadcast, batches are unpacked
+func DecodeArbitrum(msg []byte) ([][]byte, error) {
+	var b arbBroadcast
+	if err := json.Unmarshal(msg, &b); err != nil {
+		return nil, err
+	}
+	var txns [][]byte
+	for _, m := range b.Messages {
+		if m.Message.Message.Header.Kind != arbL1MessageTypeL2Message {
+			continue
+		}
+		var err error
+		if txns, err = appendArbL2Msg(txns, m.Message.Message.L2Msg, 0); err != nil {
+			return nil, fmt.Errorf("message %d: %w", m.SequenceNumber, err)
+		}
+	}
+	return txns, nil
+}
+
+func appendArbL2Msg(txns [][]byte, l2Msg []byte, depth int) ([][]byte, error) {
+	if len(l2Msg) == 0 {
+		return txns, nil
+	}
+	switch l2Msg[0] {
+	case arbL2MessageKindSignedTx:
+		return append(txns, l2Msg[1:]), nil
+	case arbL2MessageKindBatch:
+		if depth >= arbMaxBatchDepth {
+			return nil, errors.New("batch nesting is too deep")
+		}
+		rest := l2Msg[1:]
+		for len(rest) > 0 {
+			if len(rest) < 8 {
+				return nil, errors.New("truncated batch item length")
+			}
+			size := binary.BigEndian.Uint64(rest)
+			rest = rest[8:]
+			if size > uint64(len(rest)) {
+				return nil, errors.New("truncated batch item")
+			}
+			var err error
+			if txns, err = appendArbL2Msg(txns, rest[:size], depth+1); err != nil {
+				return nil, err
+			}
+			rest = rest[size:]
+		}
+		return txns, nil
+	default: // unsigned and contract transactions, heartbeats - not Ethereum transactions
+		return txns, nil
+	}
+}
+
+type flashblock struct {
+	Diff struct {
+		Transactions []hexutil.Bytes `json:"transactions"`
+	} `json:"diff"`
+}
+
+// DecodeFlashblocks - transactions of flashblock diff
+func DecodeFlashblocks(msg []byte) ([][]byte, error) {
+	var fb flashblock
+	if err := json.Unmarshal(msg, &fb); err != nil {
+		return nil, err
+	}
+	txns := make([][]byte, len(fb.Diff.Transactions))
+	for i, txn := range fb.Diff.Transactions {
+		txns[i] = txn
+	}
+	return txns, nil
+}
+
+// Feed - websocket connection to sequencer feed, reconnects until context is cancelled
+type Feed struct {
+	url    string
+	decode Decoder
+	store  *Store
+	logger log.Logger
+}
+
+func NewFeed(url string, decode Decoder, store *Store, logger log.Logger) *Feed {
+	return &Feed{url: url, decode: decode, store: store, logger: logger}
+}
+
+func (f *Feed) Run(ctx context.Context) {
+	const minBackoff, maxBackoff = time.Second, 30 * time.Second
+	backoff := minBackoff
+	for {
+		err := f.read(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		f.logger.Warn("[preconf] sequencer feed disconnected", "url", f.url, "err", err, "retryIn", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, maxBackoff)
+	}
+}
+
+func (f *Feed) read(ctx context.Context) error {
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, _, err := dialer.DialContext(ctx, f.url, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	f.logger.Info("[preconf] connected to sequencer feed", "url", f.url)
+
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+		f.store.Add(f.Decode(msg))
+	}
+}
+
+// Decode - Ethereum transactions of feed message, others are skipped
+func (f *Feed) Decode(msg []byte) []types.Transaction {
+	raw, err := f.decode(msg)
+	if err != nil {
+		f.logger.Debug("[preconf] can't decode feed message", "err", err)
+		return nil
+	}
+	txns := make([]types.Transaction, 0, len(raw))
+	for _, r := range raw {
+		txn, err := types.DecodeTransaction(r)
+		if err != nil {
+			f.logger.Trace("[preconf] skip transaction", "err", err)
+			continue
+		}
+		txns = append(txns, txn)
+	}
+	return txns
+}
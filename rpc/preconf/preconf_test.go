@@ -0,0 +1,121 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package preconf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func signedTxns(t *testing.T, n int) ([]types.Transaction, [][]byte) {
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSignerForChainID(uint256.NewInt(1).ToBig())
+	txns := make([]types.Transaction, n)
+	raw := make([][]byte, n)
+	for i := range txns {
+		txn, err := types.SignTx(types.NewTransaction(uint64(i), common.Address{1}, uint256.NewInt(1), params.TxGas, uint256.NewInt(1), nil), *signer, key)
+		require.NoError(t, err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, txn.MarshalBinary(buf))
+		txns[i], raw[i] = txn, buf.Bytes()
+	}
+	return txns, raw
+}
+
+func arbMessage(seq uint64, kind uint8, l2Msg []byte) string {
+	return fmt.Sprintf(`{"sequenceNumber":%d,"message":{"message":{"header":{"kind":%d},"l2Msg":"%s"}}}`, seq, kind, base64.StdEncoding.EncodeToString(l2Msg))
+}
+
+func TestDecodeArbitrum(t *testing.T) {
+	txns, raw := signedTxns(t, 3)
+
+	single := append([]byte{arbL2MessageKindSignedTx}, raw[0]...)
+	batch := []byte{arbL2MessageKindBatch}
+	for _, r := range raw[1:] {
+		item := append([]byte{arbL2MessageKindSignedTx}, r...)
+		batch = binary.BigEndian.AppendUint64(batch, uint64(len(item)))
+		batch = append(batch, item...)
+	}
+	batch = binary.BigEndian.AppendUint64(batch, 1)
+	batch = append(batch, 0) // unsigned tx kind without body - skipped
+	delayed := append([]byte{arbL2MessageKindSignedTx}, raw[0]...)
+
+	msg := fmt.Sprintf(`{"version":1,"messages":[%s,%s,%s]}`, arbMessage(10, arbL1MessageTypeL2Message, single), arbMessage(11, arbL1MessageTypeL2Message, batch), arbMessage(12, 12, delayed))
+	feed := NewFeed("", DecodeArbitrum, NewStore(), log.New())
+	decoded := feed.Decode([]byte(msg))
+	require.Len(t, decoded, 3)
+	for i := range txns {
+		require.Equal(t, txns[i].Hash(), decoded[i].Hash())
+	}
+
+	truncated := fmt.Sprintf(`{"version":1,"messages":[%s]}`, arbMessage(13, arbL1MessageTypeL2Message, batch[:5]))
+	_, err := DecodeArbitrum([]byte(truncated))
+	require.Error(t, err)
+}
+
+func TestDecodeFlashblocks(t *testing.T) {
+	txns, raw := signedTxns(t, 2)
+	msg := fmt.Sprintf(`{"payload_id":"0x01","index":1,"diff":{"transactions":["0x%x","0x7e00","0x%x"]},"metadata":{"block_number":5}}`, raw[0], raw[1])
+	feed := NewFeed("", DecodeFlashblocks, NewStore(), log.New())
+	decoded := feed.Decode([]byte(msg))
+	require.Len(t, decoded, 2) // deposit transaction is not Ethereum transaction
+	require.Equal(t, txns[0].Hash(), decoded[0].Hash())
+	require.Equal(t, txns[1].Hash(), decoded[1].Hash())
+
+	_, err := DecoderByName("unknown")
+	require.Error(t, err)
+}
+
+func TestStore(t *testing.T) {
+	txns, _ := signedTxns(t, 3)
+	s := NewStore()
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+
+	ch, id := s.Subscribe(4)
+	s.Add(txns[:2])
+	s.Add(txns[1:]) // txns[1] is known
+	require.Equal(t, txns[:2], <-ch)
+	require.Equal(t, txns[2:], <-ch)
+	require.Equal(t, txns, s.Txns())
+
+	s.Remove([]common.Hash{txns[1].Hash()})
+	require.Equal(t, []types.Transaction{txns[0], txns[2]}, s.Txns())
+
+	now = now.Add(maxAge + time.Second)
+	require.Empty(t, s.Txns())
+	s.Add(txns[:1]) // expired transaction can be pre-confirmed again
+	require.Equal(t, txns[:1], s.Txns())
+	require.Equal(t, txns[:1], <-ch)
+
+	s.Unsubscribe(id)
+	_, ok := <-ch
+	require.False(t, ok)
+}
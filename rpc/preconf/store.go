@@ -0,0 +1,149 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package preconf keeps transactions pre-confirmed by rollup sequencer (received from its feed) until they
+// are included into canonical chain.
+package preconf
+
+import (
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	maxTxns = 8192            // oldest pre-confirmed transactions are dropped above this limit
+	maxAge  = 2 * time.Minute // sequencer includes pre-confirmed transactions into chain much faster
+)
+
+type preconfirmed struct {
+	txn      types.Transaction
+	received time.Time
+}
+
+// Store - pre-confirmed transactions in sequencer order
+type Store struct {
+	mu    sync.Mutex
+	txns  []preconfirmed
+	known map[common.Hash]struct{}
+
+	subs   map[uint64]chan []types.Transaction
+	lastID uint64
+
+	now func() time.Time
+}
+
+func NewStore() *Store {
+	return &Store{
+		known: map[common.Hash]struct{}{},
+		subs:  map[uint64]chan []types.Transaction{},
+		now:   time.Now,
+	}
+}
+
+// Add - appends new transactions of feed, already known are ignored. Notifies subscribers.
+func (s *Store) Add(txns []types.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	added := make([]types.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		hash := txn.Hash()
+		if _, ok := s.known[hash]; ok {
+			continue
+		}
+		s.known[hash] = struct{}{}
+		s.txns = append(s.txns, preconfirmed{txn: txn, received: now})
+		added = append(added, txn)
+	}
+	if len(s.txns) > maxTxns {
+		s.drop(len(s.txns) - maxTxns)
+	}
+	if len(added) == 0 {
+		return
+	}
+	for _, ch := range s.subs {
+		select {
+		case ch <- added:
+		default: // slow subscriber
+		}
+	}
+}
+
+// Txns - pre-confirmed transactions which are not expired, in sequencer order
+func (s *Store) Txns() []types.Transaction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expired := 0
+	for expired < len(s.txns) && s.now().Sub(s.txns[expired].received) > maxAge {
+		expired++
+	}
+	s.drop(expired)
+	res := make([]types.Transaction, len(s.txns))
+	for i := range s.txns {
+		res[i] = s.txns[i].txn
+	}
+	return res
+}
+
+// Remove - forgets transactions which are included into chain
+func (s *Store) Remove(hashes []common.Hash) {
+	if len(hashes) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, hash := range hashes {
+		delete(s.known, hash)
+	}
+	kept := s.txns[:0]
+	for _, p := range s.txns {
+		if _, ok := s.known[p.txn.Hash()]; ok {
+			kept = append(kept, p)
+		}
+	}
+	clear(s.txns[len(kept):])
+	s.txns = kept
+}
+
+func (s *Store) drop(n int) {
+	for _, p := range s.txns[:n] {
+		delete(s.known, p.txn.Hash())
+	}
+	clear(s.txns[:n])
+	s.txns = s.txns[n:]
+}
+
+// Subscribe - new pre-confirmed transactions. Notifications are dropped if subscriber doesn't keep up.
+func (s *Store) Subscribe(size int) (<-chan []types.Transaction, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastID++
+	ch := make(chan []types.Transaction, size)
+	s.subs[s.lastID] = ch
+	return ch, s.lastID
+}
+
+func (s *Store) Unsubscribe(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch, ok := s.subs[id]; ok {
+		close(ch)
+		delete(s.subs, id)
+	}
+}
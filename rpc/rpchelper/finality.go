@@ -0,0 +1,89 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rpchelper
+
+import (
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	borfinality "github.com/erigontech/erigon/polygon/bor/finality"
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
+)
+
+// FinalitySourceForkchoice - safe/finalized blocks of last forkchoiceUpdated: from Caplin, external consensus layer
+// or polygon sync. Polygon with milestones whitelisting has sources borfinality.SourceMilestone and SourceCheckpoint.
+const FinalitySourceForkchoice = "forkchoice"
+
+// FinalizedBlock - block which "safe" or "finalized" tag resolves to
+type FinalizedBlock struct {
+	Number uint64
+	Hash   common.Hash
+	Source string
+}
+
+// FinalityReader - resolves "safe" and "finalized" block tags, ok=false if block is not known yet
+type FinalityReader interface {
+	Safe(tx kv.Tx) (b FinalizedBlock, ok bool, err error)
+	Finalized(tx kv.Tx) (b FinalizedBlock, ok bool, err error)
+}
+
+// Finality - finality of this chain: milestones whitelisting on polygon (if enabled), forkchoice otherwise
+func Finality() FinalityReader {
+	if whitelist.GetWhitelistingService() != nil {
+		return borFinality{}
+	}
+	return forkchoiceFinality{}
+}
+
+type forkchoiceFinality struct{}
+
+func (forkchoiceFinality) Safe(tx kv.Tx) (FinalizedBlock, bool, error) {
+	return forkchoiceBlock(tx, rawdb.ReadForkchoiceSafe(tx))
+}
+
+func (forkchoiceFinality) Finalized(tx kv.Tx) (FinalizedBlock, bool, error) {
+	return forkchoiceBlock(tx, rawdb.ReadForkchoiceFinalized(tx))
+}
+
+func forkchoiceBlock(tx kv.Tx, hash common.Hash) (FinalizedBlock, bool, error) {
+	if hash == (common.Hash{}) {
+		return FinalizedBlock{}, false, nil
+	}
+	num := rawdb.ReadHeaderNumber(tx, hash)
+	if num == nil {
+		return FinalizedBlock{}, false, nil
+	}
+	return FinalizedBlock{Number: *num, Hash: hash, Source: FinalitySourceForkchoice}, true, nil
+}
+
+// borFinality - milestones are the only finality of bor, so "safe" is same as "finalized" - unless set by forkchoice
+type borFinality struct{}
+
+func (f borFinality) Safe(tx kv.Tx) (FinalizedBlock, bool, error) {
+	if b, ok, err := (forkchoiceFinality{}).Safe(tx); ok || err != nil {
+		return b, ok, err
+	}
+	return f.Finalized(tx)
+}
+
+func (borFinality) Finalized(tx kv.Tx) (FinalizedBlock, bool, error) {
+	number, hash, source := borfinality.GetFinalizedBlock(tx)
+	if number == 0 {
+		return FinalizedBlock{}, false, nil
+	}
+	return FinalizedBlock{Number: number, Hash: hash, Source: source}, true, nil
+}
@@ -18,10 +18,8 @@ package rpchelper
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
-	"github.com/erigontech/erigon-db/rawdb"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
@@ -30,8 +28,6 @@ import (
 	"github.com/erigontech/erigon-lib/wrap"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
-	borfinality "github.com/erigontech/erigon/polygon/bor/finality"
-	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/turbo/services"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
@@ -83,17 +79,6 @@ func _GetBlockNumber(ctx context.Context, requireCanonical bool, blockNrOrHash r
 		case rpc.EarliestBlockNumber:
 			blockNumber = 0
 		case rpc.FinalizedBlockNumber:
-			if whitelist.GetWhitelistingService() != nil {
-				num := borfinality.GetFinalizedBlockNumber(tx)
-				if num == 0 {
-					// nolint
-					return 0, common.Hash{}, false, false, errors.New("No finalized block")
-				}
-
-				blockNum := borfinality.CurrentFinalizedBlock(tx, num).NumberU64()
-				blockHash := rawdb.ReadHeaderByNumber(tx, blockNum).Hash()
-				return blockNum, blockHash, false, false, nil
-			}
 			blockNumber, err = GetFinalizedBlockNumber(tx)
 			if err != nil {
 				return 0, common.Hash{}, false, false, err
@@ -112,6 +97,10 @@ func _GetBlockNumber(ctx context.Context, requireCanonical bool, blockNrOrHash r
 			}
 		case rpc.LatestExecutedBlockNumber:
 			blockNumber = plainStateBlockNumber
+		case rpc.PreconfirmedBlockNumber: // state of pre-confirmed transactions is not stored
+			if blockNumber, err = GetLatestBlockNumber(tx); err != nil {
+				return 0, common.Hash{}, false, false, err
+			}
 		default:
 			blockNumber = uint64(number.Int64())
 		}
@@ -43,5 +43,8 @@ type ApiBackend interface {
 	NodeInfo(ctx context.Context, limit uint32) ([]p2p.NodeInfo, error)
 	Peers(ctx context.Context) ([]*p2p.PeerInfo, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	BanPeer(ctx context.Context, req *remote.BanPeerRequest) (*remote.BanPeerReply, error)
+	UnbanPeer(ctx context.Context, req *remote.UnbanPeerRequest) (*remote.UnbanPeerReply, error)
+	BannedPeers(ctx context.Context) ([]*p2p.BannedPeerInfo, error)
 	PendingBlock(ctx context.Context) (*types.Block, error)
 }
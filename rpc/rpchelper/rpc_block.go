@@ -48,27 +48,28 @@ func GetLatestBlockNumber(tx kv.Tx) (uint64, error) {
 	return blockNum, nil
 }
 
+// GetFinalizedBlockNumber - block of "finalized" tag, see Finality
 func GetFinalizedBlockNumber(tx kv.Tx) (uint64, error) {
-	forkchoiceFinalizedHash := rawdb.ReadForkchoiceFinalized(tx)
-	if forkchoiceFinalizedHash != (common.Hash{}) {
-		forkchoiceFinalizedNum := rawdb.ReadHeaderNumber(tx, forkchoiceFinalizedHash)
-		if forkchoiceFinalizedNum != nil {
-			return *forkchoiceFinalizedNum, nil
-		}
+	b, ok, err := Finality().Finalized(tx)
+	if err != nil {
+		return 0, err
 	}
-
-	return 0, UnknownBlockError
+	if !ok {
+		return 0, UnknownBlockError
+	}
+	return b.Number, nil
 }
 
+// GetSafeBlockNumber - block of "safe" tag, see Finality
 func GetSafeBlockNumber(tx kv.Tx) (uint64, error) {
-	forkchoiceSafeHash := rawdb.ReadForkchoiceSafe(tx)
-	if forkchoiceSafeHash != (common.Hash{}) {
-		forkchoiceSafeNum := rawdb.ReadHeaderNumber(tx, forkchoiceSafeHash)
-		if forkchoiceSafeNum != nil {
-			return *forkchoiceSafeNum, nil
-		}
+	b, ok, err := Finality().Safe(tx)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, UnknownBlockError
 	}
-	return 0, UnknownBlockError
+	return b.Number, nil
 }
 
 func GetLatestExecutedBlockNumber(tx kv.Tx) (uint64, error) {
@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -51,6 +52,7 @@ const (
 type Server struct {
 	services        serviceRegistry
 	methodAllowList AllowList
+	apiKeys         APIKeys
 	idgen           func() ID
 	run             int32
 	codecs          mapset.Set // mapset.Set[ServerCodec] requires go 1.20
@@ -80,6 +82,11 @@ func (s *Server) SetAllowList(allowList AllowList) {
 	s.methodAllowList = allowList
 }
 
+// SetAPIKeys - http and websocket clients must send one of keys, each key has own methods allowlist
+func (s *Server) SetAPIKeys(keys APIKeys) {
+	s.apiKeys = keys
+}
+
 // SetBatchLimit sets limit of number of requests in a batch
 func (s *Server) SetBatchLimit(limit int) {
 	s.batchLimit = limit
@@ -199,6 +206,14 @@ type PeerInfo struct {
 		Origin    string
 		Host      string
 	}
+
+	// APIKey - authenticated api key of client, nil if server doesn't require keys.
+	APIKey *APIKey `json:"-"`
+}
+
+// Origin - transport and address of client, e.g. "http 1.2.3.4:5678"
+func (info PeerInfo) Origin() string {
+	return strings.TrimSpace(info.Transport + " " + info.RemoteAddr)
 }
 
 type peerInfoContextKey struct{}
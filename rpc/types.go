@@ -78,6 +78,7 @@ type BlockNumber int64
 type Timestamp uint64
 
 const (
+	PreconfirmedBlockNumber   = BlockNumber(-6)
 	LatestExecutedBlockNumber = BlockNumber(-5)
 	FinalizedBlockNumber      = BlockNumber(-4)
 	SafeBlockNumber           = BlockNumber(-3)
@@ -87,6 +88,7 @@ const (
 )
 
 var (
+	PreconfirmedBlock   = PreconfirmedBlockNumber.AsBlockReference()
 	LatestExecutedBlock = LatestExecutedBlockNumber.AsBlockReference()
 	FinalizedBlock      = FinalizedBlockNumber.AsBlockReference()
 	SafeBlock           = SafeBlockNumber.AsBlockReference()
@@ -96,7 +98,7 @@ var (
 )
 
 // UnmarshalJSON parses the given JSON fragment into a BlockNumber. It supports:
-// - "latest", "earliest", "pending", "safe", or "finalized" as string arguments
+// - "latest", "earliest", "pending", "safe", "finalized" or "preconfirmed" as string arguments
 // - the block number
 // Returned errors:
 // - an invalid block number error when the given argument isn't a known strings
@@ -126,6 +128,9 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 	case "latestExecuted":
 		*bn = LatestExecutedBlockNumber
 		return nil
+	case "preconfirmed":
+		*bn = PreconfirmedBlockNumber
+		return nil
 	case "null":
 		*bn = LatestBlockNumber
 		return nil
@@ -148,7 +153,7 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 
 func (bn BlockNumber) MarshalText() ([]byte, error) {
 	switch {
-	case bn < LatestExecutedBlockNumber:
+	case bn < PreconfirmedBlockNumber:
 		return nil, fmt.Errorf("Invalid block number %d", bn)
 	case bn < 0:
 		return []byte(bn.String()), nil
@@ -187,6 +192,8 @@ func (bn BlockNumber) string(base int) string {
 		return "finalized"
 	case LatestExecutedBlockNumber:
 		return "latestExecuted"
+	case PreconfirmedBlockNumber:
+		return "preconfirmed"
 	}
 
 	if base == 16 {
@@ -222,7 +229,7 @@ func AsBlockNumber(no interface{}) BlockNumber {
 		}
 	}
 
-	return LatestExecutedBlockNumber - 1
+	return PreconfirmedBlockNumber - 1
 }
 
 type BlockNumberOrHash struct {
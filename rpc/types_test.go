@@ -50,6 +50,7 @@ func TestBlockNumberJSONUnmarshal(t *testing.T) {
 		14: {`someString`, true, BlockNumber(0)},
 		15: {`""`, true, BlockNumber(0)},
 		16: {``, true, BlockNumber(0)},
+		17: {`"preconfirmed"`, false, PreconfirmedBlockNumber},
 	}
 
 	for i, test := range tests {
@@ -20,7 +20,6 @@
 package tests
 
 import (
-	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -76,7 +75,7 @@ func TestExecutionSpecBlockchain(t *testing.T) {
 
 	bt := new(testMatcher)
 
-	dir := filepath.Join(".", "execution-spec-tests", "blockchain_tests")
+	dir := eestDir(t, "blockchain_tests")
 	bt.skipLoad(`^prague/eip2935_historical_block_hashes_from_state/block_hashes/block_hashes_history.json`)
 	checkStateRoot := true
 
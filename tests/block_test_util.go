@@ -159,23 +159,27 @@ func (bt *BlockTest) Run(t *testing.T, checkStateRoot bool) error {
 }
 
 func (bt *BlockTest) genesis(config *chain.Config) *types.Genesis {
+	return testGenesis(config, &bt.json.Genesis, bt.json.Pre)
+}
+
+func testGenesis(config *chain.Config, header *btHeader, pre types.GenesisAlloc) *types.Genesis {
 	return &types.Genesis{
 		Config:                config,
-		Nonce:                 bt.json.Genesis.Nonce.Uint64(),
-		Timestamp:             bt.json.Genesis.Timestamp,
-		ParentHash:            bt.json.Genesis.ParentHash,
-		ExtraData:             bt.json.Genesis.ExtraData,
-		GasLimit:              bt.json.Genesis.GasLimit,
-		GasUsed:               bt.json.Genesis.GasUsed,
-		Difficulty:            bt.json.Genesis.Difficulty,
-		Mixhash:               bt.json.Genesis.MixHash,
-		Coinbase:              bt.json.Genesis.Coinbase,
-		Alloc:                 bt.json.Pre,
-		BaseFee:               bt.json.Genesis.BaseFeePerGas,
-		BlobGasUsed:           bt.json.Genesis.BlobGasUsed,
-		ExcessBlobGas:         bt.json.Genesis.ExcessBlobGas,
-		ParentBeaconBlockRoot: bt.json.Genesis.ParentBeaconBlockRoot,
-		RequestsHash:          bt.json.Genesis.RequestsHash,
+		Nonce:                 header.Nonce.Uint64(),
+		Timestamp:             header.Timestamp,
+		ParentHash:            header.ParentHash,
+		ExtraData:             header.ExtraData,
+		GasLimit:              header.GasLimit,
+		GasUsed:               header.GasUsed,
+		Difficulty:            header.Difficulty,
+		Mixhash:               header.MixHash,
+		Coinbase:              header.Coinbase,
+		Alloc:                 pre,
+		BaseFee:               header.BaseFeePerGas,
+		BlobGasUsed:           header.BlobGasUsed,
+		ExcessBlobGas:         header.ExcessBlobGas,
+		ParentBeaconBlockRoot: header.ParentBeaconBlockRoot,
+		RequestsHash:          header.RequestsHash,
 	}
 }
 
@@ -314,8 +318,12 @@ func validateHeader(h *btHeader, h2 *types.Header) error {
 }
 
 func (bt *BlockTest) validatePostState(statedb *state.IntraBlockState) error {
+	return validatePostState(statedb, bt.json.Post)
+}
+
+func validatePostState(statedb *state.IntraBlockState, post types.GenesisAlloc) error {
 	// validate post state accounts in test file against what we have in state db
-	for addr, acct := range bt.json.Post {
+	for addr, acct := range post {
 		// address is indirectly verified by the other fields, as it's the db key
 		code2, err := statedb.GetCode(addr)
 		if err != nil {
@@ -0,0 +1,165 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Fixtures of execution-spec-tests (EEST) are taken from the eest-fixtures submodule by default.
+// With EEST_VERSION set to a release tag, the fixtures of the release are downloaded instead - once,
+// into EEST_CACHE (default: user cache dir) - so that readiness for a fork is checked against the
+// upstream vectors, e.g. EEST_VERSION=v4.3.0 EEST_FLAVOR=develop. See `make test-eest`.
+const (
+	eestVersionEnv = "EEST_VERSION"
+	eestFlavorEnv  = "EEST_FLAVOR" // stable or develop, default: develop
+	eestCacheEnv   = "EEST_CACHE"
+
+	eestReleaseURL      = "https://github.com/ethereum/execution-spec-tests/releases/download/%s/fixtures_%s.tar.gz"
+	eestDownloadTimeout = 30 * time.Minute
+)
+
+var (
+	eestSubmoduleDir = filepath.Join(".", "execution-spec-tests")
+
+	eestDownloadMu sync.Mutex
+)
+
+// eestDir returns the directory of EEST fixtures of the format: blockchain_tests, blockchain_tests_engine,
+// state_tests, ...
+func eestDir(t *testing.T, format string) string {
+	version := os.Getenv(eestVersionEnv)
+	if version == "" {
+		return filepath.Join(eestSubmoduleDir, format)
+	}
+	flavor := os.Getenv(eestFlavorEnv)
+	if flavor == "" {
+		flavor = "develop"
+	}
+	root, err := downloadEESTRelease(version, flavor)
+	if err != nil {
+		t.Fatalf("can't get EEST fixtures %s (%s): %v", version, flavor, err)
+	}
+	return filepath.Join(root, "fixtures", format)
+}
+
+// downloadEESTRelease downloads and unpacks the fixtures of the release, unless they are in the cache already.
+func downloadEESTRelease(version, flavor string) (string, error) {
+	eestDownloadMu.Lock()
+	defer eestDownloadMu.Unlock()
+
+	cacheDir := os.Getenv(eestCacheEnv)
+	if cacheDir == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		cacheDir = filepath.Join(userCacheDir, "erigon", "eest")
+	}
+	root := filepath.Join(cacheDir, version+"-"+flavor)
+	if _, err := os.Stat(root); err == nil {
+		return root, nil
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), eestDownloadTimeout)
+	defer cancel()
+	url := fmt.Sprintf(eestReleaseURL, version, flavor)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", url, resp.Status)
+	}
+
+	// unpack next to the destination and rename, so that an interrupted download is not taken for the fixtures
+	tmpDir, err := os.MkdirTemp(cacheDir, version+"-"+flavor+"-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := untarGz(resp.Body, tmpDir); err != nil {
+		return "", fmt.Errorf("%s: %w", url, err)
+	}
+	if err := os.Rename(tmpDir, root); err != nil {
+		return "", err
+	}
+	return root, nil
+}
+
+func untarGz(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.Clean(hdr.Name))
+		if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("illegal path in archive: %s", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
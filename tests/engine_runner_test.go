@@ -0,0 +1,270 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/math"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpccfg"
+	"github.com/erigontech/erigon/turbo/engineapi"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// An EngineTest checks handling of blocks delivered through the Engine API, as hive's engine
+// simulators do, but without docker: the node serves the authenticated Engine API over HTTP and a
+// mock consensus client sends it every payload with engine_newPayload, then makes the valid ones
+// the head with engine_forkchoiceUpdated.
+type EngineTest struct {
+	json etJSON
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (et *EngineTest) UnmarshalJSON(in []byte) error {
+	return json.Unmarshal(in, &et.json)
+}
+
+// etJSON - the blockchain_test_engine fixture format of execution-spec-tests
+type etJSON struct {
+	Payloads  []etPayload           `json:"engineNewPayloads"`
+	Genesis   btHeader              `json:"genesisBlockHeader"`
+	Pre       types.GenesisAlloc    `json:"pre"`
+	Post      types.GenesisAlloc    `json:"postState"`
+	BestBlock common.UnprefixedHash `json:"lastblockhash"`
+	Network   string                `json:"network"`
+}
+
+type etPayload struct {
+	Params                   []json.RawMessage   `json:"params"`
+	NewPayloadVersion        math.HexOrDecimal64 `json:"newPayloadVersion"`
+	ForkchoiceUpdatedVersion math.HexOrDecimal64 `json:"forkchoiceUpdatedVersion"`
+	ValidationError          string              `json:"validationError"`
+	ErrorCode                *etErrorCode        `json:"errorCode"`
+}
+
+// etErrorCode - JSON-RPC error code, which fixtures have as a string or as a number
+type etErrorCode int
+
+func (c *etErrorCode) UnmarshalJSON(input []byte) error {
+	if len(input) > 1 && input[0] == '"' {
+		input = input[1 : len(input)-1]
+	}
+	code, err := strconv.Atoi(string(input))
+	if err != nil {
+		return fmt.Errorf("invalid error code %q: %w", input, err)
+	}
+	*c = etErrorCode(code)
+	return nil
+}
+
+func (et *EngineTest) Run(t *testing.T) error {
+	config, ok := Forks[et.json.Network]
+	if !ok {
+		return UnsupportedForkError{et.json.Network}
+	}
+
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.New())
+	m := mock.MockWithGenesisEngine(t, testGenesis(config, &et.json.Genesis, et.json.Pre), engine, true, true)
+	if m.Genesis.Hash() != et.json.Genesis.Hash {
+		return fmt.Errorf("genesis block hash doesn't match test: computed=%x, test=%x", m.Genesis.Hash().Bytes()[:6], et.json.Genesis.Hash[:6])
+	}
+
+	cl, err := startEngineNode(t, m)
+	if err != nil {
+		return err
+	}
+
+	for i, payload := range et.json.Payloads {
+		hash, status, err := cl.newPayload(m.Ctx, payload)
+		if payload.ErrorCode != nil {
+			var rpcErr rpc.Error
+			if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != int(*payload.ErrorCode) {
+				return fmt.Errorf("payload %d: expected error code %d, have: %v", i, *payload.ErrorCode, err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("payload %d: %w", i, err)
+		}
+		if payload.ValidationError != "" {
+			if status.Status != engine_types.InvalidStatus {
+				return fmt.Errorf("payload %d: expected %s (%s), have: %s", i, engine_types.InvalidStatus, payload.ValidationError, status.Status)
+			}
+			continue
+		}
+		if status.Status != engine_types.ValidStatus {
+			return fmt.Errorf("payload %d: expected %s, have: %s (%v)", i, engine_types.ValidStatus, status.Status, status.ValidationError)
+		}
+
+		fcu, err := cl.forkchoiceUpdated(m.Ctx, payload.ForkchoiceUpdatedVersion, hash)
+		if err != nil {
+			return fmt.Errorf("payload %d: forkchoice update: %w", i, err)
+		}
+		if fcu.PayloadStatus.Status != engine_types.ValidStatus {
+			return fmt.Errorf("payload %d: forkchoice update: expected %s, have: %s (%v)", i, engine_types.ValidStatus, fcu.PayloadStatus.Status, fcu.PayloadStatus.ValidationError)
+		}
+	}
+
+	tx, err := m.DB.BeginRo(m.Ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	head := rawdb.ReadHeadBlockHash(tx)
+	if common.Hash(et.json.BestBlock) != head {
+		return fmt.Errorf("last block hash validation mismatch: want: %x, have: %x", et.json.BestBlock, head)
+	}
+	if err := validatePostState(state.New(m.NewStateReader(tx)), et.json.Post); err != nil {
+		return fmt.Errorf("post state validation failed: %w", err)
+	}
+	return nil
+}
+
+// clDriver is the mock consensus client, calling the Engine API of the node over authenticated HTTP.
+type clDriver struct {
+	client *engineapi.JsonRpcClient
+}
+
+// startEngineNode serves the Engine API of the mock node on a local port, with a fresh JWT secret.
+func startEngineNode(t *testing.T, m *mock.MockSentry) (*clDriver, error) {
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	jwtSecret := make([]byte, 32)
+	if _, err := rand.Read(jwtSecret); err != nil {
+		return nil, err
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt.hex")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(jwtSecret)), 0600); err != nil {
+		return nil, err
+	}
+
+	httpConfig := &httpcfg.HttpCfg{
+		AuthRpcHTTPListenAddress: "127.0.0.1",
+		AuthRpcPort:              port,
+		AuthRpcTimeouts:          rpccfg.DefaultHTTPTimeouts,
+		JWTSecretPath:            jwtPath,
+		EvmCallTimeout:           rpccfg.DefaultEvmCallTimeout,
+	}
+	executionRpc := direct.NewExecutionClientDirect(m.Eth1ExecutionService)
+	engineServer := engineapi.NewEngineServer(m.Log, m.ChainConfig, executionRpc, m.HeaderDownload(), nil, false, true, false, true)
+	engineServer.Start(m.Ctx, httpConfig, m.DB, m.BlockReader, nil, nil, m.Engine, nil, nil, nil)
+
+	client, err := engineapi.DialJsonRpcClient(fmt.Sprintf("http://127.0.0.1:%d", port), jwtSecret, m.Log,
+		engineapi.WithJsonRpcClientMaxRetries(0))
+	if err != nil {
+		return nil, err
+	}
+	return &clDriver{client: client}, nil
+}
+
+// newPayload sends the payload with the engine_newPayload version of the fixture.
+func (cl *clDriver) newPayload(ctx context.Context, payload etPayload) (common.Hash, *engine_types.PayloadStatus, error) {
+	version := payload.NewPayloadVersion
+	if len(payload.Params) == 0 {
+		return common.Hash{}, nil, errors.New("payload without params")
+	}
+	var executionPayload engine_types.ExecutionPayload
+	if err := json.Unmarshal(payload.Params[0], &executionPayload); err != nil {
+		return common.Hash{}, nil, err
+	}
+
+	var blobHashes []common.Hash
+	var parentBeaconBlockRoot *common.Hash
+	var executionRequests []hexutil.Bytes
+	if version >= 3 {
+		if len(payload.Params) < 3 {
+			return common.Hash{}, nil, fmt.Errorf("engine_newPayloadV%d: expected at least 3 params, have %d", version, len(payload.Params))
+		}
+		if err := json.Unmarshal(payload.Params[1], &blobHashes); err != nil {
+			return common.Hash{}, nil, err
+		}
+		if err := json.Unmarshal(payload.Params[2], &parentBeaconBlockRoot); err != nil {
+			return common.Hash{}, nil, err
+		}
+	}
+	if version >= 4 {
+		if len(payload.Params) < 4 {
+			return common.Hash{}, nil, fmt.Errorf("engine_newPayloadV%d: expected 4 params, have %d", version, len(payload.Params))
+		}
+		if err := json.Unmarshal(payload.Params[3], &executionRequests); err != nil {
+			return common.Hash{}, nil, err
+		}
+	}
+
+	var status *engine_types.PayloadStatus
+	var err error
+	switch version {
+	case 1:
+		status, err = cl.client.NewPayloadV1(ctx, &executionPayload)
+	case 2:
+		status, err = cl.client.NewPayloadV2(ctx, &executionPayload)
+	case 3:
+		status, err = cl.client.NewPayloadV3(ctx, &executionPayload, blobHashes, parentBeaconBlockRoot)
+	case 4:
+		status, err = cl.client.NewPayloadV4(ctx, &executionPayload, blobHashes, parentBeaconBlockRoot, executionRequests)
+	default:
+		return common.Hash{}, nil, fmt.Errorf("unsupported engine_newPayload version: %d", version)
+	}
+	return executionPayload.BlockHash, status, err
+}
+
+// forkchoiceUpdated makes the block the head, without payload attributes.
+func (cl *clDriver) forkchoiceUpdated(ctx context.Context, version math.HexOrDecimal64, head common.Hash) (*engine_types.ForkChoiceUpdatedResponse, error) {
+	forkchoiceState := &engine_types.ForkChoiceState{HeadHash: head}
+	switch version {
+	case 1:
+		return cl.client.ForkchoiceUpdatedV1(ctx, forkchoiceState, nil)
+	case 2:
+		return cl.client.ForkchoiceUpdatedV2(ctx, forkchoiceState, nil)
+	case 3:
+		return cl.client.ForkchoiceUpdatedV3(ctx, forkchoiceState, nil)
+	default:
+		return nil, fmt.Errorf("unsupported engine_forkchoiceUpdated version: %d", version)
+	}
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// engineTestDirEnv - directory of blockchain_test_engine fixtures to run instead of the EEST ones,
+// see `make test-hive-local`.
+const engineTestDirEnv = "ENGINE_FIXTURES"
+
+func TestEngineConformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	defer log.Root().SetHandler(log.Root().GetHandler())
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlError, log.StderrHandler))
+
+	et := new(testMatcher)
+
+	dir := os.Getenv(engineTestDirEnv)
+	if dir == "" {
+		dir = eestDir(t, "blockchain_tests_engine")
+	}
+
+	et.walk(t, dir, func(t *testing.T, name string, test *EngineTest) {
+		t.Parallel()
+		if err := et.checkFailure(t, test.Run(t)); err != nil {
+			t.Error(err)
+		}
+	})
+}
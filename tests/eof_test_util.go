@@ -0,0 +1,59 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tests
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+// EOFTest is a container validation test, see
+// https://ethereum-tests.readthedocs.io/en/latest/test_types/eof_tests.html
+type EOFTest struct {
+	Vectors map[string]EOFTestVector `json:"vectors"`
+}
+
+type EOFTestVector struct {
+	Code          hexutil.Bytes            `json:"code"`
+	ContainerKind string                   `json:"containerKind"`
+	Results       map[string]EOFTestResult `json:"results"`
+}
+
+type EOFTestResult struct {
+	Result    bool   `json:"result"`
+	Exception string `json:"exception,omitempty"`
+}
+
+// Run validates every vector of the test against the expectation for fork.
+func (t *EOFTest) Run(fork string) error {
+	for name, v := range t.Vectors {
+		expected, ok := v.Results[fork]
+		if !ok {
+			continue
+		}
+		err := vm.ValidateEOF(v.Code, v.ContainerKind == "INITCODE")
+		if expected.Result && err != nil {
+			return fmt.Errorf("vector %s: unexpected validation error: %w", name, err)
+		}
+		if !expected.Result && err == nil {
+			return fmt.Errorf("vector %s: expected %s, container accepted", name, expected.Exception)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,412 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package evmdiff is a differential fuzzer of the EVM: a message call is executed by Erigon's
+// interpreter and by a reference implementation - evmone, bound via cgo when built with the
+// `evmone` tag - and any difference in status, gas, output, logs, storage or balances is a
+// consensus bug in one of them.
+//
+// A case is a call from an EOA into a contract, which may call the second contract of the state
+// (CALL, CALLCODE, DELEGATECALL, STATICCALL). Cases which create or destroy contracts or call
+// precompiles are out of scope of the reference host, and skipped.
+package evmdiff
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/config3"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	state3 "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus"
+)
+
+var (
+	Sender   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	Target   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	Callee   = common.HexToAddress("0x3000000000000000000000000000000000000003")
+	Coinbase = common.HexToAddress("0xc000000000000000000000000000000000000000")
+)
+
+// Block and transaction context of all cases. The rules are Cancun's.
+const (
+	BlockNumber   = 1_000
+	BlockTime     = 1_700_000_000
+	BlockGasLimit = 30_000_000
+	ChainID       = 1
+)
+
+var (
+	GasPrice    = uint256.NewInt(10)
+	BaseFee     = uint256.NewInt(7)
+	BlobBaseFee = uint256.NewInt(1)
+	PrevRandao  = common.Hash{0x7a}
+
+	ChainConfig = &chain.Config{
+		ChainID:               big.NewInt(ChainID),
+		HomesteadBlock:        new(big.Int),
+		TangerineWhistleBlock: new(big.Int),
+		SpuriousDragonBlock:   new(big.Int),
+		ByzantiumBlock:        new(big.Int),
+		ConstantinopleBlock:   new(big.Int),
+		PetersburgBlock:       new(big.Int),
+		IstanbulBlock:         new(big.Int),
+		MuirGlacierBlock:      new(big.Int),
+		BerlinBlock:           new(big.Int),
+		LondonBlock:           new(big.Int),
+		ArrowGlacierBlock:     new(big.Int),
+		GrayGlacierBlock:      new(big.Int),
+		ShanghaiTime:          new(big.Int),
+		CancunTime:            new(big.Int),
+	}
+)
+
+// BlockHash of the block with number n, for n in [BlockNumber-256, BlockNumber).
+func BlockHash(n uint64) common.Hash {
+	return crypto.Keccak256Hash(new(big.Int).SetUint64(n).Bytes())
+}
+
+// MaxGas - gas of a case is cut to it, so that cases stay quick
+const MaxGas = 10_000_000
+
+// Case - a call of the Target contract by the Sender.
+type Case struct {
+	Code   []byte // of the Target
+	Callee []byte // code of the Callee
+	Input  []byte
+	Gas    uint64
+	Value  uint64
+}
+
+func (c *Case) String() string {
+	return fmt.Sprintf("code=%x callee=%x input=%x gas=%d value=%d", c.Code, c.Callee, c.Input, c.Gas, c.Value)
+}
+
+type Account struct {
+	Nonce   uint64
+	Balance uint256.Int
+	Code    []byte
+	Storage map[common.Hash]uint256.Int
+}
+
+// PreState - the accounts a case starts with. Contracts have storage, so that clearing and
+// restoring slots - which affect gas and refunds - are reachable.
+func (c *Case) PreState() map[common.Address]*Account {
+	return map[common.Address]*Account{
+		Sender: {Nonce: 1, Balance: *uint256.NewInt(1_000_000_000_000_000_000)},
+		Target: {Nonce: 1, Balance: *uint256.NewInt(1_000_000_000), Code: c.Code,
+			Storage: map[common.Hash]uint256.Int{{}: *uint256.NewInt(1)}},
+		Callee: {Nonce: 1, Code: c.Callee,
+			Storage: map[common.Hash]uint256.Int{{31: 1}: *uint256.NewInt(2)}},
+	}
+}
+
+type Status int
+
+const (
+	StatusSuccess Status = iota
+	StatusRevert
+	StatusFailure
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusSuccess:
+		return "success"
+	case StatusRevert:
+		return "revert"
+	default:
+		return "failure"
+	}
+}
+
+// Outcome of a case - everything implementations must agree upon.
+type Outcome struct {
+	Status   Status
+	GasLeft  uint64
+	Refund   uint64 // on success only
+	Output   []byte // unless failed
+	Logs     []*types.Log
+	Storage  map[common.Address]map[common.Hash]uint256.Int // missing slots are zero
+	Balances map[common.Address]uint256.Int                 // missing accounts have zero balance
+}
+
+// ErrUnsupported - the case is out of scope of the reference implementation.
+var ErrUnsupported = errors.New("case is not supported by the reference")
+
+// ReferenceEVM - an EVM implementation Erigon is diffed against.
+type ReferenceEVM interface {
+	Name() string
+	Execute(c *Case) (*Outcome, error)
+}
+
+// Reference is set by the binding of the reference implementation, nil without one.
+var Reference ReferenceEVM
+
+// Check executes the case in Erigon and in the Reference, if there is one, and returns their
+// difference as an error.
+func Check(c *Case) error {
+	if c.Gas > MaxGas {
+		c.Gas %= MaxGas
+	}
+	var ref *Outcome
+	if Reference != nil {
+		var err error
+		if ref, err = Reference.Execute(c); err != nil {
+			return err
+		}
+	}
+	erigon, err := ExecuteErigon(c, ref)
+	if err != nil || ref == nil {
+		return err
+	}
+	if err := Diff(erigon, ref); err != nil {
+		return fmt.Errorf("erigon vs %s: %w\n%s", Reference.Name(), err, c)
+	}
+	return nil
+}
+
+var (
+	dbOnce sync.Once
+	db     kv.TemporalRwDB
+	dbErr  error
+	dbMu   sync.Mutex
+)
+
+// openDB - one in-memory db per process, every case is executed in a transaction which is rolled back.
+func openDB() (kv.TemporalRwDB, error) {
+	dbOnce.Do(func() {
+		tmpdir, err := os.MkdirTemp("", "evmdiff")
+		if err != nil {
+			dbErr = err
+			return
+		}
+		logger := log.New()
+		dirs := datadir.New(tmpdir)
+		rawDB := memdb.NewStateDB(tmpdir)
+		salt, err := state3.GetStateIndicesSalt(dirs, true, logger)
+		if err != nil {
+			dbErr = err
+			return
+		}
+		agg, err := state3.NewAggregator2(context.Background(), dirs, config3.DefaultStepSize, salt, rawDB, logger)
+		if err != nil {
+			dbErr = err
+			return
+		}
+		db, dbErr = temporal.New(rawDB, agg)
+	})
+	return db, dbErr
+}
+
+// ExecuteErigon executes the case in Erigon's interpreter. Slots and balances which changed in the
+// reference outcome are read too, so that a change missed by Erigon shows in the diff.
+func ExecuteErigon(c *Case, ref *Outcome) (*Outcome, error) {
+	db, err := openDB()
+	if err != nil {
+		return nil, err
+	}
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	ctx := context.Background()
+	tx, err := db.BeginTemporalRw(ctx) //nolint:gocritic
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	sd, err := state3.NewSharedDomains(tx, log.New())
+	if err != nil {
+		return nil, err
+	}
+	defer sd.Close()
+
+	rules := ChainConfig.Rules(BlockNumber, BlockTime)
+	reader, writer := state.NewReaderV3(sd), state.NewWriter(sd, nil)
+
+	// the pre-state is committed, so that it is the original state of the slots for sstore gas
+	slots := map[common.Address]map[common.Hash]struct{}{}
+	accounts := map[common.Address]struct{}{Coinbase: {}}
+	preState := state.New(reader)
+	for addr, acc := range c.PreState() {
+		accounts[addr] = struct{}{}
+		if err := preState.CreateAccount(addr, len(acc.Code) > 0); err != nil {
+			return nil, err
+		}
+		if err := preState.SetNonce(addr, acc.Nonce); err != nil {
+			return nil, err
+		}
+		if err := preState.SetBalance(addr, &acc.Balance, tracing.BalanceChangeUnspecified); err != nil {
+			return nil, err
+		}
+		if err := preState.SetCode(addr, acc.Code); err != nil {
+			return nil, err
+		}
+		for key, value := range acc.Storage {
+			touchSlot(slots, addr, key)
+			if err := preState.SetState(addr, &key, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := preState.FinalizeTx(rules, writer); err != nil {
+		return nil, err
+	}
+
+	ibs := state.New(reader)
+	ibs.SetHooks(&tracing.Hooks{
+		OnStorageChange: func(addr common.Address, slot *common.Hash, _, _ uint256.Int) {
+			touchSlot(slots, addr, *slot)
+		},
+		OnBalanceChange: func(addr common.Address, _, _ *uint256.Int, _ tracing.BalanceChangeReason) {
+			accounts[addr] = struct{}{}
+		},
+	})
+	if err := ibs.Prepare(rules, Sender, Coinbase, &Target, vm.ActivePrecompiles(rules), nil, nil); err != nil {
+		return nil, err
+	}
+	blockContext := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    consensus.Transfer,
+		GetHash:     BlockHash,
+		Coinbase:    Coinbase,
+		GasLimit:    BlockGasLimit,
+		BlockNumber: BlockNumber,
+		Time:        BlockTime,
+		Difficulty:  new(big.Int),
+		BaseFee:     BaseFee,
+		PrevRanDao:  &PrevRandao,
+		BlobBaseFee: BlobBaseFee,
+	}
+	evm := vm.NewEVM(blockContext, evmtypes.TxContext{Origin: Sender, GasPrice: GasPrice}, ibs, ChainConfig, vm.Config{})
+	ret, gasLeft, err := evm.Call(vm.AccountRef(Sender), Target, c.Input, c.Gas, uint256.NewInt(c.Value), false /* bailout */)
+
+	out := &Outcome{
+		GasLeft:  gasLeft,
+		Storage:  map[common.Address]map[common.Hash]uint256.Int{},
+		Balances: map[common.Address]uint256.Int{},
+	}
+	switch {
+	case err == nil:
+		out.Status, out.Refund, out.Output, out.Logs = StatusSuccess, ibs.GetRefund(), ret, ibs.Logs()
+	case errors.Is(err, vm.ErrExecutionReverted):
+		out.Status, out.Output = StatusRevert, ret
+	default:
+		out.Status = StatusFailure
+	}
+
+	if ref != nil {
+		for addr, storage := range ref.Storage {
+			for key := range storage {
+				touchSlot(slots, addr, key)
+			}
+		}
+		for addr := range ref.Balances {
+			accounts[addr] = struct{}{}
+		}
+	}
+	for addr, keys := range slots {
+		out.Storage[addr] = map[common.Hash]uint256.Int{}
+		for key := range keys {
+			var value uint256.Int
+			if err := ibs.GetState(addr, &key, &value); err != nil {
+				return nil, err
+			}
+			out.Storage[addr][key] = value
+		}
+	}
+	for addr := range accounts {
+		balance, err := ibs.GetBalance(addr)
+		if err != nil {
+			return nil, err
+		}
+		out.Balances[addr] = *balance
+	}
+	return out, nil
+}
+
+func touchSlot(slots map[common.Address]map[common.Hash]struct{}, addr common.Address, key common.Hash) {
+	if slots[addr] == nil {
+		slots[addr] = map[common.Hash]struct{}{}
+	}
+	slots[addr][key] = struct{}{}
+}
+
+// Diff returns the first difference of the outcomes.
+func Diff(a, b *Outcome) error {
+	if a.Status != b.Status {
+		return fmt.Errorf("status: %s != %s", a.Status, b.Status)
+	}
+	if a.GasLeft != b.GasLeft {
+		return fmt.Errorf("gas left: %d != %d", a.GasLeft, b.GasLeft)
+	}
+	if a.Status != StatusFailure && !bytes.Equal(a.Output, b.Output) {
+		return fmt.Errorf("output: %x != %x", a.Output, b.Output)
+	}
+	if a.Status == StatusSuccess {
+		if a.Refund != b.Refund {
+			return fmt.Errorf("refund: %d != %d", a.Refund, b.Refund)
+		}
+		if len(a.Logs) != len(b.Logs) {
+			return fmt.Errorf("logs: %d != %d", len(a.Logs), len(b.Logs))
+		}
+		for i := range a.Logs {
+			if a.Logs[i].Address != b.Logs[i].Address || !slices.Equal(a.Logs[i].Topics, b.Logs[i].Topics) || !bytes.Equal(a.Logs[i].Data, b.Logs[i].Data) {
+				return fmt.Errorf("log %d: %x %x %x != %x %x %x", i, a.Logs[i].Address, a.Logs[i].Topics, a.Logs[i].Data, b.Logs[i].Address, b.Logs[i].Topics, b.Logs[i].Data)
+			}
+		}
+	}
+	for _, addresses := range []map[common.Address]map[common.Hash]uint256.Int{a.Storage, b.Storage} {
+		for addr, storage := range addresses {
+			for key := range storage {
+				va, vb := a.Storage[addr][key], b.Storage[addr][key]
+				if !va.Eq(&vb) {
+					return fmt.Errorf("storage %x %x: %d != %d", addr, key, &va, &vb)
+				}
+			}
+		}
+	}
+	for _, balances := range []map[common.Address]uint256.Int{a.Balances, b.Balances} {
+		for addr := range balances {
+			ba, bb := a.Balances[addr], b.Balances[addr]
+			if !ba.Eq(&bb) {
+				return fmt.Errorf("balance %x: %d != %d", addr, &ba, &bb)
+			}
+		}
+	}
+	return nil
+}
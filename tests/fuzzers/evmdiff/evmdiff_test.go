@@ -0,0 +1,76 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package evmdiff
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+// FuzzDifferential - the seed corpus is in testdata/fuzz/FuzzDifferential, and runs with every `go test`, like
+// the failing inputs `go test -fuzz` saves there. Without the evmone tag only Erigon runs, catching crashes.
+func FuzzDifferential(f *testing.F) {
+	f.Fuzz(func(t *testing.T, code, callee, input []byte, gas, value uint64) {
+		c := &Case{Code: code, Callee: callee, Input: input, Gas: gas, Value: value % 1_000_000}
+		err := Check(c)
+		if errors.Is(err, ErrUnsupported) {
+			t.Skip(err)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestExecuteErigon(t *testing.T) {
+	t.Run("sstore", func(t *testing.T) {
+		// sstore(5, 42), sstore(0, 0)
+		out, err := ExecuteErigon(&Case{Code: common.FromHex("602a600555600060005500"), Gas: 100_000}, nil)
+		require.NoError(t, err)
+		require.Equal(t, StatusSuccess, out.Status)
+		// 2 * (push + push) + new slot (cold 2100 + 20000) + cleared slot (cold 2100 + 2900)
+		require.Equal(t, uint64(100_000-27_112), out.GasLeft)
+		require.Equal(t, uint64(4_800), out.Refund)
+		require.Equal(t, *uint256.NewInt(42), out.Storage[Target][common.Hash{31: 5}])
+		v := out.Storage[Target][common.Hash{}]
+		require.True(t, v.IsZero())
+	})
+	t.Run("revert", func(t *testing.T) {
+		// sstore(5, 42), revert(0, 0)
+		out, err := ExecuteErigon(&Case{Code: common.FromHex("602a60055560006000fd"), Gas: 100_000, Value: 7}, nil)
+		require.NoError(t, err)
+		require.Equal(t, StatusRevert, out.Status)
+		v := out.Storage[Target][common.Hash{31: 5}]
+		require.True(t, v.IsZero())
+		require.Equal(t, *uint256.NewInt(1_000_000_000), out.Balances[Target])
+	})
+}
+
+func TestDiff(t *testing.T) {
+	a := &Outcome{Storage: map[common.Address]map[common.Hash]uint256.Int{Target: {{}: *uint256.NewInt(1)}}}
+	b := &Outcome{Storage: map[common.Address]map[common.Hash]uint256.Int{}}
+	require.ErrorContains(t, Diff(a, b), "storage")
+	require.NoError(t, Diff(a, a))
+
+	b = &Outcome{Storage: a.Storage, GasLeft: 1}
+	require.ErrorContains(t, Diff(a, b), "gas left")
+}
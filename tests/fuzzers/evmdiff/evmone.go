@@ -0,0 +1,466 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build evmone && cgo
+
+package evmdiff
+
+/*
+#cgo LDFLAGS: -levmone
+#include <stdbool.h>
+#include <stdlib.h>
+#include <stdint.h>
+#include <evmc/evmc.h>
+#include <evmone/evmone.h>
+#include "evmone_host.h"
+*/
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// Built with `-tags evmone`: evmone and EVMC headers must be installed, e.g. CGO_CFLAGS=-I<evmone>/include
+// CGO_LDFLAGS=-L<evmone>/lib.
+func init() {
+	Reference = &evmone{vm: C.evmc_create_evmone()}
+}
+
+type evmone struct {
+	vm *C.struct_evmc_vm
+}
+
+func (e *evmone) Name() string { return "evmone" }
+
+// cancunPrecompiles - calls to them are out of scope of the host, they are warm from the start
+var cancunPrecompiles = func() map[common.Address]bool {
+	precompiles := map[common.Address]bool{}
+	for i := byte(1); i <= 0x0a; i++ {
+		precompiles[common.BytesToAddress([]byte{i})] = true
+	}
+	return precompiles
+}()
+
+type hostAccount struct {
+	nonce   uint64
+	balance uint256.Int
+	code    []byte
+	storage map[common.Hash]uint256.Int
+}
+
+func (a *hostAccount) empty() bool {
+	return a.nonce == 0 && a.balance.IsZero() && len(a.code) == 0
+}
+
+// hostState - everything a failed call reverts
+type hostState struct {
+	accounts  map[common.Address]*hostAccount
+	transient map[common.Address]map[common.Hash]common.Hash
+	warm      map[common.Address]bool
+	warmSlots map[common.Address]map[common.Hash]bool
+	logs      []*types.Log
+}
+
+func (s *hostState) copy() *hostState {
+	cpy := &hostState{
+		accounts:  make(map[common.Address]*hostAccount, len(s.accounts)),
+		transient: make(map[common.Address]map[common.Hash]common.Hash, len(s.transient)),
+		warm:      make(map[common.Address]bool, len(s.warm)),
+		warmSlots: make(map[common.Address]map[common.Hash]bool, len(s.warmSlots)),
+		logs:      append([]*types.Log(nil), s.logs...),
+	}
+	for addr, acc := range s.accounts {
+		accCopy := *acc
+		accCopy.storage = make(map[common.Hash]uint256.Int, len(acc.storage))
+		for key, value := range acc.storage {
+			accCopy.storage[key] = value
+		}
+		cpy.accounts[addr] = &accCopy
+	}
+	for addr, slots := range s.transient {
+		cpy.transient[addr] = make(map[common.Hash]common.Hash, len(slots))
+		for key, value := range slots {
+			cpy.transient[addr][key] = value
+		}
+	}
+	for addr := range s.warm {
+		cpy.warm[addr] = true
+	}
+	for addr, slots := range s.warmSlots {
+		cpy.warmSlots[addr] = make(map[common.Hash]bool, len(slots))
+		for key := range slots {
+			cpy.warmSlots[addr][key] = true
+		}
+	}
+	return cpy
+}
+
+func (s *hostState) account(addr common.Address) *hostAccount {
+	acc, ok := s.accounts[addr]
+	if !ok {
+		acc = &hostAccount{storage: map[common.Hash]uint256.Int{}}
+		s.accounts[addr] = acc
+	}
+	return acc
+}
+
+type host struct {
+	vm          *C.struct_evmc_vm
+	state       *hostState
+	original    map[common.Address]map[common.Hash]uint256.Int // storage at the start of the transaction
+	unsupported bool
+}
+
+func (e *evmone) Execute(c *Case) (*Outcome, error) {
+	h := &host{
+		vm: e.vm,
+		state: &hostState{
+			accounts:  map[common.Address]*hostAccount{},
+			transient: map[common.Address]map[common.Hash]common.Hash{},
+			warm:      map[common.Address]bool{Sender: true, Target: true, Coinbase: true},
+			warmSlots: map[common.Address]map[common.Hash]bool{},
+		},
+		original: map[common.Address]map[common.Hash]uint256.Int{},
+	}
+	for addr := range cancunPrecompiles {
+		h.state.warm[addr] = true
+	}
+	for addr, acc := range c.PreState() {
+		storage := make(map[common.Hash]uint256.Int, len(acc.Storage))
+		for key, value := range acc.Storage {
+			storage[key] = value
+		}
+		h.state.accounts[addr] = &hostAccount{nonce: acc.Nonce, balance: acc.Balance, code: acc.Code, storage: storage}
+		h.original[addr] = acc.Storage
+	}
+
+	var msg C.struct_evmc_message
+	msg.kind = C.EVMC_CALL
+	msg.gas = C.int64_t(c.Gas)
+	msg.recipient = toEvmcAddress(Target)
+	msg.sender = toEvmcAddress(Sender)
+	msg.code_address = toEvmcAddress(Target)
+	setBytes32(unsafe.Pointer(&msg.value), uint256.NewInt(c.Value).Bytes32())
+	var input *C.uint8_t
+	if len(c.Input) > 0 {
+		input = (*C.uint8_t)(C.CBytes(c.Input))
+		defer C.free(unsafe.Pointer(input))
+	}
+	msg.input_data = input
+	msg.input_size = C.size_t(len(c.Input))
+
+	status, gasLeft, gasRefund, output := h.call(&msg)
+	if h.unsupported {
+		return nil, ErrUnsupported
+	}
+
+	out := &Outcome{
+		Status:   status,
+		GasLeft:  gasLeft,
+		Storage:  map[common.Address]map[common.Hash]uint256.Int{},
+		Balances: map[common.Address]uint256.Int{},
+	}
+	switch status {
+	case StatusSuccess:
+		out.Refund, out.Output, out.Logs = gasRefund, output, h.state.logs
+	case StatusRevert:
+		out.Output = output
+	}
+	for addr, acc := range h.state.accounts {
+		out.Storage[addr] = acc.storage
+		out.Balances[addr] = acc.balance
+	}
+	return out, nil
+}
+
+// call executes the message, reverting the state if it fails - as the host does for nested calls too.
+func (h *host) call(msg *C.struct_evmc_message) (status Status, gasLeft, gasRefund uint64, output []byte) {
+	if msg.kind != C.EVMC_CALL && msg.kind != C.EVMC_CALLCODE && msg.kind != C.EVMC_DELEGATECALL {
+		h.unsupported = true // contract creation
+		return StatusFailure, 0, 0, nil
+	}
+	codeAddress := fromEvmcAddress(&msg.code_address)
+	if cancunPrecompiles[codeAddress] {
+		h.unsupported = true
+		return StatusFailure, 0, 0, nil
+	}
+
+	snapshot := h.state.copy()
+	value := new(uint256.Int).SetBytes32(fromEvmcBytes32((*C.evmc_bytes32)(unsafe.Pointer(&msg.value))).Bytes())
+	if msg.kind == C.EVMC_CALL && !value.IsZero() {
+		sender := h.state.account(fromEvmcAddress(&msg.sender))
+		recipient := h.state.account(fromEvmcAddress(&msg.recipient))
+		sender.balance.Sub(&sender.balance, value)
+		recipient.balance.Add(&recipient.balance, value)
+	}
+
+	code := h.state.account(codeAddress).code
+	if len(code) == 0 {
+		return StatusSuccess, uint64(msg.gas), 0, nil
+	}
+
+	handle := cgo.NewHandle(h)
+	defer handle.Delete()
+	codePtr := C.CBytes(code)
+	defer C.free(codePtr)
+	result := C.evmdiff_execute(h.vm, C.uintptr_t(handle), msg, (*C.uint8_t)(codePtr), C.size_t(len(code)))
+	defer C.evmdiff_release(&result)
+
+	switch result.status_code {
+	case C.EVMC_SUCCESS:
+		status = StatusSuccess
+	case C.EVMC_REVERT:
+		status = StatusRevert
+	default:
+		status = StatusFailure
+	}
+	if status != StatusSuccess {
+		h.state = snapshot
+	}
+	if result.output_size > 0 {
+		output = C.GoBytes(unsafe.Pointer(result.output_data), C.int(result.output_size))
+	}
+	return status, uint64(result.gas_left), uint64(result.gas_refund), output
+}
+
+func hostOf(handle C.uintptr_t) *host {
+	return cgo.Handle(handle).Value().(*host)
+}
+
+//export evmdiffAccountExists
+func evmdiffAccountExists(handle C.uintptr_t, addr *C.evmc_address) C.bool {
+	acc, ok := hostOf(handle).state.accounts[fromEvmcAddress(addr)]
+	return C.bool(ok && !acc.empty())
+}
+
+//export evmdiffGetStorage
+func evmdiffGetStorage(handle C.uintptr_t, addr *C.evmc_address, key *C.evmc_bytes32) C.evmc_bytes32 {
+	acc, ok := hostOf(handle).state.accounts[fromEvmcAddress(addr)]
+	if !ok {
+		return C.evmc_bytes32{}
+	}
+	value := acc.storage[fromEvmcBytes32(key)]
+	return toEvmcBytes32(value.Bytes32())
+}
+
+//export evmdiffSetStorage
+func evmdiffSetStorage(handle C.uintptr_t, addr *C.evmc_address, key, value *C.evmc_bytes32) C.int {
+	h := hostOf(handle)
+	address, slot := fromEvmcAddress(addr), fromEvmcBytes32(key)
+	acc := h.state.account(address)
+	original := h.original[address][slot]
+	current := acc.storage[slot]
+	var next uint256.Int
+	next.SetBytes32(fromEvmcBytes32(value).Bytes())
+	acc.storage[slot] = next
+	return C.int(storageStatus(&original, &current, &next))
+}
+
+// storageStatus - EIP-2200 and EIP-3529 classification of a storage change, from which the VM charges gas and refunds
+func storageStatus(original, current, next *uint256.Int) C.enum_evmc_storage_status {
+	switch {
+	case current.Eq(next):
+		return C.EVMC_STORAGE_ASSIGNED
+	case original.Eq(current):
+		switch {
+		case original.IsZero():
+			return C.EVMC_STORAGE_ADDED
+		case next.IsZero():
+			return C.EVMC_STORAGE_DELETED
+		default:
+			return C.EVMC_STORAGE_MODIFIED
+		}
+	case !original.IsZero():
+		switch {
+		case current.IsZero() && next.Eq(original):
+			return C.EVMC_STORAGE_DELETED_RESTORED
+		case current.IsZero():
+			return C.EVMC_STORAGE_DELETED_ADDED
+		case next.IsZero():
+			return C.EVMC_STORAGE_MODIFIED_DELETED
+		case next.Eq(original):
+			return C.EVMC_STORAGE_MODIFIED_RESTORED
+		default:
+			return C.EVMC_STORAGE_ASSIGNED
+		}
+	case next.IsZero():
+		return C.EVMC_STORAGE_ADDED_DELETED
+	default:
+		return C.EVMC_STORAGE_ASSIGNED
+	}
+}
+
+//export evmdiffGetBalance
+func evmdiffGetBalance(handle C.uintptr_t, addr *C.evmc_address) C.evmc_bytes32 {
+	acc, ok := hostOf(handle).state.accounts[fromEvmcAddress(addr)]
+	if !ok {
+		return C.evmc_bytes32{}
+	}
+	return toEvmcBytes32(acc.balance.Bytes32())
+}
+
+//export evmdiffGetCodeSize
+func evmdiffGetCodeSize(handle C.uintptr_t, addr *C.evmc_address) C.size_t {
+	acc, ok := hostOf(handle).state.accounts[fromEvmcAddress(addr)]
+	if !ok {
+		return 0
+	}
+	return C.size_t(len(acc.code))
+}
+
+//export evmdiffGetCodeHash
+func evmdiffGetCodeHash(handle C.uintptr_t, addr *C.evmc_address) C.evmc_bytes32 {
+	acc, ok := hostOf(handle).state.accounts[fromEvmcAddress(addr)]
+	if !ok || acc.empty() {
+		return C.evmc_bytes32{}
+	}
+	return toEvmcBytes32(crypto.Keccak256Hash(acc.code))
+}
+
+//export evmdiffCopyCode
+func evmdiffCopyCode(handle C.uintptr_t, addr *C.evmc_address, offset C.size_t, buffer *C.uint8_t, size C.size_t) C.size_t {
+	acc, ok := hostOf(handle).state.accounts[fromEvmcAddress(addr)]
+	if !ok || int(offset) >= len(acc.code) {
+		return 0
+	}
+	n := copy(unsafe.Slice((*byte)(unsafe.Pointer(buffer)), int(size)), acc.code[offset:])
+	return C.size_t(n)
+}
+
+//export evmdiffSelfdestruct
+func evmdiffSelfdestruct(handle C.uintptr_t) C.bool {
+	hostOf(handle).unsupported = true
+	return false
+}
+
+//export evmdiffCall
+func evmdiffCall(handle C.uintptr_t, msg *C.struct_evmc_message) C.struct_evmc_result {
+	status, gasLeft, gasRefund, output := hostOf(handle).call(msg)
+	var code C.enum_evmc_status_code = C.EVMC_FAILURE
+	switch status {
+	case StatusSuccess:
+		code = C.EVMC_SUCCESS
+	case StatusRevert:
+		code = C.EVMC_REVERT
+	}
+	var outputPtr *C.uint8_t
+	if len(output) > 0 {
+		outputPtr = (*C.uint8_t)(unsafe.Pointer(&output[0]))
+	}
+	return C.evmdiff_result(code, C.int64_t(gasLeft), C.int64_t(gasRefund), outputPtr, C.size_t(len(output)))
+}
+
+//export evmdiffGetTxContext
+func evmdiffGetTxContext(handle C.uintptr_t) C.struct_evmc_tx_context {
+	var txContext C.struct_evmc_tx_context
+	setBytes32(unsafe.Pointer(&txContext.tx_gas_price), GasPrice.Bytes32())
+	txContext.tx_origin = toEvmcAddress(Sender)
+	txContext.block_coinbase = toEvmcAddress(Coinbase)
+	txContext.block_number = BlockNumber
+	txContext.block_timestamp = BlockTime
+	txContext.block_gas_limit = BlockGasLimit
+	txContext.block_prev_randao = toEvmcBytes32(PrevRandao)
+	setBytes32(unsafe.Pointer(&txContext.chain_id), uint256.NewInt(ChainID).Bytes32())
+	setBytes32(unsafe.Pointer(&txContext.block_base_fee), BaseFee.Bytes32())
+	setBytes32(unsafe.Pointer(&txContext.blob_base_fee), BlobBaseFee.Bytes32())
+	return txContext
+}
+
+//export evmdiffGetBlockHash
+func evmdiffGetBlockHash(handle C.uintptr_t, number C.int64_t) C.evmc_bytes32 {
+	return toEvmcBytes32(BlockHash(uint64(number)))
+}
+
+//export evmdiffEmitLog
+func evmdiffEmitLog(handle C.uintptr_t, addr *C.evmc_address, data *C.uint8_t, dataSize C.size_t, topics *C.evmc_bytes32, topicsCount C.size_t) {
+	h := hostOf(handle)
+	log := &types.Log{Address: fromEvmcAddress(addr), Data: C.GoBytes(unsafe.Pointer(data), C.int(dataSize))}
+	for _, topic := range unsafe.Slice(topics, int(topicsCount)) {
+		log.Topics = append(log.Topics, fromEvmcBytes32(&topic))
+	}
+	h.state.logs = append(h.state.logs, log)
+}
+
+//export evmdiffAccessAccount
+func evmdiffAccessAccount(handle C.uintptr_t, addr *C.evmc_address) C.int {
+	h, address := hostOf(handle), fromEvmcAddress(addr)
+	if h.state.warm[address] {
+		return C.EVMC_ACCESS_WARM
+	}
+	h.state.warm[address] = true
+	return C.EVMC_ACCESS_COLD
+}
+
+//export evmdiffAccessStorage
+func evmdiffAccessStorage(handle C.uintptr_t, addr *C.evmc_address, key *C.evmc_bytes32) C.int {
+	h, address, slot := hostOf(handle), fromEvmcAddress(addr), fromEvmcBytes32(key)
+	if h.state.warmSlots[address][slot] {
+		return C.EVMC_ACCESS_WARM
+	}
+	if h.state.warmSlots[address] == nil {
+		h.state.warmSlots[address] = map[common.Hash]bool{}
+	}
+	h.state.warmSlots[address][slot] = true
+	return C.EVMC_ACCESS_COLD
+}
+
+//export evmdiffGetTransientStorage
+func evmdiffGetTransientStorage(handle C.uintptr_t, addr *C.evmc_address, key *C.evmc_bytes32) C.evmc_bytes32 {
+	return toEvmcBytes32(hostOf(handle).state.transient[fromEvmcAddress(addr)][fromEvmcBytes32(key)])
+}
+
+//export evmdiffSetTransientStorage
+func evmdiffSetTransientStorage(handle C.uintptr_t, addr *C.evmc_address, key, value *C.evmc_bytes32) {
+	h, address := hostOf(handle), fromEvmcAddress(addr)
+	if h.state.transient[address] == nil {
+		h.state.transient[address] = map[common.Hash]common.Hash{}
+	}
+	h.state.transient[address][fromEvmcBytes32(key)] = fromEvmcBytes32(value)
+}
+
+func fromEvmcAddress(addr *C.evmc_address) common.Address {
+	return common.Address(C.GoBytes(unsafe.Pointer(&addr.bytes[0]), common.AddressLength))
+}
+
+func toEvmcAddress(addr common.Address) (res C.evmc_address) {
+	for i, b := range addr {
+		res.bytes[i] = C.uint8_t(b)
+	}
+	return res
+}
+
+// setBytes32 sets an evmc_uint256be field, which is an evmc_bytes32 of another name
+func setBytes32(field unsafe.Pointer, h common.Hash) {
+	*(*C.evmc_bytes32)(field) = toEvmcBytes32(h)
+}
+
+func fromEvmcBytes32(b *C.evmc_bytes32) common.Hash {
+	return common.Hash(C.GoBytes(unsafe.Pointer(&b.bytes[0]), common.HashLength))
+}
+
+func toEvmcBytes32(h common.Hash) (res C.evmc_bytes32) {
+	for i, b := range h {
+		res.bytes[i] = C.uint8_t(b)
+	}
+	return res
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build evmone && cgo
+
+// Trampolines of the EVMC host interface into the Go host: the context is the cgo handle of the host.
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "evmone_host.h"
+#include "_cgo_export.h"
+
+#define HANDLE(ctx) ((uintptr_t)(ctx))
+
+static bool account_exists(struct evmc_host_context* ctx, const evmc_address* addr) {
+    return evmdiffAccountExists(HANDLE(ctx), (evmc_address*)addr);
+}
+
+static evmc_bytes32 get_storage(struct evmc_host_context* ctx, const evmc_address* addr, const evmc_bytes32* key) {
+    return evmdiffGetStorage(HANDLE(ctx), (evmc_address*)addr, (evmc_bytes32*)key);
+}
+
+static enum evmc_storage_status set_storage(struct evmc_host_context* ctx, const evmc_address* addr,
+                                            const evmc_bytes32* key, const evmc_bytes32* value) {
+    return (enum evmc_storage_status)evmdiffSetStorage(HANDLE(ctx), (evmc_address*)addr, (evmc_bytes32*)key,
+                                                       (evmc_bytes32*)value);
+}
+
+static evmc_uint256be get_balance(struct evmc_host_context* ctx, const evmc_address* addr) {
+    return evmdiffGetBalance(HANDLE(ctx), (evmc_address*)addr);
+}
+
+static size_t get_code_size(struct evmc_host_context* ctx, const evmc_address* addr) {
+    return evmdiffGetCodeSize(HANDLE(ctx), (evmc_address*)addr);
+}
+
+static evmc_bytes32 get_code_hash(struct evmc_host_context* ctx, const evmc_address* addr) {
+    return evmdiffGetCodeHash(HANDLE(ctx), (evmc_address*)addr);
+}
+
+static size_t copy_code(struct evmc_host_context* ctx, const evmc_address* addr, size_t code_offset,
+                        uint8_t* buffer, size_t buffer_size) {
+    return evmdiffCopyCode(HANDLE(ctx), (evmc_address*)addr, code_offset, buffer, buffer_size);
+}
+
+static bool selfdestruct(struct evmc_host_context* ctx, const evmc_address* addr, const evmc_address* beneficiary) {
+    return evmdiffSelfdestruct(HANDLE(ctx));
+}
+
+static struct evmc_result call(struct evmc_host_context* ctx, const struct evmc_message* msg) {
+    return evmdiffCall(HANDLE(ctx), (struct evmc_message*)msg);
+}
+
+static struct evmc_tx_context get_tx_context(struct evmc_host_context* ctx) {
+    return evmdiffGetTxContext(HANDLE(ctx));
+}
+
+static evmc_bytes32 get_block_hash(struct evmc_host_context* ctx, int64_t number) {
+    return evmdiffGetBlockHash(HANDLE(ctx), number);
+}
+
+static void emit_log(struct evmc_host_context* ctx, const evmc_address* addr, const uint8_t* data, size_t data_size,
+                     const evmc_bytes32 topics[], size_t topics_count) {
+    evmdiffEmitLog(HANDLE(ctx), (evmc_address*)addr, (uint8_t*)data, data_size, (evmc_bytes32*)topics, topics_count);
+}
+
+static enum evmc_access_status access_account(struct evmc_host_context* ctx, const evmc_address* addr) {
+    return (enum evmc_access_status)evmdiffAccessAccount(HANDLE(ctx), (evmc_address*)addr);
+}
+
+static enum evmc_access_status access_storage(struct evmc_host_context* ctx, const evmc_address* addr,
+                                              const evmc_bytes32* key) {
+    return (enum evmc_access_status)evmdiffAccessStorage(HANDLE(ctx), (evmc_address*)addr, (evmc_bytes32*)key);
+}
+
+static evmc_bytes32 get_transient_storage(struct evmc_host_context* ctx, const evmc_address* addr,
+                                          const evmc_bytes32* key) {
+    return evmdiffGetTransientStorage(HANDLE(ctx), (evmc_address*)addr, (evmc_bytes32*)key);
+}
+
+static void set_transient_storage(struct evmc_host_context* ctx, const evmc_address* addr, const evmc_bytes32* key,
+                                  const evmc_bytes32* value) {
+    evmdiffSetTransientStorage(HANDLE(ctx), (evmc_address*)addr, (evmc_bytes32*)key, (evmc_bytes32*)value);
+}
+
+static const struct evmc_host_interface host = {
+    .account_exists = account_exists,
+    .get_storage = get_storage,
+    .set_storage = set_storage,
+    .get_balance = get_balance,
+    .get_code_size = get_code_size,
+    .get_code_hash = get_code_hash,
+    .copy_code = copy_code,
+    .selfdestruct = selfdestruct,
+    .call = call,
+    .get_tx_context = get_tx_context,
+    .get_block_hash = get_block_hash,
+    .emit_log = emit_log,
+    .access_account = access_account,
+    .access_storage = access_storage,
+    .get_transient_storage = get_transient_storage,
+    .set_transient_storage = set_transient_storage,
+};
+
+struct evmc_result evmdiff_execute(struct evmc_vm* vm, uintptr_t handle, const struct evmc_message* msg,
+                                   const uint8_t* code, size_t code_size) {
+    return vm->execute(vm, &host, (struct evmc_host_context*)handle, EVMC_CANCUN, msg, code, code_size);
+}
+
+static void free_output(const struct evmc_result* result) {
+    free((void*)result->output_data);
+}
+
+struct evmc_result evmdiff_result(enum evmc_status_code status, int64_t gas_left, int64_t gas_refund,
+                                  const uint8_t* output, size_t output_size) {
+    struct evmc_result result;
+    memset(&result, 0, sizeof(result));
+    result.status_code = status;
+    result.gas_left = gas_left;
+    result.gas_refund = gas_refund;
+    if (output_size > 0) {
+        uint8_t* data = malloc(output_size);
+        memcpy(data, output, output_size);
+        result.output_data = data;
+        result.output_size = output_size;
+        result.release = free_output;
+    }
+    return result;
+}
+
+void evmdiff_release(struct evmc_result* result) {
+    if (result->release) {
+        result->release(result);
+    }
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+#ifndef EVMDIFF_EVMONE_HOST_H
+#define EVMDIFF_EVMONE_HOST_H
+
+#include <stdint.h>
+#include <evmc/evmc.h>
+
+// evmdiff_execute runs the code in evmone, with the Go host identified by the cgo handle.
+struct evmc_result evmdiff_execute(struct evmc_vm* vm, uintptr_t handle, const struct evmc_message* msg,
+                                   const uint8_t* code, size_t code_size);
+
+// evmdiff_result makes a result of a host call, owning a copy of the output.
+struct evmc_result evmdiff_result(enum evmc_status_code status, int64_t gas_left, int64_t gas_refund,
+                                  const uint8_t* output, size_t output_size);
+
+void evmdiff_release(struct evmc_result* result);
+
+#endif
@@ -43,6 +43,7 @@ var (
 	transactionTestDir = filepath.Join(baseDir, "TransactionTests")
 	rlpTestDir         = filepath.Join(baseDir, "RLPTests")
 	difficultyTestDir  = filepath.Join(baseDir, "DifficultyTests")
+	eofTestDir         = filepath.Join(baseDir, "EOFTests")
 )
 
 func readJSON(reader io.Reader, value interface{}) error {
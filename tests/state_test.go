@@ -59,9 +59,25 @@ func TestState(t *testing.T) {
 	st.skipLoad(`create2collisionStorageParis.json`)
 	st.skipLoad(`dynamicAccountOverwriteEmpty_Paris.json`)
 
+	runStateTests(t, st, stateTestDir)
+}
+
+func TestExecutionSpecState(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	defer log.Root().SetHandler(log.Root().GetHandler())
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlError, log.StderrHandler))
+
+	st := new(testMatcher)
+	runStateTests(t, st, eestDir(t, "state_tests"))
+}
+
+func runStateTests(t *testing.T, st *testMatcher, dir string) {
 	dirs := datadir.New(t.TempDir())
 	db := temporaltest.NewTestDB(t, dirs)
-	st.walk(t, stateTestDir, func(t *testing.T, name string, test *StateTest) {
+	st.walk(t, dir, func(t *testing.T, name string, test *StateTest) {
 		for _, subtest := range test.Subtests() {
 			subtest := subtest
 			key := fmt.Sprintf("%s/%d", subtest.Fork, subtest.Index)
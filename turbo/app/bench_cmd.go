@@ -0,0 +1,144 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/core/vm/evmbench"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var (
+	benchRunFlag = cli.StringFlag{
+		Name:  "run",
+		Usage: "Only run benchmarks whose name matches this regular expression, e.g. '^op/' or 'precompile/5/'",
+	}
+	benchTimeFlag = cli.DurationFlag{
+		Name:  "benchtime",
+		Usage: "Minimal run time of each benchmark",
+		Value: evmbench.DefaultBenchTime,
+	}
+	benchBaselineFlag = cli.StringFlag{
+		Name:  "baseline",
+		Usage: "JSON file with recorded ns/op per benchmark; the command fails if a benchmark is slower than its baseline by more than --tolerance",
+	}
+	benchWriteBaselineFlag = cli.StringFlag{
+		Name:  "write-baseline",
+		Usage: "Record the measured ns/op into this JSON file, to be used as --baseline later",
+	}
+	benchToleranceFlag = cli.Float64Flag{
+		Name:  "tolerance",
+		Usage: "Allowed slowdown against --baseline, in percent",
+		Value: 10,
+	}
+)
+
+var benchCommand = cli.Command{
+	Name:  "bench",
+	Usage: "Run micro-benchmarks",
+	Before: func(cliCtx *cli.Context) error {
+		_, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+		return err
+	},
+	Subcommands: []*cli.Command{
+		{
+			Name:   "evm",
+			Usage:  "Measure opcode and precompile throughput, optionally gated against a recorded baseline",
+			Action: doBenchEVM,
+			Flags: joinFlags([]cli.Flag{
+				&benchRunFlag,
+				&benchTimeFlag,
+				&benchBaselineFlag,
+				&benchWriteBaselineFlag,
+				&benchToleranceFlag,
+			}),
+		},
+	},
+}
+
+func doBenchEVM(cliCtx *cli.Context) error {
+	var filter *regexp.Regexp
+	if expr := cliCtx.String(benchRunFlag.Name); expr != "" {
+		var err error
+		if filter, err = regexp.Compile(expr); err != nil {
+			return fmt.Errorf("--%s: %w", benchRunFlag.Name, err)
+		}
+	}
+	var baseline evmbench.Baseline
+	if path := cliCtx.String(benchBaselineFlag.Name); path != "" {
+		var err error
+		if baseline, err = evmbench.ReadBaseline(path); err != nil {
+			return err
+		}
+	}
+	cases, err := evmbench.Cases(filter)
+	if err != nil {
+		return err
+	}
+	if len(cases) == 0 {
+		return fmt.Errorf("no benchmarks match --%s", benchRunFlag.Name)
+	}
+
+	const rowFormat = "%-56s %12s %10s %12s\n"
+	fmt.Fprintf(os.Stdout, rowFormat, "benchmark", "ns/op", "Mgas/s", "baseline")
+	results := make([]evmbench.Result, 0, len(cases))
+	for _, c := range cases {
+		select {
+		case <-cliCtx.Context.Done():
+			return cliCtx.Context.Err()
+		default:
+		}
+		res, err := evmbench.Measure(c, cliCtx.Duration(benchTimeFlag.Name))
+		if err != nil {
+			return err
+		}
+		results = append(results, res)
+		mgas, base := "", ""
+		if res.MGasPerSec > 0 {
+			mgas = fmt.Sprintf("%.1f", res.MGasPerSec)
+		}
+		if b, ok := baseline[res.Name]; ok {
+			base = fmt.Sprintf("%.1f", b)
+		}
+		fmt.Fprintf(os.Stdout, rowFormat, res.Name, fmt.Sprintf("%.1f", res.NsPerOp), mgas, base)
+	}
+
+	if path := cliCtx.String(benchWriteBaselineFlag.Name); path != "" {
+		if err := evmbench.WriteBaseline(path, results); err != nil {
+			return err
+		}
+		log.Info("[bench] baseline written", "file", path, "benchmarks", len(results))
+	}
+	if baseline == nil {
+		return nil
+	}
+	regressions := evmbench.Compare(results, baseline, cliCtx.Float64(benchToleranceFlag.Name)/100)
+	for _, r := range regressions {
+		log.Error("[bench] regression", "benchmark", r.Name, "ns/op", fmt.Sprintf("%.1f", r.Measured), "baseline", fmt.Sprintf("%.1f", r.Baseline))
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("%d of %d benchmarks regressed by more than %.1f%%", len(regressions), len(results), cliCtx.Float64(benchToleranceFlag.Name))
+	}
+	log.Info("[bench] no regressions", "benchmarks", len(results))
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/networkname"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/devfork"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var (
+	DevForkURLFlag = cli.StringFlag{
+		Name:  "fork-url",
+		Usage: "JSON-RPC endpoint of node to fork state from (must serve state of fork block: archive node for old blocks)",
+	}
+	DevForkBlockFlag = cli.Uint64Flag{
+		Name:  "fork-block",
+		Usage: "Block to fork from. Default: latest block of --fork-url or latest executed block of --datadir",
+	}
+	DevForkDatadirFlag = cli.BoolFlag{
+		Name:  "fork-datadir",
+		Usage: "Fork from local --datadir instead of --fork-url (Erigon must not be running on this datadir)",
+	}
+)
+
+var devCommand = cli.Command{
+	Action: doDev,
+	Name:   "dev",
+	Usage:  "Instant-mining dev chain forked from another chain",
+	Flags: []cli.Flag{
+		&DevForkURLFlag,
+		&DevForkBlockFlag,
+		&DevForkDatadirFlag,
+		&utils.DataDirFlag,
+		&utils.HTTPListenAddrFlag,
+		&utils.HTTPPortFlag,
+		&utils.RpcGasCapFlag,
+	},
+	Description: `
+Starts dev chain with state of given block of forked chain. State is faulted lazily (on first access) from --fork-url
+or from local --datadir. Every transaction sent by eth_sendRawTransaction is executed by Erigon's EVM and mined into
+own block. Mined blocks and state changes are kept in memory only.
+
+	erigon dev --fork-url=http://localhost:8545 --fork-block=20000000
+	erigon dev --fork-datadir --datadir=<dir>`,
+}
+
+func doDev(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+
+	var (
+		source    devfork.Source
+		config    *chain.Config
+		forkBlock uint64
+	)
+	switch {
+	case cliCtx.Bool(DevForkDatadirFlag.Name):
+		var clean func()
+		source, config, forkBlock, clean, err = openLocalForkSource(cliCtx, logger)
+		if err != nil {
+			return err
+		}
+		defer clean()
+	case cliCtx.String(DevForkURLFlag.Name) != "":
+		client, err := rpc.DialContext(ctx, cliCtx.String(DevForkURLFlag.Name), logger)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		chainID, latest, err := devfork.RPCChainInfo(ctx, client)
+		if err != nil {
+			return err
+		}
+		config, forkBlock = chainConfigByChainID(chainID), latest
+		if cliCtx.IsSet(DevForkBlockFlag.Name) {
+			forkBlock = cliCtx.Uint64(DevForkBlockFlag.Name)
+		}
+		source = devfork.NewRPCSource(client, forkBlock)
+	default:
+		return fmt.Errorf("one of --%s or --%s is required", DevForkURLFlag.Name, DevForkDatadirFlag.Name)
+	}
+
+	c, err := devfork.NewChain(ctx, config, source, forkBlock, common.Address{} /* coinbase */)
+	if err != nil {
+		return err
+	}
+	srv, err := devfork.NewServer(c, cliCtx.Uint64(utils.RpcGasCapFlag.Name), logger)
+	if err != nil {
+		return err
+	}
+	defer srv.Stop()
+
+	addr := net.JoinHostPort(cliCtx.String(utils.HTTPListenAddrFlag.Name), strconv.Itoa(cliCtx.Int(utils.HTTPPortFlag.Name)))
+	httpSrv := &http.Server{Addr: addr, Handler: srv} //nolint:gosec
+	go func() {
+		<-ctx.Done()
+		httpSrv.Close()
+	}()
+	logger.Info("[dev] forked", "chain", config.ChainName, "chainId", config.ChainID, "block", forkBlock, "http", addr)
+	if err := httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// chainConfigByChainID - config of known chain, or config with all protocol changes for unknown chain
+func chainConfigByChainID(chainID *big.Int) *chain.Config {
+	for _, name := range slices.Concat(networkname.All, params.CustomChainNames()) {
+		if config := params.ChainConfigByChainName(name); config != nil && config.ChainID.Cmp(chainID) == 0 {
+			return config
+		}
+	}
+	config := *params.AllProtocolChanges
+	config.ChainID = chainID
+	return &config
+}
+
+// openLocalForkSource - reads history of local datadir as of fork block
+func openLocalForkSource(cliCtx *cli.Context, logger log.Logger) (source devfork.Source, config *chain.Config, forkBlock uint64, clean func(), err error) {
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	closers := []func(){chainDB.Close}
+	clean = func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+	defer func() {
+		if err != nil {
+			clean()
+		}
+	}()
+
+	config = fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, config.ChainName)
+	_, _, _, blockRetire, agg, cleanSnaps, err := openSnaps(ctx, cfg, dirs, 0, chainDB, logger)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	closers = append(closers, cleanSnaps)
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	closers = append(closers, db.Close)
+	tx, err := db.BeginTemporalRo(context.Background()) // lives until dev chain is stopped
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	closers = append(closers, tx.Rollback)
+
+	blockReader, _ := blockRetire.IO()
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader))
+	execProgress, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	forkBlock = execProgress
+	if cliCtx.IsSet(DevForkBlockFlag.Name) {
+		forkBlock = cliCtx.Uint64(DevForkBlockFlag.Name)
+	}
+	if forkBlock > execProgress {
+		return nil, nil, 0, nil, fmt.Errorf("block %d is not executed yet, latest executed block: %d", forkBlock, execProgress)
+	}
+	txNum, err := txNumsReader.Min(tx, forkBlock+1)
+	if err != nil {
+		return nil, nil, 0, nil, err
+	}
+	for _, d := range []kv.Domain{kv.AccountsDomain, kv.StorageDomain, kv.CodeDomain} {
+		if from := tx.HistoryStartFrom(d); txNum < from {
+			return nil, nil, 0, nil, fmt.Errorf("history of %s domain at block %d is pruned, it's available from txNum %d", d, forkBlock, from)
+		}
+	}
+	return devfork.NewLocalSource(tx, txNum, blockReader), config, forkBlock, clean, nil
+}
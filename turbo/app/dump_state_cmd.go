@@ -0,0 +1,404 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+const (
+	DumpStateFormatGethJson = "geth-json"
+	DumpStateFormatCsv      = "csv"
+	DumpStateFormatParquet  = "parquet"
+
+	dumpStateBatchSize = 10_000 // accounts are read by batches - to not keep whole state in memory
+)
+
+var (
+	DumpStateBlockFlag = cli.Uint64Flag{
+		Name:  "block",
+		Usage: "Dump state after execution of given block. Default: latest executed block",
+	}
+	DumpStateFormatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "geth-json (same as `geth dump --iterative`: one account per line), csv or parquet",
+		Value: DumpStateFormatGethJson,
+	}
+	DumpStateOutputFlag = cli.PathFlag{
+		Name:  "output",
+		Usage: "geth-json: output file (stdout if empty). csv/parquet: directory to write accounts and storage files to (current directory if empty)",
+	}
+	DumpStateNoCodeFlag = cli.BoolFlag{
+		Name:  "nocode",
+		Usage: "Exclude contract code",
+	}
+	DumpStateNoStorageFlag = cli.BoolFlag{
+		Name:  "nostorage",
+		Usage: "Exclude storage entries",
+	}
+)
+
+// stateDumpWriter - state.DumpCollector which can fail and must be flushed
+type stateDumpWriter interface {
+	state.DumpCollector
+	Close() error // flush and return first error happened in OnAccount
+}
+
+func doDumpState(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
+	_, _, _, blockRetire, agg, clean, err := openSnaps(ctx, cfg, dirs, 0, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	w, err := newStateDumpWriter(cliCtx.String(DumpStateFormatFlag.Name), cliCtx.String(DumpStateOutputFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	blockReader, _ := blockRetire.IO()
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader))
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	execProgress, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	blockNum := execProgress
+	if cliCtx.IsSet(DumpStateBlockFlag.Name) {
+		blockNum = cliCtx.Uint64(DumpStateBlockFlag.Name)
+	}
+	if blockNum > execProgress {
+		return fmt.Errorf("block %d is not executed yet, latest executed block: %d", blockNum, execProgress)
+	}
+	txNum, err := txNumsReader.Min(tx, blockNum+1)
+	if err != nil {
+		return err
+	}
+	for _, d := range []kv.Domain{kv.AccountsDomain, kv.StorageDomain, kv.CodeDomain} {
+		if from := tx.HistoryStartFrom(d); txNum < from {
+			return fmt.Errorf("history of %s domain at block %d is pruned, it's available from txNum %d", d, blockNum, from)
+		}
+	}
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	start := time.Now()
+	logger.Info("[dump-state] start", "block", blockNum, "format", cliCtx.String(DumpStateFormatFlag.Name))
+
+	dumper := state.NewDumper(tx, txNumsReader, blockNum)
+	noCode, noStorage := cliCtx.Bool(DumpStateNoCodeFlag.Name), cliCtx.Bool(DumpStateNoStorageFlag.Name)
+	var next []byte
+	var batches int
+	for {
+		next, err = dumper.DumpToCollector(w, noCode, noStorage, common.BytesToAddress(next), dumpStateBatchSize)
+		if err != nil {
+			return err
+		}
+		batches++
+		if next == nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[dump-state] progress", "accounts", batches*dumpStateBatchSize, "at", fmt.Sprintf("%x", next))
+		default:
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	logger.Info("[dump-state] done", "block", blockNum, "took", time.Since(start))
+	return nil
+}
+
+func newStateDumpWriter(format, output string) (stateDumpWriter, error) {
+	switch format {
+	case DumpStateFormatGethJson:
+		if output == "" {
+			return newGethJsonDumpWriter(os.Stdout, nil), nil
+		}
+		f, err := os.Create(output)
+		if err != nil {
+			return nil, err
+		}
+		return newGethJsonDumpWriter(f, f), nil
+	case DumpStateFormatCsv:
+		return newCsvDumpWriter(output)
+	case DumpStateFormatParquet:
+		return newParquetDumpWriter(output)
+	default:
+		return nil, fmt.Errorf("unknown --%s=%s, expected: %s, %s or %s", DumpStateFormatFlag.Name, format, DumpStateFormatGethJson, DumpStateFormatCsv, DumpStateFormatParquet)
+	}
+}
+
+// closeAll - closes files in order and returns first error
+func closeAll(closers ...io.Closer) (err error) {
+	for _, c := range closers {
+		if c == nil {
+			continue
+		}
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// sortedStorage - storage of account in order of slots
+func sortedStorage(account state.DumpAccount) []string {
+	slots := make([]string, 0, len(account.Storage))
+	for slot := range account.Storage {
+		slots = append(slots, slot)
+	}
+	sort.Strings(slots)
+	return slots
+}
+
+// gethJsonDumpWriter - format of `geth dump --iterative`: line with state root, then one line per account
+type gethJsonDumpWriter struct {
+	w      *bufio.Writer
+	enc    *json.Encoder
+	file   io.Closer
+	err    error
+	root   bool
+	closed bool
+}
+
+func newGethJsonDumpWriter(w io.Writer, file io.Closer) *gethJsonDumpWriter {
+	bw := bufio.NewWriterSize(w, 1024*1024)
+	return &gethJsonDumpWriter{w: bw, enc: json.NewEncoder(bw), file: file}
+}
+
+func (d *gethJsonDumpWriter) OnRoot(root common.Hash) {
+	if d.root { // called once per batch
+		return
+	}
+	d.root = true
+	if err := d.enc.Encode(struct {
+		Root common.Hash `json:"root"`
+	}{root}); err != nil && d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *gethJsonDumpWriter) OnAccount(addr common.Address, account state.DumpAccount) {
+	account.Address = &addr
+	if err := d.enc.Encode(account); err != nil && d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *gethJsonDumpWriter) Close() error {
+	if d.closed {
+		return d.err
+	}
+	d.closed = true
+	if err := d.w.Flush(); err != nil && d.err == nil {
+		d.err = err
+	}
+	if err := closeAll(d.file); err != nil && d.err == nil {
+		d.err = err
+	}
+	return d.err
+}
+
+// csvDumpWriter - writes accounts.csv and storage.csv
+type csvDumpWriter struct {
+	files             []*os.File
+	accounts, storage *csv.Writer
+	err               error
+	closed            bool
+}
+
+func newCsvDumpWriter(dir string) (*csvDumpWriter, error) {
+	accounts, err := os.Create(filepath.Join(dir, "accounts.csv"))
+	if err != nil {
+		return nil, err
+	}
+	storage, err := os.Create(filepath.Join(dir, "storage.csv"))
+	if err != nil {
+		accounts.Close()
+		return nil, err
+	}
+	d := &csvDumpWriter{files: []*os.File{accounts, storage}, accounts: csv.NewWriter(accounts), storage: csv.NewWriter(storage)}
+	d.write(d.accounts, []string{"address", "balance", "nonce", "code_hash", "storage_root", "code"})
+	d.write(d.storage, []string{"address", "slot", "value"})
+	return d, nil
+}
+
+func (d *csvDumpWriter) write(w *csv.Writer, record []string) {
+	if err := w.Write(record); err != nil && d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *csvDumpWriter) OnRoot(common.Hash) {}
+
+func (d *csvDumpWriter) OnAccount(addr common.Address, account state.DumpAccount) {
+	address := addr.Hex()
+	d.write(d.accounts, []string{address, account.Balance, strconv.FormatUint(account.Nonce, 10), account.CodeHash.String(), account.Root.String(), account.Code.String()})
+	for _, slot := range sortedStorage(account) {
+		d.write(d.storage, []string{address, slot, "0x" + account.Storage[slot]})
+	}
+}
+
+func (d *csvDumpWriter) Close() error {
+	if d.closed {
+		return d.err
+	}
+	d.closed = true
+	for _, w := range []*csv.Writer{d.accounts, d.storage} {
+		w.Flush()
+		if err := w.Error(); err != nil && d.err == nil {
+			d.err = err
+		}
+	}
+	if err := closeAll(d.files[0], d.files[1]); err != nil && d.err == nil {
+		d.err = err
+	}
+	return d.err
+}
+
+type parquetAccountRow struct {
+	Address     string `parquet:"address"`
+	Balance     string `parquet:"balance"` // decimal, can be bigger than uint64
+	Nonce       uint64 `parquet:"nonce"`
+	CodeHash    string `parquet:"code_hash"`
+	StorageRoot string `parquet:"storage_root"`
+	Code        []byte `parquet:"code,optional"`
+}
+
+type parquetStorageRow struct {
+	Address string `parquet:"address"`
+	Slot    string `parquet:"slot"`
+	Value   string `parquet:"value"`
+}
+
+// parquetDumpWriter - writes accounts.parquet and storage.parquet
+type parquetDumpWriter struct {
+	files    []*os.File
+	accounts *parquet.GenericWriter[parquetAccountRow]
+	storage  *parquet.GenericWriter[parquetStorageRow]
+	rows     []parquetStorageRow
+	err      error
+	closed   bool
+}
+
+func newParquetDumpWriter(dir string) (*parquetDumpWriter, error) {
+	accounts, err := os.Create(filepath.Join(dir, "accounts.parquet"))
+	if err != nil {
+		return nil, err
+	}
+	storage, err := os.Create(filepath.Join(dir, "storage.parquet"))
+	if err != nil {
+		accounts.Close()
+		return nil, err
+	}
+	return &parquetDumpWriter{
+		files:    []*os.File{accounts, storage},
+		accounts: parquet.NewGenericWriter[parquetAccountRow](accounts, parquet.Compression(&parquet.Zstd)),
+		storage:  parquet.NewGenericWriter[parquetStorageRow](storage, parquet.Compression(&parquet.Zstd)),
+	}, nil
+}
+
+func (d *parquetDumpWriter) OnRoot(common.Hash) {}
+
+func (d *parquetDumpWriter) OnAccount(addr common.Address, account state.DumpAccount) {
+	if d.err != nil {
+		return
+	}
+	address := addr.Hex()
+	row := parquetAccountRow{Address: address, Balance: account.Balance, Nonce: account.Nonce, CodeHash: account.CodeHash.String(), StorageRoot: account.Root.String(), Code: account.Code}
+	if _, err := d.accounts.Write([]parquetAccountRow{row}); err != nil {
+		d.err = err
+		return
+	}
+	d.rows = d.rows[:0]
+	for _, slot := range sortedStorage(account) {
+		d.rows = append(d.rows, parquetStorageRow{Address: address, Slot: slot, Value: "0x" + account.Storage[slot]})
+	}
+	if _, err := d.storage.Write(d.rows); err != nil {
+		d.err = err
+	}
+}
+
+func (d *parquetDumpWriter) Close() error {
+	if d.closed {
+		return d.err
+	}
+	d.closed = true
+	if err := d.accounts.Close(); err != nil && d.err == nil {
+		d.err = err
+	}
+	if err := d.storage.Close(); err != nil && d.err == nil {
+		d.err = err
+	}
+	if err := closeAll(d.files[0], d.files[1]); err != nil && d.err == nil {
+		d.err = err
+	}
+	return d.err
+}
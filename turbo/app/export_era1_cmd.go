@@ -0,0 +1,94 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/rpc/jsonrpc/receipts"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/era1"
+)
+
+var ExportEra1OutputFlag = cli.PathFlag{
+	Name:  "output",
+	Usage: "Directory to write era1 files to (default: <datadir>/era1 - used by --history.expiry)",
+}
+
+func doExportEra1(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
+	_, _, _, blockRetire, agg, clean, err := openSnaps(ctx, cfg, dirs, 0, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blockReader, _ := blockRetire.IO()
+	var mergeBlock uint64
+	var merged bool
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		frozen := blockReader.FrozenBlocks()
+		if frozen == 0 {
+			return nil
+		}
+		mergeBlock, merged, err = era1.MergeBlock(ctx, tx, blockReader, chainConfig, frozen-1)
+		return err
+	}); err != nil {
+		return err
+	}
+	if !merged {
+		return errors.New("no frozen post-merge blocks: nothing to export")
+	}
+
+	output := cliCtx.String(ExportEra1OutputFlag.Name)
+	if output == "" {
+		output = era1.Dir(dirs)
+	}
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(ctx, chainConfig, logger)
+	exporter := era1.NewExporter(output, chainConfig, blockReader, receipts.NewGenerator(blockReader, engine), logger)
+	exported, err := exporter.Export(ctx, db, mergeBlock)
+	if err != nil {
+		return err
+	}
+	logger.Info("[era1] pre-merge blocks exported", "new_files", exported, "merge_block", mergeBlock)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/parquetexport"
+)
+
+var (
+	ExportParquetOutputFlag = cli.PathFlag{
+		Name:     "output",
+		Usage:    "Directory to write block ranges to",
+		Required: true,
+	}
+	ExportParquetRangeFlag = cli.Uint64Flag{
+		Name:  "range",
+		Usage: "Amount of blocks in one range (directory with files)",
+		Value: parquetexport.DefaultRangeSize,
+	}
+	ExportParquetFromFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Export blocks starting from given one (rounded down to range start)",
+	}
+	ExportParquetConfirmationsFlag = cli.Uint64Flag{
+		Name:  "confirmations",
+		Usage: "Export only blocks which are this deep behind latest executed block, if chain has no finalized block",
+		Value: 64,
+	}
+	ExportParquetFollowFlag = cli.BoolFlag{
+		Name:  "follow",
+		Usage: "Don't exit: export new ranges as chain advances",
+	}
+)
+
+func doExportParquet(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(false, true, true, chainConfig.ChainName)
+	blockSnaps, _, _, blockRetire, agg, clean, err := openSnaps(ctx, cfg, dirs, 0, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	blockReader, _ := blockRetire.IO()
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(ctx, chainConfig, logger)
+	exporter := parquetexport.New(cliCtx.String(ExportParquetOutputFlag.Name), cliCtx.Uint64(ExportParquetRangeFlag.Name), chainConfig, blockReader, engine, logger)
+	from, confirmations := cliCtx.Uint64(ExportParquetFromFlag.Name), cliCtx.Uint64(ExportParquetConfirmationsFlag.Name)
+
+	for {
+		var upTo uint64
+		var hasBlocks bool
+		if err := db.View(ctx, func(tx kv.Tx) error {
+			executed, err := stages.GetStageProgress(tx, stages.Execution)
+			if err != nil {
+				return err
+			}
+			if finalized := rawdb.ReadHeaderNumber(tx, rawdb.ReadForkchoiceFinalized(tx)); finalized != nil && *finalized > 0 {
+				upTo, hasBlocks = min(*finalized, executed), true
+			} else if executed >= confirmations {
+				upTo, hasBlocks = executed-confirmations, true
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if hasBlocks {
+			exported, err := exporter.Export(ctx, db, from, upTo)
+			if err != nil {
+				return err
+			}
+			logger.Info("[parquet] up to date", "exported_ranges", exported, "safe_block", upTo)
+		}
+		if !cliCtx.Bool(ExportParquetFollowFlag.Name) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Minute):
+		}
+		// node is running: pick up new files
+		if err := blockSnaps.OpenFolder(); err != nil {
+			return fmt.Errorf("reopen block files: %w", err)
+		}
+		if err := agg.OpenFolder(); err != nil {
+			return fmt.Errorf("reopen state files: %w", err)
+		}
+	}
+}
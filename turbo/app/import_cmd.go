@@ -29,8 +29,10 @@ import (
 	"time"
 
 	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/direct"
 	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
 	"github.com/erigontech/erigon-lib/kv"
@@ -41,6 +43,7 @@ import (
 	"github.com/erigontech/erigon/cmd/utils"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/eth"
+	"github.com/erigontech/erigon/eth/ethconfig/estimate"
 	"github.com/erigontech/erigon/execution/consensus/merge"
 	"github.com/erigontech/erigon/execution/eth1/eth1_chain_reader"
 	"github.com/erigontech/erigon/turbo/debug"
@@ -68,7 +71,11 @@ The import command imports blocks from an RLP-encoded form. The form can be one
 with several RLP-encoded blocks, or several files can be used.
 
 If only one file is used, import error will result in failure. If several files are used,
-processing will proceed even if an individual RLP-file import failure occurs.`,
+processing will proceed even if an individual RLP-file import failure occurs.
+
+Files in format of "geth export" are supported (optionally gzipped: *.gz). Blocks are validated
+(body hashes and senders) in parallel before insertion. Blocks which are already canonical are
+skipped - so interrupted import can be resumed by running same command again.`,
 }
 
 func importChain(cliCtx *cli.Context) error {
@@ -98,10 +105,14 @@ func importChain(cliCtx *cli.Context) error {
 		return err
 	}
 
-	if err := ImportChain(ethereum, ethereum.ChainDB(), cliCtx.Args().First(), logger); err != nil {
-		return err
+	if cliCtx.NArg() == 1 {
+		return ImportChain(ethereum, ethereum.ChainDB(), cliCtx.Args().First(), logger)
+	}
+	for _, fn := range cliCtx.Args().Slice() {
+		if err := ImportChain(ethereum, ethereum.ChainDB(), fn, logger); err != nil {
+			logger.Error("Import error", "file", fn, "err", err)
+		}
 	}
-
 	return nil
 }
 
@@ -145,16 +156,25 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 	}
 	stream := rlp.NewStream(reader, 0)
 
-	// Run actual the import.
-	blocks := make(types.Blocks, importBatchSize)
-	n := 0
+	br, _ := ethereum.BlockIO()
+	insert := func(chain *core.ChainPack) error { return InsertChain(ethereum, chain, logger) }
+	return importBlocks(ethereum.SentryCtx(), stream, importBatchSize, chainDB, br, ethereum.ChainConfig(), insert, checkInterrupt, logger.New("file", fn))
+}
+
+// importBlocks - inserts blocks of stream by batches of batchSize. Blocks imported by previous (interrupted) run
+// are skipped - so import resumes from first missing block.
+func importBlocks(ctx context.Context, stream *rlp.Stream, batchSize int, chainDB kv.RoDB, blockReader services.FullBlockReader,
+	chainConfig *chain.Config, insert func(*core.ChainPack) error, interrupted func() bool, logger log.Logger) error {
+	blocks := make(types.Blocks, batchSize)
+	n, imported := 0, 0
+	start := time.Now()
 	for batch := 0; ; batch++ {
 		// Load a batch of RLP blocks.
-		if checkInterrupt() {
+		if interrupted() {
 			return errors.New("interrupted")
 		}
 		i := 0
-		for ; i < importBatchSize; i++ {
+		for ; i < batchSize; i++ {
 			var b types.Block
 			if err := stream.Decode(&b); errors.Is(err, io.EOF) {
 				break
@@ -173,16 +193,21 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 			break
 		}
 		// Import the batch.
-		if checkInterrupt() {
+		if interrupted() {
 			return errors.New("interrupted")
 		}
 
-		br, _ := ethereum.BlockIO()
-		missing := missingBlocks(chainDB, blocks[:i], br)
+		missing, err := missingBlocks(chainDB, blocks[:i], blockReader)
+		if err != nil {
+			return err
+		}
 		if len(missing) == 0 {
 			logger.Info("Skipping batch as all blocks present", "batch", batch, "first", blocks[0].Hash(), "last", blocks[i-1].Hash())
 			continue
 		}
+		if err := validateBlocks(ctx, chainConfig, missing); err != nil {
+			return err
+		}
 
 		// RLP decoding worked, try to insert into chain:
 		missingChain := &core.ChainPack{
@@ -190,46 +215,73 @@ func ImportChain(ethereum *eth.Ethereum, chainDB kv.RwDB, fn string, logger log.
 			TopBlock: missing[len(missing)-1],
 		}
 
-		if err := InsertChain(ethereum, missingChain, logger); err != nil {
+		if err := insert(missingChain); err != nil {
 			return err
 		}
+		imported += len(missing)
+		logger.Info("Imported batch", "number", missingChain.TopBlock.NumberU64(), "blocks", imported,
+			"blk/s", fmt.Sprintf("%.1f", float64(imported)/time.Since(start).Seconds()))
 	}
 	return nil
 }
 
-func ChainHasBlock(chainDB kv.RwDB, block *types.Block) bool {
-	var chainHasBlock bool
+// validateBlocks - checks bodies against headers and recovers senders of all transactions, in parallel.
+// To fail fast on broken or foreign file: with block number instead of error deep inside of staged sync.
+func validateBlocks(ctx context.Context, chainConfig *chain.Config, blocks []*types.Block) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(estimate.AlmostAllCPUs())
+	for _, b := range blocks {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := b.HashCheck(true); err != nil {
+				return fmt.Errorf("block %d %x: %w", b.NumberU64(), b.Hash(), err)
+			}
+			signer := types.MakeSigner(chainConfig, b.NumberU64(), b.Time())
+			for i, txn := range b.Transactions() {
+				sender, err := signer.Sender(txn)
+				if err != nil {
+					return fmt.Errorf("block %d %x: txn %d %x: %w", b.NumberU64(), b.Hash(), i, txn.Hash(), err)
+				}
+				txn.SetSender(sender)
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
 
-	chainDB.View(context.Background(), func(tx kv.Tx) (err error) {
+func ChainHasBlock(chainDB kv.RoDB, block *types.Block) (bool, error) {
+	var chainHasBlock bool
+	if err := chainDB.View(context.Background(), func(tx kv.Tx) (err error) {
 		chainHasBlock = rawdb.HasBlock(tx, block.Hash(), block.NumberU64())
 		return nil
-	})
-
-	return chainHasBlock
+	}); err != nil {
+		return false, err
+	}
+	return chainHasBlock, nil
 }
 
-func missingBlocks(chainDB kv.RwDB, blocks []*types.Block, blockReader services.FullBlockReader) []*types.Block {
-	var headBlock *types.Block
-	chainDB.View(context.Background(), func(tx kv.Tx) (err error) {
-		headBlock, err = blockReader.CurrentBlock(tx)
-		return err
-	})
-
-	for i, block := range blocks {
-		// If we're behind the chain head, only check block, state is available at head
-		if headBlock.NumberU64() > block.NumberU64() {
-			if !ChainHasBlock(chainDB, block) {
-				return blocks[i:]
+// missingBlocks - returns blocks starting from first one which is not canonical yet
+func missingBlocks(chainDB kv.RoDB, blocks []*types.Block, blockReader services.FullBlockReader) ([]*types.Block, error) {
+	var res []*types.Block
+	if err := chainDB.View(context.Background(), func(tx kv.Tx) error {
+		for i, block := range blocks {
+			canonical, ok, err := blockReader.CanonicalHash(context.Background(), tx, block.NumberU64())
+			if err != nil {
+				return err
+			}
+			if !ok || canonical != block.Hash() {
+				res = blocks[i:]
+				return nil
 			}
-			continue
-		}
-
-		if !ChainHasBlock(chainDB, block) {
-			return blocks[i:]
 		}
+		return nil
+	}); err != nil {
+		return nil, err
 	}
-
-	return nil
+	return res, nil
 }
 
 func InsertChain(ethereum *eth.Ethereum, chain *core.ChainPack, logger log.Logger) error {
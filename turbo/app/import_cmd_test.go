@@ -0,0 +1,87 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestImportInterruptResume(t *testing.T) {
+	require := require.New(t)
+	m := mock.Mock(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 10, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.Address{1})
+	})
+	require.NoError(err)
+
+	var file bytes.Buffer
+	require.NoError(rlp.Encode(&file, m.Genesis))
+	for _, b := range chain.Blocks {
+		require.NoError(rlp.Encode(&file, b))
+	}
+
+	var inserted [][2]uint64 // first and last block of each inserted batch
+	insert := func(pack *core.ChainPack) error {
+		for _, b := range pack.Blocks {
+			pack.Headers = append(pack.Headers, b.Header())
+		}
+		inserted = append(inserted, [2]uint64{pack.Blocks[0].NumberU64(), pack.TopBlock.NumberU64()})
+		return m.InsertChain(pack)
+	}
+	importFile := func(interrupted func() bool) error {
+		stream := rlp.NewStream(bytes.NewReader(file.Bytes()), 0)
+		return importBlocks(m.Ctx, stream, 3, m.DB, m.BlockReader, m.ChainConfig, insert, interrupted, log.New())
+	}
+	canonicalTip := func() uint64 {
+		missing, err := missingBlocks(m.DB, chain.Blocks, m.BlockReader)
+		require.NoError(err)
+		return uint64(chain.Length() - len(missing))
+	}
+
+	// interrupt after first batch
+	err = importFile(func() bool { return len(inserted) == 1 })
+	require.ErrorContains(err, "interrupted")
+	require.Equal([][2]uint64{{1, 3}}, inserted)
+	require.Equal(uint64(3), canonicalTip())
+	has, err := ChainHasBlock(m.DB, chain.Blocks[3])
+	require.NoError(err)
+	require.False(has)
+
+	// block of next batch was inserted by previous run (crash after insert of it)
+	require.NoError(insert(&core.ChainPack{Blocks: []*types.Block{chain.Blocks[3]}, TopBlock: chain.Blocks[3]}))
+
+	// resume: inserted blocks are skipped
+	inserted = nil
+	require.NoError(importFile(func() bool { return false }))
+	require.Equal([][2]uint64{{5, 6}, {7, 9}, {10, 10}}, inserted)
+	require.Equal(uint64(10), canonicalTip())
+
+	// nothing to do on next run
+	inserted = nil
+	require.NoError(importFile(func() bool { return false }))
+	require.Empty(inserted)
+}
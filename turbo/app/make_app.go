@@ -82,6 +82,8 @@ func MakeApp(name string, action cli.ActionFunc, cliFlags []cli.Flag) *cli.App {
 		&importCommand,
 		&snapshotCommand,
 		&supportCommand,
+		&devCommand,
+		&benchCommand,
 		//&backupCommand,
 	}
 	return app
@@ -93,6 +95,7 @@ func appFlags(cliFlags []cli.Flag) []cli.Flag {
 	flags = append(flags, utils.MetricFlags...)
 	flags = append(flags, logging.Flags...)
 	flags = append(flags, &utils.ConfigFlag)
+	flags = append(flags, &utils.ChainsConfigFlag)
 
 	// remove exact duplicate flags, keeping only the first one. this will allow easier composition later down the line
 	allFlags := flags
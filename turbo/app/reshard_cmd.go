@@ -0,0 +1,107 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/eth/rawdbreset"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var ReshardStepFlag = cli.Uint64Flag{
+	Name:     "step",
+	Usage:    "New aggregation step of state files: amount of txNums in one step. Will be written as `aggregationStep` of chain config",
+	Required: true,
+}
+
+func doReshard(cliCtx *cli.Context) error {
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	ctx := cliCtx.Context
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	newStep := cliCtx.Uint64(ReshardStepFlag.Name)
+	if newStep == 0 {
+		return errors.New("--step must be positive")
+	}
+
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+
+	var genesisHash common.Hash
+	var chainConfig *chain.Config
+	if err := chainDB.View(ctx, func(tx kv.Tx) (err error) {
+		if genesisHash, err = rawdb.ReadCanonicalHash(tx, 0); err != nil {
+			return err
+		}
+		chainConfig, err = core.ReadChainConfig(tx, genesisHash)
+		return err
+	}); err != nil {
+		return err
+	}
+	if chainConfig == nil {
+		return errors.New("database is not initialized")
+	}
+	// erigon takes config of known chains from code - not from db
+	if params.ChainConfigByGenesisHash(genesisHash) != nil {
+		return fmt.Errorf("aggregation step of %s can't be changed: its state files are shared by downloader", chainConfig.ChainName)
+	}
+
+	oldStep, err := libstate.GetStateAggregationStep(dirs, chainConfig.GetAggregationStep(), false)
+	if err != nil {
+		return err
+	}
+	if oldStep != newStep {
+		cut, err := libstate.Reshard(dirs, oldStep, newStep, logger)
+		if err != nil {
+			return err
+		}
+
+		// state in chaindata is keyed by steps of old size: re-execute blocks after the end of state files
+		agg := openAgg(ctx, dirs, chainDB, chainConfig, logger)
+		defer agg.Close()
+		db, err := temporal.New(chainDB, agg)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		if err := rawdbreset.ResetExec(ctx, db); err != nil {
+			return err
+		}
+		logger.Info("[reshard] execution will continue from the end of state files", "txnum", cut)
+	}
+
+	chainConfig.AggregationStep = newStep
+	return chainDB.Update(ctx, func(tx kv.RwTx) error {
+		return core.WriteChainConfig(tx, genesisHash, chainConfig)
+	})
+}
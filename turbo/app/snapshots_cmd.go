@@ -40,6 +40,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/erigontech/erigon-db/rawdb/blockio"
+	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/compress"
 	"github.com/erigontech/erigon-lib/common/datadir"
@@ -47,7 +48,6 @@ import (
 	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/common/mem"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/downloader"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/etl"
@@ -115,6 +115,19 @@ var snapshotCommand = cli.Command{
 				&utils.DataDirFlag,
 			}),
 		},
+		{
+			Name: "relocate",
+			Action: func(c *cli.Context) error {
+				dirs := datadir.New(c.String(utils.DataDirFlag.Name))
+				return datadir.Relocate(c.Context, dirs, c.String(RelocateDirFlag.Name), c.String(RelocateToFlag.Name), log.Root())
+			},
+			Usage: "Move files to other volume (can run while Erigon is running: replaces files by symlinks, remaining files are moved and symlinks removed on next start)",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&RelocateToFlag,
+				&RelocateDirFlag,
+			}),
+		},
 		{
 			Name:    "accessor",
 			Aliases: []string{"index"},
@@ -262,6 +275,50 @@ var snapshotCommand = cli.Command{
 				&cli.StringFlag{Name: "domain", Required: true},
 			}),
 		},
+		{
+			Name:        "dump-state",
+			Action:      doDumpState,
+			Description: "Stream accounts and storage at historical block from domain files: --format=geth-json|csv|parquet",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&DumpStateBlockFlag,
+				&DumpStateFormatFlag,
+				&DumpStateOutputFlag,
+				&DumpStateNoCodeFlag,
+				&DumpStateNoStorageFlag,
+			}),
+		},
+		{
+			Name:        "export-parquet",
+			Action:      doExportParquet,
+			Description: "Export blocks, transactions, receipts and logs to Parquet files: directory per block range. Re-run (or --follow) to export new ranges",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&ExportParquetOutputFlag,
+				&ExportParquetRangeFlag,
+				&ExportParquetFromFlag,
+				&ExportParquetConfirmationsFlag,
+				&ExportParquetFollowFlag,
+			}),
+		},
+		{
+			Name:        "export-era1",
+			Action:      doExportEra1,
+			Description: "Export pre-merge blocks with receipts to era1 files (EIP-4444). Needs history of exported blocks. Re-run to export missing files",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&ExportEra1OutputFlag,
+			}),
+		},
+		{
+			Name:        "reshard",
+			Action:      doReshard,
+			Description: "Move state files to new aggregation step (private chains only). Files not aligned to new step are removed and their blocks re-executed. Erigon must be stopped",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&ReshardStepFlag,
+			}),
+		},
 		{
 			Name:   "sqeeze",
 			Action: doSqueeze,
@@ -270,6 +327,18 @@ var snapshotCommand = cli.Command{
 				&cli.StringFlag{Name: "type", Required: true},
 			}),
 		},
+		{
+			Name:        "recompress",
+			Action:      doRecompress,
+			Description: "Rewrite block segments of older versions with current compression parameters, as files of new version. Validated against existing indexes. Erigon must be stopped",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&RecompressTypesFlag,
+				&RecompressVersionFlag,
+				&RecompressForceFlag,
+				&RecompressDryRunFlag,
+			}),
+		},
 		{
 			Name:        "integrity",
 			Action:      doIntegrity,
@@ -296,6 +365,19 @@ var snapshotCommand = cli.Command{
 				&utils.DataDirFlag,
 			}),
 		},
+		{
+			Name:        "publish",
+			Action:      doPublish,
+			Description: "Publish segments of own chain: build .torrent files, sign manifest and upload all to webseed bucket (rclone)",
+			Flags: joinFlags([]cli.Flag{
+				&utils.DataDirFlag,
+				&PublishLocationFlag,
+				&PublishKeyFlag,
+				&PublishRetireFlag,
+				&PublishDryRunFlag,
+				&SnapshotFromFlag,
+			}),
+		},
 		{
 			Name:        "clearIndexing",
 			Action:      doClearIndexing,
@@ -321,6 +403,16 @@ var (
 		Name:  "file",
 		Usage: "Snapshot file",
 	}
+	RelocateToFlag = cli.StringFlag{
+		Name:     "to",
+		Usage:    "New location (absolute or relative path) - usually on other volume",
+		Required: true,
+	}
+	RelocateDirFlag = cli.StringFlag{
+		Name:  "dir",
+		Usage: "Which dir to relocate: " + strings.Join(datadir.Relocatable, ", "),
+		Value: datadir.Snapshots,
+	}
 )
 
 func doRmStateSnapshots(cliCtx *cli.Context) error {
@@ -516,6 +608,8 @@ func doDebugKey(cliCtx *cli.Context) error {
 		domain, idx = kv.ReceiptDomain, kv.ReceiptHistoryIdx
 	case "rcache":
 		domain, idx = kv.RCacheDomain, kv.RCacheHistoryIdx
+	case "accesslist":
+		domain, idx = kv.AccessListDomain, kv.AccessListHistoryIdx
 	default:
 		panic(ds)
 	}
@@ -1240,7 +1334,7 @@ func openSnaps(ctx context.Context, cfg ethconfig.BlocksFreezing, dirs datadir.D
 	blockSnapBuildSema := semaphore.NewWeighted(int64(dbg.BuildSnapshotAllowance))
 	br = freezeblocks.NewBlockRetire(estimate.CompressSnapshot.Workers(), dirs, blockReader, blockWriter, chainDB, heimdallStore, bridgeStore, chainConfig, &ethconfig.Defaults, nil, blockSnapBuildSema, logger)
 
-	agg = openAgg(ctx, dirs, chainDB, logger)
+	agg = openAgg(ctx, dirs, chainDB, chainConfig, logger)
 	agg.SetSnapshotBuildSema(blockSnapBuildSema)
 	clean = func() {
 		defer blockSnaps.Close()
@@ -1770,8 +1864,12 @@ func dbCfg(label kv.Label, path string) mdbx.MdbxOpts {
 		RoTxsLimiter(limiterB).
 		Accede(true) // integration tool: open db without creation and without blocking erigon
 }
-func openAgg(ctx context.Context, dirs datadir.Dirs, chainDB kv.RwDB, logger log.Logger) *libstate.Aggregator {
-	agg, err := libstate.NewAggregator(ctx, dirs, config3.DefaultStepSize, chainDB, logger)
+func openAgg(ctx context.Context, dirs datadir.Dirs, chainDB kv.RwDB, chainConfig *chain.Config, logger log.Logger) *libstate.Aggregator {
+	stepSize, err := libstate.GetStateAggregationStep(dirs, chainConfig.GetAggregationStep(), false)
+	if err != nil {
+		panic(err)
+	}
+	agg, err := libstate.NewAggregator(ctx, dirs, stepSize, chainDB, logger)
 	if err != nil {
 		panic(err)
 	}
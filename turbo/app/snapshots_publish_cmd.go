@@ -0,0 +1,173 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/downloader"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/snapshots/sync"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+var (
+	PublishLocationFlag = cli.StringFlag{
+		Name:     "location",
+		Usage:    "rclone location of the webseed bucket: <remote>:<bucket/path>, the remote must be in rclone config",
+		Required: true,
+	}
+	PublishKeyFlag = cli.PathFlag{
+		Name:     "key",
+		Usage:    "file with hex secp256k1 private key signing manifest.json, clients verify it by the key's address",
+		Required: true,
+	}
+	PublishRetireFlag = cli.BoolFlag{
+		Name:  "retire",
+		Usage: "produce new frozen segments before publishing, as `seg retire` does",
+	}
+	PublishDryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "build .torrent files and the manifest, report what would be uploaded, don't upload",
+	}
+)
+
+// doPublish - retires new segments (optionally), builds their .torrent files, signs the manifest of all
+// seedable files and uploads files, .torrent files, manifest.txt and manifest.json to the webseed bucket.
+// Manifests are uploaded last: webseed clients never see files which are not uploaded yet.
+func doPublish(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+
+	key, err := crypto.LoadECDSA(cliCtx.String(PublishKeyFlag.Name))
+	if err != nil {
+		return fmt.Errorf("--%s: %w", PublishKeyFlag.Name, err)
+	}
+	location := cliCtx.String(PublishLocationFlag.Name)
+	remote, _, ok := strings.Cut(location, ":")
+	if !ok || remote == "" {
+		return fmt.Errorf("--%s=%s: expected <remote>:<bucket/path>", PublishLocationFlag.Name, location)
+	}
+
+	dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	var logger log.Logger
+	if cliCtx.Bool(PublishRetireFlag.Name) {
+		if err := doRetireCommand(cliCtx, dirs); err != nil { // sets up the root logger
+			return err
+		}
+		logger = log.Root()
+	} else if logger, _, _, _, err = debug.Setup(cliCtx, true /* rootLogger */); err != nil {
+		return err
+	}
+
+	db := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	chain := fromdb.ChainConfig(db).ChainName
+	db.Close()
+
+	torrentFiles := downloader.NewAtomicTorrentFS(dirs.Snap)
+	created, err := downloader.BuildTorrentFilesIfNeed(ctx, dirs, torrentFiles, chain, nil, false)
+	if err != nil {
+		return err
+	}
+	files, err := downloader.SeedableFiles(dirs, chain, false)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("no seedable files to publish")
+	}
+	manifest, err := downloader.NewPublishManifest(torrentFiles, chain, time.Now().Unix(), files)
+	if err != nil {
+		return err
+	}
+	signed, err := manifest.Sign(key)
+	if err != nil {
+		return err
+	}
+	logger.Info("[publish] manifest signed", "chain", chain, "files", len(files), "newTorrents", created,
+		"signer", crypto.PubkeyToAddress(key.PublicKey))
+
+	upload := make([]string, 0, 2*len(files))
+	var manifestTxt bytes.Buffer
+	for _, f := range manifest.Files {
+		upload = append(upload, f.Name, f.Name+".torrent")
+		fmt.Fprintln(&manifestTxt, f.Name)
+		fmt.Fprintln(&manifestTxt, f.Name+".torrent")
+	}
+	if cliCtx.Bool(PublishDryRunFlag.Name) {
+		for _, f := range manifest.Files {
+			logger.Info("[publish] would upload", "file", f.Name, "infoHash", f.InfoHash, "length", f.Length)
+		}
+		return nil
+	}
+
+	rcCli, err := downloader.NewRCloneClient(logger)
+	if err != nil {
+		return err
+	}
+	if err := sync.CheckRemote(rcCli, remote); err != nil {
+		return err
+	}
+	session, err := rcCli.NewSession(ctx, dirs.Snap, location, nil)
+	if err != nil {
+		return err
+	}
+	defer session.Stop()
+	// unchanged files are skipped by rclone
+	if err := session.Upload(ctx, upload...); err != nil {
+		return err
+	}
+
+	manifestDir, err := os.MkdirTemp(dirs.Tmp, "publish-manifest-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(manifestDir)
+	if err := os.WriteFile(filepath.Join(manifestDir, "manifest.txt"), manifestTxt.Bytes(), 0644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(manifestDir, downloader.PublishManifestFile), signed, 0644); err != nil {
+		return err
+	}
+	manifestSession, err := rcCli.NewSession(ctx, manifestDir, location, nil)
+	if err != nil {
+		return err
+	}
+	defer manifestSession.Stop()
+	if err := manifestSession.Upload(ctx, "manifest.txt", downloader.PublishManifestFile); err != nil {
+		return err
+	}
+	logger.Info("[publish] done", "location", location, "files", len(files))
+	return nil
+}
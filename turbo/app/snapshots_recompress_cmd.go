@@ -0,0 +1,154 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/version"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	coresnaptype "github.com/erigontech/erigon/core/snaptype"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var (
+	RecompressTypesFlag = cli.StringSliceFlag{
+		Name:  "types",
+		Usage: "types of block segments to recompress",
+		Value: cli.NewStringSlice(coresnaptype.Headers.Name(), coresnaptype.Bodies.Name(), coresnaptype.Transactions.Name()),
+	}
+	RecompressVersionFlag = cli.StringFlag{
+		Name:  "version",
+		Usage: "version of recompressed files, e.g. v1.1. Default: current version of segment type",
+	}
+	RecompressForceFlag = cli.BoolFlag{
+		Name:  "force",
+		Usage: "recompress also files which already have the version (in place)",
+	}
+	RecompressDryRunFlag = cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "only report files which would be recompressed",
+	}
+)
+
+// doRecompress - rewrites block segments of older versions with current compression parameters and names them
+// by new version, so long-lived archives benefit from compressor improvements without regeneration from db.
+// Erigon must be stopped. Recompressed files have other hashes than published ones: their .torrent files are removed.
+func doRecompress(cliCtx *cli.Context) error {
+	ctx := cliCtx.Context
+	logger, _, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	var target version.Version
+	if v := cliCtx.String(RecompressVersionFlag.Name); v != "" {
+		if target, err = version.ParseVersion(v); err != nil {
+			return fmt.Errorf("--%s: %w", RecompressVersionFlag.Name, err)
+		}
+	}
+	types := cliCtx.StringSlice(RecompressTypesFlag.Name)
+	for _, t := range types {
+		if !slices.ContainsFunc(coresnaptype.BlockSnapshotTypes, func(typ snaptype.Type) bool { return typ.Name() == t }) {
+			return fmt.Errorf("--%s: %s is not a block segment type", RecompressTypesFlag.Name, t)
+		}
+	}
+	force, dryRun := cliCtx.Bool(RecompressForceFlag.Name), cliCtx.Bool(RecompressDryRunFlag.Name)
+
+	var todo []snaptype.FileInfo
+	segments := ls(dirs.Snap, ".seg")
+	for _, path := range segments {
+		f, isState, ok := snaptype.ParseFileName(dirs.Snap, filepath.Base(path))
+		if !ok || isState || !slices.Contains(types, f.Type.Name()) {
+			continue
+		}
+		ver := versionOr(target, f)
+		if ver.Less(f.Type.Versions().MinSupported) {
+			return fmt.Errorf("--%s=%s: older than min supported version %s of %s", RecompressVersionFlag.Name, ver, f.Type.Versions().MinSupported, f.Type.Name())
+		}
+		switch {
+		case ver.Less(f.Version):
+			logger.Warn("[recompress] skip: file has newer version", "file", f.Name(), "version", ver)
+			continue
+		case ver == f.Version && !force:
+			continue
+		}
+		if to := f.Type.FileName(ver, f.From, f.To); to != f.Name() && slices.Contains(segments, filepath.Join(dirs.Snap, to)) {
+			logger.Warn("[recompress] skip: file of the version exists", "file", f.Name(), "exists", to)
+			continue
+		}
+		todo = append(todo, f)
+	}
+	slices.SortFunc(todo, func(a, b snaptype.FileInfo) int { return cmp.Compare(a.From, b.From) })
+	logger.Info("[recompress] files", "amount", len(todo), "dryRun", dryRun)
+	if dryRun {
+		for _, f := range todo {
+			logger.Info("[recompress] would recompress", "file", f.Name(), "to", f.Type.FileName(versionOr(target, f), f.From, f.To))
+		}
+		return nil
+	}
+
+	db := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	chainConfig := fromdb.ChainConfig(db)
+	db.Close()
+
+	var oldTotal, newTotal int64
+	for i, f := range todo {
+		start := time.Now()
+		st, err := os.Stat(f.Path)
+		if err != nil {
+			return err
+		}
+		oldSize := st.Size()
+		to := versionOr(target, f)
+		newSize, err := freezeblocks.Recompress(ctx, dirs, f, to, chainConfig, logger)
+		if err != nil {
+			return fmt.Errorf("recompress %s: %w", f.Name(), err)
+		}
+		oldTotal, newTotal = oldTotal+oldSize, newTotal+newSize
+		logger.Info("[recompress] done", "file", f.Name(), "to", f.Type.FileName(to, f.From, f.To), "progress", fmt.Sprintf("%d/%d", i+1, len(todo)),
+			"size", datasize.ByteSize(oldSize).HR(), "newSize", datasize.ByteSize(newSize).HR(), "took", time.Since(start))
+	}
+	logger.Info("[recompress] all done", "files", len(todo), "size", datasize.ByteSize(oldTotal).HR(), "newSize", datasize.ByteSize(newTotal).HR())
+	return nil
+}
+
+// versionOr - target version, or current version of f's type if not set
+func versionOr(target version.Version, f snaptype.FileInfo) version.Version {
+	if target.IsZero() {
+		return f.Type.Versions().Current
+	}
+	return target
+}
@@ -28,7 +28,6 @@ import (
 
 	"github.com/erigontech/erigon-lib/common/datadir"
 	"github.com/erigontech/erigon-lib/common/dir"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -137,7 +136,7 @@ func squeezeStorage(ctx context.Context, dirs datadir.Dirs, logger log.Logger) e
 	ac := agg.BeginFilesRo()
 	defer ac.Close()
 
-	aggOld, err := state.NewAggregator(ctx, dirsOld, config3.DefaultStepSize, db, logger)
+	aggOld, err := state.NewAggregator(ctx, dirsOld, agg.StepSize(), db, logger)
 	if err != nil {
 		panic(err)
 	}
@@ -178,7 +177,11 @@ func squeezeStorage(ctx context.Context, dirs datadir.Dirs, logger log.Logger) e
 func squeezeCode(ctx context.Context, dirs datadir.Dirs, logger log.Logger) error {
 	db := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
 	defer db.Close()
-	agg, err := state.NewAggregator(ctx, dirs, config3.DefaultStepSize, db, logger)
+	stepSize, err := state.GetStateAggregationStep(dirs, fromdb.ChainConfig(db).GetAggregationStep(), false)
+	if err != nil {
+		return err
+	}
+	agg, err := state.NewAggregator(ctx, dirs, stepSize, db, logger)
 	if err != nil {
 		return err
 	}
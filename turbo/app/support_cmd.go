@@ -92,6 +92,9 @@ var supportCommand = cli.Command{
 	}, debug.Flags...),
 	//Category: "SUPPORT COMMANDS",
 	Description: `The support command connects a running Erigon instances to a diagnostics system specified by the URL.`,
+	Subcommands: []*cli.Command{
+		&supportBundleCommand,
+	},
 }
 
 type nodeRequest struct {
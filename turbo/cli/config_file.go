@@ -17,66 +17,84 @@
 package cli
 
 import (
-	"errors"
+	"flag"
 	"fmt"
-	"os"
-	"path/filepath"
-	"reflect"
+	"slices"
 	"strings"
 
-	"github.com/pelletier/go-toml"
 	"github.com/urfave/cli/v2"
-	"gopkg.in/yaml.v2"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/utils/flags"
 )
 
+// SetFlagsFromConfigFile sets flags which are not set in command line to values of .toml/.yaml config file.
+// Config file is validated against flags of the command: unknown keys and values of wrong type are errors.
 func SetFlagsFromConfigFile(ctx *cli.Context, filePath string) error {
-	fileExtension := filepath.Ext(filePath)
-
-	fileConfig := make(map[string]interface{})
-
-	if fileExtension == ".yml" || fileExtension == ".yaml" {
-		yamlFile, err := os.ReadFile(filePath)
-		if err != nil {
-			return err
+	flagLists := [][]cli.Flag{ctx.App.Flags}
+	for _, c := range ctx.Lineage() {
+		if c.Command != nil {
+			flagLists = append(flagLists, c.Command.Flags)
 		}
-		err = yaml.Unmarshal(yamlFile, fileConfig)
-		if err != nil {
-			return err
+	}
+	values, warnings, err := flags.NewConfigSchema(flagLists...).Load(filePath)
+	for _, w := range warnings {
+		if w.Replacement != "" {
+			log.Warn("[config] deprecated key", "key", w.Key, "use", w.Replacement, "note", w.Note, "file", filePath)
+		} else {
+			log.Warn("[config] removed key is ignored", "key", w.Key, "note", w.Note, "file", filePath)
 		}
-	} else if fileExtension == ".toml" {
-		tomlFile, err := os.ReadFile(filePath)
-		if err != nil {
-			return err
+	}
+	if err != nil {
+		return err
+	}
+
+	// sets global flags to value in yaml/toml file
+	for key, value := range values {
+		if ctx.IsSet(key) {
+			continue
 		}
-		err = toml.Unmarshal(tomlFile, &fileConfig)
-		if err != nil {
-			return err
+		if err := ctx.Set(key, value); err != nil {
+			return fmt.Errorf("failed setting %s flag with value=%s error=%w", key, value, err)
 		}
-	} else {
-		return errors.New("config files only accepted are .yaml and .toml")
 	}
-	// sets global flags to value in yaml/toml file
-	for key, value := range fileConfig {
-		if !ctx.IsSet(key) {
-			if reflect.ValueOf(value).Kind() == reflect.Slice {
-				sliceInterface := value.([]interface{})
-				s := make([]string, len(sliceInterface))
-				for i, v := range sliceInterface {
-					s[i] = fmt.Sprintf("%v", v)
-				}
-				err := ctx.Set(key, strings.Join(s, ","))
-				if err != nil {
-					return fmt.Errorf("failed setting %s flag with values=%s error=%s", key, s, err)
-				}
-			} else {
-				err := ctx.Set(key, fmt.Sprintf("%v", value))
-				if err != nil {
-					return fmt.Errorf("failed setting %s flag with value=%v error=%s", key, value, err)
+	return nil
+}
 
-				}
-			}
+// ChainContext - context of one chain of the multi-chain mode (--chains.config): flags of the chain's config file,
+// then flags set in command line (or --config) which the file doesn't set - these are shared by all chains.
+func ChainContext(parent *cli.Context, filePath string, skip ...string) (*cli.Context, error) {
+	set := flag.NewFlagSet(parent.App.Name, flag.ContinueOnError)
+	for _, f := range parent.App.Flags {
+		if dirFlag, ok := f.(*flags.DirectoryFlag); ok { // binds flag set to own Value: chains must not share it
+			dirFlagCopy := *dirFlag
+			f = &dirFlagCopy
+		}
+		if err := f.Apply(set); err != nil {
+			return nil, err
 		}
 	}
-
-	return nil
+	ctx := cli.NewContext(parent.App, set, nil)
+	ctx.Context = parent.Context
+	if err := SetFlagsFromConfigFile(ctx, filePath); err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	for _, name := range parent.FlagNames() {
+		if !parent.IsSet(name) || ctx.IsSet(name) || slices.Contains(skip, name) {
+			continue
+		}
+		// slices must be set as "a,b,c" instead of "[a b c]"
+		value := parent.String(name)
+		if result := parent.StringSlice(name); len(result) > 0 {
+			value = strings.Join(result, ",")
+		} else if result := parent.UintSlice(name); len(result) > 0 {
+			value = strings.Trim(strings.Join(strings.Fields(fmt.Sprint(result)), ","), "[]")
+		} else if result := parent.IntSlice(name); len(result) > 0 {
+			value = strings.Trim(strings.Join(strings.Fields(fmt.Sprint(result)), ","), "[]")
+		}
+		if err := ctx.Set(name, value); err != nil {
+			return nil, fmt.Errorf("%s: flag %s: %w", filePath, name, err)
+		}
+	}
+	return ctx, nil
 }
@@ -25,6 +25,7 @@ import (
 // DefaultFlags contains all flags that are used and supported by Erigon binary.
 var DefaultFlags = []cli.Flag{
 	&utils.DataDirFlag,
+	&utils.DataDirSnapFlag,
 	&utils.EthashDatasetDirFlag,
 	&utils.ExternalConsensusFlag,
 	&utils.TxPoolDisableFlag,
@@ -32,6 +33,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolPriceBumpFlag,
 	&utils.TxPoolBlobPriceBumpFlag,
 	&utils.TxPoolAccountSlotsFlag,
+	&utils.TxPoolRejectListFlag,
 	&utils.TxPoolBlobSlotsFlag,
 	&utils.TxPoolTotalBlobPoolLimit,
 	&utils.TxPoolGlobalSlotsFlag,
@@ -52,6 +54,7 @@ var DefaultFlags = []cli.Flag{
 	&TLSCertFlag,
 	&TLSKeyFlag,
 	&TLSCACertFlag,
+	&TLSACLFlag,
 	&StateStreamDisableFlag,
 	&SyncLoopThrottleFlag,
 	&BadBlockFlag,
@@ -64,6 +67,10 @@ var DefaultFlags = []cli.Flag{
 	&utils.AuthRpcAddr,
 	&utils.AuthRpcPort,
 	&utils.JWTSecretPath,
+	&utils.AdminRpcAddrFlag,
+	&utils.AdminRpcPortFlag,
+	&utils.AdminRpcAPIFlag,
+	&utils.AdminRpcJWTSecretFlag,
 	&utils.HttpCompressionFlag,
 	&utils.HTTPCORSDomainFlag,
 	&utils.HTTPVirtualHostsFlag,
@@ -79,10 +86,15 @@ var DefaultFlags = []cli.Flag{
 	&utils.RpcStreamingDisableFlag,
 	&utils.DBReadConcurrencyFlag,
 	&utils.RpcAccessListFlag,
+	&utils.RpcAPIKeysFlag,
 	&utils.RpcTraceCompatFlag,
 	&utils.RpcGasCapFlag,
 	&utils.RpcBatchLimit,
 	&utils.RpcReturnDataLimit,
+	&utils.RpcLogsPageLimit,
+	&utils.HistoryPortalURLFlag,
+	&utils.PreconfFeedURLFlag,
+	&utils.PreconfFeedTypeFlag,
 	&utils.AllowUnprotectedTxs,
 	&utils.RPCGlobalTxFeeCapFlag,
 	&utils.TxpoolApiAddrFlag,
@@ -107,6 +119,7 @@ var DefaultFlags = []cli.Flag{
 
 	&utils.SnapKeepBlocksFlag,
 	&utils.SnapStopFlag,
+	&utils.SnapMmapBudgetFlag,
 	&utils.SnapStateStopFlag,
 	&utils.SnapSkipStateSnapshotDownloadFlag,
 	&utils.DbPageSizeFlag,
@@ -123,12 +136,17 @@ var DefaultFlags = []cli.Flag{
 	&utils.ListenPortFlag,
 	&utils.P2pProtocolVersionFlag,
 	&utils.P2pProtocolAllowedPorts,
+	&utils.P2pQUICPortFlag,
+	&utils.P2pServeSnapFlag,
 	&utils.NATFlag,
 	&utils.NoDiscoverFlag,
+	&utils.DiscoveryV4Flag,
 	&utils.DiscoveryV5Flag,
 	&utils.NetrestrictFlag,
 	&utils.NodeKeyFileFlag,
 	&utils.NodeKeyHexFlag,
+	&utils.SecretsEncryptFlag,
+	&utils.SecretsPasswordFileFlag,
 	&utils.DNSDiscoveryFlag,
 	&utils.BootnodesFlag,
 	&utils.StaticPeersFlag,
@@ -139,6 +157,12 @@ var DefaultFlags = []cli.Flag{
 	&utils.VMEnableDebugFlag,
 	&utils.NetworkIdFlag,
 	&utils.PersistReceiptsV2Flag,
+	&utils.AddressAppearancesFlag,
+	&utils.AccessListsFlag,
+	&utils.WithdrawalsIndexFlag,
+	&utils.HeadersMMRFlag,
+	&utils.HistoryExpiryFlag,
+	&utils.ExecPrefetchFlag,
 	&utils.FakePoWFlag,
 	&utils.GpoBlocksFlag,
 	&utils.GpoPercentileFlag,
@@ -163,11 +187,17 @@ var DefaultFlags = []cli.Flag{
 	&utils.DownloaderAddrFlag,
 	&utils.DisableIPV4,
 	&utils.DisableIPV6,
+	&utils.TorrentDisableUTPFlag,
+	&utils.TorrentDisablePEXFlag,
+	&utils.TorrentDHTFlag,
+	&utils.TorrentDHTBootstrapFlag,
+	&utils.DownloaderScrubRateFlag,
 	&utils.NoDownloaderFlag,
 	&utils.DownloaderVerifyFlag,
 	&HealthCheckFlag,
 	&utils.HeimdallURLFlag,
 	&utils.WebSeedsFlag,
+	&utils.WebSeedAuthFlag,
 	&utils.WithoutHeimdallFlag,
 	&utils.BorBlockPeriodFlag,
 	&utils.BorBlockSizeFlag,
@@ -177,7 +207,13 @@ var DefaultFlags = []cli.Flag{
 	&utils.PolygonSyncStageFlag,
 	&utils.AAFlag,
 	&utils.EthStatsURLFlag,
+	&utils.StreamURLFlag,
+	&utils.StreamFormatFlag,
+	&utils.StreamTopicPrefixFlag,
+	&utils.StreamFromBlockFlag,
 	&utils.OverridePragueFlag,
+	&utils.OverrideFileFlag,
+	&utils.CustomChainsDirFlag,
 
 	&utils.CaplinDiscoveryAddrFlag,
 	&utils.CaplinDiscoveryPortFlag,
@@ -209,6 +245,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.SilkwormRpcJsonCompatibilityFlag,
 
 	&utils.BeaconAPIFlag,
+	&utils.BeaconApiKeymanagerTokenFlag,
 	&utils.BeaconApiAddrFlag,
 	&utils.BeaconApiAllowMethodsFlag,
 	&utils.BeaconApiAllowOriginsFlag,
@@ -225,6 +262,9 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinImmediateBlobBackfillFlag,
 
 	&utils.CaplinDisableBlobPruningFlag,
+	&utils.CaplinRetentionBlobsFlag,
+	&utils.CaplinRetentionBlocksFlag,
+	&utils.CaplinRetentionDryRunFlag,
 	&utils.CaplinDisableCheckpointSyncFlag,
 	&utils.CaplinEnableSnapshotGeneration,
 	&utils.CaplinMevRelayUrl,
@@ -234,12 +274,17 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinUseEngineApiFlag,
 
 	&utils.TrustedSetupFile,
+	&utils.CryptoBatchLatencyFlag,
 	&utils.RPCSlowFlag,
 
 	&utils.TxPoolGossipDisableFlag,
+	&utils.TxPoolAnnounceRatioFlag,
+	&utils.TxPoolBroadcastRatioFlag,
 	&SyncLoopBlockLimitFlag,
 	&SyncLoopBreakAfterFlag,
 	&SyncParallelStateFlushing,
+	&SyncMaxReorgDepthFlag,
+	&SyncReorgAlertURLFlag,
 
 	&utils.ChaosMonkeyFlag,
 
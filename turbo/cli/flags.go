@@ -28,6 +28,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/etl"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/kv/prune"
@@ -113,6 +114,11 @@ var (
 		Usage: "Specify certificate authority",
 		Value: "",
 	}
+	TLSACLFlag = cli.StringFlag{
+		Name:  "tls.acl",
+		Usage: "Roles of gRPC clients by common name of their certificates: rpcdaemon=read,txpool=admin. Other clients are rejected. Requires --tls.cacert",
+		Value: "",
+	}
 	StateStreamDisableFlag = cli.BoolFlag{
 		Name:  "state.stream.disable",
 		Usage: "Disable streaming of state changes from core to RPC daemon",
@@ -143,6 +149,18 @@ var (
 		Value: true,
 	}
 
+	SyncMaxReorgDepthFlag = cli.Uint64Flag{
+		Name:  "sync.max-reorg-depth",
+		Usage: "Refuses forkchoice reorgs which remove more canonical blocks than this, until allowed by admin_allowReorg. 0 - no limit",
+		Value: 0,
+	}
+
+	SyncReorgAlertURLFlag = cli.StringFlag{
+		Name:  "sync.reorg-alert.url",
+		Usage: "URL to POST JSON alerts of reorgs deeper than --sync.max-reorg-depth",
+		Value: "",
+	}
+
 	UploadLocationFlag = cli.StringFlag{
 		Name:  "upload.location",
 		Usage: "Location to upload snapshot segments to",
@@ -309,6 +327,8 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 		cfg.Sync.LoopBlockLimit = limit
 	}
 	cfg.Sync.ParallelStateFlushing = ctx.Bool(SyncParallelStateFlushing.Name)
+	cfg.Sync.MaxReorgDepth = ctx.Uint64(SyncMaxReorgDepthFlag.Name)
+	cfg.Sync.ReorgAlertURL = ctx.String(SyncReorgAlertURLFlag.Name)
 
 	if location := ctx.String(UploadLocationFlag.Name); len(location) > 0 {
 		cfg.Sync.UploadLocation = location
@@ -458,6 +478,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		RpcStreamingDisable:               ctx.Bool(utils.RpcStreamingDisableFlag.Name),
 		DBReadConcurrency:                 ctx.Int(utils.DBReadConcurrencyFlag.Name),
 		RpcAllowListFilePath:              ctx.String(utils.RpcAccessListFlag.Name),
+		RpcAPIKeysFilePath:                ctx.String(utils.RpcAPIKeysFlag.Name),
 		RpcFiltersConfig: rpchelper.FiltersConfig{
 			RpcSubscriptionFiltersMaxLogs:      ctx.Int(RpcSubscriptionFiltersMaxLogsFlag.Name),
 			RpcSubscriptionFiltersMaxHeaders:   ctx.Int(RpcSubscriptionFiltersMaxHeadersFlag.Name),
@@ -471,12 +492,21 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		TraceCompatibility:  ctx.Bool(utils.RpcTraceCompatFlag.Name),
 		BatchLimit:          ctx.Int(utils.RpcBatchLimit.Name),
 		ReturnDataLimit:     ctx.Int(utils.RpcReturnDataLimit.Name),
+		LogsPageLimit:       ctx.Uint64(utils.RpcLogsPageLimit.Name),
+		PortalURL:           ctx.String(utils.HistoryPortalURLFlag.Name),
+		PreconfFeedURL:      ctx.String(utils.PreconfFeedURLFlag.Name),
+		PreconfFeedType:     ctx.String(utils.PreconfFeedTypeFlag.Name),
 		AllowUnprotectedTxs: ctx.Bool(utils.AllowUnprotectedTxs.Name),
 
 		OtsMaxPageSize: ctx.Uint64(utils.OtsSearchMaxCapFlag.Name),
 
 		TxPoolApiAddr: ctx.String(utils.TxpoolApiAddrFlag.Name),
 
+		AdminRpcListenAddress: ctx.String(utils.AdminRpcAddrFlag.Name),
+		AdminRpcPort:          int(ctx.Uint(utils.AdminRpcPortFlag.Name)),
+		AdminRpcAPI:           common.CliString2Array(ctx.String(utils.AdminRpcAPIFlag.Name)),
+		AdminRpcJWTSecretPath: ctx.String(utils.AdminRpcJWTSecretFlag.Name),
+
 		StateCache:          kvcache.DefaultCoherentConfig,
 		RPCSlowLogThreshold: ctx.Duration(utils.RPCSlowFlag.Name),
 	}
@@ -541,5 +571,15 @@ func setPrivateApi(ctx *cli.Context, cfg *nodecfg.Config) {
 		cfg.TLSKeyFile = keyFile
 		cfg.TLSCACert = ctx.String(TLSCACertFlag.Name)
 	}
+	if ctx.IsSet(TLSACLFlag.Name) {
+		acl, err := grpcutil.ParseACL(ctx.String(TLSACLFlag.Name))
+		if err != nil {
+			utils.Fatalf("Invalid --%s: %v", TLSACLFlag.Name, err)
+		}
+		if !cfg.TLSConnection || cfg.TLSCACert == "" {
+			utils.Fatalf("--%s requires --%s and --%s: client certificates must be verified", TLSACLFlag.Name, TLSFlag.Name, TLSCACertFlag.Name)
+		}
+		cfg.TLSACL = acl
+	}
 	cfg.HealthCheck = ctx.Bool(HealthCheckFlag.Name)
 }
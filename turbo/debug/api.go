@@ -39,6 +39,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/turbo/logging"
 )
 
 // Handler is the global debugging handler.
@@ -56,16 +57,14 @@ type HandlerT struct {
 }
 
 // Verbosity sets the log verbosity ceiling. The verbosity of individual packages
-// and source files can be raised using Vmodule.
-func (*HandlerT) Verbosity(level int) {
-	//glogger.Verbosity(log.Lvl(level))
+// can be raised using Vmodule.
+func (*HandlerT) Verbosity(level int) error {
+	return logging.SetLogLevel("", log.Lvl(level))
 }
 
-// Vmodule sets the log verbosity pattern. See package log for details on the
-// pattern syntax.
+// Vmodule sets the log verbosity pattern: comma-separated list of <module>=<level>.
 func (*HandlerT) Vmodule(pattern string) error {
-	//return glogger.Vmodule(pattern)
-	return nil
+	return logging.SetModuleLevels(pattern)
 }
 
 // BacktraceAt sets the log backtrace location. See package log for details on
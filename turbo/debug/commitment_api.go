@@ -0,0 +1,54 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"errors"
+
+	"github.com/erigontech/erigon-lib/commitment"
+	"github.com/erigontech/erigon/rpc"
+)
+
+var errCommitmentTraceDisabled = errors.New("commitment prefix tracing is disabled, enable it by --commitment.trace.blocks")
+
+// CommitmentAPI exposes timings of commitment calculation. They are collected in-process - so available only in embedded rpcdaemon.
+type CommitmentAPI struct{}
+
+func APIs() []rpc.API {
+	return []rpc.API{{Namespace: "debug", Public: false, Service: &CommitmentAPI{}, Version: "1.0"}}
+}
+
+type CommitmentSlowestPrefixes struct {
+	Prefixes []commitment.PrefixTiming `json:"prefixes"`
+	Blocks   []commitment.BlockTrace   `json:"blocks"`
+}
+
+// CommitmentSlowestPrefixes - trie prefixes (up to 4 nibbles) which took most of fold/unfold time in last `blocks` blocks
+func (*CommitmentAPI) CommitmentSlowestPrefixes(blocks, limit *int) (*CommitmentSlowestPrefixes, error) {
+	if !commitment.PrefixTracingEnabled() {
+		return nil, errCommitmentTraceDisabled
+	}
+	var lastBlocks, maxPrefixes int
+	if blocks != nil {
+		lastBlocks = *blocks
+	}
+	if limit != nil {
+		maxPrefixes = *limit
+	}
+	prefixes, traces := commitment.SlowestPrefixes(lastBlocks, maxPrefixes)
+	return &CommitmentSlowestPrefixes{Prefixes: prefixes, Blocks: traces}, nil
+}
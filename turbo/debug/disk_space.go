@@ -0,0 +1,52 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/disk"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+const diskSpaceCheckInterval = 10 * time.Second
+
+// StartDiskSpaceWatchdog - switches process to degraded mode while free space on datadir disk is below thresholds,
+// see disk.SpaceLevel. Empty datadir - nothing to watch.
+func StartDiskSpaceWatchdog(ctx context.Context, datadir, low, critical string, logger log.Logger) error {
+	if datadir == "" {
+		return nil
+	}
+	var t disk.SpaceThresholds
+	if err := t.Low.UnmarshalText([]byte(low)); err != nil {
+		return fmt.Errorf("--%s: %w", diskSpaceLowFlag.Name, err)
+	}
+	if err := t.Critical.UnmarshalText([]byte(critical)); err != nil {
+		return fmt.Errorf("--%s: %w", diskSpaceCriticalFlag.Name, err)
+	}
+	if t.Low != 0 && t.Critical > t.Low {
+		return fmt.Errorf("--%s=%s must not be above --%s=%s", diskSpaceCriticalFlag.Name, t.Critical.HR(), diskSpaceLowFlag.Name, t.Low.HR())
+	}
+	if t.Low == 0 && t.Critical == 0 {
+		return nil
+	}
+	logger.Debug("[disk] free space watchdog", "datadir", datadir, "low", t.Low.HR(), "critical", t.Critical.HR())
+	go disk.WatchSpace(ctx, datadir, t, diskSpaceCheckInterval, logger)
+	return nil
+}
@@ -20,26 +20,25 @@
 package debug
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 	"net/http/pprof" //nolint:gosec
-	"os"
-	"path/filepath"
+	"time"
 
+	"github.com/erigontech/erigon-lib/commitment"
 	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/common/mem"
 	"github.com/erigontech/erigon-lib/metrics"
 
-	"github.com/pelletier/go-toml"
 	"github.com/spf13/cobra"
 	"github.com/urfave/cli/v2"
-	"gopkg.in/yaml.v2"
 
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon-lib/common/fdlimit"
+	cliflags "github.com/erigontech/erigon/cmd/utils/flags"
 	"github.com/erigontech/erigon/eth/tracers"
+	_ "github.com/erigontech/erigon/eth/tracers/live"
 	"github.com/erigontech/erigon/turbo/logging"
 )
 
@@ -53,11 +52,15 @@ var (
 		Name:  "vmtrace.jsonconfig",
 		Usage: "Set the config of the tracer",
 	}
-	//nolint
-	vmoduleFlag = cli.StringFlag{
-		Name:  "vmodule",
-		Usage: "Per-module verbosity: comma-separated list of <pattern>=<level> (e.g. eth/*=5,p2p=4)",
-		Value: "",
+	diskSpaceLowFlag = cli.StringFlag{
+		Name:  "disk.space.low",
+		Usage: "Free space on datadir disk below which snapshots download and files merge are paused. 0 - disabled",
+		Value: "20GB",
+	}
+	diskSpaceCriticalFlag = cli.StringFlag{
+		Name:  "disk.space.critical",
+		Usage: "Free space on datadir disk below which also write rpc methods (eth_sendRawTransaction, admin_addPeer, debug_setHead, ...) are rejected. 0 - disabled",
+		Value: "5GB",
 	}
 	configFlag = cli.StringFlag{
 		Name: "config",
@@ -92,16 +95,45 @@ var (
 		Name:  "pprof.cpuprofile",
 		Usage: "Write CPU profile to the given file",
 	}
+	pprofUploadEndpointFlag = cli.StringFlag{
+		Name:  "pprof.upload.endpoint",
+		Usage: "Continuously capture CPU/heap profiles and push them to the given Pyroscope-compatible server URL (e.g. http://127.0.0.1:4040)",
+	}
+	pprofUploadIntervalFlag = cli.DurationFlag{
+		Name:  "pprof.upload.interval",
+		Usage: "Duration of every profile pushed by continuous profiling",
+		Value: time.Minute,
+	}
+	pprofUploadLabelsFlag = cli.StringFlag{
+		Name:  "pprof.upload.labels",
+		Usage: "Static labels of continuously pushed profiles, e.g. 'host=node1,chain=mainnet'. Stage and module labels are added automatically",
+	}
 	traceFlag = cli.StringFlag{
 		Name:  "trace",
 		Usage: "Write execution trace to the given file",
 	}
+	otelEndpointFlag = cli.StringFlag{
+		Name:  "otel.endpoint",
+		Usage: "Export OpenTelemetry spans to the given OTLP/HTTP collector URL (e.g. http://127.0.0.1:4318)",
+	}
+	otelSampleRatioFlag = cli.Float64Flag{
+		Name:  "otel.sample",
+		Usage: "Fraction of root spans exported to OpenTelemetry collector",
+		Value: 1,
+	}
+	commitmentTraceBlocksFlag = cli.IntFlag{
+		Name:  "commitment.trace.blocks",
+		Usage: "Keep timings of slowest trie prefixes of commitment calculation for last N blocks (see debug_commitmentSlowestPrefixes). 0 - disabled",
+	}
 )
 
 // Flags holds all command-line flags required for debugging.
 var Flags = []cli.Flag{
 	&pprofFlag, &pprofAddrFlag, &pprofPortFlag,
+	&pprofUploadEndpointFlag, &pprofUploadIntervalFlag, &pprofUploadLabelsFlag,
 	&cpuprofileFlag, &traceFlag, &vmTraceFlag, &vmTraceJsonConfigFlag,
+	&otelEndpointFlag, &otelSampleRatioFlag, &commitmentTraceBlocksFlag,
+	&diskSpaceLowFlag, &diskSpaceCriticalFlag,
 }
 
 // SetupCobra sets up logging, profiling and tracing for cobra commands
@@ -139,6 +171,68 @@ func SetupCobra(cmd *cobra.Command, filePrefix string) log.Logger {
 		}
 	}
 
+	otelEndpoint, err := flags.GetString(otelEndpointFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	otelSampleRatio, err := flags.GetFloat64(otelSampleRatioFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	if otelEndpoint != "" {
+		if err2 := StartOtel(cmd.Context(), otelEndpoint, otelSampleRatio, logger); err2 != nil {
+			log.Error("failed to start OpenTelemetry exporter", "err", err2)
+			panic(err2)
+		}
+	}
+	commitmentTraceBlocks, err := flags.GetInt(commitmentTraceBlocksFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	commitment.SetPrefixTracing(commitmentTraceBlocks)
+
+	pprofUploadEndpoint, err := flags.GetString(pprofUploadEndpointFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	pprofUploadInterval, err := flags.GetDuration(pprofUploadIntervalFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	pprofUploadLabels, err := flags.GetString(pprofUploadLabelsFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	if pprofUploadEndpoint != "" {
+		if err2 := StartProfileUploader(cmd.Context(), pprofUploadEndpoint, filePrefix, pprofUploadLabels, pprofUploadInterval, logger); err2 != nil {
+			log.Error("failed to start continuous profiling", "err", err2)
+			panic(err2)
+		}
+	}
+
+	diskSpaceLow, err := flags.GetString(diskSpaceLowFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	diskSpaceCritical, err := flags.GetString(diskSpaceCriticalFlag.Name)
+	if err != nil {
+		log.Error("failed setting config flags from yaml/toml file", "err", err)
+		panic(err)
+	}
+	if datadirFlag := flags.Lookup("datadir"); datadirFlag != nil {
+		if err2 := StartDiskSpaceWatchdog(cmd.Context(), datadirFlag.Value.String(), diskSpaceLow, diskSpaceCritical, logger); err2 != nil {
+			log.Error("failed to start disk space watchdog", "err", err2)
+			panic(err2)
+		}
+	}
+
 	go ListenSignals(nil, logger)
 	pprof, err := flags.GetBool(pprofFlag.Name)
 	if err != nil {
@@ -236,6 +330,21 @@ func Setup(ctx *cli.Context, rootLogger bool) (log.Logger, *tracers.Tracer, *htt
 			return logger, tracer, nil, nil, err
 		}
 	}
+	if endpoint := ctx.String(otelEndpointFlag.Name); endpoint != "" {
+		if err := StartOtel(ctx.Context, endpoint, ctx.Float64(otelSampleRatioFlag.Name), logger); err != nil {
+			return logger, tracer, nil, nil, err
+		}
+	}
+	commitment.SetPrefixTracing(ctx.Int(commitmentTraceBlocksFlag.Name))
+	if err := StartDiskSpaceWatchdog(ctx.Context, ctx.String("datadir"), ctx.String(diskSpaceLowFlag.Name), ctx.String(diskSpaceCriticalFlag.Name), logger); err != nil {
+		return logger, tracer, nil, nil, err
+	}
+	if endpoint := ctx.String(pprofUploadEndpointFlag.Name); endpoint != "" {
+		if err := StartProfileUploader(ctx.Context, endpoint, "erigon", ctx.String(pprofUploadLabelsFlag.Name), ctx.Duration(pprofUploadIntervalFlag.Name), logger); err != nil {
+			return logger, tracer, nil, nil, err
+		}
+	}
+
 	pprofEnabled := ctx.Bool(pprofFlag.Name)
 	metricsEnabled := ctx.Bool(metricsEnabledFlag.Name)
 	metricsAddr := ctx.String(metricsAddrFlag.Name)
@@ -306,6 +415,8 @@ func StartPProf(address string, metricsMux *http.ServeMux) *http.ServeMux {
 func Exit() {
 	_ = Handler.StopCPUProfile()
 	_ = Handler.StopGoTrace()
+	StopOtel()
+	StopProfileUploader()
 }
 
 // RaiseFdLimit raises out the number of allowed file handles per process
@@ -330,7 +441,7 @@ func SetFlagsFromConfigFile(ctx *cli.Context) error {
 		return nil
 	}
 
-	fileConfig, err := readConfigAsMap(filePath)
+	fileConfig, err := ReadConfigAsMap(filePath)
 	if err != nil {
 		return err
 	}
@@ -364,7 +475,7 @@ func SetCobraFlagsFromConfigFile(cmd *cobra.Command) error {
 		return nil
 	}
 
-	fileConfig, err := readConfigAsMap(filePath)
+	fileConfig, err := ReadConfigAsMap(filePath)
 	if err != nil {
 		return err
 	}
@@ -381,32 +492,7 @@ func SetCobraFlagsFromConfigFile(cmd *cobra.Command) error {
 	return nil
 }
 
-func readConfigAsMap(filePath string) (map[string]interface{}, error) {
-	fileExtension := filepath.Ext(filePath)
-
-	fileConfig := make(map[string]interface{})
-
-	if fileExtension == ".yaml" || fileExtension == ".yml" {
-		yamlFile, err := os.ReadFile(filePath)
-		if err != nil {
-			return fileConfig, err
-		}
-		err = yaml.Unmarshal(yamlFile, fileConfig)
-		if err != nil {
-			return fileConfig, err
-		}
-	} else if fileExtension == ".toml" {
-		tomlFile, err := os.ReadFile(filePath)
-		if err != nil {
-			return fileConfig, err
-		}
-		err = toml.Unmarshal(tomlFile, &fileConfig)
-		if err != nil {
-			return fileConfig, err
-		}
-	} else {
-		return fileConfig, errors.New("config files only accepted are .yaml, .yml, and .toml")
-	}
-
-	return fileConfig, nil
+// ReadConfigAsMap reads .toml/.yaml config file passed by --config
+func ReadConfigAsMap(filePath string) (map[string]interface{}, error) {
+	return cliflags.ReadConfigFile(filePath)
 }
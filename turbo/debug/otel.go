@@ -0,0 +1,78 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/params"
+)
+
+var otelProvider struct {
+	mu sync.Mutex
+	tp *sdktrace.TracerProvider
+}
+
+// StartOtel registers global OpenTelemetry tracer provider which exports spans to OTLP/HTTP collector at endpoint.
+func StartOtel(ctx context.Context, endpoint string, sampleRatio float64, logger log.Logger) error {
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName("erigon"),
+			semconv.ServiceVersion(params.VersionWithMeta),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	// W3C trace context: JSON-RPC `traceparent` header and gRPC metadata between rpcdaemon, kv server, txpool
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	otelProvider.mu.Lock()
+	defer otelProvider.mu.Unlock()
+	otelProvider.tp = tp
+	logger.Info("OpenTelemetry tracing started", "endpoint", endpoint, "sample", sampleRatio)
+	return nil
+}
+
+// StopOtel flushes not yet exported spans
+func StopOtel() {
+	otelProvider.mu.Lock()
+	defer otelProvider.mu.Unlock()
+	if otelProvider.tp == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := otelProvider.tp.Shutdown(ctx); err != nil {
+		log.Warn("OpenTelemetry shutdown", "err", err)
+	}
+	otelProvider.tp = nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// ProfileUploader periodically captures CPU and heap profiles and pushes them to
+// Pyroscope-compatible `/ingest` endpoint. CPU samples carry pprof labels set by
+// dbg.WithProfileLabels (stage, module, rpc method).
+type ProfileUploader struct {
+	endpoint string
+	app      string
+	labels   map[string]string
+	interval time.Duration
+	client   *http.Client
+	logger   log.Logger
+}
+
+func NewProfileUploader(endpoint, app string, labels map[string]string, interval time.Duration, logger log.Logger) *ProfileUploader {
+	return &ProfileUploader{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		app:      app,
+		labels:   labels,
+		interval: interval,
+		client:   &http.Client{Timeout: time.Minute},
+		logger:   logger,
+	}
+}
+
+var profileUploader struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// StartProfileUploader starts continuous profiling in background, until StopProfileUploader.
+func StartProfileUploader(ctx context.Context, endpoint, app, labels string, interval time.Duration, logger log.Logger) error {
+	parsed, err := ParseProfileLabels(labels)
+	if err != nil {
+		return err
+	}
+	if interval < time.Second {
+		return fmt.Errorf("profile upload interval is too short: %s", interval)
+	}
+	u := NewProfileUploader(endpoint, app, parsed, interval, logger)
+	dbg.EnableProfileLabels()
+
+	profileUploader.mu.Lock()
+	defer profileUploader.mu.Unlock()
+	ctx, profileUploader.cancel = context.WithCancel(ctx)
+	profileUploader.done = make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		u.Run(ctx)
+	}(profileUploader.done)
+	logger.Info("Continuous profiling started", "endpoint", endpoint, "app", app, "interval", interval)
+	return nil
+}
+
+// StopProfileUploader uploads the profile of current interval and stops the uploader
+func StopProfileUploader() {
+	profileUploader.mu.Lock()
+	defer profileUploader.mu.Unlock()
+	if profileUploader.cancel == nil {
+		return
+	}
+	profileUploader.cancel()
+	<-profileUploader.done
+	profileUploader.cancel = nil
+}
+
+// ParseProfileLabels parses `key1=value1,key2=value2`
+func ParseProfileLabels(s string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid profile label %q, expected key=value", kv)
+		}
+		labels[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return labels, nil
+}
+
+func (u *ProfileUploader) Run(ctx context.Context) {
+	for {
+		from := time.Now()
+		var cpu bytes.Buffer
+		// fails if CPU profile is already being collected (--pprof.cpuprofile, /debug/pprof/profile): skip CPU for this interval
+		cpuErr := pprof.StartCPUProfile(&cpu)
+		if cpuErr != nil {
+			u.logger.Debug("[pprof] skip CPU profile", "err", cpuErr)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(u.interval):
+		}
+		if cpuErr == nil {
+			pprof.StopCPUProfile()
+		}
+		until := time.Now()
+
+		// upload of the last interval doesn't depend on cancellation
+		uploadCtx, cancel := context.WithTimeout(context.Background(), u.client.Timeout)
+		if cpuErr == nil {
+			if err := u.Upload(uploadCtx, "cpu", from, until, &cpu); err != nil {
+				u.logger.Warn("[pprof] CPU profile upload failed", "err", err)
+			}
+		}
+		var heap bytes.Buffer
+		if err := pprof.Lookup("heap").WriteTo(&heap, 0); err == nil {
+			if err := u.Upload(uploadCtx, "alloc", from, until, &heap); err != nil {
+				u.logger.Warn("[pprof] heap profile upload failed", "err", err)
+			}
+		}
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// Upload sends pprof-encoded profile of the given kind (cpu, alloc) collected in [from, until)
+func (u *ProfileUploader) Upload(ctx context.Context, kind string, from, until time.Time, profile io.Reader) error {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile("profile", "profile.pprof")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(fw, profile); err != nil {
+		return err
+	}
+	if err = mw.Close(); err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	q.Set("name", u.appName(kind))
+	q.Set("from", strconv.FormatInt(from.Unix(), 10))
+	q.Set("until", strconv.FormatInt(until.Unix(), 10))
+	q.Set("format", "pprof")
+	q.Set("spyName", "gospy")
+	if kind == "cpu" {
+		q.Set("sampleRate", "100")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.endpoint+"/ingest?"+q.Encode(), &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// appName - Pyroscope application name with static labels: `erigon.cpu{chain=mainnet,host=a}`
+func (u *ProfileUploader) appName(kind string) string {
+	keys := make([]string, 0, len(u.labels))
+	for k := range u.labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(u.app)
+	sb.WriteByte('.')
+	sb.WriteString(kind)
+	sb.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(u.labels[k])
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
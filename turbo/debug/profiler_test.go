@@ -0,0 +1,65 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package debug
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestParseProfileLabels(t *testing.T) {
+	labels, err := ParseProfileLabels(" host=node1, chain=mainnet ,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"host": "node1", "chain": "mainnet"}, labels)
+
+	_, err = ParseProfileLabels("host")
+	require.Error(t, err)
+}
+
+func TestProfileUpload(t *testing.T) {
+	var name, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/ingest", r.URL.Path)
+		require.Equal(t, "pprof", r.URL.Query().Get("format"))
+		name = r.URL.Query().Get("name")
+		f, _, err := r.FormFile("profile")
+		require.NoError(t, err)
+		b, err := io.ReadAll(f)
+		require.NoError(t, err)
+		body = string(b)
+	}))
+	defer srv.Close()
+
+	u := NewProfileUploader(srv.URL+"/", "erigon", map[string]string{"host": "a", "chain": "mainnet"}, time.Minute, log.New())
+	require.NoError(t, u.Upload(context.Background(), "cpu", time.Now().Add(-time.Minute), time.Now(), strings.NewReader("pprof")))
+	require.Equal(t, "erigon.cpu{chain=mainnet,host=a}", name)
+	require.Equal(t, "pprof", body)
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unknown app", http.StatusBadRequest)
+	})
+	require.ErrorContains(t, u.Upload(context.Background(), "cpu", time.Now(), time.Now(), strings.NewReader("pprof")), "unknown app")
+}
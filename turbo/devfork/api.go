@@ -0,0 +1,270 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devfork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/eth/ethutils"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+var errHistoricalState = errors.New("dev fork: only state of latest block is available")
+
+// EthAPI - subset of eth_ namespace needed by contract development tools (wallets, Foundry, Hardhat)
+type EthAPI struct {
+	chain  *Chain
+	gasCap uint64
+}
+
+// DevAPI - cheat-codes, in dev_ namespace
+type DevAPI struct {
+	chain *Chain
+}
+
+type NetAPI struct {
+	chain *Chain
+}
+
+// NewServer - JSON-RPC server with eth_, net_ and dev_ namespaces, to be served by http.Server
+func NewServer(c *Chain, gasCap uint64, logger log.Logger) (*rpc.Server, error) {
+	srv := rpc.NewServer(50, false /* traceRequests */, false /* debugSingleRequest */, true /* disableStreaming */, logger, 0)
+	for name, api := range map[string]interface{}{
+		"eth": &EthAPI{chain: c, gasCap: gasCap},
+		"net": &NetAPI{chain: c},
+		"dev": &DevAPI{chain: c},
+	} {
+		if err := srv.RegisterName(name, api); err != nil {
+			return nil, err
+		}
+	}
+	return srv, nil
+}
+
+func (api *NetAPI) Version() string { return api.chain.Config().ChainID.String() }
+
+func (api *EthAPI) ChainId() *hexutil.Big { return (*hexutil.Big)(api.chain.Config().ChainID) }
+func (api *EthAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.chain.Head().Number.Uint64())
+}
+
+func (api *EthAPI) GasPrice() *hexutil.Big {
+	price := new(big.Int)
+	if baseFee := api.chain.nextBaseFee(); baseFee != nil {
+		price.Set(baseFee)
+	}
+	return (*hexutil.Big)(price.Add(price, big.NewInt(1)))
+}
+
+func (api *EthAPI) MaxPriorityFeePerGas() *hexutil.Big { return (*hexutil.Big)(big.NewInt(1)) }
+
+// checkLatest - mined blocks have no history, so only latest state is available
+func (api *EthAPI) checkLatest(blockNrOrHash *rpc.BlockNumberOrHash) error {
+	if blockNrOrHash == nil {
+		return nil
+	}
+	head := api.chain.Head()
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		if hash != head.Hash() {
+			return errHistoricalState
+		}
+		return nil
+	}
+	number, _ := blockNrOrHash.Number()
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber, rpc.LatestExecutedBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		return nil
+	}
+	if uint64(number) != head.Number.Uint64() {
+		return errHistoricalState
+	}
+	return nil
+}
+
+func (api *EthAPI) GetBalance(_ context.Context, addr common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	if err := api.checkLatest(blockNrOrHash); err != nil {
+		return nil, err
+	}
+	balance, err := api.chain.State().GetBalance(addr)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance.ToBig()), nil
+}
+
+func (api *EthAPI) GetTransactionCount(_ context.Context, addr common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*hexutil.Uint64, error) {
+	if err := api.checkLatest(blockNrOrHash); err != nil {
+		return nil, err
+	}
+	nonce, err := api.chain.State().GetNonce(addr)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+func (api *EthAPI) GetCode(_ context.Context, addr common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if err := api.checkLatest(blockNrOrHash); err != nil {
+		return nil, err
+	}
+	code, err := api.chain.State().GetCode(addr)
+	if err != nil {
+		return nil, err
+	}
+	return common.Copy(code), nil
+}
+
+func (api *EthAPI) GetStorageAt(_ context.Context, addr common.Address, slot string, blockNrOrHash *rpc.BlockNumberOrHash) (string, error) {
+	if err := api.checkLatest(blockNrOrHash); err != nil {
+		return "", err
+	}
+	key, err := hexutil.Decode(slot)
+	if err != nil {
+		if key, err = hexutil.Decode("0x0" + slot[2:]); err != nil {
+			return "", fmt.Errorf("invalid storage slot %q: %w", slot, err)
+		}
+	}
+	location := common.BytesToHash(key)
+	var v uint256.Int
+	if err := api.chain.State().GetState(addr, &location, &v); err != nil {
+		return "", err
+	}
+	return hexutil.Encode(common.LeftPadBytes(v.Bytes(), 32)), nil
+}
+
+func (api *EthAPI) Call(_ context.Context, args ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if err := api.checkLatest(blockNrOrHash); err != nil {
+		return nil, err
+	}
+	msg, err := args.ToMessage(api.gasCap, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := api.chain.Call(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Revert()) > 0 {
+		return nil, ethapi.NewRevertError(res)
+	}
+	return res.Return(), res.Err
+}
+
+// EstimateGas - binary search of lowest gas limit with which call doesn't fail
+func (api *EthAPI) EstimateGas(_ context.Context, args ethapi.CallArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	if err := api.checkLatest(blockNrOrHash); err != nil {
+		return 0, err
+	}
+	hi := api.chain.Head().GasLimit
+	if args.Gas != nil && uint64(*args.Gas) < hi {
+		hi = uint64(*args.Gas)
+	}
+	run := func(gas uint64) (*evmtypes.ExecutionResult, error) {
+		args.Gas = (*hexutil.Uint64)(&gas)
+		msg, err := args.ToMessage(api.gasCap, nil)
+		if err != nil {
+			return nil, err
+		}
+		return api.chain.Call(msg)
+	}
+	res, err := run(hi)
+	if err != nil {
+		return 0, err
+	}
+	if res.Failed() {
+		if len(res.Revert()) > 0 {
+			return 0, ethapi.NewRevertError(res)
+		}
+		return 0, fmt.Errorf("gas required exceeds allowance (%d)", hi)
+	}
+	lo := uint64(0)
+	for lo+1 < hi {
+		mid := (lo + hi) / 2
+		if res, err := run(mid); err == nil && !res.Failed() { // err - intrinsic gas too low, etc.
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hexutil.Uint64(hi), nil
+}
+
+func (api *EthAPI) SendRawTransaction(_ context.Context, encodedTx hexutil.Bytes) (common.Hash, error) {
+	txn, err := types.DecodeWrappedTransaction(encodedTx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := api.chain.Mine(txn); err != nil {
+		return common.Hash{}, err
+	}
+	return txn.Hash(), nil
+}
+
+func (api *EthAPI) GetTransactionReceipt(_ context.Context, hash common.Hash) (map[string]interface{}, error) {
+	txn, receipt, block := api.chain.Transaction(hash)
+	if txn == nil {
+		return nil, nil
+	}
+	return ethutils.MarshalReceipt(receipt, txn, api.chain.Config(), block.HeaderNoCopy(), hash, true), nil
+}
+
+func (api *EthAPI) GetTransactionByHash(_ context.Context, hash common.Hash) (*ethapi.RPCTransaction, error) {
+	txn, _, block := api.chain.Transaction(hash)
+	if txn == nil {
+		return nil, nil
+	}
+	return ethapi.NewRPCTransaction(txn, block.Hash(), block.NumberU64(), 0, block.BaseFee()), nil
+}
+
+func (api *EthAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	n := api.chain.Head().Number.Uint64()
+	if number >= 0 {
+		n = uint64(number)
+	}
+	block, err := api.chain.BlockByNumber(ctx, n)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return ethapi.RPCMarshalBlock(block, true, fullTx, nil)
+}
+
+func (api *EthAPI) GetBlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	block, err := api.chain.BlockByHash(ctx, hash)
+	if err != nil || block == nil {
+		return nil, err
+	}
+	return ethapi.RPCMarshalBlock(block, true, fullTx, nil)
+}
+
+func (api *DevAPI) SetBalance(addr common.Address, balance hexutil.Big) error {
+	v, overflow := uint256.FromBig(balance.ToInt())
+	if overflow {
+		return errors.New("balance higher than 2^256-1")
+	}
+	return api.chain.SetBalance(addr, v)
+}
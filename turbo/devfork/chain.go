@@ -0,0 +1,271 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devfork
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/execution/consensus/misc"
+)
+
+// Chain - instant-mining dev chain on top of forked state: every transaction is mined into own block.
+//
+// Simplifications comparing to real chain: state root of mined blocks is not computed (it's zero),
+// system calls (EIP-4788, EIP-2935, EIP-7002, EIP-7251) are not executed, there are no withdrawals and no rewards.
+// Mined blocks live only in memory.
+type Chain struct {
+	config   *chain.Config
+	source   Source
+	state    *forkState
+	coinbase common.Address
+
+	lock      sync.Mutex
+	forkBlock *types.Header
+	blocks    []*types.Block // mined after fork block, blocks[i].Number = forkBlock.Number + i + 1
+	receipts  []types.Receipts
+	byHash    map[common.Hash]uint64 // mined block hash -> number
+	txs       map[common.Hash]uint64 // mined txn hash -> block number
+	headers   map[uint64]*types.Header
+}
+
+func NewChain(ctx context.Context, config *chain.Config, source Source, forkBlock uint64, coinbase common.Address) (*Chain, error) {
+	header, err := source.Header(ctx, forkBlock)
+	if err != nil {
+		return nil, fmt.Errorf("fork block: %w", err)
+	}
+	return &Chain{
+		config:    config,
+		source:    source,
+		state:     newForkState(ctx, source),
+		coinbase:  coinbase,
+		forkBlock: header,
+		byHash:    map[common.Hash]uint64{},
+		txs:       map[common.Hash]uint64{},
+		headers:   map[uint64]*types.Header{forkBlock: header},
+	}, nil
+}
+
+func (c *Chain) Config() *chain.Config { return c.config }
+func (c *Chain) ForkBlock() uint64     { return c.forkBlock.Number.Uint64() }
+
+func (c *Chain) Head() *types.Header {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.head()
+}
+
+func (c *Chain) head() *types.Header {
+	if len(c.blocks) == 0 {
+		return c.forkBlock
+	}
+	return c.blocks[len(c.blocks)-1].HeaderNoCopy()
+}
+
+// BlockByNumber - for fork block and blocks before it: block with header only (transactions are not faulted)
+func (c *Chain) BlockByNumber(ctx context.Context, number uint64) (*types.Block, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if number > c.ForkBlock() {
+		i := number - c.ForkBlock() - 1
+		if i >= uint64(len(c.blocks)) {
+			return nil, nil
+		}
+		return c.blocks[i], nil
+	}
+	header, err := c.header(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(header), nil
+}
+
+func (c *Chain) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	c.lock.Lock()
+	number, ok := c.byHash[hash]
+	forked := c.forkBlock.Hash() == hash
+	c.lock.Unlock()
+	if forked {
+		return types.NewBlockWithHeader(c.forkBlock), nil
+	}
+	if !ok {
+		return nil, nil
+	}
+	return c.BlockByNumber(ctx, number)
+}
+
+// Transaction - mined transaction with its receipt and block
+func (c *Chain) Transaction(hash common.Hash) (types.Transaction, *types.Receipt, *types.Block) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	number, ok := c.txs[hash]
+	if !ok {
+		return nil, nil, nil
+	}
+	i := number - c.ForkBlock() - 1
+	return c.blocks[i].Transactions()[0], c.receipts[i][0], c.blocks[i]
+}
+
+func (c *Chain) header(ctx context.Context, number uint64) (*types.Header, error) {
+	if h, ok := c.headers[number]; ok {
+		return h, nil
+	}
+	h, err := c.source.Header(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	c.headers[number] = h
+	return h, nil
+}
+
+// blockHash - for BLOCKHASH opcode. Called with c.lock held.
+func (c *Chain) blockHash(n uint64) common.Hash {
+	if n > c.ForkBlock() {
+		if i := n - c.ForkBlock() - 1; i < uint64(len(c.blocks)) {
+			return c.blocks[i].Hash()
+		}
+		return common.Hash{}
+	}
+	h, err := c.header(c.state.ctx, n)
+	if err != nil {
+		return common.Hash{}
+	}
+	return h.Hash()
+}
+
+func (c *Chain) nextHeader(parent *types.Header) *types.Header {
+	number := parent.Number.Uint64() + 1
+	t := uint64(time.Now().Unix())
+	if t <= parent.Time {
+		t = parent.Time + 1
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   c.coinbase,
+		Number:     new(big.Int).SetUint64(number),
+		GasLimit:   parent.GasLimit,
+		Time:       t,
+		Difficulty: big.NewInt(0),
+		UncleHash:  types.EmptyUncleHash,
+	}
+	if c.config.IsLondon(number) {
+		header.BaseFee = misc.CalcBaseFee(c.config, parent)
+	}
+	if c.config.IsCancun(t) {
+		excessBlobGas := misc.CalcExcessBlobGas(c.config, parent, t)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+		header.ParentBeaconBlockRoot = &common.Hash{}
+	}
+	if c.config.IsPrague(t) {
+		header.RequestsHash = &types.EmptyRequestsHash
+	}
+	return header
+}
+
+// Mine - executes txn in new block on top of head. Invalid txn (bad nonce, not enough balance, etc.) is not mined.
+func (c *Chain) Mine(txn types.Transaction) (*types.Receipt, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	parent := c.head()
+	header := c.nextHeader(parent)
+
+	ibs := state.New(c.state)
+	ibs.SetTxContext(0)
+	gp := new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(c.config.GetMaxBlobGasPerBlock(header.Time))
+	var usedGas, usedBlobGas uint64
+	receipt, _, err := core.ApplyTransaction(c.config, c.blockHash, nil, &c.coinbase, gp, ibs, c.state, header, txn, &usedGas, &usedBlobGas, vm.Config{})
+	if err != nil {
+		return nil, err
+	}
+	header.GasUsed = usedGas
+	var withdrawals []*types.Withdrawal
+	if c.config.IsShanghai(header.Time) {
+		withdrawals = []*types.Withdrawal{}
+	}
+	if header.BlobGasUsed != nil {
+		header.BlobGasUsed = &usedBlobGas
+	}
+	block := types.NewBlock(header, []types.Transaction{txn}, nil, []*types.Receipt{receipt}, withdrawals)
+
+	// logs were created before block hash was known
+	receipt.BlockHash = block.Hash()
+	for _, l := range receipt.Logs {
+		l.BlockHash = block.Hash()
+	}
+	c.blocks = append(c.blocks, block)
+	c.receipts = append(c.receipts, types.Receipts{receipt})
+	c.byHash[block.Hash()] = block.NumberU64()
+	c.txs[txn.Hash()] = block.NumberU64()
+	return receipt, nil
+}
+
+// Call - executes msg on top of head without mining. State changes are discarded.
+func (c *Chain) Call(msg core.Message) (*evmtypes.ExecutionResult, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	header := c.nextHeader(c.head())
+	ibs := state.New(c.state)
+	blockCtx := core.NewEVMBlockContext(header, c.blockHash, nil, &c.coinbase, c.config)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, c.config, vm.Config{NoBaseFee: true})
+	gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+	return core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */, nil)
+}
+
+// State - reads state of head
+func (c *Chain) State() *state.IntraBlockState {
+	return state.New(c.state)
+}
+
+// SetBalance - cheat-code for dev accounts. Changes state of head without mining.
+func (c *Chain) SetBalance(addr common.Address, balance *uint256.Int) error {
+	if balance == nil {
+		return errors.New("nil balance")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	acc, err := c.state.ReadAccountData(addr)
+	if err != nil {
+		return err
+	}
+	if acc == nil {
+		a := accounts.NewAccount()
+		a.Initialised = true
+		acc = &a
+	}
+	acc.Balance = *balance
+	return c.state.UpdateAccountData(addr, nil, acc)
+}
+
+// nextBaseFee - base fee of block which will be mined next, nil before London
+func (c *Chain) nextBaseFee() *big.Int {
+	return c.nextHeader(c.Head()).BaseFee
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devfork
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	params2 "github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/ethapi"
+)
+
+// memSource - forked chain in memory, counts faults
+type memSource struct {
+	accounts map[common.Address]*accounts.Account
+	code     map[common.Address][]byte
+	storage  map[storageKey]uint256.Int
+	faults   int
+}
+
+func (s *memSource) Account(_ context.Context, addr common.Address) (*accounts.Account, error) {
+	s.faults++
+	return s.accounts[addr], nil
+}
+func (s *memSource) Storage(_ context.Context, addr common.Address, slot common.Hash) (uint256.Int, error) {
+	s.faults++
+	return s.storage[storageKey{addr, slot}], nil
+}
+func (s *memSource) Code(_ context.Context, addr common.Address) ([]byte, error) {
+	s.faults++
+	return s.code[addr], nil
+}
+func (s *memSource) Header(_ context.Context, number uint64) (*types.Header, error) {
+	if number > 100 {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(number), GasLimit: 30_000_000, Difficulty: big.NewInt(0), BaseFee: big.NewInt(common.GWei), Time: 1000 + number}, nil
+}
+
+func TestDevFork(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	key, _ := crypto.GenerateKey()
+	sender, to, contract := crypto.PubkeyToAddress(key.PublicKey), common.Address{1}, common.Address{2}
+
+	senderAcc := accounts.NewAccount()
+	senderAcc.Initialised = true
+	senderAcc.Balance = *uint256.NewInt(common.Ether)
+	// returns storage slot 0: PUSH1 0 SLOAD PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	code := common.FromHex("60005460005260206000f3")
+	contractAcc := accounts.NewAccount()
+	contractAcc.Initialised = true
+	contractAcc.CodeHash, contractAcc.Incarnation = crypto.Keccak256Hash(code), 1
+	source := &memSource{
+		accounts: map[common.Address]*accounts.Account{sender: &senderAcc, contract: &contractAcc},
+		code:     map[common.Address][]byte{contract: code},
+		storage:  map[storageKey]uint256.Int{{contract, common.Hash{}}: *uint256.NewInt(42)},
+	}
+	c, err := NewChain(ctx, params.AllProtocolChanges, source, 100, common.Address{})
+	require.NoError(err)
+	api := &EthAPI{chain: c}
+
+	// call faults contract state lazily, and only once
+	res, err := api.Call(ctx, ethapi.CallArgs{To: &contract}, nil)
+	require.NoError(err)
+	require.Equal(uint64(42), new(uint256.Int).SetBytes(res).Uint64())
+	faults := source.faults
+	_, err = api.Call(ctx, ethapi.CallArgs{To: &contract}, nil)
+	require.NoError(err)
+	require.Equal(faults, source.faults)
+
+	// instant mining: one block per txn
+	signer := types.LatestSignerForChainID(params.AllProtocolChanges.ChainID)
+	txn, err := types.SignTx(types.NewTransaction(0, to, uint256.NewInt(1000), params2.TxGas, uint256.NewInt(10*common.GWei), nil), *signer, key)
+	require.NoError(err)
+	raw, err := types.MarshalTransactionsBinary([]types.Transaction{txn})
+	require.NoError(err)
+	hash, err := api.SendRawTransaction(ctx, raw[0])
+	require.NoError(err)
+	require.Equal(txn.Hash(), hash)
+	require.Equal(hexutil.Uint64(101), api.BlockNumber())
+
+	receipt, err := api.GetTransactionReceipt(ctx, hash)
+	require.NoError(err)
+	require.Equal(hexutil.Uint64(types.ReceiptStatusSuccessful), receipt["status"])
+	require.Equal(hexutil.Uint64(params2.TxGas), receipt["gasUsed"])
+	block, err := api.GetBlockByNumber(ctx, 101, false)
+	require.NoError(err)
+	require.Equal(receipt["blockHash"], block["hash"])
+
+	balance, err := api.GetBalance(ctx, to, nil)
+	require.NoError(err)
+	require.Equal(uint64(1000), balance.ToInt().Uint64())
+	nonce, err := api.GetTransactionCount(ctx, sender, nil)
+	require.NoError(err)
+	require.Equal(hexutil.Uint64(1), *nonce)
+
+	// same nonce again - rejected, not mined
+	_, err = api.SendRawTransaction(ctx, raw[0])
+	require.Error(err)
+	require.Equal(hexutil.Uint64(101), api.BlockNumber())
+
+	gas, err := api.EstimateGas(ctx, ethapi.CallArgs{From: &sender, To: &to}, nil)
+	require.NoError(err)
+	require.Equal(hexutil.Uint64(params2.TxGas), gas)
+
+	// only latest state
+	old := rpcBlock(100)
+	_, err = api.GetBalance(ctx, to, &old)
+	require.ErrorIs(err, errHistoricalState)
+}
+
+func rpcBlock(n int64) rpc.BlockNumberOrHash {
+	return rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(n))
+}
+
+// fork of fork: RPCSource faults state from dev chain served over HTTP
+func TestRPCSource(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	addr := common.Address{1}
+	acc := accounts.NewAccount()
+	acc.Initialised, acc.Nonce, acc.Balance = true, 3, *uint256.NewInt(7)
+	source := &memSource{accounts: map[common.Address]*accounts.Account{addr: &acc}, storage: map[storageKey]uint256.Int{{addr, common.Hash{1}}: *uint256.NewInt(5)}}
+	upstream, err := NewChain(ctx, params.AllProtocolChanges, source, 100, common.Address{})
+	require.NoError(err)
+	srv, err := NewServer(upstream, 0, log.New())
+	require.NoError(err)
+	defer srv.Stop()
+	httpSrv := httptest.NewServer(srv)
+	defer httpSrv.Close()
+
+	client, err := rpc.DialContext(ctx, httpSrv.URL, log.New())
+	require.NoError(err)
+	defer client.Close()
+	chainID, latest, err := RPCChainInfo(ctx, client)
+	require.NoError(err)
+	require.Equal(params.AllProtocolChanges.ChainID, chainID)
+	require.Equal(uint64(100), latest)
+
+	c, err := NewChain(ctx, params.AllProtocolChanges, NewRPCSource(client, latest), latest, common.Address{})
+	require.NoError(err)
+	require.Equal(upstream.Head().Hash(), c.Head().Hash())
+	ibs := c.State()
+	nonce, err := ibs.GetNonce(addr)
+	require.NoError(err)
+	require.Equal(uint64(3), nonce)
+	balance, err := ibs.GetBalance(addr)
+	require.NoError(err)
+	require.Equal(uint64(7), balance.Uint64())
+	var v uint256.Int
+	require.NoError(ibs.GetState(addr, &common.Hash{1}, &v))
+	require.Equal(uint64(5), v.Uint64())
+	exist, err := ibs.Exist(common.Address{9})
+	require.NoError(err)
+	require.False(exist)
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package devfork - Anvil-style dev chain forked from another chain at given block.
+// State is faulted lazily from Source (remote RPC node or local datadir) and cached, txns are mined instantly
+// by Erigon's EVM - one block per txn.
+package devfork
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+// Source - state and headers of forked chain as of fork block. Calls are serialized by caller.
+type Source interface {
+	Account(ctx context.Context, addr common.Address) (*accounts.Account, error) // nil - account doesn't exist
+	Storage(ctx context.Context, addr common.Address, slot common.Hash) (uint256.Int, error)
+	Code(ctx context.Context, addr common.Address) ([]byte, error)
+	Header(ctx context.Context, number uint64) (*types.Header, error) // number <= fork block
+}
+
+// RPCSource - faults state from JSON-RPC node, which must have state of fork block (archive node for old blocks).
+type RPCSource struct {
+	client *rpc.Client
+	block  string
+
+	codeLock sync.Mutex
+	code     map[common.Address][]byte // code is fetched together with account
+}
+
+func NewRPCSource(client *rpc.Client, block uint64) *RPCSource {
+	return &RPCSource{client: client, block: hexutil.EncodeUint64(block), code: map[common.Address][]byte{}}
+}
+
+func (s *RPCSource) Account(ctx context.Context, addr common.Address) (*accounts.Account, error) {
+	var (
+		balance hexutil.Big
+		nonce   hexutil.Uint64
+		code    hexutil.Bytes
+	)
+	batch := []rpc.BatchElem{
+		{Method: "eth_getBalance", Args: []interface{}{addr, s.block}, Result: &balance},
+		{Method: "eth_getTransactionCount", Args: []interface{}{addr, s.block}, Result: &nonce},
+		{Method: "eth_getCode", Args: []interface{}{addr, s.block}, Result: &code},
+	}
+	if err := s.client.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+	for _, e := range batch {
+		if e.Error != nil {
+			return nil, fmt.Errorf("%s(%x): %w", e.Method, addr, e.Error)
+		}
+	}
+	s.codeLock.Lock()
+	s.code[addr] = code
+	s.codeLock.Unlock()
+
+	if balance.ToInt().Sign() == 0 && nonce == 0 && len(code) == 0 {
+		return nil, nil
+	}
+	acc := accounts.NewAccount()
+	acc.Initialised = true
+	acc.Nonce = uint64(nonce)
+	acc.Balance.SetFromBig(balance.ToInt())
+	if len(code) > 0 {
+		acc.CodeHash = crypto.Keccak256Hash(code)
+		acc.Incarnation = state.FirstContractIncarnation
+	}
+	return &acc, nil
+}
+
+func (s *RPCSource) Storage(ctx context.Context, addr common.Address, slot common.Hash) (uint256.Int, error) {
+	var v common.Hash
+	if err := s.client.CallContext(ctx, &v, "eth_getStorageAt", addr, slot, s.block); err != nil {
+		return uint256.Int{}, err
+	}
+	var res uint256.Int
+	res.SetBytes32(v[:])
+	return res, nil
+}
+
+func (s *RPCSource) Code(ctx context.Context, addr common.Address) ([]byte, error) {
+	s.codeLock.Lock()
+	code, ok := s.code[addr]
+	s.codeLock.Unlock()
+	if ok {
+		return code, nil
+	}
+	var res hexutil.Bytes
+	if err := s.client.CallContext(ctx, &res, "eth_getCode", addr, s.block); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *RPCSource) Header(ctx context.Context, number uint64) (*types.Header, error) {
+	var h *types.Header
+	if err := s.client.CallContext(ctx, &h, "eth_getBlockByNumber", hexutil.EncodeUint64(number), false); err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return h, nil
+}
+
+// RPCChainInfo - chain id and latest block of remote node
+func RPCChainInfo(ctx context.Context, client *rpc.Client) (chainID *big.Int, latest uint64, err error) {
+	var id hexutil.Big
+	var num hexutil.Uint64
+	if err := client.CallContext(ctx, &id, "eth_chainId"); err != nil {
+		return nil, 0, err
+	}
+	if err := client.CallContext(ctx, &num, "eth_blockNumber"); err != nil {
+		return nil, 0, err
+	}
+	return id.ToInt(), uint64(num), nil
+}
+
+// LocalSource - faults state from history of local datadir (node must not be running, or datadir must be a copy).
+type LocalSource struct {
+	tx          kv.TemporalTx
+	reader      *state.HistoryReaderV3
+	blockReader services.FullBlockReader
+}
+
+// NewLocalSource - txNum: first txNum of block after fork block (state after fork block execution)
+func NewLocalSource(tx kv.TemporalTx, txNum uint64, blockReader services.FullBlockReader) *LocalSource {
+	reader := state.NewHistoryReaderV3()
+	reader.SetTx(tx)
+	reader.SetTxNum(txNum)
+	return &LocalSource{tx: tx, reader: reader, blockReader: blockReader}
+}
+
+func (s *LocalSource) Account(_ context.Context, addr common.Address) (*accounts.Account, error) {
+	return s.reader.ReadAccountData(addr)
+}
+
+func (s *LocalSource) Storage(_ context.Context, addr common.Address, slot common.Hash) (uint256.Int, error) {
+	var res uint256.Int
+	v, err := s.reader.ReadAccountStorage(addr, &slot)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(v)
+	return res, nil
+}
+
+func (s *LocalSource) Code(_ context.Context, addr common.Address) ([]byte, error) {
+	return s.reader.ReadAccountCode(addr)
+}
+
+func (s *LocalSource) Header(ctx context.Context, number uint64) (*types.Header, error) {
+	h, err := s.blockReader.HeaderByNumber(ctx, s.tx, number)
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return h, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package devfork
+
+import (
+	"context"
+	"sync"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/core/state"
+)
+
+type storageKey struct {
+	addr common.Address
+	slot common.Hash
+}
+
+// forkState - in-memory overlay of mined blocks on top of Source. Everything read from Source is cached,
+// so every account/slot is faulted at most once.
+type forkState struct {
+	ctx    context.Context
+	source Source
+
+	lock     sync.Mutex
+	accounts map[common.Address]*accounts.Account // nil value - account doesn't exist
+	code     map[common.Address][]byte
+	storage  map[storageKey]uint256.Int
+	cleared  map[common.Address]struct{} // self-destructed or re-created: storage must not be faulted from Source
+}
+
+var (
+	_ state.StateReader = (*forkState)(nil)
+	_ state.StateWriter = (*forkState)(nil)
+)
+
+func newForkState(ctx context.Context, source Source) *forkState {
+	return &forkState{
+		ctx:      ctx,
+		source:   source,
+		accounts: map[common.Address]*accounts.Account{},
+		code:     map[common.Address][]byte{},
+		storage:  map[storageKey]uint256.Int{},
+		cleared:  map[common.Address]struct{}{},
+	}
+}
+
+func (s *forkState) ReadAccountData(addr common.Address) (*accounts.Account, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	acc, ok := s.accounts[addr]
+	if !ok {
+		var err error
+		if acc, err = s.source.Account(s.ctx, addr); err != nil {
+			return nil, err
+		}
+		s.accounts[addr] = acc
+	}
+	if acc == nil {
+		return nil, nil
+	}
+	cpy := *acc
+	return &cpy, nil
+}
+
+func (s *forkState) ReadAccountDataForDebug(addr common.Address) (*accounts.Account, error) {
+	return s.ReadAccountData(addr)
+}
+
+func (s *forkState) ReadAccountStorage(addr common.Address, key *common.Hash) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	k := storageKey{addr, *key}
+	v, ok := s.storage[k]
+	if !ok {
+		if _, cleared := s.cleared[addr]; !cleared {
+			var err error
+			if v, err = s.source.Storage(s.ctx, addr, *key); err != nil {
+				return nil, err
+			}
+		}
+		s.storage[k] = v
+	}
+	if v.IsZero() {
+		return nil, nil
+	}
+	return v.Bytes(), nil
+}
+
+func (s *forkState) ReadAccountCode(addr common.Address) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	code, ok := s.code[addr]
+	if !ok {
+		var err error
+		if code, err = s.source.Code(s.ctx, addr); err != nil {
+			return nil, err
+		}
+		s.code[addr] = code
+	}
+	return code, nil
+}
+
+func (s *forkState) ReadAccountCodeSize(addr common.Address) (int, error) {
+	code, err := s.ReadAccountCode(addr)
+	return len(code), err
+}
+
+func (s *forkState) ReadAccountIncarnation(addr common.Address) (uint64, error) {
+	acc, err := s.ReadAccountData(addr)
+	if err != nil || acc == nil {
+		return 0, err
+	}
+	return acc.Incarnation, nil
+}
+
+func (s *forkState) UpdateAccountData(addr common.Address, _, account *accounts.Account) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	cpy := *account
+	s.accounts[addr] = &cpy
+	return nil
+}
+
+func (s *forkState) UpdateAccountCode(addr common.Address, _ uint64, _ common.Hash, code []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.code[addr] = common.Copy(code)
+	return nil
+}
+
+func (s *forkState) DeleteAccount(addr common.Address, _ *accounts.Account) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.accounts[addr] = nil
+	s.code[addr] = nil
+	s.clearStorage(addr)
+	return nil
+}
+
+func (s *forkState) WriteAccountStorage(addr common.Address, _ uint64, key *common.Hash, _, value *uint256.Int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.storage[storageKey{addr, *key}] = *value
+	return nil
+}
+
+func (s *forkState) CreateContract(addr common.Address) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.clearStorage(addr)
+	return nil
+}
+
+func (s *forkState) clearStorage(addr common.Address) {
+	for k := range s.storage {
+		if k.addr == addr {
+			delete(s.storage, k)
+		}
+	}
+	s.cleared[addr] = struct{}{}
+}
@@ -28,6 +28,7 @@ var ourCapabilities = []string{
 	"engine_getPayloadBodiesByRangeV1",
 	"engine_getClientVersionV1",
 	"engine_getBlobsV1",
+	"engine_signalSuperchainV1",
 }
 
 // Returns the most recent version of the payload(for the payloadID) at the time of receiving the call
@@ -156,6 +157,25 @@ func (e *EngineServer) GetClientVersionV1(ctx context.Context, callerVersion *en
 	return result, nil
 }
 
+// opStackSupport - latest OP Stack protocol version which execution rules are implemented (Ecotone)
+var opStackSupport = engine_types.NewProtocolVersionV0([8]byte{}, 6, 0, 0, 0)
+
+// Receives protocol versions of OP Stack superchain from op-node and returns the supported one
+// See https://specs.optimism.io/protocol/exec-engine.html#engine_signalsuperchainv1
+func (e *EngineServer) SignalSuperchainV1(ctx context.Context, signal *engine_types.SuperchainSignal) (engine_types.ProtocolVersion, error) {
+	if signal == nil {
+		return opStackSupport, nil
+	}
+	if signal.Required.Major() > opStackSupport.Major() {
+		e.logger.Error("[SignalSuperchainV1] Required OP Stack protocol version is not supported, upgrade is needed",
+			"required", signal.Required, "supported", opStackSupport)
+	} else if signal.Recommended.Major() > opStackSupport.Major() {
+		e.logger.Warn("[SignalSuperchainV1] Recommended OP Stack protocol version is not supported",
+			"recommended", signal.Recommended, "supported", opStackSupport)
+	}
+	return opStackSupport, nil
+}
+
 func (e *EngineServer) ExchangeCapabilities(fromCl []string) []string {
 	e.engineLogSpamer.RecordRequest()
 	missingOurs := compareCapabilities(fromCl, ourCapabilities)
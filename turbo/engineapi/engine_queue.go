@@ -0,0 +1,144 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// requestClass - priority class of engine API request, smaller is more urgent
+type requestClass int
+
+const (
+	getPayloadClass        requestClass = iota // proposal of co-located validator: has deadline of slot
+	forkchoiceUpdatedClass                     // head updates and start of payload building
+	newPayloadClass                            // validation of blocks: comes in bursts during sync
+	requestClassesCount
+)
+
+func (c requestClass) String() string {
+	return [requestClassesCount]string{"getPayload", "forkchoiceUpdated", "newPayload"}[c]
+}
+
+// queueTimeouts - budget of waiting in queue of each class, zero means no budget. Half of CL timeouts: to leave
+// time for processing. getPayload waits for its turn without budget: it's first in queue anyway, and giving up
+// would make co-located validator miss the slot when processing of request ahead takes long.
+// newPayload and forkchoiceUpdated out of budget are answered with SYNCING (see syncingIfQueueTimeout): CL
+// treats it as EL falling behind and retries later, instead of own timeout error
+// https://github.com/ethereum/execution-apis/blob/main/src/engine/paris.md#timeouts
+var queueTimeouts = [requestClassesCount]time.Duration{0, 4 * time.Second, 4 * time.Second}
+
+var errQueueTimeout = errors.New("engine API request waited in queue too long")
+
+var (
+	mxQueueWait     [requestClassesCount]metrics.Summary
+	mxQueueTimeouts [requestClassesCount]metrics.Counter
+)
+
+func init() {
+	for c := requestClass(0); c < requestClassesCount; c++ {
+		mxQueueWait[c] = metrics.GetOrCreateSummary(fmt.Sprintf(`engine_queue_wait_seconds{method="%s"}`, c))
+		mxQueueTimeouts[c] = metrics.GetOrCreateCounter(fmt.Sprintf(`engine_queue_timeouts{method="%s"}`, c))
+	}
+}
+
+// engineQueue - engine API requests are processed one at a time. Waiting requests are admitted by priority of
+// their class and in order of arrival within class: burst of newPayload during sync can't starve getPayload and
+// forkchoiceUpdated of a proposing validator.
+type engineQueue struct {
+	mu      sync.Mutex
+	busy    bool
+	waiting [requestClassesCount][]chan struct{}
+}
+
+// acquire - waits for turn of request within timeout budget of its class. Returns errQueueTimeout if budget is over.
+// Caller must call release after processing.
+func (q *engineQueue) acquire(ctx context.Context, class requestClass) error {
+	start := time.Now()
+	defer mxQueueWait[class].ObserveDuration(start)
+
+	q.mu.Lock()
+	if !q.busy {
+		q.busy = true
+		q.mu.Unlock()
+		return nil
+	}
+	turn := make(chan struct{})
+	q.waiting[class] = append(q.waiting[class], turn)
+	q.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if queueTimeouts[class] > 0 {
+		timer := time.NewTimer(queueTimeouts[class])
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	var err error
+	select {
+	case <-turn:
+		return nil
+	case <-timeout:
+		err = errQueueTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	i := slices.Index(q.waiting[class], turn)
+	if i < 0 { // got turn at the same time
+		return nil
+	}
+	q.waiting[class] = slices.Delete(q.waiting[class], i, i+1)
+	if errors.Is(err, errQueueTimeout) {
+		mxQueueTimeouts[class].Inc()
+	}
+	return err
+}
+
+// release - passes turn to most urgent waiting request
+func (q *engineQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for c := range q.waiting {
+		if len(q.waiting[c]) == 0 {
+			continue
+		}
+		turn := q.waiting[c][0]
+		q.waiting[c] = q.waiting[c][1:]
+		close(turn)
+		return
+	}
+	q.busy = false
+}
+
+// syncingIfQueueTimeout - newPayload and forkchoiceUpdated which waited in queue out of budget are not processed:
+// answered with SYNCING status, as when EL is busy with sync. Returns nil status if request got its turn.
+func syncingIfQueueTimeout(err error) (*engine_types.PayloadStatus, error) {
+	if errors.Is(err, errQueueTimeout) {
+		return &engine_types.PayloadStatus{Status: engine_types.SyncingStatus}, nil
+	}
+	return nil, err
+}
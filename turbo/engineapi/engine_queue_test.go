@@ -0,0 +1,116 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+func TestEngineQueuePriority(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	q := &engineQueue{}
+	require.NoError(q.acquire(ctx, newPayloadClass))
+
+	var mu sync.Mutex
+	var order []requestClass
+	var wg sync.WaitGroup
+	enqueue := func(class requestClass) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := q.acquire(ctx, class); err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			order = append(order, class)
+			mu.Unlock()
+			q.release()
+		}()
+		waiting := func() int {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			return len(q.waiting[class])
+		}
+		require.Eventually(func() bool { return waiting() > 0 }, time.Second, time.Millisecond)
+	}
+	// burst of newPayload arrives before proposal
+	enqueue(newPayloadClass)
+	enqueue(newPayloadClass)
+	enqueue(forkchoiceUpdatedClass)
+	enqueue(getPayloadClass)
+
+	q.release()
+	wg.Wait()
+	require.Equal([]requestClass{getPayloadClass, forkchoiceUpdatedClass, newPayloadClass, newPayloadClass}, order)
+	require.False(q.busy)
+}
+
+func TestEngineQueueTimeout(t *testing.T) {
+	require := require.New(t)
+	defer func(prev time.Duration) { queueTimeouts[newPayloadClass] = prev }(queueTimeouts[newPayloadClass])
+	queueTimeouts[newPayloadClass] = 10 * time.Millisecond
+
+	q := &engineQueue{}
+	require.NoError(q.acquire(context.Background(), forkchoiceUpdatedClass))
+	err := q.acquire(context.Background(), newPayloadClass)
+	require.ErrorIs(err, errQueueTimeout)
+
+	// newPayload out of budget is answered with SYNCING
+	status, err := syncingIfQueueTimeout(err)
+	require.NoError(err)
+	require.Equal(engine_types.SyncingStatus, status.Status)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = q.acquire(ctx, forkchoiceUpdatedClass)
+	require.ErrorIs(err, context.Canceled)
+	status, err = syncingIfQueueTimeout(err)
+	require.ErrorIs(err, context.Canceled)
+	require.Nil(status)
+
+	// timed out requests don't get turn
+	q.release()
+	require.False(q.busy)
+	require.NoError(q.acquire(context.Background(), newPayloadClass))
+	q.release()
+}
+
+func TestEngineQueueGetPayloadNoTimeout(t *testing.T) {
+	require := require.New(t)
+	q := &engineQueue{}
+	require.NoError(q.acquire(context.Background(), newPayloadClass))
+
+	// getPayload waits for its turn however long request ahead takes
+	acquired := make(chan error, 1)
+	go func() { acquired <- q.acquire(context.Background(), getPayloadClass) }()
+	select {
+	case err := <-acquired:
+		t.Fatalf("getPayload didn't wait for turn: %v", err)
+	case <-time.After(time.Second):
+	}
+	q.release()
+	require.NoError(<-acquired)
+}
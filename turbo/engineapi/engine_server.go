@@ -21,7 +21,6 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"sync"
 	"sync/atomic"
 	"time"
 
@@ -73,7 +72,7 @@ type EngineServer struct {
 	txpool           txpool.TxpoolClient // needed for getBlobs
 
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
-	lock    sync.Mutex
+	queue   engineQueue // requests are processed one at a time, by priority
 	logger  log.Logger
 
 	engineLogSpamer *engine_logs_spammer.EngineLogsSpammer
@@ -95,6 +94,7 @@ func NewEngineServer(logger log.Logger, config *chain.Config, executionService e
 		chainRW:           chainRW,
 		proposing:         proposing,
 		hd:                hd,
+		test:              test,
 		caplin:            caplin,
 		engineLogSpamer:   engine_logs_spammer.NewEngineLogsSpammer(logger, config),
 		printPectraBanner: true,
@@ -324,8 +324,14 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		return possibleStatus, nil
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	if status, err := syncingIfQueueTimeout(s.queue.acquire(ctx, newPayloadClass)); status != nil || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		s.logger.Debug("[NewPayload] queue is busy, returning SYNCING", "height", header.Number, "hash", blockHash)
+		return status, nil
+	}
+	defer s.queue.release()
 
 	s.logger.Debug("[NewPayload] sending block", "height", header.Number, "hash", blockHash)
 	block := types.NewBlockFromStorage(blockHash, &header, transactions, nil /* uncles */, withdrawals)
@@ -494,8 +500,10 @@ func (s *EngineServer) getPayload(ctx context.Context, payloadId uint64, version
 	}
 
 	s.logger.Debug("[GetPayload] acquiring lock")
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	if err := s.queue.acquire(ctx, getPayloadClass); err != nil {
+		return nil, err
+	}
+	defer s.queue.release()
 	s.logger.Debug("[GetPayload] lock acquired")
 	resp, err := s.executionService.GetAssembledBlock(ctx, &execution.GetAssembledBlockRequest{
 		Id: payloadId,
@@ -556,8 +564,14 @@ func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *e
 	if err != nil {
 		return nil, err
 	}
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	if status, err := syncingIfQueueTimeout(s.queue.acquire(ctx, forkchoiceUpdatedClass)); status != nil || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		s.logger.Debug("[ForkChoiceUpdated] queue is busy, returning SYNCING", "head", forkchoiceState.HeadHash)
+		return &engine_types.ForkChoiceUpdatedResponse{PayloadStatus: status}, nil
+	}
+	defer s.queue.release()
 
 	if status == nil {
 		s.logger.Debug("[ForkChoiceUpdated] sending forkChoiceMessage", "head", forkchoiceState.HeadHash)
@@ -626,6 +640,20 @@ func (s *EngineServer) forkchoiceUpdated(ctx context.Context, forkchoiceState *e
 		req.ParentBeaconBlockRoot = gointerfaces.ConvertHashToH256(*payloadAttributes.ParentBeaconBlockRoot)
 	}
 
+	if s.config.IsOptimism() {
+		if payloadAttributes.GasLimit == nil {
+			return nil, &engine_helpers.InvalidPayloadAttributesErr // gas limit is set by rollup node
+		}
+		req.Transactions = make([][]byte, len(payloadAttributes.Transactions))
+		for i, txn := range payloadAttributes.Transactions {
+			req.Transactions[i] = txn
+		}
+		req.NoTxPool = payloadAttributes.NoTxPool
+		req.GasLimit = (*uint64)(payloadAttributes.GasLimit)
+	} else if len(payloadAttributes.Transactions) > 0 || payloadAttributes.NoTxPool || payloadAttributes.GasLimit != nil {
+		return nil, &engine_helpers.InvalidPayloadAttributesErr // OP Stack extensions
+	}
+
 	var resp *execution.AssembleBlockResponse
 
 	execBusy, err := waitForStuff(500*time.Millisecond, func() (bool, error) {
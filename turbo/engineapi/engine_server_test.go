@@ -19,6 +19,7 @@ package engineapi
 import (
 	"bytes"
 	"math/big"
+	"path/filepath"
 	"testing"
 
 	"github.com/holiman/uint256"
@@ -112,7 +113,7 @@ func TestGetBlobsV1(t *testing.T) {
 	executionRpc := direct.NewExecutionClientDirect(mockSentry.Eth1ExecutionService)
 	eth := rpcservices.NewRemoteBackend(nil, mockSentry.DB, mockSentry.BlockReader)
 	engineServer := NewEngineServer(mockSentry.Log, mockSentry.ChainConfig, executionRpc, mockSentry.HeaderDownload(), nil, false, true, false, true)
-	engineServer.Start(ctx, &httpcfg.HttpCfg{}, mockSentry.DB, mockSentry.BlockReader, ff, nil, mockSentry.Engine, eth, txPool, nil)
+	engineServer.Start(ctx, &httpcfg.HttpCfg{JWTSecretPath: filepath.Join(t.TempDir(), "jwt.hex")}, mockSentry.DB, mockSentry.BlockReader, ff, nil, mockSentry.Engine, eth, txPool, nil)
 
 	err = wrappedTxn.MarshalBinaryWrapped(buf)
 	require.NoError(err)
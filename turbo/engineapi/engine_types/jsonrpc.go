@@ -65,6 +65,11 @@ type PayloadAttributes struct {
 	SuggestedFeeRecipient common.Address      `json:"suggestedFeeRecipient" gencodec:"required"`
 	Withdrawals           []*types.Withdrawal `json:"withdrawals"`
 	ParentBeaconBlockRoot *common.Hash        `json:"parentBeaconBlockRoot"`
+
+	// OP Stack extensions: transactions forced by rollup node (deposits first), whether to skip txpool and block gas limit
+	Transactions []hexutil.Bytes `json:"transactions,omitempty"`
+	NoTxPool     bool            `json:"noTxPool,omitempty"`
+	GasLimit     *hexutil.Uint64 `json:"gasLimit,omitempty"`
 }
 
 // TransitionConfiguration represents the correct configurations of the CL and the EL
@@ -123,6 +128,37 @@ func (c ClientVersionV1) String() string {
 	return fmt.Sprintf("ClientCode: %s, %s-%s-%s", c.Code, c.Name, c.Version, c.Commit)
 }
 
+// ProtocolVersion - OP Stack superchain protocol version, see https://specs.optimism.io/protocol/superchain-upgrades.html#protocol-version-format
+type ProtocolVersion common.Hash
+
+func NewProtocolVersionV0(build [8]byte, major, minor, patch, preRelease uint32) (v ProtocolVersion) {
+	// v[0] is version type, 0; bytes [1:8] are reserved
+	copy(v[8:16], build[:])
+	binary.BigEndian.PutUint32(v[16:20], major)
+	binary.BigEndian.PutUint32(v[20:24], minor)
+	binary.BigEndian.PutUint32(v[24:28], patch)
+	binary.BigEndian.PutUint32(v[28:32], preRelease)
+	return v
+}
+
+func (v ProtocolVersion) Major() uint32 { return binary.BigEndian.Uint32(v[16:20]) }
+
+func (v ProtocolVersion) String() string {
+	return fmt.Sprintf("v%d.%d.%d", binary.BigEndian.Uint32(v[16:20]), binary.BigEndian.Uint32(v[20:24]), binary.BigEndian.Uint32(v[24:28]))
+}
+
+func (v ProtocolVersion) MarshalText() ([]byte, error) { return common.Hash(v).MarshalText() }
+
+func (v *ProtocolVersion) UnmarshalText(input []byte) error {
+	return (*common.Hash)(v).UnmarshalText(input)
+}
+
+// SuperchainSignal - protocol versions of OP Stack superchain, signalled by op-node
+type SuperchainSignal struct {
+	Recommended ProtocolVersion `json:"recommended"`
+	Required    ProtocolVersion `json:"required"`
+}
+
 type StringifiedError struct{ err error }
 
 func NewStringifiedError(err error) *StringifiedError {
@@ -0,0 +1,80 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era1
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// e2store - "type-length-value" container of era/era1 files: every entry is 8 bytes header
+// (type uint16 LE | length uint32 LE | reserved uint16 - must be zero) followed by `length` bytes of value
+const headerSize = 8
+
+type entry struct {
+	Type  uint16
+	Value []byte
+}
+
+type e2Writer struct {
+	w       io.Writer
+	written int64
+}
+
+func (w *e2Writer) Write(typ uint16, value []byte) (int, error) {
+	var h [headerSize]byte
+	binary.LittleEndian.PutUint16(h[:], typ)
+	binary.LittleEndian.PutUint32(h[2:], uint32(len(value)))
+	n, err := w.w.Write(h[:])
+	w.written += int64(n)
+	if err != nil {
+		return n, err
+	}
+	m, err := w.w.Write(value)
+	w.written += int64(m)
+	return n + m, err
+}
+
+// readEntryHeader - reads header of entry at `off`, returns type and length of value
+func readEntryHeader(r io.ReaderAt, off int64) (typ uint16, length uint32, err error) {
+	var h [headerSize]byte
+	if _, err := r.ReadAt(h[:], off); err != nil {
+		return 0, 0, err
+	}
+	if h[6] != 0 || h[7] != 0 {
+		return 0, 0, fmt.Errorf("e2store: reserved bytes of entry at %d are not zero", off)
+	}
+	return binary.LittleEndian.Uint16(h[:]), binary.LittleEndian.Uint32(h[2:]), nil
+}
+
+// readEntry - reads entry at `off`, returns it and offset of next entry
+func readEntry(r io.ReaderAt, off int64) (*entry, int64, error) {
+	typ, length, err := readEntryHeader(r, off)
+	if err != nil {
+		return nil, 0, err
+	}
+	e := &entry{Type: typ, Value: make([]byte, length)}
+	if _, err := r.ReadAt(e.Value, off+headerSize); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	return e, off + headerSize + int64(length), nil
+}
@@ -0,0 +1,363 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package era1 - EIP-4444 history archives of pre-merge blocks: https://github.com/eth-clients/e2store-format-specs/blob/main/formats/era1.md
+//
+//	era1 := Version | block-tuple* | other-entries* | Accumulator | BlockIndex
+//	block-tuple := CompressedHeader | CompressedBody | CompressedReceipts | TotalDifficulty
+//
+// Every file has up to MaxEra1Size blocks of one epoch: [epoch*MaxEra1Size, (epoch+1)*MaxEra1Size)
+package era1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/snappy"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	TypeVersion            uint16 = 0x3265
+	TypeCompressedHeader   uint16 = 0x03
+	TypeCompressedBody     uint16 = 0x04
+	TypeCompressedReceipts uint16 = 0x05
+	TypeTotalDifficulty    uint16 = 0x06
+	TypeAccumulator        uint16 = 0x07
+	TypeBlockIndex         uint16 = 0x3266
+
+	MaxEra1Size = 8192 // blocks per file
+)
+
+// Filename - <network>-<epoch>-<first 4 bytes of accumulator root>.era1
+func Filename(network string, epoch int, root common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s.era1", network, epoch, hex.EncodeToString(root[:4]))
+}
+
+// ParseFilename - returns network and epoch of file, ok=false if name is not era1 file name
+func ParseFilename(name string) (network string, epoch int, ok bool) {
+	name = filepath.Base(name)
+	if !strings.HasSuffix(name, ".era1") {
+		return "", 0, false
+	}
+	parts := strings.Split(strings.TrimSuffix(name, ".era1"), "-")
+	if len(parts) < 3 {
+		return "", 0, false
+	}
+	epoch, err := strconv.Atoi(parts[len(parts)-2])
+	if err != nil || epoch < 0 {
+		return "", 0, false
+	}
+	return strings.Join(parts[:len(parts)-2], "-"), epoch, true
+}
+
+// Builder - writes era1 file: call Add for every block of epoch (in order), then Finalize
+type Builder struct {
+	w        *e2Writer
+	startNum *uint64
+	offsets  []int64
+	hashes   []common.Hash
+	tds      []*big.Int
+	buf      *bytes.Buffer
+	snappy   *snappy.Writer
+}
+
+func NewBuilder(w io.Writer) *Builder {
+	buf := bytes.NewBuffer(nil)
+	return &Builder{w: &e2Writer{w: w}, buf: buf, snappy: snappy.NewBufferedWriter(buf)}
+}
+
+// Add - writes block tuple. `td` - total difficulty including this block
+func (b *Builder) Add(block *types.Block, receipts types.Receipts, td *big.Int) error {
+	if b.startNum == nil {
+		if _, err := b.w.Write(TypeVersion, nil); err != nil {
+			return err
+		}
+		n := block.NumberU64()
+		b.startNum = &n
+	}
+	if len(b.offsets) >= MaxEra1Size {
+		return fmt.Errorf("exceeds max era1 size of %d blocks", MaxEra1Size)
+	}
+	if expected := *b.startNum + uint64(len(b.offsets)); block.NumberU64() != expected {
+		return fmt.Errorf("era1: expected block %d, got %d", expected, block.NumberU64())
+	}
+	b.offsets = append(b.offsets, b.w.written)
+	b.hashes = append(b.hashes, block.Hash())
+	b.tds = append(b.tds, new(big.Int).Set(td))
+
+	body := &types.Body{Transactions: block.Transactions(), Uncles: block.Uncles(), Withdrawals: block.Withdrawals()}
+	for _, it := range []struct {
+		typ uint16
+		val any
+	}{{TypeCompressedHeader, block.HeaderNoCopy()}, {TypeCompressedBody, body}, {TypeCompressedReceipts, receipts}} {
+		if err := b.writeCompressed(it.typ, it.val); err != nil {
+			return err
+		}
+	}
+	_, err := b.w.Write(TypeTotalDifficulty, bigToBytes32(td))
+	return err
+}
+
+func (b *Builder) writeCompressed(typ uint16, val any) error {
+	raw, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		return err
+	}
+	b.buf.Reset()
+	b.snappy.Reset(b.buf)
+	if _, err := b.snappy.Write(raw); err != nil {
+		return err
+	}
+	if err := b.snappy.Flush(); err != nil {
+		return err
+	}
+	_, err = b.w.Write(typ, b.buf.Bytes())
+	return err
+}
+
+// Finalize - writes accumulator and block index. Returns accumulator root
+func (b *Builder) Finalize() (common.Hash, error) {
+	if b.startNum == nil {
+		return common.Hash{}, errors.New("era1: finalize called on empty builder")
+	}
+	root, err := ComputeAccumulator(b.hashes, b.tds)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if _, err := b.w.Write(TypeAccumulator, root[:]); err != nil {
+		return common.Hash{}, err
+	}
+	// start | offset* | count - offsets are relative to beginning of block index entry
+	base := b.w.written
+	index := make([]byte, 16+len(b.offsets)*8)
+	binary.LittleEndian.PutUint64(index, *b.startNum)
+	for i, off := range b.offsets {
+		binary.LittleEndian.PutUint64(index[8+i*8:], uint64(off-base))
+	}
+	binary.LittleEndian.PutUint64(index[8+len(b.offsets)*8:], uint64(len(b.offsets)))
+	if _, err := b.w.Write(TypeBlockIndex, index); err != nil {
+		return common.Hash{}, err
+	}
+	return root, nil
+}
+
+// ComputeAccumulator - SSZ hash_tree_root of List[HeaderRecord(block_hash, total_difficulty), MaxEra1Size]
+func ComputeAccumulator(hashes []common.Hash, tds []*big.Int) (common.Hash, error) {
+	if len(hashes) != len(tds) {
+		return common.Hash{}, fmt.Errorf("era1: %d hashes but %d total difficulties", len(hashes), len(tds))
+	}
+	if len(hashes) > MaxEra1Size {
+		return common.Hash{}, fmt.Errorf("era1: too many records: %d", len(hashes))
+	}
+	layer := make([][32]byte, len(hashes))
+	for i := range hashes {
+		layer[i] = sha256.Sum256(append(common.CopyBytes(hashes[i][:]), bigToBytes32(tds[i])...))
+	}
+	var zero [32]byte
+	for width := MaxEra1Size; width > 1; width /= 2 {
+		next := make([][32]byte, (len(layer)+1)/2)
+		for i := range next {
+			right := zero
+			if 2*i+1 < len(layer) {
+				right = layer[2*i+1]
+			}
+			next[i] = sha256.Sum256(append(layer[2*i][:], right[:]...))
+		}
+		if len(next) == 0 {
+			next = [][32]byte{sha256.Sum256(append(zero[:], zero[:]...))}
+		}
+		layer, zero = next, sha256.Sum256(append(zero[:], zero[:]...))
+	}
+	var length [32]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(hashes)))
+	return sha256.Sum256(append(layer[0][:], length[:]...)), nil
+}
+
+// bigToBytes32 - little-endian uint256
+func bigToBytes32(n *big.Int) []byte {
+	b := make([]byte, 32)
+	n.FillBytes(b)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
+
+func bytes32ToBig(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// Era - reader of era1 file
+type Era struct {
+	f     *os.File
+	start uint64
+	count uint64
+	index int64 // offset of block index entry
+}
+
+func Open(path string) (*Era, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	e, err := newEra(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return e, nil
+}
+
+func newEra(f *os.File) (*Era, error) {
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if st.Size() < headerSize+16 {
+		return nil, errors.New("era1: file too small")
+	}
+	var b [8]byte
+	if _, err := f.ReadAt(b[:], st.Size()-8); err != nil {
+		return nil, err
+	}
+	count := binary.LittleEndian.Uint64(b[:])
+	if count == 0 || count > MaxEra1Size {
+		return nil, fmt.Errorf("era1: invalid blocks count %d", count)
+	}
+	index := st.Size() - headerSize - 16 - int64(count)*8
+	typ, length, err := readEntryHeader(f, index)
+	if err != nil {
+		return nil, err
+	}
+	if typ != TypeBlockIndex || int64(length) != 16+int64(count)*8 {
+		return nil, errors.New("era1: block index not found at end of file")
+	}
+	if _, err := f.ReadAt(b[:], index+headerSize); err != nil {
+		return nil, err
+	}
+	return &Era{f: f, start: binary.LittleEndian.Uint64(b[:]), count: count, index: index}, nil
+}
+
+func (e *Era) Close() error  { return e.f.Close() }
+func (e *Era) Start() uint64 { return e.start }
+func (e *Era) Count() uint64 { return e.count }
+
+// Accumulator - root stored in file (not re-computed)
+func (e *Era) Accumulator() (common.Hash, error) {
+	ent, _, err := readEntry(e.f, e.index-headerSize-32)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if ent.Type != TypeAccumulator || len(ent.Value) != 32 {
+		return common.Hash{}, errors.New("era1: accumulator not found")
+	}
+	return common.BytesToHash(ent.Value), nil
+}
+
+// tuple - reads block tuple of block `num`
+func (e *Era) tuple(num uint64) (header, body, receipts []byte, td *big.Int, err error) {
+	if num < e.start || num >= e.start+e.count {
+		return nil, nil, nil, nil, fmt.Errorf("era1: block %d out of range [%d, %d)", num, e.start, e.start+e.count)
+	}
+	var b [8]byte
+	if _, err := e.f.ReadAt(b[:], e.index+headerSize+8+int64(num-e.start)*8); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	off := e.index + int64(binary.LittleEndian.Uint64(b[:]))
+	var values [4][]byte
+	for i, typ := range []uint16{TypeCompressedHeader, TypeCompressedBody, TypeCompressedReceipts, TypeTotalDifficulty} {
+		var ent *entry
+		if ent, off, err = readEntry(e.f, off); err != nil {
+			return nil, nil, nil, nil, err
+		}
+		if ent.Type != typ {
+			return nil, nil, nil, nil, fmt.Errorf("era1: block %d: expected entry type %#x, got %#x", num, typ, ent.Type)
+		}
+		if typ == TypeTotalDifficulty {
+			values[i] = ent.Value
+			continue
+		}
+		if values[i], err = io.ReadAll(snappy.NewReader(bytes.NewReader(ent.Value))); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("era1: block %d: %w", num, err)
+		}
+	}
+	return values[0], values[1], values[2], bytes32ToBig(values[3]), nil
+}
+
+func (e *Era) Header(num uint64) (*types.Header, error) {
+	raw, _, _, _, err := e.tuple(num)
+	if err != nil {
+		return nil, err
+	}
+	h := &types.Header{}
+	if err := rlp.DecodeBytes(raw, h); err != nil {
+		return nil, fmt.Errorf("era1: header %d: %w", num, err)
+	}
+	return h, nil
+}
+
+func (e *Era) Block(num uint64) (*types.Block, error) {
+	rawHeader, rawBody, _, _, err := e.tuple(num)
+	if err != nil {
+		return nil, err
+	}
+	h, body := &types.Header{}, &types.Body{}
+	if err := rlp.DecodeBytes(rawHeader, h); err != nil {
+		return nil, fmt.Errorf("era1: header %d: %w", num, err)
+	}
+	if err := rlp.DecodeBytes(rawBody, body); err != nil {
+		return nil, fmt.Errorf("era1: body %d: %w", num, err)
+	}
+	if h.WithdrawalsHash == nil {
+		body.Withdrawals = nil
+	}
+	return types.NewBlockFromNetwork(h, body), nil
+}
+
+func (e *Era) Receipts(num uint64) (types.Receipts, error) {
+	_, _, raw, _, err := e.tuple(num)
+	if err != nil {
+		return nil, err
+	}
+	var receipts types.Receipts
+	if err := rlp.DecodeBytes(raw, &receipts); err != nil {
+		return nil, fmt.Errorf("era1: receipts %d: %w", num, err)
+	}
+	return receipts, nil
+}
+
+func (e *Era) TotalDifficulty(num uint64) (*big.Int, error) {
+	_, _, _, td, err := e.tuple(num)
+	return td, err
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era1_test
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/rpc/jsonrpc/receipts"
+	"github.com/erigontech/erigon/turbo/era1"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestExport(t *testing.T) {
+	require := require.New(t)
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.Address{0xee}
+		gspec  = &types.Genesis{
+			Config:   chain.TestChainConfig,
+			GasLimit: 3141592,
+			Alloc:    types.GenesisAlloc{addr: {Balance: big.NewInt(1000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 4, func(i int, gen *core.BlockGen) {
+		txn, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, uint256.NewInt(1000), params.TxGas, nil, nil), *signer, key)
+		require.NoError(err)
+		gen.AddTx(txn)
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chainPack))
+
+	dir := t.TempDir()
+	e := era1.NewExporter(dir, m.ChainConfig, m.BlockReader, receipts.NewGenerator(m.BlockReader, m.Engine), m.Log)
+	exported, err := e.Export(m.Ctx, m.DB, 5) // blocks [0, 5) - one partial epoch
+	require.NoError(err)
+	require.Equal(1, exported)
+	exported, err = e.Export(m.Ctx, m.DB, 5)
+	require.NoError(err)
+	require.Zero(exported)
+
+	store, err := era1.OpenStore(dir)
+	require.NoError(err)
+	defer store.Close()
+	covered, err := store.Covered()
+	require.NoError(err)
+	require.Equal(uint64(5), covered)
+
+	block, err := store.Block(2)
+	require.NoError(err)
+	require.Equal(chainPack.Blocks[1].Hash(), block.Hash())
+	require.Len(block.Transactions(), 1)
+	require.Equal(chainPack.Blocks[1].Transactions()[0].Hash(), block.Transactions()[0].Hash())
+
+	rcs, err := store.Receipts(2)
+	require.NoError(err)
+	require.Len(rcs, 1)
+	require.Equal(types.ReceiptStatusSuccessful, rcs[0].Status)
+	require.Equal(params.TxGas, rcs[0].CumulativeGasUsed)
+
+	missing, err := store.Block(5)
+	require.NoError(err)
+	require.Nil(missing)
+
+	era, err := store.Era(0)
+	require.NoError(err)
+	td := new(big.Int).Set(m.Genesis.Difficulty())
+	for _, b := range chainPack.Blocks {
+		td.Add(td, b.Difficulty())
+	}
+	lastTd, err := era.TotalDifficulty(4)
+	require.NoError(err)
+	require.Equal(td.String(), lastTd.String())
+
+	// accumulator of file matches file name and records of blocks
+	root, err := era.Accumulator()
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(dir, era1.Filename(m.ChainConfig.ChainName, 0, root)))
+	require.NoError(err)
+	hashes, tds := []common.Hash{m.Genesis.Hash()}, []*big.Int{m.Genesis.Difficulty()}
+	for i, b := range chainPack.Blocks {
+		hashes = append(hashes, b.Hash())
+		tds = append(tds, new(big.Int).Add(tds[i], b.Difficulty()))
+	}
+	expected, err := era1.ComputeAccumulator(hashes, tds)
+	require.NoError(err)
+	require.Equal(expected, root)
+}
+
+func TestStoreCovered(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+	write := func(epoch int, count uint64) {
+		var buf bytes.Buffer
+		b := era1.NewBuilder(&buf)
+		for i := uint64(0); i < count; i++ {
+			h := &types.Header{Number: new(big.Int).SetUint64(uint64(epoch)*era1.MaxEra1Size + i), Difficulty: big.NewInt(1)}
+			require.NoError(b.Add(types.NewBlockWithHeader(h), nil, big.NewInt(int64(i+1))))
+		}
+		root, err := b.Finalize()
+		require.NoError(err)
+		require.NoError(os.WriteFile(filepath.Join(dir, era1.Filename("test", epoch, root)), buf.Bytes(), 0644))
+	}
+	write(0, era1.MaxEra1Size)
+	write(2, 3) // gap: epoch 1 missing
+
+	store, err := era1.OpenStore(dir)
+	require.NoError(err)
+	defer store.Close()
+	covered, err := store.Covered()
+	require.NoError(err)
+	require.Equal(uint64(era1.MaxEra1Size), covered)
+
+	write(1, 10) // partial epoch - end of pre-merge history
+	require.NoError(store.Reopen())
+	covered, err = store.Covered()
+	require.NoError(err)
+	require.Equal(uint64(era1.MaxEra1Size+10), covered)
+
+	block, err := store.Block(2*era1.MaxEra1Size + 1)
+	require.NoError(err)
+	require.Equal(uint64(2*era1.MaxEra1Size+1), block.NumberU64())
+
+	_, err = era1.NewBuilder(&bytes.Buffer{}).Finalize()
+	require.Error(err)
+}
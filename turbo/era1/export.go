@@ -0,0 +1,159 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era1
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+type blockReader interface {
+	CanonicalHash(ctx context.Context, tx kv.Getter, blockNum uint64) (common.Hash, bool, error)
+	BlockWithSenders(ctx context.Context, tx kv.Getter, hash common.Hash, blockNum uint64) (*types.Block, []common.Address, error)
+}
+
+type ReceiptsGetter interface {
+	GetReceipts(ctx context.Context, cfg *chain.Config, tx kv.TemporalTx, block *types.Block) (types.Receipts, error)
+}
+
+// Exporter - writes pre-merge blocks to era1 files. Receipts are re-generated, so history of exported blocks must be available.
+// File appears atomically (rename) only when it's complete - exporter can be re-run any time and continues from first missing epoch.
+type Exporter struct {
+	dir         string
+	network     string
+	chainConfig *chain.Config
+	blockReader blockReader
+	receipts    ReceiptsGetter
+	logger      log.Logger
+}
+
+func NewExporter(dir string, chainConfig *chain.Config, blockReader blockReader, receipts ReceiptsGetter, logger log.Logger) *Exporter {
+	return &Exporter{dir: dir, network: chainConfig.ChainName, chainConfig: chainConfig, blockReader: blockReader, receipts: receipts, logger: logger}
+}
+
+// Export - exports all epochs of blocks [0, upTo) which are not exported yet. Last epoch may be partial.
+// Returns number of written files.
+func (e *Exporter) Export(ctx context.Context, db kv.TemporalRoDB, upTo uint64) (exported int, err error) {
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return 0, err
+	}
+	store, err := OpenStore(e.dir)
+	if err != nil {
+		return 0, err
+	}
+	defer store.Close()
+
+	td := new(big.Int)
+	for epoch := 0; uint64(epoch)*MaxEra1Size < upTo; epoch++ {
+		from, to := uint64(epoch)*MaxEra1Size, min(uint64(epoch+1)*MaxEra1Size, upTo)
+		era, err := store.Era(epoch)
+		if err != nil {
+			return exported, err
+		}
+		if era != nil {
+			if era.Start()+era.Count() < to {
+				return exported, fmt.Errorf("era1 file of epoch %d has %d blocks, expected %d", epoch, era.Count(), to-from)
+			}
+			if td, err = era.TotalDifficulty(to - 1); err != nil {
+				return exported, err
+			}
+			continue
+		}
+		t := time.Now()
+		if err := db.View(ctx, func(tx kv.Tx) error {
+			td, err = e.ExportEpoch(ctx, tx.(kv.TemporalTx), epoch, to, td)
+			return err
+		}); err != nil {
+			return exported, fmt.Errorf("export epoch %d: %w", epoch, err)
+		}
+		exported++
+		e.logger.Info("[era1] exported", "epoch", epoch, "blocks", fmt.Sprintf("%d-%d", from, to-1), "took", time.Since(t))
+	}
+	return exported, nil
+}
+
+// ExportEpoch - writes file with blocks [epoch*MaxEra1Size, to). `td` - total difficulty of block before epoch.
+// Returns total difficulty of last written block.
+func (e *Exporter) ExportEpoch(ctx context.Context, tx kv.TemporalTx, epoch int, to uint64, td *big.Int) (_ *big.Int, err error) {
+	tmpPath := filepath.Join(e.dir, fmt.Sprintf(".tmp-%s-%05d.era1", e.network, epoch))
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	td = new(big.Int).Set(td)
+	b := NewBuilder(f)
+	for num := uint64(epoch) * MaxEra1Size; num < to; num++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		hash, ok, err := e.blockReader.CanonicalHash(ctx, tx, num)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("canonical block %d not found", num)
+		}
+		block, _, err := e.blockReader.BlockWithSenders(ctx, tx, hash, num)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block %d %x not found", num, hash)
+		}
+		receipts := types.Receipts{}
+		if len(block.Transactions()) > 0 {
+			if receipts, err = e.receipts.GetReceipts(ctx, e.chainConfig, tx, block); err != nil {
+				return nil, fmt.Errorf("receipts of block %d: %w", num, err)
+			}
+		}
+		if root := types.DeriveSha(receipts); root != block.ReceiptHash() {
+			return nil, fmt.Errorf("receipts of block %d: root %x, header has %x", num, root, block.ReceiptHash())
+		}
+		td.Add(td, block.Difficulty())
+		if err := b.Add(block, receipts, td); err != nil {
+			return nil, err
+		}
+	}
+	root, err := b.Finalize()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Sync(); err != nil {
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+	return td, os.Rename(tmpPath, filepath.Join(e.dir, Filename(e.network, epoch, root)))
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era1
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// Dir - where node keeps era1 files of expired (EIP-4444) history
+func Dir(dirs datadir.Dirs) string { return filepath.Join(dirs.DataDir, "era1") }
+
+// Store - era1 files of one directory: at most one file per epoch
+type Store struct {
+	dir   string
+	lock  sync.Mutex
+	files map[int]string // epoch -> path
+	open  map[int]*Era
+}
+
+// OpenStore - indexes era1 files in `dir`. Missing dir is same as empty dir
+func OpenStore(dir string) (*Store, error) {
+	s := &Store{dir: dir, files: map[int]string{}, open: map[int]*Era{}}
+	return s, s.Reopen()
+}
+
+// Reopen - picks up files added to dir
+func (s *Store) Reopen() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	files := map[int]string{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		_, epoch, ok := ParseFilename(e.Name())
+		if !ok {
+			continue
+		}
+		if prev, ok := files[epoch]; ok {
+			return fmt.Errorf("era1: 2 files of epoch %d: %s, %s", epoch, filepath.Base(prev), e.Name())
+		}
+		files[epoch] = filepath.Join(s.dir, e.Name())
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for epoch, e := range s.open {
+		if files[epoch] != s.files[epoch] {
+			e.Close()
+			delete(s.open, epoch)
+		}
+	}
+	s.files = files
+	return nil
+}
+
+func (s *Store) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for epoch, e := range s.open {
+		e.Close()
+		delete(s.open, epoch)
+	}
+}
+
+// Epochs - sorted epochs which have file
+func (s *Store) Epochs() []int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	epochs := make([]int, 0, len(s.files))
+	for epoch := range s.files {
+		epochs = append(epochs, epoch)
+	}
+	sort.Ints(epochs)
+	return epochs
+}
+
+// Era - file of epoch, nil if there is no such file. Don't close it: owned by Store
+func (s *Store) Era(epoch int) (*Era, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if e, ok := s.open[epoch]; ok {
+		return e, nil
+	}
+	path, ok := s.files[epoch]
+	if !ok {
+		return nil, nil
+	}
+	e, err := Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if e.Start() != uint64(epoch)*MaxEra1Size {
+		e.Close()
+		return nil, fmt.Errorf("era1: %s starts at block %d", filepath.Base(path), e.Start())
+	}
+	s.open[epoch] = e
+	return e, nil
+}
+
+// Block - nil if block is not in store
+func (s *Store) Block(num uint64) (*types.Block, error) {
+	e, err := s.Era(int(num / MaxEra1Size))
+	if err != nil || e == nil || num >= e.Start()+e.Count() {
+		return nil, err
+	}
+	return e.Block(num)
+}
+
+// Receipts - nil if block is not in store
+func (s *Store) Receipts(num uint64) (types.Receipts, error) {
+	e, err := s.Era(int(num / MaxEra1Size))
+	if err != nil || e == nil || num >= e.Start()+e.Count() {
+		return nil, err
+	}
+	return e.Receipts(num)
+}
+
+// Covered - blocks [0, Covered()) are all in store
+func (s *Store) Covered() (uint64, error) {
+	var covered uint64
+	for i, epoch := range s.Epochs() {
+		if epoch != i {
+			break
+		}
+		e, err := s.Era(epoch)
+		if err != nil {
+			return 0, err
+		}
+		covered = e.Start() + e.Count()
+		if e.Count() < MaxEra1Size { // last (partial) epoch before merge
+			break
+		}
+	}
+	return covered, nil
+}
+
+type headerReader interface {
+	HeaderByNumber(ctx context.Context, tx kv.Getter, blockNum uint64) (*types.Header, error)
+}
+
+// MergeBlock - number of first proof-of-stake block (first block with zero difficulty) among blocks [0, head].
+// ok=false if chain has no merge or it's above `head`. Pre-merge history is [0, MergeBlock)
+func MergeBlock(ctx context.Context, tx kv.Getter, headers headerReader, chainConfig *chain.Config, head uint64) (uint64, bool, error) {
+	if chainConfig.TerminalTotalDifficulty == nil {
+		return 0, false, nil
+	}
+	isPoS := func(n uint64) (bool, error) {
+		h, err := headers.HeaderByNumber(ctx, tx, n)
+		if err != nil {
+			return false, err
+		}
+		if h == nil {
+			return false, fmt.Errorf("era1: header %d not found", n)
+		}
+		return h.Difficulty.Sign() == 0, nil
+	}
+	if ok, err := isPoS(head); err != nil || !ok {
+		return 0, false, err
+	}
+	lo, hi := uint64(0), head // invariant: hi is PoS
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := isPoS(mid)
+		if err != nil {
+			return 0, false, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return hi, true, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exex
+
+import (
+	"context"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// ExEx - execution extension: in-process plugin (indexer, bridge watcher, etc...) which receives chain segments
+// after they are committed to db - instead of polling RPC.
+//
+// Delivery is at-least-once: checkpoint of ExEx is persisted only after OnNotification returned nil. If it returned
+// error or node stopped before checkpoint was persisted - same segment will be delivered again. So, implementations
+// must be idempotent (by block hash).
+type ExEx interface {
+	// Name - unique name of ExEx, used as checkpoint key. Don't change it - ExEx will start from scratch.
+	Name() string
+	OnNotification(ctx context.Context, n *Notification) error
+}
+
+type NotificationKind uint8
+
+const (
+	// ChainCommitted - Blocks became canonical (and executed). Sent in ascending order.
+	ChainCommitted NotificationKind = iota + 1
+	// ChainReverted - Blocks are not canonical anymore (re-org or unwind). Sent in descending order: from
+	// last delivered block down to common ancestor (exclusive). ChainCommitted of new canonical blocks follows.
+	ChainReverted
+)
+
+func (k NotificationKind) String() string {
+	switch k {
+	case ChainCommitted:
+		return "committed"
+	case ChainReverted:
+		return "reverted"
+	default:
+		return "unknown"
+	}
+}
+
+type Notification struct {
+	Kind   NotificationKind
+	Blocks []*Block
+}
+
+// First/Last - blocks in delivery order
+func (n *Notification) First() *Block { return n.Blocks[0] }
+func (n *Notification) Last() *Block  { return n.Blocks[len(n.Blocks)-1] }
+
+type Block struct {
+	*types.Block
+	Receipts types.Receipts // nil for ChainReverted
+	Diff     *StateDiff     // nil for ChainReverted. Empty if history of block is already pruned.
+}
+
+// StateDiff - state changes made by block (including block rewards and system txs)
+type StateDiff struct {
+	Accounts []AccountDiff
+	Storage  []StorageDiff
+	Code     []CodeDiff
+}
+
+type AccountDiff struct {
+	Address common.Address
+	Prev    *accounts.Account // nil if account didn't exist before block
+	Next    *accounts.Account // nil if account was deleted by block
+}
+
+type StorageDiff struct {
+	Address common.Address
+	Slot    common.Hash
+	Prev    uint256.Int
+	Next    uint256.Int
+}
+
+type CodeDiff struct {
+	Address common.Address
+	Prev    []byte
+	Next    []byte
+}
+
+// Checkpoint - last block delivered to ExEx. Empty BlockHash means nothing delivered yet and BlockNum is next block
+// to deliver.
+type Checkpoint struct {
+	BlockNum  uint64
+	BlockHash common.Hash
+}
+
+func (c Checkpoint) next() uint64 {
+	if c.BlockHash == (common.Hash{}) {
+		return c.BlockNum
+	}
+	return c.BlockNum + 1
+}
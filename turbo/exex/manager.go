@@ -0,0 +1,324 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exex
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/rpc/jsonrpc/receipts"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/shards"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+const (
+	segmentLimit  = 64               // max amount of blocks in 1 notification
+	retryInterval = 10 * time.Second // re-try failed deliveries even if chain doesn't move
+)
+
+// Manager - delivers committed chain segments and re-orgs to registered ExExs.
+//
+// It doesn't receive data from stages: it's woken-up by new headers event and reads everything from db (which is
+// already committed at this point). It allows to deliver same segments after restart - from ExEx's checkpoint.
+// Checkpoints are stored in own small db: `datadir/exex`.
+type Manager struct {
+	db           kv.TemporalRoDB
+	dirs         datadir.Dirs
+	events       *shards.Events
+	blockReader  services.FullBlockReader
+	txNumsReader rawdbv3.TxNumsReader
+	receipts     *receipts.Generator
+	chainConfig  *chain.Config
+	logger       log.Logger
+
+	lock        sync.Mutex
+	checkpoints kv.RwDB // opened by first Register
+	exexs       []*exexState
+}
+
+type exexState struct {
+	ExEx
+	checkpoint Checkpoint
+}
+
+func NewManager(db kv.TemporalRoDB, dirs datadir.Dirs, events *shards.Events, blockReader services.FullBlockReader, engine consensus.EngineReader, chainConfig *chain.Config, logger log.Logger) *Manager {
+	return &Manager{
+		db:           db,
+		dirs:         dirs,
+		events:       events,
+		blockReader:  blockReader,
+		txNumsReader: rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(context.Background(), blockReader)),
+		receipts:     receipts.NewGenerator(blockReader, engine),
+		chainConfig:  chainConfig,
+		logger:       logger,
+	}
+}
+
+// Register - adds ExEx. Must be called before Run. `fromBlock` is used only if ExEx has no checkpoint yet.
+func (m *Manager) Register(ctx context.Context, ex ExEx, fromBlock uint64) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, e := range m.exexs {
+		if e.Name() == ex.Name() {
+			return fmt.Errorf("exex %s already registered", ex.Name())
+		}
+	}
+	if m.checkpoints == nil {
+		db, err := mdbx.New(kv.ExExDB, m.logger).
+			WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.ExExTablesCfg }).
+			GrowthStep(4 * datasize.MB).
+			MapSize(1 * datasize.GB).
+			Path(filepath.Join(m.dirs.DataDir, kv.ExExDB)).
+			Open(ctx)
+		if err != nil {
+			return err
+		}
+		m.checkpoints = db
+	}
+
+	checkpoint := Checkpoint{BlockNum: fromBlock}
+	if err := m.checkpoints.View(ctx, func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.ExExCheckpoints, []byte(ex.Name()))
+		if err != nil {
+			return err
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		if len(v) != 8+length.Hash {
+			return fmt.Errorf("exex %s: unexpected checkpoint length %d", ex.Name(), len(v))
+		}
+		checkpoint = Checkpoint{BlockNum: binary.BigEndian.Uint64(v), BlockHash: common.BytesToHash(v[8:])}
+		return nil
+	}); err != nil {
+		return err
+	}
+	m.exexs = append(m.exexs, &exexState{ExEx: ex, checkpoint: checkpoint})
+	m.logger.Info("[exex] registered", "name", ex.Name(), "checkpoint", checkpoint.BlockNum, "hash", checkpoint.BlockHash)
+	return nil
+}
+
+// Close - closes checkpoints db and ExExs which implement io.Closer
+func (m *Manager) Close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, e := range m.exexs {
+		if c, ok := e.ExEx.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				m.logger.Warn("[exex] close", "name", e.Name(), "err", err)
+			}
+		}
+	}
+	if m.checkpoints != nil {
+		m.checkpoints.Close()
+	}
+}
+
+// Run - delivers notifications until ctx is cancelled. Errors of ExExs are logged and retried - they don't stop node.
+func (m *Manager) Run(ctx context.Context) error {
+	m.lock.Lock()
+	exexs := m.exexs
+	m.lock.Unlock()
+	if len(exexs) == 0 {
+		return nil
+	}
+
+	headers, unsubscribe := m.events.AddHeaderSubscription()
+	defer unsubscribe()
+	retry := time.NewTicker(retryInterval)
+	defer retry.Stop()
+	for {
+		for _, e := range exexs {
+			if err := m.deliver(ctx, e); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				m.logger.Warn("[exex] delivery failed, will retry", "name", e.Name(), "checkpoint", e.checkpoint.BlockNum, "err", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-headers:
+		case <-retry.C:
+		}
+	}
+}
+
+// deliver - sends notifications to ExEx until it reaches head of chain. Checkpoint is persisted after each one.
+func (m *Manager) deliver(ctx context.Context, e *exexState) error {
+	for {
+		n, err := m.nextNotification(ctx, e.checkpoint)
+		if err != nil {
+			return err
+		}
+		if n == nil {
+			return nil
+		}
+		if err := e.OnNotification(ctx, n); err != nil {
+			return fmt.Errorf("%s blocks %d-%d: %w", n.Kind, n.First().NumberU64(), n.Last().NumberU64(), err)
+		}
+
+		last := n.Last()
+		checkpoint := Checkpoint{BlockNum: last.NumberU64(), BlockHash: last.Hash()}
+		if n.Kind == ChainReverted {
+			checkpoint = Checkpoint{BlockNum: last.NumberU64() - 1, BlockHash: last.ParentHash()}
+		}
+		if err := m.checkpoints.Update(ctx, func(tx kv.RwTx) error {
+			return tx.Put(kv.ExExCheckpoints, []byte(e.Name()), append(hexutil.EncodeTs(checkpoint.BlockNum), checkpoint.BlockHash[:]...))
+		}); err != nil {
+			return err
+		}
+		e.checkpoint = checkpoint
+	}
+}
+
+// nextNotification - returns nil if ExEx is at head of chain
+func (m *Manager) nextNotification(ctx context.Context, checkpoint Checkpoint) (*Notification, error) {
+	tx, err := m.db.BeginTemporalRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	head, err := stages.GetStageProgress(tx, stages.Finish)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint.BlockHash != (common.Hash{}) {
+		canonical, err := m.isCanonical(ctx, tx, checkpoint.BlockNum, checkpoint.BlockHash, head)
+		if err != nil {
+			return nil, err
+		}
+		if !canonical {
+			return m.reverted(ctx, tx, checkpoint, head)
+		}
+	}
+
+	from := checkpoint.next()
+	if from > head {
+		return nil, nil
+	}
+	to := min(head, from+segmentLimit-1)
+
+	n := &Notification{Kind: ChainCommitted, Blocks: make([]*Block, 0, to-from+1)}
+	for blockNum := from; blockNum <= to; blockNum++ {
+		b, err := m.committedBlock(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		n.Blocks = append(n.Blocks, b)
+	}
+	return n, nil
+}
+
+func (m *Manager) isCanonical(ctx context.Context, tx kv.Tx, blockNum uint64, blockHash common.Hash, head uint64) (bool, error) {
+	if blockNum > head { // unwound, even if hash is still canonical - state of this block is gone
+		return false, nil
+	}
+	canonicalHash, ok, err := m.blockReader.CanonicalHash(ctx, tx, blockNum)
+	if err != nil {
+		return false, err
+	}
+	return ok && canonicalHash == blockHash, nil
+}
+
+// reverted - walks back from checkpoint to common ancestor with canonical chain
+func (m *Manager) reverted(ctx context.Context, tx kv.Tx, checkpoint Checkpoint, head uint64) (*Notification, error) {
+	n := &Notification{Kind: ChainReverted}
+	blockNum, blockHash := checkpoint.BlockNum, checkpoint.BlockHash
+	for len(n.Blocks) < segmentLimit {
+		canonical, err := m.isCanonical(ctx, tx, blockNum, blockHash, head)
+		if err != nil {
+			return nil, err
+		}
+		if canonical {
+			break
+		}
+		if blockNum == 0 {
+			return nil, fmt.Errorf("genesis %x is not canonical", blockHash)
+		}
+
+		block, _, err := m.blockReader.BlockWithSenders(ctx, tx, blockHash, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil { // body may be already deleted - but header is enough to revert by hash
+			header, err := m.blockReader.Header(ctx, tx, blockHash, blockNum)
+			if err != nil {
+				return nil, err
+			}
+			if header == nil {
+				return nil, fmt.Errorf("reverted block %d %x not found", blockNum, blockHash)
+			}
+			block = types.NewBlockWithHeader(header)
+		}
+		n.Blocks = append(n.Blocks, &Block{Block: block})
+		blockNum, blockHash = blockNum-1, block.ParentHash()
+	}
+	return n, nil
+}
+
+func (m *Manager) committedBlock(ctx context.Context, tx kv.TemporalTx, blockNum uint64) (*Block, error) {
+	blockHash, ok, err := m.blockReader.CanonicalHash(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("canonical hash of block %d not found", blockNum)
+	}
+	block, _, err := m.blockReader.BlockWithSenders(ctx, tx, blockHash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d %x not found", blockNum, blockHash)
+	}
+
+	blockReceipts := types.Receipts{}
+	if len(block.Transactions()) > 0 {
+		if blockReceipts, err = m.receipts.GetReceipts(ctx, m.chainConfig, tx, block); err != nil {
+			return nil, err
+		}
+	}
+	diff, err := readStateDiff(tx, m.txNumsReader, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return &Block{Block: block, Receipts: blockReceipts, Diff: diff}, nil
+}
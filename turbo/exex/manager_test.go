@@ -0,0 +1,152 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exex
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+type recordingExEx struct {
+	name          string
+	notifications []*Notification
+	fail          int // amount of next notifications to reject
+}
+
+func (r *recordingExEx) Name() string { return r.name }
+func (r *recordingExEx) OnNotification(_ context.Context, n *Notification) error {
+	if r.fail > 0 {
+		r.fail--
+		return errors.New("not ready")
+	}
+	r.notifications = append(r.notifications, n)
+	return nil
+}
+
+func blockNums(n *Notification) (res []uint64) {
+	for _, b := range n.Blocks {
+		res = append(res, b.NumberU64())
+	}
+	return res
+}
+
+func TestManager(t *testing.T) {
+	require := require.New(t)
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.Address{0xee}
+		gspec  = &types.Genesis{
+			Config:   chain.TestChainConfig,
+			GasLimit: 3141592,
+			Alloc:    types.GenesisAlloc{addr: {Balance: big.NewInt(1000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	transfer := func(coinbase byte) func(i int, gen *core.BlockGen) {
+		return func(i int, gen *core.BlockGen) {
+			gen.SetCoinbase(common.Address{coinbase})
+			txn, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, uint256.NewInt(1000), params.TxGas, nil, nil), *signer, key)
+			require.NoError(err)
+			gen.AddTx(txn)
+		}
+	}
+	canonical, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 3, transfer(1))
+	require.NoError(err)
+	require.NoError(m.InsertChain(canonical))
+
+	newManager := func() *Manager {
+		mgr := NewManager(m.DB, m.Dirs, m.Notifications.Events, m.BlockReader, m.Engine, m.ChainConfig, m.Log)
+		t.Cleanup(mgr.Close)
+		return mgr
+	}
+	mgr := newManager()
+	ex := &recordingExEx{name: "test", fail: 1}
+	require.NoError(mgr.Register(m.Ctx, ex, 1))
+	require.Error(mgr.Register(m.Ctx, ex, 1))
+	e := mgr.exexs[0]
+
+	// rejected segment is delivered again
+	require.Error(mgr.deliver(m.Ctx, e))
+	require.Equal(Checkpoint{BlockNum: 1}, e.checkpoint)
+	require.NoError(mgr.deliver(m.Ctx, e))
+	require.Len(ex.notifications, 1)
+	n := ex.notifications[0]
+	require.Equal(ChainCommitted, n.Kind)
+	require.Equal([]uint64{1, 2, 3}, blockNums(n))
+	for i, b := range n.Blocks {
+		require.Equal(canonical.Blocks[i].Hash(), b.Hash())
+		require.Len(b.Receipts, 1)
+		require.Equal(types.ReceiptStatusSuccessful, b.Receipts[0].Status)
+
+		var found bool
+		for _, d := range b.Diff.Accounts {
+			if d.Address != to {
+				continue
+			}
+			found = true
+			require.NotNil(d.Next)
+			require.Equal(uint64(1000*(i+1)), d.Next.Balance.Uint64())
+			if i == 0 {
+				require.Nil(d.Prev)
+			} else {
+				require.Equal(uint64(1000*i), d.Prev.Balance.Uint64())
+			}
+		}
+		require.True(found)
+	}
+	require.NoError(mgr.deliver(m.Ctx, e)) // nothing new
+	require.Len(ex.notifications, 1)
+
+	// checkpoint survives restart
+	mgr.Close()
+	mgr = newManager()
+	ex = &recordingExEx{name: "test"}
+	require.NoError(mgr.Register(m.Ctx, ex, 1))
+	e = mgr.exexs[0]
+	require.Equal(Checkpoint{BlockNum: 3, BlockHash: canonical.TopBlock.Hash()}, e.checkpoint)
+
+	// re-org: blocks 1..3 reverted, then fork blocks 1..5 committed
+	m2 := mock.MockWithGenesis(t, gspec, key, false)
+	fork, err := core.GenerateChain(m2.ChainConfig, m2.Genesis, m2.Engine, m2.DB, 5, transfer(2))
+	require.NoError(err)
+	require.NoError(m.InsertChain(fork))
+	require.NoError(mgr.deliver(m.Ctx, e))
+	require.Len(ex.notifications, 2)
+	reverted, committed := ex.notifications[0], ex.notifications[1]
+	require.Equal(ChainReverted, reverted.Kind)
+	require.Equal([]uint64{3, 2, 1}, blockNums(reverted))
+	require.Equal(canonical.TopBlock.Hash(), reverted.First().Hash())
+	require.Equal(ChainCommitted, committed.Kind)
+	require.Equal([]uint64{1, 2, 3, 4, 5}, blockNums(committed))
+	require.Equal(fork.TopBlock.Hash(), committed.Last().Hash())
+	require.Equal(Checkpoint{BlockNum: 5, BlockHash: fork.TopBlock.Hash()}, e.checkpoint)
+}
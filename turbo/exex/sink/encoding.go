@@ -0,0 +1,209 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sink
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/execution/eth1/eth1_utils"
+	"github.com/erigontech/erigon/turbo/exex"
+)
+
+type encoder interface {
+	header(b *exex.Block) ([]byte, error)
+	receipts(b *exex.Block) ([]byte, error)
+	stateDiff(b *exex.Block) ([]byte, error)
+}
+
+// jsonEncoder - headers and receipts in same format as eth_getBlockByNumber/eth_getBlockReceipts
+type jsonEncoder struct{}
+
+type StateDiffJson struct {
+	BlockNumber hexutil.Uint64    `json:"blockNumber"`
+	BlockHash   common.Hash       `json:"blockHash"`
+	Accounts    []AccountDiffJson `json:"accounts"`
+	Storage     []StorageDiffJson `json:"storage"`
+	Code        []CodeDiffJson    `json:"code"`
+}
+
+type AccountJson struct {
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Balance     *hexutil.Big   `json:"balance"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	Incarnation hexutil.Uint64 `json:"incarnation"`
+}
+
+type AccountDiffJson struct {
+	Address common.Address `json:"address"`
+	Prev    *AccountJson   `json:"prev"` // null - account didn't exist
+	Next    *AccountJson   `json:"next"` // null - account deleted
+}
+
+type StorageDiffJson struct {
+	Address common.Address `json:"address"`
+	Slot    common.Hash    `json:"slot"`
+	Prev    *hexutil.Big   `json:"prev"`
+	Next    *hexutil.Big   `json:"next"`
+}
+
+type CodeDiffJson struct {
+	Address common.Address `json:"address"`
+	Prev    hexutil.Bytes  `json:"prev"`
+	Next    hexutil.Bytes  `json:"next"`
+}
+
+func accountJson(a *accounts.Account) *AccountJson {
+	if a == nil {
+		return nil
+	}
+	return &AccountJson{
+		Nonce:       hexutil.Uint64(a.Nonce),
+		Balance:     (*hexutil.Big)(a.Balance.ToBig()),
+		CodeHash:    a.CodeHash,
+		Incarnation: hexutil.Uint64(a.Incarnation),
+	}
+}
+
+func (jsonEncoder) header(b *exex.Block) ([]byte, error) { return json.Marshal(b.HeaderNoCopy()) }
+func (jsonEncoder) receipts(b *exex.Block) ([]byte, error) {
+	return json.Marshal(b.Receipts)
+}
+func (jsonEncoder) stateDiff(b *exex.Block) ([]byte, error) {
+	res := StateDiffJson{
+		BlockNumber: hexutil.Uint64(b.NumberU64()),
+		BlockHash:   b.Hash(),
+		Accounts:    make([]AccountDiffJson, 0, len(b.Diff.Accounts)),
+		Storage:     make([]StorageDiffJson, 0, len(b.Diff.Storage)),
+		Code:        make([]CodeDiffJson, 0, len(b.Diff.Code)),
+	}
+	for _, d := range b.Diff.Accounts {
+		res.Accounts = append(res.Accounts, AccountDiffJson{Address: d.Address, Prev: accountJson(d.Prev), Next: accountJson(d.Next)})
+	}
+	for _, d := range b.Diff.Storage {
+		res.Storage = append(res.Storage, StorageDiffJson{Address: d.Address, Slot: d.Slot, Prev: (*hexutil.Big)(d.Prev.ToBig()), Next: (*hexutil.Big)(d.Next.ToBig())})
+	}
+	for _, d := range b.Diff.Code {
+		res.Code = append(res.Code, CodeDiffJson{Address: d.Address, Prev: d.Prev, Next: d.Next})
+	}
+	return json.Marshal(res)
+}
+
+// protoEncoder - headers as execution.Header, receipts as remote.BlockReceipts, state diffs as remote.StateChange
+// (same as rpcdaemon receives from Erigon by StateChanges stream)
+type protoEncoder struct{}
+
+func (protoEncoder) header(b *exex.Block) ([]byte, error) {
+	return proto.Marshal(eth1_utils.HeaderToHeaderRPC(b.HeaderNoCopy()))
+}
+
+func (protoEncoder) receipts(b *exex.Block) ([]byte, error) {
+	res := &remote.BlockReceipts{
+		BlockHash:   gointerfaces.ConvertHashToH256(b.Hash()),
+		BlockNumber: b.NumberU64(),
+		Receipts:    make([]*remote.Receipt, 0, len(b.Receipts)),
+	}
+	for _, r := range b.Receipts {
+		if r == nil {
+			continue
+		}
+		pr := &remote.Receipt{
+			Type:              uint64(r.Type),
+			Status:            r.Status,
+			CumulativeGasUsed: r.CumulativeGasUsed,
+			GasUsed:           r.GasUsed,
+			TransactionHash:   gointerfaces.ConvertHashToH256(r.TxHash),
+			TransactionIndex:  uint64(r.TransactionIndex),
+			Logs:              make([]*remote.SubscribeLogsReply, 0, len(r.Logs)),
+		}
+		if r.ContractAddress != (common.Address{}) {
+			pr.ContractAddress = gointerfaces.ConvertAddressToH160(r.ContractAddress)
+		}
+		for _, l := range r.Logs {
+			pl := &remote.SubscribeLogsReply{
+				Address:          gointerfaces.ConvertAddressToH160(l.Address),
+				BlockHash:        gointerfaces.ConvertHashToH256(b.Hash()),
+				BlockNumber:      b.NumberU64(),
+				Data:             l.Data,
+				LogIndex:         uint64(l.Index),
+				TransactionHash:  gointerfaces.ConvertHashToH256(r.TxHash),
+				TransactionIndex: uint64(l.TxIndex),
+			}
+			for _, topic := range l.Topics {
+				pl.Topics = append(pl.Topics, gointerfaces.ConvertHashToH256(topic))
+			}
+			pr.Logs = append(pr.Logs, pl)
+		}
+		res.Receipts = append(res.Receipts, pr)
+	}
+	return proto.Marshal(res)
+}
+
+func (protoEncoder) stateDiff(b *exex.Block) ([]byte, error) {
+	res := &remote.StateChange{
+		Direction:   remote.Direction_FORWARD,
+		BlockHeight: b.NumberU64(),
+		BlockHash:   gointerfaces.ConvertHashToH256(b.Hash()),
+		BlockTime:   b.Time(),
+	}
+	index := map[common.Address]*remote.AccountChange{}
+	change := func(addr common.Address, action remote.Action) *remote.AccountChange {
+		c, ok := index[addr]
+		if !ok {
+			c = &remote.AccountChange{Address: gointerfaces.ConvertAddressToH160(addr), Action: action}
+			index[addr] = c
+			res.Changes = append(res.Changes, c)
+		}
+		return c
+	}
+	for _, d := range b.Diff.Accounts {
+		if d.Next == nil {
+			change(d.Address, remote.Action_REMOVE)
+			continue
+		}
+		c := change(d.Address, remote.Action_UPSERT)
+		c.Incarnation = d.Next.Incarnation
+		c.Data = accounts.SerialiseV3(d.Next)
+	}
+	for _, d := range b.Diff.Code {
+		c := change(d.Address, remote.Action_CODE)
+		switch c.Action {
+		case remote.Action_UPSERT:
+			c.Action = remote.Action_UPSERT_CODE
+		case remote.Action_REMOVE:
+			continue
+		}
+		c.Code = d.Next
+	}
+	for _, d := range b.Diff.Storage {
+		c := change(d.Address, remote.Action_STORAGE)
+		if c.Action == remote.Action_REMOVE {
+			continue
+		}
+		c.StorageChanges = append(c.StorageChanges, &remote.StorageChange{
+			Location: gointerfaces.ConvertHashToH256(d.Slot),
+			Data:     d.Next.Bytes(),
+		})
+	}
+	return proto.Marshal(res)
+}
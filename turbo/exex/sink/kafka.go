@@ -0,0 +1,56 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sink
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func newKafkaPublisher(brokers []string, logger log.Logger) *kafkaPublisher {
+	return &kafkaPublisher{writer: &kafka.Writer{
+		Addr: kafka.TCP(brokers...),
+		// all messages of topic go to first partition - consumers need blocks (and re-org tombstones) in order
+		Balancer:               kafka.BalancerFunc(func(_ kafka.Message, partitions ...int) int { return partitions[0] }),
+		RequiredAcks:           kafka.RequireAll,
+		BatchSize:              1_000,
+		BatchTimeout:           10 * time.Millisecond,
+		AllowAutoTopicCreation: true,
+		ErrorLogger:            kafka.LoggerFunc(func(msg string, args ...interface{}) { logger.Debug("[stream] kafka: "+msg, args...) }),
+	}}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, msgs []Message) error {
+	kmsgs := make([]kafka.Message, len(msgs))
+	for i, m := range msgs {
+		kmsgs[i] = kafka.Message{Topic: m.Topic, Key: m.Key, Value: m.Value, Headers: make([]kafka.Header, 0, len(m.Headers))}
+		for k, v := range m.Headers {
+			kmsgs[i].Headers = append(kmsgs[i].Headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+	}
+	return p.writer.WriteMessages(ctx, kmsgs...)
+}
+
+func (p *kafkaPublisher) Close() error { return p.writer.Close() }
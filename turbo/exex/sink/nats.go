@@ -0,0 +1,63 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// natsPublisher - publishes to core NATS subjects. To persist messages - create JetStream stream with `<prefix>.>` subjects.
+// NATS has no message keys: block hash is available in HeaderBlockHash.
+type natsPublisher struct {
+	conn *nats.Conn
+}
+
+func newNatsPublisher(url string, logger log.Logger) (*natsPublisher, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("erigon"),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) { logger.Warn("[stream] nats disconnected", "err", err) }),
+		nats.ReconnectHandler(func(c *nats.Conn) { logger.Info("[stream] nats reconnected", "url", c.ConnectedUrlRedacted()) }),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("nats connect: %w", err)
+	}
+	return &natsPublisher{conn: conn}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, msgs []Message) error {
+	for _, m := range msgs {
+		msg := nats.NewMsg(m.Topic)
+		msg.Data = m.Value
+		for k, v := range m.Headers {
+			msg.Header.Set(k, v)
+		}
+		if err := p.conn.PublishMsg(msg); err != nil {
+			return err
+		}
+	}
+	return p.conn.FlushWithContext(ctx) // server received everything
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,160 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package sink - streams canonical chain events (headers, receipts, state diffs) to Kafka or NATS.
+// It's ExEx - so it has at-least-once delivery and continues from checkpoint after restart.
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/turbo/exex"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+)
+
+// Message headers - same for all topics. Value of reverted block is empty (Kafka tombstone).
+const (
+	HeaderEvent       = "erigon-event" // "committed" or "reverted"
+	HeaderBlockNumber = "erigon-block-number"
+	HeaderBlockHash   = "erigon-block-hash"
+	HeaderFormat      = "erigon-format"
+)
+
+type Config struct {
+	URL         string // kafka://broker1:9092,broker2:9092 or nats://host:4222
+	Format      string // json or protobuf
+	TopicPrefix string // topics are: <prefix>.headers, <prefix>.receipts, <prefix>.statediffs
+}
+
+type Message struct {
+	Topic   string
+	Key     []byte // block hash
+	Value   []byte // nil for reverted blocks
+	Headers map[string]string
+}
+
+// Publisher - must return only when messages are accepted by broker (to not lose them on crash)
+type Publisher interface {
+	Publish(ctx context.Context, msgs []Message) error
+	Close() error
+}
+
+type Sink struct {
+	cfg       Config
+	publisher Publisher
+	encoder   encoder
+	logger    log.Logger
+}
+
+var _ exex.ExEx = (*Sink)(nil)
+
+func New(cfg Config, logger log.Logger) (*Sink, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("stream url: %w", err)
+	}
+	var publisher Publisher
+	switch u.Scheme {
+	case "kafka":
+		publisher = newKafkaPublisher(strings.Split(u.Host, ","), logger)
+	case "nats":
+		if publisher, err = newNatsPublisher(cfg.URL, logger); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported stream url scheme: %q, expected kafka:// or nats://", u.Scheme)
+	}
+	s, err := NewWithPublisher(cfg, publisher, logger)
+	if err != nil {
+		publisher.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func NewWithPublisher(cfg Config, publisher Publisher, logger log.Logger) (*Sink, error) {
+	var enc encoder
+	switch cfg.Format {
+	case FormatJSON, "":
+		cfg.Format, enc = FormatJSON, jsonEncoder{}
+	case FormatProtobuf:
+		enc = protoEncoder{}
+	default:
+		return nil, fmt.Errorf("unsupported stream format: %q, expected %s or %s", cfg.Format, FormatJSON, FormatProtobuf)
+	}
+	if cfg.TopicPrefix == "" {
+		cfg.TopicPrefix = "erigon"
+	}
+	return &Sink{cfg: cfg, publisher: publisher, encoder: enc, logger: logger}, nil
+}
+
+func (s *Sink) Name() string { return "stream" }
+func (s *Sink) Close() error { return s.publisher.Close() }
+
+func (s *Sink) HeadersTopic() string    { return s.cfg.TopicPrefix + ".headers" }
+func (s *Sink) ReceiptsTopic() string   { return s.cfg.TopicPrefix + ".receipts" }
+func (s *Sink) StateDiffsTopic() string { return s.cfg.TopicPrefix + ".statediffs" }
+
+func (s *Sink) OnNotification(ctx context.Context, n *exex.Notification) error {
+	msgs := make([]Message, 0, 3*len(n.Blocks))
+	for _, b := range n.Blocks {
+		headers := map[string]string{
+			HeaderEvent:       n.Kind.String(),
+			HeaderBlockNumber: strconv.FormatUint(b.NumberU64(), 10),
+			HeaderBlockHash:   b.Hash().Hex(),
+			HeaderFormat:      s.cfg.Format,
+		}
+		key := []byte(b.Hash().Hex())
+		if n.Kind == exex.ChainReverted {
+			for _, topic := range []string{s.HeadersTopic(), s.ReceiptsTopic(), s.StateDiffsTopic()} {
+				msgs = append(msgs, Message{Topic: topic, Key: key, Headers: headers})
+			}
+			continue
+		}
+
+		header, err := s.encoder.header(b)
+		if err != nil {
+			return err
+		}
+		receipts, err := s.encoder.receipts(b)
+		if err != nil {
+			return err
+		}
+		diff, err := s.encoder.stateDiff(b)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs,
+			Message{Topic: s.HeadersTopic(), Key: key, Value: header, Headers: headers},
+			Message{Topic: s.ReceiptsTopic(), Key: key, Value: receipts, Headers: headers},
+			Message{Topic: s.StateDiffsTopic(), Key: key, Value: diff, Headers: headers},
+		)
+	}
+	if err := s.publisher.Publish(ctx, msgs); err != nil {
+		return err
+	}
+	s.logger.Debug("[stream] published", "event", n.Kind, "from", n.First().NumberU64(), "to", n.Last().NumberU64(), "msgs", len(msgs))
+	return nil
+}
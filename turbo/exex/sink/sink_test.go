@@ -0,0 +1,151 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/types/accounts"
+	"github.com/erigontech/erigon/turbo/exex"
+)
+
+type memPublisher struct{ msgs []Message }
+
+func (p *memPublisher) Publish(_ context.Context, msgs []Message) error {
+	p.msgs = append(p.msgs, msgs...)
+	return nil
+}
+func (p *memPublisher) Close() error { return nil }
+
+func testBlock() *exex.Block {
+	addr, contract := common.Address{1}, common.Address{2}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(7), Difficulty: big.NewInt(1), GasLimit: 30_000_000, Time: 77})
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, GasUsed: 21000, TxHash: common.Hash{3},
+		Logs: []*types.Log{{Address: contract, Topics: []common.Hash{{4}}, Data: []byte{5}}}}
+	return &exex.Block{
+		Block:    block,
+		Receipts: types.Receipts{receipt},
+		Diff: &exex.StateDiff{
+			Accounts: []exex.AccountDiff{
+				{Address: addr, Prev: &accounts.Account{Nonce: 1, Balance: *uint256.NewInt(100)}, Next: &accounts.Account{Nonce: 2, Balance: *uint256.NewInt(50)}},
+				{Address: contract, Next: &accounts.Account{Nonce: 1, Incarnation: 1}},
+			},
+			Storage: []exex.StorageDiff{{Address: contract, Slot: common.Hash{9}, Next: *uint256.NewInt(42)}},
+			Code:    []exex.CodeDiff{{Address: contract, Next: []byte{0x60, 0x00}}},
+		},
+	}
+}
+
+func TestSinkJSON(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	p := &memPublisher{}
+	s, err := NewWithPublisher(Config{}, p, log.New())
+	require.NoError(err)
+	b := testBlock()
+
+	require.NoError(s.OnNotification(ctx, &exex.Notification{Kind: exex.ChainCommitted, Blocks: []*exex.Block{b}}))
+	require.Len(p.msgs, 3)
+	require.Equal([]string{"erigon.headers", "erigon.receipts", "erigon.statediffs"}, []string{p.msgs[0].Topic, p.msgs[1].Topic, p.msgs[2].Topic})
+	for _, m := range p.msgs {
+		require.Equal([]byte(b.Hash().Hex()), m.Key)
+		require.Equal("committed", m.Headers[HeaderEvent])
+		require.Equal("7", m.Headers[HeaderBlockNumber])
+		require.Equal(FormatJSON, m.Headers[HeaderFormat])
+	}
+
+	var header types.Header
+	require.NoError(json.Unmarshal(p.msgs[0].Value, &header))
+	require.Equal(b.Hash(), header.Hash())
+
+	var receipts []*types.Receipt
+	require.NoError(json.Unmarshal(p.msgs[1].Value, &receipts))
+	require.Len(receipts, 1)
+	require.Len(receipts[0].Logs, 1)
+
+	var diff StateDiffJson
+	require.NoError(json.Unmarshal(p.msgs[2].Value, &diff))
+	require.Equal(b.Hash(), diff.BlockHash)
+	require.Len(diff.Accounts, 2)
+	require.Equal(uint64(50), diff.Accounts[0].Next.Balance.ToInt().Uint64())
+	require.Nil(diff.Accounts[1].Prev)
+	require.Equal(uint64(42), diff.Storage[0].Next.ToInt().Uint64())
+	require.Equal([]byte{0x60, 0x00}, []byte(diff.Code[0].Next))
+
+	// re-org: tombstones for every topic
+	p.msgs = nil
+	require.NoError(s.OnNotification(ctx, &exex.Notification{Kind: exex.ChainReverted, Blocks: []*exex.Block{{Block: b.Block}}}))
+	require.Len(p.msgs, 3)
+	for _, m := range p.msgs {
+		require.Nil(m.Value)
+		require.Equal([]byte(b.Hash().Hex()), m.Key)
+		require.Equal("reverted", m.Headers[HeaderEvent])
+	}
+}
+
+func TestSinkProtobuf(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	p := &memPublisher{}
+	s, err := NewWithPublisher(Config{Format: FormatProtobuf, TopicPrefix: "mainnet"}, p, log.New())
+	require.NoError(err)
+	b := testBlock()
+	require.NoError(s.OnNotification(ctx, &exex.Notification{Kind: exex.ChainCommitted, Blocks: []*exex.Block{b}}))
+	require.Len(p.msgs, 3)
+	require.Equal("mainnet.headers", p.msgs[0].Topic)
+
+	var header execution.Header
+	require.NoError(proto.Unmarshal(p.msgs[0].Value, &header))
+	require.Equal(uint64(7), header.BlockNumber)
+	require.Equal(b.Hash(), common.Hash(gointerfaces.ConvertH256ToHash(header.BlockHash)))
+
+	var receipts remote.BlockReceipts
+	require.NoError(proto.Unmarshal(p.msgs[1].Value, &receipts))
+	require.Len(receipts.Receipts, 1)
+	require.Equal(uint64(21000), receipts.Receipts[0].GasUsed)
+	require.Len(receipts.Receipts[0].Logs, 1)
+	require.Equal(common.Address{2}, common.Address(gointerfaces.ConvertH160toAddress(receipts.Receipts[0].Logs[0].Address)))
+
+	var diff remote.StateChange
+	require.NoError(proto.Unmarshal(p.msgs[2].Value, &diff))
+	require.Equal(remote.Direction_FORWARD, diff.Direction)
+	require.Len(diff.Changes, 2)
+	require.Equal(remote.Action_UPSERT, diff.Changes[0].Action)
+	var acc accounts.Account
+	require.NoError(accounts.DeserialiseV3(&acc, diff.Changes[0].Data))
+	require.Equal(uint64(2), acc.Nonce)
+	require.Equal(remote.Action_UPSERT_CODE, diff.Changes[1].Action)
+	require.Equal([]byte{0x60, 0x00}, diff.Changes[1].Code)
+	require.Len(diff.Changes[1].StorageChanges, 1)
+	require.Equal([]byte{42}, diff.Changes[1].StorageChanges[0].Data)
+
+	_, err = NewWithPublisher(Config{Format: "xml"}, p, log.New())
+	require.Error(err)
+	_, err = New(Config{URL: "redis://localhost"}, log.New())
+	require.Error(err)
+}
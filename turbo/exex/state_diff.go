@@ -0,0 +1,109 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exex
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/types/accounts"
+)
+
+// readStateDiff - builds diff of block from history: HistoryRange gives keys changed by block with values before
+// block, GetAsOf(maxTxNum+1) gives values after block.
+func readStateDiff(tx kv.TemporalTx, txNumsReader rawdbv3.TxNumsReader, blockNum uint64) (*StateDiff, error) {
+	minTxNum, err := txNumsReader.Min(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	maxTxNum, err := txNumsReader.Max(tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &StateDiff{}
+	if err := forEachChange(tx, kv.AccountsDomain, minTxNum, maxTxNum+1, func(k, prev, next []byte) error {
+		d := AccountDiff{Address: common.BytesToAddress(k)}
+		if d.Prev, err = decodeAccount(prev); err != nil {
+			return err
+		}
+		if d.Next, err = decodeAccount(next); err != nil {
+			return err
+		}
+		diff.Accounts = append(diff.Accounts, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := forEachChange(tx, kv.StorageDomain, minTxNum, maxTxNum+1, func(k, prev, next []byte) error {
+		if len(k) != length.Addr+length.Hash {
+			return fmt.Errorf("unexpected storage key length: %d", len(k))
+		}
+		d := StorageDiff{Address: common.BytesToAddress(k[:length.Addr]), Slot: common.BytesToHash(k[length.Addr:])}
+		d.Prev.SetBytes(prev)
+		d.Next.SetBytes(next)
+		diff.Storage = append(diff.Storage, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := forEachChange(tx, kv.CodeDomain, minTxNum, maxTxNum+1, func(k, prev, next []byte) error {
+		diff.Code = append(diff.Code, CodeDiff{Address: common.BytesToAddress(k), Prev: prev, Next: next})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// forEachChange - calls `f` for every key changed at [fromTxNum, toTxNum). `next` is value as of toTxNum.
+func forEachChange(tx kv.TemporalTx, domain kv.Domain, fromTxNum, toTxNum uint64, f func(k, prev, next []byte) error) error {
+	it, err := tx.HistoryRange(domain, int(fromTxNum), int(toTxNum), order.Asc, kv.Unlim)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.HasNext() {
+		k, prev, err := it.Next()
+		if err != nil {
+			return err
+		}
+		next, _, err := tx.GetAsOf(domain, k, toTxNum)
+		if err != nil {
+			return err
+		}
+		if err := f(common.Copy(k), common.Copy(prev), common.Copy(next)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeAccount(v []byte) (*accounts.Account, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	var a accounts.Account
+	if err := accounts.DeserialiseV3(&a, v); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -69,6 +69,11 @@ var (
 		Value: log.LvlInfo.String(),
 	}
 
+	LogVmoduleFlag = cli.StringFlag{
+		Name:  "vmodule",
+		Usage: "Per-module verbosity: comma-separated list of <module>=<level> (e.g. eth/*=5,p2p=4). Can be changed at runtime by admin_setLogLevel",
+	}
+
 	LogBlockDelayFlag = cli.BoolFlag{
 		Name:  "log.delays",
 		Usage: "Enable block delay logging",
@@ -85,5 +90,6 @@ var Flags = []cli.Flag{
 	&LogDirPathFlag,
 	&LogDirPrefixFlag,
 	&LogDirVerbosityFlag,
+	&LogVmoduleFlag,
 	&LogBlockDelayFlag,
 }
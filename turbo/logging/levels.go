@@ -0,0 +1,228 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+var ErrLoggingNotInitialised = errors.New("logging is not initialised")
+
+// rootLevels - levels of root logger, can be changed at runtime by admin_setLogLevel
+var rootLevels atomic.Pointer[logLevels]
+
+// moduleLevel - verbosity of module: package path relative to repo root (like "p2p", "eth/stagedsync", "kv/mdbx" for erigon-lib).
+// Pattern matches module itself and all sub-packages, "eth/*" is same as "eth".
+type moduleLevel struct {
+	pattern string
+	lvl     log.Lvl
+}
+
+type moduleLevels struct {
+	levels []moduleLevel // longest pattern first
+	minLvl log.Lvl
+	maxLvl log.Lvl
+}
+
+type logLevels struct {
+	console, dir                     atomic.Int64
+	configuredConsole, configuredDir log.Lvl
+
+	mu      sync.Mutex // serialises modules updates
+	modules atomic.Pointer[moduleLevels]
+}
+
+func newLogLevels(console, dir log.Lvl) *logLevels {
+	l := &logLevels{configuredConsole: console, configuredDir: dir}
+	l.console.Store(int64(console))
+	l.dir.Store(int64(dir))
+	return l
+}
+
+// handler - sends record to console and dir (can be nil) handlers if it passes their level or level of module it was logged from.
+// Caller's module is resolved only if record level is in range of module levels.
+func (l *logLevels) handler(console, dir log.Handler) log.Handler {
+	return log.FuncHandler(func(r *log.Record) error {
+		consoleLvl, dirLvl := log.Lvl(l.console.Load()), log.Lvl(l.dir.Load())
+		if m := l.modules.Load(); m != nil && r.Lvl > min(m.minLvl, consoleLvl, dirLvl) && r.Lvl <= max(m.maxLvl, consoleLvl, dirLvl) {
+			if lvl, ok := m.lookup(callerModule()); ok {
+				consoleLvl, dirLvl = lvl, lvl
+			}
+		}
+		if r.Lvl <= consoleLvl {
+			_ = console.Log(r)
+		}
+		if dir != nil && r.Lvl <= dirLvl {
+			_ = dir.Log(r)
+		}
+		return nil
+	})
+}
+
+func (l *logLevels) setModule(pattern string, lvl log.Lvl, reset bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var levels []moduleLevel
+	if m := l.modules.Load(); m != nil {
+		for _, ml := range m.levels {
+			if ml.pattern != pattern {
+				levels = append(levels, ml)
+			}
+		}
+	}
+	if !reset {
+		levels = append(levels, moduleLevel{pattern: pattern, lvl: lvl})
+	}
+	l.modules.Store(newModuleLevels(levels))
+}
+
+func newModuleLevels(levels []moduleLevel) *moduleLevels {
+	if len(levels) == 0 {
+		return nil
+	}
+	sort.Slice(levels, func(i, j int) bool { return len(levels[i].pattern) > len(levels[j].pattern) })
+	m := &moduleLevels{levels: levels, minLvl: levels[0].lvl, maxLvl: levels[0].lvl}
+	for _, ml := range levels[1:] {
+		m.minLvl, m.maxLvl = min(m.minLvl, ml.lvl), max(m.maxLvl, ml.lvl)
+	}
+	return m
+}
+
+func (m *moduleLevels) lookup(module string) (log.Lvl, bool) {
+	for _, ml := range m.levels {
+		if module == ml.pattern || strings.HasPrefix(module, ml.pattern+"/") {
+			return ml.lvl, true
+		}
+	}
+	return 0, false
+}
+
+var modulePrefixes = []string{"github.com/erigontech/erigon-lib/", "github.com/erigontech/erigon-db/", "github.com/erigontech/erigon/"}
+
+// callerModule - module of first function in stack which is not logger itself
+func callerModule() string {
+	var pcs [24]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		f, more := frames.Next()
+		pkg := funcPackage(f.Function)
+		if pkg != "github.com/erigontech/erigon-lib/log/v3" && pkg != "github.com/erigontech/erigon/turbo/logging" {
+			for _, prefix := range modulePrefixes {
+				if strings.HasPrefix(pkg, prefix) {
+					return pkg[len(prefix):]
+				}
+			}
+			return pkg
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// funcPackage - "github.com/erigontech/erigon/p2p/discover.(*UDPv4).loop" -> "github.com/erigontech/erigon/p2p/discover"
+func funcPackage(fn string) string {
+	lastSlash := strings.LastIndexByte(fn, '/')
+	if dot := strings.IndexByte(fn[lastSlash+1:], '.'); dot >= 0 {
+		return fn[:lastSlash+1+dot]
+	}
+	return fn
+}
+
+func normalizePattern(pattern string) string {
+	return strings.TrimSuffix(strings.Trim(strings.TrimSpace(pattern), "/"), "/*")
+}
+
+// SetLogLevel - changes verbosity of module logs (for both console and dir) without restart.
+// Empty module changes verbosity of console and dir logs.
+func SetLogLevel(module string, lvl log.Lvl) error {
+	l := rootLevels.Load()
+	if l == nil {
+		return ErrLoggingNotInitialised
+	}
+	if module = normalizePattern(module); module == "" {
+		l.console.Store(int64(lvl))
+		l.dir.Store(int64(lvl))
+		return nil
+	}
+	l.setModule(module, lvl, false)
+	return nil
+}
+
+// ResetLogLevel - back to verbosity from command line: removes module override, or for empty module - resets console and dir levels
+func ResetLogLevel(module string) error {
+	l := rootLevels.Load()
+	if l == nil {
+		return ErrLoggingNotInitialised
+	}
+	if module = normalizePattern(module); module == "" {
+		l.console.Store(int64(l.configuredConsole))
+		l.dir.Store(int64(l.configuredDir))
+		return nil
+	}
+	l.setModule(module, 0, true)
+	return nil
+}
+
+// SetModuleLevels - applies comma-separated list of <module>=<level> (e.g. eth/*=5,p2p=debug)
+func SetModuleLevels(spec string) error {
+	for _, item := range strings.Split(spec, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		module, level, ok := strings.Cut(item, "=")
+		if !ok || normalizePattern(module) == "" {
+			return fmt.Errorf("invalid module verbosity %q, expected <module>=<level>", item)
+		}
+		lvl, err := tryGetLogLevel(strings.TrimSpace(level))
+		if err != nil {
+			return fmt.Errorf("invalid module verbosity %q: %w", item, err)
+		}
+		if err := SetLogLevel(module, lvl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LogLevels - current levels of console, dir and modules
+func LogLevels() (console, dir log.Lvl, modules map[string]log.Lvl, err error) {
+	l := rootLevels.Load()
+	if l == nil {
+		return 0, 0, nil, ErrLoggingNotInitialised
+	}
+	modules = map[string]log.Lvl{}
+	if m := l.modules.Load(); m != nil {
+		for _, ml := range m.levels {
+			modules[ml.pattern] = ml.lvl
+		}
+	}
+	return log.Lvl(l.console.Load()), log.Lvl(l.dir.Load()), modules, nil
+}
+
+// ParseLogLevel - level by name ("debug") or number ("4")
+func ParseLogLevel(s string) (log.Lvl, error) {
+	return tryGetLogLevel(s)
+}
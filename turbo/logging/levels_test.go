@@ -0,0 +1,116 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestFuncPackage(t *testing.T) {
+	require.Equal(t, "github.com/erigontech/erigon/p2p/discover", funcPackage("github.com/erigontech/erigon/p2p/discover.(*UDPv4).loop.func1"))
+	require.Equal(t, "main", funcPackage("main.main"))
+}
+
+func TestModuleLevels(t *testing.T) {
+	m := newModuleLevels([]moduleLevel{
+		{pattern: "eth", lvl: log.LvlDebug},
+		{pattern: "eth/stagedsync", lvl: log.LvlTrace},
+		{pattern: "p2p", lvl: log.LvlError},
+	})
+	require.Equal(t, log.LvlError, m.minLvl)
+	require.Equal(t, log.LvlTrace, m.maxLvl)
+
+	for module, expected := range map[string]log.Lvl{
+		"eth":                   log.LvlDebug,
+		"eth/filters":           log.LvlDebug,
+		"eth/stagedsync":        log.LvlTrace,
+		"eth/stagedsync/stages": log.LvlTrace,
+		"p2p/discover":          log.LvlError,
+	} {
+		lvl, ok := m.lookup(module)
+		require.True(t, ok, module)
+		require.Equal(t, expected, lvl, module)
+	}
+	for _, module := range []string{"ethdb", "p2pnet", "kv/mdbx", ""} {
+		_, ok := m.lookup(module)
+		require.False(t, ok, module)
+	}
+}
+
+func TestSetModuleLevels(t *testing.T) {
+	prev := rootLevels.Load()
+	defer rootLevels.Store(prev)
+	rootLevels.Store(newLogLevels(log.LvlInfo, log.LvlWarn))
+
+	require.NoError(t, SetModuleLevels("eth/*=5, p2p=warn,"))
+	require.Error(t, SetModuleLevels("p2p"))
+	require.Error(t, SetModuleLevels("=debug"))
+	require.Error(t, SetModuleLevels("p2p=loud"))
+
+	console, dir, modules, err := LogLevels()
+	require.NoError(t, err)
+	require.Equal(t, log.LvlInfo, console)
+	require.Equal(t, log.LvlWarn, dir)
+	require.Equal(t, map[string]log.Lvl{"eth": log.LvlTrace, "p2p": log.LvlWarn}, modules)
+
+	require.NoError(t, SetLogLevel("", log.LvlDebug))
+	require.NoError(t, ResetLogLevel("eth"))
+	console, dir, modules, err = LogLevels()
+	require.NoError(t, err)
+	require.Equal(t, log.LvlDebug, console)
+	require.Equal(t, log.LvlDebug, dir)
+	require.Equal(t, map[string]log.Lvl{"p2p": log.LvlWarn}, modules)
+
+	require.NoError(t, ResetLogLevel(""))
+	console, dir, _, err = LogLevels()
+	require.NoError(t, err)
+	require.Equal(t, log.LvlInfo, console)
+	require.Equal(t, log.LvlWarn, dir)
+}
+
+func TestLevelsHandler(t *testing.T) {
+	var console, dir []string
+	l := newLogLevels(log.LvlInfo, log.LvlDebug)
+	logger := log.New()
+	logger.SetHandler(l.handler(
+		log.FuncHandler(func(r *log.Record) error { console = append(console, r.Msg); return nil }),
+		log.FuncHandler(func(r *log.Record) error { dir = append(dir, r.Msg); return nil }),
+	))
+	logger.Info("info")
+	logger.Debug("debug")
+	logger.Trace("trace")
+	require.Equal(t, []string{"info"}, console)
+	require.Equal(t, []string{"info", "debug"}, dir)
+
+	// frames of logging package are skipped: test function is called from "testing" package
+	console, dir = nil, nil
+	l.setModule("testing", log.LvlTrace, false)
+	logger.Trace("trace")
+	require.Equal(t, []string{"trace"}, console)
+	require.Equal(t, []string{"trace"}, dir)
+
+	console, dir = nil, nil
+	l.setModule("testing", log.LvlError, false)
+	logger.Info("info")
+	logger.Error("error")
+	require.Equal(t, []string{"error"}, console)
+	require.Equal(t, []string{"error"}, dir)
+}
@@ -101,6 +101,11 @@ func SetupLoggerCtx(filePrefix string, ctx *cli.Context,
 	}
 
 	initSeparatedLogging(logger, filePrefix, dirPath, consoleLevel, dirLevel, consoleJson, dirJson)
+	if rootHandler {
+		if err := SetModuleLevels(ctx.String(LogVmoduleFlag.Name)); err != nil {
+			logger.Warn("invalid --"+LogVmoduleFlag.Name, "err", err)
+		}
+	}
 	return logger
 }
 
@@ -163,6 +168,11 @@ func SetupLoggerCmd(filePrefix string, cmd *cobra.Command) log.Logger {
 	}
 
 	initSeparatedLogging(log.Root(), filePrefix, dirPath, consoleLevel, dirLevel, consoleJson, dirJson)
+	if vmodule := cmd.Flags().Lookup(LogVmoduleFlag.Name); vmodule != nil {
+		if err := SetModuleLevels(vmodule.Value.String()); err != nil {
+			log.Warn("invalid --"+LogVmoduleFlag.Name, "err", err)
+		}
+	}
 	return log.Root()
 }
 
@@ -216,14 +226,19 @@ func initSeparatedLogging(
 	consoleJson bool,
 	dirJson bool) {
 
+	levels := newLogLevels(consoleLevel, dirLevel)
+	if logger == log.Root() {
+		rootLevels.Store(levels)
+	}
+
 	var consoleHandler log.Handler
 
 	if consoleJson {
-		consoleHandler = log.LvlFilterHandler(consoleLevel, log.StreamHandler(os.Stderr, log.JsonFormat()))
+		consoleHandler = log.StreamHandler(os.Stderr, log.JsonFormat())
 	} else {
-		consoleHandler = log.LvlFilterHandler(consoleLevel, log.StderrHandler)
+		consoleHandler = log.StderrHandler
 	}
-	logger.SetHandler(consoleHandler)
+	logger.SetHandler(levels.handler(consoleHandler, nil))
 
 	if len(dirPath) == 0 {
 		logger.Info("console logging only")
@@ -249,8 +264,7 @@ func initSeparatedLogging(
 	}
 	userLog := log.StreamHandler(lumberjack, dirFormat)
 
-	mux := log.MultiHandler(consoleHandler, log.LvlFilterHandler(dirLevel, userLog))
-	logger.SetHandler(mux)
+	logger.SetHandler(levels.handler(consoleHandler, userLog))
 	logger.Info("logging to file system", "log dir", dirPath, "file prefix", filePrefix, "log level", dirLevel, "json", dirJson)
 }
 
@@ -0,0 +1,176 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/tracers"
+	erigoncli "github.com/erigontech/erigon/turbo/cli"
+)
+
+// isolatedFlag - every chain of the process which uses the flag must have own value of it: dirs and listening ports
+type isolatedFlag struct {
+	name string
+	used func(ctx *cli.Context) bool // nil - always used
+}
+
+var isolatedFlags = []isolatedFlag{
+	{name: utils.DataDirFlag.Name},
+	{name: utils.ListenPortFlag.Name},
+	{name: utils.P2pProtocolAllowedPorts.Name},
+	{name: utils.TorrentPortFlag.Name},
+	{name: utils.HTTPPortFlag.Name},
+	{name: utils.WSPortFlag.Name, used: func(ctx *cli.Context) bool {
+		return ctx.Bool(utils.WSEnabledFlag.Name) && ctx.Int(utils.WSPortFlag.Name) != ctx.Int(utils.HTTPPortFlag.Name)
+	}},
+	{name: utils.AuthRpcPort.Name},
+	{name: erigoncli.PrivateApiAddr.Name},
+	{name: utils.CaplinDiscoveryPortFlag.Name},
+	{name: utils.CaplinDiscoveryTCPPortFlag.Name},
+	{name: utils.SentinelPortFlag.Name},
+	{name: utils.BeaconApiPortFlag.Name, used: func(ctx *cli.Context) bool {
+		return len(ctx.StringSlice(utils.BeaconAPIFlag.Name)) > 0 && !ctx.Bool(utils.CaplinUseEngineApiFlag.Name)
+	}},
+	{name: utils.DownloaderAddrFlag.Name, used: func(ctx *cli.Context) bool { // empty - downloader of the chain's node
+		return ctx.String(utils.DownloaderAddrFlag.Name) != ""
+	}},
+}
+
+// processFlags - endpoints of the process, shared by all chains: metrics of all chains are in one registry
+var processFlags = []string{
+	utils.MetricsEnabledFlag.Name,
+	utils.MetricsHTTPFlag.Name,
+	utils.MetricsPortFlag.Name,
+	"pprof",
+	"pprof.addr",
+	"pprof.port",
+}
+
+// Chain - one chain of the multi-chain mode
+type Chain struct {
+	File string
+	Ctx  *cli.Context
+}
+
+// ChainsFromFlags - chains of --chains.config, nil if the flag is not set
+func ChainsFromFlags(ctx *cli.Context) ([]Chain, error) {
+	files := ctx.StringSlice(utils.ChainsConfigFlag.Name)
+	if len(files) == 0 {
+		return nil, nil
+	}
+	chains := make([]Chain, 0, len(files))
+	for _, file := range files {
+		chainCtx, err := erigoncli.ChainContext(ctx, file, utils.ChainsConfigFlag.Name, utils.ConfigFlag.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range processFlags {
+			if chainCtx.String(name) != ctx.String(name) {
+				return nil, fmt.Errorf("%s: --%s is shared by all chains, set it in command line", file, name)
+			}
+		}
+		chains = append(chains, Chain{File: file, Ctx: chainCtx})
+	}
+	if err := checkIsolation(chains); err != nil {
+		return nil, err
+	}
+	return chains, nil
+}
+
+func checkIsolation(chains []Chain) error {
+	for _, f := range isolatedFlags {
+		seen := map[string]string{}
+		for _, c := range chains {
+			if f.used != nil && !f.used(c.Ctx) {
+				continue
+			}
+			values := []string{c.Ctx.String(f.name)}
+			switch f.name {
+			case utils.DataDirFlag.Name:
+				values = []string{datadir.New(values[0]).DataDir}
+			case utils.P2pProtocolAllowedPorts.Name: // chains must not pick ports of each other
+				values = values[:0]
+				for _, port := range c.Ctx.UintSlice(f.name) {
+					if port != 0 { // ephemeral
+						values = append(values, strconv.FormatUint(uint64(port), 10))
+					}
+				}
+			}
+			for _, v := range values {
+				if other, ok := seen[v]; ok && other != c.File {
+					return fmt.Errorf("--%s=%s is used by chains of %s and %s, set own value in each file", f.name, v, other, c.File)
+				}
+				seen[v] = c.File
+			}
+		}
+	}
+	return nil
+}
+
+// NewChains - creates and starts node of every chain. Nodes are started one by one, so that sentries of
+// each see the ports of the previous ones taken.
+func NewChains(chains []Chain, logger log.Logger, tracer *tracers.Tracer) ([]*ErigonNode, error) {
+	nodes := make([]*ErigonNode, 0, len(chains))
+	closeAll := func() {
+		for _, n := range nodes {
+			n.Close()
+		}
+	}
+	for _, c := range chains {
+		chainLogger := logger.New("chain", c.Ctx.String(utils.ChainFlag.Name))
+		nodeCfg, err := NewNodConfigUrfave(c.Ctx, chainLogger)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("%s: %w", c.File, err)
+		}
+		if err := datadir.ApplyMigrations(nodeCfg.Dirs); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("%s: %w", c.File, err)
+		}
+		ethCfg := NewEthConfigUrfave(c.Ctx, nodeCfg, chainLogger)
+		n, err := New(c.Ctx.Context, nodeCfg, ethCfg, chainLogger, tracer)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("%s: %w", c.File, err)
+		}
+		n.run()
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// ServeChains - blocks until nodes of all chains exit
+func ServeChains(nodes []*ErigonNode) {
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer n.Close()
+			n.stack.Wait()
+		}()
+	}
+	wg.Wait()
+}
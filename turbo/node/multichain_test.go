@@ -0,0 +1,159 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package node
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/cmd/utils"
+	erigoncli "github.com/erigontech/erigon/turbo/cli"
+)
+
+func testChainsContext(t *testing.T, args ...string) *cli.Context {
+	app := &cli.App{Name: "erigon", Flags: []cli.Flag{
+		&utils.ChainsConfigFlag, &utils.ConfigFlag, &utils.ChainFlag, &utils.DataDirFlag,
+		&utils.ListenPortFlag, &utils.P2pProtocolAllowedPorts, &utils.TorrentPortFlag,
+		&utils.HTTPPortFlag, &utils.WSEnabledFlag, &utils.WSPortFlag, &utils.AuthRpcPort, &erigoncli.PrivateApiAddr,
+		&utils.CaplinDiscoveryPortFlag, &utils.CaplinDiscoveryTCPPortFlag, &utils.SentinelPortFlag,
+		&utils.BeaconAPIFlag, &utils.BeaconApiPortFlag, &utils.CaplinUseEngineApiFlag, &utils.DownloaderAddrFlag,
+		&utils.MetricsEnabledFlag, &utils.MetricsHTTPFlag, &utils.MetricsPortFlag,
+	}}
+	set := flag.NewFlagSet(app.Name, flag.ContinueOnError)
+	for _, f := range app.Flags {
+		require.NoError(t, f.Apply(set))
+	}
+	require.NoError(t, set.Parse(args))
+	ctx := cli.NewContext(app, set, nil)
+	ctx.Context = context.Background()
+	return ctx
+}
+
+func writeChainConfig(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// chainConfig - TOML config of chain n, which sets own value of every isolated flag; values of extra override them
+func chainConfig(n int, extra map[string]string) string {
+	values := map[string]string{
+		"datadir":                  `"/data/chain` + strconv.Itoa(n) + `"`,
+		"port":                     strconv.Itoa(30303 + 10*n),
+		"p2p.allowed-ports":        "[" + strconv.Itoa(30303+10*n) + "]",
+		"torrent.port":             strconv.Itoa(42069 + 10*n),
+		"http.port":                strconv.Itoa(8545 + 10*n),
+		"authrpc.port":             strconv.Itoa(8551 + 10*n),
+		"private.api.addr":         `"127.0.0.1:` + strconv.Itoa(9090+10*n) + `"`,
+		"caplin.discovery.port":    strconv.Itoa(4000 + 10*n),
+		"caplin.discovery.tcpport": strconv.Itoa(4001 + 10*n),
+		"sentinel.port":            strconv.Itoa(7777 + 10*n),
+	}
+	maps.Copy(values, extra)
+	var sb strings.Builder
+	for k, v := range values {
+		fmt.Fprintf(&sb, "%q = %s\n", k, v)
+	}
+	return sb.String()
+}
+
+func TestChainsFromFlags(t *testing.T) {
+	require := require.New(t)
+	dir := t.TempDir()
+
+	chains, err := ChainsFromFlags(testChainsContext(t))
+	require.NoError(err)
+	require.Nil(chains)
+
+	a := writeChainConfig(t, dir, "a.toml", chainConfig(0, map[string]string{"chain": `"mainnet"`}))
+	b := writeChainConfig(t, dir, "b.toml", chainConfig(1, map[string]string{"chain": `"holesky"`}))
+	chains, err = ChainsFromFlags(testChainsContext(t, "--chains.config", a, "--chains.config", b, "--http.port", "1234"))
+	require.NoError(err)
+	require.Len(chains, 2)
+	require.Equal(a, chains[0].File)
+	require.Equal("mainnet", chains[0].Ctx.String(utils.ChainFlag.Name))
+	require.Equal("holesky", chains[1].Ctx.String(utils.ChainFlag.Name))
+	require.Equal([]uint{30313}, chains[1].Ctx.UintSlice(utils.P2pProtocolAllowedPorts.Name))
+	// command line is shared, file overrides it
+	require.Equal(8545, chains[0].Ctx.Int(utils.HTTPPortFlag.Name))
+
+	// metrics endpoint is process-wide
+	c := writeChainConfig(t, dir, "c.toml", chainConfig(1, map[string]string{"metrics.port": "7000"}))
+	_, err = ChainsFromFlags(testChainsContext(t, "--chains.config", a, "--chains.config", c, "--metrics.port", "6000"))
+	require.ErrorContains(err, "--metrics.port is shared by all chains")
+	_, err = ChainsFromFlags(testChainsContext(t, "--chains.config", a, "--chains.config", b, "--metrics.port", "6000"))
+	require.NoError(err)
+
+	// default of isolated flag is shared by both chains
+	d := writeChainConfig(t, dir, "d.toml", `datadir = "/data/chain3"`)
+	_, err = ChainsFromFlags(testChainsContext(t, "--chains.config", a, "--chains.config", d))
+	require.ErrorContains(err, "is used by chains of")
+}
+
+func TestCheckIsolation(t *testing.T) {
+	dir := t.TempDir()
+	chains := func(extraA, extraB map[string]string) []Chain {
+		a := writeChainConfig(t, dir, "a.toml", chainConfig(0, extraA))
+		b := writeChainConfig(t, dir, "b.toml", chainConfig(1, extraB))
+		res, err := ChainsFromFlags(testChainsContext(t, "--chains.config", a))
+		require.NoError(t, err)
+		other, err := ChainsFromFlags(testChainsContext(t, "--chains.config", b))
+		require.NoError(t, err)
+		return append(res, other...)
+	}
+	ws := func(port string) map[string]string { return map[string]string{"ws": "true", "ws.port": port} }
+	beaconAPI := map[string]string{"beacon.api": `["beacon"]`}
+
+	for _, tt := range []struct {
+		name           string
+		extraA, extraB map[string]string
+		err            string
+	}{
+		{name: "isolated"},
+		{name: "same datadir", extraB: map[string]string{"datadir": `"/data/chain0/"`}, err: "--datadir=/data/chain0"},
+		{name: "overlapping allowed ports", extraB: map[string]string{"p2p.allowed-ports": "[30313, 30303]"}, err: "--p2p.allowed-ports=30303"},
+		{name: "ephemeral allowed ports", extraA: map[string]string{"p2p.allowed-ports": "[0]"}, extraB: map[string]string{"p2p.allowed-ports": "[0]"}},
+		{name: "ws disabled", extraA: map[string]string{"ws.port": "8546"}, extraB: map[string]string{"ws.port": "8546"}},
+		{name: "ws on http port", extraA: ws("8545"), extraB: ws("8555")},
+		{name: "same ws port", extraA: ws("8546"), extraB: ws("8546"), err: "--ws.port=8546"},
+		{name: "beacon api disabled", extraA: map[string]string{"beacon.api.port": "5555"}},
+		{name: "same beacon api port", extraA: beaconAPI, extraB: beaconAPI, err: "--beacon.api.port=5555"},
+		{name: "beacon api off with engine api", extraA: beaconAPI,
+			extraB: map[string]string{"beacon.api": `["beacon"]`, "caplin.use-engine-api": "true"}},
+		{name: "same external downloader", extraA: map[string]string{"downloader.api.addr": `"127.0.0.1:9093"`},
+			extraB: map[string]string{"downloader.api.addr": `"127.0.0.1:9093"`}, err: "--downloader.api.addr=127.0.0.1:9093"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkIsolation(chains(tt.extraA, tt.extraB))
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -87,6 +87,9 @@ type Params struct {
 }
 
 func NewNodConfigUrfave(ctx *cli.Context, logger log.Logger) (*nodecfg.Config, error) {
+	if err := utils.LoadCustomChains(ctx, logger); err != nil {
+		return nil, err
+	}
 	// If we're running a known preset, log it for convenience.
 	chain := ctx.String(utils.ChainFlag.Name)
 	switch chain {
@@ -113,7 +116,11 @@ func NewNodConfigUrfave(ctx *cli.Context, logger log.Logger) (*nodecfg.Config, e
 			logger.Info("Starting Erigon on Ethereum mainnet...")
 		}
 	default:
-		logger.Info("Starting Erigon on", "devnet", chain)
+		if params.CustomChainByName(chain) != nil {
+			logger.Info("Starting Erigon on", "custom chain", chain)
+		} else {
+			logger.Info("Starting Erigon on", "devnet", chain)
+		}
 	}
 
 	nodeConfig := NewNodeConfig()
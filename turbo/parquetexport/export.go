@@ -0,0 +1,365 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package parquetexport - exports blocks, transactions, receipts and logs to Parquet files (for analytics - without ETL over RPC).
+// Every block range [from, to] is stored in own directory: <dir>/<from>-<to>/{blocks,transactions,receipts,logs}.parquet
+// Directory appears atomically (rename) only when all files are written - so exporter can be re-run any time
+// and continues from first range which is not exported yet.
+package parquetexport
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/execution/consensus"
+	"github.com/erigontech/erigon/rpc/jsonrpc/receipts"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+const DefaultRangeSize = 10_000
+
+type Exporter struct {
+	dir         string
+	rangeSize   uint64
+	chainConfig *chain.Config
+	blockReader services.FullBlockReader
+	receipts    *receipts.Generator
+	logger      log.Logger
+}
+
+func New(dir string, rangeSize uint64, chainConfig *chain.Config, blockReader services.FullBlockReader, engine consensus.EngineReader, logger log.Logger) *Exporter {
+	if rangeSize == 0 {
+		rangeSize = DefaultRangeSize
+	}
+	return &Exporter{
+		dir:         dir,
+		rangeSize:   rangeSize,
+		chainConfig: chainConfig,
+		blockReader: blockReader,
+		receipts:    receipts.NewGenerator(blockReader, engine),
+		logger:      logger,
+	}
+}
+
+// RangeDir - name of directory with files of blocks [from, to]
+func RangeDir(from, to uint64) string { return fmt.Sprintf("%09d-%09d", from, to) }
+
+// Export - exports all complete ranges (aligned to range size) between `from` and `upTo` (inclusive) which are not exported yet.
+// Returns number of exported ranges.
+func (e *Exporter) Export(ctx context.Context, db kv.TemporalRoDB, from, upTo uint64) (exported int, err error) {
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return 0, err
+	}
+	for start := from - from%e.rangeSize; start+e.rangeSize-1 <= upTo; start += e.rangeSize {
+		end := start + e.rangeSize - 1
+		if _, err := os.Stat(filepath.Join(e.dir, RangeDir(start, end))); err == nil {
+			continue
+		}
+		t := time.Now()
+		if err := db.View(ctx, func(tx kv.Tx) error { return e.ExportRange(ctx, tx.(kv.TemporalTx), start, end) }); err != nil {
+			return exported, fmt.Errorf("export blocks %d-%d: %w", start, end, err)
+		}
+		exported++
+		e.logger.Info("[parquet] exported", "from", start, "to", end, "took", time.Since(t))
+	}
+	return exported, nil
+}
+
+// ExportRange - writes files of blocks [from, to] to own directory, overwrites existing one
+func (e *Exporter) ExportRange(ctx context.Context, tx kv.TemporalTx, from, to uint64) (err error) {
+	name := RangeDir(from, to)
+	tmpDir := filepath.Join(e.dir, ".tmp-"+name)
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tmpDir, 0755); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(tmpDir)
+		}
+	}()
+
+	blocks, err := newFileWriter[BlockRow](filepath.Join(tmpDir, BlocksFile))
+	if err != nil {
+		return err
+	}
+	defer blocks.abort()
+	txs, err := newFileWriter[TransactionRow](filepath.Join(tmpDir, TransactionsFile))
+	if err != nil {
+		return err
+	}
+	defer txs.abort()
+	rcs, err := newFileWriter[ReceiptRow](filepath.Join(tmpDir, ReceiptsFile))
+	if err != nil {
+		return err
+	}
+	defer rcs.abort()
+	logs, err := newFileWriter[LogRow](filepath.Join(tmpDir, LogsFile))
+	if err != nil {
+		return err
+	}
+	defer logs.abort()
+
+	for num := from; num <= to; num++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hash, ok, err := e.blockReader.CanonicalHash(ctx, tx, num)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("canonical block %d not found", num)
+		}
+		block, senders, err := e.blockReader.BlockWithSenders(ctx, tx, hash, num)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("block %d %x not found", num, hash)
+		}
+		var blockReceipts types.Receipts
+		if len(block.Transactions()) > 0 {
+			if blockReceipts, err = e.receipts.GetReceipts(ctx, e.chainConfig, tx, block); err != nil {
+				return fmt.Errorf("receipts of block %d: %w", num, err)
+			}
+		}
+		if err := e.writeBlock(block, senders, blockReceipts, blocks, txs, rcs, logs); err != nil {
+			return err
+		}
+	}
+	for _, w := range []interface{ close() error }{blocks, txs, rcs, logs} {
+		if err := w.close(); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmpDir, filepath.Join(e.dir, name))
+}
+
+func (e *Exporter) writeBlock(block *types.Block, senders []common.Address, blockReceipts types.Receipts,
+	blocks *fileWriter[BlockRow], txs *fileWriter[TransactionRow], rcs *fileWriter[ReceiptRow], logs *fileWriter[LogRow]) error {
+	h := block.HeaderNoCopy()
+	blockHash := block.Hash().Hex()
+	row := BlockRow{
+		Number:           block.NumberU64(),
+		Hash:             blockHash,
+		ParentHash:       h.ParentHash.Hex(),
+		Timestamp:        h.Time,
+		Miner:            hexAddr(h.Coinbase),
+		Difficulty:       decimalBig(h.Difficulty),
+		GasLimit:         h.GasLimit,
+		GasUsed:          h.GasUsed,
+		Size:             uint64(block.Size()),
+		TransactionCount: uint64(len(block.Transactions())),
+		StateRoot:        h.Root.Hex(),
+		TransactionsRoot: h.TxHash.Hex(),
+		ReceiptsRoot:     h.ReceiptHash.Hex(),
+		WithdrawalCount:  uint64(len(block.Withdrawals())),
+		BlobGasUsed:      h.BlobGasUsed,
+		ExcessBlobGas:    h.ExcessBlobGas,
+		ExtraData:        h.Extra,
+	}
+	if h.BaseFee != nil {
+		row.BaseFeePerGas = ptr(h.BaseFee.String())
+	}
+	if h.WithdrawalsHash != nil {
+		row.WithdrawalsRoot = ptr(h.WithdrawalsHash.Hex())
+	}
+	if err := blocks.add(row); err != nil {
+		return err
+	}
+
+	var baseFee *uint256.Int
+	if h.BaseFee != nil {
+		baseFee = uint256.MustFromBig(h.BaseFee)
+	}
+	for i, txn := range block.Transactions() {
+		txHash := txn.Hash().Hex()
+		trow := TransactionRow{
+			BlockNumber:      block.NumberU64(),
+			BlockHash:        blockHash,
+			BlockTimestamp:   h.Time,
+			TransactionIndex: uint32(i),
+			Hash:             txHash,
+			Type:             uint32(txn.Type()),
+			Nonce:            txn.GetNonce(),
+			Value:            decimal(txn.GetValue()),
+			Gas:              txn.GetGasLimit(),
+			Input:            txn.GetData(),
+		}
+		if i < len(senders) {
+			trow.From = hexAddr(senders[i])
+		}
+		if to := txn.GetTo(); to != nil {
+			trow.To = ptr(hexAddr(*to))
+		}
+		if txn.Type() == types.LegacyTxType || txn.Type() == types.AccessListTxType {
+			trow.GasPrice = ptr(decimal(txn.GetFeeCap()))
+		} else {
+			trow.MaxFeePerGas, trow.MaxPriorityFeePerGas = ptr(decimal(txn.GetFeeCap())), ptr(decimal(txn.GetTipCap()))
+		}
+		if blobTx, ok := txn.Unwrap().(*types.BlobTx); ok {
+			trow.MaxFeePerBlobGas = ptr(decimal(blobTx.MaxFeePerBlobGas))
+		}
+		for _, bh := range txn.GetBlobHashes() {
+			trow.BlobVersionedHashes = append(trow.BlobVersionedHashes, bh.Hex())
+		}
+		if err := txs.add(trow); err != nil {
+			return err
+		}
+
+		if i >= len(blockReceipts) || blockReceipts[i] == nil {
+			return fmt.Errorf("receipt %d of block %d not found", i, block.NumberU64())
+		}
+		r := blockReceipts[i]
+		effectiveGasPrice := txn.GetFeeCap()
+		if baseFee != nil {
+			effectiveGasPrice = new(uint256.Int).Add(txn.GetEffectiveGasTip(baseFee), baseFee)
+		}
+		rrow := ReceiptRow{
+			BlockNumber:       block.NumberU64(),
+			BlockHash:         blockHash,
+			TransactionIndex:  uint32(i),
+			TransactionHash:   txHash,
+			Type:              uint32(r.Type),
+			Status:            r.Status,
+			CumulativeGasUsed: r.CumulativeGasUsed,
+			GasUsed:           r.GasUsed,
+			EffectiveGasPrice: decimal(effectiveGasPrice),
+			LogCount:          uint32(len(r.Logs)),
+		}
+		if r.ContractAddress != (common.Address{}) {
+			rrow.ContractAddress = ptr(hexAddr(r.ContractAddress))
+		}
+		if err := rcs.add(rrow); err != nil {
+			return err
+		}
+
+		for _, l := range r.Logs {
+			lrow := LogRow{
+				BlockNumber:      block.NumberU64(),
+				BlockHash:        blockHash,
+				TransactionIndex: uint32(i),
+				TransactionHash:  txHash,
+				LogIndex:         uint32(l.Index),
+				Address:          hexAddr(l.Address),
+				Data:             l.Data,
+			}
+			topics := []**string{&lrow.Topic0, &lrow.Topic1, &lrow.Topic2, &lrow.Topic3}
+			for j, topic := range l.Topics {
+				if j < len(topics) {
+					*topics[j] = ptr(topic.Hex())
+				}
+			}
+			if err := logs.add(lrow); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fileWriter - buffered writer of rows of one file
+type fileWriter[T any] struct {
+	f   *os.File
+	w   *parquet.GenericWriter[T]
+	buf []T
+}
+
+const fileWriterBatch = 1024
+
+func newFileWriter[T any](path string) (*fileWriter[T], error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	w := parquet.NewGenericWriter[T](f, parquet.Compression(&parquet.Zstd), parquet.KeyValueMetadata(SchemaVersionKey, SchemaVersion))
+	return &fileWriter[T]{f: f, w: w, buf: make([]T, 0, fileWriterBatch)}, nil
+}
+
+func (fw *fileWriter[T]) add(row T) error {
+	fw.buf = append(fw.buf, row)
+	if len(fw.buf) < fileWriterBatch {
+		return nil
+	}
+	return fw.flush()
+}
+
+func (fw *fileWriter[T]) flush() error {
+	if _, err := fw.w.Write(fw.buf); err != nil {
+		return err
+	}
+	fw.buf = fw.buf[:0]
+	return nil
+}
+
+func (fw *fileWriter[T]) close() error {
+	if fw.f == nil {
+		return nil
+	}
+	if err := fw.flush(); err != nil {
+		return err
+	}
+	if err := fw.w.Close(); err != nil {
+		return err
+	}
+	if err := fw.f.Sync(); err != nil {
+		return err
+	}
+	err := fw.f.Close()
+	fw.f = nil
+	return err
+}
+
+// abort - closes file without flushing, no-op after close
+func (fw *fileWriter[T]) abort() {
+	if fw.f != nil {
+		fw.f.Close()
+		fw.f = nil
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func hexAddr(a common.Address) string { return "0x" + common.Bytes2Hex(a[:]) }
+
+func decimal(v *uint256.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.Dec()
+}
+
+func decimalBig(v *big.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.String()
+}
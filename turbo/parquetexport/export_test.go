@@ -0,0 +1,110 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package parquetexport
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func TestExport(t *testing.T) {
+	require := require.New(t)
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.Address{0xee}
+		gspec  = &types.Genesis{
+			Config:   chain.TestChainConfig,
+			GasLimit: 3141592,
+			Alloc:    types.GenesisAlloc{addr: {Balance: big.NewInt(1000000)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	chainPack, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 4, func(i int, gen *core.BlockGen) {
+		txn, err := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, uint256.NewInt(1000), params.TxGas, nil, nil), *signer, key)
+		require.NoError(err)
+		gen.AddTx(txn)
+	})
+	require.NoError(err)
+	require.NoError(m.InsertChain(chainPack))
+
+	dir := t.TempDir()
+	e := New(dir, 2, m.ChainConfig, m.BlockReader, m.Engine, m.Log)
+	exported, err := e.Export(m.Ctx, m.DB, 1, 4) // [0,1], [2,3]; [4,5] is not complete
+	require.NoError(err)
+	require.Equal(2, exported)
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	require.Len(entries, 2)
+
+	exported, err = e.Export(m.Ctx, m.DB, 0, 4)
+	require.NoError(err)
+	require.Zero(exported)
+
+	rangeDir := filepath.Join(dir, RangeDir(2, 3))
+	blocks, err := parquet.ReadFile[BlockRow](filepath.Join(rangeDir, BlocksFile))
+	require.NoError(err)
+	require.Len(blocks, 2)
+	require.Equal(uint64(2), blocks[0].Number)
+	require.Equal(chainPack.Blocks[1].Hash().Hex(), blocks[0].Hash)
+	require.Equal(uint64(1), blocks[1].TransactionCount)
+
+	txs, err := parquet.ReadFile[TransactionRow](filepath.Join(rangeDir, TransactionsFile))
+	require.NoError(err)
+	require.Len(txs, 2)
+	require.Equal(hexAddr(addr), txs[0].From)
+	require.Equal(hexAddr(to), *txs[0].To)
+	require.Equal("1000", txs[0].Value)
+	require.Equal(chainPack.Blocks[1].Transactions()[0].Hash().Hex(), txs[0].Hash)
+
+	rcs, err := parquet.ReadFile[ReceiptRow](filepath.Join(rangeDir, ReceiptsFile))
+	require.NoError(err)
+	require.Len(rcs, 2)
+	require.Equal(types.ReceiptStatusSuccessful, rcs[1].Status)
+	require.Equal(params.TxGas, rcs[1].GasUsed)
+	require.Nil(rcs[1].ContractAddress)
+
+	logs, err := parquet.ReadFile[LogRow](filepath.Join(rangeDir, LogsFile))
+	require.NoError(err)
+	require.Empty(logs)
+
+	f, err := os.Open(filepath.Join(rangeDir, BlocksFile))
+	require.NoError(err)
+	defer f.Close()
+	stat, err := f.Stat()
+	require.NoError(err)
+	pf, err := parquet.OpenFile(f, stat.Size())
+	require.NoError(err)
+	version, ok := pf.Lookup(SchemaVersionKey)
+	require.True(ok)
+	require.Equal(SchemaVersion, version)
+}
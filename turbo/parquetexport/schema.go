@@ -0,0 +1,101 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package parquetexport
+
+// Schema of exported files. It's stable: columns can be only added (as optional) - never renamed, removed or re-typed.
+// Version is stored in key-value metadata of every file (SchemaVersionKey).
+// Hashes and addresses are 0x-prefixed lowercase hex strings, wei amounts are decimal strings (they don't fit into int64).
+
+const (
+	SchemaVersion    = "1"
+	SchemaVersionKey = "erigon.schema.version"
+
+	BlocksFile       = "blocks.parquet"
+	TransactionsFile = "transactions.parquet"
+	ReceiptsFile     = "receipts.parquet"
+	LogsFile         = "logs.parquet"
+)
+
+type BlockRow struct {
+	Number           uint64  `parquet:"number"`
+	Hash             string  `parquet:"hash"`
+	ParentHash       string  `parquet:"parent_hash"`
+	Timestamp        uint64  `parquet:"timestamp"`
+	Miner            string  `parquet:"miner"`
+	Difficulty       string  `parquet:"difficulty"`
+	GasLimit         uint64  `parquet:"gas_limit"`
+	GasUsed          uint64  `parquet:"gas_used"`
+	BaseFeePerGas    *string `parquet:"base_fee_per_gas,optional"`
+	Size             uint64  `parquet:"size"`
+	TransactionCount uint64  `parquet:"transaction_count"`
+	StateRoot        string  `parquet:"state_root"`
+	TransactionsRoot string  `parquet:"transactions_root"`
+	ReceiptsRoot     string  `parquet:"receipts_root"`
+	WithdrawalsRoot  *string `parquet:"withdrawals_root,optional"`
+	WithdrawalCount  uint64  `parquet:"withdrawal_count"`
+	BlobGasUsed      *uint64 `parquet:"blob_gas_used,optional"`
+	ExcessBlobGas    *uint64 `parquet:"excess_blob_gas,optional"`
+	ExtraData        []byte  `parquet:"extra_data"`
+}
+
+type TransactionRow struct {
+	BlockNumber          uint64   `parquet:"block_number"`
+	BlockHash            string   `parquet:"block_hash"`
+	BlockTimestamp       uint64   `parquet:"block_timestamp"`
+	TransactionIndex     uint32   `parquet:"transaction_index"`
+	Hash                 string   `parquet:"hash"`
+	Type                 uint32   `parquet:"type"`
+	From                 string   `parquet:"from"`
+	To                   *string  `parquet:"to,optional"` // null for contract creation
+	Nonce                uint64   `parquet:"nonce"`
+	Value                string   `parquet:"value"`
+	Gas                  uint64   `parquet:"gas"`
+	GasPrice             *string  `parquet:"gas_price,optional"` // legacy and access list txns
+	MaxFeePerGas         *string  `parquet:"max_fee_per_gas,optional"`
+	MaxPriorityFeePerGas *string  `parquet:"max_priority_fee_per_gas,optional"`
+	MaxFeePerBlobGas     *string  `parquet:"max_fee_per_blob_gas,optional"`
+	BlobVersionedHashes  []string `parquet:"blob_versioned_hashes,list"`
+	Input                []byte   `parquet:"input"`
+}
+
+type ReceiptRow struct {
+	BlockNumber       uint64  `parquet:"block_number"`
+	BlockHash         string  `parquet:"block_hash"`
+	TransactionIndex  uint32  `parquet:"transaction_index"`
+	TransactionHash   string  `parquet:"transaction_hash"`
+	Type              uint32  `parquet:"type"`
+	Status            uint64  `parquet:"status"`
+	CumulativeGasUsed uint64  `parquet:"cumulative_gas_used"`
+	GasUsed           uint64  `parquet:"gas_used"`
+	EffectiveGasPrice string  `parquet:"effective_gas_price"`
+	ContractAddress   *string `parquet:"contract_address,optional"`
+	LogCount          uint32  `parquet:"log_count"`
+}
+
+type LogRow struct {
+	BlockNumber      uint64  `parquet:"block_number"`
+	BlockHash        string  `parquet:"block_hash"`
+	TransactionIndex uint32  `parquet:"transaction_index"`
+	TransactionHash  string  `parquet:"transaction_hash"`
+	LogIndex         uint32  `parquet:"log_index"` // index in block
+	Address          string  `parquet:"address"`
+	Topic0           *string `parquet:"topic0,optional"`
+	Topic1           *string `parquet:"topic1,optional"`
+	Topic2           *string `parquet:"topic2,optional"`
+	Topic3           *string `parquet:"topic3,optional"`
+	Data             []byte  `parquet:"data"`
+}
@@ -0,0 +1,207 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package portal - client of Portal Network (history subnetwork) node: https://github.com/ethereum/portal-network-specs
+// Talks to local Portal client (Trin, Fluffy, Shisui, ...) over its JSON-RPC - erigon doesn't join Portal Network itself.
+// All content is verified against headers which erigon has.
+package portal
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// content key selectors of history network
+const (
+	BlockBodySelector byte = 0x01
+	ReceiptsSelector  byte = 0x02
+)
+
+func ContentKey(selector byte, blockHash common.Hash) []byte {
+	return append([]byte{selector}, blockHash[:]...)
+}
+
+type Client struct {
+	rpc *rpc.Client
+}
+
+func Dial(ctx context.Context, url string, logger log.Logger) (*Client, error) {
+	c, err := rpc.DialContext(ctx, url, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpc: c}, nil
+}
+
+func (c *Client) Close() { c.rpc.Close() }
+
+type contentResult struct {
+	Content     hexutil.Bytes `json:"content"`
+	UtpTransfer bool          `json:"utpTransfer"`
+}
+
+// Content - portal_historyGetContent: looks up content in local storage of Portal node, then in network
+func (c *Client) Content(ctx context.Context, key []byte) ([]byte, error) {
+	var res contentResult
+	if err := c.rpc.CallContext(ctx, &res, "portal_historyGetContent", hexutil.Bytes(key)); err != nil {
+		return nil, err
+	}
+	if len(res.Content) == 0 {
+		return nil, errors.New("portal: empty content")
+	}
+	return res.Content, nil
+}
+
+// BlockBody - body of block with given header
+func (c *Client) BlockBody(ctx context.Context, header *types.Header) (*types.Body, error) {
+	content, err := c.Content(ctx, ContentKey(BlockBodySelector, header.Hash()))
+	if err != nil {
+		return nil, fmt.Errorf("portal: body of block %d: %w", header.Number.Uint64(), err)
+	}
+	body, err := DecodeBlockBody(content)
+	if err != nil {
+		return nil, fmt.Errorf("portal: body of block %d: %w", header.Number.Uint64(), err)
+	}
+	if root := types.DeriveSha(types.Transactions(body.Transactions)); root != header.TxHash {
+		return nil, fmt.Errorf("portal: body of block %d: transactions root %x, header has %x", header.Number.Uint64(), root, header.TxHash)
+	}
+	if hash := types.CalcUncleHash(body.Uncles); hash != header.UncleHash {
+		return nil, fmt.Errorf("portal: body of block %d: uncles hash %x, header has %x", header.Number.Uint64(), hash, header.UncleHash)
+	}
+	if header.WithdrawalsHash != nil {
+		if root := types.DeriveSha(types.Withdrawals(body.Withdrawals)); root != *header.WithdrawalsHash {
+			return nil, fmt.Errorf("portal: body of block %d: withdrawals root %x, header has %x", header.Number.Uint64(), root, *header.WithdrawalsHash)
+		}
+	}
+	return body, nil
+}
+
+// Receipts - receipts of block with given header. Only consensus fields are set
+func (c *Client) Receipts(ctx context.Context, header *types.Header) (types.Receipts, error) {
+	content, err := c.Content(ctx, ContentKey(ReceiptsSelector, header.Hash()))
+	if err != nil {
+		return nil, fmt.Errorf("portal: receipts of block %d: %w", header.Number.Uint64(), err)
+	}
+	receipts, err := DecodeReceipts(content)
+	if err != nil {
+		return nil, fmt.Errorf("portal: receipts of block %d: %w", header.Number.Uint64(), err)
+	}
+	if root := types.DeriveSha(receipts); root != header.ReceiptHash {
+		return nil, fmt.Errorf("portal: receipts of block %d: root %x, header has %x", header.Number.Uint64(), root, header.ReceiptHash)
+	}
+	return receipts, nil
+}
+
+// DecodeBlockBody - SSZ Container(transactions: List[ByteList], uncles: ByteList) - pre-shanghai,
+// or Container(transactions: List[ByteList], uncles: ByteList, withdrawals: List[ByteList]) - post-shanghai.
+// Transactions and withdrawals are in consensus encoding, uncles - RLP list of headers.
+func DecodeBlockBody(b []byte) (*types.Body, error) {
+	fields, err := sszFields(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) != 2 && len(fields) != 3 {
+		return nil, fmt.Errorf("unexpected amount of body fields: %d", len(fields))
+	}
+	rawTxs, err := sszByteLists(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("transactions: %w", err)
+	}
+	body := &types.Body{}
+	if body.Transactions, err = types.DecodeTransactions(rawTxs); err != nil {
+		return nil, fmt.Errorf("transactions: %w", err)
+	}
+	if err := rlp.DecodeBytes(fields[1], &body.Uncles); err != nil {
+		return nil, fmt.Errorf("uncles: %w", err)
+	}
+	if len(fields) == 3 {
+		rawWithdrawals, err := sszByteLists(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("withdrawals: %w", err)
+		}
+		body.Withdrawals = make([]*types.Withdrawal, len(rawWithdrawals))
+		for i, raw := range rawWithdrawals {
+			body.Withdrawals[i] = &types.Withdrawal{}
+			if err := rlp.DecodeBytes(raw, body.Withdrawals[i]); err != nil {
+				return nil, fmt.Errorf("withdrawal %d: %w", i, err)
+			}
+		}
+	}
+	return body, nil
+}
+
+// DecodeReceipts - SSZ List[ByteList] of receipts in consensus encoding
+func DecodeReceipts(b []byte) (types.Receipts, error) {
+	raws, err := sszByteLists(b)
+	if err != nil {
+		return nil, err
+	}
+	receipts := make(types.Receipts, len(raws))
+	for i, raw := range raws {
+		receipts[i] = &types.Receipt{}
+		if err := receipts[i].UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("receipt %d: %w", i, err)
+		}
+	}
+	return receipts, nil
+}
+
+// sszFields - splits container of only variable-size fields: offsets part defines amount of fields
+func sszFields(b []byte) ([][]byte, error) {
+	if len(b) < 4 {
+		return nil, errors.New("ssz: container too short")
+	}
+	first := binary.LittleEndian.Uint32(b)
+	if first%4 != 0 || first == 0 || int(first) > len(b) {
+		return nil, fmt.Errorf("ssz: invalid first offset %d", first)
+	}
+	return sszSplit(b, int(first/4))
+}
+
+// sszByteLists - List[ByteList]: offsets of items, then items
+func sszByteLists(b []byte) ([][]byte, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	return sszFields(b)
+}
+
+func sszSplit(b []byte, n int) ([][]byte, error) {
+	if len(b) < 4*n {
+		return nil, errors.New("ssz: offsets out of range")
+	}
+	items := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		start, end := int(binary.LittleEndian.Uint32(b[4*i:])), len(b)
+		if i+1 < n {
+			end = int(binary.LittleEndian.Uint32(b[4*(i+1):]))
+		}
+		if start < 4*n || start > end || end > len(b) {
+			return nil, fmt.Errorf("ssz: invalid offset of item %d", i)
+		}
+		items[i] = b[start:end]
+	}
+	return items, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package portal_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/turbo/portal"
+)
+
+// sszByteLists - List[ByteList]
+func sszByteLists(items [][]byte) []byte {
+	var offsets, data bytes.Buffer
+	for _, it := range items {
+		binary.Write(&offsets, binary.LittleEndian, uint32(4*len(items)+data.Len()))
+		data.Write(it)
+	}
+	return append(offsets.Bytes(), data.Bytes()...)
+}
+
+func TestPortalClient(t *testing.T) {
+	require := require.New(t)
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	var txs types.Transactions
+	for i := uint64(0); i < 2; i++ {
+		txn, err := types.SignTx(types.NewTransaction(i, common.Address{0xee}, uint256.NewInt(1000), 21000, uint256.NewInt(1), nil), *signer, key)
+		require.NoError(err)
+		txs = append(txs, txn)
+	}
+	receipts := types.Receipts{
+		{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21000, Logs: []*types.Log{}},
+		{Type: types.LegacyTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 42000, Logs: []*types.Log{{Address: common.Address{1}, Topics: []common.Hash{{2}}, Data: []byte{3}}}},
+	}
+	for _, r := range receipts {
+		r.Bloom = types.CreateBloom(types.Receipts{r})
+	}
+	header := &types.Header{
+		Number:      big.NewInt(1_000_000),
+		Difficulty:  big.NewInt(1),
+		TxHash:      types.DeriveSha(txs),
+		UncleHash:   types.EmptyUncleHash,
+		ReceiptHash: types.DeriveSha(receipts),
+	}
+
+	// pre-shanghai body: Container(transactions: List[ByteList], uncles: ByteList)
+	rawTxs := make([][]byte, len(txs))
+	for i, txn := range txs {
+		var buf bytes.Buffer
+		require.NoError(txn.MarshalBinary(&buf))
+		rawTxs[i] = buf.Bytes()
+	}
+	uncles, err := rlp.EncodeToBytes([]*types.Header{})
+	require.NoError(err)
+	body := sszByteLists([][]byte{sszByteLists(rawTxs), uncles})
+	rawReceipts := make([][]byte, len(receipts))
+	for i, r := range receipts {
+		rawReceipts[i], err = r.MarshalBinary()
+		require.NoError(err)
+	}
+	content := map[string][]byte{
+		hexutil.Encode(portal.ContentKey(portal.BlockBodySelector, header.Hash())): body,
+		hexutil.Encode(portal.ContentKey(portal.ReceiptsSelector, header.Hash())):  sszByteLists(rawReceipts),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []string        `json:"params"`
+		}
+		require.NoError(json.NewDecoder(r.Body).Decode(&req))
+		require.Equal("portal_historyGetContent", req.Method)
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		if c, ok := content[req.Params[0]]; ok {
+			resp["result"] = map[string]any{"content": hexutil.Bytes(c), "utpTransfer": false}
+		} else {
+			resp["error"] = map[string]any{"code": -39001, "message": "content not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(json.NewEncoder(w).Encode(resp))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	c, err := portal.Dial(ctx, srv.URL, log.New())
+	require.NoError(err)
+	defer c.Close()
+
+	b, err := c.BlockBody(ctx, header)
+	require.NoError(err)
+	require.Len(b.Transactions, 2)
+	require.Equal(txs[1].Hash(), b.Transactions[1].Hash())
+	require.Empty(b.Uncles)
+
+	rs, err := c.Receipts(ctx, header)
+	require.NoError(err)
+	require.Len(rs, 2)
+	require.Equal(types.ReceiptStatusFailed, rs[1].Status)
+	require.Equal(common.Address{1}, rs[1].Logs[0].Address)
+
+	// content which doesn't match header is rejected
+	other := types.CopyHeader(header)
+	other.TxHash = common.Hash{1}
+	content[hexutil.Encode(portal.ContentKey(portal.BlockBodySelector, other.Hash()))] = body
+	_, err = c.BlockBody(ctx, other)
+	require.ErrorContains(err, "transactions root")
+
+	unknown := types.CopyHeader(header)
+	unknown.Number = big.NewInt(1)
+	_, err = c.BlockBody(ctx, unknown)
+	require.ErrorContains(err, "content not found")
+
+}
@@ -57,7 +57,8 @@ import (
 // 3.1.0 - add Subscribe to logs
 // 3.2.0 - add EngineGetBlobsBundleV1k
 // 3.3.0 - merge EngineGetBlobsBundleV1 into EngineGetPayload
-var EthBackendAPIVersion = &types2.VersionReply{Major: 3, Minor: 3, Patch: 0}
+// 3.4.0 - add BanPeer, UnbanPeer and BannedPeers
+var EthBackendAPIVersion = &types2.VersionReply{Major: 3, Minor: 4, Patch: 0}
 
 type EthBackendServer struct {
 	remote.UnimplementedETHBACKENDServer // must be embedded to have forward compatible implementations.
@@ -81,6 +82,9 @@ type EthBackend interface {
 	NodesInfo(limit int) (*remote.NodesInfoReply, error)
 	Peers(ctx context.Context) (*remote.PeersReply, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	BanPeer(ctx context.Context, req *remote.BanPeerRequest) (*remote.BanPeerReply, error)
+	UnbanPeer(ctx context.Context, req *remote.UnbanPeerRequest) (*remote.UnbanPeerReply, error)
+	BannedPeers(ctx context.Context) (*remote.BannedPeersReply, error)
 }
 
 func NewEthBackendServer(ctx context.Context, eth EthBackend, db kv.RwDB, notifications *shards.Notifications, blockReader services.FullBlockReader,
@@ -386,6 +390,18 @@ func (s *EthBackendServer) AddPeer(ctx context.Context, req *remote.AddPeerReque
 	return s.eth.AddPeer(ctx, req)
 }
 
+func (s *EthBackendServer) BanPeer(ctx context.Context, req *remote.BanPeerRequest) (*remote.BanPeerReply, error) {
+	return s.eth.BanPeer(ctx, req)
+}
+
+func (s *EthBackendServer) UnbanPeer(ctx context.Context, req *remote.UnbanPeerRequest) (*remote.UnbanPeerReply, error) {
+	return s.eth.UnbanPeer(ctx, req)
+}
+
+func (s *EthBackendServer) BannedPeers(ctx context.Context, _ *emptypb.Empty) (*remote.BannedPeersReply, error) {
+	return s.eth.BannedPeers(ctx)
+}
+
 func (s *EthBackendServer) SubscribeLogs(server remote.ETHBACKEND_SubscribeLogsServer) (err error) {
 	if s.logsFilter != nil {
 		return s.logsFilter.subscribeLogs(server)
@@ -0,0 +1,165 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package freezeblocks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/recsplit"
+	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon/eth/ethconfig/estimate"
+)
+
+// Recompress - rewrites block segment f with current compression parameters (BlockCompressCfg), named by version
+// ver. New file must have same words as f, indexes of new file must have same keys as existing indexes of f.
+// Only then f, its indexes and .torrent files are replaced. Returns size of new file.
+func Recompress(ctx context.Context, dirs datadir.Dirs, f snaptype.FileInfo, ver snaptype.Version, chainConfig *chain.Config, logger log.Logger) (int64, error) {
+	oldIdxNames := f.Type.IdxFileNames(f.Version, f.From, f.To)
+	oldIdx := map[string]indexKeys{}
+	for _, name := range oldIdxNames {
+		keys, err := readIndexKeys(filepath.Join(dirs.Snap, name))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // nothing to validate against, will be built
+			}
+			return 0, err
+		}
+		oldIdx[name] = keys
+	}
+
+	src, err := seg.NewDecompressor(f.Path)
+	if err != nil {
+		return 0, err
+	}
+	defer src.Close()
+
+	to := f.Type.FileName(ver, f.From, f.To)
+	tmpPath := filepath.Join(dirs.Tmp, to)
+	defer os.Remove(tmpPath)
+	if err := recompressTo(ctx, dirs, src, tmpPath, logger); err != nil {
+		return 0, err
+	}
+	dst, err := seg.NewDecompressor(tmpPath)
+	if err != nil {
+		return 0, err
+	}
+	newSize := dst.Size()
+	err = sameWords(src, dst)
+	dst.Close()
+	if err != nil {
+		return 0, fmt.Errorf("recompressed %s: %w", f.Name(), err)
+	}
+	src.Close()
+
+	toInfo, _, ok := snaptype.ParseFileName(dirs.Snap, to)
+	if !ok {
+		return 0, fmt.Errorf("can't parse file name %s", to)
+	}
+	if err := os.Rename(tmpPath, toInfo.Path); err != nil {
+		return 0, err
+	}
+	for _, name := range f.Type.IdxFileNames(ver, f.From, f.To) {
+		_ = os.Remove(filepath.Join(dirs.Snap, name))
+	}
+	if err := f.Type.BuildIndexes(ctx, toInfo, nil, chainConfig, dirs.Tmp, &background.Progress{}, log.LvlInfo, logger); err != nil {
+		return 0, err
+	}
+	for i, name := range f.Type.IdxFileNames(ver, f.From, f.To) {
+		old, ok := oldIdx[oldIdxNames[i]]
+		if !ok {
+			continue
+		}
+		keys, err := readIndexKeys(filepath.Join(dirs.Snap, name))
+		if err != nil {
+			return 0, err
+		}
+		if keys != old {
+			return 0, fmt.Errorf("index %s of recompressed file: %+v, was %+v", name, keys, old)
+		}
+	}
+
+	_ = os.Remove(toInfo.Path + ".torrent")
+	if toInfo.Path != f.Path {
+		_ = os.Remove(f.Path)
+		_ = os.Remove(f.Path + ".torrent")
+	}
+	for i, name := range f.Type.IdxFileNames(ver, f.From, f.To) {
+		_ = os.Remove(filepath.Join(dirs.Snap, name+".torrent"))
+		if oldIdxNames[i] != name {
+			_ = os.Remove(filepath.Join(dirs.Snap, oldIdxNames[i]))
+			_ = os.Remove(filepath.Join(dirs.Snap, oldIdxNames[i]+".torrent"))
+		}
+	}
+	return newSize, nil
+}
+
+func recompressTo(ctx context.Context, dirs datadir.Dirs, src *seg.Decompressor, to string, logger log.Logger) error {
+	defer src.MadvSequential().DisableReadAhead()
+	compressCfg := BlockCompressCfg
+	compressCfg.Workers = estimate.CompressSnapshot.Workers()
+	c, err := seg.NewCompressor(ctx, "recompress", to, dirs.Tmp, compressCfg, log.LvlInfo, logger)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err := c.ReadFrom(src.MakeGetter()); err != nil {
+		return err
+	}
+	return c.Compress()
+}
+
+func sameWords(a, b *seg.Decompressor) error {
+	if a.Count() != b.Count() {
+		return fmt.Errorf("words count %d, expected %d", b.Count(), a.Count())
+	}
+	defer a.MadvSequential().DisableReadAhead()
+	defer b.MadvSequential().DisableReadAhead()
+	ga, gb := a.MakeGetter(), b.MakeGetter()
+	var wa, wb []byte
+	for i := 0; ga.HasNext(); i++ {
+		wa, _ = ga.Next(wa[:0])
+		wb, _ = gb.Next(wb[:0])
+		if !bytes.Equal(wa, wb) {
+			return fmt.Errorf("word %d differs", i)
+		}
+	}
+	return nil
+}
+
+type indexKeys struct {
+	Count      uint64
+	BaseDataID uint64
+}
+
+func readIndexKeys(path string) (indexKeys, error) {
+	idx, err := recsplit.OpenIndex(path)
+	if err != nil {
+		return indexKeys{}, err
+	}
+	defer idx.Close()
+	return indexKeys{Count: idx.KeyCount(), BaseDataID: idx.BaseDataID()}, nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package freezeblocks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon-lib/version"
+	coresnaptype "github.com/erigontech/erigon/core/snaptype"
+	"github.com/erigontech/erigon/turbo/testlog"
+)
+
+func TestRecompress(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dirs := datadir.New(t.TempDir())
+	ctx := context.Background()
+
+	name := coresnaptype.Headers.FileName(version.V1_0, 0, 1_000)
+	c, err := seg.NewCompressor(ctx, "test", filepath.Join(dirs.Snap, name), dirs.Tmp, seg.DefaultCfg, log.LvlDebug, logger)
+	require.NoError(t, err)
+	c.DisableFsync()
+	for i := 0; i < 1_000; i++ {
+		require.NoError(t, c.AddWord([]byte{byte(i), 'h', byte(i >> 8), byte(i)}))
+	}
+	require.NoError(t, c.Compress())
+	c.Close()
+	f, _, ok := snaptype.ParseFileName(dirs.Snap, name)
+	require.True(t, ok)
+	require.NoError(t, f.Type.BuildIndexes(ctx, f, nil, nil, dirs.Tmp, &background.Progress{}, log.LvlDebug, logger))
+
+	_, err = Recompress(ctx, dirs, f, version.V1_1, nil, logger)
+	require.NoError(t, err)
+
+	require.NoFileExists(t, f.Path)
+	require.NoFileExists(t, filepath.Join(dirs.Snap, f.Type.IdxFileName(version.V1_0, 0, 1_000)))
+	require.FileExists(t, filepath.Join(dirs.Snap, f.Type.IdxFileName(version.V1_1, 0, 1_000)))
+	d, err := seg.NewDecompressor(filepath.Join(dirs.Snap, coresnaptype.Headers.FileName(version.V1_1, 0, 1_000)))
+	require.NoError(t, err)
+	defer d.Close()
+	require.Equal(t, 1_000, d.Count())
+	g := d.MakeGetter()
+	for i := 0; g.HasNext(); i++ {
+		w, _ := g.Next(nil)
+		require.Equal(t, []byte{byte(i), 'h', byte(i >> 8), byte(i)}, w)
+	}
+	tmp, err := os.ReadDir(dirs.Tmp)
+	require.NoError(t, err)
+	for _, e := range tmp {
+		require.NotContains(t, e.Name(), ".seg")
+	}
+}
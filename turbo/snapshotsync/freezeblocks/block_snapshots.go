@@ -146,6 +146,7 @@ func chooseSegmentEnd(from, to uint64, snapType snaptype.Enum, chainConfig *chai
 type BlockRetire struct {
 	maxScheduledBlock atomic.Uint64
 	working           atomic.Bool
+	reindexing        atomic.Bool
 
 	// shared semaphore with AggregatorV3 to allow only one type of snapshot building at a time
 	snBuildAllowed *semaphore.Weighted
@@ -483,9 +484,46 @@ func (br *BlockRetire) BuildMissedIndicesIfNeed(ctx context.Context, logPrefix s
 		}
 	}
 
+	if br.config.HeadersMMR {
+		if err := BuildHeadersMMR(ctx, br.snapshots(), br.dirs, br.logger); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// ReindexInBackground - re-builds outdated (salt change) or broken accessors of open segments. Segments stay available:
+// new accessors are swapped in when ready
+func (br *BlockRetire) ReindexInBackground(ctx context.Context, cc *chain.Config) {
+	if !br.reindexing.CompareAndSwap(false, true) {
+		return
+	}
+	go func() {
+		defer br.reindexing.Store(false)
+		if br.snBuildAllowed != nil {
+			//we are inside own goroutine - it's fine to block here
+			if err := br.snBuildAllowed.Acquire(ctx, 1); err != nil {
+				br.logger.Warn("[snapshots] reindex", "err", err)
+				return
+			}
+			defer br.snBuildAllowed.Release(1)
+		}
+		workers := estimate.IndexSnapshot.WorkersQuarter()
+		swapped, err := br.snapshots().Reindex(ctx, "reindex", br.dirs, cc, workers, br.logger)
+		if err == nil && cc.Bor != nil {
+			var swappedBor int
+			swappedBor, err = br.borSnapshots().Reindex(ctx, "reindex", br.dirs, cc, workers, br.logger)
+			swapped += swappedBor
+		}
+		if err != nil {
+			br.logger.Warn("[snapshots] reindex", "err", err)
+		}
+		if swapped > 0 && br.notifier != nil {
+			br.notifier.OnNewSnapshot()
+		}
+	}()
+}
+
 func DumpBlocks(ctx context.Context, blockFrom, blockTo uint64, chainConfig *chain.Config, tmpDir, snapDir string, chainDB kv.RoDB, workers int, lvl log.Lvl, logger log.Logger, blockReader services.FullBlockReader) error {
 	firstTxNum := blockReader.FirstTxnNumNotInSnapshots()
 	for i := blockFrom; i < blockTo; i = chooseSegmentEnd(i, blockTo, coresnaptype.Enums.Headers, chainConfig) {
@@ -0,0 +1,88 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package freezeblocks
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/crypto"
+	"github.com/erigontech/erigon-lib/datastruct/mmr"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// HeadersMMRPath - snapshot accessory: Merkle Mountain Range over hashes of frozen canonical headers (leaf i - hash of block i)
+func HeadersMMRPath(dirs datadir.Dirs) string {
+	return filepath.Join(dirs.SnapAccessors, "headers.mmr")
+}
+
+// BuildHeadersMMR - appends headers frozen since last run. Frozen headers are final - MMR never needs unwind.
+func BuildHeadersMMR(ctx context.Context, s *RoSnapshots, dirs datadir.Dirs, logger log.Logger) error {
+	f, acc, err := mmr.OpenFile(HeadersMMRPath(dirs))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+
+	view := s.View()
+	defer view.Close()
+	from := acc.Leaves()
+	for _, sn := range view.Headers() {
+		if sn.To() <= acc.Leaves() {
+			continue
+		}
+		if sn.From() > acc.Leaves() {
+			return fmt.Errorf("headers MMR: gap in header snapshots: %d-%d, expected %d", sn.From(), sn.To(), acc.Leaves())
+		}
+		g := sn.Src().MakeGetter()
+		var word []byte
+		for blockNum := sn.From(); g.HasNext(); blockNum++ {
+			if blockNum < acc.Leaves() {
+				g.Skip()
+				continue
+			}
+			word, _ = g.Next(word[:0])
+			// word = first byte of hash + header rlp
+			if err := acc.Append(crypto.Keccak256Hash(word[1:])); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-logEvery.C:
+				if err := f.Flush(); err != nil {
+					return err
+				}
+				logger.Info("[snapshots] headers MMR", "block", blockNum)
+			default:
+			}
+		}
+		if err := f.Flush(); err != nil {
+			return err
+		}
+	}
+	if acc.Leaves() > from {
+		logger.Info("[snapshots] headers MMR built", "from", from, "to", acc.Leaves())
+	}
+	return nil
+}
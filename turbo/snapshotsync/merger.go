@@ -13,6 +13,7 @@ import (
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/disk"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -142,7 +143,7 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, snapTypes []
 	v := snapshots.View()
 	defer v.Close()
 
-	if len(mergeRanges) == 0 {
+	if len(mergeRanges) == 0 || disk.IsSpaceLow() { // merge postponed until free disk space is back
 		return nil
 	}
 
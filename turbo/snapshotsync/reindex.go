@@ -0,0 +1,254 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshotsync
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon-lib/chain"
+	common2 "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dbg"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/diagnostics"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Re-indexing: accessors of open segments become outdated (salt-blocks.txt changed) or broken (file can't be opened).
+// New accessors are built alongside - in staging dir which has symlinks to segments - and then swapped:
+//   - new .idx files are renamed over old ones (readers which still use old ones keep them mmaped)
+//   - new DirtySegment replaces old one in `dirty` - only new readers see it
+//   - old DirtySegment is not ref-counted (frozen files) - so it stays open until RoSnapshots.Close
+// Node doesn't stop serving segment: old accessors are visible until swap.
+
+// saltRetries - index builders retry with salt+1 on hash collision: such accessors have current salt
+const saltRetries = 16
+
+func saltMatches(idxSalt, salt uint32) bool { return idxSalt-salt < saltRetries }
+
+// needReindex - accessors of open segment are missing (corrupted) or built with other salt
+func (s *DirtySegment) needReindex(salt uint32) bool {
+	if s.Decompressor == nil || s.canDelete.Load() || len(s.Type().Indexes()) == 0 {
+		return false
+	}
+	if !s.IsIndexed() {
+		return true
+	}
+	for _, idx := range s.indexes {
+		if !saltMatches(idx.Salt(), salt) {
+			return true
+		}
+	}
+	return false
+}
+
+func reindexStagingDir(snapDir string, salt uint32) string {
+	return filepath.Join(snapDir, fmt.Sprintf(".reindex-%08x", salt))
+}
+
+// Reindex - re-builds accessors of segments which need it, without closing segments. Returns amount of swapped segments
+func (s *RoSnapshots) Reindex(ctx context.Context, logPrefix string, dirs datadir.Dirs, cc *chain.Config, workers int, logger log.Logger) (swapped int, err error) {
+	if s == nil {
+		return 0, nil
+	}
+	salt, err := snaptype.ReadAndCreateSaltIfNeeded(dirs.Snap) // not cached: salt may change while node is running
+	if err != nil {
+		return 0, err
+	}
+
+	type job struct {
+		seg     *DirtySegment
+		sameRng []*DirtySegment // all segments of range: index builders may read other types (transactions need bodies)
+	}
+	var jobs []job
+	s.dirtyLock.RLock()
+	for _, t := range s.enums {
+		s.dirty[t].Walk(func(segs []*DirtySegment) bool {
+			for _, sn := range segs {
+				if !sn.needReindex(salt) {
+					continue
+				}
+				j := job{seg: sn}
+				for _, t2 := range s.enums {
+					s.dirty[t2].Walk(func(segs2 []*DirtySegment) bool {
+						for _, sn2 := range segs2 {
+							if sn2.from == sn.from && sn2.to == sn.to && sn2.Decompressor != nil {
+								j.sameRng = append(j.sameRng, sn2)
+							}
+						}
+						return true
+					})
+				}
+				jobs = append(jobs, j)
+			}
+			return true
+		})
+	}
+	s.dirtyLock.RUnlock()
+	if len(jobs) == 0 {
+		return 0, nil
+	}
+
+	staging := reindexStagingDir(dirs.Snap, salt)
+	if err := os.RemoveAll(staging); err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(staging, 0755); err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(staging)
+	saltBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(saltBytes, salt)
+	if err := dir.WriteFileWithFsync(filepath.Join(staging, "salt-blocks.txt"), saltBytes, os.ModePerm); err != nil {
+		return 0, err
+	}
+	logger.Info(fmt.Sprintf("[%s] Re-indexing in background", logPrefix), "segments", len(jobs))
+
+	ps := background.NewProgressSet()
+	startTime := time.Now()
+	var swappedCnt atomic.Int32
+	sendProgress := func(finished bool) {
+		var m runtime.MemStats
+		dbg.ReadMemStats(&m)
+		stats := diagnostics.SnapshotReindexStatistics{TimeElapsed: time.Since(startTime).Round(time.Second).Seconds(), Finished: finished}
+		for name, percent := range ps.DiagnosticsData() {
+			stats.Segments = append(stats.Segments, diagnostics.SnapshotSegmentIndexingStatistics{SegmentName: name, Percent: percent, Alloc: m.Alloc, Sys: m.Sys})
+		}
+		stats.Swapped = int(swappedCnt.Load())
+		diagnostics.Send(stats)
+	}
+	defer func() { sendProgress(true) }()
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	finish := make(chan struct{})
+	defer close(finish)
+	go func() {
+		for {
+			select {
+			case <-logEvery.C:
+				sendProgress(false)
+				var m runtime.MemStats
+				dbg.ReadMemStats(&m)
+				logger.Info(fmt.Sprintf("[%s] Re-indexing", logPrefix), "progress", ps.String(), "swapped", fmt.Sprintf("%d/%d", swappedCnt.Load(), len(jobs)), "alloc", common2.ByteCount(m.Alloc), "sys", common2.ByteCount(m.Sys))
+			case <-finish:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	for _, j := range jobs {
+		g.Go(func() error {
+			p := &background.Progress{}
+			ps.Add(p)
+			defer ps.Delete(p)
+			ok, err := s.reindexSegment(gCtx, j.seg, j.sameRng, staging, salt, dirs, cc, p, logger)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return err
+				}
+				// other segments still can be re-indexed
+				logger.Warn(fmt.Sprintf("[%s] Re-indexing failed", logPrefix), "file", j.seg.FileName(), "err", err)
+				return nil
+			}
+			if ok {
+				swappedCnt.Add(1)
+			}
+			return nil
+		})
+	}
+	err = g.Wait()
+	swapped = int(swappedCnt.Load())
+	if swapped > 0 {
+		s.recalcVisibleFiles()
+	}
+	if err != nil {
+		return swapped, err
+	}
+	logger.Info(fmt.Sprintf("[%s] Re-indexing done", logPrefix), "swapped", swapped, "took", time.Since(startTime).Round(time.Second))
+	return swapped, nil
+}
+
+// reindexSegment - builds accessors of `sn` in staging dir and swaps them in. ok=false if segment was closed/replaced meanwhile
+func (s *RoSnapshots) reindexSegment(ctx context.Context, sn *DirtySegment, sameRng []*DirtySegment, staging string, salt uint32, dirs datadir.Dirs, cc *chain.Config, p *background.Progress, logger log.Logger) (ok bool, err error) {
+	for _, other := range sameRng {
+		link := filepath.Join(staging, other.FileName())
+		if _, err := os.Lstat(link); err == nil {
+			continue
+		}
+		if err := os.Symlink(other.FilePath(), link); err != nil && !os.IsExist(err) {
+			return false, err
+		}
+	}
+	info, _, ok := snaptype.ParseFileName(staging, sn.FileName())
+	if !ok {
+		return false, fmt.Errorf("can't parse file name %s", sn.FileName())
+	}
+	idxNames := sn.Type().IdxFileNames(sn.version, sn.from, sn.to)
+	defer func() {
+		for _, name := range idxNames {
+			_ = os.Remove(filepath.Join(staging, name))
+		}
+	}()
+	if err := sn.Type().BuildIndexes(ctx, info, s.IndexBuilder(sn.Type()), cc, dirs.Tmp, p, log.LvlDebug, logger); err != nil {
+		return false, err
+	}
+	defer notifySegmentIndexingFinished(info.Name())
+
+	s.dirtyLock.Lock()
+	defer s.dirtyLock.Unlock()
+	if current, found := s.dirty[sn.segType.Enum()].Get(sn); !found || current != sn || sn.Decompressor == nil || sn.canDelete.Load() {
+		return false, nil
+	}
+	for _, name := range idxNames {
+		if err := os.Rename(filepath.Join(staging, name), filepath.Join(dirs.Snap, name)); err != nil {
+			return false, err
+		}
+	}
+	fresh := NewDirtySegment(sn.segType, sn.version, sn.from, sn.to, sn.frozen)
+	if err := fresh.Open(dirs.Snap); err != nil {
+		return false, err
+	}
+	if err := fresh.openIdx(dirs.Snap); err != nil {
+		fresh.close()
+		return false, err
+	}
+	for _, idx := range fresh.indexes {
+		if !saltMatches(idx.Salt(), salt) {
+			logger.Warn("[snapshots] index builder ignores salt", "file", idx.FileName())
+		}
+	}
+	s.dirty[sn.segType.Enum()].Set(fresh)
+	s.replaced = append(s.replaced, sn)
+	return true, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package snapshotsync
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/chain/networkname"
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/recsplit"
+	"github.com/erigontech/erigon-lib/version"
+
+	coresnaptype "github.com/erigontech/erigon/core/snaptype"
+	"github.com/erigontech/erigon/eth/ethconfig"
+)
+
+func TestReindex(t *testing.T) {
+	logger := log.New()
+	dirs, require := datadir.New(t.TempDir()), require.New(t)
+	types := []snaptype.Type{coresnaptype.Headers}
+	for i := uint64(0); i < 2; i++ {
+		createTestSegmentFile(t, i*10_000, (i+1)*10_000, coresnaptype.Enums.Headers, dirs.Snap, version.V1_0, logger) // random salt
+	}
+	salt := uint32(0x01020304)
+	saltBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(saltBytes, salt)
+	require.NoError(os.WriteFile(filepath.Join(dirs.Snap, "salt-blocks.txt"), saltBytes, 0644))
+
+	s := NewRoSnapshots(ethconfig.BlocksFreezing{ChainName: networkname.Mainnet}, dirs.Snap, types, 0, true, logger)
+	defer s.Close()
+	require.NoError(s.OpenFolder())
+	s.SetIndexBuilder(coresnaptype.Headers, snaptype.IndexBuilderFunc(func(ctx context.Context, info snaptype.FileInfo, salt uint32, _ *chain.Config, tmpDir string, _ *background.Progress, _ log.Lvl, logger log.Logger) error {
+		idx, err := recsplit.NewRecSplit(recsplit.RecSplitArgs{
+			KeyCount:   1,
+			BucketSize: 10,
+			Salt:       &salt,
+			TmpDir:     tmpDir,
+			IndexFile:  filepath.Join(info.Dir(), info.Type.IdxFileName(info.Version, info.From, info.To)),
+			LeafSize:   8,
+		}, logger)
+		if err != nil {
+			return err
+		}
+		defer idx.Close()
+		if err := idx.AddKey([]byte{1}, 0); err != nil {
+			return err
+		}
+		return idx.Build(ctx)
+	}))
+
+	// reader which started before re-indexing keeps working with old accessors
+	oldTx := s.ViewType(coresnaptype.Headers)
+	defer oldTx.Close()
+	require.Len(oldTx.Segments, 2)
+	old := oldTx.Segments[0].Src()
+	require.True(old.needReindex(salt))
+
+	swapped, err := s.Reindex(context.Background(), "test", dirs, nil, 2, logger)
+	require.NoError(err)
+	require.Equal(2, swapped)
+	_, ok := recsplit.NewIndexReader(oldTx.Segments[0].Src().Index()).Lookup([]byte{1})
+	require.True(ok)
+
+	newTx := s.ViewType(coresnaptype.Headers)
+	defer newTx.Close()
+	require.Len(newTx.Segments, 2)
+	for _, sn := range newTx.Segments {
+		require.NotSame(old, sn.Src())
+		require.Equal(salt, sn.Src().Index().Salt())
+		require.False(sn.Src().needReindex(salt))
+	}
+	_, ok = recsplit.NewIndexReader(newTx.Segments[0].Src().Index()).Lookup([]byte{1})
+	require.True(ok)
+
+	swapped, err = s.Reindex(context.Background(), "test", dirs, nil, 2, logger)
+	require.NoError(err)
+	require.Zero(swapped)
+	_, err = os.Stat(reindexStagingDir(dirs.Snap, salt))
+	require.True(os.IsNotExist(err))
+
+	// re-opening folder picks up new accessors
+	s2 := NewRoSnapshots(ethconfig.BlocksFreezing{ChainName: networkname.Mainnet}, dirs.Snap, types, 0, true, logger)
+	defer s2.Close()
+	require.NoError(s2.OpenFolder())
+	tx2 := s2.ViewType(coresnaptype.Headers)
+	defer tx2.Close()
+	require.Equal(salt, tx2.Segments[1].Src().Index().Salt())
+}
@@ -537,6 +537,8 @@ type RoSnapshots struct {
 	ready       ready
 	operators   map[snaptype.Enum]*retireOperators
 	alignMin    bool // do we want to align all visible segments to min availible
+
+	replaced []*DirtySegment // segments which got new accessors by Reindex - old readers may still use them. guarded by `dirtyLock`
 }
 
 // NewRoSnapshots - opens all snapshots. But to simplify everything:
@@ -1209,6 +1211,10 @@ func (s *RoSnapshots) Close() {
 	defer s.dirtyLock.Unlock()
 
 	s.closeWhatNotInList(nil)
+	for _, sn := range s.replaced {
+		sn.close()
+	}
+	s.replaced = nil
 }
 
 func (s *RoSnapshots) closeWhatNotInList(l []string) {
@@ -29,7 +29,6 @@ import (
 	"github.com/erigontech/erigon-lib/chain"
 	"github.com/erigontech/erigon-lib/chain/snapcfg"
 	"github.com/erigontech/erigon-lib/common/datadir"
-	"github.com/erigontech/erigon-lib/config3"
 	"github.com/erigontech/erigon-lib/downloader/downloadergrpc"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
 	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
@@ -42,6 +41,7 @@ import (
 
 	coresnaptype "github.com/erigontech/erigon/core/snaptype"
 	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/turbo/era1"
 )
 
 var greatOtterBanner = `
@@ -225,15 +225,15 @@ type blockReader interface {
 }
 
 // getMinimumBlocksToDownload - get the minimum number of blocks to download
-func getMinimumBlocksToDownload(tx kv.Tx, blockReader blockReader, minStep uint64, blockPruneTo, historyPruneTo uint64) (uint64, uint64, error) {
+func getMinimumBlocksToDownload(tx kv.Tx, blockReader blockReader, minStep, stepSize uint64, blockPruneTo, historyPruneTo uint64) (uint64, uint64, error) {
 	frozenBlocks := blockReader.Snapshots().SegmentsMax()
 	minToDownload := uint64(math.MaxUint64)
 	minStepToDownload := uint64(math.MaxUint32)
-	stateTxNum := minStep * config3.DefaultStepSize
+	stateTxNum := minStep * stepSize
 	if err := blockReader.IterateFrozenBodies(func(blockNum, baseTxNum, txAmount uint64) error {
 		if blockNum == historyPruneTo {
-			minStepToDownload = (baseTxNum - (config3.DefaultStepSize - 1)) / config3.DefaultStepSize
-			if baseTxNum < (config3.DefaultStepSize - 1) {
+			minStepToDownload = (baseTxNum - (stepSize - 1)) / stepSize
+			if baseTxNum < (stepSize - 1) {
 				minStepToDownload = 0
 			}
 		}
@@ -283,6 +283,7 @@ func getMaxStepRangeInSnapshots(preverified snapcfg.Preverified) (uint64, error)
 
 func computeBlocksToPrune(blockReader blockReader, p prune.Mode) (blocksToPrune uint64, historyToPrune uint64) {
 	frozenBlocks := blockReader.Snapshots().SegmentsMax()
+	p = p.Runtime() // bodies needed by embedded Caplin must be downloaded
 	return p.Blocks.PruneTo(frozenBlocks), p.History.PruneTo(frozenBlocks)
 }
 
@@ -323,7 +324,7 @@ func WaitForDownloader(ctx context.Context, logPrefix string, dirs datadir.Dirs,
 		if err != nil {
 			return err
 		}
-		minBlockToDownload, minStepToDownload, err := getMinimumBlocksToDownload(tx, blockReader, minStep, blockPrune, historyPrune)
+		minBlockToDownload, minStepToDownload, err := getMinimumBlocksToDownload(tx, blockReader, minStep, agg.StepSize(), blockPrune, historyPrune)
 		if err != nil {
 			return err
 		}
@@ -334,6 +335,20 @@ func WaitForDownloader(ctx context.Context, logPrefix string, dirs datadir.Dirs,
 		}
 	}
 
+	// EIP-4444: don't download transactions which node already has in era1 files
+	var expiredBlocks uint64
+	if syncCfg.HistoryExpiry && !headerchain {
+		store, err := era1.OpenStore(era1.Dir(dirs))
+		if err != nil {
+			return err
+		}
+		expiredBlocks, err = store.Covered()
+		store.Close()
+		if err != nil {
+			return err
+		}
+	}
+
 	// build all download requests
 	for _, p := range preverifiedBlockSnapshots {
 		if caplin == NoCaplin && (strings.Contains(p.Name, "beaconblocks") || strings.Contains(p.Name, "blobsidecars") || strings.Contains(p.Name, "caplin")) {
@@ -362,10 +377,18 @@ func WaitForDownloader(ctx context.Context, logPrefix string, dirs datadir.Dirs,
 		if !syncCfg.PersistReceiptsCacheV2 && isStateHistory(p.Name) && strings.Contains(p.Name, kv.RCacheDomain.String()) {
 			continue
 		}
+		if !syncCfg.AccessLists && isStateHistory(p.Name) && strings.Contains(p.Name, kv.AccessListDomain.String()) {
+			continue
+		}
 
 		if _, ok := blackListForPruning[p.Name]; ok {
 			continue
 		}
+		if expiredBlocks > 0 && strings.Contains(p.Name, "transactions") {
+			if info, _, ok := snaptype.ParseFileName("", p.Name); ok && info.To <= expiredBlocks {
+				continue
+			}
+		}
 
 		downloadRequest = append(downloadRequest, NewDownloadRequest(p.Name, p.Hash))
 	}
@@ -23,6 +23,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -818,8 +819,21 @@ func TestBadBlocks(t *testing.T) {
 	hash3 := putBlock(9)
 	hash4 := putBlock(10)
 
+	// archive the first bad block, while it's still readable by hash
+	require.NoError(rawdb.WriteBadBlock(tx, hash1, errors.New("invalid state root hash")))
+
 	// mark some blocks as bad
 	require.NoError(rawdb.TruncateCanonicalHash(tx, 7, true))
+
+	bad, err := rawdb.ReadBadBlock(tx, hash1)
+	require.NoError(err)
+	require.NotNil(bad)
+	require.Equal(hash1, bad.Block.Hash())
+	require.Len(bad.Block.Transactions(), 2)
+	require.Equal("invalid state root hash", bad.Reason)
+	bad, err = rawdb.ReadBadBlock(tx, hash2)
+	require.NoError(err)
+	require.Nil(bad)
 	badBlks, err := rawdb.GetLatestBadBlocks(tx)
 	require.NoError(err)
 	require.Len(badBlks, 4)
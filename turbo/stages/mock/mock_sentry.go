@@ -49,6 +49,7 @@ import (
 	ptypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/kvcfg"
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/kv/prune"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
@@ -56,6 +57,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
+	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon-lib/types"
 	"github.com/erigontech/erigon-lib/wrap"
 	"github.com/erigontech/erigon/core"
@@ -125,6 +127,10 @@ type MockSentry struct {
 
 	Notifications *shards.Notifications
 
+	// Outbound, when set, serves the messages sent to peers instead of them only being
+	// recorded - e.g. a sentry simulator, whose responses are then passed to Send
+	Outbound proto_sentry.SentryServer
+
 	// TxPool
 	TxPool           *txpool.TxPool
 	TxPoolGrpcServer txpoolproto.TxpoolServer
@@ -178,20 +184,32 @@ func (ms *MockSentry) PeerMinBlock(context.Context, *proto_sentry.PeerMinBlockRe
 func (ms *MockSentry) HandShake(ctx context.Context, in *emptypb.Empty) (*proto_sentry.HandShakeReply, error) {
 	return &proto_sentry.HandShakeReply{Protocol: proto_sentry.Protocol_ETH68}, nil
 }
-func (ms *MockSentry) SendMessageByMinBlock(_ context.Context, r *proto_sentry.SendMessageByMinBlockRequest) (*proto_sentry.SentPeers, error) {
+func (ms *MockSentry) SendMessageByMinBlock(ctx context.Context, r *proto_sentry.SendMessageByMinBlockRequest) (*proto_sentry.SentPeers, error) {
 	ms.sentMessages = append(ms.sentMessages, r.Data)
+	if ms.Outbound != nil {
+		return ms.Outbound.SendMessageByMinBlock(ctx, r)
+	}
 	return nil, nil
 }
-func (ms *MockSentry) SendMessageById(_ context.Context, r *proto_sentry.SendMessageByIdRequest) (*proto_sentry.SentPeers, error) {
+func (ms *MockSentry) SendMessageById(ctx context.Context, r *proto_sentry.SendMessageByIdRequest) (*proto_sentry.SentPeers, error) {
 	ms.sentMessages = append(ms.sentMessages, r.Data)
+	if ms.Outbound != nil {
+		return ms.Outbound.SendMessageById(ctx, r)
+	}
 	return nil, nil
 }
-func (ms *MockSentry) SendMessageToRandomPeers(_ context.Context, r *proto_sentry.SendMessageToRandomPeersRequest) (*proto_sentry.SentPeers, error) {
+func (ms *MockSentry) SendMessageToRandomPeers(ctx context.Context, r *proto_sentry.SendMessageToRandomPeersRequest) (*proto_sentry.SentPeers, error) {
 	ms.sentMessages = append(ms.sentMessages, r.Data)
+	if ms.Outbound != nil {
+		return ms.Outbound.SendMessageToRandomPeers(ctx, r)
+	}
 	return nil, nil
 }
-func (ms *MockSentry) SendMessageToAll(_ context.Context, r *proto_sentry.OutboundMessageData) (*proto_sentry.SentPeers, error) {
+func (ms *MockSentry) SendMessageToAll(ctx context.Context, r *proto_sentry.OutboundMessageData) (*proto_sentry.SentPeers, error) {
 	ms.sentMessages = append(ms.sentMessages, r)
+	if ms.Outbound != nil {
+		return ms.Outbound.SendMessageToAll(ctx, r)
+	}
 	return nil, nil
 }
 func (ms *MockSentry) SentMessage(i int) *proto_sentry.OutboundMessageData {
@@ -234,8 +252,46 @@ func (ms *MockSentry) NodeInfo(context.Context, *emptypb.Empty) (*ptypes.NodeInf
 
 const blockBufferSize = 128
 
-func MockWithGenesis(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, withPosDownloader bool) *MockSentry {
-	return MockWithGenesisPruneMode(tb, gspec, key, blockBufferSize, prune.DefaultMode, withPosDownloader)
+// Option - enables optional index of mock. Indices are part of global state schema, so they're off by default
+type Option func(cfg *ethconfig.Config)
+
+// WithAddressAppearances - maintain address appearances index (erigon_getAddressAppearances)
+func WithAddressAppearances() Option {
+	return func(cfg *ethconfig.Config) { cfg.AddressAppearances = true }
+}
+
+// WithAccessLists - keep access list of every executed txn (erigon_getTransactionAccessList)
+func WithAccessLists() Option {
+	return func(cfg *ethconfig.Config) { cfg.AccessLists = true }
+}
+
+// WithWithdrawalsIndex - maintain withdrawal address index (erigon_getWithdrawalsByAddress)
+func WithWithdrawalsIndex() Option {
+	return func(cfg *ethconfig.Config) { cfg.WithdrawalsIndex = true }
+}
+
+// enableSchemaIndices - enables in global state schema indices requested by cfg, restores schema at the end of test
+func enableSchemaIndices(tb testing.TB, cfg *ethconfig.Config) {
+	if !cfg.AddressAppearances && !cfg.AccessLists && !cfg.WithdrawalsIndex {
+		return
+	}
+	if tb != nil {
+		schema := libstate.Schema
+		tb.Cleanup(func() { libstate.Schema = schema })
+	}
+	if cfg.AddressAppearances {
+		libstate.EnableAddressAppearances()
+	}
+	if cfg.AccessLists {
+		libstate.EnableAccessLists()
+	}
+	if cfg.WithdrawalsIndex {
+		libstate.EnableWithdrawalsIndex()
+	}
+}
+
+func MockWithGenesis(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, withPosDownloader bool, opts ...Option) *MockSentry {
+	return MockWithGenesisPruneMode(tb, gspec, key, blockBufferSize, prune.DefaultMode, withPosDownloader, opts...)
 }
 
 func MockWithGenesisEngine(tb testing.TB, gspec *types.Genesis, engine consensus.Engine, withPosDownloader, checkStateRoot bool) *MockSentry {
@@ -243,7 +299,7 @@ func MockWithGenesisEngine(tb testing.TB, gspec *types.Genesis, engine consensus
 	return MockWithEverything(tb, gspec, key, prune.DefaultMode, engine, blockBufferSize, false, withPosDownloader, checkStateRoot)
 }
 
-func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, blockBufferSize int, prune prune.Mode, withPosDownloader bool) *MockSentry {
+func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, blockBufferSize int, prune prune.Mode, withPosDownloader bool, opts ...Option) *MockSentry {
 	var engine consensus.Engine
 
 	switch {
@@ -254,11 +310,11 @@ func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.Pr
 	}
 
 	checkStateRoot := true
-	return MockWithEverything(tb, gspec, key, prune, engine, blockBufferSize, false, withPosDownloader, checkStateRoot)
+	return MockWithEverything(tb, gspec, key, prune, engine, blockBufferSize, false, withPosDownloader, checkStateRoot, opts...)
 }
 
 func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
-	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool,
+	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool, opts ...Option,
 ) *MockSentry {
 	tmpdir := os.TempDir()
 	if tb != nil {
@@ -278,6 +334,10 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 	cfg.PersistReceiptsCacheV2 = true
 	cfg.ChaosMonkey = false
 	cfg.Snapshot.ChainName = gspec.Config.ChainName
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	enableSchemaIndices(tb, &cfg)
 
 	logLvl := log.LvlError
 	if lvl, ok := os.LookupEnv("MOCK_SENTRY_LOG_LEVEL"); ok {
@@ -292,6 +352,17 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	db := temporaltest.NewTestDB(tb, dirs)
+	if err := db.Update(ctx, func(tx kv.RwTx) error {
+		if err := kvcfg.AddressAppearances.ForceWrite(tx, cfg.AddressAppearances); err != nil {
+			return err
+		}
+		if err := kvcfg.AccessLists.ForceWrite(tx, cfg.AccessLists); err != nil {
+			return err
+		}
+		return kvcfg.WithdrawalsIndex.ForceWrite(tx, cfg.WithdrawalsIndex)
+	}); err != nil {
+		panic(err)
+	}
 
 	erigonGrpcServeer := remotedbserver.NewKvServer(ctx, db, nil, nil, nil, logger)
 	allSnapshots := freezeblocks.NewRoSnapshots(cfg.Snapshot, dirs.Snap, 0, logger)
@@ -337,14 +408,25 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 
 	mock.Address = crypto.PubkeyToAddress(mock.Key.PublicKey)
 
-	sendHeaderRequest := func(_ context.Context, r *headerdownload.HeaderRequest) ([64]byte, bool) { return [64]byte{}, false }
+	// requests reach peers only through Outbound, otherwise tests deliver responses themselves
+	sendHeaderRequest := func(ctx context.Context, r *headerdownload.HeaderRequest) ([64]byte, bool) {
+		if mock.Outbound == nil {
+			return [64]byte{}, false
+		}
+		return mock.sentriesClient.SendHeaderRequest(ctx, r)
+	}
 	propagateNewBlockHashes := func(context.Context, []headerdownload.Announce) {}
 	penalize := func(context.Context, []headerdownload.PenaltyItem) {}
 
 	mock.SentryClient = direct.NewSentryClientDirect(direct.ETH68, mock)
 	sentries := []proto_sentry.SentryClient{mock.SentryClient}
 
-	sendBodyRequest := func(context.Context, *bodydownload.BodyRequest) ([64]byte, bool) { return [64]byte{}, false }
+	sendBodyRequest := func(ctx context.Context, r *bodydownload.BodyRequest) ([64]byte, bool) {
+		if mock.Outbound == nil {
+			return [64]byte{}, false
+		}
+		return mock.sentriesClient.SendBodyRequest(ctx, r)
+	}
 	blockPropagator := func(Ctx context.Context, header *types.Header, body *types.RawBody, td *big.Int) {}
 	if !cfg.TxPool.Disable {
 		poolCfg := txpoolcfg.DefaultConfig
@@ -406,6 +488,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		mock.ChainConfig,
 		mock.Genesis,
 		mock.ChainConfig.ChainID.Uint64(),
+		prune,
 		logger,
 	)
 
@@ -0,0 +1,93 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/core"
+)
+
+// servedChain is the canonical chain of the simulated peers: headers and bodies of the
+// simulator. A reorg replaces it, blocks of the replaced fork are unknown to the peers then.
+type servedChain struct {
+	mu      sync.RWMutex
+	genesis *types.Block
+	blocks  []*types.Block // blocks[i] is block i+1
+	byHash  map[common.Hash]*types.Block
+}
+
+func newServedChain(genesis *types.Block, chain *core.ChainPack) *servedChain {
+	c := &servedChain{genesis: genesis}
+	c.set(chain)
+	return c
+}
+
+func (c *servedChain) set(chain *core.ChainPack) {
+	byHash := make(map[common.Hash]*types.Block, len(chain.Blocks)+1)
+	byHash[c.genesis.Hash()] = c.genesis
+	for _, block := range chain.Blocks {
+		byHash[block.Hash()] = block
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocks = chain.Blocks
+	c.byHash = byHash
+}
+
+func (c *servedChain) tip() *types.Block {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.blocks) == 0 {
+		return c.genesis
+	}
+	return c.blocks[len(c.blocks)-1]
+}
+
+func (c *servedChain) Header(_ context.Context, blockNum uint64) (*types.Header, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if blockNum == 0 {
+		return c.genesis.Header(), nil
+	}
+	if blockNum > uint64(len(c.blocks)) {
+		return nil, nil
+	}
+	return c.blocks[blockNum-1].Header(), nil
+}
+
+func (c *servedChain) HeaderByHash(_ context.Context, hash common.Hash) (*types.Header, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if block, ok := c.byHash[hash]; ok {
+		return block.Header(), nil
+	}
+	return nil, nil
+}
+
+func (c *servedChain) BodyByHash(_ context.Context, hash common.Hash) (*types.Body, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if block, ok := c.byHash[hash]; ok {
+		return block.Body(), nil
+	}
+	return nil, nil
+}
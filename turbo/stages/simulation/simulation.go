@@ -0,0 +1,396 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package simulation runs the header, body and execution stages of a mock node against
+// the sentry simulator, with the peers responding in virtual time and faults injected:
+// latency, dropped requests, protocol violations, disconnects, peers with partially
+// available snapshots and reorgs of the served chain.
+//
+// Everything random about a simulation - peer profiles, faults and reorgs - is drawn
+// from its seed, so a sync which deadlocks or gets stuck is reproduced by running the
+// simulation again with the seed of the failure. The node itself still runs on the
+// system clock and the Go scheduler, so its retry timeouts and goroutine interleaving
+// are not part of the seed.
+package simulation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-db/rawdb"
+	"github.com/erigontech/erigon-lib/chain/params"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/mclock"
+	"github.com/erigontech/erigon-lib/common/u256"
+	sentryproto "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/rlp"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon-lib/wrap"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/p2p/protocols/eth"
+	"github.com/erigontech/erigon/p2p/sentry"
+	"github.com/erigontech/erigon/p2p/sentry/simulator"
+	stages2 "github.com/erigontech/erigon/turbo/stages"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+var (
+	// ErrDeadlock - a stage loop iteration or the handling of peer messages did not return in time
+	ErrDeadlock = errors.New("sync deadlock")
+	// ErrNotSynced - the node did not execute the tip of the served chain within the steps limit
+	ErrNotSynced = errors.New("sync did not reach the tip")
+)
+
+const (
+	announceEvery = 20 // steps between announcements of the tip, as peers keep announcing blocks
+	reorgWindow   = 40 // reorgs happen within the first steps, so that the sync has to follow them
+	idleWait      = 50 * time.Millisecond
+	traceTail     = 100 // last trace lines included into the error of a failed simulation
+)
+
+// Faults - kinds and rates of faults injected into a simulation. Peer faults apply to all
+// peers but the first one, which only has latency - so that the chain stays available.
+type Faults struct {
+	MaxLatency            time.Duration // response latency is drawn uniformly from [0, MaxLatency]
+	DropRate              float64       // probability that a peer ignores a request
+	ProtocolViolationRate float64       // probability that a peer sends a broken response
+	DisconnectRate        float64       // probability that a peer disconnects instead of responding
+	PartialSnapshots      float64       // probability that a peer has only a prefix of the chain
+	Reorgs                int           // number of reorgs of the served chain during the sync
+}
+
+// DefaultFaults - moderate rates of all kinds of faults.
+var DefaultFaults = Faults{
+	MaxLatency:            500 * time.Millisecond,
+	DropRate:              0.05,
+	ProtocolViolationRate: 0.05,
+	DisconnectRate:        0.02,
+	PartialSnapshots:      0.3,
+	Reorgs:                2,
+}
+
+type Config struct {
+	Seed        int64
+	Blocks      int           // length of the initial chain, default: 32
+	Peers       int           // number of simulated peers, default: 4
+	Faults      Faults        // default: no faults
+	MaxSteps    int           // stage loop iterations until the sync is considered stuck, default: 500
+	Tick        time.Duration // virtual time passing after every iteration, default: 100ms
+	StepTimeout time.Duration // real time an iteration may take until it is considered deadlocked, default: 1m
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.Blocks <= 0 {
+		cfg.Blocks = 32
+	}
+	if cfg.Peers <= 0 {
+		cfg.Peers = 4
+	}
+	if cfg.MaxSteps <= 0 {
+		cfg.MaxSteps = 500
+	}
+	if cfg.Tick <= 0 {
+		cfg.Tick = 100 * time.Millisecond
+	}
+	if cfg.StepTimeout <= 0 {
+		cfg.StepTimeout = time.Minute
+	}
+	return cfg
+}
+
+// Result of a simulation in which the node executed the tip of the served chain.
+type Result struct {
+	Steps  int           // stage loop iterations
+	Reorgs int           // reorgs the node followed
+	Tip    *types.Header // tip of the served chain
+	Trace  []string      // injected events and delivered messages, in order
+}
+
+type reorg struct {
+	step      int
+	forkBlock uint64
+	chain     *core.ChainPack
+}
+
+type simulation struct {
+	cfg    Config
+	rng    *rand.Rand
+	ms     *mock.MockSentry
+	clock  *mclock.Simulated
+	served *servedChain
+	reorgs []reorg
+	hook   *stages2.Hook
+	step   int
+	peers  map[[64]byte]int
+	trace  []string
+}
+
+// Run syncs a fresh mock node against simulated peers serving a generated chain, until the
+// node executes its tip. An error wraps ErrDeadlock or ErrNotSynced if the sync did not get
+// there, and ends with the seed and the tail of the trace to reproduce it.
+func Run(tb testing.TB, cfg Config) (*Result, error) {
+	cfg = cfg.withDefaults()
+	s := &simulation{
+		cfg:   cfg,
+		rng:   rand.New(rand.NewSource(cfg.Seed)),
+		ms:    mock.Mock(tb),
+		clock: &mclock.Simulated{},
+		peers: map[[64]byte]int{},
+	}
+	res, err := s.run()
+	if err != nil {
+		trace := s.trace[max(0, len(s.trace)-traceTail):]
+		return nil, fmt.Errorf("seed %d, step %d: %w\ntrace:\n%s", cfg.Seed, s.step, err, strings.Join(trace, "\n"))
+	}
+	return res, nil
+}
+
+func (s *simulation) run() (*Result, error) {
+	// chains are generated before the sync, because generation reads the state of the node db
+	chain, err := s.planChains()
+	if err != nil {
+		return nil, err
+	}
+	s.served = newServedChain(s.ms.Genesis, chain)
+
+	peers, err := simulator.NewSentry(s.ms.Ctx, "", "", s.cfg.Peers, s.ms.Log,
+		simulator.WithSeed(s.cfg.Seed),
+		simulator.WithPeerProfiles(s.peerProfiles()...),
+		simulator.WithHeaderReader(s.served),
+		simulator.WithBodyReader(s.served),
+		simulator.WithClock(s.clock),
+		simulator.WithInboundHandler(s.deliver))
+	if err != nil {
+		return nil, err
+	}
+	s.ms.Outbound = peers
+	s.hook = stages2.NewHook(s.ms.Ctx, s.ms.DB, s.ms.Notifications, s.ms.Sync, s.ms.BlockReader, s.ms.ChainConfig, s.ms.Log, nil)
+
+	var reorgs int
+	for s.step = 0; s.step < s.cfg.MaxSteps; s.step++ {
+		if reorgs < len(s.reorgs) && s.reorgs[reorgs].step == s.step {
+			r := s.reorgs[reorgs]
+			s.served.set(r.chain)
+			s.tracef("reorg at block %d to tip %d %x", r.forkBlock, r.chain.TopBlock.NumberU64(), r.chain.TopBlock.Hash())
+			reorgs++
+		}
+		if s.step%announceEvery == 0 || (reorgs > 0 && s.reorgs[reorgs-1].step == s.step) {
+			if err := s.announce(); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.iterate(); err != nil {
+			return nil, err
+		}
+		synced, err := s.synced()
+		if err != nil {
+			return nil, err
+		}
+		if synced && reorgs == len(s.reorgs) {
+			return &Result{Steps: s.step + 1, Reorgs: reorgs, Tip: s.served.tip().Header(), Trace: s.trace}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w in %d steps", ErrNotSynced, s.cfg.MaxSteps)
+}
+
+// planChains generates the initial chain and draws the reorgs with the chains they switch to.
+// Every fork is longer than the chain it replaces, so that it is heavier.
+func (s *simulation) planChains() (*core.ChainPack, error) {
+	// marks[i] is put into the coinbase of block i+1, blocks of a fork get a new mark
+	marks := make([]byte, s.cfg.Blocks)
+	chain, err := s.generate(marks)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < s.cfg.Faults.Reorgs; i++ {
+		forkBlock := 1 + s.rng.Intn(len(marks))
+		length := len(marks) + 1 + s.rng.Intn(4)
+		forked := make([]byte, length)
+		copy(forked, marks[:forkBlock-1])
+		for j := forkBlock - 1; j < length; j++ {
+			forked[j] = byte(i + 1)
+		}
+		marks = forked
+		fork, err := s.generate(marks)
+		if err != nil {
+			return nil, err
+		}
+		s.reorgs = append(s.reorgs, reorg{step: 1 + s.rng.Intn(reorgWindow), forkBlock: uint64(forkBlock), chain: fork})
+	}
+	// reorgs apply in order of steps, each fork still extends the previous one's prefix
+	for i := 1; i < len(s.reorgs); i++ {
+		s.reorgs[i].step = max(s.reorgs[i].step, s.reorgs[i-1].step+1)
+	}
+	return chain, nil
+}
+
+// generate makes a chain with a value transfer in every block, so that execution has work.
+func (s *simulation) generate(marks []byte) (*core.ChainPack, error) {
+	signer := types.LatestSignerForChainID(s.ms.ChainConfig.ChainID)
+	var genErr error
+	chain, err := core.GenerateChain(s.ms.ChainConfig, s.ms.Genesis, s.ms.Engine, s.ms.DB, len(marks), func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{0: marks[i]})
+		txn, err := types.SignTx(types.NewTransaction(b.TxNonce(s.ms.Address), common.Address{1, byte(i)}, uint256.NewInt(1_000), params.TxGas, u256.Num1, nil), *signer, s.ms.Key)
+		if err != nil {
+			genErr = err
+			return
+		}
+		b.AddTx(txn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return chain, genErr
+}
+
+func (s *simulation) peerProfiles() []simulator.PeerProfile {
+	faults := s.cfg.Faults
+	profiles := make([]simulator.PeerProfile, s.cfg.Peers)
+	for i := range profiles {
+		profiles[i].Latency = simulator.UniformLatency{Max: faults.MaxLatency}
+		if i == 0 {
+			continue
+		}
+		profiles[i].DropRate = faults.DropRate
+		profiles[i].ProtocolViolationRate = faults.ProtocolViolationRate
+		profiles[i].DisconnectRate = faults.DisconnectRate
+		profiles[i].ReconnectDelay = time.Duration(1+s.rng.Intn(10)) * s.cfg.Tick
+		if s.rng.Float64() < faults.PartialSnapshots {
+			available := simulator.BlockRange{To: 1 + uint64(s.rng.Intn(s.cfg.Blocks))}
+			profiles[i].Available = []simulator.BlockRange{available}
+			s.tracef("peer#%d serves blocks below %d", i, available.To)
+		}
+	}
+	return profiles
+}
+
+// announce sends the tip of the served chain to the node as a new block.
+func (s *simulation) announce() error {
+	tip := s.served.tip()
+	b, err := rlp.EncodeToBytes(&eth.NewBlockPacket{
+		Block: tip,
+		TD:    big.NewInt(1), // This is ignored anyway
+	})
+	if err != nil {
+		return err
+	}
+	s.tracef("announce block %d %x", tip.NumberU64(), tip.Hash())
+	s.ms.ReceiveWg.Add(1)
+	for _, err := range s.ms.Send(&sentryproto.InboundMessage{Id: sentryproto.MessageId_NEW_BLOCK_66, Data: b, PeerId: s.ms.PeerId}) {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterate runs one stage loop iteration, then lets a tick of virtual time pass - in which
+// the peers respond to the requests of the node - and waits until the node handled the
+// responses.
+func (s *simulation) iterate() error {
+	err := withDeadline(s.cfg.StepTimeout, "stage loop iteration", func() error {
+		return stages2.StageLoopIteration(s.ms.Ctx, s.ms.DB, wrap.NewTxContainer(nil, nil), s.ms.Sync, mock.MockInsertAsInitialCycle, false, s.ms.Log, s.ms.BlockReader, s.hook)
+	})
+	if errors.Is(err, common.ErrStopped) {
+		// in tests the bodies stage stops after one round of requests instead of waiting for the
+		// bodies. The next iteration starts from the headers, as the node does when bodies make no
+		// progress - bodies of a replaced fork never arrive
+		err = s.ms.Sync.SetCurrentStage(stages.Headers)
+	}
+	if err != nil {
+		return err
+	}
+	if s.clock.ActiveTimers() == 0 {
+		// nothing in flight: give the retry timeouts of the node, on the system clock, a chance
+		time.Sleep(idleWait)
+	}
+	s.clock.Run(s.cfg.Tick)
+	return withDeadline(s.cfg.StepTimeout, "handling of peer messages", func() error {
+		s.ms.ReceiveWg.Wait()
+		return nil
+	})
+}
+
+// deliver passes a message of a simulated peer to the node. It's called by the simulator
+// while the virtual clock runs, so messages are delivered in order of their virtual time.
+func (s *simulation) deliver(message *sentryproto.InboundMessage) {
+	peerId := sentry.ConvertH512ToPeerID(message.PeerId)
+	peer, ok := s.peers[peerId]
+	if !ok {
+		peer = len(s.peers)
+		s.peers[peerId] = peer
+	}
+	s.tracef("deliver %s of %d bytes from peer %d", message.Id, len(message.Data), peer)
+	s.ms.ReceiveWg.Add(1)
+	for _, err := range s.ms.Send(message) {
+		if err != nil {
+			s.tracef("can't deliver %s: %v", message.Id, err)
+		}
+	}
+}
+
+// synced reports whether the node executed the tip of the served chain.
+func (s *simulation) synced() (bool, error) {
+	tip := s.served.tip()
+	var synced bool
+	err := s.ms.DB.View(s.ms.Ctx, func(tx kv.Tx) error {
+		execAt, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		hash, err := rawdb.ReadCanonicalHash(tx, tip.NumberU64())
+		if err != nil {
+			return err
+		}
+		synced = execAt >= tip.NumberU64() && hash == tip.Hash()
+		return nil
+	})
+	return synced, err
+}
+
+func (s *simulation) tracef(format string, args ...any) {
+	s.trace = append(s.trace, fmt.Sprintf("step %d: ", s.step)+fmt.Sprintf(format, args...))
+}
+
+// withDeadline runs fn, and fails with the stacks of all goroutines if it does not return
+// within timeout. The goroutine running fn is leaked then.
+func withDeadline(timeout time.Duration, what string, fn func() error) error {
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		var stacks bytes.Buffer
+		_ = pprof.Lookup("goroutine").WriteTo(&stacks, 2)
+		return fmt.Errorf("%w: %s did not return in %s\n%s", ErrDeadlock, what, timeout, stacks.String())
+	}
+}
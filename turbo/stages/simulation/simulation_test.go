@@ -0,0 +1,58 @@
+// Copyright 2025 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simulation_test
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/turbo/stages/simulation"
+)
+
+// seedEnv - set it to reproduce a failed simulation, e.g. SYNC_SIMULATION_SEED=7
+const seedEnv = "SYNC_SIMULATION_SEED"
+
+func TestSyncWithoutFaults(t *testing.T) {
+	res, err := simulation.Run(t, simulation.Config{Seed: 1, Blocks: 16, Peers: 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(16), res.Tip.Number.Uint64())
+	require.Zero(t, res.Reorgs)
+}
+
+func TestSyncWithFaults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("slow test")
+	}
+	seeds := []int64{1, 2, 3}
+	if env, ok := os.LookupEnv(seedEnv); ok {
+		seed, err := strconv.ParseInt(env, 10, 64)
+		require.NoError(t, err)
+		seeds = []int64{seed}
+	}
+	for _, seed := range seeds {
+		t.Run(fmt.Sprintf("seed %d", seed), func(t *testing.T) {
+			res, err := simulation.Run(t, simulation.Config{Seed: seed, Faults: simulation.DefaultFaults})
+			require.NoError(t, err, "reproduce with %s=%d", seedEnv, seed)
+			require.Equal(t, simulation.DefaultFaults.Reorgs, res.Reorgs)
+			require.Greater(t, res.Tip.Number.Uint64(), uint64(32))
+		})
+	}
+}
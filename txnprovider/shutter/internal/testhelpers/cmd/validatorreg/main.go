@@ -22,6 +22,7 @@ import (
 	"math"
 	"math/big"
 
+	"github.com/erigontech/erigon-lib/chain/networkname"
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/execution/abi/bind"
@@ -29,19 +30,24 @@ import (
 	"github.com/erigontech/erigon/rpc/contracts"
 	"github.com/erigontech/erigon/txnprovider/shutter"
 	shuttercontracts "github.com/erigontech/erigon/txnprovider/shutter/internal/contracts"
+	"github.com/erigontech/erigon/txnprovider/shutter/shuttercfg"
 )
 
 func main() {
 	elUrlFlag := flag.String("el-url", "", "execution layer url")
-	valRegAddrFlag := flag.String("validator-registry-address", "", "validator registry smart contract address")
+	chainFlag := flag.String("chain", networkname.Chiado, "chain name: gnosis or chiado")
+	valRegAddrFlag := flag.String("validator-registry-address", "", "validator registry smart contract address (defaults to the one of the chain)")
 	fromIndexFlag := flag.Int64("from-index", 0, "validator from index filter")
 	toIndexFlag := flag.Int64("to-index", math.MaxInt64, "validator to index filter (exclusive)")
 	flag.Parse()
 	if elUrlFlag == nil || *elUrlFlag == "" {
 		panic("el-url flag is required")
 	}
-	if valRegAddrFlag == nil || *valRegAddrFlag == "" {
-		panic("validator-registry-address flag is required")
+	if *chainFlag != networkname.Gnosis && *chainFlag != networkname.Chiado {
+		panic("chain flag must be gnosis or chiado")
+	}
+	if *valRegAddrFlag == "" {
+		*valRegAddrFlag = shuttercfg.ConfigByChainName(*chainFlag).ValidatorRegistryContractAddress
 	}
 
 	logger := log.New()
@@ -60,7 +66,7 @@ func main() {
 	}
 
 	logger.Info("num updates", "num", n.Uint64())
-	chainId := params.ChiadoChainConfig.ChainID
+	chainId := params.ChainConfigByChainName(*chainFlag).ChainID
 	for i := uint64(0); i < n.Uint64(); i++ {
 		u, err := valReg.GetUpdate(&callOpts, big.NewInt(int64(i)))
 		if err != nil {
@@ -219,6 +219,26 @@ func TestSendTxnPropagate(t *testing.T) {
 	})
 }
 
+func TestPropagationFanOut(t *testing.T) {
+	for _, tt := range []struct {
+		peers                       uint64
+		announceRatio               float64
+		broadcastRatio              float64
+		wantAnnounce, wantBroadcast uint64
+	}{
+		{peers: 0, announceRatio: 1, broadcastRatio: 0.3, wantAnnounce: 1, wantBroadcast: 1},
+		{peers: 1, announceRatio: 1, broadcastRatio: 0.3, wantAnnounce: 1, wantBroadcast: 1},
+		{peers: 100, announceRatio: 1, broadcastRatio: 0.3, wantAnnounce: 10, wantBroadcast: 3},
+		{peers: 500, announceRatio: 1, broadcastRatio: 0.3, wantAnnounce: 23, wantBroadcast: 7},
+		{peers: 500, announceRatio: 2, broadcastRatio: 0, wantAnnounce: 45, wantBroadcast: 0},
+		{peers: 4, announceRatio: 10, broadcastRatio: 10, wantAnnounce: 4, wantBroadcast: 4},
+	} {
+		announce, broadcast := propagationFanOut(tt.peers, tt.announceRatio, tt.broadcastRatio)
+		assert.Equal(t, tt.wantAnnounce, announce, "announce to %d peers", tt.peers)
+		assert.Equal(t, tt.wantBroadcast, broadcast, "broadcast to %d peers", tt.peers)
+	}
+}
+
 func decodeHex(in string) []byte {
 	payload, err := hex.DecodeString(in)
 	if err != nil {
@@ -16,10 +16,14 @@
 
 package txpool
 
-import "github.com/holiman/uint256"
+import (
+	"time"
+
+	"github.com/holiman/uint256"
+)
 
 func newMetaTxn(slot *TxnSlot, isLocal bool, timestamp uint64) *metaTxn {
-	mt := &metaTxn{TxnSlot: slot, worstIndex: -1, bestIndex: -1, timestamp: timestamp}
+	mt := &metaTxn{TxnSlot: slot, worstIndex: -1, bestIndex: -1, timestamp: timestamp, added: time.Now()}
 	if isLocal {
 		mt.subPool = IsLocal
 	}
@@ -35,7 +39,8 @@ type metaTxn struct {
 	minTip                    uint64
 	bestIndex                 int
 	worstIndex                int
-	timestamp                 uint64 // when it was added to pool
+	timestamp                 uint64    // when it was added to pool
+	added                     time.Time // wall clock time it was added to pool, for propagation metrics
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
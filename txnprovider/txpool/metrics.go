@@ -29,4 +29,7 @@ var (
 	pendingSubCounter       = metrics.GetOrCreateGauge(`txpool_pending`)
 	queuedSubCounter        = metrics.GetOrCreateGauge(`txpool_queued`)
 	basefeeSubCounter       = metrics.GetOrCreateGauge(`txpool_basefee`)
+	propagationLatency      = metrics.NewSummary(`pool_txn_propagation_latency`)
+	announceFanOutGauge     = metrics.GetOrCreateGauge(`pool_announce_fanout`)
+	broadcastFanOutGauge    = metrics.GetOrCreateGauge(`pool_broadcast_fanout`)
 )
@@ -152,6 +152,7 @@ type TxPool struct {
 	builderNotifyNewTxns    func()
 	logger                  log.Logger
 	auths                   map[common.Address]*metaTxn // All accounts with a pooled authorization
+	rejectList              atomic.Pointer[RejectList]  // `--txpool.rejectlist`, reloaded on file change
 	blobHashToTxn           map[common.Hash]struct {
 		index   int
 		txnHash common.Hash
@@ -273,6 +274,13 @@ func New(
 		pragueTimeU64 := pragueTime.Uint64()
 		res.pragueTime = &pragueTimeU64
 	}
+	if cfg.RejectListFile != "" {
+		rejectList, err := ReadRejectList(cfg.RejectListFile)
+		if err != nil {
+			return nil, err
+		}
+		res.rejectList.Store(rejectList)
+	}
 
 	res.p2pFetcher = NewFetch(ctx, sentryClients, res, stateChangesClient, poolDB, chainID, logger, opts...)
 	res.p2pSender = NewSend(ctx, sentryClients, logger, opts...)
@@ -746,6 +754,19 @@ func (p *TxPool) IsLocal(idHash []byte) bool {
 	return p.isLocalLRU.Contains(hashS)
 }
 
+// observePropagationLatency records how long the given txns stayed in the pool before
+// being propagated.
+func (p *TxPool) observePropagationLatency(hashes Hashes) {
+	now := time.Now()
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i := 0; i < hashes.Len(); i++ {
+		if mt, ok := p.byHash[string(hashes.At(i))]; ok && !mt.added.IsZero() {
+			propagationLatency.Observe(now.Sub(mt.added).Seconds())
+		}
+	}
+}
+
 func (p *TxPool) AddNewGoodPeer(peerID PeerID) {
 	p.recentlyConnectedPeers.AddPeer(peerID)
 }
@@ -932,7 +953,33 @@ func toBlobs(_blobs [][]byte) []gokzg4844.BlobRef {
 	return blobs
 }
 
+// verifyBlobProofs - KZG proofs of all blob txns of the batch are verified together (and with blob sidecars of
+// Caplin): one pairing check instead of one per txn. validateTx verifies only txns which failed here
+func (p *TxPool) verifyBlobProofs(txns *TxnSlots) {
+	var items []libkzg.BlobProofs
+	var blobTxns []*TxnSlot
+	for _, txn := range txns.Txns {
+		if txn.Type != BlobTxnType || len(txn.Blobs) == 0 || len(txn.Blobs) != len(txn.Commitments) || len(txn.Commitments) != len(txn.Proofs) {
+			continue
+		}
+		items = append(items, libkzg.BlobProofs{Blobs: toBlobs(txn.Blobs), Commitments: txn.Commitments, Proofs: txn.Proofs})
+		blobTxns = append(blobTxns, txn)
+	}
+	if len(items) == 0 {
+		return
+	}
+	for i, err := range libkzg.VerifyBlobKZGProofsNow(items...) {
+		blobTxns[i].blobProofsVerified = err == nil
+	}
+}
+
 func (p *TxPool) validateTx(txn *TxnSlot, isLocal bool, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
+	if rule := p.matchRejectList(txn); rule != "" {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx rejected by reject-list idHash=%x rule=%s", txn.IDHash, rule))
+		}
+		return txpoolcfg.RejectListed
+	}
 	isShanghai := p.isShanghai() || p.isAgra()
 	if isShanghai && txn.Creation && txn.DataLen > params.MaxInitCodeSize {
 		return txpoolcfg.InitCodeTooLarge // EIP-3860
@@ -966,10 +1013,12 @@ func (p *TxPool) validateTx(txn *TxnSlot, isLocal bool, stateCache kvcache.Cache
 		}
 
 		// https://github.com/ethereum/consensus-specs/blob/017a8495f7671f5fff2075a9bfc9238c1a0982f8/specs/deneb/polynomial-commitments.md#verify_blob_kzg_proof_batch
-		kzgCtx := libkzg.Ctx()
-		err := kzgCtx.VerifyBlobKZGProofBatch(toBlobs(txn.Blobs), txn.Commitments, txn.Proofs)
-		if err != nil {
-			return txpoolcfg.UnmatchedBlobTxExt
+		if !txn.blobProofsVerified {
+			kzgCtx := libkzg.Ctx()
+			err := kzgCtx.VerifyBlobKZGProofBatch(toBlobs(txn.Blobs), txn.Commitments, txn.Proofs)
+			if err != nil {
+				return txpoolcfg.UnmatchedBlobTxExt
+			}
 		}
 
 		if !isLocal && (p.all.blobCount(txn.SenderID)+uint64(len(txn.BlobHashes))) > p.cfg.BlobSlots {
@@ -1225,6 +1274,7 @@ func (p *TxPool) validateTxns(txns *TxnSlots, stateCache kvcache.CacheView) (rea
 		return reasons, goodTxns, err
 	}
 
+	p.verifyBlobProofs(txns)
 	goodCount := 0
 	for i, txn := range txns.Txns {
 		reason := p.validateTx(txn, txns.IsLocal[i], stateCache)
@@ -2099,6 +2149,8 @@ func (p *TxPool) Run(ctx context.Context) error {
 	defer commitEvery.Stop()
 	logEvery := time.NewTicker(p.cfg.LogEvery)
 	defer logEvery.Stop()
+	reloadRejectListEvery := time.NewTicker(10 * time.Second)
+	defer reloadRejectListEvery.Stop()
 
 	if err := p.start(ctx); err != nil {
 		p.logger.Error("[txpool] Failed to start", "err", err)
@@ -2116,6 +2168,8 @@ func (p *TxPool) Run(ctx context.Context) error {
 			return err
 		case <-logEvery.C:
 			p.logStats()
+		case <-reloadRejectListEvery.C:
+			p.reloadRejectList()
 		case <-processRemoteTxnsEvery.C:
 			if !p.Started() {
 				continue
@@ -2227,22 +2281,31 @@ func (p *TxPool) Run(ctx context.Context) error {
 					p.newSlotsStreams.Broadcast(&txpoolproto.OnAddReply{RplTxs: slotsRlp}, p.logger)
 				}
 
-				// broadcast local transactions
+				announceMaxPeers, broadcastMaxPeers := propagationFanOut(p.p2pSender.PeerCount(), p.cfg.AnnounceRatio, p.cfg.BroadcastRatio)
+				announceFanOutGauge.SetUint64(announceMaxPeers)
+				broadcastFanOutGauge.SetUint64(broadcastMaxPeers)
+
+				// broadcast local transactions, they are pushed at least as widely as remote ones
 				const localTxnsBroadcastMaxPeers uint64 = 10
-				txnSentTo := p.p2pSender.BroadcastPooledTxns(localTxnRlps, localTxnsBroadcastMaxPeers)
+				txnSentTo := p.p2pSender.BroadcastPooledTxns(localTxnRlps, max(localTxnsBroadcastMaxPeers, broadcastMaxPeers))
 				for i, peer := range txnSentTo {
 					p.logger.Trace("Local txn broadcast", "txHash", hex.EncodeToString(broadcastHashes.At(i)), "to peer", peer)
 				}
-				hashSentTo := p.p2pSender.AnnouncePooledTxns(localTxnTypes, localTxnSizes, localTxnHashes, localTxnsBroadcastMaxPeers*2)
+				hashSentTo := p.p2pSender.AnnouncePooledTxns(localTxnTypes, localTxnSizes, localTxnHashes, max(localTxnsBroadcastMaxPeers*2, announceMaxPeers))
 				for i := 0; i < localTxnHashes.Len(); i++ {
 					hash := localTxnHashes.At(i)
 					p.logger.Trace("Local txn announced", "txHash", hex.EncodeToString(hash), "to peer", hashSentTo[i], "baseFee", p.pendingBaseFee.Load())
 				}
 
-				// broadcast remote transactions
-				const remoteTxnsBroadcastMaxPeers uint64 = 3
-				p.p2pSender.BroadcastPooledTxns(remoteTxnRlps, remoteTxnsBroadcastMaxPeers)
-				p.p2pSender.AnnouncePooledTxns(remoteTxnTypes, remoteTxnSizes, remoteTxnHashes, remoteTxnsBroadcastMaxPeers*2)
+				// broadcast remote transactions, a max peers of 0 would mean all peers to the sentry
+				if broadcastMaxPeers > 0 {
+					p.p2pSender.BroadcastPooledTxns(remoteTxnRlps, broadcastMaxPeers)
+				}
+				if announceMaxPeers > 0 {
+					p.p2pSender.AnnouncePooledTxns(remoteTxnTypes, remoteTxnSizes, remoteTxnHashes, announceMaxPeers)
+				}
+
+				p.observePropagationLatency(announcements.Hashes())
 			}()
 		case <-syncToNewPeersEvery.C: // new peer
 			newPeers := p.recentlyConnectedPeers.GetAndClean()
@@ -2571,6 +2634,11 @@ func (p *TxPool) logStats() {
 	pendingSubCounter.SetInt(p.pending.Len())
 	basefeeSubCounter.SetInt(p.baseFee.Len())
 	queuedSubCounter.SetInt(p.queued.Len())
+	diagnostics.Send(diagnostics.TxPoolStatistics{
+		Pending: p.pending.Len(),
+		BaseFee: p.baseFee.Len(),
+		Queued:  p.queued.Len(),
+	})
 }
 
 // Deprecated need switch to streaming-like
@@ -674,6 +674,8 @@ func (ctx *TxnParseContext) parseTransactionBody(payload []byte, pos, p0 int, sl
 	_, _ = ctx.Keccak2.(io.Reader).Read(ctx.buf[:32])
 	//take last 20 bytes as address
 	copy(sender, ctx.buf[12:32])
+	// senders recovery of blocks will not ecrecover it again
+	types.CacheRecoveredSender(slot.IDHash, common.Address(ctx.buf[12:32]))
 
 	return p, nil
 }
@@ -842,6 +844,8 @@ type TxnSlot struct {
 	Commitments []gokzg4844.KZGCommitment
 	Proofs      []gokzg4844.KZGProof
 
+	blobProofsVerified bool // KZG proofs were verified in batch with other txns (TxPool.verifyBlobProofs)
+
 	Authorities []*common.Address // Indexed authorization signers for EIP-7702 txns (type-4)
 
 	// RIP-7560: account abstraction
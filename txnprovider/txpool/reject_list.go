@@ -0,0 +1,189 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// RejectListJson - format of `--txpool.rejectlist` file. Operators can update it at runtime (file is re-read on change)
+// to stop recurring spam campaigns without code changes:
+//
+//	{
+//	  "selectors": ["0xa9059cbb"],             // 4-byte function selectors (first 4 bytes of calldata)
+//	  "calldata":  ["^095ea7b3.{64}f{64}$"],   // regexps over lowercase hex calldata (without 0x) of calls
+//	  "initcode":  ["5b5b5b5b5b5b5b5b"]        // regexps over lowercase hex init code of contract creations
+//	}
+type RejectListJson struct {
+	Selectors []string `json:"selectors"`
+	Calldata  []string `json:"calldata"`
+	Initcode  []string `json:"initcode"`
+}
+
+// Reject-list rules - also used as metric label
+const (
+	RejectRuleSelector = "selector"
+	RejectRuleCalldata = "calldata"
+	RejectRuleInitcode = "initcode"
+)
+
+var rejectListHits = map[string]metrics.Counter{
+	RejectRuleSelector: metrics.GetOrCreateCounter(`txpool_rejectlist_hits{rule="selector"}`),
+	RejectRuleCalldata: metrics.GetOrCreateCounter(`txpool_rejectlist_hits{rule="calldata"}`),
+	RejectRuleInitcode: metrics.GetOrCreateCounter(`txpool_rejectlist_hits{rule="initcode"}`),
+}
+
+type RejectList struct {
+	selectors map[[4]byte]struct{}
+	calldata  []*regexp.Regexp
+	initcode  []*regexp.Regexp
+
+	file    string
+	modTime time.Time
+}
+
+func ParseRejectList(data []byte) (*RejectList, error) {
+	var j RejectListJson
+	if err := json.Unmarshal(data, &j); err != nil {
+		return nil, fmt.Errorf("txpool reject-list: %w", err)
+	}
+	l := &RejectList{selectors: make(map[[4]byte]struct{}, len(j.Selectors))}
+	for _, s := range j.Selectors {
+		b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+		if err != nil || len(b) != 4 {
+			return nil, fmt.Errorf("txpool reject-list: selector must be 4 bytes hex: %q", s)
+		}
+		l.selectors[[4]byte(b)] = struct{}{}
+	}
+	var err error
+	if l.calldata, err = compilePatterns(j.Calldata); err != nil {
+		return nil, err
+	}
+	if l.initcode, err = compilePatterns(j.Initcode); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("txpool reject-list: pattern %q: %w", p, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+func ReadRejectList(file string) (*RejectList, error) {
+	st, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	l, err := ParseRejectList(data)
+	if err != nil {
+		return nil, err
+	}
+	l.file, l.modTime = file, st.ModTime()
+	return l, nil
+}
+
+func (l *RejectList) Empty() bool {
+	return l == nil || (len(l.selectors) == 0 && len(l.calldata) == 0 && len(l.initcode) == 0)
+}
+
+// Match - returns rule which rejects txn data, or empty string
+func (l *RejectList) Match(data []byte, creation bool) string {
+	if creation {
+		if len(l.initcode) > 0 && matchAny(l.initcode, hex.EncodeToString(data)) {
+			return RejectRuleInitcode
+		}
+		return ""
+	}
+	if len(data) >= 4 {
+		if _, ok := l.selectors[[4]byte(data[:4])]; ok {
+			return RejectRuleSelector
+		}
+	}
+	if len(l.calldata) > 0 && matchAny(l.calldata, hex.EncodeToString(data)) {
+		return RejectRuleCalldata
+	}
+	return ""
+}
+
+func matchAny(patterns []*regexp.Regexp, s string) bool {
+	for _, re := range patterns {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRejectList - txn data is not in TxnSlot, so decode it from rlp (only if reject-list is configured)
+func (p *TxPool) matchRejectList(txn *TxnSlot) string {
+	l := p.rejectList.Load()
+	if l.Empty() || txn.Rlp == nil {
+		return ""
+	}
+	decoded, err := types.DecodeWrappedTransaction(txn.Rlp)
+	if err != nil {
+		return ""
+	}
+	rule := l.Match(decoded.GetData(), txn.Creation)
+	if rule != "" {
+		rejectListHits[rule].Inc()
+	}
+	return rule
+}
+
+// reloadRejectList - re-reads `--txpool.rejectlist` file if it was changed. Previous list stays if new one is invalid.
+func (p *TxPool) reloadRejectList() {
+	if p.cfg.RejectListFile == "" {
+		return
+	}
+	st, err := os.Stat(p.cfg.RejectListFile)
+	if err != nil {
+		p.logger.Warn("[txpool] reject-list", "err", err)
+		return
+	}
+	if l := p.rejectList.Load(); l != nil && l.modTime.Equal(st.ModTime()) {
+		return
+	}
+	l, err := ReadRejectList(p.cfg.RejectListFile)
+	if err != nil {
+		p.logger.Warn("[txpool] reject-list not updated", "err", err)
+		return
+	}
+	p.rejectList.Store(l)
+	p.logger.Info("[txpool] reject-list loaded", "file", l.file, "selectors", len(l.selectors), "calldata", len(l.calldata), "initcode", len(l.initcode))
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/u256"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+	"github.com/erigontech/erigon/txnprovider/txpool/txpoolcfg"
+)
+
+func TestRejectListMatch(t *testing.T) {
+	require := require.New(t)
+	l, err := ParseRejectList([]byte(`{"selectors": ["0xa9059cbb"], "calldata": ["^095ea7b3.{64}f{64}$"], "initcode": ["5b5b5b5b"]}`))
+	require.NoError(err)
+	require.False(l.Empty())
+
+	require.Equal(RejectRuleSelector, l.Match(common.FromHex("0xa9059cbb0000"), false))
+	require.Equal("", l.Match(common.FromHex("0xa9059cbb0000"), true)) // selectors are for calls only
+	require.Equal("", l.Match(common.FromHex("0xa905"), false))
+
+	approveMax := append(common.FromHex("0x095ea7b3"), append(bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{0xff}, 32)...)...)
+	require.Equal(RejectRuleCalldata, l.Match(approveMax, false))
+	approveMax[len(approveMax)-1] = 0
+	require.Equal("", l.Match(approveMax, false))
+
+	require.Equal(RejectRuleInitcode, l.Match(common.FromHex("0x60005b5b5b5b00"), true))
+	require.Equal("", l.Match(common.FromHex("0x60005b5b5b5b00"), false))
+
+	_, err = ParseRejectList([]byte(`{"selectors": ["0xa9059c"]}`))
+	require.Error(err)
+	_, err = ParseRejectList([]byte(`{"calldata": ["("]}`))
+	require.Error(err)
+	require.True((*RejectList)(nil).Empty())
+}
+
+func TestRejectListInPool(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	file := filepath.Join(t.TempDir(), "rejectlist.json")
+	require.NoError(os.WriteFile(file, []byte(`{"selectors": ["0xdeadbeef"]}`), 0644))
+
+	cfg := txpoolcfg.DefaultConfig
+	cfg.RejectListFile = file
+	coreDB := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	pool, err := New(ctx, make(chan Announcements, 1), nil, coreDB, cfg, kvcache.NewDummy(), *u256.N1, nil, nil, nil, nil, nil, nil, nil, func() {}, nil, nil, log.New(), WithFeeCalculator(nil))
+	require.NoError(err)
+
+	slot := func(data []byte) *TxnSlot {
+		to := common.Address{1}
+		var buf bytes.Buffer
+		txn := &types.LegacyTx{CommonTx: types.CommonTx{To: &to, Data: data, GasLimit: 100_000, Value: uint256.NewInt(0)}, GasPrice: uint256.NewInt(1)}
+		require.NoError(txn.MarshalBinary(&buf))
+		return &TxnSlot{Rlp: buf.Bytes()}
+	}
+	require.Equal(RejectRuleSelector, pool.matchRejectList(slot(common.FromHex("0xdeadbeef01"))))
+	require.Equal("", pool.matchRejectList(slot(common.FromHex("0xa9059cbb01"))))
+
+	// file changed - new list is used, broken file keeps previous list
+	require.NoError(os.WriteFile(file, []byte(`{"selectors": ["0xa9059cbb"]}`), 0644))
+	require.NoError(os.Chtimes(file, pool.rejectList.Load().modTime.Add(1), pool.rejectList.Load().modTime.Add(1)))
+	pool.reloadRejectList()
+	require.Equal("", pool.matchRejectList(slot(common.FromHex("0xdeadbeef01"))))
+	require.Equal(RejectRuleSelector, pool.matchRejectList(slot(common.FromHex("0xa9059cbb01"))))
+
+	require.NoError(os.WriteFile(file, []byte(`{"selectors": [`), 0644))
+	require.NoError(os.Chtimes(file, pool.rejectList.Load().modTime.Add(2), pool.rejectList.Load().modTime.Add(2)))
+	pool.reloadRejectList()
+	require.Equal(RejectRuleSelector, pool.matchRejectList(slot(common.FromHex("0xa9059cbb01"))))
+}
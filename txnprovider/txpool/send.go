@@ -19,6 +19,7 @@ package txpool
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"slices"
 	"sync"
@@ -62,6 +63,38 @@ func (f *Send) notifyTests() {
 	}
 }
 
+// PeerCount returns the number of peers of the ready sentries.
+func (f *Send) PeerCount() (count uint64) {
+	for _, sentryClient := range f.sentryClients {
+		if ready, ok := sentryClient.(interface{ Ready() bool }); ok && !ready.Ready() {
+			continue
+		}
+		reply, err := sentryClient.PeerCount(f.ctx, &sentryproto.PeerCountRequest{})
+		if err != nil {
+			f.logger.Debug("[txpool.send] PeerCount", "err", err)
+			continue
+		}
+		count += reply.Count
+	}
+	return count
+}
+
+// propagationFanOut returns to how many peers new txns are announced, and to how many
+// they are pushed in full. Both grow with the square root of the number of peers: with
+// eth/68 most peers learn about txns from the announcements and fetch the ones they
+// miss, so pushing full txns to every peer mostly wastes bandwidth on nodes with many
+// peers.
+func propagationFanOut(peers uint64, announceRatio, broadcastRatio float64) (announce, broadcast uint64) {
+	sqrt := math.Sqrt(float64(peers))
+	fanOut := func(ratio float64) uint64 {
+		if ratio <= 0 {
+			return 0
+		}
+		return min(max(uint64(math.Ceil(ratio*sqrt)), 1), max(peers, 1))
+	}
+	return fanOut(announceRatio), fanOut(broadcastRatio)
+}
+
 // Broadcast given RLPs to random peers
 func (f *Send) BroadcastPooledTxns(rlps [][]byte, maxPeers uint64) (txnSentTo []int) {
 	defer f.notifyTests()
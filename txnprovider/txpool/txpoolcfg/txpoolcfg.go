@@ -58,8 +58,15 @@ type Config struct {
 
 	NoGossip bool // this mode doesn't broadcast any txns, and if receive remote-txn - skip it
 
+	// eth/68 propagation of new txns: hashes are announced to AnnounceRatio*sqrt(peers) peers
+	// and full txns are pushed to BroadcastRatio*sqrt(peers) peers, 0 disables the push
+	AnnounceRatio  float64
+	BroadcastRatio float64
+
 	// Account Abstraction
 	AllowAA bool
+
+	RejectListFile string // txns matching its patterns are rejected (see txpool.RejectListJson)
 }
 
 var DefaultConfig = Config{
@@ -81,6 +88,9 @@ var DefaultConfig = Config{
 
 	NoGossip:     false,
 	MdbxWriteMap: false,
+
+	AnnounceRatio:  1,
+	BroadcastRatio: 0.3,
 }
 
 type DiscardReason uint8
@@ -123,6 +133,7 @@ const (
 	ErrAuthorityReserved DiscardReason = 34 // EIP-7702 transaction with authority already reserved
 	InvalidAA            DiscardReason = 35 // Invalid RIP-7560 transaction
 	ErrGetCode           DiscardReason = 36 // Error getting code during AA validation
+	RejectListed         DiscardReason = 37 // Calldata or init code matches operator's reject-list
 )
 
 func (r DiscardReason) String() string {
@@ -201,6 +212,8 @@ func (r DiscardReason) String() string {
 		return "RIP-7560 transaction failed validation"
 	case ErrGetCode:
 		return "error getting account code during RIP-7560 validation"
+	case RejectListed:
+		return "rejected by txpool reject-list"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}